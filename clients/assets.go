@@ -0,0 +1,90 @@
+package clients
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/googlecloudplatform/security-response-automation/clients/assets"
+	"google.golang.org/api/googleapi"
+)
+
+// cloudAssetEndpoint serves resource searches, which the generated Cloud Asset client does not
+// support yet.
+const cloudAssetEndpoint = "https://cloudasset.googleapis.com/v1"
+
+// Assets client searches Cloud Asset Inventory.
+type Assets struct {
+	client *http.Client
+}
+
+// NewAssets returns and initializes a Cloud Asset Inventory client.
+func NewAssets(ctx context.Context, authFile string) (*Assets, error) {
+	c, err := httpClient(ctx, authFile, "cloudasset")
+	if err != nil {
+		return nil, err
+	}
+	return &Assets{client: c}, nil
+}
+
+// SearchResources returns the resources of the given types within the scope, i.e. "projects/p",
+// matching the query, i.e. `displayName="web-1"`.
+func (a *Assets) SearchResources(ctx context.Context, scope, query string, assetTypes []string) ([]*assets.Asset, error) {
+	var found []*assets.Asset
+	token := ""
+	for {
+		v := url.Values{"query": {query}}
+		if len(assetTypes) > 0 {
+			v.Set("assetTypes", strings.Join(assetTypes, ","))
+		}
+		if token != "" {
+			v.Set("pageToken", token)
+		}
+		var resp struct {
+			Results       []*assets.Asset `json:"results"`
+			NextPageToken string          `json:"nextPageToken"`
+		}
+		if err := a.get(ctx, fmt.Sprintf("%s/%s:searchAllResources?%s", cloudAssetEndpoint, scope, v.Encode()), &resp); err != nil {
+			return nil, err
+		}
+		found = append(found, resp.Results...)
+		if resp.NextPageToken == "" {
+			return found, nil
+		}
+		token = resp.NextPageToken
+	}
+}
+
+// get decodes the response of the URL into v.
+func (a *Assets) get(ctx context.Context, u string, v interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := a.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := googleapi.CheckResponse(resp); err != nil {
+		return err
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
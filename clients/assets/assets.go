@@ -0,0 +1,31 @@
+// Package assets holds the resources found by Cloud Asset Inventory searches.
+package assets
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Asset is a resource found by a Cloud Asset Inventory search.
+type Asset struct {
+	// Name is the full resource name, i.e. "//compute.googleapis.com/projects/p/zones/z/instances/i".
+	Name string `json:"name"`
+	// AssetType is the type of the resource, i.e. "compute.googleapis.com/Instance".
+	AssetType   string `json:"assetType"`
+	DisplayName string `json:"displayName"`
+	Location    string `json:"location"`
+	// Project is the project containing the resource, i.e. "projects/123".
+	Project string `json:"project"`
+	// AdditionalAttributes holds the searchable attributes of the type, such as an instance's
+	// "internalIPs" and "externalIPs".
+	AdditionalAttributes map[string]interface{} `json:"additionalAttributes"`
+}
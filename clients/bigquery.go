@@ -16,9 +16,10 @@ package clients
 
 import (
 	"context"
-	"fmt"
 
 	"cloud.google.com/go/bigquery"
+	"github.com/pkg/errors"
+	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
 )
 
@@ -31,7 +32,7 @@ type BigQuery struct {
 func NewBigQuery(ctx context.Context, authFile, projectID string) (*BigQuery, error) {
 	client, err := bigquery.NewClient(ctx, projectID, option.WithCredentialsFile(authFile))
 	if err != nil {
-		return nil, fmt.Errorf("failed to init bigquery: %q", err)
+		return nil, errors.Wrap(err, "failed to init bigquery")
 	}
 	return &BigQuery{client: client}, nil
 }
@@ -48,3 +49,39 @@ func (bq *BigQuery) OverwriteDatasetMetadata(ctx context.Context, projectID, dat
 	blindWrite := ""
 	return bq.client.DatasetInProject(projectID, datasetID).Update(ctx, dm, blindWrite)
 }
+
+// TableMetadata fetches the metadata for the table.
+func (bq *BigQuery) TableMetadata(ctx context.Context, projectID, datasetID, tableID string) (*bigquery.TableMetadata, error) {
+	return bq.client.DatasetInProject(projectID, datasetID).Table(tableID).Metadata(ctx)
+}
+
+// UpdateTableMetadata modifies specific Table metadata fields, failing if the table changed since
+// its metadata with the given etag was read.
+func (bq *BigQuery) UpdateTableMetadata(ctx context.Context, projectID, datasetID, tableID string, tm bigquery.TableMetadataToUpdate, etag string) (*bigquery.TableMetadata, error) {
+	return bq.client.DatasetInProject(projectID, datasetID).Table(tableID).Update(ctx, tm, etag)
+}
+
+// Query runs the query in the client's project.
+func (bq *BigQuery) Query(ctx context.Context, query string) (*bigquery.RowIterator, error) {
+	return bq.client.Query(query).Read(ctx)
+}
+
+// QueryRows runs the query in the client's project and returns all of its rows.
+func (bq *BigQuery) QueryRows(ctx context.Context, query string) ([][]bigquery.Value, error) {
+	it, err := bq.client.Query(query).Read(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows := [][]bigquery.Value{}
+	for {
+		var row []bigquery.Value
+		err := it.Next(&row)
+		if err == iterator.Done {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+}
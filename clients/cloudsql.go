@@ -16,11 +16,9 @@ package clients
 
 import (
 	"context"
-	"fmt"
-	"log"
-	"time"
 
-	"google.golang.org/api/option"
+	"github.com/googlecloudplatform/security-response-automation/clients/operations"
+	"github.com/pkg/errors"
 	sqladmin "google.golang.org/api/sqladmin/v1beta4"
 )
 
@@ -32,9 +30,13 @@ type CloudSQL struct {
 
 // NewCloudSQL returns and initializes a Cloud SQL client.
 func NewCloudSQL(ctx context.Context, authFile string) (*CloudSQL, error) {
-	sql, err := sqladmin.NewService(ctx, option.WithCredentialsFile(authFile))
+	opts, err := clientOptions(ctx, authFile, "sqladmin")
 	if err != nil {
-		return nil, fmt.Errorf("failed to init scc: %q", err)
+		return nil, err
+	}
+	sql, err := sqladmin.NewService(ctx, opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to init scc")
 	}
 	return &CloudSQL{
 		service:    sql,
@@ -47,50 +49,52 @@ func (s *CloudSQL) UpdateUser(ctx context.Context, projectID, instance, host, na
 	return s.service.Users.Update(projectID, instance, name, user).Host(host).Context(ctx).Do()
 }
 
+// ListUsers lists the users of a given instance.
+func (s *CloudSQL) ListUsers(ctx context.Context, projectID, instance string) (*sqladmin.UsersListResponse, error) {
+	return s.service.Users.List(projectID, instance).Context(ctx).Do()
+}
+
+// DeleteUser deletes a given user.
+func (s *CloudSQL) DeleteUser(ctx context.Context, projectID, instance, host, name string) (*sqladmin.Operation, error) {
+	return s.service.Users.Delete(projectID, instance, host, name).Context(ctx).Do()
+}
+
 // PatchInstance updates partialy a Cloud SQL instance.
 func (s *CloudSQL) PatchInstance(ctx context.Context, projectID, instance string, databaseInstance *sqladmin.DatabaseInstance) (*sqladmin.Operation, error) {
-	return s.service.Instances.Patch(projectID, instance, databaseInstance).Do()
+	return s.service.Instances.Patch(projectID, instance, databaseInstance).Context(ctx).Do()
 }
 
 // InstanceDetails gets detail from a instance in a project
 func (s *CloudSQL) InstanceDetails(ctx context.Context, projectID string, instance string) (*sqladmin.DatabaseInstance, error) {
-	return s.service.Instances.Get(projectID, instance).Do()
+	return s.service.Instances.Get(projectID, instance).Context(ctx).Do()
 }
 
-// WaitSQL will wait for the global operation to complete.
-func (s *CloudSQL) WaitSQL(projectID string, op *sqladmin.Operation) []error {
-	return waitSQL(op, func() (*sqladmin.Operation, error) {
-		return s.opsService.Get(projectID, op.Name).Do()
-	})
+// Operation returns the current state of the operation.
+func (s *CloudSQL) Operation(ctx context.Context, projectID, name string) (*sqladmin.Operation, error) {
+	return s.opsService.Get(projectID, name).Context(ctx).Do()
 }
 
-func waitSQL(op *sqladmin.Operation, fn func() (*sqladmin.Operation, error)) []error {
-	if op.Error != nil {
-		return returnSQLErrorCodes(op.Error.Errors)
-	}
-	for i := 0; i < maxLoops; i++ {
-		o, err := fn()
+// WaitSQL will wait for the global operation to complete.
+func (s *CloudSQL) WaitSQL(ctx context.Context, projectID string, op *sqladmin.Operation) []error {
+	if done, err := operations.SQLDone(op); done || err != nil {
 		if err != nil {
 			return []error{err}
 		}
-		if o.Error != nil {
-			return returnSQLErrorCodes(o.Error.Errors)
-		}
-		if o.Status == "DONE" {
-			return nil
-		}
-		if i%4 == 0 {
-			log.Println("waiting")
-		}
-		time.Sleep(loopSleep)
+		return nil
 	}
-	return []error{fmt.Errorf("operation timed out: %q", op.Name)}
+	if err := operations.Wait(ctx, operations.Ref{Project: projectID, Name: op.Name}, s.PollSQL(projectID, op.Name)); err != nil {
+		return []error{err}
+	}
+	return nil
 }
 
-func returnSQLErrorCodes(errors []*sqladmin.OperationError) []error {
-	out := []error{}
-	for _, err := range errors {
-		out = append(out, fmt.Errorf("fail: %q", err.Code))
+// PollSQL returns a poll of the operation.
+func (s *CloudSQL) PollSQL(projectID, name string) operations.Poll {
+	return func(ctx context.Context) (bool, error) {
+		op, err := s.Operation(ctx, projectID, name)
+		if err != nil {
+			return false, err
+		}
+		return operations.SQLDone(op)
 	}
-	return out
 }
@@ -17,9 +17,9 @@ package clients
 
 import (
 	"context"
-	"fmt"
 
 	commandcenter "cloud.google.com/go/securitycenter/apiv1beta1"
+	"github.com/pkg/errors"
 	"google.golang.org/api/option"
 	sccpb "google.golang.org/genproto/googleapis/cloud/securitycenter/v1beta1"
 )
@@ -33,7 +33,7 @@ type SecurityCommandCenter struct {
 func NewSecurityCommandCenter(ctx context.Context, authFile string) (*SecurityCommandCenter, error) {
 	scc, err := commandcenter.NewClient(ctx, option.WithCredentialsFile(authFile))
 	if err != nil {
-		return nil, fmt.Errorf("failed to init scc: %q", err)
+		return nil, errors.Wrap(err, "failed to init scc")
 	}
 	return &SecurityCommandCenter{service: scc}, nil
 }
@@ -0,0 +1,46 @@
+package clients
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	composer "google.golang.org/api/composer/v1"
+)
+
+// Composer client.
+type Composer struct {
+	service *composer.Service
+}
+
+// NewComposer returns and initializes a Cloud Composer client.
+func NewComposer(ctx context.Context, authFile string) (*Composer, error) {
+	opts, err := clientOptions(ctx, authFile, "composer")
+	if err != nil {
+		return nil, err
+	}
+	service, err := composer.NewService(ctx, opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to init composer")
+	}
+	return &Composer{service: service}, nil
+}
+
+// GetEnvironment returns the given Cloud Composer environment.
+func (c *Composer) GetEnvironment(ctx context.Context, projectID, location, environment string) (*composer.Environment, error) {
+	return c.service.Projects.Locations.Environments.Get(fmt.Sprintf("projects/%s/locations/%s/environments/%s", projectID, location, environment)).Context(ctx).Do()
+}
@@ -15,21 +15,20 @@ package clients
 // limitations under the License.
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
-	"log"
-	"time"
+	"io"
+	"net/http"
 
+	"github.com/googlecloudplatform/security-response-automation/clients/operations"
+	"github.com/pkg/errors"
 	compute "google.golang.org/api/compute/v1"
-	"google.golang.org/api/option"
+	"google.golang.org/api/googleapi"
 )
 
-const (
-	// Maximum number of loops (where each loop is defined below) to wait.
-	maxLoops = 180
-	// How many seconds to poll each loop to check if the operation has completed.
-	loopSleep = 5 * time.Second
-)
+const computeEndpoint = "https://compute.googleapis.com/compute/v1"
 
 // Compute client.
 type Compute struct {
@@ -38,13 +37,23 @@ type Compute struct {
 	snapshots *compute.SnapshotsService
 	opsZone   *compute.ZoneOperationsService
 	opsGlobal *compute.GlobalOperationsService
+	// client calls methods missing from the generated client.
+	client *http.Client
 }
 
 // NewCompute returns and initializes a Compute client.
 func NewCompute(ctx context.Context, authFile string) (*Compute, error) {
-	cc, err := compute.NewService(ctx, option.WithCredentialsFile(authFile))
+	opts, err := clientOptions(ctx, authFile, "compute")
+	if err != nil {
+		return nil, err
+	}
+	cc, err := compute.NewService(ctx, opts...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to init cs: %q", err)
+		return nil, errors.Wrap(err, "failed to init cs")
+	}
+	c, err := httpClient(ctx, authFile, "compute")
+	if err != nil {
+		return nil, err
 	}
 	return &Compute{
 		compute:   cc,
@@ -52,6 +61,7 @@ func NewCompute(ctx context.Context, authFile string) (*Compute, error) {
 		snapshots: compute.NewSnapshotsService(cc),
 		opsZone:   compute.NewZoneOperationsService(cc),
 		opsGlobal: compute.NewGlobalOperationsService(cc),
+		client:    c,
 	}, nil
 }
 
@@ -85,11 +95,39 @@ func (c *Compute) GetInstance(ctx context.Context, project, zone, instance strin
 	return c.compute.Instances.Get(project, zone, instance).Context(ctx).Do()
 }
 
+// ListInstancesInAllZones returns the instances of the project matching the filter in every zone,
+// i.e. `name = "instance-1"`, for findings that don't include the zone of their instance.
+func (c *Compute) ListInstancesInAllZones(ctx context.Context, project, filter string) ([]*compute.Instance, error) {
+	var instances []*compute.Instance
+	err := c.compute.Instances.AggregatedList(project).Filter(filter).Pages(ctx, func(l *compute.InstanceAggregatedList) error {
+		for _, scoped := range l.Items {
+			instances = append(instances, scoped.Instances...)
+		}
+		return nil
+	})
+	return instances, err
+}
+
+// InsertInstance creates an instance in the given project and zone.
+func (c *Compute) InsertInstance(ctx context.Context, project, zone string, instance *compute.Instance) (*compute.Operation, error) {
+	return c.compute.Instances.Insert(project, zone, instance).Context(ctx).Do()
+}
+
+// GetSerialPortOutput returns the serial port output of the specified compute instance.
+func (c *Compute) GetSerialPortOutput(ctx context.Context, project, zone, instance string) (*compute.SerialPortOutput, error) {
+	return c.compute.Instances.GetSerialPortOutput(project, zone, instance).Context(ctx).Do()
+}
+
 // DeleteAccessConfig deletes an access config from an instance's network interface.
 func (c *Compute) DeleteAccessConfig(ctx context.Context, project, zone, instance, accessConfig, networkInterface string) (*compute.Operation, error) {
 	return c.compute.Instances.DeleteAccessConfig(project, zone, instance, accessConfig, networkInterface).Context(ctx).Do()
 }
 
+// AddAccessConfig adds an access config to an instance's network interface.
+func (c *Compute) AddAccessConfig(ctx context.Context, project, zone, instance, networkInterface string, accessConfig *compute.AccessConfig) (*compute.Operation, error) {
+	return c.compute.Instances.AddAccessConfig(project, zone, instance, networkInterface, accessConfig).Context(ctx).Do()
+}
+
 // FirewallRule get the details of a firewall rule
 func (c *Compute) FirewallRule(ctx context.Context, projectID string, ruleID string) (*compute.Firewall, error) {
 	return c.compute.Firewalls.Get(projectID, ruleID).Context(ctx).Do()
@@ -115,18 +153,150 @@ func (c *Compute) SetLabels(ctx context.Context, projectID, resource string, rb
 	return c.compute.Snapshots.SetLabels(projectID, resource, rb).Context(ctx).Do()
 }
 
+// ListInstanceGroups returns the instance groups in the zone.
+func (c *Compute) ListInstanceGroups(ctx context.Context, projectID, zone string) (*compute.InstanceGroupList, error) {
+	return c.compute.InstanceGroups.List(projectID, zone).Context(ctx).Do()
+}
+
+// ListInstanceGroupInstances returns the instances within an instance group.
+func (c *Compute) ListInstanceGroupInstances(ctx context.Context, projectID, zone, group string) (*compute.InstanceGroupsListInstances, error) {
+	return c.compute.InstanceGroups.ListInstances(projectID, zone, group, &compute.InstanceGroupsListInstancesRequest{}).Context(ctx).Do()
+}
+
+// ListBackendServices returns the global backend services of the project.
+func (c *Compute) ListBackendServices(ctx context.Context, projectID string) (*compute.BackendServiceList, error) {
+	return c.compute.BackendServices.List(projectID).Context(ctx).Do()
+}
+
+// ListURLMaps returns the URL maps of the project.
+func (c *Compute) ListURLMaps(ctx context.Context, projectID string) (*compute.UrlMapList, error) {
+	return c.compute.UrlMaps.List(projectID).Context(ctx).Do()
+}
+
+// UpdateURLMap replaces the URL map.
+func (c *Compute) UpdateURLMap(ctx context.Context, projectID, name string, urlMap *compute.UrlMap) (*compute.Operation, error) {
+	return c.compute.UrlMaps.Update(projectID, name, urlMap).Context(ctx).Do()
+}
+
+// ListTargetHTTPProxies returns the target HTTP proxies of the project.
+func (c *Compute) ListTargetHTTPProxies(ctx context.Context, projectID string) (*compute.TargetHttpProxyList, error) {
+	return c.compute.TargetHttpProxies.List(projectID).Context(ctx).Do()
+}
+
+// ListTargetHTTPSProxies returns the target HTTPS proxies of the project.
+func (c *Compute) ListTargetHTTPSProxies(ctx context.Context, projectID string) (*compute.TargetHttpsProxyList, error) {
+	return c.compute.TargetHttpsProxies.List(projectID).Context(ctx).Do()
+}
+
+// GetURLMap returns the URL map.
+func (c *Compute) GetURLMap(ctx context.Context, projectID, name string) (*compute.UrlMap, error) {
+	return c.compute.UrlMaps.Get(projectID, name).Context(ctx).Do()
+}
+
+// InsertURLMap creates the URL map.
+func (c *Compute) InsertURLMap(ctx context.Context, projectID string, urlMap *compute.UrlMap) (*compute.Operation, error) {
+	return c.compute.UrlMaps.Insert(projectID, urlMap).Context(ctx).Do()
+}
+
+// GetTargetHTTPProxy returns the target HTTP proxy.
+func (c *Compute) GetTargetHTTPProxy(ctx context.Context, projectID, name string) (*compute.TargetHttpProxy, error) {
+	return c.compute.TargetHttpProxies.Get(projectID, name).Context(ctx).Do()
+}
+
+// SetTargetHTTPProxyURLMap changes the URL map of the target HTTP proxy.
+func (c *Compute) SetTargetHTTPProxyURLMap(ctx context.Context, projectID, proxy, urlMap string) (*compute.Operation, error) {
+	return c.compute.TargetHttpProxies.SetUrlMap(projectID, proxy, &compute.UrlMapReference{UrlMap: urlMap}).Context(ctx).Do()
+}
+
+// GetTargetHTTPSProxy returns the target HTTPS proxy.
+func (c *Compute) GetTargetHTTPSProxy(ctx context.Context, projectID, name string) (*compute.TargetHttpsProxy, error) {
+	return c.compute.TargetHttpsProxies.Get(projectID, name).Context(ctx).Do()
+}
+
+// SetTargetHTTPSProxySSLPolicy attaches the SSL policy to the target HTTPS proxy.
+func (c *Compute) SetTargetHTTPSProxySSLPolicy(ctx context.Context, projectID, proxy, policy string) (*compute.Operation, error) {
+	return c.compute.TargetHttpsProxies.SetSslPolicy(projectID, proxy, &compute.SslPolicyReference{SslPolicy: policy}).Context(ctx).Do()
+}
+
+// GetTargetSSLProxy returns the target SSL proxy.
+func (c *Compute) GetTargetSSLProxy(ctx context.Context, projectID, name string) (*compute.TargetSslProxy, error) {
+	return c.compute.TargetSslProxies.Get(projectID, name).Context(ctx).Do()
+}
+
+// SetTargetSSLProxySSLPolicy attaches the SSL policy to the target SSL proxy.
+func (c *Compute) SetTargetSSLProxySSLPolicy(ctx context.Context, projectID, proxy, policy string) (*compute.Operation, error) {
+	return c.compute.TargetSslProxies.SetSslPolicy(projectID, proxy, &compute.SslPolicyReference{SslPolicy: policy}).Context(ctx).Do()
+}
+
+// GetSSLPolicy returns the SSL policy.
+func (c *Compute) GetSSLPolicy(ctx context.Context, projectID, name string) (*compute.SslPolicy, error) {
+	return c.compute.SslPolicies.Get(projectID, name).Context(ctx).Do()
+}
+
+// InsertSSLPolicy creates the SSL policy.
+func (c *Compute) InsertSSLPolicy(ctx context.Context, projectID string, policy *compute.SslPolicy) (*compute.Operation, error) {
+	return c.compute.SslPolicies.Insert(projectID, policy).Context(ctx).Do()
+}
+
+// ListGlobalForwardingRules returns the global forwarding rules of the project.
+func (c *Compute) ListGlobalForwardingRules(ctx context.Context, projectID string) (*compute.ForwardingRuleList, error) {
+	return c.compute.GlobalForwardingRules.List(projectID).Context(ctx).Do()
+}
+
+// DeleteGlobalForwardingRule deletes a global forwarding rule.
+func (c *Compute) DeleteGlobalForwardingRule(ctx context.Context, projectID, name string) (*compute.Operation, error) {
+	return c.compute.GlobalForwardingRules.Delete(projectID, name).Context(ctx).Do()
+}
+
 // WaitZone will wait for the zonal operation to complete.
-func (c *Compute) WaitZone(project, zone string, op *compute.Operation) []error {
-	return wait(op, func() (*compute.Operation, error) {
-		return c.opsZone.Get(project, zone, fmt.Sprintf("%d", op.Id)).Do()
-	})
+func (c *Compute) WaitZone(ctx context.Context, project, zone string, op *compute.Operation) []error {
+	return wait(ctx, op, operations.Ref{Project: project, Zone: zone, Name: op.Name}, c.PollZone(project, zone, op.Name))
 }
 
 // WaitGlobal will wait for the global operation to complete.
-func (c *Compute) WaitGlobal(project string, op *compute.Operation) []error {
-	return wait(op, func() (*compute.Operation, error) {
-		return c.opsGlobal.Get(project, fmt.Sprintf("%d", op.Id)).Do()
-	})
+func (c *Compute) WaitGlobal(ctx context.Context, project string, op *compute.Operation) []error {
+	return wait(ctx, op, operations.Ref{Project: project, Name: op.Name}, c.PollGlobal(project, op.Name))
+}
+
+// ZoneOperation returns the zonal operation.
+func (c *Compute) ZoneOperation(ctx context.Context, project, zone, name string) (*compute.Operation, error) {
+	return c.opsZone.Get(project, zone, name).Context(ctx).Do()
+}
+
+// PollZone returns a poll of the zonal operation.
+func (c *Compute) PollZone(project, zone, name string) operations.Poll {
+	return func(ctx context.Context) (bool, error) {
+		op, err := c.opsZone.Get(project, zone, name).Context(ctx).Do()
+		if err != nil {
+			return false, err
+		}
+		return operations.ComputeDone(op)
+	}
+}
+
+// PollGlobal returns a poll of the global operation.
+func (c *Compute) PollGlobal(project, name string) operations.Poll {
+	return func(ctx context.Context) (bool, error) {
+		op, err := c.opsGlobal.Get(project, name).Context(ctx).Do()
+		if err != nil {
+			return false, err
+		}
+		return operations.ComputeDone(op)
+	}
+}
+
+// wait checks the operation returned by the call before waiting on it.
+func wait(ctx context.Context, op *compute.Operation, ref operations.Ref, poll operations.Poll) []error {
+	if done, err := operations.ComputeDone(op); done || err != nil {
+		if err != nil {
+			return []error{err}
+		}
+		return nil
+	}
+	if err := operations.Wait(ctx, ref, poll); err != nil {
+		return []error{err}
+	}
+	return nil
 }
 
 // StopInstance instance command to some instance/zone
@@ -139,38 +309,65 @@ func (c *Compute) StartInstance(ctx context.Context, projectID, zone, instance s
 	return c.compute.Instances.Start(projectID, zone, instance).Context(ctx).Do()
 }
 
-// DeleteInstance deletes a given instance in given zone.
-func (c *Compute) DeleteInstance(ctx context.Context, projectID, zone, instance string) (*compute.Operation, error) {
-	return c.compute.Instances.Delete(projectID, zone, instance).Context(ctx).Do()
+// SetMetadata replaces the metadata of an instance.
+func (c *Compute) SetMetadata(ctx context.Context, project, zone, instance string, metadata *compute.Metadata) (*compute.Operation, error) {
+	return c.compute.Instances.SetMetadata(project, zone, instance, metadata).Context(ctx).Do()
+}
+
+// UpdateShieldedInstanceConfig sets the Shielded VM options of a stopped instance.
+func (c *Compute) UpdateShieldedInstanceConfig(ctx context.Context, project, zone, instance string, config *compute.ShieldedInstanceConfig) (*compute.Operation, error) {
+	return c.compute.Instances.UpdateShieldedInstanceConfig(project, zone, instance, config).Context(ctx).Do()
 }
 
-func wait(op *compute.Operation, fn func() (*compute.Operation, error)) []error {
-	if op.Error != nil {
-		return returnErrorCodes(op.Error.Errors)
+// SetCanIPForward sets whether the instance may send and receive packets for other addresses. The
+// instance is restarted if it's running. The generated client lacks instances.update so the raw
+// instance is read and written back, keeping any fields the client doesn't know about.
+func (c *Compute) SetCanIPForward(ctx context.Context, project, zone, instance string, canIPForward bool) (*compute.Operation, error) {
+	u := fmt.Sprintf("%s/projects/%s/zones/%s/instances/%s", computeEndpoint, project, zone, instance)
+	var i map[string]interface{}
+	if err := c.do(ctx, http.MethodGet, u, nil, &i); err != nil {
+		return nil, err
+	}
+	i["canIpForward"] = canIPForward
+	var op compute.Operation
+	if err := c.do(ctx, http.MethodPut, u+"?mostDisruptiveAllowedAction=RESTART", i, &op); err != nil {
+		return nil, err
 	}
-	for i := 0; i < maxLoops; i++ {
-		o, err := fn()
+	return &op, nil
+}
+
+// do sends the JSON encoded body, if any, and decodes the response into v.
+func (c *Compute) do(ctx context.Context, method, u string, body, v interface{}) error {
+	var r io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
 		if err != nil {
-			return []error{err}
-		}
-		if o.Error != nil {
-			return returnErrorCodes(o.Error.Errors)
+			return err
 		}
-		if o.Status == "DONE" {
-			return nil
-		}
-		if i%4 == 0 {
-			log.Println("waiting")
-		}
-		time.Sleep(loopSleep)
+		r = bytes.NewReader(b)
+	}
+	req, err := http.NewRequest(method, u, r)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := googleapi.CheckResponse(resp); err != nil {
+		return err
 	}
-	return []error{fmt.Errorf("operation timed out: %q", op.Name)}
+	d := json.NewDecoder(resp.Body)
+	// Numbers are kept as is since the instance is written back.
+	d.UseNumber()
+	return d.Decode(v)
 }
 
-func returnErrorCodes(errors []*compute.OperationErrorErrors) []error {
-	out := []error{}
-	for _, err := range errors {
-		out = append(out, fmt.Errorf("fail: %q", err.Code))
-	}
-	return out
+// DeleteInstance deletes a given instance in given zone.
+func (c *Compute) DeleteInstance(ctx context.Context, projectID, zone, instance string) (*compute.Operation, error) {
+	return c.compute.Instances.Delete(projectID, zone, instance).Context(ctx).Do()
 }
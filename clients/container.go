@@ -18,8 +18,9 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/googlecloudplatform/security-response-automation/clients/operations"
+	"github.com/pkg/errors"
 	container "google.golang.org/api/container/v1"
-	"google.golang.org/api/option"
 )
 
 // Container client.
@@ -29,14 +30,63 @@ type Container struct {
 
 // NewContainer returns and initializes a Container client.
 func NewContainer(ctx context.Context, authFile string) (*Container, error) {
-	cc, err := container.NewService(ctx, option.WithCredentialsFile(authFile))
+	opts, err := clientOptions(ctx, authFile, "container")
 	if err != nil {
-		return nil, fmt.Errorf("Failed to init container service: %q", err)
+		return nil, err
+	}
+	cc, err := container.NewService(ctx, opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to init container service")
 	}
 	return &Container{container: cc}, nil
 }
 
-// UpdateAddonsConfig updates the addons configuration of a given cluster.
-func (c *Container) UpdateAddonsConfig(ctx context.Context, projectID, zone, clusterID string, conf *container.SetAddonsConfigRequest) (*container.Operation, error) {
-	return c.container.Projects.Zones.Clusters.Addons(projectID, zone, clusterID, conf).Context(ctx).Do()
+// UpdateAddonsConfig updates the addons configuration of a given cluster. The location is the
+// cluster's zone, or its region for regional clusters.
+func (c *Container) UpdateAddonsConfig(ctx context.Context, projectID, location, clusterID string, conf *container.SetAddonsConfigRequest) (*container.Operation, error) {
+	return c.container.Projects.Locations.Clusters.SetAddons(clusterName(projectID, location, clusterID), conf).Context(ctx).Do()
+}
+
+// SetNetworkPolicy enables or disables network policy on the nodes of a given cluster.
+func (c *Container) SetNetworkPolicy(ctx context.Context, projectID, location, clusterID string, req *container.SetNetworkPolicyRequest) (*container.Operation, error) {
+	return c.container.Projects.Locations.Clusters.SetNetworkPolicy(clusterName(projectID, location, clusterID), req).Context(ctx).Do()
+}
+
+// UpdateCluster applies the desired settings of the update to a given cluster.
+func (c *Container) UpdateCluster(ctx context.Context, projectID, location, clusterID string, req *container.UpdateClusterRequest) (*container.Operation, error) {
+	return c.container.Projects.Locations.Clusters.Update(clusterName(projectID, location, clusterID), req).Context(ctx).Do()
+}
+
+// StartIPRotation starts rotating the IP address, and optionally the credentials, of a given
+// cluster's control plane. The rotation must be completed once clients use the new ones.
+func (c *Container) StartIPRotation(ctx context.Context, projectID, location, clusterID string, req *container.StartIPRotationRequest) (*container.Operation, error) {
+	return c.container.Projects.Locations.Clusters.StartIpRotation(clusterName(projectID, location, clusterID), req).Context(ctx).Do()
+}
+
+// WaitContainer will wait for the cluster operation to complete.
+func (c *Container) WaitContainer(ctx context.Context, projectID, location string, op *container.Operation) error {
+	if done, err := operations.ContainerDone(op); done || err != nil {
+		return err
+	}
+	return operations.Wait(ctx, operations.Ref{Project: projectID, Zone: location, Name: op.Name}, c.PollContainer(projectID, location, op.Name))
+}
+
+// PollContainer returns a poll of the cluster operation.
+func (c *Container) PollContainer(projectID, location, name string) operations.Poll {
+	return func(ctx context.Context) (bool, error) {
+		op, err := c.container.Projects.Locations.Operations.Get(fmt.Sprintf("projects/%s/locations/%s/operations/%s", projectID, location, name)).Context(ctx).Do()
+		if err != nil {
+			return false, err
+		}
+		return operations.ContainerDone(op)
+	}
+}
+
+// GetCluster returns the given cluster in its zone or region.
+func (c *Container) GetCluster(ctx context.Context, projectID, location, clusterID string) (*container.Cluster, error) {
+	return c.container.Projects.Locations.Clusters.Get(clusterName(projectID, location, clusterID)).Context(ctx).Do()
+}
+
+func clusterName(projectID, location, clusterID string) string {
+	return fmt.Sprintf("projects/%s/locations/%s/clusters/%s", projectID, location, clusterID)
 }
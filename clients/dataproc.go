@@ -0,0 +1,45 @@
+package clients
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	dataproc "google.golang.org/api/dataproc/v1"
+)
+
+// Dataproc client.
+type Dataproc struct {
+	service *dataproc.Service
+}
+
+// NewDataproc returns and initializes a Dataproc client.
+func NewDataproc(ctx context.Context, authFile string) (*Dataproc, error) {
+	opts, err := clientOptions(ctx, authFile, "dataproc")
+	if err != nil {
+		return nil, err
+	}
+	service, err := dataproc.NewService(ctx, opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to init dataproc")
+	}
+	return &Dataproc{service: service}, nil
+}
+
+// GetCluster returns the given Dataproc cluster in its region.
+func (d *Dataproc) GetCluster(ctx context.Context, projectID, region, cluster string) (*dataproc.Cluster, error) {
+	return d.service.Projects.Regions.Clusters.Get(projectID, region, cluster).Context(ctx).Do()
+}
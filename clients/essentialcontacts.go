@@ -0,0 +1,91 @@
+package clients
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+	htransport "google.golang.org/api/transport/http"
+)
+
+const essentialContactsEndpoint = "https://essentialcontacts.googleapis.com/v1"
+
+// EssentialContacts client.
+type EssentialContacts struct {
+	client *http.Client
+}
+
+// NewEssentialContacts returns and initializes an Essential Contacts client.
+func NewEssentialContacts(ctx context.Context, authFile string) (*EssentialContacts, error) {
+	c, _, err := htransport.NewClient(ctx, option.WithCredentialsFile(authFile), option.WithScopes(cloudPlatformScope))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to init essential contacts")
+	}
+	return &EssentialContacts{client: c}, nil
+}
+
+// ComputeContacts returns the email addresses of the contacts subscribed to any of the categories
+// for the resource, including contacts inherited from its parent folders and organization.
+func (e *EssentialContacts) ComputeContacts(ctx context.Context, parent string, categories []string) ([]string, error) {
+	emails := []string{}
+	pageToken := ""
+	for {
+		q := url.Values{}
+		for _, c := range categories {
+			q.Add("notificationCategories", c)
+		}
+		if pageToken != "" {
+			q.Set("pageToken", pageToken)
+		}
+		u := fmt.Sprintf("%s/%s/contacts:compute?%s", essentialContactsEndpoint, strings.TrimPrefix(parent, "/"), q.Encode())
+		req, err := http.NewRequest(http.MethodGet, u, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := e.client.Do(req.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		var page struct {
+			Contacts []struct {
+				Email string `json:"email"`
+			} `json:"contacts"`
+			NextPageToken string `json:"nextPageToken"`
+		}
+		err = googleapi.CheckResponse(resp)
+		if err == nil {
+			err = json.NewDecoder(resp.Body).Decode(&page)
+		}
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range page.Contacts {
+			emails = append(emails, c.Email)
+		}
+		if page.NextPageToken == "" {
+			return emails, nil
+		}
+		pageToken = page.NextPageToken
+	}
+}
@@ -0,0 +1,65 @@
+package clients
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2/google"
+	admin "google.golang.org/api/admin/directory/v1"
+	"google.golang.org/api/option"
+)
+
+// Groups client.
+type Groups struct {
+	service *admin.Service
+}
+
+// NewGroups returns and initializes a Google Groups client of the Admin SDK Directory API. The
+// service account must be granted domain-wide delegation and acts as the given administrator,
+// since group memberships can only be managed by a G Suite administrator.
+func NewGroups(ctx context.Context, authFile, adminEmail string) (*Groups, error) {
+	b, err := ioutil.ReadFile(authFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read credentials")
+	}
+	conf, err := google.JWTConfigFromJSON(b, admin.AdminDirectoryGroupMemberScope)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse credentials")
+	}
+	conf.Subject = adminEmail
+	service, err := admin.NewService(ctx, option.WithTokenSource(conf.TokenSource(ctx)))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to init groups")
+	}
+	return &Groups{service: service}, nil
+}
+
+// GroupMembers returns the direct members of the group.
+func (g *Groups) GroupMembers(ctx context.Context, groupKey string) ([]*admin.Member, error) {
+	members := []*admin.Member{}
+	err := g.service.Members.List(groupKey).Context(ctx).Pages(ctx, func(page *admin.Members) error {
+		members = append(members, page.Members...)
+		return nil
+	})
+	return members, err
+}
+
+// RemoveGroupMember removes the member from the group.
+func (g *Groups) RemoveGroupMember(ctx context.Context, groupKey, memberKey string) error {
+	return g.service.Members.Delete(groupKey, memberKey).Context(ctx).Do()
+}
@@ -0,0 +1,178 @@
+package clients
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+	iamcredentials "google.golang.org/api/iamcredentials/v1"
+	"google.golang.org/api/option"
+)
+
+// impersonatedLifetime is how long the access tokens generated for an impersonated service account
+// are valid for.
+const impersonatedLifetime = "3600s"
+
+var (
+	impersonationMu sync.Mutex
+	impersonate     bool
+	// impersonated holds the token source of each impersonated service account, shared by all
+	// clients.
+	impersonated = map[string]oauth2.TokenSource{}
+)
+
+type serviceAccountKey struct{}
+
+// WithServiceAccount returns a context the clients make their requests with as the given service
+// account, if impersonation is enabled, instead of the function's own.
+func WithServiceAccount(ctx context.Context, email string) context.Context {
+	return context.WithValue(ctx, serviceAccountKey{}, email)
+}
+
+// ServiceAccount returns the service account requests made with the context act as, or an empty
+// string if they're made as the function's own.
+func ServiceAccount(ctx context.Context) string {
+	email, _ := ctx.Value(serviceAccountKey{}).(string)
+	return email
+}
+
+// EnableImpersonation lets the clients act as the service account set on the context of each
+// request by generating access tokens for it. The function's own service account needs
+// roles/iam.serviceAccountTokenCreator on the impersonated ones. Clients created before it's
+// called always act as the function's own.
+func EnableImpersonation() {
+	impersonationMu.Lock()
+	defer impersonationMu.Unlock()
+	impersonate = true
+}
+
+func impersonation() bool {
+	impersonationMu.Lock()
+	defer impersonationMu.Unlock()
+	return impersonate
+}
+
+// impersonatingClient returns an HTTP client authenticating each request as the service account
+// set on its context or as the function's own.
+func impersonatingClient(ctx context.Context, authFile string) (*http.Client, error) {
+	b, err := ioutil.ReadFile(authFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read credentials")
+	}
+	creds, err := google.CredentialsFromJSON(ctx, b, cloudPlatformScope)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse credentials")
+	}
+	return &http.Client{Transport: &impersonatingTransport{
+		base: http.DefaultTransport,
+		self: creds.TokenSource,
+		tokens: func(email string) (oauth2.TokenSource, error) {
+			return impersonatedTokens(creds.TokenSource, email)
+		},
+	}}, nil
+}
+
+// impersonatedTokens returns the token source of the service account, generating its tokens as
+// the function's own.
+func impersonatedTokens(self oauth2.TokenSource, email string) (oauth2.TokenSource, error) {
+	impersonationMu.Lock()
+	defer impersonationMu.Unlock()
+	if ts, ok := impersonated[email]; ok {
+		return ts, nil
+	}
+	// The token source outlives the request it's first needed for.
+	svc, err := iamcredentials.NewService(context.Background(), option.WithTokenSource(self))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to init iam credentials client")
+	}
+	ts := oauth2.ReuseTokenSource(nil, &generatedTokens{svc: svc, email: email})
+	impersonated[email] = ts
+	return ts, nil
+}
+
+// generatedTokens generates short-lived access tokens of a service account.
+type generatedTokens struct {
+	svc   *iamcredentials.Service
+	email string
+}
+
+func (g *generatedTokens) Token() (*oauth2.Token, error) {
+	name := "projects/-/serviceAccounts/" + g.email
+	resp, err := g.svc.Projects.ServiceAccounts.GenerateAccessToken(name, &iamcredentials.GenerateAccessTokenRequest{
+		Scope:    []string{cloudPlatformScope},
+		Lifetime: impersonatedLifetime,
+	}).Do()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to generate access token of %q", g.email)
+	}
+	expiry, err := time.Parse(time.RFC3339, resp.ExpireTime)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse expiry of access token of %q", g.email)
+	}
+	return &oauth2.Token{AccessToken: resp.AccessToken, TokenType: "Bearer", Expiry: expiry}, nil
+}
+
+// impersonatingTransport authorizes each request with a token of the service account set on its
+// context, or of the function's own if none is set.
+type impersonatingTransport struct {
+	base   http.RoundTripper
+	self   oauth2.TokenSource
+	tokens func(email string) (oauth2.TokenSource, error)
+}
+
+func (t *impersonatingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ts := t.self
+	if email := ServiceAccount(req.Context()); email != "" {
+		var err error
+		if ts, err = t.tokens(email); err != nil {
+			closeBody(req)
+			return nil, err
+		}
+	}
+	tok, err := ts.Token()
+	if err != nil {
+		closeBody(req)
+		return nil, err
+	}
+	r := cloneRequest(req)
+	tok.SetAuthHeader(r)
+	return t.base.RoundTrip(r)
+}
+
+// cloneRequest returns a shallow copy of the request with its own headers, a RoundTripper must
+// not modify the request it's given.
+func cloneRequest(req *http.Request) *http.Request {
+	r := new(http.Request)
+	*r = *req
+	r.Header = make(http.Header, len(req.Header))
+	for k, v := range req.Header {
+		r.Header[k] = append([]string(nil), v...)
+	}
+	return r
+}
+
+// closeBody closes the body of a request that isn't sent, as a RoundTripper must.
+func closeBody(req *http.Request) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+}
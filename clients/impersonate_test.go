@@ -0,0 +1,69 @@
+package clients
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/oauth2"
+)
+
+func TestImpersonatingTransport(t *testing.T) {
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("Authorization")
+	}))
+	defer srv.Close()
+	c := &http.Client{Transport: &impersonatingTransport{
+		base: http.DefaultTransport,
+		self: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "self"}),
+		tokens: func(email string) (oauth2.TokenSource, error) {
+			return oauth2.StaticTokenSource(&oauth2.Token{AccessToken: email}), nil
+		},
+	}}
+	for _, tt := range []struct {
+		name           string
+		serviceAccount string
+		expected       string
+	}{
+		{name: "own service account", expected: "Bearer self"},
+		{name: "impersonated", serviceAccount: "sra@folder-123.iam.gserviceaccount.com", expected: "Bearer sra@folder-123.iam.gserviceaccount.com"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.serviceAccount != "" {
+				ctx = WithServiceAccount(ctx, tt.serviceAccount)
+			}
+			req, err := http.NewRequest("GET", srv.URL, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := c.Do(req.WithContext(ctx))
+			if err != nil {
+				t.Fatalf("%s failed: %q", tt.name, err)
+			}
+			resp.Body.Close()
+			if got != tt.expected {
+				t.Errorf("%s authorized with %q want %q", tt.name, got, tt.expected)
+			}
+			if sa := ServiceAccount(ctx); sa != tt.serviceAccount {
+				t.Errorf("%s got service account %q want %q", tt.name, sa, tt.serviceAccount)
+			}
+		})
+	}
+}
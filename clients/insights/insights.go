@@ -0,0 +1,36 @@
+// Package insights holds the insights Recommender reports about resources.
+package insights
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Insight is an observation Recommender made about resources, such as a firewall rule shadowed by
+// other rules.
+type Insight struct {
+	// Name is the insight's resource name, i.e.
+	// "projects/p/locations/global/insightTypes/google.compute.firewall.Insight/insights/123".
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// TargetResources are the full names of the resources the insight is about, i.e.
+	// "//compute.googleapis.com/projects/p/global/firewalls/allow-all".
+	TargetResources []string `json:"targetResources"`
+	// InsightSubtype is the kind of insight, i.e. "SHADOWED_RULE".
+	InsightSubtype string `json:"insightSubtype"`
+	StateInfo      struct {
+		// State is one of "ACTIVE", "ACCEPTED" or "DISMISSED".
+		State string `json:"state"`
+	} `json:"stateInfo"`
+	// Etag must be given to change the state of the insight.
+	Etag string `json:"etag"`
+}
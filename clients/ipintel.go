@@ -0,0 +1,105 @@
+package clients
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// ipIntelTimeout is the maximum time to wait for the provider to respond.
+	ipIntelTimeout = 10 * time.Second
+	// virusTotalIPURL is the VirusTotal v3 IP address report.
+	// https://developers.virustotal.com/v3.0/reference#ip-object
+	virusTotalIPURL = "https://www.virustotal.com/api/v3/ip_addresses/%s"
+)
+
+// Reputations of an IP address.
+const (
+	reputationMalicious  = "malicious"
+	reputationSuspicious = "suspicious"
+	reputationHarmless   = "harmless"
+	reputationUnknown    = "unknown"
+)
+
+// VirusTotalIPs client looks up the reputation and country of IP addresses with VirusTotal.
+type VirusTotalIPs struct {
+	client *http.Client
+	key    string
+	url    string
+}
+
+// NewVirusTotalIPs returns and initializes a VirusTotal IP client. Requests are limited by the
+// "virustotal" rate limit if one is configured.
+func NewVirusTotalIPs(apiKey string) *VirusTotalIPs {
+	c := &http.Client{Timeout: ipIntelTimeout}
+	if l := rateLimiter("virustotal"); l != nil {
+		c.Transport = &limitedTransport{base: http.DefaultTransport, limiter: l}
+	}
+	return &VirusTotalIPs{client: c, key: apiKey, url: virusTotalIPURL}
+}
+
+// LookupIP returns the reputation of the IP address, one of malicious, suspicious, harmless or
+// unknown, and the ISO 3166 code of the country it's located in if known.
+func (v *VirusTotalIPs) LookupIP(ctx context.Context, ip string) (string, string, error) {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf(v.url, ip), nil)
+	if err != nil {
+		return "", "", err
+	}
+	req.Header.Set("x-apikey", v.key)
+	resp, err := v.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return "", "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return reputationUnknown, "", nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", "", fmt.Errorf("virustotal returned status code %d", resp.StatusCode)
+	}
+	var report struct {
+		Data struct {
+			Attributes struct {
+				Country string
+				Stats   struct {
+					Malicious  int
+					Suspicious int
+					Harmless   int
+				} `json:"last_analysis_stats"`
+			}
+		}
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&report); err != nil {
+		return "", "", errors.Wrap(err, "error decoding json for ip report")
+	}
+	attrs := report.Data.Attributes
+	switch {
+	case attrs.Stats.Malicious > 0:
+		return reputationMalicious, attrs.Country, nil
+	case attrs.Stats.Suspicious > 0:
+		return reputationSuspicious, attrs.Country, nil
+	case attrs.Stats.Harmless > 0:
+		return reputationHarmless, attrs.Country, nil
+	default:
+		return reputationUnknown, attrs.Country, nil
+	}
+}
@@ -0,0 +1,69 @@
+package clients
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestVirusTotalLookupIP(t *testing.T) {
+	reports := map[string]string{
+		"/198.51.100.7": `{"data": {"attributes": {"country": "RU", "last_analysis_stats": {"malicious": 4, "harmless": 60}}}}`,
+		"/203.0.113.9":  `{"data": {"attributes": {"country": "US", "last_analysis_stats": {"harmless": 70}}}}`,
+		"/192.0.2.1":    `{"data": {"attributes": {"last_analysis_stats": {}}}}`,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("x-apikey") != "api-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		report, ok := reports[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, report)
+	}))
+	defer srv.Close()
+	for _, tt := range []struct {
+		ip, reputation, country string
+	}{
+		{ip: "198.51.100.7", reputation: "malicious", country: "RU"},
+		{ip: "203.0.113.9", reputation: "harmless", country: "US"},
+		{ip: "192.0.2.1", reputation: "unknown"},
+		{ip: "192.0.2.2", reputation: "unknown"},
+	} {
+		t.Run(tt.ip, func(t *testing.T) {
+			v := NewVirusTotalIPs("api-key")
+			v.url = srv.URL + "/%s"
+			reputation, country, err := v.LookupIP(context.Background(), tt.ip)
+			if err != nil {
+				t.Fatalf("LookupIP(%q) failed: %q", tt.ip, err)
+			}
+			if reputation != tt.reputation || country != tt.country {
+				t.Errorf("LookupIP(%q) = %q, %q, expected %q, %q", tt.ip, reputation, country, tt.reputation, tt.country)
+			}
+		})
+	}
+	v := NewVirusTotalIPs("wrong-key")
+	v.url = srv.URL + "/%s"
+	if _, _, err := v.LookupIP(context.Background(), "198.51.100.7"); err == nil {
+		t.Error("LookupIP() with a wrong key succeeded, expected an error")
+	}
+}
@@ -0,0 +1,73 @@
+package clients
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"encoding/base64"
+
+	"github.com/pkg/errors"
+	kms "google.golang.org/api/cloudkms/v1"
+)
+
+// KMS client.
+type KMS struct {
+	service *kms.Service
+}
+
+// NewKMS returns and initializes a Cloud KMS client.
+func NewKMS(ctx context.Context, authFile string) (*KMS, error) {
+	opts, err := clientOptions(ctx, authFile, "cloudkms")
+	if err != nil {
+		return nil, err
+	}
+	service, err := kms.NewService(ctx, opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to init kms")
+	}
+	return &KMS{service: service}, nil
+}
+
+// GetCryptoKeyVersion returns the key version given its resource name.
+func (k *KMS) GetCryptoKeyVersion(ctx context.Context, name string) (*kms.CryptoKeyVersion, error) {
+	return k.service.Projects.Locations.KeyRings.CryptoKeys.CryptoKeyVersions.Get(name).Context(ctx).Do()
+}
+
+// UpdateCryptoKeyVersionState sets the state of the key version, i.e. "DISABLED".
+func (k *KMS) UpdateCryptoKeyVersionState(ctx context.Context, name, state string) (*kms.CryptoKeyVersion, error) {
+	v := &kms.CryptoKeyVersion{State: state}
+	return k.service.Projects.Locations.KeyRings.CryptoKeys.CryptoKeyVersions.Patch(name, v).UpdateMask("state").Context(ctx).Do()
+}
+
+// GetCryptoKeyPolicy returns the IAM policy of the key.
+func (k *KMS) GetCryptoKeyPolicy(ctx context.Context, key string) (*kms.Policy, error) {
+	return k.service.Projects.Locations.KeyRings.CryptoKeys.GetIamPolicy(key).Context(ctx).Do()
+}
+
+// SetCryptoKeyPolicy sets the IAM policy of the key.
+func (k *KMS) SetCryptoKeyPolicy(ctx context.Context, key string, p *kms.Policy) (*kms.Policy, error) {
+	return k.service.Projects.Locations.KeyRings.CryptoKeys.SetIamPolicy(key, &kms.SetIamPolicyRequest{Policy: p}).Context(ctx).Do()
+}
+
+// AsymmetricSign signs the SHA-256 digest with the asymmetric signing key version and returns the
+// signature.
+func (k *KMS) AsymmetricSign(ctx context.Context, name string, digest []byte) ([]byte, error) {
+	req := &kms.AsymmetricSignRequest{Digest: &kms.Digest{Sha256: base64.StdEncoding.EncodeToString(digest)}}
+	resp, err := k.service.Projects.Locations.KeyRings.CryptoKeys.CryptoKeyVersions.AsymmetricSign(name, req).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(resp.Signature)
+}
@@ -0,0 +1,56 @@
+package clients
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+
+	"cloud.google.com/go/logging"
+	"cloud.google.com/go/logging/logadmin"
+	"github.com/pkg/errors"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+)
+
+// LogAdmin client.
+type LogAdmin struct {
+	client *logadmin.Client
+}
+
+// NewLogAdmin returns and initializes a LogAdmin client.
+func NewLogAdmin(ctx context.Context, authFile string) (*LogAdmin, error) {
+	c, err := logadmin.NewClient(ctx, projectID, option.WithCredentialsFile(authFile))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to init logadmin")
+	}
+	return &LogAdmin{client: c}, nil
+}
+
+// ListEntries returns up to limit log entries from the given project matching the filter, newest first.
+func (l *LogAdmin) ListEntries(ctx context.Context, projectID, filter string, limit int) ([]*logging.Entry, error) {
+	it := l.client.Entries(ctx, logadmin.ProjectIDs([]string{projectID}), logadmin.Filter(filter), logadmin.NewestFirst())
+	entries := []*logging.Entry{}
+	for len(entries) < limit {
+		entry, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
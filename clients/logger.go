@@ -21,6 +21,7 @@ import (
 	"os"
 
 	"cloud.google.com/go/logging"
+	"github.com/pkg/errors"
 	"google.golang.org/api/option"
 )
 
@@ -39,7 +40,7 @@ type Logger struct {
 func NewLogger(ctx context.Context, authFile string) (*Logger, error) {
 	c, err := logging.NewClient(ctx, projectID, option.WithCredentialsFile(authFile))
 	if err != nil {
-		return nil, fmt.Errorf("failed to init logger: %q", err)
+		return nil, errors.Wrap(err, "failed to init logger")
 	}
 	return &Logger{client: c, logger: c.Logger(loggerName)}, nil
 }
@@ -68,6 +69,12 @@ func (l *Logger) Debug(message string, a ...interface{}) {
 	l.logger.Log(logging.Entry{Payload: fmt.Sprintf(message, a...), Severity: logging.Debug})
 }
 
+// Audit sends a structured payload to the logger using notice as the severity.
+func (l *Logger) Audit(payload interface{}) {
+	log.Printf("audit: %+v", payload)
+	l.logger.Log(logging.Entry{Payload: payload, Severity: logging.Notice})
+}
+
 // Close buffer and send messages to stackdriver
 func (l *Logger) Close() {
 	l.client.Close()
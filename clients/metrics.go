@@ -0,0 +1,166 @@
+package clients
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Metrics holds counters and writes them in the Prometheus text exposition format so standard
+// monitoring stacks can scrape them.
+type Metrics struct {
+	mu       sync.Mutex
+	counters map[string]*counter
+}
+
+// counter is a family of counters sharing a name and label names, one per set of label values.
+type counter struct {
+	help   string
+	labels []string
+	// values holds the count of each set of label values, keyed by the values joined by "\x00".
+	values map[string]float64
+}
+
+// DefaultMetrics holds the counters of the process.
+var DefaultMetrics = NewMetrics()
+
+var (
+	metricsMu sync.Mutex
+	// countRequests is whether clients created afterwards count their requests in DefaultMetrics.
+	countRequests bool
+)
+
+// NewMetrics returns an empty set of counters.
+func NewMetrics() *Metrics {
+	return &Metrics{counters: map[string]*counter{}}
+}
+
+// Inc increments the counter of the given name for the label values, in the order of the
+// labels. The counter is created with the help text and label names the first time it's
+// incremented.
+func (m *Metrics) Inc(name, help string, labels []string, values ...string) {
+	if len(values) != len(labels) {
+		panic(fmt.Sprintf("counter %q has %d labels, got %d values", name, len(labels), len(values)))
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c, ok := m.counters[name]
+	if !ok {
+		c = &counter{help: help, labels: labels, values: map[string]float64{}}
+		m.counters[name] = c
+	}
+	c.values[strings.Join(values, "\x00")]++
+}
+
+// Value returns the count of the counter of the given name for the label values.
+func (m *Metrics) Value(name string, values ...string) float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c, ok := m.counters[name]
+	if !ok {
+		return 0
+	}
+	return c.values[strings.Join(values, "\x00")]
+}
+
+// Write writes the counters in the Prometheus text format, sorted by name and label values.
+func (m *Metrics) Write(w io.Writer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	names := make([]string, 0, len(m.counters))
+	for name := range m.counters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		c := m.counters[name]
+		if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s counter\n", name, c.help, name); err != nil {
+			return err
+		}
+		keys := make([]string, 0, len(c.values))
+		for k := range c.values {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			pairs := make([]string, len(c.labels))
+			for i, v := range strings.Split(k, "\x00") {
+				pairs[i] = c.labels[i] + "=" + strconv.Quote(v)
+			}
+			if _, err := fmt.Fprintf(w, "%s{%s} %v\n", name, strings.Join(pairs, ","), c.values[k]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ServeHTTP writes the counters so they can be scraped.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	m.Write(w)
+}
+
+// Names and labels of the counters of requests made by the clients.
+const (
+	RequestsMetric      = "sra_client_requests_total"
+	RequestErrorsMetric = "sra_client_request_errors_total"
+)
+
+var (
+	requestLabels      = []string{"api", "code"}
+	requestErrorLabels = []string{"api"}
+)
+
+// EnableRequestMetrics counts the requests made by the clients of each API, and those failing,
+// in DefaultMetrics. Clients created before it's called are not counted.
+func EnableRequestMetrics() {
+	metricsMu.Lock()
+	defer metricsMu.Unlock()
+	countRequests = true
+}
+
+func requestMetrics() bool {
+	metricsMu.Lock()
+	defer metricsMu.Unlock()
+	return countRequests
+}
+
+// countingTransport counts the requests made to an API by response code. Requests failing
+// without a response or with an error response are also counted as errors.
+type countingTransport struct {
+	base    http.RoundTripper
+	api     string
+	metrics *Metrics
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	code := "error"
+	if err == nil {
+		code = strconv.Itoa(resp.StatusCode)
+	}
+	t.metrics.Inc(RequestsMetric, "Requests made by the clients of each API by response code.", requestLabels, t.api, code)
+	if err != nil || resp.StatusCode >= http.StatusBadRequest {
+		t.metrics.Inc(RequestErrorsMetric, "Requests made by the clients of each API that failed.", requestErrorLabels, t.api)
+	}
+	return resp, err
+}
@@ -0,0 +1,78 @@
+package clients
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMetricsWrite(t *testing.T) {
+	m := NewMetrics()
+	m.Inc("b_total", "B.", []string{"action", "result"}, "close_bucket", "success")
+	m.Inc("b_total", "B.", []string{"action", "result"}, "close_bucket", "success")
+	m.Inc("b_total", "B.", []string{"action", "result"}, "iam_revoke", "fail\"ure")
+	m.Inc("a_total", "A.", []string{"api"}, "compute")
+	var buf bytes.Buffer
+	if err := m.Write(&buf); err != nil {
+		t.Fatalf("failed to write metrics: %q", err)
+	}
+	expected := `# HELP a_total A.
+# TYPE a_total counter
+a_total{api="compute"} 1
+# HELP b_total B.
+# TYPE b_total counter
+b_total{action="close_bucket",result="success"} 2
+b_total{action="iam_revoke",result="fail\"ure"} 1
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Errorf("metrics differ: %v", diff)
+	}
+}
+
+func TestCountingTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	m := NewMetrics()
+	c := &http.Client{Transport: &countingTransport{base: http.DefaultTransport, api: "compute", metrics: m}}
+	for _, path := range []string{"/", "/", "/missing"} {
+		resp, err := c.Get(srv.URL + path)
+		if err != nil {
+			t.Fatalf("failed to send request: %q", err)
+		}
+		resp.Body.Close()
+	}
+	for _, tt := range []struct {
+		name     string
+		values   []string
+		expected float64
+	}{
+		{name: RequestsMetric, values: []string{"compute", "200"}, expected: 2},
+		{name: RequestsMetric, values: []string{"compute", "404"}, expected: 1},
+		{name: RequestErrorsMetric, values: []string{"compute"}, expected: 1},
+	} {
+		if v := m.Value(tt.name, tt.values...); v != tt.expected {
+			t.Errorf("%s%v is %v, expected %v", tt.name, tt.values, v, tt.expected)
+		}
+	}
+}
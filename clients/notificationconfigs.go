@@ -0,0 +1,116 @@
+package clients
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/pkg/errors"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+	htransport "google.golang.org/api/transport/http"
+)
+
+// securityCenterEndpoint serves notification configs, which the generated Security Command Center
+// client does not support yet.
+const securityCenterEndpoint = "https://securitycenter.googleapis.com/v1"
+
+// NotificationConfigs client.
+type NotificationConfigs struct {
+	client *http.Client
+}
+
+// notificationConfig is a Security Command Center notification config.
+type notificationConfig struct {
+	Description     string `json:"description"`
+	PubsubTopic     string `json:"pubsubTopic"`
+	StreamingConfig struct {
+		Filter string `json:"filter"`
+	} `json:"streamingConfig"`
+}
+
+// NewNotificationConfigs returns and initializes a notification configs client.
+func NewNotificationConfigs(ctx context.Context, authFile string) (*NotificationConfigs, error) {
+	c, _, err := htransport.NewClient(ctx, option.WithCredentialsFile(authFile), option.WithScopes(cloudPlatformScope))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to init notification configs")
+	}
+	return &NotificationConfigs{client: c}, nil
+}
+
+// GetNotificationConfig returns the topic and filter of the named notification config, i.e.
+// "organizations/123/notificationConfigs/sra-sha".
+func (n *NotificationConfigs) GetNotificationConfig(ctx context.Context, name string) (string, string, error) {
+	var c notificationConfig
+	if err := n.do(ctx, http.MethodGet, fmt.Sprintf("%s/%s", securityCenterEndpoint, name), nil, &c); err != nil {
+		return "", "", err
+	}
+	return c.PubsubTopic, c.StreamingConfig.Filter, nil
+}
+
+// CreateNotificationConfig creates a notification config in the organization publishing the
+// findings matching the filter to the topic.
+func (n *NotificationConfigs) CreateNotificationConfig(ctx context.Context, parent, id, description, topic, filter string) error {
+	c := &notificationConfig{Description: description, PubsubTopic: topic}
+	c.StreamingConfig.Filter = filter
+	u := fmt.Sprintf("%s/%s/notificationConfigs?configId=%s", securityCenterEndpoint, parent, url.QueryEscape(id))
+	return n.do(ctx, http.MethodPost, u, c, nil)
+}
+
+// UpdateNotificationConfig updates the description, topic and filter of the named notification
+// config.
+func (n *NotificationConfigs) UpdateNotificationConfig(ctx context.Context, name, description, topic, filter string) error {
+	c := &notificationConfig{Description: description, PubsubTopic: topic}
+	c.StreamingConfig.Filter = filter
+	u := fmt.Sprintf("%s/%s?updateMask=description,pubsub_topic,streaming_config.filter", securityCenterEndpoint, name)
+	return n.do(ctx, http.MethodPatch, u, c, nil)
+}
+
+// do sends the JSON encoded body, if any, and decodes the response into v if it's not nil.
+func (n *NotificationConfigs) do(ctx context.Context, method, u string, body, v interface{}) error {
+	var r io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		r = bytes.NewReader(b)
+	}
+	req, err := http.NewRequest(method, u, r)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := n.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := googleapi.CheckResponse(resp); err != nil {
+		return err
+	}
+	if v == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
@@ -0,0 +1,121 @@
+// Package operations waits on the long running operations returned by Google Cloud APIs.
+package operations
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	compute "google.golang.org/api/compute/v1"
+	container "google.golang.org/api/container/v1"
+	sqladmin "google.golang.org/api/sqladmin/v1beta4"
+)
+
+var (
+	// Interval is how long to wait between polls of an operation.
+	Interval = 5 * time.Second
+	// Timeout is how long to wait on an operation if the context has no deadline.
+	Timeout = 15 * time.Minute
+	// logEvery is the number of polls between progress messages.
+	logEvery = 4
+)
+
+// Ref identifies an operation so waiting on it can be resumed by a later invocation. Zone is empty
+// for global operations.
+type Ref struct {
+	Project string `json:"project"`
+	Zone    string `json:"zone,omitempty"`
+	Name    string `json:"name"`
+}
+
+// String returns the operation's path.
+func (r Ref) String() string {
+	if r.Zone == "" {
+		return fmt.Sprintf("projects/%s/operations/%s", r.Project, r.Name)
+	}
+	return fmt.Sprintf("projects/%s/zones/%s/operations/%s", r.Project, r.Zone, r.Name)
+}
+
+// Poll gets the current state of an operation. It returns true once the operation has completed
+// and an error if it completed with errors.
+type Poll func(context.Context) (bool, error)
+
+// Wait polls the operation until it completes, the context is done or the timeout elapses. Progress
+// is logged while waiting.
+func Wait(ctx context.Context, ref Ref, poll Poll) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, Timeout)
+		defer cancel()
+	}
+	start := time.Now()
+	for i := 1; ; i++ {
+		done, err := poll(ctx)
+		if err != nil {
+			return errors.Wrapf(err, "operation %s", ref)
+		}
+		if done {
+			return nil
+		}
+		if i%logEvery == 0 {
+			log.Printf("waiting on operation %s for %s", ref, time.Since(start).Round(time.Second))
+		}
+		select {
+		case <-ctx.Done():
+			return errors.Wrapf(ctx.Err(), "operation %s did not complete", ref)
+		case <-time.After(Interval):
+		}
+	}
+}
+
+// ComputeDone returns whether the Compute Engine operation has completed and its errors if any.
+func ComputeDone(op *compute.Operation) (bool, error) {
+	if op.Error != nil && len(op.Error.Errors) > 0 {
+		codes := []string{}
+		for _, e := range op.Error.Errors {
+			codes = append(codes, e.Code)
+		}
+		return true, fmt.Errorf("failed: %s", strings.Join(codes, ", "))
+	}
+	return op.Status == "DONE", nil
+}
+
+// SQLDone returns whether the Cloud SQL Admin operation has completed and its errors if any.
+func SQLDone(op *sqladmin.Operation) (bool, error) {
+	if op.Error != nil && len(op.Error.Errors) > 0 {
+		codes := []string{}
+		for _, e := range op.Error.Errors {
+			codes = append(codes, fmt.Sprintf("%s: %s", e.Code, e.Message))
+		}
+		return true, fmt.Errorf("failed: %s", strings.Join(codes, ", "))
+	}
+	return op.Status == "DONE", nil
+}
+
+// ContainerDone returns whether the Kubernetes Engine operation has completed and its error if any.
+func ContainerDone(op *container.Operation) (bool, error) {
+	if op.Status != "DONE" {
+		return false, nil
+	}
+	if op.StatusMessage != "" {
+		return true, fmt.Errorf("failed: %s", op.StatusMessage)
+	}
+	return true, nil
+}
@@ -0,0 +1,88 @@
+package operations
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	compute "google.golang.org/api/compute/v1"
+	container "google.golang.org/api/container/v1"
+	sqladmin "google.golang.org/api/sqladmin/v1beta4"
+)
+
+func TestWait(t *testing.T) {
+	Interval = time.Millisecond
+	ref := Ref{Project: "project", Zone: "us-central1-a", Name: "operation-1"}
+	polls := 0
+	err := Wait(context.Background(), ref, func(ctx context.Context) (bool, error) {
+		polls++
+		return polls == 3, nil
+	})
+	if err != nil || polls != 3 {
+		t.Errorf("got %d polls and error %v, want 3 polls", polls, err)
+	}
+
+	failed := errors.New("quota exceeded")
+	if err := Wait(context.Background(), ref, func(ctx context.Context) (bool, error) { return true, failed }); err == nil {
+		t.Errorf("failed operations should return an error")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := Wait(ctx, ref, func(ctx context.Context) (bool, error) { return false, nil }); err == nil {
+		t.Errorf("operations outliving the context should return an error")
+	}
+}
+
+func TestDone(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		done       func() (bool, error)
+		want, fail bool
+	}{
+		{name: "compute running", done: func() (bool, error) { return ComputeDone(&compute.Operation{Status: "RUNNING"}) }},
+		{name: "compute done", want: true, done: func() (bool, error) { return ComputeDone(&compute.Operation{Status: "DONE"}) }},
+		{name: "compute failed", want: true, fail: true, done: func() (bool, error) {
+			return ComputeDone(&compute.Operation{Status: "DONE", Error: &compute.OperationError{Errors: []*compute.OperationErrorErrors{{Code: "QUOTA_EXCEEDED"}}}})
+		}},
+		{name: "sql done", want: true, done: func() (bool, error) { return SQLDone(&sqladmin.Operation{Status: "DONE"}) }},
+		{name: "sql failed", want: true, fail: true, done: func() (bool, error) {
+			return SQLDone(&sqladmin.Operation{Status: "DONE", Error: &sqladmin.OperationErrors{Errors: []*sqladmin.OperationError{{Code: "INTERNAL_ERROR"}}}})
+		}},
+		{name: "container pending", done: func() (bool, error) { return ContainerDone(&container.Operation{Status: "PENDING"}) }},
+		{name: "container failed", want: true, fail: true, done: func() (bool, error) {
+			return ContainerDone(&container.Operation{Status: "DONE", StatusMessage: "cluster is being deleted"})
+		}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			done, err := tt.done()
+			if done != tt.want || (err != nil) != tt.fail {
+				t.Errorf("got done %t error %v, want done %t error %t", done, err, tt.want, tt.fail)
+			}
+		})
+	}
+}
+
+func TestRefString(t *testing.T) {
+	if got := (Ref{Project: "p", Name: "op"}).String(); got != "projects/p/operations/op" {
+		t.Errorf("got %q for a global operation", got)
+	}
+	if got := (Ref{Project: "p", Zone: "z", Name: "op"}).String(); got != "projects/p/zones/z/operations/op" {
+		t.Errorf("got %q for a zonal operation", got)
+	}
+}
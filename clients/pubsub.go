@@ -16,9 +16,9 @@ package clients
 
 import (
 	"context"
-	"fmt"
 
 	"cloud.google.com/go/pubsub"
+	"github.com/pkg/errors"
 	"google.golang.org/api/option"
 )
 
@@ -31,7 +31,7 @@ type PubSub struct {
 func NewPubSub(ctx context.Context, authFile, projectID string) (*PubSub, error) {
 	client, err := pubsub.NewClient(ctx, projectID, option.WithCredentialsFile(authFile))
 	if err != nil {
-		return nil, fmt.Errorf("failed to init pubsub: %q", err)
+		return nil, errors.Wrap(err, "failed to init pubsub")
 	}
 	return &PubSub{client: client}, nil
 }
@@ -46,3 +46,14 @@ func (p *PubSub) Publish(ctx context.Context, topic *pubsub.Topic, message *pubs
 	defer topic.Stop()
 	return topic.Publish(ctx, message).Get(ctx)
 }
+
+// TopicExists returns whether the topic exists.
+func (p *PubSub) TopicExists(ctx context.Context, id string) (bool, error) {
+	return p.client.Topic(id).Exists(ctx)
+}
+
+// CreateTopic creates a topic.
+func (p *PubSub) CreateTopic(ctx context.Context, id string) error {
+	_, err := p.client.CreateTopic(ctx, id)
+	return err
+}
@@ -0,0 +1,185 @@
+package clients
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/api/option"
+	htransport "google.golang.org/api/transport/http"
+)
+
+// quotaBackoff is how long requests to an API are held back after it reports its quota is
+// exhausted without saying when to retry.
+const quotaBackoff = time.Second
+
+var (
+	limitersMu sync.Mutex
+	// limiters holds the rate limiter of each API, shared by all clients of that API.
+	limiters = map[string]*Limiter{}
+)
+
+// Limiter is a token bucket allowing requests at a steady rate with bursts of up to one second of
+// requests.
+type Limiter struct {
+	mu     sync.Mutex
+	qps    float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	// until holds back all requests until the given time.
+	until time.Time
+	now   func() time.Time
+}
+
+// NewLimiter returns a limiter allowing the given number of requests per second.
+func NewLimiter(qps float64) *Limiter {
+	burst := math.Max(1, math.Ceil(qps))
+	return &Limiter{qps: qps, burst: burst, tokens: burst, now: time.Now}
+}
+
+// Wait blocks until a request is allowed or the context is done.
+func (l *Limiter) Wait(ctx context.Context) error {
+	d := l.reserve()
+	if d <= 0 {
+		return nil
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// reserve takes a token and returns how long to wait before it can be used.
+func (l *Limiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	if !l.last.IsZero() {
+		l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.qps)
+	}
+	l.last = now
+	l.tokens--
+	var d time.Duration
+	if l.tokens < 0 {
+		d = time.Duration(-l.tokens / l.qps * float64(time.Second))
+	}
+	if hold := l.until.Sub(now); hold > d {
+		d = hold
+	}
+	return d
+}
+
+// Backoff holds back all requests for the given duration.
+func (l *Limiter) Backoff(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if until := l.now().Add(d); until.After(l.until) {
+		l.until = until
+	}
+}
+
+// SetRateLimit limits requests made by the clients of the given API, such as "compute", to the
+// given number of requests per second. Clients created before the limit is set are not limited.
+func SetRateLimit(api string, qps float64) error {
+	if qps <= 0 {
+		return fmt.Errorf("rate limit of %q must be positive, got %v", api, qps)
+	}
+	limitersMu.Lock()
+	defer limitersMu.Unlock()
+	limiters[api] = NewLimiter(qps)
+	return nil
+}
+
+func rateLimiter(api string) *Limiter {
+	limitersMu.Lock()
+	defer limitersMu.Unlock()
+	return limiters[api]
+}
+
+// clientOptions returns the options to create a client of the given API with, sending its
+// requests through the API's rate limiter if one is set, counting them if request metrics are
+// enabled, tracing them if tracing is enabled and acting as the service account of their context
+// if impersonation is enabled.
+func clientOptions(ctx context.Context, authFile, api string) ([]option.ClientOption, error) {
+	if rateLimiter(api) == nil && !requestMetrics() && !tracing() && !impersonation() {
+		return []option.ClientOption{option.WithCredentialsFile(authFile)}, nil
+	}
+	c, err := httpClient(ctx, authFile, api)
+	if err != nil {
+		return nil, err
+	}
+	return []option.ClientOption{option.WithHTTPClient(c)}, nil
+}
+
+// httpClient returns an authenticated HTTP client sending its requests through the API's rate
+// limiter if one is set, counting them if request metrics are enabled, tracing them if tracing is
+// enabled and acting as the service account of their context if impersonation is enabled.
+func httpClient(ctx context.Context, authFile, api string) (*http.Client, error) {
+	var c *http.Client
+	var err error
+	if impersonation() {
+		c, err = impersonatingClient(ctx, authFile)
+	} else {
+		c, _, err = htransport.NewClient(ctx, option.WithCredentialsFile(authFile), option.WithScopes(cloudPlatformScope))
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to init %s http client", api)
+	}
+	if l := rateLimiter(api); l != nil {
+		c.Transport = &limitedTransport{base: c.Transport, limiter: l}
+	}
+	if requestMetrics() {
+		c.Transport = &countingTransport{base: c.Transport, api: api, metrics: DefaultMetrics}
+	}
+	if tracing() {
+		c.Transport = &tracingTransport{base: c.Transport, api: api}
+	}
+	return c, nil
+}
+
+// limitedTransport waits for the limiter before each request and holds back further requests when
+// the API responds that its quota is exhausted.
+type limitedTransport struct {
+	base    http.RoundTripper
+	limiter *Limiter
+}
+
+func (t *limitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusTooManyRequests {
+		return resp, err
+	}
+	d := quotaBackoff
+	if s, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && s > 0 {
+		d = time.Duration(s) * time.Second
+	}
+	t.limiter.Backoff(d)
+	return resp, nil
+}
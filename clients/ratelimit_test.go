@@ -0,0 +1,66 @@
+package clients
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestLimiter(t *testing.T) {
+	now := time.Unix(0, 0)
+	l := NewLimiter(2)
+	l.now = func() time.Time { return now }
+
+	for i, expected := range []time.Duration{0, 0, 500 * time.Millisecond, time.Second} {
+		if d := l.reserve(); d != expected {
+			t.Errorf("request %d waits %v, expected %v", i, d, expected)
+		}
+	}
+	now = now.Add(2 * time.Second)
+	if d := l.reserve(); d != 0 {
+		t.Errorf("request after the burst was refilled waits %v, expected none", d)
+	}
+	l.Backoff(3 * time.Second)
+	if d := l.reserve(); d != 3*time.Second {
+		t.Errorf("request after a backoff waits %v, expected 3s", d)
+	}
+}
+
+func TestLimitedTransportBacksOffWhenQuotaIsExhausted(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+	now := time.Unix(0, 0)
+	l := NewLimiter(10)
+	l.now = func() time.Time { return now }
+	c := &http.Client{Transport: &limitedTransport{base: http.DefaultTransport, limiter: l}}
+
+	resp, err := c.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("failed to send request: %q", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("status %d, expected %d", resp.StatusCode, http.StatusTooManyRequests)
+	}
+	if d := l.reserve(); d != 30*time.Second {
+		t.Errorf("request after the quota was exhausted waits %v, expected 30s", d)
+	}
+}
@@ -0,0 +1,109 @@
+package clients
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/googlecloudplatform/security-response-automation/clients/insights"
+	"google.golang.org/api/googleapi"
+)
+
+// recommenderEndpoint serves insights, which the generated Recommender client does not support
+// yet.
+const recommenderEndpoint = "https://recommender.googleapis.com/v1"
+
+// Recommender client reads and resolves Recommender insights.
+type Recommender struct {
+	client *http.Client
+}
+
+// NewRecommender returns and initializes a Recommender client.
+func NewRecommender(ctx context.Context, authFile string) (*Recommender, error) {
+	c, err := httpClient(ctx, authFile, "recommender")
+	if err != nil {
+		return nil, err
+	}
+	return &Recommender{client: c}, nil
+}
+
+// ListInsights returns the insights of the insight type matching the filter, i.e.
+// `stateInfo.state="ACTIVE"`, given the type's resource name, i.e.
+// "projects/p/locations/global/insightTypes/google.compute.firewall.Insight".
+func (r *Recommender) ListInsights(ctx context.Context, parent, filter string) ([]*insights.Insight, error) {
+	var found []*insights.Insight
+	token := ""
+	for {
+		v := url.Values{}
+		if filter != "" {
+			v.Set("filter", filter)
+		}
+		if token != "" {
+			v.Set("pageToken", token)
+		}
+		var resp struct {
+			Insights      []*insights.Insight `json:"insights"`
+			NextPageToken string              `json:"nextPageToken"`
+		}
+		if err := r.do(ctx, http.MethodGet, fmt.Sprintf("%s/%s/insights?%s", recommenderEndpoint, parent, v.Encode()), nil, &resp); err != nil {
+			return nil, err
+		}
+		found = append(found, resp.Insights...)
+		if resp.NextPageToken == "" {
+			return found, nil
+		}
+		token = resp.NextPageToken
+	}
+}
+
+// MarkInsightAccepted marks the insight as acted on given its resource name and etag.
+func (r *Recommender) MarkInsightAccepted(ctx context.Context, name, etag string) error {
+	body := map[string]string{"etag": etag}
+	return r.do(ctx, http.MethodPost, fmt.Sprintf("%s/%s:markAccepted", recommenderEndpoint, name), body, nil)
+}
+
+// do sends the request with the body encoded as JSON and decodes the response into v, if given.
+func (r *Recommender) do(ctx context.Context, method, u string, body, v interface{}) error {
+	var b bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&b).Encode(body); err != nil {
+			return err
+		}
+	}
+	req, err := http.NewRequest(method, u, &b)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := r.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := googleapi.CheckResponse(resp); err != nil {
+		return err
+	}
+	if v == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
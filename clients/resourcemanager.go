@@ -16,26 +16,36 @@ package clients
 
 import (
 	"context"
-	"fmt"
 	"strings"
 
+	"github.com/pkg/errors"
 	crm "google.golang.org/api/cloudresourcemanager/v1"
-	"google.golang.org/api/option"
+	crmv2 "google.golang.org/api/cloudresourcemanager/v2"
 )
 
 // CloudResourceManager client.
 type CloudResourceManager struct {
 	service *crm.Service
+	// folders uses v2 of the API since folders are not available in v1.
+	folders *crmv2.Service
 }
 
 // NewCloudResourceManager returns and initalizes the Cloud Resource Manager client.
 func NewCloudResourceManager(ctx context.Context, authFile string) (*CloudResourceManager, error) {
-	s, err := crm.NewService(ctx, option.WithCredentialsFile(authFile))
+	opts, err := clientOptions(ctx, authFile, "cloudresourcemanager")
+	if err != nil {
+		return nil, err
+	}
+	s, err := crm.NewService(ctx, opts...)
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to init crm: %q", err)
+		return nil, errors.Wrap(err, "failed to init crm")
+	}
+	f, err := crmv2.NewService(ctx, opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to init crm v2")
 	}
-	return &CloudResourceManager{service: s}, nil
+	return &CloudResourceManager{service: s, folders: f}, nil
 }
 
 // GetPolicyProject returns the IAM policy for the given project resource.
@@ -54,6 +64,20 @@ func (c *CloudResourceManager) SetPolicyProjectWithMask(ctx context.Context, pro
 	return c.service.Projects.SetIamPolicy(projectID, req).Context(ctx).Do()
 }
 
+// GetProject returns the project given its ID or number.
+func (c *CloudResourceManager) GetProject(ctx context.Context, projectID string) (*crm.Project, error) {
+	return c.service.Projects.Get(projectID).Context(ctx).Do()
+}
+
+// TestPermissionsProject returns the subset of permissions the caller has on the given project.
+func (c *CloudResourceManager) TestPermissionsProject(ctx context.Context, projectID string, permissions []string) ([]string, error) {
+	resp, err := c.service.Projects.TestIamPermissions(projectID, &crm.TestIamPermissionsRequest{Permissions: permissions}).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+	return resp.Permissions, nil
+}
+
 // GetAncestry returns the ancestry for the given project.
 func (c *CloudResourceManager) GetAncestry(ctx context.Context, projectID string) (*crm.GetAncestryResponse, error) {
 	return c.service.Projects.GetAncestry(projectID, &crm.GetAncestryRequest{}).Context(ctx).Do()
@@ -74,6 +98,21 @@ func (c *CloudResourceManager) GetOrganization(ctx context.Context, name string)
 	return c.service.Organizations.Get(name).Context(ctx).Do()
 }
 
+// GetPolicyFolder returns the IAM policy for the given folder resource.
+func (c *CloudResourceManager) GetPolicyFolder(ctx context.Context, name string) (*crmv2.Policy, error) {
+	return c.folders.Folders.GetIamPolicy(name, &crmv2.GetIamPolicyRequest{}).Context(ctx).Do()
+}
+
+// SetPolicyFolder sets an IAM policy for the given folder resource.
+func (c *CloudResourceManager) SetPolicyFolder(ctx context.Context, name string, p *crmv2.Policy) (*crmv2.Policy, error) {
+	return c.folders.Folders.SetIamPolicy(name, &crmv2.SetIamPolicyRequest{Policy: p}).Context(ctx).Do()
+}
+
+// GetFolder returns the folder info by resource name.
+func (c *CloudResourceManager) GetFolder(ctx context.Context, name string) (*crmv2.Folder, error) {
+	return c.folders.Folders.Get(name).Context(ctx).Do()
+}
+
 // createMask creates a string of comma separated field names to mark which fields to change.
 // https://godoc.org/google.golang.org/api/cloudresourcemanager/v1beta1#SetIamPolicyRequest
 func createMask(values []string) string {
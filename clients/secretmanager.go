@@ -0,0 +1,85 @@
+package clients
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/pkg/errors"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+	htransport "google.golang.org/api/transport/http"
+)
+
+const (
+	secretManagerEndpoint = "https://secretmanager.googleapis.com/v1"
+	cloudPlatformScope    = "https://www.googleapis.com/auth/cloud-platform"
+)
+
+// SecretManager client.
+type SecretManager struct {
+	client *http.Client
+}
+
+// NewSecretManager returns and initializes a Secret Manager client.
+func NewSecretManager(ctx context.Context, authFile string) (*SecretManager, error) {
+	c, _, err := htransport.NewClient(ctx, option.WithCredentialsFile(authFile), option.WithScopes(cloudPlatformScope))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to init secret manager")
+	}
+	return &SecretManager{client: c}, nil
+}
+
+// CreateSecret creates a secret with automatic replication in the given project.
+func (s *SecretManager) CreateSecret(ctx context.Context, projectID, secretID string) error {
+	u := fmt.Sprintf("%s/projects/%s/secrets?secretId=%s", secretManagerEndpoint, projectID, url.QueryEscape(secretID))
+	body := map[string]interface{}{
+		"replication": map[string]interface{}{"automatic": map[string]interface{}{}},
+	}
+	return s.post(ctx, u, body)
+}
+
+// AddSecretVersion adds a new version holding the payload to an existing secret.
+func (s *SecretManager) AddSecretVersion(ctx context.Context, projectID, secretID string, payload []byte) error {
+	u := fmt.Sprintf("%s/projects/%s/secrets/%s:addVersion", secretManagerEndpoint, projectID, secretID)
+	body := map[string]interface{}{
+		"payload": map[string]string{"data": base64.StdEncoding.EncodeToString(payload)},
+	}
+	return s.post(ctx, u, body)
+}
+
+func (s *SecretManager) post(ctx context.Context, u string, body interface{}) error {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, u, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return googleapi.CheckResponse(resp)
+}
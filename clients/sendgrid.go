@@ -44,7 +44,15 @@ func NewSendGridClient(apiKey string) *SendGrid {
 
 // Send email SendGrid.
 func (s *SendGrid) Send(subject, from, body string, to []string) (*rest.Response, error) {
-	e := createEmail(subject, from, body, emailSender, to)
+	return s.send(createEmail(subject, from, body, emailSender, "text/plain", to))
+}
+
+// SendHTML sends an email with an HTML body through SendGrid.
+func (s *SendGrid) SendHTML(subject, from, body string, to []string) (*rest.Response, error) {
+	return s.send(createEmail(subject, from, body, emailSender, "text/html", to))
+}
+
+func (s *SendGrid) send(e *mail.SGMailV3) (*rest.Response, error) {
 	r, err := s.Service.Send(e)
 
 	if err != nil {
@@ -58,7 +66,7 @@ func (s *SendGrid) Send(subject, from, body string, to []string) (*rest.Response
 	return r, err
 }
 
-func createEmail(subject, from, body, sender string, to []string) *mail.SGMailV3 {
+func createEmail(subject, from, body, sender, contentType string, to []string) *mail.SGMailV3 {
 	email := mail.NewV3Mail()
 	email.SetFrom(mail.NewEmail(sender, from))
 	email.Subject = subject
@@ -67,7 +75,7 @@ func createEmail(subject, from, body, sender string, to []string) *mail.SGMailV3
 	for _, e := range to {
 		p.AddTos(mail.NewEmail(e, e))
 	}
-	email.AddContent(mail.NewContent("text/plain", body))
+	email.AddContent(mail.NewContent(contentType, body))
 	email.AddPersonalizations(p)
 	return email
 }
@@ -0,0 +1,83 @@
+package clients
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	iam "google.golang.org/api/iam/v1"
+)
+
+// ServiceAccounts client of the IAM API.
+type ServiceAccounts struct {
+	service *iam.Service
+}
+
+// NewServiceAccounts returns and initializes an IAM service accounts client.
+func NewServiceAccounts(ctx context.Context, authFile string) (*ServiceAccounts, error) {
+	opts, err := clientOptions(ctx, authFile, "iam")
+	if err != nil {
+		return nil, err
+	}
+	service, err := iam.NewService(ctx, opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to init iam")
+	}
+	return &ServiceAccounts{service: service}, nil
+}
+
+// GetServiceAccount returns the service account given its resource name, i.e.
+// "projects/-/serviceAccounts/sa@p.iam.gserviceaccount.com".
+func (s *ServiceAccounts) GetServiceAccount(ctx context.Context, name string) (*iam.ServiceAccount, error) {
+	return s.service.Projects.ServiceAccounts.Get(name).Context(ctx).Do()
+}
+
+// ListServiceAccounts returns the service accounts of the project.
+func (s *ServiceAccounts) ListServiceAccounts(ctx context.Context, projectID string) ([]*iam.ServiceAccount, error) {
+	var accounts []*iam.ServiceAccount
+	err := s.service.Projects.ServiceAccounts.List("projects/"+projectID).Pages(ctx, func(resp *iam.ListServiceAccountsResponse) error {
+		accounts = append(accounts, resp.Accounts...)
+		return nil
+	})
+	return accounts, err
+}
+
+// ListServiceAccountKeys returns the user-managed keys of the service account.
+func (s *ServiceAccounts) ListServiceAccountKeys(ctx context.Context, name string) ([]*iam.ServiceAccountKey, error) {
+	resp, err := s.service.Projects.ServiceAccounts.Keys.List(name).KeyTypes("USER_MANAGED").Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+	return resp.Keys, nil
+}
+
+// DeleteServiceAccountKey deletes the key given its resource name.
+func (s *ServiceAccounts) DeleteServiceAccountKey(ctx context.Context, name string) error {
+	_, err := s.service.Projects.ServiceAccounts.Keys.Delete(name).Context(ctx).Do()
+	return err
+}
+
+// DisableServiceAccount disables the service account, its keys can't be used until it's enabled.
+func (s *ServiceAccounts) DisableServiceAccount(ctx context.Context, name string) error {
+	_, err := s.service.Projects.ServiceAccounts.Disable(name, &iam.DisableServiceAccountRequest{}).Context(ctx).Do()
+	return err
+}
+
+// EnableServiceAccount enables a disabled service account.
+func (s *ServiceAccounts) EnableServiceAccount(ctx context.Context, name string) error {
+	_, err := s.service.Projects.ServiceAccounts.Enable(name, &iam.EnableServiceAccountRequest{}).Context(ctx).Do()
+	return err
+}
@@ -0,0 +1,74 @@
+package clients
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sendgrid/rest"
+)
+
+// SMTP client.
+type SMTP struct {
+	addr string
+	auth smtp.Auth
+}
+
+// NewSMTP returns an SMTP client sending through the server at host and port. Plain
+// authentication is used if a username is given.
+func NewSMTP(host string, port int, username, password string) *SMTP {
+	s := &SMTP{addr: net.JoinHostPort(host, strconv.Itoa(port))}
+	if username != "" {
+		s.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return s
+}
+
+// Send sends a plain text email. SMTP servers do not return a response body so the response is always nil.
+func (s *SMTP) Send(subject, from, body string, to []string) (*rest.Response, error) {
+	return nil, s.send(subject, from, body, "text/plain", to)
+}
+
+// SendHTML sends an email with an HTML body. The response is always nil.
+func (s *SMTP) SendHTML(subject, from, body string, to []string) (*rest.Response, error) {
+	return nil, s.send(subject, from, body, "text/html", to)
+}
+
+func (s *SMTP) send(subject, from, body, contentType string, to []string) error {
+	if err := smtp.SendMail(s.addr, s.auth, from, to, smtpMessage(subject, from, body, contentType, to)); err != nil {
+		return errors.Wrap(err, "failed to send email")
+	}
+	return nil
+}
+
+// smtpMessage returns the RFC 5322 message for the email.
+func smtpMessage(subject, from, body, contentType string, to []string) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s <%s>\r\n", mime.QEncoding.Encode("utf-8", emailSender), from)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&b, "Content-Type: %s; charset=\"utf-8\"\r\n", contentType)
+	b.WriteString("\r\n")
+	b.WriteString(body)
+	return b.Bytes()
+}
@@ -16,11 +16,12 @@ package clients
 
 import (
 	"context"
-	"fmt"
+	"io/ioutil"
 
 	"cloud.google.com/go/iam"
 	"cloud.google.com/go/storage"
-	"google.golang.org/api/option"
+	"github.com/pkg/errors"
+	"google.golang.org/api/iterator"
 )
 
 // Storage client.
@@ -30,9 +31,13 @@ type Storage struct {
 
 // NewStorage returns and initializes the Storage client.
 func NewStorage(ctx context.Context, authFile string) (*Storage, error) {
-	c, err := storage.NewClient(ctx, option.WithCredentialsFile(authFile))
+	opts, err := clientOptions(ctx, authFile, "storage")
 	if err != nil {
-		return nil, fmt.Errorf("failed to init storage: %q", err)
+		return nil, err
+	}
+	c, err := storage.NewClient(ctx, opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to init storage")
 	}
 	return &Storage{service: c}, nil
 }
@@ -47,6 +52,11 @@ func (s *Storage) BucketPolicy(ctx context.Context, bucketName string) (*iam.Pol
 	return s.service.Bucket(bucketName).IAM().Policy(ctx)
 }
 
+// BucketAttrs returns the attributes of the given bucket.
+func (s *Storage) BucketAttrs(ctx context.Context, bucketName string) (*storage.BucketAttrs, error) {
+	return s.service.Bucket(bucketName).Attrs(ctx)
+}
+
 // EnableBucketOnlyPolicy enables the bucket only policy for the given bucket.
 func (s *Storage) EnableBucketOnlyPolicy(ctx context.Context, bucketName string) error {
 	enableBucketPolicyOnly := storage.BucketAttrsToUpdate{
@@ -59,3 +69,120 @@ func (s *Storage) EnableBucketOnlyPolicy(ctx context.Context, bucketName string)
 	}
 	return nil
 }
+
+// WriteObject writes the content to the object in the given bucket.
+func (s *Storage) WriteObject(ctx context.Context, bucketName, objectName string, content []byte) error {
+	w := s.service.Bucket(bucketName).Object(objectName).NewWriter(ctx)
+	if _, err := w.Write(content); err != nil {
+		w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+// ReadObject returns the content of the object in the given bucket.
+func (s *Storage) ReadObject(ctx context.Context, bucketName, objectName string) ([]byte, error) {
+	r, err := s.service.Bucket(bucketName).Object(objectName).NewReader(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return ioutil.ReadAll(r)
+}
+
+// ListObjects returns the names of the objects in the given bucket starting with prefix.
+func (s *Storage) ListObjects(ctx context.Context, bucketName, prefix string) ([]string, error) {
+	var names []string
+	it := s.service.Bucket(bucketName).Objects(ctx, &storage.Query{Prefix: prefix})
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			return names, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, attrs.Name)
+	}
+}
+
+// ListObjectsUpTo returns the names of at most max objects in the given bucket starting with
+// prefix and whether the bucket holds more of them.
+func (s *Storage) ListObjectsUpTo(ctx context.Context, bucketName, prefix string, max int) ([]string, bool, error) {
+	var names []string
+	it := s.service.Bucket(bucketName).Objects(ctx, &storage.Query{Prefix: prefix})
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			return names, false, nil
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		if len(names) == max {
+			return names, true, nil
+		}
+		names = append(names, attrs.Name)
+	}
+}
+
+// ObjectACL returns the access control list of the object.
+func (s *Storage) ObjectACL(ctx context.Context, bucketName, objectName string) ([]storage.ACLRule, error) {
+	return s.service.Bucket(bucketName).Object(objectName).ACL().List(ctx)
+}
+
+// DeleteObjectACL removes the entity from the access control list of the object.
+func (s *Storage) DeleteObjectACL(ctx context.Context, bucketName, objectName string, entity storage.ACLEntity) error {
+	return s.service.Bucket(bucketName).Object(objectName).ACL().Delete(ctx, entity)
+}
+
+// DeleteObject deletes the object in the given bucket.
+func (s *Storage) DeleteObject(ctx context.Context, bucketName, objectName string) error {
+	return s.service.Bucket(bucketName).Object(objectName).Delete(ctx)
+}
+
+// EnableBucketLogging enables access logging for the given bucket, writing logs to the log bucket.
+func (s *Storage) EnableBucketLogging(ctx context.Context, bucketName, logBucket, logObjectPrefix string) error {
+	enableLogging := storage.BucketAttrsToUpdate{
+		Logging: &storage.BucketLogging{
+			LogBucket:       logBucket,
+			LogObjectPrefix: logObjectPrefix,
+		},
+	}
+	if _, err := s.service.Bucket(bucketName).Update(ctx, enableLogging); err != nil {
+		return err
+	}
+	return nil
+}
+
+// SetBucketLabel sets the label of the given bucket, keeping its other labels.
+func (s *Storage) SetBucketLabel(ctx context.Context, bucketName, key, value string) error {
+	var attrs storage.BucketAttrsToUpdate
+	attrs.SetLabel(key, value)
+	if _, err := s.service.Bucket(bucketName).Update(ctx, attrs); err != nil {
+		return err
+	}
+	return nil
+}
+
+// SetBucketDefaultKMSKey sets the Cloud KMS key new objects of the bucket are encrypted with.
+func (s *Storage) SetBucketDefaultKMSKey(ctx context.Context, bucketName, keyName string) error {
+	attrs := storage.BucketAttrsToUpdate{
+		Encryption: &storage.BucketEncryption{DefaultKMSKeyName: keyName},
+	}
+	if _, err := s.service.Bucket(bucketName).Update(ctx, attrs); err != nil {
+		return err
+	}
+	return nil
+}
+
+// EnableBucketVersioning enables object versioning for the given bucket.
+func (s *Storage) EnableBucketVersioning(ctx context.Context, bucketName string) error {
+	enableVersioning := storage.BucketAttrsToUpdate{
+		VersioningEnabled: true,
+	}
+	if _, err := s.service.Bucket(bucketName).Update(ctx, enableVersioning); err != nil {
+		return err
+	}
+	return nil
+}
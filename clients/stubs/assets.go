@@ -0,0 +1,48 @@
+package stubs
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+
+	"github.com/googlecloudplatform/security-response-automation/clients/assets"
+)
+
+// AssetsStub provides a stub for the Cloud Asset Inventory client.
+type AssetsStub struct {
+	Calls
+	// StubbedAssets are returned by searches for their type regardless of the query.
+	StubbedAssets []*assets.Asset
+	// SavedQueries holds the query of each search.
+	SavedQueries []string
+}
+
+// SearchResources returns the stubbed assets of the given types.
+func (a *AssetsStub) SearchResources(ctx context.Context, scope, query string, assetTypes []string) ([]*assets.Asset, error) {
+	a.SavedQueries = append(a.SavedQueries, query)
+	if r, ok := a.read("SearchResources"); ok {
+		resp, _ := r.Response.([]*assets.Asset)
+		return resp, r.Err
+	}
+	var found []*assets.Asset
+	for _, asset := range a.StubbedAssets {
+		for _, t := range assetTypes {
+			if asset.AssetType == t {
+				found = append(found, asset)
+			}
+		}
+	}
+	return found, nil
+}
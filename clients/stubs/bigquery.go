@@ -22,17 +22,59 @@ import (
 
 // BigQueryStub provides a stub for the BigQuery client.
 type BigQueryStub struct {
+	Calls
 	StubbedMetadata      *bigquery.DatasetMetadata
 	SavedDatasetMetadata *bigquery.DatasetMetadataToUpdate
+	StubbedTableMetadata *bigquery.TableMetadata
+	SavedTableMetadata   *bigquery.TableMetadataToUpdate
+	StubbedRows          [][]bigquery.Value
+	SavedQueries         []string
 }
 
 // DatasetMetadata fetches the metadata for the dataset.
 func (s *BigQueryStub) DatasetMetadata(ctx context.Context, projectID, datasetID string) (*bigquery.DatasetMetadata, error) {
+	if r, ok := s.read("DatasetMetadata"); ok {
+		resp, _ := r.Response.(*bigquery.DatasetMetadata)
+		return resp, r.Err
+	}
 	return s.StubbedMetadata, nil
 }
 
 // OverwriteDatasetMetadata modifies specific Dataset metadata fields.
 func (s *BigQueryStub) OverwriteDatasetMetadata(ctx context.Context, projectID, datasetID string, dm bigquery.DatasetMetadataToUpdate) (*bigquery.DatasetMetadata, error) {
+	if r, ok := s.mutate("OverwriteDatasetMetadata", projectID, datasetID, dm); ok {
+		resp, _ := r.Response.(*bigquery.DatasetMetadata)
+		return resp, r.Err
+	}
 	s.SavedDatasetMetadata = &dm
 	return nil, nil
 }
+
+// TableMetadata fetches the metadata for the table.
+func (s *BigQueryStub) TableMetadata(ctx context.Context, projectID, datasetID, tableID string) (*bigquery.TableMetadata, error) {
+	if r, ok := s.read("TableMetadata"); ok {
+		resp, _ := r.Response.(*bigquery.TableMetadata)
+		return resp, r.Err
+	}
+	return s.StubbedTableMetadata, nil
+}
+
+// UpdateTableMetadata modifies specific Table metadata fields.
+func (s *BigQueryStub) UpdateTableMetadata(ctx context.Context, projectID, datasetID, tableID string, tm bigquery.TableMetadataToUpdate, etag string) (*bigquery.TableMetadata, error) {
+	if r, ok := s.mutate("UpdateTableMetadata", projectID, datasetID, tableID, tm, etag); ok {
+		resp, _ := r.Response.(*bigquery.TableMetadata)
+		return resp, r.Err
+	}
+	s.SavedTableMetadata = &tm
+	return nil, nil
+}
+
+// QueryRows records the query and returns the stubbed rows.
+func (s *BigQueryStub) QueryRows(ctx context.Context, query string) ([][]bigquery.Value, error) {
+	s.SavedQueries = append(s.SavedQueries, query)
+	if r, ok := s.read("QueryRows"); ok {
+		resp, _ := r.Response.([][]bigquery.Value)
+		return resp, r.Err
+	}
+	return s.StubbedRows, nil
+}
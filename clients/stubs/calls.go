@@ -0,0 +1,93 @@
+package stubs
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"net/http"
+
+	"google.golang.org/api/googleapi"
+)
+
+// Call is a request made to a stub, without its context.
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+// Result is the outcome injected for a single call to a stub's method.
+type Result struct {
+	// Response is returned instead of the stub's static response, it must have the method's
+	// response type.
+	Response interface{}
+	Err      error
+}
+
+// Calls records the mutation requests made to a stub and lets tests inject the outcome of
+// individual calls. Stubs embed it so a test can, for example, fail the second update of a policy
+// while the first one succeeds.
+type Calls struct {
+	// Requests holds every mutation request made to the stub, in order.
+	Requests []Call
+	// Results holds the outcomes queued for each method, keyed by method name. Each call consumes
+	// one, the stub's static behaviour applies once none are left.
+	Results map[string][]Result
+}
+
+// Inject queues the outcomes of the next calls to the method.
+func (c *Calls) Inject(method string, results ...Result) {
+	if c.Results == nil {
+		c.Results = make(map[string][]Result)
+	}
+	c.Results[method] = append(c.Results[method], results...)
+}
+
+// FailNext makes the next calls to the method return the errors, one per call.
+func (c *Calls) FailNext(method string, errs ...error) {
+	for _, err := range errs {
+		c.Inject(method, Result{Err: err})
+	}
+}
+
+// Requested returns the mutation requests made with the method, in order.
+func (c *Calls) Requested(method string) []Call {
+	calls := []Call{}
+	for _, r := range c.Requests {
+		if r.Method == method {
+			calls = append(calls, r)
+		}
+	}
+	return calls
+}
+
+// read returns the next outcome queued for the method, if any.
+func (c *Calls) read(method string) (Result, bool) {
+	queued := c.Results[method]
+	if len(queued) == 0 {
+		return Result{}, false
+	}
+	c.Results[method] = queued[1:]
+	return queued[0], true
+}
+
+// mutate records the request and returns the next outcome queued for the method, if any.
+func (c *Calls) mutate(method string, args ...interface{}) (Result, bool) {
+	c.Requests = append(c.Requests, Call{Method: method, Args: args})
+	return c.read(method)
+}
+
+// APIError returns a Google API error with the HTTP status code, i.e. http.StatusConflict.
+func APIError(code int) error {
+	return &googleapi.Error{Code: code, Message: http.StatusText(code)}
+}
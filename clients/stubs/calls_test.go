@@ -0,0 +1,71 @@
+package stubs
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	compute "google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+)
+
+func TestCalls(t *testing.T) {
+	ctx := context.Background()
+	c := &ComputeStub{StubbedInstance: &compute.Instance{Name: "static"}}
+	c.Inject("GetInstance", Result{Response: &compute.Instance{Name: "injected"}})
+	c.FailNext("SetMetadata", APIError(http.StatusPreconditionFailed), nil)
+
+	for _, want := range []string{"injected", "static"} {
+		i, err := c.GetInstance(ctx, "p", "z", "i")
+		if err != nil {
+			t.Fatalf("GetInstance failed: %q", err)
+		}
+		if i.Name != want {
+			t.Errorf("GetInstance returned %q want %q", i.Name, want)
+		}
+	}
+
+	md := &compute.Metadata{Fingerprint: "abc"}
+	_, err := c.SetMetadata(ctx, "p", "z", "i", md)
+	if e, ok := err.(*googleapi.Error); !ok || e.Code != http.StatusPreconditionFailed {
+		t.Errorf("first SetMetadata returned %v want a precondition failure", err)
+	}
+	if c.SavedMetadata != nil {
+		t.Errorf("failed SetMetadata saved %+v", c.SavedMetadata)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := c.SetMetadata(ctx, "p", "z", "i", md); err != nil {
+			t.Errorf("SetMetadata %d failed: %q", i+2, err)
+		}
+	}
+	if c.SavedMetadata != md {
+		t.Errorf("SetMetadata did not save the metadata")
+	}
+
+	want := []Call{
+		{Method: "SetMetadata", Args: []interface{}{"p", "z", "i", md}},
+		{Method: "SetMetadata", Args: []interface{}{"p", "z", "i", md}},
+		{Method: "SetMetadata", Args: []interface{}{"p", "z", "i", md}},
+	}
+	if diff := cmp.Diff(want, c.Requested("SetMetadata")); diff != "" {
+		t.Errorf("requests differ (-want +got):\n%s", diff)
+	}
+	if got := len(c.Requested("GetInstance")); got != 0 {
+		t.Errorf("recorded %d reads, want none", got)
+	}
+}
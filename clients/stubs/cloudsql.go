@@ -22,29 +22,85 @@ import (
 
 // CloudSQL provides a stub for the SQL Admin client.
 type CloudSQL struct {
+	Calls
 	SavedInstanceUpdated    *sql.DatabaseInstance
 	InstanceDetailsResponse *sql.DatabaseInstance
 	UpdatedUser             *sql.User
+	UpdatedUserHost         string
+	ListUsersResponse       *sql.UsersListResponse
+	DeletedUsers            []string
+	// OperationResponse is returned for every operation, operations are done if it's nil.
+	OperationResponse *sql.Operation
 }
 
 // WaitSQL waits globally.
-func (s *CloudSQL) WaitSQL(project string, op *sql.Operation) []error {
+func (s *CloudSQL) WaitSQL(ctx context.Context, project string, op *sql.Operation) []error {
+	if r, ok := s.read("WaitSQL"); ok && r.Err != nil {
+		return []error{r.Err}
+	}
 	return []error{}
 }
 
 // PatchInstance updates partialy a cloud sql instance.
 func (s *CloudSQL) PatchInstance(ctx context.Context, projectID, instance string, databaseInstance *sql.DatabaseInstance) (*sql.Operation, error) {
+	if r, ok := s.mutate("PatchInstance", projectID, instance, databaseInstance); ok {
+		resp, _ := r.Response.(*sql.Operation)
+		return resp, r.Err
+	}
 	s.SavedInstanceUpdated = databaseInstance
-	return &sql.Operation{}, nil
+	return &sql.Operation{Name: "patch-" + instance}, nil
+}
+
+// Operation returns the stubbed operation.
+func (s *CloudSQL) Operation(ctx context.Context, projectID, name string) (*sql.Operation, error) {
+	if r, ok := s.read("Operation"); ok {
+		resp, _ := r.Response.(*sql.Operation)
+		return resp, r.Err
+	}
+	if s.OperationResponse == nil {
+		return &sql.Operation{Name: name, Status: "DONE"}, nil
+	}
+	return s.OperationResponse, nil
 }
 
 // UpdateUser updates a given user.
 func (s *CloudSQL) UpdateUser(ctx context.Context, projectID, instance, host, name string, user *sql.User) (*sql.Operation, error) {
+	if r, ok := s.mutate("UpdateUser", projectID, instance, host, name, user); ok {
+		resp, _ := r.Response.(*sql.Operation)
+		return resp, r.Err
+	}
 	s.UpdatedUser = user
+	s.UpdatedUserHost = host
+	return &sql.Operation{}, nil
+}
+
+// ListUsers returns the stubbed users.
+func (s *CloudSQL) ListUsers(ctx context.Context, projectID, instance string) (*sql.UsersListResponse, error) {
+	if r, ok := s.read("ListUsers"); ok {
+		resp, _ := r.Response.(*sql.UsersListResponse)
+		return resp, r.Err
+	}
+	if s.ListUsersResponse == nil {
+		return &sql.UsersListResponse{}, nil
+	}
+	return s.ListUsersResponse, nil
+}
+
+// DeleteUser records the deleted user as "name@host".
+func (s *CloudSQL) DeleteUser(ctx context.Context, projectID, instance, host, name string) (*sql.Operation, error) {
+	if r, ok := s.mutate("DeleteUser", projectID, instance, host, name); ok {
+		resp, _ := r.Response.(*sql.Operation)
+		return resp, r.Err
+	}
+	s.DeletedUsers = append(s.DeletedUsers, name+"@"+host)
 	return &sql.Operation{}, nil
 }
 
 // InstanceDetails gets detail from a instance in a project.
 func (s *CloudSQL) InstanceDetails(ctx context.Context, projectID string, instance string) (*sql.DatabaseInstance, error) {
+	if r, ok := s.read("InstanceDetails"); ok {
+		resp, _ := r.Response.(*sql.DatabaseInstance)
+		return resp, r.Err
+	}
 	return s.InstanceDetailsResponse, nil
 }
@@ -16,13 +16,14 @@ package stubs
 
 import (
 	"context"
-	"fmt"
 
 	sccpb "google.golang.org/genproto/googleapis/cloud/securitycenter/v1beta1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // ErrEntityNonExistent is an error throw if the entity was not found.
-var ErrEntityNonExistent = fmt.Errorf("rpc error: code = NotFound desc = Requested entity was not found")
+var ErrEntityNonExistent = status.Error(codes.NotFound, "Requested entity was not found")
 
 // SecurityCommandCenterStub provides a stub for the Security Command center client.
 type SecurityCommandCenterStub struct {
@@ -0,0 +1,41 @@
+package stubs
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"net/http"
+
+	composer "google.golang.org/api/composer/v1"
+)
+
+// ComposerStub provides a stub for the Cloud Composer client.
+type ComposerStub struct {
+	Calls
+	// StubbedEnvironment is returned for any environment, none is found if nil.
+	StubbedEnvironment *composer.Environment
+}
+
+// GetEnvironment returns the stubbed environment.
+func (c *ComposerStub) GetEnvironment(ctx context.Context, projectID, location, environment string) (*composer.Environment, error) {
+	if r, ok := c.read("GetEnvironment"); ok {
+		resp, _ := r.Response.(*composer.Environment)
+		return resp, r.Err
+	}
+	if c.StubbedEnvironment == nil {
+		return nil, APIError(http.StatusNotFound)
+	}
+	return c.StubbedEnvironment, nil
+}
@@ -16,20 +16,29 @@ package stubs
 
 import (
 	"context"
-	"fmt"
+	"net/http"
 
 	"github.com/pkg/errors"
 	compute "google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
 )
 
 // ErrNonexistentVM is a stub error returned simulating an error in case of VM not found.
-var ErrNonexistentVM = fmt.Errorf("googleapi: Error 404: The resource 'projects/test/zones/us-central1-a/instances/nonexistent' was not found, notFound")
+var ErrNonexistentVM error = &googleapi.Error{
+	Code:    http.StatusNotFound,
+	Message: "The resource 'projects/test/zones/us-central1-a/instances/nonexistent' was not found",
+	Errors:  []googleapi.ErrorItem{{Reason: "notFound"}},
+}
 
 // ComputeStub provides a stub for the compute client.
 type ComputeStub struct {
-	SavedFirewallRule            *compute.Firewall
-	SavedCreateSnapshots         map[string]compute.Snapshot
-	DeletedAccessConfigs         []NetworkAccessConfigStub
+	Calls
+	SavedFirewallRule    *compute.Firewall
+	SavedCreateSnapshots map[string]compute.Snapshot
+	DeletedAccessConfigs []NetworkAccessConfigStub
+	AddedAccessConfigs   []*compute.AccessConfig
+	// AddAccessConfigFailures is the number of AddAccessConfig calls to fail before succeeding.
+	AddAccessConfigFailures      int
 	DeleteAccessConfigShouldFail bool
 	GetInstanceShouldFail        bool
 	StubbedListProjectSnapshots  []*compute.SnapshotList
@@ -38,14 +47,56 @@ type ComputeStub struct {
 	StubbedStopInstance          *compute.Operation
 	StubbedStartInstance         *compute.Operation
 	StubbedInstance              *compute.Instance
-	SavedDiskInsertDst           string
-	DiskInsertCalled             bool
+	// StubbedInstancesInAllZones are listed regardless of the filter.
+	StubbedInstancesInAllZones []*compute.Instance
+	StubbedSerialPortOutput    *compute.SerialPortOutput
+	SavedInstance              *compute.Instance
+	SavedDiskInsertDst         string
+	DiskInsertCalled           bool
+	StubbedInstanceGroups      *compute.InstanceGroupList
+	// StubbedGroupInstances maps an instance group name to its instances.
+	StubbedGroupInstances     map[string]*compute.InstanceGroupsListInstances
+	StubbedBackendServices    *compute.BackendServiceList
+	StubbedURLMaps            *compute.UrlMapList
+	StubbedTargetHTTPProxies  *compute.TargetHttpProxyList
+	StubbedTargetHTTPSProxies *compute.TargetHttpsProxyList
+	StubbedForwardingRules    *compute.ForwardingRuleList
+	DeletedForwardingRules    []string
+	SavedURLMaps              []*compute.UrlMap
+	SavedMetadata             *compute.Metadata
+	// StubbedURLMapsByName and StubbedSSLPolicies are returned by name, a missing one is not found.
+	StubbedURLMapsByName    map[string]*compute.UrlMap
+	StubbedSSLPolicies      map[string]*compute.SslPolicy
+	StubbedTargetHTTPProxy  *compute.TargetHttpProxy
+	StubbedTargetHTTPSProxy *compute.TargetHttpsProxy
+	StubbedTargetSSLProxy   *compute.TargetSslProxy
+	InsertedURLMaps         []*compute.UrlMap
+	InsertedSSLPolicies     []*compute.SslPolicy
+	SavedProxyURLMap        string
+	SavedProxySSLPolicy     string
+	// SavedCanIPForward is nil unless SetCanIPForward was called.
+	SavedCanIPForward *bool
+	// SavedShieldedInstanceConfig is nil unless UpdateShieldedInstanceConfig was called.
+	SavedShieldedInstanceConfig *compute.ShieldedInstanceConfig
+	// Stopped and Started are true if StopInstance and StartInstance were called.
+	Stopped, Started bool
+	// DiskInsertError is returned by DiskInsert if set.
+	DiskInsertError error
+	// StubbedZoneOperation is returned for every zonal operation, operations are done if it's nil.
+	StubbedZoneOperation *compute.Operation
 }
 
 // DiskInsert creates a new disk in the project.
 func (c *ComputeStub) DiskInsert(ctx context.Context, projectID, zone string, disk *compute.Disk) (*compute.Operation, error) {
+	if r, ok := c.mutate("DiskInsert", projectID, zone, disk); ok {
+		resp, _ := r.Response.(*compute.Operation)
+		return resp, r.Err
+	}
 	c.SavedDiskInsertDst = projectID
 	c.DiskInsertCalled = true
+	if c.DiskInsertError != nil {
+		return nil, c.DiskInsertError
+	}
 	return nil, nil
 }
 
@@ -57,36 +108,105 @@ type NetworkAccessConfigStub struct {
 
 // InsertFirewallRule inserts a new firewall rule.
 func (c *ComputeStub) InsertFirewallRule(ctx context.Context, projectID string, fw *compute.Firewall) (*compute.Operation, error) {
+	if r, ok := c.mutate("InsertFirewallRule", projectID, fw); ok {
+		resp, _ := r.Response.(*compute.Operation)
+		return resp, r.Err
+	}
 	c.SavedFirewallRule = fw
 	return nil, nil
 }
 
 // PatchFirewallRule updates the firewall rule for the given project.
 func (c *ComputeStub) PatchFirewallRule(ctx context.Context, projectID string, rule string, rb *compute.Firewall) (*compute.Operation, error) {
+	if r, ok := c.mutate("PatchFirewallRule", projectID, rule, rb); ok {
+		resp, _ := r.Response.(*compute.Operation)
+		return resp, r.Err
+	}
 	c.SavedFirewallRule = rb
 	return nil, nil
 }
 
 // DeleteFirewallRule deletes the firewall rule for the given project.
 func (c *ComputeStub) DeleteFirewallRule(ctx context.Context, projectID string, rule string) (*compute.Operation, error) {
+	if r, ok := c.mutate("DeleteFirewallRule", projectID, rule); ok {
+		resp, _ := r.Response.(*compute.Operation)
+		return resp, r.Err
+	}
 	return nil, nil
 }
 
 // FirewallRule get the details of a firewall rule
 func (c *ComputeStub) FirewallRule(ctx context.Context, projectID string, ruleID string) (*compute.Firewall, error) {
+	if r, ok := c.read("FirewallRule"); ok {
+		resp, _ := r.Response.(*compute.Firewall)
+		return resp, r.Err
+	}
 	return c.StubbedFirewall, nil
 }
 
 // GetInstance returns the specified compute instance resource.
 func (c *ComputeStub) GetInstance(ctx context.Context, project, zone, instance string) (*compute.Instance, error) {
+	if r, ok := c.read("GetInstance"); ok {
+		resp, _ := r.Response.(*compute.Instance)
+		return resp, r.Err
+	}
 	if c.GetInstanceShouldFail {
 		return nil, errors.New("api call failed")
 	}
 	return c.StubbedInstance, nil
 }
 
+// ListInstancesInAllZones returns the stubbed instances of every zone.
+func (c *ComputeStub) ListInstancesInAllZones(ctx context.Context, project, filter string) ([]*compute.Instance, error) {
+	if r, ok := c.read("ListInstancesInAllZones"); ok {
+		resp, _ := r.Response.([]*compute.Instance)
+		return resp, r.Err
+	}
+	return c.StubbedInstancesInAllZones, nil
+}
+
+// InsertInstance records the instance to be created.
+func (c *ComputeStub) InsertInstance(ctx context.Context, project, zone string, instance *compute.Instance) (*compute.Operation, error) {
+	if r, ok := c.mutate("InsertInstance", project, zone, instance); ok {
+		resp, _ := r.Response.(*compute.Operation)
+		return resp, r.Err
+	}
+	c.SavedInstance = instance
+	return nil, nil
+}
+
+// GetSerialPortOutput returns the serial port output of the specified compute instance.
+func (c *ComputeStub) GetSerialPortOutput(ctx context.Context, project, zone, instance string) (*compute.SerialPortOutput, error) {
+	if r, ok := c.read("GetSerialPortOutput"); ok {
+		resp, _ := r.Response.(*compute.SerialPortOutput)
+		return resp, r.Err
+	}
+	if c.StubbedSerialPortOutput == nil {
+		return nil, errors.New("serial port output not available")
+	}
+	return c.StubbedSerialPortOutput, nil
+}
+
+// AddAccessConfig records the added access config.
+func (c *ComputeStub) AddAccessConfig(ctx context.Context, project, zone, instance, networkInterface string, accessConfig *compute.AccessConfig) (*compute.Operation, error) {
+	if r, ok := c.mutate("AddAccessConfig", project, zone, instance, networkInterface, accessConfig); ok {
+		resp, _ := r.Response.(*compute.Operation)
+		return resp, r.Err
+	}
+	if c.AddAccessConfigFailures > 0 {
+		c.AddAccessConfigFailures--
+		return nil, errors.New("api call failed")
+	}
+	c.AddedAccessConfigs = append(c.AddedAccessConfigs, accessConfig)
+	return nil, nil
+}
+
 // DeleteAccessConfig deletes an access config from an instance's network interface.
 func (c *ComputeStub) DeleteAccessConfig(ctx context.Context, project, zone, instance, accessConfig, networkInterface string) (*compute.Operation, error) {
+	if r, ok := c.mutate("DeleteAccessConfig", project, zone, instance, accessConfig, networkInterface); ok {
+		resp, _ := r.Response.(*compute.Operation)
+		return resp, r.Err
+	}
 	if c.DeleteAccessConfigShouldFail {
 		return nil, errors.New("api call failed")
 	}
@@ -98,18 +218,30 @@ func (c *ComputeStub) DeleteAccessConfig(ctx context.Context, project, zone, ins
 }
 
 // CreateSnapshot creates a snapshot of a specified persistent disk.
-func (c *ComputeStub) CreateSnapshot(ctx context.Context, _, _, disk string, snapshot *compute.Snapshot) (*compute.Operation, error) {
+func (c *ComputeStub) CreateSnapshot(ctx context.Context, projectID, zone, disk string, snapshot *compute.Snapshot) (*compute.Operation, error) {
+	if r, ok := c.mutate("CreateSnapshot", projectID, zone, disk, snapshot); ok {
+		resp, _ := r.Response.(*compute.Operation)
+		return resp, r.Err
+	}
 	c.SavedCreateSnapshots[disk] = *snapshot
-	return nil, nil
+	return &compute.Operation{Name: "operation-" + snapshot.Name}, nil
 }
 
 // DeleteDiskSnapshot deletes a snapshot.
-func (c *ComputeStub) DeleteDiskSnapshot(_ context.Context, _, _ string) (*compute.Operation, error) {
+func (c *ComputeStub) DeleteDiskSnapshot(ctx context.Context, projectID, snapshot string) (*compute.Operation, error) {
+	if r, ok := c.mutate("DeleteDiskSnapshot", projectID, snapshot); ok {
+		resp, _ := r.Response.(*compute.Operation)
+		return resp, r.Err
+	}
 	return nil, nil
 }
 
 // ListProjectSnapshots returns a list of snapshot resources.
 func (c *ComputeStub) ListProjectSnapshots(context.Context, string) (*compute.SnapshotList, error) {
+	if r, ok := c.read("ListProjectSnapshots"); ok {
+		resp, _ := r.Response.(*compute.SnapshotList)
+		return resp, r.Err
+	}
 	if len(c.StubbedListProjectSnapshots) == 0 {
 		return nil, nil
 	}
@@ -123,35 +255,312 @@ func (c *ComputeStub) ListProjectSnapshots(context.Context, string) (*compute.Sn
 
 // ListDisks returns a list of disks.
 func (c *ComputeStub) ListDisks(ctx context.Context, _, _ string) (*compute.DiskList, error) {
+	if r, ok := c.read("ListDisks"); ok {
+		resp, _ := r.Response.(*compute.DiskList)
+		return resp, r.Err
+	}
 	return c.StubbedListDisks, nil
 }
 
 // SetLabels sets the labels on a snapshot.
-func (c *ComputeStub) SetLabels(context.Context, string, string, *compute.GlobalSetLabelsRequest) (*compute.Operation, error) {
+func (c *ComputeStub) SetLabels(ctx context.Context, projectID, resource string, req *compute.GlobalSetLabelsRequest) (*compute.Operation, error) {
+	if r, ok := c.mutate("SetLabels", projectID, resource, req); ok {
+		resp, _ := r.Response.(*compute.Operation)
+		return resp, r.Err
+	}
 	return nil, nil
 }
 
 // WaitGlobal waits globally.
-func (c *ComputeStub) WaitGlobal(_ string, _ *compute.Operation) []error {
+func (c *ComputeStub) WaitGlobal(_ context.Context, _ string, _ *compute.Operation) []error {
+	if r, ok := c.read("WaitGlobal"); ok && r.Err != nil {
+		return []error{r.Err}
+	}
 	return []error{}
 }
 
 // WaitZone zone waits at the zone level.
-func (c *ComputeStub) WaitZone(_, _ string, _ *compute.Operation) []error {
+func (c *ComputeStub) WaitZone(_ context.Context, _, _ string, _ *compute.Operation) []error {
+	if r, ok := c.read("WaitZone"); ok && r.Err != nil {
+		return []error{r.Err}
+	}
 	return []error{}
 }
 
+// ZoneOperation returns the zonal operation, operations are done unless StubbedZoneOperation is set.
+func (c *ComputeStub) ZoneOperation(_ context.Context, _, _, name string) (*compute.Operation, error) {
+	if r, ok := c.read("ZoneOperation"); ok {
+		resp, _ := r.Response.(*compute.Operation)
+		return resp, r.Err
+	}
+	if c.StubbedZoneOperation == nil {
+		return &compute.Operation{Name: name, Status: "DONE"}, nil
+	}
+	return c.StubbedZoneOperation, nil
+}
+
+// SetMetadata records the metadata set on the instance.
+func (c *ComputeStub) SetMetadata(ctx context.Context, project, zone, instance string, metadata *compute.Metadata) (*compute.Operation, error) {
+	if r, ok := c.mutate("SetMetadata", project, zone, instance, metadata); ok {
+		resp, _ := r.Response.(*compute.Operation)
+		return resp, r.Err
+	}
+	c.SavedMetadata = metadata
+	return &compute.Operation{}, nil
+}
+
+// SetCanIPForward records whether IP forwarding was enabled or disabled.
+func (c *ComputeStub) SetCanIPForward(ctx context.Context, project, zone, instance string, canIPForward bool) (*compute.Operation, error) {
+	if r, ok := c.mutate("SetCanIPForward", project, zone, instance, canIPForward); ok {
+		resp, _ := r.Response.(*compute.Operation)
+		return resp, r.Err
+	}
+	c.SavedCanIPForward = &canIPForward
+	return &compute.Operation{}, nil
+}
+
+// UpdateShieldedInstanceConfig records the Shielded VM options set on the instance.
+func (c *ComputeStub) UpdateShieldedInstanceConfig(ctx context.Context, project, zone, instance string, config *compute.ShieldedInstanceConfig) (*compute.Operation, error) {
+	if r, ok := c.mutate("UpdateShieldedInstanceConfig", project, zone, instance, config); ok {
+		resp, _ := r.Response.(*compute.Operation)
+		return resp, r.Err
+	}
+	c.SavedShieldedInstanceConfig = config
+	return &compute.Operation{}, nil
+}
+
 // StopInstance stops an instance.
 func (c *ComputeStub) StopInstance(ctx context.Context, projectID, zone, instance string) (*compute.Operation, error) {
+	if r, ok := c.mutate("StopInstance", projectID, zone, instance); ok {
+		resp, _ := r.Response.(*compute.Operation)
+		return resp, r.Err
+	}
+	c.Stopped = true
 	return c.StubbedStopInstance, nil
 }
 
 // StartInstance starts a given instance in given zone.
 func (c *ComputeStub) StartInstance(ctx context.Context, projectID, zone, instance string) (*compute.Operation, error) {
+	if r, ok := c.mutate("StartInstance", projectID, zone, instance); ok {
+		resp, _ := r.Response.(*compute.Operation)
+		return resp, r.Err
+	}
+	c.Started = true
 	return c.StubbedStartInstance, nil
 }
 
 // DeleteInstance starts a given instance in given zone.
 func (c *ComputeStub) DeleteInstance(ctx context.Context, projectID, zone, instance string) (*compute.Operation, error) {
+	if r, ok := c.mutate("DeleteInstance", projectID, zone, instance); ok {
+		resp, _ := r.Response.(*compute.Operation)
+		return resp, r.Err
+	}
+	return nil, nil
+}
+
+// ListInstanceGroups returns the stubbed instance groups.
+func (c *ComputeStub) ListInstanceGroups(ctx context.Context, projectID, zone string) (*compute.InstanceGroupList, error) {
+	if r, ok := c.read("ListInstanceGroups"); ok {
+		resp, _ := r.Response.(*compute.InstanceGroupList)
+		return resp, r.Err
+	}
+	if c.StubbedInstanceGroups == nil {
+		return &compute.InstanceGroupList{}, nil
+	}
+	return c.StubbedInstanceGroups, nil
+}
+
+// ListInstanceGroupInstances returns the stubbed instances of the group.
+func (c *ComputeStub) ListInstanceGroupInstances(ctx context.Context, projectID, zone, group string) (*compute.InstanceGroupsListInstances, error) {
+	if r, ok := c.read("ListInstanceGroupInstances"); ok {
+		resp, _ := r.Response.(*compute.InstanceGroupsListInstances)
+		return resp, r.Err
+	}
+	if l, ok := c.StubbedGroupInstances[group]; ok {
+		return l, nil
+	}
+	return &compute.InstanceGroupsListInstances{}, nil
+}
+
+// ListBackendServices returns the stubbed backend services.
+func (c *ComputeStub) ListBackendServices(ctx context.Context, projectID string) (*compute.BackendServiceList, error) {
+	if r, ok := c.read("ListBackendServices"); ok {
+		resp, _ := r.Response.(*compute.BackendServiceList)
+		return resp, r.Err
+	}
+	if c.StubbedBackendServices == nil {
+		return &compute.BackendServiceList{}, nil
+	}
+	return c.StubbedBackendServices, nil
+}
+
+// ListURLMaps returns the stubbed URL maps.
+func (c *ComputeStub) ListURLMaps(ctx context.Context, projectID string) (*compute.UrlMapList, error) {
+	if r, ok := c.read("ListURLMaps"); ok {
+		resp, _ := r.Response.(*compute.UrlMapList)
+		return resp, r.Err
+	}
+	if c.StubbedURLMaps == nil {
+		return &compute.UrlMapList{}, nil
+	}
+	return c.StubbedURLMaps, nil
+}
+
+// UpdateURLMap records the updated URL map.
+func (c *ComputeStub) UpdateURLMap(ctx context.Context, projectID, name string, urlMap *compute.UrlMap) (*compute.Operation, error) {
+	if r, ok := c.mutate("UpdateURLMap", projectID, name, urlMap); ok {
+		resp, _ := r.Response.(*compute.Operation)
+		return resp, r.Err
+	}
+	c.SavedURLMaps = append(c.SavedURLMaps, urlMap)
+	return nil, nil
+}
+
+// ListTargetHTTPProxies returns the stubbed target HTTP proxies.
+func (c *ComputeStub) ListTargetHTTPProxies(ctx context.Context, projectID string) (*compute.TargetHttpProxyList, error) {
+	if r, ok := c.read("ListTargetHTTPProxies"); ok {
+		resp, _ := r.Response.(*compute.TargetHttpProxyList)
+		return resp, r.Err
+	}
+	if c.StubbedTargetHTTPProxies == nil {
+		return &compute.TargetHttpProxyList{}, nil
+	}
+	return c.StubbedTargetHTTPProxies, nil
+}
+
+// GetURLMap returns the stubbed URL map.
+func (c *ComputeStub) GetURLMap(ctx context.Context, projectID, name string) (*compute.UrlMap, error) {
+	if r, ok := c.read("GetURLMap"); ok {
+		resp, _ := r.Response.(*compute.UrlMap)
+		return resp, r.Err
+	}
+	m, ok := c.StubbedURLMapsByName[name]
+	if !ok {
+		return nil, &googleapi.Error{Code: http.StatusNotFound}
+	}
+	return m, nil
+}
+
+// InsertURLMap records the created URL map.
+func (c *ComputeStub) InsertURLMap(ctx context.Context, projectID string, urlMap *compute.UrlMap) (*compute.Operation, error) {
+	if r, ok := c.mutate("InsertURLMap", projectID, urlMap); ok {
+		resp, _ := r.Response.(*compute.Operation)
+		return resp, r.Err
+	}
+	c.InsertedURLMaps = append(c.InsertedURLMaps, urlMap)
+	return &compute.Operation{}, nil
+}
+
+// GetTargetHTTPProxy returns the stubbed target HTTP proxy.
+func (c *ComputeStub) GetTargetHTTPProxy(ctx context.Context, projectID, name string) (*compute.TargetHttpProxy, error) {
+	if r, ok := c.read("GetTargetHTTPProxy"); ok {
+		resp, _ := r.Response.(*compute.TargetHttpProxy)
+		return resp, r.Err
+	}
+	return c.StubbedTargetHTTPProxy, nil
+}
+
+// SetTargetHTTPProxyURLMap records the URL map set on the target HTTP proxy.
+func (c *ComputeStub) SetTargetHTTPProxyURLMap(ctx context.Context, projectID, proxy, urlMap string) (*compute.Operation, error) {
+	if r, ok := c.mutate("SetTargetHTTPProxyURLMap", projectID, proxy, urlMap); ok {
+		resp, _ := r.Response.(*compute.Operation)
+		return resp, r.Err
+	}
+	c.SavedProxyURLMap = urlMap
+	return &compute.Operation{}, nil
+}
+
+// GetTargetHTTPSProxy returns the stubbed target HTTPS proxy.
+func (c *ComputeStub) GetTargetHTTPSProxy(ctx context.Context, projectID, name string) (*compute.TargetHttpsProxy, error) {
+	if r, ok := c.read("GetTargetHTTPSProxy"); ok {
+		resp, _ := r.Response.(*compute.TargetHttpsProxy)
+		return resp, r.Err
+	}
+	return c.StubbedTargetHTTPSProxy, nil
+}
+
+// SetTargetHTTPSProxySSLPolicy records the SSL policy attached to the target HTTPS proxy.
+func (c *ComputeStub) SetTargetHTTPSProxySSLPolicy(ctx context.Context, projectID, proxy, policy string) (*compute.Operation, error) {
+	if r, ok := c.mutate("SetTargetHTTPSProxySSLPolicy", projectID, proxy, policy); ok {
+		resp, _ := r.Response.(*compute.Operation)
+		return resp, r.Err
+	}
+	c.SavedProxySSLPolicy = policy
+	return &compute.Operation{}, nil
+}
+
+// GetTargetSSLProxy returns the stubbed target SSL proxy.
+func (c *ComputeStub) GetTargetSSLProxy(ctx context.Context, projectID, name string) (*compute.TargetSslProxy, error) {
+	if r, ok := c.read("GetTargetSSLProxy"); ok {
+		resp, _ := r.Response.(*compute.TargetSslProxy)
+		return resp, r.Err
+	}
+	return c.StubbedTargetSSLProxy, nil
+}
+
+// SetTargetSSLProxySSLPolicy records the SSL policy attached to the target SSL proxy.
+func (c *ComputeStub) SetTargetSSLProxySSLPolicy(ctx context.Context, projectID, proxy, policy string) (*compute.Operation, error) {
+	if r, ok := c.mutate("SetTargetSSLProxySSLPolicy", projectID, proxy, policy); ok {
+		resp, _ := r.Response.(*compute.Operation)
+		return resp, r.Err
+	}
+	c.SavedProxySSLPolicy = policy
+	return &compute.Operation{}, nil
+}
+
+// GetSSLPolicy returns the stubbed SSL policy.
+func (c *ComputeStub) GetSSLPolicy(ctx context.Context, projectID, name string) (*compute.SslPolicy, error) {
+	if r, ok := c.read("GetSSLPolicy"); ok {
+		resp, _ := r.Response.(*compute.SslPolicy)
+		return resp, r.Err
+	}
+	p, ok := c.StubbedSSLPolicies[name]
+	if !ok {
+		return nil, &googleapi.Error{Code: http.StatusNotFound}
+	}
+	return p, nil
+}
+
+// InsertSSLPolicy records the created SSL policy.
+func (c *ComputeStub) InsertSSLPolicy(ctx context.Context, projectID string, policy *compute.SslPolicy) (*compute.Operation, error) {
+	if r, ok := c.mutate("InsertSSLPolicy", projectID, policy); ok {
+		resp, _ := r.Response.(*compute.Operation)
+		return resp, r.Err
+	}
+	c.InsertedSSLPolicies = append(c.InsertedSSLPolicies, policy)
+	return &compute.Operation{}, nil
+}
+
+// ListTargetHTTPSProxies returns the stubbed target HTTPS proxies.
+func (c *ComputeStub) ListTargetHTTPSProxies(ctx context.Context, projectID string) (*compute.TargetHttpsProxyList, error) {
+	if r, ok := c.read("ListTargetHTTPSProxies"); ok {
+		resp, _ := r.Response.(*compute.TargetHttpsProxyList)
+		return resp, r.Err
+	}
+	if c.StubbedTargetHTTPSProxies == nil {
+		return &compute.TargetHttpsProxyList{}, nil
+	}
+	return c.StubbedTargetHTTPSProxies, nil
+}
+
+// ListGlobalForwardingRules returns the stubbed forwarding rules.
+func (c *ComputeStub) ListGlobalForwardingRules(ctx context.Context, projectID string) (*compute.ForwardingRuleList, error) {
+	if r, ok := c.read("ListGlobalForwardingRules"); ok {
+		resp, _ := r.Response.(*compute.ForwardingRuleList)
+		return resp, r.Err
+	}
+	if c.StubbedForwardingRules == nil {
+		return &compute.ForwardingRuleList{}, nil
+	}
+	return c.StubbedForwardingRules, nil
+}
+
+// DeleteGlobalForwardingRule records the deleted forwarding rule.
+func (c *ComputeStub) DeleteGlobalForwardingRule(ctx context.Context, projectID, name string) (*compute.Operation, error) {
+	if r, ok := c.mutate("DeleteGlobalForwardingRule", projectID, name); ok {
+		resp, _ := r.Response.(*compute.Operation)
+		return resp, r.Err
+	}
+	c.DeletedForwardingRules = append(c.DeletedForwardingRules, name)
 	return nil, nil
 }
@@ -22,11 +22,70 @@ import (
 
 // ContainerStub provides a stub for the Container client.
 type ContainerStub struct {
+	Calls
 	UpdatedAddonsConfig *container.SetAddonsConfigRequest
+	SavedNetworkPolicy  *container.SetNetworkPolicyRequest
+	UpdatedCluster      *container.UpdateClusterRequest
+	StartedIPRotation   *container.StartIPRotationRequest
+	GetClusterResponse  *container.Cluster
 }
 
 // UpdateAddonsConfig updates the addons configuration of a given cluster.
-func (c *ContainerStub) UpdateAddonsConfig(ctx context.Context, projectID, zone, clusterID string, conf *container.SetAddonsConfigRequest) (*container.Operation, error) {
+func (c *ContainerStub) UpdateAddonsConfig(ctx context.Context, projectID, location, clusterID string, conf *container.SetAddonsConfigRequest) (*container.Operation, error) {
+	if r, ok := c.mutate("UpdateAddonsConfig", projectID, location, clusterID, conf); ok {
+		resp, _ := r.Response.(*container.Operation)
+		return resp, r.Err
+	}
 	c.UpdatedAddonsConfig = conf
 	return &container.Operation{}, nil
 }
+
+// SetNetworkPolicy records the network policy of a given cluster.
+func (c *ContainerStub) SetNetworkPolicy(ctx context.Context, projectID, location, clusterID string, req *container.SetNetworkPolicyRequest) (*container.Operation, error) {
+	if r, ok := c.mutate("SetNetworkPolicy", projectID, location, clusterID, req); ok {
+		resp, _ := r.Response.(*container.Operation)
+		return resp, r.Err
+	}
+	c.SavedNetworkPolicy = req
+	return &container.Operation{}, nil
+}
+
+// UpdateCluster records the update of a given cluster.
+func (c *ContainerStub) UpdateCluster(ctx context.Context, projectID, location, clusterID string, req *container.UpdateClusterRequest) (*container.Operation, error) {
+	if r, ok := c.mutate("UpdateCluster", projectID, location, clusterID, req); ok {
+		resp, _ := r.Response.(*container.Operation)
+		return resp, r.Err
+	}
+	c.UpdatedCluster = req
+	return &container.Operation{}, nil
+}
+
+// StartIPRotation records the rotation started on a given cluster.
+func (c *ContainerStub) StartIPRotation(ctx context.Context, projectID, location, clusterID string, req *container.StartIPRotationRequest) (*container.Operation, error) {
+	if r, ok := c.mutate("StartIPRotation", projectID, location, clusterID, req); ok {
+		resp, _ := r.Response.(*container.Operation)
+		return resp, r.Err
+	}
+	c.StartedIPRotation = req
+	return &container.Operation{}, nil
+}
+
+// WaitContainer returns immediately.
+func (c *ContainerStub) WaitContainer(ctx context.Context, projectID, location string, op *container.Operation) error {
+	if r, ok := c.read("WaitContainer"); ok {
+		return r.Err
+	}
+	return nil
+}
+
+// GetCluster returns the stubbed cluster or a cluster without addons configured if none is stubbed.
+func (c *ContainerStub) GetCluster(ctx context.Context, projectID, location, clusterID string) (*container.Cluster, error) {
+	if r, ok := c.read("GetCluster"); ok {
+		resp, _ := r.Response.(*container.Cluster)
+		return resp, r.Err
+	}
+	if c.GetClusterResponse == nil {
+		return &container.Cluster{Name: clusterID}, nil
+	}
+	return c.GetClusterResponse, nil
+}
@@ -0,0 +1,41 @@
+package stubs
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"net/http"
+
+	dataproc "google.golang.org/api/dataproc/v1"
+)
+
+// DataprocStub provides a stub for the Dataproc client.
+type DataprocStub struct {
+	Calls
+	// StubbedCluster is returned for any cluster, none is found if nil.
+	StubbedCluster *dataproc.Cluster
+}
+
+// GetCluster returns the stubbed cluster.
+func (d *DataprocStub) GetCluster(ctx context.Context, projectID, region, cluster string) (*dataproc.Cluster, error) {
+	if r, ok := d.read("GetCluster"); ok {
+		resp, _ := r.Response.(*dataproc.Cluster)
+		return resp, r.Err
+	}
+	if d.StubbedCluster == nil {
+		return nil, APIError(http.StatusNotFound)
+	}
+	return d.StubbedCluster, nil
+}
@@ -0,0 +1,52 @@
+package stubs
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"github.com/sendgrid/rest"
+)
+
+// EmailStub provides a stub for the email service client.
+type EmailStub struct {
+	SentEmails     []SentEmail
+	StubbedSendErr error
+}
+
+// SentEmail is an email sent through the stub.
+type SentEmail struct {
+	Subject string
+	From    string
+	Body    string
+	To      []string
+	HTML    bool
+}
+
+// Send records the email.
+func (e *EmailStub) Send(subject, from, body string, to []string) (*rest.Response, error) {
+	if e.StubbedSendErr != nil {
+		return nil, e.StubbedSendErr
+	}
+	e.SentEmails = append(e.SentEmails, SentEmail{Subject: subject, From: from, Body: body, To: to})
+	return &rest.Response{StatusCode: 202}, nil
+}
+
+// SendHTML records the email as an HTML email.
+func (e *EmailStub) SendHTML(subject, from, body string, to []string) (*rest.Response, error) {
+	if e.StubbedSendErr != nil {
+		return nil, e.StubbedSendErr
+	}
+	e.SentEmails = append(e.SentEmails, SentEmail{Subject: subject, From: from, Body: body, To: to, HTML: true})
+	return &rest.Response{StatusCode: 202}, nil
+}
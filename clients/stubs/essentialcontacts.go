@@ -0,0 +1,36 @@
+package stubs
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"fmt"
+)
+
+// EssentialContactsStub provides a stub for the Essential Contacts client.
+type EssentialContactsStub struct {
+	// StubbedContacts maps a parent resource to its contacts.
+	StubbedContacts map[string][]string
+	// ComputeContactsShouldFail returns an error from ComputeContacts.
+	ComputeContactsShouldFail bool
+}
+
+// ComputeContacts returns the stubbed contacts of the parent.
+func (s *EssentialContactsStub) ComputeContacts(ctx context.Context, parent string, categories []string) ([]string, error) {
+	if s.ComputeContactsShouldFail {
+		return nil, fmt.Errorf("failed to compute contacts of %q", parent)
+	}
+	return s.StubbedContacts[parent], nil
+}
@@ -0,0 +1,43 @@
+package stubs
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+
+	admin "google.golang.org/api/admin/directory/v1"
+)
+
+// GroupsStub provides a stub for the Groups client.
+type GroupsStub struct {
+	// StubbedMembers holds the members of each group, keyed by the group's email.
+	StubbedMembers map[string][]*admin.Member
+	// RemovedMembers holds the members removed from each group.
+	RemovedMembers map[string][]string
+}
+
+// GroupMembers returns the stubbed members of the group.
+func (s *GroupsStub) GroupMembers(ctx context.Context, groupKey string) ([]*admin.Member, error) {
+	return s.StubbedMembers[groupKey], nil
+}
+
+// RemoveGroupMember records the member removed from the group.
+func (s *GroupsStub) RemoveGroupMember(ctx context.Context, groupKey, memberKey string) error {
+	if s.RemovedMembers == nil {
+		s.RemovedMembers = make(map[string][]string)
+	}
+	s.RemovedMembers[groupKey] = append(s.RemovedMembers[groupKey], memberKey)
+	return nil
+}
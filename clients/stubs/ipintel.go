@@ -0,0 +1,44 @@
+package stubs
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import "context"
+
+// StubbedIP is the reputation and country returned for an IP address.
+type StubbedIP struct {
+	Reputation string
+	Country    string
+}
+
+// IPIntelStub provides a stub for the IP intelligence client.
+type IPIntelStub struct {
+	StubbedIPs map[string]StubbedIP
+	// LookedUp are the IP addresses looked up, in order.
+	LookedUp []string
+	Err      error
+}
+
+// LookupIP returns the stubbed reputation and country of the IP address, or unknown.
+func (s *IPIntelStub) LookupIP(ctx context.Context, ip string) (string, string, error) {
+	s.LookedUp = append(s.LookedUp, ip)
+	if s.Err != nil {
+		return "", "", s.Err
+	}
+	r, ok := s.StubbedIPs[ip]
+	if !ok {
+		return "unknown", "", nil
+	}
+	return r.Reputation, r.Country, nil
+}
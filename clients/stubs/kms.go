@@ -0,0 +1,75 @@
+package stubs
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+
+	kms "google.golang.org/api/cloudkms/v1"
+)
+
+// KMSStub provides a stub for the KMS client.
+type KMSStub struct {
+	// StubbedVersions holds the key versions keyed by their resource name.
+	StubbedVersions map[string]*kms.CryptoKeyVersion
+	// StubbedPolicies holds the IAM policies of the keys keyed by the key's resource name.
+	StubbedPolicies map[string]*kms.Policy
+	// SavedStates holds the state each key version was updated to.
+	SavedStates map[string]string
+	// SavedPolicies holds the IAM policies set on each key.
+	SavedPolicies map[string]*kms.Policy
+	// SignedDigests holds the digests signed with each key version.
+	SignedDigests map[string][][]byte
+}
+
+// GetCryptoKeyVersion returns the stubbed key version.
+func (s *KMSStub) GetCryptoKeyVersion(ctx context.Context, name string) (*kms.CryptoKeyVersion, error) {
+	return s.StubbedVersions[name], nil
+}
+
+// UpdateCryptoKeyVersionState records the state of the key version.
+func (s *KMSStub) UpdateCryptoKeyVersionState(ctx context.Context, name, state string) (*kms.CryptoKeyVersion, error) {
+	if s.SavedStates == nil {
+		s.SavedStates = make(map[string]string)
+	}
+	s.SavedStates[name] = state
+	return &kms.CryptoKeyVersion{Name: name, State: state}, nil
+}
+
+// GetCryptoKeyPolicy returns the stubbed IAM policy of the key.
+func (s *KMSStub) GetCryptoKeyPolicy(ctx context.Context, key string) (*kms.Policy, error) {
+	if p, ok := s.StubbedPolicies[key]; ok {
+		return p, nil
+	}
+	return &kms.Policy{}, nil
+}
+
+// SetCryptoKeyPolicy records the IAM policy set on the key.
+func (s *KMSStub) SetCryptoKeyPolicy(ctx context.Context, key string, p *kms.Policy) (*kms.Policy, error) {
+	if s.SavedPolicies == nil {
+		s.SavedPolicies = make(map[string]*kms.Policy)
+	}
+	s.SavedPolicies[key] = p
+	return p, nil
+}
+
+// AsymmetricSign records the digest and returns a signature made of the key version and digest.
+func (s *KMSStub) AsymmetricSign(ctx context.Context, name string, digest []byte) ([]byte, error) {
+	if s.SignedDigests == nil {
+		s.SignedDigests = make(map[string][][]byte)
+	}
+	s.SignedDigests[name] = append(s.SignedDigests[name], digest)
+	return append([]byte(name+":"), digest...), nil
+}
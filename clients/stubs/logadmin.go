@@ -0,0 +1,36 @@
+package stubs
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+
+	"cloud.google.com/go/logging"
+)
+
+// LogAdminStub provides a stub for the LogAdmin client.
+type LogAdminStub struct {
+	SavedFilter    string
+	StubbedEntries []*logging.Entry
+}
+
+// ListEntries returns the stubbed log entries.
+func (s *LogAdminStub) ListEntries(ctx context.Context, projectID, filter string, limit int) ([]*logging.Entry, error) {
+	s.SavedFilter = filter
+	if len(s.StubbedEntries) > limit {
+		return s.StubbedEntries[:limit], nil
+	}
+	return s.StubbedEntries, nil
+}
@@ -18,6 +18,7 @@ import "log"
 
 // LoggerStub provides a stub for the Logger client.
 type LoggerStub struct {
+	AuditRecords []interface{}
 }
 
 // Info push info log to buffer.
@@ -32,5 +33,8 @@ func (l *LoggerStub) Error(message string, a ...interface{}) { log.Printf(messag
 // Debug push debug log to buffer.
 func (l *LoggerStub) Debug(message string, a ...interface{}) { log.Printf(message, a...) }
 
+// Audit saves the audit record.
+func (l *LoggerStub) Audit(payload interface{}) { l.AuditRecords = append(l.AuditRecords, payload) }
+
 // Close buffer and send messages to stackdriver.
 func (l *LoggerStub) Close() {}
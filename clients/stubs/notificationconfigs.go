@@ -0,0 +1,59 @@
+package stubs
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"net/http"
+
+	"google.golang.org/api/googleapi"
+)
+
+// StubbedNotificationConfig is a notification config held by the stub.
+type StubbedNotificationConfig struct {
+	Description string
+	Topic       string
+	Filter      string
+}
+
+// NotificationConfigsStub provides a stub for the notification configs client.
+type NotificationConfigsStub struct {
+	// Configs maps the name of a notification config to the config.
+	Configs map[string]*StubbedNotificationConfig
+}
+
+// GetNotificationConfig returns the topic and filter of the named config or a not found error.
+func (n *NotificationConfigsStub) GetNotificationConfig(ctx context.Context, name string) (string, string, error) {
+	c, ok := n.Configs[name]
+	if !ok {
+		return "", "", &googleapi.Error{Code: http.StatusNotFound}
+	}
+	return c.Topic, c.Filter, nil
+}
+
+// CreateNotificationConfig adds the config.
+func (n *NotificationConfigsStub) CreateNotificationConfig(ctx context.Context, parent, id, description, topic, filter string) error {
+	if n.Configs == nil {
+		n.Configs = map[string]*StubbedNotificationConfig{}
+	}
+	n.Configs[parent+"/notificationConfigs/"+id] = &StubbedNotificationConfig{Description: description, Topic: topic, Filter: filter}
+	return nil
+}
+
+// UpdateNotificationConfig replaces the named config.
+func (n *NotificationConfigsStub) UpdateNotificationConfig(ctx context.Context, name, description, topic, filter string) error {
+	n.Configs[name] = &StubbedNotificationConfig{Description: description, Topic: topic, Filter: filter}
+	return nil
+}
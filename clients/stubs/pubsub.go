@@ -24,6 +24,11 @@ import (
 type PubSubStub struct {
 	StubbedTopic     *pubsub.Topic
 	PublishedMessage *pubsub.Message
+	// PublishedMessages holds every message published, in order.
+	PublishedMessages []*pubsub.Message
+	// ExistingTopics holds the IDs of the topics that exist.
+	ExistingTopics map[string]bool
+	CreatedTopics  []string
 }
 
 // Topic returns a reference to a topic.
@@ -34,5 +39,22 @@ func (p *PubSubStub) Topic(id string) *pubsub.Topic {
 // Publish will publish a message to a PubSub topic.
 func (p *PubSubStub) Publish(ctx context.Context, topic *pubsub.Topic, message *pubsub.Message) (string, error) {
 	p.PublishedMessage = message
+	p.PublishedMessages = append(p.PublishedMessages, message)
 	return "", nil
 }
+
+// TopicExists returns whether the topic was created or is one of the existing topics.
+func (p *PubSubStub) TopicExists(ctx context.Context, id string) (bool, error) {
+	for _, t := range p.CreatedTopics {
+		if t == id {
+			return true, nil
+		}
+	}
+	return p.ExistingTopics[id], nil
+}
+
+// CreateTopic records the created topic.
+func (p *PubSubStub) CreateTopic(ctx context.Context, id string) error {
+	p.CreatedTopics = append(p.CreatedTopics, id)
+	return nil
+}
@@ -0,0 +1,48 @@
+package stubs
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+
+	"github.com/googlecloudplatform/security-response-automation/clients/insights"
+)
+
+// RecommenderStub provides a stub for the Recommender client.
+type RecommenderStub struct {
+	Calls
+	// StubbedInsights are returned by every listing regardless of the filter.
+	StubbedInsights []*insights.Insight
+	// Accepted holds the names of the insights marked accepted.
+	Accepted []string
+}
+
+// ListInsights returns the stubbed insights.
+func (r *RecommenderStub) ListInsights(ctx context.Context, parent, filter string) ([]*insights.Insight, error) {
+	if res, ok := r.read("ListInsights"); ok {
+		resp, _ := res.Response.([]*insights.Insight)
+		return resp, res.Err
+	}
+	return r.StubbedInsights, nil
+}
+
+// MarkInsightAccepted records the insight as accepted.
+func (r *RecommenderStub) MarkInsightAccepted(ctx context.Context, name, etag string) error {
+	if res, ok := r.mutate("MarkInsightAccepted", name, etag); ok && res.Err != nil {
+		return res.Err
+	}
+	r.Accepted = append(r.Accepted, name)
+	return nil
+}
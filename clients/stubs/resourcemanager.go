@@ -17,8 +17,10 @@ package stubs
 
 import (
 	"context"
+	"fmt"
 
 	crm "google.golang.org/api/cloudresourcemanager/v1"
+	crmv2 "google.golang.org/api/cloudresourcemanager/v2"
 )
 
 // ResourceManagerStub provides a stub for the CRM client.
@@ -27,6 +29,12 @@ type ResourceManagerStub struct {
 	GetAncestryResponse     *crm.GetAncestryResponse
 	SavedSetPolicy          *crm.Policy
 	GetOrganizationResponse *crm.Organization
+	GetFolderPolicyResponse *crmv2.Policy
+	SavedSetFolderPolicy    *crmv2.Policy
+	GetFolderResponse       map[string]*crmv2.Folder
+	GetProjectResponse      map[string]*crm.Project
+	// DeniedPermissions are left out of the permissions returned by TestPermissionsProject.
+	DeniedPermissions []string
 }
 
 // GetPolicyProject is a stub of Cloud Resource Manager's GetIamPolicy.
@@ -46,6 +54,30 @@ func (s *ResourceManagerStub) SetPolicyProjectWithMask(ctx context.Context, proj
 	return s.SavedSetPolicy, nil
 }
 
+// GetProject is a stub of Cloud Resource Manager's GetProject.
+func (s *ResourceManagerStub) GetProject(ctx context.Context, projectID string) (*crm.Project, error) {
+	p, ok := s.GetProjectResponse[projectID]
+	if !ok {
+		return nil, fmt.Errorf("project %q not found", projectID)
+	}
+	return p, nil
+}
+
+// TestPermissionsProject is a stub of Cloud Resource Manager's TestIamPermissions.
+func (s *ResourceManagerStub) TestPermissionsProject(ctx context.Context, projectID string, permissions []string) ([]string, error) {
+	granted := []string{}
+	for _, p := range permissions {
+		denied := false
+		for _, d := range s.DeniedPermissions {
+			denied = denied || d == p
+		}
+		if !denied {
+			granted = append(granted, p)
+		}
+	}
+	return granted, nil
+}
+
 // GetAncestry is a stub of Cloud Resource Manager's GetAncestry.
 func (s *ResourceManagerStub) GetAncestry(context.Context, string) (*crm.GetAncestryResponse, error) {
 	return s.GetAncestryResponse, nil
@@ -66,3 +98,23 @@ func (s *ResourceManagerStub) SetPolicyOrganization(ctx context.Context, organiz
 func (s *ResourceManagerStub) GetOrganization(ctx context.Context, organizationID string) (*crm.Organization, error) {
 	return s.GetOrganizationResponse, nil
 }
+
+// GetPolicyFolder is a stub of Cloud Resource Manager's v2 GetIamPolicy.
+func (s *ResourceManagerStub) GetPolicyFolder(ctx context.Context, name string) (*crmv2.Policy, error) {
+	return s.GetFolderPolicyResponse, nil
+}
+
+// SetPolicyFolder is a stub of Cloud Resource Manager's v2 SetIamPolicy.
+func (s *ResourceManagerStub) SetPolicyFolder(ctx context.Context, name string, p *crmv2.Policy) (*crmv2.Policy, error) {
+	s.SavedSetFolderPolicy = p
+	return s.SavedSetFolderPolicy, nil
+}
+
+// GetFolder is a stub of Cloud Resource Manager's v2 GetFolder.
+func (s *ResourceManagerStub) GetFolder(ctx context.Context, name string) (*crmv2.Folder, error) {
+	f, ok := s.GetFolderResponse[name]
+	if !ok {
+		return nil, fmt.Errorf("folder %q not found", name)
+	}
+	return f, nil
+}
@@ -0,0 +1,49 @@
+package stubs
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"net/http"
+
+	"google.golang.org/api/googleapi"
+)
+
+// SecretManagerStub provides a stub for the Secret Manager client.
+type SecretManagerStub struct {
+	// ExistingSecrets contains the secret IDs that already exist.
+	ExistingSecrets map[string]bool
+	CreatedSecrets  []string
+	// SavedVersions maps a secret ID to the latest payload added.
+	SavedVersions map[string][]byte
+}
+
+// CreateSecret records the created secret or returns a conflict if it already exists.
+func (s *SecretManagerStub) CreateSecret(ctx context.Context, projectID, secretID string) error {
+	if s.ExistingSecrets[secretID] {
+		return &googleapi.Error{Code: http.StatusConflict}
+	}
+	s.CreatedSecrets = append(s.CreatedSecrets, secretID)
+	return nil
+}
+
+// AddSecretVersion records the payload.
+func (s *SecretManagerStub) AddSecretVersion(ctx context.Context, projectID, secretID string, payload []byte) error {
+	if s.SavedVersions == nil {
+		s.SavedVersions = make(map[string][]byte)
+	}
+	s.SavedVersions[secretID] = payload
+	return nil
+}
@@ -0,0 +1,100 @@
+package stubs
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"net/http"
+	"sort"
+
+	iam "google.golang.org/api/iam/v1"
+)
+
+// ServiceAccountsStub provides a stub for the IAM service accounts client.
+type ServiceAccountsStub struct {
+	Calls
+	// StubbedAccounts holds the service accounts keyed by resource name, a missing one is not found.
+	StubbedAccounts map[string]*iam.ServiceAccount
+	// StubbedKeys holds the keys of each service account keyed by the account's resource name.
+	StubbedKeys map[string][]*iam.ServiceAccountKey
+}
+
+// GetServiceAccount returns the stubbed service account.
+func (s *ServiceAccountsStub) GetServiceAccount(ctx context.Context, name string) (*iam.ServiceAccount, error) {
+	if r, ok := s.read("GetServiceAccount"); ok {
+		resp, _ := r.Response.(*iam.ServiceAccount)
+		return resp, r.Err
+	}
+	a, ok := s.StubbedAccounts[name]
+	if !ok {
+		return nil, APIError(http.StatusNotFound)
+	}
+	return a, nil
+}
+
+// ListServiceAccounts returns the stubbed service accounts of the project ordered by email.
+func (s *ServiceAccountsStub) ListServiceAccounts(ctx context.Context, projectID string) ([]*iam.ServiceAccount, error) {
+	if r, ok := s.read("ListServiceAccounts"); ok {
+		resp, _ := r.Response.([]*iam.ServiceAccount)
+		return resp, r.Err
+	}
+	accounts := []*iam.ServiceAccount{}
+	for _, a := range s.StubbedAccounts {
+		if a.ProjectId == projectID {
+			accounts = append(accounts, a)
+		}
+	}
+	sort.Slice(accounts, func(i, j int) bool { return accounts[i].Email < accounts[j].Email })
+	return accounts, nil
+}
+
+// ListServiceAccountKeys returns the stubbed keys of the service account.
+func (s *ServiceAccountsStub) ListServiceAccountKeys(ctx context.Context, name string) ([]*iam.ServiceAccountKey, error) {
+	if r, ok := s.read("ListServiceAccountKeys"); ok {
+		resp, _ := r.Response.([]*iam.ServiceAccountKey)
+		return resp, r.Err
+	}
+	return s.StubbedKeys[name], nil
+}
+
+// DeleteServiceAccountKey records the deleted key.
+func (s *ServiceAccountsStub) DeleteServiceAccountKey(ctx context.Context, name string) error {
+	if r, ok := s.mutate("DeleteServiceAccountKey", name); ok {
+		return r.Err
+	}
+	return nil
+}
+
+// DisableServiceAccount records the disabled service account and marks the stubbed one disabled.
+func (s *ServiceAccountsStub) DisableServiceAccount(ctx context.Context, name string) error {
+	if r, ok := s.mutate("DisableServiceAccount", name); ok {
+		return r.Err
+	}
+	if a, ok := s.StubbedAccounts[name]; ok {
+		a.Disabled = true
+	}
+	return nil
+}
+
+// EnableServiceAccount records the enabled service account and marks the stubbed one enabled.
+func (s *ServiceAccountsStub) EnableServiceAccount(ctx context.Context, name string) error {
+	if r, ok := s.mutate("EnableServiceAccount", name); ok {
+		return r.Err
+	}
+	if a, ok := s.StubbedAccounts[name]; ok {
+		a.Disabled = false
+	}
+	return nil
+}
@@ -16,15 +16,30 @@ package stubs
 
 import (
 	"context"
+	"sort"
+	"strings"
 
 	"cloud.google.com/go/iam"
+	"cloud.google.com/go/storage"
 )
 
 // StorageStub provides a stub for the Storage client.
 type StorageStub struct {
-	BucketPolicyResponse  *iam.Policy
-	RemoveBucketPolicy    *iam.Policy
-	EnabledPolicyOnBucket string
+	BucketPolicyResponse      *iam.Policy
+	RemoveBucketPolicy        *iam.Policy
+	EnabledPolicyOnBucket     string
+	EnabledLoggingOnBucket    string
+	SavedLogBucket            string
+	SavedLogObjectPrefix      string
+	EnabledVersioningOnBucket string
+	WrittenObjects            map[string][]byte
+	BucketAttrsResponse       *storage.BucketAttrs
+	// ObjectACLs holds the access control lists of objects keyed by "bucket/object".
+	ObjectACLs map[string][]storage.ACLRule
+	// BucketLabels holds the labels set on each bucket.
+	BucketLabels map[string]map[string]string
+	// BucketKMSKeys holds the default Cloud KMS key set on each bucket.
+	BucketKMSKeys map[string]string
 }
 
 // SetBucketPolicy set a policy for the given bucket.
@@ -33,13 +48,119 @@ func (s *StorageStub) SetBucketPolicy(ctx context.Context, bucketName string, p
 	return nil
 }
 
+// SetBucketLabel saves the label set on the bucket.
+func (s *StorageStub) SetBucketLabel(ctx context.Context, bucketName, key, value string) error {
+	if s.BucketLabels == nil {
+		s.BucketLabels = make(map[string]map[string]string)
+	}
+	if s.BucketLabels[bucketName] == nil {
+		s.BucketLabels[bucketName] = make(map[string]string)
+	}
+	s.BucketLabels[bucketName][key] = value
+	return nil
+}
+
+// SetBucketDefaultKMSKey saves the default Cloud KMS key set on the bucket.
+func (s *StorageStub) SetBucketDefaultKMSKey(ctx context.Context, bucketName, keyName string) error {
+	if s.BucketKMSKeys == nil {
+		s.BucketKMSKeys = make(map[string]string)
+	}
+	s.BucketKMSKeys[bucketName] = keyName
+	return nil
+}
+
 // BucketPolicy gets a bucket's policy.
 func (s *StorageStub) BucketPolicy(ctx context.Context, bucketName string) (*iam.Policy, error) {
 	return s.BucketPolicyResponse, nil
 }
 
+// BucketAttrs returns the stubbed bucket attributes or empty attributes if none are stubbed.
+func (s *StorageStub) BucketAttrs(ctx context.Context, bucketName string) (*storage.BucketAttrs, error) {
+	if s.BucketAttrsResponse == nil {
+		return &storage.BucketAttrs{Name: bucketName}, nil
+	}
+	return s.BucketAttrsResponse, nil
+}
+
 // EnableBucketOnlyPolicy saves the bucket that receives the request for enabling bucket only policy.
 func (s *StorageStub) EnableBucketOnlyPolicy(ctx context.Context, bucketName string) error {
 	s.EnabledPolicyOnBucket = bucketName
 	return nil
 }
+
+// EnableBucketLogging saves the bucket and log destination that receives the request for enabling logging.
+func (s *StorageStub) EnableBucketLogging(ctx context.Context, bucketName, logBucket, logObjectPrefix string) error {
+	s.EnabledLoggingOnBucket = bucketName
+	s.SavedLogBucket = logBucket
+	s.SavedLogObjectPrefix = logObjectPrefix
+	return nil
+}
+
+// EnableBucketVersioning saves the bucket that receives the request for enabling versioning.
+func (s *StorageStub) EnableBucketVersioning(ctx context.Context, bucketName string) error {
+	s.EnabledVersioningOnBucket = bucketName
+	return nil
+}
+
+// WriteObject records the content written to the object.
+func (s *StorageStub) WriteObject(ctx context.Context, bucketName, objectName string, content []byte) error {
+	if s.WrittenObjects == nil {
+		s.WrittenObjects = map[string][]byte{}
+	}
+	s.WrittenObjects[bucketName+"/"+objectName] = content
+	return nil
+}
+
+// ReadObject returns the content of a previously written object.
+func (s *StorageStub) ReadObject(ctx context.Context, bucketName, objectName string) ([]byte, error) {
+	content, ok := s.WrittenObjects[bucketName+"/"+objectName]
+	if !ok {
+		return nil, storage.ErrObjectNotExist
+	}
+	return content, nil
+}
+
+// ListObjects returns the sorted names of the written objects starting with prefix.
+func (s *StorageStub) ListObjects(ctx context.Context, bucketName, prefix string) ([]string, error) {
+	var names []string
+	for k := range s.WrittenObjects {
+		if name := strings.TrimPrefix(k, bucketName+"/"); name != k && strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// ListObjectsUpTo returns the sorted names of at most max written objects starting with prefix.
+func (s *StorageStub) ListObjectsUpTo(ctx context.Context, bucketName, prefix string, max int) ([]string, bool, error) {
+	names, _ := s.ListObjects(ctx, bucketName, prefix)
+	if len(names) > max {
+		return names[:max], true, nil
+	}
+	return names, false, nil
+}
+
+// ObjectACL returns the stubbed access control list of the object.
+func (s *StorageStub) ObjectACL(ctx context.Context, bucketName, objectName string) ([]storage.ACLRule, error) {
+	return s.ObjectACLs[bucketName+"/"+objectName], nil
+}
+
+// DeleteObjectACL removes the entity from the stubbed access control list of the object.
+func (s *StorageStub) DeleteObjectACL(ctx context.Context, bucketName, objectName string, entity storage.ACLEntity) error {
+	k := bucketName + "/" + objectName
+	rules := []storage.ACLRule{}
+	for _, r := range s.ObjectACLs[k] {
+		if r.Entity != entity {
+			rules = append(rules, r)
+		}
+	}
+	s.ObjectACLs[k] = rules
+	return nil
+}
+
+// DeleteObject removes a written object.
+func (s *StorageStub) DeleteObject(ctx context.Context, bucketName, objectName string) error {
+	delete(s.WrittenObjects, bucketName+"/"+objectName)
+	return nil
+}
@@ -0,0 +1,52 @@
+package stubs
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+
+	pubsubpb "google.golang.org/genproto/googleapis/pubsub/v1"
+)
+
+// SubscriberStub provides a stub for the Subscriber client.
+type SubscriberStub struct {
+	Calls
+	// Messages are the messages waiting to be pulled, they're removed once pulled.
+	Messages []*pubsubpb.ReceivedMessage
+	Acked    []string
+}
+
+// Pull returns up to max of the waiting messages.
+func (s *SubscriberStub) Pull(ctx context.Context, subscription string, max int) ([]*pubsubpb.ReceivedMessage, error) {
+	if r, ok := s.read("Pull"); ok {
+		resp, _ := r.Response.([]*pubsubpb.ReceivedMessage)
+		return resp, r.Err
+	}
+	if max > len(s.Messages) {
+		max = len(s.Messages)
+	}
+	pulled := s.Messages[:max]
+	s.Messages = s.Messages[max:]
+	return pulled, nil
+}
+
+// Acknowledge records the acknowledged messages.
+func (s *SubscriberStub) Acknowledge(ctx context.Context, subscription string, ackIDs []string) error {
+	if r, ok := s.mutate("Acknowledge", subscription, ackIDs); ok {
+		return r.Err
+	}
+	s.Acked = append(s.Acked, ackIDs...)
+	return nil
+}
@@ -0,0 +1,33 @@
+package stubs
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import "context"
+
+// TagsStub provides a stub for the Resource Manager tags client.
+type TagsStub struct {
+	Calls
+	// StubbedTags holds the effective tags of each resource keyed by full resource name.
+	StubbedTags map[string][]string
+}
+
+// EffectiveTags returns the stubbed tags of the resource.
+func (t *TagsStub) EffectiveTags(ctx context.Context, resource string) ([]string, error) {
+	if r, ok := t.read("EffectiveTags"); ok {
+		resp, _ := r.Response.([]string)
+		return resp, r.Err
+	}
+	return t.StubbedTags[resource], nil
+}
@@ -0,0 +1,37 @@
+package stubs
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"errors"
+
+	oauth2 "google.golang.org/api/oauth2/v2"
+)
+
+// TokenInfoStub provides a stub for the TokenInfo client.
+type TokenInfoStub struct {
+	// StubbedTokens maps an ID token to its claims, other tokens are invalid.
+	StubbedTokens map[string]*oauth2.Tokeninfo
+}
+
+// TokenInfo returns the stubbed claims of the ID token.
+func (t *TokenInfoStub) TokenInfo(ctx context.Context, idToken string) (*oauth2.Tokeninfo, error) {
+	info, ok := t.StubbedTokens[idToken]
+	if !ok {
+		return nil, errors.New("invalid token")
+	}
+	return info, nil
+}
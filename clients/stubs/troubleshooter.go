@@ -0,0 +1,43 @@
+package stubs
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+
+	pt "google.golang.org/api/policytroubleshooter/v1beta"
+)
+
+// PolicyTroubleshooterStub provides a stub for the Policy Troubleshooter client.
+type PolicyTroubleshooterStub struct {
+	Calls
+	// StubbedExplanations holds the explanation of each principal's access, a missing one has none.
+	StubbedExplanations map[string]*pt.GoogleCloudPolicytroubleshooterV1betaTroubleshootIamPolicyResponse
+	// Troubleshot holds the principals whose access was troubleshot.
+	Troubleshot []string
+}
+
+// TroubleshootIAMPolicy returns the stubbed explanation of the principal's access.
+func (s *PolicyTroubleshooterStub) TroubleshootIAMPolicy(ctx context.Context, principal, resource, permission string) (*pt.GoogleCloudPolicytroubleshooterV1betaTroubleshootIamPolicyResponse, error) {
+	s.Troubleshot = append(s.Troubleshot, principal)
+	if r, ok := s.read("TroubleshootIAMPolicy"); ok {
+		resp, _ := r.Response.(*pt.GoogleCloudPolicytroubleshooterV1betaTroubleshootIamPolicyResponse)
+		return resp, r.Err
+	}
+	if resp, ok := s.StubbedExplanations[principal]; ok {
+		return resp, nil
+	}
+	return &pt.GoogleCloudPolicytroubleshooterV1betaTroubleshootIamPolicyResponse{}, nil
+}
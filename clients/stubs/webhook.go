@@ -0,0 +1,36 @@
+package stubs
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"net/http"
+)
+
+// WebhookStub provides a stub for the Webhook client.
+type WebhookStub struct {
+	SavedURL    string
+	SavedBody   []byte
+	SavedHeader http.Header
+	StubbedErr  error
+}
+
+// Post records the request.
+func (w *WebhookStub) Post(ctx context.Context, url string, body []byte, header http.Header) error {
+	w.SavedURL = url
+	w.SavedBody = body
+	w.SavedHeader = header
+	return w.StubbedErr
+}
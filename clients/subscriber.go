@@ -0,0 +1,65 @@
+package clients
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"fmt"
+
+	pubsub "cloud.google.com/go/pubsub/apiv1"
+	"github.com/pkg/errors"
+	"google.golang.org/api/option"
+	pubsubpb "google.golang.org/genproto/googleapis/pubsub/v1"
+)
+
+// Subscriber client pulls messages from subscriptions.
+type Subscriber struct {
+	client    *pubsub.SubscriberClient
+	projectID string
+}
+
+// NewSubscriber returns the Subscriber client.
+func NewSubscriber(ctx context.Context, authFile, projectID string) (*Subscriber, error) {
+	client, err := pubsub.NewSubscriberClient(ctx, option.WithCredentialsFile(authFile))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to init subscriber")
+	}
+	return &Subscriber{client: client, projectID: projectID}, nil
+}
+
+// Pull returns up to max messages of the subscription without waiting for new ones.
+func (s *Subscriber) Pull(ctx context.Context, subscription string, max int) ([]*pubsubpb.ReceivedMessage, error) {
+	resp, err := s.client.Pull(ctx, &pubsubpb.PullRequest{
+		Subscription:      s.name(subscription),
+		MaxMessages:       int32(max),
+		ReturnImmediately: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetReceivedMessages(), nil
+}
+
+// Acknowledge acknowledges the messages so they're not delivered again.
+func (s *Subscriber) Acknowledge(ctx context.Context, subscription string, ackIDs []string) error {
+	return s.client.Acknowledge(ctx, &pubsubpb.AcknowledgeRequest{
+		Subscription: s.name(subscription),
+		AckIds:       ackIDs,
+	})
+}
+
+func (s *Subscriber) name(subscription string) string {
+	return fmt.Sprintf("projects/%s/subscriptions/%s", s.projectID, subscription)
+}
@@ -0,0 +1,91 @@
+package clients
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"google.golang.org/api/googleapi"
+)
+
+// resourceManagerV3Endpoint serves effective tags, which the generated Cloud Resource Manager
+// clients do not support yet.
+const resourceManagerV3Endpoint = "https://cloudresourcemanager.googleapis.com/v3"
+
+// Tags client reads the tags bound to resources with the Resource Manager Tags API.
+type Tags struct {
+	client *http.Client
+}
+
+// NewTags returns and initializes a Resource Manager tags client.
+func NewTags(ctx context.Context, authFile string) (*Tags, error) {
+	c, err := httpClient(ctx, authFile, "cloudresourcemanager")
+	if err != nil {
+		return nil, err
+	}
+	return &Tags{client: c}, nil
+}
+
+// EffectiveTags returns the tag values bound to the resource or inherited from its ancestors, i.e.
+// "tagValues/123" along with its namespaced name "456/env/prod". The resource is the full resource
+// name, i.e. "//cloudresourcemanager.googleapis.com/projects/789" where 789 is the project number.
+func (t *Tags) EffectiveTags(ctx context.Context, resource string) ([]string, error) {
+	var tags []string
+	token := ""
+	for {
+		v := url.Values{"parent": {resource}}
+		if token != "" {
+			v.Set("pageToken", token)
+		}
+		var resp struct {
+			EffectiveTags []struct {
+				TagValue           string `json:"tagValue"`
+				NamespacedTagValue string `json:"namespacedTagValue"`
+			} `json:"effectiveTags"`
+			NextPageToken string `json:"nextPageToken"`
+		}
+		if err := t.get(ctx, fmt.Sprintf("%s/effectiveTags?%s", resourceManagerV3Endpoint, v.Encode()), &resp); err != nil {
+			return nil, err
+		}
+		for _, e := range resp.EffectiveTags {
+			tags = append(tags, e.TagValue, e.NamespacedTagValue)
+		}
+		if resp.NextPageToken == "" {
+			return tags, nil
+		}
+		token = resp.NextPageToken
+	}
+}
+
+// get decodes the response of the URL into v.
+func (t *Tags) get(ctx context.Context, u string, v interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := t.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := googleapi.CheckResponse(resp); err != nil {
+		return err
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
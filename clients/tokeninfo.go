@@ -0,0 +1,43 @@
+package clients
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	oauth2 "google.golang.org/api/oauth2/v2"
+	"google.golang.org/api/option"
+)
+
+// TokenInfo client verifies Google-signed ID tokens.
+type TokenInfo struct {
+	service *oauth2.Service
+}
+
+// NewTokenInfo returns and initializes a TokenInfo client. Verifying a token needs no credentials.
+func NewTokenInfo(ctx context.Context) (*TokenInfo, error) {
+	s, err := oauth2.NewService(ctx, option.WithoutAuthentication())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to init oauth2")
+	}
+	return &TokenInfo{service: s}, nil
+}
+
+// TokenInfo returns the claims of the ID token. An error is returned if the token's signature is
+// invalid or it has expired.
+func (t *TokenInfo) TokenInfo(ctx context.Context, idToken string) (*oauth2.Tokeninfo, error) {
+	return t.service.Tokeninfo().IdToken(idToken).Context(ctx).Do()
+}
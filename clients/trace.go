@@ -0,0 +1,181 @@
+package clients
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	cloudtrace "google.golang.org/api/cloudtrace/v2"
+	"google.golang.org/api/option"
+)
+
+// TracerName is the instrumentation name of the spans recorded by the automations and clients.
+const TracerName = "github.com/googlecloudplatform/security-response-automation"
+
+const (
+	// maxSpanName is the longest display name Cloud Trace accepts, in bytes.
+	maxSpanName = 128
+	// maxAttributeValue is the longest attribute value Cloud Trace accepts, in bytes.
+	maxAttributeValue = 256
+	// statusUnknown is the google.rpc.Code of spans that ended with an error.
+	statusUnknown = 2
+)
+
+var (
+	tracingMu sync.Mutex
+	// tracerProvider is nil until tracing is enabled.
+	tracerProvider *sdktrace.TracerProvider
+)
+
+// spanKinds maps the kinds of OpenTelemetry spans to those of Cloud Trace.
+var spanKinds = map[trace.SpanKind]string{
+	trace.SpanKindInternal: "INTERNAL",
+	trace.SpanKindServer:   "SERVER",
+	trace.SpanKindClient:   "CLIENT",
+	trace.SpanKindProducer: "PRODUCER",
+	trace.SpanKindConsumer: "CONSUMER",
+}
+
+// CloudTrace client exporting OpenTelemetry spans to Cloud Trace.
+type CloudTrace struct {
+	service   *cloudtrace.Service
+	projectID string
+}
+
+// NewCloudTrace returns and initializes the Cloud Trace client, exporting spans to the project the
+// functions run in.
+func NewCloudTrace(ctx context.Context, authFile string) (*CloudTrace, error) {
+	s, err := cloudtrace.NewService(ctx, option.WithCredentialsFile(authFile))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to init cloud trace")
+	}
+	return &CloudTrace{service: s, projectID: projectID}, nil
+}
+
+// ExportSpans writes the spans to Cloud Trace.
+func (c *CloudTrace) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	req := &cloudtrace.BatchWriteSpansRequest{}
+	for _, s := range spans {
+		req.Spans = append(req.Spans, cloudTraceSpan(c.projectID, s))
+	}
+	if _, err := c.service.Projects.Traces.BatchWrite("projects/"+c.projectID, req).Context(ctx).Do(); err != nil {
+		return errors.Wrapf(err, "failed to export %d spans", len(spans))
+	}
+	return nil
+}
+
+// Shutdown is a no-op, spans are written as they're exported.
+func (c *CloudTrace) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+// cloudTraceSpan converts an OpenTelemetry span to a Cloud Trace span of the project.
+func cloudTraceSpan(projectID string, s sdktrace.ReadOnlySpan) *cloudtrace.Span {
+	sc := s.SpanContext()
+	span := &cloudtrace.Span{
+		Name:        fmt.Sprintf("projects/%s/traces/%s/spans/%s", projectID, sc.TraceID(), sc.SpanID()),
+		SpanId:      sc.SpanID().String(),
+		DisplayName: truncatable(s.Name(), maxSpanName),
+		StartTime:   s.StartTime().UTC().Format(time.RFC3339Nano),
+		EndTime:     s.EndTime().UTC().Format(time.RFC3339Nano),
+		SpanKind:    spanKinds[s.SpanKind()],
+	}
+	if p := s.Parent(); p.IsValid() {
+		span.ParentSpanId = p.SpanID().String()
+		span.SameProcessAsParentSpan = !p.IsRemote()
+	}
+	if attrs := s.Attributes(); len(attrs) > 0 {
+		span.Attributes = &cloudtrace.Attributes{AttributeMap: map[string]cloudtrace.AttributeValue{}}
+		for _, kv := range attrs {
+			span.Attributes.AttributeMap[string(kv.Key)] = cloudtrace.AttributeValue{StringValue: truncatable(kv.Value.Emit(), maxAttributeValue)}
+		}
+	}
+	if st := s.Status(); st.Code == codes.Error {
+		span.Status = &cloudtrace.Status{Code: statusUnknown, Message: st.Description}
+	}
+	return span
+}
+
+// truncatable returns the string truncated to max bytes as Cloud Trace expects.
+func truncatable(s string, max int) *cloudtrace.TruncatableString {
+	if len(s) <= max {
+		return &cloudtrace.TruncatableString{Value: s}
+	}
+	return &cloudtrace.TruncatableString{Value: s[:max], TruncatedByteCount: int64(len(s) - max)}
+}
+
+// EnableTracing records the spans of the automations, and a span for each request made by the
+// clients created afterwards, and exports them with the exporter. Spans are batched until
+// FlushTraces is called.
+func EnableTracing(exporter sdktrace.SpanExporter) {
+	tracingMu.Lock()
+	defer tracingMu.Unlock()
+	tracerProvider = sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter))
+	otel.SetTracerProvider(tracerProvider)
+}
+
+func tracing() bool {
+	tracingMu.Lock()
+	defer tracingMu.Unlock()
+	return tracerProvider != nil
+}
+
+// FlushTraces exports the spans recorded so far. Functions call it before returning since the
+// runtime may stop them as soon as they do.
+func FlushTraces(ctx context.Context) error {
+	tracingMu.Lock()
+	tp := tracerProvider
+	tracingMu.Unlock()
+	if tp == nil {
+		return nil
+	}
+	return tp.ForceFlush(ctx)
+}
+
+// tracingTransport records a client span for each request made to an API, i.e.
+// "cloudresourcemanager POST /v1/projects/p:getAncestry".
+type tracingTransport struct {
+	base http.RoundTripper
+	api  string
+}
+
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := otel.Tracer(TracerName).Start(req.Context(), t.api+" "+req.Method+" "+req.URL.Path,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("http.method", req.Method), attribute.String("http.host", req.URL.Host)))
+	defer span.End()
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return resp, err
+	}
+	span.SetAttributes(attribute.Int("http.status_code", resp.StatusCode))
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, strconv.Itoa(resp.StatusCode))
+	}
+	return resp, nil
+}
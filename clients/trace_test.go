@@ -0,0 +1,71 @@
+package clients
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracingTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/projects/missing:getAncestry" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	c := &http.Client{Transport: &tracingTransport{base: http.DefaultTransport, api: "cloudresourcemanager"}}
+	for _, path := range []string{"/v1/projects/p:getAncestry", "/v1/projects/missing:getAncestry"} {
+		resp, err := c.Post(srv.URL+path, "application/json", nil)
+		if err != nil {
+			t.Fatalf("failed to request %q: %q", path, err)
+		}
+		resp.Body.Close()
+	}
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(spans))
+	}
+	for i, want := range []struct {
+		name   string
+		failed bool
+	}{
+		{name: "cloudresourcemanager POST /v1/projects/p:getAncestry"},
+		{name: "cloudresourcemanager POST /v1/projects/missing:getAncestry", failed: true},
+	} {
+		span := cloudTraceSpan("test-project", spans[i])
+		if span.DisplayName.Value != want.name || span.SpanKind != "CLIENT" || (span.Status != nil) != want.failed {
+			t.Errorf("unexpected span %d: %q %q %+v", i, span.DisplayName.Value, span.SpanKind, span.Status)
+		}
+		if !strings.HasPrefix(span.Name, "projects/test-project/traces/") || span.Attributes.AttributeMap["http.method"].StringValue.Value != "POST" {
+			t.Errorf("unexpected span %d: %q %+v", i, span.Name, span.Attributes)
+		}
+	}
+}
+
+func TestTruncatable(t *testing.T) {
+	s := truncatable(strings.Repeat("a", 130), maxSpanName)
+	if len(s.Value) != maxSpanName || s.TruncatedByteCount != 2 {
+		t.Errorf("got %d bytes truncated by %d", len(s.Value), s.TruncatedByteCount)
+	}
+}
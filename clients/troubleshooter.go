@@ -0,0 +1,53 @@
+package clients
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	pt "google.golang.org/api/policytroubleshooter/v1beta"
+)
+
+// PolicyTroubleshooter client of the Policy Troubleshooter API.
+type PolicyTroubleshooter struct {
+	service *pt.Service
+}
+
+// NewPolicyTroubleshooter returns and initializes a Policy Troubleshooter client.
+func NewPolicyTroubleshooter(ctx context.Context, authFile string) (*PolicyTroubleshooter, error) {
+	opts, err := clientOptions(ctx, authFile, "policytroubleshooter")
+	if err != nil {
+		return nil, err
+	}
+	service, err := pt.NewService(ctx, opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to init policy troubleshooter")
+	}
+	return &PolicyTroubleshooter{service: service}, nil
+}
+
+// TroubleshootIAMPolicy explains whether the principal, i.e. "jane@example.com", has the
+// permission on the resource given its full name, i.e.
+// "//cloudresourcemanager.googleapis.com/projects/p", and through which bindings.
+func (p *PolicyTroubleshooter) TroubleshootIAMPolicy(ctx context.Context, principal, resource, permission string) (*pt.GoogleCloudPolicytroubleshooterV1betaTroubleshootIamPolicyResponse, error) {
+	return p.service.Iam.Troubleshoot(&pt.GoogleCloudPolicytroubleshooterV1betaTroubleshootIamPolicyRequest{
+		AccessTuple: &pt.GoogleCloudPolicytroubleshooterV1betaAccessTuple{
+			Principal:        principal,
+			FullResourceName: resource,
+			Permission:       permission,
+		},
+	}).Context(ctx).Do()
+}
@@ -20,6 +20,7 @@ package clients
 // 		- Possibly also support official VT Go API https://github.com/VirusTotal/vt-go
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -54,8 +55,12 @@ const (
 )
 
 // SamplesFromDomain returns a slice of hashes associated with a domain name.
-func SamplesFromDomain(domain string) ([]string, error) {
-	resp, err := http.Get(fmt.Sprintf(domainURL, domain, key))
+func SamplesFromDomain(ctx context.Context, domain string) ([]string, error) {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf(domainURL, domain, key), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
 	if err != nil {
 		return nil, err
 	}
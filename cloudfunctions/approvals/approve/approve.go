@@ -0,0 +1,79 @@
+// Package approve records the approvals of automations waiting to be approved.
+package approve
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"github.com/googlecloudplatform/security-response-automation/services"
+	"github.com/pkg/errors"
+)
+
+// action is the action recorded in the audit record of an approval.
+const action = "approve"
+
+// requestID matches the IDs returned by services.ApprovalID.
+var requestID = regexp.MustCompile("^[0-9a-f]{64}$")
+
+// Values contains the required values needed for this function.
+type Values struct {
+	// ID is the ID of the approval request.
+	ID string `json:"id"`
+	// Approver is the verified email of the caller, it's never read from the request.
+	Approver string `json:"-"`
+}
+
+// Services contains the services needed for this function.
+type Services struct {
+	Approval *services.Approval
+	Logger   *services.Logger
+}
+
+// ReadValues reads the approval request to approve from the request body.
+func ReadValues(b []byte) (*Values, error) {
+	var values Values
+	if err := json.Unmarshal(b, &values); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal request")
+	}
+	if !requestID.MatchString(values.ID) {
+		return nil, fmt.Errorf("invalid request id %q", values.ID)
+	}
+	return &values, nil
+}
+
+// Execute records the approval of the request by the authenticated approver. Once the request has
+// enough approvals its automation is run again and finds it approved.
+func Execute(ctx context.Context, values *Values, svcs *Services) (bool, error) {
+	approved, err := svcs.Approval.Approve(ctx, values.ID, values.Approver)
+	if err != nil {
+		return false, err
+	}
+	message := fmt.Sprintf("approved by %q", values.Approver)
+	if approved {
+		message += ", automation resumed"
+	}
+	svcs.Logger.Audit(&services.AuditRecord{
+		Action:   action,
+		Resource: values.ID,
+		Result:   services.AuditResultSuccess,
+		Message:  message,
+	})
+	svcs.Logger.Info("approval request %q %s", values.ID, message)
+	return approved, nil
+}
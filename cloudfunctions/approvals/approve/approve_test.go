@@ -0,0 +1,77 @@
+package approve
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+	"github.com/googlecloudplatform/security-response-automation/services"
+)
+
+func TestReadValues(t *testing.T) {
+	id := services.ApprovalID("iam_revoke_org", "organizations/123", []string{"user:tom@gmail.com"})
+	for _, tt := range []struct {
+		name          string
+		body          string
+		expectedError bool
+	}{
+		{name: "request", body: `{"id": "` + id + `"}`},
+		{name: "approver is never read", body: `{"id": "` + id + `", "Approver": "alice@foo.com"}`},
+		{name: "invalid id", body: `{"id": "../` + id + `"}`, expectedError: true},
+		{name: "no id", body: `{}`, expectedError: true},
+		{name: "malformed", body: `{`, expectedError: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := ReadValues([]byte(tt.body))
+			if (err != nil) != tt.expectedError {
+				t.Fatalf("%s failed: got error %v, want error %t", tt.name, err, tt.expectedError)
+			}
+			if err == nil && values.Approver != "" {
+				t.Errorf("%s failed: approver %q read from the request", tt.name, values.Approver)
+			}
+		})
+	}
+}
+
+func TestApprove(t *testing.T) {
+	ctx := context.Background()
+	id := services.ApprovalID("remove_load_balancer", "projects/p/zones/z/instances/i", []string{"forwardingRules/web"})
+	psStub := &stubs.PubSubStub{}
+	loggerStub := &stubs.LoggerStub{}
+	approval := services.NewApproval(services.NewPubSub(psStub), services.NewState(&stubs.StorageStub{}, "state"), "approvals", []string{"alice@foo.com"})
+	if err := approval.Request(ctx, &services.ApprovalRequest{ID: id, Required: 1, Topic: "threat-findings-remove-load-balancer", Values: []byte("{}")}); err != nil {
+		t.Fatalf("failed to request approval: %q", err)
+	}
+	svcs := &Services{Approval: approval, Logger: services.NewLogger(loggerStub)}
+	if _, err := Execute(ctx, &Values{ID: id, Approver: "mallory@gmail.com"}, svcs); err == nil {
+		t.Errorf("approval by a non-approver accepted")
+	}
+	approved, err := Execute(ctx, &Values{ID: id, Approver: "alice@foo.com"}, svcs)
+	if err != nil {
+		t.Fatalf("failed to approve: %q", err)
+	}
+	if !approved {
+		t.Errorf("request not approved")
+	}
+	if len(loggerStub.AuditRecords) != 1 {
+		t.Fatalf("got %d audit records, want 1", len(loggerStub.AuditRecords))
+	}
+	if r := loggerStub.AuditRecords[0].(*services.AuditRecord); r.Resource != id || !strings.Contains(r.Message, "automation resumed") {
+		t.Errorf("unexpected audit record: %+v", r)
+	}
+}
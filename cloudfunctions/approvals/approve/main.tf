@@ -0,0 +1,48 @@
+# Copyright 2020 Google LLC
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+# 	https://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+locals {
+  # ID tokens of approvers must be issued for the function's URL.
+  approve-url = "https://${var.setup.region}-${var.setup.automation-project}.cloudfunctions.net/Approve"
+}
+
+resource "google_cloudfunctions_function" "approve" {
+  name                  = "Approve"
+  description           = "Records approvals of automations waiting to be approved."
+  runtime               = "go121"
+  available_memory_mb   = 128
+  source_archive_bucket = var.setup.gcf-bucket-name
+  source_archive_object = var.setup.gcf-object-name
+  timeout               = 60
+  project               = var.setup.automation-project
+  region                = var.setup.region
+  entry_point           = "Approve"
+  trigger_http          = true
+
+  environment_variables = {
+    APPROVAL_TOPIC    = var.setup.approval-topic
+    APPROVAL_AUDIENCE = local.approve-url
+    APPROVERS         = join(",", var.approvers)
+  }
+}
+
+# Only approvers may call the function, their ID token is verified again by the function.
+resource "google_cloudfunctions_function_iam_member" "approvers-invoker" {
+  count = length(var.approvers)
+
+  project        = var.setup.automation-project
+  region         = var.setup.region
+  cloud_function = google_cloudfunctions_function.approve.name
+  role           = "roles/cloudfunctions.invoker"
+  member         = "user:${var.approvers[count.index]}"
+}
@@ -0,0 +1,6 @@
+variable "setup" {}
+
+variable "approvers" {
+  type        = list(string)
+  description = "Emails of the people allowed to approve automations."
+}
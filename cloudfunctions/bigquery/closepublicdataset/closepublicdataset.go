@@ -35,14 +35,20 @@ type Services struct {
 }
 
 // Execute removes public access of a BigQuery dataset.
-func Execute(ctx context.Context, values *Values, services *Services) error {
+func Execute(ctx context.Context, values *Values, svcs *Services) (*services.Result, error) {
+	result := services.NewResult("close_public_dataset", values.DryRun)
+	public, err := svcs.BigQuery.DatasetIsPublic(ctx, values.ProjectID, values.DatasetID)
+	if err != nil {
+		return nil, err
+	}
+	if !public {
+		return result.Skip(values.DatasetID, "bigquery dataset %q in project %q is not public", values.DatasetID, values.ProjectID), nil
+	}
 	if values.DryRun {
-		services.Logger.Info("dry_run on, would have removed public access on bigquery dataset %q in project %q", values.DatasetID, values.ProjectID)
-		return nil
+		return result.Touch(values.DatasetID), nil
 	}
-	if err := services.BigQuery.RemoveDatasetPublicAccess(ctx, values.ProjectID, values.DatasetID); err != nil {
-		return errors.Wrapf(err, "error removing bigquery dataset %q public access in project %q", values.DatasetID, values.ProjectID)
+	if err := svcs.BigQuery.RemoveDatasetPublicAccess(ctx, values.ProjectID, values.DatasetID); err != nil {
+		return nil, errors.Wrapf(err, "error removing bigquery dataset %q public access in project %q", values.DatasetID, values.ProjectID)
 	}
-	services.Logger.Info("removed public access on bigquery dataset %q in project %q", values.DatasetID, values.ProjectID)
-	return nil
+	return result.Touch(values.DatasetID), nil
 }
@@ -62,7 +62,7 @@ func TestClosePublicDataset(t *testing.T) {
 				DatasetID: "dataset-id",
 			}
 			bq := services.NewBigQuery(bigqueryStub)
-			if err := Execute(ctx, values, &Services{
+			if _, err := Execute(ctx, values, &Services{
 				BigQuery: bq,
 				Logger:   svcs.Logger,
 			}); err != nil {
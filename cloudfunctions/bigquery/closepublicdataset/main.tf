@@ -14,7 +14,7 @@
 resource "google_cloudfunctions_function" "close-public-dataset" {
   name                  = "ClosePublicDataset"
   description           = "Removes public access of a BigQuery dataset."
-  runtime               = "go111"
+  runtime               = "go121"
   available_memory_mb   = 128
   source_archive_bucket = var.setup.gcf-bucket-name
   source_archive_object = var.setup.gcf-object-name
@@ -26,6 +26,10 @@ resource "google_cloudfunctions_function" "close-public-dataset" {
   event_trigger {
     event_type = "providers/cloud.pubsub/eventTypes/topic.publish"
     resource   = "threat-findings-close-public-dataset"
+
+    failure_policy {
+      retry = true
+    }
   }
 }
 
@@ -0,0 +1,102 @@
+// Package enablebackups enables automated backups and point in time recovery on Cloud SQL instances.
+package enablebackups
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/googlecloudplatform/security-response-automation/services"
+	sqladmin "google.golang.org/api/sqladmin/v1beta4"
+)
+
+// action is the automation name recorded in audit records.
+const action = "cloud_sql_enable_backups"
+
+// Values contains the required values needed for this function.
+type Values struct {
+	ProjectID, InstanceName string
+	DryRun                  bool
+}
+
+// Services contains the services needed for this function.
+type Services struct {
+	CloudSQL *services.CloudSQL
+	Resource *services.Resource
+	Logger   *services.Logger
+	// State is optional. When set the change is started without waiting for it to complete and its
+	// operation is recorded so the poll operations function can audit it once done.
+	State *services.State
+}
+
+// Execute enables automated backups on the Cloud SQL instance. MySQL instances also have binary
+// logging enabled so they can be restored to any point in time. No result is returned when the
+// operation is left for the poll operations function to audit.
+func Execute(ctx context.Context, values *Values, svcs *Services) (*services.Result, error) {
+	result := services.NewResult(action, values.DryRun)
+	instance, err := svcs.CloudSQL.InstanceDetails(ctx, values.ProjectID, values.InstanceName)
+	if err != nil {
+		return nil, err
+	}
+	binaryLog := strings.HasPrefix(instance.DatabaseVersion, "MYSQL")
+	if backupsEnabled(instance, binaryLog) {
+		return result.Skip(values.InstanceName, "backups already enabled on sql instance %q in project %q", values.InstanceName, values.ProjectID), nil
+	}
+	result.Message = fmt.Sprintf("backups enabled with binary logging %t in project %q", binaryLog, values.ProjectID)
+	if values.DryRun {
+		return result.Touch(values.InstanceName), nil
+	}
+	if svcs.State != nil {
+		return nil, start(ctx, values, svcs, binaryLog)
+	}
+	if err := svcs.CloudSQL.EnableBackups(ctx, values.ProjectID, values.InstanceName, binaryLog); err != nil {
+		return nil, err
+	}
+	return result.Touch(values.InstanceName), nil
+}
+
+// start enables backups without waiting for the patch to complete. Patching a large instance can
+// outlast the function's timeout which would have the message redelivered and the patch started
+// again, so a patch already in progress is left to complete.
+func start(ctx context.Context, values *Values, svcs *Services, binaryLog bool) error {
+	pending, err := svcs.State.PendingOperation(ctx, action, values.ProjectID, values.InstanceName)
+	if err != nil {
+		return err
+	}
+	if pending != nil {
+		svcs.Logger.Info("enabling backups on sql instance %q in project %q already in progress with operation %q", values.InstanceName, values.ProjectID, pending.Operation)
+		return nil
+	}
+	op, err := svcs.CloudSQL.StartEnableBackups(ctx, values.ProjectID, values.InstanceName, binaryLog)
+	if err != nil {
+		return err
+	}
+	message := fmt.Sprintf("backups enabled with binary logging %t in project %q", binaryLog, values.ProjectID)
+	if _, err := svcs.State.RecordOperation(ctx, action, values.ProjectID, values.InstanceName, services.OperationCloudSQL, op, message); err != nil {
+		return err
+	}
+	svcs.Logger.Info("started enabling backups on sql instance %q in project %q with operation %q.", values.InstanceName, values.ProjectID, op)
+	return nil
+}
+
+func backupsEnabled(instance *sqladmin.DatabaseInstance, binaryLog bool) bool {
+	if instance.Settings == nil || instance.Settings.BackupConfiguration == nil {
+		return false
+	}
+	c := instance.Settings.BackupConfiguration
+	return c.Enabled && (c.BinaryLogEnabled || !binaryLog)
+}
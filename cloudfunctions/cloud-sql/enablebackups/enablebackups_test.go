@@ -0,0 +1,113 @@
+package enablebackups
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+	"github.com/googlecloudplatform/security-response-automation/services"
+	sqladmin "google.golang.org/api/sqladmin/v1beta4"
+)
+
+func TestEnableBackups(t *testing.T) {
+	ctx := context.Background()
+	test := []struct {
+		name            string
+		instance        *sqladmin.DatabaseInstance
+		dryRun          bool
+		expectedRequest *sqladmin.DatabaseInstance
+		expectedResult  string
+	}{
+		{
+			name:     "enable backups and binary logging on mysql",
+			instance: &sqladmin.DatabaseInstance{DatabaseVersion: "MYSQL_5_7", Settings: &sqladmin.Settings{}},
+			expectedRequest: &sqladmin.DatabaseInstance{
+				Name:    "no-backups",
+				Project: "sha-resources-20191002",
+				Settings: &sqladmin.Settings{
+					BackupConfiguration: &sqladmin.BackupConfiguration{Enabled: true, BinaryLogEnabled: true},
+				},
+			},
+			expectedResult: services.AuditResultSuccess,
+		},
+		{
+			name:     "enable backups on postgres",
+			instance: &sqladmin.DatabaseInstance{DatabaseVersion: "POSTGRES_11", Settings: &sqladmin.Settings{}},
+			expectedRequest: &sqladmin.DatabaseInstance{
+				Name:    "no-backups",
+				Project: "sha-resources-20191002",
+				Settings: &sqladmin.Settings{
+					BackupConfiguration: &sqladmin.BackupConfiguration{Enabled: true},
+				},
+			},
+			expectedResult: services.AuditResultSuccess,
+		},
+		{
+			name: "binary logging disabled on mysql",
+			instance: &sqladmin.DatabaseInstance{DatabaseVersion: "MYSQL_5_7", Settings: &sqladmin.Settings{
+				BackupConfiguration: &sqladmin.BackupConfiguration{Enabled: true},
+			}},
+			expectedRequest: &sqladmin.DatabaseInstance{
+				Name:    "no-backups",
+				Project: "sha-resources-20191002",
+				Settings: &sqladmin.Settings{
+					BackupConfiguration: &sqladmin.BackupConfiguration{Enabled: true, BinaryLogEnabled: true},
+				},
+			},
+			expectedResult: services.AuditResultSuccess,
+		},
+		{
+			name: "backups already enabled",
+			instance: &sqladmin.DatabaseInstance{DatabaseVersion: "MYSQL_5_7", Settings: &sqladmin.Settings{
+				BackupConfiguration: &sqladmin.BackupConfiguration{Enabled: true, BinaryLogEnabled: true},
+			}},
+			expectedResult: services.AuditResultAlreadyRemediated,
+		},
+		{
+			name:           "dry run",
+			instance:       &sqladmin.DatabaseInstance{DatabaseVersion: "MYSQL_5_7", Settings: &sqladmin.Settings{}},
+			dryRun:         true,
+			expectedResult: services.AuditResultDryRun,
+		},
+	}
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			loggerStub := &stubs.LoggerStub{}
+			sqlStub := &stubs.CloudSQL{InstanceDetailsResponse: tt.instance}
+			values := &Values{
+				ProjectID:    "sha-resources-20191002",
+				InstanceName: "no-backups",
+				DryRun:       tt.dryRun,
+			}
+			r, err := Execute(ctx, values, &Services{
+				CloudSQL: services.NewCloudSQL(sqlStub),
+				Resource: services.NewResource(&stubs.ResourceManagerStub{}, &stubs.StorageStub{}),
+				Logger:   services.NewLogger(loggerStub),
+			})
+			if err != nil {
+				t.Fatalf("%s failed: %q", tt.name, err)
+			}
+			if diff := cmp.Diff(tt.expectedRequest, sqlStub.SavedInstanceUpdated); diff != "" {
+				t.Errorf("%s failed (-want +got):\n%s", tt.name, diff)
+			}
+			if got := r.Status(); got != tt.expectedResult {
+				t.Errorf("%s failed: got result %q want %q", tt.name, got, tt.expectedResult)
+			}
+		})
+	}
+}
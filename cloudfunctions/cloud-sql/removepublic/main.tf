@@ -14,7 +14,7 @@
 resource "google_cloudfunctions_function" "close-cloud-sql" {
   name                  = "CloseCloudSQL"
   description           = "Removes public IPs from a Cloud SQL instance."
-  runtime               = "go111"
+  runtime               = "go121"
   available_memory_mb   = 128
   source_archive_bucket = var.setup.gcf-bucket-name
   source_archive_object = var.setup.gcf-object-name
@@ -26,6 +26,10 @@ resource "google_cloudfunctions_function" "close-cloud-sql" {
   event_trigger {
     event_type = "providers/cloud.pubsub/eventTypes/topic.publish"
     resource   = "threat-findings-remove-public-sql"
+
+    failure_policy {
+      retry = true
+    }
   }
 }
 
@@ -35,25 +35,23 @@ type Services struct {
 }
 
 // Execute will remove any public IPs in SQL instance found within the provided resources.
-func Execute(ctx context.Context, values *Values, services *Services) error {
+func Execute(ctx context.Context, values *Values, svcs *Services) (*services.Result, error) {
+	result := services.NewResult("close_cloud_sql", values.DryRun)
 	log.Printf("getting details from Cloud SQL instance %q in project %q.", values.InstanceName, values.ProjectID)
-	instance, err := services.CloudSQL.InstanceDetails(ctx, values.ProjectID, values.InstanceName)
+	instance, err := svcs.CloudSQL.InstanceDetails(ctx, values.ProjectID, values.InstanceName)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	acls := instance.Settings.IpConfiguration.AuthorizedNetworks
-	if !services.CloudSQL.IsPublic(acls) {
-		services.Logger.Info("instance %q does not have public access enabled", values.InstanceName)
-		return nil
+	if !svcs.CloudSQL.IsPublic(acls) {
+		return result.Skip(values.InstanceName, "instance %q in project %q does not have public access enabled", values.InstanceName, values.ProjectID), nil
 	}
 	if values.DryRun {
-		services.Logger.Info("dry_run on, would have removed public access from Cloud SQL instance %q in project %q.", values.InstanceName, values.ProjectID)
-		return nil
+		return result.Touch(values.InstanceName), nil
 	}
-	if err := services.CloudSQL.ClosePublicAccess(ctx, values.ProjectID, values.InstanceName, acls); err != nil {
-		return err
+	if err := svcs.CloudSQL.ClosePublicAccess(ctx, values.ProjectID, values.InstanceName, acls); err != nil {
+		return nil, err
 	}
-	services.Logger.Info("removed public access from Cloud SQL instance %q in project %q.", values.InstanceName, values.ProjectID)
-	return nil
+	return result.Touch(values.InstanceName), nil
 }
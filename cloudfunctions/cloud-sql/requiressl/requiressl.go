@@ -16,6 +16,7 @@ package requiressl
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/googlecloudplatform/security-response-automation/services"
 )
@@ -31,17 +32,56 @@ type Services struct {
 	CloudSQL *services.CloudSQL
 	Resource *services.Resource
 	Logger   *services.Logger
+	// State is optional. When set the change is started without waiting for it to complete and its
+	// operation is recorded so the poll operations function can audit it once done.
+	State *services.State
 }
 
-// Execute will remove any public ips in sql instance found within the provided folders.
-func Execute(ctx context.Context, values *Values, services *Services) error {
+// action is the automation name recorded with started operations.
+const action = "cloud_sql_require_ssl"
+
+// Execute will require SSL on the sql instance. No result is returned when the operation is left for
+// the poll operations function.
+func Execute(ctx context.Context, values *Values, svcs *Services) (*services.Result, error) {
+	result := services.NewResult(action, values.DryRun)
+	instance, err := svcs.CloudSQL.InstanceDetails(ctx, values.ProjectID, values.InstanceName)
+	if err != nil {
+		return nil, err
+	}
+	if instance.Settings != nil && instance.Settings.IpConfiguration != nil && instance.Settings.IpConfiguration.RequireSsl {
+		return result.Skip(values.InstanceName, "ssl already enforced on sql instance %q in project %q", values.InstanceName, values.ProjectID), nil
+	}
 	if values.DryRun {
-		services.Logger.Info("dry_run on, enforced ssl on sql instance %q in project %q.", values.InstanceName, values.ProjectID)
+		return result.Touch(values.InstanceName), nil
+	}
+	if svcs.State != nil {
+		return nil, start(ctx, values, svcs)
+	}
+	if err := svcs.CloudSQL.RequireSSL(ctx, values.ProjectID, values.InstanceName); err != nil {
+		return nil, err
+	}
+	return result.Touch(values.InstanceName), nil
+}
+
+// start requires SSL without waiting for the patch to complete. A patch already in progress from an
+// earlier delivery of the same finding is left to complete.
+func start(ctx context.Context, values *Values, svcs *Services) error {
+	pending, err := svcs.State.PendingOperation(ctx, action, values.ProjectID, values.InstanceName)
+	if err != nil {
+		return err
+	}
+	if pending != nil {
+		svcs.Logger.Info("enforcing ssl on sql instance %q in project %q already in progress with operation %q", values.InstanceName, values.ProjectID, pending.Operation)
 		return nil
 	}
-	if err := services.CloudSQL.RequireSSL(ctx, values.ProjectID, values.InstanceName); err != nil {
+	op, err := svcs.CloudSQL.StartRequireSSL(ctx, values.ProjectID, values.InstanceName)
+	if err != nil {
+		return err
+	}
+	message := fmt.Sprintf("ssl enforced in project %q", values.ProjectID)
+	if _, err := svcs.State.RecordOperation(ctx, action, values.ProjectID, values.InstanceName, services.OperationCloudSQL, op, message); err != nil {
 		return err
 	}
-	services.Logger.Info("enforced ssl on sql instance %q in project %q.", values.InstanceName, values.ProjectID)
+	svcs.Logger.Info("started enforcing ssl on sql instance %q in project %q with operation %q.", values.InstanceName, values.ProjectID, op)
 	return nil
 }
@@ -29,10 +29,12 @@ func TestCloudSQLRequireSSL(t *testing.T) {
 	ctx := context.Background()
 	test := []struct {
 		name            string
+		instance        *sqladmin.DatabaseInstance
 		expectedRequest *sqladmin.DatabaseInstance
 	}{
 		{
-			name: "enforce ssl on sql instance",
+			name:     "enforce ssl on sql instance",
+			instance: &sqladmin.DatabaseInstance{Settings: &sqladmin.Settings{IpConfiguration: &sqladmin.IpConfiguration{}}},
 			expectedRequest: &sqladmin.DatabaseInstance{
 				Name:    "public-sql-instance",
 				Project: "sha-resources-20191002",
@@ -43,15 +45,21 @@ func TestCloudSQLRequireSSL(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:            "ssl already enforced",
+			instance:        &sqladmin.DatabaseInstance{Settings: &sqladmin.Settings{IpConfiguration: &sqladmin.IpConfiguration{RequireSsl: true}}},
+			expectedRequest: nil,
+		},
 	}
 	for _, tt := range test {
 		t.Run(tt.name, func(t *testing.T) {
 			svcs, sqlStub := cloudSQLRequireSSL()
+			sqlStub.InstanceDetailsResponse = tt.instance
 			values := &Values{
 				ProjectID:    "sha-resources-20191002",
 				InstanceName: "public-sql-instance",
 			}
-			if err := Execute(ctx, values, &Services{
+			if _, err := Execute(ctx, values, &Services{
 				CloudSQL: svcs.CloudSQL,
 				Resource: svcs.Resource,
 				Logger:   svcs.Logger,
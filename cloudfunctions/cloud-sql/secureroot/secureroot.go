@@ -0,0 +1,177 @@
+// Package secureroot secures Cloud SQL root users that have no password.
+package secureroot
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"cloud.google.com/go/pubsub"
+	"github.com/googlecloudplatform/security-response-automation/services"
+	"github.com/pkg/errors"
+)
+
+const (
+	// action is the automation name recorded in audit records and owner notifications.
+	action = "cloud_sql_secure_root"
+	// ModeRotate sets a generated password on the root users and stores it in Secret Manager.
+	ModeRotate = "rotate"
+	// ModeDeleteWildcard deletes root users that may connect from any host.
+	ModeDeleteWildcard = "delete_wildcard"
+	// rootUser is the MySQL user name secured by this automation.
+	rootUser = "root"
+	// hostWildcard matches any MySQL host. Reference: https://cloud.google.com/sql/docs/mysql/users.
+	hostWildcard = "%"
+)
+
+// Values contains the required values needed for this function.
+type Values struct {
+	ProjectID, InstanceName string
+	// Mode is either "rotate" or "delete_wildcard".
+	Mode string
+	// NotificationTopic is the Pub/Sub topic the project owners are notified on, if set.
+	NotificationTopic string
+	DryRun            bool
+}
+
+// Services contains the services needed for this function.
+type Services struct {
+	CloudSQL      *services.CloudSQL
+	SecretManager *services.SecretManager
+	PubSub        *services.PubSub
+	Resource      *services.Resource
+	Logger        *services.Logger
+}
+
+// Notification is published to the notification topic after the root users are secured.
+type Notification struct {
+	Action    string   `json:"action"`
+	ProjectID string   `json:"project_id"`
+	Instance  string   `json:"instance"`
+	Mode      string   `json:"mode"`
+	Users     []string `json:"users"`
+	Owners    []string `json:"owners"`
+	// Secret is the Secret Manager secret holding the new password when rotating.
+	Secret string `json:"secret,omitempty"`
+}
+
+// Execute secures the root users of the MySQL instance.
+//
+// When rotating, a strong password is generated and saved as a new version of the instance's secret
+// before it is set on the root users so the password is never lost nor sent through Pub/Sub. When
+// deleting, only root users that accept connections from any host are removed. The project owners
+// are notified of the change if a notification topic is configured.
+func Execute(ctx context.Context, values *Values, svcs *Services) (*services.Result, error) {
+	result := services.NewResult(action, values.DryRun)
+	if values.Mode != ModeRotate && values.Mode != ModeDeleteWildcard {
+		return nil, fmt.Errorf("unknown mode %q", values.Mode)
+	}
+	users, err := svcs.CloudSQL.ListUsers(ctx, values.ProjectID, values.InstanceName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list users of instance %q", values.InstanceName)
+	}
+	var hosts []string
+	for _, u := range users {
+		if u.Name != rootUser {
+			continue
+		}
+		if values.Mode == ModeDeleteWildcard && u.Host != hostWildcard {
+			continue
+		}
+		hosts = append(hosts, u.Host)
+	}
+	if len(hosts) == 0 {
+		return result.Skip(values.InstanceName, "no root users to secure on sql instance %q in project %q", values.InstanceName, values.ProjectID), nil
+	}
+	secured := make([]string, 0, len(hosts))
+	for _, h := range hosts {
+		secured = append(secured, rootUser+"@"+h)
+	}
+	result.Message = fmt.Sprintf("secured root users %v with mode %q in project %q", secured, values.Mode, values.ProjectID)
+	if values.DryRun {
+		return result.Touch(values.InstanceName), nil
+	}
+	n := &Notification{
+		Action:    action,
+		ProjectID: values.ProjectID,
+		Instance:  values.InstanceName,
+		Mode:      values.Mode,
+		Users:     secured,
+	}
+	switch values.Mode {
+	case ModeRotate:
+		n.Secret, err = rotate(ctx, svcs.CloudSQL, svcs.SecretManager, values, hosts)
+	case ModeDeleteWildcard:
+		err = deleteUsers(ctx, svcs.CloudSQL, values, hosts)
+	}
+	if err != nil {
+		return nil, err
+	}
+	result.Touch(values.InstanceName)
+	if values.NotificationTopic == "" {
+		return result, nil
+	}
+	if n.Owners, err = svcs.Resource.ProjectOwners(ctx, values.ProjectID); err != nil {
+		return nil, err
+	}
+	if err := notifyOwners(ctx, svcs.PubSub, values.NotificationTopic, n); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// SecretID returns the ID of the secret holding the root password of the instance.
+func SecretID(instance string) string {
+	return "cloudsql-" + instance + "-root"
+}
+
+func rotate(ctx context.Context, sql *services.CloudSQL, sm *services.SecretManager, values *Values, hosts []string) (string, error) {
+	password, err := services.GeneratePassword()
+	if err != nil {
+		return "", err
+	}
+	secretID := SecretID(values.InstanceName)
+	if err := sm.StoreSecret(ctx, values.ProjectID, secretID, []byte(password)); err != nil {
+		return "", err
+	}
+	for _, h := range hosts {
+		if err := sql.UpdateUserPassword(ctx, values.ProjectID, values.InstanceName, h, rootUser, password); err != nil {
+			return "", errors.Wrapf(err, "failed to update password of %s@%s", rootUser, h)
+		}
+	}
+	return fmt.Sprintf("projects/%s/secrets/%s", values.ProjectID, secretID), nil
+}
+
+func deleteUsers(ctx context.Context, sql *services.CloudSQL, values *Values, hosts []string) error {
+	for _, h := range hosts {
+		if err := sql.DeleteUser(ctx, values.ProjectID, values.InstanceName, h, rootUser); err != nil {
+			return errors.Wrapf(err, "failed to delete %s@%s", rootUser, h)
+		}
+	}
+	return nil
+}
+
+func notifyOwners(ctx context.Context, ps *services.PubSub, topic string, n *Notification) error {
+	b, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+	if _, err := ps.Publish(ctx, topic, &pubsub.Message{Data: b}); err != nil {
+		return errors.Wrap(err, "failed to notify project owners")
+	}
+	return nil
+}
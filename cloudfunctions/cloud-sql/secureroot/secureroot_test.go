@@ -0,0 +1,171 @@
+package secureroot
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+	"github.com/googlecloudplatform/security-response-automation/services"
+	crm "google.golang.org/api/cloudresourcemanager/v1"
+	sqladmin "google.golang.org/api/sqladmin/v1beta4"
+)
+
+func TestSecureRoot(t *testing.T) {
+	ctx := context.Background()
+	const (
+		projectID = "threat-auto-tests-07102019"
+		instance  = "test-no-password"
+	)
+	users := []*sqladmin.User{
+		{Name: "root", Host: "%"},
+		{Name: "root", Host: "localhost"},
+		{Name: "app", Host: "%"},
+	}
+	test := []struct {
+		name             string
+		mode             string
+		users            []*sqladmin.User
+		existingSecret   bool
+		expectedHost     string
+		expectedDeleted  []string
+		expectedCreated  []string
+		expectedNotified *Notification
+	}{
+		{
+			name:            "rotate root password",
+			mode:            ModeRotate,
+			users:           users,
+			expectedHost:    "localhost",
+			expectedCreated: []string{"cloudsql-test-no-password-root"},
+			expectedNotified: &Notification{
+				Action:    action,
+				ProjectID: projectID,
+				Instance:  instance,
+				Mode:      ModeRotate,
+				Users:     []string{"root@%", "root@localhost"},
+				Owners:    []string{"owner@google.com"},
+				Secret:    "projects/threat-auto-tests-07102019/secrets/cloudsql-test-no-password-root",
+			},
+		},
+		{
+			name:           "rotate with existing secret",
+			mode:           ModeRotate,
+			users:          users[:1],
+			existingSecret: true,
+			expectedHost:   "%",
+			expectedNotified: &Notification{
+				Action:    action,
+				ProjectID: projectID,
+				Instance:  instance,
+				Mode:      ModeRotate,
+				Users:     []string{"root@%"},
+				Owners:    []string{"owner@google.com"},
+				Secret:    "projects/threat-auto-tests-07102019/secrets/cloudsql-test-no-password-root",
+			},
+		},
+		{
+			name:            "delete wildcard root",
+			mode:            ModeDeleteWildcard,
+			users:           users,
+			expectedDeleted: []string{"root@%"},
+			expectedNotified: &Notification{
+				Action:    action,
+				ProjectID: projectID,
+				Instance:  instance,
+				Mode:      ModeDeleteWildcard,
+				Users:     []string{"root@%"},
+				Owners:    []string{"owner@google.com"},
+			},
+		},
+		{
+			name:  "no wildcard root to delete",
+			mode:  ModeDeleteWildcard,
+			users: users[1:],
+		},
+	}
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlStub := &stubs.CloudSQL{ListUsersResponse: &sqladmin.UsersListResponse{Items: tt.users}}
+			smStub := &stubs.SecretManagerStub{ExistingSecrets: map[string]bool{SecretID(instance): tt.existingSecret}}
+			psStub := &stubs.PubSubStub{}
+			crmStub := &stubs.ResourceManagerStub{
+				GetPolicyResponse: &crm.Policy{Bindings: []*crm.Binding{
+					{Role: "roles/owner", Members: []string{"user:owner@google.com", "serviceAccount:sa@google.com"}},
+					{Role: "roles/editor", Members: []string{"user:editor@google.com"}},
+				}},
+			}
+			values := &Values{
+				ProjectID:         projectID,
+				InstanceName:      instance,
+				Mode:              tt.mode,
+				NotificationTopic: "notifications",
+			}
+			if _, err := Execute(ctx, values, &Services{
+				CloudSQL:      services.NewCloudSQL(sqlStub),
+				SecretManager: services.NewSecretManager(smStub),
+				PubSub:        services.NewPubSub(psStub),
+				Resource:      services.NewResource(crmStub, &stubs.StorageStub{}),
+				Logger:        services.NewLogger(&stubs.LoggerStub{}),
+			}); err != nil {
+				t.Fatalf("%s failed: %q", tt.name, err)
+			}
+			if diff := cmp.Diff(tt.expectedDeleted, sqlStub.DeletedUsers); diff != "" {
+				t.Errorf("%s failed, deleted users differ (-want +got):\n%s", tt.name, diff)
+			}
+			if diff := cmp.Diff(tt.expectedCreated, smStub.CreatedSecrets); diff != "" {
+				t.Errorf("%s failed, created secrets differ (-want +got):\n%s", tt.name, diff)
+			}
+			if tt.mode == ModeRotate {
+				saved := string(smStub.SavedVersions[SecretID(instance)])
+				if saved == "" || sqlStub.UpdatedUser == nil || sqlStub.UpdatedUser.Password != saved {
+					t.Errorf("%s failed, stored password does not match the password set", tt.name)
+				}
+				if sqlStub.UpdatedUserHost != tt.expectedHost {
+					t.Errorf("%s failed, got host %q want %q", tt.name, sqlStub.UpdatedUserHost, tt.expectedHost)
+				}
+			}
+			var got *Notification
+			if psStub.PublishedMessage != nil {
+				if err := json.Unmarshal(psStub.PublishedMessage.Data, &got); err != nil {
+					t.Fatalf("%s failed to unmarshal notification: %q", tt.name, err)
+				}
+			}
+			if diff := cmp.Diff(tt.expectedNotified, got); diff != "" {
+				t.Errorf("%s failed, notification differs (-want +got):\n%s", tt.name, diff)
+			}
+		})
+	}
+}
+
+func TestSecureRootDryRun(t *testing.T) {
+	ctx := context.Background()
+	sqlStub := &stubs.CloudSQL{ListUsersResponse: &sqladmin.UsersListResponse{Items: []*sqladmin.User{{Name: "root", Host: "%"}}}}
+	smStub := &stubs.SecretManagerStub{}
+	values := &Values{ProjectID: "p", InstanceName: "i", Mode: ModeRotate, DryRun: true}
+	if _, err := Execute(ctx, values, &Services{
+		CloudSQL:      services.NewCloudSQL(sqlStub),
+		SecretManager: services.NewSecretManager(smStub),
+		Logger:        services.NewLogger(&stubs.LoggerStub{}),
+	}); err != nil {
+		t.Fatalf("dry run failed: %q", err)
+	}
+	if sqlStub.UpdatedUser != nil || len(smStub.SavedVersions) != 0 {
+		t.Errorf("dry run changed the instance or stored a secret")
+	}
+}
@@ -35,15 +35,14 @@ type Services struct {
 }
 
 // Execute will update the root password for the MySQL instance found within the provided resources.
-func Execute(ctx context.Context, values *Values, services *Services) error {
+func Execute(ctx context.Context, values *Values, svcs *Services) (*services.Result, error) {
+	result := services.NewResult("cloud_sql_update_password", values.DryRun)
 	log.Printf("updating root password for MySQL instance %q in project %q.", values.InstanceName, values.ProjectID)
 	if values.DryRun {
-		services.Logger.Info("dry_run on, would have updated root password for MySQL instance %q in project %q.", values.InstanceName, values.ProjectID)
-		return nil
+		return result.Touch(values.InstanceName), nil
 	}
-	if err := services.CloudSQL.UpdateUserPassword(ctx, values.ProjectID, values.InstanceName, values.Host, values.UserName, values.Password); err != nil {
-		return err
+	if err := svcs.CloudSQL.UpdateUserPassword(ctx, values.ProjectID, values.InstanceName, values.Host, values.UserName, values.Password); err != nil {
+		return nil, err
 	}
-	services.Logger.Info("updated root password for MySQL instance %q in project %q.", values.InstanceName, values.ProjectID)
-	return nil
+	return result.Touch(values.InstanceName), nil
 }
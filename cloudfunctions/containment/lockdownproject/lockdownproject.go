@@ -0,0 +1,280 @@
+// Package lockdownproject locks down a compromised project by removing its IAM bindings, disabling
+// its service accounts and stopping its instances.
+package lockdownproject
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/googlecloudplatform/security-response-automation/services"
+	"github.com/pkg/errors"
+	iam "google.golang.org/api/iam/v1"
+)
+
+const (
+	// action is the automation name used to derive approval request IDs.
+	action = "lockdown_project"
+	// Topic is the Pub/Sub topic that triggers this automation.
+	Topic = "threat-findings-lockdown-project"
+	// requiredApprovals is the number of distinct approvers needed before locking down a project,
+	// as many as revoke_org_members requires for an organization.
+	requiredApprovals = 2
+)
+
+// Values contains the required values needed for this function.
+type Values struct {
+	ProjectID string
+	// BreakGlassGroup is the email of the group whose bindings are kept.
+	BreakGlassGroup string
+	// AllowMembers are members whose bindings are also kept, such as "user:admin@foo.com".
+	AllowMembers []string
+	DryRun       bool
+}
+
+// Services contains the services needed for this function.
+type Services struct {
+	Resource        *services.Resource
+	ServiceAccounts *services.ServiceAccounts
+	Host            *services.Host
+	State           *services.State
+	Approval        *services.Approval
+	Logger          *services.Logger
+}
+
+// plan holds the changes made to lock down a project.
+type plan struct {
+	bindings []services.BindingChange
+	// serviceAccounts are all of the project's service accounts and accounts the emails of those
+	// that are enabled.
+	serviceAccounts []*iam.ServiceAccount
+	accounts        []string
+	instances       []instance
+}
+
+type instance struct {
+	zone string
+	name string
+}
+
+func (i instance) String() string {
+	return i.zone + "/" + i.name
+}
+
+// Execute locks down a project: every binding of its IAM policy other than those of the break-glass
+// group, the allowed members and the project's Google-managed service agents is removed, its service
+// accounts are disabled and its running instances are stopped.
+//
+// This is meant for a project that's compromised beyond cleaning up a single resource so nothing is
+// changed until two approvers approve the exact changes. The bindings, service accounts, keys and
+// instances are stored in the state bucket before any change is made. Disabling a service account
+// makes all of its keys unusable while keeping them so the account can be investigated. No result
+// is returned while the lockdown awaits approval. A dry run reports the changes and the ID of the
+// approval request without requesting approval.
+func Execute(ctx context.Context, values *Values, svcs *Services) (*services.Result, error) {
+	result := services.NewResult(action, values.DryRun)
+	if values.ProjectID == "" {
+		return nil, errors.New("must provide a project to lock down")
+	}
+	if !strings.Contains(values.BreakGlassGroup, "@") {
+		return nil, fmt.Errorf("break-glass group %q must be the email of a group", values.BreakGlassGroup)
+	}
+	if svcs.State == nil {
+		return nil, errors.New("a state bucket is required to store the state of the project")
+	}
+	p, err := lockdownPlan(ctx, svcs, values)
+	if err != nil {
+		return nil, err
+	}
+	project := "projects/" + values.ProjectID
+	if len(p.bindings) == 0 && len(p.accounts) == 0 && len(p.instances) == 0 {
+		return result.Skip(project, "project %q is locked down", values.ProjectID), nil
+	}
+	changes := toChange(p)
+	id := ApprovalID(values, changes)
+	if values.DryRun {
+		result.Message = fmt.Sprintf("would lock down the project keeping the bindings of %q once approval %s is approved: %q", values.BreakGlassGroup, id, changes)
+		result.PolicyChanges = p.bindings
+		return result.Touch(project), nil
+	}
+	if err := svcs.Approval.Approved(ctx, id, requiredApprovals); err != nil {
+		svcs.Logger.Info("locking down %q requires approval: %s", values.ProjectID, err)
+		return nil, requestApproval(ctx, svcs.Approval, id, changes, values)
+	}
+	result.Message = fmt.Sprintf("project locked down, bindings of %q kept", values.BreakGlassGroup)
+	if err := recordBefore(ctx, svcs, values.ProjectID, p); err != nil {
+		return nil, err
+	}
+	removed, err := svcs.Resource.RemoveBindingsProject(ctx, values.ProjectID, p.bindings)
+	if err != nil {
+		return nil, err
+	}
+	for _, email := range p.accounts {
+		if err := svcs.ServiceAccounts.Disable(ctx, email); err != nil {
+			return nil, err
+		}
+	}
+	for _, i := range p.instances {
+		if err := svcs.Host.StopInstance(ctx, values.ProjectID, i.zone, i.name); err != nil {
+			return nil, errors.Wrapf(err, "failed to stop instance %q", i)
+		}
+	}
+	result.PolicyChanges = removed
+	return result.Touch(project), nil
+}
+
+// ApprovalID returns the approval request ID for applying changes to the project.
+func ApprovalID(values *Values, changes []string) string {
+	return services.ApprovalID(action, "projects/"+values.ProjectID, changes)
+}
+
+// lockdownPlan returns the bindings to remove, the enabled service accounts and the running
+// instances of the project.
+func lockdownPlan(ctx context.Context, svcs *Services, values *Values) (*plan, error) {
+	number, err := svcs.Resource.ProjectNumber(ctx, values.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	bindings, err := svcs.Resource.ProjectBindings(ctx, values.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	keep := map[string]bool{services.NormalizeMember(breakGlassMember(values)): true}
+	for _, m := range values.AllowMembers {
+		keep[services.NormalizeMember(m)] = true
+	}
+	p := &plan{}
+	for _, b := range bindings {
+		m := services.NormalizeMember(b.Member)
+		if keep[m] || isServiceAgent(m, number) {
+			continue
+		}
+		p.bindings = append(p.bindings, b)
+	}
+	accounts, err := svcs.ServiceAccounts.ProjectServiceAccounts(ctx, values.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	p.serviceAccounts = accounts
+	for _, a := range accounts {
+		if !a.Disabled {
+			p.accounts = append(p.accounts, a.Email)
+		}
+	}
+	instances, err := svcs.Host.RunningInstances(ctx, values.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	for _, i := range instances {
+		p.instances = append(p.instances, instance{zone: services.LocationOf(i.Zone), name: i.Name})
+	}
+	return p, nil
+}
+
+// recordBefore stores the bindings, service accounts and instances of the project before they're
+// changed. A record left by an earlier run that failed part way is added to rather than replaced
+// so the state of the project before its first change is kept.
+func recordBefore(ctx context.Context, svcs *Services, projectID string, p *plan) error {
+	record, err := svcs.State.Lockdown(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	seen := map[string]bool{}
+	for _, b := range record.Bindings {
+		seen[b.Role+" "+services.NormalizeMember(b.Member)] = true
+	}
+	for _, b := range p.bindings {
+		if !seen[b.Role+" "+services.NormalizeMember(b.Member)] {
+			record.Bindings = append(record.Bindings, b)
+		}
+	}
+	recorded := map[string]bool{}
+	for _, a := range record.ServiceAccounts {
+		recorded[a.Email] = true
+	}
+	for _, a := range p.serviceAccounts {
+		if recorded[a.Email] {
+			continue
+		}
+		keys, err := svcs.ServiceAccounts.Keys(ctx, a.Email)
+		if err != nil {
+			return err
+		}
+		record.ServiceAccounts = append(record.ServiceAccounts, services.LockedServiceAccount{Email: a.Email, WasDisabled: a.Disabled, Keys: keys})
+	}
+	stopped := map[string]bool{}
+	for _, i := range record.Instances {
+		stopped[i] = true
+	}
+	for _, i := range p.instances {
+		if !stopped[i.String()] {
+			record.Instances = append(record.Instances, i.String())
+		}
+	}
+	return svcs.State.SaveLockdown(ctx, record)
+}
+
+// isServiceAgent returns whether the normalized member is one of the Google-managed service agents
+// of the project, i.e. "serviceaccount:service-123@compute-system.iam.gserviceaccount.com" or
+// "serviceaccount:123@cloudservices.gserviceaccount.com" for project number 123. Removing their
+// roles would break the services of the project.
+func isServiceAgent(member, number string) bool {
+	if !strings.HasPrefix(member, "serviceaccount:") || !services.IsServiceAccount(member) {
+		return false
+	}
+	email := strings.TrimPrefix(member, "serviceaccount:")
+	return strings.HasPrefix(email, "service-"+number+"@") || email == number+"@cloudservices.gserviceaccount.com"
+}
+
+func breakGlassMember(values *Values) string {
+	if strings.HasPrefix(values.BreakGlassGroup, "group:") {
+		return values.BreakGlassGroup
+	}
+	return "group:" + values.BreakGlassGroup
+}
+
+// toChange describes the changes made to the project.
+func toChange(p *plan) []string {
+	changes := []string{}
+	for _, b := range p.bindings {
+		changes = append(changes, fmt.Sprintf("%s %s %s", b.Change, b.Role, b.Member))
+	}
+	for _, a := range p.accounts {
+		changes = append(changes, "disable "+a)
+	}
+	for _, i := range p.instances {
+		changes = append(changes, "stop "+i.String())
+	}
+	return changes
+}
+
+func requestApproval(ctx context.Context, approval *services.Approval, id string, changes []string, values *Values) error {
+	b, err := json.Marshal(values)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal values")
+	}
+	return approval.Request(ctx, &services.ApprovalRequest{
+		ID:       id,
+		Action:   action,
+		Resource: "projects/" + values.ProjectID,
+		Changes:  changes,
+		Required: requiredApprovals,
+		Topic:    Topic,
+		Values:   b,
+	})
+}
@@ -0,0 +1,218 @@
+package lockdownproject
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+	"github.com/googlecloudplatform/security-response-automation/services"
+	crm "google.golang.org/api/cloudresourcemanager/v1"
+	compute "google.golang.org/api/compute/v1"
+	iam "google.golang.org/api/iam/v1"
+)
+
+const (
+	appAccount = "app@test-project.iam.gserviceaccount.com"
+	appName    = "projects/-/serviceAccounts/" + appAccount
+	oldAccount = "old@test-project.iam.gserviceaccount.com"
+	oldName    = "projects/-/serviceAccounts/" + oldAccount
+	breakGlass = "break-glass@example.com"
+	agent      = "serviceAccount:service-123@compute-system.iam.gserviceaccount.com"
+)
+
+type lockdownStubs struct {
+	crm     *stubs.ResourceManagerStub
+	sa      *stubs.ServiceAccountsStub
+	compute *stubs.ComputeStub
+	ps      *stubs.PubSubStub
+	logger  *stubs.LoggerStub
+	state   *services.State
+}
+
+func (s *lockdownStubs) services() *Services {
+	return &Services{
+		Resource:        services.NewResource(s.crm, &stubs.StorageStub{}),
+		ServiceAccounts: services.NewServiceAccounts(s.sa),
+		Host:            services.NewHost(s.compute),
+		State:           s.state,
+		Approval:        services.NewApproval(services.NewPubSub(s.ps), s.state, "approvals", []string{"alice@foo.com", "bob@foo.com"}),
+		Logger:          services.NewLogger(s.logger),
+	}
+}
+
+func compromisedProject(bindings []*crm.Binding) *lockdownStubs {
+	return &lockdownStubs{
+		crm: &stubs.ResourceManagerStub{
+			GetPolicyResponse:  &crm.Policy{Bindings: bindings},
+			GetProjectResponse: map[string]*crm.Project{"test-project": {ProjectId: "test-project", ProjectNumber: 123}},
+		},
+		sa: &stubs.ServiceAccountsStub{
+			StubbedAccounts: map[string]*iam.ServiceAccount{
+				appName: {Email: appAccount, ProjectId: "test-project"},
+				oldName: {Email: oldAccount, ProjectId: "test-project", Disabled: true},
+			},
+			StubbedKeys: map[string][]*iam.ServiceAccountKey{appName: {{Name: appName + "/keys/1"}}},
+		},
+		compute: &stubs.ComputeStub{
+			StubbedInstancesInAllZones: []*compute.Instance{
+				{Name: "web-1", Zone: "https://www.googleapis.com/compute/v1/projects/test-project/zones/us-central1-a", Status: "RUNNING"},
+				{Name: "web-2", Zone: "https://www.googleapis.com/compute/v1/projects/test-project/zones/us-central1-b", Status: "TERMINATED"},
+			},
+		},
+		ps:     &stubs.PubSubStub{},
+		logger: &stubs.LoggerStub{},
+		state:  services.NewState(&stubs.StorageStub{}, "state"),
+	}
+}
+
+func TestLockdownProject(t *testing.T) {
+	ctx := context.Background()
+	bindings := []*crm.Binding{
+		{Role: "roles/owner", Members: []string{"group:" + breakGlass, "user:eve@gmail.com"}},
+		{Role: "roles/compute.serviceAgent", Members: []string{agent}},
+		{Role: "roles/editor", Members: []string{"serviceAccount:" + appAccount, "user:admin@example.com"}},
+	}
+	base := Values{ProjectID: "test-project", BreakGlassGroup: breakGlass, AllowMembers: []string{"user:admin@example.com"}}
+	id := ApprovalID(&base, []string{
+		"removed roles/editor serviceAccount:" + appAccount,
+		"removed roles/owner user:eve@gmail.com",
+		"disable " + appAccount,
+		"stop us-central1-a/web-1",
+	})
+	for _, tt := range []struct {
+		name             string
+		approvals        map[string][]string
+		dryRun           bool
+		expectedApproval bool
+		expectedLocked   bool
+	}{
+		{
+			name:             "requires approval",
+			expectedApproval: true,
+		},
+		{
+			name:             "approval for a different change",
+			approvals:        map[string][]string{"other": {"alice@foo.com"}},
+			expectedApproval: true,
+		},
+		{
+			name:             "one of two approvals",
+			approvals:        map[string][]string{id: {"alice@foo.com"}},
+			expectedApproval: true,
+		},
+		{
+			name:   "dry run without approval",
+			dryRun: true,
+		},
+		{
+			name:           "approved",
+			approvals:      map[string][]string{id: {"alice@foo.com", "bob@foo.com"}},
+			expectedLocked: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			s := compromisedProject(bindings)
+			for id, emails := range tt.approvals {
+				for _, email := range emails {
+					if err := s.state.RecordApproval(ctx, id, email); err != nil {
+						t.Fatalf("%s failed to record approval: %q", tt.name, err)
+					}
+				}
+			}
+			values := base
+			values.DryRun = tt.dryRun
+			r, err := Execute(ctx, &values, s.services())
+			if err != nil {
+				t.Fatalf("%s failed: %q", tt.name, err)
+			}
+			if tt.dryRun && (r == nil || r.Status() != services.AuditResultDryRun || !strings.Contains(r.Message, id)) {
+				t.Errorf("%s failed: dry run should report approval %s, got %+v", tt.name, id, r)
+			}
+			if got := s.ps.PublishedMessage != nil; got != tt.expectedApproval {
+				t.Errorf("%s failed: approval requested %t want %t", tt.name, got, tt.expectedApproval)
+			}
+			if !tt.expectedLocked {
+				if s.crm.SavedSetPolicy != nil || s.sa.StubbedAccounts[appName].Disabled || s.compute.Stopped {
+					t.Errorf("%s failed: project should not be changed", tt.name)
+				}
+				return
+			}
+			want := []*crm.Binding{
+				{Role: "roles/owner", Members: []string{"group:" + breakGlass}},
+				{Role: "roles/compute.serviceAgent", Members: []string{agent}},
+				{Role: "roles/editor", Members: []string{"user:admin@example.com"}},
+			}
+			if diff := cmp.Diff(want, s.crm.SavedSetPolicy.Bindings); diff != "" {
+				t.Errorf("%s failed, project bindings difference:%+v", tt.name, diff)
+			}
+			if !s.sa.StubbedAccounts[appName].Disabled {
+				t.Errorf("%s failed: service account should be disabled", tt.name)
+			}
+			if diff := cmp.Diff([]stubs.Call{{Method: "StopInstance", Args: []interface{}{"test-project", "us-central1-a", "web-1"}}}, s.compute.Requested("StopInstance")); diff != "" {
+				t.Errorf("%s failed, stopped instances difference:%+v", tt.name, diff)
+			}
+			record, err := s.state.Lockdown(ctx, "test-project")
+			if err != nil {
+				t.Fatalf("%s failed to read lockdown record: %q", tt.name, err)
+			}
+			wantRecord := &services.LockdownRecord{
+				ID:        record.ID,
+				ProjectID: "test-project",
+				Bindings: []services.BindingChange{
+					{Role: "roles/editor", Member: "serviceAccount:" + appAccount, Change: services.BindingRemoved},
+					{Role: "roles/owner", Member: "user:eve@gmail.com", Change: services.BindingRemoved},
+				},
+				ServiceAccounts: []services.LockedServiceAccount{
+					{Email: appAccount, Keys: []string{appName + "/keys/1"}},
+					{Email: oldAccount, WasDisabled: true, Keys: []string{}},
+				},
+				Instances:  []string{"us-central1-a/web-1"},
+				CreateTime: record.CreateTime,
+			}
+			if diff := cmp.Diff(wantRecord, record); diff != "" {
+				t.Errorf("%s failed, lockdown record difference:%+v", tt.name, diff)
+			}
+		})
+	}
+}
+
+func TestLockdownProjectAlreadyLockedDown(t *testing.T) {
+	ctx := context.Background()
+	s := compromisedProject([]*crm.Binding{{Role: "roles/owner", Members: []string{"group:" + breakGlass}}})
+	s.sa.StubbedAccounts[appName].Disabled = true
+	s.compute.StubbedInstancesInAllZones = nil
+	values := &Values{ProjectID: "test-project", BreakGlassGroup: breakGlass}
+	r, err := Execute(ctx, values, s.services())
+	if err != nil {
+		t.Fatalf("failed: %q", err)
+	}
+	if s.ps.PublishedMessage != nil {
+		t.Errorf("approval requested for a project that is already locked down")
+	}
+	if r == nil || r.Status() != services.AuditResultAlreadyRemediated {
+		t.Errorf("expected an already remediated result, got %v", r)
+	}
+}
+
+func TestLockdownProjectRequiresBreakGlassGroup(t *testing.T) {
+	_, err := Execute(context.Background(), &Values{ProjectID: "test-project"}, &Services{})
+	if err == nil {
+		t.Errorf("lockdown without a break-glass group should fail")
+	}
+}
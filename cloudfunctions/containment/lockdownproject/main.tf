@@ -0,0 +1,73 @@
+# Copyright 2020 Google LLC
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+# 	https://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+resource "google_cloudfunctions_function" "lockdown-project" {
+  name                  = "LockdownProject"
+  description           = "Removes the IAM bindings, disables the service accounts and stops the instances of a compromised project once approved."
+  runtime               = "go121"
+  available_memory_mb   = 128
+  source_archive_bucket = var.setup.gcf-bucket-name
+  source_archive_object = var.setup.gcf-object-name
+  timeout               = 540
+  project               = var.setup.automation-project
+  region                = var.setup.region
+  entry_point           = "LockdownProject"
+
+  event_trigger {
+    event_type = "providers/cloud.pubsub/eventTypes/topic.publish"
+    resource   = "threat-findings-lockdown-project"
+
+    failure_policy {
+      retry = true
+    }
+  }
+
+  environment_variables = {
+    APPROVAL_TOPIC = var.setup.approval-topic
+    APPROVERS      = join(",", var.approvers)
+  }
+}
+
+# Required to remove the IAM bindings of projects within this folder.
+resource "google_folder_iam_member" "roles-folder-admin" {
+  count = length(var.folder-ids)
+
+  folder = "folders/${var.folder-ids[count.index]}"
+  role   = "roles/resourcemanager.folderAdmin"
+  member = "serviceAccount:${var.setup.automation-service-account}"
+}
+
+# Required to disable the service accounts of projects within this folder and list their keys.
+resource "google_folder_iam_member" "roles-service-account-admin" {
+  count = length(var.folder-ids)
+
+  folder = "folders/${var.folder-ids[count.index]}"
+  role   = "roles/iam.serviceAccountAdmin"
+  member = "serviceAccount:${var.setup.automation-service-account}"
+}
+
+# Required to stop the instances of projects within this folder.
+resource "google_folder_iam_member" "roles-instance-admin" {
+  count = length(var.folder-ids)
+
+  folder = "folders/${var.folder-ids[count.index]}"
+  role   = "roles/compute.instanceAdmin.v1"
+  member = "serviceAccount:${var.setup.automation-service-account}"
+}
+
+# PubSub topic to trigger this automation. Approvals are recorded by the Approve function, never read
+# from its messages.
+resource "google_pubsub_topic" "topic" {
+  name    = "threat-findings-lockdown-project"
+  project = var.setup.automation-project
+}
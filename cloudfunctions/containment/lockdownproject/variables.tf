@@ -0,0 +1,11 @@
+variable "setup" {}
+
+variable "folder-ids" {
+  type        = list(string)
+  description = "Folder IDs to grant the necessary permissions for this Cloud Function execution."
+}
+
+variable "approvers" {
+  type        = list(string)
+  description = "Emails of the people allowed to approve locking down projects."
+}
@@ -0,0 +1,74 @@
+# Copyright 2020 Google LLC
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+# 	https://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+resource "google_cloudfunctions_function" "restore-containment" {
+  name                  = "RestoreContainment"
+  description           = "Reverts temporary containment actions once they expire."
+  runtime               = "go121"
+  available_memory_mb   = 128
+  source_archive_bucket = var.setup.gcf-bucket-name
+  source_archive_object = var.setup.gcf-object-name
+  timeout               = 360
+  project               = var.setup.automation-project
+  region                = var.setup.region
+  entry_point           = "RestoreContainment"
+
+  event_trigger {
+    event_type = "providers/cloud.pubsub/eventTypes/topic.publish"
+    resource   = "threat-findings-restore-containment"
+  }
+}
+
+# PubSub topic to trigger this automation.
+resource "google_pubsub_topic" "topic" {
+  name    = "threat-findings-restore-containment"
+  project = var.setup.automation-project
+}
+
+# Periodically checks for expired containment actions.
+resource "google_cloud_scheduler_job" "restore-containment" {
+  name     = "restore-containment"
+  project  = var.setup.automation-project
+  region   = var.setup.region
+  schedule = var.schedule
+
+  pubsub_target {
+    topic_name = google_pubsub_topic.topic.id
+    data       = base64encode("{}")
+  }
+}
+
+# Required to restore firewall rules within this folder.
+resource "google_folder_iam_member" "roles-security-admin" {
+  count = length(var.folder-ids)
+
+  folder = "folders/${var.folder-ids[count.index]}"
+  role   = "roles/compute.securityAdmin"
+  member = "serviceAccount:${var.setup.automation-service-account}"
+}
+
+# Required to restore public IPs of instances within this folder.
+resource "google_folder_iam_member" "roles-instance-admin" {
+  count = length(var.folder-ids)
+
+  folder = "folders/${var.folder-ids[count.index]}"
+  role   = "roles/compute.instanceAdmin.v1"
+  member = "serviceAccount:${var.setup.automation-service-account}"
+}
+
+resource "google_project_service" "cloudscheduler_api" {
+  project                    = var.setup.automation-project
+  service                    = "cloudscheduler.googleapis.com"
+  disable_dependent_services = false
+  disable_on_destroy         = false
+}
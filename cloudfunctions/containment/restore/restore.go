@@ -0,0 +1,94 @@
+// Package restore reverts temporary containment actions once they expire.
+package restore
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gce/blockegress"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gce/openfirewall"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gce/removepublicip"
+	"github.com/googlecloudplatform/security-response-automation/services"
+)
+
+// action is the automation name recorded in audit records.
+const action = "restore_containment"
+
+// Values contains the values needed for this function.
+type Values struct {
+	DryRun bool
+}
+
+// Services contains the services needed for this function.
+type Services struct {
+	State    *services.State
+	Firewall *services.Firewall
+	Host     *services.Host
+	Logger   *services.Logger
+}
+
+// Execute reverts every containment action whose TTL has expired.
+//
+// Each action is reverted using the state of the resource saved before it was contained. Records
+// are only removed once reverted so failed reverts are retried on the next run.
+func Execute(ctx context.Context, values *Values, svcs *Services) (*services.Result, error) {
+	result := services.NewResult(action, values.DryRun)
+	if svcs.State == nil {
+		return nil, fmt.Errorf("no state bucket configured")
+	}
+	expired, err := svcs.State.ExpiredContainments(ctx, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	failed := 0
+	for _, r := range expired {
+		if values.DryRun {
+			result.Touch(r.Resource)
+			continue
+		}
+		if err := revert(ctx, svcs, r); err != nil {
+			svcs.Logger.Error("failed to revert %q on %q in project %q: %q", r.Action, r.Resource, r.ProjectID, err)
+			failed++
+			continue
+		}
+		if err := svcs.State.RemoveContainment(ctx, r); err != nil {
+			svcs.Logger.Error("reverted %q on %q but failed to remove its record: %q", r.Action, r.Resource, err)
+			failed++
+			continue
+		}
+		svcs.Logger.Info("reverted %q on %q in project %q which expired at %s", r.Action, r.Resource, r.ProjectID, r.ExpireTime.Format(time.RFC3339))
+		result.Touch(r.Resource)
+	}
+	if failed > 0 {
+		return nil, fmt.Errorf("failed to revert %d of %d expired containment actions", failed, len(expired))
+	}
+	return result, nil
+}
+
+func revert(ctx context.Context, svcs *Services, r *services.ContainmentRecord) error {
+	switch r.Action {
+	case openfirewall.DisableContainment, openfirewall.UpdateRangeContainment, openfirewall.BlockContainment:
+		return openfirewall.Restore(ctx, svcs.Firewall, r)
+	case removepublicip.Containment:
+		return removepublicip.Restore(ctx, svcs.Host, r)
+	case blockegress.Containment:
+		return blockegress.Restore(ctx, svcs.Firewall, r)
+	default:
+		return fmt.Errorf("unknown containment action %q", r.Action)
+	}
+}
@@ -0,0 +1,153 @@
+package restore
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gce/openfirewall"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gce/removepublicip"
+	"github.com/googlecloudplatform/security-response-automation/services"
+	compute "google.golang.org/api/compute/v1"
+)
+
+func TestRestoreExpired(t *testing.T) {
+	ctx := context.Background()
+	storageStub := &stubs.StorageStub{}
+	state := services.NewState(storageStub, "state-bucket")
+	logr := services.NewLogger(&stubs.LoggerStub{})
+	computeStub := &stubs.ComputeStub{
+		StubbedFirewall: &compute.Firewall{Name: "open-firewall", SourceRanges: []string{"0.0.0.0/0"}},
+		StubbedInstance: &compute.Instance{NetworkInterfaces: []*compute.NetworkInterface{
+			{Name: "nic0", AccessConfigs: []*compute.AccessConfig{{Name: "External NAT", NatIP: "35.192.206.126", Type: "ONE_TO_ONE_NAT"}}},
+		}},
+	}
+	fw := services.NewFirewall(computeStub)
+	host := services.NewHost(computeStub)
+
+	// Contain a firewall and an instance with a TTL that expires immediately, and another firewall
+	// with a TTL that has not expired yet.
+	for _, ttl := range []string{"1ns", "24h"} {
+		if _, err := openfirewall.Execute(ctx, &openfirewall.Values{
+			Action:       "update_source_range",
+			ProjectID:    "project-id",
+			FirewallID:   "open-firewall",
+			SourceRanges: []string{"10.0.0.0/8"},
+			TTL:          ttl,
+		}, &openfirewall.Services{Firewall: fw, Logger: logr, State: state}); err != nil {
+			t.Fatalf("failed to update firewall: %q", err)
+		}
+	}
+	if _, err := removepublicip.Execute(ctx, &removepublicip.Values{
+		ProjectID:    "project-id",
+		InstanceZone: "us-central1-a",
+		InstanceID:   "instance-id",
+		TTL:          "1ns",
+	}, &removepublicip.Services{Host: host, Logger: logr, State: state}); err != nil {
+		t.Fatalf("failed to remove public ip: %q", err)
+	}
+	if len(storageStub.WrittenObjects) != 3 {
+		t.Fatalf("got %d containment records want 3", len(storageStub.WrittenObjects))
+	}
+
+	computeStub.SavedFirewallRule = nil
+	if _, err := Execute(ctx, &Values{}, &Services{State: state, Firewall: fw, Host: host, Logger: logr}); err != nil {
+		t.Fatalf("failed to restore: %q", err)
+	}
+	if diff := cmp.Diff(&compute.Firewall{Name: "open-firewall", SourceRanges: []string{"0.0.0.0/0"}}, computeStub.SavedFirewallRule); diff != "" {
+		t.Errorf("firewall not restored (-want +got):\n%s", diff)
+	}
+	expectedConfigs := []*compute.AccessConfig{{Name: "External NAT", NatIP: "35.192.206.126", Type: "ONE_TO_ONE_NAT"}}
+	if diff := cmp.Diff(expectedConfigs, computeStub.AddedAccessConfigs); diff != "" {
+		t.Errorf("public ip not restored (-want +got):\n%s", diff)
+	}
+	if len(storageStub.WrittenObjects) != 1 {
+		t.Errorf("got %d containment records remaining want 1", len(storageStub.WrittenObjects))
+	}
+}
+
+func TestRestoreDisabledFirewall(t *testing.T) {
+	ctx := context.Background()
+	state := services.NewState(&stubs.StorageStub{}, "state-bucket")
+	logr := services.NewLogger(&stubs.LoggerStub{})
+	computeStub := &stubs.ComputeStub{StubbedFirewall: &compute.Firewall{Name: "open-firewall"}}
+	fw := services.NewFirewall(computeStub)
+	if _, err := openfirewall.Execute(ctx, &openfirewall.Values{Action: "disable", ProjectID: "project-id", FirewallID: "open-firewall", TTL: "1ns"},
+		&openfirewall.Services{Firewall: fw, Logger: logr, State: state}); err != nil {
+		t.Fatalf("failed to disable firewall: %q", err)
+	}
+	if _, err := Execute(ctx, &Values{}, &Services{State: state, Firewall: fw, Logger: logr}); err != nil {
+		t.Fatalf("failed to restore: %q", err)
+	}
+	expected := &compute.Firewall{Name: "open-firewall", Disabled: false, ForceSendFields: []string{"Disabled"}}
+	if diff := cmp.Diff(expected, computeStub.SavedFirewallRule); diff != "" {
+		t.Errorf("firewall not enabled (-want +got):\n%s", diff)
+	}
+}
+
+func TestRestoreBlockedRanges(t *testing.T) {
+	ctx := context.Background()
+	for _, tt := range []struct {
+		name     string
+		blocked  []string
+		expected []string
+		deleted  bool
+	}{
+		{name: "other ranges blocked", blocked: []string{"203.0.113.9/32", "198.51.100.7/32"}, expected: []string{"203.0.113.9/32"}},
+		{name: "only range blocked", blocked: []string{"198.51.100.7/32"}, deleted: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			state := services.NewState(&stubs.StorageStub{}, "state-bucket")
+			logr := services.NewLogger(&stubs.LoggerStub{})
+			computeStub := &stubs.ComputeStub{StubbedFirewall: &compute.Firewall{Id: 123, Name: "automatic-rdp-block"}}
+			fw := services.NewFirewall(computeStub)
+			if _, err := openfirewall.Execute(ctx, &openfirewall.Values{Action: "block_rdp", ProjectID: "project-id", SourceRanges: []string{"198.51.100.7/32"}, TTL: "1ns"},
+				&openfirewall.Services{Firewall: fw, Logger: logr, State: state}); err != nil {
+				t.Fatalf("failed to block rdp: %q", err)
+			}
+			computeStub.StubbedFirewall.SourceRanges = tt.blocked
+			computeStub.SavedFirewallRule = nil
+			if _, err := Execute(ctx, &Values{}, &Services{State: state, Firewall: fw, Logger: logr}); err != nil {
+				t.Fatalf("failed to restore: %q", err)
+			}
+			if deleted := len(computeStub.Requested("DeleteFirewallRule")) == 1; deleted != tt.deleted {
+				t.Errorf("rule deleted = %v, expected %v", deleted, tt.deleted)
+			}
+			if tt.deleted {
+				return
+			}
+			if diff := cmp.Diff(&compute.Firewall{Name: "automatic-rdp-block", SourceRanges: tt.expected}, computeStub.SavedFirewallRule); diff != "" {
+				t.Errorf("ranges not unblocked (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRestoreEphemeralIP(t *testing.T) {
+	ctx := context.Background()
+	computeStub := &stubs.ComputeStub{AddAccessConfigFailures: 1}
+	host := services.NewHost(computeStub)
+	configs := []services.ExternalAccessConfig{{NetworkInterface: "nic0", Name: "External NAT", NatIP: "35.192.206.126"}}
+	if err := host.RestoreExternalIPs(ctx, "project-id", "us-central1-a", "instance-id", configs); err != nil {
+		t.Fatalf("failed to restore: %q", err)
+	}
+	expected := []*compute.AccessConfig{{Name: "External NAT", Type: "ONE_TO_ONE_NAT"}}
+	if diff := cmp.Diff(expected, computeStub.AddedAccessConfigs); diff != "" {
+		t.Errorf("ephemeral ip not restored (-want +got):\n%s", diff)
+	}
+}
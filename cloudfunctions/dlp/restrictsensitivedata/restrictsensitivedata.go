@@ -0,0 +1,96 @@
+package restrictsensitivedata
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/googlecloudplatform/security-response-automation/services"
+	"github.com/pkg/errors"
+)
+
+const (
+	// Label is set on resources once their broad access is removed so they can be told apart from
+	// resources reviewed by their owner.
+	Label      = "dlp-restricted"
+	labelValue = "true"
+)
+
+// Values contains the required values needed for this function. Either Bucket or DatasetID is set.
+type Values struct {
+	ProjectID string
+	Bucket    string
+	DatasetID string
+	// InfoType is the type of sensitive data found, i.e. "EMAIL_ADDRESS".
+	InfoType string
+	DryRun   bool
+}
+
+// Services contains the services needed for this function.
+type Services struct {
+	Resource *services.Resource
+	BigQuery *services.BigQuery
+	Logger   *services.Logger
+}
+
+// Execute removes the grants giving everyone or a whole domain access to the bucket or dataset
+// holding sensitive data and labels it as restricted.
+func Execute(ctx context.Context, values *Values, svcs *Services) (*services.Result, error) {
+	result := services.NewResult("restrict_sensitive_data", values.DryRun)
+	switch {
+	case values.Bucket != "":
+		return restrictBucket(ctx, values, svcs, result)
+	case values.DatasetID != "":
+		return restrictDataset(ctx, values, svcs, result)
+	default:
+		return nil, fmt.Errorf("no bucket or dataset holding %s in project %q", values.InfoType, values.ProjectID)
+	}
+}
+
+func restrictBucket(ctx context.Context, values *Values, svcs *Services, result *services.Result) (*services.Result, error) {
+	broad, err := svcs.Resource.BroadBucketMembers(ctx, values.Bucket)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get members of bucket %q", values.Bucket)
+	}
+	result.Members = services.MemberOutcomes(broad, broad, broad, broad, values.DryRun)
+	if values.DryRun {
+		return result.Touch(values.Bucket), nil
+	}
+	if len(broad) > 0 {
+		if err := svcs.Resource.RemoveMembersFromBucket(ctx, values.Bucket, broad); err != nil {
+			return nil, errors.Wrapf(err, "failed to remove members from bucket %q", values.Bucket)
+		}
+	}
+	if err := svcs.Resource.LabelBucket(ctx, values.Bucket, Label, labelValue); err != nil {
+		return nil, errors.Wrapf(err, "failed to label bucket %q", values.Bucket)
+	}
+	return result.Touch(values.Bucket), nil
+}
+
+func restrictDataset(ctx context.Context, values *Values, svcs *Services, result *services.Result) (*services.Result, error) {
+	broad, err := svcs.BigQuery.BroadDatasetAccess(ctx, values.ProjectID, values.DatasetID)
+	if err != nil {
+		return nil, err
+	}
+	result.Members = services.MemberOutcomes(broad, broad, broad, broad, values.DryRun)
+	if values.DryRun {
+		return result.Touch(values.DatasetID), nil
+	}
+	if err := svcs.BigQuery.RestrictDataset(ctx, values.ProjectID, values.DatasetID, Label, labelValue); err != nil {
+		return nil, err
+	}
+	return result.Touch(values.DatasetID), nil
+}
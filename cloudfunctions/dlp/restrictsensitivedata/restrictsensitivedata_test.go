@@ -0,0 +1,108 @@
+package restrictsensitivedata
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"testing"
+
+	"cloud.google.com/go/bigquery"
+	"cloud.google.com/go/iam"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+	"github.com/googlecloudplatform/security-response-automation/services"
+)
+
+func TestRestrictBucket(t *testing.T) {
+	ctx := context.Background()
+	for _, tt := range []struct {
+		name     string
+		members  []string
+		dryRun   bool
+		expected []string
+		labels   map[string]string
+	}{
+		{
+			name:     "remove broad members",
+			members:  []string{"allUsers", "domain:example.com", "user:owner@example.com"},
+			expected: []string{"user:owner@example.com"},
+			labels:   map[string]string{Label: "true"},
+		},
+		{
+			name:    "no broad members",
+			members: []string{"user:owner@example.com"},
+			labels:  map[string]string{Label: "true"},
+		},
+		{
+			name:    "dry run",
+			members: []string{"allAuthenticatedUsers"},
+			dryRun:  true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			storageStub := &stubs.StorageStub{BucketPolicyResponse: &iam.Policy{}}
+			for _, m := range tt.members {
+				storageStub.BucketPolicyResponse.Add(m, "roles/storage.objectViewer")
+			}
+			values := &Values{ProjectID: "project-name", Bucket: "customer-exports", InfoType: "EMAIL_ADDRESS", DryRun: tt.dryRun}
+			if _, err := Execute(ctx, values, &Services{
+				Resource: services.NewResource(&stubs.ResourceManagerStub{}, storageStub),
+				Logger:   services.NewLogger(&stubs.LoggerStub{}),
+			}); err != nil {
+				t.Fatalf("%s failed: %q", tt.name, err)
+			}
+			if tt.expected != nil {
+				if diff := cmp.Diff(tt.expected, storageStub.RemoveBucketPolicy.Members("roles/storage.objectViewer")); diff != "" {
+					t.Errorf("%s failed, members difference: %v", tt.name, diff)
+				}
+			} else if storageStub.RemoveBucketPolicy != nil {
+				t.Errorf("%s failed: policy should not be written", tt.name)
+			}
+			if diff := cmp.Diff(tt.labels, storageStub.BucketLabels["customer-exports"]); diff != "" {
+				t.Errorf("%s failed, labels difference: %v", tt.name, diff)
+			}
+		})
+	}
+}
+
+func TestRestrictDataset(t *testing.T) {
+	ctx := context.Background()
+	bqStub := &stubs.BigQueryStub{StubbedMetadata: &bigquery.DatasetMetadata{
+		Access: []*bigquery.AccessEntry{
+			{Role: bigquery.OwnerRole, EntityType: bigquery.UserEmailEntity, Entity: "owner@example.com"},
+			{Role: bigquery.ReaderRole, EntityType: bigquery.DomainEntity, Entity: "example.com"},
+		},
+	}}
+	values := &Values{ProjectID: "project-name", DatasetID: "customers", InfoType: "US_SOCIAL_SECURITY_NUMBER"}
+	if _, err := Execute(ctx, values, &Services{
+		BigQuery: services.NewBigQuery(bqStub),
+		Logger:   services.NewLogger(&stubs.LoggerStub{}),
+	}); err != nil {
+		t.Fatalf("failed to execute: %q", err)
+	}
+	expected := []*bigquery.AccessEntry{
+		{Role: bigquery.OwnerRole, EntityType: bigquery.UserEmailEntity, Entity: "owner@example.com"},
+	}
+	if diff := cmp.Diff(expected, bqStub.SavedDatasetMetadata.Access); diff != "" {
+		t.Errorf("access difference: %v", diff)
+	}
+}
+
+func TestRestrictNoResource(t *testing.T) {
+	values := &Values{ProjectID: "project-name", InfoType: "EMAIL_ADDRESS"}
+	if _, err := Execute(context.Background(), values, &Services{}); err == nil {
+		t.Errorf("expected an error without a bucket or dataset")
+	}
+}
@@ -0,0 +1,106 @@
+// Package events runs the automations as a Cloud Run service receiving the messages of their
+// topics as CloudEvents.
+package events
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/functions/metadata"
+	"cloud.google.com/go/pubsub"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/router"
+	"github.com/googlecloudplatform/security-response-automation/services"
+	"github.com/pkg/errors"
+)
+
+// Function is an entry point in exec.go run by a Pub/Sub trigger.
+type Function func(ctx context.Context, m pubsub.Message) error
+
+// Services contains the services needed to dispatch the events.
+type Services struct {
+	Logger *services.Logger
+}
+
+// pushMessage is the body of a Pub/Sub CloudEvent sent in binary mode, the same as a push
+// subscription's.
+type pushMessage struct {
+	Message struct {
+		ID         string            `json:"messageId"`
+		Data       []byte            `json:"data"`
+		Attributes map[string]string `json:"attributes"`
+	} `json:"message"`
+	Subscription string `json:"subscription"`
+}
+
+// Handler returns the handler running the function subscribed to the topic of each Pub/Sub
+// CloudEvent, as sent by Eventarc in binary mode. The topic is taken from the Ce-Source header,
+// i.e. "//pubsub.googleapis.com/projects/p/topics/threat-findings". Failed functions are
+// answered with an error so the event is redelivered. The event's ID and time are passed to the
+// function as the metadata of a Cloud Functions event.
+func Handler(services *Services, functions map[string]Function) http.Handler {
+	entryPoints := router.TopicFunctions()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		topic, err := topicOf(r.Header.Get("Ce-Source"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		f, ok := functions[entryPoints[topic]]
+		if !ok {
+			services.Logger.Error("no function is subscribed to topic %q", topic)
+			http.Error(w, "no function is subscribed to topic", http.StatusNotFound)
+			return
+		}
+		var push pushMessage
+		if err := json.NewDecoder(r.Body).Decode(&push); err != nil {
+			http.Error(w, "failed to decode message", http.StatusBadRequest)
+			return
+		}
+		m := pubsub.Message{ID: push.Message.ID, Data: push.Message.Data, Attributes: push.Message.Attributes}
+		if err := f(eventContext(r), m); err != nil {
+			services.Logger.Error("%s failed: %q", entryPoints[topic], err)
+			http.Error(w, "function failed", http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// eventContext returns the request's context carrying the metadata of the CloudEvent.
+func eventContext(r *http.Request) context.Context {
+	meta := &metadata.Metadata{EventID: r.Header.Get("Ce-Id")}
+	if t, err := time.Parse(time.RFC3339, r.Header.Get("Ce-Time")); err == nil {
+		meta.Timestamp = t
+	}
+	return metadata.NewContext(r.Context(), meta)
+}
+
+// topicOf returns the name of the topic a Pub/Sub CloudEvent was published to from its source.
+func topicOf(source string) (string, error) {
+	i := strings.LastIndex(source, "/topics/")
+	if !strings.HasPrefix(source, "//pubsub.googleapis.com/projects/") || i < 0 {
+		return "", errors.Errorf("source %q is not a Pub/Sub topic", source)
+	}
+	return source[i+len("/topics/"):], nil
+}
@@ -0,0 +1,134 @@
+package events
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+	"github.com/googlecloudplatform/security-response-automation/services"
+)
+
+func TestHandler(t *testing.T) {
+	const body = `{"message":{"messageId":"1","data":"eyJmb28iOiJiYXIifQ==","attributes":{"severity":"HIGH"}},"subscription":"projects/p/subscriptions/s"}`
+	for _, tt := range []struct {
+		name       string
+		method     string
+		source     string
+		body       string
+		err        error
+		wantStatus int
+		want       *pubsub.Message
+	}{
+		{
+			name:       "runs the function of the topic",
+			method:     http.MethodPost,
+			source:     "//pubsub.googleapis.com/projects/automation/topics/threat-findings-iam-revoke",
+			body:       body,
+			wantStatus: http.StatusNoContent,
+			want:       &pubsub.Message{ID: "1", Data: []byte(`{"foo":"bar"}`), Attributes: map[string]string{"severity": "HIGH"}},
+		},
+		{
+			name:       "failed function is redelivered",
+			method:     http.MethodPost,
+			source:     "//pubsub.googleapis.com/projects/automation/topics/threat-findings-iam-revoke",
+			body:       body,
+			err:        errors.New("failed"),
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:       "not a pubsub event",
+			method:     http.MethodPost,
+			source:     "//storage.googleapis.com/projects/_/buckets/b",
+			body:       body,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "no function subscribed",
+			method:     http.MethodPost,
+			source:     "//pubsub.googleapis.com/projects/automation/topics/unknown",
+			body:       body,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "invalid body",
+			method:     http.MethodPost,
+			source:     "//pubsub.googleapis.com/projects/automation/topics/threat-findings-iam-revoke",
+			body:       "{",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "post only",
+			method:     http.MethodGet,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *pubsub.Message
+			h := Handler(&Services{Logger: services.NewLogger(&stubs.LoggerStub{})}, map[string]Function{
+				"IAMRevoke": func(ctx context.Context, m pubsub.Message) error {
+					got = &m
+					return tt.err
+				},
+			})
+			r := httptest.NewRequest(tt.method, "/", strings.NewReader(tt.body))
+			r.Header.Set("Ce-Source", tt.source)
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("%s failed: got status %d, want %d", tt.name, w.Code, tt.wantStatus)
+			}
+			if tt.want != nil {
+				if diff := cmp.Diff(tt.want, got, cmp.AllowUnexported(pubsub.Message{})); diff != "" {
+					t.Errorf("%s failed, difference: %v", tt.name, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestHandlerEventTime(t *testing.T) {
+	const body = `{"message":{"messageId":"1","data":"e30="}}`
+	var final bool
+	h := Handler(&Services{Logger: services.NewLogger(&stubs.LoggerStub{})}, map[string]Function{
+		"IAMRevoke": func(ctx context.Context, m pubsub.Message) error {
+			final = services.FinalAttempt(ctx)
+			return nil
+		},
+	})
+	for _, tt := range []struct {
+		published time.Time
+		final     bool
+	}{
+		{published: time.Now()},
+		{published: time.Now().Add(-services.RetryWindow - time.Minute), final: true},
+	} {
+		r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		r.Header.Set("Ce-Source", "//pubsub.googleapis.com/projects/automation/topics/threat-findings-iam-revoke")
+		r.Header.Set("Ce-Time", tt.published.UTC().Format(time.RFC3339))
+		h.ServeHTTP(httptest.NewRecorder(), r)
+		if final != tt.final {
+			t.Errorf("event published at %s got final %t want %t", tt.published, final, tt.final)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package batchexport
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/router"
+	"github.com/googlecloudplatform/security-response-automation/services"
+	"github.com/pkg/errors"
+)
+
+const (
+	// routerTopic is the topic the router receives findings from.
+	routerTopic = "threat-findings"
+	// defaultLimit is how many findings are read per run if no limit is given.
+	defaultLimit = 500
+)
+
+// Values contains the values needed for this function.
+type Values struct {
+	// Table is the BigQuery table findings are exported to, i.e. "project.dataset.findings".
+	Table string `json:"table"`
+	// Limit is how many findings are read per run, the rest are read on the next runs.
+	Limit int `json:"limit"`
+	// Start is the event time, in RFC 3339 format, findings are read from on the first run. Findings
+	// are read from the start of the table if empty.
+	Start string `json:"start"`
+}
+
+// Services contains the services needed for this function.
+type Services struct {
+	State    *services.State
+	BigQuery *services.BigQuery
+	PubSub   *services.PubSub
+	Logger   *services.Logger
+}
+
+// Execute reads the findings exported to the table since the last run and publishes each to the
+// router, which remediates them as if they were delivered by a Pub/Sub notification.
+//
+// The watermark is advanced past each finding once it's published so an outage of the router or
+// of this function is caught up with on the next runs.
+func Execute(ctx context.Context, values *Values, svcs *Services) (*services.Result, error) {
+	result := services.NewResult("process_finding_export", false)
+	if svcs.State == nil {
+		return nil, fmt.Errorf("no state bucket configured")
+	}
+	if values.Table == "" {
+		return nil, fmt.Errorf("no export table given")
+	}
+	limit := values.Limit
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	w, err := svcs.State.Watermark(ctx, values.Table)
+	if err != nil {
+		return nil, err
+	}
+	if w.EventTime.IsZero() && values.Start != "" {
+		start, err := time.Parse(time.RFC3339, values.Start)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse start %q", values.Start)
+		}
+		w.EventTime = start
+	}
+	findings, err := svcs.BigQuery.ExportedFindings(ctx, values.Table, w.EventTime, limit)
+	if err != nil {
+		return nil, err
+	}
+	var published error
+	for _, f := range findings {
+		if w.Processed(f.ID, f.EventTime) {
+			continue
+		}
+		if _, err := svcs.PubSub.Publish(ctx, routerTopic, &pubsub.Message{
+			Data:       f.Notification,
+			Attributes: map[string]string{router.ReplayAttribute: "true"},
+		}); err != nil {
+			published = errors.Wrapf(err, "failed to publish finding %q", f.ID)
+			break
+		}
+		w.Advance(f.ID, f.EventTime)
+		result.Touch(f.ID)
+	}
+	if result.Empty() && published == nil {
+		return result, nil
+	}
+	if err := svcs.State.SaveWatermark(ctx, w); err != nil {
+		return nil, err
+	}
+	if published != nil {
+		return nil, published
+	}
+	svcs.Logger.Info("sent %d findings exported to %q up to %s to the router", len(result.Resources), values.Table, w.EventTime.Format(time.RFC3339))
+	return result, nil
+}
@@ -0,0 +1,109 @@
+package batchexport
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/router"
+	"github.com/googlecloudplatform/security-response-automation/services"
+)
+
+func TestBatchExport(t *testing.T) {
+	ctx := context.Background()
+	t0 := time.Date(2020, 3, 1, 10, 0, 0, 0, time.UTC)
+	row := func(id string, at time.Time) []bigquery.Value {
+		return []bigquery.Value{id, at, `{"finding":{"name":"` + id + `"}}`}
+	}
+	storageStub := &stubs.StorageStub{}
+	bqStub := &stubs.BigQueryStub{}
+	psStub := &stubs.PubSubStub{}
+	svcs := &Services{
+		State:    services.NewState(storageStub, "state-bucket"),
+		BigQuery: services.NewBigQuery(bqStub),
+		PubSub:   services.NewPubSub(psStub),
+		Logger:   services.NewLogger(&stubs.LoggerStub{}),
+	}
+	values := &Values{Table: "p.scc.findings", Start: "2020-03-01T00:00:00Z"}
+
+	for _, tt := range []struct {
+		name      string
+		rows      [][]bigquery.Value
+		since     string
+		published []string
+	}{
+		{
+			name:      "first run",
+			rows:      [][]bigquery.Value{row("f1", t0), row("f2", t0.Add(time.Minute))},
+			since:     "2020-03-01T00:00:00Z",
+			published: []string{"f1", "f2"},
+		},
+		{
+			// The newest finding is read again with a finding exported at the same time.
+			name:      "next run",
+			rows:      [][]bigquery.Value{row("f2", t0.Add(time.Minute)), row("f3", t0.Add(time.Minute))},
+			since:     "2020-03-01T10:01:00Z",
+			published: []string{"f3"},
+		},
+		{
+			name:  "nothing new",
+			rows:  [][]bigquery.Value{row("f2", t0.Add(time.Minute)), row("f3", t0.Add(time.Minute))},
+			since: "2020-03-01T10:01:00Z",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			bqStub.StubbedRows = tt.rows
+			bqStub.SavedQueries = nil
+			psStub.PublishedMessages = nil
+			r, err := Execute(ctx, values, svcs)
+			if err != nil {
+				t.Fatalf("%s failed: %q", tt.name, err)
+			}
+			if q := bqStub.SavedQueries[0]; !strings.Contains(q, tt.since) {
+				t.Errorf("%s queried %q want findings since %s", tt.name, q, tt.since)
+			}
+			got := []string{}
+			for _, m := range psStub.PublishedMessages {
+				got = append(got, string(m.Data))
+				if m.Attributes[router.ReplayAttribute] == "" {
+					t.Errorf("%s published %q without the replay attribute", tt.name, m.Data)
+				}
+			}
+			want := []string{}
+			for _, id := range tt.published {
+				want = append(want, `{"finding":{"name":"`+id+`"}}`)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("%s published unexpected findings (-want +got):\n%s", tt.name, diff)
+			}
+			if len(tt.published) != len(r.Resources) {
+				t.Errorf("%s got %d findings in result want %d", tt.name, len(r.Resources), len(tt.published))
+			}
+		})
+	}
+}
+
+func TestBatchExportRequiresTable(t *testing.T) {
+	svcs := &Services{State: services.NewState(&stubs.StorageStub{}, "state-bucket")}
+	if _, err := Execute(context.Background(), &Values{}, svcs); err == nil {
+		t.Errorf("expected error without an export table")
+	}
+}
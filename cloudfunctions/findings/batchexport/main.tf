@@ -0,0 +1,77 @@
+# Copyright 2020 Google LLC
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+# 	https://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+resource "google_cloudfunctions_function" "process-finding-export" {
+  name                  = "ProcessFindingExport"
+  description           = "Sends findings exported to BigQuery since the last run to the router."
+  runtime               = "go121"
+  available_memory_mb   = 256
+  source_archive_bucket = var.setup.gcf-bucket-name
+  source_archive_object = var.setup.gcf-object-name
+  timeout               = 360
+  project               = var.setup.automation-project
+  region                = var.setup.region
+  entry_point           = "ProcessFindingExport"
+
+  event_trigger {
+    event_type = "providers/cloud.pubsub/eventTypes/topic.publish"
+    resource   = "threat-findings-process-finding-export"
+  }
+}
+
+# PubSub topic to trigger this automation.
+resource "google_pubsub_topic" "topic" {
+  name    = "threat-findings-process-finding-export"
+  project = var.setup.automation-project
+}
+
+# Periodically reads the findings exported since the last run, only if findings are exported.
+resource "google_cloud_scheduler_job" "process-finding-export" {
+  count = var.findings-table == "" ? 0 : 1
+
+  name     = "process-finding-export"
+  project  = var.setup.automation-project
+  region   = var.setup.region
+  schedule = var.schedule
+
+  pubsub_target {
+    topic_name = google_pubsub_topic.topic.id
+    data       = base64encode(jsonencode({ table = var.findings-table, limit = var.batch-size, start = var.start }))
+  }
+}
+
+# Required to run the query in the automation project.
+resource "google_project_iam_member" "bigquery-job-user" {
+  count = var.findings-table == "" ? 0 : 1
+
+  project = var.setup.automation-project
+  role    = "roles/bigquery.jobUser"
+  member  = "serviceAccount:${var.setup.automation-service-account}"
+}
+
+# Required to read the findings exported to the dataset.
+resource "google_bigquery_dataset_iam_member" "bigquery-data-viewer" {
+  count = var.findings-table == "" ? 0 : 1
+
+  project    = split(".", var.findings-table)[0]
+  dataset_id = split(".", var.findings-table)[1]
+  role       = "roles/bigquery.dataViewer"
+  member     = "serviceAccount:${var.setup.automation-service-account}"
+}
+
+resource "google_project_service" "cloudscheduler_api" {
+  project                    = var.setup.automation-project
+  service                    = "cloudscheduler.googleapis.com"
+  disable_dependent_services = false
+  disable_on_destroy         = false
+}
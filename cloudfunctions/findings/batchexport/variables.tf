@@ -0,0 +1,24 @@
+variable "setup" {}
+
+variable "findings-table" {
+  type        = string
+  description = "BigQuery table findings are exported to, i.e. \"project.dataset.findings\"."
+}
+
+variable "schedule" {
+  type        = string
+  default     = "*/15 * * * *"
+  description = "Cron schedule to read the findings exported since the last run on."
+}
+
+variable "batch-size" {
+  type        = number
+  default     = 500
+  description = "How many findings are read per run."
+}
+
+variable "start" {
+  type        = string
+  default     = ""
+  description = "Event time, in RFC 3339 format, findings are read from on the first run. Findings are read from the start of the table if empty."
+}
@@ -0,0 +1,84 @@
+# Copyright 2020 Google LLC
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+# 	https://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+resource "google_cloudfunctions_function" "replay-dead-letters" {
+  name                  = "ReplayDeadLetters"
+  description           = "Replays the findings the router failed to route once they're actionable again."
+  runtime               = "go121"
+  available_memory_mb   = 256
+  source_archive_bucket = var.setup.gcf-bucket-name
+  source_archive_object = var.setup.gcf-object-name
+  timeout               = 360
+  project               = var.setup.automation-project
+  region                = var.setup.region
+  entry_point           = "ReplayDeadLetters"
+
+  event_trigger {
+    event_type = "providers/cloud.pubsub/eventTypes/topic.publish"
+    resource   = "threat-findings-replay-dead-letters"
+  }
+
+  environment_variables = {
+    TICKET_TOPIC = var.setup.ticket-topic
+  }
+}
+
+# PubSub topic to trigger this automation.
+resource "google_pubsub_topic" "topic" {
+  name    = "threat-findings-replay-dead-letters"
+  project = var.setup.automation-project
+}
+
+# The router sends the findings it fails to route to this topic.
+resource "google_pubsub_topic" "dead-letter" {
+  name    = "threat-findings-dead-letter"
+  project = var.setup.automation-project
+}
+
+# Holds the dead letters until they're replayed or dropped. Dead letters left for a later run are
+# not delivered again within the same run.
+resource "google_pubsub_subscription" "dead-letter-replay" {
+  name                       = "threat-findings-dead-letter-replay"
+  project                    = var.setup.automation-project
+  topic                      = google_pubsub_topic.dead-letter.name
+  ack_deadline_seconds       = 600
+  message_retention_duration = "604800s"
+}
+
+# Required to pull and acknowledge dead letters.
+resource "google_pubsub_subscription_iam_member" "subscriber" {
+  project      = var.setup.automation-project
+  subscription = google_pubsub_subscription.dead-letter-replay.name
+  role         = "roles/pubsub.subscriber"
+  member       = "serviceAccount:${var.setup.automation-service-account}"
+}
+
+# Periodically replays the dead letters.
+resource "google_cloud_scheduler_job" "replay-dead-letters" {
+  name     = "replay-dead-letters"
+  project  = var.setup.automation-project
+  region   = var.setup.region
+  schedule = var.schedule
+
+  pubsub_target {
+    topic_name = google_pubsub_topic.topic.id
+    data       = base64encode(jsonencode({ max_replays = var.max-replays, backoff = var.backoff }))
+  }
+}
+
+resource "google_project_service" "cloudscheduler_api" {
+  project                    = var.setup.automation-project
+  service                    = "cloudscheduler.googleapis.com"
+  disable_dependent_services = false
+  disable_on_destroy         = false
+}
@@ -0,0 +1,182 @@
+package replay
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/router"
+	"github.com/googlecloudplatform/security-response-automation/services"
+	"github.com/pkg/errors"
+)
+
+const (
+	// routerTopic is the topic the router receives findings from.
+	routerTopic = "threat-findings"
+	// batchSize is how many dead letters are pulled at once.
+	batchSize = 50
+	// defaultLimit is how many dead letters are received per run if no limit is given.
+	defaultLimit = 500
+	// defaultMaxReplays is how many times a finding is replayed if no limit is given.
+	defaultMaxReplays = 5
+	// defaultBackoff is how long after its first failure a finding is replayed if no backoff is given.
+	defaultBackoff = 10 * time.Minute
+)
+
+// What became of a dead letter.
+const (
+	replayed = "replayed"
+	dropped  = "dropped"
+	pending  = "pending"
+)
+
+// Values contains the values needed for this function.
+type Values struct {
+	// Limit is how many dead letters are received per run.
+	Limit int `json:"limit"`
+	// MaxReplays is how many times a finding is replayed before it's dropped.
+	MaxReplays int `json:"max_replays"`
+	// Backoff is how long after its first failure a finding is replayed, i.e. "10m". The backoff
+	// doubles with each replay.
+	Backoff string `json:"backoff"`
+}
+
+// Services contains the services needed for this function.
+type Services struct {
+	DeadLetters *services.DeadLetters
+	PubSub      *services.PubSub
+	Logger      *services.Logger
+	// Router validates each finding against the current configuration.
+	Router *router.Services
+}
+
+// Execute drains the dead-letter topic and replays the findings that are actionable again.
+//
+// Each finding is simulated against the current configuration first. Findings an automation would
+// now run for, i.e. after its permissions were fixed, are sent back to the router once their
+// backoff passed. Findings still failing are left for a later run and findings no automation
+// applies to anymore, or replayed MaxReplays times already, are dropped.
+func Execute(ctx context.Context, values *Values, svcs *Services) (*services.Result, error) {
+	result := services.NewResult("replay_dead_letters", false)
+	limit := values.Limit
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	maxReplays := values.MaxReplays
+	if maxReplays <= 0 {
+		maxReplays = defaultMaxReplays
+	}
+	backoff := defaultBackoff
+	if values.Backoff != "" {
+		d, err := time.ParseDuration(values.Backoff)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse backoff %q", values.Backoff)
+		}
+		backoff = d
+	}
+	failed := 0
+	for received := 0; received < limit; {
+		n := batchSize
+		if limit-received < n {
+			n = limit - received
+		}
+		letters, err := svcs.DeadLetters.Receive(ctx, n)
+		if err != nil {
+			return nil, err
+		}
+		if len(letters) == 0 {
+			break
+		}
+		received += len(letters)
+		done := []*services.DeadLetter{}
+		for _, l := range letters {
+			status, err := replay(ctx, svcs, l, maxReplays, backoff)
+			if err != nil {
+				svcs.Logger.Error("failed to replay dead letter %q: %q", l.ID, err)
+				failed++
+				continue
+			}
+			if status != pending {
+				done = append(done, l)
+			}
+			if status == replayed {
+				result.Touch(l.ID)
+			}
+		}
+		if err := svcs.DeadLetters.Ack(ctx, done); err != nil {
+			return nil, err
+		}
+	}
+	if failed > 0 {
+		return nil, fmt.Errorf("%d dead letters failed to replay", failed)
+	}
+	return result, nil
+}
+
+// replay sends the finding back to the router if it's actionable and its backoff passed. Dead
+// letters that aren't pending are acknowledged.
+func replay(ctx context.Context, svcs *Services, l *services.DeadLetter, maxReplays int, backoff time.Duration) (string, error) {
+	if l.Replays >= maxReplays {
+		svcs.Logger.Error("dropped dead letter %q after %d replays, last failed with: %q", l.ID, l.Replays, l.Error)
+		return dropped, nil
+	}
+	if time.Now().Before(l.Time.Add(backoff << uint(l.Replays))) {
+		return pending, nil
+	}
+	outcomes, err := router.Simulate(ctx, &router.Values{Finding: l.Data, Replay: true}, svcs.Router)
+	if err != nil && services.Permanent(err) {
+		svcs.Logger.Error("dropped dead letter %q, it can't be routed: %q", l.ID, err)
+		return dropped, nil
+	}
+	if err != nil {
+		svcs.Logger.Warning("dead letter %q is not actionable yet: %q", l.ID, err)
+		return pending, nil
+	}
+	switch actionable(outcomes) {
+	case router.OutcomeRun:
+		m := l.ReplayMessage()
+		m.Attributes[router.ReplayAttribute] = "true"
+		if _, err := svcs.PubSub.Publish(ctx, routerTopic, m); err != nil {
+			return "", errors.Wrap(err, "failed to publish finding")
+		}
+		svcs.Logger.Info("replayed dead letter %q, attempt %d", l.ID, l.Replays+1)
+		return replayed, nil
+	case router.OutcomeFailed:
+		svcs.Logger.Warning("dead letter %q is not actionable yet", l.ID)
+		return pending, nil
+	}
+	svcs.Logger.Info("dropped dead letter %q, no automation applies to it anymore", l.ID)
+	return dropped, nil
+}
+
+// actionable returns OutcomeRun if any automation would run, OutcomeFailed if any would fail or
+// lacks permissions and OutcomeSkipped otherwise.
+func actionable(outcomes []router.Outcome) string {
+	failing := false
+	for _, o := range outcomes {
+		switch o.Result {
+		case router.OutcomeRun:
+			return router.OutcomeRun
+		case router.OutcomeFailed, router.OutcomePermissionDenied:
+			failing = true
+		}
+	}
+	if failing {
+		return router.OutcomeFailed
+	}
+	return router.OutcomeSkipped
+}
@@ -0,0 +1,136 @@
+package replay
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/router"
+	"github.com/googlecloudplatform/security-response-automation/services"
+	pubsubpb "google.golang.org/genproto/googleapis/pubsub/v1"
+)
+
+const (
+	compromisedInstance = `{"siemAlert": {"source": "chronicle", "id": "de_1234", "category": "compromised_instance", "resource": {"projectId": "test-project", "zone": "us-central1-a", "instance": "miner"}}}`
+	publicBucket        = `{"siemAlert": {"source": "chronicle", "id": "de_5678", "category": "public_bucket", "resource": {"projectId": "test-project", "bucket": "bucket"}}}`
+)
+
+func TestReplay(t *testing.T) {
+	now := time.Now()
+	for _, tt := range []struct {
+		name      string
+		finding   string
+		failed    time.Time
+		replays   int
+		acked     []string
+		published bool
+	}{
+		{
+			name:      "actionable",
+			finding:   compromisedInstance,
+			failed:    now.Add(-time.Hour),
+			acked:     []string{"ack-1"},
+			published: true,
+		},
+		{
+			name:    "backoff not passed",
+			finding: compromisedInstance,
+			failed:  now.Add(-30 * time.Minute),
+			replays: 2,
+		},
+		{
+			name:    "replay limit reached",
+			finding: compromisedInstance,
+			failed:  now.Add(-24 * time.Hour),
+			replays: 5,
+			acked:   []string{"ack-1"},
+		},
+		{
+			name:    "malformed finding",
+			finding: `{"siemAlert": `,
+			failed:  now.Add(-time.Hour),
+			acked:   []string{"ack-1"},
+		},
+		{
+			name:    "unknown rule",
+			finding: `{"siemAlert": {"source": "chronicle", "id": "de_9012", "category": "unknown"}}`,
+			failed:  now.Add(-time.Hour),
+			acked:   []string{"ack-1"},
+		},
+		{
+			name:    "no automation applies",
+			finding: publicBucket,
+			failed:  now.Add(-time.Hour),
+			acked:   []string{"ack-1"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			m := services.NewDeadLetterMessage(&pubsub.Message{Data: []byte(tt.finding)}, errors.New("permission denied"), tt.failed)
+			m.Attributes["sra-replays"] = strconv.Itoa(tt.replays)
+			subStub := &stubs.SubscriberStub{Messages: []*pubsubpb.ReceivedMessage{
+				{AckId: "ack-1", Message: &pubsubpb.PubsubMessage{MessageId: "1", Data: m.Data, Attributes: m.Attributes}},
+			}}
+			psStub := &stubs.PubSubStub{}
+			crmStub := &stubs.ResourceManagerStub{}
+			crmStub.GetAncestryResponse = services.CreateAncestors([]string{"project/test-project", "folder/123", "organization/456"})
+			storageStub := &stubs.StorageStub{}
+			conf := &router.Configuration{}
+			conf.Spec.Parameters.SIEM.CompromisedInstance = []router.Automation{
+				{Action: "gce_create_disk_snapshot", Target: []string{"organizations/456/folders/123/projects/test-project"}},
+			}
+			logr := services.NewLogger(&stubs.LoggerStub{})
+			svcs := &Services{
+				DeadLetters: services.NewDeadLetters(subStub, services.DeadLetterSubscription),
+				PubSub:      services.NewPubSub(psStub),
+				Logger:      logr,
+				Router: &router.Services{
+					Configuration:         conf,
+					Logger:                logr,
+					Resource:              services.NewResource(crmStub, storageStub),
+					SecurityCommandCenter: services.NewCommandCenter(&stubs.SecurityCommandCenterStub{}),
+					State:                 services.NewState(storageStub, "state"),
+				},
+			}
+			r, err := Execute(ctx, &Values{}, svcs)
+			if err != nil {
+				t.Fatalf("%s failed: %q", tt.name, err)
+			}
+			if diff := cmp.Diff(tt.acked, subStub.Acked); diff != "" {
+				t.Errorf("%s acked difference:%+v", tt.name, diff)
+			}
+			if published := psStub.PublishedMessage != nil; published != tt.published {
+				t.Fatalf("%s published %t want %t", tt.name, published, tt.published)
+			}
+			if !tt.published {
+				return
+			}
+			want := &pubsub.Message{Data: []byte(tt.finding), Attributes: map[string]string{"sra-replays": "1", router.ReplayAttribute: "true"}}
+			if diff := cmp.Diff(want, psStub.PublishedMessage, cmp.AllowUnexported(pubsub.Message{})); diff != "" {
+				t.Errorf("%s replayed message difference:%+v", tt.name, diff)
+			}
+			if diff := cmp.Diff([]string{"1"}, r.Resources); diff != "" {
+				t.Errorf("%s result difference:%+v", tt.name, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+variable "setup" {}
+
+variable "schedule" {
+  type        = string
+  default     = "*/10 * * * *"
+  description = "Cron schedule to replay dead letters on."
+}
+
+variable "max-replays" {
+  type        = number
+  default     = 5
+  description = "How many times a finding is replayed before it's dropped."
+}
+
+variable "backoff" {
+  type        = string
+  default     = "10m"
+  description = "How long after its first failure a finding is replayed, doubling with each replay."
+}
@@ -0,0 +1,75 @@
+package exportbundle
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/googlecloudplatform/security-response-automation/services"
+	"github.com/pkg/errors"
+)
+
+// action is the action recorded in the audit record of an exported bundle.
+const action = "export_bundle"
+
+// Values contains the required values needed for this function.
+type Values struct {
+	// FindingName is the Security Command Center name of the finding the incident started with.
+	FindingName string `json:"finding"`
+	ProjectID   string `json:"project_id"`
+	// Resource optionally selects the audit records included, defaults to the project.
+	Resource string `json:"resource"`
+}
+
+// Services contains the services needed for this function.
+type Services struct {
+	Bundle *services.IncidentBundle
+	Logger *services.Logger
+}
+
+// ReadValues reads the incident to export from the request body.
+func ReadValues(b []byte) (*Values, error) {
+	var values Values
+	if err := json.Unmarshal(b, &values); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal request")
+	}
+	if values.FindingName == "" && values.ProjectID == "" {
+		return nil, errors.New("missing finding or project_id")
+	}
+	return &values, nil
+}
+
+// Execute exports the incident's bundle to the forensics bucket and records where it was written.
+func Execute(ctx context.Context, values *Values, svcs *Services) (*services.BundleManifest, error) {
+	m, err := svcs.Bundle.Export(ctx, &services.BundleQuery{
+		FindingName: values.FindingName,
+		ProjectID:   values.ProjectID,
+		Resource:    values.Resource,
+	})
+	if err != nil {
+		return nil, err
+	}
+	archive := fmt.Sprintf("gs://%s/%s", m.Bucket, m.Archive)
+	svcs.Logger.Audit(&services.AuditRecord{
+		Action:   action,
+		Resource: archive,
+		Result:   services.AuditResultSuccess,
+		Message:  fmt.Sprintf("exported %d files signed with %q", len(m.Files), m.KeyVersion),
+	})
+	svcs.Logger.Info("exported incident bundle to %q", archive)
+	return m, nil
+}
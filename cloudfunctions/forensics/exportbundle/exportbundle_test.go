@@ -0,0 +1,71 @@
+package exportbundle
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+	"github.com/googlecloudplatform/security-response-automation/services"
+)
+
+func TestReadValues(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		body          string
+		expectedError bool
+	}{
+		{name: "finding", body: `{"finding": "organizations/1/sources/2/findings/abc"}`},
+		{name: "project", body: `{"project_id": "test-project"}`},
+		{name: "no incident", body: `{"resource": "projects/test-project"}`, expectedError: true},
+		{name: "malformed", body: `{`, expectedError: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadValues([]byte(tt.body))
+			if (err != nil) != tt.expectedError {
+				t.Errorf("%s failed: got error %v, want error %t", tt.name, err, tt.expectedError)
+			}
+		})
+	}
+}
+
+func TestExportBundle(t *testing.T) {
+	const keyVersion = "projects/p/locations/global/keyRings/r/cryptoKeys/k/cryptoKeyVersions/1"
+	ctx := context.Background()
+	storageStub := &stubs.StorageStub{}
+	loggerStub := &stubs.LoggerStub{}
+	svcs := &Services{
+		Bundle: services.NewIncidentBundle(services.NewHistory(&stubs.LogAdminStub{}, "automation-project"), storageStub, &stubs.KMSStub{}, "forensics-bucket", keyVersion, ""),
+		Logger: services.NewLogger(loggerStub),
+	}
+	m, err := Execute(ctx, &Values{ProjectID: "test-project"}, svcs)
+	if err != nil {
+		t.Fatalf("failed to export bundle: %q", err)
+	}
+	for _, object := range []string{m.Archive, m.Signature} {
+		if _, ok := storageStub.WrittenObjects["forensics-bucket/"+object]; !ok {
+			t.Errorf("object %q not written", object)
+		}
+	}
+	if !strings.HasPrefix(m.Archive, "projects/test-project/") {
+		t.Errorf("unexpected archive %q", m.Archive)
+	}
+	record, ok := loggerStub.AuditRecords[0].(*services.AuditRecord)
+	if !ok || record.Action != "export_bundle" || record.Resource != "gs://forensics-bucket/"+m.Archive {
+		t.Errorf("unexpected audit record %+v", loggerStub.AuditRecords)
+	}
+}
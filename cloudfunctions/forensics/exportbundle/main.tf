@@ -0,0 +1,53 @@
+# Copyright 2020 Google LLC
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+# 	https://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+resource "google_cloudfunctions_function" "export-incident-bundle" {
+  name                  = "ExportIncidentBundle"
+  description           = "Exports an incident as a signed archive to the forensics bucket."
+  runtime               = "go121"
+  available_memory_mb   = 256
+  source_archive_bucket = var.setup.gcf-bucket-name
+  source_archive_object = var.setup.gcf-object-name
+  timeout               = 120
+  project               = var.setup.automation-project
+  region                = var.setup.region
+  entry_point           = "ExportIncidentBundle"
+  trigger_http          = true
+}
+
+# Required to write bundles to the forensics bucket.
+resource "google_storage_bucket_iam_member" "forensics-object-creator" {
+  count = var.forensics-bucket == "" ? 0 : 1
+
+  bucket = var.forensics-bucket
+  role   = "roles/storage.objectCreator"
+  member = "serviceAccount:${var.setup.automation-service-account}"
+}
+
+# Required to include the manifests of the evidence collected in bundles.
+resource "google_storage_bucket_iam_member" "evidence-object-viewer" {
+  count = var.evidence-bucket == "" ? 0 : 1
+
+  bucket = var.evidence-bucket
+  role   = "roles/storage.objectViewer"
+  member = "serviceAccount:${var.setup.automation-service-account}"
+}
+
+# Required to sign bundles.
+resource "google_kms_crypto_key_iam_member" "signer" {
+  count = var.signing-key-version == "" ? 0 : 1
+
+  crypto_key_id = replace(var.signing-key-version, "/\\/cryptoKeyVersions\\/.*$/", "")
+  role          = "roles/cloudkms.signer"
+  member        = "serviceAccount:${var.setup.automation-service-account}"
+}
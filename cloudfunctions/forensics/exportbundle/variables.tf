@@ -0,0 +1,16 @@
+variable "setup" {}
+
+variable "forensics-bucket" {
+  type        = string
+  description = "Bucket incident bundles are exported to."
+}
+
+variable "evidence-bucket" {
+  type        = string
+  description = "Bucket evidence is collected to, its manifests are included in bundles."
+}
+
+variable "signing-key-version" {
+  type        = string
+  description = "Resource name of the asymmetric signing Cloud KMS key version bundles are signed with."
+}
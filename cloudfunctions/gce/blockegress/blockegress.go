@@ -0,0 +1,215 @@
+// Package blockegress denies outbound traffic from an instance suspected of exfiltrating data.
+package blockegress
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/googlecloudplatform/security-response-automation/services"
+	"github.com/pkg/errors"
+	compute "google.golang.org/api/compute/v1"
+)
+
+const (
+	// Containment is the containment record action of temporarily blocked egress.
+	Containment = "block_egress"
+	// ScopeNetworkTag targets the egress rule at the network tags of the instance.
+	ScopeNetworkTag = "network_tag"
+	// ScopeServiceAccount targets the egress rule at the service account of the instance.
+	ScopeServiceAccount = "service_account"
+)
+
+// ruleNamePrefix prefixes the name of each egress rule, followed by the instance ID and the index of
+// the network.
+const ruleNamePrefix = "sra-block-egress-"
+
+// Values contains the required values needed for this function.
+type Values struct {
+	ProjectID string
+	Zone      string
+	Instance  string
+	// Scope is either ScopeNetworkTag or ScopeServiceAccount. Every instance sharing the tags or
+	// service account is blocked too.
+	Scope string
+	// DestinationRanges are the ranges denied, all destinations if empty.
+	DestinationRanges []string
+	// TTL optionally removes the egress rules after this duration, i.e. "24h".
+	TTL    string
+	DryRun bool
+}
+
+// Services contains the services needed for this function.
+type Services struct {
+	Host     *services.Host
+	Firewall *services.Firewall
+	Logger   *services.Logger
+	// State is required when a TTL is set.
+	State *services.State
+}
+
+// Before records the egress rules created so they're removed once the containment expires.
+type Before struct {
+	Rules []string `json:"rules"`
+}
+
+// Execute creates a highest priority firewall rule denying egress from the instance on each of its
+// networks.
+func Execute(ctx context.Context, values *Values, svcs *Services) (*services.Result, error) {
+	result := services.NewResult(Containment, values.DryRun)
+	if values.TTL != "" && svcs.State == nil {
+		return nil, errors.New("a state bucket must be configured to remove the egress rules after their ttl")
+	}
+	ttl, err := time.ParseDuration(values.TTL)
+	if values.TTL != "" && err != nil {
+		return nil, errors.Wrapf(err, "invalid ttl %q", values.TTL)
+	}
+	if values.Zone, err = svcs.Host.InstanceZone(ctx, values.ProjectID, values.Zone, values.Instance); err != nil {
+		return nil, err
+	}
+	instance, err := svcs.Host.Instance(ctx, values.ProjectID, values.Zone, values.Instance)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := egressRules(instance, values)
+	if err != nil {
+		return nil, err
+	}
+	missing := []*compute.Firewall{}
+	for _, r := range rules {
+		switch _, err := svcs.Firewall.FirewallRule(ctx, values.ProjectID, r.Name); {
+		case isNotFound(err):
+			missing = append(missing, r)
+		case err != nil:
+			return nil, errors.Wrapf(err, "failed to get firewall rule %q", r.Name)
+		}
+	}
+	if len(missing) == 0 {
+		return result.Skip(values.Instance, "egress of instance %q in project %q already blocked", values.Instance, values.ProjectID), nil
+	}
+	result.Message = fmt.Sprintf("blocked egress of instance %q in zone %q with %d firewall rules", values.Instance, values.Zone, len(missing))
+	if values.DryRun {
+		return result.Touch(values.Instance), nil
+	}
+	if err := addRules(ctx, svcs, values, ttl, missing); err != nil {
+		return nil, err
+	}
+	return result.Touch(values.Instance), nil
+}
+
+// Restore removes the egress rules of an instance once its temporary containment expires.
+func Restore(ctx context.Context, fw *services.Firewall, r *services.ContainmentRecord) error {
+	var before Before
+	if err := json.Unmarshal(r.Before, &before); err != nil {
+		return errors.Wrap(err, "failed to unmarshal egress rules")
+	}
+	for _, name := range before.Rules {
+		op, err := fw.DeleteFirewallRule(ctx, r.ProjectID, name)
+		if isNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to delete firewall rule %q", name)
+		}
+		if errs := fw.WaitGlobal(ctx, r.ProjectID, op); len(errs) > 0 {
+			return errs[0]
+		}
+	}
+	return nil
+}
+
+// egressRules returns the rules denying egress from the instance, one for each network its
+// interfaces are attached to.
+func egressRules(instance *compute.Instance, values *Values) ([]*compute.Firewall, error) {
+	target := &compute.Firewall{}
+	switch values.Scope {
+	case ScopeNetworkTag, "":
+		if instance.Tags == nil || len(instance.Tags.Items) == 0 {
+			return nil, errors.Errorf("instance %q has no network tags to block egress of", values.Instance)
+		}
+		target.TargetTags = instance.Tags.Items
+	case ScopeServiceAccount:
+		if len(instance.ServiceAccounts) == 0 {
+			return nil, errors.Errorf("instance %q has no service account to block egress of", values.Instance)
+		}
+		target.TargetServiceAccounts = []string{instance.ServiceAccounts[0].Email}
+	default:
+		return nil, errors.Errorf("unknown scope %q", values.Scope)
+	}
+	destinations := values.DestinationRanges
+	if len(destinations) == 0 {
+		destinations = []string{"0.0.0.0/0"}
+	}
+	rules := []*compute.Firewall{}
+	seen := make(map[string]bool)
+	for _, ni := range instance.NetworkInterfaces {
+		if seen[ni.Network] {
+			continue
+		}
+		seen[ni.Network] = true
+		rules = append(rules, &compute.Firewall{
+			Name:                  fmt.Sprintf("%s%d-%d", ruleNamePrefix, instance.Id, len(rules)),
+			Description:           fmt.Sprintf("Block egress of instance %q by Security Response Automation", instance.Name),
+			Network:               ni.Network,
+			Direction:             "EGRESS",
+			Priority:              0,
+			Denied:                []*compute.FirewallDenied{{IPProtocol: "all"}},
+			DestinationRanges:     destinations,
+			TargetTags:            target.TargetTags,
+			TargetServiceAccounts: target.TargetServiceAccounts,
+			// Priority 0 is the highest and otherwise omitted.
+			ForceSendFields: []string{"Priority"},
+		})
+	}
+	return rules, nil
+}
+
+// addRules creates the egress rules. If they should be removed after a TTL they're recorded first
+// so a rule is never created without a way to remove it.
+func addRules(ctx context.Context, svcs *Services, values *Values, ttl time.Duration, rules []*compute.Firewall) error {
+	var rec *services.ContainmentRecord
+	if values.TTL != "" {
+		before := &Before{}
+		for _, r := range rules {
+			before.Rules = append(before.Rules, r.Name)
+		}
+		var err error
+		if rec, err = svcs.State.RecordContainment(ctx, Containment, values.ProjectID, values.Instance, ttl, before); err != nil {
+			return err
+		}
+	}
+	for i, r := range rules {
+		err := svcs.Firewall.AddFirewallRule(ctx, values.ProjectID, r)
+		if err == nil {
+			continue
+		}
+		// Rules already created are kept, they're removed through the record once it expires.
+		if i == 0 && rec != nil {
+			if rerr := svcs.State.RemoveContainment(ctx, rec); rerr != nil {
+				svcs.Logger.Error("failed to remove containment record %q: %q", rec.ID, rerr)
+			}
+		}
+		return errors.Wrapf(err, "failed to add firewall rule %q", r.Name)
+	}
+	return nil
+}
+
+// isNotFound returns whether the error is that the firewall rule doesn't exist.
+func isNotFound(err error) bool {
+	return services.IsNotFound(err)
+}
@@ -0,0 +1,154 @@
+package blockegress
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+	"github.com/googlecloudplatform/security-response-automation/services"
+	compute "google.golang.org/api/compute/v1"
+)
+
+const network = "https://www.googleapis.com/compute/v1/projects/test-project/global/networks/default"
+
+func TestBlockEgress(t *testing.T) {
+	instance := &compute.Instance{
+		Id:                123,
+		Name:              "exfil-1",
+		Tags:              &compute.Tags{Items: []string{"web"}},
+		ServiceAccounts:   []*compute.ServiceAccount{{Email: "app@test-project.iam.gserviceaccount.com"}},
+		NetworkInterfaces: []*compute.NetworkInterface{{Network: network}, {Network: network}},
+	}
+	for _, tt := range []struct {
+		name     string
+		values   *Values
+		expected *compute.Firewall
+	}{
+		{
+			name:   "network tag",
+			values: &Values{ProjectID: "test-project", Zone: "us-central1-a", Instance: "exfil-1"},
+			expected: &compute.Firewall{
+				Name:              "sra-block-egress-123-0",
+				Description:       `Block egress of instance "exfil-1" by Security Response Automation`,
+				Network:           network,
+				Direction:         "EGRESS",
+				Denied:            []*compute.FirewallDenied{{IPProtocol: "all"}},
+				DestinationRanges: []string{"0.0.0.0/0"},
+				TargetTags:        []string{"web"},
+				ForceSendFields:   []string{"Priority"},
+			},
+		},
+		{
+			name:   "service account",
+			values: &Values{ProjectID: "test-project", Zone: "us-central1-a", Instance: "exfil-1", Scope: ScopeServiceAccount, DestinationRanges: []string{"203.0.113.0/24"}},
+			expected: &compute.Firewall{
+				Name:                  "sra-block-egress-123-0",
+				Description:           `Block egress of instance "exfil-1" by Security Response Automation`,
+				Network:               network,
+				Direction:             "EGRESS",
+				Denied:                []*compute.FirewallDenied{{IPProtocol: "all"}},
+				DestinationRanges:     []string{"203.0.113.0/24"},
+				TargetServiceAccounts: []string{"app@test-project.iam.gserviceaccount.com"},
+				ForceSendFields:       []string{"Priority"},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			computeStub := &stubs.ComputeStub{StubbedInstance: instance}
+			computeStub.FailNext("FirewallRule", stubs.APIError(http.StatusNotFound))
+			if _, err := Execute(context.Background(), tt.values, &Services{
+				Host:     services.NewHost(computeStub),
+				Firewall: services.NewFirewall(computeStub),
+				Logger:   services.NewLogger(&stubs.LoggerStub{}),
+			}); err != nil {
+				t.Fatalf("%s failed: %q", tt.name, err)
+			}
+			if n := len(computeStub.Requested("InsertFirewallRule")); n != 1 {
+				t.Errorf("%s failed: got %d rules want 1", tt.name, n)
+			}
+			if diff := cmp.Diff(tt.expected, computeStub.SavedFirewallRule); diff != "" {
+				t.Errorf("%s failed (-want +got):\n%s", tt.name, diff)
+			}
+		})
+	}
+}
+
+func TestBlockEgressAlreadyBlocked(t *testing.T) {
+	computeStub := &stubs.ComputeStub{
+		StubbedInstance: &compute.Instance{Id: 123, Tags: &compute.Tags{Items: []string{"web"}}, NetworkInterfaces: []*compute.NetworkInterface{{Network: network}}},
+		StubbedFirewall: &compute.Firewall{Name: "sra-block-egress-123-0"},
+	}
+	if _, err := Execute(context.Background(), &Values{ProjectID: "test-project", Zone: "us-central1-a", Instance: "exfil-1"}, &Services{
+		Host:     services.NewHost(computeStub),
+		Firewall: services.NewFirewall(computeStub),
+		Logger:   services.NewLogger(&stubs.LoggerStub{}),
+	}); err != nil {
+		t.Fatalf("failed: %q", err)
+	}
+	if n := len(computeStub.Requested("InsertFirewallRule")); n != 0 {
+		t.Errorf("got %d rules want 0", n)
+	}
+}
+
+func TestBlockEgressNoTags(t *testing.T) {
+	computeStub := &stubs.ComputeStub{StubbedInstance: &compute.Instance{Id: 123, NetworkInterfaces: []*compute.NetworkInterface{{Network: network}}}}
+	if _, err := Execute(context.Background(), &Values{ProjectID: "test-project", Zone: "us-central1-a", Instance: "exfil-1"}, &Services{
+		Host:     services.NewHost(computeStub),
+		Firewall: services.NewFirewall(computeStub),
+		Logger:   services.NewLogger(&stubs.LoggerStub{}),
+	}); err == nil {
+		t.Errorf("expected an error blocking an instance without network tags")
+	}
+}
+
+func TestRestore(t *testing.T) {
+	ctx := context.Background()
+	other := "https://www.googleapis.com/compute/v1/projects/test-project/global/networks/other"
+	computeStub := &stubs.ComputeStub{StubbedInstance: &compute.Instance{
+		Id:                123,
+		Tags:              &compute.Tags{Items: []string{"web"}},
+		NetworkInterfaces: []*compute.NetworkInterface{{Network: network}, {Network: other}},
+	}}
+	computeStub.FailNext("FirewallRule", stubs.APIError(http.StatusNotFound), stubs.APIError(http.StatusNotFound))
+	state := services.NewState(&stubs.StorageStub{}, "state-bucket")
+	fw := services.NewFirewall(computeStub)
+	if _, err := Execute(ctx, &Values{ProjectID: "test-project", Zone: "us-central1-a", Instance: "exfil-1", TTL: "24h"}, &Services{
+		Host:     services.NewHost(computeStub),
+		Firewall: fw,
+		Logger:   services.NewLogger(&stubs.LoggerStub{}),
+		State:    state,
+	}); err != nil {
+		t.Fatalf("failed to block egress: %q", err)
+	}
+	expired, err := state.ExpiredContainments(ctx, time.Now().Add(25*time.Hour))
+	if err != nil || len(expired) != 1 {
+		t.Fatalf("got %d containment records want 1: %v", len(expired), err)
+	}
+	if err := Restore(ctx, fw, expired[0]); err != nil {
+		t.Fatalf("failed to restore: %q", err)
+	}
+	deleted := []string{}
+	for _, c := range computeStub.Requested("DeleteFirewallRule") {
+		deleted = append(deleted, c.Args[1].(string))
+	}
+	if diff := cmp.Diff([]string{"sra-block-egress-123-0", "sra-block-egress-123-1"}, deleted); diff != "" {
+		t.Errorf("unexpected deleted rules (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,136 @@
+package createanalysisvm
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"github.com/googlecloudplatform/security-response-automation/services"
+	"github.com/pkg/errors"
+)
+
+const (
+	instancePrefix     = "forensic-analysis-"
+	defaultMachineType = "n1-standard-2"
+	defaultNetwork     = "global/networks/default"
+	defaultImage       = "projects/debian-cloud/global/images/family/debian-10"
+)
+
+// Values contains the required values needed for this function.
+type Values struct {
+	// ProjectID is the forensics project where the analysis instance is created.
+	ProjectID string
+	Zone      string
+	// SourceProjectID is the project of the compromised instance where the snapshots were taken.
+	SourceProjectID string
+	// Instance is the name of the compromised instance.
+	Instance  string
+	Snapshots []string
+	// MachineType, Network and Image are optional and default to a small Debian instance in the default network.
+	MachineType string
+	Network     string
+	Image       string
+	// Topic is the optional Pub/Sub topic where connection details are posted for responders.
+	Topic  string
+	DryRun bool
+}
+
+// Services contains the services needed for this function.
+type Services struct {
+	Host   *services.Host
+	PubSub *services.PubSub
+	Logger *services.Logger
+}
+
+// ConnectionDetails are posted to the responder topic once the analysis instance is ready.
+type ConnectionDetails struct {
+	ProjectID      string   `json:"project_id"`
+	Zone           string   `json:"zone"`
+	Instance       string   `json:"instance"`
+	InternalIP     string   `json:"internal_ip"`
+	SourceInstance string   `json:"source_instance"`
+	Snapshots      []string `json:"snapshots"`
+	SSHCommand     string   `json:"ssh_command"`
+}
+
+// Execute creates an analysis instance in the forensics project with the snapshots of the
+// compromised instance attached read-only. The instance has no external IP address so it
+// can only be reached via IAP or from within the forensics network.
+func Execute(ctx context.Context, values *Values, services *Services) (*ConnectionDetails, error) {
+	if values.ProjectID == "" || values.Zone == "" {
+		return nil, errors.New("missing analysis vm project or zone")
+	}
+	if len(values.Snapshots) == 0 {
+		return nil, errors.Errorf("no snapshots to attach for instance %q", values.Instance)
+	}
+	name := instanceName(values.Instance, time.Now())
+	snapshots := make([]string, 0, len(values.Snapshots))
+	for _, s := range values.Snapshots {
+		snapshots = append(snapshots, fmt.Sprintf("projects/%s/global/snapshots/%s", values.SourceProjectID, s))
+	}
+	if values.DryRun {
+		services.Logger.Info("dry_run on, would have created analysis instance %q in project %q with %d snapshots attached", name, values.ProjectID, len(snapshots))
+		return nil, nil
+	}
+	i, err := services.Host.CreateAnalysisInstance(ctx, values.ProjectID, values.Zone, name, orDefault(values.MachineType, defaultMachineType), orDefault(values.Network, defaultNetwork), orDefault(values.Image, defaultImage), snapshots)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create analysis instance %q", name)
+	}
+	services.Logger.Info("created analysis instance %q in project %q with %d snapshots attached", name, values.ProjectID, len(snapshots))
+	details := &ConnectionDetails{
+		ProjectID:      values.ProjectID,
+		Zone:           values.Zone,
+		Instance:       name,
+		SourceInstance: fmt.Sprintf("projects/%s/instances/%s", values.SourceProjectID, values.Instance),
+		Snapshots:      values.Snapshots,
+		SSHCommand:     fmt.Sprintf("gcloud compute ssh %s --project %s --zone %s --tunnel-through-iap", name, values.ProjectID, values.Zone),
+	}
+	if i != nil && len(i.NetworkInterfaces) > 0 {
+		details.InternalIP = i.NetworkInterfaces[0].NetworkIP
+	}
+	if values.Topic == "" {
+		return details, nil
+	}
+	b, err := json.Marshal(details)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := services.PubSub.Publish(ctx, values.Topic, &pubsub.Message{Data: b}); err != nil {
+		return nil, errors.Wrapf(err, "failed to publish connection details to %q", values.Topic)
+	}
+	services.Logger.Info("sent connection details for analysis instance %q to %q", name, values.Topic)
+	return details, nil
+}
+
+// instanceName returns an instance name within the 63 character limit.
+func instanceName(instance string, t time.Time) string {
+	name := fmt.Sprintf("%s%d-%s", instancePrefix, t.Unix(), instance)
+	if len(name) > 63 {
+		name = name[:63]
+	}
+	return strings.TrimRight(name, "-")
+}
+
+func orDefault(v, d string) string {
+	if v == "" {
+		return d
+	}
+	return v
+}
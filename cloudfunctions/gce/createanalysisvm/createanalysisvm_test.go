@@ -0,0 +1,111 @@
+package createanalysisvm
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+	"github.com/googlecloudplatform/security-response-automation/services"
+	compute "google.golang.org/api/compute/v1"
+)
+
+func TestCreateAnalysisVM(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name              string
+		values            *Values
+		expectedDisks     []*compute.AttachedDisk
+		expectedPublished bool
+		expectedError     bool
+	}{
+		{
+			name: "create analysis vm",
+			values: &Values{
+				ProjectID:       "forensics-project",
+				Zone:            "us-central1-a",
+				SourceProjectID: "compromised-project",
+				Instance:        "compromised-instance",
+				Snapshots:       []string{"forensic-snapshots-bad-ip-disk0"},
+				Topic:           "responders",
+			},
+			expectedDisks: []*compute.AttachedDisk{
+				{Boot: true, AutoDelete: true, InitializeParams: &compute.AttachedDiskInitializeParams{SourceImage: defaultImage}},
+				{AutoDelete: true, Mode: "READ_ONLY", InitializeParams: &compute.AttachedDiskInitializeParams{SourceSnapshot: "projects/compromised-project/global/snapshots/forensic-snapshots-bad-ip-disk0"}},
+			},
+			expectedPublished: true,
+		},
+		{
+			name: "dry run",
+			values: &Values{
+				ProjectID: "forensics-project",
+				Zone:      "us-central1-a",
+				Snapshots: []string{"forensic-snapshots-bad-ip-disk0"},
+				DryRun:    true,
+			},
+		},
+		{
+			name: "no snapshots",
+			values: &Values{
+				ProjectID: "forensics-project",
+				Zone:      "us-central1-a",
+			},
+			expectedError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			computeStub := &stubs.ComputeStub{
+				StubbedInstance: &compute.Instance{NetworkInterfaces: []*compute.NetworkInterface{{NetworkIP: "10.0.0.5"}}},
+			}
+			psStub := &stubs.PubSubStub{}
+			_, err := Execute(ctx, tt.values, &Services{
+				Host:   services.NewHost(computeStub),
+				PubSub: services.NewPubSub(psStub),
+				Logger: services.NewLogger(&stubs.LoggerStub{}),
+			})
+			if tt.expectedError != (err != nil) {
+				t.Fatalf("%s failed: got error %v", tt.name, err)
+			}
+			if tt.expectedDisks == nil {
+				if computeStub.SavedInstance != nil {
+					t.Errorf("%s failed: instance should not have been created", tt.name)
+				}
+				return
+			}
+			if diff := cmp.Diff(tt.expectedDisks, computeStub.SavedInstance.Disks); diff != "" {
+				t.Errorf("%s failed, difference: %+v", tt.name, diff)
+			}
+			for _, ni := range computeStub.SavedInstance.NetworkInterfaces {
+				if len(ni.AccessConfigs) > 0 {
+					t.Errorf("%s failed: instance should not have an external IP", tt.name)
+				}
+			}
+			if tt.expectedPublished != (psStub.PublishedMessage != nil) {
+				t.Fatalf("%s failed: published %v", tt.name, psStub.PublishedMessage)
+			}
+			var details ConnectionDetails
+			if err := json.Unmarshal(psStub.PublishedMessage.Data, &details); err != nil {
+				t.Fatalf("%s failed: %q", tt.name, err)
+			}
+			if details.InternalIP != "10.0.0.5" {
+				t.Errorf("%s failed: got:%q want:%q", tt.name, details.InternalIP, "10.0.0.5")
+			}
+		})
+	}
+}
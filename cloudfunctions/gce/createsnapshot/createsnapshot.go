@@ -16,6 +16,7 @@ package createsnapshot
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"strings"
 	"time"
@@ -26,6 +27,8 @@ import (
 )
 
 const (
+	// action is the name the automation's progress is recorded under.
+	action         = "gce_create_disk_snapshot"
 	snapshotPrefix = "forensic-snapshots-"
 	// allowSnapshotOlderThanDuration defines how old a snapshot must be before we overwrite.
 	allowSnapshotOlderThanDuration = 5 * time.Minute
@@ -51,6 +54,15 @@ type Values struct {
 		Zone      string
 	}
 
+	AnalysisVM struct {
+		ProjectID   string
+		Zone        string
+		MachineType string
+		Network     string
+		Image       string
+		Topic       string
+	}
+
 	// DestProjectID is the optional project ID where the newly created snapshot should be copied to.
 	DestProjectID string
 	// DestZone is the optional zone where the newly created snapshot should be copied to.
@@ -62,12 +74,24 @@ type Services struct {
 	Host     *services.Host
 	Logger   *services.Logger
 	Resource *services.Resource
+	// State optionally records the disks already snapshotted so a retry resumes where the failed
+	// attempt stopped. When set and the snapshots aren't copied or sent anywhere they're started
+	// without waiting for them to complete and their operations are recorded so the poll operations
+	// function can audit them once done.
+	State *services.State
 }
 
 // Output contains the output of this function.
 type Output struct {
 	// DiskNames optionally contains the names of the disks copied to a target project.
 	DiskNames []string
+	// SnapshotNames contains the names of the snapshots created.
+	SnapshotNames []string
+}
+
+// Result returns the outcome of the automation, the snapshots created are the resources it touched.
+func (o *Output) Result(dryRun bool) *services.Result {
+	return services.NewResult(action, dryRun).Touch(o.SnapshotNames...)
 }
 
 // Execute creates a snapshot of an instance's disk.
@@ -79,10 +103,56 @@ type Output struct {
 // In order for the snapshot to be create the service account must be granted the correct
 // role on the affected project. At this time this grant is defined per project but should
 // be changed to support folder and organization level grants.
+//
+// If the state bucket is configured the snapshots and copies completed before a failure or the
+// function's deadline are recorded so the retry resumes rather than starting over. Snapshots that
+// aren't copied or sent to an output are started without waiting and audited by the poll
+// operations function once they complete.
 func Execute(ctx context.Context, values *Values, services *Services) (*Output, error) {
+	var err error
+	if values.Zone, err = services.Host.InstanceZone(ctx, values.ProjectID, values.Zone, values.Instance); err != nil {
+		return nil, err
+	}
+	if services.State == nil || values.DryRun {
+		return snapshot(ctx, values, services, newProgress())
+	}
+	progress, err := services.State.Progress(ctx, action, values.ProjectID, values.Instance)
+	if err != nil {
+		return nil, err
+	}
+	output, err := snapshot(ctx, values, services, progress)
+	if err != nil {
+		saveProgress(ctx, services, progress)
+		return nil, err
+	}
+	if err := services.State.RemoveProgress(ctx, progress); err != nil {
+		services.Logger.Error("failed to remove progress of %q: %q", values.Instance, err)
+	}
+	return output, nil
+}
+
+// newProgress returns a progress record that isn't stored.
+func newProgress() *services.ProgressRecord {
+	return &services.ProgressRecord{}
+}
+
+// saveProgress stores the steps completed before a failure. A detached context is used since the
+// failure may be ctx running out.
+func saveProgress(ctx context.Context, svcs *Services, progress *services.ProgressRecord) {
+	ctx, cancel := services.Detach(ctx)
+	defer cancel()
+	if err := svcs.State.SaveProgress(ctx, progress); err != nil {
+		svcs.Logger.Error("failed to save progress of %q: %q", progress.Resource, err)
+	}
+}
+
+// snapshot creates the snapshots, skipping the steps completed by an earlier attempt and marking
+// each step in progress as it completes.
+func snapshot(ctx context.Context, values *Values, services *Services, progress *services.ProgressRecord) (*Output, error) {
 	var output Output
 	log.Printf("listing disk names within instance %q, in zone %q and project %q", values.Instance, values.Zone, values.ProjectID)
 	disksCopied := []string{}
+	snapshotsCreated := []string{}
 	rule := strings.Replace(values.RuleName, "_", "-", -1)
 	disks, err := services.Host.ListInstanceDisks(ctx, values.ProjectID, values.Zone, values.Instance)
 	if err != nil {
@@ -97,53 +167,105 @@ func Execute(ctx context.Context, values *Values, services *Services) (*Output,
 
 	for _, disk := range disks {
 		snapshotName := createSnapshotName(rule, disk.Name)
-		create, removeExisting, err := canCreateSnapshot(snapshots, disk, rule)
-		if err != nil {
-			return nil, errors.Wrapf(err, "failed checking if can create snapshot for %q", disk.Name)
-		}
+		if progress.Done(snapshotStep(disk.Name)) {
+			log.Printf("snapshot %q for disk %q was created by an earlier attempt", snapshotName, disk.Name)
+		} else {
+			create, removeExisting, err := canCreateSnapshot(snapshots, disk, rule)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed checking if can create snapshot for %q", disk.Name)
+			}
 
-		if !create {
-			log.Printf("snapshot %q for disk %q will be skipped (not old enough or from another finding)", snapshotName, disk.Name)
-			continue
-		}
+			if !create {
+				log.Printf("snapshot %q for disk %q will be skipped (not old enough or from another finding)", snapshotName, disk.Name)
+				continue
+			}
 
-		if values.DryRun {
-			services.Logger.Info("dry_run on, would created a snapshot of %q from %q", disk.Name, values.ProjectID)
-			continue
-		}
+			if values.DryRun {
+				services.Logger.Info("dry_run on, would created a snapshot of %q from %q", disk.Name, values.ProjectID)
+				continue
+			}
 
-		for k := range removeExisting {
-			if err := services.Host.DeleteDiskSnapshot(ctx, values.ProjectID, k); err != nil {
-				return nil, errors.Wrapf(err, "failed deleting snapshot: %q", k)
+			for k := range removeExisting {
+				if err := services.Host.DeleteDiskSnapshot(ctx, values.ProjectID, k); err != nil {
+					return nil, errors.Wrapf(err, "failed deleting snapshot: %q", k)
+				}
+				services.Logger.Info("removed existing snapshot %q from disk %q", k, disk.Name)
 			}
-			services.Logger.Info("removed existing snapshot %q from disk %q", k, disk.Name)
-		}
 
-		log.Printf("creating a snapshot %q for %q", snapshotName, disk.Name)
-		if err := services.Host.CreateDiskSnapshot(ctx, values.ProjectID, values.Zone, disk.Name, snapshotName); err != nil {
-			return nil, errors.Wrapf(err, "failed creating snapshot: %q", snapshotName)
-		}
-		services.Logger.Info("created snapshot for disk %q", disk.Name)
+			if async(values, services) {
+				if err := start(ctx, values, services, disk.Name, snapshotName); err != nil {
+					return nil, err
+				}
+				progress.Complete(snapshotStep(disk.Name))
+				snapshotsCreated = append(snapshotsCreated, snapshotName)
+				continue
+			}
 
-		if err := services.Host.SetSnapshotLabels(ctx, values.ProjectID, snapshotName, disk, labels); err != nil {
-			return nil, errors.Wrapf(err, "failed setting labels: %q", snapshotName)
+			log.Printf("creating a snapshot %q for %q", snapshotName, disk.Name)
+			if err := services.Host.CreateDiskSnapshot(ctx, values.ProjectID, values.Zone, disk.Name, snapshotName); err != nil {
+				return nil, errors.Wrapf(err, "failed creating snapshot: %q", snapshotName)
+			}
+			services.Logger.Info("created snapshot for disk %q", disk.Name)
+
+			if err := services.Host.SetSnapshotLabels(ctx, values.ProjectID, snapshotName, disk, labels); err != nil {
+				return nil, errors.Wrapf(err, "failed setting labels: %q", snapshotName)
+			}
+			log.Printf("set labels for snapshot %q for disk %q", snapshotName, disk.Name)
+			progress.Complete(snapshotStep(disk.Name))
 		}
-		log.Printf("set labels for snapshot %q for disk %q", snapshotName, disk.Name)
+		snapshotsCreated = append(snapshotsCreated, snapshotName)
 
 		if values.DestProjectID != "" {
-			log.Printf("copying snapshot %q for %q to %q in %q", snapshotName, disk.Name, values.DestProjectID, values.DestZone)
-			if err := services.Host.CopyDiskSnapshot(ctx, values.ProjectID, values.DestProjectID, values.DestZone, snapshotName); err != nil {
-				return nil, errors.Wrapf(err, "failed to copy disk to %q", values.DestProjectID)
+			if !progress.Done(copyStep(disk.Name)) {
+				log.Printf("copying snapshot %q for %q to %q in %q", snapshotName, disk.Name, values.DestProjectID, values.DestZone)
+				if err := services.Host.CopyDiskSnapshot(ctx, values.ProjectID, values.DestProjectID, values.DestZone, snapshotName); err != nil {
+					return nil, errors.Wrapf(err, "failed to copy disk to %q", values.DestProjectID)
+				}
+				services.Logger.Info("copied snapshot %q to %q in %q", snapshotName, values.DestProjectID, values.DestZone)
+				progress.Complete(copyStep(disk.Name))
 			}
 			disksCopied = append(disksCopied, snapshotName)
-			services.Logger.Info("copied snapshot %q to %q in %q", snapshotName, values.DestProjectID, values.DestZone)
 		}
 	}
 	log.Printf("completed")
 	output.DiskNames = disksCopied
+	output.SnapshotNames = snapshotsCreated
 	return &output, nil
 }
 
+// async returns whether snapshots are started without waiting for them. Copies and outputs need the
+// snapshots to be complete so they're waited for if any are requested.
+func async(values *Values, svcs *Services) bool {
+	return svcs.State != nil && values.DestProjectID == "" && len(values.Output) == 0
+}
+
+// start creates the labeled snapshot without waiting for it to complete. A snapshot already in
+// progress from an earlier delivery of the same finding is left to complete.
+func start(ctx context.Context, values *Values, svcs *Services, disk, snapshotName string) error {
+	pending, err := svcs.State.PendingOperation(ctx, action, values.ProjectID, snapshotName)
+	if err != nil {
+		return err
+	}
+	if pending != nil {
+		svcs.Logger.Info("snapshot %q of disk %q already in progress with operation %q", snapshotName, disk, pending.Operation)
+		return nil
+	}
+	op, err := svcs.Host.StartDiskSnapshot(ctx, values.ProjectID, values.Zone, disk, snapshotName, labels)
+	if err != nil {
+		return errors.Wrapf(err, "failed creating snapshot: %q", snapshotName)
+	}
+	message := fmt.Sprintf("snapshot of disk %q created in project %q", disk, values.ProjectID)
+	if _, err := svcs.State.RecordOperation(ctx, action, values.ProjectID, snapshotName, services.OperationComputeZone, op, message); err != nil {
+		return err
+	}
+	svcs.Logger.Info("started snapshot %q of disk %q with operation %q", snapshotName, disk, op)
+	return nil
+}
+
+// snapshotStep and copyStep name the progress steps of snapshotting a disk and copying its snapshot.
+func snapshotStep(disk string) string { return "snapshot/" + disk }
+func copyStep(disk string) string     { return "copy/" + disk }
+
 // canCreateSnapshot checks if we should create a snapshot along with a map of existing snapshots to be removed.
 func canCreateSnapshot(snapshots *compute.SnapshotList, disk *compute.Disk, rule string) (bool, map[string]bool, error) {
 	create := true
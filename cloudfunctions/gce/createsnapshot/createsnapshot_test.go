@@ -203,6 +203,56 @@ func TestCreateSnapshot(t *testing.T) {
 	}
 }
 
+func TestCreateSnapshotResumes(t *testing.T) {
+	ctx := context.Background()
+	const snapshotName = "forensic-snapshots-bad-ip-sample-disk-name"
+	svcs, computeStub := createSnapshotSetup()
+	computeStub.StubbedListDisks = &compute.DiskList{Items: []*compute.Disk{createDisk("sample-disk-name", "instance1")}}
+	// Snapshot lists are returned last first: the first attempt's check, its labeling, then the retry.
+	created := &compute.SnapshotList{Items: []*compute.Snapshot{createSs(snapshotName, time.Now().Format(time.RFC3339), "sample-disk-name")}}
+	computeStub.StubbedListProjectSnapshots = []*compute.SnapshotList{created, created, {}}
+	computeStub.DiskInsertError = context.DeadlineExceeded
+	storageStub := &stubs.StorageStub{}
+	state := services.NewState(storageStub, "state-bucket")
+	values := &Values{
+		ProjectID:     "project-id-123",
+		DestProjectID: "foo-project-123",
+		RuleName:      "bad_ip",
+		Instance:      "instance1",
+		Zone:          "test-zone",
+	}
+	execute := func() (*Output, error) {
+		return Execute(ctx, values, &Services{Host: svcs.Host, Logger: svcs.Logger, State: state})
+	}
+
+	if _, err := execute(); !services.IsDeadlineExceeded(err) {
+		t.Fatalf("first attempt got error %q want deadline exceeded", err)
+	}
+	progress, err := state.Progress(ctx, action, values.ProjectID, values.Instance)
+	if err != nil {
+		t.Fatalf("failed to read progress: %q", err)
+	}
+	if diff := cmp.Diff(progress.Steps, []string{"snapshot/sample-disk-name"}); diff != "" {
+		t.Errorf("progress steps differ (-got +want):\n%s", diff)
+	}
+
+	computeStub.DiskInsertError = nil
+	computeStub.SavedCreateSnapshots = make(map[string]compute.Snapshot)
+	output, err := execute()
+	if err != nil {
+		t.Fatalf("retry failed: %q", err)
+	}
+	if len(computeStub.SavedCreateSnapshots) != 0 {
+		t.Errorf("retry created snapshots again: %v", computeStub.SavedCreateSnapshots)
+	}
+	if diff := cmp.Diff(output, &Output{DiskNames: []string{snapshotName}, SnapshotNames: []string{snapshotName}}); diff != "" {
+		t.Errorf("output differs (-got +want):\n%s", diff)
+	}
+	if len(storageStub.WrittenObjects) != 0 {
+		t.Errorf("progress record not removed: %v", storageStub.WrittenObjects)
+	}
+}
+
 func createDisk(name, instance string) *compute.Disk {
 	return &compute.Disk{
 		Name:     name,
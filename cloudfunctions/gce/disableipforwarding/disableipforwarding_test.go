@@ -0,0 +1,61 @@
+package disableipforwarding
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"testing"
+
+	compute "google.golang.org/api/compute/v1"
+
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+	"github.com/googlecloudplatform/security-response-automation/services"
+)
+
+func TestDisableIPForwarding(t *testing.T) {
+	ctx := context.Background()
+	for _, tt := range []struct {
+		name         string
+		canIPForward bool
+		dryRun       bool
+		updated      bool
+	}{
+		{name: "disable ip forwarding", canIPForward: true, updated: true},
+		{name: "already disabled", canIPForward: false, updated: false},
+		{name: "dry run", canIPForward: true, dryRun: true, updated: false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			computeStub := &stubs.ComputeStub{StubbedInstance: &compute.Instance{CanIpForward: tt.canIPForward}}
+			values := &Values{
+				ProjectID:    "project-id",
+				InstanceZone: "instance-zone",
+				InstanceID:   "instance-id",
+				DryRun:       tt.dryRun,
+			}
+			if _, err := Execute(ctx, values, &Services{
+				Host:   services.NewHost(computeStub),
+				Logger: services.NewLogger(&stubs.LoggerStub{}),
+			}); err != nil {
+				t.Fatalf("%s failed to disable ip forwarding: %q", tt.name, err)
+			}
+			if updated := computeStub.SavedCanIPForward != nil; updated != tt.updated {
+				t.Fatalf("%s failed: got updated %t, want %t", tt.name, updated, tt.updated)
+			}
+			if tt.updated && *computeStub.SavedCanIPForward {
+				t.Errorf("%s failed: ip forwarding was not disabled", tt.name)
+			}
+		})
+	}
+}
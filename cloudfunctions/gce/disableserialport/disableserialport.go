@@ -0,0 +1,57 @@
+package disableserialport
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+
+	"github.com/googlecloudplatform/security-response-automation/services"
+	"github.com/pkg/errors"
+)
+
+// Values contains the required values needed for this function.
+type Values struct {
+	ProjectID, InstanceZone, InstanceID string
+	DryRun                              bool
+}
+
+// Services contains the services needed for this function.
+type Services struct {
+	Host   *services.Host
+	Logger *services.Logger
+}
+
+// Execute disables interactive serial port access on a GCE instance.
+func Execute(ctx context.Context, values *Values, svcs *Services) (*services.Result, error) {
+	result := services.NewResult("disable_serial_port", values.DryRun)
+	var err error
+	if values.InstanceZone, err = svcs.Host.InstanceZone(ctx, values.ProjectID, values.InstanceZone, values.InstanceID); err != nil {
+		return nil, err
+	}
+	disabled, err := svcs.Host.SerialPortDisabled(ctx, values.ProjectID, values.InstanceZone, values.InstanceID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to check serial port access")
+	}
+	if disabled {
+		return result.Skip(values.InstanceID, "instance %q in zone %q in project %q has serial port access disabled", values.InstanceID, values.InstanceZone, values.ProjectID), nil
+	}
+	if values.DryRun {
+		return result.Touch(values.InstanceID), nil
+	}
+	if err := svcs.Host.DisableSerialPort(ctx, values.ProjectID, values.InstanceZone, values.InstanceID); err != nil {
+		return nil, errors.Wrap(err, "failed to disable serial port access")
+	}
+	return result.Touch(values.InstanceID), nil
+}
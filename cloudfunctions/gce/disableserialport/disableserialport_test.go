@@ -0,0 +1,111 @@
+package disableserialport
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	compute "google.golang.org/api/compute/v1"
+
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+	"github.com/googlecloudplatform/security-response-automation/services"
+)
+
+func TestDisableSerialPort(t *testing.T) {
+	ctx := context.Background()
+	enabled, disabled, startup := "true", "false", "echo hello"
+	for _, tt := range []struct {
+		name     string
+		instance *compute.Instance
+		dryRun   bool
+		expected *compute.Metadata
+	}{
+		{
+			name: "disable serial port",
+			instance: &compute.Instance{Metadata: &compute.Metadata{
+				Fingerprint: "abc",
+				Items:       []*compute.MetadataItems{{Key: "serial-port-enable", Value: &enabled}, {Key: "startup-script", Value: &startup}},
+			}},
+			expected: &compute.Metadata{
+				Fingerprint: "abc",
+				Items:       []*compute.MetadataItems{{Key: "serial-port-enable", Value: &disabled}, {Key: "startup-script", Value: &startup}},
+			},
+		},
+		{
+			name:     "enabled by project metadata",
+			instance: &compute.Instance{},
+			expected: &compute.Metadata{Items: []*compute.MetadataItems{{Key: "serial-port-enable", Value: &disabled}}},
+		},
+		{
+			name: "already disabled",
+			instance: &compute.Instance{Metadata: &compute.Metadata{
+				Items: []*compute.MetadataItems{{Key: "serial-port-enable", Value: &disabled}},
+			}},
+			expected: nil,
+		},
+		{
+			name: "dry run",
+			instance: &compute.Instance{Metadata: &compute.Metadata{
+				Items: []*compute.MetadataItems{{Key: "serial-port-enable", Value: &enabled}},
+			}},
+			dryRun:   true,
+			expected: nil,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			computeStub := &stubs.ComputeStub{StubbedInstance: tt.instance}
+			values := &Values{
+				ProjectID:    "project-id",
+				InstanceZone: "instance-zone",
+				InstanceID:   "instance-id",
+				DryRun:       tt.dryRun,
+			}
+			if _, err := Execute(ctx, values, &Services{
+				Host:   services.NewHost(computeStub),
+				Logger: services.NewLogger(&stubs.LoggerStub{}),
+			}); err != nil {
+				t.Fatalf("%s failed to disable serial port: %q", tt.name, err)
+			}
+			if diff := cmp.Diff(tt.expected, computeStub.SavedMetadata); diff != "" {
+				t.Errorf("%v failed, difference: %+v", tt.name, diff)
+			}
+		})
+	}
+}
+
+func TestDisableSerialPortFindsZone(t *testing.T) {
+	enabled := "true"
+	computeStub := &stubs.ComputeStub{
+		StubbedInstance: &compute.Instance{Metadata: &compute.Metadata{
+			Items: []*compute.MetadataItems{{Key: "serial-port-enable", Value: &enabled}},
+		}},
+		StubbedInstancesInAllZones: []*compute.Instance{
+			{Name: "instance-id", Zone: "https://www.googleapis.com/compute/v1/projects/project-id/zones/europe-west1-b"},
+		},
+	}
+	values := &Values{ProjectID: "project-id", InstanceID: "instance-id"}
+	if _, err := Execute(context.Background(), values, &Services{
+		Host:   services.NewHost(computeStub),
+		Logger: services.NewLogger(&stubs.LoggerStub{}),
+	}); err != nil {
+		t.Fatalf("failed to disable serial port: %q", err)
+	}
+	calls := computeStub.Requested("SetMetadata")
+	if len(calls) != 1 || calls[0].Args[1] != "europe-west1-b" {
+		t.Errorf("metadata set by %+v, expected in zone europe-west1-b", calls)
+	}
+}
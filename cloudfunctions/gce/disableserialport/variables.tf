@@ -0,0 +1,6 @@
+variable "setup" {}
+
+variable "folder-ids" {
+  type        = list(string)
+  description = "Folder IDs to grant the necessary permissions for this Cloud Function execution."
+}
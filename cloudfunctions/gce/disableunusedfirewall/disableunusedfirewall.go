@@ -0,0 +1,102 @@
+// Package disableunusedfirewall disables firewall rules Firewall Insights found shadowed or unused.
+package disableunusedfirewall
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/googlecloudplatform/security-response-automation/services"
+)
+
+// Firewall Insights subtypes of rules that can be disabled without changing the traffic allowed.
+const (
+	// ShadowedRule is a rule whose traffic is entirely matched by higher priority rules.
+	ShadowedRule = "SHADOWED_RULE"
+	// UnusedRule is an allow rule that had no hits during the observation period.
+	UnusedRule = "UNUSED_ALLOW_RULE"
+)
+
+// DefaultSubtypes are the insight subtypes a rule is disabled for if none are configured.
+var DefaultSubtypes = []string{ShadowedRule, UnusedRule}
+
+// Values contains the required values needed for this function.
+type Values struct {
+	ProjectID  string
+	FirewallID string
+	// Subtypes are the Firewall Insights subtypes the rule is disabled for, DefaultSubtypes if
+	// empty.
+	Subtypes []string
+	DryRun   bool
+}
+
+// Services contains the services needed for this function.
+type Services struct {
+	Firewall *services.Firewall
+	Insights *services.Insights
+	Logger   *services.Logger
+}
+
+// Execute disables an overly permissive firewall rule if Firewall Insights reports it's shadowed
+// by other rules or had no hits, so disabling it doesn't break any traffic. Rules without such an
+// insight are left to the remediate_firewall automation. The insights acted on are marked
+// accepted.
+func Execute(ctx context.Context, values *Values, svcs *Services) (*services.Result, error) {
+	result := services.NewResult("disable_unused_firewall", values.DryRun)
+	subtypes := values.Subtypes
+	if len(subtypes) == 0 {
+		subtypes = DefaultSubtypes
+	}
+	r, err := svcs.Firewall.FirewallRule(ctx, values.ProjectID, values.FirewallID)
+	if services.IsNotFound(err) {
+		return result.Skip(values.FirewallID, "firewall %q in project %q already deleted", values.FirewallID, values.ProjectID), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if r.Disabled {
+		return result.Skip(values.FirewallID, "firewall %q in project %q already disabled", r.Name, values.ProjectID), nil
+	}
+	found, err := svcs.Insights.FirewallInsights(ctx, values.ProjectID, r.Name, subtypes)
+	if err != nil {
+		return nil, err
+	}
+	if len(found) == 0 {
+		return result.Ignore(values.FirewallID, "firewall %q in project %q has no %s insight", r.Name, values.ProjectID, strings.Join(subtypes, " or ")), nil
+	}
+	flagged := []string{}
+	for _, in := range found {
+		flagged = append(flagged, in.InsightSubtype)
+	}
+	result.Message = fmt.Sprintf("firewall %q flagged as %s by Firewall Insights", r.Name, strings.Join(flagged, ", "))
+	if values.DryRun {
+		return result.Touch(values.FirewallID), nil
+	}
+	op, err := svcs.Firewall.DisableFirewallRule(ctx, values.ProjectID, values.FirewallID, r.Name)
+	if err != nil {
+		return nil, err
+	}
+	if errs := svcs.Firewall.WaitGlobal(ctx, values.ProjectID, op); len(errs) > 0 {
+		return nil, errs[0]
+	}
+	for _, in := range found {
+		if err := svcs.Insights.Accept(ctx, in); err != nil {
+			svcs.Logger.Warning("disabled firewall %q but %q", r.Name, err)
+		}
+	}
+	return result.Touch(values.FirewallID), nil
+}
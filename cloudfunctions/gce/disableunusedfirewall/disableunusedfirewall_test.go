@@ -0,0 +1,104 @@
+package disableunusedfirewall
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googlecloudplatform/security-response-automation/clients/insights"
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+	"github.com/googlecloudplatform/security-response-automation/services"
+	compute "google.golang.org/api/compute/v1"
+)
+
+func TestDisableUnusedFirewall(t *testing.T) {
+	const target = "//compute.googleapis.com/projects/test-project/global/firewalls/allow-all"
+	shadowed := &insights.Insight{Name: "insights/1", InsightSubtype: ShadowedRule, TargetResources: []string{target}}
+	unused := &insights.Insight{Name: "insights/2", InsightSubtype: UnusedRule, TargetResources: []string{target}}
+	otherRule := &insights.Insight{Name: "insights/3", InsightSubtype: ShadowedRule, TargetResources: []string{"//compute.googleapis.com/projects/test-project/global/firewalls/other"}}
+	for _, tt := range []struct {
+		name           string
+		disabled       bool
+		insights       []*insights.Insight
+		subtypes       []string
+		dryRun         bool
+		expected       *compute.Firewall
+		expectedResult string
+		accepted       []string
+	}{
+		{
+			name:           "shadowed",
+			insights:       []*insights.Insight{shadowed, otherRule},
+			expected:       &compute.Firewall{Name: "allow-all", Disabled: true},
+			expectedResult: services.AuditResultSuccess,
+			accepted:       []string{"insights/1"},
+		},
+		{
+			name:           "unused",
+			insights:       []*insights.Insight{unused},
+			expected:       &compute.Firewall{Name: "allow-all", Disabled: true},
+			expectedResult: services.AuditResultSuccess,
+			accepted:       []string{"insights/2"},
+		},
+		{
+			name:           "subtype not configured",
+			insights:       []*insights.Insight{unused},
+			subtypes:       []string{ShadowedRule},
+			expectedResult: services.AuditResultSkipped,
+		},
+		{
+			name:           "in use",
+			insights:       []*insights.Insight{otherRule},
+			expectedResult: services.AuditResultSkipped,
+		},
+		{
+			name:           "already disabled",
+			disabled:       true,
+			insights:       []*insights.Insight{shadowed},
+			expectedResult: services.AuditResultAlreadyRemediated,
+		},
+		{
+			name:           "dry run",
+			insights:       []*insights.Insight{shadowed},
+			dryRun:         true,
+			expectedResult: services.AuditResultDryRun,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			computeStub := &stubs.ComputeStub{StubbedFirewall: &compute.Firewall{Id: 123, Name: "allow-all", Disabled: tt.disabled}}
+			recommenderStub := &stubs.RecommenderStub{StubbedInsights: tt.insights}
+			values := &Values{ProjectID: "test-project", FirewallID: "123", Subtypes: tt.subtypes, DryRun: tt.dryRun}
+			r, err := Execute(context.Background(), values, &Services{
+				Firewall: services.NewFirewall(computeStub),
+				Insights: services.NewInsights(recommenderStub),
+				Logger:   services.NewLogger(&stubs.LoggerStub{}),
+			})
+			if err != nil {
+				t.Fatalf("%s failed: %q", tt.name, err)
+			}
+			if diff := cmp.Diff(tt.expected, computeStub.SavedFirewallRule); diff != "" {
+				t.Errorf("%s failed, difference:%+v", tt.name, diff)
+			}
+			if status := r.Status(); status != tt.expectedResult {
+				t.Errorf("%s got result %q want %q", tt.name, status, tt.expectedResult)
+			}
+			if diff := cmp.Diff(tt.accepted, recommenderStub.Accepted); diff != "" {
+				t.Errorf("%s accepted insights difference:%+v", tt.name, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,6 @@
+variable "setup" {}
+
+variable "folder-ids" {
+  type        = list(string)
+  description = "Disable unused or shadowed firewall rules if they are within the given folder IDs."
+}
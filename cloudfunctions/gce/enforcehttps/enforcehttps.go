@@ -0,0 +1,107 @@
+package enforcehttps
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/googlecloudplatform/security-response-automation/services"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultSSLPolicy is the SSL policy attached when none is configured.
+	DefaultSSLPolicy = "sra-modern-tls"
+	// httpProxies are redirected instead since they don't serve TLS.
+	httpProxies = services.TargetHTTPProxies
+)
+
+// Values contains the required values needed for this function.
+type Values struct {
+	ProjectID string
+	// ProxyType is the collection of the target proxy, i.e. "targetHttpsProxies".
+	ProxyType, Proxy string
+	// SSLPolicy is attached to HTTPS and SSL proxies, it's created requiring TLS 1.2 if missing.
+	SSLPolicy string
+	// RedirectHTTP redirects the requests of HTTP proxies to HTTPS.
+	RedirectHTTP bool
+	DryRun       bool
+}
+
+// Services contains the services needed for this function.
+type Services struct {
+	LoadBalancer *services.LoadBalancer
+	Logger       *services.Logger
+}
+
+// Execute attaches an SSL policy requiring TLS 1.2 to HTTPS and SSL proxies and, if enabled,
+// redirects the requests of HTTP proxies to HTTPS.
+func Execute(ctx context.Context, values *Values, svcs *Services) (*services.Result, error) {
+	result := services.NewResult("enforce_https", values.DryRun)
+	if values.ProxyType == httpProxies {
+		return redirect(ctx, values, svcs, result)
+	}
+	policy := values.SSLPolicy
+	if policy == "" {
+		policy = DefaultSSLPolicy
+	}
+	current, err := svcs.LoadBalancer.ProxySSLPolicy(ctx, values.ProjectID, values.ProxyType, values.Proxy)
+	if err != nil {
+		return nil, err
+	}
+	if current != "" {
+		modern, err := svcs.LoadBalancer.ModernSSLPolicy(ctx, values.ProjectID, current)
+		if err != nil {
+			return nil, err
+		}
+		if modern {
+			return result.Skip(values.Proxy, "proxy %q in project %q already requires TLS 1.2", values.Proxy, values.ProjectID), nil
+		}
+	}
+	if values.DryRun {
+		return result.Touch(values.Proxy), nil
+	}
+	link, err := svcs.LoadBalancer.EnsureSSLPolicy(ctx, values.ProjectID, policy)
+	if err != nil {
+		return nil, err
+	}
+	if err := svcs.LoadBalancer.SetProxySSLPolicy(ctx, values.ProjectID, values.ProxyType, values.Proxy, link); err != nil {
+		return nil, err
+	}
+	return result.Touch(values.Proxy), nil
+}
+
+func redirect(ctx context.Context, values *Values, svcs *Services, result *services.Result) (*services.Result, error) {
+	if !values.RedirectHTTP {
+		return result.Ignore(values.Proxy, "redirect_http off, not redirecting http proxy %q in project %q to https", values.Proxy, values.ProjectID), nil
+	}
+	redirects, err := svcs.LoadBalancer.RedirectsToHTTPS(ctx, values.ProjectID, values.Proxy)
+	if err != nil {
+		return nil, err
+	}
+	if redirects {
+		return result.Skip(values.Proxy, "http proxy %q in project %q already redirects to https", values.Proxy, values.ProjectID), nil
+	}
+	if values.DryRun {
+		return result.Touch(values.Proxy), nil
+	}
+	previous, err := svcs.LoadBalancer.RedirectToHTTPS(ctx, values.ProjectID, values.Proxy)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to redirect to https")
+	}
+	result.Message = fmt.Sprintf("redirected http proxy %q to https, it previously used url map %q", values.Proxy, previous)
+	return result.Touch(values.Proxy), nil
+}
@@ -0,0 +1,164 @@
+package enforcehttps
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	compute "google.golang.org/api/compute/v1"
+
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+	"github.com/googlecloudplatform/security-response-automation/services"
+)
+
+const policyLink = "https://www.googleapis.com/compute/v1/projects/project-id/global/sslPolicies/"
+
+func TestEnforceSSLPolicy(t *testing.T) {
+	ctx := context.Background()
+	for _, tt := range []struct {
+		name         string
+		proxyType    string
+		current      string
+		policies     map[string]*compute.SslPolicy
+		sslPolicy    string
+		dryRun       bool
+		wantInserted []*compute.SslPolicy
+		wantAttached string
+		wantErr      bool
+	}{
+		{
+			name:         "create default policy",
+			proxyType:    services.TargetHTTPSProxies,
+			wantInserted: []*compute.SslPolicy{{Name: DefaultSSLPolicy, Description: "Requires TLS 1.2 or later.", Profile: "MODERN", MinTlsVersion: "TLS_1_2"}},
+			wantAttached: policyLink + DefaultSSLPolicy,
+		},
+		{
+			name:         "replace weak policy with configured policy",
+			proxyType:    services.TargetSSLProxies,
+			current:      policyLink + "weak",
+			policies:     map[string]*compute.SslPolicy{"weak": {Profile: "COMPATIBLE", MinTlsVersion: "TLS_1_0"}, "strict": {Profile: "RESTRICTED", MinTlsVersion: "TLS_1_2"}},
+			sslPolicy:    "strict",
+			wantAttached: policyLink + "strict",
+		},
+		{
+			name:      "already modern",
+			proxyType: services.TargetHTTPSProxies,
+			current:   policyLink + "strict",
+			policies:  map[string]*compute.SslPolicy{"strict": {Profile: "MODERN", MinTlsVersion: "TLS_1_2"}},
+		},
+		{
+			name:      "configured policy is weak",
+			proxyType: services.TargetHTTPSProxies,
+			policies:  map[string]*compute.SslPolicy{"weak": {Profile: "MODERN", MinTlsVersion: "TLS_1_1"}},
+			sslPolicy: "weak",
+			wantErr:   true,
+		},
+		{
+			name:      "dry run",
+			proxyType: services.TargetHTTPSProxies,
+			dryRun:    true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			computeStub := &stubs.ComputeStub{
+				StubbedSSLPolicies:      tt.policies,
+				StubbedTargetHTTPSProxy: &compute.TargetHttpsProxy{SslPolicy: tt.current},
+				StubbedTargetSSLProxy:   &compute.TargetSslProxy{SslPolicy: tt.current},
+			}
+			values := &Values{
+				ProjectID: "project-id",
+				ProxyType: tt.proxyType,
+				Proxy:     "proxy",
+				SSLPolicy: tt.sslPolicy,
+				DryRun:    tt.dryRun,
+			}
+			_, err := Execute(ctx, values, &Services{
+				LoadBalancer: services.NewLoadBalancer(computeStub),
+				Logger:       services.NewLogger(&stubs.LoggerStub{}),
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("%s failed: got err %v, want err %t", tt.name, err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.wantInserted, computeStub.InsertedSSLPolicies); diff != "" {
+				t.Errorf("%s failed, difference: %+v", tt.name, diff)
+			}
+			if computeStub.SavedProxySSLPolicy != tt.wantAttached {
+				t.Errorf("%s failed: got policy %q attached, want %q", tt.name, computeStub.SavedProxySSLPolicy, tt.wantAttached)
+			}
+		})
+	}
+}
+
+func TestRedirectHTTP(t *testing.T) {
+	ctx := context.Background()
+	redirect := &compute.UrlMap{DefaultUrlRedirect: &compute.HttpRedirectAction{HttpsRedirect: true}}
+	for _, tt := range []struct {
+		name         string
+		redirectHTTP bool
+		urlMaps      map[string]*compute.UrlMap
+		wantInserted int
+		wantURLMap   string
+	}{
+		{
+			name:         "redirect",
+			redirectHTTP: true,
+			urlMaps:      map[string]*compute.UrlMap{"web": {}},
+			wantInserted: 1,
+			wantURLMap:   "https://www.googleapis.com/compute/v1/projects/project-id/global/urlMaps/proxy-https-redirect",
+		},
+		{
+			name:         "reuse redirect url map",
+			redirectHTTP: true,
+			urlMaps:      map[string]*compute.UrlMap{"web": {}, "proxy-https-redirect": redirect},
+			wantURLMap:   "https://www.googleapis.com/compute/v1/projects/project-id/global/urlMaps/proxy-https-redirect",
+		},
+		{
+			name:         "already redirects",
+			redirectHTTP: true,
+			urlMaps:      map[string]*compute.UrlMap{"web": redirect},
+		},
+		{
+			name:    "redirect off",
+			urlMaps: map[string]*compute.UrlMap{"web": {}},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			computeStub := &stubs.ComputeStub{
+				StubbedURLMapsByName:   tt.urlMaps,
+				StubbedTargetHTTPProxy: &compute.TargetHttpProxy{UrlMap: "https://www.googleapis.com/compute/v1/projects/project-id/global/urlMaps/web"},
+			}
+			values := &Values{
+				ProjectID:    "project-id",
+				ProxyType:    services.TargetHTTPProxies,
+				Proxy:        "proxy",
+				RedirectHTTP: tt.redirectHTTP,
+			}
+			if _, err := Execute(ctx, values, &Services{
+				LoadBalancer: services.NewLoadBalancer(computeStub),
+				Logger:       services.NewLogger(&stubs.LoggerStub{}),
+			}); err != nil {
+				t.Fatalf("%s failed: %q", tt.name, err)
+			}
+			if len(computeStub.InsertedURLMaps) != tt.wantInserted {
+				t.Errorf("%s failed: got %d url maps created, want %d", tt.name, len(computeStub.InsertedURLMaps), tt.wantInserted)
+			}
+			if computeStub.SavedProxyURLMap != tt.wantURLMap {
+				t.Errorf("%s failed: got url map %q, want %q", tt.name, computeStub.SavedProxyURLMap, tt.wantURLMap)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package hardeninstance
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+
+	"github.com/googlecloudplatform/security-response-automation/services"
+	"github.com/pkg/errors"
+)
+
+// action is the automation name recorded when the instance is already hardened.
+const action = "harden_instance"
+
+// Values contains the required values needed for this function.
+type Values struct {
+	ProjectID, InstanceZone, InstanceID string
+	// DisableLegacyMetadata disables the legacy metadata server endpoints.
+	DisableLegacyMetadata bool
+	// EnableShieldedVM enables the Shielded VM options if the boot image supports them.
+	EnableShieldedVM bool
+	// Restart allows a running instance to be stopped to enable Shielded VM.
+	Restart bool
+	DryRun  bool
+}
+
+// Services contains the services needed for this function.
+type Services struct {
+	Host   *services.Host
+	Logger *services.Logger
+}
+
+// Execute disables the legacy metadata server endpoints and enables Shielded VM on a GCE instance.
+func Execute(ctx context.Context, values *Values, svcs *Services) (*services.Result, error) {
+	result := services.NewResult(action, values.DryRun)
+	var err error
+	if values.InstanceZone, err = svcs.Host.InstanceZone(ctx, values.ProjectID, values.InstanceZone, values.InstanceID); err != nil {
+		return nil, err
+	}
+	hardened := true
+	if values.DisableLegacyMetadata {
+		needed, err := disableLegacyMetadata(ctx, values, svcs.Host, svcs.Logger)
+		if err != nil {
+			return nil, err
+		}
+		hardened = hardened && !needed
+	}
+	if values.EnableShieldedVM {
+		needed, err := enableShieldedVM(ctx, values, svcs.Host, svcs.Logger)
+		if err != nil {
+			return nil, err
+		}
+		hardened = hardened && !needed
+	}
+	if hardened {
+		return result.Skip(values.InstanceID, "instance %q in zone %q in project %q is already hardened", values.InstanceID, values.InstanceZone, values.ProjectID), nil
+	}
+	return result.Touch(values.InstanceID), nil
+}
+
+// disableLegacyMetadata returns true if the legacy endpoints weren't already disabled.
+func disableLegacyMetadata(ctx context.Context, values *Values, host *services.Host, logr *services.Logger) (bool, error) {
+	disabled, err := host.LegacyMetadataDisabled(ctx, values.ProjectID, values.InstanceZone, values.InstanceID)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to check legacy metadata endpoints")
+	}
+	if disabled {
+		return false, nil
+	}
+	if values.DryRun {
+		logr.Info("dry_run on, would have disabled legacy metadata endpoints for instance %q, in zone %q in project %q.", values.InstanceID, values.InstanceZone, values.ProjectID)
+		return true, nil
+	}
+	if err := host.DisableLegacyMetadata(ctx, values.ProjectID, values.InstanceZone, values.InstanceID); err != nil {
+		return false, errors.Wrap(err, "failed to disable legacy metadata endpoints")
+	}
+	logr.Info("disabled legacy metadata endpoints for instance %q, in zone %q in project %q.", values.InstanceID, values.InstanceZone, values.ProjectID)
+	return true, nil
+}
+
+// enableShieldedVM returns true if Shielded VM wasn't already enabled. Instances whose boot image
+// doesn't support Shielded VM, or running instances that may not be restarted, are left as is.
+func enableShieldedVM(ctx context.Context, values *Values, host *services.Host, logr *services.Logger) (bool, error) {
+	state, err := host.ShieldedVMState(ctx, values.ProjectID, values.InstanceZone, values.InstanceID)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to check shielded vm")
+	}
+	switch {
+	case state.Enabled:
+		return false, nil
+	case !state.Supported:
+		logr.Warning("instance %q in zone %q in project %q boots from an image without Shielded VM support, recreate it from a shielded image", values.InstanceID, values.InstanceZone, values.ProjectID)
+		return true, nil
+	case state.Running && !values.Restart:
+		logr.Warning("instance %q in zone %q in project %q must be stopped to enable Shielded VM, set restart to allow it", values.InstanceID, values.InstanceZone, values.ProjectID)
+		return true, nil
+	}
+	if values.DryRun {
+		logr.Info("dry_run on, would have enabled Shielded VM for instance %q, in zone %q in project %q.", values.InstanceID, values.InstanceZone, values.ProjectID)
+		return true, nil
+	}
+	if err := host.EnableShieldedVM(ctx, values.ProjectID, values.InstanceZone, values.InstanceID); err != nil {
+		return false, errors.Wrap(err, "failed to enable shielded vm")
+	}
+	logr.Info("enabled Shielded VM for instance %q, in zone %q in project %q.", values.InstanceID, values.InstanceZone, values.ProjectID)
+	return true, nil
+}
@@ -0,0 +1,157 @@
+package hardeninstance
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	compute "google.golang.org/api/compute/v1"
+
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+	"github.com/googlecloudplatform/security-response-automation/services"
+)
+
+func TestDisableLegacyMetadata(t *testing.T) {
+	ctx := context.Background()
+	enabled, disabled, startup := "false", "true", "echo hello"
+	for _, tt := range []struct {
+		name     string
+		instance *compute.Instance
+		dryRun   bool
+		expected *compute.Metadata
+	}{
+		{
+			name: "disable legacy endpoints",
+			instance: &compute.Instance{Metadata: &compute.Metadata{
+				Fingerprint: "abc",
+				Items:       []*compute.MetadataItems{{Key: "disable-legacy-endpoints", Value: &enabled}, {Key: "startup-script", Value: &startup}},
+			}},
+			expected: &compute.Metadata{
+				Fingerprint: "abc",
+				Items:       []*compute.MetadataItems{{Key: "disable-legacy-endpoints", Value: &disabled}, {Key: "startup-script", Value: &startup}},
+			},
+		},
+		{
+			name:     "no metadata",
+			instance: &compute.Instance{},
+			expected: &compute.Metadata{Items: []*compute.MetadataItems{{Key: "disable-legacy-endpoints", Value: &disabled}}},
+		},
+		{
+			name: "already disabled",
+			instance: &compute.Instance{Metadata: &compute.Metadata{
+				Items: []*compute.MetadataItems{{Key: "disable-legacy-endpoints", Value: &disabled}},
+			}},
+			expected: nil,
+		},
+		{
+			name:     "dry run",
+			instance: &compute.Instance{},
+			dryRun:   true,
+			expected: nil,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			computeStub := &stubs.ComputeStub{StubbedInstance: tt.instance}
+			values := &Values{
+				ProjectID:             "project-id",
+				InstanceZone:          "instance-zone",
+				InstanceID:            "instance-id",
+				DisableLegacyMetadata: true,
+				DryRun:                tt.dryRun,
+			}
+			if _, err := Execute(ctx, values, &Services{
+				Host:   services.NewHost(computeStub),
+				Logger: services.NewLogger(&stubs.LoggerStub{}),
+			}); err != nil {
+				t.Fatalf("%s failed to disable legacy metadata endpoints: %q", tt.name, err)
+			}
+			if diff := cmp.Diff(tt.expected, computeStub.SavedMetadata); diff != "" {
+				t.Errorf("%v failed, difference: %+v", tt.name, diff)
+			}
+		})
+	}
+}
+
+func TestEnableShieldedVM(t *testing.T) {
+	ctx := context.Background()
+	uefi := []*compute.AttachedDisk{{Boot: true, GuestOsFeatures: []*compute.GuestOsFeature{{Type: "UEFI_COMPATIBLE"}}}}
+	shielded := &compute.ShieldedInstanceConfig{EnableSecureBoot: true, EnableVtpm: true, EnableIntegrityMonitoring: true}
+	for _, tt := range []struct {
+		name             string
+		instance         *compute.Instance
+		restart          bool
+		dryRun           bool
+		expected         *compute.ShieldedInstanceConfig
+		expectedRestarts bool
+	}{
+		{
+			name:     "stopped instance",
+			instance: &compute.Instance{Status: "TERMINATED", Disks: uefi},
+			expected: shielded,
+		},
+		{
+			name:             "restart running instance",
+			instance:         &compute.Instance{Status: "RUNNING", Disks: uefi},
+			restart:          true,
+			expected:         shielded,
+			expectedRestarts: true,
+		},
+		{
+			name:     "running instance without restart",
+			instance: &compute.Instance{Status: "RUNNING", Disks: uefi},
+		},
+		{
+			name:     "image without uefi",
+			instance: &compute.Instance{Status: "TERMINATED", Disks: []*compute.AttachedDisk{{Boot: true}}},
+		},
+		{
+			name:     "already enabled",
+			instance: &compute.Instance{Status: "RUNNING", Disks: uefi, ShieldedInstanceConfig: shielded},
+			restart:  true,
+		},
+		{
+			name:     "dry run",
+			instance: &compute.Instance{Status: "RUNNING", Disks: uefi},
+			restart:  true,
+			dryRun:   true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			computeStub := &stubs.ComputeStub{StubbedInstance: tt.instance}
+			values := &Values{
+				ProjectID:        "project-id",
+				InstanceZone:     "instance-zone",
+				InstanceID:       "instance-id",
+				EnableShieldedVM: true,
+				Restart:          tt.restart,
+				DryRun:           tt.dryRun,
+			}
+			if _, err := Execute(ctx, values, &Services{
+				Host:   services.NewHost(computeStub),
+				Logger: services.NewLogger(&stubs.LoggerStub{}),
+			}); err != nil {
+				t.Fatalf("%s failed to enable shielded vm: %q", tt.name, err)
+			}
+			if diff := cmp.Diff(tt.expected, computeStub.SavedShieldedInstanceConfig); diff != "" {
+				t.Errorf("%v failed, difference: %+v", tt.name, diff)
+			}
+			if computeStub.Stopped != tt.expectedRestarts || computeStub.Started != tt.expectedRestarts {
+				t.Errorf("%v failed, stopped:%t started:%t want:%t", tt.name, computeStub.Stopped, computeStub.Started, tt.expectedRestarts)
+			}
+		})
+	}
+}
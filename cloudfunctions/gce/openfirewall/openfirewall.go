@@ -16,19 +16,33 @@ package openfirewall
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/googlecloudplatform/security-response-automation/services"
 	"github.com/pkg/errors"
 )
 
+const (
+	// DisableContainment is the containment record action of a temporarily disabled firewall.
+	DisableContainment = "remediate_firewall_disable"
+	// UpdateRangeContainment is the containment record action of temporarily updated source ranges.
+	UpdateRangeContainment = "remediate_firewall_update_source_range"
+	// BlockContainment is the containment record action of temporarily blocked source ranges.
+	BlockContainment = "remediate_firewall_block"
+)
+
 // Values contains the required and optional values needed for this function.
 type Values struct {
 	Action       string
 	ProjectID    string
 	FirewallID   string
 	SourceRanges []string
-	DryRun       bool
+	// TTL optionally reverts the disable, update_source_range, block_ssh and block_rdp actions after
+	// this duration, i.e. "24h".
+	TTL    string
+	DryRun bool
 }
 
 // Services contains the services needed for this function.
@@ -36,76 +50,183 @@ type Services struct {
 	Firewall *services.Firewall
 	Resource *services.Resource
 	Logger   *services.Logger
+	// State is required when a TTL is set.
+	State *services.State
+}
+
+// Before is the state of the firewall rule saved before a temporary containment.
+type Before struct {
+	FirewallID   string   `json:"firewall_id"`
+	Name         string   `json:"name"`
+	SourceRanges []string `json:"source_ranges,omitempty"`
 }
 
 // Execute remediates an open firewall.
-func Execute(ctx context.Context, values *Values, services *Services) error {
+func Execute(ctx context.Context, values *Values, svcs *Services) (*services.Result, error) {
+	result := services.NewResult("remediate_firewall", values.DryRun)
+	if values.TTL != "" && svcs.State == nil {
+		return nil, errors.New("a state bucket must be configured to revert the firewall after its ttl")
+	}
+	if _, err := time.ParseDuration(values.TTL); values.TTL != "" && err != nil {
+		return nil, errors.Wrapf(err, "invalid ttl %q", values.TTL)
+	}
 	if values.DryRun {
-		services.Logger.Info("dry_run on, would have remediated firewall %q in project %q with action %q", values.FirewallID, values.ProjectID, values.Action)
-		return nil
+		return result.Touch(values.FirewallID), nil
 	}
 	switch action := values.Action; action {
-	case "block_ssh":
-		return blockSSH(ctx, services.Logger, services.Firewall, values)
+	case "block_ssh", "block_rdp":
+		return block(ctx, svcs.Firewall, svcs.State, values, result)
 	case "disable":
-		return disable(ctx, services.Logger, services.Firewall, values)
+		return disable(ctx, svcs.Firewall, svcs.State, values, result)
 	case "delete":
-		return delete(ctx, services.Logger, services.Firewall, values)
+		return delete(ctx, svcs.Firewall, values, result)
 	case "update_source_range":
-		return updateRange(ctx, services.Logger, services.Firewall, values)
+		return updateRange(ctx, svcs.Firewall, svcs.State, values, result)
 	default:
-		return fmt.Errorf("unknown open firewall remediation action: %q", action)
+		return nil, fmt.Errorf("unknown open firewall remediation action: %q", action)
 	}
 }
 
-func blockSSH(ctx context.Context, logr *services.Logger, fw *services.Firewall, values *Values) error {
-	if err := fw.BlockSSH(ctx, values.ProjectID, values.SourceRanges); err != nil {
-		return errors.Wrapf(err, "failed to block ssh on %q from %q", values.ProjectID, values.SourceRanges)
+// block adds the source ranges to the project's rule blocking SSH or RDP. Only the ranges added
+// are recorded so an expired block leaves the ranges of other findings in place.
+func block(ctx context.Context, fw *services.Firewall, state *services.State, values *Values, result *services.Result) (*services.Result, error) {
+	protocol, name, blockFn := "ssh", services.SSHBlockName, fw.BlockSSH
+	if values.Action == "block_rdp" {
+		protocol, name, blockFn = "rdp", services.RDPBlockName, fw.BlockRDP
+	}
+	rec, err := record(ctx, state, values, BlockContainment, &Before{FirewallID: name, Name: name, SourceRanges: values.SourceRanges})
+	if err != nil {
+		return nil, err
 	}
-	logr.Info("blocked ssh on %q from %q", values.ProjectID, values.SourceRanges)
-	return nil
+	if err := blockFn(ctx, values.ProjectID, values.SourceRanges); err != nil {
+		return nil, discard(ctx, state, rec, errors.Wrapf(err, "failed to block %s on %q from %q", protocol, values.ProjectID, values.SourceRanges))
+	}
+	result.Message = fmt.Sprintf("blocked %s on %q from %q", protocol, values.ProjectID, values.SourceRanges)
+	return result.Touch(name), nil
 }
 
-func disable(ctx context.Context, logr *services.Logger, fw *services.Firewall, values *Values) error {
+func disable(ctx context.Context, fw *services.Firewall, state *services.State, values *Values, result *services.Result) (*services.Result, error) {
 	r, err := fw.FirewallRule(ctx, values.ProjectID, values.FirewallID)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	op, err := fw.DisableFirewallRule(ctx, values.ProjectID, values.FirewallID, r.Name)
+	if r.Disabled {
+		return result.Skip(values.FirewallID, "firewall %q in project %q already disabled", r.Name, values.ProjectID), nil
+	}
+	rec, err := record(ctx, state, values, DisableContainment, &Before{FirewallID: values.FirewallID, Name: r.Name})
 	if err != nil {
-		return err
+		return nil, err
+	}
+	op, err := fw.DisableFirewallRule(ctx, values.ProjectID, values.FirewallID, r.Name)
+	if err == nil {
+		if errs := fw.WaitGlobal(ctx, values.ProjectID, op); len(errs) > 0 {
+			err = errs[0]
+		}
 	}
-	if errs := fw.WaitGlobal(values.ProjectID, op); len(errs) > 0 {
-		return errs[0]
+	if err != nil {
+		return nil, discard(ctx, state, rec, err)
 	}
-	logr.Info("disabled firewall %q in project %q.", r.Name, values.ProjectID)
-	return nil
+	return result.Touch(values.FirewallID), nil
 }
 
-func delete(ctx context.Context, logr *services.Logger, fw *services.Firewall, values *Values) error {
-	r, err := fw.FirewallRule(ctx, values.ProjectID, values.FirewallID)
+func delete(ctx context.Context, fw *services.Firewall, values *Values, result *services.Result) (*services.Result, error) {
+	_, err := fw.FirewallRule(ctx, values.ProjectID, values.FirewallID)
+	if services.IsNotFound(err) {
+		return result.Skip(values.FirewallID, "firewall %q in project %q already deleted", values.FirewallID, values.ProjectID), nil
+	}
 	if err != nil {
-		return err
+		return nil, err
 	}
 	op, err := fw.DeleteFirewallRule(ctx, values.ProjectID, values.FirewallID)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	if errs := fw.WaitGlobal(values.ProjectID, op); len(errs) > 0 {
-		return errs[0]
+	if errs := fw.WaitGlobal(ctx, values.ProjectID, op); len(errs) > 0 {
+		return nil, errs[0]
 	}
-	logr.Info("deleted firewall %q in project %q.", r.Name, values.ProjectID)
-	return nil
+	return result.Touch(values.FirewallID), nil
 }
 
-func updateRange(ctx context.Context, logr *services.Logger, fw *services.Firewall, values *Values) error {
+func updateRange(ctx context.Context, fw *services.Firewall, state *services.State, values *Values, result *services.Result) (*services.Result, error) {
 	r, err := fw.FirewallRule(ctx, values.ProjectID, values.FirewallID)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if sameRanges(r.SourceRanges, values.SourceRanges) {
+		return result.Skip(values.FirewallID, "firewall %q in project %q already limited to %q", r.Name, values.ProjectID, values.SourceRanges), nil
+	}
+	rec, err := record(ctx, state, values, UpdateRangeContainment, &Before{FirewallID: values.FirewallID, Name: r.Name, SourceRanges: r.SourceRanges})
+	if err != nil {
+		return nil, err
 	}
 	if err := fw.UpdateFirewallRuleSourceRange(ctx, values.ProjectID, values.FirewallID, r.Name, values.SourceRanges); err != nil {
+		return nil, discard(ctx, state, rec, err)
+	}
+	return result.Touch(values.FirewallID), nil
+}
+
+// Restore reverts an expired temporary containment of a firewall rule using its saved state.
+func Restore(ctx context.Context, fw *services.Firewall, r *services.ContainmentRecord) error {
+	var before Before
+	if err := json.Unmarshal(r.Before, &before); err != nil {
+		return errors.Wrap(err, "failed to unmarshal firewall state")
+	}
+	switch r.Action {
+	case DisableContainment:
+		op, err := fw.EnableFirewallRule(ctx, r.ProjectID, before.FirewallID, before.Name)
+		if err != nil {
+			return err
+		}
+		if errs := fw.WaitGlobal(ctx, r.ProjectID, op); len(errs) > 0 {
+			return errs[0]
+		}
+		return nil
+	case UpdateRangeContainment:
+		return fw.UpdateFirewallRuleSourceRange(ctx, r.ProjectID, before.FirewallID, before.Name, before.SourceRanges)
+	case BlockContainment:
+		return fw.Unblock(ctx, r.ProjectID, before.Name, before.SourceRanges)
+	default:
+		return fmt.Errorf("unknown firewall containment action %q", r.Action)
+	}
+}
+
+// record saves the state of the firewall before it's changed if the change should expire. The
+// record is saved first so a change is never made that cannot be reverted.
+func record(ctx context.Context, state *services.State, values *Values, action string, before *Before) (*services.ContainmentRecord, error) {
+	if values.TTL == "" {
+		return nil, nil
+	}
+	ttl, err := time.ParseDuration(values.TTL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid ttl %q", values.TTL)
+	}
+	return state.RecordContainment(ctx, action, values.ProjectID, before.FirewallID, ttl, before)
+}
+
+// discard removes the containment record of a change that failed and returns the change's error.
+func discard(ctx context.Context, state *services.State, rec *services.ContainmentRecord, err error) error {
+	if rec == nil {
 		return err
 	}
-	logr.Info("updated source range firewall %q in project %q.", r.Name, values.ProjectID)
-	return nil
+	if rerr := state.RemoveContainment(ctx, rec); rerr != nil {
+		return errors.Wrapf(err, "failed to remove containment record %q: %s", rec.ID, rerr)
+	}
+	return err
+}
+
+// sameRanges returns true if both slices contain the same ranges regardless of order.
+func sameRanges(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	ranges := make(map[string]bool)
+	for _, r := range a {
+		ranges[r] = true
+	}
+	for _, r := range b {
+		if !ranges[r] {
+			return false
+		}
+	}
+	return true
 }
@@ -16,7 +16,9 @@ package openfirewall
 
 import (
 	"context"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	compute "google.golang.org/api/compute/v1"
@@ -59,7 +61,7 @@ func TestBlockSSH(t *testing.T) {
 				SourceRanges: tt.sourceRanges,
 				Action:       "block_ssh",
 			}
-			if err := Execute(ctx, values, &Services{
+			if _, err := Execute(ctx, values, &Services{
 				Firewall: svcs.Firewall,
 				Resource: svcs.Resource,
 				Logger:   svcs.Logger,
@@ -72,6 +74,38 @@ func TestBlockSSH(t *testing.T) {
 		})
 	}
 }
+func TestBlockRDP(t *testing.T) {
+	ctx := context.Background()
+	svcs, computeStub := openFirewallSetup()
+	computeStub.FailNext("FirewallRule", stubs.APIError(http.StatusNotFound))
+	state := services.NewState(&stubs.StorageStub{}, "state-bucket")
+	values := &Values{
+		ProjectID:    "test-project",
+		SourceRanges: []string{"198.51.100.7/32"},
+		Action:       "block_rdp",
+		TTL:          "24h",
+	}
+	if _, err := Execute(ctx, values, &Services{Firewall: svcs.Firewall, Logger: svcs.Logger, State: state}); err != nil {
+		t.Fatalf("failed to block rdp: %q", err)
+	}
+	expected := &compute.Firewall{
+		Denied:       []*compute.FirewallDenied{{IPProtocol: "tcp", Ports: []string{"3389"}}},
+		Description:  "Block RDP TCP port 3389 by Security Response Automation",
+		Name:         "automatic-rdp-block",
+		SourceRanges: []string{"198.51.100.7/32"},
+	}
+	if diff := cmp.Diff(expected, computeStub.SavedFirewallRule); diff != "" {
+		t.Errorf("unexpected firewall rule (-want +got):\n%s", diff)
+	}
+	expired, err := state.ExpiredContainments(ctx, time.Now().Add(25*time.Hour))
+	if err != nil {
+		t.Fatalf("failed to list containments: %q", err)
+	}
+	if len(expired) != 1 || expired[0].Action != BlockContainment || expired[0].Resource != "automatic-rdp-block" {
+		t.Errorf("unexpected containment records: %+v", expired)
+	}
+}
+
 func TestOpenFirewall(t *testing.T) {
 	ctx := context.Background()
 	test := []struct {
@@ -114,7 +148,7 @@ func TestOpenFirewall(t *testing.T) {
 				Action:       tt.remediationAction,
 				SourceRanges: tt.sourceRange,
 			}
-			if err := Execute(ctx, values, &Services{
+			if _, err := Execute(ctx, values, &Services{
 				Firewall: svcs.Firewall,
 				Resource: svcs.Resource,
 				Logger:   svcs.Logger,
@@ -0,0 +1,64 @@
+# Copyright 2020 Google LLC
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+# 	https://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+resource "google_cloudfunctions_function" "remove-load-balancer" {
+  name                  = "RemoveLoadBalancer"
+  description           = "Removes external load balancers exposing a compromised instance once approved."
+  runtime               = "go121"
+  available_memory_mb   = 128
+  source_archive_bucket = var.setup.gcf-bucket-name
+  source_archive_object = var.setup.gcf-object-name
+  timeout               = 360
+  project               = var.setup.automation-project
+  region                = var.setup.region
+  entry_point           = "RemoveLoadBalancer"
+
+  event_trigger {
+    event_type = "providers/cloud.pubsub/eventTypes/topic.publish"
+    resource   = "threat-findings-remove-load-balancer"
+
+    failure_policy {
+      retry = true
+    }
+  }
+
+  environment_variables = {
+    APPROVAL_TOPIC = var.setup.approval-topic
+    APPROVERS      = join(",", var.approvers)
+  }
+}
+
+# Required to retrieve ancestry for projects within this folder.
+resource "google_folder_iam_member" "roles-viewer" {
+  count = length(var.folder-ids)
+
+  folder = "folders/${var.folder-ids[count.index]}"
+  role   = "roles/viewer"
+  member = "serviceAccount:${var.setup.automation-service-account}"
+}
+
+# Required to delete forwarding rules and update URL maps within this folder.
+resource "google_folder_iam_member" "roles-load-balancer-admin" {
+  count = length(var.folder-ids)
+
+  folder = "folders/${var.folder-ids[count.index]}"
+  role   = "roles/compute.loadBalancerAdmin"
+  member = "serviceAccount:${var.setup.automation-service-account}"
+}
+
+# PubSub topic to trigger this automation. Approvals are recorded by the Approve function, never read
+# from its messages.
+resource "google_pubsub_topic" "topic" {
+  name    = "threat-findings-remove-load-balancer"
+  project = var.setup.automation-project
+}
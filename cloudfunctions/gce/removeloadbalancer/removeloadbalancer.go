@@ -0,0 +1,138 @@
+// Package removeloadbalancer removes external load balancers exposing a compromised instance.
+package removeloadbalancer
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/googlecloudplatform/security-response-automation/services"
+	"github.com/pkg/errors"
+)
+
+const (
+	// action is the automation name used to derive approval request IDs.
+	action = "remove_load_balancer"
+	// Topic is the Pub/Sub topic that triggers this automation.
+	Topic = "threat-findings-remove-load-balancer"
+	// ModeDelete deletes the external forwarding rules exposing the instance.
+	ModeDelete = "delete"
+	// ModeDetach routes the URL maps' traffic to a quarantine backend service instead.
+	ModeDetach = "detach"
+	// requiredApprovals is the number of distinct approvers needed before removing a load balancer.
+	requiredApprovals = 1
+)
+
+// Values contains the required values needed for this function.
+type Values struct {
+	ProjectID, Zone, Instance string
+	// Mode is either "delete" or "detach".
+	Mode string
+	// QuarantineBackendService is the backend service traffic is sent to when detaching.
+	QuarantineBackendService string
+	DryRun                   bool
+}
+
+// Services contains the services needed for this function.
+type Services struct {
+	LoadBalancer *services.LoadBalancer
+	Approval     *services.Approval
+	Logger       *services.Logger
+}
+
+// Execute removes the external HTTP(S) load balancers exposing a compromised instance.
+//
+// Taking a service offline has a large impact so nothing is changed until an approver approves
+// the exact forwarding rules or URL maps to change. The load balancers are looked up again each
+// time so an approval never applies to a configuration that has since changed. No result is returned
+// while the change awaits approval.
+func Execute(ctx context.Context, values *Values, svcs *Services) (*services.Result, error) {
+	result := services.NewResult(action, values.DryRun)
+	if values.Mode != ModeDelete && values.Mode != ModeDetach {
+		return nil, fmt.Errorf("unknown mode %q", values.Mode)
+	}
+	if values.Mode == ModeDetach && values.QuarantineBackendService == "" {
+		return nil, errors.New("must provide a quarantine backend service to detach")
+	}
+	exposure, err := svcs.LoadBalancer.InstanceExposure(ctx, values.ProjectID, values.Zone, values.Instance)
+	if err != nil {
+		return nil, err
+	}
+	if len(exposure.ForwardingRules) == 0 {
+		return result.Skip(resource(values), "instance %q in project %q is not exposed by an external load balancer", values.Instance, values.ProjectID), nil
+	}
+	changes := toChange(values.Mode, exposure)
+	id := ApprovalID(values, changes)
+	if err := svcs.Approval.Approved(ctx, id, requiredApprovals); err != nil {
+		svcs.Logger.Info("changing %q requires approval: %s", changes, err)
+		return nil, requestApproval(ctx, svcs.Approval, id, changes, values)
+	}
+	result.Message = fmt.Sprintf("mode %q changed %q", values.Mode, changes)
+	if values.DryRun {
+		return result.Touch(resource(values)), nil
+	}
+	switch values.Mode {
+	case ModeDelete:
+		err = svcs.LoadBalancer.DeleteForwardingRules(ctx, values.ProjectID, exposure.ForwardingRules)
+	case ModeDetach:
+		err = svcs.LoadBalancer.DetachBackendServices(ctx, values.ProjectID, exposure.URLMaps, exposure.BackendServices, values.QuarantineBackendService)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result.Touch(resource(values)), nil
+}
+
+// ApprovalID returns the approval request ID for applying changes to the load balancers of an instance.
+func ApprovalID(values *Values, changes []string) string {
+	return services.ApprovalID(action, resource(values), changes)
+}
+
+func resource(values *Values) string {
+	return fmt.Sprintf("projects/%s/zones/%s/instances/%s", values.ProjectID, values.Zone, values.Instance)
+}
+
+// toChange returns the forwarding rules or URL maps the mode will change.
+func toChange(mode string, exposure *services.Exposure) []string {
+	changes := []string{}
+	if mode == ModeDelete {
+		for _, r := range exposure.ForwardingRules {
+			changes = append(changes, "forwardingRules/"+r)
+		}
+		return changes
+	}
+	for _, m := range exposure.URLMaps {
+		changes = append(changes, "urlMaps/"+m)
+	}
+	return changes
+}
+
+func requestApproval(ctx context.Context, approval *services.Approval, id string, changes []string, values *Values) error {
+	b, err := json.Marshal(values)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal values")
+	}
+	return approval.Request(ctx, &services.ApprovalRequest{
+		ID:       id,
+		Action:   action,
+		Resource: resource(values),
+		Changes:  changes,
+		Required: requiredApprovals,
+		Topic:    Topic,
+		Values:   b,
+	})
+}
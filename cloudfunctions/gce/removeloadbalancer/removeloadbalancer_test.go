@@ -0,0 +1,179 @@
+package removeloadbalancer
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+	"github.com/googlecloudplatform/security-response-automation/services"
+	compute "google.golang.org/api/compute/v1"
+)
+
+const (
+	projectID = "test-project"
+	zone      = "us-central1-a"
+	instance  = "compromised"
+	link      = "https://www.googleapis.com/compute/v1/projects/test-project/"
+)
+
+func exposedStub() *stubs.ComputeStub {
+	return &stubs.ComputeStub{
+		StubbedInstanceGroups: &compute.InstanceGroupList{Items: []*compute.InstanceGroup{
+			{Name: "web", SelfLink: link + "zones/us-central1-a/instanceGroups/web"},
+			{Name: "other", SelfLink: link + "zones/us-central1-a/instanceGroups/other"},
+		}},
+		StubbedGroupInstances: map[string]*compute.InstanceGroupsListInstances{
+			"web": {Items: []*compute.InstanceWithNamedPorts{{Instance: link + "zones/us-central1-a/instances/compromised"}}},
+		},
+		StubbedBackendServices: &compute.BackendServiceList{Items: []*compute.BackendService{
+			{Name: "web-backend", SelfLink: link + "global/backendServices/web-backend", Backends: []*compute.Backend{{Group: link + "zones/us-central1-a/instanceGroups/web"}}},
+			{Name: "quarantine", SelfLink: link + "global/backendServices/quarantine"},
+		}},
+		StubbedURLMaps: &compute.UrlMapList{Items: []*compute.UrlMap{
+			{
+				Name:           "web-map",
+				SelfLink:       link + "global/urlMaps/web-map",
+				DefaultService: link + "global/backendServices/web-backend",
+				PathMatchers: []*compute.PathMatcher{{
+					DefaultService: link + "global/backendServices/static",
+					PathRules:      []*compute.PathRule{{Service: link + "global/backendServices/web-backend"}},
+				}},
+			},
+			{Name: "static-map", SelfLink: link + "global/urlMaps/static-map", DefaultService: link + "global/backendServices/static"},
+		}},
+		StubbedTargetHTTPSProxies: &compute.TargetHttpsProxyList{Items: []*compute.TargetHttpsProxy{
+			{SelfLink: link + "global/targetHttpsProxies/web-proxy", UrlMap: link + "global/urlMaps/web-map"},
+		}},
+		StubbedForwardingRules: &compute.ForwardingRuleList{Items: []*compute.ForwardingRule{
+			{Name: "web-https", LoadBalancingScheme: "EXTERNAL", Target: link + "global/targetHttpsProxies/web-proxy"},
+			{Name: "web-internal", LoadBalancingScheme: "INTERNAL_SELF_MANAGED", Target: link + "global/targetHttpsProxies/web-proxy"},
+			{Name: "static-https", LoadBalancingScheme: "EXTERNAL", Target: link + "global/targetHttpsProxies/static-proxy"},
+		}},
+	}
+}
+
+func TestRemoveLoadBalancer(t *testing.T) {
+	ctx := context.Background()
+	base := Values{ProjectID: projectID, Zone: zone, Instance: instance, QuarantineBackendService: "quarantine"}
+	deleteID := ApprovalID(&base, []string{"forwardingRules/web-https"})
+	detachID := ApprovalID(&base, []string{"urlMaps/web-map"})
+	for _, tt := range []struct {
+		name             string
+		mode             string
+		approvals        map[string][]string
+		dryRun           bool
+		expectedDeleted  []string
+		expectedURLMap   *compute.UrlMap
+		expectedApproval bool
+	}{
+		{
+			name:             "delete requires approval",
+			mode:             ModeDelete,
+			expectedApproval: true,
+		},
+		{
+			name:             "approval for a different change",
+			mode:             ModeDelete,
+			approvals:        map[string][]string{detachID: {"alice@foo.com"}},
+			expectedApproval: true,
+		},
+		{
+			name:            "delete approved",
+			mode:            ModeDelete,
+			approvals:       map[string][]string{deleteID: {"alice@foo.com"}},
+			expectedDeleted: []string{"web-https"},
+		},
+		{
+			name:      "delete approved in dry run",
+			mode:      ModeDelete,
+			approvals: map[string][]string{deleteID: {"alice@foo.com"}},
+			dryRun:    true,
+		},
+		{
+			name:      "detach approved",
+			mode:      ModeDetach,
+			approvals: map[string][]string{detachID: {"alice@foo.com"}},
+			expectedURLMap: &compute.UrlMap{
+				Name:           "web-map",
+				SelfLink:       link + "global/urlMaps/web-map",
+				DefaultService: link + "global/backendServices/quarantine",
+				PathMatchers: []*compute.PathMatcher{{
+					DefaultService: link + "global/backendServices/static",
+					PathRules:      []*compute.PathRule{{Service: link + "global/backendServices/quarantine"}},
+				}},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			computeStub := exposedStub()
+			psStub := &stubs.PubSubStub{}
+			state := services.NewState(&stubs.StorageStub{}, "state")
+			for id, emails := range tt.approvals {
+				for _, email := range emails {
+					if err := state.RecordApproval(ctx, id, email); err != nil {
+						t.Fatalf("%s failed to record approval: %q", tt.name, err)
+					}
+				}
+			}
+			values := base
+			values.Mode = tt.mode
+			values.DryRun = tt.dryRun
+			if _, err := Execute(ctx, &values, &Services{
+				LoadBalancer: services.NewLoadBalancer(computeStub),
+				Approval:     services.NewApproval(services.NewPubSub(psStub), state, "approvals", []string{"alice@foo.com"}),
+				Logger:       services.NewLogger(&stubs.LoggerStub{}),
+			}); err != nil {
+				t.Fatalf("%s failed: %q", tt.name, err)
+			}
+			if diff := cmp.Diff(tt.expectedDeleted, computeStub.DeletedForwardingRules); diff != "" {
+				t.Errorf("%s failed, deleted forwarding rules differ (-want +got):\n%s", tt.name, diff)
+			}
+			var gotURLMap *compute.UrlMap
+			if len(computeStub.SavedURLMaps) > 0 {
+				gotURLMap = computeStub.SavedURLMaps[0]
+			}
+			if diff := cmp.Diff(tt.expectedURLMap, gotURLMap); diff != "" {
+				t.Errorf("%s failed, url map differs (-want +got):\n%s", tt.name, diff)
+			}
+			if got := psStub.PublishedMessage != nil; got != tt.expectedApproval {
+				t.Errorf("%s failed: approval requested %t want %t", tt.name, got, tt.expectedApproval)
+			}
+		})
+	}
+}
+
+func TestRemoveLoadBalancerNotExposed(t *testing.T) {
+	ctx := context.Background()
+	loggerStub := &stubs.LoggerStub{}
+	psStub := &stubs.PubSubStub{}
+	values := &Values{ProjectID: projectID, Zone: zone, Instance: "not-in-a-group", Mode: ModeDelete}
+	r, err := Execute(ctx, values, &Services{
+		LoadBalancer: services.NewLoadBalancer(exposedStub()),
+		Approval:     services.NewApproval(services.NewPubSub(psStub), services.NewState(&stubs.StorageStub{}, "state"), "approvals", []string{"alice@foo.com"}),
+		Logger:       services.NewLogger(loggerStub),
+	})
+	if err != nil {
+		t.Fatalf("failed: %q", err)
+	}
+	if psStub.PublishedMessage != nil {
+		t.Errorf("approval requested for an instance that is not exposed")
+	}
+	if r == nil || r.Status() != services.AuditResultAlreadyRemediated {
+		t.Errorf("expected an already remediated result, got %v", r)
+	}
+}
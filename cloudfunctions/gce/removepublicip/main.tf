@@ -14,7 +14,7 @@
 resource "google_cloudfunctions_function" "remove-public-ip" {
   name                  = "RemovePublicIP"
   description           = "Removes all the external IP addresses of a GCE instance."
-  runtime               = "go111"
+  runtime               = "go121"
   available_memory_mb   = 128
   source_archive_bucket = var.setup.gcf-bucket-name
   source_archive_object = var.setup.gcf-object-name
@@ -26,6 +26,10 @@ resource "google_cloudfunctions_function" "remove-public-ip" {
   event_trigger {
     event_type = "providers/cloud.pubsub/eventTypes/topic.publish"
     resource   = "threat-findings-remove-public-ip"
+
+    failure_policy {
+      retry = true
+    }
   }
 }
 
@@ -53,6 +57,15 @@ resource "google_folder_iam_member" "roles-instance-admin-v1" {
   member = "serviceAccount:${var.setup.automation-service-account}"
 }
 
+# Required to read VPC flow logs when collecting evidence before removing the IP.
+resource "google_folder_iam_member" "roles-logging-viewer" {
+  count = length(var.folder-ids)
+
+  folder = "folders/${var.folder-ids[count.index]}"
+  role   = "roles/logging.viewer"
+  member = "serviceAccount:${var.setup.automation-service-account}"
+}
+
 resource "google_project_service" "compute_api" {
   project                    = var.setup.automation-project
   service                    = "compute.googleapis.com"
@@ -16,15 +16,24 @@ package removepublicip
 
 import (
 	"context"
+	"encoding/json"
+	"time"
 
 	"github.com/googlecloudplatform/security-response-automation/services"
 	"github.com/pkg/errors"
 )
 
+// Containment is the containment record action of temporarily removed public IPs.
+const Containment = "remove_public_ip"
+
 // Values contains the required values needed for this function.
 type Values struct {
 	ProjectID, InstanceZone, InstanceID string
-	DryRun                              bool
+	// EvidenceBucket is the optional bucket where forensic evidence is collected to before removing the IP.
+	EvidenceBucket string
+	// TTL optionally restores the public IPs after this duration, i.e. "24h".
+	TTL    string
+	DryRun bool
 }
 
 // Services contains the services needed for this function.
@@ -32,17 +41,85 @@ type Services struct {
 	Host     *services.Host
 	Resource *services.Resource
 	Logger   *services.Logger
+	Evidence *services.Evidence
+	// State is required when a TTL is set.
+	State *services.State
+}
+
+// Before is the state of the instance saved before its public IPs are temporarily removed.
+type Before struct {
+	ProjectID     string                          `json:"project_id"`
+	Zone          string                          `json:"zone"`
+	Instance      string                          `json:"instance"`
+	AccessConfigs []services.ExternalAccessConfig `json:"access_configs"`
 }
 
 // Execute removes the public IP of a GCE instance.
-func Execute(ctx context.Context, values *Values, services *Services) error {
+func Execute(ctx context.Context, values *Values, svcs *Services) (*services.Result, error) {
+	result := services.NewResult("remove_public_ip", values.DryRun)
+	if values.TTL != "" && svcs.State == nil {
+		return nil, errors.New("a state bucket must be configured to restore the public ip after its ttl")
+	}
+	ttl, err := time.ParseDuration(values.TTL)
+	if values.TTL != "" && err != nil {
+		return nil, errors.Wrapf(err, "invalid ttl %q", values.TTL)
+	}
+	if values.InstanceZone, err = svcs.Host.InstanceZone(ctx, values.ProjectID, values.InstanceZone, values.InstanceID); err != nil {
+		return nil, err
+	}
+	public, err := svcs.Host.HasExternalIP(ctx, values.ProjectID, values.InstanceZone, values.InstanceID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to check for public ip")
+	}
+	if !public {
+		return result.Skip(values.InstanceID, "instance %q in zone %q in project %q has no public IP address", values.InstanceID, values.InstanceZone, values.ProjectID), nil
+	}
 	if values.DryRun {
-		services.Logger.Info("dry_run on, would have removed public IP address for instance %q, in zone %q in project %q.", values.InstanceID, values.ProjectID)
-		return nil
+		return result.Touch(values.InstanceID), nil
+	}
+	if values.EvidenceBucket != "" {
+		m, err := svcs.Evidence.CollectInstance(ctx, values.ProjectID, values.InstanceZone, values.InstanceID, values.EvidenceBucket)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to collect evidence")
+		}
+		svcs.Logger.Info("collected %d objects and %d snapshots as evidence for instance %q to bucket %q", len(m.Objects), len(m.Snapshots), values.InstanceID, values.EvidenceBucket)
+	}
+	if err := removeIPs(ctx, svcs, values, ttl); err != nil {
+		return nil, err
+	}
+	return result.Touch(values.InstanceID), nil
+}
+
+// Restore adds back the public IPs of an instance once its temporary containment expires.
+func Restore(ctx context.Context, host *services.Host, r *services.ContainmentRecord) error {
+	var before Before
+	if err := json.Unmarshal(r.Before, &before); err != nil {
+		return errors.Wrap(err, "failed to unmarshal instance state")
+	}
+	return host.RestoreExternalIPs(ctx, before.ProjectID, before.Zone, before.Instance, before.AccessConfigs)
+}
+
+// removeIPs removes the public IPs of the instance. If they should be restored after a TTL they're
+// recorded first so they're never removed without a way to restore them.
+func removeIPs(ctx context.Context, svcs *Services, values *Values, ttl time.Duration) error {
+	var rec *services.ContainmentRecord
+	if values.TTL != "" {
+		configs, err := svcs.Host.ExternalAccessConfigs(ctx, values.ProjectID, values.InstanceZone, values.InstanceID)
+		if err != nil {
+			return err
+		}
+		before := &Before{ProjectID: values.ProjectID, Zone: values.InstanceZone, Instance: values.InstanceID, AccessConfigs: configs}
+		if rec, err = svcs.State.RecordContainment(ctx, Containment, values.ProjectID, values.InstanceID, ttl, before); err != nil {
+			return err
+		}
 	}
-	if err := services.Host.RemoveExternalIPs(ctx, values.ProjectID, values.InstanceZone, values.InstanceID); err != nil {
+	if err := svcs.Host.RemoveExternalIPs(ctx, values.ProjectID, values.InstanceZone, values.InstanceID); err != nil {
+		if rec != nil {
+			if rerr := svcs.State.RemoveContainment(ctx, rec); rerr != nil {
+				svcs.Logger.Error("failed to remove containment record %q: %q", rec.ID, rerr)
+			}
+		}
 		return errors.Wrap(err, "failed to remove public ip")
 	}
-	services.Logger.Info("removed public IP address for instance %q, in zone %q in project %q.", values.InstanceID, values.InstanceZone, values.ProjectID)
 	return nil
 }
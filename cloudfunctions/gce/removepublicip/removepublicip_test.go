@@ -58,6 +58,13 @@ func TestRemovePublicIP(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "public ip already removed",
+			instance: &compute.Instance{
+				NetworkInterfaces: []*compute.NetworkInterface{{Name: "nic0"}},
+			},
+			expectedDeletedAccessConfigs: nil,
+		},
 	}
 	for _, tt := range test {
 		t.Run(tt.name, func(t *testing.T) {
@@ -69,7 +76,7 @@ func TestRemovePublicIP(t *testing.T) {
 				InstanceID:   "instance-id",
 			}
 
-			if err := Execute(ctx, values, &Services{
+			if _, err := Execute(ctx, values, &Services{
 				Host:     svcs.Host,
 				Resource: svcs.Resource,
 				Logger:   svcs.Logger,
@@ -94,3 +101,36 @@ func setupRemovePublicIP() (*services.Global, *stubs.ComputeStub) {
 	h := services.NewHost(computeStub)
 	return &services.Global{Logger: log, Host: h, Resource: res}, computeStub
 }
+
+func TestRemovePublicIPCollectsEvidence(t *testing.T) {
+	ctx := context.Background()
+	svcs, computeStub := setupRemovePublicIP()
+	storageStub := &stubs.StorageStub{}
+	computeStub.SavedCreateSnapshots = make(map[string]compute.Snapshot)
+	computeStub.StubbedListDisks = &compute.DiskList{}
+	computeStub.StubbedInstance = &compute.Instance{
+		NetworkInterfaces: []*compute.NetworkInterface{
+			{Name: "nic0", AccessConfigs: []*compute.AccessConfig{{Name: "External NAT", Type: "ONE_TO_ONE_NAT"}}},
+		},
+	}
+	values := &Values{
+		ProjectID:      "project-id",
+		InstanceZone:   "instance-zone",
+		InstanceID:     "instance-id",
+		EvidenceBucket: "evidence-bucket",
+	}
+	if _, err := Execute(ctx, values, &Services{
+		Host:     svcs.Host,
+		Resource: svcs.Resource,
+		Logger:   svcs.Logger,
+		Evidence: services.NewEvidence(computeStub, storageStub, &stubs.LogAdminStub{}),
+	}); err != nil {
+		t.Fatalf("failed to remove public ip: %q", err)
+	}
+	if len(storageStub.WrittenObjects) == 0 {
+		t.Errorf("no evidence collected")
+	}
+	if len(computeStub.DeletedAccessConfigs) != 1 {
+		t.Errorf("got %d deleted access configs want 1", len(computeStub.DeletedAccessConfigs))
+	}
+}
@@ -37,14 +37,20 @@ type Services struct {
 }
 
 // Execute will remove any public users from buckets found within the provided folders.
-func Execute(ctx context.Context, values *Values, services *Services) error {
+func Execute(ctx context.Context, values *Values, svcs *Services) (*services.Result, error) {
+	result := services.NewResult("close_bucket", values.DryRun)
+	present, err := svcs.Resource.PresentBucketMembers(ctx, values.BucketName, publicUsers)
+	if err != nil {
+		return nil, err
+	}
+	if len(present) == 0 {
+		return result.Skip(values.BucketName, "bucket %q in project %q is not public", values.BucketName, values.ProjectID), nil
+	}
 	if values.DryRun {
-		services.Logger.Info("dry_run on, would have removed public members from bucket %q in project %q", values.BucketName, values.ProjectID)
-		return nil
+		return result.Touch(values.BucketName), nil
 	}
-	if err := services.Resource.RemoveMembersFromBucket(ctx, values.BucketName, publicUsers); err != nil {
-		return err
+	if err := svcs.Resource.RemoveMembersFromBucket(ctx, values.BucketName, publicUsers); err != nil {
+		return nil, err
 	}
-	services.Logger.Info("removed public members from bucket %q in project %q", values.BucketName, values.ProjectID)
-	return nil
+	return result.Touch(values.BucketName), nil
 }
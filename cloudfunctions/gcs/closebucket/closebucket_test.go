@@ -50,7 +50,7 @@ func TestCloseBucket(t *testing.T) {
 				BucketName: "open-bucket-name",
 			}
 
-			if err := Execute(ctx, required, &Services{
+			if _, err := Execute(ctx, required, &Services{
 				Resource: svcs.Resource,
 				Logger:   svcs.Logger,
 			}); err != nil {
@@ -67,6 +67,32 @@ func TestCloseBucket(t *testing.T) {
 	}
 }
 
+func TestCloseBucketAlreadyRemediated(t *testing.T) {
+	loggerStub := &stubs.LoggerStub{}
+	storageStub := &stubs.StorageStub{BucketPolicyResponse: &iam.Policy{}}
+	storageStub.BucketPolicyResponse.Add("member:tom@tom.com", "project/viewer")
+	values := &Values{ProjectID: "project-name", BucketName: "private-bucket-name"}
+	r, err := Execute(context.Background(), values, &Services{
+		Resource: services.NewResource(&stubs.ResourceManagerStub{}, storageStub),
+		Logger:   services.NewLogger(loggerStub),
+	})
+	if err != nil {
+		t.Fatalf("failed to execute: %q", err)
+	}
+	if storageStub.RemoveBucketPolicy != nil {
+		t.Errorf("policy should not be written for a private bucket")
+	}
+	want := &services.AuditRecord{
+		Action:   "close_bucket",
+		Resource: "private-bucket-name",
+		Result:   services.AuditResultAlreadyRemediated,
+		Message:  `bucket "private-bucket-name" in project "project-name" is not public`,
+	}
+	if diff := cmp.Diff(want, r.AuditRecord(values.ProjectID)); diff != "" {
+		t.Errorf("unexpected audit record: %s", diff)
+	}
+}
+
 func closeBucketSetup() (*services.Global, *stubs.StorageStub) {
 	loggerStub := &stubs.LoggerStub{}
 	log := services.NewLogger(loggerStub)
@@ -0,0 +1,81 @@
+package closestagingbucket
+
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/googlecloudplatform/security-response-automation/services"
+)
+
+// publicUsers contains a slice of public users we want to remove.
+var publicUsers = []string{"allUsers", "allAuthenticatedUsers"}
+
+// stagingBucket matches the names of buckets Dataproc and Dataflow create for staging and temporary data.
+var stagingBucket = regexp.MustCompile(`^(dataproc-staging-|dataproc-temp-|dataflow-staging-|dataproc-[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}-)`)
+
+// maxObjects is the most objects whose ACLs are checked in one run. Staging buckets can hold very many
+// objects, those past it are left to enable_bucket_only_policy which makes object ACLs moot.
+var maxObjects = 1000
+
+// Values contains the required values needed for this function.
+type Values struct {
+	BucketName string
+	ProjectID  string
+	DryRun     bool
+}
+
+// Services contains the services needed for this function.
+type Services struct {
+	Resource *services.Resource
+	Logger   *services.Logger
+}
+
+// Execute will remove public users from a Dataproc or Dataflow staging bucket and from the ACLs of its objects.
+func Execute(ctx context.Context, values *Values, svcs *Services) (*services.Result, error) {
+	result := services.NewResult("close_staging_bucket", values.DryRun)
+	if !stagingBucket.MatchString(values.BucketName) {
+		return result.Ignore(values.BucketName, "bucket %q in project %q is not a Dataproc or Dataflow staging bucket", values.BucketName, values.ProjectID), nil
+	}
+	members, err := svcs.Resource.PresentBucketMembers(ctx, values.BucketName, publicUsers)
+	if err != nil {
+		return nil, err
+	}
+	objects, truncated, err := svcs.Resource.PublicObjects(ctx, values.BucketName, maxObjects)
+	if err != nil {
+		return nil, err
+	}
+	if truncated {
+		svcs.Logger.Warning("only the first %d objects of staging bucket %q were checked for public ACLs", maxObjects, values.BucketName)
+		result.Message = fmt.Sprintf("checked the ACLs of the first %d objects only, enable bucket policy only to close the others", maxObjects)
+	}
+	if len(members) == 0 && len(objects) == 0 && !truncated {
+		return result.Skip(values.BucketName, "staging bucket %q in project %q is not public", values.BucketName, values.ProjectID), nil
+	}
+	if values.DryRun {
+		return result.Touch(values.BucketName), nil
+	}
+	if len(members) > 0 {
+		if err := svcs.Resource.RemoveMembersFromBucket(ctx, values.BucketName, publicUsers); err != nil {
+			return nil, err
+		}
+	}
+	if err := svcs.Resource.RemovePublicObjectACLs(ctx, values.BucketName, objects); err != nil {
+		return nil, err
+	}
+	return result.Touch(values.BucketName), nil
+}
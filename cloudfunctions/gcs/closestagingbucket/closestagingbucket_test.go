@@ -0,0 +1,190 @@
+package closestagingbucket
+
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"testing"
+
+	"cloud.google.com/go/iam"
+	"cloud.google.com/go/storage"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+	"github.com/googlecloudplatform/security-response-automation/services"
+)
+
+func TestCloseStagingBucket(t *testing.T) {
+	ctx := context.Background()
+	owner := storage.ACLRule{Entity: "user-tom@tom.com", Role: storage.RoleOwner}
+	public := storage.ACLRule{Entity: storage.AllUsers, Role: storage.RoleReader}
+
+	test := []struct {
+		name            string
+		bucketName      string
+		initialMembers  []string
+		bucketAttrs     *storage.BucketAttrs
+		expectedMembers []string
+		expectedACLs    map[string][]storage.ACLRule
+	}{
+		{
+			name:            "remove public members and object acls",
+			bucketName:      "dataproc-staging-us-central1-123-abcdef",
+			initialMembers:  []string{"allUsers", "member:tom@tom.com"},
+			expectedMembers: []string{"member:tom@tom.com"},
+			expectedACLs: map[string][]storage.ACLRule{
+				"dataproc-staging-us-central1-123-abcdef/job/driveroutput": {owner},
+				"dataproc-staging-us-central1-123-abcdef/job/config":       {owner},
+			},
+		},
+		{
+			name:           "remove public object acls from private bucket",
+			bucketName:     "dataflow-staging-us-central1-123",
+			initialMembers: []string{"member:tom@tom.com"},
+			expectedACLs: map[string][]storage.ACLRule{
+				"dataflow-staging-us-central1-123/job/driveroutput": {owner},
+				"dataflow-staging-us-central1-123/job/config":       {owner},
+			},
+		},
+		{
+			name:            "skip object acls with bucket policy only",
+			bucketName:      "dataproc-temp-us-central1-123-abcdef",
+			initialMembers:  []string{"allAuthenticatedUsers", "member:tom@tom.com"},
+			bucketAttrs:     &storage.BucketAttrs{BucketPolicyOnly: storage.BucketPolicyOnly{Enabled: true}},
+			expectedMembers: []string{"member:tom@tom.com"},
+			expectedACLs: map[string][]storage.ACLRule{
+				"dataproc-temp-us-central1-123-abcdef/job/driveroutput": {owner, public},
+				"dataproc-temp-us-central1-123-abcdef/job/config":       {owner},
+			},
+		},
+	}
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			svcs, storageStub := closeStagingBucketSetup()
+			storageStub.BucketAttrsResponse = tt.bucketAttrs
+			for _, v := range tt.initialMembers {
+				storageStub.BucketPolicyResponse.Add(v, "project/viewer")
+			}
+			storageStub.WrittenObjects = map[string][]byte{
+				tt.bucketName + "/job/driveroutput": nil,
+				tt.bucketName + "/job/config":       nil,
+			}
+			storageStub.ObjectACLs = map[string][]storage.ACLRule{
+				tt.bucketName + "/job/driveroutput": {owner, public},
+				tt.bucketName + "/job/config":       {owner},
+			}
+
+			values := &Values{ProjectID: "project-name", BucketName: tt.bucketName}
+			if _, err := Execute(ctx, values, &Services{
+				Resource: svcs.Resource,
+				Logger:   svcs.Logger,
+			}); err != nil {
+				t.Errorf("%s test failed want:%q", tt.name, err)
+			}
+
+			if tt.expectedMembers != nil {
+				s := storageStub.RemoveBucketPolicy.Members("project/viewer")
+				if diff := cmp.Diff(s, tt.expectedMembers); diff != "" {
+					t.Errorf("%v failed exp:%v got:%v", tt.name, tt.expectedMembers, s)
+				}
+			} else if storageStub.RemoveBucketPolicy != nil {
+				t.Errorf("%v failed: bucket policy should not be written", tt.name)
+			}
+			if diff := cmp.Diff(tt.expectedACLs, storageStub.ObjectACLs); diff != "" {
+				t.Errorf("%v failed, unexpected object acls: %s", tt.name, diff)
+			}
+		})
+	}
+}
+
+func TestCloseStagingBucketTruncated(t *testing.T) {
+	defer func(max int) { maxObjects = max }(maxObjects)
+	maxObjects = 1
+	owner := storage.ACLRule{Entity: "user-tom@tom.com", Role: storage.RoleOwner}
+	public := storage.ACLRule{Entity: storage.AllUsers, Role: storage.RoleReader}
+	svcs, storageStub := closeStagingBucketSetup()
+	bucket := "dataflow-staging-us-central1-123"
+	storageStub.WrittenObjects = map[string][]byte{bucket + "/job/a": nil, bucket + "/job/b": nil}
+	storageStub.ObjectACLs = map[string][]storage.ACLRule{bucket + "/job/a": {owner, public}, bucket + "/job/b": {owner, public}}
+	values := &Values{ProjectID: "project-name", BucketName: bucket}
+	r, err := Execute(context.Background(), values, &Services{
+		Resource: svcs.Resource,
+		Logger:   svcs.Logger,
+	})
+	if err != nil {
+		t.Fatalf("failed to execute: %q", err)
+	}
+	want := map[string][]storage.ACLRule{bucket + "/job/a": {owner}, bucket + "/job/b": {owner, public}}
+	if diff := cmp.Diff(want, storageStub.ObjectACLs); diff != "" {
+		t.Errorf("unexpected object acls: %s", diff)
+	}
+	if want := "checked the ACLs of the first 1 objects only, enable bucket policy only to close the others"; r.Message != want {
+		t.Errorf("got message %q want %q", r.Message, want)
+	}
+}
+
+func TestCloseStagingBucketNotStaging(t *testing.T) {
+	svcs, storageStub := closeStagingBucketSetup()
+	storageStub.BucketPolicyResponse.Add("allUsers", "project/viewer")
+	values := &Values{ProjectID: "project-name", BucketName: "website-assets"}
+	r, err := Execute(context.Background(), values, &Services{
+		Resource: svcs.Resource,
+		Logger:   svcs.Logger,
+	})
+	if err != nil {
+		t.Fatalf("failed to execute: %q", err)
+	}
+	if storageStub.RemoveBucketPolicy != nil {
+		t.Errorf("policy should not be written for a bucket that isn't a staging bucket")
+	}
+	if got := r.Status(); got != services.AuditResultSkipped {
+		t.Errorf("got result %q want %q", got, services.AuditResultSkipped)
+	}
+}
+
+func TestCloseStagingBucketAlreadyRemediated(t *testing.T) {
+	loggerStub := &stubs.LoggerStub{}
+	storageStub := &stubs.StorageStub{BucketPolicyResponse: &iam.Policy{}}
+	storageStub.BucketPolicyResponse.Add("member:tom@tom.com", "project/viewer")
+	values := &Values{ProjectID: "project-name", BucketName: "dataflow-staging-us-central1-123"}
+	r, err := Execute(context.Background(), values, &Services{
+		Resource: services.NewResource(&stubs.ResourceManagerStub{}, storageStub),
+		Logger:   services.NewLogger(loggerStub),
+	})
+	if err != nil {
+		t.Fatalf("failed to execute: %q", err)
+	}
+	if storageStub.RemoveBucketPolicy != nil {
+		t.Errorf("policy should not be written for a private bucket")
+	}
+	want := &services.AuditRecord{
+		Action:   "close_staging_bucket",
+		Resource: "dataflow-staging-us-central1-123",
+		Result:   services.AuditResultAlreadyRemediated,
+		Message:  `staging bucket "dataflow-staging-us-central1-123" in project "project-name" is not public`,
+	}
+	if diff := cmp.Diff(want, r.AuditRecord(values.ProjectID)); diff != "" {
+		t.Errorf("unexpected audit record: %s", diff)
+	}
+}
+
+func closeStagingBucketSetup() (*services.Global, *stubs.StorageStub) {
+	loggerStub := &stubs.LoggerStub{}
+	log := services.NewLogger(loggerStub)
+	crmStub := &stubs.ResourceManagerStub{}
+	storageStub := &stubs.StorageStub{}
+	res := services.NewResource(crmStub, storageStub)
+	storageStub.BucketPolicyResponse = &iam.Policy{}
+	return &services.Global{Logger: log, Resource: res}, storageStub
+}
@@ -0,0 +1,75 @@
+package enablebucketlogging
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+
+	"github.com/googlecloudplatform/security-response-automation/services"
+	"github.com/pkg/errors"
+)
+
+// Values contains the required values needed for this function.
+type Values struct {
+	BucketName string
+	ProjectID  string
+	// LogBucket is the bucket where access logs of the affected bucket will be written to.
+	LogBucket string
+	// LogObjectPrefix is the optional prefix used for the access log objects.
+	LogObjectPrefix string
+	// EnableVersioning will also enable object versioning on the affected bucket.
+	EnableVersioning bool
+	DryRun           bool
+}
+
+// Services contains the services needed for this function.
+type Services struct {
+	Resource *services.Resource
+	Logger   *services.Logger
+}
+
+// Execute will enable access logging and optionally object versioning on the affected bucket.
+// Without a log bucket only object versioning is enabled.
+func Execute(ctx context.Context, values *Values, svcs *Services) (*services.Result, error) {
+	result := services.NewResult("enable_bucket_logging", values.DryRun)
+	if values.LogBucket == "" && !values.EnableVersioning {
+		return nil, errors.Errorf("missing log bucket for bucket %q in project %q", values.BucketName, values.ProjectID)
+	}
+	attrs, err := svcs.Resource.BucketAttrs(ctx, values.BucketName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get attributes of bucket %q", values.BucketName)
+	}
+	logging := values.LogBucket == "" || (attrs.Logging != nil && attrs.Logging.LogBucket == values.LogBucket)
+	versioning := attrs.VersioningEnabled || !values.EnableVersioning
+	if logging && versioning {
+		return result.Skip(values.BucketName, "access logging and versioning already configured on bucket %q in project %q", values.BucketName, values.ProjectID), nil
+	}
+	if values.DryRun {
+		return result.Touch(values.BucketName), nil
+	}
+	if !logging {
+		if err := svcs.Resource.EnableBucketLogging(ctx, values.BucketName, values.LogBucket, values.LogObjectPrefix); err != nil {
+			return nil, errors.Wrapf(err, "failed to enable access logging on bucket %q", values.BucketName)
+		}
+	}
+	result.Touch(values.BucketName)
+	if versioning {
+		return result, nil
+	}
+	if err := svcs.Resource.EnableBucketVersioning(ctx, values.BucketName); err != nil {
+		return nil, errors.Wrapf(err, "failed to enable object versioning on bucket %q", values.BucketName)
+	}
+	return result, nil
+}
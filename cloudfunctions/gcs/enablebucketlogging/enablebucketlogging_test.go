@@ -0,0 +1,97 @@
+package enablebucketlogging
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"testing"
+
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+	"github.com/googlecloudplatform/security-response-automation/services"
+)
+
+func TestEnableBucketLogging(t *testing.T) {
+	ctx := context.Background()
+
+	test := []struct {
+		name               string
+		values             *Values
+		expectedLogging    string
+		expectedLogBucket  string
+		expectedVersioning string
+		shouldFail         bool
+	}{
+		{
+			name:              "enable logging",
+			values:            &Values{ProjectID: "project-name", BucketName: "bucket-to-log", LogBucket: "log-bucket"},
+			expectedLogging:   "bucket-to-log",
+			expectedLogBucket: "log-bucket",
+		},
+		{
+			name:               "enable logging and versioning",
+			values:             &Values{ProjectID: "project-name", BucketName: "bucket-to-log", LogBucket: "log-bucket", EnableVersioning: true},
+			expectedLogging:    "bucket-to-log",
+			expectedLogBucket:  "log-bucket",
+			expectedVersioning: "bucket-to-log",
+		},
+		{
+			name:               "enable versioning only",
+			values:             &Values{ProjectID: "project-name", BucketName: "bucket-to-log", EnableVersioning: true},
+			expectedVersioning: "bucket-to-log",
+		},
+		{
+			name:   "dry run",
+			values: &Values{ProjectID: "project-name", BucketName: "bucket-to-log", LogBucket: "log-bucket", EnableVersioning: true, DryRun: true},
+		},
+		{
+			name:       "missing log bucket",
+			values:     &Values{ProjectID: "project-name", BucketName: "bucket-to-log"},
+			shouldFail: true,
+		},
+	}
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			svcs, storageStub := enableBucketLoggingSetup()
+			_, err := Execute(ctx, tt.values, &Services{
+				Resource: svcs.Resource,
+				Logger:   svcs.Logger,
+			})
+			if tt.shouldFail && err == nil {
+				t.Errorf("%s test should have failed", tt.name)
+			}
+			if !tt.shouldFail && err != nil {
+				t.Errorf("%s test failed: %q", tt.name, err)
+			}
+			if s := storageStub.EnabledLoggingOnBucket; s != tt.expectedLogging {
+				t.Errorf("%v failed exp:%v got:%v", tt.name, tt.expectedLogging, s)
+			}
+			if s := storageStub.SavedLogBucket; s != tt.expectedLogBucket {
+				t.Errorf("%v failed exp:%v got:%v", tt.name, tt.expectedLogBucket, s)
+			}
+			if s := storageStub.EnabledVersioningOnBucket; s != tt.expectedVersioning {
+				t.Errorf("%v failed exp:%v got:%v", tt.name, tt.expectedVersioning, s)
+			}
+		})
+	}
+}
+
+func enableBucketLoggingSetup() (*services.Global, *stubs.StorageStub) {
+	loggerStub := &stubs.LoggerStub{}
+	log := services.NewLogger(loggerStub)
+	crmStub := &stubs.ResourceManagerStub{}
+	storageStub := &stubs.StorageStub{}
+	res := services.NewResource(crmStub, storageStub)
+	return &services.Global{Logger: log, Resource: res}, storageStub
+}
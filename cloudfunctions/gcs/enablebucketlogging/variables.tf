@@ -0,0 +1,6 @@
+variable "setup" {}
+
+variable "folder-ids" {
+  type        = list(string)
+  description = "Enable bucket logging if the buckets are within the given folder IDs."
+}
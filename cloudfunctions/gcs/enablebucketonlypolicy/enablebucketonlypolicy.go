@@ -34,14 +34,20 @@ type Services struct {
 }
 
 // Execute will enable bucket only policy on buckets found within the provided folders.
-func Execute(ctx context.Context, values *Values, services *Services) error {
+func Execute(ctx context.Context, values *Values, svcs *Services) (*services.Result, error) {
+	result := services.NewResult("enable_bucket_only_policy", values.DryRun)
+	attrs, err := svcs.Resource.BucketAttrs(ctx, values.BucketName)
+	if err != nil {
+		return nil, err
+	}
+	if attrs.BucketPolicyOnly.Enabled {
+		return result.Skip(values.BucketName, "bucket only policy already enabled on bucket %q in project %q", values.BucketName, values.ProjectID), nil
+	}
 	if values.DryRun {
-		services.Logger.Info("dry_run on, would have enabled Bucket only policy on bucket %q in project %q.", values.BucketName, values.ProjectID)
-		return nil
+		return result.Touch(values.BucketName), nil
 	}
-	if err := services.Resource.EnableBucketOnlyPolicy(ctx, values.BucketName); err != nil {
-		return err
+	if err := svcs.Resource.EnableBucketOnlyPolicy(ctx, values.BucketName); err != nil {
+		return nil, err
 	}
-	services.Logger.Info("Bucket only policy enabled on bucket %q in project %q.", values.BucketName, values.ProjectID)
-	return nil
+	return result.Touch(values.BucketName), nil
 }
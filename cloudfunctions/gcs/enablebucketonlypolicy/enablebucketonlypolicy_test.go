@@ -43,7 +43,7 @@ func TestEnableBucketOnlyPolicy(t *testing.T) {
 				BucketName: "bucket-to-enable-policy",
 			}
 
-			if err := Execute(ctx, values, &Services{
+			if _, err := Execute(ctx, values, &Services{
 				Resource: svcs.Resource,
 				Logger:   svcs.Logger,
 			}); err != nil {
@@ -34,14 +34,20 @@ type Services struct {
 }
 
 // Execute disables the Kubernetes dashboard.
-func Execute(ctx context.Context, values *Values, service *Services) error {
+func Execute(ctx context.Context, values *Values, svcs *Services) (*services.Result, error) {
+	result := services.NewResult("disable_dashboard", values.DryRun)
+	disabled, err := svcs.Container.DashboardDisabled(ctx, values.ProjectID, values.Zone, values.ClusterID)
+	if err != nil {
+		return nil, err
+	}
+	if disabled {
+		return result.Skip(values.ClusterID, "dashboard already disabled on cluster %q in project %q", values.ClusterID, values.ProjectID), nil
+	}
 	if values.DryRun {
-		service.Logger.Info("dry_run on, would have disabled dashboard from custer %q in zone %q in project %q", values.ClusterID, values.Zone, values.ProjectID)
-		return nil
+		return result.Touch(values.ClusterID), nil
 	}
-	if _, err := service.Container.DisableDashboard(ctx, values.ProjectID, values.Zone, values.ClusterID); err != nil {
-		return err
+	if _, err := svcs.Container.DisableDashboard(ctx, values.ProjectID, values.Zone, values.ClusterID); err != nil {
+		return nil, err
 	}
-	service.Logger.Info("successfully disabled dashboard from cluster %q in project %q", values.ClusterID, values.ProjectID)
-	return nil
+	return result.Touch(values.ClusterID), nil
 }
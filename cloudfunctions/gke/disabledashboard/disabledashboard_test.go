@@ -49,7 +49,7 @@ func TestDisableDashboard(t *testing.T) {
 			Zone:      "us-central1-a",
 			ClusterID: "test-cluster",
 		}
-		if err := Execute(ctx, values, &Services{
+		if _, err := Execute(ctx, values, &Services{
 			Container: svcs.Container,
 			Resource:  svcs.Resource,
 			Logger:    svcs.Logger,
@@ -15,7 +15,7 @@
 resource "google_cloudfunctions_function" "disable-dashboard" {
   name                  = "DisableDasboard"
   description           = "Disable the Kubernetes dashboard addon"
-  runtime               = "go111"
+  runtime               = "go121"
   available_memory_mb   = 128
   source_archive_bucket = var.setup.gcf-bucket-name
   source_archive_object = var.setup.gcf-object-name
@@ -27,6 +27,10 @@ resource "google_cloudfunctions_function" "disable-dashboard" {
   event_trigger {
     event_type = "providers/cloud.pubsub/eventTypes/topic.publish"
     resource   = "threat-findings-disable-dashboard"
+
+    failure_policy {
+      retry = true
+    }
   }
 }
 
@@ -0,0 +1,111 @@
+package hardenclusternetwork
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+
+	"github.com/googlecloudplatform/security-response-automation/services"
+	container "google.golang.org/api/container/v1"
+)
+
+// action is the automation name recorded when the cluster is already hardened.
+const action = "harden_cluster_network"
+
+// Values contains the required values needed for this function.
+type Values struct {
+	ProjectID, Zone, ClusterID string
+	// EnableNetworkPolicy enforces network policy on the cluster's nodes.
+	EnableNetworkPolicy bool
+	// AuthorizedNetworks are the CIDR ranges allowed to reach the cluster's control plane. Master
+	// authorized networks aren't enabled if there are none.
+	AuthorizedNetworks []string
+	// PrivateNodes warns if the cluster's nodes have external IP addresses. Private nodes can't be
+	// enabled on an existing cluster so the control plane is restricted instead.
+	PrivateNodes bool
+	DryRun       bool
+}
+
+// Services contains the services needed for this function.
+type Services struct {
+	Container *services.Container
+	Resource  *services.Resource
+	Logger    *services.Logger
+}
+
+// Execute enforces network policy on a GKE cluster and restricts access to its control plane to
+// the authorized networks.
+func Execute(ctx context.Context, values *Values, svcs *Services) (*services.Result, error) {
+	result := services.NewResult(action, values.DryRun)
+	cluster, err := svcs.Container.Cluster(ctx, values.ProjectID, values.Zone, values.ClusterID)
+	if err != nil {
+		return nil, err
+	}
+	if values.PrivateNodes && !services.PrivateNodes(cluster) {
+		svcs.Logger.Warning("cluster %q in project %q has nodes with external IP addresses, recreate it as a private cluster", values.ClusterID, values.ProjectID)
+	}
+	hardened := true
+	if values.EnableNetworkPolicy {
+		needed, err := enableNetworkPolicy(ctx, values, cluster, svcs)
+		if err != nil {
+			return nil, err
+		}
+		hardened = hardened && !needed
+	}
+	if len(values.AuthorizedNetworks) > 0 {
+		needed, err := restrictControlPlane(ctx, values, cluster, svcs)
+		if err != nil {
+			return nil, err
+		}
+		hardened = hardened && !needed
+	}
+	if hardened {
+		return result.Skip(values.ClusterID, "network of cluster %q in project %q is already hardened", values.ClusterID, values.ProjectID), nil
+	}
+	return result.Touch(values.ClusterID), nil
+}
+
+// enableNetworkPolicy returns true if network policy wasn't already enforced.
+func enableNetworkPolicy(ctx context.Context, values *Values, cluster *container.Cluster, svcs *Services) (bool, error) {
+	if services.NetworkPolicyEnabled(cluster) {
+		return false, nil
+	}
+	if values.DryRun {
+		svcs.Logger.Info("dry_run on, would have enabled network policy on cluster %q in project %q.", values.ClusterID, values.ProjectID)
+		return true, nil
+	}
+	if err := svcs.Container.EnableNetworkPolicy(ctx, values.ProjectID, values.Zone, cluster); err != nil {
+		return false, err
+	}
+	svcs.Logger.Info("enabled network policy on cluster %q in project %q.", values.ClusterID, values.ProjectID)
+	return true, nil
+}
+
+// restrictControlPlane returns true if master authorized networks weren't already enabled. Networks
+// already authorized by the cluster's owners are left as they are.
+func restrictControlPlane(ctx context.Context, values *Values, cluster *container.Cluster, svcs *Services) (bool, error) {
+	if services.AuthorizedNetworksEnabled(cluster) {
+		return false, nil
+	}
+	if values.DryRun {
+		svcs.Logger.Info("dry_run on, would have restricted the control plane of cluster %q in project %q to %q.", values.ClusterID, values.ProjectID, values.AuthorizedNetworks)
+		return true, nil
+	}
+	if err := svcs.Container.RestrictControlPlane(ctx, values.ProjectID, values.Zone, values.ClusterID, values.AuthorizedNetworks); err != nil {
+		return false, err
+	}
+	svcs.Logger.Info("restricted the control plane of cluster %q in project %q to %q.", values.ClusterID, values.ProjectID, values.AuthorizedNetworks)
+	return true, nil
+}
@@ -0,0 +1,128 @@
+package hardenclusternetwork
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	container "google.golang.org/api/container/v1"
+
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+	"github.com/googlecloudplatform/security-response-automation/services"
+)
+
+func TestHardenClusterNetwork(t *testing.T) {
+	ctx := context.Background()
+	networks := []string{"10.0.0.0/8"}
+	addon := &container.SetAddonsConfigRequest{AddonsConfig: &container.AddonsConfig{
+		NetworkPolicyConfig: &container.NetworkPolicyConfig{ForceSendFields: []string{"Disabled"}},
+	}}
+	policy := &container.SetNetworkPolicyRequest{NetworkPolicy: &container.NetworkPolicy{Enabled: true, Provider: "CALICO"}}
+	authorized := &container.UpdateClusterRequest{Update: &container.ClusterUpdate{
+		DesiredMasterAuthorizedNetworksConfig: &container.MasterAuthorizedNetworksConfig{
+			Enabled:    true,
+			CidrBlocks: []*container.CidrBlock{{CidrBlock: "10.0.0.0/8"}},
+		},
+	}}
+	for _, tt := range []struct {
+		name                string
+		cluster             *container.Cluster
+		enableNetworkPolicy bool
+		authorizedNetworks  []string
+		dryRun              bool
+		expectedAddon       *container.SetAddonsConfigRequest
+		expectedPolicy      *container.SetNetworkPolicyRequest
+		expectedUpdate      *container.UpdateClusterRequest
+		expectedSkipped     bool
+	}{
+		{
+			name:                "enable network policy and addon",
+			cluster:             &container.Cluster{Name: "cluster-id"},
+			enableNetworkPolicy: true,
+			authorizedNetworks:  networks,
+			expectedAddon:       addon,
+			expectedPolicy:      policy,
+			expectedUpdate:      authorized,
+		},
+		{
+			name: "addon already enabled",
+			cluster: &container.Cluster{
+				Name:                           "cluster-id",
+				AddonsConfig:                   &container.AddonsConfig{NetworkPolicyConfig: &container.NetworkPolicyConfig{}},
+				MasterAuthorizedNetworksConfig: &container.MasterAuthorizedNetworksConfig{Enabled: true},
+			},
+			enableNetworkPolicy: true,
+			authorizedNetworks:  networks,
+			expectedPolicy:      policy,
+		},
+		{
+			name:               "restrict control plane only",
+			cluster:            &container.Cluster{Name: "cluster-id"},
+			authorizedNetworks: networks,
+			expectedUpdate:     authorized,
+		},
+		{
+			name: "already hardened",
+			cluster: &container.Cluster{
+				Name:                           "cluster-id",
+				NetworkPolicy:                  &container.NetworkPolicy{Enabled: true},
+				MasterAuthorizedNetworksConfig: &container.MasterAuthorizedNetworksConfig{Enabled: true},
+			},
+			enableNetworkPolicy: true,
+			authorizedNetworks:  networks,
+			expectedSkipped:     true,
+		},
+		{
+			name:                "dry run",
+			cluster:             &container.Cluster{Name: "cluster-id"},
+			enableNetworkPolicy: true,
+			authorizedNetworks:  networks,
+			dryRun:              true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			containerStub := &stubs.ContainerStub{GetClusterResponse: tt.cluster}
+			values := &Values{
+				ProjectID:           "project-id",
+				Zone:                "us-central1-a",
+				ClusterID:           "cluster-id",
+				EnableNetworkPolicy: tt.enableNetworkPolicy,
+				AuthorizedNetworks:  tt.authorizedNetworks,
+				DryRun:              tt.dryRun,
+			}
+			r, err := Execute(ctx, values, &Services{
+				Container: services.NewContainer(containerStub),
+				Logger:    services.NewLogger(&stubs.LoggerStub{}),
+			})
+			if err != nil {
+				t.Fatalf("%s failed: %q", tt.name, err)
+			}
+			if diff := cmp.Diff(tt.expectedAddon, containerStub.UpdatedAddonsConfig); diff != "" {
+				t.Errorf("%s failed, addons difference: %+v", tt.name, diff)
+			}
+			if diff := cmp.Diff(tt.expectedPolicy, containerStub.SavedNetworkPolicy); diff != "" {
+				t.Errorf("%s failed, network policy difference: %+v", tt.name, diff)
+			}
+			if diff := cmp.Diff(tt.expectedUpdate, containerStub.UpdatedCluster); diff != "" {
+				t.Errorf("%s failed, update difference: %+v", tt.name, diff)
+			}
+			if skipped := len(r.Skipped) > 0; skipped != tt.expectedSkipped {
+				t.Errorf("%s failed, skipped:%t want:%t", tt.name, skipped, tt.expectedSkipped)
+			}
+		})
+	}
+}
@@ -38,14 +38,20 @@ type Values struct {
 }
 
 // Execute is the entry point for the Cloud Function to enable audit logs for a specific project.
-func Execute(ctx context.Context, values *Values, services *Services) error {
+func Execute(ctx context.Context, values *Values, svcs *Services) (*services.Result, error) {
+	result := services.NewResult("enable_audit_logs", values.DryRun)
+	enabled, err := svcs.Resource.AuditLogsEnabled(ctx, values.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	if enabled {
+		return result.Skip("projects/"+values.ProjectID, "data access audit logs already enabled in project %q", values.ProjectID), nil
+	}
 	if values.DryRun {
-		services.Logger.Info("dry_run on, would have enabled data access audit logs in project %q", values.ProjectID)
-		return nil
+		return result.Touch("projects/" + values.ProjectID), nil
 	}
-	if _, err := services.Resource.EnableAuditLogs(ctx, values.ProjectID); err != nil {
-		return err
+	if _, err := svcs.Resource.EnableAuditLogs(ctx, values.ProjectID); err != nil {
+		return nil, err
 	}
-	services.Logger.Info("audit logs was enabled on %q", values.ProjectID)
-	return nil
+	return result.Touch("projects/" + values.ProjectID), nil
 }
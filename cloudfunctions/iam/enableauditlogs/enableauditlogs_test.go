@@ -48,7 +48,7 @@ func TestExecuteEnableDataAccessAuditLogs(t *testing.T) {
 			required := &Values{ProjectID: "fake-project"}
 			policy := &crm.Policy{AuditConfigs: []*crm.AuditConfig{}}
 			entity := setupAuditLogs(policy)
-			if err := Execute(ctx, required, &Services{
+			if _, err := Execute(ctx, required, &Services{
 				Resource: entity.Resource,
 				Logger:   entity.Logger,
 			}); err != nil {
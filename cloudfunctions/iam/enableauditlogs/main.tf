@@ -14,7 +14,7 @@
 resource "google_cloudfunctions_function" "enable-audit-logs" {
   name                  = "EnableAuditLogs"
   description           = "Remediate projects with data access audit logging disabled"
-  runtime               = "go111"
+  runtime               = "go121"
   available_memory_mb   = 128
   source_archive_bucket = var.setup.gcf-bucket-name
   source_archive_object = var.setup.gcf-object-name
@@ -26,6 +26,10 @@ resource "google_cloudfunctions_function" "enable-audit-logs" {
   event_trigger {
     event_type = "providers/cloud.pubsub/eventTypes/topic.publish"
     resource   = "threat-findings-enable-audit-logs"
+
+    failure_policy {
+      retry = true
+    }
   }
 }
 
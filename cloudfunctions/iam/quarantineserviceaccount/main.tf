@@ -0,0 +1,74 @@
+# Package automation contains the Cloud Function code to automate actions.
+
+# Copyright 2020 Google LLC
+
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+
+# 	https://www.apache.org/licenses/LICENSE-2.0
+
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+resource "google_cloudfunctions_function" "quarantine_service_account_function" {
+  name                  = "QuarantineServiceAccount"
+  description           = "Removes the roles of a compromised service account and disables it."
+  runtime               = "go121"
+  available_memory_mb   = 128
+  source_archive_bucket = var.setup.gcf-bucket-name
+  source_archive_object = var.setup.gcf-object-name
+  timeout               = 120
+  project               = var.setup.automation-project
+  region                = var.setup.region
+  entry_point           = "QuarantineServiceAccount"
+
+  event_trigger {
+    event_type = "providers/cloud.pubsub/eventTypes/topic.publish"
+    resource   = "threat-findings-quarantine-service-account"
+
+    failure_policy {
+      retry = true
+    }
+  }
+}
+
+# Required to remove the service account's roles from projects and folders within this folder.
+resource "google_folder_iam_member" "quarantine_service_account_cloudfunction-folder-bind" {
+  count = length(var.folder-ids)
+
+  folder = "folders/${var.folder-ids[count.index]}"
+  role   = "roles/resourcemanager.folderAdmin"
+  member = "serviceAccount:${var.setup.automation-service-account}"
+}
+
+# Required to disable service accounts of projects within this folder and list their keys.
+resource "google_folder_iam_member" "quarantine_service_account_sa_admin_cloudfunction-folder-bind" {
+  count = length(var.folder-ids)
+
+  folder = "folders/${var.folder-ids[count.index]}"
+  role   = "roles/iam.serviceAccountAdmin"
+  member = "serviceAccount:${var.setup.automation-service-account}"
+}
+
+# Required to remove the service account's roles from the organization policy if ancestors are included.
+resource "google_organization_iam_member" "quarantine_service_account_cloudfunction-org-bind" {
+  org_id = var.organization-id
+  role   = "roles/resourcemanager.organizationAdmin"
+  member = "serviceAccount:${var.setup.automation-service-account}"
+}
+
+# PubSub topic to trigger this automation.
+resource "google_pubsub_topic" "topic" {
+  name    = "threat-findings-quarantine-service-account"
+  project = var.setup.automation-project
+}
+
+resource "google_project_service" "iam_api" {
+  project                    = var.setup.automation-project
+  service                    = "iam.googleapis.com"
+  disable_dependent_services = false
+  disable_on_destroy         = false
+}
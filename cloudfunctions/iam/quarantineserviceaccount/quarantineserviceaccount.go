@@ -0,0 +1,281 @@
+// Package quarantineserviceaccount strips a compromised service account of its roles and disables it.
+package quarantineserviceaccount
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/googlecloudplatform/security-response-automation/services"
+	"github.com/pkg/errors"
+)
+
+const (
+	// action is the automation name recorded in audit records.
+	action = "quarantine_service_account"
+	// restoreAction is recorded in the audit records of restored service accounts.
+	restoreAction = "restore_service_account"
+)
+
+// Values contains the required values needed for this function.
+type Values struct {
+	ProjectID string
+	// ServiceAccount is the email of the compromised service account, i.e.
+	// "sa@p.iam.gserviceaccount.com".
+	ServiceAccount string
+	// IncludeAncestors also removes the account's roles on the folders and organization of the
+	// project.
+	IncludeAncestors bool
+	DryRun           bool
+}
+
+// RestoreValues contains the values needed to restore a quarantined service account.
+type RestoreValues struct {
+	ProjectID      string
+	ServiceAccount string
+	// AllowDomains are the domains members may be restored from, i.e. "p.iam.gserviceaccount.com"
+	// for the service accounts of project p. Any domain is allowed if empty.
+	AllowDomains []string
+	// AllowMembers are restored regardless of their domain.
+	AllowMembers []string
+	DryRun       bool
+}
+
+// Services contains the services needed for this function.
+type Services struct {
+	Resource        *services.Resource
+	ServiceAccounts *services.ServiceAccounts
+	State           *services.State
+	Logger          *services.Logger
+}
+
+// Execute is the entry point for the quarantine service account Cloud Function.
+//
+// Rather than deleting a compromised service account, which can't be undone once the deletion is
+// final, this automation removes every role it's bound to on the project, and optionally the
+// project's folders and organization, then disables it. The account and its keys are kept so the
+// account can be investigated and the removed bindings are stored in the state bucket so they can
+// be restored. Bindings are stored as each policy is written so a failed run loses none of them.
+func Execute(ctx context.Context, values *Values, svcs *Services) (*services.Result, error) {
+	result := services.NewResult(action, values.DryRun)
+	if !isServiceAccount(values.ServiceAccount) {
+		return nil, fmt.Errorf("%q is not a service account", values.ServiceAccount)
+	}
+	if svcs.State == nil {
+		return nil, errors.New("a state bucket is required to store the removed bindings")
+	}
+	resources, err := quarantineResources(ctx, svcs.Resource, values)
+	if err != nil {
+		return nil, err
+	}
+	member := "serviceAccount:" + values.ServiceAccount
+	disabled, err := svcs.ServiceAccounts.Disabled(ctx, values.ServiceAccount)
+	if err != nil {
+		return nil, err
+	}
+	if values.DryRun {
+		bound := []string{}
+		for _, r := range resources {
+			bindings, err := svcs.Resource.MemberBindings(ctx, r, member)
+			if err != nil {
+				return nil, err
+			}
+			if len(bindings) > 0 {
+				bound = append(bound, r)
+			}
+		}
+		result.Message = fmt.Sprintf("would have removed the roles on %v and disabled it", bound)
+		return result.Touch(member), nil
+	}
+	record, err := svcs.State.Quarantine(ctx, values.ProjectID, values.ServiceAccount)
+	if err != nil {
+		return nil, err
+	}
+	if record.CreateTime.IsZero() {
+		record.WasDisabled = disabled
+	}
+	removed, err := removeBindings(ctx, svcs, record, resources, member)
+	if err != nil {
+		return nil, err
+	}
+	if len(removed) == 0 && disabled {
+		return result.Skip(member, "service account %q has no roles on %v and is disabled", values.ServiceAccount, resources), nil
+	}
+	if record.Keys, err = svcs.ServiceAccounts.Keys(ctx, values.ServiceAccount); err != nil {
+		return nil, err
+	}
+	if err := svcs.State.SaveQuarantine(ctx, record); err != nil {
+		return nil, err
+	}
+	if !disabled {
+		if err := svcs.ServiceAccounts.Disable(ctx, values.ServiceAccount); err != nil {
+			return nil, err
+		}
+	}
+	result.Message = fmt.Sprintf("quarantined in project %s", values.ProjectID)
+	result.PolicyChanges = removed
+	return result.Touch(member), nil
+}
+
+// Restore re-grants the bindings removed when the service account was quarantined and enables it
+// again, unless it was already disabled when it was quarantined.
+//
+// Nothing is restored if any removed member is outside the allowed domains and not an allowed
+// member since the revoke automations would remove it again. Each resource's bindings are dropped
+// from the record once restored so a failed restore can be retried, and the record is removed once
+// the account is enabled.
+func Restore(ctx context.Context, values *RestoreValues, svcs *Services) error {
+	if svcs.State == nil {
+		return errors.New("a state bucket is required to read the removed bindings")
+	}
+	record, err := svcs.State.Quarantine(ctx, values.ProjectID, values.ServiceAccount)
+	if err != nil {
+		return err
+	}
+	if record.CreateTime.IsZero() {
+		return fmt.Errorf("service account %q is not quarantined in project %q", values.ServiceAccount, values.ProjectID)
+	}
+	disallowed := disallowedMembers(record, values.AllowDomains, values.AllowMembers)
+	if len(disallowed) > 0 {
+		return fmt.Errorf("members %q are not allowed, none of the bindings were restored", disallowed)
+	}
+	resources := make([]string, 0, len(record.Bindings))
+	for r := range record.Bindings {
+		resources = append(resources, r)
+	}
+	sort.Strings(resources)
+	if values.DryRun {
+		svcs.Logger.Info("dry_run on, would have restored the bindings of %q on %v", values.ServiceAccount, resources)
+		restoreAudit(svcs.Logger, values, nil)
+		return nil
+	}
+	added, err := addBindings(ctx, svcs, record, resources)
+	if err != nil {
+		return err
+	}
+	if !record.WasDisabled {
+		if err := svcs.ServiceAccounts.Enable(ctx, values.ServiceAccount); err != nil {
+			return err
+		}
+	}
+	if err := svcs.State.RemoveQuarantine(ctx, record); err != nil {
+		return err
+	}
+	svcs.Logger.Info("restored service account %q: added %d bindings", values.ServiceAccount, len(added))
+	restoreAudit(svcs.Logger, values, added)
+	return nil
+}
+
+// addBindings adds back the bindings of each resource, dropping them from the record once added.
+func addBindings(ctx context.Context, svcs *Services, record *services.QuarantineRecord, resources []string) ([]services.BindingChange, error) {
+	added := []services.BindingChange{}
+	for _, r := range resources {
+		changes, err := svcs.Resource.AddBindings(ctx, r, record.Bindings[r])
+		if err != nil {
+			return nil, err
+		}
+		added = append(added, changes...)
+		delete(record.Bindings, r)
+		if err := svcs.State.SaveQuarantine(ctx, record); err != nil {
+			return nil, err
+		}
+	}
+	return added, nil
+}
+
+// disallowedMembers returns the members of the removed bindings that are neither from one of the
+// allowed domains nor allowed members.
+func disallowedMembers(record *services.QuarantineRecord, allowDomains, allowMembers []string) []string {
+	if len(allowDomains) == 0 {
+		return nil
+	}
+	allowedRegExp := services.DomainMatcher(allowDomains)
+	members := []string{}
+	seen := map[string]bool{}
+	for _, bindings := range record.Bindings {
+		for _, b := range bindings {
+			m := services.NormalizeMember(b.Member)
+			if seen[m] || allowedRegExp.MatchString(m) {
+				continue
+			}
+			seen[m] = true
+			members = append(members, b.Member)
+		}
+	}
+	sort.Strings(members)
+	return services.ExemptMembers(members, allowMembers)
+}
+
+// removeBindings removes the member's bindings on each resource and adds them to the record, which is
+// stored after each policy is written.
+func removeBindings(ctx context.Context, svcs *Services, record *services.QuarantineRecord, resources []string, member string) ([]services.BindingChange, error) {
+	removed := []services.BindingChange{}
+	for _, r := range resources {
+		changes, err := svcs.Resource.RemoveMemberBindings(ctx, r, member)
+		if err != nil {
+			return nil, err
+		}
+		if len(changes) == 0 {
+			continue
+		}
+		removed = append(removed, changes...)
+		record.Bindings[r] = append(record.Bindings[r], changes...)
+		if err := svcs.State.SaveQuarantine(ctx, record); err != nil {
+			return nil, err
+		}
+	}
+	return removed, nil
+}
+
+func isServiceAccount(email string) bool {
+	return services.IsServiceAccount(email)
+}
+
+// quarantineResources returns the project followed by its folders and organization, nearest first,
+// if ancestors are included.
+func quarantineResources(ctx context.Context, resource *services.Resource, values *Values) ([]string, error) {
+	project := "projects/" + values.ProjectID
+	if !values.IncludeAncestors {
+		return []string{project}, nil
+	}
+	path, err := resource.AncestryPath(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+	// The path alternates resource types and IDs, i.e. "organizations/456/folders/123/projects/p".
+	parts := strings.Split(path, "/")
+	resources := []string{}
+	for i := len(parts) - 2; i >= 0; i -= 2 {
+		resources = append(resources, parts[i]+"/"+parts[i+1])
+	}
+	return resources, nil
+}
+
+func restoreAudit(logr *services.Logger, values *RestoreValues, changes []services.BindingChange) {
+	result := services.AuditResultSuccess
+	if values.DryRun {
+		result = services.AuditResultDryRun
+	}
+	logr.Audit(&services.AuditRecord{
+		Action:        restoreAction,
+		Resource:      "serviceAccount:" + values.ServiceAccount,
+		Result:        result,
+		Message:       fmt.Sprintf("restored in project %s", values.ProjectID),
+		PolicyChanges: changes,
+	})
+}
@@ -0,0 +1,353 @@
+package quarantineserviceaccount
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+	"github.com/googlecloudplatform/security-response-automation/services"
+	crm "google.golang.org/api/cloudresourcemanager/v1"
+	crmv2 "google.golang.org/api/cloudresourcemanager/v2"
+	iam "google.golang.org/api/iam/v1"
+)
+
+const (
+	email = "sa@test-project.iam.gserviceaccount.com"
+	name  = "projects/-/serviceAccounts/" + email
+)
+
+func TestQuarantineServiceAccount(t *testing.T) {
+	ctx := context.Background()
+	member := "serviceAccount:" + email
+	for _, tt := range []struct {
+		name             string
+		includeAncestors bool
+		dryRun           bool
+		disabled         bool
+		projectBindings  []*crm.Binding
+		expectedProject  []*crm.Binding
+		expectedFolder   []*crmv2.Binding
+		expectedRecord   map[string][]services.BindingChange
+		expectedResult   string
+		expectedMessage  string
+	}{
+		{
+			name: "remove project roles",
+			projectBindings: []*crm.Binding{
+				{Role: "roles/owner", Members: []string{"user:admin@example.com", member}},
+				{Role: "roles/storage.admin", Members: []string{member}},
+			},
+			expectedProject: []*crm.Binding{
+				{Role: "roles/owner", Members: []string{"user:admin@example.com"}},
+				{Role: "roles/storage.admin", Members: []string{}},
+			},
+			expectedRecord: map[string][]services.BindingChange{
+				"projects/test-project": {
+					{Role: "roles/owner", Member: member, Change: services.BindingRemoved},
+					{Role: "roles/storage.admin", Member: member, Change: services.BindingRemoved},
+				},
+			},
+			expectedResult: services.AuditResultSuccess,
+		},
+		{
+			name:             "remove project and folder roles",
+			includeAncestors: true,
+			projectBindings:  []*crm.Binding{{Role: "roles/editor", Members: []string{member}}},
+			expectedProject:  []*crm.Binding{{Role: "roles/editor", Members: []string{}}},
+			expectedFolder:   []*crmv2.Binding{{Role: "roles/viewer", Members: []string{"user:admin@example.com"}}},
+			expectedRecord: map[string][]services.BindingChange{
+				"projects/test-project": {{Role: "roles/editor", Member: member, Change: services.BindingRemoved}},
+				"folders/123":           {{Role: "roles/viewer", Member: member, Change: services.BindingRemoved}},
+			},
+			expectedResult: services.AuditResultSuccess,
+		},
+		{
+			name:            "disable account without roles",
+			projectBindings: []*crm.Binding{{Role: "roles/owner", Members: []string{"user:admin@example.com"}}},
+			expectedRecord:  map[string][]services.BindingChange{},
+			expectedResult:  services.AuditResultSuccess,
+		},
+		{
+			name:            "dry run",
+			dryRun:          true,
+			projectBindings: []*crm.Binding{{Role: "roles/owner", Members: []string{member}}},
+			expectedResult:  services.AuditResultDryRun,
+			expectedMessage: "would have removed the roles on [projects/test-project] and disabled it",
+		},
+		{
+			name:             "dry run with ancestors",
+			includeAncestors: true,
+			dryRun:           true,
+			projectBindings:  []*crm.Binding{{Role: "roles/owner", Members: []string{"user:admin@example.com"}}},
+			expectedResult:   services.AuditResultDryRun,
+			expectedMessage:  "would have removed the roles on [folders/123] and disabled it",
+		},
+		{
+			name:            "already quarantined",
+			disabled:        true,
+			projectBindings: []*crm.Binding{{Role: "roles/owner", Members: []string{"user:admin@example.com"}}},
+			expectedResult:  services.AuditResultAlreadyRemediated,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			loggerStub := &stubs.LoggerStub{}
+			crmStub := &stubs.ResourceManagerStub{
+				GetPolicyResponse:       &crm.Policy{Bindings: tt.projectBindings},
+				GetFolderPolicyResponse: &crmv2.Policy{Bindings: []*crmv2.Binding{{Role: "roles/viewer", Members: []string{"user:admin@example.com", member}}}},
+				GetAncestryResponse: &crm.GetAncestryResponse{Ancestor: []*crm.Ancestor{
+					{ResourceId: &crm.ResourceId{Type: "project", Id: "test-project"}},
+					{ResourceId: &crm.ResourceId{Type: "folder", Id: "123"}},
+					{ResourceId: &crm.ResourceId{Type: "organization", Id: "456"}},
+				}},
+			}
+			saStub := &stubs.ServiceAccountsStub{
+				StubbedAccounts: map[string]*iam.ServiceAccount{name: {Email: email, Disabled: tt.disabled}},
+				StubbedKeys:     map[string][]*iam.ServiceAccountKey{name: {{Name: name + "/keys/1"}}},
+			}
+			state := services.NewState(&stubs.StorageStub{}, "state")
+			values := &Values{
+				ProjectID:        "test-project",
+				ServiceAccount:   email,
+				IncludeAncestors: tt.includeAncestors,
+				DryRun:           tt.dryRun,
+			}
+			r, err := Execute(ctx, values, &Services{
+				Resource:        services.NewResource(crmStub, &stubs.StorageStub{}),
+				ServiceAccounts: services.NewServiceAccounts(saStub),
+				State:           state,
+				Logger:          services.NewLogger(loggerStub),
+			})
+			if err != nil {
+				t.Fatalf("%s failed: %q", tt.name, err)
+			}
+			if tt.expectedProject == nil && crmStub.SavedSetPolicy != nil {
+				t.Errorf("%s failed: project policy should not be written", tt.name)
+			}
+			if tt.expectedProject != nil {
+				if crmStub.SavedSetPolicy == nil {
+					t.Fatalf("%s failed: project policy not written", tt.name)
+				}
+				if diff := cmp.Diff(tt.expectedProject, crmStub.SavedSetPolicy.Bindings); diff != "" {
+					t.Errorf("%s failed, project bindings difference:%+v", tt.name, diff)
+				}
+			}
+			if tt.expectedFolder == nil && crmStub.SavedSetFolderPolicy != nil {
+				t.Errorf("%s failed: folder policy should not be written", tt.name)
+			}
+			if tt.expectedFolder != nil {
+				if crmStub.SavedSetFolderPolicy == nil {
+					t.Fatalf("%s failed: folder policy not written", tt.name)
+				}
+				if diff := cmp.Diff(tt.expectedFolder, crmStub.SavedSetFolderPolicy.Bindings); diff != "" {
+					t.Errorf("%s failed, folder bindings difference:%+v", tt.name, diff)
+				}
+			}
+			record, err := state.Quarantine(ctx, values.ProjectID, email)
+			if err != nil {
+				t.Fatalf("%s failed to read quarantine record: %q", tt.name, err)
+			}
+			if tt.expectedRecord != nil {
+				if diff := cmp.Diff(tt.expectedRecord, record.Bindings); diff != "" {
+					t.Errorf("%s failed, recorded bindings difference:%+v", tt.name, diff)
+				}
+				if diff := cmp.Diff([]string{name + "/keys/1"}, record.Keys); diff != "" {
+					t.Errorf("%s failed, recorded keys difference:%+v", tt.name, diff)
+				}
+				if !saStub.StubbedAccounts[name].Disabled {
+					t.Errorf("%s failed: service account should be disabled", tt.name)
+				}
+			}
+			if tt.dryRun && saStub.StubbedAccounts[name].Disabled {
+				t.Errorf("%s failed: service account should not be disabled", tt.name)
+			}
+			if got := r.Status(); got != tt.expectedResult {
+				t.Errorf("%s failed: result got:%q want:%q", tt.name, got, tt.expectedResult)
+			}
+			if tt.expectedMessage != "" && r.Message != tt.expectedMessage {
+				t.Errorf("%s failed: message got:%q want:%q", tt.name, r.Message, tt.expectedMessage)
+			}
+		})
+	}
+}
+
+func TestQuarantineServiceAccountRequiresServiceAccount(t *testing.T) {
+	_, err := Execute(context.Background(), &Values{ProjectID: "test-project", ServiceAccount: "jane@example.com"}, &Services{})
+	if err == nil {
+		t.Errorf("user account should fail")
+	}
+}
+
+func TestRestoreServiceAccount(t *testing.T) {
+	ctx := context.Background()
+	member := "serviceAccount:" + email
+	for _, tt := range []struct {
+		name            string
+		allowDomains    []string
+		allowMembers    []string
+		wasDisabled     bool
+		dryRun          bool
+		expectedProject []*crm.Binding
+		expectedFolder  []*crmv2.Binding
+		expectedChanges []services.BindingChange
+		fails           bool
+	}{
+		{
+			name: "restore bindings",
+			expectedProject: []*crm.Binding{
+				{Role: "roles/owner", Members: []string{"user:admin@example.com", member}},
+				{Role: "roles/storage.admin", Members: []string{member}},
+			},
+			expectedFolder: []*crmv2.Binding{{Role: "roles/viewer", Members: []string{member}}},
+			expectedChanges: []services.BindingChange{
+				{Role: "roles/viewer", Member: member, Change: services.BindingAdded},
+				{Role: "roles/owner", Member: member, Change: services.BindingAdded},
+				{Role: "roles/storage.admin", Member: member, Change: services.BindingAdded},
+			},
+		},
+		{
+			name:         "allowed domain",
+			allowDomains: []string{"example.com", "test-project.iam.gserviceaccount.com"},
+			wasDisabled:  true,
+			expectedProject: []*crm.Binding{
+				{Role: "roles/owner", Members: []string{"user:admin@example.com", member}},
+				{Role: "roles/storage.admin", Members: []string{member}},
+			},
+			expectedFolder: []*crmv2.Binding{{Role: "roles/viewer", Members: []string{member}}},
+			expectedChanges: []services.BindingChange{
+				{Role: "roles/viewer", Member: member, Change: services.BindingAdded},
+				{Role: "roles/owner", Member: member, Change: services.BindingAdded},
+				{Role: "roles/storage.admin", Member: member, Change: services.BindingAdded},
+			},
+		},
+		{
+			name:         "disallowed domain",
+			allowDomains: []string{"example.com"},
+			fails:        true,
+		},
+		{
+			name:         "allowed member",
+			allowDomains: []string{"example.com"},
+			allowMembers: []string{member},
+			expectedProject: []*crm.Binding{
+				{Role: "roles/owner", Members: []string{"user:admin@example.com", member}},
+				{Role: "roles/storage.admin", Members: []string{member}},
+			},
+			expectedFolder: []*crmv2.Binding{{Role: "roles/viewer", Members: []string{member}}},
+			expectedChanges: []services.BindingChange{
+				{Role: "roles/viewer", Member: member, Change: services.BindingAdded},
+				{Role: "roles/owner", Member: member, Change: services.BindingAdded},
+				{Role: "roles/storage.admin", Member: member, Change: services.BindingAdded},
+			},
+		},
+		{
+			name:   "dry run",
+			dryRun: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			loggerStub := &stubs.LoggerStub{}
+			crmStub := &stubs.ResourceManagerStub{
+				GetPolicyResponse:       &crm.Policy{Bindings: []*crm.Binding{{Role: "roles/owner", Members: []string{"user:admin@example.com"}}}},
+				GetFolderPolicyResponse: &crmv2.Policy{},
+			}
+			saStub := &stubs.ServiceAccountsStub{
+				StubbedAccounts: map[string]*iam.ServiceAccount{name: {Email: email, Disabled: true}},
+			}
+			state := services.NewState(&stubs.StorageStub{}, "state")
+			record, err := state.Quarantine(ctx, "test-project", email)
+			if err != nil {
+				t.Fatal(err)
+			}
+			record.WasDisabled = tt.wasDisabled
+			record.Bindings = map[string][]services.BindingChange{
+				"projects/test-project": {
+					{Role: "roles/owner", Member: member, Change: services.BindingRemoved},
+					{Role: "roles/storage.admin", Member: member, Change: services.BindingRemoved},
+				},
+				"folders/123": {{Role: "roles/viewer", Member: member, Change: services.BindingRemoved}},
+			}
+			if err := state.SaveQuarantine(ctx, record); err != nil {
+				t.Fatal(err)
+			}
+			values := &RestoreValues{
+				ProjectID:      "test-project",
+				ServiceAccount: email,
+				AllowDomains:   tt.allowDomains,
+				AllowMembers:   tt.allowMembers,
+				DryRun:         tt.dryRun,
+			}
+			err = Restore(ctx, values, &Services{
+				Resource:        services.NewResource(crmStub, &stubs.StorageStub{}),
+				ServiceAccounts: services.NewServiceAccounts(saStub),
+				State:           state,
+				Logger:          services.NewLogger(loggerStub),
+			})
+			if tt.fails {
+				if err == nil {
+					t.Fatalf("%s should fail", tt.name)
+				}
+				if crmStub.SavedSetPolicy != nil || crmStub.SavedSetFolderPolicy != nil {
+					t.Errorf("%s failed: no policy should be written", tt.name)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%s failed: %q", tt.name, err)
+			}
+			if tt.expectedProject == nil && crmStub.SavedSetPolicy != nil {
+				t.Errorf("%s failed: project policy should not be written", tt.name)
+			}
+			if tt.expectedProject != nil {
+				if crmStub.SavedSetPolicy == nil {
+					t.Fatalf("%s failed: project policy not written", tt.name)
+				}
+				if diff := cmp.Diff(tt.expectedProject, crmStub.SavedSetPolicy.Bindings); diff != "" {
+					t.Errorf("%s failed, project bindings difference:%+v", tt.name, diff)
+				}
+				if diff := cmp.Diff(tt.expectedFolder, crmStub.SavedSetFolderPolicy.Bindings); diff != "" {
+					t.Errorf("%s failed, folder bindings difference:%+v", tt.name, diff)
+				}
+				if enabled := !saStub.StubbedAccounts[name].Disabled; enabled == tt.wasDisabled {
+					t.Errorf("%s failed: service account enabled %t, was disabled %t", tt.name, enabled, tt.wasDisabled)
+				}
+				record, err := state.Quarantine(ctx, "test-project", email)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !record.CreateTime.IsZero() {
+					t.Errorf("%s failed: quarantine record should be removed", tt.name)
+				}
+			}
+			if len(loggerStub.AuditRecords) != 1 {
+				t.Fatalf("%s failed: got %d audit records, want 1", tt.name, len(loggerStub.AuditRecords))
+			}
+			if diff := cmp.Diff(tt.expectedChanges, loggerStub.AuditRecords[0].(*services.AuditRecord).PolicyChanges); diff != "" {
+				t.Errorf("%s failed, policy changes difference:%+v", tt.name, diff)
+			}
+		})
+	}
+}
+
+func TestRestoreServiceAccountNotQuarantined(t *testing.T) {
+	err := Restore(context.Background(), &RestoreValues{ProjectID: "test-project", ServiceAccount: email}, &Services{
+		State: services.NewState(&stubs.StorageStub{}, "state"),
+	})
+	if err == nil {
+		t.Errorf("service account that isn't quarantined should fail")
+	}
+}
@@ -0,0 +1,11 @@
+variable "setup" {}
+
+variable "organization-id" {
+  type        = string
+  description = "Organization ID to remove the roles of compromised service accounts from."
+}
+
+variable "folder-ids" {
+  type        = list(string)
+  description = "Quarantine compromised service accounts of projects within the given folder IDs."
+}
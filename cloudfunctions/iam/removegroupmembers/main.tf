@@ -0,0 +1,52 @@
+# Package automation contains the Cloud Function code to automate actions.
+
+# Copyright 2020 Google LLC
+
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+
+# 	https://www.apache.org/licenses/LICENSE-2.0
+
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+resource "google_cloudfunctions_function" "remove_group_members_function" {
+  name                  = "RemoveExternalGroupMembers"
+  description           = "Removes members outside of the allowed domains from groups granted roles on a project."
+  runtime               = "go121"
+  available_memory_mb   = 128
+  source_archive_bucket = var.setup.gcf-bucket-name
+  source_archive_object = var.setup.gcf-object-name
+  timeout               = 120
+  project               = var.setup.automation-project
+  region                = var.setup.region
+  entry_point           = "RemoveExternalGroupMembers"
+
+  event_trigger {
+    event_type = "providers/cloud.pubsub/eventTypes/topic.publish"
+    resource   = "threat-findings-remove-external-group-members"
+
+    failure_policy {
+      retry = true
+    }
+  }
+}
+
+# Required by RemoveExternalGroupMembers to read the IAM policies of projects within this folder.
+# Removing members from the groups is granted through domain-wide delegation, see automations.md.
+resource "google_folder_iam_member" "remove_group_members_cloudfunction-folder-bind" {
+  count = length(var.folder-ids)
+
+  folder = "folders/${var.folder-ids[count.index]}"
+  role   = "roles/iam.securityReviewer"
+  member = "serviceAccount:${var.setup.automation-service-account}"
+}
+
+# PubSub topic to trigger this automation.
+resource "google_pubsub_topic" "topic" {
+  name    = "threat-findings-remove-external-group-members"
+  project = var.setup.automation-project
+}
@@ -0,0 +1,85 @@
+package removegroupmembers
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+
+	"github.com/googlecloudplatform/security-response-automation/services"
+	"github.com/pkg/errors"
+)
+
+// action is the automation name recorded in audit records.
+const action = "remove_external_group_members"
+
+// Values contains the required values needed for this function.
+type Values struct {
+	ProjectID string
+	// AllowDomains are the domains whose members are kept in the groups, i.e. "example.com".
+	AllowDomains []string
+	// AllowMembers are members of other domains that are never removed, such as a partner's account.
+	AllowMembers []string
+	DryRun       bool
+}
+
+// Services contains the services needed for this function.
+type Services struct {
+	Resource *services.Resource
+	// Groups is nil if no G Suite administrator is configured.
+	Groups *services.Groups
+	Logger *services.Logger
+}
+
+// Execute is the entry point for the remove external group members Cloud Function.
+//
+// This automation reads the project's IAM policy for the groups granted any role and removes the
+// direct members of those groups outside of the allowed domains, except the allowed members. The
+// result records each group that had external members and the outcome for each of those members.
+func Execute(ctx context.Context, values *Values, svcs *Services) (*services.Result, error) {
+	result := services.NewResult(action, values.DryRun)
+	if svcs.Groups == nil {
+		return nil, errors.New("groups are not configured, see credentials/groups.json")
+	}
+	groups, err := svcs.Resource.ProjectGroups(ctx, values.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range groups {
+		external, err := svcs.Groups.ExternalMembers(ctx, group, values.AllowDomains, values.AllowMembers)
+		if err != nil {
+			return nil, err
+		}
+		if len(external) == 0 {
+			continue
+		}
+		var removed []string
+		if !values.DryRun {
+			if removed, err = svcs.Groups.RemoveMembers(ctx, group, external); err != nil {
+				return nil, err
+			}
+		}
+		if result.Members == nil {
+			result.Members = map[string]string{}
+		}
+		for m, outcome := range services.MemberOutcomes(external, external, external, removed, values.DryRun) {
+			result.Members[m] = outcome
+		}
+		result.Touch("group:" + group)
+	}
+	if len(result.Resources) == 0 {
+		return result.Skip("projects/"+values.ProjectID, "no members outside of %q found in the groups of %q", values.AllowDomains, values.ProjectID), nil
+	}
+	return result, nil
+}
@@ -0,0 +1,115 @@
+package removegroupmembers
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+	"github.com/googlecloudplatform/security-response-automation/services"
+	admin "google.golang.org/api/admin/directory/v1"
+	crm "google.golang.org/api/cloudresourcemanager/v1"
+)
+
+func TestRemoveExternalGroupMembers(t *testing.T) {
+	ctx := context.Background()
+	policy := &crm.Policy{Bindings: []*crm.Binding{
+		{Role: "roles/editor", Members: []string{"group:devs@example.com", "user:admin@example.com"}},
+		{Role: "roles/viewer", Members: []string{"group:devs@example.com", "group:auditors@example.com"}},
+	}}
+	members := map[string][]*admin.Member{
+		"devs@example.com": {
+			{Email: "jane@example.com"},
+			{Email: "eve@gmail.com"},
+			{Email: "partner@vendor.com"},
+		},
+		"auditors@example.com": {{Email: "auditor@example.com"}},
+	}
+	for _, tt := range []struct {
+		name            string
+		dryRun          bool
+		members         map[string][]*admin.Member
+		expectedRemoved map[string][]string
+		expectedRecord  *services.AuditRecord
+	}{
+		{
+			name:            "remove external members",
+			members:         members,
+			expectedRemoved: map[string][]string{"devs@example.com": {"eve@gmail.com"}},
+			expectedRecord: &services.AuditRecord{
+				Action:   action,
+				Resource: "group:devs@example.com",
+				Result:   services.AuditResultSuccess,
+				Members:  map[string]string{"eve@gmail.com": services.MemberRemoved},
+			},
+		},
+		{
+			name:    "dry run",
+			dryRun:  true,
+			members: members,
+			expectedRecord: &services.AuditRecord{
+				Action:   action,
+				Resource: "group:devs@example.com",
+				Result:   services.AuditResultDryRun,
+				Members:  map[string]string{"eve@gmail.com": services.MemberDryRun},
+			},
+		},
+		{
+			name:    "no external members",
+			members: map[string][]*admin.Member{"devs@example.com": {{Email: "jane@example.com"}}},
+			expectedRecord: &services.AuditRecord{
+				Action:   action,
+				Resource: "projects/test-project",
+				Result:   services.AuditResultAlreadyRemediated,
+				Message:  `no members outside of ["example.com"] found in the groups of "test-project"`,
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			loggerStub := &stubs.LoggerStub{}
+			crmStub := &stubs.ResourceManagerStub{GetPolicyResponse: policy}
+			groupsStub := &stubs.GroupsStub{StubbedMembers: tt.members}
+			values := &Values{
+				ProjectID:    "test-project",
+				AllowDomains: []string{"example.com"},
+				AllowMembers: []string{"user:partner@vendor.com"},
+				DryRun:       tt.dryRun,
+			}
+			r, err := Execute(ctx, values, &Services{
+				Resource: services.NewResource(crmStub, &stubs.StorageStub{}),
+				Groups:   services.NewGroups(groupsStub),
+				Logger:   services.NewLogger(loggerStub),
+			})
+			if err != nil {
+				t.Fatalf("%s failed: %q", tt.name, err)
+			}
+			if diff := cmp.Diff(tt.expectedRemoved, groupsStub.RemovedMembers); diff != "" {
+				t.Errorf("%s failed, removed members difference:%+v", tt.name, diff)
+			}
+			if diff := cmp.Diff(tt.expectedRecord, r.AuditRecord(values.ProjectID)); diff != "" {
+				t.Errorf("%s failed, audit record difference:%+v", tt.name, diff)
+			}
+		})
+	}
+}
+
+func TestRemoveExternalGroupMembersNotConfigured(t *testing.T) {
+	_, err := Execute(context.Background(), &Values{ProjectID: "test-project", AllowDomains: []string{"example.com"}}, &Services{})
+	if err == nil {
+		t.Errorf("missing groups configuration should fail")
+	}
+}
@@ -24,6 +24,8 @@ import (
 type Values struct {
 	ProjectID    string
 	AllowDomains []string
+	// AllowMembers are members that are never removed even though they are outside of the allowed domains.
+	AllowMembers []string
 	DryRun       bool
 }
 
@@ -34,15 +36,19 @@ type Services struct {
 }
 
 // Execute removes all users from a specific project not in allowed domain list.
-func Execute(ctx context.Context, values *Values, services *Services) error {
+func Execute(ctx context.Context, values *Values, svcs *Services) (*services.Result, error) {
+	result := services.NewResult("remove_non_org_members", values.DryRun)
 	if values.DryRun {
-		services.Logger.Info("dry run, would have removed users not from %q in %q", values.AllowDomains, values.ProjectID)
-		return nil
+		return result.Touch("projects/" + values.ProjectID), nil
 	}
-	removed, err := services.Resource.ProjectOnlyKeepUsersFromDomains(ctx, values.ProjectID, values.AllowDomains)
+	removed, changes, err := svcs.Resource.ProjectOnlyKeepUsersFromDomains(ctx, values.ProjectID, values.AllowDomains, values.AllowMembers)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	services.Logger.Info("successfully removed %q from %s", removed, values.ProjectID)
-	return nil
+	if len(removed) == 0 {
+		return result.Skip("projects/"+values.ProjectID, "no users outside of %q found in %q", values.AllowDomains, values.ProjectID), nil
+	}
+	result.Members = services.MemberOutcomes(removed, removed, removed, removed, false)
+	result.PolicyChanges = changes
+	return result.Touch("projects/" + values.ProjectID), nil
 }
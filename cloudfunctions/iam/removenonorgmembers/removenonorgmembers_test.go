@@ -49,7 +49,7 @@ func TestErrors(t *testing.T) {
 			policy := &crm.Policy{Bindings: tt.policyInput}
 			entity, _ := setupNonOrgTest(policy)
 			values := &Values{ProjectID: "project-id"}
-			err := Execute(context.Background(), values, &Services{
+			_, err := Execute(context.Background(), values, &Services{
 				Resource: entity.Resource,
 				Logger:   entity.Logger,
 			})
@@ -67,6 +67,7 @@ func TestRemoveNonOrgMembers(t *testing.T) {
 		policyInput     []*crm.Binding
 		expectedBinding []*crm.Binding
 		allowDomains    []string
+		allowMembers    []string
 	}{
 		{
 			name: "only remove users not in the allowed domain",
@@ -89,6 +90,22 @@ func TestRemoveNonOrgMembers(t *testing.T) {
 				"cloudorg.com",
 			},
 		},
+		{
+			name: "keep allowed members",
+			policyInput: createBindings([]string{
+				"user:bob@gmail.com",
+				"user:partner@gmail.com",
+				"user:ddgo@cloudorg.com"}),
+			expectedBinding: createBindings([]string{
+				"user:partner@gmail.com",
+				"user:ddgo@cloudorg.com"}),
+			allowDomains: []string{
+				"cloudorg.com",
+			},
+			allowMembers: []string{
+				"user:PARTNER@gmail.com",
+			},
+		},
 		{
 			name: "several allowed domains",
 			policyInput: createBindings([]string{
@@ -126,8 +143,8 @@ func TestRemoveNonOrgMembers(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			policy := &crm.Policy{Bindings: tt.policyInput}
 			entity, crmStub := setupNonOrgTest(policy)
-			values := &Values{ProjectID: "project-id", AllowDomains: tt.allowDomains}
-			err := Execute(context.Background(), values, &Services{
+			values := &Values{ProjectID: "project-id", AllowDomains: tt.allowDomains, AllowMembers: tt.allowMembers}
+			_, err := Execute(context.Background(), values, &Services{
 				Resource: entity.Resource,
 				Logger:   entity.Logger,
 			})
@@ -16,7 +16,7 @@
 resource "google_cloudfunctions_function" "revoke_member_function" {
   name                  = "IAMRevoke"
   description           = "Revokes IAM Event Threat Detection anomalous IAM grants."
-  runtime               = "go111"
+  runtime               = "go121"
   available_memory_mb   = 128
   source_archive_bucket = var.setup.gcf-bucket-name
   source_archive_object = var.setup.gcf-object-name
@@ -28,6 +28,10 @@ resource "google_cloudfunctions_function" "revoke_member_function" {
   event_trigger {
     event_type = "providers/cloud.pubsub/eventTypes/topic.publish"
     resource   = "threat-findings-iam-revoke"
+
+    failure_policy {
+      retry = true
+    }
   }
 }
 
@@ -17,9 +17,6 @@ package revoke
 
 import (
 	"context"
-	"fmt"
-	"regexp"
-	"strings"
 
 	"github.com/googlecloudplatform/security-response-automation/services"
 )
@@ -29,13 +26,19 @@ type Values struct {
 	ProjectID       string
 	ExternalMembers []string
 	AllowDomains    []string
-	DryRun          bool
+	// AllowMembers are external members that are never revoked, such as a partner's account.
+	AllowMembers []string
+	// Preflight explains, before revoking, whether each member keeps access through other
+	// bindings, such as through a group or on a folder.
+	Preflight bool
+	DryRun    bool
 }
 
 // Services contains the services needed for this function.
 type Services struct {
-	Resource *services.Resource
-	Logger   *services.Logger
+	Resource       *services.Resource
+	Troubleshooter *services.PolicyTroubleshooter
+	Logger         *services.Logger
 }
 
 // Execute is the entry point for the IAM revoker Cloud Function.
@@ -44,39 +47,73 @@ type Services struct {
 // - The users are believed to be external as reported from the finding provider.
 // - The project where the external users were found are within the set configured resources.
 // - The users do not match the list of allowed domains.
+// - The users are not one of the allowed members.
 //
-func Execute(ctx context.Context, values *Values, services *Services) error {
-	members, err := toRemove(values.ExternalMembers, values.AllowDomains)
+func Execute(ctx context.Context, values *Values, svcs *Services) (*services.Result, error) {
+	result := services.NewResult("iam_revoke", values.DryRun)
+	members := toRemove(values.ExternalMembers, values.AllowDomains, values.AllowMembers)
+	project := "projects/" + values.ProjectID
+	if len(members) == 0 {
+		result.Members = services.MemberOutcomes(values.ExternalMembers, members, nil, nil, values.DryRun)
+		return result.Ignore(project, "no disallowed members to remove from %q", values.ProjectID), nil
+	}
+	// The finding may be minutes old so make sure the members are still in the policy.
+	present, err := svcs.Resource.PresentMembers(ctx, project, members)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if len(present) == 0 {
+		result.Members = services.MemberOutcomes(values.ExternalMembers, members, present, nil, values.DryRun)
+		return result.Skip(project, "members %q no longer in the policy of %q", members, values.ProjectID), nil
+	}
+	if values.Preflight {
+		bindings, err := svcs.Resource.ProjectBindings(ctx, values.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+		result.AccessAnalysis = svcs.Troubleshooter.RetainedAccess(ctx, values.ProjectID, memberBindings(bindings, present))
 	}
 	if values.DryRun {
-		services.Logger.Info("dry_run on, would have removed %q from %q", members, values.ProjectID)
-		return nil
+		result.Members = services.MemberOutcomes(values.ExternalMembers, members, present, nil, values.DryRun)
+		return result.Touch(project), nil
+	}
+	// All members are removed from every binding with a single policy write.
+	changes, err := svcs.Resource.RemoveUsersProject(ctx, values.ProjectID, present)
+	if err != nil {
+		return nil, err
 	}
-	if err := services.Resource.RemoveUsersProject(ctx, values.ProjectID, members); err != nil {
-		return err
+	result.Members = services.MemberOutcomes(values.ExternalMembers, members, present, present, values.DryRun)
+	result.PolicyChanges = changes
+	return result.Touch(project), nil
+}
+
+// memberBindings returns the bindings of the given members.
+func memberBindings(bindings []services.BindingChange, members []string) []services.BindingChange {
+	set := make(map[string]bool, len(members))
+	for _, m := range members {
+		set[services.NormalizeMember(m)] = true
+	}
+	kept := []services.BindingChange{}
+	for _, b := range bindings {
+		if set[services.NormalizeMember(b.Member)] {
+			kept = append(kept, b)
+		}
 	}
-	services.Logger.Info("successfully removed %q from %s", members, values.ProjectID)
-	return nil
+	return kept
 }
 
-// toRemove returns a slice containing only external members that are disallowed.
+// toRemove returns a slice containing only external members that are disallowed and not exempt.
 // This check is done to ensure we only consider removing members that came from the finding and not
 // just any members that aren't part of the configured allow list.
-func toRemove(members []string, allowed []string) ([]string, error) {
-	allowedList := strings.Replace(strings.Join(allowed, "|"), ".", `\.`, -1)
-	allowedRegExp, err := regexp.Compile("^.+@" + allowedList + "$")
-	if err != nil {
-		return nil, fmt.Errorf("failed to compile regex: %q", err)
-	}
+func toRemove(members []string, allowed, exempt []string) []string {
+	allowedRegExp := services.DomainMatcher(allowed)
 	remove := []string{}
 	for _, user := range members {
-		if allowedRegExp.MatchString(user) {
+		if allowedRegExp.MatchString(services.NormalizeMember(user)) {
 			continue
 		}
 		remove = append(remove, user)
 
 	}
-	return remove, nil
+	return services.ExemptMembers(remove, exempt)
 }
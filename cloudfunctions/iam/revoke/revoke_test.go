@@ -16,6 +16,7 @@ package revoke
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -37,6 +38,7 @@ func TestIAMRevoke(t *testing.T) {
 		folderIDs       []string
 		projectIDs      []string
 		allowed         []string
+		allowMembers    []string
 		expectedMembers []string
 		ancestry        *crm.GetAncestryResponse
 	}{
@@ -95,6 +97,17 @@ func TestIAMRevoke(t *testing.T) {
 			expectedMembers: []string{"user:test@test.com", "user:tom@foo.com"},
 			ancestry:        services.CreateAncestors([]string{"project/projectID", "folder/folderID", "organization/organizationID"}),
 		},
+		{
+			name:            "anchor every allowed domain",
+			expectedError:   nil,
+			folderIDs:       []string{"folderID"},
+			projectIDs:      []string{},
+			externalMembers: []string{"user:x@evil.b.com.attacker", "user:y@b.com.evil", "user:z@b.com"},
+			initialMembers:  []string{"user:test@a.com", "user:x@evil.b.com.attacker", "user:y@b.com.evil", "user:z@b.com"},
+			allowed:         []string{"a.com", "b.com", "c.com"},
+			expectedMembers: []string{"user:test@a.com", "user:z@b.com"},
+			ancestry:        services.CreateAncestors([]string{"project/projectID", "folder/folderID", "organization/organizationID"}),
+		},
 		{
 			name:            "ignore non-users",
 			expectedError:   nil,
@@ -117,6 +130,18 @@ func TestIAMRevoke(t *testing.T) {
 			expectedMembers: []string{"user:test@test.com", "serviceAccount:bob@foo.com"},
 			ancestry:        services.CreateAncestors([]string{"project/projectID", "folder/folderID", "organization/organizationID"}),
 		},
+		{
+			name:            "keep allowed members regardless of case",
+			expectedError:   nil,
+			folderIDs:       []string{"folderID"},
+			projectIDs:      []string{},
+			externalMembers: []string{"user:tom@gmail.com", "user:partner@gmail.com", "serviceAccount:vendor@vendor.iam.gserviceaccount.com"},
+			initialMembers:  []string{"user:test@test.com", "user:tom@gmail.com", "user:partner@gmail.com", "serviceAccount:vendor@vendor.iam.gserviceaccount.com"},
+			allowed:         []string{},
+			allowMembers:    []string{"user:Partner@gmail.com", "serviceAccount:vendor@vendor.iam.gserviceaccount.com"},
+			expectedMembers: []string{"user:test@test.com", "user:partner@gmail.com", "serviceAccount:vendor@vendor.iam.gserviceaccount.com"},
+			ancestry:        services.CreateAncestors([]string{"project/projectID", "folder/folderID", "organization/organizationID"}),
+		},
 		{
 			name:            "provide multiple folders and remove gmail users",
 			expectedError:   nil,
@@ -138,8 +163,9 @@ func TestIAMRevoke(t *testing.T) {
 				ProjectID:       "test-project-id",
 				ExternalMembers: tt.externalMembers,
 				AllowDomains:    tt.allowed,
+				AllowMembers:    tt.allowMembers,
 			}
-			if err := Execute(ctx, values, &Services{
+			if _, err := Execute(ctx, values, &Services{
 				Resource: svcs.Resource,
 				Logger:   svcs.Logger,
 			}); err != nil {
@@ -151,7 +177,12 @@ func TestIAMRevoke(t *testing.T) {
 			if crmStub.SavedSetPolicy == nil && tt.expectedMembers == nil {
 				return
 			}
-			if diff := cmp.Diff(crmStub.SavedSetPolicy.Bindings, createPolicy(tt.expectedMembers)); diff != "" {
+			// The policy is not written if there was nothing to remove.
+			got := crmStub.GetPolicyResponse
+			if crmStub.SavedSetPolicy != nil {
+				got = crmStub.SavedSetPolicy
+			}
+			if diff := cmp.Diff(got.Bindings, createPolicy(tt.expectedMembers)); diff != "" {
 				t.Errorf("%s failed diff:%q", tt.name, diff)
 			}
 		})
@@ -175,3 +206,64 @@ func revokeGrantsSetup(folderIDs, projectIDs, allowed []string) (*services.Globa
 	r := services.NewResource(crmStub, storageStub)
 	return &services.Global{Logger: l, Resource: r}, crmStub
 }
+
+func TestIAMRevokeManyMembers(t *testing.T) {
+	ctx := context.Background()
+	external := []string{}
+	bindings := []*crm.Binding{}
+	for i := 0; i < 40; i++ {
+		member := fmt.Sprintf("user:attacker%d@gmail.com", i)
+		external = append(external, member)
+		bindings = append(bindings, &crm.Binding{
+			Role:    fmt.Sprintf("roles/custom%d", i%5),
+			Members: []string{"user:test@test.com", member},
+		})
+	}
+	// This member was already removed by someone else.
+	external = append(external, "user:gone@gmail.com")
+	svcs, crmStub := revokeGrantsSetup(nil, nil, nil)
+	loggerStub := &stubs.LoggerStub{}
+	crmStub.GetPolicyResponse = &crm.Policy{Bindings: bindings}
+	values := &Values{ProjectID: "test-project-id", ExternalMembers: external, AllowDomains: []string{"test.com"}}
+	r, err := Execute(ctx, values, &Services{
+		Resource: svcs.Resource,
+		Logger:   services.NewLogger(loggerStub),
+	})
+	if err != nil {
+		t.Fatalf("failed to revoke: %q", err)
+	}
+	for _, b := range crmStub.SavedSetPolicy.Bindings {
+		if diff := cmp.Diff([]string{"user:test@test.com"}, b.Members); diff != "" {
+			t.Errorf("binding %q not cleaned: %s", b.Role, diff)
+		}
+	}
+	record := r.AuditRecord(values.ProjectID)
+	if record.Result != services.AuditResultSuccess || len(record.Members) != len(external) {
+		t.Errorf("unexpected audit record: %+v", record)
+	}
+	if got := record.Members["user:gone@gmail.com"]; got != services.MemberNotFound {
+		t.Errorf("got outcome %q for removed member", got)
+	}
+	if got := record.Members["user:attacker7@gmail.com"]; got != services.MemberRemoved {
+		t.Errorf("got outcome %q for attacker", got)
+	}
+}
+
+func TestIAMRevokeNothingToRemove(t *testing.T) {
+	svcs, crmStub := revokeGrantsSetup(nil, nil, nil)
+	crmStub.GetPolicyResponse = &crm.Policy{Bindings: createPolicy([]string{"user:tom@foo.com"})}
+	values := &Values{ProjectID: "test-project-id", ExternalMembers: []string{"user:tom@foo.com"}, AllowDomains: []string{"foo.com"}}
+	r, err := Execute(context.Background(), values, &Services{
+		Resource: svcs.Resource,
+		Logger:   svcs.Logger,
+	})
+	if err != nil {
+		t.Fatalf("failed to revoke: %q", err)
+	}
+	if crmStub.SavedSetPolicy != nil {
+		t.Errorf("policy should not be written when no member is disallowed")
+	}
+	if got := r.Status(); got != services.AuditResultSkipped {
+		t.Errorf("got result %q want %q", got, services.AuditResultSkipped)
+	}
+}
@@ -0,0 +1,112 @@
+// Package revokegrants removes the IAM grants made by a suspicious principal.
+package revokegrants
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/googlecloudplatform/security-response-automation/services"
+	"github.com/pkg/errors"
+)
+
+const (
+	// action is the automation name recorded in audit records.
+	action = "iam_revoke_grants"
+	// DefaultWindow is how far back the audit logs are read if no window is configured.
+	DefaultWindow = "24h"
+)
+
+// Values contains the required values needed for this function.
+type Values struct {
+	ProjectID string
+	// Principal is the email of the account believed to be compromised, i.e. "jane@example.com".
+	Principal string
+	// Window is how far back the grants made by the principal are revoked, i.e. "24h".
+	Window string
+	// AllowMembers are members whose grants are never revoked, such as a break glass account.
+	AllowMembers []string
+	// Preflight explains, before revoking, whether each member keeps access through other
+	// bindings, such as through a group or on a folder.
+	Preflight bool
+	DryRun    bool
+}
+
+// Services contains the services needed for this function.
+type Services struct {
+	AuditLogs      *services.AuditLogs
+	Resource       *services.Resource
+	Troubleshooter *services.PolicyTroubleshooter
+	Logger         *services.Logger
+}
+
+// Execute is the entry point for the revoke grants Cloud Function.
+//
+// This automation reads the project's Admin Activity audit logs for the policy changes the
+// principal made within the window and removes every binding the principal added that is still in
+// the policy, except those of allowed members. Grants the principal later removed itself are
+// ignored.
+func Execute(ctx context.Context, values *Values, svcs *Services) (*services.Result, error) {
+	result := services.NewResult(action, values.DryRun)
+	if values.Principal == "" {
+		return nil, errors.New("principal is required")
+	}
+	window := values.Window
+	if window == "" {
+		window = DefaultWindow
+	}
+	d, err := time.ParseDuration(window)
+	if err != nil {
+		return nil, errors.Wrapf(err, "window %q is not a valid duration", window)
+	}
+	grants, err := svcs.AuditLogs.GrantsBy(ctx, values.ProjectID, values.Principal, time.Now().Add(-d))
+	if err != nil {
+		return nil, err
+	}
+	grants = exemptGrants(grants, values.AllowMembers)
+	project := "projects/" + values.ProjectID
+	if len(grants) == 0 {
+		return result.Ignore(project, "no grants made by %q to %q in the last %s", values.Principal, values.ProjectID, window), nil
+	}
+	result.Message = fmt.Sprintf("grants made by %s", values.Principal)
+	if values.Preflight {
+		result.AccessAnalysis = svcs.Troubleshooter.RetainedAccess(ctx, values.ProjectID, grants)
+	}
+	if values.DryRun {
+		return result.Touch(project), nil
+	}
+	changes, err := svcs.Resource.RemoveBindingsProject(ctx, values.ProjectID, grants)
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 {
+		return result.Skip(project, "grants made by %q no longer in the policy of %q", values.Principal, values.ProjectID), nil
+	}
+	result.PolicyChanges = changes
+	return result.Touch(project), nil
+}
+
+// exemptGrants returns the grants without those made to exempt members.
+func exemptGrants(grants []services.BindingChange, exempt []string) []services.BindingChange {
+	kept := []services.BindingChange{}
+	for _, g := range grants {
+		if len(services.ExemptMembers([]string{g.Member}, exempt)) > 0 {
+			kept = append(kept, g)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,181 @@
+package revokegrants
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"testing"
+
+	"cloud.google.com/go/logging"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+	"github.com/googlecloudplatform/security-response-automation/services"
+	crm "google.golang.org/api/cloudresourcemanager/v1"
+	pt "google.golang.org/api/policytroubleshooter/v1beta"
+	auditpb "google.golang.org/genproto/googleapis/cloud/audit"
+	iam "google.golang.org/genproto/googleapis/iam/v1"
+	iamlogging "google.golang.org/genproto/googleapis/iam/v1/logging"
+)
+
+func TestRevokeGrants(t *testing.T) {
+	ctx := context.Background()
+	grants := []*iam.BindingDelta{
+		{Action: iam.BindingDelta_ADD, Role: "roles/owner", Member: "user:eve@gmail.com"},
+		{Action: iam.BindingDelta_ADD, Role: "roles/editor", Member: "user:breakglass@example.com"},
+		{Action: iam.BindingDelta_ADD, Role: "roles/viewer", Member: "user:gone@gmail.com"},
+	}
+	initial := []*crm.Binding{
+		{Role: "roles/owner", Members: []string{"user:admin@example.com", "user:eve@gmail.com"}},
+		{Role: "roles/editor", Members: []string{"user:breakglass@example.com", "user:eve@gmail.com"}},
+	}
+	explanations := map[string]*pt.GoogleCloudPolicytroubleshooterV1betaTroubleshootIamPolicyResponse{
+		"eve@gmail.com": {ExplainedPolicies: []*pt.GoogleCloudPolicytroubleshooterV1betaExplainedPolicy{
+			{
+				FullResourceName: "//cloudresourcemanager.googleapis.com/projects/test-project",
+				BindingExplanations: []*pt.GoogleCloudPolicytroubleshooterV1betaBindingExplanation{
+					{Role: "roles/owner", Memberships: map[string]pt.GoogleCloudPolicytroubleshooterV1betaBindingExplanationAnnotatedMembership{
+						"user:admin@example.com": {Membership: "MEMBERSHIP_NOT_INCLUDED"},
+						"user:eve@gmail.com":     {Membership: "MEMBERSHIP_INCLUDED"},
+					}},
+				},
+			},
+			{
+				FullResourceName: "//cloudresourcemanager.googleapis.com/folders/123",
+				BindingExplanations: []*pt.GoogleCloudPolicytroubleshooterV1betaBindingExplanation{
+					{Role: "roles/owner", Memberships: map[string]pt.GoogleCloudPolicytroubleshooterV1betaBindingExplanationAnnotatedMembership{
+						"group:admins@example.com": {Membership: "MEMBERSHIP_INCLUDED"},
+					}},
+				},
+			},
+		}},
+	}
+	for _, tt := range []struct {
+		name             string
+		dryRun           bool
+		preflight        bool
+		deltas           []*iam.BindingDelta
+		expected         []*crm.Binding
+		expectedResult   string
+		expectedChanges  []services.BindingChange
+		expectedAnalysis []services.AccessAnalysis
+	}{
+		{
+			name:   "remove grants made by the principal",
+			deltas: grants,
+			expected: []*crm.Binding{
+				{Role: "roles/owner", Members: []string{"user:admin@example.com"}},
+				{Role: "roles/editor", Members: []string{"user:breakglass@example.com", "user:eve@gmail.com"}},
+			},
+			expectedResult: services.AuditResultSuccess,
+			expectedChanges: []services.BindingChange{
+				{Role: "roles/owner", Member: "user:eve@gmail.com", Change: services.BindingRemoved},
+			},
+		},
+		{
+			name:           "preflight",
+			dryRun:         true,
+			preflight:      true,
+			deltas:         grants,
+			expectedResult: services.AuditResultDryRun,
+			expectedAnalysis: []services.AccessAnalysis{
+				{
+					Member:     "user:eve@gmail.com",
+					Retained:   []services.RetainedBinding{{Resource: "folders/123", Role: "roles/owner", Via: "group:admins@example.com"}},
+					Equivalent: true,
+				},
+				{Member: "user:gone@gmail.com"},
+			},
+		},
+		{
+			name:           "dry run",
+			dryRun:         true,
+			deltas:         grants,
+			expectedResult: services.AuditResultDryRun,
+		},
+		{
+			name:           "no grants",
+			expectedResult: services.AuditResultSkipped,
+		},
+		{
+			name:           "grants no longer in the policy",
+			deltas:         grants[2:],
+			expectedResult: services.AuditResultAlreadyRemediated,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := ptypes.MarshalAny(&iamlogging.AuditData{PolicyDelta: &iam.PolicyDelta{BindingDeltas: tt.deltas}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			logStub := &stubs.LogAdminStub{StubbedEntries: []*logging.Entry{{Payload: &auditpb.AuditLog{ServiceData: data}}}}
+			loggerStub := &stubs.LoggerStub{}
+			crmStub := &stubs.ResourceManagerStub{}
+			crmStub.GetPolicyResponse = &crm.Policy{Bindings: copyBindings(initial)}
+			values := &Values{
+				ProjectID:    "test-project",
+				Principal:    "mallory@example.com",
+				AllowMembers: []string{"user:breakglass@example.com"},
+				Preflight:    tt.preflight,
+				DryRun:       tt.dryRun,
+			}
+			r, err := Execute(ctx, values, &Services{
+				AuditLogs:      services.NewAuditLogs(logStub),
+				Resource:       services.NewResource(crmStub, &stubs.StorageStub{}),
+				Troubleshooter: services.NewPolicyTroubleshooter(&stubs.PolicyTroubleshooterStub{StubbedExplanations: explanations}),
+				Logger:         services.NewLogger(loggerStub),
+			})
+			if err != nil {
+				t.Fatalf("%s failed: %q", tt.name, err)
+			}
+			if tt.expected == nil && crmStub.SavedSetPolicy != nil {
+				t.Errorf("%s failed: policy should not be written", tt.name)
+			}
+			if tt.expected != nil {
+				if crmStub.SavedSetPolicy == nil {
+					t.Fatalf("%s failed: policy not written", tt.name)
+				}
+				if diff := cmp.Diff(tt.expected, crmStub.SavedSetPolicy.Bindings); diff != "" {
+					t.Errorf("%s failed, difference:%+v", tt.name, diff)
+				}
+			}
+			record := r.AuditRecord(values.ProjectID)
+			if record.Result != tt.expectedResult {
+				t.Errorf("%s failed: result got:%q want:%q", tt.name, record.Result, tt.expectedResult)
+			}
+			if diff := cmp.Diff(tt.expectedChanges, record.PolicyChanges); diff != "" {
+				t.Errorf("%s failed, policy changes difference:%+v", tt.name, diff)
+			}
+			if diff := cmp.Diff(tt.expectedAnalysis, record.AccessAnalysis); diff != "" {
+				t.Errorf("%s failed, access analysis difference:%+v", tt.name, diff)
+			}
+		})
+	}
+}
+
+func TestRevokeGrantsInvalidWindow(t *testing.T) {
+	_, err := Execute(context.Background(), &Values{ProjectID: "test-project", Principal: "mallory@example.com", Window: "a day"}, &Services{})
+	if err == nil {
+		t.Errorf("invalid window should fail")
+	}
+}
+
+func copyBindings(bindings []*crm.Binding) []*crm.Binding {
+	c := make([]*crm.Binding, 0, len(bindings))
+	for _, b := range bindings {
+		c = append(c, &crm.Binding{Role: b.Role, Members: append([]string{}, b.Members...)})
+	}
+	return c
+}
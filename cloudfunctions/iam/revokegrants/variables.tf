@@ -0,0 +1,6 @@
+variable "setup" {}
+
+variable "folder-ids" {
+  type        = list(string)
+  description = "Revoke grants made by suspicious principals if they are within the given folder IDs."
+}
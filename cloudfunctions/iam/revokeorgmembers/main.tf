@@ -0,0 +1,62 @@
+# Copyright 2020 Google LLC
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+# 	https://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+resource "google_cloudfunctions_function" "revoke-org-members" {
+  name                  = "IAMRevokeOrganization"
+  description           = "Removes external members from organization and folder policies once approved."
+  runtime               = "go121"
+  available_memory_mb   = 128
+  source_archive_bucket = var.setup.gcf-bucket-name
+  source_archive_object = var.setup.gcf-object-name
+  timeout               = 60
+  project               = var.setup.automation-project
+  region                = var.setup.region
+  entry_point           = "IAMRevokeOrganization"
+
+  event_trigger {
+    event_type = "providers/cloud.pubsub/eventTypes/topic.publish"
+    resource   = "threat-findings-iam-revoke-org"
+
+    failure_policy {
+      retry = true
+    }
+  }
+
+  environment_variables = {
+    APPROVAL_TOPIC = var.setup.approval-topic
+    APPROVERS      = join(",", var.approvers)
+  }
+}
+
+# Required to get and set organization policies.
+resource "google_organization_iam_member" "roles-org-admin" {
+  org_id = var.organization-id
+  role   = "roles/resourcemanager.organizationAdmin"
+  member = "serviceAccount:${var.setup.automation-service-account}"
+}
+
+# Required to get and set folder policies.
+resource "google_folder_iam_member" "roles-folder-admin" {
+  count = length(var.folder-ids)
+
+  folder = "folders/${var.folder-ids[count.index]}"
+  role   = "roles/resourcemanager.folderAdmin"
+  member = "serviceAccount:${var.setup.automation-service-account}"
+}
+
+# PubSub topic to trigger this automation. Approvals are recorded by the Approve function, never read
+# from its messages.
+resource "google_pubsub_topic" "topic" {
+  name    = "threat-findings-iam-revoke-org"
+  project = var.setup.automation-project
+}
@@ -0,0 +1,152 @@
+// Package revokeorgmembers removes external members from organization and folder IAM policies.
+package revokeorgmembers
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/googlecloudplatform/security-response-automation/services"
+	"github.com/pkg/errors"
+)
+
+const (
+	// action is the automation name used to derive approval request IDs.
+	action = "iam_revoke_org"
+	// Topic is the Pub/Sub topic that triggers this automation.
+	Topic = "threat-findings-iam-revoke-org"
+	// organizationApprovals is the number of distinct approvers needed to change an organization policy.
+	organizationApprovals = 2
+	// folderApprovals is the number of distinct approvers needed to change a folder policy.
+	folderApprovals = 1
+)
+
+// Values contains the required values needed for this function.
+type Values struct {
+	// Resource is the organization or folder to remove members from, i.e. "organizations/123" or "folders/456".
+	Resource        string
+	ExternalMembers []string
+	AllowDomains    []string
+	// AllowMembers are external members that are never revoked, such as a partner's account.
+	AllowMembers []string
+	DryRun       bool
+}
+
+// Services contains the services needed for this function.
+type Services struct {
+	Resource *services.Resource
+	Approval *services.Approval
+	Logger   *services.Logger
+}
+
+// Execute is the entry point for the organization IAM revoker Cloud Function.
+//
+// Changes to organization and folder policies affect every project below them so this automation
+// never acts on a finding alone. Instead it publishes an approval request and only removes the
+// members once the exact same change has been approved by enough of the configured approvers:
+// two for an organization and one for a folder. At least one allowed domain must be configured.
+// No result is returned while the removal awaits approval.
+func Execute(ctx context.Context, values *Values, svcs *Services) (*services.Result, error) {
+	result := services.NewResult(action, values.DryRun)
+	required, err := requiredApprovals(values.Resource)
+	if err != nil {
+		return nil, err
+	}
+	if len(values.AllowDomains) == 0 {
+		return nil, errors.New("must provide at least one domain to allow")
+	}
+	members := toRemove(values.ExternalMembers, values.AllowDomains, values.AllowMembers)
+	if len(members) == 0 {
+		return result.Ignore(values.Resource, "no disallowed members to remove from %q", values.Resource), nil
+	}
+	// The finding or approval may be old so make sure the members are still in the policy.
+	present, err := svcs.Resource.PresentMembers(ctx, values.Resource, members)
+	if err != nil {
+		return nil, err
+	}
+	if len(present) == 0 {
+		result.Members = services.MemberOutcomes(values.ExternalMembers, members, present, nil, values.DryRun)
+		return result.Skip(values.Resource, "members %q no longer in the policy of %q", members, values.Resource), nil
+	}
+	id := ApprovalID(values.Resource, members)
+	if err := svcs.Approval.Approved(ctx, id, required); err != nil {
+		svcs.Logger.Info("removing %q from %q requires approval: %s", members, values.Resource, err)
+		return nil, requestApproval(ctx, svcs.Approval, id, members, required, values)
+	}
+	if values.DryRun {
+		result.Members = services.MemberOutcomes(values.ExternalMembers, members, present, nil, values.DryRun)
+		return result.Touch(values.Resource), nil
+	}
+	// All members are removed from every binding with a single policy write.
+	var removed []string
+	if strings.HasPrefix(values.Resource, "organizations/") {
+		removed, err = svcs.Resource.RemoveUsersOrganization(ctx, values.Resource, present)
+	} else {
+		removed, err = svcs.Resource.RemoveUsersFolder(ctx, values.Resource, present)
+	}
+	if err != nil {
+		return nil, err
+	}
+	result.Members = services.MemberOutcomes(values.ExternalMembers, members, present, removed, values.DryRun)
+	return result.Touch(values.Resource), nil
+}
+
+// ApprovalID returns the approval request ID for removing members from resource.
+func ApprovalID(resource string, members []string) string {
+	return services.ApprovalID(action, resource, members)
+}
+
+func requiredApprovals(resource string) (int, error) {
+	switch {
+	case strings.HasPrefix(resource, "organizations/"):
+		return organizationApprovals, nil
+	case strings.HasPrefix(resource, "folders/"):
+		return folderApprovals, nil
+	default:
+		return 0, fmt.Errorf("resource %q is not an organization or folder", resource)
+	}
+}
+
+func requestApproval(ctx context.Context, approval *services.Approval, id string, members []string, required int, values *Values) error {
+	b, err := json.Marshal(values)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal values")
+	}
+	return approval.Request(ctx, &services.ApprovalRequest{
+		ID:       id,
+		Action:   action,
+		Resource: values.Resource,
+		Changes:  members,
+		Required: required,
+		Topic:    Topic,
+		Values:   b,
+	})
+}
+
+// toRemove returns a slice containing only external members that are disallowed and not exempt.
+func toRemove(members []string, allowed, exempt []string) []string {
+	allowedRegExp := services.DomainMatcher(allowed)
+	remove := []string{}
+	for _, user := range members {
+		if allowedRegExp.MatchString(services.NormalizeMember(user)) {
+			continue
+		}
+		remove = append(remove, user)
+	}
+	return services.ExemptMembers(remove, exempt)
+}
@@ -0,0 +1,170 @@
+package revokeorgmembers
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+	"github.com/googlecloudplatform/security-response-automation/services"
+	crm "google.golang.org/api/cloudresourcemanager/v1"
+	crmv2 "google.golang.org/api/cloudresourcemanager/v2"
+)
+
+func TestRevokeOrganizationMembers(t *testing.T) {
+	ctx := context.Background()
+	const (
+		org    = "organizations/123"
+		folder = "folders/456"
+	)
+	external := []string{"user:tom@gmail.com", "user:bob@foo.com"}
+	initial := []string{"user:test@foo.com", "user:tom@gmail.com", "user:bob@foo.com"}
+	orgID := ApprovalID(org, []string{"user:tom@gmail.com"})
+	folderID := ApprovalID(folder, []string{"user:tom@gmail.com"})
+	for _, tt := range []struct {
+		name             string
+		resource         string
+		approvals        map[string][]string
+		dryRun           bool
+		expectedMembers  []string
+		expectedApproval bool
+	}{
+		{
+			name:             "organization requires approval",
+			resource:         org,
+			expectedApproval: true,
+		},
+		{
+			name:             "organization requires two approvals",
+			resource:         org,
+			approvals:        map[string][]string{orgID: {"alice@foo.com"}},
+			expectedApproval: true,
+		},
+		{
+			name:             "same approver counted once",
+			resource:         org,
+			approvals:        map[string][]string{orgID: {"alice@foo.com", "Alice@foo.com"}},
+			expectedApproval: true,
+		},
+		{
+			name:             "approval for a different change",
+			resource:         org,
+			approvals:        map[string][]string{folderID: {"alice@foo.com", "carol@foo.com"}},
+			expectedApproval: true,
+		},
+		{
+			name:             "unknown approver",
+			resource:         org,
+			approvals:        map[string][]string{orgID: {"alice@foo.com", "mallory@gmail.com"}},
+			expectedApproval: true,
+		},
+		{
+			name:            "organization approved",
+			resource:        org,
+			approvals:       map[string][]string{orgID: {"alice@foo.com", "carol@foo.com"}},
+			expectedMembers: []string{"user:test@foo.com", "user:bob@foo.com"},
+		},
+		{
+			name:      "organization approved dry run",
+			resource:  org,
+			approvals: map[string][]string{orgID: {"alice@foo.com", "carol@foo.com"}},
+			dryRun:    true,
+		},
+		{
+			name:            "folder approved",
+			resource:        folder,
+			approvals:       map[string][]string{folderID: {"carol@foo.com"}},
+			expectedMembers: []string{"user:test@foo.com", "user:bob@foo.com"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			crmStub := &stubs.ResourceManagerStub{
+				GetPolicyResponse:       &crm.Policy{Bindings: []*crm.Binding{{Role: "roles/owner", Members: append([]string{}, initial...)}}},
+				GetFolderPolicyResponse: &crmv2.Policy{Bindings: []*crmv2.Binding{{Role: "roles/owner", Members: append([]string{}, initial...)}}},
+			}
+			psStub := &stubs.PubSubStub{}
+			state := services.NewState(&stubs.StorageStub{}, "state")
+			for id, emails := range tt.approvals {
+				for _, email := range emails {
+					if err := state.RecordApproval(ctx, id, email); err != nil {
+						t.Fatalf("%q failed to record approval: %q", tt.name, err)
+					}
+				}
+			}
+			svcs := &Services{
+				Resource: services.NewResource(crmStub, &stubs.StorageStub{}),
+				Approval: services.NewApproval(services.NewPubSub(psStub), state, "approvals", []string{"alice@foo.com", "carol@foo.com"}),
+				Logger:   services.NewLogger(&stubs.LoggerStub{}),
+			}
+			values := &Values{
+				Resource:        tt.resource,
+				ExternalMembers: external,
+				AllowDomains:    []string{"foo.com"},
+				DryRun:          tt.dryRun,
+			}
+			if _, err := Execute(ctx, values, svcs); err != nil {
+				t.Fatalf("%q failed: %q", tt.name, err)
+			}
+			var got []string
+			if crmStub.SavedSetPolicy != nil {
+				got = crmStub.SavedSetPolicy.Bindings[0].Members
+			}
+			if crmStub.SavedSetFolderPolicy != nil {
+				got = crmStub.SavedSetFolderPolicy.Bindings[0].Members
+			}
+			if diff := cmp.Diff(tt.expectedMembers, got); diff != "" {
+				t.Errorf("%q failed, difference:%+v", tt.name, diff)
+			}
+			if tt.expectedApproval != (psStub.PublishedMessage != nil) {
+				t.Fatalf("%q failed, approval requested: %t", tt.name, psStub.PublishedMessage != nil)
+			}
+			if !tt.expectedApproval {
+				return
+			}
+			var req services.ApprovalRequest
+			if err := json.Unmarshal(psStub.PublishedMessage.Data, &req); err != nil {
+				t.Fatalf("%q failed to unmarshal approval request: %q", tt.name, err)
+			}
+			if req.Topic != Topic || req.Required != organizationApprovals || req.ID != orgID {
+				t.Errorf("%q failed, unexpected approval request: %+v", tt.name, req)
+			}
+			// The request is kept so its values can be published once it's approved.
+			stored, err := state.ApprovalRequest(ctx, orgID)
+			if err != nil {
+				t.Fatalf("%q failed to read approval request: %q", tt.name, err)
+			}
+			if stored == nil || stored.Topic != Topic {
+				t.Errorf("%q failed, approval request not stored: %+v", tt.name, stored)
+			}
+		})
+	}
+}
+
+func TestRevokeOrganizationMembersRequiresAllowDomains(t *testing.T) {
+	svcs := &Services{
+		Resource: services.NewResource(&stubs.ResourceManagerStub{}, &stubs.StorageStub{}),
+		Approval: services.NewApproval(services.NewPubSub(&stubs.PubSubStub{}), services.NewState(&stubs.StorageStub{}, "state"), "approvals", nil),
+		Logger:   services.NewLogger(&stubs.LoggerStub{}),
+	}
+	for _, resource := range []string{"organizations/123", "projects/foo"} {
+		values := &Values{Resource: resource, ExternalMembers: []string{"user:tom@gmail.com"}}
+		if _, err := Execute(context.Background(), values, svcs); err == nil {
+			t.Errorf("%q expected an error", resource)
+		}
+	}
+}
@@ -0,0 +1,16 @@
+variable "setup" {}
+
+variable "organization-id" {
+  type        = string
+  description = "Organization ID to remove external members from."
+}
+
+variable "folder-ids" {
+  type        = list(string)
+  description = "Remove external members from the policies of these folder IDs."
+}
+
+variable "approvers" {
+  type        = list(string)
+  description = "Emails of the people allowed to approve changes to organization and folder policies."
+}
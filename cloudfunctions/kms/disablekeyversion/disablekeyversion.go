@@ -0,0 +1,151 @@
+// Package disablekeyversion disables a misused Cloud KMS key version and locks down its key.
+package disablekeyversion
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/googlecloudplatform/security-response-automation/services"
+	"github.com/pkg/errors"
+)
+
+const (
+	// action is the automation name used to derive approval request IDs.
+	action = "disable_key_version"
+	// Topic is the Pub/Sub topic that triggers this automation.
+	Topic = "threat-findings-disable-key-version"
+	// requiredApprovals is the number of distinct approvers needed before disabling a key version.
+	requiredApprovals = 1
+)
+
+// versionPattern matches the resource name of a Cloud KMS key version.
+var versionPattern = regexp.MustCompile(`^projects/[^/]+/locations/[^/]+/keyRings/[^/]+/cryptoKeys/[^/]+/cryptoKeyVersions/[^/]+$`)
+
+// Values contains the required values needed for this function.
+type Values struct {
+	ProjectID string
+	// KeyVersion is the resource name of the misused key version, i.e.
+	// "projects/p/locations/us/keyRings/r/cryptoKeys/k/cryptoKeyVersions/1".
+	KeyVersion string
+	// BreakGlassGroup is the email of the group left administering the key.
+	BreakGlassGroup string
+	DryRun          bool
+}
+
+// Services contains the services needed for this function.
+type Services struct {
+	KMS      *services.KMS
+	Approval *services.Approval
+	Logger   *services.Logger
+}
+
+// Execute disables a key version found to be misused and restricts its key's IAM policy to the
+// break-glass group.
+//
+// Disabling a key version makes everything encrypted with it unreadable so nothing is changed until
+// an approver approves the exact changes. The key is looked up again each time so an approval never
+// applies to a key that has since changed. No result is returned while the changes await approval.
+func Execute(ctx context.Context, values *Values, svcs *Services) (*services.Result, error) {
+	result := services.NewResult(action, values.DryRun)
+	if !versionPattern.MatchString(values.KeyVersion) {
+		return nil, fmt.Errorf("key version %q is not a valid key version name", values.KeyVersion)
+	}
+	if values.BreakGlassGroup == "" {
+		return nil, errors.New("must provide a break-glass group to restrict the key to")
+	}
+	key := cryptoKey(values)
+	enabled, err := svcs.KMS.KeyVersionEnabled(ctx, values.KeyVersion)
+	if err != nil {
+		return nil, err
+	}
+	policyChanges, err := svcs.KMS.KeyPolicyChanges(ctx, key, breakGlassMember(values))
+	if err != nil {
+		return nil, err
+	}
+	if !enabled && len(policyChanges) == 0 {
+		return result.Skip(values.KeyVersion, "key version %q is disabled and key %q is restricted to %q", values.KeyVersion, key, values.BreakGlassGroup), nil
+	}
+	changes := toChange(values, enabled, policyChanges)
+	id := ApprovalID(values, changes)
+	if err := svcs.Approval.Approved(ctx, id, requiredApprovals); err != nil {
+		svcs.Logger.Info("changing %q requires approval: %s", changes, err)
+		return nil, requestApproval(ctx, svcs.Approval, id, changes, values)
+	}
+	result.Message = fmt.Sprintf("key version disabled, key restricted to %q", values.BreakGlassGroup)
+	if values.DryRun {
+		result.PolicyChanges = policyChanges
+		return result.Touch(values.KeyVersion), nil
+	}
+	if enabled {
+		if err := svcs.KMS.DisableKeyVersion(ctx, values.KeyVersion); err != nil {
+			return nil, err
+		}
+	}
+	policyChanges, err = svcs.KMS.RestrictKeyPolicy(ctx, key, breakGlassMember(values))
+	if err != nil {
+		return nil, err
+	}
+	result.PolicyChanges = policyChanges
+	return result.Touch(values.KeyVersion), nil
+}
+
+// ApprovalID returns the approval request ID for applying changes to the key of a key version.
+func ApprovalID(values *Values, changes []string) string {
+	return services.ApprovalID(action, values.KeyVersion, changes)
+}
+
+func cryptoKey(values *Values) string {
+	return services.CryptoKey(values.KeyVersion)
+}
+
+func breakGlassMember(values *Values) string {
+	if strings.HasPrefix(values.BreakGlassGroup, "group:") {
+		return values.BreakGlassGroup
+	}
+	return "group:" + values.BreakGlassGroup
+}
+
+// toChange describes the changes made to the key version and the key's IAM policy.
+func toChange(values *Values, enabled bool, policyChanges []services.BindingChange) []string {
+	changes := []string{}
+	if enabled {
+		changes = append(changes, "disable "+values.KeyVersion)
+	}
+	for _, c := range policyChanges {
+		changes = append(changes, fmt.Sprintf("%s %s %s", c.Change, c.Role, c.Member))
+	}
+	return changes
+}
+
+func requestApproval(ctx context.Context, approval *services.Approval, id string, changes []string, values *Values) error {
+	b, err := json.Marshal(values)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal values")
+	}
+	return approval.Request(ctx, &services.ApprovalRequest{
+		ID:       id,
+		Action:   action,
+		Resource: values.KeyVersion,
+		Changes:  changes,
+		Required: requiredApprovals,
+		Topic:    Topic,
+		Values:   b,
+	})
+}
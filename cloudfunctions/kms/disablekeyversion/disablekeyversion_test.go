@@ -0,0 +1,143 @@
+package disablekeyversion
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+	"github.com/googlecloudplatform/security-response-automation/services"
+	kms "google.golang.org/api/cloudkms/v1"
+)
+
+const (
+	key        = "projects/kms-project/locations/us/keyRings/sra/cryptoKeys/app"
+	keyVersion = key + "/cryptoKeyVersions/2"
+	breakGlass = "break-glass@example.com"
+)
+
+func misusedStub() *stubs.KMSStub {
+	return &stubs.KMSStub{
+		StubbedVersions: map[string]*kms.CryptoKeyVersion{
+			keyVersion: {Name: keyVersion, State: "ENABLED"},
+		},
+		StubbedPolicies: map[string]*kms.Policy{
+			key: {Bindings: []*kms.Binding{
+				{Role: "roles/cloudkms.cryptoKeyEncrypterDecrypter", Members: []string{"serviceAccount:app@kms-project.iam.gserviceaccount.com"}},
+			}},
+		},
+	}
+}
+
+func TestDisableKeyVersion(t *testing.T) {
+	ctx := context.Background()
+	base := Values{ProjectID: "kms-project", KeyVersion: keyVersion, BreakGlassGroup: breakGlass}
+	id := ApprovalID(&base, []string{
+		"disable " + keyVersion,
+		"added roles/cloudkms.admin group:break-glass@example.com",
+		"removed roles/cloudkms.cryptoKeyEncrypterDecrypter serviceAccount:app@kms-project.iam.gserviceaccount.com",
+	})
+	for _, tt := range []struct {
+		name             string
+		approvals        map[string][]string
+		dryRun           bool
+		expectedStates   map[string]string
+		expectedPolicy   *kms.Policy
+		expectedApproval bool
+	}{
+		{
+			name:             "requires approval",
+			expectedApproval: true,
+		},
+		{
+			name:             "approval for a different change",
+			approvals:        map[string][]string{"other": {"alice@foo.com"}},
+			expectedApproval: true,
+		},
+		{
+			name:      "approved in dry run",
+			approvals: map[string][]string{id: {"alice@foo.com"}},
+			dryRun:    true,
+		},
+		{
+			name:           "approved",
+			approvals:      map[string][]string{id: {"alice@foo.com"}},
+			expectedStates: map[string]string{keyVersion: "DISABLED"},
+			expectedPolicy: &kms.Policy{Bindings: []*kms.Binding{
+				{Role: "roles/cloudkms.admin", Members: []string{"group:break-glass@example.com"}},
+			}},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			kmsStub := misusedStub()
+			psStub := &stubs.PubSubStub{}
+			state := services.NewState(&stubs.StorageStub{}, "state")
+			for id, emails := range tt.approvals {
+				for _, email := range emails {
+					if err := state.RecordApproval(ctx, id, email); err != nil {
+						t.Fatalf("%s failed to record approval: %q", tt.name, err)
+					}
+				}
+			}
+			values := base
+			values.DryRun = tt.dryRun
+			if _, err := Execute(ctx, &values, &Services{
+				KMS:      services.NewKMS(kmsStub),
+				Approval: services.NewApproval(services.NewPubSub(psStub), state, "approvals", []string{"alice@foo.com"}),
+				Logger:   services.NewLogger(&stubs.LoggerStub{}),
+			}); err != nil {
+				t.Fatalf("%s failed: %q", tt.name, err)
+			}
+			if diff := cmp.Diff(tt.expectedStates, kmsStub.SavedStates); diff != "" {
+				t.Errorf("%s failed, key version states differ (-want +got):\n%s", tt.name, diff)
+			}
+			if diff := cmp.Diff(tt.expectedPolicy, kmsStub.SavedPolicies[key]); diff != "" {
+				t.Errorf("%s failed, key policy differs (-want +got):\n%s", tt.name, diff)
+			}
+			if got := psStub.PublishedMessage != nil; got != tt.expectedApproval {
+				t.Errorf("%s failed: approval requested %t want %t", tt.name, got, tt.expectedApproval)
+			}
+		})
+	}
+}
+
+func TestDisableKeyVersionAlreadyRemediated(t *testing.T) {
+	ctx := context.Background()
+	loggerStub := &stubs.LoggerStub{}
+	psStub := &stubs.PubSubStub{}
+	kmsStub := &stubs.KMSStub{
+		StubbedVersions: map[string]*kms.CryptoKeyVersion{keyVersion: {Name: keyVersion, State: "DISABLED"}},
+		StubbedPolicies: map[string]*kms.Policy{key: {Bindings: []*kms.Binding{
+			{Role: "roles/cloudkms.admin", Members: []string{"group:break-glass@example.com"}},
+		}}},
+	}
+	values := &Values{ProjectID: "kms-project", KeyVersion: keyVersion, BreakGlassGroup: breakGlass}
+	r, err := Execute(ctx, values, &Services{
+		KMS:      services.NewKMS(kmsStub),
+		Approval: services.NewApproval(services.NewPubSub(psStub), services.NewState(&stubs.StorageStub{}, "state"), "approvals", []string{"alice@foo.com"}),
+		Logger:   services.NewLogger(loggerStub),
+	})
+	if err != nil {
+		t.Fatalf("failed: %q", err)
+	}
+	if psStub.PublishedMessage != nil {
+		t.Errorf("approval requested for a key that is already restricted")
+	}
+	if r == nil || r.Status() != services.AuditResultAlreadyRemediated {
+		t.Errorf("expected an already remediated result, got %v", r)
+	}
+}
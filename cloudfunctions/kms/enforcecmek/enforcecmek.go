@@ -0,0 +1,149 @@
+package enforcecmek
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/googlecloudplatform/security-response-automation/services"
+	"github.com/pkg/errors"
+)
+
+// action is the automation name recorded when the resource already uses a customer-managed key.
+const action = "enforce_cmek"
+
+// keyPattern matches the resource name of a Cloud KMS key.
+var keyPattern = regexp.MustCompile(`^projects/[^/]+/locations/[^/]+/keyRings/[^/]+/cryptoKeys/[^/]+$`)
+
+// Values contains the required values needed for this function. Either Bucket, DatasetID with an
+// optional TableID, or Disk with its Zone is set.
+type Values struct {
+	ProjectID string
+	Bucket    string
+	DatasetID string
+	TableID   string
+	Disk      string
+	Zone      string
+	// KeyName is the Cloud KMS key applied, i.e.
+	// "projects/p/locations/us/keyRings/sra/cryptoKeys/default". The key must be in the resource's
+	// location.
+	KeyName string
+	DryRun  bool
+}
+
+// Services contains the services needed for this function.
+type Services struct {
+	Resource *services.Resource
+	BigQuery *services.BigQuery
+	Tickets  *services.Tickets
+	Logger   *services.Logger
+}
+
+// Execute applies the customer-managed key to a bucket, dataset or table encrypted with a
+// Google-managed key. Disks can't change their key in place so a ticket is opened instead.
+func Execute(ctx context.Context, values *Values, svcs *Services) (*services.Result, error) {
+	result := services.NewResult(action, values.DryRun)
+	if !keyPattern.MatchString(values.KeyName) {
+		return nil, fmt.Errorf("kms key %q is not a valid key name, i.e. \"projects/p/locations/us/keyRings/r/cryptoKeys/k\"", values.KeyName)
+	}
+	switch {
+	case values.Bucket != "":
+		return enforceBucket(ctx, values, svcs, result)
+	case values.TableID != "":
+		return enforceTable(ctx, values, svcs, result)
+	case values.DatasetID != "":
+		return enforceDataset(ctx, values, svcs, result)
+	case values.Disk != "":
+		return ticketDisk(ctx, values, svcs.Tickets, result)
+	default:
+		return nil, fmt.Errorf("no bucket, dataset, table or disk without cmek in project %q", values.ProjectID)
+	}
+}
+
+func enforceBucket(ctx context.Context, values *Values, svcs *Services, result *services.Result) (*services.Result, error) {
+	key, err := svcs.Resource.BucketDefaultKMSKey(ctx, values.Bucket)
+	if err != nil {
+		return nil, err
+	}
+	if key != "" {
+		return result.Skip(values.Bucket, "bucket %q in project %q is encrypted with %q", values.Bucket, values.ProjectID, key), nil
+	}
+	if values.DryRun {
+		return result.Touch(values.Bucket), nil
+	}
+	if err := svcs.Resource.SetBucketDefaultKMSKey(ctx, values.Bucket, values.KeyName); err != nil {
+		return nil, errors.Wrapf(err, "failed to set default kms key of bucket %q", values.Bucket)
+	}
+	result.Message = fmt.Sprintf("set the default kms key of bucket %q to %q, existing objects keep their key", values.Bucket, values.KeyName)
+	return result.Touch(values.Bucket), nil
+}
+
+func enforceDataset(ctx context.Context, values *Values, svcs *Services, result *services.Result) (*services.Result, error) {
+	key, err := svcs.BigQuery.DatasetKMSKey(ctx, values.ProjectID, values.DatasetID)
+	if err != nil {
+		return nil, err
+	}
+	if key != "" {
+		return result.Skip(values.DatasetID, "dataset %q in project %q is encrypted with %q", values.DatasetID, values.ProjectID, key), nil
+	}
+	if values.DryRun {
+		return result.Touch(values.DatasetID), nil
+	}
+	if err := svcs.BigQuery.SetDatasetKMSKey(ctx, values.ProjectID, values.DatasetID, values.KeyName); err != nil {
+		return nil, err
+	}
+	result.Message = fmt.Sprintf("set the default kms key of dataset %q to %q, existing tables keep their key", values.DatasetID, values.KeyName)
+	return result.Touch(values.DatasetID), nil
+}
+
+func enforceTable(ctx context.Context, values *Values, svcs *Services, result *services.Result) (*services.Result, error) {
+	table := values.DatasetID + "." + values.TableID
+	key, err := svcs.BigQuery.TableKMSKey(ctx, values.ProjectID, values.DatasetID, values.TableID)
+	if err != nil {
+		return nil, err
+	}
+	if key != "" {
+		return result.Skip(table, "table %q in project %q is encrypted with %q", table, values.ProjectID, key), nil
+	}
+	if values.DryRun {
+		return result.Touch(table), nil
+	}
+	if err := svcs.BigQuery.SetTableKMSKey(ctx, values.ProjectID, values.DatasetID, values.TableID, values.KeyName); err != nil {
+		return nil, err
+	}
+	return result.Touch(table), nil
+}
+
+// ticketDisk opens a ticket for the disk's owners to recreate it with the key.
+func ticketDisk(ctx context.Context, values *Values, tickets *services.Tickets, result *services.Result) (*services.Result, error) {
+	resource := fmt.Sprintf("projects/%s/zones/%s/disks/%s", values.ProjectID, values.Zone, values.Disk)
+	if values.DryRun {
+		return result.Touch(resource), nil
+	}
+	if err := tickets.Open(ctx, &services.Ticket{
+		Action:    action,
+		ProjectID: values.ProjectID,
+		Resource:  resource,
+		Title:     fmt.Sprintf("Encrypt disk %s with a customer-managed key", values.Disk),
+		Description: fmt.Sprintf("The key of a disk can't be changed in place. Snapshot disk %q, create a new disk from the snapshot with the kms key %q and attach it in place of the original disk before deleting it.",
+			resource, values.KeyName),
+	}); err != nil {
+		return nil, err
+	}
+	result.Message = fmt.Sprintf("opened a ticket to encrypt disk %q with %q", resource, values.KeyName)
+	return result.Touch(resource), nil
+}
@@ -0,0 +1,138 @@
+package enforcecmek
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"cloud.google.com/go/bigquery"
+	"cloud.google.com/go/storage"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+	"github.com/googlecloudplatform/security-response-automation/services"
+)
+
+const key = "projects/kms-project/locations/us/keyRings/sra/cryptoKeys/default"
+
+func TestEnforceCMEK(t *testing.T) {
+	ctx := context.Background()
+	other := &bigquery.EncryptionConfig{KMSKeyName: "projects/p/locations/us/keyRings/r/cryptoKeys/other"}
+	for _, tt := range []struct {
+		name                 string
+		values               *Values
+		bucketAttrs          *storage.BucketAttrs
+		datasetMetadata      *bigquery.DatasetMetadata
+		tableMetadata        *bigquery.TableMetadata
+		expectedBucketKeys   map[string]string
+		expectedDatasetKey   *bigquery.EncryptionConfig
+		expectedTableKey     *bigquery.EncryptionConfig
+		expectedTicketOpened bool
+	}{
+		{
+			name:               "bucket",
+			values:             &Values{Bucket: "bucket-1"},
+			expectedBucketKeys: map[string]string{"bucket-1": key},
+		},
+		{
+			name:        "bucket already encrypted",
+			values:      &Values{Bucket: "bucket-1"},
+			bucketAttrs: &storage.BucketAttrs{Encryption: &storage.BucketEncryption{DefaultKMSKeyName: other.KMSKeyName}},
+		},
+		{
+			name:               "dataset",
+			values:             &Values{DatasetID: "dataset-1"},
+			datasetMetadata:    &bigquery.DatasetMetadata{},
+			expectedDatasetKey: &bigquery.EncryptionConfig{KMSKeyName: key},
+		},
+		{
+			name:            "dataset already encrypted",
+			values:          &Values{DatasetID: "dataset-1"},
+			datasetMetadata: &bigquery.DatasetMetadata{DefaultEncryptionConfig: other},
+		},
+		{
+			name:             "table",
+			values:           &Values{DatasetID: "dataset-1", TableID: "table-1"},
+			tableMetadata:    &bigquery.TableMetadata{ETag: "abc"},
+			expectedTableKey: &bigquery.EncryptionConfig{KMSKeyName: key},
+		},
+		{
+			name:          "table already encrypted",
+			values:        &Values{DatasetID: "dataset-1", TableID: "table-1"},
+			tableMetadata: &bigquery.TableMetadata{EncryptionConfig: other},
+		},
+		{
+			name:                 "disk",
+			values:               &Values{Disk: "disk-1", Zone: "us-central1-a"},
+			expectedTicketOpened: true,
+		},
+		{
+			name:   "dry run",
+			values: &Values{Bucket: "bucket-1", DryRun: true},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			storageStub := &stubs.StorageStub{BucketAttrsResponse: tt.bucketAttrs}
+			bqStub := &stubs.BigQueryStub{StubbedMetadata: tt.datasetMetadata, StubbedTableMetadata: tt.tableMetadata}
+			psStub := &stubs.PubSubStub{}
+			tt.values.ProjectID = "test-project"
+			tt.values.KeyName = key
+			if _, err := Execute(ctx, tt.values, &Services{
+				Resource: services.NewResource(&stubs.ResourceManagerStub{}, storageStub),
+				BigQuery: services.NewBigQuery(bqStub),
+				Tickets:  services.NewTickets(services.NewPubSub(psStub), "tickets"),
+				Logger:   services.NewLogger(&stubs.LoggerStub{}),
+			}); err != nil {
+				t.Fatalf("%s failed: %q", tt.name, err)
+			}
+			if diff := cmp.Diff(tt.expectedBucketKeys, storageStub.BucketKMSKeys); diff != "" {
+				t.Errorf("%s failed, bucket keys difference:%+v", tt.name, diff)
+			}
+			var datasetKey, tableKey *bigquery.EncryptionConfig
+			if bqStub.SavedDatasetMetadata != nil {
+				datasetKey = bqStub.SavedDatasetMetadata.DefaultEncryptionConfig
+			}
+			if bqStub.SavedTableMetadata != nil {
+				tableKey = bqStub.SavedTableMetadata.EncryptionConfig
+			}
+			if diff := cmp.Diff(tt.expectedDatasetKey, datasetKey); diff != "" {
+				t.Errorf("%s failed, dataset key difference:%+v", tt.name, diff)
+			}
+			if diff := cmp.Diff(tt.expectedTableKey, tableKey); diff != "" {
+				t.Errorf("%s failed, table key difference:%+v", tt.name, diff)
+			}
+			if opened := psStub.PublishedMessage != nil; opened != tt.expectedTicketOpened {
+				t.Fatalf("%s failed: ticket opened got:%t want:%t", tt.name, opened, tt.expectedTicketOpened)
+			}
+			if tt.expectedTicketOpened {
+				var ticket services.Ticket
+				if err := json.Unmarshal(psStub.PublishedMessage.Data, &ticket); err != nil {
+					t.Fatal(err)
+				}
+				if ticket.Resource != "projects/test-project/zones/us-central1-a/disks/disk-1" {
+					t.Errorf("%s failed: ticket for %q", tt.name, ticket.Resource)
+				}
+			}
+		})
+	}
+}
+
+func TestEnforceCMEKInvalidKey(t *testing.T) {
+	_, err := Execute(context.Background(), &Values{ProjectID: "test-project", Bucket: "bucket-1", KeyName: "default"}, &Services{})
+	if err == nil {
+		t.Errorf("invalid key should fail")
+	}
+}
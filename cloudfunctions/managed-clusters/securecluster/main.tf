@@ -0,0 +1,97 @@
+# Copyright 2020 Google LLC
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+# 	https://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+resource "google_cloudfunctions_function" "secure-managed-cluster" {
+  name                  = "SecureManagedCluster"
+  description           = "Removes public access from and rotates the credentials of a Composer environment or Dataproc cluster once approved."
+  runtime               = "go121"
+  available_memory_mb   = 128
+  source_archive_bucket = var.setup.gcf-bucket-name
+  source_archive_object = var.setup.gcf-object-name
+  timeout               = 540
+  project               = var.setup.automation-project
+  region                = var.setup.region
+  entry_point           = "SecureManagedCluster"
+
+  event_trigger {
+    event_type = "providers/cloud.pubsub/eventTypes/topic.publish"
+    resource   = "threat-findings-secure-managed-cluster"
+
+    failure_policy {
+      retry = true
+    }
+  }
+
+  environment_variables = {
+    APPROVAL_TOPIC = var.setup.approval-topic
+    APPROVERS      = join(",", var.approvers)
+  }
+}
+
+# Required to look up Composer environments and Dataproc clusters within this folder.
+resource "google_folder_iam_member" "roles-viewer" {
+  count = length(var.folder-ids)
+
+  folder = "folders/${var.folder-ids[count.index]}"
+  role   = "roles/viewer"
+  member = "serviceAccount:${var.setup.automation-service-account}"
+}
+
+# Required to restrict the control plane and rotate the credentials of Composer's GKE clusters.
+resource "google_folder_iam_member" "roles-cluster-admin" {
+  count = length(var.folder-ids)
+
+  folder = "folders/${var.folder-ids[count.index]}"
+  role   = "roles/container.clusterAdmin"
+  member = "serviceAccount:${var.setup.automation-service-account}"
+}
+
+# Required to remove the external IP addresses of Dataproc instances.
+resource "google_folder_iam_member" "roles-instance-admin" {
+  count = length(var.folder-ids)
+
+  folder = "folders/${var.folder-ids[count.index]}"
+  role   = "roles/compute.instanceAdmin.v1"
+  member = "serviceAccount:${var.setup.automation-service-account}"
+}
+
+# Required to delete the keys of the service accounts Dataproc clusters run as.
+resource "google_folder_iam_member" "roles-service-account-key-admin" {
+  count = length(var.folder-ids)
+
+  folder = "folders/${var.folder-ids[count.index]}"
+  role   = "roles/iam.serviceAccountKeyAdmin"
+  member = "serviceAccount:${var.setup.automation-service-account}"
+}
+
+# PubSub topic to trigger this automation. Approvals are recorded by the Approve function, never read
+# from its messages.
+resource "google_pubsub_topic" "topic" {
+  name    = "threat-findings-secure-managed-cluster"
+  project = var.setup.automation-project
+}
+
+resource "google_project_service" "composer_api" {
+  project                    = var.setup.automation-project
+  service                    = "composer.googleapis.com"
+  disable_dependent_services = false
+  disable_on_destroy         = false
+}
+
+resource "google_project_service" "dataproc_api" {
+  project                    = var.setup.automation-project
+  service                    = "dataproc.googleapis.com"
+  disable_dependent_services = false
+  disable_on_destroy         = false
+}
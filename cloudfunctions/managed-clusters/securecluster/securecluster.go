@@ -0,0 +1,223 @@
+// Package securecluster removes public access from and rotates the credentials of Cloud Composer
+// environments and Dataproc clusters.
+package securecluster
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/googlecloudplatform/security-response-automation/services"
+	"github.com/pkg/errors"
+)
+
+const (
+	// action is the automation name used to derive approval request IDs.
+	action = "secure_managed_cluster"
+	// Topic is the Pub/Sub topic that triggers this automation.
+	Topic = "threat-findings-secure-managed-cluster"
+	// KindComposer is a Cloud Composer environment.
+	KindComposer = "composer"
+	// KindDataproc is a Dataproc cluster.
+	KindDataproc = "dataproc"
+	// requiredApprovals is the number of distinct approvers needed before securing a cluster.
+	requiredApprovals = 1
+)
+
+// Values contains the required values needed for this function.
+type Values struct {
+	ProjectID string
+	// Kind is either "composer" or "dataproc".
+	Kind string
+	// Region is the location of the environment or cluster.
+	Region string
+	// Name is the name of the environment or cluster.
+	Name string
+	// AuthorizedNetworks are the CIDR ranges allowed to reach the control plane of a Composer
+	// environment's GKE cluster. The control plane isn't restricted if there are none.
+	AuthorizedNetworks []string
+	DryRun             bool
+}
+
+// Services contains the services needed for this function.
+type Services struct {
+	ManagedClusters *services.ManagedClusters
+	Container       *services.Container
+	Host            *services.Host
+	ServiceAccounts *services.ServiceAccounts
+	Approval        *services.Approval
+	Logger          *services.Logger
+}
+
+// step is a change to a cluster along with how it's applied.
+type step struct {
+	change string
+	apply  func(context.Context) error
+}
+
+// Execute removes public access from a Cloud Composer environment or Dataproc cluster and rotates
+// the credentials it runs with.
+//
+// Composer environments have the control plane of their GKE cluster restricted to the authorized
+// networks and its credentials rotated. Dataproc clusters have the external IP addresses of their
+// instances removed and the user-managed keys of their service account deleted.
+//
+// Rotating credentials breaks the clients using them so nothing is changed until an approver
+// approves the exact changes. The changes are looked up again each time so an approval never
+// applies to a cluster that has since changed. No result is returned while the change awaits
+// approval.
+func Execute(ctx context.Context, values *Values, svcs *Services) (*services.Result, error) {
+	result := services.NewResult(action, values.DryRun)
+	var steps []step
+	var err error
+	switch values.Kind {
+	case KindComposer:
+		steps, err = composerSteps(ctx, values, svcs)
+	case KindDataproc:
+		steps, err = dataprocSteps(ctx, values, svcs)
+	default:
+		return nil, fmt.Errorf("unknown kind %q", values.Kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(steps) == 0 {
+		return result.Skip(resource(values), "%s %q in project %q has no public access or credentials to rotate", values.Kind, values.Name, values.ProjectID), nil
+	}
+	changes := make([]string, 0, len(steps))
+	for _, s := range steps {
+		changes = append(changes, s.change)
+	}
+	id := ApprovalID(values, changes)
+	if err := svcs.Approval.Approved(ctx, id, requiredApprovals); err != nil {
+		svcs.Logger.Info("changing %q requires approval: %s", changes, err)
+		return nil, requestApproval(ctx, svcs.Approval, id, changes, values)
+	}
+	result.Message = fmt.Sprintf("changed %q", changes)
+	if values.DryRun {
+		return result.Touch(resource(values)), nil
+	}
+	for _, s := range steps {
+		if err := s.apply(ctx); err != nil {
+			return nil, err
+		}
+		svcs.Logger.Info("applied %q to %s %q in project %q", s.change, values.Kind, values.Name, values.ProjectID)
+	}
+	return result.Touch(resource(values)), nil
+}
+
+// composerSteps restricts the control plane of the environment's GKE cluster, unless its owners
+// already restricted it, and rotates the cluster's credentials.
+func composerSteps(ctx context.Context, values *Values, svcs *Services) ([]step, error) {
+	location, clusterID, err := svcs.ManagedClusters.ComposerCluster(ctx, values.ProjectID, values.Region, values.Name)
+	if err != nil {
+		return nil, err
+	}
+	cluster, err := svcs.Container.Cluster(ctx, values.ProjectID, location, clusterID)
+	if err != nil {
+		return nil, err
+	}
+	steps := []step{}
+	if len(values.AuthorizedNetworks) > 0 && !services.AuthorizedNetworksEnabled(cluster) {
+		steps = append(steps, step{
+			change: fmt.Sprintf("clusters/%s/authorizedNetworks", clusterID),
+			apply: func(ctx context.Context) error {
+				return svcs.Container.RestrictControlPlane(ctx, values.ProjectID, location, clusterID, values.AuthorizedNetworks)
+			},
+		})
+	}
+	steps = append(steps, step{
+		change: fmt.Sprintf("clusters/%s/credentials", clusterID),
+		apply: func(ctx context.Context) error {
+			return svcs.Container.RotateCredentials(ctx, values.ProjectID, location, clusterID)
+		},
+	})
+	return steps, nil
+}
+
+// dataprocSteps removes the external IP addresses of the cluster's instances and deletes the
+// user-managed keys of their service account. The project's default compute service account is
+// shared with other instances so its keys are left as they are.
+func dataprocSteps(ctx context.Context, values *Values, svcs *Services) ([]step, error) {
+	nodes, err := svcs.ManagedClusters.DataprocNodes(ctx, values.ProjectID, values.Region, values.Name)
+	if err != nil {
+		return nil, err
+	}
+	steps := []step{}
+	for _, instance := range nodes.Instances {
+		instance := instance
+		external, err := svcs.Host.HasExternalIP(ctx, values.ProjectID, nodes.Zone, instance)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to check external ip of instance %q", instance)
+		}
+		if !external {
+			continue
+		}
+		steps = append(steps, step{
+			change: fmt.Sprintf("instances/%s/externalIPs", instance),
+			apply: func(ctx context.Context) error {
+				return svcs.Host.RemoveExternalIPs(ctx, values.ProjectID, nodes.Zone, instance)
+			},
+		})
+	}
+	if nodes.ServiceAccount == "" {
+		svcs.Logger.Warning("dataproc cluster %q in project %q runs as the default compute service account, its keys are left as they are", values.Name, values.ProjectID)
+		return steps, nil
+	}
+	keys, err := svcs.ServiceAccounts.Keys(ctx, nodes.ServiceAccount)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		key := key
+		steps = append(steps, step{
+			change: key,
+			apply: func(ctx context.Context) error {
+				return svcs.ServiceAccounts.DeleteKey(ctx, key)
+			},
+		})
+	}
+	return steps, nil
+}
+
+// ApprovalID returns the approval request ID for applying changes to a cluster.
+func ApprovalID(values *Values, changes []string) string {
+	return services.ApprovalID(action, resource(values), changes)
+}
+
+func resource(values *Values) string {
+	if values.Kind == KindComposer {
+		return fmt.Sprintf("projects/%s/locations/%s/environments/%s", values.ProjectID, values.Region, values.Name)
+	}
+	return fmt.Sprintf("projects/%s/regions/%s/clusters/%s", values.ProjectID, values.Region, values.Name)
+}
+
+func requestApproval(ctx context.Context, approval *services.Approval, id string, changes []string, values *Values) error {
+	b, err := json.Marshal(values)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal values")
+	}
+	return approval.Request(ctx, &services.ApprovalRequest{
+		ID:       id,
+		Action:   action,
+		Resource: resource(values),
+		Changes:  changes,
+		Required: requiredApprovals,
+		Topic:    Topic,
+		Values:   b,
+	})
+}
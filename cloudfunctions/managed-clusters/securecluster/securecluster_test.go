@@ -0,0 +1,195 @@
+package securecluster
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+	"github.com/googlecloudplatform/security-response-automation/services"
+	composer "google.golang.org/api/composer/v1"
+	compute "google.golang.org/api/compute/v1"
+	container "google.golang.org/api/container/v1"
+	dataproc "google.golang.org/api/dataproc/v1"
+	iam "google.golang.org/api/iam/v1"
+)
+
+const (
+	projectID = "test-project"
+	region    = "us-central1"
+	sa        = "dataproc@test-project.iam.gserviceaccount.com"
+	key       = "projects/test-project/serviceAccounts/dataproc@test-project.iam.gserviceaccount.com/keys/abc"
+)
+
+func TestSecureComposer(t *testing.T) {
+	ctx := context.Background()
+	base := Values{ProjectID: projectID, Kind: KindComposer, Region: region, Name: "airflow", AuthorizedNetworks: []string{"10.0.0.0/8"}}
+	bothID := ApprovalID(&base, []string{"clusters/airflow-gke/authorizedNetworks", "clusters/airflow-gke/credentials"})
+	rotateID := ApprovalID(&base, []string{"clusters/airflow-gke/credentials"})
+	for _, tt := range []struct {
+		name             string
+		cluster          *container.Cluster
+		approvals        map[string][]string
+		dryRun           bool
+		expectedUpdate   bool
+		expectedRotation bool
+		expectedApproval bool
+	}{
+		{
+			name:             "requires approval",
+			cluster:          &container.Cluster{Name: "airflow-gke"},
+			expectedApproval: true,
+		},
+		{
+			name:             "approved",
+			cluster:          &container.Cluster{Name: "airflow-gke"},
+			approvals:        map[string][]string{bothID: {"alice@foo.com"}},
+			expectedUpdate:   true,
+			expectedRotation: true,
+		},
+		{
+			name:             "approval for a different change",
+			cluster:          &container.Cluster{Name: "airflow-gke", MasterAuthorizedNetworksConfig: &container.MasterAuthorizedNetworksConfig{Enabled: true}},
+			approvals:        map[string][]string{bothID: {"alice@foo.com"}},
+			expectedApproval: true,
+		},
+		{
+			name:             "control plane already restricted",
+			cluster:          &container.Cluster{Name: "airflow-gke", MasterAuthorizedNetworksConfig: &container.MasterAuthorizedNetworksConfig{Enabled: true}},
+			approvals:        map[string][]string{rotateID: {"alice@foo.com"}},
+			expectedRotation: true,
+		},
+		{
+			name:      "approved in dry run",
+			cluster:   &container.Cluster{Name: "airflow-gke"},
+			approvals: map[string][]string{bothID: {"alice@foo.com"}},
+			dryRun:    true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			containerStub := &stubs.ContainerStub{GetClusterResponse: tt.cluster}
+			composerStub := &stubs.ComposerStub{StubbedEnvironment: &composer.Environment{
+				Config: &composer.EnvironmentConfig{GkeCluster: "projects/test-project/zones/us-central1-a/clusters/airflow-gke"},
+			}}
+			psStub := &stubs.PubSubStub{}
+			state := services.NewState(&stubs.StorageStub{}, "state")
+			for id, emails := range tt.approvals {
+				for _, email := range emails {
+					if err := state.RecordApproval(ctx, id, email); err != nil {
+						t.Fatalf("%s failed to record approval: %q", tt.name, err)
+					}
+				}
+			}
+			values := base
+			values.DryRun = tt.dryRun
+			if _, err := Execute(ctx, &values, &Services{
+				ManagedClusters: services.NewManagedClusters(composerStub, &stubs.DataprocStub{}),
+				Container:       services.NewContainer(containerStub),
+				Approval:        services.NewApproval(services.NewPubSub(psStub), state, "approvals", []string{"alice@foo.com"}),
+				Logger:          services.NewLogger(&stubs.LoggerStub{}),
+			}); err != nil {
+				t.Fatalf("%s failed: %q", tt.name, err)
+			}
+			if got := containerStub.UpdatedCluster != nil; got != tt.expectedUpdate {
+				t.Errorf("%s failed: control plane restricted %t want %t", tt.name, got, tt.expectedUpdate)
+			}
+			if got := containerStub.StartedIPRotation != nil; got != tt.expectedRotation {
+				t.Errorf("%s failed: credentials rotated %t want %t", tt.name, got, tt.expectedRotation)
+			}
+			if got := psStub.PublishedMessage != nil; got != tt.expectedApproval {
+				t.Errorf("%s failed: approval requested %t want %t", tt.name, got, tt.expectedApproval)
+			}
+		})
+	}
+}
+
+func TestSecureDataproc(t *testing.T) {
+	ctx := context.Background()
+	base := Values{ProjectID: projectID, Kind: KindDataproc, Region: region, Name: "etl"}
+	id := ApprovalID(&base, []string{"instances/etl-m/externalIPs", key})
+	ipID := ApprovalID(&base, []string{"instances/etl-m/externalIPs"})
+	for _, tt := range []struct {
+		name             string
+		serviceAccount   string
+		approvals        map[string][]string
+		expectedRemoved  []stubs.NetworkAccessConfigStub
+		expectedDeleted  int
+		expectedApproval bool
+	}{
+		{
+			name:             "requires approval",
+			serviceAccount:   sa,
+			expectedApproval: true,
+		},
+		{
+			name:            "approved",
+			serviceAccount:  sa,
+			approvals:       map[string][]string{id: {"alice@foo.com"}},
+			expectedRemoved: []stubs.NetworkAccessConfigStub{{NetworkInterfaceName: "nic0", AccessConfigName: "external-nat"}},
+			expectedDeleted: 1,
+		},
+		{
+			name:            "default service account keys kept",
+			approvals:       map[string][]string{ipID: {"alice@foo.com"}},
+			expectedRemoved: []stubs.NetworkAccessConfigStub{{NetworkInterfaceName: "nic0", AccessConfigName: "external-nat"}},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			computeStub := &stubs.ComputeStub{StubbedInstance: &compute.Instance{
+				NetworkInterfaces: []*compute.NetworkInterface{{
+					Name:          "nic0",
+					AccessConfigs: []*compute.AccessConfig{{Name: "external-nat", Type: "ONE_TO_ONE_NAT", NatIP: "35.1.2.3"}},
+				}},
+			}}
+			dataprocStub := &stubs.DataprocStub{StubbedCluster: &dataproc.Cluster{Config: &dataproc.ClusterConfig{
+				GceClusterConfig: &dataproc.GceClusterConfig{ZoneUri: "https://www.googleapis.com/compute/v1/projects/test-project/zones/us-central1-a", ServiceAccount: tt.serviceAccount},
+				MasterConfig:     &dataproc.InstanceGroupConfig{InstanceNames: []string{"etl-m"}},
+			}}}
+			iamStub := &stubs.ServiceAccountsStub{StubbedKeys: map[string][]*iam.ServiceAccountKey{
+				"projects/-/serviceAccounts/" + sa: {{Name: key}},
+			}}
+			psStub := &stubs.PubSubStub{}
+			state := services.NewState(&stubs.StorageStub{}, "state")
+			for id, emails := range tt.approvals {
+				for _, email := range emails {
+					if err := state.RecordApproval(ctx, id, email); err != nil {
+						t.Fatalf("%s failed to record approval: %q", tt.name, err)
+					}
+				}
+			}
+			values := base
+			if _, err := Execute(ctx, &values, &Services{
+				ManagedClusters: services.NewManagedClusters(&stubs.ComposerStub{}, dataprocStub),
+				Host:            services.NewHost(computeStub),
+				ServiceAccounts: services.NewServiceAccounts(iamStub),
+				Approval:        services.NewApproval(services.NewPubSub(psStub), state, "approvals", []string{"alice@foo.com"}),
+				Logger:          services.NewLogger(&stubs.LoggerStub{}),
+			}); err != nil {
+				t.Fatalf("%s failed: %q", tt.name, err)
+			}
+			if diff := cmp.Diff(tt.expectedRemoved, computeStub.DeletedAccessConfigs); diff != "" {
+				t.Errorf("%s failed, removed access configs differ (-want +got):\n%s", tt.name, diff)
+			}
+			if got := len(iamStub.Requested("DeleteServiceAccountKey")); got != tt.expectedDeleted {
+				t.Errorf("%s failed: deleted %d keys want %d", tt.name, got, tt.expectedDeleted)
+			}
+			if got := psStub.PublishedMessage != nil; got != tt.expectedApproval {
+				t.Errorf("%s failed: approval requested %t want %t", tt.name, got, tt.expectedApproval)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package poll
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/googlecloudplatform/security-response-automation/services"
+)
+
+// maxAge is how long an operation is polled for before it's given up on.
+const maxAge = 24 * time.Hour
+
+// Values contains the values needed for this function.
+type Values struct{}
+
+// Services contains the services needed for this function.
+type Services struct {
+	State    *services.State
+	CloudSQL *services.CloudSQL
+	Host     *services.Host
+	Logger   *services.Logger
+}
+
+// Execute checks every long running operation started by an automation.
+//
+// Completed operations are audited on behalf of the automation that started them and their records
+// removed. Operations still running, or that couldn't be looked up, are checked again on the next
+// run unless they are older than maxAge in which case they're reported as failed and no longer
+// polled.
+func Execute(ctx context.Context, values *Values, svcs *Services) (*services.Result, error) {
+	result := services.NewResult("poll_operations", false)
+	if svcs.State == nil {
+		return nil, fmt.Errorf("no state bucket configured")
+	}
+	pending, err := svcs.State.PendingOperations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	failed := 0
+	for _, r := range pending {
+		done, err := operationDone(ctx, svcs, r)
+		expired := time.Since(r.StartTime) >= maxAge
+		switch {
+		case !done && err != nil && !expired:
+			svcs.Logger.Warning("failed to check %q on %q in project %q, checking again on the next run: %q", r.Action, r.Resource, r.ProjectID, err)
+			continue
+		case !done && !expired:
+			continue
+		case !done && err == nil:
+			err = fmt.Errorf("operation did not complete within %s", maxAge)
+		}
+		if err != nil {
+			svcs.Logger.Error("%q on %q in project %q failed: %q", r.Action, r.Resource, r.ProjectID, err)
+			failed++
+		} else {
+			svcs.Logger.Info("%q on %q in project %q completed", r.Action, r.Resource, r.ProjectID)
+			audit(svcs.Logger, r)
+			result.Touch(r.Resource)
+		}
+		if err := svcs.State.RemoveOperation(ctx, r); err != nil {
+			svcs.Logger.Error("failed to remove record of operation %q: %q", r.Operation, err)
+		}
+	}
+	if failed > 0 {
+		return nil, fmt.Errorf("%d of %d pending operations failed", failed, len(pending))
+	}
+	return result, nil
+}
+
+// operationDone returns whether the operation completed. An error with done false means the
+// operation couldn't be looked up, with done true that it completed with errors.
+func operationDone(ctx context.Context, svcs *Services, r *services.OperationRecord) (bool, error) {
+	switch r.Service {
+	case services.OperationCloudSQL:
+		return svcs.CloudSQL.OperationDone(ctx, r.ProjectID, r.Operation)
+	case services.OperationComputeZone:
+		return svcs.Host.ZoneOperationDone(ctx, r.ProjectID, r.Operation)
+	default:
+		return false, fmt.Errorf("unknown operation service %q", r.Service)
+	}
+}
+
+func audit(logr *services.Logger, r *services.OperationRecord) {
+	logr.Audit(&services.AuditRecord{
+		Action:   r.Action,
+		Resource: r.Resource,
+		Result:   services.AuditResultSuccess,
+		Message:  r.Message,
+	})
+}
@@ -0,0 +1,181 @@
+package poll
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/cloud-sql/enablebackups"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gce/createsnapshot"
+	"github.com/googlecloudplatform/security-response-automation/services"
+	compute "google.golang.org/api/compute/v1"
+	sqladmin "google.golang.org/api/sqladmin/v1beta4"
+)
+
+func TestPollOperations(t *testing.T) {
+	ctx := context.Background()
+	storageStub := &stubs.StorageStub{}
+	state := services.NewState(storageStub, "state-bucket")
+	loggerStub := &stubs.LoggerStub{}
+	logr := services.NewLogger(loggerStub)
+	sqlStub := &stubs.CloudSQL{
+		InstanceDetailsResponse: &sqladmin.DatabaseInstance{DatabaseVersion: "POSTGRES_11", Settings: &sqladmin.Settings{}},
+		OperationResponse:       &sqladmin.Operation{Status: "RUNNING"},
+	}
+	cloudSQL := services.NewCloudSQL(sqlStub)
+	values := &enablebackups.Values{ProjectID: "project-id", InstanceName: "instance"}
+
+	// Redelivering the finding while the patch is running must not start it again.
+	for i := 0; i < 2; i++ {
+		sqlStub.SavedInstanceUpdated = nil
+		if _, err := enablebackups.Execute(ctx, values, &enablebackups.Services{CloudSQL: cloudSQL, Logger: logr, State: state}); err != nil {
+			t.Fatalf("failed to enable backups: %q", err)
+		}
+		if patched := sqlStub.SavedInstanceUpdated != nil; patched != (i == 0) {
+			t.Errorf("delivery %d patched instance: %t", i, patched)
+		}
+	}
+	if len(storageStub.WrittenObjects) != 1 {
+		t.Fatalf("got %d operation records want 1", len(storageStub.WrittenObjects))
+	}
+	if len(loggerStub.AuditRecords) != 0 {
+		t.Fatalf("got audit records before the operation completed: %+v", loggerStub.AuditRecords)
+	}
+
+	svcs := &Services{State: state, CloudSQL: cloudSQL, Logger: logr}
+	if _, err := Execute(ctx, &Values{}, svcs); err != nil {
+		t.Fatalf("failed to poll: %q", err)
+	}
+	if len(storageStub.WrittenObjects) != 1 {
+		t.Fatalf("running operation should still be recorded")
+	}
+
+	sqlStub.OperationResponse = nil
+	if _, err := Execute(ctx, &Values{}, svcs); err != nil {
+		t.Fatalf("failed to poll: %q", err)
+	}
+	if len(storageStub.WrittenObjects) != 0 {
+		t.Errorf("got %d operation records remaining want 0", len(storageStub.WrittenObjects))
+	}
+	want := []interface{}{&services.AuditRecord{
+		Action:   "cloud_sql_enable_backups",
+		Resource: "instance",
+		Result:   services.AuditResultSuccess,
+		Message:  `backups enabled with binary logging false in project "project-id"`,
+	}}
+	if diff := cmp.Diff(want, loggerStub.AuditRecords); diff != "" {
+		t.Errorf("unexpected audit records: %s", diff)
+	}
+}
+
+func TestPollFailedOperation(t *testing.T) {
+	ctx := context.Background()
+	storageStub := &stubs.StorageStub{}
+	state := services.NewState(storageStub, "state-bucket")
+	if _, err := state.RecordOperation(ctx, "cloud_sql_require_ssl", "project-id", "instance", services.OperationCloudSQL, "operation-1", ""); err != nil {
+		t.Fatalf("failed to record operation: %q", err)
+	}
+	sqlStub := &stubs.CloudSQL{OperationResponse: &sqladmin.Operation{
+		Status: "DONE",
+		Error:  &sqladmin.OperationErrors{Errors: []*sqladmin.OperationError{{Code: "INTERNAL_ERROR", Message: "failed"}}},
+	}}
+	loggerStub := &stubs.LoggerStub{}
+	_, err := Execute(ctx, &Values{}, &Services{State: state, CloudSQL: services.NewCloudSQL(sqlStub), Logger: services.NewLogger(loggerStub)})
+	if err == nil {
+		t.Errorf("failed operation should return an error")
+	}
+	if len(storageStub.WrittenObjects) != 0 {
+		t.Errorf("failed operation should no longer be polled")
+	}
+	if len(loggerStub.AuditRecords) != 0 {
+		t.Errorf("failed operation should not be audited as a success")
+	}
+}
+
+func TestPollKeepsOperationOnLookupError(t *testing.T) {
+	ctx := context.Background()
+	storageStub := &stubs.StorageStub{}
+	state := services.NewState(storageStub, "state-bucket")
+	if _, err := state.RecordOperation(ctx, "cloud_sql_require_ssl", "project-id", "instance", services.OperationCloudSQL, "operation-1", ""); err != nil {
+		t.Fatalf("failed to record operation: %q", err)
+	}
+	sqlStub := &stubs.CloudSQL{}
+	sqlStub.Inject("Operation", stubs.Result{Err: errors.New("backend unavailable")})
+	if _, err := Execute(ctx, &Values{}, &Services{State: state, CloudSQL: services.NewCloudSQL(sqlStub), Logger: services.NewLogger(&stubs.LoggerStub{})}); err != nil {
+		t.Errorf("lookup error should be retried on the next run: %q", err)
+	}
+	if len(storageStub.WrittenObjects) != 1 {
+		t.Errorf("operation that couldn't be looked up should still be recorded")
+	}
+}
+
+func TestPollSnapshotOperations(t *testing.T) {
+	ctx := context.Background()
+	storageStub := &stubs.StorageStub{}
+	state := services.NewState(storageStub, "state-bucket")
+	computeStub := &stubs.ComputeStub{
+		SavedCreateSnapshots: map[string]compute.Snapshot{},
+		StubbedListDisks: &compute.DiskList{Items: []*compute.Disk{{
+			Name:     "disk",
+			SelfLink: "/projects/project-id/zones/zone/disks/disk",
+			Users:    []string{"/projects/project-id/zones/zone/instances/instance"},
+		}}},
+		StubbedListProjectSnapshots: []*compute.SnapshotList{{}, {}},
+		StubbedZoneOperation:        &compute.Operation{Status: "RUNNING"},
+	}
+	host := services.NewHost(computeStub)
+	loggerStub := &stubs.LoggerStub{}
+	logr := services.NewLogger(loggerStub)
+	values := &createsnapshot.Values{ProjectID: "project-id", RuleName: "bad_ip", Instance: "instance", Zone: "zone"}
+
+	// Redelivering the finding while the snapshot is being created must not start it again.
+	for i := 0; i < 2; i++ {
+		computeStub.SavedCreateSnapshots = map[string]compute.Snapshot{}
+		if _, err := createsnapshot.Execute(ctx, values, &createsnapshot.Services{Host: host, Logger: logr, State: state}); err != nil {
+			t.Fatalf("failed to snapshot: %q", err)
+		}
+		if created := len(computeStub.SavedCreateSnapshots) == 1; created != (i == 0) {
+			t.Errorf("delivery %d created snapshot: %t", i, created)
+		}
+	}
+	if len(storageStub.WrittenObjects) != 1 {
+		t.Fatalf("got %d operation records want 1", len(storageStub.WrittenObjects))
+	}
+
+	svcs := &Services{State: state, Host: host, Logger: logr}
+	if _, err := Execute(ctx, &Values{}, svcs); err != nil {
+		t.Fatalf("failed to poll: %q", err)
+	}
+	if len(storageStub.WrittenObjects) != 1 {
+		t.Fatalf("running operation should still be recorded")
+	}
+	computeStub.StubbedZoneOperation = nil
+	if _, err := Execute(ctx, &Values{}, svcs); err != nil {
+		t.Fatalf("failed to poll: %q", err)
+	}
+	want := []interface{}{&services.AuditRecord{
+		Action:   "gce_create_disk_snapshot",
+		Resource: "forensic-snapshots-bad-ip-disk",
+		Result:   services.AuditResultSuccess,
+		Message:  `snapshot of disk "disk" created in project "project-id"`,
+	}}
+	if diff := cmp.Diff(want, loggerStub.AuditRecords); diff != "" {
+		t.Errorf("unexpected audit records: %s", diff)
+	}
+}
@@ -0,0 +1,12 @@
+variable "setup" {}
+
+variable "folder-ids" {
+  type        = list(string)
+  description = "Folder IDs to grant the necessary permissions for this Cloud Function execution."
+}
+
+variable "schedule" {
+  type        = string
+  default     = "*/5 * * * *"
+  description = "Cron schedule to check long running operations on."
+}
@@ -0,0 +1,490 @@
+package router
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/googlecloudplatform/security-response-automation/services"
+	"github.com/pkg/errors"
+)
+
+// conditionFields are the fields a condition can refer to. Labels of the project are referred to
+// as "project.labels.<key>".
+var conditionFields = map[string]bool{
+	"severity":   true,
+	"rule":       true,
+	"hour":       true,
+	"weekday":    true,
+	"project.id": true,
+	"ancestry":   true,
+	// ip.reputation is the worst reputation of the finding's public IPs and ip.country the comma
+	// separated countries they're located in, both looked up with the intel provider.
+	"ip.reputation": true,
+	"ip.country":    true,
+}
+
+const labelFieldPrefix = "project.labels."
+
+// Condition is a predicate on the finding and the resource an automation acts on, such as
+// `severity in ["HIGH", "CRITICAL"] and project.labels.env != "prod"`. Comparisons are combined
+// with and, or, not and parentheses. The operators are ==, !=, <, <=, >, >=, in and contains.
+// Values are compared as numbers if both are numbers and as strings otherwise.
+type Condition struct {
+	expr expr
+}
+
+// Lookup returns the value of a field of a condition.
+type Lookup func(field string) (string, error)
+
+// ParseCondition parses a condition, failing on syntax errors and unknown fields.
+func ParseCondition(s string) (*Condition, error) {
+	tokens, err := tokenize(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	e, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEnd {
+		return nil, fmt.Errorf("unexpected %q at %d", t.text, t.pos)
+	}
+	return &Condition{expr: e}, nil
+}
+
+// Eval returns whether the condition holds given the values of its fields.
+func (c *Condition) Eval(lookup Lookup) (bool, error) {
+	return c.expr.eval(lookup)
+}
+
+type expr interface {
+	eval(Lookup) (bool, error)
+}
+
+type and struct{ left, right expr }
+
+func (e and) eval(l Lookup) (bool, error) {
+	ok, err := e.left.eval(l)
+	if err != nil || !ok {
+		return false, err
+	}
+	return e.right.eval(l)
+}
+
+type or struct{ left, right expr }
+
+func (e or) eval(l Lookup) (bool, error) {
+	ok, err := e.left.eval(l)
+	if err != nil || ok {
+		return ok, err
+	}
+	return e.right.eval(l)
+}
+
+type not struct{ expr expr }
+
+func (e not) eval(l Lookup) (bool, error) {
+	ok, err := e.expr.eval(l)
+	if err != nil {
+		return false, err
+	}
+	return !ok, nil
+}
+
+// operand is either a field or a literal value.
+type operand struct {
+	field string
+	value string
+}
+
+func (o operand) resolve(l Lookup) (string, error) {
+	if o.field == "" {
+		return o.value, nil
+	}
+	return l(o.field)
+}
+
+type comparison struct {
+	op          string
+	left, right operand
+	list        []string
+}
+
+func (e comparison) eval(l Lookup) (bool, error) {
+	left, err := e.left.resolve(l)
+	if err != nil {
+		return false, err
+	}
+	if e.op == "in" {
+		for _, v := range e.list {
+			if compare(left, v) == 0 {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	right, err := e.right.resolve(l)
+	if err != nil {
+		return false, err
+	}
+	switch e.op {
+	case "contains":
+		return strings.Contains(left, right), nil
+	case "==":
+		return compare(left, right) == 0, nil
+	case "!=":
+		return compare(left, right) != 0, nil
+	case "<":
+		return compare(left, right) < 0, nil
+	case "<=":
+		return compare(left, right) <= 0, nil
+	case ">":
+		return compare(left, right) > 0, nil
+	default:
+		return compare(left, right) >= 0, nil
+	}
+}
+
+// compare compares a and b as numbers if both are numbers, otherwise as strings ignoring case.
+func compare(a, b string) int {
+	x, errA := strconv.ParseFloat(a, 64)
+	y, errB := strconv.ParseFloat(b, 64)
+	if errA == nil && errB == nil {
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		default:
+			return 0
+		}
+	}
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+const (
+	tokenEnd = iota
+	tokenIdent
+	tokenString
+	tokenNumber
+	tokenOp
+	tokenPunct
+)
+
+type token struct {
+	kind int
+	text string
+	pos  int
+}
+
+// tokenize splits a condition into identifiers, quoted strings, numbers, operators and brackets.
+func tokenize(s string) ([]token, error) {
+	tokens := []token{}
+	rs := []rune(s)
+	for i := 0; i < len(rs); {
+		r := rs[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '"' || r == '\'':
+			j := i + 1
+			for j < len(rs) && rs[j] != r {
+				j++
+			}
+			if j == len(rs) {
+				return nil, fmt.Errorf("unterminated string at %d", i)
+			}
+			tokens = append(tokens, token{kind: tokenString, text: string(rs[i+1 : j]), pos: i})
+			i = j + 1
+		case unicode.IsDigit(r) || r == '-' && i+1 < len(rs) && unicode.IsDigit(rs[i+1]):
+			j := i + 1
+			for j < len(rs) && (unicode.IsDigit(rs[j]) || rs[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: string(rs[i:j]), pos: i})
+			i = j
+		case unicode.IsLetter(r) || r == '_':
+			j := i + 1
+			for j < len(rs) && (unicode.IsLetter(rs[j]) || unicode.IsDigit(rs[j]) || strings.ContainsRune("_.-", rs[j])) {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: string(rs[i:j]), pos: i})
+			i = j
+		case strings.ContainsRune("()[],", r):
+			tokens = append(tokens, token{kind: tokenPunct, text: string(r), pos: i})
+			i++
+		case strings.ContainsRune("=!<>", r):
+			op := string(r)
+			if i+1 < len(rs) && rs[i+1] == '=' {
+				op += "="
+			}
+			if op == "=" || op == "!" {
+				return nil, fmt.Errorf("unknown operator %q at %d", op, i)
+			}
+			tokens = append(tokens, token{kind: tokenOp, text: op, pos: i})
+			i += len(op)
+		default:
+			return nil, fmt.Errorf("unexpected %q at %d", r, i)
+		}
+	}
+	return append(tokens, token{kind: tokenEnd, text: "end of condition", pos: len(rs)}), nil
+}
+
+// parser is a recursive descent parser of conditions, from the lowest precedence:
+//
+//	or         = and { "or" and }
+//	and        = unary { "and" unary }
+//	unary      = "not" unary | "(" or ")" | comparison
+//	comparison = operand op operand | operand "in" "[" value { "," value } "]"
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token { return p.tokens[p.pos] }
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEnd {
+		p.pos++
+	}
+	return t
+}
+
+// keyword consumes the next token if it's the given keyword.
+func (p *parser) keyword(k string) bool {
+	if t := p.peek(); t.kind == tokenIdent && t.text == k {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(text string) error {
+	if t := p.next(); t.text != text || t.kind == tokenString {
+		return fmt.Errorf("expected %q at %d, got %q", text, t.pos, t.text)
+	}
+	return nil
+}
+
+func (p *parser) or() (expr, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("or") {
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		left = or{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) and() (expr, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("and") {
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		left = and{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) unary() (expr, error) {
+	if p.keyword("not") {
+		e, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return not{e}, nil
+	}
+	if t := p.peek(); t.kind == tokenPunct && t.text == "(" {
+		p.pos++
+		e, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		return e, p.expect(")")
+	}
+	return p.comparison()
+}
+
+func (p *parser) comparison() (expr, error) {
+	left, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+	if p.keyword("in") {
+		list, err := p.list()
+		if err != nil {
+			return nil, err
+		}
+		return comparison{op: "in", left: left, list: list}, nil
+	}
+	op := p.next()
+	if op.kind != tokenOp && !(op.kind == tokenIdent && op.text == "contains") {
+		return nil, fmt.Errorf("expected an operator at %d, got %q", op.pos, op.text)
+	}
+	right, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+	return comparison{op: op.text, left: left, right: right}, nil
+}
+
+func (p *parser) operand() (operand, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenString, tokenNumber:
+		return operand{value: t.text}, nil
+	case tokenIdent:
+		if !conditionFields[t.text] && !(strings.HasPrefix(t.text, labelFieldPrefix) && len(t.text) > len(labelFieldPrefix)) {
+			return operand{}, fmt.Errorf("unknown field %q at %d", t.text, t.pos)
+		}
+		return operand{field: t.text}, nil
+	default:
+		return operand{}, fmt.Errorf("expected a field or value at %d, got %q", t.pos, t.text)
+	}
+}
+
+func (p *parser) list() ([]string, error) {
+	if err := p.expect("["); err != nil {
+		return nil, err
+	}
+	values := []string{}
+	for {
+		t := p.next()
+		if t.kind != tokenString && t.kind != tokenNumber {
+			return nil, fmt.Errorf("expected a value at %d, got %q", t.pos, t.text)
+		}
+		values = append(values, t.text)
+		if t := p.next(); t.text == "]" && t.kind == tokenPunct {
+			return values, nil
+		} else if t.text != "," || t.kind != tokenPunct {
+			return nil, fmt.Errorf("expected \",\" or \"]\" at %d, got %q", t.pos, t.text)
+		}
+	}
+}
+
+// checkCondition returns whether the automation's condition holds for the finding and the resource.
+// Automations without a condition always run. A playbook step whose condition does not hold is
+// added to its playbook as skipped.
+func checkCondition(ctx context.Context, svcs *Services, automation Automation, resource string, meta metadata, topic string, attrs map[string]string, values interface{}) (bool, error) {
+	if automation.Condition == "" {
+		return true, nil
+	}
+	c, err := ParseCondition(automation.Condition)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to parse condition of %q", automation.Action)
+	}
+	ok, err := c.Eval(findingLookup(ctx, svcs, resource, meta, time.Now().UTC()))
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to evaluate condition of %q", automation.Action)
+	}
+	if ok {
+		return true, nil
+	}
+	log.Printf("condition %q of %q does not hold for %q", automation.Condition, automation.Action, resource)
+	if s := simulating(ctx); s != nil {
+		s.record(automation, resource, OutcomeSkipped, nil, values)
+	}
+	if _, err := hold(ctx, resource, automation.Action, topic, attrs, values, services.StepSkipped); err != nil {
+		return false, err
+	}
+	return false, nil
+}
+
+// findingLookup returns the values of the fields of conditions for the finding and the resource an
+// automation acts on. The time fields are in UTC and the project fields are empty unless the
+// resource is a project. The IP fields are empty if no intel provider is configured.
+func findingLookup(ctx context.Context, svcs *Services, resource string, meta metadata, now time.Time) Lookup {
+	projectID := ""
+	if strings.HasPrefix(resource, "projects/") {
+		projectID = strings.TrimPrefix(resource, "projects/")
+	}
+	var intel []services.IPIntel
+	lookupIntel := func() ([]services.IPIntel, error) {
+		if intel != nil || svcs.Intel == nil || len(meta.IPs) == 0 {
+			return intel, nil
+		}
+		var err error
+		intel, err = svcs.Intel.Lookup(ctx, meta.IPs)
+		return intel, err
+	}
+	return func(field string) (string, error) {
+		switch {
+		case field == "severity":
+			return meta.Severity, nil
+		case field == "rule":
+			return meta.Rule, nil
+		case field == "hour":
+			return strconv.Itoa(now.Hour()), nil
+		case field == "weekday":
+			return now.Weekday().String()[:3], nil
+		case field == "project.id":
+			return projectID, nil
+		case field == "ancestry":
+			return svcs.Resource.AncestryPath(ctx, resource)
+		case field == "ip.reputation" && svcs.Intel != nil:
+			intel, err := lookupIntel()
+			if err != nil {
+				return "", err
+			}
+			return services.WorstReputation(intel), nil
+		case field == "ip.country":
+			intel, err := lookupIntel()
+			if err != nil {
+				return "", err
+			}
+			return countries(intel), nil
+		case strings.HasPrefix(field, labelFieldPrefix) && projectID != "":
+			labels, err := svcs.Resource.ProjectLabels(ctx, projectID)
+			if err != nil {
+				return "", err
+			}
+			return labels[strings.TrimPrefix(field, labelFieldPrefix)], nil
+		default:
+			return "", nil
+		}
+	}
+}
+
+// countries returns the distinct countries of the IPs, comma separated in the order first seen.
+func countries(intel []services.IPIntel) string {
+	var cs []string
+	seen := map[string]bool{}
+	for _, r := range intel {
+		if r.Country != "" && !seen[r.Country] {
+			seen[r.Country] = true
+			cs = append(cs, r.Country)
+		}
+	}
+	return strings.Join(cs, ",")
+}
@@ -0,0 +1,173 @@
+package router
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+	"github.com/googlecloudplatform/security-response-automation/services"
+	crm "google.golang.org/api/cloudresourcemanager/v1"
+)
+
+func TestCondition(t *testing.T) {
+	fields := map[string]string{
+		"severity":           "HIGH",
+		"rule":               "bad_ip",
+		"hour":               "20",
+		"weekday":            "Sat",
+		"project.id":         "test-project",
+		"project.labels.env": "dev",
+		"ancestry":           "organizations/456/folders/123/projects/test-project",
+	}
+	lookup := func(field string) (string, error) { return fields[field], nil }
+	for _, tt := range []struct {
+		condition string
+		expected  bool
+	}{
+		{condition: `severity == "high"`, expected: true},
+		{condition: `severity in ["HIGH", "CRITICAL"]`, expected: true},
+		{condition: `severity in ['LOW']`, expected: false},
+		{condition: `hour < 9 or hour >= 18`, expected: true},
+		{condition: `hour >= 9 and hour < 18`, expected: false},
+		{condition: `hour > 3`, expected: true},
+		{condition: `weekday in ["Sat", "Sun"] and not project.labels.env == "prod"`, expected: true},
+		{condition: `project.labels.env != "prod" and (rule == "ssh_brute_force" or hour <= 20)`, expected: true},
+		{condition: `not (severity == "HIGH" or rule == "bad_ip")`, expected: false},
+		{condition: `project.labels.owner == ""`, expected: true},
+		{condition: `ancestry contains "folders/123/"`, expected: true},
+		{condition: `project.id contains "prod"`, expected: false},
+	} {
+		t.Run(tt.condition, func(t *testing.T) {
+			c, err := ParseCondition(tt.condition)
+			if err != nil {
+				t.Fatalf("failed to parse: %q", err)
+			}
+			got, err := c.Eval(lookup)
+			if err != nil {
+				t.Fatalf("failed to evaluate: %q", err)
+			}
+			if got != tt.expected {
+				t.Errorf("got %t, expected %t", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseConditionErrors(t *testing.T) {
+	for _, tt := range []struct {
+		condition string
+		expected  string
+	}{
+		{condition: `severity = "HIGH"`, expected: `unknown operator "=" at 9`},
+		{condition: `colour == "red"`, expected: `unknown field "colour" at 0`},
+		{condition: `severity == "HIGH`, expected: `unterminated string at 12`},
+		{condition: `severity in "HIGH"`, expected: `expected "[" at 12, got "HIGH"`},
+		{condition: `(hour < 9`, expected: `expected ")" at 9, got "end of condition"`},
+		{condition: `hour < 9 hour`, expected: `unexpected "hour" at 9`},
+		{condition: `severity`, expected: `expected an operator at 8, got "end of condition"`},
+		{condition: ``, expected: `expected a field or value at 0, got "end of condition"`},
+	} {
+		t.Run(tt.condition, func(t *testing.T) {
+			_, err := ParseCondition(tt.condition)
+			if err == nil || err.Error() != tt.expected {
+				t.Errorf("got error %v, expected %q", err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestConditionSkipsSteps(t *testing.T) {
+	const siemAlert = `{"siemAlert": {"source": "chronicle", "id": "de_1234", "category": "compromised_instance", "resource": {"projectId": "test-project", "zone": "us-central1-a", "instance": "miner"}}}`
+	target := []string{"organizations/456/folders/123/projects/test-project"}
+	conf := &Configuration{}
+	conf.Spec.Parameters.SIEM.CompromisedInstance = []Automation{
+		{Action: "gce_create_disk_snapshot", Target: target, Playbook: "contain"},
+		{Action: "remove_public_ip", Target: target, Playbook: "contain", Condition: `project.labels.env != "prod"`},
+		{Action: "remove_load_balancer", Target: target, Condition: `project.labels.env != "prod"`},
+	}
+	crmStub := &stubs.ResourceManagerStub{
+		GetProjectResponse: map[string]*crm.Project{"test-project": {ProjectId: "test-project", Labels: map[string]string{"env": "prod"}}},
+	}
+	crmStub.GetAncestryResponse = services.CreateAncestors([]string{"project/test-project", "folder/123", "organization/456"})
+	storageStub := &stubs.StorageStub{}
+	psStub := &stubs.PubSubStub{}
+	loggerStub := &stubs.LoggerStub{}
+	svcs := &Services{
+		PubSub:                services.NewPubSub(psStub),
+		Logger:                services.NewLogger(loggerStub),
+		Configuration:         conf,
+		Resource:              services.NewResource(crmStub, storageStub),
+		SecurityCommandCenter: services.NewCommandCenter(&stubs.SecurityCommandCenterStub{}),
+		State:                 services.NewState(storageStub, "state"),
+	}
+	ctx := context.Background()
+	if err := Execute(ctx, &Values{Finding: []byte(siemAlert)}, svcs); err != nil {
+		t.Fatalf("failed to route finding: %q", err)
+	}
+	first := psStub.PublishedMessage
+	if first.Attributes[PlaybookStepAttribute] != "0" {
+		t.Fatalf("expected the first step to be published, got %+v", first.Attributes)
+	}
+	if err := ContinuePlaybook(ctx, svcs, first.Attributes, nil); err != nil {
+		t.Fatalf("failed to continue playbook: %q", err)
+	}
+	expected := []interface{}{&services.AuditRecord{
+		Action:   "playbook",
+		Resource: "projects/test-project",
+		Result:   services.AuditResultSuccess,
+		Message:  `playbook "contain" finished with success`,
+		Steps: []services.StepOutcome{
+			{Action: "gce_create_disk_snapshot", Status: services.StepSucceeded},
+			{Action: "remove_public_ip", Status: services.StepSkipped},
+		},
+	}}
+	if diff := cmp.Diff(expected, loggerStub.AuditRecords); diff != "" {
+		t.Errorf("audit record difference:%+v", diff)
+	}
+}
+
+func TestIPConditionFields(t *testing.T) {
+	ctx := context.Background()
+	intel := &stubs.IPIntelStub{StubbedIPs: map[string]stubs.StubbedIP{
+		"198.51.100.7": {Reputation: "malicious", Country: "RU"},
+		"203.0.113.9":  {Reputation: "harmless", Country: "US"},
+	}}
+	meta := metadata{IPs: []string{"198.51.100.7", "203.0.113.9"}}
+	for _, tt := range []struct {
+		name       string
+		intel      *services.Intel
+		meta       metadata
+		reputation string
+		country    string
+	}{
+		{name: "known bad", intel: services.NewIntel(intel), meta: meta, reputation: "malicious", country: "RU,US"},
+		{name: "no ips", intel: services.NewIntel(intel), meta: metadata{}, reputation: "unknown", country: ""},
+		{name: "no provider", intel: nil, meta: meta, reputation: "", country: ""},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			lookup := findingLookup(ctx, &Services{Intel: tt.intel}, "projects/test-project", tt.meta, time.Now())
+			if got, err := lookup("ip.reputation"); err != nil || got != tt.reputation {
+				t.Errorf("ip.reputation = %q, %v, expected %q", got, err, tt.reputation)
+			}
+			if got, err := lookup("ip.country"); err != nil || got != tt.country {
+				t.Errorf("ip.country = %q, %v, expected %q", got, err, tt.country)
+			}
+		})
+	}
+}
@@ -0,0 +1,549 @@
+package router
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import "sort"
+
+// Deployment is what an automation needs deployed: the topic it's published to, the Cloud Function
+// subscribed to the topic and the roles granted to the function's service account.
+type Deployment struct {
+	// Action is the action name used in the configuration, or restore_containment and
+	// poll_operations for the scheduled functions.
+	Action string
+	Topic  string
+	// Function is the name of the Cloud Function and its entry point in exec.go.
+	Function    string
+	Description string
+	// Timeout is the function's timeout in seconds.
+	Timeout int
+	// Schedule is the cron schedule the function is run on, empty unless it's run by Cloud Scheduler.
+	Schedule string
+	// Approval is whether the function requests approval and needs the approval topic and approvers.
+	Approval bool
+	// FolderRoles are granted on each folder the automations apply to.
+	FolderRoles []string
+	// OrganizationRoles are granted on the organization.
+	OrganizationRoles []string
+	// ProjectRoles are granted on the automation project.
+	ProjectRoles []string
+	// Permissions are the permissions the automation uses on the project of the finding, granted
+	// through FolderRoles. They're tested before the finding is published to the automation.
+	Permissions []string
+}
+
+// scheduled are the functions run by Cloud Scheduler to finish the work of other automations.
+var scheduled = map[string]Deployment{
+	"restore_containment": {
+		Topic:       "threat-findings-restore-containment",
+		Function:    "RestoreContainment",
+		Description: "Reverts temporary containment actions once they expire.",
+		Timeout:     360,
+		Schedule:    "*/15 * * * *",
+		FolderRoles: []string{"roles/compute.securityAdmin", "roles/compute.instanceAdmin.v1"},
+		Permissions: []string{
+			"compute.firewalls.delete",
+			"compute.firewalls.get",
+			"compute.firewalls.update",
+			"compute.globalOperations.get",
+			"compute.instances.addAccessConfig",
+			"compute.instances.get",
+			"compute.zoneOperations.get",
+		},
+	},
+	"poll_operations": {
+		Topic:       "threat-findings-poll-operations",
+		Function:    "PollOperations",
+		Description: "Audits long running operations started by automations once they complete.",
+		Timeout:     360,
+		Schedule:    "*/5 * * * *",
+		FolderRoles: []string{"roles/cloudsql.viewer", "roles/compute.viewer"},
+		Permissions: []string{"cloudsql.instances.get", "compute.zoneOperations.get"},
+	},
+}
+
+// deployments maps each action handled by Execute to its deployment. The topics are taken from
+// topics and the roles must match the function's Terraform module.
+var deployments = map[string]Deployment{
+	"gce_create_disk_snapshot": {
+		Function:     "SnapshotDisk",
+		Description:  "Takes a snapshot of a GCE disk.",
+		Timeout:      360,
+		FolderRoles:  []string{"roles/viewer", "roles/compute.admin"},
+		ProjectRoles: []string{"roles/pubsub.editor"},
+		Permissions: []string{
+			"compute.disks.createSnapshot",
+			"compute.disks.list",
+			"compute.globalOperations.get",
+			"compute.snapshots.create",
+			"compute.snapshots.delete",
+			"compute.snapshots.list",
+			"compute.snapshots.setLabels",
+			"compute.zoneOperations.get",
+		},
+	},
+	"iam_revoke": {
+		Function:     "IAMRevoke",
+		Description:  "Revokes IAM Event Threat Detection anomalous IAM grants.",
+		Timeout:      60,
+		FolderRoles:  []string{"roles/resourcemanager.folderAdmin", "roles/viewer"},
+		ProjectRoles: []string{"roles/pubsub.editor"},
+		Permissions: []string{
+			"resourcemanager.projects.get",
+			"resourcemanager.projects.getIamPolicy",
+			"resourcemanager.projects.setIamPolicy",
+		},
+	},
+	"iam_revoke_org": {
+		Function:          "IAMRevokeOrganization",
+		Description:       "Removes external members from organization and folder policies once approved.",
+		Timeout:           60,
+		Approval:          true,
+		FolderRoles:       []string{"roles/resourcemanager.folderAdmin"},
+		OrganizationRoles: []string{"roles/resourcemanager.organizationAdmin"},
+	},
+	"iam_revoke_grants": {
+		Function:     "IAMRevokeGrants",
+		Description:  "Revokes the IAM grants made by a suspicious principal.",
+		Timeout:      120,
+		FolderRoles:  []string{"roles/resourcemanager.folderAdmin", "roles/logging.viewer"},
+		ProjectRoles: []string{"roles/pubsub.editor"},
+		Permissions: []string{
+			"logging.logEntries.list",
+			"resourcemanager.projects.getIamPolicy",
+			"resourcemanager.projects.setIamPolicy",
+		},
+	},
+	"quarantine_service_account": {
+		Function:          "QuarantineServiceAccount",
+		Description:       "Removes the roles of a compromised service account and disables it.",
+		Timeout:           120,
+		FolderRoles:       []string{"roles/resourcemanager.folderAdmin", "roles/iam.serviceAccountAdmin"},
+		OrganizationRoles: []string{"roles/resourcemanager.organizationAdmin"},
+		Permissions: []string{
+			"iam.serviceAccountKeys.list",
+			"iam.serviceAccounts.disable",
+			"iam.serviceAccounts.get",
+			"resourcemanager.projects.getIamPolicy",
+			"resourcemanager.projects.setIamPolicy",
+		},
+	},
+	"close_bucket": {
+		Function:    "CloseBucket",
+		Description: "Removes users that enable public viewing of GCS buckets.",
+		Timeout:     60,
+		FolderRoles: []string{"roles/viewer", "roles/storage.admin"},
+		Permissions: []string{
+			"storage.buckets.getIamPolicy",
+			"storage.buckets.setIamPolicy",
+		},
+	},
+	"close_staging_bucket": {
+		Function:    "CloseStagingBucket",
+		Description: "Removes public access from Dataproc and Dataflow staging buckets and their objects.",
+		Timeout:     60,
+		FolderRoles: []string{"roles/viewer", "roles/storage.admin"},
+		Permissions: []string{
+			"storage.buckets.getIamPolicy",
+			"storage.buckets.setIamPolicy",
+			"storage.objects.getIamPolicy",
+			"storage.objects.list",
+			"storage.objects.setIamPolicy",
+		},
+	},
+	"enable_bucket_only_policy": {
+		Function:    "EnableBucketOnlyPolicy",
+		Description: "Enable bucket only IAM policy on GCS buckets.",
+		Timeout:     60,
+		FolderRoles: []string{"roles/viewer", "roles/storage.admin"},
+		Permissions: []string{
+			"storage.buckets.get",
+			"storage.buckets.update",
+		},
+	},
+	"enable_bucket_logging": {
+		Function:    "EnableBucketLogging",
+		Description: "Enable access logging and object versioning on GCS buckets.",
+		Timeout:     60,
+		FolderRoles: []string{"roles/viewer", "roles/storage.admin"},
+		Permissions: []string{
+			"storage.buckets.get",
+			"storage.buckets.update",
+		},
+	},
+	"close_cloud_sql": {
+		Function:    "CloseCloudSQL",
+		Description: "Removes public IPs from a Cloud SQL instance.",
+		Timeout:     180,
+		FolderRoles: []string{"roles/viewer", "roles/cloudsql.editor"},
+		Permissions: []string{
+			"cloudsql.instances.get",
+			"cloudsql.instances.update",
+		},
+	},
+	"cloud_sql_require_ssl": {
+		Function:    "CloudSQLRequireSSL",
+		Description: "Enforces SSL to a Cloud SQL instance.",
+		Timeout:     180,
+		FolderRoles: []string{"roles/viewer", "roles/cloudsql.editor"},
+		Permissions: []string{
+			"cloudsql.instances.get",
+			"cloudsql.instances.update",
+		},
+	},
+	"cloud_sql_update_password": {
+		Function:    "UpdatePassword",
+		Description: "Updates the root user password of a Cloud SQL instance.",
+		Timeout:     180,
+		FolderRoles: []string{"roles/viewer", "roles/cloudsql.admin"},
+		Permissions: []string{"cloudsql.users.update"},
+	},
+	"cloud_sql_secure_root": {
+		Function:    "SecureRoot",
+		Description: "Secures Cloud SQL root users without a password.",
+		Timeout:     180,
+		FolderRoles: []string{"roles/viewer", "roles/cloudsql.admin", "roles/secretmanager.admin"},
+		Permissions: []string{
+			"cloudsql.users.delete",
+			"cloudsql.users.list",
+			"cloudsql.users.update",
+			"resourcemanager.projects.getIamPolicy",
+			"secretmanager.secrets.create",
+			"secretmanager.versions.add",
+		},
+	},
+	"cloud_sql_enable_backups": {
+		Function:    "EnableBackups",
+		Description: "Enables automated backups and binary logging on a Cloud SQL instance.",
+		Timeout:     360,
+		FolderRoles: []string{"roles/viewer", "roles/cloudsql.admin"},
+		Permissions: []string{
+			"cloudsql.instances.get",
+			"cloudsql.instances.update",
+		},
+	},
+	"remove_load_balancer": {
+		Function:    "RemoveLoadBalancer",
+		Description: "Removes external load balancers exposing a compromised instance once approved.",
+		Timeout:     360,
+		Approval:    true,
+		FolderRoles: []string{"roles/viewer", "roles/compute.loadBalancerAdmin"},
+		Permissions: []string{
+			"compute.backendServices.list",
+			"compute.globalForwardingRules.delete",
+			"compute.globalForwardingRules.list",
+			"compute.globalOperations.get",
+			"compute.instanceGroups.list",
+			"compute.targetHttpProxies.list",
+			"compute.targetHttpsProxies.list",
+			"compute.urlMaps.list",
+			"compute.urlMaps.update",
+		},
+	},
+	"disable_key_version": {
+		Function:    "DisableKeyVersion",
+		Description: "Disables a misused Cloud KMS key version and restricts its key to a break-glass group once approved.",
+		Timeout:     360,
+		Approval:    true,
+		FolderRoles: []string{"roles/cloudkms.admin"},
+		Permissions: []string{
+			"cloudkms.cryptoKeyVersions.get",
+			"cloudkms.cryptoKeyVersions.update",
+			"cloudkms.cryptoKeys.getIamPolicy",
+			"cloudkms.cryptoKeys.setIamPolicy",
+		},
+	},
+	"secure_managed_cluster": {
+		Function:    "SecureManagedCluster",
+		Description: "Removes public access from and rotates the credentials of a Composer environment or Dataproc cluster once approved.",
+		Timeout:     540,
+		Approval:    true,
+		FolderRoles: []string{"roles/viewer", "roles/container.clusterAdmin", "roles/compute.instanceAdmin.v1", "roles/iam.serviceAccountKeyAdmin"},
+		Permissions: []string{
+			"composer.environments.get",
+			"compute.instances.deleteAccessConfig",
+			"compute.instances.get",
+			"compute.zoneOperations.get",
+			"container.clusters.get",
+			"container.clusters.update",
+			"container.operations.get",
+			"dataproc.clusters.get",
+			"iam.serviceAccountKeys.delete",
+			"iam.serviceAccountKeys.list",
+		},
+	},
+	"lockdown_project": {
+		Function:    "LockdownProject",
+		Description: "Removes the IAM bindings, disables the service accounts and stops the instances of a compromised project once approved.",
+		Timeout:     540,
+		Approval:    true,
+		FolderRoles: []string{"roles/resourcemanager.folderAdmin", "roles/iam.serviceAccountAdmin", "roles/compute.instanceAdmin.v1"},
+		Permissions: []string{
+			"compute.instances.list",
+			"compute.instances.stop",
+			"compute.zoneOperations.get",
+			"iam.serviceAccountKeys.list",
+			"iam.serviceAccounts.disable",
+			"iam.serviceAccounts.list",
+			"resourcemanager.projects.get",
+			"resourcemanager.projects.getIamPolicy",
+			"resourcemanager.projects.setIamPolicy",
+		},
+	},
+	"disable_dashboard": {
+		Function:    "DisableDashboard",
+		Description: "Disable the Kubernetes dashboard addon",
+		Timeout:     60,
+		FolderRoles: []string{"roles/viewer", "roles/container.clusterAdmin"},
+		Permissions: []string{
+			"container.clusters.get",
+			"container.clusters.update",
+			"container.operations.get",
+		},
+	},
+	"harden_cluster_network": {
+		Function:    "HardenClusterNetwork",
+		Description: "Enforces network policy and master authorized networks on a GKE cluster",
+		Timeout:     540,
+		FolderRoles: []string{"roles/viewer", "roles/container.clusterAdmin"},
+		Permissions: []string{
+			"container.clusters.get",
+			"container.clusters.update",
+			"container.operations.get",
+		},
+	},
+	"remove_public_ip": {
+		Function:    "RemovePublicIP",
+		Description: "Removes all the external IP addresses of a GCE instance.",
+		Timeout:     180,
+		FolderRoles: []string{"roles/viewer", "roles/compute.instanceAdmin.v1", "roles/logging.viewer"},
+		Permissions: []string{
+			"compute.instances.deleteAccessConfig",
+			"compute.instances.get",
+			"compute.zoneOperations.get",
+			"logging.logEntries.list",
+		},
+	},
+	"disable_serial_port": {
+		Function:    "DisableSerialPort",
+		Description: "Disables interactive serial port access on a GCE instance.",
+		Timeout:     180,
+		FolderRoles: []string{"roles/viewer", "roles/compute.instanceAdmin.v1"},
+		Permissions: []string{
+			"compute.instances.get",
+			"compute.instances.setMetadata",
+			"compute.zoneOperations.get",
+		},
+	},
+	"harden_instance": {
+		Function:    "HardenInstance",
+		Description: "Disables legacy metadata endpoints and enables Shielded VM on a GCE instance.",
+		Timeout:     300,
+		FolderRoles: []string{"roles/viewer", "roles/compute.instanceAdmin.v1"},
+		Permissions: []string{
+			"compute.instances.get",
+			"compute.instances.setMetadata",
+			"compute.instances.start",
+			"compute.instances.stop",
+			"compute.instances.updateShieldedInstanceConfig",
+			"compute.zoneOperations.get",
+		},
+	},
+	"enforce_cmek": {
+		Function:    "EnforceCMEK",
+		Description: "Applies a customer-managed encryption key to buckets, datasets and tables, ticketing disks.",
+		Timeout:     60,
+		FolderRoles: []string{"roles/viewer", "roles/storage.admin", "roles/bigquery.dataOwner"},
+		Permissions: []string{
+			"bigquery.datasets.get",
+			"bigquery.datasets.update",
+			"bigquery.tables.get",
+			"bigquery.tables.update",
+			"storage.buckets.get",
+			"storage.buckets.update",
+		},
+	},
+	"disable_ip_forwarding": {
+		Function:    "DisableIPForwarding",
+		Description: "Disables IP forwarding on a GCE instance.",
+		Timeout:     180,
+		FolderRoles: []string{"roles/viewer", "roles/compute.instanceAdmin.v1"},
+		Permissions: []string{
+			"compute.instances.get",
+			"compute.instances.update",
+			"compute.zoneOperations.get",
+		},
+	},
+	"enforce_https": {
+		Function:    "EnforceHTTPS",
+		Description: "Requires modern TLS on load balancers and redirects HTTP to HTTPS.",
+		Timeout:     180,
+		FolderRoles: []string{"roles/viewer", "roles/compute.loadBalancerAdmin"},
+		Permissions: []string{
+			"compute.globalOperations.get",
+			"compute.sslPolicies.create",
+			"compute.sslPolicies.get",
+			"compute.targetHttpsProxies.get",
+			"compute.targetHttpsProxies.setSslPolicy",
+			"compute.targetSslProxies.get",
+			"compute.targetSslProxies.setSslPolicy",
+		},
+	},
+	"remediate_firewall": {
+		Function:    "OpenFirewall",
+		Description: "Remediate a open firewall rule.",
+		Timeout:     180,
+		FolderRoles: []string{"roles/viewer", "roles/compute.securityAdmin"},
+		Permissions: []string{
+			"compute.firewalls.create",
+			"compute.firewalls.delete",
+			"compute.firewalls.get",
+			"compute.firewalls.update",
+			"compute.globalOperations.get",
+		},
+	},
+	"disable_unused_firewall": {
+		Function:    "DisableUnusedFirewall",
+		Description: "Disables an open firewall rule Firewall Insights found shadowed or unused.",
+		Timeout:     180,
+		FolderRoles: []string{"roles/viewer", "roles/compute.securityAdmin", "roles/recommender.firewallAdmin"},
+		Permissions: []string{
+			"compute.firewalls.get",
+			"compute.firewalls.update",
+			"compute.globalOperations.get",
+			"recommender.computeFirewallInsights.list",
+			"recommender.computeFirewallInsights.update",
+		},
+	},
+	"block_egress": {
+		Function:    "BlockEgress",
+		Description: "Denies outbound traffic from a GCE instance suspected of exfiltrating data.",
+		Timeout:     180,
+		FolderRoles: []string{"roles/viewer", "roles/compute.securityAdmin"},
+		Permissions: []string{
+			"compute.firewalls.create",
+			"compute.firewalls.get",
+			"compute.globalOperations.get",
+			"compute.instances.get",
+		},
+	},
+	"close_public_dataset": {
+		Function:    "ClosePublicDataset",
+		Description: "Removes public access of a BigQuery dataset.",
+		Timeout:     60,
+		FolderRoles: []string{"roles/viewer", "roles/bigquery.dataOwner"},
+		Permissions: []string{
+			"bigquery.datasets.get",
+			"bigquery.datasets.update",
+		},
+	},
+	"restrict_sensitive_data": {
+		Function:    "RestrictSensitiveData",
+		Description: "Removes broad access to buckets and datasets holding sensitive data found by Cloud DLP.",
+		Timeout:     60,
+		FolderRoles: []string{"roles/viewer", "roles/storage.admin", "roles/bigquery.dataOwner"},
+		Permissions: []string{
+			"bigquery.datasets.get",
+			"bigquery.datasets.update",
+			"resourcemanager.projects.getIamPolicy",
+			"storage.buckets.getIamPolicy",
+			"storage.buckets.setIamPolicy",
+			"storage.buckets.update",
+		},
+	},
+	"enable_audit_logs": {
+		Function:    "EnableAuditLogs",
+		Description: "Remediate projects with data access audit logging disabled",
+		Timeout:     60,
+		FolderRoles: []string{"roles/editor", "roles/resourcemanager.folderAdmin"},
+		Permissions: []string{
+			"resourcemanager.projects.getIamPolicy",
+			"resourcemanager.projects.setIamPolicy",
+		},
+	},
+	"remove_non_org_members": {
+		Function:    "RemoveNonOrganizationMembers",
+		Description: "Removes all non-org members in which organization is not in the whitelist",
+		Timeout:     60,
+		FolderRoles: []string{"roles/resourcemanager.folderAdmin"},
+		Permissions: []string{
+			"resourcemanager.projects.getIamPolicy",
+			"resourcemanager.projects.setIamPolicy",
+		},
+	},
+	"remove_external_group_members": {
+		Function:    "RemoveExternalGroupMembers",
+		Description: "Removes members outside of the allowed domains from groups granted roles on a project.",
+		Timeout:     120,
+		FolderRoles: []string{"roles/iam.securityReviewer"},
+		Permissions: []string{
+			"resourcemanager.projects.getIamPolicy",
+		},
+	},
+}
+
+// Deployments returns what must be deployed for the automations in the configuration: each
+// configured action and the scheduled functions finishing their work, sorted by action. Actions
+// that aren't configured are left out so their roles aren't granted. The router is deployed
+// separately.
+func Deployments(c *Configuration) []Deployment {
+	enabled := map[string]bool{}
+	for _, r := range rules(c) {
+		for _, a := range r.automations {
+			if _, ok := deployments[a.Action]; !ok || !contains(r.actions, a.Action) {
+				continue
+			}
+			enabled[a.Action] = true
+			if a.Properties.TTL != "" {
+				enabled["restore_containment"] = true
+			}
+			if a.Action == "cloud_sql_require_ssl" || a.Action == "cloud_sql_enable_backups" || a.Action == "gce_create_disk_snapshot" {
+				enabled["poll_operations"] = true
+			}
+		}
+	}
+	actions := make([]string, 0, len(enabled))
+	for action := range enabled {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+	ds := make([]Deployment, 0, len(actions))
+	for _, action := range actions {
+		d, ok := deployments[action]
+		if ok {
+			d.Topic = topics[action].Topic
+		} else {
+			d = scheduled[action]
+		}
+		d.Action = action
+		ds = append(ds, d)
+	}
+	return ds
+}
+
+// RouterTopic is the topic the router receives findings from.
+const RouterTopic = "threat-findings"
+
+// TopicFunctions returns the entry point in exec.go of the function subscribed to each topic,
+// including the router and the scheduled functions.
+func TopicFunctions() map[string]string {
+	fs := map[string]string{RouterTopic: "Router"}
+	for action, d := range deployments {
+		fs[topics[action].Topic] = d.Function
+	}
+	for _, d := range scheduled {
+		fs[d.Topic] = d.Function
+	}
+	return fs
+}
@@ -0,0 +1,99 @@
+package router
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDeploymentsCoverTopics(t *testing.T) {
+	for action := range topics {
+		if _, ok := deployments[action]; !ok {
+			t.Errorf("action %q has a topic but no deployment", action)
+		}
+	}
+	for action, d := range deployments {
+		if _, ok := topics[action]; !ok {
+			t.Errorf("action %q has a deployment but no topic", action)
+		}
+		// Organization and folder automations aren't tested against the finding's project.
+		if len(d.Permissions) == 0 && len(d.OrganizationRoles) == 0 {
+			t.Errorf("action %q does not list the permissions it needs", action)
+		}
+	}
+}
+
+func TestDeployments(t *testing.T) {
+	const sqlConfig = `apiVersion: security-response-automation.cloud.google.com/v1alpha1
+kind: Remediation
+metadata:
+  name: router
+spec:
+  parameters:
+    sha:
+      ssl_not_enforced:
+        - action: cloud_sql_require_ssl
+          target:
+            - organizations/456/folders/123/projects/*
+      public_sql_instance:
+        - action: not_an_action
+          target:
+            - organizations/456/folders/123/projects/*
+`
+	for _, tt := range []struct {
+		name    string
+		config  string
+		actions []string
+	}{
+		{name: "firewall with ttl", config: validConfig, actions: []string{"remediate_firewall", "restore_containment"}},
+		{name: "cloud sql", config: sqlConfig, actions: []string{"cloud_sql_require_ssl", "poll_operations"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := ParseConfig([]byte(tt.config))
+			if err != nil {
+				t.Fatalf("failed to parse config: %q", err)
+			}
+			actions := []string{}
+			for _, d := range Deployments(c) {
+				if d.Topic == "" || d.Function == "" {
+					t.Errorf("deployment of %q is missing its topic or function: %+v", d.Action, d)
+				}
+				actions = append(actions, d.Action)
+			}
+			if diff := cmp.Diff(actions, tt.actions); diff != "" {
+				t.Errorf("%s failed: actions differ (-got +want):\n%s", tt.name, diff)
+			}
+		})
+	}
+}
+
+func TestTopicFunctions(t *testing.T) {
+	fs := TopicFunctions()
+	if expected := len(deployments) + len(scheduled) + 1; len(fs) != expected {
+		t.Errorf("%d topics have a function, expected %d", len(fs), expected)
+	}
+	for topic, f := range map[string]string{
+		RouterTopic:                           "Router",
+		"threat-findings-iam-revoke":          "IAMRevoke",
+		"threat-findings-restore-containment": "RestoreContainment",
+		"threat-findings-disable-key-version": "DisableKeyVersion",
+	} {
+		if fs[topic] != f {
+			t.Errorf("topic %q runs %q, expected %q", topic, fs[topic], f)
+		}
+	}
+}
@@ -0,0 +1,238 @@
+package router
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/googlecloudplatform/security-response-automation/services"
+	"github.com/pkg/errors"
+)
+
+// Modes automations are downgraded to during a freeze.
+const (
+	// FreezeDryRun runs the automation in dry run mode.
+	FreezeDryRun = "dry_run"
+	// FreezeTicket opens a ticket for the change instead of running the automation.
+	FreezeTicket = "ticket"
+)
+
+// maxFreezeDuration is the longest a recurring freeze can last, so checking whether one is in
+// progress stays cheap.
+const maxFreezeDuration = 7 * 24 * time.Hour
+
+// Freeze is a time window, such as a change freeze or a maintenance window, during which
+// automations are downgraded to dry run or ticket only mode.
+type Freeze struct {
+	Name string
+	// Start and End bound a one-off window in RFC 3339 format, i.e. "2020-12-20T00:00:00Z".
+	Start string
+	End   string
+	// Schedule is a cron expression, i.e. "0 18 * * 5", of when a recurring window starts and
+	// Duration how long it lasts, i.e. "62h".
+	Schedule string
+	Duration string
+	// TimeZone is the IANA time zone the schedule is in, UTC if empty.
+	TimeZone string `yaml:"time_zone"`
+	// Mode is FreezeDryRun, the default, or FreezeTicket.
+	Mode string
+	// Actions optionally limits the freeze to these automations.
+	Actions []string
+}
+
+// active returns whether the freeze applies to the action at t.
+func (f Freeze) active(action string, t time.Time) (bool, error) {
+	if len(f.Actions) > 0 && !contains(f.Actions, action) {
+		return false, nil
+	}
+	if f.Schedule == "" {
+		start, err := time.Parse(time.RFC3339, f.Start)
+		if err != nil {
+			return false, errors.Wrapf(err, "invalid start %q", f.Start)
+		}
+		end, err := time.Parse(time.RFC3339, f.End)
+		if err != nil {
+			return false, errors.Wrapf(err, "invalid end %q", f.End)
+		}
+		return !t.Before(start) && t.Before(end), nil
+	}
+	s, err := parseSchedule(f.Schedule)
+	if err != nil {
+		return false, err
+	}
+	d, err := time.ParseDuration(f.Duration)
+	if err != nil {
+		return false, errors.Wrapf(err, "invalid duration %q", f.Duration)
+	}
+	loc, err := time.LoadLocation(f.TimeZone)
+	if err != nil {
+		return false, errors.Wrapf(err, "invalid time zone %q", f.TimeZone)
+	}
+	// The window is in progress if it started within the last duration.
+	t = t.In(loc).Truncate(time.Minute)
+	for start := t; t.Sub(start) < d && t.Sub(start) <= maxFreezeDuration; start = start.Add(-time.Minute) {
+		if s.matches(start) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// activeFreeze returns the first freeze applying to the action now, if any.
+func activeFreeze(c *Configuration, action string, now time.Time) (*Freeze, error) {
+	for i, f := range c.Spec.Freezes {
+		ok, err := f.active(action, now)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to check freeze %q", f.Name)
+		}
+		if ok {
+			return &c.Spec.Freezes[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// freeze downgrades the automation if a freeze is in progress, recording the decision in the
+// audit log. It returns true if a ticket was opened instead of running the automation.
+func freeze(ctx context.Context, svcs *Services, automation Automation, resource, projectID string, attrs map[string]string, values interface{}) (bool, error) {
+	f, err := activeFreeze(svcs.Configuration, automation.Action, time.Now())
+	if err != nil || f == nil {
+		return false, err
+	}
+	attrs[FreezeAttribute] = f.Name
+	mode := f.Mode
+	if mode == FreezeTicket && svcs.Tickets == nil {
+		svcs.Logger.Error("freeze %q opens tickets but no ticket topic is configured, running %q in dry run mode", f.Name, automation.Action)
+		mode = FreezeDryRun
+	}
+	if mode != FreezeTicket {
+		forceDryRun(values)
+		auditFreeze(ctx, svcs, automation.Action, resource, fmt.Sprintf("freeze %q downgraded the automation to dry run", f.Name))
+		return false, nil
+	}
+	if s := simulating(ctx); s != nil {
+		s.record(automation, resource, OutcomeFrozen, nil, values)
+		return true, nil
+	}
+	b, err := json.MarshalIndent(values, "", "  ")
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to marshal when running %q", automation.Action)
+	}
+	if err := svcs.Tickets.Open(ctx, &services.Ticket{
+		Action:      automation.Action,
+		ProjectID:   projectID,
+		Resource:    resource,
+		Title:       fmt.Sprintf("%s held by freeze %q", automation.Action, f.Name),
+		Description: fmt.Sprintf("%q was not run on %q during freeze %q. Make the change by hand once it's safe to, the automation would have been run with:\n%s", automation.Action, resource, f.Name, b),
+	}); err != nil {
+		return false, err
+	}
+	auditFreeze(ctx, svcs, automation.Action, resource, fmt.Sprintf("freeze %q opened a ticket instead of running the automation", f.Name))
+	return true, nil
+}
+
+func auditFreeze(ctx context.Context, svcs *Services, action, resource, message string) {
+	if simulating(ctx) != nil {
+		return
+	}
+	svcs.Logger.Audit(&services.AuditRecord{
+		Action:   action,
+		Resource: resource,
+		Result:   services.AuditResultFrozen,
+		Message:  message,
+	})
+}
+
+// schedule is a parsed cron expression, each field holding the values it matches.
+type schedule struct {
+	minute, hour, dom, month, dow map[int]bool
+	// domAny and dowAny are whether the day of month and the day of week are unrestricted.
+	domAny, dowAny bool
+}
+
+// parseSchedule parses a cron expression of minute, hour, day of month, month and day of week.
+// Fields are "*", a value, a range "1-5", a step "*/15" or "1-30/2", or a list of those.
+func parseSchedule(expr string) (*schedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, errors.Errorf("schedule %q must have 5 fields", expr)
+	}
+	bounds := [5][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 6}}
+	parsed := [5]map[int]bool{}
+	for i, field := range fields {
+		m, err := parseCronField(field, bounds[i][0], bounds[i][1])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid schedule %q", expr)
+		}
+		parsed[i] = m
+	}
+	return &schedule{
+		minute: parsed[0], hour: parsed[1], dom: parsed[2], month: parsed[3], dow: parsed[4],
+		domAny: fields[2] == "*", dowAny: fields[4] == "*",
+	}, nil
+}
+
+func parseCronField(field string, min, max int) (map[int]bool, error) {
+	m := map[int]bool{}
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n <= 0 {
+				return nil, errors.Errorf("invalid step in %q", part)
+			}
+			step, part = n, part[:i]
+		}
+		lo, hi := min, max
+		if part != "*" {
+			bounds := strings.SplitN(part, "-", 2)
+			var err error
+			if lo, err = strconv.Atoi(bounds[0]); err != nil {
+				return nil, errors.Errorf("invalid value %q", part)
+			}
+			hi = lo
+			if len(bounds) == 2 {
+				if hi, err = strconv.Atoi(bounds[1]); err != nil {
+					return nil, errors.Errorf("invalid value %q", part)
+				}
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return nil, errors.Errorf("%q is outside %d-%d", part, min, max)
+		}
+		for v := lo; v <= hi; v += step {
+			m[v] = true
+		}
+	}
+	return m, nil
+}
+
+// matches returns whether the schedule fires at the minute of t. Like cron, if both the day of
+// month and the day of week are restricted either may match.
+func (s *schedule) matches(t time.Time) bool {
+	if !s.minute[t.Minute()] || !s.hour[t.Hour()] || !s.month[int(t.Month())] {
+		return false
+	}
+	dom, dow := s.dom[t.Day()], s.dow[int(t.Weekday())]
+	if !s.domAny && !s.dowAny {
+		return dom || dow
+	}
+	return dom && dow
+}
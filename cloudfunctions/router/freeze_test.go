@@ -0,0 +1,129 @@
+package router
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gce/createsnapshot"
+	"github.com/googlecloudplatform/security-response-automation/services"
+)
+
+func TestFreezeActive(t *testing.T) {
+	// Friday 2020-03-06 20:00 UTC.
+	friday := time.Date(2020, 3, 6, 20, 0, 0, 0, time.UTC)
+	weekend := Freeze{Name: "weekend", Schedule: "0 18 * * 5", Duration: "62h"}
+	for _, tt := range []struct {
+		name   string
+		freeze Freeze
+		action string
+		at     time.Time
+		want   bool
+	}{
+		{name: "within calendar window", freeze: Freeze{Start: "2020-03-01T00:00:00Z", End: "2020-03-08T00:00:00Z"}, at: friday, want: true},
+		{name: "after calendar window", freeze: Freeze{Start: "2020-03-01T00:00:00Z", End: "2020-03-06T00:00:00Z"}, at: friday, want: false},
+		{name: "start of recurring window", freeze: weekend, at: friday.Add(-2 * time.Hour), want: true},
+		{name: "within recurring window", freeze: weekend, at: friday.Add(48 * time.Hour), want: true},
+		{name: "after recurring window", freeze: weekend, at: friday.Add(60 * time.Hour), want: false},
+		{name: "before recurring window", freeze: weekend, at: friday.Add(-3 * time.Hour), want: false},
+		{name: "time zone", freeze: Freeze{Schedule: "0 18 * * 5", Duration: "1h", TimeZone: "America/New_York"}, at: friday.Add(3 * time.Hour), want: true},
+		{name: "other action", freeze: Freeze{Start: "2020-03-01T00:00:00Z", End: "2020-03-08T00:00:00Z", Actions: []string{"iam_revoke"}}, action: "close_bucket", at: friday, want: false},
+		{name: "listed action", freeze: Freeze{Start: "2020-03-01T00:00:00Z", End: "2020-03-08T00:00:00Z", Actions: []string{"iam_revoke"}}, action: "iam_revoke", at: friday, want: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.freeze.active(tt.action, tt.at)
+			if err != nil {
+				t.Fatalf("%s failed: %q", tt.name, err)
+			}
+			if got != tt.want {
+				t.Errorf("%s got active %t want %t", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFreeze(t *testing.T) {
+	const siemAlert = `{"siemAlert": {"source": "chronicle", "id": "de_1234", "category": "compromised_instance", "resource": {"projectId": "test-project", "zone": "us-central1-a", "instance": "miner"}}}`
+	now := time.Now().UTC()
+	window := Freeze{Name: "holidays", Start: now.Add(-time.Hour).Format(time.RFC3339), End: now.Add(time.Hour).Format(time.RFC3339)}
+	for _, tt := range []struct {
+		name    string
+		mode    string
+		dryRun  bool
+		ticket  bool
+		message string
+	}{
+		{name: "dry run", mode: FreezeDryRun, dryRun: true, message: `freeze "holidays" downgraded the automation to dry run`},
+		{name: "ticket", mode: FreezeTicket, ticket: true, message: `freeze "holidays" opened a ticket instead of running the automation`},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &Configuration{}
+			f := window
+			f.Mode = tt.mode
+			conf.Spec.Freezes = []Freeze{f}
+			conf.Spec.Parameters.SIEM.CompromisedInstance = []Automation{
+				{Action: "gce_create_disk_snapshot", Target: []string{"organizations/456/folders/123/projects/test-project"}},
+			}
+			crmStub := &stubs.ResourceManagerStub{}
+			crmStub.GetAncestryResponse = services.CreateAncestors([]string{"project/test-project", "folder/123", "organization/456"})
+			psStub := &stubs.PubSubStub{}
+			loggerStub := &stubs.LoggerStub{}
+			ps := services.NewPubSub(psStub)
+			svcs := &Services{
+				PubSub:                ps,
+				Logger:                services.NewLogger(loggerStub),
+				Configuration:         conf,
+				Resource:              services.NewResource(crmStub, &stubs.StorageStub{}),
+				SecurityCommandCenter: services.NewCommandCenter(&stubs.SecurityCommandCenterStub{}),
+				Tickets:               services.NewTickets(ps, "tickets"),
+			}
+			if err := Execute(context.Background(), &Values{Finding: []byte(siemAlert)}, svcs); err != nil {
+				t.Fatalf("%s failed: %q", tt.name, err)
+			}
+			if len(psStub.PublishedMessages) != 1 {
+				t.Fatalf("%s published %d messages want 1", tt.name, len(psStub.PublishedMessages))
+			}
+			m := psStub.PublishedMessages[0]
+			if tt.ticket {
+				var ticket services.Ticket
+				if err := json.Unmarshal(m.Data, &ticket); err != nil || ticket.Action != "gce_create_disk_snapshot" || ticket.ProjectID != "test-project" {
+					t.Errorf("%s got ticket %+v, %v", tt.name, ticket, err)
+				}
+			} else {
+				var values createsnapshot.Values
+				if err := json.Unmarshal(m.Data, &values); err != nil || values.DryRun != tt.dryRun {
+					t.Errorf("%s got values %+v, %v", tt.name, values, err)
+				}
+				if m.Attributes[FreezeAttribute] != "holidays" {
+					t.Errorf("%s got attributes %+v", tt.name, m.Attributes)
+				}
+			}
+			want := []interface{}{&services.AuditRecord{
+				Action:   "gce_create_disk_snapshot",
+				Resource: "projects/test-project",
+				Result:   services.AuditResultFrozen,
+				Message:  tt.message,
+			}}
+			if diff := cmp.Diff(want, loggerStub.AuditRecords); diff != "" {
+				t.Errorf("%s audit records difference:%+v", tt.name, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package router
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"encoding/json"
+	"net"
+	"sort"
+	"strings"
+)
+
+// ipKeys are the lower cased keys findings hold IP addresses under, such as the "ip" property of
+// bad IP findings, "sourceIp" of SSH brute force and "callerIp" of anomalous IAM grants.
+var ipKeys = map[string]bool{
+	"ip":        true,
+	"ips":       true,
+	"sourceip":  true,
+	"callerip":  true,
+	"remoteip":  true,
+	"ipaddress": true,
+}
+
+// privateNetworks are the ranges of IP addresses that are not routable on the internet.
+var privateNetworks = func() []*net.IPNet {
+	var nets []*net.IPNet
+	for _, cidr := range []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "100.64.0.0/10", "fc00::/7"} {
+		_, n, _ := net.ParseCIDR(cidr)
+		nets = append(nets, n)
+	}
+	return nets
+}()
+
+// findingIPs returns the public IP addresses held anywhere in the finding, sorted and without
+// duplicates. Private, loopback and link local addresses are left out as intel providers know
+// nothing about them.
+func findingIPs(b []byte) []string {
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil
+	}
+	found := map[string]bool{}
+	collectIPs(v, false, found)
+	if len(found) == 0 {
+		return nil
+	}
+	ips := make([]string, 0, len(found))
+	for ip := range found {
+		ips = append(ips, ip)
+	}
+	sort.Strings(ips)
+	return ips
+}
+
+// collectIPs adds the public IPs of v to found, underIPKey is set if v is the value of an IP key.
+func collectIPs(v interface{}, underIPKey bool, found map[string]bool) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, vv := range v {
+			collectIPs(vv, ipKeys[strings.ToLower(k)], found)
+		}
+	case []interface{}:
+		for _, vv := range v {
+			collectIPs(vv, underIPKey, found)
+		}
+	case string:
+		if !underIPKey {
+			return
+		}
+		if ip := net.ParseIP(v); ip != nil && isPublic(ip) {
+			found[ip.String()] = true
+		}
+	}
+}
+
+func isPublic(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() || ip.IsMulticast() {
+		return false
+	}
+	for _, n := range privateNetworks {
+		if n.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
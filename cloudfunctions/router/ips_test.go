@@ -0,0 +1,61 @@
+package router
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFindingIPs(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		finding  string
+		expected []string
+	}{
+		{
+			name: "bad ip",
+			finding: `{"finding": {"sourceProperties": {"properties": {
+				"ip": ["198.51.100.7", "203.0.113.9", "198.51.100.7"],
+				"location": {"country": "167.0.0.1"}
+			}}}}`,
+			expected: []string{"198.51.100.7", "203.0.113.9"},
+		},
+		{
+			name:     "caller ip",
+			finding:  `{"jsonPayload": {"properties": {"callerIp": "2001:db8::1"}}}`,
+			expected: []string{"2001:db8::1"},
+		},
+		{
+			name: "private addresses",
+			finding: `{"jsonPayload": {"properties": {"attempts": [
+				{"sourceIp": "10.128.0.2"}, {"sourceIp": "127.0.0.1"}, {"sourceIp": "192.168.1.5"}, {"sourceIp": "not an ip"}
+			]}}}`,
+			expected: nil,
+		},
+		{
+			name:     "invalid json",
+			finding:  `{`,
+			expected: nil,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.expected, findingIPs([]byte(tt.finding))); diff != "" {
+				t.Errorf("findingIPs() returned unexpected IPs (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
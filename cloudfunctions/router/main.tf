@@ -14,7 +14,7 @@
 resource "google_cloudfunctions_function" "router" {
   name                  = "Router"
   description           = "Routes findings to automations."
-  runtime               = "go111"
+  runtime               = "go121"
   available_memory_mb   = 128
   source_archive_bucket = var.setup.gcf-bucket-name
   source_archive_object = var.setup.gcf-object-name
@@ -22,11 +22,45 @@ resource "google_cloudfunctions_function" "router" {
   project               = var.setup.automation-project
   region                = var.setup.region
   entry_point           = "Router"
+  max_instances         = var.max-instances
 
   event_trigger {
     event_type = "providers/cloud.pubsub/eventTypes/topic.publish"
     resource   = "threat-findings"
   }
+
+  environment_variables = {
+    TICKET_TOPIC = var.setup.ticket-topic
+  }
+}
+
+resource "google_cloudfunctions_function" "sweep" {
+  name                  = "Sweep"
+  description           = "Runs an automation on every resource of its kind within a scope."
+  runtime               = "go121"
+  available_memory_mb   = 128
+  source_archive_bucket = var.setup.gcf-bucket-name
+  source_archive_object = var.setup.gcf-object-name
+  timeout               = 540
+  project               = var.setup.automation-project
+  region                = var.setup.region
+  entry_point           = "Sweep"
+  max_instances         = 1
+
+  event_trigger {
+    event_type = "providers/cloud.pubsub/eventTypes/topic.publish"
+    resource   = google_pubsub_topic.sweep.name
+  }
+
+  environment_variables = {
+    TICKET_TOPIC = var.setup.ticket-topic
+  }
+}
+
+# PubSub topic sweeps are requested on, a sweep publishes its own continuation to it.
+resource "google_pubsub_topic" "sweep" {
+  name    = "threat-findings-sweep"
+  project = var.setup.automation-project
 }
 
 resource "google_project_iam_member" "router-pubsub-writer" {
@@ -42,3 +76,11 @@ resource "google_folder_iam_member" "roles-browser" {
   role   = "roles/browser"
   member = "serviceAccount:${var.setup.automation-service-account}"
 }
+
+# Required to read the tags of projects within this folder for target_tags and exclude_tags.
+resource "google_folder_iam_member" "roles-tag-viewer" {
+  count  = length(var.folder-ids)
+  folder = "folders/${var.folder-ids[count.index]}"
+  role   = "roles/resourcemanager.tagViewer"
+  member = "serviceAccount:${var.setup.automation-service-account}"
+}
@@ -0,0 +1,104 @@
+package router
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"fmt"
+	"strings"
+)
+
+// categories maps the rules of findings sent by Security Command Center notifications to the
+// categories of those findings. Forseti violations and SIEM alerts are sent to the router directly.
+var categories = map[string][]string{
+	"etd.bad_ip":                       {"Malware: Bad IP"},
+	"etd.anomalous_iam":                {"Persistence: IAM Anomalous Grant"},
+	"etd.ssh_brute_force":              {"Brute force: SSH"},
+	"etd.bad_domain":                   {"Malware: Bad Domain"},
+	"etd.new_geography":                {"Persistence: New Geography"},
+	"etd.bigquery_exfiltration":        {"Exfiltration: BigQuery Data Exfiltration"},
+	"etd.cryptomining":                 {"Malware: Cryptomining Bad IP", "Malware: Cryptomining Bad Domain"},
+	"sha.public_bucket_acl":            {"PUBLIC_BUCKET_ACL"},
+	"sha.bucket_policy_only_disabled":  {"BUCKET_POLICY_ONLY_DISABLED"},
+	"sha.bucket_logging_disabled":      {"BUCKET_LOGGING_DISABLED"},
+	"sha.object_versioning_disabled":   {"OBJECT_VERSIONING_DISABLED"},
+	"sha.public_sql_instance":          {"PUBLIC_SQL_INSTANCE"},
+	"sha.ssl_not_enforced":             {"SSL_NOT_ENFORCED"},
+	"sha.sql_no_root_password":         {"SQL_NO_ROOT_PASSWORD"},
+	"sha.sql_auto_backup_disabled":     {"SQL_AUTO_BACKUP_DISABLED"},
+	"sha.public_ip_address":            {"PUBLIC_IP_ADDRESS"},
+	"sha.compute_serial_ports_enabled": {"COMPUTE_SERIAL_PORTS_ENABLED"},
+	"sha.ip_forwarding_enabled":        {"IP_FORWARDING_ENABLED"},
+	"sha.legacy_metadata_enabled":      {"LEGACY_METADATA_ENABLED"},
+	"sha.shielded_vm_disabled":         {"SHIELDED_VM_DISABLED"},
+	"sha.bucket_cmek_disabled":         {"BUCKET_CMEK_DISABLED"},
+	"sha.dataset_cmek_disabled":        {"DATASET_CMEK_DISABLED"},
+	"sha.bigquery_table_cmek_disabled": {"BIGQUERY_TABLE_CMEK_DISABLED"},
+	"sha.disk_cmek_disabled":           {"DISK_CMEK_DISABLED"},
+	"sha.http_load_balancer":           {"HTTP_LOAD_BALANCER"},
+	"sha.weak_ssl_policy":              {"WEAK_SSL_POLICY"},
+	"sha.open_firewall":                {"OPEN_FIREWALL", "OPEN_SSH_PORT", "OPEN_RDP_PORT"},
+	"sha.bigquery_public_dataset":      {"PUBLIC_DATASET"},
+	"sha.audit_logging_disabled":       {"AUDIT_LOGGING_DISABLED"},
+	"sha.web_ui_enabled":               {"WEB_UI_ENABLED"},
+	"sha.network_policy_disabled":      {"NETWORK_POLICY_DISABLED"},
+	"sha.private_cluster_disabled":     {"PRIVATE_CLUSTER_DISABLED"},
+	"sha.non_org_members":              {"NON_ORG_IAM_MEMBER"},
+}
+
+// NotificationFilter is the Security Command Center notification filter matching the findings of
+// a provider that have automations configured.
+type NotificationFilter struct {
+	// Provider is the provider of the findings, "etd" or "sha".
+	Provider string
+	// Categories are the categories of the findings matched.
+	Categories []string
+	Filter     string
+}
+
+// NotificationFilters returns the notification filters of each provider with automations
+// configured. Only active findings are matched and, if severities are given, only findings of
+// those severities.
+func NotificationFilters(c *Configuration, severities []string) []NotificationFilter {
+	var filters []NotificationFilter
+	for _, provider := range []string{"etd", "sha"} {
+		var cats []string
+		for _, r := range rules(c) {
+			if len(r.automations) > 0 && strings.HasPrefix(r.path, provider+".") {
+				cats = append(cats, categories[r.path]...)
+			}
+		}
+		if len(cats) == 0 {
+			continue
+		}
+		filter := `state = "ACTIVE" AND ` + anyOf("category", cats)
+		if len(severities) > 0 {
+			filter += " AND " + anyOf("severity", severities)
+		}
+		filters = append(filters, NotificationFilter{Provider: provider, Categories: cats, Filter: filter})
+	}
+	return filters
+}
+
+// anyOf returns a filter matching any of the values of the field.
+func anyOf(field string, values []string) string {
+	terms := make([]string, 0, len(values))
+	for _, v := range values {
+		terms = append(terms, fmt.Sprintf("%s = %q", field, v))
+	}
+	if len(terms) == 1 {
+		return terms[0]
+	}
+	return "(" + strings.Join(terms, " OR ") + ")"
+}
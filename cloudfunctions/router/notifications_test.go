@@ -0,0 +1,46 @@
+package router
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNotificationFilters(t *testing.T) {
+	c := &Configuration{}
+	c.Spec.Parameters.SHA.OpenFirewall = []Automation{{Action: "remediate_firewall"}}
+	c.Spec.Parameters.SHA.PublicBucketACL = []Automation{{Action: "close_bucket"}}
+	c.Spec.Parameters.Forseti.BucketViolation = []Automation{{Action: "close_bucket"}}
+	expected := []NotificationFilter{{
+		Provider:   "sha",
+		Categories: []string{"PUBLIC_BUCKET_ACL", "OPEN_FIREWALL", "OPEN_SSH_PORT", "OPEN_RDP_PORT"},
+		Filter:     `state = "ACTIVE" AND (category = "PUBLIC_BUCKET_ACL" OR category = "OPEN_FIREWALL" OR category = "OPEN_SSH_PORT" OR category = "OPEN_RDP_PORT") AND severity = "HIGH"`,
+	}}
+	if diff := cmp.Diff(expected, NotificationFilters(c, []string{"HIGH"})); diff != "" {
+		t.Errorf("filters difference:%+v", diff)
+	}
+}
+
+func TestCategoriesCoverNotifiedRules(t *testing.T) {
+	for _, r := range rules(&Configuration{}) {
+		notified := strings.HasPrefix(r.path, "etd.") || strings.HasPrefix(r.path, "sha.")
+		if _, ok := categories[r.path]; notified && !ok {
+			t.Errorf("rule %q has no categories", r.path)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package router
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+
+	"cloud.google.com/go/pubsub"
+	"github.com/googlecloudplatform/security-response-automation/services"
+	"github.com/pkg/errors"
+)
+
+// playbookAction is the action recorded in the audit record of a playbook.
+const playbookAction = "playbook"
+
+// playbooksKey is the context key of the playbooks collected while routing a finding.
+type playbooksKey struct{}
+
+// playbooks holds the steps of each playbook of a finding, in the order they're configured.
+type playbooks struct {
+	names     []string
+	resources map[string]string
+	steps     map[string][]*services.PlaybookStep
+}
+
+func newPlaybooks() *playbooks {
+	return &playbooks{resources: map[string]string{}, steps: map[string][]*services.PlaybookStep{}}
+}
+
+// hold adds the automation to its playbook rather than publishing it if it's a step of one. The
+// step is added with the given status, or pending if it's empty.
+func hold(ctx context.Context, resource, action, topic string, attrs map[string]string, values interface{}, status string) (bool, error) {
+	p, ok := ctx.Value(playbooksKey{}).(*playbooks)
+	name := attrs[PlaybookAttribute]
+	if !ok || name == "" {
+		return false, nil
+	}
+	b, err := json.Marshal(&values)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to marshal when running %q", action)
+	}
+	if _, ok := p.steps[name]; !ok {
+		p.names = append(p.names, name)
+		p.resources[name] = resource
+	}
+	p.steps[name] = append(p.steps[name], &services.PlaybookStep{Action: action, Topic: topic, Attributes: attrs, Values: b, Status: status})
+	return true, nil
+}
+
+// startPlaybooks records each playbook and runs its first pending step.
+func startPlaybooks(ctx context.Context, svcs *Services, p *playbooks) error {
+	for _, name := range p.names {
+		if svcs.State == nil {
+			svcs.Logger.Error("playbook %q requires a state bucket, not running its %d steps", name, len(p.steps[name]))
+			continue
+		}
+		r, err := svcs.State.RecordPlaybook(ctx, name, p.resources[name], p.steps[name])
+		if err != nil {
+			return err
+		}
+		if err := advance(ctx, svcs, r, 0); err != nil {
+			return err
+		}
+		log.Printf("started playbook %q with %d steps", name, len(r.Steps))
+	}
+	return nil
+}
+
+// ContinuePlaybook records the outcome of a step of a playbook given the attributes of the message
+// the step's automation received and runs the next step. A permanent failure, or one on the step's
+// final attempt, stops the playbook and skips its remaining steps while other errors are left for
+// Pub/Sub to retry the step. Once the playbook stops the outcome of its steps is logged as a single
+// audit record.
+func ContinuePlaybook(ctx context.Context, svcs *Services, attrs map[string]string, err error) error {
+	id := attrs[PlaybookAttribute]
+	if id == "" || (err != nil && !services.Permanent(err) && !services.FinalAttempt(ctx)) {
+		return nil
+	}
+	if svcs.State == nil {
+		return fmt.Errorf("playbook %q requires a state bucket", id)
+	}
+	i, serr := strconv.Atoi(attrs[PlaybookStepAttribute])
+	if serr != nil {
+		return errors.Wrapf(serr, "invalid step of playbook %q", id)
+	}
+	r, serr := svcs.State.Playbook(ctx, id)
+	if serr != nil {
+		return serr
+	}
+	// The step's message may be redelivered after the step was recorded.
+	if i < 0 || i >= len(r.Steps) || r.Steps[i].Status != services.StepRunning {
+		return nil
+	}
+	if err != nil {
+		r.Steps[i].Status = services.StepFailed
+		r.Steps[i].Error = err.Error()
+		for _, step := range r.Steps[i+1:] {
+			if step.Status == services.StepPending {
+				step.Status = services.StepSkipped
+			}
+		}
+		return finishPlaybook(ctx, svcs, r)
+	}
+	r.Steps[i].Status = services.StepSucceeded
+	return advance(ctx, svcs, r, i+1)
+}
+
+// advance runs the first pending step from the given step on, or finishes the playbook if there
+// are none left.
+func advance(ctx context.Context, svcs *Services, r *services.PlaybookRecord, from int) error {
+	for i := from; i < len(r.Steps); i++ {
+		if r.Steps[i].Status == services.StepPending {
+			return runStep(ctx, svcs, r, i)
+		}
+	}
+	return finishPlaybook(ctx, svcs, r)
+}
+
+// runStep records the step as running and publishes it to its automation.
+func runStep(ctx context.Context, svcs *Services, r *services.PlaybookRecord, i int) error {
+	step := r.Steps[i]
+	step.Status = services.StepRunning
+	if err := svcs.State.SavePlaybook(ctx, r); err != nil {
+		return err
+	}
+	attrs := map[string]string{}
+	for k, v := range step.Attributes {
+		attrs[k] = v
+	}
+	attrs[PlaybookAttribute] = r.ID
+	attrs[PlaybookStepAttribute] = strconv.Itoa(i)
+	attrs = services.InjectSpan(ctx, attrs)
+	if _, err := svcs.PubSub.Publish(ctx, step.Topic, &pubsub.Message{Data: step.Values, Attributes: attrs}); err != nil {
+		return errors.Wrapf(err, "failed to publish step %d of playbook %q to %q", i, r.Name, step.Topic)
+	}
+	log.Printf("sent step %d of playbook %q to pubsub topic: %q", i, r.Name, step.Topic)
+	return nil
+}
+
+// finishPlaybook logs the outcome of each step of the playbook, exports the incident's bundle if a
+// forensics bucket is configured and removes its record. Failing to export the bundle does not fail
+// the playbook.
+func finishPlaybook(ctx context.Context, svcs *Services, r *services.PlaybookRecord) error {
+	result := services.AuditResultSuccess
+	steps := make([]services.StepOutcome, 0, len(r.Steps))
+	for _, step := range r.Steps {
+		if step.Status == services.StepFailed {
+			result = services.AuditResultFailure
+		}
+		steps = append(steps, services.StepOutcome{Action: step.Action, Status: step.Status, Error: step.Error})
+	}
+	svcs.Logger.Audit(&services.AuditRecord{
+		Action:   playbookAction,
+		Resource: r.Resource,
+		Result:   result,
+		Message:  fmt.Sprintf("playbook %q finished with %s", r.Name, result),
+		Steps:    steps,
+	})
+	if svcs.Bundle != nil {
+		exportBundle(ctx, svcs, r)
+	}
+	return svcs.State.RemovePlaybook(ctx, r)
+}
+
+// exportBundle exports the bundle of the finding the playbook ran for.
+func exportBundle(ctx context.Context, svcs *Services, r *services.PlaybookRecord) {
+	q := &services.BundleQuery{Resource: r.Resource}
+	for _, step := range r.Steps {
+		if name := step.Attributes[FindingAttribute]; name != "" {
+			q.FindingName = name
+			break
+		}
+	}
+	if q.FindingName == "" {
+		svcs.Logger.Warning("playbook %q has no finding, not exporting its bundle", r.Name)
+		return
+	}
+	m, err := svcs.Bundle.Export(ctx, q)
+	if err != nil {
+		svcs.Logger.Error("failed to export bundle of playbook %q: %q", r.Name, err)
+		return
+	}
+	log.Printf("exported bundle of playbook %q to %q", r.Name, "gs://"+m.Bucket+"/"+m.Archive)
+}
@@ -0,0 +1,189 @@
+package router
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	functions "cloud.google.com/go/functions/metadata"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gce/createsnapshot"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gce/removepublicip"
+	"github.com/googlecloudplatform/security-response-automation/services"
+)
+
+func TestPlaybook(t *testing.T) {
+	const siemAlert = `{"siemAlert": {"source": "chronicle", "id": "de_1234", "category": "compromised_instance", "resource": {"projectId": "test-project", "zone": "us-central1-a", "instance": "miner"}}}`
+	target := []string{"organizations/456/folders/123/projects/test-project"}
+	conf := &Configuration{}
+	conf.Spec.Parameters.SIEM.CompromisedInstance = []Automation{
+		{Action: "gce_create_disk_snapshot", Target: target, Playbook: "cryptomining"},
+		{Action: "remove_public_ip", Target: target, Playbook: "cryptomining"},
+	}
+	snapshot, _ := json.Marshal(&createsnapshot.Values{ProjectID: "test-project", RuleName: "compromised_instance", Instance: "miner", Zone: "us-central1-a"})
+	removeIP, _ := json.Marshal(&removepublicip.Values{ProjectID: "test-project", InstanceZone: "us-central1-a", InstanceID: "miner"})
+
+	crmStub := &stubs.ResourceManagerStub{}
+	crmStub.GetAncestryResponse = services.CreateAncestors([]string{"project/test-project", "folder/123", "organization/456"})
+	storageStub := &stubs.StorageStub{}
+	psStub := &stubs.PubSubStub{}
+	loggerStub := &stubs.LoggerStub{}
+	svcs := &Services{
+		PubSub:                services.NewPubSub(psStub),
+		Logger:                services.NewLogger(loggerStub),
+		Configuration:         conf,
+		Resource:              services.NewResource(crmStub, storageStub),
+		SecurityCommandCenter: services.NewCommandCenter(&stubs.SecurityCommandCenterStub{}),
+		State:                 services.NewState(storageStub, "state"),
+	}
+	ctx := context.Background()
+
+	if err := Execute(ctx, &Values{Finding: []byte(siemAlert)}, svcs); err != nil {
+		t.Fatalf("failed to route finding: %q", err)
+	}
+	first := psStub.PublishedMessage
+	if diff := cmp.Diff(snapshot, first.Data); diff != "" {
+		t.Errorf("first step difference:%+v", diff)
+	}
+	if first.Attributes[PlaybookStepAttribute] != "0" || first.Attributes[PlaybookAttribute] == "cryptomining" {
+		t.Errorf("first step attributes %+v do not identify the playbook's step", first.Attributes)
+	}
+
+	psStub.PublishedMessage = nil
+	retried := functions.NewContext(ctx, &functions.Metadata{Timestamp: time.Now()})
+	if err := ContinuePlaybook(retried, svcs, first.Attributes, errors.New("transient")); err != nil {
+		t.Fatalf("failed to continue playbook: %q", err)
+	}
+	if psStub.PublishedMessage != nil {
+		t.Errorf("next step published after a retryable failure")
+	}
+
+	if err := ContinuePlaybook(ctx, svcs, first.Attributes, nil); err != nil {
+		t.Fatalf("failed to continue playbook: %q", err)
+	}
+	second := psStub.PublishedMessage
+	if diff := cmp.Diff(removeIP, second.Data); diff != "" {
+		t.Errorf("second step difference:%+v", diff)
+	}
+
+	psStub.PublishedMessage = nil
+	if err := ContinuePlaybook(ctx, svcs, first.Attributes, nil); err != nil {
+		t.Fatalf("failed to continue playbook: %q", err)
+	}
+	if psStub.PublishedMessage != nil {
+		t.Errorf("step published again after the first step was redelivered")
+	}
+
+	if err := ContinuePlaybook(ctx, svcs, second.Attributes, services.NotFound(errors.New("instance not found"))); err != nil {
+		t.Fatalf("failed to continue playbook: %q", err)
+	}
+	expected := []interface{}{&services.AuditRecord{
+		Action:   "playbook",
+		Resource: "projects/test-project",
+		Result:   services.AuditResultFailure,
+		Message:  `playbook "cryptomining" finished with failure`,
+		Steps: []services.StepOutcome{
+			{Action: "gce_create_disk_snapshot", Status: services.StepSucceeded},
+			{Action: "remove_public_ip", Status: services.StepFailed, Error: "instance not found"},
+		},
+	}}
+	if diff := cmp.Diff(expected, loggerStub.AuditRecords); diff != "" {
+		t.Errorf("audit record difference:%+v", diff)
+	}
+	if len(storageStub.WrittenObjects) != 0 {
+		t.Errorf("playbook record not removed: %+v", storageStub.WrittenObjects)
+	}
+}
+
+func TestPlaybookStopsOnFailure(t *testing.T) {
+	storageStub := &stubs.StorageStub{}
+	loggerStub := &stubs.LoggerStub{}
+	svcs := &Services{
+		PubSub: services.NewPubSub(&stubs.PubSubStub{}),
+		Logger: services.NewLogger(loggerStub),
+		State:  services.NewState(storageStub, "state"),
+	}
+	ctx := context.Background()
+	r, err := svcs.State.RecordPlaybook(ctx, "contain", "projects/test-project", []*services.PlaybookStep{
+		{Action: "gce_create_disk_snapshot", Topic: "snapshot"},
+		{Action: "remove_public_ip", Topic: "remove-ip"},
+		{Action: "disable_serial_port", Topic: "serial-port"},
+	})
+	if err != nil {
+		t.Fatalf("failed to record playbook: %q", err)
+	}
+	if err := runStep(ctx, svcs, r, 0); err != nil {
+		t.Fatalf("failed to run step: %q", err)
+	}
+	attrs := map[string]string{PlaybookAttribute: r.ID, PlaybookStepAttribute: "0"}
+	if err := ContinuePlaybook(ctx, svcs, attrs, services.PermissionDenied(errors.New("denied"))); err != nil {
+		t.Fatalf("failed to continue playbook: %q", err)
+	}
+	expected := []services.StepOutcome{
+		{Action: "gce_create_disk_snapshot", Status: services.StepFailed, Error: "denied"},
+		{Action: "remove_public_ip", Status: services.StepSkipped},
+		{Action: "disable_serial_port", Status: services.StepSkipped},
+	}
+	if len(loggerStub.AuditRecords) != 1 {
+		t.Fatalf("expected a single audit record, got %d", len(loggerStub.AuditRecords))
+	}
+	if diff := cmp.Diff(expected, loggerStub.AuditRecords[0].(*services.AuditRecord).Steps); diff != "" {
+		t.Errorf("step outcomes difference:%+v", diff)
+	}
+}
+
+func TestPlaybookStopsAfterFinalAttempt(t *testing.T) {
+	storageStub := &stubs.StorageStub{}
+	loggerStub := &stubs.LoggerStub{}
+	svcs := &Services{
+		PubSub: services.NewPubSub(&stubs.PubSubStub{}),
+		Logger: services.NewLogger(loggerStub),
+		State:  services.NewState(storageStub, "state"),
+	}
+	ctx := context.Background()
+	r, err := svcs.State.RecordPlaybook(ctx, "contain", "projects/test-project", []*services.PlaybookStep{
+		{Action: "gce_create_disk_snapshot", Topic: "snapshot"},
+		{Action: "remove_public_ip", Topic: "remove-ip"},
+	})
+	if err != nil {
+		t.Fatalf("failed to record playbook: %q", err)
+	}
+	if err := runStep(ctx, svcs, r, 0); err != nil {
+		t.Fatalf("failed to run step: %q", err)
+	}
+	attrs := map[string]string{PlaybookAttribute: r.ID, PlaybookStepAttribute: "0"}
+	expired := functions.NewContext(ctx, &functions.Metadata{Timestamp: time.Now().Add(-services.RetryWindow - time.Minute)})
+	if err := ContinuePlaybook(expired, svcs, attrs, errors.New("backend unavailable")); err != nil {
+		t.Fatalf("failed to continue playbook: %q", err)
+	}
+	expected := []services.StepOutcome{
+		{Action: "gce_create_disk_snapshot", Status: services.StepFailed, Error: "backend unavailable"},
+		{Action: "remove_public_ip", Status: services.StepSkipped},
+	}
+	if len(loggerStub.AuditRecords) != 1 {
+		t.Fatalf("expected a single audit record, got %d", len(loggerStub.AuditRecords))
+	}
+	if diff := cmp.Diff(expected, loggerStub.AuditRecords[0].(*services.AuditRecord).Steps); diff != "" {
+		t.Errorf("step outcomes difference:%+v", diff)
+	}
+	if len(storageStub.WrittenObjects) != 0 {
+		t.Errorf("playbook record not removed: %+v", storageStub.WrittenObjects)
+	}
+}
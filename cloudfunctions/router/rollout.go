@@ -0,0 +1,95 @@
+package router
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"hash/fnv"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Cohorts of an automation being rolled out, sent as the RolloutAttribute.
+const (
+	// RolloutEnforced is the cohort of resources the automation is enforced on.
+	RolloutEnforced = "enforced"
+	// RolloutDryRun is the cohort of resources the automation is dry-run on.
+	RolloutDryRun = "dry_run"
+)
+
+// Rollout limits enforcement of an automation to a share of projects while dry-running it on the
+// rest, so enforcement can be ramped up gradually.
+type Rollout struct {
+	// Percent is the share of projects, from 0 to 100, the automation is enforced on. Projects are
+	// bucketed by a hash of their ID so a project stays enforced as the percentage grows.
+	Percent int
+	// Pilot are the organizations, folders or projects the automation is always enforced on, in
+	// the format of target.
+	Pilot []string
+}
+
+// rollout dry-runs the automation on the resource unless the resource is in the enforced cohort of
+// the automation's rollout, and records the cohort in attrs. Automations without a rollout are left
+// as configured.
+func rollout(ctx context.Context, services *Services, automation Automation, resource string, attrs map[string]string, values interface{}) error {
+	if automation.Rollout == nil {
+		return nil
+	}
+	enforced, err := inRollout(ctx, services, automation.Rollout, resource)
+	if err != nil {
+		return errors.Wrapf(err, "failed to check if %q is within the pilot", resource)
+	}
+	if enforced {
+		attrs[RolloutAttribute] = RolloutEnforced
+		return nil
+	}
+	attrs[RolloutAttribute] = RolloutDryRun
+	forceDryRun(values)
+	return nil
+}
+
+// inRollout returns whether the resource is within the pilot or the enforced percentage.
+func inRollout(ctx context.Context, services *Services, r *Rollout, resource string) (bool, error) {
+	if rolloutBucket(resource) < r.Percent {
+		return true, nil
+	}
+	if len(r.Pilot) == 0 {
+		return false, nil
+	}
+	return ancestryMatches(ctx, services, resource, r.Pilot, nil)
+}
+
+// rolloutBucket returns the bucket, from 0 to 99, of the project or other resource.
+func rolloutBucket(resource string) int {
+	h := fnv.New32a()
+	h.Write([]byte(strings.TrimPrefix(resource, "projects/")))
+	return int(h.Sum32() % 100)
+}
+
+// forceDryRun turns on the DryRun field of the automation's values.
+func forceDryRun(values interface{}) {
+	v := reflect.ValueOf(values)
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return
+	}
+	if f := v.FieldByName("DryRun"); f.IsValid() && f.CanSet() && f.Kind() == reflect.Bool {
+		f.SetBool(true)
+	}
+}
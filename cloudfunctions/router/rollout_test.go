@@ -0,0 +1,76 @@
+package router
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gce/createsnapshot"
+	"github.com/googlecloudplatform/security-response-automation/services"
+)
+
+func TestRollout(t *testing.T) {
+	const siemAlert = `{"siemAlert": {"source": "chronicle", "id": "de_1234", "category": "compromised_instance", "resource": {"projectId": "test-project", "zone": "us-central1-a", "instance": "miner"}}}`
+	// The bucket of "test-project" is 41.
+	for _, tt := range []struct {
+		name    string
+		rollout *Rollout
+		dryRun  bool
+		cohort  string
+	}{
+		{name: "no rollout", rollout: nil, dryRun: false},
+		{name: "within percentage", rollout: &Rollout{Percent: 42}, dryRun: false, cohort: RolloutEnforced},
+		{name: "outside percentage", rollout: &Rollout{Percent: 41}, dryRun: true, cohort: RolloutDryRun},
+		{name: "pilot folder", rollout: &Rollout{Pilot: []string{"organizations/456/folders/123/*"}}, dryRun: false, cohort: RolloutEnforced},
+		{name: "other pilot folder", rollout: &Rollout{Percent: 10, Pilot: []string{"organizations/456/folders/789/*"}}, dryRun: true, cohort: RolloutDryRun},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &Configuration{}
+			conf.Spec.Parameters.SIEM.CompromisedInstance = []Automation{
+				{Action: "gce_create_disk_snapshot", Target: []string{"organizations/456/folders/123/projects/test-project"}, Rollout: tt.rollout},
+			}
+			crmStub := &stubs.ResourceManagerStub{}
+			crmStub.GetAncestryResponse = services.CreateAncestors([]string{"project/test-project", "folder/123", "organization/456"})
+			psStub := &stubs.PubSubStub{}
+			svcs := &Services{
+				PubSub:                services.NewPubSub(psStub),
+				Logger:                services.NewLogger(&stubs.LoggerStub{}),
+				Configuration:         conf,
+				Resource:              services.NewResource(crmStub, &stubs.StorageStub{}),
+				SecurityCommandCenter: services.NewCommandCenter(&stubs.SecurityCommandCenterStub{}),
+			}
+			if err := Execute(context.Background(), &Values{Finding: []byte(siemAlert)}, svcs); err != nil {
+				t.Fatalf("%s failed: %q", tt.name, err)
+			}
+			if psStub.PublishedMessage == nil {
+				t.Fatalf("%s published nothing", tt.name)
+			}
+			var values createsnapshot.Values
+			if err := json.Unmarshal(psStub.PublishedMessage.Data, &values); err != nil {
+				t.Fatalf("%s failed to unmarshal values: %q", tt.name, err)
+			}
+			if values.DryRun != tt.dryRun {
+				t.Errorf("%s got dry run %t want %t", tt.name, values.DryRun, tt.dryRun)
+			}
+			if diff := cmp.Diff(tt.cohort, psStub.PublishedMessage.Attributes[RolloutAttribute]); diff != "" {
+				t.Errorf("%s cohort difference:%+v", tt.name, diff)
+			}
+		})
+	}
+}
@@ -20,11 +20,19 @@ import (
 	"fmt"
 	"io/ioutil"
 	"log"
+	"strings"
+	"time"
 
 	"cloud.google.com/go/pubsub"
+	"github.com/googlecloudplatform/security-response-automation/providers/dlp/sensitivedata"
 	"github.com/googlecloudplatform/security-response-automation/providers/etd/anomalousiam"
+	"github.com/googlecloudplatform/security-response-automation/providers/etd/baddomain"
 	"github.com/googlecloudplatform/security-response-automation/providers/etd/badip"
+	"github.com/googlecloudplatform/security-response-automation/providers/etd/bigqueryexfiltration"
+	"github.com/googlecloudplatform/security-response-automation/providers/etd/cryptomining"
+	"github.com/googlecloudplatform/security-response-automation/providers/etd/newgeography"
 	"github.com/googlecloudplatform/security-response-automation/providers/etd/sshbruteforce"
+	"github.com/googlecloudplatform/security-response-automation/providers/forseti/violation"
 	"github.com/googlecloudplatform/security-response-automation/providers/sha/computeinstancescanner"
 	"github.com/googlecloudplatform/security-response-automation/providers/sha/containerscanner"
 	"github.com/googlecloudplatform/security-response-automation/providers/sha/datasetscanner"
@@ -33,6 +41,7 @@ import (
 	"github.com/googlecloudplatform/security-response-automation/providers/sha/loggingscanner"
 	"github.com/googlecloudplatform/security-response-automation/providers/sha/sqlscanner"
 	"github.com/googlecloudplatform/security-response-automation/providers/sha/storagescanner"
+	"github.com/googlecloudplatform/security-response-automation/providers/siem/alert"
 	"github.com/googlecloudplatform/security-response-automation/services"
 	"github.com/pkg/errors"
 	"gopkg.in/yaml.v2"
@@ -41,7 +50,11 @@ import (
 var findings = []Namer{
 	&anomalousiam.Finding{},
 	&badip.Finding{},
+	&baddomain.Finding{},
 	&sshbruteforce.Finding{},
+	&newgeography.Finding{},
+	&bigqueryexfiltration.Finding{},
+	&cryptomining.Finding{},
 	&storagescanner.Finding{},
 	&sqlscanner.Finding{},
 	&containerscanner.Finding{},
@@ -50,11 +63,51 @@ var findings = []Namer{
 	&datasetscanner.Finding{},
 	&loggingscanner.Finding{},
 	&iamscanner.Finding{},
+	&sensitivedata.Finding{},
+	&violation.Finding{},
+	&alert.Finding{},
 }
 
 // originalEventTime is the security mark key name used to hold the finding's event time.
 const originalEventTime = "sra-remediated-event-time"
 
+const (
+	// SeverityAttribute is the message attribute holding the severity of the finding, if known.
+	SeverityAttribute = "severity"
+	// FindingAttribute is the message attribute holding the Security Command Center name of the
+	// finding, if known.
+	FindingAttribute = "finding"
+	// EmailAttribute is the message attribute holding the comma separated addresses to email
+	// a summary of the automation to.
+	EmailAttribute = "email_to"
+	// ResourceAttribute is the message attribute holding the Security Command Center resource name
+	// of the finding, if known, i.e. "//storage.googleapis.com/bucket".
+	ResourceAttribute = "resource"
+	// IPsAttribute is the message attribute holding the comma separated public IP addresses seen in
+	// the finding, if any.
+	IPsAttribute = "ips"
+	// CategoryAttribute is the message attribute holding the rule the finding was routed by, i.e.
+	// "bad_ip".
+	CategoryAttribute = "category"
+	// PlaybookAttribute is the message attribute holding the ID of the playbook an automation is
+	// run by.
+	PlaybookAttribute = "playbook"
+	// PlaybookStepAttribute is the message attribute holding the index of the playbook's step.
+	PlaybookStepAttribute = "playbook_step"
+	// RolloutAttribute is the message attribute holding the rollout cohort of the resource, either
+	// RolloutEnforced or RolloutDryRun, if the automation is being rolled out.
+	RolloutAttribute = "rollout"
+	// FreezeAttribute is the message attribute holding the name of the freeze the automation was
+	// downgraded to dry run by, if any.
+	FreezeAttribute = "freeze"
+	// CorrelationAttribute is the message attribute holding the ID tracing the remediation of the
+	// finding across the automations it triggered and the steps of their playbooks.
+	CorrelationAttribute = "correlation_id"
+	// ReplayAttribute is set on findings sent back to the router by a dead letter replay or a
+	// batch export catch-up, which are exempt from max_finding_age.
+	ReplayAttribute = "replay"
+)
+
 // Namer represents findings that export their name.
 type Namer interface {
 	Name([]byte) string
@@ -67,40 +120,105 @@ type Services struct {
 	Logger                *services.Logger
 	Resource              *services.Resource
 	SecurityCommandCenter *services.CommandCenter
+	// State stores the steps of playbooks, it's nil if no state bucket is configured.
+	State *services.State
+	// Intel looks up the IPs of findings for conditions, it's nil if no provider is configured.
+	Intel *services.Intel
+	// Tags looks up the tags of resources for automations scoped by tags, it's nil if none are.
+	Tags *services.Tags
+	// Bundle exports the incident once a playbook finishes, it's nil if no forensics bucket is
+	// configured.
+	Bundle *services.IncidentBundle
+	// Tickets opens tickets instead of running automations during freezes, it's nil if no ticket
+	// topic is configured.
+	Tickets *services.Tickets
+	// Assets lists the resources of sweeps, it's only needed by Sweep.
+	Assets *services.Assets
 }
 
 // Values contains the required values for this function.
 type Values struct {
 	Finding []byte
+	// CorrelationID is forwarded to the automations the finding triggers, if set.
+	CorrelationID string
+	// Replay is true if the finding is replayed or caught up on rather than newly detected.
+	Replay bool
 }
 
 // topics maps automation targets to PubSub topics.
 var topics = map[string]struct{ Topic string }{
-	"gce_create_disk_snapshot":  {Topic: "threat-findings-create-disk-snapshot"},
-	"iam_revoke":                {Topic: "threat-findings-iam-revoke"},
-	"close_bucket":              {Topic: "threat-findings-close-bucket"},
-	"enable_bucket_only_policy": {Topic: "threat-findings-enable-bucket-only-policy"},
-	"close_cloud_sql":           {Topic: "threat-findings-remove-public-sql"},
-	"cloud_sql_require_ssl":     {Topic: "threat-findings-require-ssl"},
-	"cloud_sql_update_password": {Topic: "threat-findings-update-password"},
-	"disable_dashboard":         {Topic: "threat-findings-disable-dashboard"},
-	"remove_public_ip":          {Topic: "threat-findings-remove-public-ip"},
-	"remediate_firewall":        {Topic: "threat-findings-open-firewall"},
-	"close_public_dataset":      {Topic: "threat-findings-close-public-dataset"},
-	"enable_audit_logs":         {Topic: "threat-findings-enable-audit-logs"},
-	"remove_non_org_members":    {Topic: "threat-findings-remove-non-org-members"},
-}
-
-// Automation represents configuration for an automation.
+	"gce_create_disk_snapshot":      {Topic: "threat-findings-create-disk-snapshot"},
+	"iam_revoke":                    {Topic: "threat-findings-iam-revoke"},
+	"iam_revoke_org":                {Topic: "threat-findings-iam-revoke-org"},
+	"iam_revoke_grants":             {Topic: "threat-findings-iam-revoke-grants"},
+	"quarantine_service_account":    {Topic: "threat-findings-quarantine-service-account"},
+	"close_bucket":                  {Topic: "threat-findings-close-bucket"},
+	"close_staging_bucket":          {Topic: "threat-findings-close-staging-bucket"},
+	"enable_bucket_only_policy":     {Topic: "threat-findings-enable-bucket-only-policy"},
+	"enable_bucket_logging":         {Topic: "threat-findings-enable-bucket-logging"},
+	"close_cloud_sql":               {Topic: "threat-findings-remove-public-sql"},
+	"cloud_sql_require_ssl":         {Topic: "threat-findings-require-ssl"},
+	"cloud_sql_update_password":     {Topic: "threat-findings-update-password"},
+	"cloud_sql_secure_root":         {Topic: "threat-findings-secure-root"},
+	"cloud_sql_enable_backups":      {Topic: "threat-findings-enable-backups"},
+	"remove_load_balancer":          {Topic: "threat-findings-remove-load-balancer"},
+	"disable_dashboard":             {Topic: "threat-findings-disable-dashboard"},
+	"harden_cluster_network":        {Topic: "threat-findings-harden-cluster-network"},
+	"secure_managed_cluster":        {Topic: "threat-findings-secure-managed-cluster"},
+	"remove_public_ip":              {Topic: "threat-findings-remove-public-ip"},
+	"disable_serial_port":           {Topic: "threat-findings-disable-serial-port"},
+	"disable_ip_forwarding":         {Topic: "threat-findings-disable-ip-forwarding"},
+	"enforce_https":                 {Topic: "threat-findings-enforce-https"},
+	"remediate_firewall":            {Topic: "threat-findings-open-firewall"},
+	"disable_unused_firewall":       {Topic: "threat-findings-disable-unused-firewall"},
+	"close_public_dataset":          {Topic: "threat-findings-close-public-dataset"},
+	"enable_audit_logs":             {Topic: "threat-findings-enable-audit-logs"},
+	"remove_non_org_members":        {Topic: "threat-findings-remove-non-org-members"},
+	"restrict_sensitive_data":       {Topic: "threat-findings-restrict-sensitive-data"},
+	"harden_instance":               {Topic: "threat-findings-harden-instance"},
+	"enforce_cmek":                  {Topic: "threat-findings-enforce-cmek"},
+	"remove_external_group_members": {Topic: "threat-findings-remove-external-group-members"},
+	"disable_key_version":           {Topic: "threat-findings-disable-key-version"},
+	"block_egress":                  {Topic: "threat-findings-block-egress"},
+	"lockdown_project":              {Topic: "threat-findings-lockdown-project"},
+}
+
+// Automation represents configuration for an automation. Playbook names the playbook the
+// automation is a step of, automations of a finding sharing a playbook are run one after another in
+// the order they're configured. The automation only runs if its Condition holds, see
+// ParseCondition.
 type Automation struct {
-	Action     string
-	Target     []string
-	Exclude    []string
-	Properties struct {
+	Action  string
+	Target  []string
+	Exclude []string
+	// TargetTags and ExcludeTags scope the automation by the tags of resources in addition to their
+	// ancestry, see inScope.
+	TargetTags  []string `yaml:"target_tags"`
+	ExcludeTags []string `yaml:"exclude_tags"`
+	Playbook    string
+	Condition   string
+	// Rollout optionally enforces the automation on only some projects, dry-running the rest.
+	Rollout *Rollout
+	// MaxFindingAge overrides the max_finding_age of the spec for this automation.
+	MaxFindingAge string `yaml:"max_finding_age"`
+	Properties    struct {
 		DryRun    bool `yaml:"dry_run"`
+		TTL       string
 		RevokeIAM struct {
 			AllowDomains []string `yaml:"allow_domains"`
+			AllowMembers []string `yaml:"allow_members"`
+			// Preflight explains whether members removed from a project keep access through
+			// other bindings, it's ignored by iam_revoke_org.
+			Preflight bool
 		} `yaml:"revoke_iam"`
+		RevokeGrants struct {
+			Window       string
+			AllowMembers []string `yaml:"allow_members"`
+			Preflight    bool
+		} `yaml:"revoke_grants"`
+		QuarantineServiceAccount struct {
+			IncludeAncestors bool `yaml:"include_ancestors"`
+		} `yaml:"quarantine_service_account"`
 		CreateSnapshot struct {
 			TargetSnapshotProjectID string `yaml:"target_snapshot_project_id"`
 			TargetSnapshotZone      string `yaml:"target_snapshot_zone"`
@@ -110,41 +228,153 @@ type Automation struct {
 				Topic     string
 				Zone      string
 			}
+			AnalysisVM struct {
+				ProjectID   string `yaml:"project_id"`
+				Zone        string
+				MachineType string `yaml:"machine_type"`
+				Network     string
+				Image       string
+				Topic       string
+			} `yaml:"analysis_vm"`
 		} `yaml:"gce_create_snapshot"`
 		OpenFirewall struct {
 			SourceRanges      []string `yaml:"source_ranges"`
 			RemediationAction string   `yaml:"remediation_action"`
 		} `yaml:"open_firewall"`
+		FirewallInsights struct {
+			Subtypes []string `yaml:"insight_subtypes"`
+		} `yaml:"firewall_insights"`
 		NonOrgMembers struct {
 			AllowDomains []string `yaml:"allow_domains"`
+			AllowMembers []string `yaml:"allow_members"`
 		} `yaml:"non_org_members"`
+		RemoveGroupMembers struct {
+			AllowDomains []string `yaml:"allow_domains"`
+			AllowMembers []string `yaml:"allow_members"`
+		} `yaml:"remove_external_group_members"`
+		CollectEvidence struct {
+			Bucket string
+		} `yaml:"collect_evidence"`
+		BlockEgress struct {
+			Scope             string
+			DestinationRanges []string `yaml:"destination_ranges"`
+		} `yaml:"block_egress"`
+		RemoveLoadBalancer struct {
+			Mode                     string
+			QuarantineBackendService string `yaml:"quarantine_backend_service"`
+		} `yaml:"remove_load_balancer"`
+		EnforceHTTPS struct {
+			SSLPolicy    string `yaml:"ssl_policy"`
+			RedirectHTTP bool   `yaml:"redirect_http"`
+		} `yaml:"enforce_https"`
+		HardenInstance struct {
+			Restart bool
+		} `yaml:"harden_instance"`
+		HardenClusterNetwork struct {
+			AuthorizedNetworks []string `yaml:"authorized_networks"`
+		} `yaml:"harden_cluster_network"`
+		SecureManagedCluster struct {
+			AuthorizedNetworks []string `yaml:"authorized_networks"`
+		} `yaml:"secure_managed_cluster"`
+		EnforceCMEK struct {
+			KMSKey string `yaml:"kms_key"`
+		} `yaml:"enforce_cmek"`
+		DisableKeyVersion struct {
+			BreakGlassGroup string `yaml:"break_glass_group"`
+		} `yaml:"disable_key_version"`
+		LockdownProject struct {
+			BreakGlassGroup string   `yaml:"break_glass_group"`
+			AllowMembers    []string `yaml:"allow_members"`
+		} `yaml:"lockdown_project"`
+		SecureRoot struct {
+			Mode              string
+			NotificationTopic string `yaml:"notification_topic"`
+		} `yaml:"cloud_sql_secure_root"`
+		EnableBucketLogging struct {
+			LogBucket        string `yaml:"log_bucket"`
+			LogObjectPrefix  string `yaml:"log_object_prefix"`
+			EnableVersioning bool   `yaml:"enable_versioning"`
+		} `yaml:"enable_bucket_logging"`
+		Notify struct {
+			Email struct {
+				To         []string
+				Severities []string
+			}
+		}
 	}
 }
 
 // Configuration maps findings to automations.
 type Configuration struct {
-	APIVersion string
-	Spec       struct {
-		Name       string
-		Parameters struct {
+	APIVersion string `yaml:"apiVersion"`
+	Kind       string
+	Metadata   struct {
+		Name string
+	}
+	Spec struct {
+		Name string
+		// Freezes are the windows during which automations are downgraded, see Freeze.
+		Freezes []Freeze
+		// MaxFindingAge is the oldest a finding can be, i.e. "2h", for automations to remediate it,
+		// older findings open a ticket instead. Findings of any age are remediated if empty.
+		MaxFindingAge string `yaml:"max_finding_age"`
+		Parameters    struct {
 			ETD struct {
-				BadIP         []Automation `yaml:"bad_ip"`
-				AnomalousIAM  []Automation `yaml:"anomalous_iam"`
-				SSHBruteForce []Automation `yaml:"ssh_brute_force"`
+				BadIP                []Automation `yaml:"bad_ip"`
+				BadDomain            []Automation `yaml:"bad_domain"`
+				AnomalousIAM         []Automation `yaml:"anomalous_iam"`
+				SSHBruteForce        []Automation `yaml:"ssh_brute_force"`
+				NewGeography         []Automation `yaml:"new_geography"`
+				BigQueryExfiltration []Automation `yaml:"bigquery_exfiltration"`
+				Cryptomining         []Automation `yaml:"cryptomining"`
 			}
 			SHA struct {
 				PublicBucketACL         []Automation `yaml:"public_bucket_acl"`
 				BucketPolicyOnlyDisable []Automation `yaml:"bucket_policy_only_disabled"`
+				BucketLoggingDisabled   []Automation `yaml:"bucket_logging_disabled"`
+				VersioningDisabled      []Automation `yaml:"object_versioning_disabled"`
 				PublicSQLInstance       []Automation `yaml:"public_sql_instance"`
 				SSLNotEnforced          []Automation `yaml:"ssl_not_enforced"`
 				SQLNoRootPassword       []Automation `yaml:"sql_no_root_password"`
+				SQLAutoBackupDisabled   []Automation `yaml:"sql_auto_backup_disabled"`
 				PublicIPAddress         []Automation `yaml:"public_ip_address"`
+				SerialPortsEnabled      []Automation `yaml:"compute_serial_ports_enabled"`
+				IPForwardingEnabled     []Automation `yaml:"ip_forwarding_enabled"`
+				LegacyMetadataEnabled   []Automation `yaml:"legacy_metadata_enabled"`
+				ShieldedVMDisabled      []Automation `yaml:"shielded_vm_disabled"`
+				BucketCMEKDisabled      []Automation `yaml:"bucket_cmek_disabled"`
+				DatasetCMEKDisabled     []Automation `yaml:"dataset_cmek_disabled"`
+				TableCMEKDisabled       []Automation `yaml:"bigquery_table_cmek_disabled"`
+				DiskCMEKDisabled        []Automation `yaml:"disk_cmek_disabled"`
+				HTTPLoadBalancer        []Automation `yaml:"http_load_balancer"`
+				WeakSSLPolicy           []Automation `yaml:"weak_ssl_policy"`
 				OpenFirewall            []Automation `yaml:"open_firewall"`
 				PublicDataset           []Automation `yaml:"bigquery_public_dataset"`
 				AuditLoggingDisabled    []Automation `yaml:"audit_logging_disabled"`
 				WebUIEnabled            []Automation `yaml:"web_ui_enabled"`
+				NetworkPolicyDisabled   []Automation `yaml:"network_policy_disabled"`
+				PrivateClusterDisabled  []Automation `yaml:"private_cluster_disabled"`
 				NonOrgMembers           []Automation `yaml:"non_org_members"`
 			}
+			DLP struct {
+				SensitiveData []Automation `yaml:"sensitive_data"`
+			} `yaml:"dlp"`
+			Forseti struct {
+				BucketViolation    []Automation `yaml:"bucket_violation"`
+				IAMPolicyViolation []Automation `yaml:"iam_policy_violation"`
+				FirewallViolation  []Automation `yaml:"firewall_violation"`
+			}
+			SIEM struct {
+				CompromisedInstance  []Automation `yaml:"compromised_instance"`
+				CompromisedProject   []Automation `yaml:"compromised_project"`
+				DataExfiltration     []Automation `yaml:"data_exfiltration"`
+				ExternalMember       []Automation `yaml:"external_member"`
+				PublicBucket         []Automation `yaml:"public_bucket"`
+				OpenFirewall         []Automation `yaml:"open_firewall"`
+				KeyMisuse            []Automation `yaml:"key_misuse"`
+				RDPBruteForce        []Automation `yaml:"rdp_brute_force"`
+				PublicManagedCluster []Automation `yaml:"public_managed_cluster"`
+			} `yaml:"siem"`
 		}
 	}
 }
@@ -162,6 +392,16 @@ func Config() (*Configuration, error) {
 	return &c, nil
 }
 
+// ParseConfig unmarshals the configuration, failing on unknown or mistyped keys. Unlike Config
+// it is used to validate a configuration before it is deployed.
+func ParseConfig(b []byte) (*Configuration, error) {
+	var c Configuration
+	if err := yaml.UnmarshalStrict(b, &c); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal configuration")
+	}
+	return &c, nil
+}
+
 // ruleName will attempt to deserialize all findings until a name is extracted.
 func ruleName(b []byte) string {
 	for _, finding := range findings {
@@ -173,6 +413,9 @@ func ruleName(b []byte) string {
 }
 
 func markAsRemediated(ctx context.Context, name, eventTime string, services *Services) error {
+	if simulating(ctx) != nil {
+		return nil
+	}
 	m := map[string]string{"sra-remediated-event-time": eventTime}
 	if _, err := services.SecurityCommandCenter.AddSecurityMarks(ctx, name, m); err != nil {
 		return err
@@ -180,14 +423,30 @@ func markAsRemediated(ctx context.Context, name, eventTime string, services *Ser
 	return nil
 }
 
-// Execute will route the incoming finding to the appropriate remediations.
+// Execute will route the incoming finding to the appropriate remediations. Automations that are
+// steps of a playbook are held back and only the playbook's first step is run.
 func Execute(ctx context.Context, values *Values, services *Services) error {
-	switch name := ruleName(values.Finding); name {
+	ctx, end := startSpan(ctx, "route", "sra.rule", ruleName(values.Finding))
+	pending := newPlaybooks()
+	err := route(context.WithValue(ctx, playbooksKey{}, pending), values, services)
+	if err == nil {
+		err = startPlaybooks(ctx, services, pending)
+	}
+	end(err)
+	return err
+}
+
+func route(ctx context.Context, values *Values, services *Services) error {
+	meta := findingMetadata(values.Finding)
+	meta.Rule = ruleName(values.Finding)
+	meta.CorrelationID = values.CorrelationID
+	meta.Replay = values.Replay
+	switch name := meta.Rule; name {
 	case "bad_ip":
 		automations := services.Configuration.Spec.Parameters.ETD.BadIP
 		badIP, err := badip.New(values.Finding)
 		if err != nil {
-			return err
+			return invalidFinding(err)
 		}
 		if badIP.UseCSCC {
 			securityMarks := badIP.BadIPCSCC.GetFinding().GetSecurityMarks().GetMarks()
@@ -209,8 +468,25 @@ func Execute(ctx context.Context, values *Values, services *Services) error {
 				values.Turbinia.ProjectID = automation.Properties.CreateSnapshot.Turbinia.ProjectID
 				values.Turbinia.Topic = automation.Properties.CreateSnapshot.Turbinia.Topic
 				values.Turbinia.Zone = automation.Properties.CreateSnapshot.Turbinia.Zone
+				values.AnalysisVM.ProjectID = automation.Properties.CreateSnapshot.AnalysisVM.ProjectID
+				values.AnalysisVM.Zone = automation.Properties.CreateSnapshot.AnalysisVM.Zone
+				values.AnalysisVM.MachineType = automation.Properties.CreateSnapshot.AnalysisVM.MachineType
+				values.AnalysisVM.Network = automation.Properties.CreateSnapshot.AnalysisVM.Network
+				values.AnalysisVM.Image = automation.Properties.CreateSnapshot.AnalysisVM.Image
+				values.AnalysisVM.Topic = automation.Properties.CreateSnapshot.AnalysisVM.Topic
+				topic := topics[automation.Action].Topic
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
+					services.Logger.Error("failed to publish: %q", err)
+					continue
+				}
+			case "block_egress":
+				values := badIP.BlockEgress()
+				values.DryRun = automation.Properties.DryRun
+				values.TTL = automation.Properties.TTL
+				values.Scope = automation.Properties.BlockEgress.Scope
+				values.DestinationRanges = automation.Properties.BlockEgress.DestinationRanges
 				topic := topics[automation.Action].Topic
-				if err := publish(ctx, services, automation.Action, topic, values.ProjectID, automation.Target, automation.Exclude, values); err != nil {
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
 					services.Logger.Error("failed to publish: %q", err)
 					continue
 				}
@@ -227,7 +503,7 @@ func Execute(ctx context.Context, values *Values, services *Services) error {
 		automations := services.Configuration.Spec.Parameters.ETD.AnomalousIAM
 		anomalousIAM, err := anomalousiam.New(values.Finding)
 		if err != nil {
-			return err
+			return invalidFinding(err)
 		}
 		log.Printf("got rule %q with %d automations", name, len(automations))
 		for _, automation := range automations {
@@ -236,8 +512,62 @@ func Execute(ctx context.Context, values *Values, services *Services) error {
 				values := anomalousIAM.IAMRevoke()
 				values.DryRun = automation.Properties.DryRun
 				values.AllowDomains = automation.Properties.RevokeIAM.AllowDomains
+				values.AllowMembers = automation.Properties.RevokeIAM.AllowMembers
+				values.Preflight = automation.Properties.RevokeIAM.Preflight
+				topic := topics[automation.Action].Topic
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
+					services.Logger.Error("failed to publish: %q", err)
+					continue
+				}
+			case "iam_revoke_org":
+				values := anomalousIAM.IAMRevokeOrganization()
+				if values.Resource == "" {
+					log.Printf("grant was not made on an organization or folder, skipping %q", automation.Action)
+					continue
+				}
+				values.DryRun = automation.Properties.DryRun
+				values.AllowDomains = automation.Properties.RevokeIAM.AllowDomains
+				values.AllowMembers = automation.Properties.RevokeIAM.AllowMembers
+				topic := topics[automation.Action].Topic
+				if err := publishResource(ctx, services, automation, topic, values.Resource, meta, values); err != nil {
+					services.Logger.Error("failed to publish: %q", err)
+					continue
+				}
+			case "iam_revoke_grants":
+				values := anomalousIAM.RevokeGrants()
+				if values.Principal == "" {
+					log.Printf("grantor of the grant is unknown, skipping %q", automation.Action)
+					continue
+				}
+				values.DryRun = automation.Properties.DryRun
+				values.Window = automation.Properties.RevokeGrants.Window
+				values.AllowMembers = automation.Properties.RevokeGrants.AllowMembers
+				values.Preflight = automation.Properties.RevokeGrants.Preflight
+				topic := topics[automation.Action].Topic
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
+					services.Logger.Error("failed to publish: %q", err)
+					continue
+				}
+			case "quarantine_service_account":
+				values := anomalousIAM.QuarantineServiceAccount()
+				if !isServiceAccount(values.ServiceAccount) {
+					log.Printf("grantor %q is not a service account, skipping %q", values.ServiceAccount, automation.Action)
+					continue
+				}
+				values.DryRun = automation.Properties.DryRun
+				values.IncludeAncestors = automation.Properties.QuarantineServiceAccount.IncludeAncestors
+				topic := topics[automation.Action].Topic
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
+					services.Logger.Error("failed to publish: %q", err)
+					continue
+				}
+			case "lockdown_project":
+				values := anomalousIAM.LockdownProject()
+				values.DryRun = automation.Properties.DryRun
+				values.BreakGlassGroup = automation.Properties.LockdownProject.BreakGlassGroup
+				values.AllowMembers = automation.Properties.LockdownProject.AllowMembers
 				topic := topics[automation.Action].Topic
-				if err := publish(ctx, services, automation.Action, topic, values.ProjectID, automation.Target, automation.Exclude, values); err != nil {
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
 					services.Logger.Error("failed to publish: %q", err)
 					continue
 				}
@@ -249,7 +579,7 @@ func Execute(ctx context.Context, values *Values, services *Services) error {
 		automations := services.Configuration.Spec.Parameters.ETD.SSHBruteForce
 		sshBruteForce, err := sshbruteforce.New(values.Finding)
 		if err != nil {
-			return err
+			return invalidFinding(err)
 		}
 		log.Printf("got rule %q with %d automations", name, len(automations))
 		for _, automation := range automations {
@@ -257,9 +587,10 @@ func Execute(ctx context.Context, values *Values, services *Services) error {
 			case "remediate_firewall":
 				values := sshBruteForce.OpenFirewall()
 				values.DryRun = automation.Properties.DryRun
+				values.TTL = automation.Properties.TTL
 				values.Action = "block_ssh"
 				topic := topics[automation.Action].Topic
-				if err := publish(ctx, services, automation.Action, topic, values.ProjectID, automation.Target, automation.Exclude, values); err != nil {
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
 					services.Logger.Error("failed to publish: %q", err)
 					continue
 				}
@@ -267,26 +598,51 @@ func Execute(ctx context.Context, values *Values, services *Services) error {
 				return fmt.Errorf("action %q not found", automation.Action)
 			}
 		}
-	case "public_bucket_acl":
-		automations := services.Configuration.Spec.Parameters.SHA.PublicBucketACL
-		storageScanner, err := storagescanner.New(values.Finding)
+	case "bad_domain":
+		automations := services.Configuration.Spec.Parameters.ETD.BadDomain
+		badDomain, err := baddomain.New(values.Finding)
 		if err != nil {
-			return err
+			return invalidFinding(err)
 		}
-		securityMarks := storageScanner.StorageScanner.GetFinding().GetSecurityMarks().GetMarks()
-		remediated := securityMarks[originalEventTime] == storageScanner.StorageScanner.GetFinding().GetEventTime()
-		if remediated {
-			log.Printf("finding already remediated")
-			return nil
+		if badDomain.UseCSCC {
+			securityMarks := badDomain.BadDomainCSCC.GetFinding().GetSecurityMarks().GetMarks()
+			remediated := securityMarks[originalEventTime] == badDomain.BadDomainCSCC.GetFinding().GetEventTime()
+			if remediated {
+				log.Printf("finding already remediated")
+				return nil
+			}
 		}
 		log.Printf("got rule %q with %d automations", name, len(automations))
 		for _, automation := range automations {
 			switch automation.Action {
-			case "close_bucket":
-				values := storageScanner.CloseBucket()
+			case "gce_create_disk_snapshot":
+				values := badDomain.CreateSnapshot()
+				values.DryRun = automation.Properties.DryRun
+				values.Output = automation.Properties.CreateSnapshot.Output
+				values.DestProjectID = automation.Properties.CreateSnapshot.TargetSnapshotProjectID
+				values.DestZone = automation.Properties.CreateSnapshot.TargetSnapshotZone
+				values.Turbinia.ProjectID = automation.Properties.CreateSnapshot.Turbinia.ProjectID
+				values.Turbinia.Topic = automation.Properties.CreateSnapshot.Turbinia.Topic
+				values.Turbinia.Zone = automation.Properties.CreateSnapshot.Turbinia.Zone
+				values.AnalysisVM.ProjectID = automation.Properties.CreateSnapshot.AnalysisVM.ProjectID
+				values.AnalysisVM.Zone = automation.Properties.CreateSnapshot.AnalysisVM.Zone
+				values.AnalysisVM.MachineType = automation.Properties.CreateSnapshot.AnalysisVM.MachineType
+				values.AnalysisVM.Network = automation.Properties.CreateSnapshot.AnalysisVM.Network
+				values.AnalysisVM.Image = automation.Properties.CreateSnapshot.AnalysisVM.Image
+				values.AnalysisVM.Topic = automation.Properties.CreateSnapshot.AnalysisVM.Topic
+				topic := topics[automation.Action].Topic
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
+					services.Logger.Error("failed to publish: %q", err)
+					continue
+				}
+			case "block_egress":
+				values := badDomain.BlockEgress()
 				values.DryRun = automation.Properties.DryRun
+				values.TTL = automation.Properties.TTL
+				values.Scope = automation.Properties.BlockEgress.Scope
+				values.DestinationRanges = automation.Properties.BlockEgress.DestinationRanges
 				topic := topics[automation.Action].Topic
-				if err := publish(ctx, services, automation.Action, topic, values.ProjectID, automation.Target, automation.Exclude, values); err != nil {
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
 					services.Logger.Error("failed to publish: %q", err)
 					continue
 				}
@@ -294,29 +650,28 @@ func Execute(ctx context.Context, values *Values, services *Services) error {
 				return fmt.Errorf("action %q not found", automation.Action)
 			}
 		}
-		if err := markAsRemediated(ctx, storageScanner.StorageScanner.GetFinding().GetName(), storageScanner.StorageScanner.GetFinding().GetEventTime(), services); err != nil {
-			return err
+		if badDomain.UseCSCC {
+			if err := markAsRemediated(ctx, badDomain.BadDomainCSCC.GetFinding().GetName(), badDomain.BadDomainCSCC.GetFinding().GetEventTime(), services); err != nil {
+				return err
+			}
 		}
-	case "bucket_policy_only_disabled":
-		automations := services.Configuration.Spec.Parameters.SHA.BucketPolicyOnlyDisable
-		storageScanner, err := storagescanner.New(values.Finding)
+	case "new_geography":
+		automations := services.Configuration.Spec.Parameters.ETD.NewGeography
+		newGeography, err := newgeography.New(values.Finding)
 		if err != nil {
-			return err
-		}
-		securityMarks := storageScanner.StorageScanner.GetFinding().GetSecurityMarks().GetMarks()
-		remediated := securityMarks[originalEventTime] == storageScanner.StorageScanner.GetFinding().GetEventTime()
-		if remediated {
-			log.Printf("finding already remediated")
-			return nil
+			return invalidFinding(err)
 		}
 		log.Printf("got rule %q with %d automations", name, len(automations))
 		for _, automation := range automations {
 			switch automation.Action {
-			case "enable_bucket_only_policy":
-				values := storageScanner.EnableBucketOnlyPolicy()
+			case "iam_revoke":
+				values := newGeography.IAMRevoke()
 				values.DryRun = automation.Properties.DryRun
+				values.AllowDomains = automation.Properties.RevokeIAM.AllowDomains
+				values.AllowMembers = automation.Properties.RevokeIAM.AllowMembers
+				values.Preflight = automation.Properties.RevokeIAM.Preflight
 				topic := topics[automation.Action].Topic
-				if err := publish(ctx, services, automation.Action, topic, values.ProjectID, automation.Target, automation.Exclude, values); err != nil {
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
 					services.Logger.Error("failed to publish: %q", err)
 					continue
 				}
@@ -324,29 +679,23 @@ func Execute(ctx context.Context, values *Values, services *Services) error {
 				return fmt.Errorf("action %q not found", automation.Action)
 			}
 		}
-		if err := markAsRemediated(ctx, storageScanner.StorageScanner.GetFinding().GetName(), storageScanner.StorageScanner.GetFinding().GetEventTime(), services); err != nil {
-			return err
-		}
-	case "public_sql_instance":
-		automations := services.Configuration.Spec.Parameters.SHA.PublicSQLInstance
-		sqlScanner, err := sqlscanner.New(values.Finding)
+	case "bigquery_exfiltration":
+		automations := services.Configuration.Spec.Parameters.ETD.BigQueryExfiltration
+		exfiltration, err := bigqueryexfiltration.New(values.Finding)
 		if err != nil {
-			return err
-		}
-		securityMarks := sqlScanner.SQLScanner.GetFinding().GetSecurityMarks().GetMarks()
-		remediated := securityMarks[originalEventTime] == sqlScanner.SQLScanner.GetFinding().GetEventTime()
-		if remediated {
-			log.Printf("finding already remediated")
-			return nil
+			return invalidFinding(err)
 		}
 		log.Printf("got rule %q with %d automations", name, len(automations))
 		for _, automation := range automations {
 			switch automation.Action {
-			case "close_cloud_sql":
-				values := sqlScanner.RemovePublic()
+			case "iam_revoke":
+				values := exfiltration.IAMRevoke()
 				values.DryRun = automation.Properties.DryRun
+				values.AllowDomains = automation.Properties.RevokeIAM.AllowDomains
+				values.AllowMembers = automation.Properties.RevokeIAM.AllowMembers
+				values.Preflight = automation.Properties.RevokeIAM.Preflight
 				topic := topics[automation.Action].Topic
-				if err := publish(ctx, services, automation.Action, topic, values.ProjectID, automation.Target, automation.Exclude, values); err != nil {
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
 					services.Logger.Error("failed to publish: %q", err)
 					continue
 				}
@@ -354,29 +703,50 @@ func Execute(ctx context.Context, values *Values, services *Services) error {
 				return fmt.Errorf("action %q not found", automation.Action)
 			}
 		}
-		if err := markAsRemediated(ctx, sqlScanner.SQLScanner.GetFinding().GetName(), sqlScanner.SQLScanner.GetFinding().GetEventTime(), services); err != nil {
-			return err
-		}
-	case "ssl_not_enforced":
-		automations := services.Configuration.Spec.Parameters.SHA.SSLNotEnforced
-		sqlScanner, err := sqlscanner.New(values.Finding)
+	case "cryptomining":
+		automations := services.Configuration.Spec.Parameters.ETD.Cryptomining
+		miner, err := cryptomining.New(values.Finding)
 		if err != nil {
-			return err
+			return invalidFinding(err)
 		}
-		securityMarks := sqlScanner.SQLScanner.GetFinding().GetSecurityMarks().GetMarks()
-		remediated := securityMarks[originalEventTime] == sqlScanner.SQLScanner.GetFinding().GetEventTime()
-		if remediated {
-			log.Printf("finding already remediated")
-			return nil
+		if miner.UseCSCC {
+			securityMarks := miner.CryptominingCSCC.GetFinding().GetSecurityMarks().GetMarks()
+			remediated := securityMarks[originalEventTime] == miner.CryptominingCSCC.GetFinding().GetEventTime()
+			if remediated {
+				log.Printf("finding already remediated")
+				return nil
+			}
 		}
 		log.Printf("got rule %q with %d automations", name, len(automations))
 		for _, automation := range automations {
 			switch automation.Action {
-			case "cloud_sql_require_ssl":
-				values := sqlScanner.RequireSSL()
+			case "gce_create_disk_snapshot":
+				values := miner.CreateSnapshot()
+				values.DryRun = automation.Properties.DryRun
+				values.Output = automation.Properties.CreateSnapshot.Output
+				values.DestProjectID = automation.Properties.CreateSnapshot.TargetSnapshotProjectID
+				values.DestZone = automation.Properties.CreateSnapshot.TargetSnapshotZone
+				values.Turbinia.ProjectID = automation.Properties.CreateSnapshot.Turbinia.ProjectID
+				values.Turbinia.Topic = automation.Properties.CreateSnapshot.Turbinia.Topic
+				values.Turbinia.Zone = automation.Properties.CreateSnapshot.Turbinia.Zone
+				values.AnalysisVM.ProjectID = automation.Properties.CreateSnapshot.AnalysisVM.ProjectID
+				values.AnalysisVM.Zone = automation.Properties.CreateSnapshot.AnalysisVM.Zone
+				values.AnalysisVM.MachineType = automation.Properties.CreateSnapshot.AnalysisVM.MachineType
+				values.AnalysisVM.Network = automation.Properties.CreateSnapshot.AnalysisVM.Network
+				values.AnalysisVM.Image = automation.Properties.CreateSnapshot.AnalysisVM.Image
+				values.AnalysisVM.Topic = automation.Properties.CreateSnapshot.AnalysisVM.Topic
+				topic := topics[automation.Action].Topic
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
+					services.Logger.Error("failed to publish: %q", err)
+					continue
+				}
+			case "remove_public_ip":
+				values := miner.RemovePublicIP()
 				values.DryRun = automation.Properties.DryRun
+				values.TTL = automation.Properties.TTL
+				values.EvidenceBucket = automation.Properties.CollectEvidence.Bucket
 				topic := topics[automation.Action].Topic
-				if err := publish(ctx, services, automation.Action, topic, values.ProjectID, automation.Target, automation.Exclude, values); err != nil {
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
 					services.Logger.Error("failed to publish: %q", err)
 					continue
 				}
@@ -384,17 +754,19 @@ func Execute(ctx context.Context, values *Values, services *Services) error {
 				return fmt.Errorf("action %q not found", automation.Action)
 			}
 		}
-		if err := markAsRemediated(ctx, sqlScanner.SQLScanner.GetFinding().GetName(), sqlScanner.SQLScanner.GetFinding().GetEventTime(), services); err != nil {
-			return err
+		if miner.UseCSCC {
+			if err := markAsRemediated(ctx, miner.CryptominingCSCC.GetFinding().GetName(), miner.CryptominingCSCC.GetFinding().GetEventTime(), services); err != nil {
+				return err
+			}
 		}
-	case "sql_no_root_password":
-		automations := services.Configuration.Spec.Parameters.SHA.SQLNoRootPassword
-		sqlScanner, err := sqlscanner.New(values.Finding)
+	case "public_bucket_acl":
+		automations := services.Configuration.Spec.Parameters.SHA.PublicBucketACL
+		storageScanner, err := storagescanner.New(values.Finding)
 		if err != nil {
-			return err
+			return invalidFinding(err)
 		}
-		securityMarks := sqlScanner.SQLScanner.GetFinding().GetSecurityMarks().GetMarks()
-		remediated := securityMarks[originalEventTime] == sqlScanner.SQLScanner.GetFinding().GetEventTime()
+		securityMarks := storageScanner.StorageScanner.GetFinding().GetSecurityMarks().GetMarks()
+		remediated := securityMarks[originalEventTime] == storageScanner.StorageScanner.GetFinding().GetEventTime()
 		if remediated {
 			log.Printf("finding already remediated")
 			return nil
@@ -402,15 +774,19 @@ func Execute(ctx context.Context, values *Values, services *Services) error {
 		log.Printf("got rule %q with %d automations", name, len(automations))
 		for _, automation := range automations {
 			switch automation.Action {
-			case "cloud_sql_update_password":
-				values, err := sqlScanner.UpdatePassword()
-				if err != nil {
-					services.Logger.Error("failed to get values for %q: %q", automation.Action, err)
+			case "close_bucket":
+				values := storageScanner.CloseBucket()
+				values.DryRun = automation.Properties.DryRun
+				topic := topics[automation.Action].Topic
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
+					services.Logger.Error("failed to publish: %q", err)
 					continue
 				}
+			case "close_staging_bucket":
+				values := storageScanner.CloseStagingBucket()
 				values.DryRun = automation.Properties.DryRun
 				topic := topics[automation.Action].Topic
-				if err := publish(ctx, services, automation.Action, topic, values.ProjectID, automation.Target, automation.Exclude, values); err != nil {
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
 					services.Logger.Error("failed to publish: %q", err)
 					continue
 				}
@@ -418,17 +794,17 @@ func Execute(ctx context.Context, values *Values, services *Services) error {
 				return fmt.Errorf("action %q not found", automation.Action)
 			}
 		}
-		if err := markAsRemediated(ctx, sqlScanner.SQLScanner.GetFinding().GetName(), sqlScanner.SQLScanner.GetFinding().GetEventTime(), services); err != nil {
+		if err := markAsRemediated(ctx, storageScanner.StorageScanner.GetFinding().GetName(), storageScanner.StorageScanner.GetFinding().GetEventTime(), services); err != nil {
 			return err
 		}
-	case "public_ip_address":
-		automations := services.Configuration.Spec.Parameters.SHA.PublicIPAddress
-		computeInstanceScanner, err := computeinstancescanner.New(values.Finding)
+	case "bucket_policy_only_disabled":
+		automations := services.Configuration.Spec.Parameters.SHA.BucketPolicyOnlyDisable
+		storageScanner, err := storagescanner.New(values.Finding)
 		if err != nil {
-			return err
+			return invalidFinding(err)
 		}
-		securityMarks := computeInstanceScanner.ComputeInstanceScanner.GetFinding().GetSecurityMarks().GetMarks()
-		remediated := securityMarks[originalEventTime] == computeInstanceScanner.ComputeInstanceScanner.GetFinding().GetEventTime()
+		securityMarks := storageScanner.StorageScanner.GetFinding().GetSecurityMarks().GetMarks()
+		remediated := securityMarks[originalEventTime] == storageScanner.StorageScanner.GetFinding().GetEventTime()
 		if remediated {
 			log.Printf("finding already remediated")
 			return nil
@@ -436,11 +812,11 @@ func Execute(ctx context.Context, values *Values, services *Services) error {
 		log.Printf("got rule %q with %d automations", name, len(automations))
 		for _, automation := range automations {
 			switch automation.Action {
-			case "remove_public_ip":
-				values := computeInstanceScanner.RemovePublicIP()
+			case "enable_bucket_only_policy":
+				values := storageScanner.EnableBucketOnlyPolicy()
 				values.DryRun = automation.Properties.DryRun
 				topic := topics[automation.Action].Topic
-				if err := publish(ctx, services, automation.Action, topic, values.ProjectID, automation.Target, automation.Exclude, values); err != nil {
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
 					services.Logger.Error("failed to publish: %q", err)
 					continue
 				}
@@ -448,17 +824,17 @@ func Execute(ctx context.Context, values *Values, services *Services) error {
 				return fmt.Errorf("action %q not found", automation.Action)
 			}
 		}
-		if err := markAsRemediated(ctx, computeInstanceScanner.ComputeInstanceScanner.GetFinding().GetName(), computeInstanceScanner.ComputeInstanceScanner.GetFinding().GetEventTime(), services); err != nil {
+		if err := markAsRemediated(ctx, storageScanner.StorageScanner.GetFinding().GetName(), storageScanner.StorageScanner.GetFinding().GetEventTime(), services); err != nil {
 			return err
 		}
-	case "open_firewall":
-		automations := services.Configuration.Spec.Parameters.SHA.OpenFirewall
-		firewallScanner, err := firewallscanner.New(values.Finding)
+	case "bucket_cmek_disabled":
+		automations := services.Configuration.Spec.Parameters.SHA.BucketCMEKDisabled
+		storageScanner, err := storagescanner.New(values.Finding)
 		if err != nil {
-			return err
+			return invalidFinding(err)
 		}
-		securityMarks := firewallScanner.FirewallScanner.GetFinding().GetSecurityMarks().GetMarks()
-		remediated := securityMarks[originalEventTime] == firewallScanner.FirewallScanner.GetFinding().GetEventTime()
+		securityMarks := storageScanner.StorageScanner.GetFinding().GetSecurityMarks().GetMarks()
+		remediated := securityMarks[originalEventTime] == storageScanner.StorageScanner.GetFinding().GetEventTime()
 		if remediated {
 			log.Printf("finding already remediated")
 			return nil
@@ -466,13 +842,12 @@ func Execute(ctx context.Context, values *Values, services *Services) error {
 		log.Printf("got rule %q with %d automations", name, len(automations))
 		for _, automation := range automations {
 			switch automation.Action {
-			case "remediate_firewall":
-				values := firewallScanner.OpenFirewall()
+			case "enforce_cmek":
+				values := storageScanner.EnforceCMEK()
 				values.DryRun = automation.Properties.DryRun
-				values.SourceRanges = automation.Properties.OpenFirewall.SourceRanges
-				values.Action = automation.Properties.OpenFirewall.RemediationAction
+				values.KeyName = automation.Properties.EnforceCMEK.KMSKey
 				topic := topics[automation.Action].Topic
-				if err := publish(ctx, services, automation.Action, topic, values.ProjectID, automation.Target, automation.Exclude, values); err != nil {
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
 					services.Logger.Error("failed to publish: %q", err)
 					continue
 				}
@@ -480,17 +855,20 @@ func Execute(ctx context.Context, values *Values, services *Services) error {
 				return fmt.Errorf("action %q not found", automation.Action)
 			}
 		}
-		if err := markAsRemediated(ctx, firewallScanner.FirewallScanner.GetFinding().GetName(), firewallScanner.FirewallScanner.GetFinding().GetEventTime(), services); err != nil {
+		if err := markAsRemediated(ctx, storageScanner.StorageScanner.GetFinding().GetName(), storageScanner.StorageScanner.GetFinding().GetEventTime(), services); err != nil {
 			return err
 		}
-	case "open_ssh_port":
-		automations := services.Configuration.Spec.Parameters.SHA.OpenFirewall
-		firewallScanner, err := firewallscanner.New(values.Finding)
+	case "bucket_logging_disabled", "object_versioning_disabled":
+		automations := services.Configuration.Spec.Parameters.SHA.BucketLoggingDisabled
+		if name == "object_versioning_disabled" {
+			automations = services.Configuration.Spec.Parameters.SHA.VersioningDisabled
+		}
+		storageScanner, err := storagescanner.New(values.Finding)
 		if err != nil {
-			return err
+			return invalidFinding(err)
 		}
-		securityMarks := firewallScanner.FirewallScanner.GetFinding().GetSecurityMarks().GetMarks()
-		remediated := securityMarks[originalEventTime] == firewallScanner.FirewallScanner.GetFinding().GetEventTime()
+		securityMarks := storageScanner.StorageScanner.GetFinding().GetSecurityMarks().GetMarks()
+		remediated := securityMarks[originalEventTime] == storageScanner.StorageScanner.GetFinding().GetEventTime()
 		if remediated {
 			log.Printf("finding already remediated")
 			return nil
@@ -498,13 +876,14 @@ func Execute(ctx context.Context, values *Values, services *Services) error {
 		log.Printf("got rule %q with %d automations", name, len(automations))
 		for _, automation := range automations {
 			switch automation.Action {
-			case "remediate_firewall":
-				values := firewallScanner.OpenFirewall()
+			case "enable_bucket_logging":
+				values := storageScanner.EnableBucketLogging()
 				values.DryRun = automation.Properties.DryRun
-				values.SourceRanges = automation.Properties.OpenFirewall.SourceRanges
-				values.Action = automation.Properties.OpenFirewall.RemediationAction
+				values.LogBucket = automation.Properties.EnableBucketLogging.LogBucket
+				values.LogObjectPrefix = automation.Properties.EnableBucketLogging.LogObjectPrefix
+				values.EnableVersioning = automation.Properties.EnableBucketLogging.EnableVersioning || name == "object_versioning_disabled"
 				topic := topics[automation.Action].Topic
-				if err := publish(ctx, services, automation.Action, topic, values.ProjectID, automation.Target, automation.Exclude, values); err != nil {
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
 					services.Logger.Error("failed to publish: %q", err)
 					continue
 				}
@@ -512,17 +891,17 @@ func Execute(ctx context.Context, values *Values, services *Services) error {
 				return fmt.Errorf("action %q not found", automation.Action)
 			}
 		}
-		if err := markAsRemediated(ctx, firewallScanner.FirewallScanner.GetFinding().GetName(), firewallScanner.FirewallScanner.GetFinding().GetEventTime(), services); err != nil {
+		if err := markAsRemediated(ctx, storageScanner.StorageScanner.GetFinding().GetName(), storageScanner.StorageScanner.GetFinding().GetEventTime(), services); err != nil {
 			return err
 		}
-	case "open_rdp_port":
-		automations := services.Configuration.Spec.Parameters.SHA.OpenFirewall
-		firewallScanner, err := firewallscanner.New(values.Finding)
+	case "public_sql_instance":
+		automations := services.Configuration.Spec.Parameters.SHA.PublicSQLInstance
+		sqlScanner, err := sqlscanner.New(values.Finding)
 		if err != nil {
-			return err
+			return invalidFinding(err)
 		}
-		securityMarks := firewallScanner.FirewallScanner.GetFinding().GetSecurityMarks().GetMarks()
-		remediated := securityMarks[originalEventTime] == firewallScanner.FirewallScanner.GetFinding().GetEventTime()
+		securityMarks := sqlScanner.SQLScanner.GetFinding().GetSecurityMarks().GetMarks()
+		remediated := securityMarks[originalEventTime] == sqlScanner.SQLScanner.GetFinding().GetEventTime()
 		if remediated {
 			log.Printf("finding already remediated")
 			return nil
@@ -530,13 +909,11 @@ func Execute(ctx context.Context, values *Values, services *Services) error {
 		log.Printf("got rule %q with %d automations", name, len(automations))
 		for _, automation := range automations {
 			switch automation.Action {
-			case "remediate_firewall":
-				values := firewallScanner.OpenFirewall()
+			case "close_cloud_sql":
+				values := sqlScanner.RemovePublic()
 				values.DryRun = automation.Properties.DryRun
-				values.SourceRanges = automation.Properties.OpenFirewall.SourceRanges
-				values.Action = automation.Properties.OpenFirewall.RemediationAction
 				topic := topics[automation.Action].Topic
-				if err := publish(ctx, services, automation.Action, topic, values.ProjectID, automation.Target, automation.Exclude, values); err != nil {
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
 					services.Logger.Error("failed to publish: %q", err)
 					continue
 				}
@@ -544,17 +921,17 @@ func Execute(ctx context.Context, values *Values, services *Services) error {
 				return fmt.Errorf("action %q not found", automation.Action)
 			}
 		}
-		if err := markAsRemediated(ctx, firewallScanner.FirewallScanner.GetFinding().GetName(), firewallScanner.FirewallScanner.GetFinding().GetEventTime(), services); err != nil {
+		if err := markAsRemediated(ctx, sqlScanner.SQLScanner.GetFinding().GetName(), sqlScanner.SQLScanner.GetFinding().GetEventTime(), services); err != nil {
 			return err
 		}
-	case "public_dataset":
-		automations := services.Configuration.Spec.Parameters.SHA.PublicDataset
-		publicDataset, err := datasetscanner.New(values.Finding)
+	case "ssl_not_enforced":
+		automations := services.Configuration.Spec.Parameters.SHA.SSLNotEnforced
+		sqlScanner, err := sqlscanner.New(values.Finding)
 		if err != nil {
-			return err
+			return invalidFinding(err)
 		}
-		securityMarks := publicDataset.DatasetScanner.GetFinding().GetSecurityMarks().GetMarks()
-		remediated := securityMarks[originalEventTime] == publicDataset.DatasetScanner.GetFinding().GetEventTime()
+		securityMarks := sqlScanner.SQLScanner.GetFinding().GetSecurityMarks().GetMarks()
+		remediated := securityMarks[originalEventTime] == sqlScanner.SQLScanner.GetFinding().GetEventTime()
 		if remediated {
 			log.Printf("finding already remediated")
 			return nil
@@ -562,11 +939,11 @@ func Execute(ctx context.Context, values *Values, services *Services) error {
 		log.Printf("got rule %q with %d automations", name, len(automations))
 		for _, automation := range automations {
 			switch automation.Action {
-			case "close_public_dataset":
-				values := publicDataset.ClosePublicDataset()
+			case "cloud_sql_require_ssl":
+				values := sqlScanner.RequireSSL()
 				values.DryRun = automation.Properties.DryRun
 				topic := topics[automation.Action].Topic
-				if err := publish(ctx, services, automation.Action, topic, values.ProjectID, automation.Target, automation.Exclude, values); err != nil {
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
 					services.Logger.Error("failed to publish: %q", err)
 					continue
 				}
@@ -574,29 +951,773 @@ func Execute(ctx context.Context, values *Values, services *Services) error {
 				return fmt.Errorf("action %q not found", automation.Action)
 			}
 		}
-		if err := markAsRemediated(ctx, publicDataset.DatasetScanner.GetFinding().GetName(), publicDataset.DatasetScanner.GetFinding().GetEventTime(), services); err != nil {
+		if err := markAsRemediated(ctx, sqlScanner.SQLScanner.GetFinding().GetName(), sqlScanner.SQLScanner.GetFinding().GetEventTime(), services); err != nil {
+			return err
+		}
+	case "sql_auto_backup_disabled":
+		automations := services.Configuration.Spec.Parameters.SHA.SQLAutoBackupDisabled
+		sqlScanner, err := sqlscanner.New(values.Finding)
+		if err != nil {
+			return invalidFinding(err)
+		}
+		securityMarks := sqlScanner.SQLScanner.GetFinding().GetSecurityMarks().GetMarks()
+		remediated := securityMarks[originalEventTime] == sqlScanner.SQLScanner.GetFinding().GetEventTime()
+		if remediated {
+			log.Printf("finding already remediated")
+			return nil
+		}
+		log.Printf("got rule %q with %d automations", name, len(automations))
+		for _, automation := range automations {
+			switch automation.Action {
+			case "cloud_sql_enable_backups":
+				values := sqlScanner.EnableBackups()
+				values.DryRun = automation.Properties.DryRun
+				topic := topics[automation.Action].Topic
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
+					services.Logger.Error("failed to publish: %q", err)
+					continue
+				}
+			default:
+				return fmt.Errorf("action %q not found", automation.Action)
+			}
+		}
+		if err := markAsRemediated(ctx, sqlScanner.SQLScanner.GetFinding().GetName(), sqlScanner.SQLScanner.GetFinding().GetEventTime(), services); err != nil {
+			return err
+		}
+	case "sql_no_root_password":
+		automations := services.Configuration.Spec.Parameters.SHA.SQLNoRootPassword
+		sqlScanner, err := sqlscanner.New(values.Finding)
+		if err != nil {
+			return invalidFinding(err)
+		}
+		securityMarks := sqlScanner.SQLScanner.GetFinding().GetSecurityMarks().GetMarks()
+		remediated := securityMarks[originalEventTime] == sqlScanner.SQLScanner.GetFinding().GetEventTime()
+		if remediated {
+			log.Printf("finding already remediated")
+			return nil
+		}
+		log.Printf("got rule %q with %d automations", name, len(automations))
+		for _, automation := range automations {
+			switch automation.Action {
+			case "cloud_sql_update_password":
+				values, err := sqlScanner.UpdatePassword()
+				if err != nil {
+					services.Logger.Error("failed to get values for %q: %q", automation.Action, err)
+					continue
+				}
+				values.DryRun = automation.Properties.DryRun
+				topic := topics[automation.Action].Topic
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
+					services.Logger.Error("failed to publish: %q", err)
+					continue
+				}
+			case "cloud_sql_secure_root":
+				values := sqlScanner.SecureRoot()
+				values.Mode = automation.Properties.SecureRoot.Mode
+				values.NotificationTopic = automation.Properties.SecureRoot.NotificationTopic
+				values.DryRun = automation.Properties.DryRun
+				topic := topics[automation.Action].Topic
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
+					services.Logger.Error("failed to publish: %q", err)
+					continue
+				}
+			default:
+				return fmt.Errorf("action %q not found", automation.Action)
+			}
+		}
+		if err := markAsRemediated(ctx, sqlScanner.SQLScanner.GetFinding().GetName(), sqlScanner.SQLScanner.GetFinding().GetEventTime(), services); err != nil {
+			return err
+		}
+	case "public_ip_address":
+		automations := services.Configuration.Spec.Parameters.SHA.PublicIPAddress
+		computeInstanceScanner, err := computeinstancescanner.New(values.Finding)
+		if err != nil {
+			return invalidFinding(err)
+		}
+		securityMarks := computeInstanceScanner.ComputeInstanceScanner.GetFinding().GetSecurityMarks().GetMarks()
+		remediated := securityMarks[originalEventTime] == computeInstanceScanner.ComputeInstanceScanner.GetFinding().GetEventTime()
+		if remediated {
+			log.Printf("finding already remediated")
+			return nil
+		}
+		log.Printf("got rule %q with %d automations", name, len(automations))
+		for _, automation := range automations {
+			switch automation.Action {
+			case "remove_public_ip":
+				values := computeInstanceScanner.RemovePublicIP()
+				values.DryRun = automation.Properties.DryRun
+				values.TTL = automation.Properties.TTL
+				values.EvidenceBucket = automation.Properties.CollectEvidence.Bucket
+				topic := topics[automation.Action].Topic
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
+					services.Logger.Error("failed to publish: %q", err)
+					continue
+				}
+			default:
+				return fmt.Errorf("action %q not found", automation.Action)
+			}
+		}
+		if err := markAsRemediated(ctx, computeInstanceScanner.ComputeInstanceScanner.GetFinding().GetName(), computeInstanceScanner.ComputeInstanceScanner.GetFinding().GetEventTime(), services); err != nil {
+			return err
+		}
+	case "compute_serial_ports_enabled", "ip_forwarding_enabled":
+		automations := services.Configuration.Spec.Parameters.SHA.SerialPortsEnabled
+		if name == "ip_forwarding_enabled" {
+			automations = services.Configuration.Spec.Parameters.SHA.IPForwardingEnabled
+		}
+		computeInstanceScanner, err := computeinstancescanner.New(values.Finding)
+		if err != nil {
+			return invalidFinding(err)
+		}
+		securityMarks := computeInstanceScanner.ComputeInstanceScanner.GetFinding().GetSecurityMarks().GetMarks()
+		remediated := securityMarks[originalEventTime] == computeInstanceScanner.ComputeInstanceScanner.GetFinding().GetEventTime()
+		if remediated {
+			log.Printf("finding already remediated")
+			return nil
+		}
+		log.Printf("got rule %q with %d automations", name, len(automations))
+		for _, automation := range automations {
+			switch automation.Action {
+			case "disable_serial_port":
+				values := computeInstanceScanner.DisableSerialPort()
+				values.DryRun = automation.Properties.DryRun
+				topic := topics[automation.Action].Topic
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
+					services.Logger.Error("failed to publish: %q", err)
+					continue
+				}
+			case "disable_ip_forwarding":
+				values := computeInstanceScanner.DisableIPForwarding()
+				values.DryRun = automation.Properties.DryRun
+				topic := topics[automation.Action].Topic
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
+					services.Logger.Error("failed to publish: %q", err)
+					continue
+				}
+			default:
+				return fmt.Errorf("action %q not found", automation.Action)
+			}
+		}
+		if err := markAsRemediated(ctx, computeInstanceScanner.ComputeInstanceScanner.GetFinding().GetName(), computeInstanceScanner.ComputeInstanceScanner.GetFinding().GetEventTime(), services); err != nil {
+			return err
+		}
+	case "legacy_metadata_enabled", "shielded_vm_disabled":
+		automations := services.Configuration.Spec.Parameters.SHA.LegacyMetadataEnabled
+		if name == "shielded_vm_disabled" {
+			automations = services.Configuration.Spec.Parameters.SHA.ShieldedVMDisabled
+		}
+		computeInstanceScanner, err := computeinstancescanner.New(values.Finding)
+		if err != nil {
+			return invalidFinding(err)
+		}
+		securityMarks := computeInstanceScanner.ComputeInstanceScanner.GetFinding().GetSecurityMarks().GetMarks()
+		remediated := securityMarks[originalEventTime] == computeInstanceScanner.ComputeInstanceScanner.GetFinding().GetEventTime()
+		if remediated {
+			log.Printf("finding already remediated")
+			return nil
+		}
+		log.Printf("got rule %q with %d automations", name, len(automations))
+		for _, automation := range automations {
+			switch automation.Action {
+			case "harden_instance":
+				values := computeInstanceScanner.HardenInstance()
+				values.DryRun = automation.Properties.DryRun
+				values.Restart = automation.Properties.HardenInstance.Restart
+				topic := topics[automation.Action].Topic
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
+					services.Logger.Error("failed to publish: %q", err)
+					continue
+				}
+			default:
+				return fmt.Errorf("action %q not found", automation.Action)
+			}
+		}
+		if err := markAsRemediated(ctx, computeInstanceScanner.ComputeInstanceScanner.GetFinding().GetName(), computeInstanceScanner.ComputeInstanceScanner.GetFinding().GetEventTime(), services); err != nil {
+			return err
+		}
+	case "disk_cmek_disabled":
+		automations := services.Configuration.Spec.Parameters.SHA.DiskCMEKDisabled
+		computeInstanceScanner, err := computeinstancescanner.New(values.Finding)
+		if err != nil {
+			return invalidFinding(err)
+		}
+		securityMarks := computeInstanceScanner.ComputeInstanceScanner.GetFinding().GetSecurityMarks().GetMarks()
+		remediated := securityMarks[originalEventTime] == computeInstanceScanner.ComputeInstanceScanner.GetFinding().GetEventTime()
+		if remediated {
+			log.Printf("finding already remediated")
+			return nil
+		}
+		log.Printf("got rule %q with %d automations", name, len(automations))
+		for _, automation := range automations {
+			switch automation.Action {
+			case "enforce_cmek":
+				values := computeInstanceScanner.EnforceCMEK()
+				values.DryRun = automation.Properties.DryRun
+				values.KeyName = automation.Properties.EnforceCMEK.KMSKey
+				topic := topics[automation.Action].Topic
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
+					services.Logger.Error("failed to publish: %q", err)
+					continue
+				}
+			default:
+				return fmt.Errorf("action %q not found", automation.Action)
+			}
+		}
+		if err := markAsRemediated(ctx, computeInstanceScanner.ComputeInstanceScanner.GetFinding().GetName(), computeInstanceScanner.ComputeInstanceScanner.GetFinding().GetEventTime(), services); err != nil {
+			return err
+		}
+	case "http_load_balancer", "weak_ssl_policy":
+		automations := services.Configuration.Spec.Parameters.SHA.HTTPLoadBalancer
+		if name == "weak_ssl_policy" {
+			automations = services.Configuration.Spec.Parameters.SHA.WeakSSLPolicy
+		}
+		computeInstanceScanner, err := computeinstancescanner.New(values.Finding)
+		if err != nil {
+			return invalidFinding(err)
+		}
+		securityMarks := computeInstanceScanner.ComputeInstanceScanner.GetFinding().GetSecurityMarks().GetMarks()
+		remediated := securityMarks[originalEventTime] == computeInstanceScanner.ComputeInstanceScanner.GetFinding().GetEventTime()
+		if remediated {
+			log.Printf("finding already remediated")
+			return nil
+		}
+		log.Printf("got rule %q with %d automations", name, len(automations))
+		for _, automation := range automations {
+			switch automation.Action {
+			case "enforce_https":
+				values := computeInstanceScanner.EnforceHTTPS()
+				values.DryRun = automation.Properties.DryRun
+				values.SSLPolicy = automation.Properties.EnforceHTTPS.SSLPolicy
+				values.RedirectHTTP = automation.Properties.EnforceHTTPS.RedirectHTTP
+				topic := topics[automation.Action].Topic
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
+					services.Logger.Error("failed to publish: %q", err)
+					continue
+				}
+			default:
+				return fmt.Errorf("action %q not found", automation.Action)
+			}
+		}
+		if err := markAsRemediated(ctx, computeInstanceScanner.ComputeInstanceScanner.GetFinding().GetName(), computeInstanceScanner.ComputeInstanceScanner.GetFinding().GetEventTime(), services); err != nil {
+			return err
+		}
+	case "open_firewall":
+		automations := services.Configuration.Spec.Parameters.SHA.OpenFirewall
+		firewallScanner, err := firewallscanner.New(values.Finding)
+		if err != nil {
+			return invalidFinding(err)
+		}
+		securityMarks := firewallScanner.FirewallScanner.GetFinding().GetSecurityMarks().GetMarks()
+		remediated := securityMarks[originalEventTime] == firewallScanner.FirewallScanner.GetFinding().GetEventTime()
+		if remediated {
+			log.Printf("finding already remediated")
+			return nil
+		}
+		log.Printf("got rule %q with %d automations", name, len(automations))
+		for _, automation := range automations {
+			switch automation.Action {
+			case "remediate_firewall":
+				values := firewallScanner.OpenFirewall()
+				values.DryRun = automation.Properties.DryRun
+				values.TTL = automation.Properties.TTL
+				values.SourceRanges = automation.Properties.OpenFirewall.SourceRanges
+				values.Action = automation.Properties.OpenFirewall.RemediationAction
+				topic := topics[automation.Action].Topic
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
+					services.Logger.Error("failed to publish: %q", err)
+					continue
+				}
+			case "disable_unused_firewall":
+				values := firewallScanner.DisableUnusedFirewall()
+				values.DryRun = automation.Properties.DryRun
+				values.Subtypes = automation.Properties.FirewallInsights.Subtypes
+				topic := topics[automation.Action].Topic
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
+					services.Logger.Error("failed to publish: %q", err)
+					continue
+				}
+			default:
+				return fmt.Errorf("action %q not found", automation.Action)
+			}
+		}
+		if err := markAsRemediated(ctx, firewallScanner.FirewallScanner.GetFinding().GetName(), firewallScanner.FirewallScanner.GetFinding().GetEventTime(), services); err != nil {
+			return err
+		}
+	case "open_ssh_port":
+		automations := services.Configuration.Spec.Parameters.SHA.OpenFirewall
+		firewallScanner, err := firewallscanner.New(values.Finding)
+		if err != nil {
+			return invalidFinding(err)
+		}
+		securityMarks := firewallScanner.FirewallScanner.GetFinding().GetSecurityMarks().GetMarks()
+		remediated := securityMarks[originalEventTime] == firewallScanner.FirewallScanner.GetFinding().GetEventTime()
+		if remediated {
+			log.Printf("finding already remediated")
+			return nil
+		}
+		log.Printf("got rule %q with %d automations", name, len(automations))
+		for _, automation := range automations {
+			switch automation.Action {
+			case "remediate_firewall":
+				values := firewallScanner.OpenFirewall()
+				values.DryRun = automation.Properties.DryRun
+				values.TTL = automation.Properties.TTL
+				values.SourceRanges = automation.Properties.OpenFirewall.SourceRanges
+				values.Action = automation.Properties.OpenFirewall.RemediationAction
+				topic := topics[automation.Action].Topic
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
+					services.Logger.Error("failed to publish: %q", err)
+					continue
+				}
+			case "disable_unused_firewall":
+				values := firewallScanner.DisableUnusedFirewall()
+				values.DryRun = automation.Properties.DryRun
+				values.Subtypes = automation.Properties.FirewallInsights.Subtypes
+				topic := topics[automation.Action].Topic
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
+					services.Logger.Error("failed to publish: %q", err)
+					continue
+				}
+			default:
+				return fmt.Errorf("action %q not found", automation.Action)
+			}
+		}
+		if err := markAsRemediated(ctx, firewallScanner.FirewallScanner.GetFinding().GetName(), firewallScanner.FirewallScanner.GetFinding().GetEventTime(), services); err != nil {
+			return err
+		}
+	case "open_rdp_port":
+		automations := services.Configuration.Spec.Parameters.SHA.OpenFirewall
+		firewallScanner, err := firewallscanner.New(values.Finding)
+		if err != nil {
+			return invalidFinding(err)
+		}
+		securityMarks := firewallScanner.FirewallScanner.GetFinding().GetSecurityMarks().GetMarks()
+		remediated := securityMarks[originalEventTime] == firewallScanner.FirewallScanner.GetFinding().GetEventTime()
+		if remediated {
+			log.Printf("finding already remediated")
+			return nil
+		}
+		log.Printf("got rule %q with %d automations", name, len(automations))
+		for _, automation := range automations {
+			switch automation.Action {
+			case "remediate_firewall":
+				values := firewallScanner.OpenFirewall()
+				values.DryRun = automation.Properties.DryRun
+				values.TTL = automation.Properties.TTL
+				values.SourceRanges = automation.Properties.OpenFirewall.SourceRanges
+				values.Action = automation.Properties.OpenFirewall.RemediationAction
+				topic := topics[automation.Action].Topic
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
+					services.Logger.Error("failed to publish: %q", err)
+					continue
+				}
+			case "disable_unused_firewall":
+				values := firewallScanner.DisableUnusedFirewall()
+				values.DryRun = automation.Properties.DryRun
+				values.Subtypes = automation.Properties.FirewallInsights.Subtypes
+				topic := topics[automation.Action].Topic
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
+					services.Logger.Error("failed to publish: %q", err)
+					continue
+				}
+			default:
+				return fmt.Errorf("action %q not found", automation.Action)
+			}
+		}
+		if err := markAsRemediated(ctx, firewallScanner.FirewallScanner.GetFinding().GetName(), firewallScanner.FirewallScanner.GetFinding().GetEventTime(), services); err != nil {
+			return err
+		}
+	case "public_dataset":
+		automations := services.Configuration.Spec.Parameters.SHA.PublicDataset
+		publicDataset, err := datasetscanner.New(values.Finding)
+		if err != nil {
+			return invalidFinding(err)
+		}
+		securityMarks := publicDataset.DatasetScanner.GetFinding().GetSecurityMarks().GetMarks()
+		remediated := securityMarks[originalEventTime] == publicDataset.DatasetScanner.GetFinding().GetEventTime()
+		if remediated {
+			log.Printf("finding already remediated")
+			return nil
+		}
+		log.Printf("got rule %q with %d automations", name, len(automations))
+		for _, automation := range automations {
+			switch automation.Action {
+			case "close_public_dataset":
+				values := publicDataset.ClosePublicDataset()
+				values.DryRun = automation.Properties.DryRun
+				topic := topics[automation.Action].Topic
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
+					services.Logger.Error("failed to publish: %q", err)
+					continue
+				}
+			default:
+				return fmt.Errorf("action %q not found", automation.Action)
+			}
+		}
+		if err := markAsRemediated(ctx, publicDataset.DatasetScanner.GetFinding().GetName(), publicDataset.DatasetScanner.GetFinding().GetEventTime(), services); err != nil {
+			return err
+		}
+	case "bigquery_table_cmek_disabled", "dataset_cmek_disabled":
+		automations := services.Configuration.Spec.Parameters.SHA.TableCMEKDisabled
+		if name == "dataset_cmek_disabled" {
+			automations = services.Configuration.Spec.Parameters.SHA.DatasetCMEKDisabled
+		}
+		datasetScanner, err := datasetscanner.New(values.Finding)
+		if err != nil {
+			return invalidFinding(err)
+		}
+		securityMarks := datasetScanner.DatasetScanner.GetFinding().GetSecurityMarks().GetMarks()
+		remediated := securityMarks[originalEventTime] == datasetScanner.DatasetScanner.GetFinding().GetEventTime()
+		if remediated {
+			log.Printf("finding already remediated")
+			return nil
+		}
+		log.Printf("got rule %q with %d automations", name, len(automations))
+		for _, automation := range automations {
+			switch automation.Action {
+			case "enforce_cmek":
+				values := datasetScanner.EnforceCMEK()
+				values.DryRun = automation.Properties.DryRun
+				values.KeyName = automation.Properties.EnforceCMEK.KMSKey
+				topic := topics[automation.Action].Topic
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
+					services.Logger.Error("failed to publish: %q", err)
+					continue
+				}
+			default:
+				return fmt.Errorf("action %q not found", automation.Action)
+			}
+		}
+		if err := markAsRemediated(ctx, datasetScanner.DatasetScanner.GetFinding().GetName(), datasetScanner.DatasetScanner.GetFinding().GetEventTime(), services); err != nil {
 			return err
 		}
 	case "audit_logging_disabled":
 		automations := services.Configuration.Spec.Parameters.SHA.AuditLoggingDisabled
 		loggingScanner, err := loggingscanner.New(values.Finding)
 		if err != nil {
+			return invalidFinding(err)
+		}
+		securityMarks := loggingScanner.Loggingscanner.GetFinding().GetSecurityMarks().GetMarks()
+		remediated := securityMarks[originalEventTime] == loggingScanner.Loggingscanner.GetFinding().GetEventTime()
+		if remediated {
+			log.Printf("finding already remediated")
+			return nil
+		}
+		log.Printf("got rule %q with %d automations", name, len(automations))
+		for _, automation := range automations {
+			switch automation.Action {
+			case "enable_audit_logs":
+				values := loggingScanner.EnableAuditLogs()
+				values.DryRun = automation.Properties.DryRun
+				topic := topics[automation.Action].Topic
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
+					services.Logger.Error("failed to publish: %q", err)
+					continue
+				}
+			default:
+				return fmt.Errorf("action %q not found", automation.Action)
+			}
+		}
+		if err := markAsRemediated(ctx, loggingScanner.Loggingscanner.GetFinding().GetName(), loggingScanner.Loggingscanner.GetFinding().GetEventTime(), services); err != nil {
+			return err
+		}
+	case "web_ui_enabled":
+		automations := services.Configuration.Spec.Parameters.SHA.WebUIEnabled
+		containerScanner, err := containerscanner.New(values.Finding)
+		if err != nil {
+			return invalidFinding(err)
+		}
+		securityMarks := containerScanner.Containerscanner.GetFinding().GetSecurityMarks().GetMarks()
+		remediated := securityMarks[originalEventTime] == containerScanner.Containerscanner.GetFinding().GetEventTime()
+		if remediated {
+			log.Printf("finding already remediated")
+			return nil
+		}
+		log.Printf("got rule %q with %d automations", name, len(automations))
+		for _, automation := range automations {
+			switch automation.Action {
+			case "disable_dashboard":
+				values := containerScanner.DisableDashboard()
+				values.DryRun = automation.Properties.DryRun
+				topic := topics[automation.Action].Topic
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
+					services.Logger.Error("failed to publish: %q", err)
+					continue
+				}
+			default:
+				return fmt.Errorf("action %q not found", automation.Action)
+			}
+		}
+		if err := markAsRemediated(ctx, containerScanner.Containerscanner.GetFinding().GetName(), containerScanner.Containerscanner.GetFinding().GetEventTime(), services); err != nil {
+			return err
+		}
+	case "network_policy_disabled", "private_cluster_disabled":
+		automations := services.Configuration.Spec.Parameters.SHA.NetworkPolicyDisabled
+		if name == "private_cluster_disabled" {
+			automations = services.Configuration.Spec.Parameters.SHA.PrivateClusterDisabled
+		}
+		containerScanner, err := containerscanner.New(values.Finding)
+		if err != nil {
+			return invalidFinding(err)
+		}
+		securityMarks := containerScanner.Containerscanner.GetFinding().GetSecurityMarks().GetMarks()
+		remediated := securityMarks[originalEventTime] == containerScanner.Containerscanner.GetFinding().GetEventTime()
+		if remediated {
+			log.Printf("finding already remediated")
+			return nil
+		}
+		log.Printf("got rule %q with %d automations", name, len(automations))
+		for _, automation := range automations {
+			switch automation.Action {
+			case "harden_cluster_network":
+				values := containerScanner.HardenClusterNetwork()
+				values.DryRun = automation.Properties.DryRun
+				values.EnableNetworkPolicy = name == "network_policy_disabled"
+				values.PrivateNodes = name == "private_cluster_disabled"
+				values.AuthorizedNetworks = automation.Properties.HardenClusterNetwork.AuthorizedNetworks
+				topic := topics[automation.Action].Topic
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
+					services.Logger.Error("failed to publish: %q", err)
+					continue
+				}
+			default:
+				return fmt.Errorf("action %q not found", automation.Action)
+			}
+		}
+		if err := markAsRemediated(ctx, containerScanner.Containerscanner.GetFinding().GetName(), containerScanner.Containerscanner.GetFinding().GetEventTime(), services); err != nil {
+			return err
+		}
+	case "non_org_iam_member":
+		automations := services.Configuration.Spec.Parameters.SHA.NonOrgMembers
+		iamScanner, err := iamscanner.New(values.Finding)
+		if err != nil {
+			return invalidFinding(err)
+		}
+		securityMarks := iamScanner.IAMScanner.GetFinding().GetSecurityMarks().GetMarks()
+		remediated := securityMarks[originalEventTime] == iamScanner.IAMScanner.GetFinding().GetEventTime()
+		if remediated {
+			log.Printf("finding already remediated")
+			return nil
+		}
+		log.Printf("got rule %q with %d automations", name, len(automations))
+		for _, automation := range automations {
+			switch automation.Action {
+			case "remove_non_org_members":
+				values := iamScanner.RemoveNonOrgMembers()
+				values.DryRun = automation.Properties.DryRun
+				values.AllowDomains = automation.Properties.NonOrgMembers.AllowDomains
+				values.AllowMembers = automation.Properties.NonOrgMembers.AllowMembers
+				topic := topics[automation.Action].Topic
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
+					services.Logger.Error("failed to publish: %q", err)
+					continue
+				}
+			case "remove_external_group_members":
+				values := iamScanner.RemoveExternalGroupMembers()
+				values.DryRun = automation.Properties.DryRun
+				values.AllowDomains = automation.Properties.RemoveGroupMembers.AllowDomains
+				values.AllowMembers = automation.Properties.RemoveGroupMembers.AllowMembers
+				topic := topics[automation.Action].Topic
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
+					services.Logger.Error("failed to publish: %q", err)
+					continue
+				}
+			default:
+				return fmt.Errorf("action %q not found", automation.Action)
+			}
+		}
+		if err := markAsRemediated(ctx, iamScanner.IAMScanner.GetFinding().GetName(), iamScanner.IAMScanner.GetFinding().GetEventTime(), services); err != nil {
+			return err
+		}
+	case "sensitive_data":
+		automations := services.Configuration.Spec.Parameters.DLP.SensitiveData
+		sensitiveData, err := sensitivedata.New(values.Finding)
+		if err != nil {
+			return invalidFinding(err)
+		}
+		securityMarks := sensitiveData.SensitiveData.GetFinding().GetSecurityMarks().GetMarks()
+		remediated := securityMarks[originalEventTime] == sensitiveData.SensitiveData.GetFinding().GetEventTime()
+		if remediated {
+			log.Printf("finding already remediated")
+			return nil
+		}
+		log.Printf("got rule %q with %d automations", name, len(automations))
+		for _, automation := range automations {
+			switch automation.Action {
+			case "restrict_sensitive_data":
+				values := sensitiveData.RestrictSensitiveData()
+				values.DryRun = automation.Properties.DryRun
+				topic := topics[automation.Action].Topic
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
+					services.Logger.Error("failed to publish: %q", err)
+					continue
+				}
+			default:
+				return fmt.Errorf("action %q not found", automation.Action)
+			}
+		}
+		if err := markAsRemediated(ctx, sensitiveData.SensitiveData.GetFinding().GetName(), sensitiveData.SensitiveData.GetFinding().GetEventTime(), services); err != nil {
 			return err
 		}
-		securityMarks := loggingScanner.Loggingscanner.GetFinding().GetSecurityMarks().GetMarks()
-		remediated := securityMarks[originalEventTime] == loggingScanner.Loggingscanner.GetFinding().GetEventTime()
-		if remediated {
-			log.Printf("finding already remediated")
-			return nil
+	case "bucket_violation":
+		automations := services.Configuration.Spec.Parameters.Forseti.BucketViolation
+		v, err := violation.New(values.Finding)
+		if err != nil {
+			return invalidFinding(err)
+		}
+		log.Printf("got rule %q with %d automations", name, len(automations))
+		for _, automation := range automations {
+			switch automation.Action {
+			case "close_bucket":
+				values := v.CloseBucket()
+				values.DryRun = automation.Properties.DryRun
+				topic := topics[automation.Action].Topic
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
+					services.Logger.Error("failed to publish: %q", err)
+					continue
+				}
+			default:
+				return fmt.Errorf("action %q not found", automation.Action)
+			}
+		}
+	case "iam_policy_violation":
+		automations := services.Configuration.Spec.Parameters.Forseti.IAMPolicyViolation
+		v, err := violation.New(values.Finding)
+		if err != nil {
+			return invalidFinding(err)
+		}
+		log.Printf("got rule %q with %d automations", name, len(automations))
+		for _, automation := range automations {
+			switch automation.Action {
+			case "iam_revoke":
+				values := v.IAMRevoke()
+				values.DryRun = automation.Properties.DryRun
+				values.AllowDomains = automation.Properties.RevokeIAM.AllowDomains
+				values.AllowMembers = automation.Properties.RevokeIAM.AllowMembers
+				values.Preflight = automation.Properties.RevokeIAM.Preflight
+				topic := topics[automation.Action].Topic
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
+					services.Logger.Error("failed to publish: %q", err)
+					continue
+				}
+			default:
+				return fmt.Errorf("action %q not found", automation.Action)
+			}
+		}
+	case "firewall_violation":
+		automations := services.Configuration.Spec.Parameters.Forseti.FirewallViolation
+		v, err := violation.New(values.Finding)
+		if err != nil {
+			return invalidFinding(err)
+		}
+		log.Printf("got rule %q with %d automations", name, len(automations))
+		for _, automation := range automations {
+			switch automation.Action {
+			case "remediate_firewall":
+				values := v.OpenFirewall()
+				values.DryRun = automation.Properties.DryRun
+				values.TTL = automation.Properties.TTL
+				values.SourceRanges = automation.Properties.OpenFirewall.SourceRanges
+				values.Action = automation.Properties.OpenFirewall.RemediationAction
+				topic := topics[automation.Action].Topic
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
+					services.Logger.Error("failed to publish: %q", err)
+					continue
+				}
+			default:
+				return fmt.Errorf("action %q not found", automation.Action)
+			}
+		}
+	case "siem_compromised_instance":
+		automations := services.Configuration.Spec.Parameters.SIEM.CompromisedInstance
+		siemAlert, err := alert.New(values.Finding)
+		if err != nil {
+			return invalidFinding(err)
+		}
+		log.Printf("got rule %q with %d automations", name, len(automations))
+		for _, automation := range automations {
+			switch automation.Action {
+			case "gce_create_disk_snapshot":
+				values := siemAlert.CreateSnapshot()
+				values.DryRun = automation.Properties.DryRun
+				values.Output = automation.Properties.CreateSnapshot.Output
+				values.DestProjectID = automation.Properties.CreateSnapshot.TargetSnapshotProjectID
+				values.DestZone = automation.Properties.CreateSnapshot.TargetSnapshotZone
+				values.Turbinia.ProjectID = automation.Properties.CreateSnapshot.Turbinia.ProjectID
+				values.Turbinia.Topic = automation.Properties.CreateSnapshot.Turbinia.Topic
+				values.Turbinia.Zone = automation.Properties.CreateSnapshot.Turbinia.Zone
+				values.AnalysisVM.ProjectID = automation.Properties.CreateSnapshot.AnalysisVM.ProjectID
+				values.AnalysisVM.Zone = automation.Properties.CreateSnapshot.AnalysisVM.Zone
+				values.AnalysisVM.MachineType = automation.Properties.CreateSnapshot.AnalysisVM.MachineType
+				values.AnalysisVM.Network = automation.Properties.CreateSnapshot.AnalysisVM.Network
+				values.AnalysisVM.Image = automation.Properties.CreateSnapshot.AnalysisVM.Image
+				values.AnalysisVM.Topic = automation.Properties.CreateSnapshot.AnalysisVM.Topic
+				topic := topics[automation.Action].Topic
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
+					services.Logger.Error("failed to publish: %q", err)
+					continue
+				}
+			case "remove_public_ip":
+				values := siemAlert.RemovePublicIP()
+				values.DryRun = automation.Properties.DryRun
+				values.TTL = automation.Properties.TTL
+				values.EvidenceBucket = automation.Properties.CollectEvidence.Bucket
+				topic := topics[automation.Action].Topic
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
+					services.Logger.Error("failed to publish: %q", err)
+					continue
+				}
+			case "remove_load_balancer":
+				values := siemAlert.RemoveLoadBalancer()
+				values.DryRun = automation.Properties.DryRun
+				values.Mode = automation.Properties.RemoveLoadBalancer.Mode
+				values.QuarantineBackendService = automation.Properties.RemoveLoadBalancer.QuarantineBackendService
+				topic := topics[automation.Action].Topic
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
+					services.Logger.Error("failed to publish: %q", err)
+					continue
+				}
+			case "block_egress":
+				values := siemAlert.BlockEgress()
+				values.DryRun = automation.Properties.DryRun
+				values.TTL = automation.Properties.TTL
+				values.Scope = automation.Properties.BlockEgress.Scope
+				values.DestinationRanges = automation.Properties.BlockEgress.DestinationRanges
+				topic := topics[automation.Action].Topic
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
+					services.Logger.Error("failed to publish: %q", err)
+					continue
+				}
+			case "lockdown_project":
+				values := siemAlert.LockdownProject()
+				values.DryRun = automation.Properties.DryRun
+				values.BreakGlassGroup = automation.Properties.LockdownProject.BreakGlassGroup
+				values.AllowMembers = automation.Properties.LockdownProject.AllowMembers
+				topic := topics[automation.Action].Topic
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
+					services.Logger.Error("failed to publish: %q", err)
+					continue
+				}
+			default:
+				return fmt.Errorf("action %q not found", automation.Action)
+			}
+		}
+	case "siem_compromised_project":
+		automations := services.Configuration.Spec.Parameters.SIEM.CompromisedProject
+		siemAlert, err := alert.New(values.Finding)
+		if err != nil {
+			return invalidFinding(err)
 		}
 		log.Printf("got rule %q with %d automations", name, len(automations))
 		for _, automation := range automations {
 			switch automation.Action {
-			case "enable_audit_logs":
-				values := loggingScanner.EnableAuditLogs()
+			case "lockdown_project":
+				values := siemAlert.LockdownProject()
 				values.DryRun = automation.Properties.DryRun
+				values.BreakGlassGroup = automation.Properties.LockdownProject.BreakGlassGroup
+				values.AllowMembers = automation.Properties.LockdownProject.AllowMembers
 				topic := topics[automation.Action].Topic
-				if err := publish(ctx, services, automation.Action, topic, values.ProjectID, automation.Target, automation.Exclude, values); err != nil {
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
 					services.Logger.Error("failed to publish: %q", err)
 					continue
 				}
@@ -604,29 +1725,88 @@ func Execute(ctx context.Context, values *Values, services *Services) error {
 				return fmt.Errorf("action %q not found", automation.Action)
 			}
 		}
-		if err := markAsRemediated(ctx, loggingScanner.Loggingscanner.GetFinding().GetName(), loggingScanner.Loggingscanner.GetFinding().GetEventTime(), services); err != nil {
-			return err
+	case "siem_data_exfiltration":
+		automations := services.Configuration.Spec.Parameters.SIEM.DataExfiltration
+		siemAlert, err := alert.New(values.Finding)
+		if err != nil {
+			return invalidFinding(err)
 		}
-	case "web_ui_enabled":
-		automations := services.Configuration.Spec.Parameters.SHA.WebUIEnabled
-		containerScanner, err := containerscanner.New(values.Finding)
+		log.Printf("got rule %q with %d automations", name, len(automations))
+		for _, automation := range automations {
+			switch automation.Action {
+			case "block_egress":
+				values := siemAlert.BlockEgress()
+				values.DryRun = automation.Properties.DryRun
+				values.TTL = automation.Properties.TTL
+				values.Scope = automation.Properties.BlockEgress.Scope
+				values.DestinationRanges = automation.Properties.BlockEgress.DestinationRanges
+				topic := topics[automation.Action].Topic
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
+					services.Logger.Error("failed to publish: %q", err)
+					continue
+				}
+			case "remove_public_ip":
+				values := siemAlert.RemovePublicIP()
+				values.DryRun = automation.Properties.DryRun
+				values.TTL = automation.Properties.TTL
+				values.EvidenceBucket = automation.Properties.CollectEvidence.Bucket
+				topic := topics[automation.Action].Topic
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
+					services.Logger.Error("failed to publish: %q", err)
+					continue
+				}
+			default:
+				return fmt.Errorf("action %q not found", automation.Action)
+			}
+		}
+	case "siem_external_member":
+		automations := services.Configuration.Spec.Parameters.SIEM.ExternalMember
+		siemAlert, err := alert.New(values.Finding)
 		if err != nil {
-			return err
+			return invalidFinding(err)
 		}
-		securityMarks := containerScanner.Containerscanner.GetFinding().GetSecurityMarks().GetMarks()
-		remediated := securityMarks[originalEventTime] == containerScanner.Containerscanner.GetFinding().GetEventTime()
-		if remediated {
-			log.Printf("finding already remediated")
-			return nil
+		log.Printf("got rule %q with %d automations", name, len(automations))
+		for _, automation := range automations {
+			switch automation.Action {
+			case "iam_revoke":
+				values := siemAlert.IAMRevoke()
+				values.DryRun = automation.Properties.DryRun
+				values.AllowDomains = automation.Properties.RevokeIAM.AllowDomains
+				values.AllowMembers = automation.Properties.RevokeIAM.AllowMembers
+				values.Preflight = automation.Properties.RevokeIAM.Preflight
+				topic := topics[automation.Action].Topic
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
+					services.Logger.Error("failed to publish: %q", err)
+					continue
+				}
+			case "remove_external_group_members":
+				values := siemAlert.RemoveExternalGroupMembers()
+				values.DryRun = automation.Properties.DryRun
+				values.AllowDomains = automation.Properties.RemoveGroupMembers.AllowDomains
+				values.AllowMembers = automation.Properties.RemoveGroupMembers.AllowMembers
+				topic := topics[automation.Action].Topic
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
+					services.Logger.Error("failed to publish: %q", err)
+					continue
+				}
+			default:
+				return fmt.Errorf("action %q not found", automation.Action)
+			}
+		}
+	case "siem_public_bucket":
+		automations := services.Configuration.Spec.Parameters.SIEM.PublicBucket
+		siemAlert, err := alert.New(values.Finding)
+		if err != nil {
+			return invalidFinding(err)
 		}
 		log.Printf("got rule %q with %d automations", name, len(automations))
 		for _, automation := range automations {
 			switch automation.Action {
-			case "disable_dashboard":
-				values := containerScanner.DisableDashboard()
+			case "close_bucket":
+				values := siemAlert.CloseBucket()
 				values.DryRun = automation.Properties.DryRun
 				topic := topics[automation.Action].Topic
-				if err := publish(ctx, services, automation.Action, topic, values.ProjectID, automation.Target, automation.Exclude, values); err != nil {
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
 					services.Logger.Error("failed to publish: %q", err)
 					continue
 				}
@@ -634,30 +1814,77 @@ func Execute(ctx context.Context, values *Values, services *Services) error {
 				return fmt.Errorf("action %q not found", automation.Action)
 			}
 		}
-		if err := markAsRemediated(ctx, containerScanner.Containerscanner.GetFinding().GetName(), containerScanner.Containerscanner.GetFinding().GetEventTime(), services); err != nil {
-			return err
+	case "siem_open_firewall":
+		automations := services.Configuration.Spec.Parameters.SIEM.OpenFirewall
+		siemAlert, err := alert.New(values.Finding)
+		if err != nil {
+			return invalidFinding(err)
 		}
-	case "non_org_iam_member":
-		automations := services.Configuration.Spec.Parameters.SHA.NonOrgMembers
-		iamScanner, err := iamscanner.New(values.Finding)
+		log.Printf("got rule %q with %d automations", name, len(automations))
+		for _, automation := range automations {
+			switch automation.Action {
+			case "remediate_firewall":
+				values := siemAlert.OpenFirewall()
+				values.DryRun = automation.Properties.DryRun
+				values.TTL = automation.Properties.TTL
+				values.SourceRanges = automation.Properties.OpenFirewall.SourceRanges
+				values.Action = automation.Properties.OpenFirewall.RemediationAction
+				topic := topics[automation.Action].Topic
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
+					services.Logger.Error("failed to publish: %q", err)
+					continue
+				}
+			default:
+				return fmt.Errorf("action %q not found", automation.Action)
+			}
+		}
+	case "siem_key_misuse":
+		automations := services.Configuration.Spec.Parameters.SIEM.KeyMisuse
+		siemAlert, err := alert.New(values.Finding)
 		if err != nil {
-			return err
+			return invalidFinding(err)
 		}
-		securityMarks := iamScanner.IAMScanner.GetFinding().GetSecurityMarks().GetMarks()
-		remediated := securityMarks[originalEventTime] == iamScanner.IAMScanner.GetFinding().GetEventTime()
-		if remediated {
-			log.Printf("finding already remediated")
-			return nil
+		log.Printf("got rule %q with %d automations", name, len(automations))
+		for _, automation := range automations {
+			switch automation.Action {
+			case "disable_key_version":
+				values := siemAlert.DisableKeyVersion()
+				values.DryRun = automation.Properties.DryRun
+				values.BreakGlassGroup = automation.Properties.DisableKeyVersion.BreakGlassGroup
+				topic := topics[automation.Action].Topic
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
+					services.Logger.Error("failed to publish: %q", err)
+					continue
+				}
+			default:
+				return fmt.Errorf("action %q not found", automation.Action)
+			}
+		}
+	case "siem_rdp_brute_force":
+		automations := services.Configuration.Spec.Parameters.SIEM.RDPBruteForce
+		siemAlert, err := alert.New(values.Finding)
+		if err != nil {
+			return invalidFinding(err)
 		}
 		log.Printf("got rule %q with %d automations", name, len(automations))
 		for _, automation := range automations {
 			switch automation.Action {
-			case "remove_non_org_members":
-				values := iamScanner.RemoveNonOrgMembers()
+			case "remediate_firewall":
+				values := siemAlert.BlockRDP()
 				values.DryRun = automation.Properties.DryRun
-				values.AllowDomains = automation.Properties.NonOrgMembers.AllowDomains
+				values.TTL = automation.Properties.TTL
+				topic := topics[automation.Action].Topic
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
+					services.Logger.Error("failed to publish: %q", err)
+					continue
+				}
+			case "remove_public_ip":
+				values := siemAlert.RemovePublicIP()
+				values.DryRun = automation.Properties.DryRun
+				values.TTL = automation.Properties.TTL
+				values.EvidenceBucket = automation.Properties.CollectEvidence.Bucket
 				topic := topics[automation.Action].Topic
-				if err := publish(ctx, services, automation.Action, topic, values.ProjectID, automation.Target, automation.Exclude, values); err != nil {
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
 					services.Logger.Error("failed to publish: %q", err)
 					continue
 				}
@@ -665,30 +1892,222 @@ func Execute(ctx context.Context, values *Values, services *Services) error {
 				return fmt.Errorf("action %q not found", automation.Action)
 			}
 		}
-		if err := markAsRemediated(ctx, iamScanner.IAMScanner.GetFinding().GetName(), iamScanner.IAMScanner.GetFinding().GetEventTime(), services); err != nil {
-			return err
+	case "siem_public_managed_cluster":
+		automations := services.Configuration.Spec.Parameters.SIEM.PublicManagedCluster
+		siemAlert, err := alert.New(values.Finding)
+		if err != nil {
+			return invalidFinding(err)
+		}
+		log.Printf("got rule %q with %d automations", name, len(automations))
+		for _, automation := range automations {
+			switch automation.Action {
+			case "secure_managed_cluster":
+				values := siemAlert.SecureManagedCluster()
+				values.DryRun = automation.Properties.DryRun
+				values.AuthorizedNetworks = automation.Properties.SecureManagedCluster.AuthorizedNetworks
+				topic := topics[automation.Action].Topic
+				if err := publish(ctx, services, automation, topic, values.ProjectID, meta, values); err != nil {
+					services.Logger.Error("failed to publish: %q", err)
+					continue
+				}
+			default:
+				return fmt.Errorf("action %q not found", automation.Action)
+			}
 		}
-
 	default:
-		return fmt.Errorf("rule %q not found", name)
+		return invalidFinding(fmt.Errorf("rule %q not found", name))
 	}
 	return nil
 }
 
-func publish(ctx context.Context, services *Services, action, topic, projectID string, target, exclude []string, values interface{}) error {
-	ok, err := services.Resource.CheckMatches(ctx, projectID, target, exclude)
+// invalidFinding classifies err as caused by a finding that can't be routed. Retrying such findings
+// won't succeed so their messages are acknowledged.
+func invalidFinding(err error) error {
+	return services.InvalidFinding(err)
+}
+
+// isServiceAccount returns whether the email is that of a service account.
+func isServiceAccount(email string) bool {
+	return services.IsServiceAccount(email)
+}
+
+// startSpan starts a span of the router, see services.StartSpan. Automations exempted from a
+// resource don't mark their span as failed.
+func startSpan(ctx context.Context, name string, kv ...string) (context.Context, func(error)) {
+	ctx, end := services.StartSpan(ctx, name, kv...)
+	return ctx, func(err error) {
+		if services.IsExempted(err) {
+			err = nil
+		}
+		end(err)
+	}
+}
+
+// injectSpan adds the span of ctx to the attributes of the message sent to an automation so its
+// function continues the trace of the finding.
+func injectSpan(ctx context.Context, attrs map[string]string) map[string]string {
+	return services.InjectSpan(ctx, attrs)
+}
+
+// exempted classifies err as caused by a resource excluded from an automation.
+func exempted(err error) error {
+	return services.Exempted(err)
+}
+
+func publish(ctx context.Context, services *Services, automation Automation, topic, projectID string, meta metadata, values interface{}) error {
+	ctx, end := startSpan(ctx, "publish "+automation.Action, "sra.action", automation.Action, "sra.resource", "projects/"+projectID)
+	err := publishProject(ctx, services, automation, topic, projectID, meta, values)
+	simulated(ctx, automation, "projects/"+projectID, values, err)
+	end(err)
+	return err
+}
+
+func publishProject(ctx context.Context, services *Services, automation Automation, topic, projectID string, meta metadata, values interface{}) error {
+	action := automation.Action
+	ok, err := inScope(ctx, services, automation, "projects/"+projectID)
 	if err != nil {
 		return errors.Wrapf(err, "failed to check if project %q is within the target or is excluded", projectID)
 	}
 	if !ok {
-		return fmt.Errorf("project %q is not within the target or is excluded", projectID)
+		return exempted(fmt.Errorf("project %q is not within the target or is excluded", projectID))
+	}
+	attrs := attributes(automation, meta)
+	if ok, err := checkCondition(ctx, services, automation, "projects/"+projectID, meta, topic, attrs, values); !ok || err != nil {
+		return err
+	}
+	if err := checkPermissions(ctx, services, action, projectID); err != nil {
+		return err
+	}
+	if err := rollout(ctx, services, automation, "projects/"+projectID, attrs, values); err != nil {
+		return err
+	}
+	if ok, err := stale(ctx, services, automation, "projects/"+projectID, projectID, meta, values); ok || err != nil {
+		return err
+	}
+	if ok, err := freeze(ctx, services, automation, "projects/"+projectID, projectID, attrs, values); ok || err != nil {
+		return err
+	}
+	if ok, err := hold(ctx, "projects/"+projectID, action, topic, attrs, values, ""); ok || err != nil {
+		return err
+	}
+	if s := simulating(ctx); s != nil {
+		s.record(automation, "projects/"+projectID, OutcomeRun, nil, values)
+		return nil
+	}
+	return publishValues(ctx, services, action, topic, attrs, values)
+}
+
+// inScope returns whether the project, folder or organization resource is within the automation's
+// target and not excluded. If the automation sets target or exclude tags the resource's tags are
+// also matched: it's in scope if its ancestry or tags match a target and neither matches an
+// exclusion.
+func inScope(ctx context.Context, services *Services, automation Automation, resource string) (bool, error) {
+	if len(automation.TargetTags) == 0 && len(automation.ExcludeTags) == 0 {
+		return ancestryMatches(ctx, services, resource, automation.Target, automation.Exclude)
+	}
+	if services.Tags == nil {
+		return false, errors.New("no tags service to match target_tags and exclude_tags")
+	}
+	tags, err := services.Tags.EffectiveTags(ctx, resource)
+	if err != nil {
+		return false, err
+	}
+	if tagsMatch(automation.ExcludeTags, tags) {
+		return false, nil
+	}
+	if ok, err := ancestryMatches(ctx, services, resource, automation.Target, automation.Exclude); ok || err != nil {
+		return ok, err
+	}
+	if !tagsMatch(automation.TargetTags, tags) {
+		return false, nil
+	}
+	// A tagged resource is still excluded by its ancestry.
+	excluded, err := ancestryMatches(ctx, services, resource, automation.Exclude, nil)
+	return !excluded, err
+}
+
+// ancestryMatches returns whether the resource's ancestry matches the target and not ignore.
+func ancestryMatches(ctx context.Context, services *Services, resource string, target, ignore []string) (bool, error) {
+	if strings.HasPrefix(resource, "projects/") {
+		return services.Resource.CheckMatches(ctx, strings.TrimPrefix(resource, "projects/"), target, ignore)
+	}
+	return services.Resource.CheckResourceMatches(ctx, resource, target, ignore)
+}
+
+// tagsMatch returns whether any of the tags matches one of the patterns.
+func tagsMatch(patterns, tags []string) bool {
+	return services.TagsMatch(patterns, tags)
+}
+
+// checkPermissions returns an error if the service account lacks permissions the action needs on
+// the project, recording the missing permissions so they're granted rather than failing the
+// automation part way through. The automation is still run if the permissions can't be tested.
+func checkPermissions(ctx context.Context, services *Services, action, projectID string) error {
+	missing, err := services.Resource.MissingPermissions(ctx, projectID, deployments[action].Permissions)
+	if err != nil {
+		services.Logger.Warning("failed to test permissions of %q on project %q: %q", action, projectID, err)
+		return nil
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	services.Logger.MissingPermissions(action, "projects/"+projectID, missing)
+	return permissionDenied(fmt.Errorf("missing permissions %s on project %q needed by %q", strings.Join(missing, ", "), projectID, action))
+}
+
+func permissionDenied(err error) error {
+	return services.PermissionDenied(err)
+}
+
+// publishResource is like publish for automations acting on an organization or folder resource.
+func publishResource(ctx context.Context, services *Services, automation Automation, topic, resource string, meta metadata, values interface{}) error {
+	ctx, end := startSpan(ctx, "publish "+automation.Action, "sra.action", automation.Action, "sra.resource", resource)
+	err := publishToResource(ctx, services, automation, topic, resource, meta, values)
+	simulated(ctx, automation, resource, values, err)
+	end(err)
+	return err
+}
+
+func publishToResource(ctx context.Context, services *Services, automation Automation, topic, resource string, meta metadata, values interface{}) error {
+	action := automation.Action
+	ok, err := inScope(ctx, services, automation, resource)
+	if err != nil {
+		return errors.Wrapf(err, "failed to check if %q is within the target or is excluded", resource)
+	}
+	if !ok {
+		return exempted(fmt.Errorf("%q is not within the target or is excluded", resource))
+	}
+	attrs := attributes(automation, meta)
+	if ok, err := checkCondition(ctx, services, automation, resource, meta, topic, attrs, values); !ok || err != nil {
+		return err
+	}
+	if err := rollout(ctx, services, automation, resource, attrs, values); err != nil {
+		return err
+	}
+	if ok, err := stale(ctx, services, automation, resource, "", meta, values); ok || err != nil {
+		return err
+	}
+	if ok, err := freeze(ctx, services, automation, resource, "", attrs, values); ok || err != nil {
+		return err
 	}
+	if ok, err := hold(ctx, resource, action, topic, attrs, values, ""); ok || err != nil {
+		return err
+	}
+	if s := simulating(ctx); s != nil {
+		s.record(automation, resource, OutcomeRun, nil, values)
+		return nil
+	}
+	return publishValues(ctx, services, action, topic, attrs, values)
+}
+
+func publishValues(ctx context.Context, services *Services, action, topic string, attrs map[string]string, values interface{}) error {
 	b, err := json.Marshal(&values)
 	if err != nil {
 		return errors.Wrapf(err, "failed to marshal when running %q", action)
 	}
 	if _, err := services.PubSub.Publish(ctx, topic, &pubsub.Message{
-		Data: b,
+		Data:       b,
+		Attributes: injectSpan(ctx, attrs),
 	}); err != nil {
 		services.Logger.Error("failed to publish to %q for action %q", topic, action)
 		return err
@@ -696,3 +2115,114 @@ func publish(ctx context.Context, services *Services, action, topic, projectID s
 	log.Printf("sent to pubsub topic: %q", topic)
 	return nil
 }
+
+// metadata holds the values common to findings forwarded to automations as message attributes.
+type metadata struct {
+	// Name is the Security Command Center name of the finding.
+	Name string
+	// Severity is the upper cased severity of the finding.
+	Severity string
+	// Rule is the name of the rule the finding was routed by, i.e. "bad_ip".
+	Rule string
+	// Resource is the Security Command Center resource name of the finding.
+	Resource string
+	// IPs are the public IP addresses seen in the finding, sorted.
+	IPs []string
+	// EventTime is when the finding was detected, zero if unknown.
+	EventTime time.Time
+	// CorrelationID traces the remediation of the finding, if set.
+	CorrelationID string
+	// Replay is true if the finding is replayed or caught up on rather than newly detected.
+	Replay bool
+}
+
+// findingMetadata returns the name, resource and severity of the finding. Either are empty if the finding
+// does not have one, Forseti violations and SIEM alerts do not carry a severity and only findings
+// from Security Command Center notifications have a name.
+func findingMetadata(b []byte) metadata {
+	var f struct {
+		Finding struct {
+			Name             string
+			ResourceName     string `json:"resourceName"`
+			Severity         string
+			EventTime        string `json:"eventTime"`
+			SourceProperties struct {
+				SeverityLevel string
+			} `json:"sourceProperties"`
+		}
+		JSONPayload struct {
+			DetectionPriority string `json:"detectionPriority"`
+		} `json:"jsonPayload"`
+		// Timestamp is the time of the log entry of Event Threat Detection findings.
+		Timestamp string
+		SIEMAlert struct {
+			EventTime string `json:"eventTime"`
+		} `json:"siemAlert"`
+		DetectionTime string `json:"detectionTime"`
+	}
+	if err := json.Unmarshal(b, &f); err != nil {
+		return metadata{}
+	}
+	meta := metadata{Name: f.Finding.Name, Resource: f.Finding.ResourceName, IPs: findingIPs(b)}
+	for _, s := range []string{f.Finding.Severity, f.Finding.SourceProperties.SeverityLevel, f.JSONPayload.DetectionPriority} {
+		if s != "" && s != "SEVERITY_UNSPECIFIED" {
+			meta.Severity = strings.ToUpper(s)
+			break
+		}
+	}
+	for _, s := range []string{f.Finding.EventTime, f.Timestamp, f.SIEMAlert.EventTime, f.DetectionTime} {
+		if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+			meta.EventTime = t
+			break
+		}
+	}
+	return meta
+}
+
+// attributes returns the message attributes sent along with the automation's values. Recipients
+// are only included if the automation emails findings of this severity, or of any severity if
+// none are configured.
+func attributes(automation Automation, meta metadata) map[string]string {
+	attrs := map[string]string{}
+	if meta.Name != "" {
+		attrs[FindingAttribute] = meta.Name
+	}
+	if meta.Severity != "" {
+		attrs[SeverityAttribute] = meta.Severity
+	}
+	if meta.Rule != "" {
+		attrs[CategoryAttribute] = meta.Rule
+	}
+	if meta.Resource != "" {
+		attrs[ResourceAttribute] = meta.Resource
+	}
+	if len(meta.IPs) > 0 {
+		attrs[IPsAttribute] = strings.Join(meta.IPs, ",")
+	}
+	if meta.CorrelationID != "" {
+		attrs[CorrelationAttribute] = meta.CorrelationID
+	}
+	email := automation.Properties.Notify.Email
+	if len(email.To) > 0 && matchesSeverity(email.Severities, meta.Severity) {
+		attrs[EmailAttribute] = strings.Join(email.To, ",")
+	}
+	if automation.Playbook != "" {
+		attrs[PlaybookAttribute] = automation.Playbook
+	}
+	if len(attrs) == 0 {
+		return nil
+	}
+	return attrs
+}
+
+func matchesSeverity(severities []string, severity string) bool {
+	if len(severities) == 0 {
+		return true
+	}
+	for _, s := range severities {
+		if strings.EqualFold(s, severity) {
+			return true
+		}
+	}
+	return false
+}
@@ -17,16 +17,34 @@ package router
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
 	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/bigquery/closepublicdataset"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/containment/lockdownproject"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/dlp/restrictsensitivedata"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gce/blockegress"
 	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gce/createsnapshot"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gce/disableipforwarding"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gce/enforcehttps"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gce/hardeninstance"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gce/openfirewall"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gce/removepublicip"
 	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gcs/closebucket"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gcs/enablebucketlogging"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gke/hardenclusternetwork"
 	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/iam/enableauditlogs"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/iam/quarantineserviceaccount"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/iam/removegroupmembers"
 	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/iam/removenonorgmembers"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/iam/revoke"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/iam/revokeorgmembers"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/kms/disablekeyversion"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/kms/enforcecmek"
 	"github.com/googlecloudplatform/security-response-automation/services"
+	crm "google.golang.org/api/cloudresourcemanager/v1"
 )
 
 func TestRouter(t *testing.T) {
@@ -105,7 +123,69 @@ func TestRouter(t *testing.T) {
 				"createTime": "2019-09-23T17:20:27.934Z"
 			}
 		}`
-		validPublicDataset = `{
+		validBucketLoggingDisabled = `{
+			"notificationConfigName": "organizations/154584661726/notificationConfigs/sampleConfigId",
+			"finding": {
+				"name": "organizations/154584661726/sources/2673592633662526977/findings/0b1c3f52631d61da6117a3772137c270",
+				"parent": "organizations/154584661726/sources/2673592633662526977",
+				"resourceName": "//storage.googleapis.com/bucket-without-logging",
+				"state": "ACTIVE",
+				"category": "BUCKET_LOGGING_DISABLED",
+				"externalUri": "https://console.cloud.google.com/storage/browser/bucket-without-logging",
+				"sourceProperties": {
+					"ReactivationCount": 0.0,
+					"SeverityLevel": "Low",
+					"ProjectId": "test-project",
+					"ScannerName": "STORAGE_SCANNER",
+					"ScanRunId": "2019-09-23T10:20:27.204-07:00",
+					"Explanation": "There is a storage bucket that does not have logging enabled."
+				},
+				"securityMarks": {
+					"name": "organizations/154584661726/sources/2673592633662526977/findings/0b1c3f52631d61da6117a3772137c270/securityMarks"
+				},
+				"eventTime": "2019-09-23T17:20:27.204Z",
+				"createTime": "2019-09-23T17:20:27.934Z"
+			}
+		}`
+		validVersioningDisabled = `{
+			"notificationConfigName": "organizations/154584661726/notificationConfigs/sampleConfigId",
+			"finding": {
+				"name": "organizations/154584661726/sources/2673592633662526977/findings/5d3e7a1b9c2f4e8a6b0d1c3f5a7e9b2d",
+				"parent": "organizations/154584661726/sources/2673592633662526977",
+				"resourceName": "//storage.googleapis.com/bucket-without-versioning",
+				"state": "ACTIVE",
+				"category": "OBJECT_VERSIONING_DISABLED",
+				"externalUri": "https://console.cloud.google.com/storage/browser/bucket-without-versioning",
+				"sourceProperties": {
+					"ReactivationCount": 0.0,
+					"SeverityLevel": "Low",
+					"ProjectId": "test-project",
+					"ScannerName": "STORAGE_SCANNER",
+					"ScanRunId": "2019-09-23T10:20:27.204-07:00",
+					"Explanation": "There is a storage bucket that does not have object versioning enabled."
+				},
+				"securityMarks": {
+					"name": "organizations/154584661726/sources/2673592633662526977/findings/5d3e7a1b9c2f4e8a6b0d1c3f5a7e9b2d/securityMarks"
+				},
+				"eventTime": "2019-09-23T17:20:27.204Z",
+				"createTime": "2019-09-23T17:20:27.934Z"
+			}
+		}`
+		validForsetiBucketViolation = `{
+			"resource_id": "this-is-public-on-purpose",
+			"resource_type": "bucket",
+			"full_name": "organization/456/folder/123/project/test-project/bucket/this-is-public-on-purpose/",
+			"rule_name": "Bucket acls rule to search for public buckets",
+			"violation_type": "BUCKET_VIOLATION",
+			"violation_data": {"role": "READER", "entity": "allUsers"}
+		}`
+		validSIEMPublicBucket   = `{"siemAlert": {"source": "chronicle", "id": "de_1234", "category": "public_bucket", "resource": {"projectId": "test-project", "bucket": "this-is-public-on-purpose"}}}`
+		validSIEMExternalMember = `{"siemAlert": {"source": "chronicle", "id": "de_5678", "category": "external_member", "resource": {"projectId": "test-project", "members": ["user:eve@gmail.com"]}}}`
+		validSIEMKeyMisuse      = `{"siemAlert": {"source": "chronicle", "id": "de_9012", "category": "key_misuse", "resource": {"projectId": "test-project", "cryptoKeyVersion": "projects/test-project/locations/us/keyRings/sra/cryptoKeys/app/cryptoKeyVersions/2"}}}`
+		validSIEMRDPBruteForce  = `{"siemAlert": {"source": "splunk", "id": "4625-1", "category": "rdp_brute_force", "resource": {"projectId": "test-project", "zone": "us-central1-a", "instance": "windows-1", "sourceIps": ["198.51.100.7"]}}}`
+		validSIEMExfiltration   = `{"siemAlert": {"source": "chronicle", "id": "de_3456", "category": "data_exfiltration", "resource": {"projectId": "test-project", "zone": "us-central1-a", "instance": "db-1"}}}`
+		validSIEMProject        = `{"siemAlert": {"source": "chronicle", "id": "de_7890", "category": "compromised_project", "resource": {"projectId": "test-project"}}}`
+		validPublicDataset      = `{
 			"notificationConfigName": "organizations/154584661726/notificationConfigs/sampleConfigId",
 			"finding": {
 				"name": "organizations/154584661726/sources/7086426792249889955/findings/8682cf07ec50f921172082270bdd96e7",
@@ -186,6 +266,159 @@ func TestRouter(t *testing.T) {
 			"createTime": "2019-10-18T15:31:58.487Z"
            }
 		}`
+		validIPForwardingEnabled = `{
+		"finding": {
+			"name": "organizations/1050000000008/sources/1986930501000008034/findings/5b0b6d3d3f1e4a2f8e8b7c52ad3e4f11",
+			"parent": "organizations/1050000000008/sources/1986930501000008034",
+			"resourceName": "//compute.googleapis.com/projects/test-project/zones/us-central1-a/instances/router-vm",
+			"state": "ACTIVE",
+			"category": "IP_FORWARDING_ENABLED",
+			"sourceProperties": {
+				"ProjectId": "test-project",
+				"ScannerName": "COMPUTE_INSTANCE_SCANNER"
+			},
+			"securityMarks": {
+				"name": "organizations/1050000000008/sources/1986930501000008034/findings/5b0b6d3d3f1e4a2f8e8b7c52ad3e4f11/securityMarks"
+			},
+			"eventTime": "2019-10-18T15:30:22.082Z",
+			"createTime": "2019-10-18T15:31:58.487Z"
+		}
+		}`
+		validShieldedVMDisabled = `{
+		"finding": {
+			"name": "organizations/1050000000008/sources/1986930501000008034/findings/9d2e1f0a4b3c4d5e8f7a6b5c4d3e2f1a",
+			"parent": "organizations/1050000000008/sources/1986930501000008034",
+			"resourceName": "//compute.googleapis.com/projects/test-project/zones/us-central1-a/instances/web-vm",
+			"state": "ACTIVE",
+			"category": "SHIELDED_VM_DISABLED",
+			"sourceProperties": {
+				"ProjectId": "test-project",
+				"ScannerName": "COMPUTE_INSTANCE_SCANNER"
+			},
+			"securityMarks": {
+				"name": "organizations/1050000000008/sources/1986930501000008034/findings/9d2e1f0a4b3c4d5e8f7a6b5c4d3e2f1a/securityMarks"
+			},
+			"eventTime": "2019-10-18T15:30:22.082Z",
+			"createTime": "2019-10-18T15:31:58.487Z"
+		}
+		}`
+		validNetworkPolicyDisabled = `{
+		"finding": {
+			"name": "organizations/1050000000008/sources/1986930501000008034/findings/5a1c2e3f4b6d4c7e9a8b0c1d2e3f4a5b",
+			"parent": "organizations/1050000000008/sources/1986930501000008034",
+			"resourceName": "//container.googleapis.com/projects/test-project/zones/us-central1-a/clusters/web-cluster",
+			"state": "ACTIVE",
+			"category": "NETWORK_POLICY_DISABLED",
+			"sourceProperties": {
+				"ProjectId": "test-project",
+				"ScannerName": "CONTAINER_SCANNER"
+			},
+			"securityMarks": {
+				"name": "organizations/1050000000008/sources/1986930501000008034/findings/5a1c2e3f4b6d4c7e9a8b0c1d2e3f4a5b/securityMarks"
+			},
+			"eventTime": "2019-10-18T15:30:22.082Z",
+			"createTime": "2019-10-18T15:31:58.487Z"
+		}
+		}`
+		validTableCMEKDisabled = `{
+		"finding": {
+			"name": "organizations/1050000000008/sources/1986930501000008034/findings/3e4f5a6b7c8d4e9fa0b1c2d3e4f5a6b7",
+			"parent": "organizations/1050000000008/sources/1986930501000008034",
+			"resourceName": "//bigquery.googleapis.com/projects/test-project/datasets/sales/tables/orders",
+			"state": "ACTIVE",
+			"category": "BIGQUERY_TABLE_CMEK_DISABLED",
+			"sourceProperties": {
+				"ProjectId": "test-project",
+				"ScannerName": "DATASET_SCANNER"
+			},
+			"securityMarks": {
+				"name": "organizations/1050000000008/sources/1986930501000008034/findings/3e4f5a6b7c8d4e9fa0b1c2d3e4f5a6b7/securityMarks"
+			},
+			"eventTime": "2019-10-18T15:30:22.082Z",
+			"createTime": "2019-10-18T15:31:58.487Z"
+		}
+		}`
+		validWeakSSLPolicy = `{
+		"finding": {
+			"name": "organizations/1050000000008/sources/1986930501000008034/findings/8c1d0e4f5a6b4c7d9e0f1a2b3c4d5e6f",
+			"parent": "organizations/1050000000008/sources/1986930501000008034",
+			"resourceName": "//compute.googleapis.com/projects/test-project/global/targetHttpsProxies/web-proxy",
+			"state": "ACTIVE",
+			"category": "WEAK_SSL_POLICY",
+			"sourceProperties": {
+				"ProjectId": "test-project",
+				"ScannerName": "COMPUTE_INSTANCE_SCANNER"
+			},
+			"securityMarks": {
+				"name": "organizations/1050000000008/sources/1986930501000008034/findings/8c1d0e4f5a6b4c7d9e0f1a2b3c4d5e6f/securityMarks"
+			},
+			"eventTime": "2019-10-18T15:30:22.082Z",
+			"createTime": "2019-10-18T15:31:58.487Z"
+		}
+		}`
+		validCryptomining = `{
+			"jsonPayload": {
+				"properties": {
+					"instanceDetails": "/projects/test-project/zones/zone-name/instances/miner",
+					"network": {
+						"project": "test-project"
+					},
+					"domain": ["pool.example.com"]
+				},
+				"detectionCategory": {
+					"ruleName": "cryptomining"
+				}
+			},
+			"logName": "projects/test-project/logs/threatdetection.googleapis.com` + "%%2F" + `detection"
+		}`
+		validNewGeography = `{
+			"jsonPayload": {
+				"properties": {
+					"principalEmail": "john.doe@gmail.com",
+					"callerIp": "198.51.100.4",
+					"anomalousLocation": "AQ"
+				},
+				"detectionCategory": {
+					"ruleName": "new_geography"
+				},
+				"evidence": [{"sourceLogId": {"projectId": "test-project"}}]
+			},
+			"logName": "projects/test-project/logs/threatdetection.googleapis.com` + "%%2F" + `detection"
+		}`
+		validSensitiveData = `{
+			"notificationConfigName": "organizations/456/notificationConfigs/noticonf-active-001-id",
+			"finding": {
+				"name": "organizations/456/sources/2810934752948494317/findings/0f61d9c3a2e84a4b",
+				"resourceName": "//storage.googleapis.com/customer-exports",
+				"state": "ACTIVE",
+				"category": "EMAIL_ADDRESS",
+				"eventTime": "2020-03-01T18:40:22.538Z",
+				"sourceProperties": {
+					"projectId": "test-project",
+					"infoType": "EMAIL_ADDRESS"
+				}
+			}
+		}`
+		validOrganizationAnomalousIAM = `{
+			"notificationConfigName": "organizations/456/notificationConfigs/noticonf-active-001-id",
+			"finding": {
+				"name": "organizations/456/sources/0000000000000000000/findings/6a30ce604c11417995b1fa260753f3b5",
+				"resourceName": "//cloudresourcemanager.googleapis.com/organizations/456",
+				"state": "ACTIVE",
+				"category": "Persistence: IAM Anomalous Grant",
+				"sourceProperties": {
+					"detectionCategory": {
+						"ruleName": "iam_anomalous_grant"
+					},
+					"evidence": [{"sourceLogId": {"projectId": "test-project"}}],
+					"properties": {
+						"sensitiveRoleGrant": {
+							"members": ["user:john.doe@gmail.com"]
+						}
+					}
+				}
+			}
+		}`
 	)
 	conf := &Configuration{}
 	// BadIP findings should map to "gce_create_disk_snapshot".
@@ -209,6 +442,25 @@ func TestRouter(t *testing.T) {
 	}
 	sccCreateSnapshot, _ := json.Marshal(sccCreateSnapshotValues)
 
+	conf.Spec.Parameters.ETD.Cryptomining = []Automation{
+		{Action: "remove_public_ip", Target: []string{"organizations/456/folders/123/projects/test-project"}},
+	}
+	removePublicIPValues := &removepublicip.Values{
+		ProjectID:    "test-project",
+		InstanceZone: "zone-name",
+		InstanceID:   "miner",
+	}
+	removePublicIP, _ := json.Marshal(removePublicIPValues)
+
+	conf.Spec.Parameters.ETD.NewGeography = []Automation{
+		{Action: "iam_revoke", Target: []string{"organizations/456/folders/123/projects/test-project"}},
+	}
+	revokeValues := &revoke.Values{
+		ProjectID:       "test-project",
+		ExternalMembers: []string{"user:john.doe@gmail.com"},
+	}
+	revokeMembers, _ := json.Marshal(revokeValues)
+
 	conf.Spec.Parameters.SHA.PublicBucketACL = []Automation{
 		{Action: "close_bucket", Target: []string{"organizations/456/folders/123/projects/test-project"}},
 	}
@@ -219,6 +471,102 @@ func TestRouter(t *testing.T) {
 	}
 	closeBucket, _ := json.Marshal(closeBucketValues)
 
+	conf.Spec.Parameters.SHA.BucketLoggingDisabled = []Automation{
+		{Action: "enable_bucket_logging", Target: []string{"organizations/456/folders/123/projects/test-project"}},
+	}
+	conf.Spec.Parameters.SHA.BucketLoggingDisabled[0].Properties.EnableBucketLogging.LogBucket = "access-logs"
+	enableBucketLoggingValues := &enablebucketlogging.Values{
+		ProjectID:  "test-project",
+		BucketName: "bucket-without-logging",
+		LogBucket:  "access-logs",
+		DryRun:     false,
+	}
+	enableBucketLogging, _ := json.Marshal(enableBucketLoggingValues)
+
+	conf.Spec.Parameters.SHA.VersioningDisabled = []Automation{
+		{Action: "enable_bucket_logging", Target: []string{"organizations/456/folders/123/projects/test-project"}},
+	}
+	enableBucketVersioningValues := &enablebucketlogging.Values{
+		ProjectID:        "test-project",
+		BucketName:       "bucket-without-versioning",
+		EnableVersioning: true,
+	}
+	enableBucketVersioning, _ := json.Marshal(enableBucketVersioningValues)
+
+	conf.Spec.Parameters.Forseti.BucketViolation = []Automation{
+		{Action: "close_bucket", Target: []string{"organizations/456/folders/123/projects/test-project"}},
+	}
+
+	conf.Spec.Parameters.SIEM.PublicBucket = []Automation{
+		{Action: "close_bucket", Target: []string{"organizations/456/folders/123/projects/test-project"}},
+	}
+
+	conf.Spec.Parameters.SIEM.ExternalMember = []Automation{
+		{Action: "remove_external_group_members", Target: []string{"organizations/456/folders/123/projects/test-project"}},
+	}
+	conf.Spec.Parameters.SIEM.ExternalMember[0].Properties.RemoveGroupMembers.AllowDomains = []string{"foo.com"}
+	removeGroupMembersValues := &removegroupmembers.Values{
+		ProjectID:    "test-project",
+		AllowDomains: []string{"foo.com"},
+	}
+	removeGroupMembers, _ := json.Marshal(removeGroupMembersValues)
+
+	conf.Spec.Parameters.SIEM.KeyMisuse = []Automation{
+		{Action: "disable_key_version", Target: []string{"organizations/456/folders/123/projects/test-project"}},
+	}
+	conf.Spec.Parameters.SIEM.KeyMisuse[0].Properties.DisableKeyVersion.BreakGlassGroup = "break-glass@foo.com"
+	disableKeyVersionValues := &disablekeyversion.Values{
+		ProjectID:       "test-project",
+		KeyVersion:      "projects/test-project/locations/us/keyRings/sra/cryptoKeys/app/cryptoKeyVersions/2",
+		BreakGlassGroup: "break-glass@foo.com",
+	}
+	disableKeyVersion, _ := json.Marshal(disableKeyVersionValues)
+
+	conf.Spec.Parameters.SIEM.RDPBruteForce = []Automation{
+		{Action: "remediate_firewall", Target: []string{"organizations/456/folders/123/projects/test-project"}},
+	}
+	conf.Spec.Parameters.SIEM.RDPBruteForce[0].Properties.TTL = "24h"
+	blockRDPValues := &openfirewall.Values{
+		Action:       "block_rdp",
+		ProjectID:    "test-project",
+		SourceRanges: []string{"198.51.100.7/32"},
+		TTL:          "24h",
+	}
+	blockRDP, _ := json.Marshal(blockRDPValues)
+
+	conf.Spec.Parameters.SIEM.DataExfiltration = []Automation{
+		{Action: "block_egress", Target: []string{"organizations/456/folders/123/projects/test-project"}},
+	}
+	conf.Spec.Parameters.SIEM.DataExfiltration[0].Properties.BlockEgress.Scope = "service_account"
+	blockEgressValues := &blockegress.Values{
+		ProjectID: "test-project",
+		Zone:      "us-central1-a",
+		Instance:  "db-1",
+		Scope:     "service_account",
+	}
+	blockEgress, _ := json.Marshal(blockEgressValues)
+
+	conf.Spec.Parameters.SIEM.CompromisedProject = []Automation{
+		{Action: "lockdown_project", Target: []string{"organizations/456/folders/123/projects/test-project"}},
+	}
+	conf.Spec.Parameters.SIEM.CompromisedProject[0].Properties.LockdownProject.BreakGlassGroup = "break-glass@foo.com"
+	lockdownProjectValues := &lockdownproject.Values{
+		ProjectID:       "test-project",
+		BreakGlassGroup: "break-glass@foo.com",
+	}
+	lockdownProject, _ := json.Marshal(lockdownProjectValues)
+
+	conf.Spec.Parameters.ETD.AnomalousIAM = []Automation{
+		{Action: "iam_revoke_org", Target: []string{"organizations/456"}},
+	}
+	conf.Spec.Parameters.ETD.AnomalousIAM[0].Properties.RevokeIAM.AllowDomains = []string{"foo.com"}
+	revokeOrgMembersValues := &revokeorgmembers.Values{
+		Resource:        "organizations/456",
+		ExternalMembers: []string{"user:john.doe@gmail.com"},
+		AllowDomains:    []string{"foo.com"},
+	}
+	revokeOrgMembers, _ := json.Marshal(revokeOrgMembersValues)
+
 	crmStub := &stubs.ResourceManagerStub{}
 	storageStub := &stubs.StorageStub{}
 	ancestryResponse := services.CreateAncestors([]string{"project/test-project", "folder/123", "organization/456"})
@@ -254,6 +602,76 @@ func TestRouter(t *testing.T) {
 	}
 	removeNonOrgMembers, _ := json.Marshal(removeNonOrgMembersValues)
 
+	conf.Spec.Parameters.SHA.IPForwardingEnabled = []Automation{
+		{Action: "disable_ip_forwarding", Target: []string{"organizations/456/folders/123/projects/test-project"}},
+	}
+	disableIPForwardingValues := &disableipforwarding.Values{
+		ProjectID:    "test-project",
+		InstanceZone: "us-central1-a",
+		InstanceID:   "router-vm",
+	}
+	disableIPForwarding, _ := json.Marshal(disableIPForwardingValues)
+
+	conf.Spec.Parameters.SHA.ShieldedVMDisabled = []Automation{
+		{Action: "harden_instance", Target: []string{"organizations/456/folders/123/projects/test-project"}},
+	}
+	conf.Spec.Parameters.SHA.ShieldedVMDisabled[0].Properties.HardenInstance.Restart = true
+	hardenInstanceValues := &hardeninstance.Values{
+		ProjectID:        "test-project",
+		InstanceZone:     "us-central1-a",
+		InstanceID:       "web-vm",
+		EnableShieldedVM: true,
+		Restart:          true,
+	}
+	hardenInstance, _ := json.Marshal(hardenInstanceValues)
+
+	conf.Spec.Parameters.SHA.NetworkPolicyDisabled = []Automation{
+		{Action: "harden_cluster_network", Target: []string{"organizations/456/folders/123/projects/test-project"}},
+	}
+	conf.Spec.Parameters.SHA.NetworkPolicyDisabled[0].Properties.HardenClusterNetwork.AuthorizedNetworks = []string{"10.0.0.0/8"}
+	hardenClusterNetworkValues := &hardenclusternetwork.Values{
+		ProjectID:           "test-project",
+		Zone:                "us-central1-a",
+		ClusterID:           "web-cluster",
+		EnableNetworkPolicy: true,
+		AuthorizedNetworks:  []string{"10.0.0.0/8"},
+	}
+	hardenClusterNetwork, _ := json.Marshal(hardenClusterNetworkValues)
+
+	conf.Spec.Parameters.SHA.TableCMEKDisabled = []Automation{
+		{Action: "enforce_cmek", Target: []string{"organizations/456/folders/123/projects/test-project"}},
+	}
+	conf.Spec.Parameters.SHA.TableCMEKDisabled[0].Properties.EnforceCMEK.KMSKey = "projects/kms/locations/us/keyRings/sra/cryptoKeys/default"
+	enforceCMEKValues := &enforcecmek.Values{
+		ProjectID: "test-project",
+		DatasetID: "sales",
+		TableID:   "orders",
+		KeyName:   "projects/kms/locations/us/keyRings/sra/cryptoKeys/default",
+	}
+	enforceCMEK, _ := json.Marshal(enforceCMEKValues)
+
+	conf.Spec.Parameters.SHA.WeakSSLPolicy = []Automation{
+		{Action: "enforce_https", Target: []string{"organizations/456/folders/123/projects/test-project"}},
+	}
+	conf.Spec.Parameters.SHA.WeakSSLPolicy[0].Properties.EnforceHTTPS.SSLPolicy = "tls-1-2"
+	enforceHTTPSValues := &enforcehttps.Values{
+		ProjectID: "test-project",
+		ProxyType: "targetHttpsProxies",
+		Proxy:     "web-proxy",
+		SSLPolicy: "tls-1-2",
+	}
+	enforceHTTPS, _ := json.Marshal(enforceHTTPSValues)
+
+	conf.Spec.Parameters.DLP.SensitiveData = []Automation{
+		{Action: "restrict_sensitive_data", Target: []string{"organizations/456/folders/123/projects/test-project"}},
+	}
+	restrictSensitiveDataValues := &restrictsensitivedata.Values{
+		ProjectID: "test-project",
+		Bucket:    "customer-exports",
+		InfoType:  "EMAIL_ADDRESS",
+	}
+	restrictSensitiveData, _ := json.Marshal(restrictSensitiveDataValues)
+
 	for _, tt := range []struct {
 		name    string
 		mapTo   []byte
@@ -261,10 +679,28 @@ func TestRouter(t *testing.T) {
 	}{
 		{name: "bad_ip", finding: []byte(validBadIP), mapTo: createSnapshot},
 		{name: "bad_ip_scc", finding: []byte(validBadIPSCC), mapTo: sccCreateSnapshot},
+		{name: "cryptomining", finding: []byte(validCryptomining), mapTo: removePublicIP},
+		{name: "new_geography", finding: []byte(validNewGeography), mapTo: revokeMembers},
 		{name: "public_bucket_acl", finding: []byte(validPublicBucket), mapTo: closeBucket},
+		{name: "bucket_logging_disabled", finding: []byte(validBucketLoggingDisabled), mapTo: enableBucketLogging},
+		{name: "object_versioning_disabled", finding: []byte(validVersioningDisabled), mapTo: enableBucketVersioning},
+		{name: "forseti_bucket_violation", finding: []byte(validForsetiBucketViolation), mapTo: closeBucket},
+		{name: "siem_public_bucket", finding: []byte(validSIEMPublicBucket), mapTo: closeBucket},
+		{name: "siem_external_member", finding: []byte(validSIEMExternalMember), mapTo: removeGroupMembers},
+		{name: "siem_key_misuse", finding: []byte(validSIEMKeyMisuse), mapTo: disableKeyVersion},
+		{name: "siem_rdp_brute_force", finding: []byte(validSIEMRDPBruteForce), mapTo: blockRDP},
+		{name: "siem_data_exfiltration", finding: []byte(validSIEMExfiltration), mapTo: blockEgress},
+		{name: "siem_compromised_project", finding: []byte(validSIEMProject), mapTo: lockdownProject},
 		{name: "public_dataset", finding: []byte(validPublicDataset), mapTo: closePublicDataset},
 		{name: "audit_logging_disabled", finding: []byte(validAuditLogDisabled), mapTo: enableAuditLog},
 		{name: "non_org_members", finding: []byte(validNonOrgMembers), mapTo: removeNonOrgMembers},
+		{name: "ip_forwarding_enabled", finding: []byte(validIPForwardingEnabled), mapTo: disableIPForwarding},
+		{name: "shielded_vm_disabled", finding: []byte(validShieldedVMDisabled), mapTo: hardenInstance},
+		{name: "network_policy_disabled", finding: []byte(validNetworkPolicyDisabled), mapTo: hardenClusterNetwork},
+		{name: "bigquery_table_cmek_disabled", finding: []byte(validTableCMEKDisabled), mapTo: enforceCMEK},
+		{name: "weak_ssl_policy", finding: []byte(validWeakSSLPolicy), mapTo: enforceHTTPS},
+		{name: "organization_anomalous_iam", finding: []byte(validOrganizationAnomalousIAM), mapTo: revokeOrgMembers},
+		{name: "sensitive_data", finding: []byte(validSensitiveData), mapTo: restrictSensitiveData},
 	} {
 		ctx := context.Background()
 		psStub := &stubs.PubSubStub{}
@@ -486,3 +922,248 @@ func TestRemediated(t *testing.T) {
 		})
 	}
 }
+
+func TestAttributes(t *testing.T) {
+	const (
+		shaFinding = `{"finding": {"name": "organizations/1/sources/2/findings/3", "resourceName": "//storage.googleapis.com/bucket", "sourceProperties": {"SeverityLevel": "High"}}}`
+		etdFinding = `{"jsonPayload": {"detectionPriority": "LOW", "properties": {"ip": ["203.0.113.9", "10.0.0.1"]}}}`
+		siemAlert  = `{"siemAlert": {"category": "public_bucket"}}`
+	)
+	for _, tt := range []struct {
+		name          string
+		finding       string
+		to            []string
+		severities    []string
+		correlationID string
+		expected      map[string]string
+	}{
+		{
+			name:       "matching severity",
+			finding:    shaFinding,
+			to:         []string{"soc@example.com", "team@example.com"},
+			severities: []string{"high", "critical"},
+			expected: map[string]string{
+				FindingAttribute:  "organizations/1/sources/2/findings/3",
+				ResourceAttribute: "//storage.googleapis.com/bucket",
+				SeverityAttribute: "HIGH",
+				EmailAttribute:    "soc@example.com,team@example.com",
+			},
+		},
+		{
+			name:       "other severity",
+			finding:    etdFinding,
+			to:         []string{"soc@example.com"},
+			severities: []string{"HIGH"},
+			expected:   map[string]string{SeverityAttribute: "LOW", IPsAttribute: "203.0.113.9"},
+		},
+		{
+			name:     "any severity",
+			finding:  siemAlert,
+			to:       []string{"soc@example.com"},
+			expected: map[string]string{EmailAttribute: "soc@example.com"},
+		},
+		{
+			name:    "no recipients",
+			finding: siemAlert,
+		},
+		{
+			name:          "correlated",
+			finding:       siemAlert,
+			correlationID: "5f0c6b9e-correlation",
+			expected:      map[string]string{CorrelationAttribute: "5f0c6b9e-correlation"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var automation Automation
+			automation.Properties.Notify.Email.To = tt.to
+			automation.Properties.Notify.Email.Severities = tt.severities
+			meta := findingMetadata([]byte(tt.finding))
+			meta.CorrelationID = tt.correlationID
+			attrs := attributes(automation, meta)
+			if diff := cmp.Diff(tt.expected, attrs); diff != "" {
+				t.Errorf("%q failed, difference:%+v", tt.name, diff)
+			}
+		})
+	}
+}
+
+func TestMissingPermissions(t *testing.T) {
+	const siemAlert = `{"siemAlert": {"source": "chronicle", "id": "de_1234", "category": "public_bucket", "resource": {"projectId": "test-project", "bucket": "this-is-public-on-purpose"}}}`
+	for _, tt := range []struct {
+		name      string
+		denied    []string
+		published bool
+		expected  []interface{}
+	}{
+		{
+			name:      "granted",
+			published: true,
+			expected:  []interface{}{},
+		},
+		{
+			name:   "missing",
+			denied: []string{"storage.buckets.setIamPolicy"},
+			expected: []interface{}{&services.AuditRecord{
+				Action:             "close_bucket",
+				Resource:           "projects/test-project",
+				Result:             services.AuditResultMissingPermissions,
+				Message:            `service account lacks 1 permissions needed by "close_bucket"`,
+				MissingPermissions: []string{"storage.buckets.setIamPolicy"},
+			}},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &Configuration{}
+			conf.Spec.Parameters.SIEM.PublicBucket = []Automation{
+				{Action: "close_bucket", Target: []string{"organizations/456/folders/123/projects/test-project"}},
+			}
+			crmStub := &stubs.ResourceManagerStub{DeniedPermissions: tt.denied}
+			crmStub.GetAncestryResponse = services.CreateAncestors([]string{"project/test-project", "folder/123", "organization/456"})
+			psStub := &stubs.PubSubStub{}
+			loggerStub := &stubs.LoggerStub{AuditRecords: []interface{}{}}
+			if err := Execute(context.Background(), &Values{Finding: []byte(siemAlert)}, &Services{
+				PubSub:                services.NewPubSub(psStub),
+				Logger:                services.NewLogger(loggerStub),
+				Configuration:         conf,
+				Resource:              services.NewResource(crmStub, &stubs.StorageStub{}),
+				SecurityCommandCenter: services.NewCommandCenter(&stubs.SecurityCommandCenterStub{}),
+			}); err != nil {
+				t.Fatalf("%q failed: %q", tt.name, err)
+			}
+			if published := psStub.PublishedMessage != nil; published != tt.published {
+				t.Errorf("%q failed, published %t expected %t", tt.name, published, tt.published)
+			}
+			if diff := cmp.Diff(tt.expected, loggerStub.AuditRecords); diff != "" {
+				t.Errorf("%q failed, difference:%+v", tt.name, diff)
+			}
+		})
+	}
+}
+
+func TestQuarantineServiceAccount(t *testing.T) {
+	const finding = `{
+		"jsonPayload": {
+			"properties": {
+				"principalEmail": %q,
+				"sensitiveRoleGrant": {
+					"members": ["user:john.doe@gmail.com"]
+				}
+			},
+			"detectionCategory": {
+				"ruleName": "iam_anomalous_grant"
+			},
+			"evidence": [{"sourceLogId": {"projectId": "test-project"}}]
+		},
+		"logName": "projects/test-project/logs/threatdetection.googleapis.com%%2Fdetection"
+	}`
+	quarantined, _ := json.Marshal(&quarantineserviceaccount.Values{
+		ProjectID:        "test-project",
+		ServiceAccount:   "sa@test-project.iam.gserviceaccount.com",
+		IncludeAncestors: true,
+	})
+	for _, tt := range []struct {
+		name     string
+		grantor  string
+		expected []byte
+	}{
+		{name: "service account", grantor: "sa@test-project.iam.gserviceaccount.com", expected: quarantined},
+		{name: "user", grantor: "jane@example.com"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &Configuration{}
+			conf.Spec.Parameters.ETD.AnomalousIAM = []Automation{
+				{Action: "quarantine_service_account", Target: []string{"organizations/456/folders/123/projects/test-project"}},
+			}
+			conf.Spec.Parameters.ETD.AnomalousIAM[0].Properties.QuarantineServiceAccount.IncludeAncestors = true
+			crmStub := &stubs.ResourceManagerStub{}
+			crmStub.GetAncestryResponse = services.CreateAncestors([]string{"project/test-project", "folder/123", "organization/456"})
+			psStub := &stubs.PubSubStub{}
+			if err := Execute(context.Background(), &Values{Finding: []byte(fmt.Sprintf(finding, tt.grantor))}, &Services{
+				PubSub:                services.NewPubSub(psStub),
+				Logger:                services.NewLogger(&stubs.LoggerStub{}),
+				Configuration:         conf,
+				Resource:              services.NewResource(crmStub, &stubs.StorageStub{}),
+				SecurityCommandCenter: services.NewCommandCenter(&stubs.SecurityCommandCenterStub{}),
+			}); err != nil {
+				t.Fatalf("%q failed: %q", tt.name, err)
+			}
+			if tt.expected == nil {
+				if psStub.PublishedMessage != nil {
+					t.Errorf("%q failed: should not be published", tt.name)
+				}
+				return
+			}
+			if psStub.PublishedMessage == nil {
+				t.Fatalf("%q failed: not published", tt.name)
+			}
+			if diff := cmp.Diff(tt.expected, psStub.PublishedMessage.Data); diff != "" {
+				t.Errorf("%q failed, difference:%+v", tt.name, diff)
+			}
+		})
+	}
+}
+
+func TestTargetTags(t *testing.T) {
+	const finding = `{
+		"jsonPayload": {
+			"properties": {
+				"principalEmail": "sa@test-project.iam.gserviceaccount.com",
+				"sensitiveRoleGrant": {
+					"members": ["user:john.doe@gmail.com"]
+				}
+			},
+			"detectionCategory": {
+				"ruleName": "iam_anomalous_grant"
+			},
+			"evidence": [{"sourceLogId": {"projectId": "test-project"}}]
+		},
+		"logName": "projects/test-project/logs/threatdetection.googleapis.com%2Fdetection"
+	}`
+	for _, tt := range []struct {
+		name        string
+		target      []string
+		exclude     []string
+		targetTags  []string
+		excludeTags []string
+		published   bool
+	}{
+		{name: "tagged", target: []string{"organizations/456/folders/999/*"}, targetTags: []string{"456/env/prod"}, published: true},
+		{name: "wildcard tag", targetTags: []string{"456/env/*"}, published: true},
+		{name: "not tagged", target: []string{"organizations/456/folders/999/*"}, targetTags: []string{"456/env/dev"}},
+		{name: "excluded tag", target: []string{"organizations/456/folders/123/*"}, excludeTags: []string{"tagValues/1"}},
+		{name: "excluded ancestry", targetTags: []string{"456/env/prod"}, exclude: []string{"organizations/456/folders/123/*"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &Configuration{}
+			conf.Spec.Parameters.ETD.AnomalousIAM = []Automation{{
+				Action:      "quarantine_service_account",
+				Target:      tt.target,
+				Exclude:     tt.exclude,
+				TargetTags:  tt.targetTags,
+				ExcludeTags: tt.excludeTags,
+			}}
+			crmStub := &stubs.ResourceManagerStub{
+				GetProjectResponse: map[string]*crm.Project{"test-project": {ProjectId: "test-project", ProjectNumber: 789}},
+			}
+			crmStub.GetAncestryResponse = services.CreateAncestors([]string{"project/test-project", "folder/123", "organization/456"})
+			tagsStub := &stubs.TagsStub{StubbedTags: map[string][]string{
+				"//cloudresourcemanager.googleapis.com/projects/789": {"tagValues/1", "456/env/prod"},
+			}}
+			r := services.NewResource(crmStub, &stubs.StorageStub{})
+			psStub := &stubs.PubSubStub{}
+			if err := Execute(context.Background(), &Values{Finding: []byte(finding)}, &Services{
+				PubSub:                services.NewPubSub(psStub),
+				Logger:                services.NewLogger(&stubs.LoggerStub{}),
+				Configuration:         conf,
+				Resource:              r,
+				Tags:                  services.NewTags(tagsStub, r),
+				SecurityCommandCenter: services.NewCommandCenter(&stubs.SecurityCommandCenterStub{}),
+			}); err != nil {
+				t.Fatalf("%q failed: %q", tt.name, err)
+			}
+			if published := psStub.PublishedMessage != nil; published != tt.published {
+				t.Errorf("%q failed: published = %v, expected %v", tt.name, published, tt.published)
+			}
+		})
+	}
+}
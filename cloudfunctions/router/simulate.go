@@ -0,0 +1,116 @@
+package router
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/googlecloudplatform/security-response-automation/services"
+)
+
+// Results of an automation in a simulation.
+const (
+	// OutcomeRun is the result of an automation that would have run.
+	OutcomeRun = "run"
+	// OutcomeExempted is the result of an automation not targeting the resource or excluding it.
+	OutcomeExempted = "exempted"
+	// OutcomeSkipped is the result of an automation whose condition does not hold.
+	OutcomeSkipped = "skipped"
+	// OutcomePermissionDenied is the result of an automation the service account lacks permissions for.
+	OutcomePermissionDenied = "permission_denied"
+	// OutcomeFailed is the result of an automation that could not be routed.
+	OutcomeFailed = "failed"
+	// OutcomeFrozen is the result of an automation replaced by a ticket during a freeze.
+	OutcomeFrozen = "frozen"
+	// OutcomeStale is the result of an automation replaced by a ticket as the finding is too old.
+	OutcomeStale = "stale"
+)
+
+// simulationKey is the context key of the simulation a finding is routed by.
+type simulationKey struct{}
+
+// simulation holds the outcomes of the automations of a simulated finding.
+type simulation struct {
+	outcomes []Outcome
+}
+
+// Outcome is what an automation would have done with a finding.
+type Outcome struct {
+	Rule     string `json:"rule"`
+	Action   string `json:"action"`
+	Resource string `json:"resource"`
+	Playbook string `json:"playbook,omitempty"`
+	Result   string `json:"result"`
+	// Reason is why the automation would not have run.
+	Reason string `json:"reason,omitempty"`
+	// Values are the values the automation would have been sent.
+	Values json.RawMessage `json:"values,omitempty"`
+}
+
+// Simulate routes the finding like Execute but returns the outcome of each configured automation
+// instead of running it. Nothing is published, playbooks are not recorded and findings are not
+// marked as remediated. The finding's targets and permissions are still looked up.
+func Simulate(ctx context.Context, values *Values, svcs *Services) ([]Outcome, error) {
+	s := &simulation{}
+	err := route(context.WithValue(ctx, simulationKey{}, s), values, svcs)
+	rule := ruleName(values.Finding)
+	for i := range s.outcomes {
+		s.outcomes[i].Rule = rule
+	}
+	return s.outcomes, err
+}
+
+func simulating(ctx context.Context) *simulation {
+	s, _ := ctx.Value(simulationKey{}).(*simulation)
+	return s
+}
+
+// record adds the automation's outcome to the simulation. The result is derived from err if it's
+// not nil.
+func (s *simulation) record(automation Automation, resource, result string, err error, values interface{}) {
+	outcome := Outcome{Action: automation.Action, Resource: resource, Playbook: automation.Playbook, Result: result}
+	if err != nil {
+		outcome.Reason = err.Error()
+		switch {
+		case services.IsExempted(err):
+			outcome.Result = OutcomeExempted
+		case services.IsPermissionDenied(err):
+			outcome.Result = OutcomePermissionDenied
+		default:
+			outcome.Result = OutcomeFailed
+		}
+	}
+	if b, err := json.Marshal(&values); err == nil {
+		outcome.Values = b
+	}
+	s.outcomes = append(s.outcomes, outcome)
+}
+
+// simulated records the error of an automation if the finding is simulated.
+func simulated(ctx context.Context, automation Automation, resource string, values interface{}, err error) {
+	if s := simulating(ctx); s != nil && err != nil {
+		s.record(automation, resource, "", err, values)
+	}
+}
+
+// ForceDryRun turns on the dry_run property of every automation in the configuration.
+func ForceDryRun(c *Configuration) {
+	for _, r := range rules(c) {
+		for i := range r.automations {
+			r.automations[i].Properties.DryRun = true
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package router
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gce/createsnapshot"
+	"github.com/googlecloudplatform/security-response-automation/services"
+)
+
+func TestSimulate(t *testing.T) {
+	const siemAlert = `{"siemAlert": {"source": "chronicle", "id": "de_1234", "category": "compromised_instance", "resource": {"projectId": "test-project", "zone": "us-central1-a", "instance": "miner"}}}`
+	target := []string{"organizations/456/folders/123/projects/test-project"}
+	conf := &Configuration{}
+	conf.Spec.Parameters.SIEM.CompromisedInstance = []Automation{
+		{Action: "gce_create_disk_snapshot", Target: target, Playbook: "contain"},
+		{Action: "remove_public_ip", Target: target, Playbook: "contain", Condition: `severity == "HIGH"`},
+		{Action: "remove_load_balancer", Target: target, Exclude: target},
+	}
+	snapshot, _ := json.Marshal(&createsnapshot.Values{ProjectID: "test-project", RuleName: "compromised_instance", Instance: "miner", Zone: "us-central1-a"})
+
+	crmStub := &stubs.ResourceManagerStub{}
+	crmStub.GetAncestryResponse = services.CreateAncestors([]string{"project/test-project", "folder/123", "organization/456"})
+	storageStub := &stubs.StorageStub{}
+	psStub := &stubs.PubSubStub{}
+	svcs := &Services{
+		PubSub:                services.NewPubSub(psStub),
+		Logger:                services.NewLogger(&stubs.LoggerStub{}),
+		Configuration:         conf,
+		Resource:              services.NewResource(crmStub, storageStub),
+		SecurityCommandCenter: services.NewCommandCenter(&stubs.SecurityCommandCenterStub{}),
+		State:                 services.NewState(storageStub, "state"),
+	}
+
+	outcomes, err := Simulate(context.Background(), &Values{Finding: []byte(siemAlert)}, svcs)
+	if err != nil {
+		t.Fatalf("failed to simulate finding: %q", err)
+	}
+	expected := []Outcome{
+		{Rule: "siem_compromised_instance", Action: "gce_create_disk_snapshot", Resource: "projects/test-project", Playbook: "contain", Result: OutcomeRun},
+		{Rule: "siem_compromised_instance", Action: "remove_public_ip", Resource: "projects/test-project", Playbook: "contain", Result: OutcomeSkipped},
+		{Rule: "siem_compromised_instance", Action: "remove_load_balancer", Resource: "projects/test-project", Result: OutcomeExempted, Reason: `project "test-project" is not within the target or is excluded`},
+	}
+	if diff := cmp.Diff(expected, outcomes, cmpopts.IgnoreFields(Outcome{}, "Values")); diff != "" {
+		t.Errorf("outcome difference:%+v", diff)
+	}
+	if diff := cmp.Diff(json.RawMessage(snapshot), outcomes[0].Values); diff != "" {
+		t.Errorf("values difference:%+v", diff)
+	}
+	if psStub.PublishedMessage != nil {
+		t.Errorf("message published while simulating: %+v", psStub.PublishedMessage)
+	}
+	if len(storageStub.WrittenObjects) != 0 {
+		t.Errorf("playbook recorded while simulating: %+v", storageStub.WrittenObjects)
+	}
+}
@@ -0,0 +1,96 @@
+package router
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/googlecloudplatform/security-response-automation/services"
+	"github.com/pkg/errors"
+)
+
+// maxFindingAge returns the oldest a finding can be for the automation to remediate it, zero if
+// findings of any age are. The automation's max_finding_age overrides the one of the spec.
+func maxFindingAge(c *Configuration, automation Automation) (time.Duration, error) {
+	age := c.Spec.MaxFindingAge
+	if automation.MaxFindingAge != "" {
+		age = automation.MaxFindingAge
+	}
+	if age == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(age)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid max_finding_age %q", age)
+	}
+	return d, nil
+}
+
+// stale replaces the automation by a ticket if the finding is older than its max_finding_age,
+// such as after a Pub/Sub backlog, so resources aren't changed long after the detection. It runs
+// the automation in dry run mode instead if no ticket topic is configured and returns true if a
+// ticket was opened. Findings without an event time are never stale, nor are replayed and
+// caught up findings as their age is expected.
+func stale(ctx context.Context, svcs *Services, automation Automation, resource, projectID string, meta metadata, values interface{}) (bool, error) {
+	max, err := maxFindingAge(svcs.Configuration, automation)
+	if err != nil || max == 0 || meta.EventTime.IsZero() || meta.Replay {
+		return false, err
+	}
+	age := time.Since(meta.EventTime)
+	if age <= max {
+		return false, nil
+	}
+	age = age.Truncate(time.Second)
+	if svcs.Tickets == nil {
+		svcs.Logger.Error("finding is %s old but no ticket topic is configured, running %q in dry run mode", age, automation.Action)
+		forceDryRun(values)
+		auditStale(ctx, svcs, automation.Action, resource, fmt.Sprintf("finding older than %s downgraded the automation to dry run", max))
+		return false, nil
+	}
+	if s := simulating(ctx); s != nil {
+		s.record(automation, resource, OutcomeStale, nil, values)
+		return true, nil
+	}
+	b, err := json.MarshalIndent(values, "", "  ")
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to marshal when running %q", automation.Action)
+	}
+	if err := svcs.Tickets.Open(ctx, &services.Ticket{
+		Action:      automation.Action,
+		ProjectID:   projectID,
+		Resource:    resource,
+		Title:       fmt.Sprintf("%s held back for a stale finding", automation.Action),
+		Description: fmt.Sprintf("%q was not run on %q because the finding was detected %s ago, more than the max_finding_age of %s. Check the resource still needs the change, the automation would have been run with:\n%s", automation.Action, resource, age, max, b),
+	}); err != nil {
+		return false, err
+	}
+	auditStale(ctx, svcs, automation.Action, resource, fmt.Sprintf("finding older than %s opened a ticket instead of running the automation", max))
+	return true, nil
+}
+
+func auditStale(ctx context.Context, svcs *Services, action, resource, message string) {
+	if simulating(ctx) != nil {
+		return
+	}
+	svcs.Logger.Audit(&services.AuditRecord{
+		Action:   action,
+		Resource: resource,
+		Result:   services.AuditResultStale,
+		Message:  message,
+	})
+}
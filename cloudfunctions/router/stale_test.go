@@ -0,0 +1,110 @@
+package router
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gce/createsnapshot"
+	"github.com/googlecloudplatform/security-response-automation/services"
+)
+
+func TestStale(t *testing.T) {
+	const siemAlert = `{"siemAlert": {"source": "chronicle", "id": "de_1234", "category": "compromised_instance", "eventTime": %q, "resource": {"projectId": "test-project", "zone": "us-central1-a", "instance": "miner"}}}`
+	now := time.Now().UTC()
+	for _, tt := range []struct {
+		name          string
+		eventTime     time.Time
+		maxFindingAge string
+		override      string
+		replay        bool
+		noTickets     bool
+		ticket        bool
+		dryRun        bool
+		message       string
+	}{
+		{name: "fresh finding", eventTime: now.Add(-time.Minute), maxFindingAge: "1h"},
+		{name: "no max age", eventTime: now.Add(-48 * time.Hour)},
+		{name: "no event time", maxFindingAge: "1h"},
+		{name: "stale finding", eventTime: now.Add(-3 * time.Hour), maxFindingAge: "1h", ticket: true, message: "finding older than 1h0m0s opened a ticket instead of running the automation"},
+		{name: "automation override", eventTime: now.Add(-3 * time.Hour), maxFindingAge: "1h", override: "4h"},
+		{name: "replayed finding", eventTime: now.Add(-3 * time.Hour), maxFindingAge: "1h", replay: true},
+		{name: "no ticket topic", eventTime: now.Add(-3 * time.Hour), maxFindingAge: "1h", noTickets: true, dryRun: true, message: "finding older than 1h0m0s downgraded the automation to dry run"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &Configuration{}
+			conf.Spec.MaxFindingAge = tt.maxFindingAge
+			conf.Spec.Parameters.SIEM.CompromisedInstance = []Automation{
+				{Action: "gce_create_disk_snapshot", Target: []string{"organizations/456/folders/123/projects/test-project"}, MaxFindingAge: tt.override},
+			}
+			crmStub := &stubs.ResourceManagerStub{}
+			crmStub.GetAncestryResponse = services.CreateAncestors([]string{"project/test-project", "folder/123", "organization/456"})
+			psStub := &stubs.PubSubStub{}
+			loggerStub := &stubs.LoggerStub{}
+			ps := services.NewPubSub(psStub)
+			svcs := &Services{
+				PubSub:                ps,
+				Logger:                services.NewLogger(loggerStub),
+				Configuration:         conf,
+				Resource:              services.NewResource(crmStub, &stubs.StorageStub{}),
+				SecurityCommandCenter: services.NewCommandCenter(&stubs.SecurityCommandCenterStub{}),
+			}
+			if !tt.noTickets {
+				svcs.Tickets = services.NewTickets(ps, "tickets")
+			}
+			eventTime := ""
+			if !tt.eventTime.IsZero() {
+				eventTime = tt.eventTime.Format(time.RFC3339Nano)
+			}
+			finding := fmt.Sprintf(siemAlert, eventTime)
+			if err := Execute(context.Background(), &Values{Finding: []byte(finding), Replay: tt.replay}, svcs); err != nil {
+				t.Fatalf("%s failed: %q", tt.name, err)
+			}
+			if len(psStub.PublishedMessages) != 1 {
+				t.Fatalf("%s published %d messages want 1", tt.name, len(psStub.PublishedMessages))
+			}
+			m := psStub.PublishedMessages[0]
+			if tt.ticket {
+				var ticket services.Ticket
+				if err := json.Unmarshal(m.Data, &ticket); err != nil || ticket.Action != "gce_create_disk_snapshot" || ticket.ProjectID != "test-project" {
+					t.Errorf("%s got ticket %+v, %v", tt.name, ticket, err)
+				}
+			} else {
+				var values createsnapshot.Values
+				if err := json.Unmarshal(m.Data, &values); err != nil || values.DryRun != tt.dryRun {
+					t.Errorf("%s got values %+v, %v", tt.name, values, err)
+				}
+			}
+			var want []interface{}
+			if tt.message != "" {
+				want = []interface{}{&services.AuditRecord{
+					Action:   "gce_create_disk_snapshot",
+					Resource: "projects/test-project",
+					Result:   services.AuditResultStale,
+					Message:  tt.message,
+				}}
+			}
+			if diff := cmp.Diff(want, loggerStub.AuditRecords); diff != "" {
+				t.Errorf("%s audit records difference:%+v", tt.name, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package router
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/bigquery/closepublicdataset"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/cloud-sql/removepublic"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/cloud-sql/requiressl"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gce/disableipforwarding"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gce/disableserialport"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gce/removepublicip"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gcs/closebucket"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gcs/enablebucketonlypolicy"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gke/disabledashboard"
+	"github.com/googlecloudplatform/security-response-automation/services"
+	"github.com/pkg/errors"
+)
+
+const (
+	// SweepTopic is the topic sweeps are requested on and continued from.
+	SweepTopic = "threat-findings-sweep"
+	// SweepCategory is the category of the events of automations run by a sweep.
+	SweepCategory = "sweep"
+	// defaultSweepLimit is how many resources are sent per run if no limit is given.
+	defaultSweepLimit = 500
+	// defaultSweepRate is how many resources are sent per second if no rate is given.
+	defaultSweepRate = 5
+)
+
+// SweepValues request an automation to be run on every resource of its kind within a scope, as if
+// each had a finding.
+type SweepValues struct {
+	// Action is the automation to run, i.e. "close_bucket".
+	Action string `json:"action"`
+	// Scope is the organization, folder or project the resources are listed in, i.e. "folders/123".
+	Scope string `json:"scope"`
+	// Target and Exclude further scope the projects like those of an automation, every project of
+	// the scope is targeted if empty.
+	Target  []string `json:"target"`
+	Exclude []string `json:"exclude"`
+	DryRun  bool     `json:"dry_run"`
+	// Limit is how many resources are sent per run, the sweep is continued by another run past it.
+	Limit int `json:"limit"`
+	// Rate is how many resources are sent per second so the automations don't exhaust API quotas.
+	Rate float64 `json:"rate"`
+	// After is the key of the last resource sent by the previous run of the sweep.
+	After string `json:"after,omitempty"`
+}
+
+// sweeper returns the values of an automation for a resource of its kind.
+type sweeper struct {
+	kind   string
+	values func(*services.TypedResource) interface{}
+}
+
+// sweepers are the automations that can be swept, by action.
+var sweepers = map[string]sweeper{
+	"close_bucket": {services.KindBucket, func(r *services.TypedResource) interface{} {
+		return &closebucket.Values{ProjectID: r.ProjectID, BucketName: r.Name}
+	}},
+	"enable_bucket_only_policy": {services.KindBucket, func(r *services.TypedResource) interface{} {
+		return &enablebucketonlypolicy.Values{ProjectID: r.ProjectID, BucketName: r.Name}
+	}},
+	"close_public_dataset": {services.KindDataset, func(r *services.TypedResource) interface{} {
+		return &closepublicdataset.Values{ProjectID: r.ProjectID, DatasetID: r.Name}
+	}},
+	"close_cloud_sql": {services.KindSQLInstance, func(r *services.TypedResource) interface{} {
+		return &removepublic.Values{ProjectID: r.ProjectID, InstanceName: r.Name}
+	}},
+	"cloud_sql_require_ssl": {services.KindSQLInstance, func(r *services.TypedResource) interface{} {
+		return &requiressl.Values{ProjectID: r.ProjectID, InstanceName: r.Name}
+	}},
+	"remove_public_ip": {services.KindInstance, func(r *services.TypedResource) interface{} {
+		return &removepublicip.Values{ProjectID: r.ProjectID, InstanceZone: r.Zone, InstanceID: r.Name}
+	}},
+	"disable_serial_port": {services.KindInstance, func(r *services.TypedResource) interface{} {
+		return &disableserialport.Values{ProjectID: r.ProjectID, InstanceZone: r.Zone, InstanceID: r.Name}
+	}},
+	"disable_ip_forwarding": {services.KindInstance, func(r *services.TypedResource) interface{} {
+		return &disableipforwarding.Values{ProjectID: r.ProjectID, InstanceZone: r.Zone, InstanceID: r.Name}
+	}},
+	"disable_dashboard": {services.KindCluster, func(r *services.TypedResource) interface{} {
+		return &disabledashboard.Values{ProjectID: r.ProjectID, Zone: r.Zone, ClusterID: r.Name}
+	}},
+}
+
+// SweepActions returns the actions that can be swept, sorted.
+func SweepActions() []string {
+	actions := make([]string, 0, len(sweepers))
+	for a := range sweepers {
+		actions = append(actions, a)
+	}
+	sort.Strings(actions)
+	return actions
+}
+
+// Sweep runs the automation on every resource of its kind in the scope, listed with Cloud Asset
+// Inventory, turning it into an on-demand hygiene job. Each resource goes through the same checks
+// as a finding: the projects targeted, permissions, freezes and dry run, and the automation audits
+// and notifies its outcome as usual. Automations skip the resources that don't need remediating.
+//
+// At most the limit of resources are sent per run, at the rate given, and the sweep requests its
+// own continuation past the last one.
+func Sweep(ctx context.Context, values *SweepValues, svcs *Services) (*services.Result, error) {
+	result := services.NewResult("sweep", values.DryRun)
+	sw, ok := sweepers[values.Action]
+	if !ok {
+		return nil, errors.Errorf("action %q can't be swept, use one of %q", values.Action, SweepActions())
+	}
+	if values.Scope == "" {
+		return nil, errors.New("no scope to sweep")
+	}
+	if svcs.Assets == nil {
+		return nil, errors.New("no assets service to list the resources to sweep")
+	}
+	resources, err := svcs.Assets.List(ctx, values.Scope, sw.kind)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(resources, func(i, j int) bool { return sweepKey(resources[i]) < sweepKey(resources[j]) })
+	limit := values.Limit
+	if limit <= 0 {
+		limit = defaultSweepLimit
+	}
+	rate := values.Rate
+	if rate <= 0 {
+		rate = defaultSweepRate
+	}
+	tick := time.NewTicker(time.Duration(float64(time.Second) / rate))
+	defer tick.Stop()
+	automation := Automation{Action: values.Action, Target: values.Target, Exclude: values.Exclude}
+	if len(automation.Target) == 0 {
+		automation.Target = []string{"*"}
+	}
+	automation.Properties.DryRun = values.DryRun
+	topic := topics[values.Action].Topic
+	meta := metadata{Rule: SweepCategory, CorrelationID: services.CorrelationID(ctx)}
+	sent, skipped, failed, last := 0, 0, 0, ""
+	for _, r := range resources {
+		key := sweepKey(r)
+		if key <= values.After {
+			continue
+		}
+		if sent == limit {
+			next := *values
+			next.After = last
+			if err := continueSweep(ctx, svcs, &next); err != nil {
+				return nil, err
+			}
+			break
+		}
+		if sent > 0 {
+			select {
+			case <-tick.C:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+		sent, last = sent+1, key
+		v := sw.values(r)
+		if values.DryRun {
+			forceDryRun(v)
+		}
+		switch err := publish(ctx, svcs, automation, topic, r.ProjectID, meta, v); {
+		case services.IsExempted(err), services.IsPermissionDenied(err):
+			skipped++
+		case err != nil:
+			svcs.Logger.Error("failed to sweep %q: %q", key, err)
+			failed++
+		default:
+			result.Touch(key)
+		}
+	}
+	result.Message = fmt.Sprintf("sent %d resources of %q to %q, %d exempted or lacking permissions and %d failed", len(result.Resources), values.Scope, values.Action, skipped, failed)
+	svcs.Logger.Info("swept %d resources: %s", sent, result.Message)
+	return result, nil
+}
+
+// sweepKey identifies the resource within the sweep, resources are sent in the order of their keys.
+func sweepKey(r *services.TypedResource) string {
+	return strings.Join([]string{r.ProjectID, r.Zone + r.Region, r.Name}, "/")
+}
+
+// continueSweep requests the sweep to be continued by another run.
+func continueSweep(ctx context.Context, svcs *Services, values *SweepValues) error {
+	b, err := json.Marshal(values)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal sweep")
+	}
+	m := &pubsub.Message{Data: b}
+	if id := services.CorrelationID(ctx); id != "" {
+		m.Attributes = map[string]string{CorrelationAttribute: id}
+	}
+	m.Attributes = services.InjectSpan(ctx, m.Attributes)
+	if _, err := svcs.PubSub.Publish(ctx, SweepTopic, m); err != nil {
+		return errors.Wrapf(err, "failed to continue sweep of %q after %q", values.Scope, values.After)
+	}
+	svcs.Logger.Info("sweep of %q with %q continues after %q", values.Scope, values.Action, values.After)
+	return nil
+}
@@ -0,0 +1,127 @@
+package router
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googlecloudplatform/security-response-automation/clients/assets"
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gcs/closebucket"
+	"github.com/googlecloudplatform/security-response-automation/services"
+)
+
+func TestSweep(t *testing.T) {
+	buckets := []*assets.Asset{
+		{Name: "//storage.googleapis.com/public-b", AssetType: "storage.googleapis.com/Bucket", Project: "projects/test-project"},
+		{Name: "//storage.googleapis.com/public-a", AssetType: "storage.googleapis.com/Bucket", Project: "projects/test-project"},
+		{Name: "//storage.googleapis.com/public-c", AssetType: "storage.googleapis.com/Bucket", Project: "projects/test-project"},
+		{Name: "//compute.googleapis.com/projects/test-project/zones/us-central1-a/instances/vm", AssetType: "compute.googleapis.com/Instance"},
+	}
+	for _, tt := range []struct {
+		name     string
+		values   *SweepValues
+		buckets  []string
+		dryRun   bool
+		resumes  string
+		expected *services.Result
+	}{
+		{
+			name:    "dry run",
+			values:  &SweepValues{Action: "close_bucket", Scope: "folders/123", DryRun: true, Rate: 1000},
+			buckets: []string{"public-a", "public-b", "public-c"},
+			dryRun:  true,
+			expected: &services.Result{
+				Action:    "sweep",
+				DryRun:    true,
+				Resources: []string{"test-project//public-a", "test-project//public-b", "test-project//public-c"},
+				Message:   `sent 3 resources of "folders/123" to "close_bucket", 0 exempted or lacking permissions and 0 failed`,
+			},
+		},
+		{
+			name:    "continued past limit",
+			values:  &SweepValues{Action: "close_bucket", Scope: "folders/123", Limit: 1, Rate: 1000, After: "test-project//public-a"},
+			buckets: []string{"public-b"},
+			resumes: "test-project//public-b",
+			expected: &services.Result{
+				Action:    "sweep",
+				Resources: []string{"test-project//public-b"},
+				Message:   `sent 1 resources of "folders/123" to "close_bucket", 0 exempted or lacking permissions and 0 failed`,
+			},
+		},
+		{
+			name:   "excluded",
+			values: &SweepValues{Action: "close_bucket", Scope: "folders/123", Exclude: []string{"organizations/456/folders/123/*"}, Rate: 1000},
+			expected: &services.Result{
+				Action:  "sweep",
+				Message: `sent 0 resources of "folders/123" to "close_bucket", 3 exempted or lacking permissions and 0 failed`,
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			crmStub := &stubs.ResourceManagerStub{}
+			crmStub.GetAncestryResponse = services.CreateAncestors([]string{"project/test-project", "folder/123", "organization/456"})
+			res := services.NewResource(crmStub, &stubs.StorageStub{})
+			psStub := &stubs.PubSubStub{}
+			svcs := &Services{
+				PubSub:        services.NewPubSub(psStub),
+				Logger:        services.NewLogger(&stubs.LoggerStub{}),
+				Configuration: &Configuration{},
+				Resource:      res,
+				Assets:        services.NewAssets(&stubs.AssetsStub{StubbedAssets: buckets}, services.NewResolver(res)),
+			}
+			r, err := Sweep(context.Background(), tt.values, svcs)
+			if err != nil {
+				t.Fatalf("%s failed: %q", tt.name, err)
+			}
+			if diff := cmp.Diff(tt.expected, r); diff != "" {
+				t.Errorf("%s result difference:%+v", tt.name, diff)
+			}
+			var got []string
+			resumes := ""
+			for _, m := range psStub.PublishedMessages {
+				if m.Attributes[CategoryAttribute] != SweepCategory {
+					var next SweepValues
+					if err := json.Unmarshal(m.Data, &next); err != nil {
+						t.Fatalf("%s failed to unmarshal sweep: %q", tt.name, err)
+					}
+					resumes = next.After
+					continue
+				}
+				var values closebucket.Values
+				if err := json.Unmarshal(m.Data, &values); err != nil || values.DryRun != tt.dryRun || values.ProjectID != "test-project" {
+					t.Errorf("%s got values %+v, %v", tt.name, values, err)
+				}
+				got = append(got, values.BucketName)
+			}
+			if diff := cmp.Diff(tt.buckets, got); diff != "" {
+				t.Errorf("%s buckets difference:%+v", tt.name, diff)
+			}
+			if resumes != tt.resumes {
+				t.Errorf("%s resumes after %q want %q", tt.name, resumes, tt.resumes)
+			}
+		})
+	}
+}
+
+func TestSweepUnsupportedAction(t *testing.T) {
+	_, err := Sweep(context.Background(), &SweepValues{Action: "iam_revoke", Scope: "folders/123"}, &Services{})
+	if err == nil {
+		t.Errorf("sweeping iam_revoke did not fail")
+	}
+}
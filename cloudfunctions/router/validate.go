@@ -0,0 +1,439 @@
+package router
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/cloud-sql/secureroot"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gce/blockegress"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gce/removeloadbalancer"
+	"github.com/googlecloudplatform/security-response-automation/services"
+)
+
+// configAPIVersion is the API version of the configuration.
+const configAPIVersion = "security-response-automation.cloud.google.com/v1alpha1"
+
+// targetPattern matches the ancestry patterns used by target and exclude, i.e.
+// "organizations/456/folders/*/projects/p", "organizations/456/*/projects/p" or, to match a folder
+// at any depth, "folders/123/*".
+var targetPattern = regexp.MustCompile(`^(organizations|folders)/[^/]+(/(folders/[^/]+|projects/[^/]+|\*))*$`)
+
+// tagPattern matches the tag values used by target_tags and exclude_tags, by ID or namespaced name,
+// i.e. "tagValues/123" or "456/env/prod".
+var tagPattern = regexp.MustCompile(`^(tagValues/[^/]+|[^/]+/[^/]+/[^/]+)$`)
+
+// kmsKeyPattern matches the resource name of a Cloud KMS key.
+var kmsKeyPattern = regexp.MustCompile(`^projects/[^/]+/locations/[^/]+/keyRings/[^/]+/cryptoKeys/[^/]+$`)
+
+// severities are the known severities of findings.
+var severities = map[string]bool{"LOW": true, "MEDIUM": true, "HIGH": true, "CRITICAL": true}
+
+// ConfigProblem is a misconfiguration found when validating the configuration.
+type ConfigProblem struct {
+	// Path locates the automation, i.e. "sha.open_firewall[0]".
+	Path    string
+	Message string
+}
+
+func (p ConfigProblem) String() string {
+	if p.Path == "" {
+		return p.Message
+	}
+	return p.Path + ": " + p.Message
+}
+
+// bruteForce are the rules whose remediate_firewall action always blocks the sources of the
+// attack, open_firewall.remediation_action is ignored.
+var bruteForce = map[string]bool{
+	"etd.ssh_brute_force":  true,
+	"siem.rdp_brute_force": true,
+}
+
+// rule is a finding along with its configured automations and the actions it supports.
+type rule struct {
+	path        string
+	automations []Automation
+	actions     []string
+}
+
+// rules returns the rules of the configuration. The supported actions must match those handled
+// by Execute.
+func rules(c *Configuration) []rule {
+	p := c.Spec.Parameters
+	return []rule{
+		{"etd.bad_ip", p.ETD.BadIP, []string{"gce_create_disk_snapshot", "block_egress"}},
+		{"etd.anomalous_iam", p.ETD.AnomalousIAM, []string{"iam_revoke", "iam_revoke_org", "iam_revoke_grants", "quarantine_service_account", "lockdown_project"}},
+		{"etd.ssh_brute_force", p.ETD.SSHBruteForce, []string{"remediate_firewall"}},
+		{"etd.bad_domain", p.ETD.BadDomain, []string{"gce_create_disk_snapshot", "block_egress"}},
+		{"etd.new_geography", p.ETD.NewGeography, []string{"iam_revoke"}},
+		{"etd.bigquery_exfiltration", p.ETD.BigQueryExfiltration, []string{"iam_revoke"}},
+		{"etd.cryptomining", p.ETD.Cryptomining, []string{"gce_create_disk_snapshot", "remove_public_ip"}},
+		{"sha.public_bucket_acl", p.SHA.PublicBucketACL, []string{"close_bucket", "close_staging_bucket"}},
+		{"sha.bucket_policy_only_disabled", p.SHA.BucketPolicyOnlyDisable, []string{"enable_bucket_only_policy"}},
+		{"sha.bucket_logging_disabled", p.SHA.BucketLoggingDisabled, []string{"enable_bucket_logging"}},
+		{"sha.object_versioning_disabled", p.SHA.VersioningDisabled, []string{"enable_bucket_logging"}},
+		{"sha.public_sql_instance", p.SHA.PublicSQLInstance, []string{"close_cloud_sql"}},
+		{"sha.ssl_not_enforced", p.SHA.SSLNotEnforced, []string{"cloud_sql_require_ssl"}},
+		{"sha.sql_no_root_password", p.SHA.SQLNoRootPassword, []string{"cloud_sql_update_password", "cloud_sql_secure_root"}},
+		{"sha.sql_auto_backup_disabled", p.SHA.SQLAutoBackupDisabled, []string{"cloud_sql_enable_backups"}},
+		{"sha.public_ip_address", p.SHA.PublicIPAddress, []string{"remove_public_ip"}},
+		{"sha.compute_serial_ports_enabled", p.SHA.SerialPortsEnabled, []string{"disable_serial_port"}},
+		{"sha.ip_forwarding_enabled", p.SHA.IPForwardingEnabled, []string{"disable_ip_forwarding"}},
+		{"sha.legacy_metadata_enabled", p.SHA.LegacyMetadataEnabled, []string{"harden_instance"}},
+		{"sha.shielded_vm_disabled", p.SHA.ShieldedVMDisabled, []string{"harden_instance"}},
+		{"sha.bucket_cmek_disabled", p.SHA.BucketCMEKDisabled, []string{"enforce_cmek"}},
+		{"sha.dataset_cmek_disabled", p.SHA.DatasetCMEKDisabled, []string{"enforce_cmek"}},
+		{"sha.bigquery_table_cmek_disabled", p.SHA.TableCMEKDisabled, []string{"enforce_cmek"}},
+		{"sha.disk_cmek_disabled", p.SHA.DiskCMEKDisabled, []string{"enforce_cmek"}},
+		{"sha.http_load_balancer", p.SHA.HTTPLoadBalancer, []string{"enforce_https"}},
+		{"sha.weak_ssl_policy", p.SHA.WeakSSLPolicy, []string{"enforce_https"}},
+		{"sha.open_firewall", p.SHA.OpenFirewall, []string{"remediate_firewall", "disable_unused_firewall"}},
+		{"sha.bigquery_public_dataset", p.SHA.PublicDataset, []string{"close_public_dataset"}},
+		{"sha.audit_logging_disabled", p.SHA.AuditLoggingDisabled, []string{"enable_audit_logs"}},
+		{"sha.web_ui_enabled", p.SHA.WebUIEnabled, []string{"disable_dashboard"}},
+		{"sha.network_policy_disabled", p.SHA.NetworkPolicyDisabled, []string{"harden_cluster_network"}},
+		{"sha.private_cluster_disabled", p.SHA.PrivateClusterDisabled, []string{"harden_cluster_network"}},
+		{"sha.non_org_members", p.SHA.NonOrgMembers, []string{"remove_non_org_members", "remove_external_group_members"}},
+		{"dlp.sensitive_data", p.DLP.SensitiveData, []string{"restrict_sensitive_data"}},
+		{"forseti.bucket_violation", p.Forseti.BucketViolation, []string{"close_bucket"}},
+		{"forseti.iam_policy_violation", p.Forseti.IAMPolicyViolation, []string{"iam_revoke"}},
+		{"forseti.firewall_violation", p.Forseti.FirewallViolation, []string{"remediate_firewall"}},
+		{"siem.compromised_instance", p.SIEM.CompromisedInstance, []string{"gce_create_disk_snapshot", "remove_public_ip", "remove_load_balancer", "block_egress", "lockdown_project"}},
+		{"siem.compromised_project", p.SIEM.CompromisedProject, []string{"lockdown_project"}},
+		{"siem.data_exfiltration", p.SIEM.DataExfiltration, []string{"block_egress", "remove_public_ip"}},
+		{"siem.external_member", p.SIEM.ExternalMember, []string{"iam_revoke", "remove_external_group_members"}},
+		{"siem.public_bucket", p.SIEM.PublicBucket, []string{"close_bucket"}},
+		{"siem.open_firewall", p.SIEM.OpenFirewall, []string{"remediate_firewall"}},
+		{"siem.key_misuse", p.SIEM.KeyMisuse, []string{"disable_key_version"}},
+		{"siem.rdp_brute_force", p.SIEM.RDPBruteForce, []string{"remediate_firewall", "remove_public_ip"}},
+		{"siem.public_managed_cluster", p.SIEM.PublicManagedCluster, []string{"secure_managed_cluster"}},
+	}
+}
+
+// AllowedMembers returns the domains and members allowed by the configured automations revoking
+// IAM grants, which remove any other member a finding reports. Removing members outside an
+// organization's domains only applies to users so it's left out.
+func AllowedMembers(c *Configuration) ([]string, []string) {
+	domains, members := []string{}, []string{}
+	for _, r := range rules(c) {
+		for _, a := range r.automations {
+			switch a.Action {
+			case "iam_revoke", "iam_revoke_org":
+				domains = append(domains, a.Properties.RevokeIAM.AllowDomains...)
+				members = append(members, a.Properties.RevokeIAM.AllowMembers...)
+			case "iam_revoke_grants":
+				members = append(members, a.Properties.RevokeGrants.AllowMembers...)
+			}
+		}
+	}
+	return domains, members
+}
+
+// Validate returns the problems found in the configuration without calling any API.
+func Validate(c *Configuration) []ConfigProblem {
+	problems := []ConfigProblem{}
+	if c.APIVersion != configAPIVersion {
+		problems = append(problems, ConfigProblem{Message: fmt.Sprintf("apiVersion must be %q", configAPIVersion)})
+	}
+	for i, f := range c.Spec.Freezes {
+		path := fmt.Sprintf("freezes[%d]", i)
+		for _, msg := range validateFreeze(f) {
+			problems = append(problems, ConfigProblem{Path: path, Message: msg})
+		}
+	}
+	if msg := validateMaxFindingAge(c.Spec.MaxFindingAge); msg != "" {
+		problems = append(problems, ConfigProblem{Path: "max_finding_age", Message: msg})
+	}
+	for _, r := range rules(c) {
+		for i, a := range r.automations {
+			path := fmt.Sprintf("%s[%d]", r.path, i)
+			for _, msg := range validateAutomation(r, a) {
+				problems = append(problems, ConfigProblem{Path: path, Message: msg})
+			}
+		}
+	}
+	return problems
+}
+
+// validateAutomation returns the problems found in a single automation of the rule.
+func validateAutomation(r rule, a Automation) []string {
+	msgs := []string{}
+	if !contains(r.actions, a.Action) {
+		msgs = append(msgs, fmt.Sprintf("action %q is not supported, use one of %q", a.Action, r.actions))
+	}
+	if len(a.Target) == 0 && len(a.TargetTags) == 0 {
+		msgs = append(msgs, "no target or target_tags are set so the automation never runs")
+	}
+	for _, pattern := range append(append([]string{}, a.Target...), a.Exclude...) {
+		if !targetPattern.MatchString(pattern) {
+			msgs = append(msgs, fmt.Sprintf("%q is not a valid target, i.e. \"organizations/456/folders/*\"", pattern))
+		}
+	}
+	for _, tag := range append(append([]string{}, a.TargetTags...), a.ExcludeTags...) {
+		if !tagPattern.MatchString(tag) {
+			msgs = append(msgs, fmt.Sprintf("%q is not a valid tag value, i.e. \"tagValues/123\" or \"456/env/prod\"", tag))
+		}
+	}
+	if msg := validateMaxFindingAge(a.MaxFindingAge); msg != "" {
+		msgs = append(msgs, msg)
+	}
+	if r := a.Rollout; r != nil {
+		if r.Percent < 0 || r.Percent > 100 {
+			msgs = append(msgs, fmt.Sprintf("rollout.percent %d must be between 0 and 100", r.Percent))
+		}
+		for _, pattern := range r.Pilot {
+			if !targetPattern.MatchString(pattern) {
+				msgs = append(msgs, fmt.Sprintf("rollout.pilot %q is not a valid target, i.e. \"organizations/456/folders/*\"", pattern))
+			}
+		}
+	}
+	if a.Condition != "" {
+		if _, err := ParseCondition(a.Condition); err != nil {
+			msgs = append(msgs, fmt.Sprintf("condition %q is invalid: %s", a.Condition, err))
+		}
+	}
+	p := a.Properties
+	if p.TTL != "" {
+		if _, err := time.ParseDuration(p.TTL); err != nil {
+			msgs = append(msgs, fmt.Sprintf("ttl %q is not a valid duration, i.e. \"24h\"", p.TTL))
+		}
+		temporary := a.Action == "remove_public_ip" || a.Action == "block_egress" ||
+			a.Action == "remediate_firewall" && (bruteForce[r.path] || p.OpenFirewall.RemediationAction == "disable" || p.OpenFirewall.RemediationAction == "update_source_range")
+		if !temporary {
+			msgs = append(msgs, "ttl is only supported by remove_public_ip, block_egress, the disable and update_source_range actions of remediate_firewall and blocking brute force sources")
+		}
+	}
+	switch a.Action {
+	case "iam_revoke_grants":
+		if w := p.RevokeGrants.Window; w != "" {
+			if _, err := time.ParseDuration(w); err != nil {
+				msgs = append(msgs, fmt.Sprintf("revoke_grants.window %q is not a valid duration, i.e. \"24h\"", w))
+			}
+		}
+	case "enforce_cmek":
+		if !kmsKeyPattern.MatchString(p.EnforceCMEK.KMSKey) {
+			msgs = append(msgs, fmt.Sprintf("enforce_cmek.kms_key %q must be a key name, i.e. \"projects/p/locations/us/keyRings/r/cryptoKeys/k\"", p.EnforceCMEK.KMSKey))
+		}
+	case "disable_key_version":
+		if !strings.Contains(p.DisableKeyVersion.BreakGlassGroup, "@") {
+			msgs = append(msgs, fmt.Sprintf("disable_key_version.break_glass_group %q must be the email of a group", p.DisableKeyVersion.BreakGlassGroup))
+		}
+	case "lockdown_project":
+		if !strings.Contains(p.LockdownProject.BreakGlassGroup, "@") {
+			msgs = append(msgs, fmt.Sprintf("lockdown_project.break_glass_group %q must be the email of a group", p.LockdownProject.BreakGlassGroup))
+		}
+	case "remove_external_group_members":
+		if len(p.RemoveGroupMembers.AllowDomains) == 0 {
+			msgs = append(msgs, "remove_external_group_members.allow_domains must be set")
+		}
+	case "remediate_firewall":
+		if bruteForce[r.path] {
+			break
+		}
+		switch p.OpenFirewall.RemediationAction {
+		case "disable", "delete", "block_ssh":
+		case "update_source_range":
+			if len(p.OpenFirewall.SourceRanges) == 0 {
+				msgs = append(msgs, "open_firewall.source_ranges must be set to update the source range")
+			}
+			for _, r := range p.OpenFirewall.SourceRanges {
+				if _, _, err := net.ParseCIDR(r); err != nil {
+					msgs = append(msgs, fmt.Sprintf("open_firewall.source_ranges %q is not in CIDR notation", r))
+				}
+			}
+		default:
+			msgs = append(msgs, fmt.Sprintf("open_firewall.remediation_action %q must be one of disable, delete, block_ssh or update_source_range", p.OpenFirewall.RemediationAction))
+		}
+	case "block_egress":
+		switch p.BlockEgress.Scope {
+		case "", blockegress.ScopeNetworkTag, blockegress.ScopeServiceAccount:
+		default:
+			msgs = append(msgs, fmt.Sprintf("block_egress.scope %q must be one of %s or %s", p.BlockEgress.Scope, blockegress.ScopeNetworkTag, blockegress.ScopeServiceAccount))
+		}
+		for _, r := range p.BlockEgress.DestinationRanges {
+			if _, _, err := net.ParseCIDR(r); err != nil {
+				msgs = append(msgs, fmt.Sprintf("block_egress.destination_ranges %q is not in CIDR notation", r))
+			}
+		}
+	case "remove_load_balancer":
+		switch p.RemoveLoadBalancer.Mode {
+		case removeloadbalancer.ModeDelete:
+		case removeloadbalancer.ModeDetach:
+			if p.RemoveLoadBalancer.QuarantineBackendService == "" {
+				msgs = append(msgs, "remove_load_balancer.quarantine_backend_service must be set to detach")
+			}
+		default:
+			msgs = append(msgs, fmt.Sprintf("remove_load_balancer.mode %q must be one of %s or %s", p.RemoveLoadBalancer.Mode, removeloadbalancer.ModeDelete, removeloadbalancer.ModeDetach))
+		}
+	case "cloud_sql_secure_root":
+		if m := p.SecureRoot.Mode; m != secureroot.ModeRotate && m != secureroot.ModeDeleteWildcard {
+			msgs = append(msgs, fmt.Sprintf("cloud_sql_secure_root.mode %q must be one of %s or %s", m, secureroot.ModeRotate, secureroot.ModeDeleteWildcard))
+		}
+	case "enable_bucket_logging":
+		if p.EnableBucketLogging.LogBucket == "" {
+			msgs = append(msgs, "enable_bucket_logging.log_bucket must be set")
+		}
+	case "harden_cluster_network":
+		if r.path == "sha.private_cluster_disabled" && len(p.HardenClusterNetwork.AuthorizedNetworks) == 0 {
+			msgs = append(msgs, "harden_cluster_network.authorized_networks must be set to restrict clusters without private nodes")
+		}
+		for _, n := range p.HardenClusterNetwork.AuthorizedNetworks {
+			if _, _, err := net.ParseCIDR(n); err != nil {
+				msgs = append(msgs, fmt.Sprintf("harden_cluster_network.authorized_networks %q is not in CIDR notation", n))
+			}
+		}
+	case "secure_managed_cluster":
+		for _, n := range p.SecureManagedCluster.AuthorizedNetworks {
+			if _, _, err := net.ParseCIDR(n); err != nil {
+				msgs = append(msgs, fmt.Sprintf("secure_managed_cluster.authorized_networks %q is not in CIDR notation", n))
+			}
+		}
+	}
+	email := p.Notify.Email
+	for _, to := range email.To {
+		if !strings.Contains(to, "@") {
+			msgs = append(msgs, fmt.Sprintf("notify.email.to %q is not an email address", to))
+		}
+	}
+	if len(email.Severities) > 0 && len(email.To) == 0 {
+		msgs = append(msgs, "notify.email.severities are set without any notify.email.to recipients")
+	}
+	for _, s := range email.Severities {
+		if !severities[strings.ToUpper(s)] {
+			msgs = append(msgs, fmt.Sprintf("notify.email.severities %q must be one of LOW, MEDIUM, HIGH or CRITICAL", s))
+		}
+	}
+	return msgs
+}
+
+// validateFreeze returns the problems found in a freeze.
+// validateMaxFindingAge returns the problem with the max_finding_age, if any.
+func validateMaxFindingAge(age string) string {
+	if age == "" {
+		return ""
+	}
+	if d, err := time.ParseDuration(age); err != nil || d <= 0 {
+		return fmt.Sprintf("max_finding_age %q must be a positive duration, i.e. \"2h\"", age)
+	}
+	return ""
+}
+
+func validateFreeze(f Freeze) []string {
+	msgs := []string{}
+	if f.Name == "" {
+		msgs = append(msgs, "name must be set")
+	}
+	switch f.Mode {
+	case "", FreezeDryRun, FreezeTicket:
+	default:
+		msgs = append(msgs, fmt.Sprintf("mode %q must be one of %s or %s", f.Mode, FreezeDryRun, FreezeTicket))
+	}
+	if f.Schedule == "" {
+		if f.Duration != "" || f.TimeZone != "" {
+			msgs = append(msgs, "duration and time_zone are only used with schedule")
+		}
+		start, err := time.Parse(time.RFC3339, f.Start)
+		if err != nil {
+			msgs = append(msgs, fmt.Sprintf("start %q must be set in RFC 3339 format, i.e. \"2020-12-20T00:00:00Z\"", f.Start))
+		}
+		end, err := time.Parse(time.RFC3339, f.End)
+		if err != nil {
+			msgs = append(msgs, fmt.Sprintf("end %q must be set in RFC 3339 format, i.e. \"2021-01-04T00:00:00Z\"", f.End))
+		}
+		if !start.IsZero() && !end.IsZero() && !end.After(start) {
+			msgs = append(msgs, "end must be after start")
+		}
+		return msgs
+	}
+	if f.Start != "" || f.End != "" {
+		msgs = append(msgs, "start and end can't be used with schedule")
+	}
+	if _, err := parseSchedule(f.Schedule); err != nil {
+		msgs = append(msgs, err.Error())
+	}
+	if d, err := time.ParseDuration(f.Duration); err != nil || d <= 0 || d > maxFreezeDuration {
+		msgs = append(msgs, fmt.Sprintf("duration %q must be a duration of at most %s, i.e. \"62h\"", f.Duration, maxFreezeDuration))
+	}
+	if _, err := time.LoadLocation(f.TimeZone); err != nil {
+		msgs = append(msgs, fmt.Sprintf("time_zone %q is not a known time zone, i.e. \"Europe/Paris\"", f.TimeZone))
+	}
+	return msgs
+}
+
+// CheckScopes returns the problems found verifying each organization, folder and project named in
+// the targets and excludes exists and is visible to the service account. Wildcards are skipped.
+func CheckScopes(ctx context.Context, c *Configuration, r *services.Resource) []ConfigProblem {
+	paths := map[string][]string{}
+	for _, rl := range rules(c) {
+		for i, a := range rl.automations {
+			path := fmt.Sprintf("%s[%d]", rl.path, i)
+			for _, pattern := range append(append([]string{}, a.Target...), a.Exclude...) {
+				for _, resource := range scopeResources(pattern) {
+					paths[resource] = append(paths[resource], path)
+				}
+			}
+		}
+	}
+	resources := make([]string, 0, len(paths))
+	for resource := range paths {
+		resources = append(resources, resource)
+	}
+	sort.Strings(resources)
+	problems := []ConfigProblem{}
+	for _, resource := range resources {
+		if err := r.Visible(ctx, resource); err != nil {
+			for _, path := range paths[resource] {
+				problems = append(problems, ConfigProblem{Path: path, Message: fmt.Sprintf("%q does not exist or is not visible to the service account", resource)})
+			}
+		}
+	}
+	return problems
+}
+
+// scopeResources returns the resources named in an ancestry pattern that are not wildcards, i.e.
+// "organizations/456/folders/*/projects/p" returns "organizations/456" and "projects/p".
+func scopeResources(pattern string) []string {
+	if !targetPattern.MatchString(pattern) {
+		return nil
+	}
+	parts := strings.Split(pattern, "/")
+	resources := []string{}
+	for i := 0; i+1 < len(parts); i++ {
+		if parts[i] == "*" {
+			continue
+		}
+		if !strings.Contains(parts[i+1], "*") {
+			resources = append(resources, parts[i]+"/"+parts[i+1])
+		}
+		i++
+	}
+	return resources
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
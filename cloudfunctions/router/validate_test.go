@@ -0,0 +1,278 @@
+package router
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"io/ioutil"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+	"github.com/googlecloudplatform/security-response-automation/services"
+	crmv2 "google.golang.org/api/cloudresourcemanager/v2"
+)
+
+const validConfig = `apiVersion: security-response-automation.cloud.google.com/v1alpha1
+kind: Remediation
+metadata:
+  name: router
+spec:
+  parameters:
+    sha:
+      open_firewall:
+        - action: remediate_firewall
+          target:
+            - organizations/456/folders/123/projects/*
+          exclude:
+            - organizations/456/folders/123/projects/excluded
+          properties:
+            ttl: 24h
+            open_firewall:
+              remediation_action: update_source_range
+              source_ranges:
+                - 10.128.0.0/9
+            notify:
+              email:
+                to:
+                  - soc@example.com
+                severities:
+                  - high
+`
+
+func TestParseConfig(t *testing.T) {
+	b, err := ioutil.ReadFile("empty-config.yaml")
+	if err != nil {
+		t.Fatalf("failed to read empty config: %q", err)
+	}
+	for _, tt := range []struct {
+		name    string
+		config  string
+		wantErr bool
+	}{
+		{name: "empty config", config: string(b)},
+		{name: "valid config", config: validConfig},
+		{name: "unknown key", config: "apiVersion: v1\nspec:\n  parameters:\n    sha:\n      open_firewal:\n", wantErr: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := ParseConfig([]byte(tt.config))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("%s failed: got err %v, want err %t", tt.name, err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if problems := Validate(c); len(problems) != 0 {
+				t.Errorf("%s failed: unexpected problems %v", tt.name, problems)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		automation func(*Automation)
+		want       []ConfigProblem
+	}{
+		{
+			name: "unsupported action",
+			automation: func(a *Automation) {
+				a.Action = "close_bucket"
+				a.Properties.TTL = ""
+			},
+			want: []ConfigProblem{
+				{Path: "sha.open_firewall[0]", Message: `action "close_bucket" is not supported, use one of ["remediate_firewall" "disable_unused_firewall"]`},
+			},
+		},
+		{
+			name: "invalid target",
+			automation: func(a *Automation) {
+				a.Target = []string{"projects/p"}
+			},
+			want: []ConfigProblem{
+				{Path: "sha.open_firewall[0]", Message: `"projects/p" is not a valid target, i.e. "organizations/456/folders/*"`},
+			},
+		},
+		{
+			name: "wildcard target",
+			automation: func(a *Automation) {
+				a.Target = []string{"organizations/456/*/projects/p", "organizations/456/folders/123/*", "folders/123/*"}
+			},
+			want: []ConfigProblem{},
+		},
+		{
+			name: "invalid rollout",
+			automation: func(a *Automation) {
+				a.Rollout = &Rollout{Percent: 120, Pilot: []string{"projects/p"}}
+			},
+			want: []ConfigProblem{
+				{Path: "sha.open_firewall[0]", Message: "rollout.percent 120 must be between 0 and 100"},
+				{Path: "sha.open_firewall[0]", Message: `rollout.pilot "projects/p" is not a valid target, i.e. "organizations/456/folders/*"`},
+			},
+		},
+		{
+			name: "tag target",
+			automation: func(a *Automation) {
+				a.Target = nil
+				a.TargetTags = []string{"456/env/prod", "tagValues/123"}
+				a.ExcludeTags = []string{"456/sra/*"}
+			},
+			want: []ConfigProblem{},
+		},
+		{
+			name: "no target",
+			automation: func(a *Automation) {
+				a.Target = nil
+			},
+			want: []ConfigProblem{
+				{Path: "sha.open_firewall[0]", Message: "no target or target_tags are set so the automation never runs"},
+			},
+		},
+		{
+			name: "invalid tag",
+			automation: func(a *Automation) {
+				a.TargetTags = []string{"env/prod"}
+			},
+			want: []ConfigProblem{
+				{Path: "sha.open_firewall[0]", Message: `"env/prod" is not a valid tag value, i.e. "tagValues/123" or "456/env/prod"`},
+			},
+		},
+		{
+			name: "invalid source range",
+			automation: func(a *Automation) {
+				a.Properties.OpenFirewall.SourceRanges = []string{"10.0.0.0"}
+			},
+			want: []ConfigProblem{
+				{Path: "sha.open_firewall[0]", Message: `open_firewall.source_ranges "10.0.0.0" is not in CIDR notation`},
+			},
+		},
+		{
+			name: "ttl not supported",
+			automation: func(a *Automation) {
+				a.Properties.OpenFirewall.RemediationAction = "delete"
+				a.Properties.OpenFirewall.SourceRanges = nil
+			},
+			want: []ConfigProblem{
+				{Path: "sha.open_firewall[0]", Message: "ttl is only supported by remove_public_ip, block_egress, the disable and update_source_range actions of remediate_firewall and blocking brute force sources"},
+			},
+		},
+		{
+			name: "unknown severity",
+			automation: func(a *Automation) {
+				a.Properties.Notify.Email.Severities = []string{"urgent"}
+			},
+			want: []ConfigProblem{
+				{Path: "sha.open_firewall[0]", Message: `notify.email.severities "urgent" must be one of LOW, MEDIUM, HIGH or CRITICAL`},
+			},
+		},
+		{
+			name: "invalid condition",
+			automation: func(a *Automation) {
+				a.Condition = `project.label.env != "prod"`
+			},
+			want: []ConfigProblem{
+				{Path: "sha.open_firewall[0]", Message: `condition "project.label.env != \"prod\"" is invalid: unknown field "project.label.env" at 0`},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := ParseConfig([]byte(validConfig))
+			if err != nil {
+				t.Fatalf("%s failed to parse config: %q", tt.name, err)
+			}
+			tt.automation(&c.Spec.Parameters.SHA.OpenFirewall[0])
+			if diff := cmp.Diff(tt.want, Validate(c)); diff != "" {
+				t.Errorf("%s failed, difference: %v", tt.name, diff)
+			}
+		})
+	}
+}
+
+func TestValidateFreezes(t *testing.T) {
+	c, err := ParseConfig([]byte(validConfig))
+	if err != nil {
+		t.Fatalf("failed to parse config: %q", err)
+	}
+	c.Spec.Freezes = []Freeze{
+		{Name: "holidays", Start: "2020-12-20T00:00:00Z", End: "2021-01-04T00:00:00Z", Mode: FreezeTicket},
+		{Name: "weekend", Schedule: "0 18 * * 5", Duration: "62h", TimeZone: "Europe/Paris"},
+		{Name: "backwards", Start: "2021-01-04T00:00:00Z", End: "2020-12-20T00:00:00Z", Mode: "skip"},
+		{Name: "nightly", Schedule: "0 25 * * *", Duration: "200h"},
+	}
+	want := []ConfigProblem{
+		{Path: "freezes[2]", Message: `mode "skip" must be one of dry_run or ticket`},
+		{Path: "freezes[2]", Message: "end must be after start"},
+		{Path: "freezes[3]", Message: `invalid schedule "0 25 * * *": "25" is outside 0-23`},
+		{Path: "freezes[3]", Message: `duration "200h" must be a duration of at most 168h0m0s, i.e. "62h"`},
+	}
+	if diff := cmp.Diff(want, Validate(c)); diff != "" {
+		t.Errorf("unexpected problems: %v", diff)
+	}
+}
+
+func TestValidateMaxFindingAge(t *testing.T) {
+	c, err := ParseConfig([]byte(validConfig))
+	if err != nil {
+		t.Fatalf("failed to parse config: %q", err)
+	}
+	c.Spec.MaxFindingAge = "2 hours"
+	c.Spec.Parameters.SHA.OpenFirewall[0].MaxFindingAge = "-1h"
+	want := []ConfigProblem{
+		{Path: "max_finding_age", Message: `max_finding_age "2 hours" must be a positive duration, i.e. "2h"`},
+		{Path: "sha.open_firewall[0]", Message: `max_finding_age "-1h" must be a positive duration, i.e. "2h"`},
+	}
+	if diff := cmp.Diff(want, Validate(c)); diff != "" {
+		t.Errorf("unexpected problems: %v", diff)
+	}
+}
+
+func TestAllowedMembers(t *testing.T) {
+	c := &Configuration{}
+	c.Spec.Parameters.ETD.NewGeography = []Automation{{Action: "iam_revoke"}}
+	c.Spec.Parameters.ETD.NewGeography[0].Properties.RevokeIAM.AllowDomains = []string{"example.com"}
+	c.Spec.Parameters.ETD.NewGeography[0].Properties.RevokeIAM.AllowMembers = []string{"user:partner@gmail.com"}
+	c.Spec.Parameters.ETD.AnomalousIAM = []Automation{{Action: "iam_revoke_grants"}}
+	c.Spec.Parameters.ETD.AnomalousIAM[0].Properties.RevokeGrants.AllowMembers = []string{"user:breakglass@example.com"}
+	c.Spec.Parameters.SHA.NonOrgMembers = []Automation{{Action: "remove_non_org_members"}}
+	c.Spec.Parameters.SHA.NonOrgMembers[0].Properties.NonOrgMembers.AllowDomains = []string{"other.com"}
+	domains, members := AllowedMembers(c)
+	if diff := cmp.Diff([]string{"example.com"}, domains); diff != "" {
+		t.Errorf("domains differ (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"user:breakglass@example.com", "user:partner@gmail.com"}, members); diff != "" {
+		t.Errorf("members differ (-want +got):\n%s", diff)
+	}
+}
+
+func TestCheckScopes(t *testing.T) {
+	c, err := ParseConfig([]byte(validConfig))
+	if err != nil {
+		t.Fatalf("failed to parse config: %q", err)
+	}
+	crmStub := &stubs.ResourceManagerStub{GetFolderResponse: map[string]*crmv2.Folder{}}
+	r := services.NewResource(crmStub, &stubs.StorageStub{})
+	want := []ConfigProblem{
+		{Path: "sha.open_firewall[0]", Message: `"folders/123" does not exist or is not visible to the service account`},
+		{Path: "sha.open_firewall[0]", Message: `"folders/123" does not exist or is not visible to the service account`},
+	}
+	if diff := cmp.Diff(want, CheckScopes(context.Background(), c, r)); diff != "" {
+		t.Errorf("check scopes failed, difference: %v", diff)
+	}
+	crmStub.GetFolderResponse["folders/123"] = &crmv2.Folder{Name: "folders/123", Parent: "organizations/456"}
+	if problems := CheckScopes(context.Background(), c, r); len(problems) != 0 {
+		t.Errorf("check scopes failed: unexpected problems %v", problems)
+	}
+}
@@ -4,3 +4,9 @@ variable "folder-ids" {
   type        = list(string)
   description = "Folder IDs to grant the necessary permissions for this Cloud Function execution."
 }
+
+variable "max-instances" {
+  type        = number
+  default     = 0
+  description = "Maximum number of findings routed at once, unlimited if 0."
+}
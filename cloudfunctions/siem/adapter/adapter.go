@@ -0,0 +1,132 @@
+package adapter
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"net"
+	"strings"
+
+	"cloud.google.com/go/pubsub"
+	pb "github.com/googlecloudplatform/security-response-automation/compiled/siem/protos"
+	"github.com/googlecloudplatform/security-response-automation/providers/siem"
+	"github.com/googlecloudplatform/security-response-automation/services"
+	"github.com/pkg/errors"
+)
+
+// routerTopic is the topic the router receives findings from.
+const routerTopic = "threat-findings"
+
+// Values contains the required values needed for this function.
+type Values struct {
+	// Alert is the alert as received from the SIEM, either a Chronicle rule detection or an
+	// already normalized alert.
+	Alert []byte
+}
+
+// Services contains the services needed for this function.
+type Services struct {
+	PubSub *services.PubSub
+	Logger *services.Logger
+	// Assets optionally locates the instances alerts name by IP address or without their zone.
+	Assets *services.Assets
+}
+
+// Authorized returns if the authorization header carries the expected bearer token.
+func Authorized(header, token string) bool {
+	if token == "" {
+		return false
+	}
+	got := strings.TrimPrefix(header, "Bearer ")
+	return subtle.ConstantTimeCompare([]byte(got), []byte(token)) == 1
+}
+
+// Execute normalizes the SIEM alert and publishes it to the router.
+func Execute(ctx context.Context, values *Values, services *Services) error {
+	alert, err := normalize(values.Alert)
+	if err != nil {
+		return err
+	}
+	if services.Assets != nil {
+		if err := locate(ctx, alert.GetSiemAlert().GetResource(), services.Assets); err != nil {
+			return err
+		}
+	}
+	b, err := json.Marshal(alert)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal alert")
+	}
+	if _, err := services.PubSub.Publish(ctx, routerTopic, &pubsub.Message{Data: b}); err != nil {
+		return errors.Wrap(err, "failed to publish alert")
+	}
+	services.Logger.Info("sent %q alert %q from %q to the router", alert.GetSiemAlert().GetCategory(), alert.GetSiemAlert().GetId(), alert.GetSiemAlert().GetSource())
+	return nil
+}
+
+// locate replaces an instance the alert names by IP address with the instance using the address
+// and fills in the zone of an instance named without it, searching the alert's project.
+func locate(ctx context.Context, r *pb.Alert_Resource, assets *services.Assets) error {
+	byIP := net.ParseIP(r.GetInstance()) != nil
+	if r.GetInstance() == "" || (r.GetZone() != "" && !byIP) {
+		return nil
+	}
+	if r.GetProjectId() == "" {
+		return errors.Errorf("instance %q has no project to search", r.GetInstance())
+	}
+	if !byIP {
+		found, err := assets.FindByName(ctx, r.GetProjectId(), services.KindInstance, r.GetInstance())
+		if err != nil {
+			return err
+		}
+		r.Zone = found.Zone
+		return nil
+	}
+	found, err := assets.FindByIP(ctx, r.GetProjectId(), r.GetInstance())
+	if err != nil {
+		return err
+	}
+	var instances []*services.TypedResource
+	for _, f := range found {
+		if f.Kind == services.KindInstance {
+			instances = append(instances, f)
+		}
+	}
+	if len(instances) != 1 {
+		return errors.Errorf("%d instances of project %q use ip %q", len(instances), r.GetProjectId(), r.GetInstance())
+	}
+	r.Instance, r.Zone = instances[0].Name, instances[0].Zone
+	return nil
+}
+
+// normalize returns the alert in the normalized format.
+func normalize(b []byte) (*pb.Alert, error) {
+	var alert pb.Alert
+	if err := json.Unmarshal(b, &alert); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal alert")
+	}
+	if alert.GetSiemAlert() != nil {
+		if !siem.Categories[alert.GetSiemAlert().GetCategory()] {
+			return nil, errors.Errorf("unsupported category %q", alert.GetSiemAlert().GetCategory())
+		}
+		return &alert, nil
+	}
+	var detection pb.ChronicleDetection
+	if err := json.Unmarshal(b, &detection); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal chronicle detection")
+	}
+	return siem.FromChronicle(&detection)
+}
@@ -0,0 +1,159 @@
+package adapter
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googlecloudplatform/security-response-automation/clients/assets"
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+	"github.com/googlecloudplatform/security-response-automation/services"
+)
+
+func TestExecute(t *testing.T) {
+	const (
+		chronicleDetection = `{
+			"id": "de_1234",
+			"type": "RULE_DETECTION",
+			"detectionTime": "2020-03-01T10:00:00Z",
+			"detection": [{
+				"ruleName": "gcp_public_bucket",
+				"ruleId": "ru_5678",
+				"ruleLabels": [{"key": "sra_category", "value": "public_bucket"}],
+				"detectionFields": [
+					{"key": "project_id", "value": "test-project"},
+					{"key": "bucket", "value": "public-bucket"}
+				]
+			}]
+		}`
+		normalizedAlert = `{"siemAlert": {"source": "splunk", "id": "1", "category": "external_member", "resource": {"projectId": "test-project", "members": ["user:attacker@gmail.com"]}}}`
+		unknownCategory = `{"siemAlert": {"source": "splunk", "id": "2", "category": "not_supported"}}`
+	)
+	for _, tt := range []struct {
+		name          string
+		alert         string
+		expected      string
+		expectedError bool
+	}{
+		{
+			name:     "chronicle",
+			alert:    chronicleDetection,
+			expected: `{"siemAlert":{"source":"chronicle","id":"de_1234","category":"public_bucket","eventTime":"2020-03-01T10:00:00Z","resource":{"projectId":"test-project","bucket":"public-bucket"}}}`,
+		},
+		{
+			name:     "normalized",
+			alert:    normalizedAlert,
+			expected: `{"siemAlert":{"source":"splunk","id":"1","category":"external_member","resource":{"projectId":"test-project","members":["user:attacker@gmail.com"]}}}`,
+		},
+		{name: "unknown category", alert: unknownCategory, expectedError: true},
+		{name: "unknown format", alert: `{"foo": "bar"}`, expectedError: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			psStub := &stubs.PubSubStub{}
+			err := Execute(context.Background(), &Values{Alert: []byte(tt.alert)}, &Services{
+				PubSub: services.NewPubSub(psStub),
+				Logger: services.NewLogger(&stubs.LoggerStub{}),
+			})
+			if tt.expectedError {
+				if err == nil || psStub.PublishedMessage != nil {
+					t.Errorf("%s failed: expected error and no message", tt.name)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%s failed: %q", tt.name, err)
+			}
+			if diff := cmp.Diff(tt.expected, string(psStub.PublishedMessage.Data)); diff != "" {
+				t.Errorf("%s failed, difference: %+v", tt.name, diff)
+			}
+		})
+	}
+}
+
+func TestExecuteLocatesInstance(t *testing.T) {
+	stubbed := []*assets.Asset{{
+		Name:                 "//compute.googleapis.com/projects/test-project/zones/us-central1-a/instances/web-1",
+		AssetType:            "compute.googleapis.com/Instance",
+		DisplayName:          "web-1",
+		AdditionalAttributes: map[string]interface{}{"externalIPs": []interface{}{"35.1.2.3"}},
+	}}
+	for _, tt := range []struct {
+		name          string
+		resource      string
+		expected      string
+		expectedError bool
+	}{
+		{
+			name:     "by ip",
+			resource: `{"projectId": "test-project", "instance": "35.1.2.3"}`,
+			expected: `{"projectId":"test-project","zone":"us-central1-a","instance":"web-1"}`,
+		},
+		{
+			name:     "without zone",
+			resource: `{"projectId": "test-project", "instance": "web-1"}`,
+			expected: `{"projectId":"test-project","zone":"us-central1-a","instance":"web-1"}`,
+		},
+		{
+			name:     "with zone",
+			resource: `{"projectId": "test-project", "zone": "europe-west1-b", "instance": "web-9"}`,
+			expected: `{"projectId":"test-project","zone":"europe-west1-b","instance":"web-9"}`,
+		},
+		{name: "unknown ip", resource: `{"projectId": "test-project", "instance": "35.9.9.9"}`, expectedError: true},
+		{name: "no project", resource: `{"instance": "35.1.2.3"}`, expectedError: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			psStub := &stubs.PubSubStub{}
+			alert := `{"siemAlert": {"source": "splunk", "id": "1", "category": "compromised_instance", "resource": ` + tt.resource + `}}`
+			err := Execute(context.Background(), &Values{Alert: []byte(alert)}, &Services{
+				PubSub: services.NewPubSub(psStub),
+				Logger: services.NewLogger(&stubs.LoggerStub{}),
+				Assets: services.NewAssets(&stubs.AssetsStub{StubbedAssets: stubbed}, services.NewResolver(services.NewResource(&stubs.ResourceManagerStub{}, &stubs.StorageStub{}))),
+			})
+			if tt.expectedError {
+				if err == nil || psStub.PublishedMessage != nil {
+					t.Errorf("%s failed: expected error and no message", tt.name)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%s failed: %q", tt.name, err)
+			}
+			expected := `{"siemAlert":{"source":"splunk","id":"1","category":"compromised_instance","resource":` + tt.expected + `}}`
+			if diff := cmp.Diff(expected, string(psStub.PublishedMessage.Data)); diff != "" {
+				t.Errorf("%s failed, difference: %+v", tt.name, diff)
+			}
+		})
+	}
+}
+
+func TestAuthorized(t *testing.T) {
+	for _, tt := range []struct {
+		name, header, token string
+		expected            bool
+	}{
+		{name: "valid", header: "Bearer secret", token: "secret", expected: true},
+		{name: "invalid", header: "Bearer wrong", token: "secret", expected: false},
+		{name: "missing header", header: "", token: "secret", expected: false},
+		{name: "token not configured", header: "Bearer ", token: "", expected: false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Authorized(tt.header, tt.token); got != tt.expected {
+				t.Errorf("%s failed: got:%v want:%v", tt.name, got, tt.expected)
+			}
+		})
+	}
+}
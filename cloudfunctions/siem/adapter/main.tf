@@ -0,0 +1,62 @@
+# Copyright 2020 Google LLC
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+# 	https://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+resource "google_cloudfunctions_function" "siem-adapter" {
+  name                  = "SIEMAdapter"
+  description           = "Converts SIEM alerts and sends them to the router."
+  runtime               = "go121"
+  available_memory_mb   = 128
+  source_archive_bucket = var.setup.gcf-bucket-name
+  source_archive_object = var.setup.gcf-object-name
+  timeout               = 60
+  project               = var.setup.automation-project
+  region                = var.setup.region
+  entry_point           = "SIEMAdapter"
+  trigger_http          = true
+
+  environment_variables = {
+    SIEM_ADAPTER_TOKEN = var.siem-adapter-token
+  }
+}
+
+# Allows the SIEM to call the function, requests are authenticated with the adapter token.
+resource "google_cloudfunctions_function_iam_member" "invoker" {
+  project        = var.setup.automation-project
+  region         = var.setup.region
+  cloud_function = google_cloudfunctions_function.siem-adapter.name
+  role           = "roles/cloudfunctions.invoker"
+  member         = "allUsers"
+}
+
+# Required to send alerts to the router.
+resource "google_project_iam_member" "pubsub-publisher" {
+  role    = "roles/pubsub.publisher"
+  project = var.setup.automation-project
+  member  = "serviceAccount:${var.setup.automation-service-account}"
+}
+
+# Required to locate the instances alerts name by IP address or without their zone.
+resource "google_folder_iam_member" "roles-cloudasset-viewer" {
+  count = length(var.folder-ids)
+
+  folder = "folders/${var.folder-ids[count.index]}"
+  role   = "roles/cloudasset.viewer"
+  member = "serviceAccount:${var.setup.automation-service-account}"
+}
+
+resource "google_project_service" "cloudasset_api" {
+  project                    = var.setup.automation-project
+  service                    = "cloudasset.googleapis.com"
+  disable_dependent_services = false
+  disable_on_destroy         = false
+}
@@ -0,0 +1,11 @@
+variable "setup" {}
+
+variable "folder-ids" {
+  type        = list(string)
+  description = "Folder IDs to grant the necessary permissions for this Cloud Function execution."
+}
+
+variable "siem-adapter-token" {
+  type        = string
+  description = "Bearer token the SIEM must send to the adapter."
+}
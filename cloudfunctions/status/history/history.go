@@ -0,0 +1,115 @@
+package history
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/googlecloudplatform/security-response-automation/services"
+	"github.com/pkg/errors"
+)
+
+// Services contains the services needed for this API.
+type Services struct {
+	History *services.History
+	Logger  *services.Logger
+}
+
+// Handler returns the read-only API serving the remediation history.
+//
+//	GET /v1/actions?project_id=&finding=&start=&end=&limit=
+//	GET /v1/policy_changes?project_id=&resource=&start=&end=&limit=
+//
+// The start and end parameters are RFC 3339 timestamps and records are returned newest first.
+func Handler(services *Services) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/actions", func(w http.ResponseWriter, r *http.Request) {
+		q, ok := query(w, r)
+		if !ok {
+			return
+		}
+		actions, err := services.History.Actions(r.Context(), q)
+		if err != nil {
+			services.Logger.Error("failed to list actions: %q", err)
+			http.Error(w, "failed to list actions", http.StatusInternalServerError)
+			return
+		}
+		respond(w, map[string]interface{}{"actions": actions})
+	})
+	mux.HandleFunc("/v1/policy_changes", func(w http.ResponseWriter, r *http.Request) {
+		q, ok := query(w, r)
+		if !ok {
+			return
+		}
+		changes, err := services.History.PolicyChanges(r.Context(), q)
+		if err != nil {
+			services.Logger.Error("failed to list policy changes: %q", err)
+			http.Error(w, "failed to list policy changes", http.StatusInternalServerError)
+			return
+		}
+		respond(w, map[string]interface{}{"policy_changes": changes})
+	})
+	return mux
+}
+
+// query parses the request's parameters, writing an error response if they are invalid.
+func query(w http.ResponseWriter, r *http.Request) (*services.HistoryQuery, bool) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return nil, false
+	}
+	q, err := parseQuery(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, false
+	}
+	return q, true
+}
+
+func parseQuery(r *http.Request) (*services.HistoryQuery, error) {
+	v := r.URL.Query()
+	q := &services.HistoryQuery{
+		ProjectID:   v.Get("project_id"),
+		FindingName: v.Get("finding"),
+		Resource:    v.Get("resource"),
+	}
+	var err error
+	if s := v.Get("start"); s != "" {
+		if q.Start, err = time.Parse(time.RFC3339, s); err != nil {
+			return nil, errors.Errorf("invalid start %q", s)
+		}
+	}
+	if s := v.Get("end"); s != "" {
+		if q.End, err = time.Parse(time.RFC3339, s); err != nil {
+			return nil, errors.Errorf("invalid end %q", s)
+		}
+	}
+	if s := v.Get("limit"); s != "" {
+		if q.Limit, err = strconv.Atoi(s); err != nil || q.Limit < 0 {
+			return nil, errors.Errorf("invalid limit %q", s)
+		}
+	}
+	return q, nil
+}
+
+func respond(w http.ResponseWriter, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		http.Error(w, "failed to encode response", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,106 @@
+package history
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/logging"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+	"github.com/googlecloudplatform/security-response-automation/services"
+)
+
+func TestHandler(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		method     string
+		url        string
+		entries    []*logging.Entry
+		wantStatus int
+		wantBody   string
+		wantFilter string
+	}{
+		{
+			name:   "actions by project and time",
+			method: http.MethodGet,
+			url:    "/v1/actions?project_id=test-project&start=2020-01-02T00:00:00Z&end=2020-01-03T00:00:00Z&limit=10",
+			entries: []*logging.Entry{
+				{Payload: map[string]string{"id": "event-1", "action": "close_bucket", "project_id": "test-project", "result": "success"}},
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"actions":[{"id":"event-1","time":"","action":"close_bucket","project_id":"test-project","result":"success"}]}`,
+			wantFilter: `timestamp>="2020-01-02T00:00:00Z" AND timestamp<"2020-01-03T00:00:00Z" AND jsonPayload.project_id="test-project"`,
+		},
+		{
+			name:       "policy changes by resource",
+			method:     http.MethodGet,
+			url:        "/v1/policy_changes?resource=projects/test-project",
+			wantStatus: http.StatusOK,
+			wantBody:   `{"policy_changes":[]}`,
+			wantFilter: `jsonPayload.resource="projects/test-project"`,
+		},
+		{
+			name:       "invalid start",
+			method:     http.MethodGet,
+			url:        "/v1/actions?start=yesterday",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid limit",
+			method:     http.MethodGet,
+			url:        "/v1/actions?limit=-1",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "read only",
+			method:     http.MethodPost,
+			url:        "/v1/actions",
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			logStub := &stubs.LogAdminStub{StubbedEntries: tt.entries}
+			h := Handler(&Services{
+				History: services.NewHistory(logStub, "automation-project"),
+				Logger:  services.NewLogger(&stubs.LoggerStub{}),
+			})
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(tt.method, tt.url, nil))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("%s failed: got status %d, want %d", tt.name, w.Code, tt.wantStatus)
+			}
+			if tt.wantBody != "" {
+				var got, want interface{}
+				if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+					t.Fatalf("%s failed to decode response: %q", tt.name, err)
+				}
+				if err := json.Unmarshal([]byte(tt.wantBody), &want); err != nil {
+					t.Fatalf("%s failed to decode expected body: %q", tt.name, err)
+				}
+				if diff := cmp.Diff(want, got); diff != "" {
+					t.Errorf("%s failed, difference: %v", tt.name, diff)
+				}
+			}
+			if tt.wantFilter != "" && !strings.HasSuffix(logStub.SavedFilter, tt.wantFilter) {
+				t.Errorf("%s failed: filter %q does not end with %q", tt.name, logStub.SavedFilter, tt.wantFilter)
+			}
+		})
+	}
+}
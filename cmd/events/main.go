@@ -0,0 +1,87 @@
+// Command events runs the automations as a Cloud Run service, i.e. behind Eventarc triggers on
+// their topics, serving the health check and metrics for monitoring.
+package main
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"os"
+
+	exec "github.com/googlecloudplatform/security-response-automation"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/events"
+	"github.com/googlecloudplatform/security-response-automation/services"
+)
+
+// functions are the entry points in exec.go run for the events of their topics.
+var functions = map[string]events.Function{
+	"BlockEgress":                  exec.BlockEgress,
+	"DisableUnusedFirewall":        exec.DisableUnusedFirewall,
+	"CloseBucket":                  exec.CloseBucket,
+	"CloseCloudSQL":                exec.CloseCloudSQL,
+	"ClosePublicDataset":           exec.ClosePublicDataset,
+	"CloseStagingBucket":           exec.CloseStagingBucket,
+	"CloudSQLRequireSSL":           exec.CloudSQLRequireSSL,
+	"DisableDashboard":             exec.DisableDashboard,
+	"DisableIPForwarding":          exec.DisableIPForwarding,
+	"DisableKeyVersion":            exec.DisableKeyVersion,
+	"DisableSerialPort":            exec.DisableSerialPort,
+	"EnableAuditLogs":              exec.EnableAuditLogs,
+	"EnableBackups":                exec.EnableBackups,
+	"EnableBucketLogging":          exec.EnableBucketLogging,
+	"EnableBucketOnlyPolicy":       exec.EnableBucketOnlyPolicy,
+	"EnforceCMEK":                  exec.EnforceCMEK,
+	"EnforceHTTPS":                 exec.EnforceHTTPS,
+	"HardenClusterNetwork":         exec.HardenClusterNetwork,
+	"HardenInstance":               exec.HardenInstance,
+	"IAMRevoke":                    exec.IAMRevoke,
+	"IAMRevokeGrants":              exec.IAMRevokeGrants,
+	"QuarantineServiceAccount":     exec.QuarantineServiceAccount,
+	"IAMRevokeOrganization":        exec.IAMRevokeOrganization,
+	"LockdownProject":              exec.LockdownProject,
+	"OpenFirewall":                 exec.OpenFirewall,
+	"PollOperations":               exec.PollOperations,
+	"ProcessFindingExport":         exec.ProcessFindingExport,
+	"RemoveExternalGroupMembers":   exec.RemoveExternalGroupMembers,
+	"RemoveLoadBalancer":           exec.RemoveLoadBalancer,
+	"RemoveNonOrganizationMembers": exec.RemoveNonOrganizationMembers,
+	"RemovePublicIP":               exec.RemovePublicIP,
+	"ReplayDeadLetters":            exec.ReplayDeadLetters,
+	"RestoreContainment":           exec.RestoreContainment,
+	"RestrictSensitiveData":        exec.RestrictSensitiveData,
+	"Router":                       exec.Router,
+	"SecureManagedCluster":         exec.SecureManagedCluster,
+	"SecureRoot":                   exec.SecureRoot,
+	"SnapshotDisk":                 exec.SnapshotDisk,
+	"Sweep":                        exec.Sweep,
+	"UpdatePassword":               exec.UpdatePassword,
+}
+
+func main() {
+	svcs, err := services.New(context.Background())
+	if err != nil {
+		log.Fatalf("failed to initialize services: %q", err)
+	}
+	port := os.Getenv("PORT")
+	if port == "" {
+		port = "8080"
+	}
+	log.Printf("serving automations on port %s", port)
+	log.Fatal(http.ListenAndServe(":"+port, services.WithHealth(events.Handler(&events.Services{
+		Logger: svcs.Logger,
+	}, functions))))
+}
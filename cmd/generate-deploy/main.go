@@ -0,0 +1,399 @@
+// Command generate-deploy writes the infrastructure needed by the automations enabled in the
+// router configuration as Terraform or as a Deployment Manager template.
+package main
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"flag"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/router"
+)
+
+var (
+	config = flag.String("config", "cloudfunctions/router/config.yaml", "Path to the router configuration to generate the deployment of.")
+	format = flag.String("format", "terraform", "Output format, either terraform or deployment-manager.")
+	out    = flag.String("out", "", "File to write the deployment to instead of standard output.")
+)
+
+// binding is a role granted to the automation service account along with the actions needing it.
+type binding struct {
+	Role    string
+	Actions []string
+}
+
+// Name returns an identifier for the binding, i.e. "roles-compute-instanceadmin-v1".
+func (b binding) Name() string {
+	return strings.ToLower(dash(strings.Replace(b.Role, "/", "-", -1)))
+}
+
+// deployment holds what the templates render.
+type deployment struct {
+	Config            string
+	Deployments       []router.Deployment
+	FolderRoles       []binding
+	OrganizationRoles []binding
+	ProjectRoles      []binding
+	Approval          bool
+}
+
+func main() {
+	flag.Parse()
+	b, err := ioutil.ReadFile(*config)
+	if err != nil {
+		log.Fatalf("failed to read %q: %q", *config, err)
+	}
+	c, err := router.ParseConfig(b)
+	if err != nil {
+		log.Fatalf("%s: %s", *config, err)
+	}
+	var t *template.Template
+	switch *format {
+	case "terraform":
+		t = template.Must(template.New("terraform").Funcs(funcs).Parse(terraformTemplate))
+	case "deployment-manager":
+		t = template.Must(template.New("deployment-manager").Delims("[[", "]]").Funcs(funcs).Parse(deploymentManagerTemplate))
+	default:
+		log.Fatalf("format %q must be one of terraform or deployment-manager", *format)
+	}
+	var w io.Writer = os.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			log.Fatalf("failed to create %q: %q", *out, err)
+		}
+		defer f.Close()
+		w = f
+	}
+	if err := t.Execute(w, newDeployment(*config, router.Deployments(c))); err != nil {
+		log.Fatalf("failed to write deployment: %q", err)
+	}
+}
+
+// newDeployment groups the roles of the deployments by where they're granted. The automations share
+// a service account so each role is granted once.
+func newDeployment(config string, ds []router.Deployment) *deployment {
+	folder := map[string][]string{}
+	org := map[string][]string{}
+	project := map[string][]string{}
+	approval := false
+	for _, d := range ds {
+		for _, r := range d.FolderRoles {
+			folder[r] = append(folder[r], d.Action)
+		}
+		for _, r := range d.OrganizationRoles {
+			org[r] = append(org[r], d.Action)
+		}
+		for _, r := range d.ProjectRoles {
+			project[r] = append(project[r], d.Action)
+		}
+		approval = approval || d.Approval
+	}
+	return &deployment{
+		Config:            config,
+		Deployments:       ds,
+		FolderRoles:       bindings(folder),
+		OrganizationRoles: bindings(org),
+		ProjectRoles:      bindings(project),
+		Approval:          approval,
+	}
+}
+
+// bindings returns the roles sorted by name.
+func bindings(roles map[string][]string) []binding {
+	bs := make([]binding, 0, len(roles))
+	for role, actions := range roles {
+		bs = append(bs, binding{Role: role, Actions: actions})
+	}
+	sort.Slice(bs, func(i, j int) bool { return bs[i].Role < bs[j].Role })
+	return bs
+}
+
+// dash replaces the underscores and dots of a name with dashes, i.e. for topic or job names.
+func dash(name string) string {
+	return strings.NewReplacer("_", "-", ".", "-").Replace(name)
+}
+
+var funcs = template.FuncMap{
+	"dash": dash,
+	"join": strings.Join,
+}
+
+const terraformTemplate = `# Generated by cmd/generate-deploy from {{.Config}}. Do not edit.
+variable "setup" {}
+
+variable "folder-ids" {
+  type        = list(string)
+  description = "Folder IDs the automations apply to."
+}
+{{- if .OrganizationRoles}}
+
+variable "organization-id" {
+  type        = string
+  description = "Organization ID the automations apply to."
+}
+{{- end}}
+{{- if .Approval}}
+
+variable "approvers" {
+  type        = list(string)
+  description = "Emails of the people allowed to approve automations waiting for approval."
+}
+{{- end}}
+{{range .Deployments}}
+resource "google_pubsub_topic" "{{.Action}}" {
+  name    = "{{.Topic}}"
+  project = var.setup.automation-project
+}
+
+resource "google_cloudfunctions_function" "{{.Action}}" {
+  name                  = "{{.Function}}"
+  description           = "{{.Description}}"
+  runtime               = "go121"
+  available_memory_mb   = 128
+  source_archive_bucket = var.setup.gcf-bucket-name
+  source_archive_object = var.setup.gcf-object-name
+  timeout               = {{.Timeout}}
+  project               = var.setup.automation-project
+  region                = var.setup.region
+  entry_point           = "{{.Function}}"
+
+  event_trigger {
+    event_type = "providers/cloud.pubsub/eventTypes/topic.publish"
+    resource   = google_pubsub_topic.{{.Action}}.name
+{{- if not .Schedule}}
+
+    failure_policy {
+      retry = true
+    }
+{{- end}}
+  }
+{{- if .Approval}}
+
+  environment_variables = {
+    APPROVAL_TOPIC = var.setup.approval-topic
+    APPROVERS      = join(",", var.approvers)
+  }
+{{- end}}
+}
+{{- if .Schedule}}
+
+resource "google_cloud_scheduler_job" "{{.Action}}" {
+  name     = "{{dash .Action}}"
+  project  = var.setup.automation-project
+  region   = var.setup.region
+  schedule = "{{.Schedule}}"
+
+  pubsub_target {
+    topic_name = google_pubsub_topic.{{.Action}}.id
+    data       = base64encode("{}")
+  }
+}
+{{- end}}
+{{end}}
+{{- if .Approval}}
+resource "google_cloudfunctions_function" "approve" {
+  name                  = "Approve"
+  description           = "Records approvals of automations waiting to be approved."
+  runtime               = "go121"
+  available_memory_mb   = 128
+  source_archive_bucket = var.setup.gcf-bucket-name
+  source_archive_object = var.setup.gcf-object-name
+  timeout               = 60
+  project               = var.setup.automation-project
+  region                = var.setup.region
+  entry_point           = "Approve"
+  trigger_http          = true
+
+  environment_variables = {
+    APPROVAL_TOPIC    = var.setup.approval-topic
+    APPROVAL_AUDIENCE = "https://${var.setup.region}-${var.setup.automation-project}.cloudfunctions.net/Approve"
+    APPROVERS         = join(",", var.approvers)
+  }
+}
+
+# Only approvers may call the function, their ID token is verified again by the function.
+resource "google_cloudfunctions_function_iam_member" "approve" {
+  count = length(var.approvers)
+
+  project        = var.setup.automation-project
+  region         = var.setup.region
+  cloud_function = google_cloudfunctions_function.approve.name
+  role           = "roles/cloudfunctions.invoker"
+  member         = "user:${var.approvers[count.index]}"
+}
+{{end}}
+{{- range .FolderRoles}}
+# Required by {{join .Actions ", "}}.
+resource "google_folder_iam_member" "{{.Name}}" {
+  count = length(var.folder-ids)
+
+  folder = "folders/${var.folder-ids[count.index]}"
+  role   = "{{.Role}}"
+  member = "serviceAccount:${var.setup.automation-service-account}"
+}
+{{end}}
+{{- range .OrganizationRoles}}
+# Required by {{join .Actions ", "}}.
+resource "google_organization_iam_member" "{{.Name}}" {
+  org_id = var.organization-id
+  role   = "{{.Role}}"
+  member = "serviceAccount:${var.setup.automation-service-account}"
+}
+{{end}}
+{{- range .ProjectRoles}}
+# Required by {{join .Actions ", "}}.
+resource "google_project_iam_member" "{{.Name}}" {
+  project = var.setup.automation-project
+  role    = "{{.Role}}"
+  member  = "serviceAccount:${var.setup.automation-service-account}"
+}
+{{end}}`
+
+const deploymentManagerTemplate = `{# Generated by cmd/generate-deploy from [[.Config]]. Do not edit. #}
+{#
+  Properties:
+    automation-project: project the functions are deployed to.
+    region: region the functions are deployed to.
+    source-archive-url: gs:// URL of the zipped function source.
+    automation-service-account: service account the functions run as.
+    folder-ids: folder IDs the automations apply to.
+[[- if .OrganizationRoles]]
+    organization-id: organization ID the automations apply to.
+[[- end]]
+[[- if .Approval]]
+    approval-topic: topic approval requests are published to.
+    approvers: emails of the people allowed to approve automations waiting for approval.
+[[- end]]
+#}
+{% set project = properties["automation-project"] %}
+{% set region = properties["region"] %}
+{% set member = "serviceAccount:" + properties["automation-service-account"] %}
+resources:
+[[- range .Deployments]]
+- name: [[dash .Action]]-topic
+  type: gcp-types/pubsub-v1:projects.topics
+  properties:
+    topic: [[.Topic]]
+- name: [[dash .Action]]-function
+  type: gcp-types/cloudfunctions-v1:projects.locations.functions
+  properties:
+    parent: projects/{{ project }}/locations/{{ region }}
+    function: [[.Function]]
+    description: "[[.Description]]"
+    runtime: go121
+    availableMemoryMb: 128
+    sourceArchiveUrl: {{ properties["source-archive-url"] }}
+    timeout: [[.Timeout]]s
+    entryPoint: [[.Function]]
+    serviceAccountEmail: {{ properties["automation-service-account"] }}
+    eventTrigger:
+      eventType: providers/cloud.pubsub/eventTypes/topic.publish
+      resource: projects/{{ project }}/topics/[[.Topic]]
+[[- if not .Schedule]]
+      failurePolicy:
+        retry: {}
+[[- end]]
+[[- if .Approval]]
+    environmentVariables:
+      APPROVAL_TOPIC: {{ properties["approval-topic"] }}
+      APPROVERS: {{ properties["approvers"] | join(",") }}
+[[- end]]
+  metadata:
+    dependsOn:
+    - [[dash .Action]]-topic
+[[- if .Schedule]]
+- name: [[dash .Action]]-job
+  type: gcp-types/cloudscheduler-v1:projects.locations.jobs
+  properties:
+    parent: projects/{{ project }}/locations/{{ region }}
+    name: projects/{{ project }}/locations/{{ region }}/jobs/[[dash .Action]]
+    schedule: "[[.Schedule]]"
+    pubsubTarget:
+      topicName: projects/{{ project }}/topics/[[.Topic]]
+      data: e30=
+  metadata:
+    dependsOn:
+    - [[dash .Action]]-topic
+[[- end]]
+[[- end]]
+[[- if .Approval]]
+- name: approve-function
+  type: gcp-types/cloudfunctions-v1:projects.locations.functions
+  properties:
+    parent: projects/{{ project }}/locations/{{ region }}
+    function: Approve
+    description: "Records approvals of automations waiting to be approved."
+    runtime: go121
+    availableMemoryMb: 128
+    sourceArchiveUrl: {{ properties["source-archive-url"] }}
+    timeout: 60s
+    entryPoint: Approve
+    serviceAccountEmail: {{ properties["automation-service-account"] }}
+    httpsTrigger: {}
+    environmentVariables:
+      APPROVAL_TOPIC: {{ properties["approval-topic"] }}
+      APPROVAL_AUDIENCE: https://{{ region }}-{{ project }}.cloudfunctions.net/Approve
+      APPROVERS: {{ properties["approvers"] | join(",") }}
+{% for approver in properties["approvers"] %}
+# Only approvers may call the function, their ID token is verified again by the function.
+- name: approve-invoker-{{ loop.index }}
+  type: gcp-types/cloudfunctions-v1:virtual.projects.locations.functions.iamMemberBinding
+  properties:
+    resource: projects/{{ project }}/locations/{{ region }}/functions/Approve
+    role: roles/cloudfunctions.invoker
+    member: user:{{ approver }}
+  metadata:
+    dependsOn:
+    - approve-function
+{% endfor %}
+[[- end]]
+{% for folder in properties["folder-ids"] %}
+[[- range .FolderRoles]]
+# Required by [[join .Actions ", "]].
+- name: [[.Name]]-{{ folder }}
+  type: gcp-types/cloudresourcemanager-v2:virtual.folders.iamMemberBinding
+  properties:
+    resource: folders/{{ folder }}
+    role: [[.Role]]
+    member: {{ member }}
+[[- end]]
+{% endfor %}
+[[- range .OrganizationRoles]]
+# Required by [[join .Actions ", "]].
+- name: [[.Name]]-organization
+  type: gcp-types/cloudresourcemanager-v1:virtual.organizations.iamMemberBinding
+  properties:
+    resource: organizations/{{ properties["organization-id"] }}
+    role: [[.Role]]
+    member: {{ member }}
+[[- end]]
+[[- range .ProjectRoles]]
+# Required by [[join .Actions ", "]].
+- name: [[.Name]]-project
+  type: gcp-types/cloudresourcemanager-v1:virtual.projects.iamMemberBinding
+  properties:
+    resource: {{ project }}
+    role: [[.Role]]
+    member: {{ member }}
+[[- end]]
+`
@@ -0,0 +1,73 @@
+// Command permissions prints the roles and IAM permissions the automation service account needs for
+// each automation enabled in the router configuration.
+package main
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/router"
+)
+
+var config = flag.String("config", "cloudfunctions/router/config.yaml", "Path to the router configuration to list the permissions of.")
+
+func main() {
+	flag.Parse()
+	b, err := ioutil.ReadFile(*config)
+	if err != nil {
+		log.Fatalf("failed to read %q: %q", *config, err)
+	}
+	c, err := router.ParseConfig(b)
+	if err != nil {
+		log.Fatalf("%s: %s", *config, err)
+	}
+	all := map[string]bool{}
+	for _, d := range router.Deployments(c) {
+		fmt.Printf("%s (%s)\n", d.Action, d.Function)
+		printRoles("folder roles", d.FolderRoles)
+		printRoles("organization roles", d.OrganizationRoles)
+		printRoles("automation project roles", d.ProjectRoles)
+		if len(d.Permissions) > 0 {
+			fmt.Println("  permissions on each target project:")
+		}
+		for _, p := range d.Permissions {
+			fmt.Printf("    %s\n", p)
+			all[p] = true
+		}
+		fmt.Println()
+	}
+	permissions := make([]string, 0, len(all))
+	for p := range all {
+		permissions = append(permissions, p)
+	}
+	sort.Strings(permissions)
+	fmt.Printf("all permissions on each target project (%d):\n", len(permissions))
+	for _, p := range permissions {
+		fmt.Printf("  %s\n", p)
+	}
+}
+
+// printRoles prints the roles granted at a scope, if any.
+func printRoles(scope string, roles []string) {
+	if len(roles) > 0 {
+		fmt.Printf("  %s: %s\n", scope, strings.Join(roles, ", "))
+	}
+}
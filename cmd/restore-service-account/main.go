@@ -0,0 +1,79 @@
+// Command restore-service-account re-grants the bindings removed from a service account when it was
+// quarantined and enables it again once the incident is closed.
+package main
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"flag"
+	"io/ioutil"
+	"log"
+	"strings"
+
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/iam/quarantineserviceaccount"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/router"
+	"github.com/googlecloudplatform/security-response-automation/services"
+)
+
+var (
+	config         = flag.String("config", "cloudfunctions/router/config.yaml", "Path to the router configuration whose allowed domains and members the bindings are validated against.")
+	projectID      = flag.String("project_id", "", "Project the service account was quarantined in.")
+	serviceAccount = flag.String("service_account", "", "Email of the quarantined service account.")
+	allowMembers   = flag.String("allow_members", "", "Comma separated members restored even if they're outside the allowed domains.")
+	dryRun         = flag.Bool("dry_run", false, "Only print the bindings that would be restored.")
+)
+
+func main() {
+	flag.Parse()
+	if *projectID == "" || *serviceAccount == "" {
+		log.Fatalf("-project_id and -service_account must be set")
+	}
+	b, err := ioutil.ReadFile(*config)
+	if err != nil {
+		log.Fatalf("failed to read %q: %q", *config, err)
+	}
+	c, err := router.ParseConfig(b)
+	if err != nil {
+		log.Fatalf("%s: %s", *config, err)
+	}
+	domains, members := router.AllowedMembers(c)
+	if *allowMembers != "" {
+		members = append(members, strings.Split(*allowMembers, ",")...)
+	}
+
+	ctx := context.Background()
+	svcs, err := services.New(ctx)
+	if err != nil {
+		log.Fatalf("failed to initialize services: %q", err)
+	}
+	err = quarantineserviceaccount.Restore(ctx, &quarantineserviceaccount.RestoreValues{
+		ProjectID:      *projectID,
+		ServiceAccount: *serviceAccount,
+		AllowDomains:   domains,
+		AllowMembers:   members,
+		DryRun:         *dryRun,
+	}, &quarantineserviceaccount.Services{
+		Resource:        svcs.Resource,
+		ServiceAccounts: svcs.ServiceAccounts,
+		State:           svcs.State,
+		Logger:          svcs.Logger,
+	})
+	// Audit records are buffered by the logger so it's flushed before exiting.
+	svcs.Logger.Close()
+	if err != nil {
+		log.Fatalf("%s", err)
+	}
+}
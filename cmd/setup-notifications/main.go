@@ -0,0 +1,95 @@
+// Command setup-notifications creates or updates the Security Command Center notification configs
+// and the Pub/Sub topic sending the findings handled by the router configuration to the router.
+package main
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"strings"
+
+	"github.com/googlecloudplatform/security-response-automation/clients"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/router"
+	"github.com/googlecloudplatform/security-response-automation/services"
+)
+
+var (
+	config         = flag.String("config", "cloudfunctions/router/config.yaml", "Path to the router configuration to send the findings of.")
+	credentials    = flag.String("credentials", "credentials/auth.json", "Service account key used to create the notification configs and topic.")
+	organizationID = flag.String("organization_id", "", "Organization whose findings are sent to the router.")
+	projectID      = flag.String("project_id", "", "Automation project where the router is installed.")
+	topic          = flag.String("topic", "threat-findings", "Topic the router receives findings from.")
+	prefix         = flag.String("prefix", "sra-", "Prefix of the IDs of the notification configs, followed by the provider.")
+	severities     = flag.String("severities", "", "Comma separated severities of the findings to send, all if empty.")
+	dryRun         = flag.Bool("dry_run", false, "Only print the notification configs without creating them.")
+)
+
+func main() {
+	flag.Parse()
+	if *organizationID == "" || *projectID == "" {
+		log.Fatalf("-organization_id and -project_id must be set")
+	}
+	b, err := ioutil.ReadFile(*config)
+	if err != nil {
+		log.Fatalf("failed to read %q: %q", *config, err)
+	}
+	c, err := router.ParseConfig(b)
+	if err != nil {
+		log.Fatalf("%s: %s", *config, err)
+	}
+	var sev []string
+	if *severities != "" {
+		sev = strings.Split(strings.ToUpper(*severities), ",")
+	}
+	filters := router.NotificationFilters(c, sev)
+	if len(filters) == 0 {
+		fmt.Printf("%s: no automations of Security Command Center findings are configured\n", *config)
+		return
+	}
+	if *dryRun {
+		for _, f := range filters {
+			fmt.Printf("%s%s: %s\n", *prefix, f.Provider, f.Filter)
+		}
+		return
+	}
+
+	ctx := context.Background()
+	nc, err := clients.NewNotificationConfigs(ctx, *credentials)
+	if err != nil {
+		log.Fatalf("failed to initialize notification configs client: %q", err)
+	}
+	ps, err := clients.NewPubSub(ctx, *credentials, *projectID)
+	if err != nil {
+		log.Fatalf("failed to initialize pubsub client: %q", err)
+	}
+	n := services.NewNotifications(nc, ps)
+	change, err := n.EnsureTopic(ctx, *topic)
+	if err != nil {
+		log.Fatalf("%s", err)
+	}
+	fmt.Printf("topic %q: %s\n", *topic, change)
+	for _, f := range filters {
+		id := *prefix + f.Provider
+		change, err := n.EnsureNotificationConfig(ctx, *organizationID, id, *projectID, *topic, f.Filter)
+		if err != nil {
+			log.Fatalf("%s", err)
+		}
+		fmt.Printf("notification config %q: %s, %d categories\n", id, change, len(f.Categories))
+	}
+}
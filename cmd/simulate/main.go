@@ -0,0 +1,245 @@
+// Command simulate replays historical findings through the automations of the router
+// configuration in dry run mode and reports what each automation would have done.
+package main
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/googlecloudplatform/security-response-automation/clients"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/router"
+	"github.com/googlecloudplatform/security-response-automation/services"
+	"google.golang.org/api/iterator"
+)
+
+var (
+	config      = flag.String("config", "cloudfunctions/router/config.yaml", "Path to the router configuration to simulate.")
+	credentials = flag.String("credentials", "credentials/auth.json", "Service account key used to look up the ancestry and permissions of projects.")
+	findings    = flag.String("findings", "", "File or directory of findings to replay, each file holding one finding or one per line.")
+	query       = flag.String("query", "", "BigQuery query returning the findings to replay as a single string column of JSON.")
+	project     = flag.String("project", "", "Project to run the BigQuery query in.")
+	format      = flag.String("format", "text", "Report format, text or json.")
+	verbose     = flag.Bool("verbose", false, "Print the router's logs.")
+)
+
+// finding is a finding to replay along with where it was read from.
+type finding struct {
+	Source string          `json:"source"`
+	Data   json.RawMessage `json:"-"`
+}
+
+// result holds the outcomes of the automations of a finding.
+type result struct {
+	finding
+	Outcomes []router.Outcome `json:"outcomes"`
+	Error    string           `json:"error,omitempty"`
+}
+
+func main() {
+	flag.Parse()
+	if (*findings == "") == (*query == "") {
+		log.Fatalf("either -findings or -query must be set")
+	}
+	if *format != "text" && *format != "json" {
+		log.Fatalf("unknown format %q", *format)
+	}
+	b, err := ioutil.ReadFile(*config)
+	if err != nil {
+		log.Fatalf("failed to read %q: %q", *config, err)
+	}
+	c, err := router.ParseConfig(b)
+	if err != nil {
+		log.Fatalf("%s: %s", *config, err)
+	}
+	router.ForceDryRun(c)
+
+	ctx := context.Background()
+	var fs []finding
+	if *findings != "" {
+		fs, err = readFiles(*findings)
+	} else {
+		fs, err = readQuery(ctx, *project, *query)
+	}
+	if err != nil {
+		log.Fatalf("failed to read findings: %q", err)
+	}
+	crm, err := clients.NewCloudResourceManager(ctx, *credentials)
+	if err != nil {
+		log.Fatalf("failed to initialize cloud resource manager client: %q", err)
+	}
+	tags, err := clients.NewTags(ctx, *credentials)
+	if err != nil {
+		log.Fatalf("failed to initialize tags client: %q", err)
+	}
+	res := services.NewResource(crm, nil)
+	svcs := &router.Services{
+		Configuration: c,
+		Logger:        services.NewLogger(&logger{}),
+		Resource:      res,
+		Tags:          services.NewTags(tags, res),
+	}
+	if !*verbose {
+		log.SetOutput(ioutil.Discard)
+	}
+	results := make([]result, 0, len(fs))
+	for _, f := range fs {
+		outcomes, err := router.Simulate(ctx, &router.Values{Finding: f.Data}, svcs)
+		r := result{finding: f, Outcomes: outcomes}
+		if err != nil {
+			r.Error = err.Error()
+		}
+		results = append(results, r)
+	}
+	if *format == "json" {
+		writeJSON(os.Stdout, results)
+		return
+	}
+	writeText(os.Stdout, results)
+}
+
+// readFiles reads the findings of the file or of each JSON file in the directory.
+func readFiles(path string) ([]finding, error) {
+	files := []string{path}
+	if fi, err := os.Stat(path); err != nil {
+		return nil, err
+	} else if fi.IsDir() {
+		if files, err = filepath.Glob(filepath.Join(path, "*.json")); err != nil {
+			return nil, err
+		}
+	}
+	var fs []finding
+	for _, file := range files {
+		b, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		d := json.NewDecoder(bytes.NewReader(b))
+		for i := 1; ; i++ {
+			var raw json.RawMessage
+			if err := d.Decode(&raw); err == io.EOF {
+				break
+			} else if err != nil {
+				return nil, fmt.Errorf("%s: finding %d: %s", file, i, err)
+			}
+			fs = append(fs, finding{Source: fmt.Sprintf("%s#%d", file, i), Data: raw})
+		}
+	}
+	return fs, nil
+}
+
+// readQuery reads the findings returned by the query, one per row.
+func readQuery(ctx context.Context, projectID, q string) ([]finding, error) {
+	bq, err := clients.NewBigQuery(ctx, *credentials, projectID)
+	if err != nil {
+		return nil, err
+	}
+	it, err := bq.Query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	var fs []finding
+	for i := 1; ; i++ {
+		var row []bigquery.Value
+		if err := it.Next(&row); err == iterator.Done {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		var s string
+		if len(row) == 1 {
+			s, _ = row[0].(string)
+		}
+		if s == "" {
+			return nil, fmt.Errorf("row %d: expected a single string column, got %v", i, row)
+		}
+		fs = append(fs, finding{Source: fmt.Sprintf("row %d", i), Data: json.RawMessage(s)})
+	}
+	return fs, nil
+}
+
+func writeJSON(w io.Writer, results []result) {
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+	if err := e.Encode(results); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write report: %q\n", err)
+		os.Exit(1)
+	}
+}
+
+// writeText writes the outcomes of each finding followed by how often each automation would have
+// had each result.
+func writeText(w io.Writer, results []result) {
+	totals := map[string]map[string]int{}
+	for _, r := range results {
+		switch {
+		case r.Error != "":
+			fmt.Fprintf(w, "%s: error: %s\n", r.Source, r.Error)
+		case len(r.Outcomes) == 0:
+			fmt.Fprintf(w, "%s: no automations\n", r.Source)
+		}
+		for _, o := range r.Outcomes {
+			fmt.Fprintf(w, "%s: %s %s on %s: %s", r.Source, o.Rule, o.Action, o.Resource, o.Result)
+			if o.Reason != "" {
+				fmt.Fprintf(w, " (%s)", o.Reason)
+			}
+			if o.Result == router.OutcomeRun {
+				fmt.Fprintf(w, " %s", o.Values)
+			}
+			fmt.Fprintln(w)
+			if totals[o.Action] == nil {
+				totals[o.Action] = map[string]int{}
+			}
+			totals[o.Action][o.Result]++
+		}
+	}
+	actions := make([]string, 0, len(totals))
+	for a := range totals {
+		actions = append(actions, a)
+	}
+	sort.Strings(actions)
+	fmt.Fprintf(w, "\n%d findings replayed\n", len(results))
+	for _, a := range actions {
+		counts := []string{}
+		for _, res := range []string{router.OutcomeRun, router.OutcomeSkipped, router.OutcomeExempted, router.OutcomePermissionDenied, router.OutcomeFailed} {
+			if n := totals[a][res]; n > 0 {
+				counts = append(counts, fmt.Sprintf("%d %s", n, res))
+			}
+		}
+		fmt.Fprintf(w, "  %s: %s\n", a, strings.Join(counts, ", "))
+	}
+}
+
+// logger prints the router's logs if -verbose is set and drops its audit records.
+type logger struct{}
+
+func (l *logger) Info(message string, a ...interface{})    { log.Printf(message, a...) }
+func (l *logger) Warning(message string, a ...interface{}) { log.Printf(message, a...) }
+func (l *logger) Error(message string, a ...interface{})   { log.Printf(message, a...) }
+func (l *logger) Debug(message string, a ...interface{})   { log.Printf(message, a...) }
+func (l *logger) Audit(payload interface{})                {}
+func (l *logger) Close()                                   {}
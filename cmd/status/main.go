@@ -0,0 +1,51 @@
+// Command status serves the read-only remediation history API, i.e. on Cloud Run.
+package main
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"os"
+
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/status/history"
+	"github.com/googlecloudplatform/security-response-automation/services"
+)
+
+func main() {
+	ctx := context.Background()
+	projectID := os.Getenv("GCP_PROJECT")
+	if projectID == "" {
+		log.Fatalf("GCP_PROJECT environment variable not set")
+	}
+	svcs, err := services.New(ctx)
+	if err != nil {
+		log.Fatalf("failed to initialize services: %q", err)
+	}
+	h, err := services.InitHistory(ctx, projectID)
+	if err != nil {
+		log.Fatalf("failed to initialize history: %q", err)
+	}
+	port := os.Getenv("PORT")
+	if port == "" {
+		port = "8080"
+	}
+	log.Printf("serving remediation history on port %s", port)
+	log.Fatal(http.ListenAndServe(":"+port, services.WithHealth(history.Handler(&history.Services{
+		History: h,
+		Logger:  svcs.Logger,
+	}))))
+}
@@ -0,0 +1,63 @@
+// Command validate-config reports misconfigured automations before they are deployed.
+package main
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+
+	"github.com/googlecloudplatform/security-response-automation/clients"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/router"
+	"github.com/googlecloudplatform/security-response-automation/services"
+)
+
+var (
+	config      = flag.String("config", "cloudfunctions/router/config.yaml", "Path to the router configuration to validate.")
+	credentials = flag.String("credentials", "credentials/auth.json", "Service account key used to verify the targets are visible.")
+	skipScopes  = flag.Bool("skip_scopes", false, "Only validate the configuration without verifying its targets.")
+)
+
+func main() {
+	flag.Parse()
+	b, err := ioutil.ReadFile(*config)
+	if err != nil {
+		log.Fatalf("failed to read %q: %q", *config, err)
+	}
+	c, err := router.ParseConfig(b)
+	if err != nil {
+		log.Fatalf("%s: %s", *config, err)
+	}
+	problems := router.Validate(c)
+	if !*skipScopes {
+		ctx := context.Background()
+		crm, err := clients.NewCloudResourceManager(ctx, *credentials)
+		if err != nil {
+			log.Fatalf("failed to initialize cloud resource manager client: %q", err)
+		}
+		problems = append(problems, router.CheckScopes(ctx, c, services.NewResource(crm, nil))...)
+	}
+	for _, p := range problems {
+		fmt.Printf("%s: %s\n", *config, p)
+	}
+	if len(problems) > 0 {
+		os.Exit(1)
+	}
+	fmt.Printf("%s: ok\n", *config)
+}
@@ -0,0 +1,304 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: dlp/protos/dlp.proto
+
+package dlp
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type SensitiveData struct {
+	NotificationConfigName string                 `protobuf:"bytes,1,opt,name=notificationConfigName,proto3" json:"notificationConfigName,omitempty"`
+	Finding                *SensitiveData_Finding `protobuf:"bytes,2,opt,name=finding,proto3" json:"finding,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{}               `json:"-"`
+	XXX_unrecognized       []byte                 `json:"-"`
+	XXX_sizecache          int32                  `json:"-"`
+}
+
+func (m *SensitiveData) Reset()         { *m = SensitiveData{} }
+func (m *SensitiveData) String() string { return proto.CompactTextString(m) }
+func (*SensitiveData) ProtoMessage()    {}
+func (*SensitiveData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_30d1f9bbefb728c4, []int{0}
+}
+
+func (m *SensitiveData) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SensitiveData.Unmarshal(m, b)
+}
+func (m *SensitiveData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SensitiveData.Marshal(b, m, deterministic)
+}
+func (m *SensitiveData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SensitiveData.Merge(m, src)
+}
+func (m *SensitiveData) XXX_Size() int {
+	return xxx_messageInfo_SensitiveData.Size(m)
+}
+func (m *SensitiveData) XXX_DiscardUnknown() {
+	xxx_messageInfo_SensitiveData.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SensitiveData proto.InternalMessageInfo
+
+func (m *SensitiveData) GetNotificationConfigName() string {
+	if m != nil {
+		return m.NotificationConfigName
+	}
+	return ""
+}
+
+func (m *SensitiveData) GetFinding() *SensitiveData_Finding {
+	if m != nil {
+		return m.Finding
+	}
+	return nil
+}
+
+type SensitiveData_SecurityMarks struct {
+	Marks                map[string]string `protobuf:"bytes,1,rep,name=marks,proto3" json:"marks,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *SensitiveData_SecurityMarks) Reset()         { *m = SensitiveData_SecurityMarks{} }
+func (m *SensitiveData_SecurityMarks) String() string { return proto.CompactTextString(m) }
+func (*SensitiveData_SecurityMarks) ProtoMessage()    {}
+func (*SensitiveData_SecurityMarks) Descriptor() ([]byte, []int) {
+	return fileDescriptor_30d1f9bbefb728c4, []int{0, 0}
+}
+
+func (m *SensitiveData_SecurityMarks) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SensitiveData_SecurityMarks.Unmarshal(m, b)
+}
+func (m *SensitiveData_SecurityMarks) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SensitiveData_SecurityMarks.Marshal(b, m, deterministic)
+}
+func (m *SensitiveData_SecurityMarks) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SensitiveData_SecurityMarks.Merge(m, src)
+}
+func (m *SensitiveData_SecurityMarks) XXX_Size() int {
+	return xxx_messageInfo_SensitiveData_SecurityMarks.Size(m)
+}
+func (m *SensitiveData_SecurityMarks) XXX_DiscardUnknown() {
+	xxx_messageInfo_SensitiveData_SecurityMarks.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SensitiveData_SecurityMarks proto.InternalMessageInfo
+
+func (m *SensitiveData_SecurityMarks) GetMarks() map[string]string {
+	if m != nil {
+		return m.Marks
+	}
+	return nil
+}
+
+type SensitiveData_SourceProperties struct {
+	ProjectId            string   `protobuf:"bytes,1,opt,name=projectId,proto3" json:"projectId,omitempty"`
+	InfoType             string   `protobuf:"bytes,2,opt,name=infoType,proto3" json:"infoType,omitempty"`
+	Likelihood           string   `protobuf:"bytes,3,opt,name=likelihood,proto3" json:"likelihood,omitempty"`
+	JobName              string   `protobuf:"bytes,4,opt,name=jobName,proto3" json:"jobName,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SensitiveData_SourceProperties) Reset()         { *m = SensitiveData_SourceProperties{} }
+func (m *SensitiveData_SourceProperties) String() string { return proto.CompactTextString(m) }
+func (*SensitiveData_SourceProperties) ProtoMessage()    {}
+func (*SensitiveData_SourceProperties) Descriptor() ([]byte, []int) {
+	return fileDescriptor_30d1f9bbefb728c4, []int{0, 1}
+}
+
+func (m *SensitiveData_SourceProperties) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SensitiveData_SourceProperties.Unmarshal(m, b)
+}
+func (m *SensitiveData_SourceProperties) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SensitiveData_SourceProperties.Marshal(b, m, deterministic)
+}
+func (m *SensitiveData_SourceProperties) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SensitiveData_SourceProperties.Merge(m, src)
+}
+func (m *SensitiveData_SourceProperties) XXX_Size() int {
+	return xxx_messageInfo_SensitiveData_SourceProperties.Size(m)
+}
+func (m *SensitiveData_SourceProperties) XXX_DiscardUnknown() {
+	xxx_messageInfo_SensitiveData_SourceProperties.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SensitiveData_SourceProperties proto.InternalMessageInfo
+
+func (m *SensitiveData_SourceProperties) GetProjectId() string {
+	if m != nil {
+		return m.ProjectId
+	}
+	return ""
+}
+
+func (m *SensitiveData_SourceProperties) GetInfoType() string {
+	if m != nil {
+		return m.InfoType
+	}
+	return ""
+}
+
+func (m *SensitiveData_SourceProperties) GetLikelihood() string {
+	if m != nil {
+		return m.Likelihood
+	}
+	return ""
+}
+
+func (m *SensitiveData_SourceProperties) GetJobName() string {
+	if m != nil {
+		return m.JobName
+	}
+	return ""
+}
+
+type SensitiveData_Finding struct {
+	SourceProperties     *SensitiveData_SourceProperties `protobuf:"bytes,1,opt,name=sourceProperties,proto3" json:"sourceProperties,omitempty"`
+	Category             string                          `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
+	ResourceName         string                          `protobuf:"bytes,3,opt,name=resourceName,proto3" json:"resourceName,omitempty"`
+	State                string                          `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"`
+	SecurityMarks        *SensitiveData_SecurityMarks    `protobuf:"bytes,5,opt,name=securityMarks,proto3" json:"securityMarks,omitempty"`
+	EventTime            string                          `protobuf:"bytes,6,opt,name=eventTime,proto3" json:"eventTime,omitempty"`
+	Name                 string                          `protobuf:"bytes,7,opt,name=name,proto3" json:"name,omitempty"`
+	Severity             string                          `protobuf:"bytes,8,opt,name=severity,proto3" json:"severity,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
+	XXX_unrecognized     []byte                          `json:"-"`
+	XXX_sizecache        int32                           `json:"-"`
+}
+
+func (m *SensitiveData_Finding) Reset()         { *m = SensitiveData_Finding{} }
+func (m *SensitiveData_Finding) String() string { return proto.CompactTextString(m) }
+func (*SensitiveData_Finding) ProtoMessage()    {}
+func (*SensitiveData_Finding) Descriptor() ([]byte, []int) {
+	return fileDescriptor_30d1f9bbefb728c4, []int{0, 2}
+}
+
+func (m *SensitiveData_Finding) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SensitiveData_Finding.Unmarshal(m, b)
+}
+func (m *SensitiveData_Finding) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SensitiveData_Finding.Marshal(b, m, deterministic)
+}
+func (m *SensitiveData_Finding) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SensitiveData_Finding.Merge(m, src)
+}
+func (m *SensitiveData_Finding) XXX_Size() int {
+	return xxx_messageInfo_SensitiveData_Finding.Size(m)
+}
+func (m *SensitiveData_Finding) XXX_DiscardUnknown() {
+	xxx_messageInfo_SensitiveData_Finding.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SensitiveData_Finding proto.InternalMessageInfo
+
+func (m *SensitiveData_Finding) GetSourceProperties() *SensitiveData_SourceProperties {
+	if m != nil {
+		return m.SourceProperties
+	}
+	return nil
+}
+
+func (m *SensitiveData_Finding) GetCategory() string {
+	if m != nil {
+		return m.Category
+	}
+	return ""
+}
+
+func (m *SensitiveData_Finding) GetResourceName() string {
+	if m != nil {
+		return m.ResourceName
+	}
+	return ""
+}
+
+func (m *SensitiveData_Finding) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *SensitiveData_Finding) GetSecurityMarks() *SensitiveData_SecurityMarks {
+	if m != nil {
+		return m.SecurityMarks
+	}
+	return nil
+}
+
+func (m *SensitiveData_Finding) GetEventTime() string {
+	if m != nil {
+		return m.EventTime
+	}
+	return ""
+}
+
+func (m *SensitiveData_Finding) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *SensitiveData_Finding) GetSeverity() string {
+	if m != nil {
+		return m.Severity
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*SensitiveData)(nil), "SensitiveData")
+	proto.RegisterType((*SensitiveData_SecurityMarks)(nil), "SensitiveData.SecurityMarks")
+	proto.RegisterMapType((map[string]string)(nil), "SensitiveData.SecurityMarks.MarksEntry")
+	proto.RegisterType((*SensitiveData_SourceProperties)(nil), "SensitiveData.SourceProperties")
+	proto.RegisterType((*SensitiveData_Finding)(nil), "SensitiveData.Finding")
+}
+
+func init() { proto.RegisterFile("dlp/protos/dlp.proto", fileDescriptor_30d1f9bbefb728c4) }
+
+var fileDescriptor_30d1f9bbefb728c4 = []byte{
+	// 400 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x92, 0xdd, 0x6a, 0xdb, 0x30,
+	0x14, 0xc7, 0x71, 0x52, 0xd7, 0xcd, 0xc9, 0x0a, 0x41, 0x94, 0x22, 0x4c, 0xd9, 0x42, 0x6f, 0x96,
+	0x2b, 0x77, 0x64, 0x30, 0xca, 0x60, 0x37, 0xfb, 0x82, 0x31, 0x36, 0x86, 0x93, 0x17, 0x50, 0xec,
+	0xe3, 0x4c, 0x89, 0x23, 0x19, 0x49, 0x31, 0xf8, 0x05, 0xc6, 0x9e, 0x6a, 0x8f, 0xb0, 0x67, 0x1a,
+	0x92, 0xe2, 0x24, 0xf6, 0xd8, 0x6e, 0xcc, 0xf9, 0x9f, 0x2f, 0xff, 0xf4, 0x97, 0xe0, 0x26, 0x2f,
+	0xab, 0x87, 0x4a, 0x49, 0x23, 0xf5, 0x43, 0x5e, 0x56, 0x89, 0x0b, 0xef, 0x7f, 0x87, 0x70, 0xbd,
+	0x40, 0xa1, 0xb9, 0xe1, 0x35, 0xbe, 0x67, 0x86, 0x91, 0x57, 0x70, 0x2b, 0xa4, 0xe1, 0x05, 0xcf,
+	0x98, 0xe1, 0x52, 0xbc, 0x93, 0xa2, 0xe0, 0xeb, 0xaf, 0x6c, 0x87, 0x34, 0x98, 0x06, 0xb3, 0x51,
+	0xfa, 0x8f, 0x2a, 0x79, 0x01, 0x51, 0xc1, 0x45, 0xce, 0xc5, 0x9a, 0x0e, 0xa6, 0xc1, 0x6c, 0x3c,
+	0xbf, 0x4d, 0x3a, 0x8b, 0x93, 0x8f, 0xbe, 0x9a, 0xb6, 0x6d, 0xf1, 0xcf, 0xc0, 0xfe, 0x3b, 0xdb,
+	0x2b, 0x6e, 0x9a, 0x2f, 0x4c, 0x6d, 0x35, 0x79, 0x03, 0xe1, 0xce, 0x06, 0x34, 0x98, 0x0e, 0x67,
+	0xe3, 0xf9, 0xf3, 0xde, 0x86, 0x4e, 0x73, 0xe2, 0xbe, 0x1f, 0x84, 0x51, 0x4d, 0xea, 0xa7, 0xe2,
+	0x47, 0x80, 0x53, 0x92, 0x4c, 0x60, 0xb8, 0xc5, 0xe6, 0x40, 0x6d, 0x43, 0x72, 0x03, 0x61, 0xcd,
+	0xca, 0x3d, 0x3a, 0xc0, 0x51, 0xea, 0xc5, 0xeb, 0xc1, 0x63, 0x10, 0xff, 0x08, 0x60, 0xb2, 0x90,
+	0x7b, 0x95, 0xe1, 0x37, 0x25, 0x2b, 0x54, 0x86, 0xa3, 0x26, 0x77, 0x30, 0xaa, 0x94, 0xdc, 0x60,
+	0x66, 0x3e, 0xe5, 0x87, 0x35, 0xa7, 0x04, 0x89, 0xe1, 0x8a, 0x8b, 0x42, 0x2e, 0x9b, 0xaa, 0xdd,
+	0x77, 0xd4, 0xe4, 0x29, 0x40, 0xc9, 0xb7, 0x58, 0xf2, 0xef, 0x52, 0xe6, 0x74, 0xe8, 0xaa, 0x67,
+	0x19, 0x42, 0x21, 0xda, 0xc8, 0x95, 0x33, 0xf5, 0xc2, 0x15, 0x5b, 0x19, 0xff, 0x1a, 0x40, 0x74,
+	0x30, 0x8a, 0x7c, 0x86, 0x89, 0xee, 0x31, 0x39, 0x8c, 0xf1, 0xfc, 0x59, 0xdf, 0x98, 0x5e, 0x5b,
+	0xfa, 0xd7, 0xa0, 0xc5, 0xcd, 0x98, 0xc1, 0xb5, 0x54, 0x4d, 0x8b, 0xdb, 0x6a, 0x72, 0x0f, 0x4f,
+	0x14, 0xfa, 0x09, 0xc7, 0xe4, 0x81, 0x3b, 0x39, 0xeb, 0x9d, 0x36, 0xcc, 0xb4, 0xc0, 0x5e, 0x90,
+	0xb7, 0x70, 0xad, 0xcf, 0x2f, 0x85, 0x86, 0x8e, 0xef, 0xee, 0x7f, 0x17, 0x97, 0x76, 0x47, 0xac,
+	0xcd, 0x58, 0xa3, 0x30, 0x4b, 0xbe, 0x43, 0x7a, 0xe9, 0x6d, 0x3e, 0x26, 0x08, 0x81, 0x0b, 0x61,
+	0x99, 0x22, 0x57, 0x70, 0xb1, 0x3d, 0x8b, 0xc6, 0x1a, 0xed, 0x0a, 0x7a, 0xe5, 0xcf, 0xd2, 0xea,
+	0xd5, 0xa5, 0x7b, 0xd7, 0x2f, 0xff, 0x0c, 0x00, 0x47, 0x5f, 0xe9, 0x99, 0xef, 0x02, 0x00, 0x00,
+}
@@ -20,12 +20,13 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
-// TODO: Finish.
 type BadDomain struct {
-	IpAddress            string   `protobuf:"bytes,1,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	InsertId             string                 `protobuf:"bytes,1,opt,name=insertId,proto3" json:"insertId,omitempty"`
+	LogName              string                 `protobuf:"bytes,2,opt,name=logName,proto3" json:"logName,omitempty"`
+	JsonPayload          *BadDomain_JSONPayload `protobuf:"bytes,3,opt,name=jsonPayload,proto3" json:"jsonPayload,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
 }
 
 func (m *BadDomain) Reset()         { *m = BadDomain{} }
@@ -53,13 +54,207 @@ func (m *BadDomain) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_BadDomain proto.InternalMessageInfo
 
-func (m *BadDomain) GetIpAddress() string {
+func (m *BadDomain) GetInsertId() string {
+	if m != nil {
+		return m.InsertId
+	}
+	return ""
+}
+
+func (m *BadDomain) GetLogName() string {
+	if m != nil {
+		return m.LogName
+	}
+	return ""
+}
+
+func (m *BadDomain) GetJsonPayload() *BadDomain_JSONPayload {
+	if m != nil {
+		return m.JsonPayload
+	}
+	return nil
+}
+
+type BadDomain_Network struct {
+	Project              string   `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BadDomain_Network) Reset()         { *m = BadDomain_Network{} }
+func (m *BadDomain_Network) String() string { return proto.CompactTextString(m) }
+func (*BadDomain_Network) ProtoMessage()    {}
+func (*BadDomain_Network) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7762cc4b80af3525, []int{0, 0}
+}
+
+func (m *BadDomain_Network) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BadDomain_Network.Unmarshal(m, b)
+}
+func (m *BadDomain_Network) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BadDomain_Network.Marshal(b, m, deterministic)
+}
+func (m *BadDomain_Network) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BadDomain_Network.Merge(m, src)
+}
+func (m *BadDomain_Network) XXX_Size() int {
+	return xxx_messageInfo_BadDomain_Network.Size(m)
+}
+func (m *BadDomain_Network) XXX_DiscardUnknown() {
+	xxx_messageInfo_BadDomain_Network.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BadDomain_Network proto.InternalMessageInfo
+
+func (m *BadDomain_Network) GetProject() string {
+	if m != nil {
+		return m.Project
+	}
+	return ""
+}
+
+type BadDomain_Properties struct {
+	Network              *BadDomain_Network `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
+	InstanceDetails      string             `protobuf:"bytes,2,opt,name=instanceDetails,proto3" json:"instanceDetails,omitempty"`
+	Domain               []string           `protobuf:"bytes,3,rep,name=domain,proto3" json:"domain,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *BadDomain_Properties) Reset()         { *m = BadDomain_Properties{} }
+func (m *BadDomain_Properties) String() string { return proto.CompactTextString(m) }
+func (*BadDomain_Properties) ProtoMessage()    {}
+func (*BadDomain_Properties) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7762cc4b80af3525, []int{0, 1}
+}
+
+func (m *BadDomain_Properties) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BadDomain_Properties.Unmarshal(m, b)
+}
+func (m *BadDomain_Properties) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BadDomain_Properties.Marshal(b, m, deterministic)
+}
+func (m *BadDomain_Properties) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BadDomain_Properties.Merge(m, src)
+}
+func (m *BadDomain_Properties) XXX_Size() int {
+	return xxx_messageInfo_BadDomain_Properties.Size(m)
+}
+func (m *BadDomain_Properties) XXX_DiscardUnknown() {
+	xxx_messageInfo_BadDomain_Properties.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BadDomain_Properties proto.InternalMessageInfo
+
+func (m *BadDomain_Properties) GetNetwork() *BadDomain_Network {
+	if m != nil {
+		return m.Network
+	}
+	return nil
+}
+
+func (m *BadDomain_Properties) GetInstanceDetails() string {
+	if m != nil {
+		return m.InstanceDetails
+	}
+	return ""
+}
+
+func (m *BadDomain_Properties) GetDomain() []string {
+	if m != nil {
+		return m.Domain
+	}
+	return nil
+}
+
+type BadDomain_DetectionCategory struct {
+	RuleName             string   `protobuf:"bytes,1,opt,name=ruleName,proto3" json:"ruleName,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BadDomain_DetectionCategory) Reset()         { *m = BadDomain_DetectionCategory{} }
+func (m *BadDomain_DetectionCategory) String() string { return proto.CompactTextString(m) }
+func (*BadDomain_DetectionCategory) ProtoMessage()    {}
+func (*BadDomain_DetectionCategory) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7762cc4b80af3525, []int{0, 2}
+}
+
+func (m *BadDomain_DetectionCategory) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BadDomain_DetectionCategory.Unmarshal(m, b)
+}
+func (m *BadDomain_DetectionCategory) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BadDomain_DetectionCategory.Marshal(b, m, deterministic)
+}
+func (m *BadDomain_DetectionCategory) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BadDomain_DetectionCategory.Merge(m, src)
+}
+func (m *BadDomain_DetectionCategory) XXX_Size() int {
+	return xxx_messageInfo_BadDomain_DetectionCategory.Size(m)
+}
+func (m *BadDomain_DetectionCategory) XXX_DiscardUnknown() {
+	xxx_messageInfo_BadDomain_DetectionCategory.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BadDomain_DetectionCategory proto.InternalMessageInfo
+
+func (m *BadDomain_DetectionCategory) GetRuleName() string {
 	if m != nil {
-		return m.IpAddress
+		return m.RuleName
 	}
 	return ""
 }
 
+type BadDomain_JSONPayload struct {
+	Properties           *BadDomain_Properties        `protobuf:"bytes,1,opt,name=properties,proto3" json:"properties,omitempty"`
+	DetectionCategory    *BadDomain_DetectionCategory `protobuf:"bytes,2,opt,name=detectionCategory,proto3" json:"detectionCategory,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
+	XXX_unrecognized     []byte                       `json:"-"`
+	XXX_sizecache        int32                        `json:"-"`
+}
+
+func (m *BadDomain_JSONPayload) Reset()         { *m = BadDomain_JSONPayload{} }
+func (m *BadDomain_JSONPayload) String() string { return proto.CompactTextString(m) }
+func (*BadDomain_JSONPayload) ProtoMessage()    {}
+func (*BadDomain_JSONPayload) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7762cc4b80af3525, []int{0, 3}
+}
+
+func (m *BadDomain_JSONPayload) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BadDomain_JSONPayload.Unmarshal(m, b)
+}
+func (m *BadDomain_JSONPayload) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BadDomain_JSONPayload.Marshal(b, m, deterministic)
+}
+func (m *BadDomain_JSONPayload) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BadDomain_JSONPayload.Merge(m, src)
+}
+func (m *BadDomain_JSONPayload) XXX_Size() int {
+	return xxx_messageInfo_BadDomain_JSONPayload.Size(m)
+}
+func (m *BadDomain_JSONPayload) XXX_DiscardUnknown() {
+	xxx_messageInfo_BadDomain_JSONPayload.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BadDomain_JSONPayload proto.InternalMessageInfo
+
+func (m *BadDomain_JSONPayload) GetProperties() *BadDomain_Properties {
+	if m != nil {
+		return m.Properties
+	}
+	return nil
+}
+
+func (m *BadDomain_JSONPayload) GetDetectionCategory() *BadDomain_DetectionCategory {
+	if m != nil {
+		return m.DetectionCategory
+	}
+	return nil
+}
+
 type AnomalousIAMGrant struct {
 	InsertId             string                         `protobuf:"bytes,1,opt,name=insertId,proto3" json:"insertId,omitempty"`
 	LogName              string                         `protobuf:"bytes,2,opt,name=logName,proto3" json:"logName,omitempty"`
@@ -156,6 +351,7 @@ func (m *AnomalousIAMGrant_SensitiveRoleGrant) GetMembers() []string {
 
 type AnomalousIAMGrant_Properties struct {
 	SensitiveRoleGrant   *AnomalousIAMGrant_SensitiveRoleGrant `protobuf:"bytes,1,opt,name=sensitiveRoleGrant,proto3" json:"sensitiveRoleGrant,omitempty"`
+	PrincipalEmail       string                                `protobuf:"bytes,2,opt,name=principalEmail,proto3" json:"principalEmail,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                              `json:"-"`
 	XXX_unrecognized     []byte                                `json:"-"`
 	XXX_sizecache        int32                                 `json:"-"`
@@ -193,6 +389,13 @@ func (m *AnomalousIAMGrant_Properties) GetSensitiveRoleGrant() *AnomalousIAMGran
 	return nil
 }
 
+func (m *AnomalousIAMGrant_Properties) GetPrincipalEmail() string {
+	if m != nil {
+		return m.PrincipalEmail
+	}
+	return ""
+}
+
 type AnomalousIAMGrant_SourceLogId struct {
 	ProjectId            string   `protobuf:"bytes,1,opt,name=projectId,proto3" json:"projectId,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -1513,6 +1716,7 @@ func (m *AnomalousIAMGrantSCC_SensitiveRoleGrant) GetMembers() []string {
 
 type AnomalousIAMGrantSCC_Properties struct {
 	SensitiveRoleGrant   *AnomalousIAMGrantSCC_SensitiveRoleGrant `protobuf:"bytes,1,opt,name=sensitiveRoleGrant,proto3" json:"sensitiveRoleGrant,omitempty"`
+	PrincipalEmail       string                                   `protobuf:"bytes,2,opt,name=principalEmail,proto3" json:"principalEmail,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                                 `json:"-"`
 	XXX_unrecognized     []byte                                   `json:"-"`
 	XXX_sizecache        int32                                    `json:"-"`
@@ -1550,6 +1754,13 @@ func (m *AnomalousIAMGrantSCC_Properties) GetSensitiveRoleGrant() *AnomalousIAMG
 	return nil
 }
 
+func (m *AnomalousIAMGrantSCC_Properties) GetPrincipalEmail() string {
+	if m != nil {
+		return m.PrincipalEmail
+	}
+	return ""
+}
+
 type AnomalousIAMGrantSCC_DetectionCategory struct {
 	RuleName             string   `protobuf:"bytes,1,opt,name=ruleName,proto3" json:"ruleName,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -2165,8 +2376,2341 @@ func (m *SshBruteForceSCC_Finding) GetName() string {
 	return ""
 }
 
+type BadDomainSCC struct {
+	NotificationConfigName string                `protobuf:"bytes,1,opt,name=notificationConfigName,proto3" json:"notificationConfigName,omitempty"`
+	Finding                *BadDomainSCC_Finding `protobuf:"bytes,2,opt,name=finding,proto3" json:"finding,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{}              `json:"-"`
+	XXX_unrecognized       []byte                `json:"-"`
+	XXX_sizecache          int32                 `json:"-"`
+}
+
+func (m *BadDomainSCC) Reset()         { *m = BadDomainSCC{} }
+func (m *BadDomainSCC) String() string { return proto.CompactTextString(m) }
+func (*BadDomainSCC) ProtoMessage()    {}
+func (*BadDomainSCC) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7762cc4b80af3525, []int{7}
+}
+
+func (m *BadDomainSCC) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BadDomainSCC.Unmarshal(m, b)
+}
+func (m *BadDomainSCC) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BadDomainSCC.Marshal(b, m, deterministic)
+}
+func (m *BadDomainSCC) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BadDomainSCC.Merge(m, src)
+}
+func (m *BadDomainSCC) XXX_Size() int {
+	return xxx_messageInfo_BadDomainSCC.Size(m)
+}
+func (m *BadDomainSCC) XXX_DiscardUnknown() {
+	xxx_messageInfo_BadDomainSCC.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BadDomainSCC proto.InternalMessageInfo
+
+func (m *BadDomainSCC) GetNotificationConfigName() string {
+	if m != nil {
+		return m.NotificationConfigName
+	}
+	return ""
+}
+
+func (m *BadDomainSCC) GetFinding() *BadDomainSCC_Finding {
+	if m != nil {
+		return m.Finding
+	}
+	return nil
+}
+
+type BadDomainSCC_SecurityMarks struct {
+	Marks                map[string]string `protobuf:"bytes,1,rep,name=marks,proto3" json:"marks,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *BadDomainSCC_SecurityMarks) Reset()         { *m = BadDomainSCC_SecurityMarks{} }
+func (m *BadDomainSCC_SecurityMarks) String() string { return proto.CompactTextString(m) }
+func (*BadDomainSCC_SecurityMarks) ProtoMessage()    {}
+func (*BadDomainSCC_SecurityMarks) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7762cc4b80af3525, []int{7, 0}
+}
+
+func (m *BadDomainSCC_SecurityMarks) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BadDomainSCC_SecurityMarks.Unmarshal(m, b)
+}
+func (m *BadDomainSCC_SecurityMarks) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BadDomainSCC_SecurityMarks.Marshal(b, m, deterministic)
+}
+func (m *BadDomainSCC_SecurityMarks) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BadDomainSCC_SecurityMarks.Merge(m, src)
+}
+func (m *BadDomainSCC_SecurityMarks) XXX_Size() int {
+	return xxx_messageInfo_BadDomainSCC_SecurityMarks.Size(m)
+}
+func (m *BadDomainSCC_SecurityMarks) XXX_DiscardUnknown() {
+	xxx_messageInfo_BadDomainSCC_SecurityMarks.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BadDomainSCC_SecurityMarks proto.InternalMessageInfo
+
+func (m *BadDomainSCC_SecurityMarks) GetMarks() map[string]string {
+	if m != nil {
+		return m.Marks
+	}
+	return nil
+}
+
+type BadDomainSCC_Network struct {
+	Project              string   `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BadDomainSCC_Network) Reset()         { *m = BadDomainSCC_Network{} }
+func (m *BadDomainSCC_Network) String() string { return proto.CompactTextString(m) }
+func (*BadDomainSCC_Network) ProtoMessage()    {}
+func (*BadDomainSCC_Network) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7762cc4b80af3525, []int{7, 1}
+}
+
+func (m *BadDomainSCC_Network) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BadDomainSCC_Network.Unmarshal(m, b)
+}
+func (m *BadDomainSCC_Network) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BadDomainSCC_Network.Marshal(b, m, deterministic)
+}
+func (m *BadDomainSCC_Network) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BadDomainSCC_Network.Merge(m, src)
+}
+func (m *BadDomainSCC_Network) XXX_Size() int {
+	return xxx_messageInfo_BadDomainSCC_Network.Size(m)
+}
+func (m *BadDomainSCC_Network) XXX_DiscardUnknown() {
+	xxx_messageInfo_BadDomainSCC_Network.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BadDomainSCC_Network proto.InternalMessageInfo
+
+func (m *BadDomainSCC_Network) GetProject() string {
+	if m != nil {
+		return m.Project
+	}
+	return ""
+}
+
+type BadDomainSCC_Properties struct {
+	Network              *BadDomainSCC_Network `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
+	InstanceDetails      string                `protobuf:"bytes,2,opt,name=instanceDetails,proto3" json:"instanceDetails,omitempty"`
+	Domain               []string              `protobuf:"bytes,3,rep,name=domain,proto3" json:"domain,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *BadDomainSCC_Properties) Reset()         { *m = BadDomainSCC_Properties{} }
+func (m *BadDomainSCC_Properties) String() string { return proto.CompactTextString(m) }
+func (*BadDomainSCC_Properties) ProtoMessage()    {}
+func (*BadDomainSCC_Properties) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7762cc4b80af3525, []int{7, 2}
+}
+
+func (m *BadDomainSCC_Properties) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BadDomainSCC_Properties.Unmarshal(m, b)
+}
+func (m *BadDomainSCC_Properties) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BadDomainSCC_Properties.Marshal(b, m, deterministic)
+}
+func (m *BadDomainSCC_Properties) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BadDomainSCC_Properties.Merge(m, src)
+}
+func (m *BadDomainSCC_Properties) XXX_Size() int {
+	return xxx_messageInfo_BadDomainSCC_Properties.Size(m)
+}
+func (m *BadDomainSCC_Properties) XXX_DiscardUnknown() {
+	xxx_messageInfo_BadDomainSCC_Properties.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BadDomainSCC_Properties proto.InternalMessageInfo
+
+func (m *BadDomainSCC_Properties) GetNetwork() *BadDomainSCC_Network {
+	if m != nil {
+		return m.Network
+	}
+	return nil
+}
+
+func (m *BadDomainSCC_Properties) GetInstanceDetails() string {
+	if m != nil {
+		return m.InstanceDetails
+	}
+	return ""
+}
+
+func (m *BadDomainSCC_Properties) GetDomain() []string {
+	if m != nil {
+		return m.Domain
+	}
+	return nil
+}
+
+type BadDomainSCC_DetectionCategory struct {
+	RuleName             string   `protobuf:"bytes,1,opt,name=ruleName,proto3" json:"ruleName,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BadDomainSCC_DetectionCategory) Reset()         { *m = BadDomainSCC_DetectionCategory{} }
+func (m *BadDomainSCC_DetectionCategory) String() string { return proto.CompactTextString(m) }
+func (*BadDomainSCC_DetectionCategory) ProtoMessage()    {}
+func (*BadDomainSCC_DetectionCategory) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7762cc4b80af3525, []int{7, 3}
+}
+
+func (m *BadDomainSCC_DetectionCategory) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BadDomainSCC_DetectionCategory.Unmarshal(m, b)
+}
+func (m *BadDomainSCC_DetectionCategory) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BadDomainSCC_DetectionCategory.Marshal(b, m, deterministic)
+}
+func (m *BadDomainSCC_DetectionCategory) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BadDomainSCC_DetectionCategory.Merge(m, src)
+}
+func (m *BadDomainSCC_DetectionCategory) XXX_Size() int {
+	return xxx_messageInfo_BadDomainSCC_DetectionCategory.Size(m)
+}
+func (m *BadDomainSCC_DetectionCategory) XXX_DiscardUnknown() {
+	xxx_messageInfo_BadDomainSCC_DetectionCategory.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BadDomainSCC_DetectionCategory proto.InternalMessageInfo
+
+func (m *BadDomainSCC_DetectionCategory) GetRuleName() string {
+	if m != nil {
+		return m.RuleName
+	}
+	return ""
+}
+
+type BadDomainSCC_SourceProperties struct {
+	Properties           *BadDomainSCC_Properties        `protobuf:"bytes,1,opt,name=properties,proto3" json:"properties,omitempty"`
+	DetectionCategory    *BadDomainSCC_DetectionCategory `protobuf:"bytes,2,opt,name=detectionCategory,proto3" json:"detectionCategory,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
+	XXX_unrecognized     []byte                          `json:"-"`
+	XXX_sizecache        int32                           `json:"-"`
+}
+
+func (m *BadDomainSCC_SourceProperties) Reset()         { *m = BadDomainSCC_SourceProperties{} }
+func (m *BadDomainSCC_SourceProperties) String() string { return proto.CompactTextString(m) }
+func (*BadDomainSCC_SourceProperties) ProtoMessage()    {}
+func (*BadDomainSCC_SourceProperties) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7762cc4b80af3525, []int{7, 4}
+}
+
+func (m *BadDomainSCC_SourceProperties) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BadDomainSCC_SourceProperties.Unmarshal(m, b)
+}
+func (m *BadDomainSCC_SourceProperties) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BadDomainSCC_SourceProperties.Marshal(b, m, deterministic)
+}
+func (m *BadDomainSCC_SourceProperties) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BadDomainSCC_SourceProperties.Merge(m, src)
+}
+func (m *BadDomainSCC_SourceProperties) XXX_Size() int {
+	return xxx_messageInfo_BadDomainSCC_SourceProperties.Size(m)
+}
+func (m *BadDomainSCC_SourceProperties) XXX_DiscardUnknown() {
+	xxx_messageInfo_BadDomainSCC_SourceProperties.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BadDomainSCC_SourceProperties proto.InternalMessageInfo
+
+func (m *BadDomainSCC_SourceProperties) GetProperties() *BadDomainSCC_Properties {
+	if m != nil {
+		return m.Properties
+	}
+	return nil
+}
+
+func (m *BadDomainSCC_SourceProperties) GetDetectionCategory() *BadDomainSCC_DetectionCategory {
+	if m != nil {
+		return m.DetectionCategory
+	}
+	return nil
+}
+
+type BadDomainSCC_Finding struct {
+	SourceProperties     *BadDomainSCC_SourceProperties `protobuf:"bytes,1,opt,name=sourceProperties,proto3" json:"sourceProperties,omitempty"`
+	Category             string                         `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
+	ResourceName         string                         `protobuf:"bytes,3,opt,name=resourceName,proto3" json:"resourceName,omitempty"`
+	State                string                         `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"`
+	SecurityMarks        *BadDomainSCC_SecurityMarks    `protobuf:"bytes,5,opt,name=securityMarks,proto3" json:"securityMarks,omitempty"`
+	EventTime            string                         `protobuf:"bytes,6,opt,name=eventTime,proto3" json:"eventTime,omitempty"`
+	Name                 string                         `protobuf:"bytes,7,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                       `json:"-"`
+	XXX_unrecognized     []byte                         `json:"-"`
+	XXX_sizecache        int32                          `json:"-"`
+}
+
+func (m *BadDomainSCC_Finding) Reset()         { *m = BadDomainSCC_Finding{} }
+func (m *BadDomainSCC_Finding) String() string { return proto.CompactTextString(m) }
+func (*BadDomainSCC_Finding) ProtoMessage()    {}
+func (*BadDomainSCC_Finding) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7762cc4b80af3525, []int{7, 5}
+}
+
+func (m *BadDomainSCC_Finding) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BadDomainSCC_Finding.Unmarshal(m, b)
+}
+func (m *BadDomainSCC_Finding) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BadDomainSCC_Finding.Marshal(b, m, deterministic)
+}
+func (m *BadDomainSCC_Finding) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BadDomainSCC_Finding.Merge(m, src)
+}
+func (m *BadDomainSCC_Finding) XXX_Size() int {
+	return xxx_messageInfo_BadDomainSCC_Finding.Size(m)
+}
+func (m *BadDomainSCC_Finding) XXX_DiscardUnknown() {
+	xxx_messageInfo_BadDomainSCC_Finding.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BadDomainSCC_Finding proto.InternalMessageInfo
+
+func (m *BadDomainSCC_Finding) GetSourceProperties() *BadDomainSCC_SourceProperties {
+	if m != nil {
+		return m.SourceProperties
+	}
+	return nil
+}
+
+func (m *BadDomainSCC_Finding) GetCategory() string {
+	if m != nil {
+		return m.Category
+	}
+	return ""
+}
+
+func (m *BadDomainSCC_Finding) GetResourceName() string {
+	if m != nil {
+		return m.ResourceName
+	}
+	return ""
+}
+
+func (m *BadDomainSCC_Finding) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *BadDomainSCC_Finding) GetSecurityMarks() *BadDomainSCC_SecurityMarks {
+	if m != nil {
+		return m.SecurityMarks
+	}
+	return nil
+}
+
+func (m *BadDomainSCC_Finding) GetEventTime() string {
+	if m != nil {
+		return m.EventTime
+	}
+	return ""
+}
+
+func (m *BadDomainSCC_Finding) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type NewGeography struct {
+	InsertId             string                    `protobuf:"bytes,1,opt,name=insertId,proto3" json:"insertId,omitempty"`
+	LogName              string                    `protobuf:"bytes,2,opt,name=logName,proto3" json:"logName,omitempty"`
+	JsonPayload          *NewGeography_JSONPayload `protobuf:"bytes,3,opt,name=jsonPayload,proto3" json:"jsonPayload,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *NewGeography) Reset()         { *m = NewGeography{} }
+func (m *NewGeography) String() string { return proto.CompactTextString(m) }
+func (*NewGeography) ProtoMessage()    {}
+func (*NewGeography) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7762cc4b80af3525, []int{8}
+}
+
+func (m *NewGeography) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NewGeography.Unmarshal(m, b)
+}
+func (m *NewGeography) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NewGeography.Marshal(b, m, deterministic)
+}
+func (m *NewGeography) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NewGeography.Merge(m, src)
+}
+func (m *NewGeography) XXX_Size() int {
+	return xxx_messageInfo_NewGeography.Size(m)
+}
+func (m *NewGeography) XXX_DiscardUnknown() {
+	xxx_messageInfo_NewGeography.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NewGeography proto.InternalMessageInfo
+
+func (m *NewGeography) GetInsertId() string {
+	if m != nil {
+		return m.InsertId
+	}
+	return ""
+}
+
+func (m *NewGeography) GetLogName() string {
+	if m != nil {
+		return m.LogName
+	}
+	return ""
+}
+
+func (m *NewGeography) GetJsonPayload() *NewGeography_JSONPayload {
+	if m != nil {
+		return m.JsonPayload
+	}
+	return nil
+}
+
+type NewGeography_Properties struct {
+	PrincipalEmail       string   `protobuf:"bytes,1,opt,name=principalEmail,proto3" json:"principalEmail,omitempty"`
+	CallerIp             string   `protobuf:"bytes,2,opt,name=callerIp,proto3" json:"callerIp,omitempty"`
+	AnomalousLocation    string   `protobuf:"bytes,3,opt,name=anomalousLocation,proto3" json:"anomalousLocation,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NewGeography_Properties) Reset()         { *m = NewGeography_Properties{} }
+func (m *NewGeography_Properties) String() string { return proto.CompactTextString(m) }
+func (*NewGeography_Properties) ProtoMessage()    {}
+func (*NewGeography_Properties) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7762cc4b80af3525, []int{8, 0}
+}
+
+func (m *NewGeography_Properties) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NewGeography_Properties.Unmarshal(m, b)
+}
+func (m *NewGeography_Properties) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NewGeography_Properties.Marshal(b, m, deterministic)
+}
+func (m *NewGeography_Properties) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NewGeography_Properties.Merge(m, src)
+}
+func (m *NewGeography_Properties) XXX_Size() int {
+	return xxx_messageInfo_NewGeography_Properties.Size(m)
+}
+func (m *NewGeography_Properties) XXX_DiscardUnknown() {
+	xxx_messageInfo_NewGeography_Properties.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NewGeography_Properties proto.InternalMessageInfo
+
+func (m *NewGeography_Properties) GetPrincipalEmail() string {
+	if m != nil {
+		return m.PrincipalEmail
+	}
+	return ""
+}
+
+func (m *NewGeography_Properties) GetCallerIp() string {
+	if m != nil {
+		return m.CallerIp
+	}
+	return ""
+}
+
+func (m *NewGeography_Properties) GetAnomalousLocation() string {
+	if m != nil {
+		return m.AnomalousLocation
+	}
+	return ""
+}
+
+type NewGeography_SourceLogId struct {
+	ProjectId            string   `protobuf:"bytes,1,opt,name=projectId,proto3" json:"projectId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NewGeography_SourceLogId) Reset()         { *m = NewGeography_SourceLogId{} }
+func (m *NewGeography_SourceLogId) String() string { return proto.CompactTextString(m) }
+func (*NewGeography_SourceLogId) ProtoMessage()    {}
+func (*NewGeography_SourceLogId) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7762cc4b80af3525, []int{8, 1}
+}
+
+func (m *NewGeography_SourceLogId) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NewGeography_SourceLogId.Unmarshal(m, b)
+}
+func (m *NewGeography_SourceLogId) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NewGeography_SourceLogId.Marshal(b, m, deterministic)
+}
+func (m *NewGeography_SourceLogId) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NewGeography_SourceLogId.Merge(m, src)
+}
+func (m *NewGeography_SourceLogId) XXX_Size() int {
+	return xxx_messageInfo_NewGeography_SourceLogId.Size(m)
+}
+func (m *NewGeography_SourceLogId) XXX_DiscardUnknown() {
+	xxx_messageInfo_NewGeography_SourceLogId.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NewGeography_SourceLogId proto.InternalMessageInfo
+
+func (m *NewGeography_SourceLogId) GetProjectId() string {
+	if m != nil {
+		return m.ProjectId
+	}
+	return ""
+}
+
+type NewGeography_Evidence struct {
+	SourceLogId          *NewGeography_SourceLogId `protobuf:"bytes,1,opt,name=sourceLogId,proto3" json:"sourceLogId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *NewGeography_Evidence) Reset()         { *m = NewGeography_Evidence{} }
+func (m *NewGeography_Evidence) String() string { return proto.CompactTextString(m) }
+func (*NewGeography_Evidence) ProtoMessage()    {}
+func (*NewGeography_Evidence) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7762cc4b80af3525, []int{8, 2}
+}
+
+func (m *NewGeography_Evidence) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NewGeography_Evidence.Unmarshal(m, b)
+}
+func (m *NewGeography_Evidence) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NewGeography_Evidence.Marshal(b, m, deterministic)
+}
+func (m *NewGeography_Evidence) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NewGeography_Evidence.Merge(m, src)
+}
+func (m *NewGeography_Evidence) XXX_Size() int {
+	return xxx_messageInfo_NewGeography_Evidence.Size(m)
+}
+func (m *NewGeography_Evidence) XXX_DiscardUnknown() {
+	xxx_messageInfo_NewGeography_Evidence.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NewGeography_Evidence proto.InternalMessageInfo
+
+func (m *NewGeography_Evidence) GetSourceLogId() *NewGeography_SourceLogId {
+	if m != nil {
+		return m.SourceLogId
+	}
+	return nil
+}
+
+type NewGeography_DetectionCategory struct {
+	RuleName             string   `protobuf:"bytes,1,opt,name=ruleName,proto3" json:"ruleName,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NewGeography_DetectionCategory) Reset()         { *m = NewGeography_DetectionCategory{} }
+func (m *NewGeography_DetectionCategory) String() string { return proto.CompactTextString(m) }
+func (*NewGeography_DetectionCategory) ProtoMessage()    {}
+func (*NewGeography_DetectionCategory) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7762cc4b80af3525, []int{8, 3}
+}
+
+func (m *NewGeography_DetectionCategory) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NewGeography_DetectionCategory.Unmarshal(m, b)
+}
+func (m *NewGeography_DetectionCategory) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NewGeography_DetectionCategory.Marshal(b, m, deterministic)
+}
+func (m *NewGeography_DetectionCategory) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NewGeography_DetectionCategory.Merge(m, src)
+}
+func (m *NewGeography_DetectionCategory) XXX_Size() int {
+	return xxx_messageInfo_NewGeography_DetectionCategory.Size(m)
+}
+func (m *NewGeography_DetectionCategory) XXX_DiscardUnknown() {
+	xxx_messageInfo_NewGeography_DetectionCategory.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NewGeography_DetectionCategory proto.InternalMessageInfo
+
+func (m *NewGeography_DetectionCategory) GetRuleName() string {
+	if m != nil {
+		return m.RuleName
+	}
+	return ""
+}
+
+type NewGeography_JSONPayload struct {
+	Properties           *NewGeography_Properties        `protobuf:"bytes,1,opt,name=properties,proto3" json:"properties,omitempty"`
+	DetectionCategory    *NewGeography_DetectionCategory `protobuf:"bytes,2,opt,name=detectionCategory,proto3" json:"detectionCategory,omitempty"`
+	Evidence             []*NewGeography_Evidence        `protobuf:"bytes,3,rep,name=evidence,proto3" json:"evidence,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
+	XXX_unrecognized     []byte                          `json:"-"`
+	XXX_sizecache        int32                           `json:"-"`
+}
+
+func (m *NewGeography_JSONPayload) Reset()         { *m = NewGeography_JSONPayload{} }
+func (m *NewGeography_JSONPayload) String() string { return proto.CompactTextString(m) }
+func (*NewGeography_JSONPayload) ProtoMessage()    {}
+func (*NewGeography_JSONPayload) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7762cc4b80af3525, []int{8, 4}
+}
+
+func (m *NewGeography_JSONPayload) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NewGeography_JSONPayload.Unmarshal(m, b)
+}
+func (m *NewGeography_JSONPayload) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NewGeography_JSONPayload.Marshal(b, m, deterministic)
+}
+func (m *NewGeography_JSONPayload) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NewGeography_JSONPayload.Merge(m, src)
+}
+func (m *NewGeography_JSONPayload) XXX_Size() int {
+	return xxx_messageInfo_NewGeography_JSONPayload.Size(m)
+}
+func (m *NewGeography_JSONPayload) XXX_DiscardUnknown() {
+	xxx_messageInfo_NewGeography_JSONPayload.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NewGeography_JSONPayload proto.InternalMessageInfo
+
+func (m *NewGeography_JSONPayload) GetProperties() *NewGeography_Properties {
+	if m != nil {
+		return m.Properties
+	}
+	return nil
+}
+
+func (m *NewGeography_JSONPayload) GetDetectionCategory() *NewGeography_DetectionCategory {
+	if m != nil {
+		return m.DetectionCategory
+	}
+	return nil
+}
+
+func (m *NewGeography_JSONPayload) GetEvidence() []*NewGeography_Evidence {
+	if m != nil {
+		return m.Evidence
+	}
+	return nil
+}
+
+type NewGeographySCC struct {
+	NotificationConfigName string                   `protobuf:"bytes,1,opt,name=notificationConfigName,proto3" json:"notificationConfigName,omitempty"`
+	Finding                *NewGeographySCC_Finding `protobuf:"bytes,2,opt,name=finding,proto3" json:"finding,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{}                 `json:"-"`
+	XXX_unrecognized       []byte                   `json:"-"`
+	XXX_sizecache          int32                    `json:"-"`
+}
+
+func (m *NewGeographySCC) Reset()         { *m = NewGeographySCC{} }
+func (m *NewGeographySCC) String() string { return proto.CompactTextString(m) }
+func (*NewGeographySCC) ProtoMessage()    {}
+func (*NewGeographySCC) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7762cc4b80af3525, []int{9}
+}
+
+func (m *NewGeographySCC) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NewGeographySCC.Unmarshal(m, b)
+}
+func (m *NewGeographySCC) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NewGeographySCC.Marshal(b, m, deterministic)
+}
+func (m *NewGeographySCC) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NewGeographySCC.Merge(m, src)
+}
+func (m *NewGeographySCC) XXX_Size() int {
+	return xxx_messageInfo_NewGeographySCC.Size(m)
+}
+func (m *NewGeographySCC) XXX_DiscardUnknown() {
+	xxx_messageInfo_NewGeographySCC.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NewGeographySCC proto.InternalMessageInfo
+
+func (m *NewGeographySCC) GetNotificationConfigName() string {
+	if m != nil {
+		return m.NotificationConfigName
+	}
+	return ""
+}
+
+func (m *NewGeographySCC) GetFinding() *NewGeographySCC_Finding {
+	if m != nil {
+		return m.Finding
+	}
+	return nil
+}
+
+type NewGeographySCC_SecurityMarks struct {
+	Marks                map[string]string `protobuf:"bytes,1,rep,name=marks,proto3" json:"marks,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *NewGeographySCC_SecurityMarks) Reset()         { *m = NewGeographySCC_SecurityMarks{} }
+func (m *NewGeographySCC_SecurityMarks) String() string { return proto.CompactTextString(m) }
+func (*NewGeographySCC_SecurityMarks) ProtoMessage()    {}
+func (*NewGeographySCC_SecurityMarks) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7762cc4b80af3525, []int{9, 0}
+}
+
+func (m *NewGeographySCC_SecurityMarks) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NewGeographySCC_SecurityMarks.Unmarshal(m, b)
+}
+func (m *NewGeographySCC_SecurityMarks) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NewGeographySCC_SecurityMarks.Marshal(b, m, deterministic)
+}
+func (m *NewGeographySCC_SecurityMarks) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NewGeographySCC_SecurityMarks.Merge(m, src)
+}
+func (m *NewGeographySCC_SecurityMarks) XXX_Size() int {
+	return xxx_messageInfo_NewGeographySCC_SecurityMarks.Size(m)
+}
+func (m *NewGeographySCC_SecurityMarks) XXX_DiscardUnknown() {
+	xxx_messageInfo_NewGeographySCC_SecurityMarks.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NewGeographySCC_SecurityMarks proto.InternalMessageInfo
+
+func (m *NewGeographySCC_SecurityMarks) GetMarks() map[string]string {
+	if m != nil {
+		return m.Marks
+	}
+	return nil
+}
+
+type NewGeographySCC_Properties struct {
+	PrincipalEmail       string   `protobuf:"bytes,1,opt,name=principalEmail,proto3" json:"principalEmail,omitempty"`
+	CallerIp             string   `protobuf:"bytes,2,opt,name=callerIp,proto3" json:"callerIp,omitempty"`
+	AnomalousLocation    string   `protobuf:"bytes,3,opt,name=anomalousLocation,proto3" json:"anomalousLocation,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NewGeographySCC_Properties) Reset()         { *m = NewGeographySCC_Properties{} }
+func (m *NewGeographySCC_Properties) String() string { return proto.CompactTextString(m) }
+func (*NewGeographySCC_Properties) ProtoMessage()    {}
+func (*NewGeographySCC_Properties) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7762cc4b80af3525, []int{9, 1}
+}
+
+func (m *NewGeographySCC_Properties) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NewGeographySCC_Properties.Unmarshal(m, b)
+}
+func (m *NewGeographySCC_Properties) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NewGeographySCC_Properties.Marshal(b, m, deterministic)
+}
+func (m *NewGeographySCC_Properties) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NewGeographySCC_Properties.Merge(m, src)
+}
+func (m *NewGeographySCC_Properties) XXX_Size() int {
+	return xxx_messageInfo_NewGeographySCC_Properties.Size(m)
+}
+func (m *NewGeographySCC_Properties) XXX_DiscardUnknown() {
+	xxx_messageInfo_NewGeographySCC_Properties.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NewGeographySCC_Properties proto.InternalMessageInfo
+
+func (m *NewGeographySCC_Properties) GetPrincipalEmail() string {
+	if m != nil {
+		return m.PrincipalEmail
+	}
+	return ""
+}
+
+func (m *NewGeographySCC_Properties) GetCallerIp() string {
+	if m != nil {
+		return m.CallerIp
+	}
+	return ""
+}
+
+func (m *NewGeographySCC_Properties) GetAnomalousLocation() string {
+	if m != nil {
+		return m.AnomalousLocation
+	}
+	return ""
+}
+
+type NewGeographySCC_SourceLogId struct {
+	ProjectId            string   `protobuf:"bytes,1,opt,name=projectId,proto3" json:"projectId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NewGeographySCC_SourceLogId) Reset()         { *m = NewGeographySCC_SourceLogId{} }
+func (m *NewGeographySCC_SourceLogId) String() string { return proto.CompactTextString(m) }
+func (*NewGeographySCC_SourceLogId) ProtoMessage()    {}
+func (*NewGeographySCC_SourceLogId) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7762cc4b80af3525, []int{9, 2}
+}
+
+func (m *NewGeographySCC_SourceLogId) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NewGeographySCC_SourceLogId.Unmarshal(m, b)
+}
+func (m *NewGeographySCC_SourceLogId) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NewGeographySCC_SourceLogId.Marshal(b, m, deterministic)
+}
+func (m *NewGeographySCC_SourceLogId) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NewGeographySCC_SourceLogId.Merge(m, src)
+}
+func (m *NewGeographySCC_SourceLogId) XXX_Size() int {
+	return xxx_messageInfo_NewGeographySCC_SourceLogId.Size(m)
+}
+func (m *NewGeographySCC_SourceLogId) XXX_DiscardUnknown() {
+	xxx_messageInfo_NewGeographySCC_SourceLogId.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NewGeographySCC_SourceLogId proto.InternalMessageInfo
+
+func (m *NewGeographySCC_SourceLogId) GetProjectId() string {
+	if m != nil {
+		return m.ProjectId
+	}
+	return ""
+}
+
+type NewGeographySCC_Evidence struct {
+	SourceLogId          *NewGeographySCC_SourceLogId `protobuf:"bytes,1,opt,name=sourceLogId,proto3" json:"sourceLogId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
+	XXX_unrecognized     []byte                       `json:"-"`
+	XXX_sizecache        int32                        `json:"-"`
+}
+
+func (m *NewGeographySCC_Evidence) Reset()         { *m = NewGeographySCC_Evidence{} }
+func (m *NewGeographySCC_Evidence) String() string { return proto.CompactTextString(m) }
+func (*NewGeographySCC_Evidence) ProtoMessage()    {}
+func (*NewGeographySCC_Evidence) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7762cc4b80af3525, []int{9, 3}
+}
+
+func (m *NewGeographySCC_Evidence) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NewGeographySCC_Evidence.Unmarshal(m, b)
+}
+func (m *NewGeographySCC_Evidence) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NewGeographySCC_Evidence.Marshal(b, m, deterministic)
+}
+func (m *NewGeographySCC_Evidence) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NewGeographySCC_Evidence.Merge(m, src)
+}
+func (m *NewGeographySCC_Evidence) XXX_Size() int {
+	return xxx_messageInfo_NewGeographySCC_Evidence.Size(m)
+}
+func (m *NewGeographySCC_Evidence) XXX_DiscardUnknown() {
+	xxx_messageInfo_NewGeographySCC_Evidence.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NewGeographySCC_Evidence proto.InternalMessageInfo
+
+func (m *NewGeographySCC_Evidence) GetSourceLogId() *NewGeographySCC_SourceLogId {
+	if m != nil {
+		return m.SourceLogId
+	}
+	return nil
+}
+
+type NewGeographySCC_DetectionCategory struct {
+	RuleName             string   `protobuf:"bytes,1,opt,name=ruleName,proto3" json:"ruleName,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NewGeographySCC_DetectionCategory) Reset()         { *m = NewGeographySCC_DetectionCategory{} }
+func (m *NewGeographySCC_DetectionCategory) String() string { return proto.CompactTextString(m) }
+func (*NewGeographySCC_DetectionCategory) ProtoMessage()    {}
+func (*NewGeographySCC_DetectionCategory) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7762cc4b80af3525, []int{9, 4}
+}
+
+func (m *NewGeographySCC_DetectionCategory) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NewGeographySCC_DetectionCategory.Unmarshal(m, b)
+}
+func (m *NewGeographySCC_DetectionCategory) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NewGeographySCC_DetectionCategory.Marshal(b, m, deterministic)
+}
+func (m *NewGeographySCC_DetectionCategory) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NewGeographySCC_DetectionCategory.Merge(m, src)
+}
+func (m *NewGeographySCC_DetectionCategory) XXX_Size() int {
+	return xxx_messageInfo_NewGeographySCC_DetectionCategory.Size(m)
+}
+func (m *NewGeographySCC_DetectionCategory) XXX_DiscardUnknown() {
+	xxx_messageInfo_NewGeographySCC_DetectionCategory.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NewGeographySCC_DetectionCategory proto.InternalMessageInfo
+
+func (m *NewGeographySCC_DetectionCategory) GetRuleName() string {
+	if m != nil {
+		return m.RuleName
+	}
+	return ""
+}
+
+type NewGeographySCC_SourceProperties struct {
+	Properties           *NewGeographySCC_Properties        `protobuf:"bytes,1,opt,name=properties,proto3" json:"properties,omitempty"`
+	DetectionCategory    *NewGeographySCC_DetectionCategory `protobuf:"bytes,2,opt,name=detectionCategory,proto3" json:"detectionCategory,omitempty"`
+	Evidence             []*NewGeographySCC_Evidence        `protobuf:"bytes,3,rep,name=evidence,proto3" json:"evidence,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                           `json:"-"`
+	XXX_unrecognized     []byte                             `json:"-"`
+	XXX_sizecache        int32                              `json:"-"`
+}
+
+func (m *NewGeographySCC_SourceProperties) Reset()         { *m = NewGeographySCC_SourceProperties{} }
+func (m *NewGeographySCC_SourceProperties) String() string { return proto.CompactTextString(m) }
+func (*NewGeographySCC_SourceProperties) ProtoMessage()    {}
+func (*NewGeographySCC_SourceProperties) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7762cc4b80af3525, []int{9, 5}
+}
+
+func (m *NewGeographySCC_SourceProperties) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NewGeographySCC_SourceProperties.Unmarshal(m, b)
+}
+func (m *NewGeographySCC_SourceProperties) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NewGeographySCC_SourceProperties.Marshal(b, m, deterministic)
+}
+func (m *NewGeographySCC_SourceProperties) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NewGeographySCC_SourceProperties.Merge(m, src)
+}
+func (m *NewGeographySCC_SourceProperties) XXX_Size() int {
+	return xxx_messageInfo_NewGeographySCC_SourceProperties.Size(m)
+}
+func (m *NewGeographySCC_SourceProperties) XXX_DiscardUnknown() {
+	xxx_messageInfo_NewGeographySCC_SourceProperties.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NewGeographySCC_SourceProperties proto.InternalMessageInfo
+
+func (m *NewGeographySCC_SourceProperties) GetProperties() *NewGeographySCC_Properties {
+	if m != nil {
+		return m.Properties
+	}
+	return nil
+}
+
+func (m *NewGeographySCC_SourceProperties) GetDetectionCategory() *NewGeographySCC_DetectionCategory {
+	if m != nil {
+		return m.DetectionCategory
+	}
+	return nil
+}
+
+func (m *NewGeographySCC_SourceProperties) GetEvidence() []*NewGeographySCC_Evidence {
+	if m != nil {
+		return m.Evidence
+	}
+	return nil
+}
+
+type NewGeographySCC_Finding struct {
+	SourceProperties     *NewGeographySCC_SourceProperties `protobuf:"bytes,1,opt,name=sourceProperties,proto3" json:"sourceProperties,omitempty"`
+	Category             string                            `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
+	ResourceName         string                            `protobuf:"bytes,3,opt,name=resourceName,proto3" json:"resourceName,omitempty"`
+	State                string                            `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"`
+	SecurityMarks        *NewGeographySCC_SecurityMarks    `protobuf:"bytes,5,opt,name=securityMarks,proto3" json:"securityMarks,omitempty"`
+	EventTime            string                            `protobuf:"bytes,6,opt,name=eventTime,proto3" json:"eventTime,omitempty"`
+	Name                 string                            `protobuf:"bytes,7,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                          `json:"-"`
+	XXX_unrecognized     []byte                            `json:"-"`
+	XXX_sizecache        int32                             `json:"-"`
+}
+
+func (m *NewGeographySCC_Finding) Reset()         { *m = NewGeographySCC_Finding{} }
+func (m *NewGeographySCC_Finding) String() string { return proto.CompactTextString(m) }
+func (*NewGeographySCC_Finding) ProtoMessage()    {}
+func (*NewGeographySCC_Finding) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7762cc4b80af3525, []int{9, 6}
+}
+
+func (m *NewGeographySCC_Finding) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NewGeographySCC_Finding.Unmarshal(m, b)
+}
+func (m *NewGeographySCC_Finding) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NewGeographySCC_Finding.Marshal(b, m, deterministic)
+}
+func (m *NewGeographySCC_Finding) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NewGeographySCC_Finding.Merge(m, src)
+}
+func (m *NewGeographySCC_Finding) XXX_Size() int {
+	return xxx_messageInfo_NewGeographySCC_Finding.Size(m)
+}
+func (m *NewGeographySCC_Finding) XXX_DiscardUnknown() {
+	xxx_messageInfo_NewGeographySCC_Finding.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NewGeographySCC_Finding proto.InternalMessageInfo
+
+func (m *NewGeographySCC_Finding) GetSourceProperties() *NewGeographySCC_SourceProperties {
+	if m != nil {
+		return m.SourceProperties
+	}
+	return nil
+}
+
+func (m *NewGeographySCC_Finding) GetCategory() string {
+	if m != nil {
+		return m.Category
+	}
+	return ""
+}
+
+func (m *NewGeographySCC_Finding) GetResourceName() string {
+	if m != nil {
+		return m.ResourceName
+	}
+	return ""
+}
+
+func (m *NewGeographySCC_Finding) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *NewGeographySCC_Finding) GetSecurityMarks() *NewGeographySCC_SecurityMarks {
+	if m != nil {
+		return m.SecurityMarks
+	}
+	return nil
+}
+
+func (m *NewGeographySCC_Finding) GetEventTime() string {
+	if m != nil {
+		return m.EventTime
+	}
+	return ""
+}
+
+func (m *NewGeographySCC_Finding) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type BigQueryExfiltration struct {
+	InsertId             string                            `protobuf:"bytes,1,opt,name=insertId,proto3" json:"insertId,omitempty"`
+	LogName              string                            `protobuf:"bytes,2,opt,name=logName,proto3" json:"logName,omitempty"`
+	JsonPayload          *BigQueryExfiltration_JSONPayload `protobuf:"bytes,3,opt,name=jsonPayload,proto3" json:"jsonPayload,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                          `json:"-"`
+	XXX_unrecognized     []byte                            `json:"-"`
+	XXX_sizecache        int32                             `json:"-"`
+}
+
+func (m *BigQueryExfiltration) Reset()         { *m = BigQueryExfiltration{} }
+func (m *BigQueryExfiltration) String() string { return proto.CompactTextString(m) }
+func (*BigQueryExfiltration) ProtoMessage()    {}
+func (*BigQueryExfiltration) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7762cc4b80af3525, []int{10}
+}
+
+func (m *BigQueryExfiltration) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BigQueryExfiltration.Unmarshal(m, b)
+}
+func (m *BigQueryExfiltration) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BigQueryExfiltration.Marshal(b, m, deterministic)
+}
+func (m *BigQueryExfiltration) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BigQueryExfiltration.Merge(m, src)
+}
+func (m *BigQueryExfiltration) XXX_Size() int {
+	return xxx_messageInfo_BigQueryExfiltration.Size(m)
+}
+func (m *BigQueryExfiltration) XXX_DiscardUnknown() {
+	xxx_messageInfo_BigQueryExfiltration.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BigQueryExfiltration proto.InternalMessageInfo
+
+func (m *BigQueryExfiltration) GetInsertId() string {
+	if m != nil {
+		return m.InsertId
+	}
+	return ""
+}
+
+func (m *BigQueryExfiltration) GetLogName() string {
+	if m != nil {
+		return m.LogName
+	}
+	return ""
+}
+
+func (m *BigQueryExfiltration) GetJsonPayload() *BigQueryExfiltration_JSONPayload {
+	if m != nil {
+		return m.JsonPayload
+	}
+	return nil
+}
+
+type BigQueryExfiltration_Properties struct {
+	PrincipalEmail       string   `protobuf:"bytes,1,opt,name=principalEmail,proto3" json:"principalEmail,omitempty"`
+	SourceTable          string   `protobuf:"bytes,2,opt,name=sourceTable,proto3" json:"sourceTable,omitempty"`
+	DestinationTable     string   `protobuf:"bytes,3,opt,name=destinationTable,proto3" json:"destinationTable,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BigQueryExfiltration_Properties) Reset()         { *m = BigQueryExfiltration_Properties{} }
+func (m *BigQueryExfiltration_Properties) String() string { return proto.CompactTextString(m) }
+func (*BigQueryExfiltration_Properties) ProtoMessage()    {}
+func (*BigQueryExfiltration_Properties) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7762cc4b80af3525, []int{10, 0}
+}
+
+func (m *BigQueryExfiltration_Properties) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BigQueryExfiltration_Properties.Unmarshal(m, b)
+}
+func (m *BigQueryExfiltration_Properties) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BigQueryExfiltration_Properties.Marshal(b, m, deterministic)
+}
+func (m *BigQueryExfiltration_Properties) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BigQueryExfiltration_Properties.Merge(m, src)
+}
+func (m *BigQueryExfiltration_Properties) XXX_Size() int {
+	return xxx_messageInfo_BigQueryExfiltration_Properties.Size(m)
+}
+func (m *BigQueryExfiltration_Properties) XXX_DiscardUnknown() {
+	xxx_messageInfo_BigQueryExfiltration_Properties.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BigQueryExfiltration_Properties proto.InternalMessageInfo
+
+func (m *BigQueryExfiltration_Properties) GetPrincipalEmail() string {
+	if m != nil {
+		return m.PrincipalEmail
+	}
+	return ""
+}
+
+func (m *BigQueryExfiltration_Properties) GetSourceTable() string {
+	if m != nil {
+		return m.SourceTable
+	}
+	return ""
+}
+
+func (m *BigQueryExfiltration_Properties) GetDestinationTable() string {
+	if m != nil {
+		return m.DestinationTable
+	}
+	return ""
+}
+
+type BigQueryExfiltration_SourceLogId struct {
+	ProjectId            string   `protobuf:"bytes,1,opt,name=projectId,proto3" json:"projectId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BigQueryExfiltration_SourceLogId) Reset()         { *m = BigQueryExfiltration_SourceLogId{} }
+func (m *BigQueryExfiltration_SourceLogId) String() string { return proto.CompactTextString(m) }
+func (*BigQueryExfiltration_SourceLogId) ProtoMessage()    {}
+func (*BigQueryExfiltration_SourceLogId) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7762cc4b80af3525, []int{10, 1}
+}
+
+func (m *BigQueryExfiltration_SourceLogId) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BigQueryExfiltration_SourceLogId.Unmarshal(m, b)
+}
+func (m *BigQueryExfiltration_SourceLogId) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BigQueryExfiltration_SourceLogId.Marshal(b, m, deterministic)
+}
+func (m *BigQueryExfiltration_SourceLogId) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BigQueryExfiltration_SourceLogId.Merge(m, src)
+}
+func (m *BigQueryExfiltration_SourceLogId) XXX_Size() int {
+	return xxx_messageInfo_BigQueryExfiltration_SourceLogId.Size(m)
+}
+func (m *BigQueryExfiltration_SourceLogId) XXX_DiscardUnknown() {
+	xxx_messageInfo_BigQueryExfiltration_SourceLogId.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BigQueryExfiltration_SourceLogId proto.InternalMessageInfo
+
+func (m *BigQueryExfiltration_SourceLogId) GetProjectId() string {
+	if m != nil {
+		return m.ProjectId
+	}
+	return ""
+}
+
+type BigQueryExfiltration_Evidence struct {
+	SourceLogId          *BigQueryExfiltration_SourceLogId `protobuf:"bytes,1,opt,name=sourceLogId,proto3" json:"sourceLogId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                          `json:"-"`
+	XXX_unrecognized     []byte                            `json:"-"`
+	XXX_sizecache        int32                             `json:"-"`
+}
+
+func (m *BigQueryExfiltration_Evidence) Reset()         { *m = BigQueryExfiltration_Evidence{} }
+func (m *BigQueryExfiltration_Evidence) String() string { return proto.CompactTextString(m) }
+func (*BigQueryExfiltration_Evidence) ProtoMessage()    {}
+func (*BigQueryExfiltration_Evidence) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7762cc4b80af3525, []int{10, 2}
+}
+
+func (m *BigQueryExfiltration_Evidence) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BigQueryExfiltration_Evidence.Unmarshal(m, b)
+}
+func (m *BigQueryExfiltration_Evidence) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BigQueryExfiltration_Evidence.Marshal(b, m, deterministic)
+}
+func (m *BigQueryExfiltration_Evidence) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BigQueryExfiltration_Evidence.Merge(m, src)
+}
+func (m *BigQueryExfiltration_Evidence) XXX_Size() int {
+	return xxx_messageInfo_BigQueryExfiltration_Evidence.Size(m)
+}
+func (m *BigQueryExfiltration_Evidence) XXX_DiscardUnknown() {
+	xxx_messageInfo_BigQueryExfiltration_Evidence.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BigQueryExfiltration_Evidence proto.InternalMessageInfo
+
+func (m *BigQueryExfiltration_Evidence) GetSourceLogId() *BigQueryExfiltration_SourceLogId {
+	if m != nil {
+		return m.SourceLogId
+	}
+	return nil
+}
+
+type BigQueryExfiltration_DetectionCategory struct {
+	RuleName             string   `protobuf:"bytes,1,opt,name=ruleName,proto3" json:"ruleName,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BigQueryExfiltration_DetectionCategory) Reset() {
+	*m = BigQueryExfiltration_DetectionCategory{}
+}
+func (m *BigQueryExfiltration_DetectionCategory) String() string { return proto.CompactTextString(m) }
+func (*BigQueryExfiltration_DetectionCategory) ProtoMessage()    {}
+func (*BigQueryExfiltration_DetectionCategory) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7762cc4b80af3525, []int{10, 3}
+}
+
+func (m *BigQueryExfiltration_DetectionCategory) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BigQueryExfiltration_DetectionCategory.Unmarshal(m, b)
+}
+func (m *BigQueryExfiltration_DetectionCategory) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BigQueryExfiltration_DetectionCategory.Marshal(b, m, deterministic)
+}
+func (m *BigQueryExfiltration_DetectionCategory) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BigQueryExfiltration_DetectionCategory.Merge(m, src)
+}
+func (m *BigQueryExfiltration_DetectionCategory) XXX_Size() int {
+	return xxx_messageInfo_BigQueryExfiltration_DetectionCategory.Size(m)
+}
+func (m *BigQueryExfiltration_DetectionCategory) XXX_DiscardUnknown() {
+	xxx_messageInfo_BigQueryExfiltration_DetectionCategory.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BigQueryExfiltration_DetectionCategory proto.InternalMessageInfo
+
+func (m *BigQueryExfiltration_DetectionCategory) GetRuleName() string {
+	if m != nil {
+		return m.RuleName
+	}
+	return ""
+}
+
+type BigQueryExfiltration_JSONPayload struct {
+	Properties           *BigQueryExfiltration_Properties        `protobuf:"bytes,1,opt,name=properties,proto3" json:"properties,omitempty"`
+	DetectionCategory    *BigQueryExfiltration_DetectionCategory `protobuf:"bytes,2,opt,name=detectionCategory,proto3" json:"detectionCategory,omitempty"`
+	Evidence             []*BigQueryExfiltration_Evidence        `protobuf:"bytes,3,rep,name=evidence,proto3" json:"evidence,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                `json:"-"`
+	XXX_unrecognized     []byte                                  `json:"-"`
+	XXX_sizecache        int32                                   `json:"-"`
+}
+
+func (m *BigQueryExfiltration_JSONPayload) Reset()         { *m = BigQueryExfiltration_JSONPayload{} }
+func (m *BigQueryExfiltration_JSONPayload) String() string { return proto.CompactTextString(m) }
+func (*BigQueryExfiltration_JSONPayload) ProtoMessage()    {}
+func (*BigQueryExfiltration_JSONPayload) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7762cc4b80af3525, []int{10, 4}
+}
+
+func (m *BigQueryExfiltration_JSONPayload) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BigQueryExfiltration_JSONPayload.Unmarshal(m, b)
+}
+func (m *BigQueryExfiltration_JSONPayload) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BigQueryExfiltration_JSONPayload.Marshal(b, m, deterministic)
+}
+func (m *BigQueryExfiltration_JSONPayload) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BigQueryExfiltration_JSONPayload.Merge(m, src)
+}
+func (m *BigQueryExfiltration_JSONPayload) XXX_Size() int {
+	return xxx_messageInfo_BigQueryExfiltration_JSONPayload.Size(m)
+}
+func (m *BigQueryExfiltration_JSONPayload) XXX_DiscardUnknown() {
+	xxx_messageInfo_BigQueryExfiltration_JSONPayload.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BigQueryExfiltration_JSONPayload proto.InternalMessageInfo
+
+func (m *BigQueryExfiltration_JSONPayload) GetProperties() *BigQueryExfiltration_Properties {
+	if m != nil {
+		return m.Properties
+	}
+	return nil
+}
+
+func (m *BigQueryExfiltration_JSONPayload) GetDetectionCategory() *BigQueryExfiltration_DetectionCategory {
+	if m != nil {
+		return m.DetectionCategory
+	}
+	return nil
+}
+
+func (m *BigQueryExfiltration_JSONPayload) GetEvidence() []*BigQueryExfiltration_Evidence {
+	if m != nil {
+		return m.Evidence
+	}
+	return nil
+}
+
+type BigQueryExfiltrationSCC struct {
+	NotificationConfigName string                           `protobuf:"bytes,1,opt,name=notificationConfigName,proto3" json:"notificationConfigName,omitempty"`
+	Finding                *BigQueryExfiltrationSCC_Finding `protobuf:"bytes,2,opt,name=finding,proto3" json:"finding,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{}                         `json:"-"`
+	XXX_unrecognized       []byte                           `json:"-"`
+	XXX_sizecache          int32                            `json:"-"`
+}
+
+func (m *BigQueryExfiltrationSCC) Reset()         { *m = BigQueryExfiltrationSCC{} }
+func (m *BigQueryExfiltrationSCC) String() string { return proto.CompactTextString(m) }
+func (*BigQueryExfiltrationSCC) ProtoMessage()    {}
+func (*BigQueryExfiltrationSCC) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7762cc4b80af3525, []int{11}
+}
+
+func (m *BigQueryExfiltrationSCC) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BigQueryExfiltrationSCC.Unmarshal(m, b)
+}
+func (m *BigQueryExfiltrationSCC) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BigQueryExfiltrationSCC.Marshal(b, m, deterministic)
+}
+func (m *BigQueryExfiltrationSCC) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BigQueryExfiltrationSCC.Merge(m, src)
+}
+func (m *BigQueryExfiltrationSCC) XXX_Size() int {
+	return xxx_messageInfo_BigQueryExfiltrationSCC.Size(m)
+}
+func (m *BigQueryExfiltrationSCC) XXX_DiscardUnknown() {
+	xxx_messageInfo_BigQueryExfiltrationSCC.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BigQueryExfiltrationSCC proto.InternalMessageInfo
+
+func (m *BigQueryExfiltrationSCC) GetNotificationConfigName() string {
+	if m != nil {
+		return m.NotificationConfigName
+	}
+	return ""
+}
+
+func (m *BigQueryExfiltrationSCC) GetFinding() *BigQueryExfiltrationSCC_Finding {
+	if m != nil {
+		return m.Finding
+	}
+	return nil
+}
+
+type BigQueryExfiltrationSCC_SecurityMarks struct {
+	Marks                map[string]string `protobuf:"bytes,1,rep,name=marks,proto3" json:"marks,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *BigQueryExfiltrationSCC_SecurityMarks) Reset()         { *m = BigQueryExfiltrationSCC_SecurityMarks{} }
+func (m *BigQueryExfiltrationSCC_SecurityMarks) String() string { return proto.CompactTextString(m) }
+func (*BigQueryExfiltrationSCC_SecurityMarks) ProtoMessage()    {}
+func (*BigQueryExfiltrationSCC_SecurityMarks) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7762cc4b80af3525, []int{11, 0}
+}
+
+func (m *BigQueryExfiltrationSCC_SecurityMarks) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BigQueryExfiltrationSCC_SecurityMarks.Unmarshal(m, b)
+}
+func (m *BigQueryExfiltrationSCC_SecurityMarks) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BigQueryExfiltrationSCC_SecurityMarks.Marshal(b, m, deterministic)
+}
+func (m *BigQueryExfiltrationSCC_SecurityMarks) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BigQueryExfiltrationSCC_SecurityMarks.Merge(m, src)
+}
+func (m *BigQueryExfiltrationSCC_SecurityMarks) XXX_Size() int {
+	return xxx_messageInfo_BigQueryExfiltrationSCC_SecurityMarks.Size(m)
+}
+func (m *BigQueryExfiltrationSCC_SecurityMarks) XXX_DiscardUnknown() {
+	xxx_messageInfo_BigQueryExfiltrationSCC_SecurityMarks.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BigQueryExfiltrationSCC_SecurityMarks proto.InternalMessageInfo
+
+func (m *BigQueryExfiltrationSCC_SecurityMarks) GetMarks() map[string]string {
+	if m != nil {
+		return m.Marks
+	}
+	return nil
+}
+
+type BigQueryExfiltrationSCC_Properties struct {
+	PrincipalEmail       string   `protobuf:"bytes,1,opt,name=principalEmail,proto3" json:"principalEmail,omitempty"`
+	SourceTable          string   `protobuf:"bytes,2,opt,name=sourceTable,proto3" json:"sourceTable,omitempty"`
+	DestinationTable     string   `protobuf:"bytes,3,opt,name=destinationTable,proto3" json:"destinationTable,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BigQueryExfiltrationSCC_Properties) Reset()         { *m = BigQueryExfiltrationSCC_Properties{} }
+func (m *BigQueryExfiltrationSCC_Properties) String() string { return proto.CompactTextString(m) }
+func (*BigQueryExfiltrationSCC_Properties) ProtoMessage()    {}
+func (*BigQueryExfiltrationSCC_Properties) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7762cc4b80af3525, []int{11, 1}
+}
+
+func (m *BigQueryExfiltrationSCC_Properties) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BigQueryExfiltrationSCC_Properties.Unmarshal(m, b)
+}
+func (m *BigQueryExfiltrationSCC_Properties) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BigQueryExfiltrationSCC_Properties.Marshal(b, m, deterministic)
+}
+func (m *BigQueryExfiltrationSCC_Properties) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BigQueryExfiltrationSCC_Properties.Merge(m, src)
+}
+func (m *BigQueryExfiltrationSCC_Properties) XXX_Size() int {
+	return xxx_messageInfo_BigQueryExfiltrationSCC_Properties.Size(m)
+}
+func (m *BigQueryExfiltrationSCC_Properties) XXX_DiscardUnknown() {
+	xxx_messageInfo_BigQueryExfiltrationSCC_Properties.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BigQueryExfiltrationSCC_Properties proto.InternalMessageInfo
+
+func (m *BigQueryExfiltrationSCC_Properties) GetPrincipalEmail() string {
+	if m != nil {
+		return m.PrincipalEmail
+	}
+	return ""
+}
+
+func (m *BigQueryExfiltrationSCC_Properties) GetSourceTable() string {
+	if m != nil {
+		return m.SourceTable
+	}
+	return ""
+}
+
+func (m *BigQueryExfiltrationSCC_Properties) GetDestinationTable() string {
+	if m != nil {
+		return m.DestinationTable
+	}
+	return ""
+}
+
+type BigQueryExfiltrationSCC_SourceLogId struct {
+	ProjectId            string   `protobuf:"bytes,1,opt,name=projectId,proto3" json:"projectId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BigQueryExfiltrationSCC_SourceLogId) Reset()         { *m = BigQueryExfiltrationSCC_SourceLogId{} }
+func (m *BigQueryExfiltrationSCC_SourceLogId) String() string { return proto.CompactTextString(m) }
+func (*BigQueryExfiltrationSCC_SourceLogId) ProtoMessage()    {}
+func (*BigQueryExfiltrationSCC_SourceLogId) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7762cc4b80af3525, []int{11, 2}
+}
+
+func (m *BigQueryExfiltrationSCC_SourceLogId) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BigQueryExfiltrationSCC_SourceLogId.Unmarshal(m, b)
+}
+func (m *BigQueryExfiltrationSCC_SourceLogId) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BigQueryExfiltrationSCC_SourceLogId.Marshal(b, m, deterministic)
+}
+func (m *BigQueryExfiltrationSCC_SourceLogId) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BigQueryExfiltrationSCC_SourceLogId.Merge(m, src)
+}
+func (m *BigQueryExfiltrationSCC_SourceLogId) XXX_Size() int {
+	return xxx_messageInfo_BigQueryExfiltrationSCC_SourceLogId.Size(m)
+}
+func (m *BigQueryExfiltrationSCC_SourceLogId) XXX_DiscardUnknown() {
+	xxx_messageInfo_BigQueryExfiltrationSCC_SourceLogId.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BigQueryExfiltrationSCC_SourceLogId proto.InternalMessageInfo
+
+func (m *BigQueryExfiltrationSCC_SourceLogId) GetProjectId() string {
+	if m != nil {
+		return m.ProjectId
+	}
+	return ""
+}
+
+type BigQueryExfiltrationSCC_Evidence struct {
+	SourceLogId          *BigQueryExfiltrationSCC_SourceLogId `protobuf:"bytes,1,opt,name=sourceLogId,proto3" json:"sourceLogId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                             `json:"-"`
+	XXX_unrecognized     []byte                               `json:"-"`
+	XXX_sizecache        int32                                `json:"-"`
+}
+
+func (m *BigQueryExfiltrationSCC_Evidence) Reset()         { *m = BigQueryExfiltrationSCC_Evidence{} }
+func (m *BigQueryExfiltrationSCC_Evidence) String() string { return proto.CompactTextString(m) }
+func (*BigQueryExfiltrationSCC_Evidence) ProtoMessage()    {}
+func (*BigQueryExfiltrationSCC_Evidence) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7762cc4b80af3525, []int{11, 3}
+}
+
+func (m *BigQueryExfiltrationSCC_Evidence) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BigQueryExfiltrationSCC_Evidence.Unmarshal(m, b)
+}
+func (m *BigQueryExfiltrationSCC_Evidence) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BigQueryExfiltrationSCC_Evidence.Marshal(b, m, deterministic)
+}
+func (m *BigQueryExfiltrationSCC_Evidence) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BigQueryExfiltrationSCC_Evidence.Merge(m, src)
+}
+func (m *BigQueryExfiltrationSCC_Evidence) XXX_Size() int {
+	return xxx_messageInfo_BigQueryExfiltrationSCC_Evidence.Size(m)
+}
+func (m *BigQueryExfiltrationSCC_Evidence) XXX_DiscardUnknown() {
+	xxx_messageInfo_BigQueryExfiltrationSCC_Evidence.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BigQueryExfiltrationSCC_Evidence proto.InternalMessageInfo
+
+func (m *BigQueryExfiltrationSCC_Evidence) GetSourceLogId() *BigQueryExfiltrationSCC_SourceLogId {
+	if m != nil {
+		return m.SourceLogId
+	}
+	return nil
+}
+
+type BigQueryExfiltrationSCC_DetectionCategory struct {
+	RuleName             string   `protobuf:"bytes,1,opt,name=ruleName,proto3" json:"ruleName,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BigQueryExfiltrationSCC_DetectionCategory) Reset() {
+	*m = BigQueryExfiltrationSCC_DetectionCategory{}
+}
+func (m *BigQueryExfiltrationSCC_DetectionCategory) String() string {
+	return proto.CompactTextString(m)
+}
+func (*BigQueryExfiltrationSCC_DetectionCategory) ProtoMessage() {}
+func (*BigQueryExfiltrationSCC_DetectionCategory) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7762cc4b80af3525, []int{11, 4}
+}
+
+func (m *BigQueryExfiltrationSCC_DetectionCategory) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BigQueryExfiltrationSCC_DetectionCategory.Unmarshal(m, b)
+}
+func (m *BigQueryExfiltrationSCC_DetectionCategory) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BigQueryExfiltrationSCC_DetectionCategory.Marshal(b, m, deterministic)
+}
+func (m *BigQueryExfiltrationSCC_DetectionCategory) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BigQueryExfiltrationSCC_DetectionCategory.Merge(m, src)
+}
+func (m *BigQueryExfiltrationSCC_DetectionCategory) XXX_Size() int {
+	return xxx_messageInfo_BigQueryExfiltrationSCC_DetectionCategory.Size(m)
+}
+func (m *BigQueryExfiltrationSCC_DetectionCategory) XXX_DiscardUnknown() {
+	xxx_messageInfo_BigQueryExfiltrationSCC_DetectionCategory.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BigQueryExfiltrationSCC_DetectionCategory proto.InternalMessageInfo
+
+func (m *BigQueryExfiltrationSCC_DetectionCategory) GetRuleName() string {
+	if m != nil {
+		return m.RuleName
+	}
+	return ""
+}
+
+type BigQueryExfiltrationSCC_SourceProperties struct {
+	Properties           *BigQueryExfiltrationSCC_Properties        `protobuf:"bytes,1,opt,name=properties,proto3" json:"properties,omitempty"`
+	DetectionCategory    *BigQueryExfiltrationSCC_DetectionCategory `protobuf:"bytes,2,opt,name=detectionCategory,proto3" json:"detectionCategory,omitempty"`
+	Evidence             []*BigQueryExfiltrationSCC_Evidence        `protobuf:"bytes,3,rep,name=evidence,proto3" json:"evidence,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                   `json:"-"`
+	XXX_unrecognized     []byte                                     `json:"-"`
+	XXX_sizecache        int32                                      `json:"-"`
+}
+
+func (m *BigQueryExfiltrationSCC_SourceProperties) Reset() {
+	*m = BigQueryExfiltrationSCC_SourceProperties{}
+}
+func (m *BigQueryExfiltrationSCC_SourceProperties) String() string { return proto.CompactTextString(m) }
+func (*BigQueryExfiltrationSCC_SourceProperties) ProtoMessage()    {}
+func (*BigQueryExfiltrationSCC_SourceProperties) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7762cc4b80af3525, []int{11, 5}
+}
+
+func (m *BigQueryExfiltrationSCC_SourceProperties) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BigQueryExfiltrationSCC_SourceProperties.Unmarshal(m, b)
+}
+func (m *BigQueryExfiltrationSCC_SourceProperties) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BigQueryExfiltrationSCC_SourceProperties.Marshal(b, m, deterministic)
+}
+func (m *BigQueryExfiltrationSCC_SourceProperties) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BigQueryExfiltrationSCC_SourceProperties.Merge(m, src)
+}
+func (m *BigQueryExfiltrationSCC_SourceProperties) XXX_Size() int {
+	return xxx_messageInfo_BigQueryExfiltrationSCC_SourceProperties.Size(m)
+}
+func (m *BigQueryExfiltrationSCC_SourceProperties) XXX_DiscardUnknown() {
+	xxx_messageInfo_BigQueryExfiltrationSCC_SourceProperties.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BigQueryExfiltrationSCC_SourceProperties proto.InternalMessageInfo
+
+func (m *BigQueryExfiltrationSCC_SourceProperties) GetProperties() *BigQueryExfiltrationSCC_Properties {
+	if m != nil {
+		return m.Properties
+	}
+	return nil
+}
+
+func (m *BigQueryExfiltrationSCC_SourceProperties) GetDetectionCategory() *BigQueryExfiltrationSCC_DetectionCategory {
+	if m != nil {
+		return m.DetectionCategory
+	}
+	return nil
+}
+
+func (m *BigQueryExfiltrationSCC_SourceProperties) GetEvidence() []*BigQueryExfiltrationSCC_Evidence {
+	if m != nil {
+		return m.Evidence
+	}
+	return nil
+}
+
+type BigQueryExfiltrationSCC_Finding struct {
+	SourceProperties     *BigQueryExfiltrationSCC_SourceProperties `protobuf:"bytes,1,opt,name=sourceProperties,proto3" json:"sourceProperties,omitempty"`
+	Category             string                                    `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
+	ResourceName         string                                    `protobuf:"bytes,3,opt,name=resourceName,proto3" json:"resourceName,omitempty"`
+	State                string                                    `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"`
+	SecurityMarks        *BigQueryExfiltrationSCC_SecurityMarks    `protobuf:"bytes,5,opt,name=securityMarks,proto3" json:"securityMarks,omitempty"`
+	EventTime            string                                    `protobuf:"bytes,6,opt,name=eventTime,proto3" json:"eventTime,omitempty"`
+	Name                 string                                    `protobuf:"bytes,7,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                  `json:"-"`
+	XXX_unrecognized     []byte                                    `json:"-"`
+	XXX_sizecache        int32                                     `json:"-"`
+}
+
+func (m *BigQueryExfiltrationSCC_Finding) Reset()         { *m = BigQueryExfiltrationSCC_Finding{} }
+func (m *BigQueryExfiltrationSCC_Finding) String() string { return proto.CompactTextString(m) }
+func (*BigQueryExfiltrationSCC_Finding) ProtoMessage()    {}
+func (*BigQueryExfiltrationSCC_Finding) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7762cc4b80af3525, []int{11, 6}
+}
+
+func (m *BigQueryExfiltrationSCC_Finding) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BigQueryExfiltrationSCC_Finding.Unmarshal(m, b)
+}
+func (m *BigQueryExfiltrationSCC_Finding) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BigQueryExfiltrationSCC_Finding.Marshal(b, m, deterministic)
+}
+func (m *BigQueryExfiltrationSCC_Finding) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BigQueryExfiltrationSCC_Finding.Merge(m, src)
+}
+func (m *BigQueryExfiltrationSCC_Finding) XXX_Size() int {
+	return xxx_messageInfo_BigQueryExfiltrationSCC_Finding.Size(m)
+}
+func (m *BigQueryExfiltrationSCC_Finding) XXX_DiscardUnknown() {
+	xxx_messageInfo_BigQueryExfiltrationSCC_Finding.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BigQueryExfiltrationSCC_Finding proto.InternalMessageInfo
+
+func (m *BigQueryExfiltrationSCC_Finding) GetSourceProperties() *BigQueryExfiltrationSCC_SourceProperties {
+	if m != nil {
+		return m.SourceProperties
+	}
+	return nil
+}
+
+func (m *BigQueryExfiltrationSCC_Finding) GetCategory() string {
+	if m != nil {
+		return m.Category
+	}
+	return ""
+}
+
+func (m *BigQueryExfiltrationSCC_Finding) GetResourceName() string {
+	if m != nil {
+		return m.ResourceName
+	}
+	return ""
+}
+
+func (m *BigQueryExfiltrationSCC_Finding) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *BigQueryExfiltrationSCC_Finding) GetSecurityMarks() *BigQueryExfiltrationSCC_SecurityMarks {
+	if m != nil {
+		return m.SecurityMarks
+	}
+	return nil
+}
+
+func (m *BigQueryExfiltrationSCC_Finding) GetEventTime() string {
+	if m != nil {
+		return m.EventTime
+	}
+	return ""
+}
+
+func (m *BigQueryExfiltrationSCC_Finding) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type Cryptomining struct {
+	InsertId             string                    `protobuf:"bytes,1,opt,name=insertId,proto3" json:"insertId,omitempty"`
+	LogName              string                    `protobuf:"bytes,2,opt,name=logName,proto3" json:"logName,omitempty"`
+	JsonPayload          *Cryptomining_JSONPayload `protobuf:"bytes,3,opt,name=jsonPayload,proto3" json:"jsonPayload,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *Cryptomining) Reset()         { *m = Cryptomining{} }
+func (m *Cryptomining) String() string { return proto.CompactTextString(m) }
+func (*Cryptomining) ProtoMessage()    {}
+func (*Cryptomining) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7762cc4b80af3525, []int{12}
+}
+
+func (m *Cryptomining) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Cryptomining.Unmarshal(m, b)
+}
+func (m *Cryptomining) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Cryptomining.Marshal(b, m, deterministic)
+}
+func (m *Cryptomining) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Cryptomining.Merge(m, src)
+}
+func (m *Cryptomining) XXX_Size() int {
+	return xxx_messageInfo_Cryptomining.Size(m)
+}
+func (m *Cryptomining) XXX_DiscardUnknown() {
+	xxx_messageInfo_Cryptomining.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Cryptomining proto.InternalMessageInfo
+
+func (m *Cryptomining) GetInsertId() string {
+	if m != nil {
+		return m.InsertId
+	}
+	return ""
+}
+
+func (m *Cryptomining) GetLogName() string {
+	if m != nil {
+		return m.LogName
+	}
+	return ""
+}
+
+func (m *Cryptomining) GetJsonPayload() *Cryptomining_JSONPayload {
+	if m != nil {
+		return m.JsonPayload
+	}
+	return nil
+}
+
+type Cryptomining_Network struct {
+	Project              string   `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Cryptomining_Network) Reset()         { *m = Cryptomining_Network{} }
+func (m *Cryptomining_Network) String() string { return proto.CompactTextString(m) }
+func (*Cryptomining_Network) ProtoMessage()    {}
+func (*Cryptomining_Network) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7762cc4b80af3525, []int{12, 0}
+}
+
+func (m *Cryptomining_Network) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Cryptomining_Network.Unmarshal(m, b)
+}
+func (m *Cryptomining_Network) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Cryptomining_Network.Marshal(b, m, deterministic)
+}
+func (m *Cryptomining_Network) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Cryptomining_Network.Merge(m, src)
+}
+func (m *Cryptomining_Network) XXX_Size() int {
+	return xxx_messageInfo_Cryptomining_Network.Size(m)
+}
+func (m *Cryptomining_Network) XXX_DiscardUnknown() {
+	xxx_messageInfo_Cryptomining_Network.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Cryptomining_Network proto.InternalMessageInfo
+
+func (m *Cryptomining_Network) GetProject() string {
+	if m != nil {
+		return m.Project
+	}
+	return ""
+}
+
+type Cryptomining_Properties struct {
+	Network              *Cryptomining_Network `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
+	InstanceDetails      string                `protobuf:"bytes,2,opt,name=instanceDetails,proto3" json:"instanceDetails,omitempty"`
+	Ip                   []string              `protobuf:"bytes,3,rep,name=ip,proto3" json:"ip,omitempty"`
+	Domain               []string              `protobuf:"bytes,4,rep,name=domain,proto3" json:"domain,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *Cryptomining_Properties) Reset()         { *m = Cryptomining_Properties{} }
+func (m *Cryptomining_Properties) String() string { return proto.CompactTextString(m) }
+func (*Cryptomining_Properties) ProtoMessage()    {}
+func (*Cryptomining_Properties) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7762cc4b80af3525, []int{12, 1}
+}
+
+func (m *Cryptomining_Properties) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Cryptomining_Properties.Unmarshal(m, b)
+}
+func (m *Cryptomining_Properties) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Cryptomining_Properties.Marshal(b, m, deterministic)
+}
+func (m *Cryptomining_Properties) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Cryptomining_Properties.Merge(m, src)
+}
+func (m *Cryptomining_Properties) XXX_Size() int {
+	return xxx_messageInfo_Cryptomining_Properties.Size(m)
+}
+func (m *Cryptomining_Properties) XXX_DiscardUnknown() {
+	xxx_messageInfo_Cryptomining_Properties.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Cryptomining_Properties proto.InternalMessageInfo
+
+func (m *Cryptomining_Properties) GetNetwork() *Cryptomining_Network {
+	if m != nil {
+		return m.Network
+	}
+	return nil
+}
+
+func (m *Cryptomining_Properties) GetInstanceDetails() string {
+	if m != nil {
+		return m.InstanceDetails
+	}
+	return ""
+}
+
+func (m *Cryptomining_Properties) GetIp() []string {
+	if m != nil {
+		return m.Ip
+	}
+	return nil
+}
+
+func (m *Cryptomining_Properties) GetDomain() []string {
+	if m != nil {
+		return m.Domain
+	}
+	return nil
+}
+
+type Cryptomining_DetectionCategory struct {
+	RuleName             string   `protobuf:"bytes,1,opt,name=ruleName,proto3" json:"ruleName,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Cryptomining_DetectionCategory) Reset()         { *m = Cryptomining_DetectionCategory{} }
+func (m *Cryptomining_DetectionCategory) String() string { return proto.CompactTextString(m) }
+func (*Cryptomining_DetectionCategory) ProtoMessage()    {}
+func (*Cryptomining_DetectionCategory) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7762cc4b80af3525, []int{12, 2}
+}
+
+func (m *Cryptomining_DetectionCategory) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Cryptomining_DetectionCategory.Unmarshal(m, b)
+}
+func (m *Cryptomining_DetectionCategory) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Cryptomining_DetectionCategory.Marshal(b, m, deterministic)
+}
+func (m *Cryptomining_DetectionCategory) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Cryptomining_DetectionCategory.Merge(m, src)
+}
+func (m *Cryptomining_DetectionCategory) XXX_Size() int {
+	return xxx_messageInfo_Cryptomining_DetectionCategory.Size(m)
+}
+func (m *Cryptomining_DetectionCategory) XXX_DiscardUnknown() {
+	xxx_messageInfo_Cryptomining_DetectionCategory.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Cryptomining_DetectionCategory proto.InternalMessageInfo
+
+func (m *Cryptomining_DetectionCategory) GetRuleName() string {
+	if m != nil {
+		return m.RuleName
+	}
+	return ""
+}
+
+type Cryptomining_JSONPayload struct {
+	Properties           *Cryptomining_Properties        `protobuf:"bytes,1,opt,name=properties,proto3" json:"properties,omitempty"`
+	DetectionCategory    *Cryptomining_DetectionCategory `protobuf:"bytes,2,opt,name=detectionCategory,proto3" json:"detectionCategory,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
+	XXX_unrecognized     []byte                          `json:"-"`
+	XXX_sizecache        int32                           `json:"-"`
+}
+
+func (m *Cryptomining_JSONPayload) Reset()         { *m = Cryptomining_JSONPayload{} }
+func (m *Cryptomining_JSONPayload) String() string { return proto.CompactTextString(m) }
+func (*Cryptomining_JSONPayload) ProtoMessage()    {}
+func (*Cryptomining_JSONPayload) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7762cc4b80af3525, []int{12, 3}
+}
+
+func (m *Cryptomining_JSONPayload) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Cryptomining_JSONPayload.Unmarshal(m, b)
+}
+func (m *Cryptomining_JSONPayload) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Cryptomining_JSONPayload.Marshal(b, m, deterministic)
+}
+func (m *Cryptomining_JSONPayload) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Cryptomining_JSONPayload.Merge(m, src)
+}
+func (m *Cryptomining_JSONPayload) XXX_Size() int {
+	return xxx_messageInfo_Cryptomining_JSONPayload.Size(m)
+}
+func (m *Cryptomining_JSONPayload) XXX_DiscardUnknown() {
+	xxx_messageInfo_Cryptomining_JSONPayload.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Cryptomining_JSONPayload proto.InternalMessageInfo
+
+func (m *Cryptomining_JSONPayload) GetProperties() *Cryptomining_Properties {
+	if m != nil {
+		return m.Properties
+	}
+	return nil
+}
+
+func (m *Cryptomining_JSONPayload) GetDetectionCategory() *Cryptomining_DetectionCategory {
+	if m != nil {
+		return m.DetectionCategory
+	}
+	return nil
+}
+
+type CryptominingSCC struct {
+	NotificationConfigName string                   `protobuf:"bytes,1,opt,name=notificationConfigName,proto3" json:"notificationConfigName,omitempty"`
+	Finding                *CryptominingSCC_Finding `protobuf:"bytes,2,opt,name=finding,proto3" json:"finding,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{}                 `json:"-"`
+	XXX_unrecognized       []byte                   `json:"-"`
+	XXX_sizecache          int32                    `json:"-"`
+}
+
+func (m *CryptominingSCC) Reset()         { *m = CryptominingSCC{} }
+func (m *CryptominingSCC) String() string { return proto.CompactTextString(m) }
+func (*CryptominingSCC) ProtoMessage()    {}
+func (*CryptominingSCC) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7762cc4b80af3525, []int{13}
+}
+
+func (m *CryptominingSCC) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CryptominingSCC.Unmarshal(m, b)
+}
+func (m *CryptominingSCC) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CryptominingSCC.Marshal(b, m, deterministic)
+}
+func (m *CryptominingSCC) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CryptominingSCC.Merge(m, src)
+}
+func (m *CryptominingSCC) XXX_Size() int {
+	return xxx_messageInfo_CryptominingSCC.Size(m)
+}
+func (m *CryptominingSCC) XXX_DiscardUnknown() {
+	xxx_messageInfo_CryptominingSCC.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CryptominingSCC proto.InternalMessageInfo
+
+func (m *CryptominingSCC) GetNotificationConfigName() string {
+	if m != nil {
+		return m.NotificationConfigName
+	}
+	return ""
+}
+
+func (m *CryptominingSCC) GetFinding() *CryptominingSCC_Finding {
+	if m != nil {
+		return m.Finding
+	}
+	return nil
+}
+
+type CryptominingSCC_SecurityMarks struct {
+	Marks                map[string]string `protobuf:"bytes,1,rep,name=marks,proto3" json:"marks,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *CryptominingSCC_SecurityMarks) Reset()         { *m = CryptominingSCC_SecurityMarks{} }
+func (m *CryptominingSCC_SecurityMarks) String() string { return proto.CompactTextString(m) }
+func (*CryptominingSCC_SecurityMarks) ProtoMessage()    {}
+func (*CryptominingSCC_SecurityMarks) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7762cc4b80af3525, []int{13, 0}
+}
+
+func (m *CryptominingSCC_SecurityMarks) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CryptominingSCC_SecurityMarks.Unmarshal(m, b)
+}
+func (m *CryptominingSCC_SecurityMarks) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CryptominingSCC_SecurityMarks.Marshal(b, m, deterministic)
+}
+func (m *CryptominingSCC_SecurityMarks) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CryptominingSCC_SecurityMarks.Merge(m, src)
+}
+func (m *CryptominingSCC_SecurityMarks) XXX_Size() int {
+	return xxx_messageInfo_CryptominingSCC_SecurityMarks.Size(m)
+}
+func (m *CryptominingSCC_SecurityMarks) XXX_DiscardUnknown() {
+	xxx_messageInfo_CryptominingSCC_SecurityMarks.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CryptominingSCC_SecurityMarks proto.InternalMessageInfo
+
+func (m *CryptominingSCC_SecurityMarks) GetMarks() map[string]string {
+	if m != nil {
+		return m.Marks
+	}
+	return nil
+}
+
+type CryptominingSCC_Network struct {
+	Project              string   `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CryptominingSCC_Network) Reset()         { *m = CryptominingSCC_Network{} }
+func (m *CryptominingSCC_Network) String() string { return proto.CompactTextString(m) }
+func (*CryptominingSCC_Network) ProtoMessage()    {}
+func (*CryptominingSCC_Network) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7762cc4b80af3525, []int{13, 1}
+}
+
+func (m *CryptominingSCC_Network) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CryptominingSCC_Network.Unmarshal(m, b)
+}
+func (m *CryptominingSCC_Network) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CryptominingSCC_Network.Marshal(b, m, deterministic)
+}
+func (m *CryptominingSCC_Network) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CryptominingSCC_Network.Merge(m, src)
+}
+func (m *CryptominingSCC_Network) XXX_Size() int {
+	return xxx_messageInfo_CryptominingSCC_Network.Size(m)
+}
+func (m *CryptominingSCC_Network) XXX_DiscardUnknown() {
+	xxx_messageInfo_CryptominingSCC_Network.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CryptominingSCC_Network proto.InternalMessageInfo
+
+func (m *CryptominingSCC_Network) GetProject() string {
+	if m != nil {
+		return m.Project
+	}
+	return ""
+}
+
+type CryptominingSCC_Properties struct {
+	Network              *CryptominingSCC_Network `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
+	InstanceDetails      string                   `protobuf:"bytes,2,opt,name=instanceDetails,proto3" json:"instanceDetails,omitempty"`
+	Ip                   []string                 `protobuf:"bytes,3,rep,name=ip,proto3" json:"ip,omitempty"`
+	Domain               []string                 `protobuf:"bytes,4,rep,name=domain,proto3" json:"domain,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *CryptominingSCC_Properties) Reset()         { *m = CryptominingSCC_Properties{} }
+func (m *CryptominingSCC_Properties) String() string { return proto.CompactTextString(m) }
+func (*CryptominingSCC_Properties) ProtoMessage()    {}
+func (*CryptominingSCC_Properties) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7762cc4b80af3525, []int{13, 2}
+}
+
+func (m *CryptominingSCC_Properties) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CryptominingSCC_Properties.Unmarshal(m, b)
+}
+func (m *CryptominingSCC_Properties) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CryptominingSCC_Properties.Marshal(b, m, deterministic)
+}
+func (m *CryptominingSCC_Properties) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CryptominingSCC_Properties.Merge(m, src)
+}
+func (m *CryptominingSCC_Properties) XXX_Size() int {
+	return xxx_messageInfo_CryptominingSCC_Properties.Size(m)
+}
+func (m *CryptominingSCC_Properties) XXX_DiscardUnknown() {
+	xxx_messageInfo_CryptominingSCC_Properties.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CryptominingSCC_Properties proto.InternalMessageInfo
+
+func (m *CryptominingSCC_Properties) GetNetwork() *CryptominingSCC_Network {
+	if m != nil {
+		return m.Network
+	}
+	return nil
+}
+
+func (m *CryptominingSCC_Properties) GetInstanceDetails() string {
+	if m != nil {
+		return m.InstanceDetails
+	}
+	return ""
+}
+
+func (m *CryptominingSCC_Properties) GetIp() []string {
+	if m != nil {
+		return m.Ip
+	}
+	return nil
+}
+
+func (m *CryptominingSCC_Properties) GetDomain() []string {
+	if m != nil {
+		return m.Domain
+	}
+	return nil
+}
+
+type CryptominingSCC_DetectionCategory struct {
+	RuleName             string   `protobuf:"bytes,1,opt,name=ruleName,proto3" json:"ruleName,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CryptominingSCC_DetectionCategory) Reset()         { *m = CryptominingSCC_DetectionCategory{} }
+func (m *CryptominingSCC_DetectionCategory) String() string { return proto.CompactTextString(m) }
+func (*CryptominingSCC_DetectionCategory) ProtoMessage()    {}
+func (*CryptominingSCC_DetectionCategory) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7762cc4b80af3525, []int{13, 3}
+}
+
+func (m *CryptominingSCC_DetectionCategory) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CryptominingSCC_DetectionCategory.Unmarshal(m, b)
+}
+func (m *CryptominingSCC_DetectionCategory) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CryptominingSCC_DetectionCategory.Marshal(b, m, deterministic)
+}
+func (m *CryptominingSCC_DetectionCategory) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CryptominingSCC_DetectionCategory.Merge(m, src)
+}
+func (m *CryptominingSCC_DetectionCategory) XXX_Size() int {
+	return xxx_messageInfo_CryptominingSCC_DetectionCategory.Size(m)
+}
+func (m *CryptominingSCC_DetectionCategory) XXX_DiscardUnknown() {
+	xxx_messageInfo_CryptominingSCC_DetectionCategory.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CryptominingSCC_DetectionCategory proto.InternalMessageInfo
+
+func (m *CryptominingSCC_DetectionCategory) GetRuleName() string {
+	if m != nil {
+		return m.RuleName
+	}
+	return ""
+}
+
+type CryptominingSCC_SourceProperties struct {
+	Properties           *CryptominingSCC_Properties        `protobuf:"bytes,1,opt,name=properties,proto3" json:"properties,omitempty"`
+	DetectionCategory    *CryptominingSCC_DetectionCategory `protobuf:"bytes,2,opt,name=detectionCategory,proto3" json:"detectionCategory,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                           `json:"-"`
+	XXX_unrecognized     []byte                             `json:"-"`
+	XXX_sizecache        int32                              `json:"-"`
+}
+
+func (m *CryptominingSCC_SourceProperties) Reset()         { *m = CryptominingSCC_SourceProperties{} }
+func (m *CryptominingSCC_SourceProperties) String() string { return proto.CompactTextString(m) }
+func (*CryptominingSCC_SourceProperties) ProtoMessage()    {}
+func (*CryptominingSCC_SourceProperties) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7762cc4b80af3525, []int{13, 4}
+}
+
+func (m *CryptominingSCC_SourceProperties) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CryptominingSCC_SourceProperties.Unmarshal(m, b)
+}
+func (m *CryptominingSCC_SourceProperties) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CryptominingSCC_SourceProperties.Marshal(b, m, deterministic)
+}
+func (m *CryptominingSCC_SourceProperties) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CryptominingSCC_SourceProperties.Merge(m, src)
+}
+func (m *CryptominingSCC_SourceProperties) XXX_Size() int {
+	return xxx_messageInfo_CryptominingSCC_SourceProperties.Size(m)
+}
+func (m *CryptominingSCC_SourceProperties) XXX_DiscardUnknown() {
+	xxx_messageInfo_CryptominingSCC_SourceProperties.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CryptominingSCC_SourceProperties proto.InternalMessageInfo
+
+func (m *CryptominingSCC_SourceProperties) GetProperties() *CryptominingSCC_Properties {
+	if m != nil {
+		return m.Properties
+	}
+	return nil
+}
+
+func (m *CryptominingSCC_SourceProperties) GetDetectionCategory() *CryptominingSCC_DetectionCategory {
+	if m != nil {
+		return m.DetectionCategory
+	}
+	return nil
+}
+
+type CryptominingSCC_Finding struct {
+	SourceProperties     *CryptominingSCC_SourceProperties `protobuf:"bytes,1,opt,name=sourceProperties,proto3" json:"sourceProperties,omitempty"`
+	Category             string                            `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
+	ResourceName         string                            `protobuf:"bytes,3,opt,name=resourceName,proto3" json:"resourceName,omitempty"`
+	State                string                            `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"`
+	SecurityMarks        *CryptominingSCC_SecurityMarks    `protobuf:"bytes,5,opt,name=securityMarks,proto3" json:"securityMarks,omitempty"`
+	EventTime            string                            `protobuf:"bytes,6,opt,name=eventTime,proto3" json:"eventTime,omitempty"`
+	Name                 string                            `protobuf:"bytes,7,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                          `json:"-"`
+	XXX_unrecognized     []byte                            `json:"-"`
+	XXX_sizecache        int32                             `json:"-"`
+}
+
+func (m *CryptominingSCC_Finding) Reset()         { *m = CryptominingSCC_Finding{} }
+func (m *CryptominingSCC_Finding) String() string { return proto.CompactTextString(m) }
+func (*CryptominingSCC_Finding) ProtoMessage()    {}
+func (*CryptominingSCC_Finding) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7762cc4b80af3525, []int{13, 5}
+}
+
+func (m *CryptominingSCC_Finding) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CryptominingSCC_Finding.Unmarshal(m, b)
+}
+func (m *CryptominingSCC_Finding) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CryptominingSCC_Finding.Marshal(b, m, deterministic)
+}
+func (m *CryptominingSCC_Finding) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CryptominingSCC_Finding.Merge(m, src)
+}
+func (m *CryptominingSCC_Finding) XXX_Size() int {
+	return xxx_messageInfo_CryptominingSCC_Finding.Size(m)
+}
+func (m *CryptominingSCC_Finding) XXX_DiscardUnknown() {
+	xxx_messageInfo_CryptominingSCC_Finding.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CryptominingSCC_Finding proto.InternalMessageInfo
+
+func (m *CryptominingSCC_Finding) GetSourceProperties() *CryptominingSCC_SourceProperties {
+	if m != nil {
+		return m.SourceProperties
+	}
+	return nil
+}
+
+func (m *CryptominingSCC_Finding) GetCategory() string {
+	if m != nil {
+		return m.Category
+	}
+	return ""
+}
+
+func (m *CryptominingSCC_Finding) GetResourceName() string {
+	if m != nil {
+		return m.ResourceName
+	}
+	return ""
+}
+
+func (m *CryptominingSCC_Finding) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *CryptominingSCC_Finding) GetSecurityMarks() *CryptominingSCC_SecurityMarks {
+	if m != nil {
+		return m.SecurityMarks
+	}
+	return nil
+}
+
+func (m *CryptominingSCC_Finding) GetEventTime() string {
+	if m != nil {
+		return m.EventTime
+	}
+	return ""
+}
+
+func (m *CryptominingSCC_Finding) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*BadDomain)(nil), "BadDomain")
+	proto.RegisterType((*BadDomain_Network)(nil), "BadDomain.Network")
+	proto.RegisterType((*BadDomain_Properties)(nil), "BadDomain.Properties")
+	proto.RegisterType((*BadDomain_DetectionCategory)(nil), "BadDomain.DetectionCategory")
+	proto.RegisterType((*BadDomain_JSONPayload)(nil), "BadDomain.JSONPayload")
 	proto.RegisterType((*AnomalousIAMGrant)(nil), "AnomalousIAMGrant")
 	proto.RegisterType((*AnomalousIAMGrant_SensitiveRoleGrant)(nil), "AnomalousIAMGrant.SensitiveRoleGrant")
 	proto.RegisterType((*AnomalousIAMGrant_Properties)(nil), "AnomalousIAMGrant.Properties")
@@ -2213,87 +4757,182 @@ func init() {
 	proto.RegisterType((*SshBruteForceSCC_DetectionCategory)(nil), "SshBruteForceSCC.DetectionCategory")
 	proto.RegisterType((*SshBruteForceSCC_SourceProperties)(nil), "SshBruteForceSCC.SourceProperties")
 	proto.RegisterType((*SshBruteForceSCC_Finding)(nil), "SshBruteForceSCC.Finding")
+	proto.RegisterType((*BadDomainSCC)(nil), "BadDomainSCC")
+	proto.RegisterType((*BadDomainSCC_SecurityMarks)(nil), "BadDomainSCC.SecurityMarks")
+	proto.RegisterMapType((map[string]string)(nil), "BadDomainSCC.SecurityMarks.MarksEntry")
+	proto.RegisterType((*BadDomainSCC_Network)(nil), "BadDomainSCC.Network")
+	proto.RegisterType((*BadDomainSCC_Properties)(nil), "BadDomainSCC.Properties")
+	proto.RegisterType((*BadDomainSCC_DetectionCategory)(nil), "BadDomainSCC.DetectionCategory")
+	proto.RegisterType((*BadDomainSCC_SourceProperties)(nil), "BadDomainSCC.SourceProperties")
+	proto.RegisterType((*BadDomainSCC_Finding)(nil), "BadDomainSCC.Finding")
+	proto.RegisterType((*NewGeography)(nil), "NewGeography")
+	proto.RegisterType((*NewGeography_Properties)(nil), "NewGeography.Properties")
+	proto.RegisterType((*NewGeography_SourceLogId)(nil), "NewGeography.SourceLogId")
+	proto.RegisterType((*NewGeography_Evidence)(nil), "NewGeography.Evidence")
+	proto.RegisterType((*NewGeography_DetectionCategory)(nil), "NewGeography.DetectionCategory")
+	proto.RegisterType((*NewGeography_JSONPayload)(nil), "NewGeography.JSONPayload")
+	proto.RegisterType((*NewGeographySCC)(nil), "NewGeographySCC")
+	proto.RegisterType((*NewGeographySCC_SecurityMarks)(nil), "NewGeographySCC.SecurityMarks")
+	proto.RegisterMapType((map[string]string)(nil), "NewGeographySCC.SecurityMarks.MarksEntry")
+	proto.RegisterType((*NewGeographySCC_Properties)(nil), "NewGeographySCC.Properties")
+	proto.RegisterType((*NewGeographySCC_SourceLogId)(nil), "NewGeographySCC.SourceLogId")
+	proto.RegisterType((*NewGeographySCC_Evidence)(nil), "NewGeographySCC.Evidence")
+	proto.RegisterType((*NewGeographySCC_DetectionCategory)(nil), "NewGeographySCC.DetectionCategory")
+	proto.RegisterType((*NewGeographySCC_SourceProperties)(nil), "NewGeographySCC.SourceProperties")
+	proto.RegisterType((*NewGeographySCC_Finding)(nil), "NewGeographySCC.Finding")
+	proto.RegisterType((*BigQueryExfiltration)(nil), "BigQueryExfiltration")
+	proto.RegisterType((*BigQueryExfiltration_Properties)(nil), "BigQueryExfiltration.Properties")
+	proto.RegisterType((*BigQueryExfiltration_SourceLogId)(nil), "BigQueryExfiltration.SourceLogId")
+	proto.RegisterType((*BigQueryExfiltration_Evidence)(nil), "BigQueryExfiltration.Evidence")
+	proto.RegisterType((*BigQueryExfiltration_DetectionCategory)(nil), "BigQueryExfiltration.DetectionCategory")
+	proto.RegisterType((*BigQueryExfiltration_JSONPayload)(nil), "BigQueryExfiltration.JSONPayload")
+	proto.RegisterType((*BigQueryExfiltrationSCC)(nil), "BigQueryExfiltrationSCC")
+	proto.RegisterType((*BigQueryExfiltrationSCC_SecurityMarks)(nil), "BigQueryExfiltrationSCC.SecurityMarks")
+	proto.RegisterMapType((map[string]string)(nil), "BigQueryExfiltrationSCC.SecurityMarks.MarksEntry")
+	proto.RegisterType((*BigQueryExfiltrationSCC_Properties)(nil), "BigQueryExfiltrationSCC.Properties")
+	proto.RegisterType((*BigQueryExfiltrationSCC_SourceLogId)(nil), "BigQueryExfiltrationSCC.SourceLogId")
+	proto.RegisterType((*BigQueryExfiltrationSCC_Evidence)(nil), "BigQueryExfiltrationSCC.Evidence")
+	proto.RegisterType((*BigQueryExfiltrationSCC_DetectionCategory)(nil), "BigQueryExfiltrationSCC.DetectionCategory")
+	proto.RegisterType((*BigQueryExfiltrationSCC_SourceProperties)(nil), "BigQueryExfiltrationSCC.SourceProperties")
+	proto.RegisterType((*BigQueryExfiltrationSCC_Finding)(nil), "BigQueryExfiltrationSCC.Finding")
+	proto.RegisterType((*Cryptomining)(nil), "Cryptomining")
+	proto.RegisterType((*Cryptomining_Network)(nil), "Cryptomining.Network")
+	proto.RegisterType((*Cryptomining_Properties)(nil), "Cryptomining.Properties")
+	proto.RegisterType((*Cryptomining_DetectionCategory)(nil), "Cryptomining.DetectionCategory")
+	proto.RegisterType((*Cryptomining_JSONPayload)(nil), "Cryptomining.JSONPayload")
+	proto.RegisterType((*CryptominingSCC)(nil), "CryptominingSCC")
+	proto.RegisterType((*CryptominingSCC_SecurityMarks)(nil), "CryptominingSCC.SecurityMarks")
+	proto.RegisterMapType((map[string]string)(nil), "CryptominingSCC.SecurityMarks.MarksEntry")
+	proto.RegisterType((*CryptominingSCC_Network)(nil), "CryptominingSCC.Network")
+	proto.RegisterType((*CryptominingSCC_Properties)(nil), "CryptominingSCC.Properties")
+	proto.RegisterType((*CryptominingSCC_DetectionCategory)(nil), "CryptominingSCC.DetectionCategory")
+	proto.RegisterType((*CryptominingSCC_SourceProperties)(nil), "CryptominingSCC.SourceProperties")
+	proto.RegisterType((*CryptominingSCC_Finding)(nil), "CryptominingSCC.Finding")
 }
 
 func init() { proto.RegisterFile("etd/protos/etd.proto", fileDescriptor_7762cc4b80af3525) }
 
 var fileDescriptor_7762cc4b80af3525 = []byte{
-	// 1228 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x58, 0xcf, 0x6f, 0x1b, 0x45,
-	0x14, 0x96, 0xe3, 0x24, 0x8e, 0x9f, 0x1b, 0x48, 0x46, 0x51, 0x6b, 0xb6, 0x6d, 0x62, 0x1c, 0x7e,
-	0x58, 0x8d, 0xe4, 0x88, 0xb4, 0xa2, 0xa1, 0x4a, 0xa5, 0x38, 0x4e, 0x82, 0x8c, 0x92, 0x34, 0x1d,
-	0x53, 0x89, 0x5b, 0xd9, 0xec, 0x8e, 0xdd, 0x6d, 0xec, 0x1d, 0x6b, 0x67, 0x1c, 0x14, 0x0e, 0x1c,
-	0xe0, 0xc8, 0x81, 0x03, 0x17, 0xee, 0x48, 0x88, 0x23, 0x77, 0xfe, 0x01, 0xc4, 0x85, 0x33, 0x47,
-	0x4e, 0xfc, 0x05, 0x1c, 0x91, 0xd0, 0xee, 0xce, 0xda, 0xb3, 0xbb, 0x33, 0x65, 0x13, 0x2b, 0x84,
-	0x4b, 0x34, 0x6f, 0x66, 0xde, 0x9b, 0x37, 0x6f, 0xbe, 0xf7, 0xed, 0xe7, 0xc0, 0x12, 0xe1, 0xf6,
-	0xfa, 0xc0, 0xa3, 0x9c, 0xb2, 0x75, 0xc2, 0xed, 0x7a, 0x30, 0xac, 0xde, 0x83, 0xe2, 0x8e, 0x69,
-	0xef, 0xd2, 0xbe, 0xe9, 0xb8, 0xe8, 0x2e, 0x80, 0x33, 0x78, 0x6e, 0xda, 0xb6, 0x47, 0x18, 0x2b,
-	0xe7, 0x2a, 0xb9, 0x5a, 0x11, 0x17, 0x9d, 0x41, 0x23, 0x9c, 0xa8, 0xfe, 0x3a, 0x03, 0x8b, 0x0d,
-	0x97, 0xf6, 0xcd, 0x1e, 0x1d, 0xb2, 0x56, 0xe3, 0xf0, 0x43, 0xcf, 0x74, 0x39, 0x32, 0x60, 0xce,
-	0x71, 0x19, 0xf1, 0x78, 0xcb, 0x16, 0x2e, 0x23, 0x1b, 0x95, 0xa1, 0xd0, 0xa3, 0xdd, 0x23, 0xb3,
-	0x4f, 0xca, 0x53, 0xc1, 0x52, 0x64, 0xa2, 0x6d, 0x28, 0xbd, 0x64, 0xd4, 0x3d, 0x36, 0xcf, 0x7b,
-	0xd4, 0xb4, 0xcb, 0xf9, 0x4a, 0xae, 0x56, 0xda, 0x58, 0xae, 0xa7, 0xc2, 0xd7, 0x3f, 0x6a, 0x3f,
-	0x39, 0x12, 0xbb, 0xb0, 0xec, 0x62, 0xd4, 0x01, 0xb5, 0x89, 0xcb, 0x1c, 0xee, 0x9c, 0x11, 0x4c,
-	0x7b, 0x24, 0xcc, 0xa6, 0x0c, 0x85, 0x3e, 0xe9, 0x9f, 0x10, 0xcf, 0xcf, 0x3f, 0xef, 0x9f, 0x28,
-	0x4c, 0xc3, 0x02, 0x38, 0xf6, 0xe8, 0x80, 0x78, 0xdc, 0x21, 0x0c, 0x3d, 0x03, 0xc4, 0x52, 0xde,
-	0x41, 0xfe, 0xa5, 0x8d, 0xb7, 0x15, 0x69, 0xa4, 0x8f, 0xc2, 0x8a, 0x00, 0xc6, 0x1a, 0x94, 0xda,
-	0x74, 0xe8, 0x59, 0xe4, 0x80, 0x76, 0x5b, 0x36, 0xba, 0x03, 0xc5, 0x81, 0x47, 0x5f, 0x12, 0x6b,
-	0x5c, 0x9c, 0xf1, 0x84, 0x71, 0x00, 0x73, 0x7b, 0x67, 0x8e, 0x4d, 0x5c, 0x2b, 0xa8, 0x07, 0x1b,
-	0x3b, 0x8a, 0x44, 0x54, 0xf5, 0x90, 0xc2, 0x63, 0xd9, 0xc5, 0x78, 0x0a, 0x8b, 0xbb, 0x84, 0x13,
-	0x8b, 0x3b, 0xd4, 0x6d, 0x9a, 0x9c, 0x74, 0xa9, 0x77, 0xee, 0x3f, 0x8e, 0x37, 0xec, 0x91, 0xe0,
-	0x05, 0xc4, 0xe3, 0x44, 0x36, 0xaa, 0x40, 0x89, 0x0d, 0x4f, 0x70, 0xb4, 0x1c, 0x3e, 0x90, 0x3c,
-	0x65, 0xfc, 0x9e, 0x83, 0x92, 0x54, 0x7f, 0xf4, 0x18, 0x60, 0x30, 0x2a, 0xa1, 0xc8, 0xf1, 0xae,
-	0x22, 0xc7, 0x71, 0x9d, 0xb1, 0xe4, 0x80, 0x30, 0x2c, 0xda, 0xc9, 0x0c, 0x83, 0x63, 0x4b, 0x1b,
-	0x6f, 0x29, 0xa2, 0xa4, 0x6e, 0x83, 0xd3, 0xee, 0xe8, 0x21, 0xcc, 0x11, 0x51, 0xc3, 0x72, 0xbe,
-	0x92, 0xaf, 0x95, 0x36, 0x6e, 0x2b, 0x42, 0x45, 0x65, 0xc6, 0xa3, 0xcd, 0xd5, 0x9f, 0xa7, 0x61,
-	0x66, 0xc7, 0xb4, 0x5b, 0xc7, 0x97, 0x04, 0xf0, 0x03, 0x15, 0x80, 0x51, 0x3d, 0x08, 0xa9, 0x07,
-	0xed, 0x2a, 0x14, 0x8e, 0x08, 0xff, 0x8c, 0x7a, 0xa7, 0x7e, 0x68, 0x01, 0x05, 0x71, 0x6a, 0x64,
-	0x1a, 0x9f, 0xc6, 0x90, 0x5a, 0x83, 0x82, 0x1b, 0xba, 0x88, 0x8a, 0xbf, 0x26, 0x0e, 0x11, 0x81,
-	0x70, 0xb4, 0x8c, 0x6a, 0xf0, 0xba, 0xe3, 0x32, 0x6e, 0xba, 0x16, 0xd9, 0x25, 0xdc, 0x74, 0x7a,
-	0x4c, 0x24, 0x9d, 0x9c, 0x36, 0xb6, 0x60, 0xa1, 0xd1, 0xe9, 0x10, 0x8b, 0x13, 0x1b, 0x93, 0x10,
-	0x44, 0xbe, 0x77, 0xd7, 0x1a, 0x44, 0xa6, 0x84, 0x98, 0xe4, 0xb4, 0xb1, 0x7e, 0x41, 0xa4, 0x19,
-	0xbf, 0x25, 0x70, 0xb4, 0x07, 0x8b, 0x66, 0xe2, 0xf8, 0xb0, 0x5d, 0x4b, 0x1b, 0xb7, 0xc4, 0xe5,
-	0x92, 0xe9, 0xe1, 0xb4, 0x07, 0x7a, 0x2f, 0x06, 0xc7, 0x10, 0x48, 0x8b, 0xc2, 0x5f, 0x03, 0xc1,
-	0x7d, 0x15, 0x04, 0xc3, 0xb7, 0x2b, 0x0b, 0xcf, 0x2c, 0xb0, 0xab, 0x7e, 0x39, 0x0b, 0xf3, 0x6d,
-	0xf6, 0x62, 0xc7, 0x1b, 0x72, 0xb2, 0x4f, 0xfd, 0xf2, 0x5d, 0x0e, 0x45, 0x5b, 0x2a, 0x14, 0x19,
-	0xf5, 0x58, 0x68, 0x3d, 0x9a, 0xbe, 0x80, 0x1b, 0x07, 0xb4, 0xeb, 0xb8, 0x0d, 0xce, 0x49, 0x7f,
-	0xc0, 0xd1, 0x32, 0x80, 0x39, 0xe4, 0x2f, 0x30, 0x61, 0xc3, 0x5e, 0x84, 0x2a, 0x69, 0xc6, 0xcf,
-	0x31, 0xac, 0x5d, 0x6b, 0x20, 0x12, 0x19, 0xd9, 0xfe, 0xda, 0x90, 0x11, 0x2f, 0x48, 0x32, 0x1f,
-	0xae, 0x45, 0x36, 0xba, 0x09, 0xb3, 0x67, 0xfd, 0x60, 0x65, 0x3a, 0x58, 0x11, 0x96, 0xf1, 0x7d,
-	0x2e, 0x86, 0xd4, 0x15, 0x28, 0x45, 0x40, 0x7b, 0xee, 0x44, 0x55, 0x80, 0x68, 0xaa, 0x65, 0xfb,
-	0xdf, 0x17, 0x81, 0x71, 0x7f, 0x7d, 0x2a, 0xc1, 0x87, 0x08, 0xc1, 0xf4, 0xe7, 0xd4, 0x8d, 0x8e,
-	0x0f, 0xc6, 0xa8, 0x01, 0xf3, 0xf2, 0x15, 0x59, 0x79, 0x5a, 0x34, 0x79, 0xbc, 0x44, 0xf2, 0x1e,
-	0x1c, 0xf7, 0xf8, 0xaf, 0xc1, 0xfe, 0x67, 0x02, 0xec, 0x87, 0x7a, 0xb0, 0xaf, 0x24, 0x6e, 0x91,
-	0x05, 0xf4, 0x1f, 0x28, 0x40, 0xff, 0x46, 0x22, 0x8e, 0x06, 0xfc, 0x47, 0x7a, 0xf0, 0x57, 0x12,
-	0x11, 0x32, 0x35, 0xc1, 0x1f, 0xb3, 0x30, 0x17, 0xf4, 0x4c, 0xbb, 0xd9, 0x44, 0xef, 0xc3, 0x4d,
-	0x97, 0x72, 0xa7, 0xe3, 0x58, 0x66, 0xb0, 0x89, 0xba, 0x1d, 0xa7, 0x2b, 0x15, 0x48, 0xb3, 0x8a,
-	0xd6, 0xa0, 0xd0, 0x71, 0x5c, 0xdb, 0x71, 0xbb, 0xf1, 0x0e, 0x6e, 0x37, 0x9b, 0xf5, 0xfd, 0x70,
-	0x01, 0x47, 0x3b, 0x8c, 0xaf, 0x72, 0x30, 0xdf, 0x26, 0xd6, 0xd0, 0x73, 0xf8, 0xf9, 0xa1, 0xe9,
-	0x9d, 0x32, 0xb4, 0x09, 0x33, 0x7d, 0x7f, 0x20, 0x2a, 0x5a, 0x1d, 0x3b, 0xc7, 0xf6, 0xd5, 0x83,
-	0xbf, 0x7b, 0x2e, 0xf7, 0xce, 0x71, 0xe8, 0x60, 0x6c, 0x02, 0x8c, 0x27, 0xd1, 0x02, 0xe4, 0x4f,
-	0xc9, 0xb9, 0xc8, 0xd5, 0x1f, 0xa2, 0x25, 0x98, 0x39, 0x33, 0x7b, 0xc3, 0xa8, 0x65, 0x43, 0xe3,
-	0xd1, 0xd4, 0x66, 0x2e, 0x1b, 0x89, 0xc7, 0xe5, 0xc6, 0x5a, 0x92, 0xc4, 0xa5, 0x5b, 0x4e, 0xc0,
-	0xe3, 0x17, 0x06, 0xe7, 0xb7, 0x39, 0x58, 0x08, 0x15, 0x84, 0x94, 0xdc, 0x03, 0xc5, 0x67, 0x7d,
-	0x69, 0x9c, 0x9f, 0x06, 0x4d, 0x2d, 0xfd, 0xd7, 0xfc, 0xf6, 0xd8, 0x39, 0x0b, 0x90, 0x8c, 0xef,
-	0xa6, 0xa0, 0x20, 0xde, 0x1a, 0xed, 0xc3, 0x02, 0x4b, 0x24, 0x28, 0x52, 0x32, 0xa4, 0xb7, 0x4d,
-	0xec, 0xc0, 0x29, 0x1f, 0xbf, 0x0a, 0x96, 0x9c, 0x55, 0x11, 0x8f, 0x6c, 0x54, 0x85, 0x1b, 0x9e,
-	0xdc, 0xfa, 0x21, 0xe1, 0xc4, 0xe6, 0xfc, 0xe7, 0x67, 0xdc, 0xe4, 0x11, 0xe5, 0x85, 0x06, 0x7a,
-	0x0c, 0xf3, 0x4c, 0xc6, 0x55, 0x79, 0x26, 0x48, 0xed, 0x96, 0x06, 0x76, 0x38, 0xbe, 0xdb, 0xd7,
-	0x83, 0xe4, 0x8c, 0xb8, 0xfc, 0x63, 0xa7, 0x4f, 0xca, 0xb3, 0x21, 0xff, 0x8d, 0x26, 0x7c, 0xfe,
-	0x73, 0xfd, 0x74, 0x0a, 0x21, 0xff, 0xf9, 0xe3, 0xea, 0xdf, 0x73, 0xb0, 0x94, 0xd2, 0x33, 0x93,
-	0xf4, 0xdb, 0xc3, 0x64, 0xbf, 0x29, 0x04, 0x9c, 0xb2, 0xf7, 0xbe, 0x49, 0xf5, 0xde, 0x6e, 0xbc,
-	0xf7, 0xea, 0xea, 0x40, 0x57, 0xd7, 0x87, 0x17, 0x12, 0xdb, 0x4f, 0x24, 0xb1, 0xdd, 0x54, 0x89,
-	0xed, 0x37, 0x35, 0xe9, 0xeb, 0xf4, 0xf6, 0x45, 0x7f, 0x7f, 0x74, 0x62, 0x84, 0xf0, 0xc9, 0x2b,
-	0x7e, 0x7f, 0xd4, 0x74, 0x85, 0xcc, 0xf4, 0x13, 0xe4, 0x32, 0x1f, 0xac, 0x34, 0x27, 0x6c, 0x2b,
-	0x38, 0xa1, 0xa2, 0xce, 0x4b, 0xc3, 0x0f, 0xcf, 0xf4, 0xfc, 0xf0, 0xae, 0x3a, 0x50, 0x26, 0xc1,
-	0xff, 0x28, 0x25, 0xf8, 0x97, 0xd5, 0xd1, 0xd2, 0x9a, 0xdf, 0xf8, 0x49, 0xe2, 0x19, 0xac, 0xe5,
-	0x99, 0x77, 0x5e, 0x05, 0x84, 0x6b, 0xe0, 0x9c, 0x96, 0x9a, 0x73, 0x56, 0x33, 0xb4, 0xdb, 0xe4,
-	0xfc, 0xf3, 0x4b, 0x11, 0x16, 0x62, 0xd2, 0x60, 0x12, 0xee, 0xb9, 0x9f, 0xe4, 0x9e, 0x84, 0x70,
-	0x51, 0xf2, 0xce, 0xd7, 0x29, 0xde, 0xd9, 0x8e, 0xf3, 0xce, 0xbd, 0x74, 0x90, 0xab, 0xe3, 0x9c,
-	0xeb, 0x96, 0xdc, 0x3f, 0x5c, 0xbd, 0xe4, 0xde, 0x55, 0x4b, 0xee, 0xe5, 0x74, 0x99, 0xff, 0x47,
-	0xaa, 0xfb, 0x2f, 0x15, 0x89, 0x1d, 0xeb, 0xa5, 0x77, 0x35, 0x7d, 0x9b, 0x2c, 0xea, 0x7b, 0x4b,
-	0xa1, 0xbe, 0xef, 0xa4, 0x43, 0x69, 0x28, 0xf1, 0xa9, 0x5e, 0x80, 0xaf, 0xa6, 0x83, 0x64, 0x92,
-	0x4e, 0x3f, 0x4a, 0x94, 0x76, 0xa4, 0xa5, 0x34, 0xc5, 0x6d, 0xaf, 0x8d, 0xce, 0xf6, 0xd4, 0x74,
-	0xb6, 0xf2, 0x2f, 0x5d, 0x3c, 0x31, 0x95, 0x9d, 0xcc, 0x06, 0xff, 0xf1, 0xbc, 0xff, 0x4f, 0x00,
-	0x00, 0x00, 0xff, 0xff, 0x46, 0xbb, 0xbf, 0x77, 0x09, 0x15, 0x00, 0x00,
+	// 1926 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x5a, 0x4d, 0x6c, 0x1b, 0xc7,
+	0x15, 0x06, 0xf5, 0x47, 0xf1, 0x51, 0xb2, 0xa5, 0x85, 0x2a, 0xd3, 0x2b, 0x59, 0xa2, 0x28, 0x57,
+	0x95, 0x7f, 0x40, 0xc1, 0xb4, 0x5d, 0xab, 0xb6, 0xdc, 0x5a, 0xa2, 0x7e, 0x40, 0x41, 0x92, 0xe5,
+	0x95, 0x0d, 0xf8, 0xd6, 0xae, 0xc8, 0x11, 0xbd, 0x36, 0xb9, 0x4b, 0xec, 0x2e, 0xe5, 0xb2, 0x28,
+	0x5a, 0xc0, 0x45, 0x51, 0xa0, 0x06, 0xea, 0xc2, 0x05, 0x8a, 0x16, 0xb9, 0x24, 0x08, 0x60, 0x04,
+	0x39, 0xe5, 0x14, 0x04, 0xc8, 0x2d, 0xa7, 0x20, 0x97, 0x5c, 0x93, 0x63, 0x7c, 0xc9, 0x35, 0x40,
+	0xe0, 0x7b, 0xb0, 0x7f, 0xe4, 0xfc, 0xca, 0x2b, 0xae, 0x14, 0xca, 0x17, 0x83, 0x33, 0x3b, 0xf3,
+	0xf6, 0xcd, 0xdb, 0xef, 0xfb, 0xde, 0x7b, 0x63, 0xc1, 0x08, 0xb2, 0x4b, 0x73, 0x35, 0xd3, 0xb0,
+	0x0d, 0x6b, 0x0e, 0xd9, 0xa5, 0xac, 0xfb, 0x33, 0xf3, 0xba, 0x1b, 0x12, 0x4b, 0x6a, 0x69, 0xd9,
+	0xa8, 0xaa, 0x9a, 0x2e, 0xc9, 0xd0, 0xaf, 0xe9, 0x16, 0x32, 0xed, 0x42, 0x29, 0x15, 0x4b, 0xc7,
+	0x66, 0x13, 0x4a, 0x73, 0x2c, 0xa5, 0x20, 0x5e, 0x31, 0xca, 0x5b, 0x6a, 0x15, 0xa5, 0xba, 0xdc,
+	0x47, 0xc1, 0x50, 0x9a, 0x87, 0xe4, 0x63, 0xcb, 0xd0, 0xb7, 0xd5, 0x46, 0xc5, 0x50, 0x4b, 0xa9,
+	0xee, 0x74, 0x6c, 0x36, 0x99, 0x1b, 0xcd, 0x36, 0xcd, 0x66, 0xd7, 0x77, 0xee, 0x6e, 0xf9, 0x4f,
+	0x15, 0x7c, 0xa9, 0x3c, 0x0d, 0xf1, 0x2d, 0x64, 0x3f, 0x35, 0xcc, 0x27, 0x8e, 0xf9, 0x9a, 0x69,
+	0x3c, 0x46, 0x45, 0xdb, 0x7f, 0x73, 0x30, 0x94, 0xff, 0x0c, 0xb0, 0x6d, 0x1a, 0x35, 0x64, 0xda,
+	0x1a, 0xb2, 0xa4, 0xcb, 0x10, 0xd7, 0xbd, 0x2d, 0xee, 0xba, 0x64, 0x4e, 0xc2, 0x5e, 0xe4, 0x1b,
+	0x53, 0x82, 0x25, 0xd2, 0x2c, 0x9c, 0xd6, 0x74, 0xcb, 0x56, 0xf5, 0x22, 0x5a, 0x46, 0xb6, 0xaa,
+	0x55, 0x2c, 0xdf, 0x79, 0x7a, 0x5a, 0x1a, 0x85, 0xbe, 0x92, 0x6b, 0x24, 0xd5, 0x9d, 0xee, 0x9e,
+	0x4d, 0x28, 0xfe, 0x48, 0x9e, 0x83, 0xe1, 0x65, 0x64, 0xa3, 0xa2, 0xad, 0x19, 0x7a, 0x5e, 0xb5,
+	0x51, 0xd9, 0x30, 0x1b, 0x4e, 0x9c, 0xcc, 0x7a, 0x05, 0xb9, 0xc1, 0xf0, 0xe3, 0x14, 0x8c, 0xe5,
+	0x7f, 0xc7, 0x20, 0x89, 0x1d, 0x58, 0xba, 0x0e, 0x50, 0x6b, 0xba, 0xef, 0xfb, 0xfc, 0x0b, 0xcc,
+	0xe7, 0xd6, 0xd9, 0x14, 0x6c, 0xa1, 0xb4, 0x0e, 0xc3, 0x25, 0xfa, 0xbd, 0xae, 0xef, 0xc9, 0xdc,
+	0x38, 0xb6, 0x9b, 0xf1, 0x4d, 0x61, 0xb7, 0x65, 0x7e, 0xec, 0x85, 0xe1, 0x45, 0xdd, 0xa8, 0xaa,
+	0x15, 0xa3, 0x6e, 0x15, 0x16, 0x37, 0xd7, 0x4c, 0x55, 0xb7, 0xdb, 0xfc, 0xd8, 0x77, 0x78, 0x1f,
+	0x7b, 0x22, 0xcb, 0x98, 0x17, 0x7f, 0xf4, 0x2c, 0x48, 0x3b, 0x48, 0xb7, 0x34, 0x5b, 0xdb, 0x47,
+	0x8a, 0x51, 0x41, 0x9e, 0x37, 0x29, 0x88, 0x57, 0x51, 0x75, 0x17, 0x99, 0x4e, 0x8c, 0x9c, 0x0f,
+	0x10, 0x0c, 0xe5, 0xe7, 0x31, 0x02, 0x00, 0x0f, 0x40, 0xb2, 0x98, 0xed, 0x7e, 0x5c, 0x7f, 0xc9,
+	0xf1, 0x83, 0x7d, 0x97, 0xc2, 0x31, 0x20, 0xcd, 0xc0, 0xa9, 0x9a, 0xa9, 0xe9, 0x45, 0xad, 0xa6,
+	0x56, 0x56, 0xaa, 0xaa, 0x56, 0xf1, 0x0f, 0x4e, 0xcd, 0xca, 0x97, 0x20, 0xb9, 0x63, 0xd4, 0xcd,
+	0x22, 0xda, 0x30, 0xca, 0x85, 0x92, 0x34, 0x0e, 0x09, 0x1f, 0xa7, 0xcd, 0x28, 0xb6, 0x26, 0xe4,
+	0x0d, 0xe8, 0x5f, 0xd9, 0xd7, 0x4a, 0x48, 0x2f, 0xba, 0x81, 0xb3, 0x5a, 0x1b, 0x53, 0x31, 0x61,
+	0xe0, 0x30, 0xf3, 0x0a, 0xbe, 0x45, 0xbe, 0x77, 0x48, 0x28, 0x4a, 0x69, 0x48, 0x5a, 0xf5, 0x5d,
+	0x25, 0x78, 0xec, 0x1d, 0x08, 0x9f, 0x92, 0xbf, 0xa5, 0xc0, 0x7a, 0x9b, 0x03, 0xd6, 0x73, 0x1c,
+	0x1f, 0x05, 0xa0, 0x55, 0xc4, 0xa0, 0x3d, 0xcf, 0xb1, 0x12, 0x06, 0xbc, 0xd2, 0x0d, 0xe8, 0x47,
+	0x7e, 0x0c, 0x5d, 0x6a, 0x26, 0x73, 0x63, 0x1c, 0x53, 0x41, 0x98, 0x95, 0xe6, 0xe2, 0xcc, 0xe7,
+	0x3d, 0xd0, 0xbb, 0xa4, 0x96, 0x0a, 0xdb, 0x6d, 0x22, 0xfd, 0x1a, 0x0f, 0xe9, 0xae, 0xda, 0x14,
+	0xb6, 0x23, 0x4a, 0xda, 0x1f, 0x08, 0x44, 0xcf, 0xd2, 0x92, 0x76, 0xca, 0x7f, 0x49, 0xfb, 0x72,
+	0x26, 0x2f, 0xc0, 0xd0, 0xe2, 0xde, 0x1e, 0x2a, 0xda, 0xa8, 0xa4, 0x20, 0x0f, 0x44, 0xce, 0xee,
+	0x72, 0xb1, 0x16, 0x0c, 0x31, 0xc4, 0xd0, 0xd3, 0x87, 0x17, 0xbd, 0xaf, 0x29, 0x1c, 0xad, 0xc0,
+	0xb0, 0x4a, 0xbd, 0xde, 0xe3, 0x75, 0x32, 0x77, 0xc6, 0x3f, 0x1c, 0xed, 0x9e, 0xc2, 0xee, 0x90,
+	0xae, 0x10, 0x70, 0xf4, 0x80, 0x34, 0xec, 0xef, 0x17, 0x40, 0x70, 0x95, 0x07, 0x41, 0xef, 0xdb,
+	0xa5, 0xfc, 0x9d, 0xa1, 0x34, 0xf3, 0x59, 0x1f, 0x0c, 0xee, 0x58, 0x8f, 0x96, 0xcc, 0xba, 0x8d,
+	0x56, 0x0d, 0x27, 0x7c, 0xed, 0xa1, 0x68, 0x81, 0x87, 0x22, 0x39, 0x4b, 0x98, 0x16, 0xa3, 0xe9,
+	0x2f, 0x30, 0xb0, 0x61, 0x94, 0x35, 0x7d, 0xd1, 0xb6, 0x51, 0xb5, 0x66, 0x4b, 0x13, 0x00, 0x6a,
+	0xdd, 0x7e, 0xa4, 0x20, 0xab, 0x5e, 0x09, 0x50, 0x85, 0xcd, 0x38, 0x3e, 0x7a, 0xb1, 0x2b, 0xd4,
+	0x7c, 0x47, 0x9a, 0x63, 0xe7, 0x59, 0xdd, 0x42, 0xa6, 0xeb, 0x64, 0xb7, 0xf7, 0x2c, 0x18, 0x3b,
+	0xd9, 0x6f, 0xbf, 0xea, 0x3e, 0xe9, 0x71, 0x9f, 0xf8, 0x23, 0xf9, 0x43, 0x52, 0x7b, 0x27, 0x21,
+	0x19, 0x00, 0xed, 0xf7, 0x5a, 0x10, 0x05, 0x08, 0xa6, 0x0a, 0x25, 0xe9, 0x1c, 0x80, 0x8f, 0x71,
+	0xe7, 0x79, 0x17, 0xa5, 0x87, 0x92, 0x04, 0x3d, 0x7f, 0x32, 0xf4, 0xe0, 0xf5, 0xee, 0x6f, 0x69,
+	0x11, 0x06, 0xf1, 0x23, 0x5a, 0xa9, 0x1e, 0x9f, 0xe4, 0x64, 0x88, 0xf0, 0x35, 0x0a, 0xb9, 0xe3,
+	0xe7, 0x06, 0xfb, 0xf7, 0x14, 0xd8, 0x37, 0xc5, 0x60, 0x9f, 0xa4, 0x4e, 0x11, 0x06, 0xf4, 0xbf,
+	0xe1, 0x80, 0xfe, 0x2c, 0x65, 0x47, 0x00, 0xfe, 0x2d, 0x31, 0xf8, 0xd3, 0x94, 0x85, 0x50, 0x24,
+	0xf8, 0xae, 0x0f, 0xfa, 0x5d, 0xce, 0xec, 0xe4, 0xf3, 0xd2, 0xaf, 0x61, 0x54, 0x37, 0x6c, 0x6d,
+	0x4f, 0x2b, 0xaa, 0xee, 0x22, 0x43, 0xdf, 0xd3, 0xca, 0x58, 0x80, 0x04, 0x4f, 0xa5, 0x4b, 0x10,
+	0xdf, 0xd3, 0xf4, 0x92, 0xa6, 0x97, 0x49, 0x06, 0xef, 0xe4, 0xf3, 0xd9, 0x55, 0xef, 0x81, 0x12,
+	0xac, 0x90, 0xff, 0x16, 0x83, 0xc1, 0x1d, 0x54, 0xac, 0x9b, 0x9a, 0xdd, 0xd8, 0x54, 0xcd, 0x27,
+	0x96, 0x34, 0x0f, 0xbd, 0x55, 0xe7, 0x87, 0x1f, 0xd1, 0x4c, 0x6b, 0x33, 0xb1, 0x2e, 0xeb, 0xfe,
+	0xbb, 0xa2, 0xdb, 0x66, 0x43, 0xf1, 0x36, 0xc8, 0xf3, 0x00, 0xad, 0x49, 0x69, 0x08, 0xba, 0x9f,
+	0xa0, 0x86, 0xef, 0xab, 0xf3, 0x53, 0x1a, 0x81, 0xde, 0x7d, 0xb5, 0x52, 0x0f, 0x28, 0xeb, 0x0d,
+	0x6e, 0x76, 0xcd, 0xc7, 0xc2, 0x89, 0x78, 0x91, 0xa0, 0xc6, 0x25, 0x5a, 0xc4, 0xb1, 0x53, 0x46,
+	0xd0, 0xf1, 0x43, 0x83, 0xf3, 0x3f, 0x31, 0x18, 0xf2, 0x2a, 0x08, 0xcc, 0xb9, 0x6b, 0x9c, 0xb4,
+	0x3e, 0xd2, 0xf2, 0x4f, 0x80, 0xa6, 0x82, 0x38, 0x9b, 0x8f, 0xb5, 0x36, 0x87, 0x01, 0x92, 0xfc,
+	0xbf, 0x2e, 0x88, 0xfb, 0xdf, 0x5a, 0x5a, 0x85, 0x21, 0x8b, 0x72, 0xd0, 0x77, 0x49, 0xc6, 0xbe,
+	0x2d, 0xb5, 0x42, 0x61, 0xf6, 0x38, 0x51, 0x28, 0xe2, 0x5e, 0x25, 0x94, 0xe6, 0x58, 0xca, 0xc0,
+	0x80, 0x89, 0x53, 0xdf, 0x13, 0x1c, 0x62, 0xce, 0xf9, 0xfc, 0x96, 0xad, 0xda, 0x81, 0xe4, 0x79,
+	0x03, 0xe9, 0x36, 0x0c, 0x5a, 0x38, 0xae, 0x52, 0xbd, 0xe9, 0x58, 0x2b, 0x6b, 0x31, 0xb0, 0x53,
+	0xc8, 0xd5, 0x4e, 0x3d, 0x88, 0xf6, 0x91, 0x6e, 0xdf, 0xd7, 0xaa, 0x28, 0xd5, 0xe7, 0xe9, 0x5f,
+	0x73, 0xc2, 0xd1, 0x3f, 0xdd, 0x71, 0x27, 0xee, 0xe9, 0x9f, 0xf3, 0x3b, 0xf3, 0x2a, 0x01, 0x23,
+	0x4c, 0x3d, 0x13, 0x85, 0x6f, 0x37, 0x68, 0xbe, 0x71, 0x0a, 0x38, 0x2e, 0xf7, 0x5e, 0x30, 0xdc,
+	0x5b, 0x26, 0xb9, 0x97, 0xe5, 0x1b, 0x3a, 0x3e, 0x1e, 0x1e, 0xaa, 0xd8, 0xbe, 0x8b, 0x15, 0xdb,
+	0x79, 0x5e, 0xb1, 0x3d, 0x25, 0x70, 0x5f, 0x54, 0x6f, 0x1f, 0xb6, 0x51, 0xf9, 0x17, 0x99, 0x2c,
+	0x1f, 0x1e, 0xd0, 0xa8, 0xcc, 0x8a, 0x22, 0x79, 0xa4, 0xbd, 0x4a, 0x3b, 0x99, 0x8d, 0x15, 0x8f,
+	0x3b, 0x1c, 0xf1, 0x48, 0xf3, 0xfd, 0x17, 0x08, 0xc9, 0x03, 0xb1, 0x90, 0xfc, 0x8a, 0x6f, 0x28,
+	0x54, 0x67, 0x70, 0x93, 0xe9, 0x0c, 0x26, 0xf8, 0xd6, 0xd8, 0xe6, 0x40, 0xfe, 0x04, 0x13, 0x24,
+	0x45, 0x28, 0x48, 0x33, 0x07, 0x21, 0xa6, 0x03, 0xe2, 0x54, 0xe0, 0x8b, 0xd3, 0x74, 0x08, 0x5e,
+	0x46, 0x17, 0xaa, 0x2f, 0x13, 0x30, 0x44, 0xd4, 0x10, 0x51, 0x44, 0xea, 0x2a, 0x2d, 0x52, 0x54,
+	0x85, 0xc3, 0x15, 0xa8, 0xe7, 0x8c, 0x40, 0xdd, 0x21, 0x05, 0xea, 0x22, 0x6b, 0xe4, 0xf8, 0xc4,
+	0xa9, 0xd3, 0xb5, 0xf9, 0xab, 0xe3, 0xaf, 0xcd, 0x97, 0xf9, 0xb5, 0xf9, 0x04, 0x1b, 0xe6, 0x13,
+	0x54, 0x9e, 0xbf, 0xe1, 0x89, 0xd8, 0xb6, 0xb8, 0x46, 0xcf, 0xb0, 0xa7, 0x09, 0x53, 0xa6, 0x2f,
+	0x70, 0xca, 0xf4, 0x71, 0xd6, 0x94, 0x40, 0x12, 0xef, 0x89, 0x2b, 0xf5, 0x69, 0xd6, 0x48, 0xa8,
+	0x1a, 0xeb, 0x23, 0x4c, 0xd2, 0xb6, 0x84, 0x92, 0xc6, 0x39, 0x6d, 0xc7, 0xe4, 0x6c, 0x85, 0x2f,
+	0x67, 0x93, 0x6f, 0x61, 0x71, 0x74, 0x29, 0xfb, 0x7f, 0x1c, 0x06, 0x9a, 0x77, 0xa8, 0x51, 0x64,
+	0x6c, 0x8e, 0x96, 0x31, 0xec, 0x66, 0x97, 0x2b, 0x61, 0xff, 0x60, 0x24, 0x6c, 0x81, 0x94, 0xb0,
+	0x19, 0xd2, 0x40, 0x87, 0x7b, 0x9c, 0xbf, 0x12, 0x12, 0x33, 0x47, 0xf7, 0x38, 0xd4, 0x69, 0x4f,
+	0xc2, 0xf5, 0xfb, 0x7b, 0x3c, 0xf6, 0xcf, 0x73, 0x4a, 0x98, 0x14, 0xe9, 0xbb, 0x80, 0xa7, 0x9b,
+	0xe2, 0xd2, 0x65, 0x92, 0x34, 0x10, 0x8a, 0xa3, 0xef, 0x63, 0x1c, 0x5d, 0x17, 0x72, 0x74, 0x82,
+	0xc2, 0x40, 0xa7, 0xf8, 0xb9, 0xc8, 0xe7, 0xe7, 0xd8, 0x01, 0x10, 0x8d, 0xce, 0xcd, 0x37, 0x3d,
+	0x30, 0xb0, 0x85, 0x9e, 0xae, 0x21, 0xa3, 0x6c, 0xaa, 0xb5, 0x47, 0x8d, 0x36, 0xef, 0xdd, 0x6e,
+	0xf1, 0xee, 0xdd, 0xce, 0x66, 0x71, 0xcb, 0x07, 0x5d, 0xbb, 0xe1, 0xb0, 0x67, 0xcb, 0xed, 0x18,
+	0xaf, 0xdc, 0xf6, 0x3e, 0x42, 0xa5, 0x82, 0xcc, 0x56, 0x82, 0x0f, 0xc6, 0xd2, 0x65, 0x18, 0x56,
+	0x83, 0x1a, 0x6d, 0xc3, 0xf0, 0x74, 0xc4, 0xff, 0x12, 0xec, 0x83, 0xc3, 0xf5, 0x3d, 0x6b, 0x58,
+	0xdf, 0x73, 0x8b, 0xd7, 0xf7, 0x50, 0xa7, 0x16, 0xf6, 0x3b, 0x87, 0xe6, 0xda, 0x57, 0xd4, 0x45,
+	0x18, 0x9f, 0x66, 0xc4, 0xcb, 0xdb, 0xa1, 0x19, 0x61, 0x20, 0x54, 0x67, 0x90, 0x63, 0x3a, 0x83,
+	0x51, 0xd2, 0x0a, 0xe7, 0xbf, 0x0b, 0x3e, 0xeb, 0x87, 0xd3, 0xf8, 0x9a, 0x28, 0x69, 0x21, 0x47,
+	0xa7, 0x05, 0x32, 0x0a, 0xdc, 0xcc, 0xf0, 0x4f, 0x26, 0x33, 0xfc, 0x8e, 0xcc, 0x0c, 0x17, 0x18,
+	0x1b, 0xc7, 0x59, 0xdb, 0xbe, 0x43, 0x04, 0x58, 0xc7, 0x08, 0xf0, 0x5b, 0x1e, 0x01, 0xc6, 0xd9,
+	0xc8, 0x1d, 0x19, 0x07, 0xbe, 0xe1, 0xe5, 0x9b, 0x5b, 0x1c, 0x22, 0x8c, 0x31, 0x4e, 0x08, 0xb8,
+	0xb0, 0x2d, 0xe6, 0x42, 0x86, 0xb1, 0x11, 0x8a, 0x0e, 0xd7, 0x19, 0x3a, 0x9c, 0x65, 0x0c, 0x71,
+	0x7a, 0xe4, 0x57, 0x58, 0xb2, 0xda, 0x14, 0x26, 0xab, 0x29, 0x41, 0x70, 0x3b, 0x90, 0xaf, 0x96,
+	0xf9, 0xf9, 0x6a, 0xe2, 0x60, 0xe2, 0x44, 0x4f, 0x59, 0x1f, 0xf7, 0xc2, 0xc8, 0x92, 0x56, 0xbe,
+	0x57, 0x47, 0x66, 0x63, 0xe5, 0x8f, 0x7b, 0x5a, 0xc5, 0x36, 0x5d, 0x18, 0xb7, 0x99, 0xba, 0xf2,
+	0xbc, 0xd4, 0x35, 0x95, 0xe5, 0xbd, 0x41, 0x9c, 0xc2, 0x9e, 0xc5, 0xda, 0xa2, 0x70, 0x3a, 0xe0,
+	0xcf, 0x7d, 0x75, 0xb7, 0xd2, 0xfa, 0x2f, 0xe3, 0xd6, 0x94, 0x74, 0x11, 0x86, 0x4a, 0xc8, 0xb2,
+	0x35, 0xdd, 0x75, 0xc0, 0x5b, 0xe6, 0x7d, 0x22, 0x66, 0xfe, 0xc8, 0xef, 0xef, 0xb8, 0x21, 0x38,
+	0x3a, 0x2e, 0xbf, 0xa6, 0xf2, 0x19, 0xff, 0xe6, 0x8b, 0xeb, 0x44, 0x3b, 0x37, 0x5f, 0x5c, 0x43,
+	0x6d, 0xdf, 0x7c, 0x71, 0xad, 0x71, 0xf2, 0xdc, 0xdf, 0x13, 0x70, 0x86, 0xb7, 0x36, 0x4a, 0xbe,
+	0xbb, 0x49, 0xe7, 0x3b, 0x7e, 0x94, 0xb8, 0x79, 0xef, 0x25, 0x93, 0xf7, 0xd6, 0xc8, 0xbc, 0x77,
+	0x45, 0x68, 0xeb, 0xf8, 0xf2, 0xdf, 0xbb, 0xc7, 0x1e, 0x05, 0x63, 0xcf, 0x2a, 0x8f, 0x3d, 0xe7,
+	0xc5, 0x61, 0x3c, 0x32, 0x02, 0xfd, 0xc0, 0x4b, 0x86, 0x79, 0x0e, 0x8b, 0xa6, 0x85, 0xce, 0x08,
+	0x88, 0xf4, 0x50, 0x4c, 0xa4, 0x8b, 0x42, 0x5b, 0xa1, 0xb8, 0x74, 0x9b, 0xe1, 0xd2, 0x94, 0xd0,
+	0x20, 0x27, 0x49, 0x7e, 0x8a, 0x25, 0xc9, 0x07, 0xc2, 0x24, 0x79, 0xe1, 0x2d, 0xc1, 0xef, 0x40,
+	0xb2, 0xdc, 0xe0, 0x27, 0xcb, 0x99, 0x70, 0x6c, 0x8b, 0x9e, 0x34, 0x9f, 0xf5, 0xc0, 0x40, 0xde,
+	0x6c, 0xd4, 0x6c, 0xa3, 0xaa, 0xe9, 0x4e, 0xf4, 0x8e, 0xb4, 0xcf, 0xc3, 0x2d, 0x47, 0xfc, 0x63,
+	0x9d, 0x17, 0xb1, 0xb7, 0x5d, 0x82, 0x10, 0x2f, 0x8b, 0x70, 0x09, 0x72, 0x0a, 0xba, 0xb4, 0x9a,
+	0x7f, 0x01, 0xd2, 0xa5, 0xd5, 0xb0, 0x4b, 0x91, 0x9e, 0x68, 0x97, 0x22, 0xff, 0x0d, 0xd5, 0xa8,
+	0x11, 0xc7, 0x68, 0xa7, 0x51, 0x23, 0x0c, 0x84, 0xfa, 0x03, 0x83, 0x2f, 0xe2, 0x70, 0x1a, 0xdf,
+	0x75, 0xc4, 0x4d, 0x17, 0x65, 0xba, 0xad, 0xa6, 0x8b, 0xb6, 0xd1, 0xe1, 0x1b, 0xb9, 0x97, 0x24,
+	0x1a, 0x73, 0x34, 0x1a, 0xd9, 0x43, 0x9f, 0x44, 0x40, 0x7e, 0x10, 0xbe, 0x6b, 0xa2, 0x8f, 0xd3,
+	0x4e, 0xd7, 0x44, 0xdb, 0x08, 0x75, 0x57, 0x17, 0xb6, 0xfd, 0x61, 0x00, 0x72, 0xe2, 0xda, 0x9f,
+	0x03, 0x21, 0x1c, 0x59, 0xc9, 0x77, 0xfb, 0xdc, 0x3f, 0x25, 0xbf, 0xfa, 0xd3, 0x00, 0xe2, 0x8e,
+	0x3c, 0x41, 0x62, 0x2e, 0x00, 0x00,
 }
@@ -0,0 +1,189 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: forseti/protos/forseti.proto
+
+package forseti
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type Violation struct {
+	ResourceId           string                   `protobuf:"bytes,1,opt,name=resource_id,json=resourceId,proto3" json:"resource_id,omitempty"`
+	ResourceType         string                   `protobuf:"bytes,2,opt,name=resource_type,json=resourceType,proto3" json:"resource_type,omitempty"`
+	ResourceName         string                   `protobuf:"bytes,3,opt,name=resource_name,json=resourceName,proto3" json:"resource_name,omitempty"`
+	FullName             string                   `protobuf:"bytes,4,opt,name=full_name,json=fullName,proto3" json:"full_name,omitempty"`
+	RuleName             string                   `protobuf:"bytes,5,opt,name=rule_name,json=ruleName,proto3" json:"rule_name,omitempty"`
+	ViolationType        string                   `protobuf:"bytes,6,opt,name=violation_type,json=violationType,proto3" json:"violation_type,omitempty"`
+	ViolationData        *Violation_ViolationData `protobuf:"bytes,7,opt,name=violation_data,json=violationData,proto3" json:"violation_data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *Violation) Reset()         { *m = Violation{} }
+func (m *Violation) String() string { return proto.CompactTextString(m) }
+func (*Violation) ProtoMessage()    {}
+func (*Violation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f8b9c7af4d63a09f, []int{0}
+}
+
+func (m *Violation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Violation.Unmarshal(m, b)
+}
+func (m *Violation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Violation.Marshal(b, m, deterministic)
+}
+func (m *Violation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Violation.Merge(m, src)
+}
+func (m *Violation) XXX_Size() int {
+	return xxx_messageInfo_Violation.Size(m)
+}
+func (m *Violation) XXX_DiscardUnknown() {
+	xxx_messageInfo_Violation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Violation proto.InternalMessageInfo
+
+func (m *Violation) GetResourceId() string {
+	if m != nil {
+		return m.ResourceId
+	}
+	return ""
+}
+
+func (m *Violation) GetResourceType() string {
+	if m != nil {
+		return m.ResourceType
+	}
+	return ""
+}
+
+func (m *Violation) GetResourceName() string {
+	if m != nil {
+		return m.ResourceName
+	}
+	return ""
+}
+
+func (m *Violation) GetFullName() string {
+	if m != nil {
+		return m.FullName
+	}
+	return ""
+}
+
+func (m *Violation) GetRuleName() string {
+	if m != nil {
+		return m.RuleName
+	}
+	return ""
+}
+
+func (m *Violation) GetViolationType() string {
+	if m != nil {
+		return m.ViolationType
+	}
+	return ""
+}
+
+func (m *Violation) GetViolationData() *Violation_ViolationData {
+	if m != nil {
+		return m.ViolationData
+	}
+	return nil
+}
+
+type Violation_ViolationData struct {
+	Role                 string   `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`
+	Member               string   `protobuf:"bytes,2,opt,name=member,proto3" json:"member,omitempty"`
+	FullName             string   `protobuf:"bytes,3,opt,name=full_name,json=fullName,proto3" json:"full_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Violation_ViolationData) Reset()         { *m = Violation_ViolationData{} }
+func (m *Violation_ViolationData) String() string { return proto.CompactTextString(m) }
+func (*Violation_ViolationData) ProtoMessage()    {}
+func (*Violation_ViolationData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f8b9c7af4d63a09f, []int{0, 0}
+}
+
+func (m *Violation_ViolationData) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Violation_ViolationData.Unmarshal(m, b)
+}
+func (m *Violation_ViolationData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Violation_ViolationData.Marshal(b, m, deterministic)
+}
+func (m *Violation_ViolationData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Violation_ViolationData.Merge(m, src)
+}
+func (m *Violation_ViolationData) XXX_Size() int {
+	return xxx_messageInfo_Violation_ViolationData.Size(m)
+}
+func (m *Violation_ViolationData) XXX_DiscardUnknown() {
+	xxx_messageInfo_Violation_ViolationData.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Violation_ViolationData proto.InternalMessageInfo
+
+func (m *Violation_ViolationData) GetRole() string {
+	if m != nil {
+		return m.Role
+	}
+	return ""
+}
+
+func (m *Violation_ViolationData) GetMember() string {
+	if m != nil {
+		return m.Member
+	}
+	return ""
+}
+
+func (m *Violation_ViolationData) GetFullName() string {
+	if m != nil {
+		return m.FullName
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*Violation)(nil), "Violation")
+	proto.RegisterType((*Violation_ViolationData)(nil), "Violation.ViolationData")
+}
+
+func init() { proto.RegisterFile("forseti/protos/forseti.proto", fileDescriptor_f8b9c7af4d63a09f) }
+
+var fileDescriptor_f8b9c7af4d63a09f = []byte{
+	// 236 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x92, 0x49, 0xcb, 0x2f, 0x2a,
+	0x4e, 0x2d, 0xc9, 0xd4, 0x2f, 0x28, 0xca, 0x2f, 0xc9, 0x2f, 0xd6, 0x87, 0x72, 0xf5, 0xc0, 0x5c,
+	0xa5, 0x4f, 0x4c, 0x5c, 0x9c, 0x61, 0x99, 0xf9, 0x39, 0x89, 0x25, 0x99, 0xf9, 0x79, 0x42, 0xf2,
+	0x5c, 0xdc, 0x45, 0xa9, 0xc5, 0xf9, 0xa5, 0x45, 0xc9, 0xa9, 0xf1, 0x99, 0x29, 0x12, 0x8c, 0x0a,
+	0x8c, 0x1a, 0x9c, 0x41, 0x5c, 0x30, 0x21, 0xcf, 0x14, 0x21, 0x65, 0x2e, 0x5e, 0xb8, 0x82, 0x92,
+	0xca, 0x82, 0x54, 0x09, 0x26, 0xb0, 0x12, 0x1e, 0x98, 0x60, 0x48, 0x65, 0x41, 0x2a, 0x8a, 0xa2,
+	0xbc, 0xc4, 0xdc, 0x54, 0x09, 0x66, 0x54, 0x45, 0x7e, 0x89, 0xb9, 0xa9, 0x42, 0xd2, 0x5c, 0x9c,
+	0x69, 0xa5, 0x39, 0x39, 0x10, 0x05, 0x2c, 0x60, 0x05, 0x1c, 0x20, 0x01, 0x98, 0x64, 0x51, 0x69,
+	0x0e, 0x54, 0x37, 0x2b, 0x44, 0x12, 0x24, 0x00, 0x96, 0x54, 0xe5, 0xe2, 0x2b, 0x83, 0xb9, 0x18,
+	0xe2, 0x08, 0x36, 0xb0, 0x0a, 0x5e, 0xb8, 0x28, 0xd8, 0x15, 0xf6, 0xc8, 0xca, 0x52, 0x12, 0x4b,
+	0x12, 0x25, 0xd8, 0x15, 0x18, 0x35, 0xb8, 0x8d, 0x24, 0xf4, 0xe0, 0xfe, 0x45, 0xb0, 0x5c, 0x12,
+	0x4b, 0x12, 0x91, 0x0c, 0x00, 0x71, 0xa5, 0x22, 0xb8, 0x78, 0x51, 0xe4, 0x85, 0x84, 0xb8, 0x58,
+	0x8a, 0xf2, 0x73, 0x52, 0xa1, 0xc1, 0x02, 0x66, 0x0b, 0x89, 0x71, 0xb1, 0xe5, 0xa6, 0xe6, 0x26,
+	0xa5, 0x16, 0x41, 0x43, 0x02, 0xca, 0x43, 0xf5, 0x1e, 0x33, 0xaa, 0xf7, 0x92, 0xd8, 0xc0, 0x61,
+	0x6f, 0x0c, 0x18, 0x00, 0x28, 0x8e, 0xbe, 0x2c, 0x9b, 0x01, 0x00, 0x00,
+}
@@ -0,0 +1,470 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: siem/protos/siem.proto
+
+package siem
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type Alert struct {
+	SiemAlert            *Alert_SIEMAlert `protobuf:"bytes,1,opt,name=siemAlert,proto3" json:"siemAlert,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *Alert) Reset()         { *m = Alert{} }
+func (m *Alert) String() string { return proto.CompactTextString(m) }
+func (*Alert) ProtoMessage()    {}
+func (*Alert) Descriptor() ([]byte, []int) {
+	return fileDescriptor_080523a26db7d972, []int{0}
+}
+
+func (m *Alert) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Alert.Unmarshal(m, b)
+}
+func (m *Alert) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Alert.Marshal(b, m, deterministic)
+}
+func (m *Alert) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Alert.Merge(m, src)
+}
+func (m *Alert) XXX_Size() int {
+	return xxx_messageInfo_Alert.Size(m)
+}
+func (m *Alert) XXX_DiscardUnknown() {
+	xxx_messageInfo_Alert.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Alert proto.InternalMessageInfo
+
+func (m *Alert) GetSiemAlert() *Alert_SIEMAlert {
+	if m != nil {
+		return m.SiemAlert
+	}
+	return nil
+}
+
+type Alert_Resource struct {
+	ProjectId            string   `protobuf:"bytes,1,opt,name=projectId,proto3" json:"projectId,omitempty"`
+	Zone                 string   `protobuf:"bytes,2,opt,name=zone,proto3" json:"zone,omitempty"`
+	Instance             string   `protobuf:"bytes,3,opt,name=instance,proto3" json:"instance,omitempty"`
+	Bucket               string   `protobuf:"bytes,4,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	FirewallId           string   `protobuf:"bytes,5,opt,name=firewallId,proto3" json:"firewallId,omitempty"`
+	Members              []string `protobuf:"bytes,6,rep,name=members,proto3" json:"members,omitempty"`
+	CryptoKeyVersion     string   `protobuf:"bytes,7,opt,name=cryptoKeyVersion,proto3" json:"cryptoKeyVersion,omitempty"`
+	SourceIps            []string `protobuf:"bytes,8,rep,name=sourceIps,proto3" json:"sourceIps,omitempty"`
+	Region               string   `protobuf:"bytes,9,opt,name=region,proto3" json:"region,omitempty"`
+	ComposerEnvironment  string   `protobuf:"bytes,10,opt,name=composerEnvironment,proto3" json:"composerEnvironment,omitempty"`
+	DataprocCluster      string   `protobuf:"bytes,11,opt,name=dataprocCluster,proto3" json:"dataprocCluster,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Alert_Resource) Reset()         { *m = Alert_Resource{} }
+func (m *Alert_Resource) String() string { return proto.CompactTextString(m) }
+func (*Alert_Resource) ProtoMessage()    {}
+func (*Alert_Resource) Descriptor() ([]byte, []int) {
+	return fileDescriptor_080523a26db7d972, []int{0, 0}
+}
+
+func (m *Alert_Resource) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Alert_Resource.Unmarshal(m, b)
+}
+func (m *Alert_Resource) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Alert_Resource.Marshal(b, m, deterministic)
+}
+func (m *Alert_Resource) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Alert_Resource.Merge(m, src)
+}
+func (m *Alert_Resource) XXX_Size() int {
+	return xxx_messageInfo_Alert_Resource.Size(m)
+}
+func (m *Alert_Resource) XXX_DiscardUnknown() {
+	xxx_messageInfo_Alert_Resource.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Alert_Resource proto.InternalMessageInfo
+
+func (m *Alert_Resource) GetProjectId() string {
+	if m != nil {
+		return m.ProjectId
+	}
+	return ""
+}
+
+func (m *Alert_Resource) GetZone() string {
+	if m != nil {
+		return m.Zone
+	}
+	return ""
+}
+
+func (m *Alert_Resource) GetInstance() string {
+	if m != nil {
+		return m.Instance
+	}
+	return ""
+}
+
+func (m *Alert_Resource) GetBucket() string {
+	if m != nil {
+		return m.Bucket
+	}
+	return ""
+}
+
+func (m *Alert_Resource) GetFirewallId() string {
+	if m != nil {
+		return m.FirewallId
+	}
+	return ""
+}
+
+func (m *Alert_Resource) GetMembers() []string {
+	if m != nil {
+		return m.Members
+	}
+	return nil
+}
+
+func (m *Alert_Resource) GetCryptoKeyVersion() string {
+	if m != nil {
+		return m.CryptoKeyVersion
+	}
+	return ""
+}
+
+func (m *Alert_Resource) GetSourceIps() []string {
+	if m != nil {
+		return m.SourceIps
+	}
+	return nil
+}
+
+func (m *Alert_Resource) GetRegion() string {
+	if m != nil {
+		return m.Region
+	}
+	return ""
+}
+
+func (m *Alert_Resource) GetComposerEnvironment() string {
+	if m != nil {
+		return m.ComposerEnvironment
+	}
+	return ""
+}
+
+func (m *Alert_Resource) GetDataprocCluster() string {
+	if m != nil {
+		return m.DataprocCluster
+	}
+	return ""
+}
+
+type Alert_SIEMAlert struct {
+	Source               string          `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	Id                   string          `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Category             string          `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
+	EventTime            string          `protobuf:"bytes,4,opt,name=eventTime,proto3" json:"eventTime,omitempty"`
+	Resource             *Alert_Resource `protobuf:"bytes,5,opt,name=resource,proto3" json:"resource,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *Alert_SIEMAlert) Reset()         { *m = Alert_SIEMAlert{} }
+func (m *Alert_SIEMAlert) String() string { return proto.CompactTextString(m) }
+func (*Alert_SIEMAlert) ProtoMessage()    {}
+func (*Alert_SIEMAlert) Descriptor() ([]byte, []int) {
+	return fileDescriptor_080523a26db7d972, []int{0, 1}
+}
+
+func (m *Alert_SIEMAlert) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Alert_SIEMAlert.Unmarshal(m, b)
+}
+func (m *Alert_SIEMAlert) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Alert_SIEMAlert.Marshal(b, m, deterministic)
+}
+func (m *Alert_SIEMAlert) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Alert_SIEMAlert.Merge(m, src)
+}
+func (m *Alert_SIEMAlert) XXX_Size() int {
+	return xxx_messageInfo_Alert_SIEMAlert.Size(m)
+}
+func (m *Alert_SIEMAlert) XXX_DiscardUnknown() {
+	xxx_messageInfo_Alert_SIEMAlert.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Alert_SIEMAlert proto.InternalMessageInfo
+
+func (m *Alert_SIEMAlert) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *Alert_SIEMAlert) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Alert_SIEMAlert) GetCategory() string {
+	if m != nil {
+		return m.Category
+	}
+	return ""
+}
+
+func (m *Alert_SIEMAlert) GetEventTime() string {
+	if m != nil {
+		return m.EventTime
+	}
+	return ""
+}
+
+func (m *Alert_SIEMAlert) GetResource() *Alert_Resource {
+	if m != nil {
+		return m.Resource
+	}
+	return nil
+}
+
+type ChronicleDetection struct {
+	Id                   string                          `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type                 string                          `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	DetectionTime        string                          `protobuf:"bytes,3,opt,name=detectionTime,proto3" json:"detectionTime,omitempty"`
+	Detection            []*ChronicleDetection_Detection `protobuf:"bytes,4,rep,name=detection,proto3" json:"detection,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
+	XXX_unrecognized     []byte                          `json:"-"`
+	XXX_sizecache        int32                           `json:"-"`
+}
+
+func (m *ChronicleDetection) Reset()         { *m = ChronicleDetection{} }
+func (m *ChronicleDetection) String() string { return proto.CompactTextString(m) }
+func (*ChronicleDetection) ProtoMessage()    {}
+func (*ChronicleDetection) Descriptor() ([]byte, []int) {
+	return fileDescriptor_080523a26db7d972, []int{1}
+}
+
+func (m *ChronicleDetection) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChronicleDetection.Unmarshal(m, b)
+}
+func (m *ChronicleDetection) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChronicleDetection.Marshal(b, m, deterministic)
+}
+func (m *ChronicleDetection) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChronicleDetection.Merge(m, src)
+}
+func (m *ChronicleDetection) XXX_Size() int {
+	return xxx_messageInfo_ChronicleDetection.Size(m)
+}
+func (m *ChronicleDetection) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChronicleDetection.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChronicleDetection proto.InternalMessageInfo
+
+func (m *ChronicleDetection) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *ChronicleDetection) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *ChronicleDetection) GetDetectionTime() string {
+	if m != nil {
+		return m.DetectionTime
+	}
+	return ""
+}
+
+func (m *ChronicleDetection) GetDetection() []*ChronicleDetection_Detection {
+	if m != nil {
+		return m.Detection
+	}
+	return nil
+}
+
+type ChronicleDetection_Label struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value                string   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChronicleDetection_Label) Reset()         { *m = ChronicleDetection_Label{} }
+func (m *ChronicleDetection_Label) String() string { return proto.CompactTextString(m) }
+func (*ChronicleDetection_Label) ProtoMessage()    {}
+func (*ChronicleDetection_Label) Descriptor() ([]byte, []int) {
+	return fileDescriptor_080523a26db7d972, []int{1, 0}
+}
+
+func (m *ChronicleDetection_Label) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChronicleDetection_Label.Unmarshal(m, b)
+}
+func (m *ChronicleDetection_Label) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChronicleDetection_Label.Marshal(b, m, deterministic)
+}
+func (m *ChronicleDetection_Label) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChronicleDetection_Label.Merge(m, src)
+}
+func (m *ChronicleDetection_Label) XXX_Size() int {
+	return xxx_messageInfo_ChronicleDetection_Label.Size(m)
+}
+func (m *ChronicleDetection_Label) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChronicleDetection_Label.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChronicleDetection_Label proto.InternalMessageInfo
+
+func (m *ChronicleDetection_Label) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *ChronicleDetection_Label) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+type ChronicleDetection_Detection struct {
+	RuleName             string                      `protobuf:"bytes,1,opt,name=ruleName,proto3" json:"ruleName,omitempty"`
+	RuleId               string                      `protobuf:"bytes,2,opt,name=ruleId,proto3" json:"ruleId,omitempty"`
+	RuleLabels           []*ChronicleDetection_Label `protobuf:"bytes,3,rep,name=ruleLabels,proto3" json:"ruleLabels,omitempty"`
+	DetectionFields      []*ChronicleDetection_Label `protobuf:"bytes,4,rep,name=detectionFields,proto3" json:"detectionFields,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
+	XXX_unrecognized     []byte                      `json:"-"`
+	XXX_sizecache        int32                       `json:"-"`
+}
+
+func (m *ChronicleDetection_Detection) Reset()         { *m = ChronicleDetection_Detection{} }
+func (m *ChronicleDetection_Detection) String() string { return proto.CompactTextString(m) }
+func (*ChronicleDetection_Detection) ProtoMessage()    {}
+func (*ChronicleDetection_Detection) Descriptor() ([]byte, []int) {
+	return fileDescriptor_080523a26db7d972, []int{1, 1}
+}
+
+func (m *ChronicleDetection_Detection) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChronicleDetection_Detection.Unmarshal(m, b)
+}
+func (m *ChronicleDetection_Detection) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChronicleDetection_Detection.Marshal(b, m, deterministic)
+}
+func (m *ChronicleDetection_Detection) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChronicleDetection_Detection.Merge(m, src)
+}
+func (m *ChronicleDetection_Detection) XXX_Size() int {
+	return xxx_messageInfo_ChronicleDetection_Detection.Size(m)
+}
+func (m *ChronicleDetection_Detection) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChronicleDetection_Detection.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChronicleDetection_Detection proto.InternalMessageInfo
+
+func (m *ChronicleDetection_Detection) GetRuleName() string {
+	if m != nil {
+		return m.RuleName
+	}
+	return ""
+}
+
+func (m *ChronicleDetection_Detection) GetRuleId() string {
+	if m != nil {
+		return m.RuleId
+	}
+	return ""
+}
+
+func (m *ChronicleDetection_Detection) GetRuleLabels() []*ChronicleDetection_Label {
+	if m != nil {
+		return m.RuleLabels
+	}
+	return nil
+}
+
+func (m *ChronicleDetection_Detection) GetDetectionFields() []*ChronicleDetection_Label {
+	if m != nil {
+		return m.DetectionFields
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Alert)(nil), "Alert")
+	proto.RegisterType((*Alert_Resource)(nil), "Alert.Resource")
+	proto.RegisterType((*Alert_SIEMAlert)(nil), "Alert.SIEMAlert")
+	proto.RegisterType((*ChronicleDetection)(nil), "ChronicleDetection")
+	proto.RegisterType((*ChronicleDetection_Label)(nil), "ChronicleDetection.Label")
+	proto.RegisterType((*ChronicleDetection_Detection)(nil), "ChronicleDetection.Detection")
+}
+
+func init() { proto.RegisterFile("siem/protos/siem.proto", fileDescriptor_080523a26db7d972) }
+
+var fileDescriptor_080523a26db7d972 = []byte{
+	// 505 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x53, 0xdb, 0x6e, 0xd3, 0x40,
+	0x10, 0x55, 0x62, 0xa7, 0x8d, 0x27, 0x82, 0x44, 0x03, 0xaa, 0x16, 0x0b, 0x50, 0x54, 0xf1, 0x10,
+	0x81, 0xe4, 0xa0, 0xf0, 0x84, 0x78, 0x42, 0xa1, 0x48, 0x11, 0x97, 0x07, 0x83, 0x78, 0x77, 0xec,
+	0xa1, 0x98, 0xda, 0x5e, 0x6b, 0x77, 0x1d, 0x64, 0x3e, 0x82, 0x0f, 0xe0, 0x67, 0xfa, 0x3b, 0x7c,
+	0x06, 0xda, 0xf1, 0xad, 0xb4, 0x95, 0x78, 0x3b, 0xe7, 0xcc, 0x8c, 0xf7, 0xcc, 0xd9, 0x35, 0x9c,
+	0xe8, 0x94, 0xf2, 0x75, 0xa9, 0xa4, 0x91, 0x7a, 0x6d, 0x71, 0xc0, 0xf8, 0xf4, 0xd2, 0x85, 0xc9,
+	0xeb, 0x8c, 0x94, 0xc1, 0x00, 0x3c, 0xab, 0x33, 0x11, 0xa3, 0xe5, 0x68, 0x35, 0xdb, 0x2c, 0x02,
+	0x66, 0xc1, 0xa7, 0xdd, 0xd9, 0x07, 0x46, 0xe1, 0xd0, 0xe2, 0xff, 0x19, 0xc3, 0x34, 0x24, 0x2d,
+	0x2b, 0x15, 0x13, 0x3e, 0x04, 0xaf, 0x54, 0xf2, 0x3b, 0xc5, 0x66, 0x97, 0xf0, 0xb0, 0x17, 0x0e,
+	0x02, 0x22, 0xb8, 0x3f, 0x65, 0x41, 0x62, 0xcc, 0x05, 0xc6, 0xe8, 0xc3, 0x34, 0x2d, 0xb4, 0x89,
+	0x8a, 0x98, 0x84, 0xc3, 0x7a, 0xcf, 0xf1, 0x04, 0x8e, 0xf6, 0x55, 0x7c, 0x41, 0x46, 0xb8, 0x5c,
+	0x69, 0x19, 0x3e, 0x06, 0xf8, 0x9a, 0x2a, 0xfa, 0x11, 0x65, 0xd9, 0x2e, 0x11, 0x13, 0xae, 0x5d,
+	0x51, 0x50, 0xc0, 0x71, 0x4e, 0xf9, 0x9e, 0x94, 0x16, 0x47, 0x4b, 0x67, 0xe5, 0x85, 0x1d, 0xc5,
+	0xa7, 0xb0, 0x88, 0x55, 0x5d, 0x1a, 0xf9, 0x8e, 0xea, 0x2f, 0xa4, 0x74, 0x2a, 0x0b, 0x71, 0xcc,
+	0xf3, 0x37, 0x74, 0xbb, 0x4b, 0xb3, 0xd5, 0xae, 0xd4, 0x62, 0xca, 0xdf, 0x19, 0x04, 0xeb, 0x4d,
+	0xd1, 0xb9, 0x9d, 0xf7, 0x1a, 0x6f, 0x0d, 0xc3, 0xe7, 0x70, 0x2f, 0x96, 0x79, 0x29, 0x35, 0xa9,
+	0xb3, 0xe2, 0x90, 0x2a, 0x59, 0xe4, 0x54, 0x18, 0x01, 0xdc, 0x74, 0x5b, 0x09, 0x57, 0x30, 0x4f,
+	0x22, 0x13, 0x95, 0x4a, 0xc6, 0xdb, 0xac, 0xd2, 0x86, 0x94, 0x98, 0x71, 0xf7, 0x75, 0xd9, 0xff,
+	0x3d, 0x02, 0xaf, 0xbf, 0x03, 0xeb, 0xa0, 0xb1, 0xd3, 0x06, 0xdd, 0x32, 0xbc, 0x0b, 0xe3, 0x34,
+	0x69, 0x33, 0x1e, 0xa7, 0x89, 0x4d, 0x38, 0x8e, 0x0c, 0x9d, 0x4b, 0x55, 0x77, 0x09, 0x77, 0xdc,
+	0xee, 0x48, 0x07, 0x2a, 0xcc, 0xe7, 0x34, 0xa7, 0x36, 0xe4, 0x41, 0xc0, 0x67, 0x30, 0x55, 0xed,
+	0xcd, 0x72, 0xca, 0xb3, 0xcd, 0xbc, 0x7d, 0x09, 0xdd, 0x85, 0x87, 0x7d, 0xc3, 0xe9, 0x2f, 0x07,
+	0x70, 0xfb, 0x4d, 0xc9, 0x22, 0x8d, 0x33, 0x7a, 0x43, 0x86, 0x62, 0x63, 0xf3, 0x68, 0xdc, 0x8c,
+	0x7a, 0x37, 0x08, 0xae, 0xa9, 0xcb, 0xfe, 0x0d, 0x58, 0x8c, 0x4f, 0xe0, 0x4e, 0xd2, 0x0d, 0xb0,
+	0x93, 0xc6, 0xe6, 0xbf, 0x22, 0xbe, 0x02, 0xaf, 0x17, 0x84, 0xbb, 0x74, 0x56, 0xb3, 0xcd, 0xa3,
+	0xe0, 0xe6, 0x89, 0x41, 0x8f, 0xc2, 0xa1, 0xdf, 0x5f, 0xc3, 0xe4, 0x7d, 0xb4, 0xa7, 0x0c, 0x17,
+	0xe0, 0x5c, 0x50, 0xdd, 0x1a, 0xb2, 0x10, 0xef, 0xc3, 0xe4, 0x10, 0x65, 0x55, 0x67, 0xa9, 0x21,
+	0xfe, 0xe5, 0x08, 0xbc, 0x61, 0x0b, 0x1f, 0xa6, 0xaa, 0xca, 0xe8, 0x63, 0x94, 0x77, 0x69, 0xf7,
+	0x9c, 0x5f, 0x42, 0x95, 0xd1, 0xae, 0xcb, 0xbc, 0x65, 0xf8, 0x12, 0xc0, 0x22, 0x3e, 0x56, 0x0b,
+	0x87, 0x0d, 0x3f, 0xb8, 0xcd, 0x30, 0x77, 0x84, 0x57, 0x9a, 0x71, 0x0b, 0xf3, 0xde, 0xfa, 0xdb,
+	0x94, 0xb2, 0x44, 0x0b, 0xf7, 0x7f, 0xf3, 0xd7, 0x27, 0xf6, 0x47, 0xfc, 0x67, 0xbf, 0xf8, 0x3b,
+	0x00, 0x5b, 0xc0, 0x1f, 0x9d, 0xf3, 0x03, 0x00, 0x00,
+}
@@ -18,32 +18,82 @@ package exec
 import (
 	"context"
 	"encoding/json"
+	"io/ioutil"
 	"log"
+	"net/http"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"cloud.google.com/go/pubsub"
+	"github.com/googlecloudplatform/security-response-automation/clients"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/approvals/approve"
 	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/bigquery/closepublicdataset"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/cloud-sql/enablebackups"
 	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/cloud-sql/removepublic"
 	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/cloud-sql/requiressl"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/cloud-sql/secureroot"
 	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/cloud-sql/updatepassword"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/containment/lockdownproject"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/containment/restore"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/dlp/restrictsensitivedata"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/findings/batchexport"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/findings/replay"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/forensics/exportbundle"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gce/blockegress"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gce/createanalysisvm"
 	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gce/createsnapshot"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gce/disableipforwarding"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gce/disableserialport"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gce/disableunusedfirewall"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gce/enforcehttps"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gce/hardeninstance"
 	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gce/openfirewall"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gce/removeloadbalancer"
 	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gce/removepublicip"
 	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gcs/closebucket"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gcs/closestagingbucket"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gcs/enablebucketlogging"
 	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gcs/enablebucketonlypolicy"
 	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gke/disabledashboard"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gke/hardenclusternetwork"
 	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/iam/enableauditlogs"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/iam/quarantineserviceaccount"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/iam/removegroupmembers"
 	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/iam/removenonorgmembers"
 	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/iam/revoke"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/iam/revokegrants"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/iam/revokeorgmembers"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/kms/disablekeyversion"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/kms/enforcecmek"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/managed-clusters/securecluster"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/operations/poll"
 	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/router"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/siem/adapter"
 	"github.com/googlecloudplatform/security-response-automation/services"
+	"github.com/pkg/errors"
 )
 
 var (
-	svcs      *services.Global
+	svcs *services.Global
+	// bundle is nil if no forensics bucket is configured.
+	bundle    *services.IncidentBundle
 	projectID = os.Getenv("GCP_PROJECT")
 )
 
+const (
+	// activityWindow is how far back the admin activity on the affected resource is included in
+	// notifications.
+	activityWindow = 24 * time.Hour
+	// resultMarkPrefix prefixes the security mark holding the result of each automation run on a
+	// finding, i.e. "sra-result-close_bucket".
+	resultMarkPrefix = "sra-result-"
+	// correlationMark is the security mark holding the correlation ID of the finding's
+	// remediation, so its logs, audit records and notifications can be found from the finding.
+	correlationMark = "sra-correlation-id"
+)
+
 func init() {
 	ctx := context.Background()
 	var err error
@@ -54,12 +104,252 @@ func init() {
 	if err != nil {
 		log.Fatalf("failed to initialize services: %q", err)
 	}
+	bundle, err = services.InitIncidentBundle(ctx, projectID)
+	if err != nil {
+		log.Fatalf("failed to initialize incident bundle: %q", err)
+	}
+	if margin := os.Getenv("DEADLINE_MARGIN"); margin != "" {
+		if services.DeadlineMargin, err = time.ParseDuration(margin); err != nil {
+			log.Fatalf("failed to parse DEADLINE_MARGIN: %q", err)
+		}
+	}
+	if window := os.Getenv("RETRY_WINDOW"); window != "" {
+		if services.RetryWindow, err = time.ParseDuration(window); err != nil {
+			log.Fatalf("failed to parse RETRY_WINDOW: %q", err)
+		}
+	}
+}
+
+// withDeadline bounds ctx by the function's timeout so API calls still in flight are cancelled
+// before the runtime stops the invocation. The invocation is traced by a span, named after the
+// automation by notify, that the returned function ends and exports.
+func withDeadline(ctx context.Context) (context.Context, context.CancelFunc) {
+	timeout, _ := strconv.Atoi(os.Getenv("FUNCTION_TIMEOUT_SEC"))
+	ctx, end := services.StartSpan(ctx, "invocation")
+	ctx, cancel := services.WithDeadline(ctx, time.Duration(timeout)*time.Second)
+	return ctx, func() {
+		cancel()
+		end(nil)
+		flushTraces()
+	}
+}
+
+// flushTraces exports the spans of the invocation before the runtime may stop the function.
+func flushTraces() {
+	ctx, cancel := context.WithTimeout(context.Background(), services.DeadlineMargin)
+	defer cancel()
+	if err := clients.FlushTraces(ctx); err != nil {
+		svcs.Logger.Error("failed to export traces: %q", err)
+	}
+}
+
+// correlate returns a context carrying the correlation ID the message was sent with, or a new one
+// if it has none such as a finding sent to the router. Every log line, audit record, notification,
+// ticket and security mark of the remediation carries the ID, and the router forwards it to the
+// automations the finding triggers along with the steps of their playbooks. The context also
+// carries the span the message was sent from so the invocation continues its trace.
+func correlate(ctx context.Context, m pubsub.Message) context.Context {
+	ctx = services.ExtractSpan(ctx, m.Attributes)
+	id := m.Attributes[router.CorrelationAttribute]
+	if id == "" {
+		id = services.NewCorrelationID()
+	}
+	return services.WithCorrelationID(ctx, id)
+}
+
+// notify records the outcome of an automation: the result is logged, audited and marked on the
+// finding, then its event is recorded in the history, fanned out to the configured notification
+// channels, such as the webhook and the project's security contacts, and emailed to the recipients
+// the router selected for the automation if they are configured, including the recent admin
+// activity on the affected resource and the reputation and country of the finding's IPs. The next
+// step is run if the automation is a step of a playbook, then the automation's error is returned.
+// Failing to notify does not fail the automation. Permanent failures such as a missing resource, and
+// failures past the retry window, are logged and not returned so their message is acknowledged
+// instead of being redelivered. If the automation ran out of time the notifications are sent with a
+// context detached from the expired one.
+func notify(ctx context.Context, action, projectID string, m pubsub.Message, r *services.Result, err error) error {
+	if ctx.Err() != nil {
+		var cancel context.CancelFunc
+		ctx, cancel = services.Detach(ctx)
+		defer cancel()
+	}
+	logger := svcs.Logger.For(ctx)
+	if err == nil && r != nil && !r.Empty() {
+		logger.Info("%s", r)
+		record := r.AuditRecord(projectID)
+		record.Identity = clients.ServiceAccount(ctx)
+		logger.Audit(record)
+	}
+	event := services.NewWebhookEvent(action, projectID, m.Data, r != nil && r.DryRun, err)
+	event.Severity = m.Attributes[router.SeverityAttribute]
+	event.FindingName = m.Attributes[router.FindingAttribute]
+	event.Category = m.Attributes[router.CategoryAttribute]
+	event.Rollout = m.Attributes[router.RolloutAttribute]
+	event.CorrelationID = services.CorrelationID(ctx)
+	event.Outcome = r
+	logger.Event(event)
+	services.RecordSpan(ctx, action, err, "sra.action", action, "sra.project_id", projectID, "sra.result", event.Result)
+	if event.FindingName != "" && (r != nil || err != nil) {
+		status := event.Result
+		if err == nil {
+			status = r.Status()
+		}
+		marks := map[string]string{resultMarkPrefix + action: status}
+		if event.CorrelationID != "" {
+			marks[correlationMark] = event.CorrelationID
+		}
+		if _, merr := svcs.SecurityCommandCenter.AddSecurityMarks(ctx, event.FindingName, marks); merr != nil {
+			logger.Error("failed to mark finding %q with result of %q: %q", event.FindingName, action, merr)
+		}
+	}
+	to := m.Attributes[router.EmailAttribute]
+	emailing := svcs.EmailNotifier != nil && to != ""
+	if svcs.Notifier != nil || emailing {
+		enrich(ctx, event, m)
+	}
+	if svcs.Notifier != nil {
+		if nerr := svcs.Notifier.Notify(ctx, event); nerr != nil {
+			logger.Error("failed to notify outcome of %q: %q", action, nerr)
+		}
+	}
+	if emailing {
+		if nerr := svcs.EmailNotifier.Notify(ctx, event, strings.Split(to, ",")); nerr != nil {
+			logger.Error("failed to email summary of %q: %q", action, nerr)
+		}
+	}
+	if m.Attributes[router.PlaybookAttribute] != "" {
+		if perr := continuePlaybook(ctx, m, err); perr != nil {
+			logger.Error("failed to continue playbook after %q: %q", action, perr)
+		}
+	}
+	if services.Permanent(err) {
+		logger.Error("%q failed permanently, not retrying: %q", action, err)
+		return nil
+	}
+	if err != nil && services.FinalAttempt(ctx) {
+		logger.Error("%q failed past its retry window of %s, not retrying: %q", action, services.RetryWindow, err)
+		return nil
+	}
+	return err
+}
+
+// enrich adds the recent admin activity on the affected resource, the reputation and country of the
+// finding's IPs and, if channels are configured, the owners and editors of the project to the event.
+func enrich(ctx context.Context, event *services.WebhookEvent, m pubsub.Message) {
+	logger := svcs.Logger.For(ctx)
+	if event.ProjectID != "" {
+		activity, err := svcs.AuditLogs.RecentActivity(ctx, event.ProjectID, m.Attributes[router.ResourceAttribute], time.Now().Add(-activityWindow))
+		if err != nil {
+			logger.Error("failed to get recent activity of project %q: %q", event.ProjectID, err)
+		}
+		event.Activity = activity
+	}
+	if ips := m.Attributes[router.IPsAttribute]; ips != "" && svcs.Intel != nil {
+		intel, err := svcs.Intel.Lookup(ctx, strings.Split(ips, ","))
+		if err != nil {
+			logger.Error("failed to look up IPs of %q: %q", event.Action, err)
+		}
+		event.Intel = intel
+	}
+	if event.ProjectID != "" && svcs.Notifier != nil {
+		contacts, err := svcs.Resource.ProjectContacts(ctx, event.ProjectID)
+		if err != nil {
+			logger.Error("failed to get contacts of project %q: %q", event.ProjectID, err)
+		}
+		event.Contacts = contacts
+	}
+}
+
+// continuePlaybook records the outcome of the playbook step and runs the next step.
+func continuePlaybook(ctx context.Context, m pubsub.Message, err error) error {
+	ps, perr := services.InitPubSub(ctx, projectID)
+	if perr != nil {
+		return perr
+	}
+	return router.ContinuePlaybook(ctx, &router.Services{
+		PubSub: ps,
+		Logger: svcs.Logger.For(ctx),
+		State:  svcs.State,
+		Bundle: bundle,
+	}, m.Attributes, err)
+}
+
+// resolveProject replaces a project number with the project's ID since findings may carry either
+// while most remediation APIs expect the ID. The returned context acts as the service account
+// impersonated in the project, if any.
+func resolveProject(ctx context.Context, project *string) (context.Context, error) {
+	id, err := svcs.Resource.ProjectID(ctx, *project)
+	if err != nil {
+		return ctx, err
+	}
+	*project = id
+	if svcs.Impersonation == nil {
+		return ctx, nil
+	}
+	return svcs.Impersonation.Scope(ctx, id)
+}
+
+// newApproval returns the approval service of automations that must be approved before they act.
+// Approvals are kept in the state bucket so one must be configured.
+func newApproval(ctx context.Context) (*services.Approval, error) {
+	if svcs.State == nil {
+		return nil, errors.New("approvals require a state bucket")
+	}
+	ps, err := services.InitPubSub(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	return services.NewApproval(ps, svcs.State, os.Getenv("APPROVAL_TOPIC"), strings.Split(os.Getenv("APPROVERS"), ",")), nil
 }
 
 // Router is the entry point for the router Cloud Function.
 //
 // This Cloud Function will receive all findings and route them to configured automation.
 func Router(ctx context.Context, m pubsub.Message) error {
+	ctx, cancel := withDeadline(correlate(ctx, m))
+	defer cancel()
+	ps, err := services.InitPubSub(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	conf, err := router.Config()
+	if err != nil {
+		return err
+	}
+	err = router.Execute(ctx, &router.Values{
+		Finding:       m.Data,
+		CorrelationID: services.CorrelationID(ctx),
+		Replay:        m.Attributes[router.ReplayAttribute] != "",
+	}, routerServices(ctx, ps, conf))
+	services.RecordSpan(ctx, "router", err)
+	if err != nil {
+		// Failed findings are kept on the dead-letter topic to be replayed by ReplayDeadLetters.
+		if _, perr := ps.Publish(ctx, services.DeadLetterTopic, services.NewDeadLetterMessage(&m, err, time.Now())); perr != nil {
+			svcs.Logger.For(ctx).Error("failed to send finding to the dead-letter topic: %q", perr)
+		}
+	}
+	return err
+}
+
+// Sweep is the entry point for the sweep Cloud Function.
+//
+// This Cloud Function is triggered on demand, or on a schedule by Cloud Scheduler, with the
+// automation to run and the organization, folder or project to run it over. Every resource of the
+// automation's kind in the scope is listed with Cloud Asset Inventory and sent to the automation as
+// if it had a finding, going through the same targets, freezes and dry run as the router.
+//
+// Permissions required
+//	- roles/cloudasset.viewer on the folders to list the resources of the scope.
+//	- roles/browser on the folders to match the projects of the resources to the target.
+//	- roles/pubsub.publisher on the automations' topics and the sweep topic to continue the sweep.
+//
+func Sweep(ctx context.Context, m pubsub.Message) error {
+	ctx, cancel := withDeadline(correlate(ctx, m))
+	defer cancel()
+	var values router.SweepValues
+	if err := json.Unmarshal(m.Data, &values); err != nil {
+		return err
+	}
 	ps, err := services.InitPubSub(ctx, projectID)
 	if err != nil {
 		return err
@@ -68,15 +358,175 @@ func Router(ctx context.Context, m pubsub.Message) error {
 	if err != nil {
 		return err
 	}
-	return router.Execute(ctx, &router.Values{
-		Finding: m.Data,
-	}, &router.Services{
+	rs := routerServices(ctx, ps, conf)
+	rs.Assets = svcs.Assets
+	r, err := router.Sweep(ctx, &values, rs)
+	return notify(ctx, "sweep", projectID, m, r, err)
+}
+
+func routerServices(ctx context.Context, ps *services.PubSub, conf *router.Configuration) *router.Services {
+	rs := &router.Services{
 		PubSub:                ps,
 		Configuration:         conf,
-		Logger:                svcs.Logger,
+		Logger:                svcs.Logger.For(ctx),
 		Resource:              svcs.Resource,
 		SecurityCommandCenter: svcs.SecurityCommandCenter,
+		State:                 svcs.State,
+		Intel:                 svcs.Intel,
+		Tags:                  svcs.Tags,
+		Bundle:                bundle,
+	}
+	// Automations are replaced by tickets during freezes only if a ticket topic is configured.
+	if topic := os.Getenv("TICKET_TOPIC"); topic != "" {
+		rs.Tickets = services.NewTickets(ps, topic)
+	}
+	return rs
+}
+
+// SIEMAdapter is the entry point for the SIEM adapter Cloud Function.
+//
+// This HTTP Cloud Function accepts alerts from Chronicle or any SIEM able to send the normalized
+// alert format, converts them and sends them to the router so they can trigger the same
+// automations as Security Command Center findings. Requests must carry the token configured in
+// the SIEM_ADAPTER_TOKEN environment variable as a bearer token.
+//
+// Permissions required
+//	- roles/pubsub.publisher to send alerts to the router.
+//
+func SIEMAdapter(w http.ResponseWriter, r *http.Request) {
+	if !adapter.Authorized(r.Header.Get("Authorization"), os.Getenv("SIEM_ADAPTER_TOKEN")) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	b, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "failed to read request", http.StatusBadRequest)
+		return
+	}
+	ctx := r.Context()
+	ps, err := services.InitPubSub(ctx, projectID)
+	if err != nil {
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	if err := adapter.Execute(ctx, &adapter.Values{Alert: b}, &adapter.Services{
+		PubSub: ps,
+		Logger: svcs.Logger,
+		Assets: svcs.Assets,
+	}); err != nil {
+		svcs.Logger.Error("failed to process siem alert: %q", err)
+		http.Error(w, "failed to process alert", http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// ExportIncidentBundle is the entry point for the incident bundle export Cloud Function.
+//
+// This HTTP Cloud Function exports everything known about an incident, selected by the finding
+// name or project ID of the JSON request, as a single archive signed with the configured Cloud KMS
+// key version in the forensics bucket so it can be handed to an incident response team. The same
+// bundle is exported whenever a playbook finishes. Callers must be granted
+// roles/cloudfunctions.invoker on the function.
+//
+// Permissions required
+//	- roles/logging.viewer to read the events and audit records of the automations.
+//	- roles/storage.objectCreator on the forensics bucket to write the bundle.
+//	- roles/storage.objectViewer on the evidence bucket to read the evidence manifests.
+//	- roles/cloudkms.signer on the key to sign the bundle.
+//
+func ExportIncidentBundle(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if bundle == nil {
+		http.Error(w, "no forensics bucket configured", http.StatusNotImplemented)
+		return
+	}
+	b, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "failed to read request", http.StatusBadRequest)
+		return
+	}
+	values, err := exportbundle.ReadValues(b)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	m, err := exportbundle.Execute(r.Context(), values, &exportbundle.Services{
+		Bundle: bundle,
+		Logger: svcs.Logger,
+	})
+	if err != nil {
+		svcs.Logger.Error("failed to export incident bundle: %q", err)
+		http.Error(w, "failed to export bundle", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(m); err != nil {
+		svcs.Logger.Error("failed to encode bundle manifest: %q", err)
+	}
+}
+
+// Approve is the entry point for the approve Cloud Function.
+//
+// This HTTP Cloud Function records the approval of an automation waiting to be approved, selected
+// by the "id" of the JSON request. Callers are authenticated by a Google-signed ID token issued for
+// the audience in the APPROVAL_AUDIENCE environment variable and must be one of the approvers
+// listed in the APPROVERS environment variable. Approvals are kept in the state bucket and, once a
+// request has enough of them, its values are published to the automation's topic to run it again.
+//
+// Permissions required
+//	- roles/pubsub.publisher to run approved automations.
+//	- roles/storage.objectAdmin on the state bucket to read approval requests and record approvals.
+//
+func Approve(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	ctx := r.Context()
+	identity, err := services.InitIdentity(ctx, os.Getenv("APPROVAL_AUDIENCE"))
+	if err != nil {
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	approver, err := identity.Caller(ctx, r.Header.Get("Authorization"))
+	if err != nil {
+		svcs.Logger.Warning("rejected approval: %q", err)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	b, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "failed to read request", http.StatusBadRequest)
+		return
+	}
+	values, err := approve.ReadValues(b)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	values.Approver = approver
+	approval, err := newApproval(ctx)
+	if err != nil {
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	approved, err := approve.Execute(ctx, values, &approve.Services{
+		Approval: approval,
+		Logger:   svcs.Logger,
 	})
+	if err != nil {
+		svcs.Logger.Error("failed to approve %q: %q", values.ID, err)
+		http.Error(w, "failed to approve", http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(map[string]bool{"approved": approved}); err != nil {
+		svcs.Logger.Error("failed to encode approval: %q", err)
+	}
 }
 
 // IAMRevoke is the entry point for the IAM revoker Cloud Function.
@@ -93,173 +543,862 @@ func Router(ctx context.Context, m pubsub.Message) error {
 // 	- roles/resourcemanager.folderAdmin to revoke IAM grants.
 //	- roles/viewer to verify the affected project is within the enforced folder.
 //
-func IAMRevoke(ctx context.Context, m pubsub.Message) error {
-	var values revoke.Values
+func IAMRevoke(ctx context.Context, m pubsub.Message) error {
+	ctx, cancel := withDeadline(correlate(ctx, m))
+	defer cancel()
+	var values revoke.Values
+	switch err := json.Unmarshal(m.Data, &values); err {
+	case nil:
+		if ctx, err = resolveProject(ctx, &values.ProjectID); err != nil {
+			return err
+		}
+		r, err := revoke.Execute(ctx, &values, &revoke.Services{
+			Resource:       svcs.Resource,
+			Troubleshooter: svcs.Troubleshooter,
+			Logger:         svcs.Logger.For(ctx),
+		})
+		return notify(ctx, "iam_revoke", values.ProjectID, m, r, err)
+	default:
+		return err
+	}
+}
+
+// IAMRevokeGrants is the entry point for the revoke grants Cloud Function.
+//
+// This function removes the bindings a suspicious principal added to a project's policy within the
+// configured window, read from the project's Admin Activity audit logs.
+//
+// Permissions required
+//	- roles/resourcemanager.folderAdmin to revoke IAM grants.
+//	- roles/logging.viewer to read the policy changes from the audit logs.
+//
+func IAMRevokeGrants(ctx context.Context, m pubsub.Message) error {
+	ctx, cancel := withDeadline(correlate(ctx, m))
+	defer cancel()
+	var values revokegrants.Values
+	switch err := json.Unmarshal(m.Data, &values); err {
+	case nil:
+		if ctx, err = resolveProject(ctx, &values.ProjectID); err != nil {
+			return err
+		}
+		r, err := revokegrants.Execute(ctx, &values, &revokegrants.Services{
+			AuditLogs:      svcs.AuditLogs,
+			Resource:       svcs.Resource,
+			Troubleshooter: svcs.Troubleshooter,
+			Logger:         svcs.Logger.For(ctx),
+		})
+		return notify(ctx, "iam_revoke_grants", values.ProjectID, m, r, err)
+	default:
+		return err
+	}
+}
+
+// QuarantineServiceAccount is the entry point for the quarantine service account Cloud Function.
+//
+// This function removes the roles of a compromised service account on the project, and optionally
+// its folders and organization, then disables the account. The removed bindings are stored in the
+// state bucket so they can be restored.
+//
+// Permissions required
+//	- roles/resourcemanager.folderAdmin to remove the account's roles.
+//	- roles/iam.serviceAccountAdmin to disable the account and list its keys.
+//	- roles/resourcemanager.organizationAdmin to remove the account's organization roles.
+//
+func QuarantineServiceAccount(ctx context.Context, m pubsub.Message) error {
+	ctx, cancel := withDeadline(correlate(ctx, m))
+	defer cancel()
+	var values quarantineserviceaccount.Values
+	switch err := json.Unmarshal(m.Data, &values); err {
+	case nil:
+		if ctx, err = resolveProject(ctx, &values.ProjectID); err != nil {
+			return err
+		}
+		r, err := quarantineserviceaccount.Execute(ctx, &values, &quarantineserviceaccount.Services{
+			Resource:        svcs.Resource,
+			ServiceAccounts: svcs.ServiceAccounts,
+			State:           svcs.State,
+			Logger:          svcs.Logger.For(ctx),
+		})
+		return notify(ctx, "quarantine_service_account", values.ProjectID, m, r, err)
+	default:
+		return err
+	}
+}
+
+// IAMRevokeOrganization is the entry point for the organization IAM revoker Cloud Function.
+//
+// This function removes external members from organization and folder policies if they do not
+// match the allowed domains. Since these policies apply to every project below them no change is
+// made until it's approved: an approval request is published to the topic in the APPROVAL_TOPIC
+// environment variable and the change is made once the request has been approved by enough of the
+// approvers listed in the APPROVERS environment variable through the Approve function.
+//
+// Permissions required
+//	- roles/resourcemanager.organizationAdmin to revoke grants on the organization.
+//	- roles/resourcemanager.folderAdmin to revoke grants on folders.
+//	- roles/pubsub.publisher to publish approval requests.
+//	- roles/storage.objectAdmin on the state bucket to keep approval requests and read approvals.
+//
+func IAMRevokeOrganization(ctx context.Context, m pubsub.Message) error {
+	ctx, cancel := withDeadline(correlate(ctx, m))
+	defer cancel()
+	var values revokeorgmembers.Values
+	switch err := json.Unmarshal(m.Data, &values); err {
+	case nil:
+		approval, err := newApproval(ctx)
+		if err != nil {
+			return err
+		}
+		r, err := revokeorgmembers.Execute(ctx, &values, &revokeorgmembers.Services{
+			Resource: svcs.Resource,
+			Approval: approval,
+			Logger:   svcs.Logger.For(ctx),
+		})
+		return notify(ctx, "iam_revoke_org", values.Resource, m, r, err)
+	default:
+		return err
+	}
+}
+
+// RemoveLoadBalancer is the entry point for the remove load balancer Cloud Function.
+//
+// This function removes the external HTTP(S) load balancers exposing a compromised instance, either
+// by deleting their forwarding rules or by routing their traffic to a quarantine backend service.
+// No change is made until it's approved: an approval request is published to the topic in the
+// APPROVAL_TOPIC environment variable and the change is made once one of the approvers listed in
+// the APPROVERS environment variable approves it through the Approve function.
+//
+// Permissions required
+//	- roles/compute.loadBalancerAdmin to delete forwarding rules and update URL maps.
+//	- roles/pubsub.publisher to publish approval requests.
+//	- roles/storage.objectAdmin on the state bucket to keep approval requests and read approvals.
+//
+func RemoveLoadBalancer(ctx context.Context, m pubsub.Message) error {
+	ctx, cancel := withDeadline(correlate(ctx, m))
+	defer cancel()
+	var values removeloadbalancer.Values
+	switch err := json.Unmarshal(m.Data, &values); err {
+	case nil:
+		if ctx, err = resolveProject(ctx, &values.ProjectID); err != nil {
+			return err
+		}
+		approval, err := newApproval(ctx)
+		if err != nil {
+			return err
+		}
+		r, err := removeloadbalancer.Execute(ctx, &values, &removeloadbalancer.Services{
+			LoadBalancer: svcs.LoadBalancer,
+			Approval:     approval,
+			Logger:       svcs.Logger.For(ctx),
+		})
+		return notify(ctx, "remove_load_balancer", values.ProjectID, m, r, err)
+	default:
+		return err
+	}
+}
+
+// DisableKeyVersion is the entry point for the disable key version Cloud Function.
+//
+// This function disables a Cloud KMS key version a SIEM found to be misused, such as one decrypting
+// far more than usual, and restricts the key's IAM policy to a break-glass group. No change is made
+// until it's approved: an approval request is published to the topic in the APPROVAL_TOPIC
+// environment variable and the change is made once one of the approvers listed in the APPROVERS
+// environment variable approves it through the Approve function.
+//
+// Permissions required
+//	- roles/cloudkms.admin to disable the key version and set the key's IAM policy.
+//	- roles/pubsub.publisher to publish approval requests.
+//	- roles/storage.objectAdmin on the state bucket to keep approval requests and read approvals.
+//
+func DisableKeyVersion(ctx context.Context, m pubsub.Message) error {
+	ctx, cancel := withDeadline(correlate(ctx, m))
+	defer cancel()
+	var values disablekeyversion.Values
+	switch err := json.Unmarshal(m.Data, &values); err {
+	case nil:
+		if ctx, err = resolveProject(ctx, &values.ProjectID); err != nil {
+			return err
+		}
+		approval, err := newApproval(ctx)
+		if err != nil {
+			return err
+		}
+		r, err := disablekeyversion.Execute(ctx, &values, &disablekeyversion.Services{
+			KMS:      svcs.KMS,
+			Approval: approval,
+			Logger:   svcs.Logger.For(ctx),
+		})
+		return notify(ctx, "disable_key_version", values.ProjectID, m, r, err)
+	default:
+		return err
+	}
+}
+
+// SecureManagedCluster is the entry point for the secure managed cluster Cloud Function.
+//
+// This function responds to SIEM alerts of Cloud Composer environments or Dataproc clusters with
+// public endpoints. The control plane of a Composer environment's GKE cluster is restricted to the
+// authorized networks and its credentials rotated. The external IP addresses of a Dataproc
+// cluster's instances are removed and the user-managed keys of their service account deleted. No
+// change is made until it's approved: an approval request is published to the topic in the
+// APPROVAL_TOPIC environment variable and the change is made once one of the approvers listed in
+// the APPROVERS environment variable approves it through the Approve function.
+//
+// Permissions required
+//	- roles/container.clusterAdmin to update and rotate the credentials of GKE clusters.
+//	- roles/compute.instanceAdmin.v1 to remove the external IP addresses of instances.
+//	- roles/iam.serviceAccountKeyAdmin to delete service account keys.
+//	- roles/pubsub.publisher to publish approval requests.
+//	- roles/storage.objectAdmin on the state bucket to keep approval requests and read approvals.
+//
+func SecureManagedCluster(ctx context.Context, m pubsub.Message) error {
+	ctx, cancel := withDeadline(correlate(ctx, m))
+	defer cancel()
+	var values securecluster.Values
+	switch err := json.Unmarshal(m.Data, &values); err {
+	case nil:
+		if ctx, err = resolveProject(ctx, &values.ProjectID); err != nil {
+			return err
+		}
+		approval, err := newApproval(ctx)
+		if err != nil {
+			return err
+		}
+		r, err := securecluster.Execute(ctx, &values, &securecluster.Services{
+			ManagedClusters: svcs.ManagedClusters,
+			Container:       svcs.Container,
+			Host:            svcs.Host,
+			ServiceAccounts: svcs.ServiceAccounts,
+			Approval:        approval,
+			Logger:          svcs.Logger.For(ctx),
+		})
+		return notify(ctx, "secure_managed_cluster", values.ProjectID, m, r, err)
+	default:
+		return err
+	}
+}
+
+// LockdownProject is the entry point for the lockdown project Cloud Function.
+//
+// This function locks down a compromised project: every IAM binding other than those of a
+// break-glass group, the allowed members and the project's service agents is removed, its service
+// accounts are disabled and its running instances are stopped. The state of the project is stored
+// in the state bucket first. No change is made until it's approved: an approval request is
+// published to the topic in the APPROVAL_TOPIC environment variable and the change is made once two
+// of the approvers listed in the APPROVERS environment variable approve it through the Approve
+// function.
+//
+// Permissions required
+//	- roles/resourcemanager.folderAdmin to remove the project's bindings.
+//	- roles/iam.serviceAccountAdmin to disable service accounts and list their keys.
+//	- roles/compute.instanceAdmin.v1 to stop instances.
+//	- roles/pubsub.publisher to publish approval requests.
+//	- roles/storage.objectAdmin on the state bucket to keep approval requests and read approvals.
+//
+func LockdownProject(ctx context.Context, m pubsub.Message) error {
+	ctx, cancel := withDeadline(correlate(ctx, m))
+	defer cancel()
+	var values lockdownproject.Values
+	switch err := json.Unmarshal(m.Data, &values); err {
+	case nil:
+		if ctx, err = resolveProject(ctx, &values.ProjectID); err != nil {
+			return err
+		}
+		approval, err := newApproval(ctx)
+		if err != nil {
+			return err
+		}
+		r, err := lockdownproject.Execute(ctx, &values, &lockdownproject.Services{
+			Resource:        svcs.Resource,
+			ServiceAccounts: svcs.ServiceAccounts,
+			Host:            svcs.Host,
+			State:           svcs.State,
+			Approval:        approval,
+			Logger:          svcs.Logger.For(ctx),
+		})
+		return notify(ctx, "lockdown_project", values.ProjectID, m, r, err)
+	default:
+		return err
+	}
+}
+
+// SnapshotDisk is the entry point for the auto creation of GCE snapshots Cloud Function.
+//
+// Once a supported finding is received this Cloud Function will look for any existing disk snapshots
+// for the affected instance. If there are recent snapshots then no action is taken. This is so we
+// do not overwrite a recent snapshot. If we have not taken a snapshot recently, take a new snapshot
+// for each disk within the instance.
+//
+// If the analysis_vm output is enabled an instance without external network access is created in
+// the forensics project with the new snapshots attached read-only. Connection details are posted
+// to the configured Pub/Sub topic.
+//
+// Permissions required
+//	- roles/compute.instanceAdmin.v1 to manage disk snapshots.
+//	- roles/compute.instanceAdmin.v1 in the forensics project to create the analysis instance.
+//
+func SnapshotDisk(ctx context.Context, m pubsub.Message) error {
+	ctx, cancel := withDeadline(correlate(ctx, m))
+	defer cancel()
+	var values createsnapshot.Values
+	switch err := json.Unmarshal(m.Data, &values); err {
+	case nil:
+		if ctx, err = resolveProject(ctx, &values.ProjectID); err != nil {
+			return err
+		}
+		output, err := createsnapshot.Execute(ctx, &values, &createsnapshot.Services{
+			Host:   svcs.Host,
+			Logger: svcs.Logger.For(ctx),
+			State:  svcs.State,
+		})
+		var r *services.Result
+		if err == nil {
+			r = output.Result(values.DryRun)
+		}
+		if err := notify(ctx, "gce_create_disk_snapshot", values.ProjectID, m, r, err); err != nil {
+			return err
+		}
+		for _, dest := range values.Output {
+			switch dest {
+			case "turbinia":
+				log.Println("turbinia output is enabled, sending each copied disk to turbinia")
+				turbiniaProjectID := values.Turbinia.ProjectID
+				turbiniaTopicName := values.Turbinia.Topic
+				turbiniaZone := values.Turbinia.Zone
+				diskNames := output.DiskNames
+				if err := services.SendTurbinia(ctx, turbiniaProjectID, turbiniaTopicName, turbiniaZone, diskNames); err != nil {
+					return err
+				}
+				svcs.Logger.For(ctx).Info("sent %d disks to turbinia", len(diskNames))
+			case "analysis_vm":
+				log.Println("analysis vm output is enabled, creating an analysis instance from the snapshots")
+				ps, err := services.InitPubSub(ctx, projectID)
+				if err != nil {
+					return err
+				}
+				if _, err := createanalysisvm.Execute(ctx, &createanalysisvm.Values{
+					ProjectID:       values.AnalysisVM.ProjectID,
+					Zone:            values.AnalysisVM.Zone,
+					SourceProjectID: values.ProjectID,
+					Instance:        values.Instance,
+					Snapshots:       output.SnapshotNames,
+					MachineType:     values.AnalysisVM.MachineType,
+					Network:         values.AnalysisVM.Network,
+					Image:           values.AnalysisVM.Image,
+					Topic:           values.AnalysisVM.Topic,
+					DryRun:          values.DryRun,
+				}, &createanalysisvm.Services{
+					Host:   svcs.Host,
+					PubSub: ps,
+					Logger: svcs.Logger.For(ctx),
+				}); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	default:
+		return err
+	}
+}
+
+// CloseBucket will remove any public users from buckets found within the provided folders.
+//
+// Permissions required
+//	- roles/viewer to retrieve ancestry.
+//	- roles/storeage.admin to modify buckets.
+//
+func CloseBucket(ctx context.Context, m pubsub.Message) error {
+	ctx, cancel := withDeadline(correlate(ctx, m))
+	defer cancel()
+	var values closebucket.Values
+	switch err := json.Unmarshal(m.Data, &values); err {
+	case nil:
+		if ctx, err = resolveProject(ctx, &values.ProjectID); err != nil {
+			return err
+		}
+		r, err := closebucket.Execute(ctx, &values, &closebucket.Services{
+			Resource: svcs.Resource,
+			Logger:   svcs.Logger.For(ctx),
+		})
+		return notify(ctx, "close_bucket", values.ProjectID, m, r, err)
+	default:
+		return err
+	}
+}
+
+// CloseStagingBucket will remove public access from Dataproc and Dataflow staging buckets and their objects.
+//
+// Permissions required
+//	- roles/viewer to retrieve ancestry.
+//	- roles/storage.admin to modify buckets and object ACLs.
+//
+func CloseStagingBucket(ctx context.Context, m pubsub.Message) error {
+	ctx, cancel := withDeadline(correlate(ctx, m))
+	defer cancel()
+	var values closestagingbucket.Values
+	switch err := json.Unmarshal(m.Data, &values); err {
+	case nil:
+		if ctx, err = resolveProject(ctx, &values.ProjectID); err != nil {
+			return err
+		}
+		r, err := closestagingbucket.Execute(ctx, &values, &closestagingbucket.Services{
+			Resource: svcs.Resource,
+			Logger:   svcs.Logger.For(ctx),
+		})
+		return notify(ctx, "close_staging_bucket", values.ProjectID, m, r, err)
+	default:
+		return err
+	}
+}
+
+// OpenFirewall will remediate an open firewall.
+//
+// Permissions required
+//	- roles/viewer to retrieve ancestry.
+//	- roles/compute.securityAdmin to modify firewall rules.
+//
+func OpenFirewall(ctx context.Context, m pubsub.Message) error {
+	ctx, cancel := withDeadline(correlate(ctx, m))
+	defer cancel()
+	var values openfirewall.Values
+	switch err := json.Unmarshal(m.Data, &values); err {
+	case nil:
+		if ctx, err = resolveProject(ctx, &values.ProjectID); err != nil {
+			return err
+		}
+		r, err := openfirewall.Execute(ctx, &values, &openfirewall.Services{
+			Firewall: svcs.Firewall,
+			Resource: svcs.Resource,
+			Logger:   svcs.Logger.For(ctx),
+			State:    svcs.State,
+		})
+		return notify(ctx, "remediate_firewall", values.ProjectID, m, r, err)
+	default:
+		return err
+	}
+}
+
+// DisableUnusedFirewall disables an open firewall rule Firewall Insights found shadowed or unused.
+//
+// This Cloud Function will respond to Security Health Analytics **OPEN_FIREWALL**, **OPEN_SSH_PORT**
+// and **OPEN_RDP_PORT** findings. Rules without a shadowed or unused insight are left as they are.
+//
+// Permissions required
+//	- roles/viewer to retrieve ancestry.
+//	- roles/compute.securityAdmin to disable firewall rules.
+//	- roles/recommender.firewallAdmin to read and accept Firewall Insights.
+//
+func DisableUnusedFirewall(ctx context.Context, m pubsub.Message) error {
+	ctx, cancel := withDeadline(correlate(ctx, m))
+	defer cancel()
+	var values disableunusedfirewall.Values
+	switch err := json.Unmarshal(m.Data, &values); err {
+	case nil:
+		if ctx, err = resolveProject(ctx, &values.ProjectID); err != nil {
+			return err
+		}
+		r, err := disableunusedfirewall.Execute(ctx, &values, &disableunusedfirewall.Services{
+			Firewall: svcs.Firewall,
+			Insights: svcs.Insights,
+			Logger:   svcs.Logger.For(ctx),
+		})
+		return notify(ctx, "disable_unused_firewall", values.ProjectID, m, r, err)
+	default:
+		return err
+	}
+}
+
+// BlockEgress denies outbound traffic from a GCE instance suspected of exfiltrating data.
+//
+// This Cloud Function will respond to Event Threat Detection **Bad IP** and **Bad Domain** findings
+// and SIEM **data_exfiltration** and **compromised_instance** alerts. A highest priority egress
+// deny rule is created on each network of the instance, targeting its network tags or service
+// account.
+//
+// Permissions required
+//	- roles/viewer to retrieve ancestry and get instance data.
+//	- roles/compute.securityAdmin to create firewall rules.
+//
+func BlockEgress(ctx context.Context, m pubsub.Message) error {
+	ctx, cancel := withDeadline(correlate(ctx, m))
+	defer cancel()
+	var values blockegress.Values
+	switch err := json.Unmarshal(m.Data, &values); err {
+	case nil:
+		if ctx, err = resolveProject(ctx, &values.ProjectID); err != nil {
+			return err
+		}
+		r, err := blockegress.Execute(ctx, &values, &blockegress.Services{
+			Host:     svcs.Host,
+			Firewall: svcs.Firewall,
+			Logger:   svcs.Logger.For(ctx),
+			State:    svcs.State,
+		})
+		return notify(ctx, "block_egress", values.ProjectID, m, r, err)
+	default:
+		return err
+	}
+}
+
+// RemoveNonOrganizationMembers removes all members that do not match the organization domain.
+//
+// This Cloud Function will respond to Security Health Analytics **NON_ORG_IAM_MEMBER** findings from **IAM Scanner**.
+// All user member types (user:) that do not correspond to the organization will be removed from policy binding.
+//
+// Permissions required
+//	- roles/resourcemanager.organizationAdmin to get org info and policies and set policies.
+//
+func RemoveNonOrganizationMembers(ctx context.Context, m pubsub.Message) error {
+	ctx, cancel := withDeadline(correlate(ctx, m))
+	defer cancel()
+	var values removenonorgmembers.Values
+	switch err := json.Unmarshal(m.Data, &values); err {
+	case nil:
+		if ctx, err = resolveProject(ctx, &values.ProjectID); err != nil {
+			return err
+		}
+		r, err := removenonorgmembers.Execute(ctx, &values, &removenonorgmembers.Services{
+			Logger:   svcs.Logger.For(ctx),
+			Resource: svcs.Resource,
+		})
+		return notify(ctx, "remove_non_org_members", values.ProjectID, m, r, err)
+	default:
+		return err
+	}
+}
+
+// RemoveExternalGroupMembers removes members outside of the allowed domains from the groups granted
+// roles on a project.
+//
+// This Cloud Function will respond to Security Health Analytics **NON_ORG_IAM_MEMBER** findings and
+// SIEM external member alerts. The direct members of every group in the project's policy are read
+// with the Admin SDK and those outside of the allowed domains are removed from the group.
+//
+// Permissions required
+//	- roles/iam.securityReviewer to get the project's policy.
+//	- Domain-wide delegation of the admin.directory.group.member scope, see credentials/groups.json.
+//
+func RemoveExternalGroupMembers(ctx context.Context, m pubsub.Message) error {
+	ctx, cancel := withDeadline(correlate(ctx, m))
+	defer cancel()
+	var values removegroupmembers.Values
+	switch err := json.Unmarshal(m.Data, &values); err {
+	case nil:
+		if ctx, err = resolveProject(ctx, &values.ProjectID); err != nil {
+			return err
+		}
+		r, err := removegroupmembers.Execute(ctx, &values, &removegroupmembers.Services{
+			Logger:   svcs.Logger.For(ctx),
+			Resource: svcs.Resource,
+			Groups:   svcs.Groups,
+		})
+		return notify(ctx, "remove_external_group_members", values.ProjectID, m, r, err)
+	default:
+		return err
+	}
+}
+
+// RemovePublicIP removes all the external IP addresses of a GCE instance.
+//
+// This Cloud Function will respond to Security Health Analytics **Public IP Address** findings
+// from **Compute Instance Scanner**. All public IP addresses of the affected instance will be
+// deleted when this function is activated. If an evidence bucket is configured, forensic evidence
+// is collected from the instance before any IP is removed.
+//
+// Permissions required
+//	- roles/compute.instanceAdmin.v1 to get instance data and delete access config.
+//	- roles/logging.viewer to read VPC flow logs when collecting evidence.
+//	- roles/storage.objectCreator on the evidence bucket when collecting evidence.
+//
+func RemovePublicIP(ctx context.Context, m pubsub.Message) error {
+	ctx, cancel := withDeadline(correlate(ctx, m))
+	defer cancel()
+	var values removepublicip.Values
+	switch err := json.Unmarshal(m.Data, &values); err {
+	case nil:
+		if ctx, err = resolveProject(ctx, &values.ProjectID); err != nil {
+			return err
+		}
+		r, err := removepublicip.Execute(ctx, &values, &removepublicip.Services{
+			Host:     svcs.Host,
+			Resource: svcs.Resource,
+			Logger:   svcs.Logger.For(ctx),
+			Evidence: svcs.Evidence,
+			State:    svcs.State,
+		})
+		return notify(ctx, "remove_public_ip", values.ProjectID, m, r, err)
+	default:
+		return err
+	}
+}
+
+// DisableSerialPort disables interactive serial port access on a GCE instance.
+//
+// This Cloud Function will respond to Security Health Analytics **Compute Serial Ports Enabled**
+// findings from **Compute Instance Scanner**. The instance's `serial-port-enable` metadata is set
+// to false, overriding any project wide setting. Other metadata is kept.
+//
+// Permissions required
+//	- roles/compute.instanceAdmin.v1 to get instance data and set its metadata.
+//
+func DisableSerialPort(ctx context.Context, m pubsub.Message) error {
+	ctx, cancel := withDeadline(correlate(ctx, m))
+	defer cancel()
+	var values disableserialport.Values
 	switch err := json.Unmarshal(m.Data, &values); err {
 	case nil:
-		return revoke.Execute(ctx, &values, &revoke.Services{
-			Resource: svcs.Resource,
-			Logger:   svcs.Logger,
+		if ctx, err = resolveProject(ctx, &values.ProjectID); err != nil {
+			return err
+		}
+		r, err := disableserialport.Execute(ctx, &values, &disableserialport.Services{
+			Host:   svcs.Host,
+			Logger: svcs.Logger.For(ctx),
 		})
+		return notify(ctx, "disable_serial_port", values.ProjectID, m, r, err)
 	default:
 		return err
 	}
 }
 
-// SnapshotDisk is the entry point for the auto creation of GCE snapshots Cloud Function.
+// HardenInstance disables the legacy metadata endpoints and enables Shielded VM on a GCE instance.
 //
-// Once a supported finding is received this Cloud Function will look for any existing disk snapshots
-// for the affected instance. If there are recent snapshots then no action is taken. This is so we
-// do not overwrite a recent snapshot. If we have not taken a snapshot recently, take a new snapshot
-// for each disk within the instance.
+// This Cloud Function will respond to Security Health Analytics **Legacy Metadata Enabled** and
+// **Shielded VM Disabled** findings from **Compute Instance Scanner**. The instance's
+// `disable-legacy-endpoints` metadata is set to true, or secure boot, vTPM and integrity monitoring
+// are enabled if its boot image supports them. A running instance is only restarted if allowed.
 //
 // Permissions required
-//	- roles/compute.instanceAdmin.v1 to manage disk snapshots.
+//	- roles/compute.instanceAdmin.v1 to get instance data, set its metadata and Shielded VM options.
 //
-func SnapshotDisk(ctx context.Context, m pubsub.Message) error {
-	var values createsnapshot.Values
+func HardenInstance(ctx context.Context, m pubsub.Message) error {
+	ctx, cancel := withDeadline(correlate(ctx, m))
+	defer cancel()
+	var values hardeninstance.Values
 	switch err := json.Unmarshal(m.Data, &values); err {
 	case nil:
-		output, err := createsnapshot.Execute(ctx, &values, &createsnapshot.Services{
-			Host:   svcs.Host,
-			Logger: svcs.Logger,
-		})
-		if err != nil {
+		if ctx, err = resolveProject(ctx, &values.ProjectID); err != nil {
 			return err
 		}
-		for _, dest := range values.Output {
-			switch dest {
-			case "turbinia":
-				log.Println("turbinia output is enabled, sending each copied disk to turbinia")
-				turbiniaProjectID := values.Turbinia.ProjectID
-				turbiniaTopicName := values.Turbinia.Topic
-				turbiniaZone := values.Turbinia.Zone
-				diskNames := output.DiskNames
-				if err := services.SendTurbinia(ctx, turbiniaProjectID, turbiniaTopicName, turbiniaZone, diskNames); err != nil {
-					return err
-				}
-				svcs.Logger.Info("sent %d disks to turbinia", len(diskNames))
-			}
-		}
-		return nil
+		r, err := hardeninstance.Execute(ctx, &values, &hardeninstance.Services{
+			Host:   svcs.Host,
+			Logger: svcs.Logger.For(ctx),
+		})
+		return notify(ctx, "harden_instance", values.ProjectID, m, r, err)
 	default:
 		return err
 	}
 }
 
-// CloseBucket will remove any public users from buckets found within the provided folders.
+// DisableIPForwarding disables IP forwarding on a GCE instance.
+//
+// This Cloud Function will respond to Security Health Analytics **IP Forwarding Enabled** findings
+// from **Compute Instance Scanner**. The instance will no longer send or receive packets for
+// addresses other than its own. Since this only applies at boot, a running instance is restarted.
 //
 // Permissions required
-//	- roles/viewer to retrieve ancestry.
-//	- roles/storeage.admin to modify buckets.
+//	- roles/compute.instanceAdmin.v1 to get and update the instance.
 //
-func CloseBucket(ctx context.Context, m pubsub.Message) error {
-	var values closebucket.Values
+func DisableIPForwarding(ctx context.Context, m pubsub.Message) error {
+	ctx, cancel := withDeadline(correlate(ctx, m))
+	defer cancel()
+	var values disableipforwarding.Values
 	switch err := json.Unmarshal(m.Data, &values); err {
 	case nil:
-		return closebucket.Execute(ctx, &values, &closebucket.Services{
-			Resource: svcs.Resource,
-			Logger:   svcs.Logger,
+		if ctx, err = resolveProject(ctx, &values.ProjectID); err != nil {
+			return err
+		}
+		r, err := disableipforwarding.Execute(ctx, &values, &disableipforwarding.Services{
+			Host:   svcs.Host,
+			Logger: svcs.Logger.For(ctx),
 		})
+		return notify(ctx, "disable_ip_forwarding", values.ProjectID, m, r, err)
 	default:
 		return err
 	}
 }
 
-// OpenFirewall will remediate an open firewall.
+// EnforceHTTPS requires modern TLS on external load balancers and optionally redirects HTTP to HTTPS.
+//
+// This Cloud Function will respond to Security Health Analytics **Weak SSL Policy** and **HTTP
+// Load Balancer** findings from **Compute Instance Scanner**. Target HTTPS and SSL proxies get the
+// configured SSL policy, created with the MODERN profile and a minimum of TLS 1.2 if it doesn't
+// exist. If enabled, the URL map of target HTTP proxies is replaced by one redirecting to HTTPS.
 //
 // Permissions required
-//	- roles/viewer to retrieve ancestry.
-//	- roles/compute.securityAdmin to modify firewall rules.
+//	- roles/compute.loadBalancerAdmin to manage SSL policies, URL maps and target proxies.
 //
-func OpenFirewall(ctx context.Context, m pubsub.Message) error {
-	var values openfirewall.Values
+func EnforceHTTPS(ctx context.Context, m pubsub.Message) error {
+	ctx, cancel := withDeadline(correlate(ctx, m))
+	defer cancel()
+	var values enforcehttps.Values
 	switch err := json.Unmarshal(m.Data, &values); err {
 	case nil:
-		err := openfirewall.Execute(ctx, &values, &openfirewall.Services{
-			Firewall: svcs.Firewall,
-			Resource: svcs.Resource,
-			Logger:   svcs.Logger,
-		})
-		if err != nil {
+		if ctx, err = resolveProject(ctx, &values.ProjectID); err != nil {
 			return err
 		}
-		return nil
+		r, err := enforcehttps.Execute(ctx, &values, &enforcehttps.Services{
+			LoadBalancer: svcs.LoadBalancer,
+			Logger:       svcs.Logger.For(ctx),
+		})
+		return notify(ctx, "enforce_https", values.ProjectID, m, r, err)
 	default:
 		return err
 	}
 }
 
-// RemoveNonOrganizationMembers removes all members that do not match the organization domain.
+// ClosePublicDataset removes public access of a BigQuery dataset.
 //
-// This Cloud Function will respond to Security Health Analytics **NON_ORG_IAM_MEMBER** findings from **IAM Scanner**.
-// All user member types (user:) that do not correspond to the organization will be removed from policy binding.
+// This Cloud Function will respond to Security Health Analytics **Public Dataset** findings
+// from **Dataset Scanner**. All public access of the affected dataset will be
+// removed when this function is activated.
 //
 // Permissions required
-//	- roles/resourcemanager.organizationAdmin to get org info and policies and set policies.
+//	- roles/bigquery.dataOwner to get and update dataset metadata.
 //
-func RemoveNonOrganizationMembers(ctx context.Context, m pubsub.Message) error {
-	var values removenonorgmembers.Values
+func ClosePublicDataset(ctx context.Context, m pubsub.Message) error {
+	ctx, cancel := withDeadline(correlate(ctx, m))
+	defer cancel()
+	var values closepublicdataset.Values
 	switch err := json.Unmarshal(m.Data, &values); err {
 	case nil:
-		return removenonorgmembers.Execute(ctx, &values, &removenonorgmembers.Services{
-			Logger:   svcs.Logger,
-			Resource: svcs.Resource,
+		if ctx, err = resolveProject(ctx, &values.ProjectID); err != nil {
+			return err
+		}
+		bigquery, err := services.InitBigQuery(ctx, values.ProjectID)
+		if err != nil {
+			return err
+		}
+		r, err := closepublicdataset.Execute(ctx, &values, &closepublicdataset.Services{
+			BigQuery: bigquery,
+			Logger:   svcs.Logger.For(ctx),
 		})
+		return notify(ctx, "close_public_dataset", values.ProjectID, m, r, err)
 	default:
 		return err
 	}
 }
 
-// RemovePublicIP removes all the external IP addresses of a GCE instance.
+// RestrictSensitiveData removes broad access to a bucket or dataset holding sensitive data.
 //
-// This Cloud Function will respond to Security Health Analytics **Public IP Address** findings
-// from **Compute Instance Scanner**. All public IP addresses of the affected instance will be
-// deleted when this function is activated.
+// This Cloud Function will respond to Cloud DLP findings of sensitive data sent by Security Command
+// Center. Grants to allUsers, allAuthenticatedUsers and whole domains are removed from the affected
+// bucket or dataset, which is then labeled dlp-restricted. The owners of the project holding the
+// data are emailed a summary along with the recipients configured for the automation.
 //
 // Permissions required
-//	- roles/compute.instanceAdmin.v1 to get instance data and delete access config.
+//	- roles/viewer to retrieve ancestry and the owners of the project.
+//	- roles/storage.admin to update the bucket's policy and labels.
+//	- roles/bigquery.dataOwner to update the dataset's access and labels.
 //
-func RemovePublicIP(ctx context.Context, m pubsub.Message) error {
-	var values removepublicip.Values
+func RestrictSensitiveData(ctx context.Context, m pubsub.Message) error {
+	ctx, cancel := withDeadline(correlate(ctx, m))
+	defer cancel()
+	var values restrictsensitivedata.Values
 	switch err := json.Unmarshal(m.Data, &values); err {
 	case nil:
-		return removepublicip.Execute(ctx, &values, &removepublicip.Services{
-			Host:     svcs.Host,
+		if ctx, err = resolveProject(ctx, &values.ProjectID); err != nil {
+			return err
+		}
+		rs := &restrictsensitivedata.Services{
 			Resource: svcs.Resource,
-			Logger:   svcs.Logger,
-		})
+			Logger:   svcs.Logger.For(ctx),
+		}
+		if values.DatasetID != "" {
+			bigquery, err := services.InitBigQuery(ctx, values.ProjectID)
+			if err != nil {
+				return err
+			}
+			rs.BigQuery = bigquery
+		}
+		r, err := restrictsensitivedata.Execute(ctx, &values, rs)
+		notifyDataOwners(ctx, &m, values.ProjectID)
+		return notify(ctx, "restrict_sensitive_data", values.ProjectID, m, r, err)
 	default:
 		return err
 	}
 }
 
-// ClosePublicDataset removes public access of a BigQuery dataset.
+// EnforceCMEK applies a customer-managed Cloud KMS key to a resource using a Google-managed key.
 //
-// This Cloud Function will respond to Security Health Analytics **Public Dataset** findings
-// from **Dataset Scanner**. All public access of the affected dataset will be
-// removed when this function is activated.
+// This Cloud Function will respond to Security Health Analytics **Bucket CMEK Disabled**, **Dataset
+// CMEK Disabled**, **BigQuery Table CMEK Disabled** and **Disk CMEK Disabled** findings. The
+// configured key becomes the default key of the bucket or dataset, or re-encrypts the table. Disks
+// can't change their key in place so a ticket is published to the topic in the TICKET_TOPIC
+// environment variable instead.
 //
 // Permissions required
-//	- roles/bigquery.dataOwner to get and update dataset metadata.
+//	- roles/viewer to retrieve ancestry and the disk's details.
+//	- roles/storage.admin to get and update the bucket's encryption.
+//	- roles/bigquery.dataOwner to get and update the dataset's and table's encryption.
 //
-func ClosePublicDataset(ctx context.Context, m pubsub.Message) error {
-	var values closepublicdataset.Values
+func EnforceCMEK(ctx context.Context, m pubsub.Message) error {
+	ctx, cancel := withDeadline(correlate(ctx, m))
+	defer cancel()
+	var values enforcecmek.Values
 	switch err := json.Unmarshal(m.Data, &values); err {
 	case nil:
-		bigquery, err := services.InitBigQuery(ctx, values.ProjectID)
+		if ctx, err = resolveProject(ctx, &values.ProjectID); err != nil {
+			return err
+		}
+		ps, err := services.InitPubSub(ctx, projectID)
 		if err != nil {
 			return err
 		}
-		return closepublicdataset.Execute(ctx, &values, &closepublicdataset.Services{
-			BigQuery: bigquery,
-			Logger:   svcs.Logger,
-		})
+		es := &enforcecmek.Services{
+			Resource: svcs.Resource,
+			Tickets:  services.NewTickets(ps, os.Getenv("TICKET_TOPIC")),
+			Logger:   svcs.Logger.For(ctx),
+		}
+		if values.DatasetID != "" {
+			bigquery, err := services.InitBigQuery(ctx, values.ProjectID)
+			if err != nil {
+				return err
+			}
+			es.BigQuery = bigquery
+		}
+		r, err := enforcecmek.Execute(ctx, &values, es)
+		return notify(ctx, "enforce_cmek", values.ProjectID, m, r, err)
 	default:
 		return err
 	}
 }
 
+// notifyDataOwners adds the owners of the project holding the data to the recipients the summary of
+// the automation is emailed to, if an email sender is configured.
+func notifyDataOwners(ctx context.Context, m *pubsub.Message, projectID string) {
+	if svcs.EmailNotifier == nil {
+		return
+	}
+	owners, err := svcs.Resource.ProjectOwners(ctx, projectID)
+	if err != nil {
+		svcs.Logger.For(ctx).Error("failed to get owners of project %q: %q", projectID, err)
+		return
+	}
+	if len(owners) == 0 {
+		return
+	}
+	attrs := map[string]string{}
+	for k, v := range m.Attributes {
+		attrs[k] = v
+	}
+	var to []string
+	if existing := attrs[router.EmailAttribute]; existing != "" {
+		to = strings.Split(existing, ",")
+	}
+	seen := map[string]bool{}
+	for _, r := range to {
+		seen[r] = true
+	}
+	for _, o := range owners {
+		if !seen[o] {
+			to = append(to, o)
+		}
+	}
+	attrs[router.EmailAttribute] = strings.Join(to, ",")
+	m.Attributes = attrs
+}
+
 // EnableBucketOnlyPolicy Enable bucket only policy on a GCS bucket.
 //
 // This Cloud Function will respond to Security Health Analytics **BUCKET_POLICY_ONLY_DISABLED** findings
@@ -269,13 +1408,48 @@ func ClosePublicDataset(ctx context.Context, m pubsub.Message) error {
 //	- roles/storage.admin to change the Bucket policy mode.
 //
 func EnableBucketOnlyPolicy(ctx context.Context, m pubsub.Message) error {
+	ctx, cancel := withDeadline(correlate(ctx, m))
+	defer cancel()
 	var values enablebucketonlypolicy.Values
 	switch err := json.Unmarshal(m.Data, &values); err {
 	case nil:
-		return enablebucketonlypolicy.Execute(ctx, &values, &enablebucketonlypolicy.Services{
+		if ctx, err = resolveProject(ctx, &values.ProjectID); err != nil {
+			return err
+		}
+		r, err := enablebucketonlypolicy.Execute(ctx, &values, &enablebucketonlypolicy.Services{
+			Resource: svcs.Resource,
+			Logger:   svcs.Logger.For(ctx),
+		})
+		return notify(ctx, "enable_bucket_only_policy", values.ProjectID, m, r, err)
+	default:
+		return err
+	}
+}
+
+// EnableBucketLogging enables access logging and object versioning on a GCS bucket.
+//
+// This Cloud Function will respond to Security Health Analytics **BUCKET_LOGGING_DISABLED** and
+// **OBJECT_VERSIONING_DISABLED** findings from **STORAGE_SCANNER**. Access logs will be written to
+// the configured log bucket and object versioning will be enabled if configured, or always for
+// versioning findings.
+//
+// Permissions required
+//	- roles/storage.admin to update the bucket configuration.
+//
+func EnableBucketLogging(ctx context.Context, m pubsub.Message) error {
+	ctx, cancel := withDeadline(correlate(ctx, m))
+	defer cancel()
+	var values enablebucketlogging.Values
+	switch err := json.Unmarshal(m.Data, &values); err {
+	case nil:
+		if ctx, err = resolveProject(ctx, &values.ProjectID); err != nil {
+			return err
+		}
+		r, err := enablebucketlogging.Execute(ctx, &values, &enablebucketlogging.Services{
 			Resource: svcs.Resource,
-			Logger:   svcs.Logger,
+			Logger:   svcs.Logger.For(ctx),
 		})
+		return notify(ctx, "enable_bucket_logging", values.ProjectID, m, r, err)
 	default:
 		return err
 	}
@@ -291,14 +1465,20 @@ func EnableBucketOnlyPolicy(ctx context.Context, m pubsub.Message) error {
 //	- roles/cloudsql.editor to get instance data and delete access config.
 //
 func CloseCloudSQL(ctx context.Context, m pubsub.Message) error {
+	ctx, cancel := withDeadline(correlate(ctx, m))
+	defer cancel()
 	var values removepublic.Values
 	switch err := json.Unmarshal(m.Data, &values); err {
 	case nil:
-		return removepublic.Execute(ctx, &values, &removepublic.Services{
+		if ctx, err = resolveProject(ctx, &values.ProjectID); err != nil {
+			return err
+		}
+		r, err := removepublic.Execute(ctx, &values, &removepublic.Services{
 			CloudSQL: svcs.CloudSQL,
 			Resource: svcs.Resource,
-			Logger:   svcs.Logger,
+			Logger:   svcs.Logger.For(ctx),
 		})
+		return notify(ctx, "close_cloud_sql", values.ProjectID, m, r, err)
 	default:
 		return err
 	}
@@ -314,14 +1494,21 @@ func CloseCloudSQL(ctx context.Context, m pubsub.Message) error {
 //	- roles/cloudsql.editor to get instance data and delete access config.
 //
 func CloudSQLRequireSSL(ctx context.Context, m pubsub.Message) error {
+	ctx, cancel := withDeadline(correlate(ctx, m))
+	defer cancel()
 	var values requiressl.Values
 	switch err := json.Unmarshal(m.Data, &values); err {
 	case nil:
-		return requiressl.Execute(ctx, &values, &requiressl.Services{
+		if ctx, err = resolveProject(ctx, &values.ProjectID); err != nil {
+			return err
+		}
+		r, err := requiressl.Execute(ctx, &values, &requiressl.Services{
 			CloudSQL: svcs.CloudSQL,
 			Resource: svcs.Resource,
-			Logger:   svcs.Logger,
+			Logger:   svcs.Logger.For(ctx),
+			State:    svcs.State,
 		})
+		return notify(ctx, "cloud_sql_require_ssl", values.ProjectID, m, r, err)
 	default:
 		return err
 	}
@@ -337,14 +1524,50 @@ func CloudSQLRequireSSL(ctx context.Context, m pubsub.Message) error {
 //	- roles/container.clusterAdmin update cluster addon.
 //
 func DisableDashboard(ctx context.Context, m pubsub.Message) error {
+	ctx, cancel := withDeadline(correlate(ctx, m))
+	defer cancel()
 	var values disabledashboard.Values
 	switch err := json.Unmarshal(m.Data, &values); err {
 	case nil:
-		return disabledashboard.Execute(ctx, &values, &disabledashboard.Services{
+		if ctx, err = resolveProject(ctx, &values.ProjectID); err != nil {
+			return err
+		}
+		r, err := disabledashboard.Execute(ctx, &values, &disabledashboard.Services{
+			Container: svcs.Container,
+			Resource:  svcs.Resource,
+			Logger:    svcs.Logger.For(ctx),
+		})
+		return notify(ctx, "disable_dashboard", values.ProjectID, m, r, err)
+	default:
+		return err
+	}
+}
+
+// HardenClusterNetwork enforces network policy and master authorized networks on a GKE cluster.
+//
+// This Cloud Function will respond to Security Health Analytics **Network Policy Disabled** and
+// **Private Cluster Disabled** findings from **Container Scanner**. Network policy is enforced with
+// Calico, which recreates the cluster's node pools, and access to the control plane is restricted
+// to the configured authorized networks. Private nodes can't be enabled on an existing cluster.
+//
+// Permissions required
+//	- roles/container.clusterAdmin update the cluster and its addons.
+//
+func HardenClusterNetwork(ctx context.Context, m pubsub.Message) error {
+	ctx, cancel := withDeadline(correlate(ctx, m))
+	defer cancel()
+	var values hardenclusternetwork.Values
+	switch err := json.Unmarshal(m.Data, &values); err {
+	case nil:
+		if ctx, err = resolveProject(ctx, &values.ProjectID); err != nil {
+			return err
+		}
+		r, err := hardenclusternetwork.Execute(ctx, &values, &hardenclusternetwork.Services{
 			Container: svcs.Container,
 			Resource:  svcs.Resource,
-			Logger:    svcs.Logger,
+			Logger:    svcs.Logger.For(ctx),
 		})
+		return notify(ctx, "harden_cluster_network", values.ProjectID, m, r, err)
 	default:
 		return err
 	}
@@ -360,13 +1583,91 @@ func DisableDashboard(ctx context.Context, m pubsub.Message) error {
 //	- roles/editor to get/update resource policy to specific project.
 //
 func EnableAuditLogs(ctx context.Context, m pubsub.Message) error {
+	ctx, cancel := withDeadline(correlate(ctx, m))
+	defer cancel()
 	var values enableauditlogs.Values
 	switch err := json.Unmarshal(m.Data, &values); err {
 	case nil:
-		return enableauditlogs.Execute(ctx, &values, &enableauditlogs.Services{
+		if ctx, err = resolveProject(ctx, &values.ProjectID); err != nil {
+			return err
+		}
+		r, err := enableauditlogs.Execute(ctx, &values, &enableauditlogs.Services{
+			Resource: svcs.Resource,
+			Logger:   svcs.Logger.For(ctx),
+		})
+		return notify(ctx, "enable_audit_logs", values.ProjectID, m, r, err)
+	default:
+		return err
+	}
+}
+
+// EnableBackups enables automated backups on a Cloud SQL instance.
+//
+// This Cloud Function will respond to Security Health Analytics **SQL Auto Backup Disabled** findings
+// from **SQL Scanner**. Automated backups, and binary logging for MySQL instances so they can be
+// restored to a point in time, will be enabled when this function is activated.
+//
+// Permissions required
+//	- roles/cloudsql.admin to update the instance backup configuration.
+//
+func EnableBackups(ctx context.Context, m pubsub.Message) error {
+	ctx, cancel := withDeadline(correlate(ctx, m))
+	defer cancel()
+	var values enablebackups.Values
+	switch err := json.Unmarshal(m.Data, &values); err {
+	case nil:
+		if ctx, err = resolveProject(ctx, &values.ProjectID); err != nil {
+			return err
+		}
+		r, err := enablebackups.Execute(ctx, &values, &enablebackups.Services{
+			CloudSQL: svcs.CloudSQL,
 			Resource: svcs.Resource,
-			Logger:   svcs.Logger,
+			Logger:   svcs.Logger.For(ctx),
+			State:    svcs.State,
+		})
+		return notify(ctx, "cloud_sql_enable_backups", values.ProjectID, m, r, err)
+	default:
+		return err
+	}
+}
+
+// SecureRoot secures the root users of a Cloud SQL instance that have no password.
+//
+// This Cloud Function will respond to Security Health Analytics **SQL No Root Password** findings
+// from **SQL Scanner**. Depending on the configured mode the root users will either have a generated
+// password set, which is first stored in Secret Manager, or root users accepting connections from
+// any host will be deleted. The project owners are notified on the configured topic.
+//
+// Permissions required
+//	- roles/cloudsql.admin to list, update and delete users.
+//	- roles/secretmanager.admin to store the generated password.
+//	- roles/iam.securityReviewer to look up the project owners.
+//
+func SecureRoot(ctx context.Context, m pubsub.Message) error {
+	ctx, cancel := withDeadline(correlate(ctx, m))
+	defer cancel()
+	var values secureroot.Values
+	switch err := json.Unmarshal(m.Data, &values); err {
+	case nil:
+		if ctx, err = resolveProject(ctx, &values.ProjectID); err != nil {
+			return err
+		}
+		sm, err := services.InitSecretManager(ctx)
+		if err != nil {
+			return err
+		}
+		ps, err := services.InitPubSub(ctx, projectID)
+		if err != nil {
+			return err
+		}
+		r, err := secureroot.Execute(ctx, &values, &secureroot.Services{
+			CloudSQL:      svcs.CloudSQL,
+			SecretManager: sm,
+			PubSub:        ps,
+			Resource:      svcs.Resource,
+			Logger:        svcs.Logger.For(ctx),
 		})
+		return notify(ctx, "cloud_sql_secure_root", values.ProjectID, m, r, err)
 	default:
 		return err
 	}
@@ -382,15 +1683,160 @@ func EnableAuditLogs(ctx context.Context, m pubsub.Message) error {
 //	- roles/cloudsql.admin to update a user password.
 //
 func UpdatePassword(ctx context.Context, m pubsub.Message) error {
+	ctx, cancel := withDeadline(correlate(ctx, m))
+	defer cancel()
 	var values updatepassword.Values
 	switch err := json.Unmarshal(m.Data, &values); err {
 	case nil:
-		return updatepassword.Execute(ctx, &values, &updatepassword.Services{
+		if ctx, err = resolveProject(ctx, &values.ProjectID); err != nil {
+			return err
+		}
+		r, err := updatepassword.Execute(ctx, &values, &updatepassword.Services{
 			CloudSQL: svcs.CloudSQL,
 			Resource: svcs.Resource,
-			Logger:   svcs.Logger,
+			Logger:   svcs.Logger.For(ctx),
 		})
+		return notify(ctx, "cloud_sql_update_password", values.ProjectID, m, r, err)
 	default:
 		return err
 	}
 }
+
+// RestoreContainment reverts temporary containment actions once their TTL expires.
+//
+// This Cloud Function is triggered on a schedule by Cloud Scheduler. Automations run with a `ttl`
+// save the state of the resource before containing it and this function uses that state to revert
+// the firewall rules and public IPs of every containment that has expired, and to remove the
+// egress rules of instances whose egress was blocked.
+//
+// Permissions required
+//	- roles/compute.securityAdmin to restore firewall rules.
+//	- roles/compute.instanceAdmin.v1 to restore public IPs.
+//	- roles/storage.objectAdmin on the state bucket to read and remove containment records.
+//
+func RestoreContainment(ctx context.Context, m pubsub.Message) error {
+	ctx, cancel := withDeadline(correlate(ctx, m))
+	defer cancel()
+	var values restore.Values
+	if len(m.Data) > 0 {
+		if err := json.Unmarshal(m.Data, &values); err != nil {
+			return err
+		}
+	}
+	r, err := restore.Execute(ctx, &values, &restore.Services{
+		State:    svcs.State,
+		Firewall: svcs.Firewall,
+		Host:     svcs.Host,
+		Logger:   svcs.Logger.For(ctx),
+	})
+	return notify(ctx, "restore_containment", projectID, m, r, err)
+}
+
+// PollOperations checks the long running operations started by automations.
+//
+// This Cloud Function is triggered on a schedule by Cloud Scheduler. Automations that would
+// otherwise outlive their timeout start their change and record its operation in the state bucket.
+// This function audits each operation once it completes and stops polling it.
+//
+// Permissions required
+//	- roles/cloudsql.viewer to get the state of Cloud SQL operations.
+//	- roles/compute.viewer to get the state of disk snapshot operations.
+//	- roles/storage.objectAdmin on the state bucket to read and remove operation records.
+//
+func PollOperations(ctx context.Context, m pubsub.Message) error {
+	ctx, cancel := withDeadline(correlate(ctx, m))
+	defer cancel()
+	var values poll.Values
+	if len(m.Data) > 0 {
+		if err := json.Unmarshal(m.Data, &values); err != nil {
+			return err
+		}
+	}
+	r, err := poll.Execute(ctx, &values, &poll.Services{
+		State:    svcs.State,
+		CloudSQL: svcs.CloudSQL,
+		Host:     svcs.Host,
+		Logger:   svcs.Logger.For(ctx),
+	})
+	return notify(ctx, "poll_operations", projectID, m, r, err)
+}
+
+// ProcessFindingExport sends the findings exported to BigQuery since its last run to the router.
+//
+// This Cloud Function is triggered on a schedule by Cloud Scheduler. Findings exported to a
+// BigQuery table rather than delivered by a Pub/Sub notification are read in batches from the last
+// watermark, kept in the state bucket, and remediated by the same router and automations.
+//
+// Permissions required
+//	- roles/bigquery.jobUser on the automation project to run the query.
+//	- roles/bigquery.dataViewer on the dataset findings are exported to.
+//	- roles/pubsub.publisher on the router's topic.
+//	- roles/storage.objectAdmin on the state bucket to read and write the watermark.
+//
+func ProcessFindingExport(ctx context.Context, m pubsub.Message) error {
+	ctx, cancel := withDeadline(correlate(ctx, m))
+	defer cancel()
+	var values batchexport.Values
+	if len(m.Data) > 0 {
+		if err := json.Unmarshal(m.Data, &values); err != nil {
+			return err
+		}
+	}
+	bq, err := services.InitBigQuery(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	ps, err := services.InitPubSub(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	r, err := batchexport.Execute(ctx, &values, &batchexport.Services{
+		State:    svcs.State,
+		BigQuery: bq,
+		PubSub:   ps,
+		Logger:   svcs.Logger.For(ctx),
+	})
+	return notify(ctx, "process_finding_export", projectID, m, r, err)
+}
+
+// ReplayDeadLetters replays the findings the router failed to route once they're actionable again.
+//
+// This Cloud Function is triggered on a schedule by Cloud Scheduler. The router sends each finding
+// it fails to route to the dead-letter topic. This function drains the topic, validates each finding
+// against the current configuration and sends the ones an automation would now run for back to the
+// router, backing off between replays and dropping a finding after too many.
+//
+// Permissions required
+//	- roles/pubsub.subscriber on the dead-letter subscription.
+//	- roles/pubsub.publisher on the router's topic.
+//	- roles/browser and roles/resourcemanager.tagViewer to validate the targets of each finding.
+//
+func ReplayDeadLetters(ctx context.Context, m pubsub.Message) error {
+	ctx, cancel := withDeadline(correlate(ctx, m))
+	defer cancel()
+	var values replay.Values
+	if len(m.Data) > 0 {
+		if err := json.Unmarshal(m.Data, &values); err != nil {
+			return err
+		}
+	}
+	ps, err := services.InitPubSub(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	dl, err := services.InitDeadLetters(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	conf, err := router.Config()
+	if err != nil {
+		return err
+	}
+	r, err := replay.Execute(ctx, &values, &replay.Services{
+		DeadLetters: dl,
+		PubSub:      ps,
+		Logger:      svcs.Logger.For(ctx),
+		Router:      routerServices(ctx, ps, conf),
+	})
+	return notify(ctx, "replay_dead_letters", projectID, m, r, err)
+}
@@ -0,0 +1,141 @@
+//go:build integration
+// +build integration
+
+package integration
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"cloud.google.com/go/storage"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gcs/closebucket"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gcs/enablebucketonlypolicy"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/iam/revoke"
+	"github.com/googlecloudplatform/security-response-automation/services"
+)
+
+var sandbox *Sandbox
+
+func TestMain(m *testing.M) {
+	ctx := context.Background()
+	s, err := NewSandbox(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to set up sandbox: %q\n", err)
+		os.Exit(1)
+	}
+	if s == nil {
+		fmt.Println("neither SRA_INTEGRATION_PROJECT nor SRA_INTEGRATION_PARENT is set, skipping integration tests")
+		return
+	}
+	sandbox = s
+	code := m.Run()
+	s.Teardown(ctx)
+	os.Exit(code)
+}
+
+func TestRevokeExternalMember(t *testing.T) {
+	ctx := context.Background()
+	email, err := sandbox.CreateServiceAccount(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	member := "serviceAccount:" + email
+	if err := sandbox.GrantProject(ctx, "roles/viewer", member); err != nil {
+		t.Fatal(err)
+	}
+	if err := revoke.Execute(ctx, &revoke.Values{
+		ProjectID:       sandbox.ProjectID,
+		ExternalMembers: []string{member},
+		AllowDomains:    []string{"example.com"},
+	}, &revoke.Services{Resource: sandbox.Resource, Logger: sandbox.Logger}); err != nil {
+		t.Fatalf("revoke failed: %q", err)
+	}
+	viewers, err := sandbox.ProjectMembers(ctx, "roles/viewer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range viewers {
+		if v == member {
+			t.Errorf("%q still has roles/viewer on %q", member, sandbox.ProjectID)
+		}
+	}
+}
+
+func TestCloseBucket(t *testing.T) {
+	ctx := context.Background()
+	bucket, err := sandbox.CreateBucket(ctx, &storage.BucketAttrs{BucketPolicyOnly: storage.BucketPolicyOnly{Enabled: true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sandbox.GrantBucket(ctx, bucket, "roles/storage.objectViewer", "allUsers"); err != nil {
+		t.Skipf("can't make bucket public, an organization policy may prevent it: %q", err)
+	}
+	values := &closebucket.Values{ProjectID: sandbox.ProjectID, BucketName: bucket}
+	svcs := &closebucket.Services{Resource: sandbox.Resource, Logger: sandbox.Logger}
+	if err := closebucket.Execute(ctx, values, svcs); err != nil {
+		t.Fatalf("close bucket failed: %q", err)
+	}
+	viewers, err := sandbox.BucketMembers(ctx, bucket, "roles/storage.objectViewer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range viewers {
+		if v == "allUsers" {
+			t.Errorf("bucket %q is still public", bucket)
+		}
+	}
+	// Running again must find the bucket already remediated.
+	if err := closebucket.Execute(ctx, values, svcs); err != nil {
+		t.Fatalf("close bucket failed: %q", err)
+	}
+	if r := lastAudit(); r == nil || r.Result != services.AuditResultAlreadyRemediated {
+		t.Errorf("second run recorded %+v, want already remediated", r)
+	}
+}
+
+func TestEnableBucketOnlyPolicy(t *testing.T) {
+	ctx := context.Background()
+	bucket, err := sandbox.CreateBucket(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := enablebucketonlypolicy.Execute(ctx, &enablebucketonlypolicy.Values{
+		ProjectID:  sandbox.ProjectID,
+		BucketName: bucket,
+	}, &enablebucketonlypolicy.Services{Resource: sandbox.Resource, Logger: sandbox.Logger}); err != nil {
+		t.Fatalf("enable bucket only policy failed: %q", err)
+	}
+	attrs, err := sandbox.BucketAttrs(ctx, bucket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !attrs.BucketPolicyOnly.Enabled {
+		t.Errorf("bucket only policy not enabled on %q", bucket)
+	}
+}
+
+// lastAudit returns the last audit record logged by an automation.
+func lastAudit() *services.AuditRecord {
+	records := sandbox.Log.AuditRecords
+	if len(records) == 0 {
+		return nil
+	}
+	r, _ := records[len(records)-1].(*services.AuditRecord)
+	return r
+}
@@ -0,0 +1,307 @@
+//go:build integration
+// +build integration
+
+// Package integration runs automations against a real sandbox project so policy changes are
+// checked against the APIs' actual semantics rather than only the stubs.
+//
+// The tests only build with the integration tag and need either an existing project to use, which
+// is left in place, or a parent to create a throwaway project in, which is deleted afterwards:
+//
+//	SRA_INTEGRATION_PROJECT=my-sandbox go test -tags integration ./integration/...
+//	SRA_INTEGRATION_PARENT=folders/123 SRA_INTEGRATION_BILLING_ACCOUNT=billingAccounts/0000-0000-0000 \
+//		go test -tags integration ./integration/...
+//
+// SRA_INTEGRATION_CREDENTIALS is the service account key used, credentials/auth.json by default.
+// The service account needs roles/owner on the project, or roles/resourcemanager.projectCreator and
+// roles/billing.user to create one.
+package integration
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/iam"
+	"cloud.google.com/go/storage"
+	"github.com/googlecloudplatform/security-response-automation/clients"
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+	"github.com/googlecloudplatform/security-response-automation/services"
+	"github.com/pkg/errors"
+	cloudbilling "google.golang.org/api/cloudbilling/v1"
+	crm "google.golang.org/api/cloudresourcemanager/v1"
+	iamadmin "google.golang.org/api/iam/v1"
+	"google.golang.org/api/option"
+	serviceusage "google.golang.org/api/serviceusage/v1"
+)
+
+const (
+	// pollInterval is how often long running operations are checked.
+	pollInterval = 5 * time.Second
+	// pollTimeout is how long long running operations are waited for.
+	pollTimeout = 5 * time.Minute
+)
+
+// apis are enabled on created projects, the automations under test call them.
+var apis = []string{"cloudresourcemanager.googleapis.com", "iam.googleapis.com", "storage-component.googleapis.com"}
+
+// Sandbox is the project automations are run against along with the resources created in it.
+type Sandbox struct {
+	ProjectID string
+	// Resource and Logger are the services handed to the automations under test. Audit records
+	// are kept by the logger's stub so tests can check them.
+	Resource *services.Resource
+	Logger   *services.Logger
+	Log      *stubs.LoggerStub
+
+	authFile string
+	created  bool
+	crm      *crm.Service
+	iam      *iamadmin.Service
+	storage  *storage.Client
+	buckets  []string
+	accounts []string
+}
+
+// NewSandbox returns the sandbox configured by the environment, creating its project if needed. A
+// nil sandbox is returned if none is configured.
+func NewSandbox(ctx context.Context) (*Sandbox, error) {
+	projectID, parent := os.Getenv("SRA_INTEGRATION_PROJECT"), os.Getenv("SRA_INTEGRATION_PARENT")
+	if projectID == "" && parent == "" {
+		return nil, nil
+	}
+	authFile := os.Getenv("SRA_INTEGRATION_CREDENTIALS")
+	if authFile == "" {
+		authFile = "../credentials/auth.json"
+	}
+	opt := option.WithCredentialsFile(authFile)
+	s := &Sandbox{ProjectID: projectID, authFile: authFile, Log: &stubs.LoggerStub{}}
+	var err error
+	if s.crm, err = crm.NewService(ctx, opt); err != nil {
+		return nil, errors.Wrap(err, "failed to init crm")
+	}
+	if s.iam, err = iamadmin.NewService(ctx, opt); err != nil {
+		return nil, errors.Wrap(err, "failed to init iam")
+	}
+	if s.storage, err = storage.NewClient(ctx, opt); err != nil {
+		return nil, errors.Wrap(err, "failed to init storage")
+	}
+	if projectID == "" {
+		if err := s.createProject(ctx, parent, os.Getenv("SRA_INTEGRATION_BILLING_ACCOUNT")); err != nil {
+			s.Teardown(ctx)
+			return nil, err
+		}
+	}
+	crmClient, err := clients.NewCloudResourceManager(ctx, authFile)
+	if err != nil {
+		return nil, err
+	}
+	storageClient, err := clients.NewStorage(ctx, authFile)
+	if err != nil {
+		return nil, err
+	}
+	s.Resource = services.NewResource(crmClient, storageClient)
+	s.Logger = services.NewLogger(s.Log)
+	return s, nil
+}
+
+// createProject creates a throwaway project under the parent, links it to the billing account and
+// enables the APIs the automations need.
+func (s *Sandbox) createProject(ctx context.Context, parent, billingAccount string) error {
+	kind, id := "folder", strings.TrimPrefix(parent, "folders/")
+	if strings.HasPrefix(parent, "organizations/") {
+		kind, id = "organization", strings.TrimPrefix(parent, "organizations/")
+	}
+	s.ProjectID = "sra-it-" + suffix()
+	op, err := s.crm.Projects.Create(&crm.Project{
+		ProjectId: s.ProjectID,
+		Name:      "SRA integration",
+		Parent:    &crm.ResourceId{Type: kind, Id: id},
+		Labels:    map[string]string{"sra-integration": "true"},
+	}).Context(ctx).Do()
+	if err != nil {
+		return errors.Wrapf(err, "failed to create project %q", s.ProjectID)
+	}
+	s.created = true
+	if err := poll(ctx, func() (bool, error) {
+		o, err := s.crm.Operations.Get(op.Name).Context(ctx).Do()
+		if err != nil {
+			return false, err
+		}
+		if o.Error != nil {
+			return false, errors.New(o.Error.Message)
+		}
+		return o.Done, nil
+	}); err != nil {
+		return errors.Wrapf(err, "failed to create project %q", s.ProjectID)
+	}
+	if billingAccount != "" {
+		billing, err := cloudbilling.NewService(ctx, option.WithCredentialsFile(s.authFile))
+		if err != nil {
+			return errors.Wrap(err, "failed to init billing")
+		}
+		if _, err := billing.Projects.UpdateBillingInfo("projects/"+s.ProjectID, &cloudbilling.ProjectBillingInfo{BillingAccountName: billingAccount}).Context(ctx).Do(); err != nil {
+			return errors.Wrapf(err, "failed to link project %q to %q", s.ProjectID, billingAccount)
+		}
+	}
+	usage, err := serviceusage.NewService(ctx, option.WithCredentialsFile(s.authFile))
+	if err != nil {
+		return errors.Wrap(err, "failed to init service usage")
+	}
+	enable, err := usage.Services.BatchEnable("projects/"+s.ProjectID, &serviceusage.BatchEnableServicesRequest{ServiceIds: apis}).Context(ctx).Do()
+	if err != nil {
+		return errors.Wrapf(err, "failed to enable apis on %q", s.ProjectID)
+	}
+	return poll(ctx, func() (bool, error) {
+		o, err := usage.Operations.Get(enable.Name).Context(ctx).Do()
+		if err != nil {
+			return false, err
+		}
+		if o.Error != nil {
+			return false, errors.New(o.Error.Message)
+		}
+		return o.Done, nil
+	})
+}
+
+// CreateBucket creates an empty bucket in the sandbox project, deleted on teardown.
+func (s *Sandbox) CreateBucket(ctx context.Context, attrs *storage.BucketAttrs) (string, error) {
+	name := s.ProjectID + "-" + suffix()
+	if attrs == nil {
+		attrs = &storage.BucketAttrs{}
+	}
+	if err := s.storage.Bucket(name).Create(ctx, s.ProjectID, attrs); err != nil {
+		return "", errors.Wrapf(err, "failed to create bucket %q", name)
+	}
+	s.buckets = append(s.buckets, name)
+	return name, nil
+}
+
+// GrantBucket grants the member the role on the bucket.
+func (s *Sandbox) GrantBucket(ctx context.Context, bucket, role, member string) error {
+	h := s.storage.Bucket(bucket).IAM()
+	p, err := h.Policy(ctx)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get policy of bucket %q", bucket)
+	}
+	p.Add(member, iam.RoleName(role))
+	return errors.Wrapf(h.SetPolicy(ctx, p), "failed to grant %q %q on bucket %q", member, role, bucket)
+}
+
+// CreateServiceAccount creates a service account in the sandbox project, deleted on teardown, and
+// returns its email.
+func (s *Sandbox) CreateServiceAccount(ctx context.Context) (string, error) {
+	sa, err := s.iam.Projects.ServiceAccounts.Create("projects/"+s.ProjectID, &iamadmin.CreateServiceAccountRequest{
+		AccountId:      "sra-it-" + suffix(),
+		ServiceAccount: &iamadmin.ServiceAccount{DisplayName: "SRA integration"},
+	}).Context(ctx).Do()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create service account")
+	}
+	s.accounts = append(s.accounts, sa.Name)
+	return sa.Email, nil
+}
+
+// GrantProject grants the member the role on the sandbox project.
+func (s *Sandbox) GrantProject(ctx context.Context, role, member string) error {
+	p, err := s.crm.Projects.GetIamPolicy(s.ProjectID, &crm.GetIamPolicyRequest{}).Context(ctx).Do()
+	if err != nil {
+		return errors.Wrapf(err, "failed to get policy of %q", s.ProjectID)
+	}
+	p.Bindings = append(p.Bindings, &crm.Binding{Role: role, Members: []string{member}})
+	_, err = s.crm.Projects.SetIamPolicy(s.ProjectID, &crm.SetIamPolicyRequest{Policy: p}).Context(ctx).Do()
+	return errors.Wrapf(err, "failed to grant %q %q on %q", member, role, s.ProjectID)
+}
+
+// ProjectMembers returns the members granted the role on the sandbox project.
+func (s *Sandbox) ProjectMembers(ctx context.Context, role string) ([]string, error) {
+	p, err := s.crm.Projects.GetIamPolicy(s.ProjectID, &crm.GetIamPolicyRequest{}).Context(ctx).Do()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get policy of %q", s.ProjectID)
+	}
+	members := []string{}
+	for _, b := range p.Bindings {
+		if b.Role == role {
+			members = append(members, b.Members...)
+		}
+	}
+	return members, nil
+}
+
+// BucketMembers returns the members granted the role on the bucket.
+func (s *Sandbox) BucketMembers(ctx context.Context, bucket, role string) ([]string, error) {
+	p, err := s.storage.Bucket(bucket).IAM().Policy(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get policy of bucket %q", bucket)
+	}
+	return p.Members(iam.RoleName(role)), nil
+}
+
+// BucketAttrs returns the bucket's attributes.
+func (s *Sandbox) BucketAttrs(ctx context.Context, bucket string) (*storage.BucketAttrs, error) {
+	return s.storage.Bucket(bucket).Attrs(ctx)
+}
+
+// Teardown deletes the resources created in the sandbox and the project if it was created. Failures
+// are printed rather than returned so every resource is attempted.
+func (s *Sandbox) Teardown(ctx context.Context) {
+	for _, b := range s.buckets {
+		if err := s.storage.Bucket(b).Delete(ctx); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to delete bucket %q: %q\n", b, err)
+		}
+	}
+	for _, a := range s.accounts {
+		if _, err := s.iam.Projects.ServiceAccounts.Delete(a).Context(ctx).Do(); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to delete service account %q: %q\n", a, err)
+		}
+	}
+	if s.created {
+		if _, err := s.crm.Projects.Delete(s.ProjectID).Context(ctx).Do(); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to delete project %q: %q\n", s.ProjectID, err)
+		}
+	}
+}
+
+// poll calls done until it reports the operation is done or fails.
+func poll(ctx context.Context, done func() (bool, error)) error {
+	deadline := time.Now().Add(pollTimeout)
+	for time.Now().Before(deadline) {
+		ok, err := done()
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+	return errors.New("timed out waiting for operation")
+}
+
+// suffix returns a random suffix keeping names of concurrent runs apart.
+func suffix() string {
+	b := make([]byte, 3)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
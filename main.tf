@@ -31,12 +31,64 @@ module "google-setup" {
   findings-project                = var.findings-project
   cscc-notifications-topic-prefix = local.cscc-findings-topic
   findings-topic                  = local.findings-topic
+  webhook-url                     = var.webhook-url
+  webhook-secret                  = var.webhook-secret
+  sendgrid-api-key                = var.sendgrid-api-key
+  notification-email-from         = var.notification-email-from
+  smtp-host                       = var.smtp-host
+  smtp-port                       = var.smtp-port
+  smtp-username                   = var.smtp-username
+  smtp-password                   = var.smtp-password
+  rate-limits                     = var.rate-limits
+  virustotal-api-key              = var.virustotal-api-key
+  groups-admin-email              = var.groups-admin-email
+  notification-channels           = var.notification-channels
+  message-templates-bucket        = var.message-templates-bucket
+  message-templates-prefix        = var.message-templates-prefix
+  runbooks                        = var.runbooks
+  forensics-bucket                = var.forensics-bucket
+  bundle-signing-key-version      = var.bundle-signing-key-version
+  evidence-bucket                 = var.evidence-bucket
+  impersonation-scopes            = var.impersonation-scopes
 }
 
 module "router" {
-  source     = "./cloudfunctions/router/"
-  setup      = module.google-setup
-  folder-ids = var.folder-ids
+  source        = "./cloudfunctions/router/"
+  setup         = module.google-setup
+  folder-ids    = var.folder-ids
+  max-instances = var.router-max-instances
+}
+
+module "siem_adapter" {
+  source             = "./cloudfunctions/siem/adapter"
+  setup              = module.google-setup
+  siem-adapter-token = var.siem-adapter-token
+  folder-ids         = var.folder-ids
+}
+
+module "export_incident_bundle" {
+  source              = "./cloudfunctions/forensics/exportbundle"
+  setup               = module.google-setup
+  forensics-bucket    = var.forensics-bucket
+  evidence-bucket     = var.evidence-bucket
+  signing-key-version = var.bundle-signing-key-version
+}
+
+module "approve" {
+  source    = "./cloudfunctions/approvals/approve"
+  setup     = module.google-setup
+  approvers = var.approvers
+}
+
+module "process_finding_export" {
+  source         = "./cloudfunctions/findings/batchexport"
+  setup          = module.google-setup
+  findings-table = var.findings-export-table
+}
+
+module "replay_dead_letters" {
+  source = "./cloudfunctions/findings/replay"
+  setup  = module.google-setup
 }
 
 module "close_public_bucket" {
@@ -45,12 +97,58 @@ module "close_public_bucket" {
   folder-ids = var.folder-ids
 }
 
+module "close_staging_bucket" {
+  source     = "./cloudfunctions/gcs/closestagingbucket"
+  setup      = module.google-setup
+  folder-ids = var.folder-ids
+}
+
 module "revoke_iam_grants" {
   source     = "./cloudfunctions/iam/revoke"
   setup      = module.google-setup
   folder-ids = var.folder-ids
 }
 
+module "revoke_grants_by_principal" {
+  source     = "./cloudfunctions/iam/revokegrants"
+  setup      = module.google-setup
+  folder-ids = var.folder-ids
+}
+
+module "quarantine_service_account" {
+  source          = "./cloudfunctions/iam/quarantineserviceaccount"
+  setup           = module.google-setup
+  organization-id = var.organization-id
+  folder-ids      = var.folder-ids
+}
+
+module "revoke_org_iam_grants" {
+  source          = "./cloudfunctions/iam/revokeorgmembers"
+  setup           = module.google-setup
+  organization-id = var.organization-id
+  folder-ids      = var.folder-ids
+  approvers       = var.approvers
+}
+
+module "restore_containment" {
+  source     = "./cloudfunctions/containment/restore"
+  setup      = module.google-setup
+  folder-ids = var.folder-ids
+}
+
+module "poll_operations" {
+  source     = "./cloudfunctions/operations/poll"
+  setup      = module.google-setup
+  folder-ids = var.folder-ids
+}
+
+module "remove_load_balancer" {
+  source     = "./cloudfunctions/gce/removeloadbalancer"
+  setup      = module.google-setup
+  folder-ids = var.folder-ids
+  approvers  = var.approvers
+}
+
 module "create_disk_snapshot" {
   source              = "./cloudfunctions/gce/createsnapshot"
   setup               = module.google-setup
@@ -65,24 +163,92 @@ module "enable_bucket_only_policy" {
   folder-ids = var.folder-ids
 }
 
+module "enable_bucket_logging" {
+  source     = "./cloudfunctions/gcs/enablebucketlogging"
+  setup      = module.google-setup
+  folder-ids = var.folder-ids
+}
+
 module "open_firewall" {
   source     = "./cloudfunctions/gce/openfirewall"
   setup      = module.google-setup
   folder-ids = var.folder-ids
 }
 
+module "disable_unused_firewall" {
+  source     = "./cloudfunctions/gce/disableunusedfirewall"
+  setup      = module.google-setup
+  folder-ids = var.folder-ids
+}
+
+module "block_egress" {
+  source     = "./cloudfunctions/gce/blockegress"
+  setup      = module.google-setup
+  folder-ids = var.folder-ids
+}
+
 module "remove_public_ip" {
   source     = "./cloudfunctions/gce/removepublicip"
   setup      = module.google-setup
   folder-ids = var.folder-ids
 }
 
+module "disable_serial_port" {
+  source     = "./cloudfunctions/gce/disableserialport"
+  setup      = module.google-setup
+  folder-ids = var.folder-ids
+}
+
+module "harden_instance" {
+  source     = "./cloudfunctions/gce/hardeninstance"
+  setup      = module.google-setup
+  folder-ids = var.folder-ids
+}
+
+module "disable_ip_forwarding" {
+  source     = "./cloudfunctions/gce/disableipforwarding"
+  setup      = module.google-setup
+  folder-ids = var.folder-ids
+}
+
+module "enforce_https" {
+  source     = "./cloudfunctions/gce/enforcehttps"
+  setup      = module.google-setup
+  folder-ids = var.folder-ids
+}
+
 module "close_public_dataset" {
   source     = "./cloudfunctions/bigquery/closepublicdataset"
   setup      = module.google-setup
   folder-ids = var.folder-ids
 }
 
+module "restrict_sensitive_data" {
+  source     = "./cloudfunctions/dlp/restrictsensitivedata"
+  setup      = module.google-setup
+  folder-ids = var.folder-ids
+}
+
+module "enforce_cmek" {
+  source     = "./cloudfunctions/kms/enforcecmek"
+  setup      = module.google-setup
+  folder-ids = var.folder-ids
+}
+
+module "disable_key_version" {
+  source     = "./cloudfunctions/kms/disablekeyversion"
+  setup      = module.google-setup
+  folder-ids = var.folder-ids
+  approvers  = var.approvers
+}
+
+module "lockdown_project" {
+  source     = "./cloudfunctions/containment/lockdownproject"
+  setup      = module.google-setup
+  folder-ids = var.folder-ids
+  approvers  = var.approvers
+}
+
 module "close_public_cloud_sql" {
   source     = "./cloudfunctions/cloud-sql/removepublic"
   setup      = module.google-setup
@@ -101,12 +267,37 @@ module "disable_dashboard" {
   folder-ids = var.folder-ids
 }
 
+module "harden_cluster_network" {
+  source     = "./cloudfunctions/gke/hardenclusternetwork"
+  setup      = module.google-setup
+  folder-ids = var.folder-ids
+}
+
+module "secure_managed_cluster" {
+  source     = "./cloudfunctions/managed-clusters/securecluster"
+  setup      = module.google-setup
+  folder-ids = var.folder-ids
+  approvers  = var.approvers
+}
+
 module "update_password" {
   source     = "./cloudfunctions/cloud-sql/updatepassword"
   setup      = module.google-setup
   folder-ids = var.folder-ids
 }
 
+module "enable_backups" {
+  source     = "./cloudfunctions/cloud-sql/enablebackups"
+  setup      = module.google-setup
+  folder-ids = var.folder-ids
+}
+
+module "secure_root" {
+  source     = "./cloudfunctions/cloud-sql/secureroot"
+  setup      = module.google-setup
+  folder-ids = var.folder-ids
+}
+
 module "enable_audit_logs" {
   source     = "./cloudfunctions/iam/enableauditlogs"
   setup      = module.google-setup
@@ -119,3 +310,9 @@ module "enable_audit_logs" {
 //  setup      = module.google-setup
 //  folder-ids = var.folder-ids
 //}
+
+module "remove_external_group_members" {
+  source     = "./cloudfunctions/iam/removegroupmembers"
+  setup      = module.google-setup
+  folder-ids = var.folder-ids
+}
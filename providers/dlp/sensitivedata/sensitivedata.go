@@ -0,0 +1,103 @@
+// Package sensitivedata represents Cloud DLP findings of sensitive data in a bucket or BigQuery
+// table.
+package sensitivedata
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/dlp/restrictsensitivedata"
+	pb "github.com/googlecloudplatform/security-response-automation/compiled/dlp/protos"
+)
+
+const storagePrefix = "//storage.googleapis.com/"
+
+// extractTable is a regex to extract the project and dataset of a BigQuery table's resource name.
+var extractTable = regexp.MustCompile(`^//bigquery\.googleapis\.com/projects/([^/]+)/datasets/([^/]+)`)
+
+// Finding represents a sensitive data finding.
+type Finding struct {
+	SensitiveData *pb.SensitiveData
+}
+
+// Name returns the rule name of the finding.
+func (f *Finding) Name(b []byte) string {
+	ff, err := New(b)
+	if err != nil {
+		return ""
+	}
+	if ff.InfoType() == "" || (ff.Bucket() == "" && ff.DatasetID() == "") {
+		return ""
+	}
+	return "sensitive_data"
+}
+
+// New returns a new sensitive data finding.
+func New(b []byte) (*Finding, error) {
+	var f Finding
+	if err := json.Unmarshal(b, &f.SensitiveData); err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+func (f *Finding) resourceName() string {
+	return f.SensitiveData.GetFinding().GetResourceName()
+}
+
+// InfoType returns the type of sensitive data found, i.e. "EMAIL_ADDRESS".
+func (f *Finding) InfoType() string {
+	return f.SensitiveData.GetFinding().GetSourceProperties().GetInfoType()
+}
+
+// ProjectID returns the project of the resource holding the sensitive data.
+func (f *Finding) ProjectID() string {
+	if p := f.SensitiveData.GetFinding().GetSourceProperties().GetProjectId(); p != "" {
+		return p
+	}
+	if m := extractTable.FindStringSubmatch(f.resourceName()); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// Bucket returns the bucket holding the sensitive data, if it was found in a bucket.
+func (f *Finding) Bucket() string {
+	if !strings.HasPrefix(f.resourceName(), storagePrefix) {
+		return ""
+	}
+	return strings.Split(strings.TrimPrefix(f.resourceName(), storagePrefix), "/")[0]
+}
+
+// DatasetID returns the dataset of the table holding the sensitive data, if it was found in BigQuery.
+func (f *Finding) DatasetID() string {
+	if m := extractTable.FindStringSubmatch(f.resourceName()); m != nil {
+		return m[2]
+	}
+	return ""
+}
+
+// RestrictSensitiveData returns values for the restrict sensitive data automation.
+func (f *Finding) RestrictSensitiveData() *restrictsensitivedata.Values {
+	return &restrictsensitivedata.Values{
+		ProjectID: f.ProjectID(),
+		Bucket:    f.Bucket(),
+		DatasetID: f.DatasetID(),
+		InfoType:  f.InfoType(),
+	}
+}
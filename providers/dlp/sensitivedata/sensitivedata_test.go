@@ -0,0 +1,105 @@
+package sensitivedata
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/dlp/restrictsensitivedata"
+)
+
+func TestReadFinding(t *testing.T) {
+	const (
+		bucketFinding = `{
+		  "notificationConfigName": "organizations/154584661726/notificationConfigs/sra-dlp",
+		  "finding": {
+			"name": "organizations/154584661726/sources/2810934752948494317/findings/0f61d9c3a2e84a4b",
+			"parent": "organizations/154584661726/sources/2810934752948494317",
+			"resourceName": "//storage.googleapis.com/customer-exports",
+			"state": "ACTIVE",
+			"category": "EMAIL_ADDRESS",
+			"sourceProperties": {
+			  "projectId": "dlp-resources-20200301",
+			  "infoType": "EMAIL_ADDRESS",
+			  "likelihood": "LIKELY",
+			  "jobName": "projects/dlp-resources-20200301/dlpJobs/i-2551456116153920494"
+			},
+			"securityMarks": {
+			  "name": "organizations/154584661726/sources/2810934752948494317/findings/0f61d9c3a2e84a4b/securityMarks"
+			},
+			"eventTime": "2020-03-01T18:40:22.538Z",
+			"createTime": "2020-03-01T18:40:23.445Z",
+			"severity": "HIGH"
+		  }
+		}`
+		tableFinding = `{
+		  "notificationConfigName": "organizations/154584661726/notificationConfigs/sra-dlp",
+		  "finding": {
+			"name": "organizations/154584661726/sources/2810934752948494317/findings/9c2dd0b1e5f34f6d",
+			"resourceName": "//bigquery.googleapis.com/projects/dlp-resources-20200301/datasets/customers/tables/accounts",
+			"state": "ACTIVE",
+			"category": "US_SOCIAL_SECURITY_NUMBER",
+			"sourceProperties": {
+			  "infoType": "US_SOCIAL_SECURITY_NUMBER",
+			  "likelihood": "VERY_LIKELY"
+			},
+			"eventTime": "2020-03-01T18:40:22.538Z"
+		  }
+		}`
+		otherFinding = `{"finding": {"resourceName": "//compute.googleapis.com/projects/p/zones/z/instances/i", "sourceProperties": {"infoType": "EMAIL_ADDRESS"}}}`
+	)
+	for _, tt := range []struct {
+		name     string
+		finding  string
+		rule     string
+		expected *restrictsensitivedata.Values
+	}{
+		{
+			name:     "bucket",
+			finding:  bucketFinding,
+			rule:     "sensitive_data",
+			expected: &restrictsensitivedata.Values{ProjectID: "dlp-resources-20200301", Bucket: "customer-exports", InfoType: "EMAIL_ADDRESS"},
+		},
+		{
+			name:     "table",
+			finding:  tableFinding,
+			rule:     "sensitive_data",
+			expected: &restrictsensitivedata.Values{ProjectID: "dlp-resources-20200301", DatasetID: "customers", InfoType: "US_SOCIAL_SECURITY_NUMBER"},
+		},
+		{
+			name:    "other resource",
+			finding: otherFinding,
+			rule:    "",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &Finding{}
+			if got := f.Name([]byte(tt.finding)); got != tt.rule {
+				t.Errorf("%s failed: got rule %q want %q", tt.name, got, tt.rule)
+			}
+			if tt.expected == nil {
+				return
+			}
+			f, err := New([]byte(tt.finding))
+			if err != nil {
+				t.Fatalf("%s failed: %q", tt.name, err)
+			}
+			if diff := cmp.Diff(tt.expected, f.RestrictSensitiveData()); diff != "" {
+				t.Errorf("%s failed, difference: %v", tt.name, diff)
+			}
+		})
+	}
+}
@@ -3,8 +3,13 @@ package anomalousiam
 
 import (
 	"encoding/json"
+	"strings"
 
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/containment/lockdownproject"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/iam/quarantineserviceaccount"
 	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/iam/revoke"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/iam/revokegrants"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/iam/revokeorgmembers"
 	pb "github.com/googlecloudplatform/security-response-automation/compiled/etd/protos"
 )
 
@@ -62,3 +67,72 @@ func (f *Finding) IAMRevoke() *revoke.Values {
 		ExternalMembers: f.anomalousIAM.GetJsonPayload().GetProperties().GetSensitiveRoleGrant().GetMembers(),
 	}
 }
+
+// Resource returns the organization or folder the grant was made on, i.e. "organizations/123".
+// An empty string is returned if the grant was made on a project or the resource is unknown.
+func (f *Finding) Resource() string {
+	if !f.UseCSCC {
+		return ""
+	}
+	name := strings.TrimPrefix(f.anomalousIAMSCC.GetFinding().GetResourceName(), "//cloudresourcemanager.googleapis.com/")
+	if strings.HasPrefix(name, "organizations/") || strings.HasPrefix(name, "folders/") {
+		return name
+	}
+	return ""
+}
+
+// IAMRevokeOrganization returns values for the organization IAM revoker automation.
+func (f *Finding) IAMRevokeOrganization() *revokeorgmembers.Values {
+	return &revokeorgmembers.Values{
+		Resource:        f.Resource(),
+		ExternalMembers: f.anomalousIAMSCC.GetFinding().GetSourceProperties().GetProperties().GetSensitiveRoleGrant().GetMembers(),
+	}
+}
+
+// Grantor returns the email of the principal that made the grant.
+func (f *Finding) Grantor() string {
+	if f.UseCSCC {
+		return f.anomalousIAMSCC.GetFinding().GetSourceProperties().GetProperties().GetPrincipalEmail()
+	}
+	return f.anomalousIAM.GetJsonPayload().GetProperties().GetPrincipalEmail()
+}
+
+// projectID returns the project the grant was logged in.
+func (f *Finding) projectID() string {
+	if f.UseCSCC {
+		evidence := f.anomalousIAMSCC.GetFinding().GetSourceProperties().GetEvidence()
+		if len(evidence) == 0 {
+			return ""
+		}
+		return evidence[0].GetSourceLogId().GetProjectId()
+	}
+	evidence := f.anomalousIAM.GetJsonPayload().GetEvidence()
+	if len(evidence) == 0 {
+		return ""
+	}
+	return evidence[0].GetSourceLogId().GetProjectId()
+}
+
+// RevokeGrants returns values for the automation revoking the grants made by the grantor.
+func (f *Finding) RevokeGrants() *revokegrants.Values {
+	return &revokegrants.Values{
+		ProjectID: f.projectID(),
+		Principal: f.Grantor(),
+	}
+}
+
+// QuarantineServiceAccount returns values for the automation quarantining the grantor, it's only
+// run if the grantor is a service account.
+func (f *Finding) QuarantineServiceAccount() *quarantineserviceaccount.Values {
+	return &quarantineserviceaccount.Values{
+		ProjectID:      f.projectID(),
+		ServiceAccount: f.Grantor(),
+	}
+}
+
+// LockdownProject returns values for the lockdown project automation.
+func (f *Finding) LockdownProject() *lockdownproject.Values {
+	return &lockdownproject.Values{
+		ProjectID: f.projectID(),
+	}
+}
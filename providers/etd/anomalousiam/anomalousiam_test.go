@@ -24,6 +24,7 @@ func TestReadFinding(t *testing.T) {
 					},
 					"evidence": [{"sourceLogId": {"projectId": "onboarding-project"}}],
 					"properties": {
+						"principalEmail": "mallory@example.com",
 						"sensitiveRoleGrant": {
 							"members": ["user:john.doe@example.com", "user:jane.doe@example.com"]
 						}
@@ -37,6 +38,7 @@ func TestReadFinding(t *testing.T) {
 		etdAnomalousIAM = `{
 			"jsonPayload": {
 				"properties": {
+					"principalEmail": "mallory@example.com",
 					"sensitiveRoleGrant": {
 						"members": ["user:john.doe@example.com", "user:jane.doe@example.com"]
 					}
@@ -78,7 +80,44 @@ func TestReadFinding(t *testing.T) {
 				if values.ProjectID != tt.projectID {
 					t.Errorf("%s failed: got:%q want:%q", tt.name, values.ProjectID, tt.projectID)
 				}
+				grants := r.RevokeGrants()
+				if grants.ProjectID != tt.projectID || grants.Principal != "mallory@example.com" {
+					t.Errorf("%s failed: got:%+v", tt.name, grants)
+				}
 			}
 		})
 	}
 }
+
+func TestOrganizationGrant(t *testing.T) {
+	const orgAnomalousIAM = `{
+		"notificationConfigName": "organizations/0000000000000/notificationConfigs/noticonf-active-001-id",
+		"finding": {
+			"name": "organizations/0000000000000/sources/0000000000000000000/findings/6a30ce604c11417995b1fa260753f3b5",
+			"resourceName": "//cloudresourcemanager.googleapis.com/organizations/0000000000000",
+			"state": "ACTIVE",
+			"sourceProperties": {
+				"detectionCategory": {
+					"ruleName": "iam_anomalous_grant"
+				},
+				"evidence": [{"sourceLogId": {"projectId": "onboarding-project"}}],
+				"properties": {
+					"sensitiveRoleGrant": {
+						"members": ["user:john.doe@example.com"]
+					}
+				}
+			}
+		}
+	}`
+	r, err := New([]byte(orgAnomalousIAM))
+	if err != nil {
+		t.Fatalf("failed to read finding: %q", err)
+	}
+	values := r.IAMRevokeOrganization()
+	if values.Resource != "organizations/0000000000000" {
+		t.Errorf("got resource %q", values.Resource)
+	}
+	if diff := cmp.Diff([]string{"user:john.doe@example.com"}, values.ExternalMembers); diff != "" {
+		t.Errorf("unexpected members: %s", diff)
+	}
+}
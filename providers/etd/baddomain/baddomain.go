@@ -0,0 +1,119 @@
+// Package baddomain represents the bad domain finding.
+package baddomain
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"encoding/json"
+
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gce/blockegress"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gce/createsnapshot"
+	pb "github.com/googlecloudplatform/security-response-automation/compiled/etd/protos"
+	"github.com/googlecloudplatform/security-response-automation/providers/etd"
+)
+
+// Name returns the rule name of the finding.
+func (f *Finding) Name(b []byte) string {
+	ff, err := New(b)
+	if err != nil {
+		return ""
+	}
+	if ff.ruleName() != "bad_domain" {
+		return ""
+	}
+	return "bad_domain"
+}
+
+// Finding represents a bad domain finding.
+type Finding struct {
+	UseCSCC       bool
+	badDomain     *pb.BadDomain
+	BadDomainCSCC *pb.BadDomainSCC
+}
+
+// New returns a new bad domain finding.
+func New(b []byte) (*Finding, error) {
+	var f Finding
+	if err := json.Unmarshal(b, &f.badDomain); err != nil {
+		return nil, err
+	}
+	if f.badDomain.GetJsonPayload().GetDetectionCategory().GetRuleName() != "" {
+		return &f, nil
+	}
+	if err := json.Unmarshal(b, &f.BadDomainCSCC); err != nil {
+		return nil, err
+	}
+	f.UseCSCC = true
+	return &f, nil
+}
+
+func (f *Finding) ruleName() string {
+	if f.UseCSCC {
+		return f.BadDomainCSCC.GetFinding().GetSourceProperties().GetDetectionCategory().GetRuleName()
+	}
+	return f.badDomain.GetJsonPayload().GetDetectionCategory().GetRuleName()
+}
+
+// ProjectID returns the project of the instance that contacted the domain.
+func (f *Finding) ProjectID() string {
+	if f.UseCSCC {
+		return f.BadDomainCSCC.GetFinding().GetSourceProperties().GetProperties().GetNetwork().GetProject()
+	}
+	return f.badDomain.GetJsonPayload().GetProperties().GetNetwork().GetProject()
+}
+
+func (f *Finding) instanceDetails() string {
+	if f.UseCSCC {
+		return f.BadDomainCSCC.GetFinding().GetSourceProperties().GetProperties().GetInstanceDetails()
+	}
+	return f.badDomain.GetJsonPayload().GetProperties().GetInstanceDetails()
+}
+
+// Instance returns the name of the instance that contacted the domain.
+func (f *Finding) Instance() string {
+	return etd.Instance(f.instanceDetails())
+}
+
+// Zone returns the zone of the instance that contacted the domain.
+func (f *Finding) Zone() string {
+	return etd.Zone(f.instanceDetails())
+}
+
+// Domains returns the known bad domains the instance contacted.
+func (f *Finding) Domains() []string {
+	if f.UseCSCC {
+		return f.BadDomainCSCC.GetFinding().GetSourceProperties().GetProperties().GetDomain()
+	}
+	return f.badDomain.GetJsonPayload().GetProperties().GetDomain()
+}
+
+// CreateSnapshot returns values for the create snapshot automation.
+func (f *Finding) CreateSnapshot() *createsnapshot.Values {
+	return &createsnapshot.Values{
+		ProjectID: f.ProjectID(),
+		RuleName:  f.ruleName(),
+		Instance:  f.Instance(),
+		Zone:      f.Zone(),
+	}
+}
+
+// BlockEgress returns values for the block egress automation.
+func (f *Finding) BlockEgress() *blockegress.Values {
+	return &blockegress.Values{
+		ProjectID: f.ProjectID(),
+		Zone:      f.Zone(),
+		Instance:  f.Instance(),
+	}
+}
@@ -0,0 +1,101 @@
+package baddomain
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBadDomain(t *testing.T) {
+	const (
+		badDomainSCC = `{
+			"finding": {
+				"name": "organizations/0000000000000/sources/0000000000000000000/findings/7b30ce604c11417995b1fa260753f3b5",
+				"parent": "organizations/0000000000000/sources/0000000000000000000",
+				"resourceName": "//cloudresourcemanager.googleapis.com/projects/000000000000",
+				"state": "ACTIVE",
+				"category": "Malware: Bad Domain",
+				"sourceProperties": {
+					"detectionCategory": {
+						"ruleName": "bad_domain"
+					},
+					"properties": {
+						"instanceDetails": "/projects/test-project-15511551515/zones/us-central1-a/instances/bad-domain-caller",
+						"network": {
+							"project": "test-project-15511551515"
+						},
+						"domain": ["malware.example.com"]
+					}
+				},
+				"securityMarks": {},
+				"eventTime": "2020-03-02T18:34:36.153Z",
+				"createTime": "2020-03-02T18:34:36.688Z"
+			}
+		}`
+		badDomainStackdriver = `{
+			"jsonPayload": {
+				"properties": {
+					"instanceDetails": "/projects/test-project-15511551515/zones/us-central1-a/instances/bad-domain-caller",
+					"network": {
+						"project": "test-project-15511551515"
+					},
+					"domain": ["malware.example.com"]
+				},
+				"detectionCategory": {
+					"ruleName": "bad_domain"
+				}
+			},
+			"logName": "projects/test-project/logs/threatdetection.googleapis.com` + "%%2F" + `detection"
+		}`
+	)
+
+	for _, tt := range []struct {
+		name      string
+		ruleName  string
+		finding   []byte
+		projectID string
+		instance  string
+		zone      string
+		domains   []string
+	}{
+		{name: "bad_domain SD", finding: []byte(badDomainStackdriver), ruleName: "bad_domain", projectID: "test-project-15511551515", instance: "bad-domain-caller", zone: "us-central1-a", domains: []string{"malware.example.com"}},
+		{name: "bad_domain CSCC", finding: []byte(badDomainSCC), ruleName: "bad_domain", projectID: "test-project-15511551515", instance: "bad-domain-caller", zone: "us-central1-a", domains: []string{"malware.example.com"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := New(tt.finding)
+			if err != nil {
+				t.Fatalf("%q failed: %q", tt.name, err)
+			}
+			if name := f.Name(tt.finding); name != tt.ruleName {
+				t.Errorf("%q got:%q want:%q", tt.name, name, tt.ruleName)
+			}
+			values := f.CreateSnapshot()
+			if values.ProjectID != tt.projectID {
+				t.Errorf("%s failed: got:%q want:%q", tt.name, values.ProjectID, tt.projectID)
+			}
+			if values.Instance != tt.instance {
+				t.Errorf("%s failed: got:%q want:%q", tt.name, values.Instance, tt.instance)
+			}
+			if values.Zone != tt.zone {
+				t.Errorf("%s failed: got:%q want:%q", tt.name, values.Zone, tt.zone)
+			}
+			if diff := cmp.Diff(tt.domains, f.Domains()); diff != "" {
+				t.Errorf("%s failed: domains diff (-want +got):\n%s", tt.name, diff)
+			}
+		})
+	}
+}
@@ -18,6 +18,7 @@ package badip
 import (
 	"encoding/json"
 
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gce/blockegress"
 	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gce/createsnapshot"
 	pb "github.com/googlecloudplatform/security-response-automation/compiled/etd/protos"
 	"github.com/googlecloudplatform/security-response-automation/providers/etd"
@@ -81,3 +82,13 @@ func (f *Finding) CreateSnapshot() *createsnapshot.Values {
 		Zone:      etd.Zone(f.badIP.GetJsonPayload().GetProperties().GetInstanceDetails()),
 	}
 }
+
+// BlockEgress returns values for the block egress automation.
+func (f *Finding) BlockEgress() *blockegress.Values {
+	v := f.CreateSnapshot()
+	return &blockegress.Values{
+		ProjectID: v.ProjectID,
+		Zone:      v.Zone,
+		Instance:  v.Instance,
+	}
+}
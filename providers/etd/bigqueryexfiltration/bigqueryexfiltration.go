@@ -0,0 +1,119 @@
+// Package bigqueryexfiltration represents the BigQuery data exfiltration finding.
+package bigqueryexfiltration
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"encoding/json"
+
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/iam/revoke"
+	pb "github.com/googlecloudplatform/security-response-automation/compiled/etd/protos"
+	"github.com/googlecloudplatform/security-response-automation/providers/etd"
+)
+
+// Name returns the rule name of the finding.
+func (f *Finding) Name(b []byte) string {
+	ff, err := New(b)
+	if err != nil {
+		return ""
+	}
+	if ff.ruleName() != "bigquery_exfiltration" {
+		return ""
+	}
+	return "bigquery_exfiltration"
+}
+
+// Finding represents a BigQuery data exfiltration finding.
+type Finding struct {
+	UseCSCC                  bool
+	bigQueryExfiltration     *pb.BigQueryExfiltration
+	BigQueryExfiltrationCSCC *pb.BigQueryExfiltrationSCC
+}
+
+// New returns a new BigQuery data exfiltration finding.
+func New(b []byte) (*Finding, error) {
+	var f Finding
+	if err := json.Unmarshal(b, &f.bigQueryExfiltration); err != nil {
+		return nil, err
+	}
+	if f.bigQueryExfiltration.GetJsonPayload().GetDetectionCategory().GetRuleName() != "" {
+		return &f, nil
+	}
+	if err := json.Unmarshal(b, &f.BigQueryExfiltrationCSCC); err != nil {
+		return nil, err
+	}
+	f.UseCSCC = true
+	return &f, nil
+}
+
+func (f *Finding) ruleName() string {
+	if f.UseCSCC {
+		return f.BigQueryExfiltrationCSCC.GetFinding().GetSourceProperties().GetDetectionCategory().GetRuleName()
+	}
+	return f.bigQueryExfiltration.GetJsonPayload().GetDetectionCategory().GetRuleName()
+}
+
+// ProjectID returns the project whose logs recorded the exfiltration, falling back to the project
+// of the source table.
+func (f *Finding) ProjectID() string {
+	var project string
+	if f.UseCSCC {
+		if evidence := f.BigQueryExfiltrationCSCC.GetFinding().GetSourceProperties().GetEvidence(); len(evidence) > 0 {
+			project = evidence[0].GetSourceLogId().GetProjectId()
+		}
+	} else if evidence := f.bigQueryExfiltration.GetJsonPayload().GetEvidence(); len(evidence) > 0 {
+		project = evidence[0].GetSourceLogId().GetProjectId()
+	}
+	if project == "" {
+		return etd.ProjectOfTable(f.SourceTable())
+	}
+	return project
+}
+
+// PrincipalEmail returns the email of the principal that copied the data.
+func (f *Finding) PrincipalEmail() string {
+	if f.UseCSCC {
+		return f.BigQueryExfiltrationCSCC.GetFinding().GetSourceProperties().GetProperties().GetPrincipalEmail()
+	}
+	return f.bigQueryExfiltration.GetJsonPayload().GetProperties().GetPrincipalEmail()
+}
+
+// SourceTable returns the table the data was copied from.
+func (f *Finding) SourceTable() string {
+	if f.UseCSCC {
+		return f.BigQueryExfiltrationCSCC.GetFinding().GetSourceProperties().GetProperties().GetSourceTable()
+	}
+	return f.bigQueryExfiltration.GetJsonPayload().GetProperties().GetSourceTable()
+}
+
+// DestinationTable returns the table the data was copied to.
+func (f *Finding) DestinationTable() string {
+	if f.UseCSCC {
+		return f.BigQueryExfiltrationCSCC.GetFinding().GetSourceProperties().GetProperties().GetDestinationTable()
+	}
+	return f.bigQueryExfiltration.GetJsonPayload().GetProperties().GetDestinationTable()
+}
+
+// IAMRevoke returns values for the IAM revoke automation.
+func (f *Finding) IAMRevoke() *revoke.Values {
+	var members []string
+	if m := etd.Member(f.PrincipalEmail()); m != "" {
+		members = append(members, m)
+	}
+	return &revoke.Values{
+		ProjectID:       f.ProjectID(),
+		ExternalMembers: members,
+	}
+}
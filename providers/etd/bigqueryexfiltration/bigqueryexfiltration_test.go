@@ -0,0 +1,93 @@
+package bigqueryexfiltration
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/iam/revoke"
+)
+
+func TestBigQueryExfiltration(t *testing.T) {
+	const (
+		exfiltrationSCC = `{
+			"finding": {
+				"name": "organizations/0000000000000/sources/0000000000000000000/findings/0e30ce604c11417995b1fa260753f3b5",
+				"parent": "organizations/0000000000000/sources/0000000000000000000",
+				"resourceName": "//cloudresourcemanager.googleapis.com/projects/000000000000",
+				"state": "ACTIVE",
+				"category": "Exfiltration: BigQuery Data Exfiltration",
+				"sourceProperties": {
+					"detectionCategory": {
+						"ruleName": "bigquery_exfiltration"
+					},
+					"properties": {
+						"principalEmail": "jane@example.com",
+						"sourceTable": "//bigquery.googleapis.com/projects/source-project/datasets/sales/tables/orders",
+						"destinationTable": "//bigquery.googleapis.com/projects/other-project/datasets/copy/tables/orders"
+					},
+					"evidence": [{
+						"sourceLogId": {
+							"projectId": "test-project-15511551515"
+						}
+					}]
+				},
+				"securityMarks": {},
+				"eventTime": "2020-03-02T18:34:36.153Z",
+				"createTime": "2020-03-02T18:34:36.688Z"
+			}
+		}`
+		exfiltrationStackdriver = `{
+			"jsonPayload": {
+				"properties": {
+					"principalEmail": "jane@example.com",
+					"sourceTable": "projects/source-project/datasets/sales/tables/orders",
+					"destinationTable": "projects/other-project/datasets/copy/tables/orders"
+				},
+				"detectionCategory": {
+					"ruleName": "bigquery_exfiltration"
+				}
+			},
+			"logName": "projects/test-project/logs/threatdetection.googleapis.com` + "%%2F" + `detection"
+		}`
+	)
+
+	for _, tt := range []struct {
+		name        string
+		finding     []byte
+		values      *revoke.Values
+		destination string
+	}{
+		{name: "bigquery_exfiltration SD", finding: []byte(exfiltrationStackdriver), values: &revoke.Values{ProjectID: "source-project", ExternalMembers: []string{"user:jane@example.com"}}, destination: "projects/other-project/datasets/copy/tables/orders"},
+		{name: "bigquery_exfiltration CSCC", finding: []byte(exfiltrationSCC), values: &revoke.Values{ProjectID: "test-project-15511551515", ExternalMembers: []string{"user:jane@example.com"}}, destination: "//bigquery.googleapis.com/projects/other-project/datasets/copy/tables/orders"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := New(tt.finding)
+			if err != nil {
+				t.Fatalf("%q failed: %q", tt.name, err)
+			}
+			if name := f.Name(tt.finding); name != "bigquery_exfiltration" {
+				t.Errorf("%q got:%q want:%q", tt.name, name, "bigquery_exfiltration")
+			}
+			if diff := cmp.Diff(tt.values, f.IAMRevoke()); diff != "" {
+				t.Errorf("%s failed: values diff (-want +got):\n%s", tt.name, diff)
+			}
+			if f.DestinationTable() != tt.destination {
+				t.Errorf("%s failed: got:%q want:%q", tt.name, f.DestinationTable(), tt.destination)
+			}
+		})
+	}
+}
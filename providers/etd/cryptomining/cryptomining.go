@@ -0,0 +1,127 @@
+// Package cryptomining represents the cryptomining finding.
+package cryptomining
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"encoding/json"
+
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gce/createsnapshot"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gce/removepublicip"
+	pb "github.com/googlecloudplatform/security-response-automation/compiled/etd/protos"
+	"github.com/googlecloudplatform/security-response-automation/providers/etd"
+)
+
+// Name returns the rule name of the finding.
+func (f *Finding) Name(b []byte) string {
+	ff, err := New(b)
+	if err != nil {
+		return ""
+	}
+	if ff.ruleName() != "cryptomining" {
+		return ""
+	}
+	return "cryptomining"
+}
+
+// Finding represents a cryptomining finding.
+type Finding struct {
+	UseCSCC          bool
+	cryptomining     *pb.Cryptomining
+	CryptominingCSCC *pb.CryptominingSCC
+}
+
+// New returns a new cryptomining finding.
+func New(b []byte) (*Finding, error) {
+	var f Finding
+	if err := json.Unmarshal(b, &f.cryptomining); err != nil {
+		return nil, err
+	}
+	if f.cryptomining.GetJsonPayload().GetDetectionCategory().GetRuleName() != "" {
+		return &f, nil
+	}
+	if err := json.Unmarshal(b, &f.CryptominingCSCC); err != nil {
+		return nil, err
+	}
+	f.UseCSCC = true
+	return &f, nil
+}
+
+func (f *Finding) ruleName() string {
+	if f.UseCSCC {
+		return f.CryptominingCSCC.GetFinding().GetSourceProperties().GetDetectionCategory().GetRuleName()
+	}
+	return f.cryptomining.GetJsonPayload().GetDetectionCategory().GetRuleName()
+}
+
+// ProjectID returns the project of the mining instance.
+func (f *Finding) ProjectID() string {
+	if f.UseCSCC {
+		return f.CryptominingCSCC.GetFinding().GetSourceProperties().GetProperties().GetNetwork().GetProject()
+	}
+	return f.cryptomining.GetJsonPayload().GetProperties().GetNetwork().GetProject()
+}
+
+func (f *Finding) instanceDetails() string {
+	if f.UseCSCC {
+		return f.CryptominingCSCC.GetFinding().GetSourceProperties().GetProperties().GetInstanceDetails()
+	}
+	return f.cryptomining.GetJsonPayload().GetProperties().GetInstanceDetails()
+}
+
+// Instance returns the name of the mining instance.
+func (f *Finding) Instance() string {
+	return etd.Instance(f.instanceDetails())
+}
+
+// Zone returns the zone of the mining instance.
+func (f *Finding) Zone() string {
+	return etd.Zone(f.instanceDetails())
+}
+
+// IPs returns the IP addresses of the mining pools the instance contacted.
+func (f *Finding) IPs() []string {
+	if f.UseCSCC {
+		return f.CryptominingCSCC.GetFinding().GetSourceProperties().GetProperties().GetIp()
+	}
+	return f.cryptomining.GetJsonPayload().GetProperties().GetIp()
+}
+
+// Domains returns the domains of the mining pools the instance contacted.
+func (f *Finding) Domains() []string {
+	if f.UseCSCC {
+		return f.CryptominingCSCC.GetFinding().GetSourceProperties().GetProperties().GetDomain()
+	}
+	return f.cryptomining.GetJsonPayload().GetProperties().GetDomain()
+}
+
+// CreateSnapshot returns values for the create snapshot automation.
+func (f *Finding) CreateSnapshot() *createsnapshot.Values {
+	return &createsnapshot.Values{
+		ProjectID: f.ProjectID(),
+		RuleName:  f.ruleName(),
+		Instance:  f.Instance(),
+		Zone:      f.Zone(),
+	}
+}
+
+// RemovePublicIP returns values for the remove public IP automation.
+func (f *Finding) RemovePublicIP() *removepublicip.Values {
+	return &removepublicip.Values{
+		ProjectID:    f.ProjectID(),
+		InstanceZone: f.Zone(),
+		InstanceID:   f.Instance(),
+	}
+}
@@ -0,0 +1,96 @@
+package cryptomining
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCryptomining(t *testing.T) {
+	const (
+		cryptominingSCC = `{
+			"finding": {
+				"name": "organizations/0000000000000/sources/0000000000000000000/findings/8c30ce604c11417995b1fa260753f3b5",
+				"parent": "organizations/0000000000000/sources/0000000000000000000",
+				"resourceName": "//cloudresourcemanager.googleapis.com/projects/000000000000",
+				"state": "ACTIVE",
+				"category": "Malware: Cryptomining Bad IP",
+				"sourceProperties": {
+					"detectionCategory": {
+						"ruleName": "cryptomining"
+					},
+					"properties": {
+						"instanceDetails": "/projects/test-project-15511551515/zones/us-central1-a/instances/miner",
+						"network": {
+							"project": "test-project-15511551515"
+						},
+						"ip": ["203.0.113.7"],
+						"domain": ["pool.example.com"]
+					}
+				},
+				"securityMarks": {},
+				"eventTime": "2020-03-02T18:34:36.153Z",
+				"createTime": "2020-03-02T18:34:36.688Z"
+			}
+		}`
+		cryptominingStackdriver = `{
+			"jsonPayload": {
+				"properties": {
+					"instanceDetails": "/projects/test-project-15511551515/zones/us-central1-a/instances/miner",
+					"network": {
+						"project": "test-project-15511551515"
+					},
+					"ip": ["203.0.113.7"],
+					"domain": ["pool.example.com"]
+				},
+				"detectionCategory": {
+					"ruleName": "cryptomining"
+				}
+			},
+			"logName": "projects/test-project/logs/threatdetection.googleapis.com` + "%%2F" + `detection"
+		}`
+	)
+
+	for _, tt := range []struct {
+		name     string
+		ruleName string
+		finding  []byte
+	}{
+		{name: "cryptomining SD", finding: []byte(cryptominingStackdriver), ruleName: "cryptomining"},
+		{name: "cryptomining CSCC", finding: []byte(cryptominingSCC), ruleName: "cryptomining"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := New(tt.finding)
+			if err != nil {
+				t.Fatalf("%q failed: %q", tt.name, err)
+			}
+			if name := f.Name(tt.finding); name != tt.ruleName {
+				t.Errorf("%q got:%q want:%q", tt.name, name, tt.ruleName)
+			}
+			values := f.RemovePublicIP()
+			if values.ProjectID != "test-project-15511551515" || values.InstanceZone != "us-central1-a" || values.InstanceID != "miner" {
+				t.Errorf("%s failed: got:%+v", tt.name, values)
+			}
+			if diff := cmp.Diff([]string{"203.0.113.7"}, f.IPs()); diff != "" {
+				t.Errorf("%s failed: IPs diff (-want +got):\n%s", tt.name, diff)
+			}
+			if diff := cmp.Diff([]string{"pool.example.com"}, f.Domains()); diff != "" {
+				t.Errorf("%s failed: domains diff (-want +got):\n%s", tt.name, diff)
+			}
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package etd
 
-import "regexp"
+import (
+	"regexp"
+	"strings"
+)
 
 // Copyright 2019 Google LLC
 //
@@ -40,3 +43,24 @@ func Zone(resource string) string {
 	}
 	return i[1]
 }
+
+// Member returns the IAM member of the principal's email, i.e. "user:jane@example.com".
+func Member(email string) string {
+	if email == "" {
+		return ""
+	}
+	if strings.HasSuffix(email, ".gserviceaccount.com") {
+		return "serviceAccount:" + email
+	}
+	return "user:" + email
+}
+
+// ProjectOfTable returns the project from a BigQuery table resource name, i.e.
+// "projects/p/datasets/d/tables/t".
+func ProjectOfTable(table string) string {
+	parts := strings.Split(strings.TrimPrefix(table, "//bigquery.googleapis.com/"), "/")
+	if len(parts) < 2 || parts[0] != "projects" {
+		return ""
+	}
+	return parts[1]
+}
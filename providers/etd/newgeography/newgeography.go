@@ -0,0 +1,119 @@
+// Package newgeography represents the IAM anomalous behavior finding of a principal acting from a
+// new geography.
+package newgeography
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"encoding/json"
+
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/iam/revoke"
+	pb "github.com/googlecloudplatform/security-response-automation/compiled/etd/protos"
+	"github.com/googlecloudplatform/security-response-automation/providers/etd"
+)
+
+// Name returns the rule name of the finding.
+func (f *Finding) Name(b []byte) string {
+	ff, err := New(b)
+	if err != nil {
+		return ""
+	}
+	if ff.ruleName() != "new_geography" {
+		return ""
+	}
+	return "new_geography"
+}
+
+// Finding represents a new geography finding.
+type Finding struct {
+	UseCSCC          bool
+	newGeography     *pb.NewGeography
+	NewGeographyCSCC *pb.NewGeographySCC
+}
+
+// New returns a new geography finding.
+func New(b []byte) (*Finding, error) {
+	var f Finding
+	if err := json.Unmarshal(b, &f.newGeography); err != nil {
+		return nil, err
+	}
+	if f.newGeography.GetJsonPayload().GetDetectionCategory().GetRuleName() != "" {
+		return &f, nil
+	}
+	if err := json.Unmarshal(b, &f.NewGeographyCSCC); err != nil {
+		return nil, err
+	}
+	f.UseCSCC = true
+	return &f, nil
+}
+
+func (f *Finding) ruleName() string {
+	if f.UseCSCC {
+		return f.NewGeographyCSCC.GetFinding().GetSourceProperties().GetDetectionCategory().GetRuleName()
+	}
+	return f.newGeography.GetJsonPayload().GetDetectionCategory().GetRuleName()
+}
+
+// ProjectID returns the project whose logs recorded the activity.
+func (f *Finding) ProjectID() string {
+	if f.UseCSCC {
+		evidence := f.NewGeographyCSCC.GetFinding().GetSourceProperties().GetEvidence()
+		if len(evidence) == 0 {
+			return ""
+		}
+		return evidence[0].GetSourceLogId().GetProjectId()
+	}
+	evidence := f.newGeography.GetJsonPayload().GetEvidence()
+	if len(evidence) == 0 {
+		return ""
+	}
+	return evidence[0].GetSourceLogId().GetProjectId()
+}
+
+// PrincipalEmail returns the email of the principal acting from the new geography.
+func (f *Finding) PrincipalEmail() string {
+	if f.UseCSCC {
+		return f.NewGeographyCSCC.GetFinding().GetSourceProperties().GetProperties().GetPrincipalEmail()
+	}
+	return f.newGeography.GetJsonPayload().GetProperties().GetPrincipalEmail()
+}
+
+// CallerIP returns the IP address the principal acted from.
+func (f *Finding) CallerIP() string {
+	if f.UseCSCC {
+		return f.NewGeographyCSCC.GetFinding().GetSourceProperties().GetProperties().GetCallerIp()
+	}
+	return f.newGeography.GetJsonPayload().GetProperties().GetCallerIp()
+}
+
+// Location returns the anomalous location the principal acted from.
+func (f *Finding) Location() string {
+	if f.UseCSCC {
+		return f.NewGeographyCSCC.GetFinding().GetSourceProperties().GetProperties().GetAnomalousLocation()
+	}
+	return f.newGeography.GetJsonPayload().GetProperties().GetAnomalousLocation()
+}
+
+// IAMRevoke returns values for the IAM revoke automation.
+func (f *Finding) IAMRevoke() *revoke.Values {
+	var members []string
+	if m := etd.Member(f.PrincipalEmail()); m != "" {
+		members = append(members, m)
+	}
+	return &revoke.Values{
+		ProjectID:       f.ProjectID(),
+		ExternalMembers: members,
+	}
+}
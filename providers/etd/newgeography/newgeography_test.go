@@ -0,0 +1,113 @@
+package newgeography
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/iam/revoke"
+)
+
+func TestNewGeography(t *testing.T) {
+	const (
+		newGeographySCC = `{
+			"finding": {
+				"name": "organizations/0000000000000/sources/0000000000000000000/findings/9d30ce604c11417995b1fa260753f3b5",
+				"parent": "organizations/0000000000000/sources/0000000000000000000",
+				"resourceName": "//cloudresourcemanager.googleapis.com/projects/000000000000",
+				"state": "ACTIVE",
+				"category": "Persistence: New Geography",
+				"sourceProperties": {
+					"detectionCategory": {
+						"ruleName": "new_geography"
+					},
+					"properties": {
+						"principalEmail": "jane@example.com",
+						"callerIp": "198.51.100.4",
+						"anomalousLocation": "AQ"
+					},
+					"evidence": [{
+						"sourceLogId": {
+							"projectId": "test-project-15511551515"
+						}
+					}]
+				},
+				"securityMarks": {},
+				"eventTime": "2020-03-02T18:34:36.153Z",
+				"createTime": "2020-03-02T18:34:36.688Z"
+			}
+		}`
+		newGeographyStackdriver = `{
+			"jsonPayload": {
+				"properties": {
+					"principalEmail": "automation@test-project-15511551515.iam.gserviceaccount.com",
+					"callerIp": "198.51.100.4",
+					"anomalousLocation": "AQ"
+				},
+				"detectionCategory": {
+					"ruleName": "new_geography"
+				},
+				"evidence": [{
+					"sourceLogId": {
+						"projectId": "test-project-15511551515"
+					}
+				}]
+			},
+			"logName": "projects/test-project/logs/threatdetection.googleapis.com` + "%%2F" + `detection"
+		}`
+		noEvidence = `{
+			"jsonPayload": {
+				"properties": {
+					"principalEmail": "jane@example.com"
+				},
+				"detectionCategory": {
+					"ruleName": "new_geography"
+				}
+			}
+		}`
+	)
+
+	for _, tt := range []struct {
+		name     string
+		finding  []byte
+		values   *revoke.Values
+		callerIP string
+		location string
+	}{
+		{name: "new_geography SD", finding: []byte(newGeographyStackdriver), values: &revoke.Values{ProjectID: "test-project-15511551515", ExternalMembers: []string{"serviceAccount:automation@test-project-15511551515.iam.gserviceaccount.com"}}, callerIP: "198.51.100.4", location: "AQ"},
+		{name: "new_geography CSCC", finding: []byte(newGeographySCC), values: &revoke.Values{ProjectID: "test-project-15511551515", ExternalMembers: []string{"user:jane@example.com"}}, callerIP: "198.51.100.4", location: "AQ"},
+		{name: "no evidence", finding: []byte(noEvidence), values: &revoke.Values{ExternalMembers: []string{"user:jane@example.com"}}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := New(tt.finding)
+			if err != nil {
+				t.Fatalf("%q failed: %q", tt.name, err)
+			}
+			if name := f.Name(tt.finding); name != "new_geography" {
+				t.Errorf("%q got:%q want:%q", tt.name, name, "new_geography")
+			}
+			if diff := cmp.Diff(tt.values, f.IAMRevoke()); diff != "" {
+				t.Errorf("%s failed: values diff (-want +got):\n%s", tt.name, diff)
+			}
+			if f.CallerIP() != tt.callerIP {
+				t.Errorf("%s failed: got:%q want:%q", tt.name, f.CallerIP(), tt.callerIP)
+			}
+			if f.Location() != tt.location {
+				t.Errorf("%s failed: got:%q want:%q", tt.name, f.Location(), tt.location)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package forseti
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import "regexp"
+
+// extractProjectID is a regex to extract the project ID from a violation's full name such as
+// "organization/1234/folder/5678/project/my-project/bucket/my-bucket/".
+var extractProjectID = regexp.MustCompile(`(?:^|/)project/([^/]+)/`)
+
+// ProjectID returns the project ID from the full name of the violating resource.
+func ProjectID(fullName string) string {
+	i := extractProjectID.FindStringSubmatch(fullName)
+	if len(i) != 2 {
+		return ""
+	}
+	return i[1]
+}
@@ -0,0 +1,76 @@
+// Package violation represents violations published by the Forseti notifier.
+package violation
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gce/openfirewall"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gcs/closebucket"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/iam/revoke"
+	pb "github.com/googlecloudplatform/security-response-automation/compiled/forseti/protos"
+	"github.com/googlecloudplatform/security-response-automation/providers/forseti"
+)
+
+// Finding represents this finding.
+type Finding struct {
+	Violation *pb.Violation
+}
+
+// Name returns the rule name of the violation.
+//
+// Bucket ACL violations map to "bucket_violation", members added to a project's IAM policy
+// in violation of a rule map to "iam_policy_violation" and all firewall violations map to
+// "firewall_violation". Other violations are not supported.
+func (f *Finding) Name(b []byte) string {
+	ff, err := New(b)
+	if err != nil {
+		return ""
+	}
+	v := ff.Violation
+	if v.GetFullName() == "" || v.GetResourceId() == "" {
+		return ""
+	}
+	switch t := v.GetViolationType(); {
+	case t == "BUCKET_VIOLATION" && v.GetResourceType() == "bucket":
+		return "bucket_violation"
+	case t == "ADDED" && v.GetResourceType() == "project":
+		return "iam_policy_violation"
+	case strings.HasPrefix(t, "FIREWALL_") && v.GetResourceType() == "firewall":
+		return "firewall_violation"
+	}
+	return ""
+}
+
+// New returns a new finding.
+func New(b []byte) (*Finding, error) {
+	var f Finding
+	if err := json.Unmarshal(b, &f.Violation); err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+// CloseBucket returns values for the close bucket automation.
+func (f *Finding) CloseBucket() *closebucket.Values {
+	return &closebucket.Values{
+		ProjectID:  forseti.ProjectID(f.Violation.GetFullName()),
+		BucketName: f.Violation.GetResourceId(),
+	}
+}
+
+// IAMRevoke returns values for the IAM revoke automation.
+func (f *Finding) IAMRevoke() *revoke.Values {
+	return &revoke.Values{
+		ProjectID:       f.Violation.GetResourceId(),
+		ExternalMembers: []string{f.Violation.GetViolationData().GetMember()},
+	}
+}
+
+// OpenFirewall returns values for the remediate firewall automation.
+func (f *Finding) OpenFirewall() *openfirewall.Values {
+	return &openfirewall.Values{
+		ProjectID:  forseti.ProjectID(f.Violation.GetFullName()),
+		FirewallID: f.Violation.GetResourceId(),
+	}
+}
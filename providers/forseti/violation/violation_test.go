@@ -0,0 +1,99 @@
+package violation
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const (
+	bucketViolation = `{
+		"resource_id": "public-bucket",
+		"resource_type": "bucket",
+		"resource_name": "public-bucket",
+		"full_name": "organization/123456789012/project/test-project/bucket/public-bucket/",
+		"rule_index": 0,
+		"rule_name": "Bucket acls rule to search for public buckets",
+		"violation_type": "BUCKET_VIOLATION",
+		"violation_data": {"role": "READER", "entity": "allUsers", "full_name": "organization/123456789012/project/test-project/bucket/public-bucket/"},
+		"resource_data": "{\"bucket\": \"public-bucket\"}"
+	}`
+	iamViolation = `{
+		"resource_id": "test-project",
+		"resource_type": "project",
+		"resource_name": "test-project",
+		"full_name": "organization/123456789012/folder/987654321/project/test-project/",
+		"rule_index": 1,
+		"rule_name": "Allow only members of the organization",
+		"violation_type": "ADDED",
+		"violation_data": {"role": "roles/editor", "member": "user:attacker@gmail.com", "full_name": "organization/123456789012/folder/987654321/project/test-project/"},
+		"resource_data": "{\"bindings\": []}"
+	}`
+	firewallViolation = `{
+		"resource_id": "6190685430815455733",
+		"resource_type": "firewall",
+		"resource_name": "default-allow-ssh",
+		"full_name": "organization/123456789012/project/test-project/firewall/6190685430815455733/",
+		"rule_index": 0,
+		"rule_name": "Block world open ssh",
+		"violation_type": "FIREWALL_BLACKLIST_VIOLATION",
+		"violation_data": {"policy_names": ["default-allow-ssh"], "recommended_actions": {"DELETE_FIREWALL_RULES": ["default-allow-ssh"]}},
+		"resource_data": "{}"
+	}`
+	iamRemovedViolation = `{
+		"resource_id": "test-project",
+		"resource_type": "project",
+		"full_name": "organization/123456789012/project/test-project/",
+		"violation_type": "REMOVED",
+		"violation_data": {"role": "roles/owner", "member": "group:admins@example.com"}
+	}`
+)
+
+func TestName(t *testing.T) {
+	for _, tt := range []struct {
+		name, expected string
+		bytes          []byte
+	}{
+		{name: "bucket", expected: "bucket_violation", bytes: []byte(bucketViolation)},
+		{name: "iam", expected: "iam_policy_violation", bytes: []byte(iamViolation)},
+		{name: "firewall", expected: "firewall_violation", bytes: []byte(firewallViolation)},
+		{name: "unsupported", expected: "", bytes: []byte(iamRemovedViolation)},
+		{name: "not a violation", expected: "", bytes: []byte(`{"finding": {"name": "organizations/123/sources/456/findings/789"}}`)},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (&Finding{}).Name(tt.bytes); got != tt.expected {
+				t.Errorf("%s failed: got:%q want:%q", tt.name, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestReadViolation(t *testing.T) {
+	b, err := New([]byte(bucketViolation))
+	if err != nil {
+		t.Fatalf("failed to read bucket violation: %q", err)
+	}
+	if values := b.CloseBucket(); values.ProjectID != "test-project" || values.BucketName != "public-bucket" {
+		t.Errorf("unexpected close bucket values: %+v", values)
+	}
+
+	i, err := New([]byte(iamViolation))
+	if err != nil {
+		t.Fatalf("failed to read iam violation: %q", err)
+	}
+	values := i.IAMRevoke()
+	if values.ProjectID != "test-project" {
+		t.Errorf("got:%q want:%q", values.ProjectID, "test-project")
+	}
+	if diff := cmp.Diff([]string{"user:attacker@gmail.com"}, values.ExternalMembers); diff != "" {
+		t.Errorf("unexpected members: %s", diff)
+	}
+
+	f, err := New([]byte(firewallViolation))
+	if err != nil {
+		t.Fatalf("failed to read firewall violation: %q", err)
+	}
+	if values := f.OpenFirewall(); values.ProjectID != "test-project" || values.FirewallID != "6190685430815455733" {
+		t.Errorf("unexpected open firewall values: %+v", values)
+	}
+}
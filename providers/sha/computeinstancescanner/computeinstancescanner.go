@@ -4,7 +4,12 @@ import (
 	"encoding/json"
 	"strings"
 
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gce/disableipforwarding"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gce/disableserialport"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gce/enforcehttps"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gce/hardeninstance"
 	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gce/removepublicip"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/kms/enforcecmek"
 	pb "github.com/googlecloudplatform/security-response-automation/compiled/sha/protos"
 	"github.com/googlecloudplatform/security-response-automation/providers/sha"
 )
@@ -43,3 +48,52 @@ func (f *Finding) RemovePublicIP() *removepublicip.Values {
 		InstanceID:   sha.Instance(f.ComputeInstanceScanner.GetFinding().GetResourceName()),
 	}
 }
+
+// DisableSerialPort returns values for the disable serial port automation.
+func (f *Finding) DisableSerialPort() *disableserialport.Values {
+	return &disableserialport.Values{
+		ProjectID:    f.ComputeInstanceScanner.GetFinding().GetSourceProperties().GetProjectID(),
+		InstanceZone: sha.Zone(f.ComputeInstanceScanner.GetFinding().GetResourceName()),
+		InstanceID:   sha.Instance(f.ComputeInstanceScanner.GetFinding().GetResourceName()),
+	}
+}
+
+// DisableIPForwarding returns values for the disable IP forwarding automation.
+func (f *Finding) DisableIPForwarding() *disableipforwarding.Values {
+	return &disableipforwarding.Values{
+		ProjectID:    f.ComputeInstanceScanner.GetFinding().GetSourceProperties().GetProjectID(),
+		InstanceZone: sha.Zone(f.ComputeInstanceScanner.GetFinding().GetResourceName()),
+		InstanceID:   sha.Instance(f.ComputeInstanceScanner.GetFinding().GetResourceName()),
+	}
+}
+
+// HardenInstance returns values for the harden instance automation, hardening only what the
+// finding is about.
+func (f *Finding) HardenInstance() *hardeninstance.Values {
+	category := f.ComputeInstanceScanner.GetFinding().GetCategory()
+	return &hardeninstance.Values{
+		ProjectID:             f.ComputeInstanceScanner.GetFinding().GetSourceProperties().GetProjectID(),
+		InstanceZone:          sha.Zone(f.ComputeInstanceScanner.GetFinding().GetResourceName()),
+		InstanceID:            sha.Instance(f.ComputeInstanceScanner.GetFinding().GetResourceName()),
+		DisableLegacyMetadata: category == "LEGACY_METADATA_ENABLED",
+		EnableShieldedVM:      category == "SHIELDED_VM_DISABLED",
+	}
+}
+
+// EnforceHTTPS returns values for the enforce HTTPS automation.
+func (f *Finding) EnforceHTTPS() *enforcehttps.Values {
+	return &enforcehttps.Values{
+		ProjectID: f.ComputeInstanceScanner.GetFinding().GetSourceProperties().GetProjectID(),
+		ProxyType: sha.ProxyType(f.ComputeInstanceScanner.GetFinding().GetResourceName()),
+		Proxy:     sha.Proxy(f.ComputeInstanceScanner.GetFinding().GetResourceName()),
+	}
+}
+
+// EnforceCMEK returns values for the enforce CMEK automation.
+func (f *Finding) EnforceCMEK() *enforcecmek.Values {
+	return &enforcecmek.Values{
+		ProjectID: f.ComputeInstanceScanner.GetFinding().GetSourceProperties().GetProjectID(),
+		Disk:      sha.Disk(f.ComputeInstanceScanner.GetFinding().GetResourceName()),
+		Zone:      sha.DiskZone(f.ComputeInstanceScanner.GetFinding().GetResourceName()),
+	}
+}
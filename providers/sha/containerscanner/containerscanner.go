@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gke/disabledashboard"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gke/hardenclusternetwork"
 	pb "github.com/googlecloudplatform/security-response-automation/compiled/sha/protos"
 	"github.com/googlecloudplatform/security-response-automation/providers/sha"
 )
@@ -43,3 +44,12 @@ func (f *Finding) DisableDashboard() *disabledashboard.Values {
 		ClusterID: sha.ClusterID(f.Containerscanner.GetFinding().GetResourceName()),
 	}
 }
+
+// HardenClusterNetwork returns values for the harden cluster network automation.
+func (f *Finding) HardenClusterNetwork() *hardenclusternetwork.Values {
+	return &hardenclusternetwork.Values{
+		ProjectID: f.Containerscanner.GetFinding().GetSourceProperties().GetProjectID(),
+		Zone:      sha.ClusterZone(f.Containerscanner.GetFinding().GetResourceName()),
+		ClusterID: sha.ClusterID(f.Containerscanner.GetFinding().GetResourceName()),
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/bigquery/closepublicdataset"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/kms/enforcecmek"
 	pb "github.com/googlecloudplatform/security-response-automation/compiled/sha/protos"
 	"github.com/googlecloudplatform/security-response-automation/providers/sha"
 )
@@ -42,3 +43,12 @@ func (f *Finding) ClosePublicDataset() *closepublicdataset.Values {
 		DatasetID: sha.Dataset(f.DatasetScanner.GetFinding().GetResourceName()),
 	}
 }
+
+// EnforceCMEK returns values for the enforce CMEK automation, for the table or the dataset.
+func (f *Finding) EnforceCMEK() *enforcecmek.Values {
+	return &enforcecmek.Values{
+		ProjectID: f.DatasetScanner.GetFinding().GetSourceProperties().GetProjectID(),
+		DatasetID: sha.Dataset(f.DatasetScanner.GetFinding().GetResourceName()),
+		TableID:   sha.Table(f.DatasetScanner.GetFinding().GetResourceName()),
+	}
+}
@@ -28,15 +28,22 @@ var (
 	// extractInstance is a regex to extract the name of the instance that is on the external uri.
 	extractInstance = regexp.MustCompile(`/instances/(.+)`)
 	// extractDataset is a regex to extract the dataset ID that is on the resource name.
-	extractDataset = regexp.MustCompile(`/datasets/(.+)`)
+	extractDataset = regexp.MustCompile(`/datasets/([^/]+)`)
+	// extractTable is a regex to extract the table ID that is on the resource name.
+	extractTable = regexp.MustCompile(`/datasets/[^/]+/tables/([^/]+)$`)
+	// extractDisk is a regex to extract the zone and name of the disk that is on the resource name.
+	extractDisk = regexp.MustCompile(`/zones/([^/]+)/disks/([^/]+)$`)
 	// extractFirewallID is a regex to extract the firewall ID that is on the resource name.
 	extractFirewallID = regexp.MustCompile(`/global/firewalls/(.*)$`)
-	// extractClusterZone is a regex to extract the zone of the cluster that is on the resource name.
-	extractClusterZone = regexp.MustCompile(`/zones/(.+)/clusters`)
+	// extractClusterZone is a regex to extract the zone, or region of a regional cluster, of the
+	// cluster that is on the resource name.
+	extractClusterZone = regexp.MustCompile(`/(?:zones|locations)/([^/]+)/clusters`)
 	// extractClusterID is a regex to extract the Cluster ID of the cluster that is on the resource name.
 	extractClusterID = regexp.MustCompile(`/clusters/(.+)`)
 	// extractOrganizationID is a regex to extract the organizationID value from a resource string.
 	extractOrganizationID = regexp.MustCompile(`organizations/(.+)/sources`)
+	// extractProxy is a regex to extract the collection and name of the target proxy on the resource name.
+	extractProxy = regexp.MustCompile(`/global/(target(?:Http|Https|Ssl)Proxies)/([^/]+)$`)
 )
 
 // GenericFindingState is a finding that exposes its state.
@@ -64,6 +71,30 @@ func Dataset(resource string) string {
 	return extractDataset.FindStringSubmatch(resource)[1]
 }
 
+// Table returns the ID of the BigQuery table, or an empty string if the resource is a dataset.
+func Table(resource string) string {
+	if m := extractTable.FindStringSubmatch(resource); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// DiskZone returns the zone of the disk.
+func DiskZone(resource string) string {
+	if m := extractDisk.FindStringSubmatch(resource); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// Disk returns the name of the disk.
+func Disk(resource string) string {
+	if m := extractDisk.FindStringSubmatch(resource); m != nil {
+		return m[2]
+	}
+	return ""
+}
+
 // BucketName returns name of the bucket. Resource assumed valid due to prior validate call.
 func BucketName(resource string) string {
 	return strings.Split(resource, resourcePrefix)[1]
@@ -74,7 +105,7 @@ func FirewallID(resource string) string {
 	return extractFirewallID.FindStringSubmatch(resource)[1]
 }
 
-// ClusterZone returns the zone of the cluster.
+// ClusterZone returns the zone of the cluster, or its region if it's a regional cluster.
 func ClusterZone(resource string) string {
 	return extractClusterZone.FindStringSubmatch(resource)[1]
 }
@@ -88,3 +119,20 @@ func ClusterID(resource string) string {
 func OrganizationID(resource string) string {
 	return extractOrganizationID.FindStringSubmatch(resource)[1]
 }
+
+// ProxyType returns the collection of the target proxy, i.e. "targetHttpsProxies", or an empty
+// string if the resource isn't a target proxy.
+func ProxyType(resource string) string {
+	if m := extractProxy.FindStringSubmatch(resource); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// Proxy returns the name of the target proxy.
+func Proxy(resource string) string {
+	if m := extractProxy.FindStringSubmatch(resource); m != nil {
+		return m[2]
+	}
+	return ""
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"strings"
 
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gce/disableunusedfirewall"
 	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gce/openfirewall"
 	pb "github.com/googlecloudplatform/security-response-automation/compiled/sha/protos"
 	"github.com/googlecloudplatform/security-response-automation/providers/sha"
@@ -42,3 +43,11 @@ func (f *Finding) OpenFirewall() *openfirewall.Values {
 		FirewallID: sha.FirewallID(f.FirewallScanner.GetFinding().GetResourceName()),
 	}
 }
+
+// DisableUnusedFirewall returns values for the disable unused firewall automation.
+func (f *Finding) DisableUnusedFirewall() *disableunusedfirewall.Values {
+	return &disableunusedfirewall.Values{
+		ProjectID:  f.FirewallScanner.GetFinding().GetSourceProperties().GetProjectId(),
+		FirewallID: sha.FirewallID(f.FirewallScanner.GetFinding().GetResourceName()),
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"strings"
 
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/iam/removegroupmembers"
 	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/iam/removenonorgmembers"
 	pb "github.com/googlecloudplatform/security-response-automation/compiled/sha/protos"
 )
@@ -40,3 +41,10 @@ func (f *Finding) RemoveNonOrgMembers() *removenonorgmembers.Values {
 		ProjectID: f.IAMScanner.GetFinding().GetSourceProperties().GetProjectID(),
 	}
 }
+
+// RemoveExternalGroupMembers returns values for the remove external group members automation.
+func (f *Finding) RemoveExternalGroupMembers() *removegroupmembers.Values {
+	return &removegroupmembers.Values{
+		ProjectID: f.IAMScanner.GetFinding().GetSourceProperties().GetProjectID(),
+	}
+}
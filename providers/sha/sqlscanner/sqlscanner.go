@@ -4,8 +4,10 @@ import (
 	"encoding/json"
 	"strings"
 
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/cloud-sql/enablebackups"
 	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/cloud-sql/removepublic"
 	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/cloud-sql/requiressl"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/cloud-sql/secureroot"
 	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/cloud-sql/updatepassword"
 	pb "github.com/googlecloudplatform/security-response-automation/compiled/sha/protos"
 	"github.com/googlecloudplatform/security-response-automation/providers/sha"
@@ -68,6 +70,22 @@ func (f *Finding) UpdatePassword() (*updatepassword.Values, error) {
 	}, nil
 }
 
+// SecureRoot returns values for the secure root automation.
+func (f *Finding) SecureRoot() *secureroot.Values {
+	return &secureroot.Values{
+		ProjectID:    f.SQLScanner.GetFinding().GetSourceProperties().GetProjectID(),
+		InstanceName: sha.Instance(f.SQLScanner.GetFinding().GetResourceName()),
+	}
+}
+
+// EnableBackups returns values for the enable backups automation.
+func (f *Finding) EnableBackups() *enablebackups.Values {
+	return &enablebackups.Values{
+		ProjectID:    f.SQLScanner.GetFinding().GetSourceProperties().GetProjectID(),
+		InstanceName: sha.Instance(f.SQLScanner.GetFinding().GetResourceName()),
+	}
+}
+
 // RequireSSL returns values for the require SSL automation.
 func (f *Finding) RequireSSL() *requiressl.Values {
 	return &requiressl.Values{
@@ -68,6 +68,10 @@ func TestReadFindingUpdatePassword(t *testing.T) {
 			if err == nil && r != nil && values.Password == "" {
 				t.Errorf("%s failed: got:%q", tt.name, values.Password)
 			}
+			secure := r.SecureRoot()
+			if err == nil && r != nil && (secure.InstanceName != tt.instanceName || secure.ProjectID != tt.projectID) {
+				t.Errorf("%s failed: got:%q %q want:%q %q", tt.name, secure.ProjectID, secure.InstanceName, tt.projectID, tt.instanceName)
+			}
 		})
 	}
 }
@@ -185,3 +189,32 @@ func TestReadFindingRemovePublic(t *testing.T) {
 		})
 	}
 }
+
+func TestReadFindingEnableBackups(t *testing.T) {
+	const autoBackupDisabled = `{
+		"notificationConfigName": "organizations/1055058813388/notificationConfigs/noticonf-active-001-id",
+		"finding": {
+			"name": "organizations/1055058813388/sources/1986930501971458034/findings/1a2b3c4d5e6f",
+			"parent": "organizations/1055058813388/sources/1986930501971458034",
+			"resourceName": "//cloudsql.googleapis.com/projects/sha-resources-20191002/instances/no-backups",
+			"state": "ACTIVE",
+			"category": "SQL_AUTO_BACKUP_DISABLED",
+			"sourceProperties": {
+				"ProjectId": "sha-resources-20191002",
+				"ScannerName": "SQL_SCANNER"
+			},
+			"eventTime": "2019-10-25T23:20:25.280Z"
+		}
+	}`
+	r, err := New([]byte(autoBackupDisabled))
+	if err != nil {
+		t.Fatalf("failed to read finding: %q", err)
+	}
+	if name := r.Name([]byte(autoBackupDisabled)); name != "sql_auto_backup_disabled" {
+		t.Errorf("got name %q want %q", name, "sql_auto_backup_disabled")
+	}
+	values := r.EnableBackups()
+	if values.ProjectID != "sha-resources-20191002" || values.InstanceName != "no-backups" {
+		t.Errorf("got %q %q want %q %q", values.ProjectID, values.InstanceName, "sha-resources-20191002", "no-backups")
+	}
+}
@@ -5,7 +5,10 @@ import (
 	"strings"
 
 	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gcs/closebucket"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gcs/closestagingbucket"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gcs/enablebucketlogging"
 	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gcs/enablebucketonlypolicy"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/kms/enforcecmek"
 	pb "github.com/googlecloudplatform/security-response-automation/compiled/sha/protos"
 	"github.com/googlecloudplatform/security-response-automation/providers/sha"
 )
@@ -51,3 +54,27 @@ func (f *Finding) CloseBucket() *closebucket.Values {
 		BucketName: sha.BucketName(f.StorageScanner.GetFinding().GetResourceName()),
 	}
 }
+
+// CloseStagingBucket returns values for the close staging bucket automation.
+func (f *Finding) CloseStagingBucket() *closestagingbucket.Values {
+	return &closestagingbucket.Values{
+		ProjectID:  f.StorageScanner.GetFinding().GetSourceProperties().GetProjectId(),
+		BucketName: sha.BucketName(f.StorageScanner.GetFinding().GetResourceName()),
+	}
+}
+
+// EnableBucketLogging returns values for the enable bucket logging automation.
+func (f *Finding) EnableBucketLogging() *enablebucketlogging.Values {
+	return &enablebucketlogging.Values{
+		ProjectID:  f.StorageScanner.GetFinding().GetSourceProperties().GetProjectId(),
+		BucketName: sha.BucketName(f.StorageScanner.GetFinding().GetResourceName()),
+	}
+}
+
+// EnforceCMEK returns values for the enforce CMEK automation.
+func (f *Finding) EnforceCMEK() *enforcecmek.Values {
+	return &enforcecmek.Values{
+		ProjectID: f.StorageScanner.GetFinding().GetSourceProperties().GetProjectId(),
+		Bucket:    sha.BucketName(f.StorageScanner.GetFinding().GetResourceName()),
+	}
+}
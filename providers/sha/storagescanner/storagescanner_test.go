@@ -121,8 +121,58 @@ func TestReadFindingCloseBucket(t *testing.T) {
 				if values.ProjectID != tt.projectID {
 					t.Errorf("%s failed: got:%q want:%q", tt.name, values.ProjectID, tt.projectID)
 				}
+				staging := r.CloseStagingBucket()
+				if staging.BucketName != tt.bucket || staging.ProjectID != tt.projectID {
+					t.Errorf("%s failed: got:%+v want bucket %q in project %q", tt.name, staging, tt.bucket, tt.projectID)
+				}
 			}
 
 		})
 	}
 }
+
+func TestReadFindingEnableBucketLogging(t *testing.T) {
+	const (
+		storageScanner = `{
+			"notificationConfigName": "organizations/154584661726/notificationConfigs/sampleConfigId",
+			"finding": {
+				"name": "organizations/154584661726/sources/2673592633662526977/findings/0b1c3f52631d61da6117a3772137c270",
+				"parent": "organizations/154584661726/sources/2673592633662526977",
+				"resourceName": "//storage.googleapis.com/bucket-without-logging",
+				"state": "ACTIVE",
+				"category": "BUCKET_LOGGING_DISABLED",
+				"externalUri": "https://console.cloud.google.com/storage/browser/bucket-without-logging",
+				"sourceProperties": {
+					"ReactivationCount": 0.0,
+					"SeverityLevel": "Low",
+					"ProjectId": "aerial-jigsaw-235219",
+					"ScannerName": "STORAGE_SCANNER",
+					"ScanRunId": "2019-09-23T10:20:27.204-07:00",
+					"Explanation": "There is a storage bucket that does not have logging enabled."
+				},
+				"eventTime": "2019-09-23T17:20:27.204Z",
+				"createTime": "2019-09-23T17:20:27.934Z"
+			}
+		}`
+	)
+	for _, tt := range []struct {
+		name, bucket, projectID string
+		bytes                   []byte
+	}{
+		{name: "read", bucket: "bucket-without-logging", projectID: "aerial-jigsaw-235219", bytes: []byte(storageScanner)},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := New(tt.bytes)
+			if err != nil {
+				t.Fatalf("%s failed: %q", tt.name, err)
+			}
+			values := r.EnableBucketLogging()
+			if values.BucketName != tt.bucket {
+				t.Errorf("%s failed: got:%q want:%q", tt.name, values.BucketName, tt.bucket)
+			}
+			if values.ProjectID != tt.projectID {
+				t.Errorf("%s failed: got:%q want:%q", tt.name, values.ProjectID, tt.projectID)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+// Package alert represents alerts received from a SIEM.
+package alert
+
+import (
+	"encoding/json"
+
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/containment/lockdownproject"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gce/blockegress"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gce/createsnapshot"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gce/openfirewall"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gce/removeloadbalancer"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gce/removepublicip"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/gcs/closebucket"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/iam/removegroupmembers"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/iam/revoke"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/kms/disablekeyversion"
+	"github.com/googlecloudplatform/security-response-automation/cloudfunctions/managed-clusters/securecluster"
+	pb "github.com/googlecloudplatform/security-response-automation/compiled/siem/protos"
+	"github.com/googlecloudplatform/security-response-automation/providers/siem"
+)
+
+// Finding represents this finding.
+type Finding struct {
+	Alert *pb.Alert
+}
+
+// Name returns the rule name of the alert.
+func (f *Finding) Name(b []byte) string {
+	ff, err := New(b)
+	if err != nil {
+		return ""
+	}
+	category := ff.Alert.GetSiemAlert().GetCategory()
+	if !siem.Categories[category] {
+		return ""
+	}
+	return "siem_" + category
+}
+
+// New returns a new finding.
+func New(b []byte) (*Finding, error) {
+	var f Finding
+	if err := json.Unmarshal(b, &f.Alert); err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+// CreateSnapshot returns values for the create snapshot automation.
+func (f *Finding) CreateSnapshot() *createsnapshot.Values {
+	r := f.Alert.GetSiemAlert().GetResource()
+	return &createsnapshot.Values{
+		ProjectID: r.GetProjectId(),
+		RuleName:  f.Alert.GetSiemAlert().GetCategory(),
+		Instance:  r.GetInstance(),
+		Zone:      r.GetZone(),
+	}
+}
+
+// RemovePublicIP returns values for the remove public IP automation.
+func (f *Finding) RemovePublicIP() *removepublicip.Values {
+	r := f.Alert.GetSiemAlert().GetResource()
+	return &removepublicip.Values{
+		ProjectID:    r.GetProjectId(),
+		InstanceZone: r.GetZone(),
+		InstanceID:   r.GetInstance(),
+	}
+}
+
+// RemoveLoadBalancer returns values for the remove load balancer automation.
+func (f *Finding) RemoveLoadBalancer() *removeloadbalancer.Values {
+	r := f.Alert.GetSiemAlert().GetResource()
+	return &removeloadbalancer.Values{
+		ProjectID: r.GetProjectId(),
+		Zone:      r.GetZone(),
+		Instance:  r.GetInstance(),
+	}
+}
+
+// IAMRevoke returns values for the IAM revoke automation.
+func (f *Finding) IAMRevoke() *revoke.Values {
+	r := f.Alert.GetSiemAlert().GetResource()
+	return &revoke.Values{
+		ProjectID:       r.GetProjectId(),
+		ExternalMembers: r.GetMembers(),
+	}
+}
+
+// RemoveExternalGroupMembers returns values for the remove external group members automation.
+func (f *Finding) RemoveExternalGroupMembers() *removegroupmembers.Values {
+	return &removegroupmembers.Values{
+		ProjectID: f.Alert.GetSiemAlert().GetResource().GetProjectId(),
+	}
+}
+
+// CloseBucket returns values for the close bucket automation.
+func (f *Finding) CloseBucket() *closebucket.Values {
+	r := f.Alert.GetSiemAlert().GetResource()
+	return &closebucket.Values{
+		ProjectID:  r.GetProjectId(),
+		BucketName: r.GetBucket(),
+	}
+}
+
+// DisableKeyVersion returns values for the disable key version automation.
+func (f *Finding) DisableKeyVersion() *disablekeyversion.Values {
+	r := f.Alert.GetSiemAlert().GetResource()
+	return &disablekeyversion.Values{
+		ProjectID:  r.GetProjectId(),
+		KeyVersion: r.GetCryptoKeyVersion(),
+	}
+}
+
+// OpenFirewall returns values for the remediate firewall automation.
+func (f *Finding) OpenFirewall() *openfirewall.Values {
+	r := f.Alert.GetSiemAlert().GetResource()
+	return &openfirewall.Values{
+		ProjectID:  r.GetProjectId(),
+		FirewallID: r.GetFirewallId(),
+	}
+}
+
+// BlockRDP returns values for the remediate firewall automation blocking RDP from the alert's
+// source IPs.
+func (f *Finding) BlockRDP() *openfirewall.Values {
+	r := f.Alert.GetSiemAlert().GetResource()
+	ranges := []string{}
+	for _, ip := range r.GetSourceIps() {
+		ranges = append(ranges, ip+"/32")
+	}
+	return &openfirewall.Values{
+		ProjectID:    r.GetProjectId(),
+		SourceRanges: ranges,
+		Action:       "block_rdp",
+	}
+}
+
+// BlockEgress returns values for the block egress automation.
+func (f *Finding) BlockEgress() *blockegress.Values {
+	r := f.Alert.GetSiemAlert().GetResource()
+	return &blockegress.Values{
+		ProjectID: r.GetProjectId(),
+		Zone:      r.GetZone(),
+		Instance:  r.GetInstance(),
+	}
+}
+
+// LockdownProject returns values for the lockdown project automation.
+func (f *Finding) LockdownProject() *lockdownproject.Values {
+	return &lockdownproject.Values{
+		ProjectID: f.Alert.GetSiemAlert().GetResource().GetProjectId(),
+	}
+}
+
+// SecureManagedCluster returns values for the secure managed cluster automation. The alert names
+// either a Composer environment or a Dataproc cluster.
+func (f *Finding) SecureManagedCluster() *securecluster.Values {
+	r := f.Alert.GetSiemAlert().GetResource()
+	values := &securecluster.Values{
+		ProjectID: r.GetProjectId(),
+		Region:    r.GetRegion(),
+		Kind:      securecluster.KindDataproc,
+		Name:      r.GetDataprocCluster(),
+	}
+	if r.GetComposerEnvironment() != "" {
+		values.Kind = securecluster.KindComposer
+		values.Name = r.GetComposerEnvironment()
+	}
+	return values
+}
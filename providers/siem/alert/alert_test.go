@@ -0,0 +1,79 @@
+package alert
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestReadAlert(t *testing.T) {
+	const (
+		compromisedInstance = `{"siemAlert": {"source": "chronicle", "id": "de_1", "category": "compromised_instance", "resource": {"projectId": "test-project", "zone": "us-central1-a", "instance": "bad-instance"}}}`
+		externalMember      = `{"siemAlert": {"source": "chronicle", "id": "de_2", "category": "external_member", "resource": {"projectId": "test-project", "members": ["user:attacker@gmail.com"]}}}`
+		keyMisuse           = `{"siemAlert": {"source": "chronicle", "id": "de_3", "category": "key_misuse", "resource": {"projectId": "kms-project", "cryptoKeyVersion": "projects/kms-project/locations/us/keyRings/sra/cryptoKeys/app/cryptoKeyVersions/2"}}}`
+		rdpBruteForce       = `{"siemAlert": {"source": "splunk", "id": "4", "category": "rdp_brute_force", "resource": {"projectId": "test-project", "zone": "us-central1-a", "instance": "windows-1", "sourceIps": ["198.51.100.7", "203.0.113.9"]}}}`
+		publicComposer      = `{"siemAlert": {"source": "splunk", "id": "5", "category": "public_managed_cluster", "resource": {"projectId": "test-project", "region": "us-central1", "composerEnvironment": "airflow"}}}`
+	)
+	for _, tt := range []struct {
+		name, alert, expectedName string
+	}{
+		{name: "compromised instance", alert: compromisedInstance, expectedName: "siem_compromised_instance"},
+		{name: "external member", alert: externalMember, expectedName: "siem_external_member"},
+		{name: "key misuse", alert: keyMisuse, expectedName: "siem_key_misuse"},
+		{name: "rdp brute force", alert: rdpBruteForce, expectedName: "siem_rdp_brute_force"},
+		{name: "public managed cluster", alert: publicComposer, expectedName: "siem_public_managed_cluster"},
+		{name: "unsupported", alert: `{"siemAlert": {"category": "unknown"}}`, expectedName: ""},
+		{name: "not an alert", alert: `{"finding": {"category": "PUBLIC_BUCKET_ACL"}}`, expectedName: ""},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (&Finding{}).Name([]byte(tt.alert)); got != tt.expectedName {
+				t.Errorf("%s failed: got:%q want:%q", tt.name, got, tt.expectedName)
+			}
+		})
+	}
+
+	f, err := New([]byte(compromisedInstance))
+	if err != nil {
+		t.Fatalf("failed to read alert: %q", err)
+	}
+	if v := f.RemovePublicIP(); v.ProjectID != "test-project" || v.InstanceZone != "us-central1-a" || v.InstanceID != "bad-instance" {
+		t.Errorf("unexpected remove public ip values: %+v", v)
+	}
+	if v := f.CreateSnapshot(); v.Instance != "bad-instance" || v.RuleName != "compromised_instance" {
+		t.Errorf("unexpected create snapshot values: %+v", v)
+	}
+	if v := f.RemoveLoadBalancer(); v.ProjectID != "test-project" || v.Zone != "us-central1-a" || v.Instance != "bad-instance" {
+		t.Errorf("unexpected remove load balancer values: %+v", v)
+	}
+	f, err = New([]byte(externalMember))
+	if err != nil {
+		t.Fatalf("failed to read alert: %q", err)
+	}
+	if diff := cmp.Diff([]string{"user:attacker@gmail.com"}, f.IAMRevoke().ExternalMembers); diff != "" {
+		t.Errorf("unexpected members: %s", diff)
+	}
+	f, err = New([]byte(keyMisuse))
+	if err != nil {
+		t.Fatalf("failed to read alert: %q", err)
+	}
+	if v := f.DisableKeyVersion(); v.ProjectID != "kms-project" || v.KeyVersion != "projects/kms-project/locations/us/keyRings/sra/cryptoKeys/app/cryptoKeyVersions/2" {
+		t.Errorf("unexpected disable key version values: %+v", v)
+	}
+	f, err = New([]byte(rdpBruteForce))
+	if err != nil {
+		t.Fatalf("failed to read alert: %q", err)
+	}
+	if v := f.BlockRDP(); v.ProjectID != "test-project" || v.Action != "block_rdp" {
+		t.Errorf("unexpected block rdp values: %+v", v)
+	}
+	if diff := cmp.Diff([]string{"198.51.100.7/32", "203.0.113.9/32"}, f.BlockRDP().SourceRanges); diff != "" {
+		t.Errorf("unexpected source ranges: %s", diff)
+	}
+	f, err = New([]byte(publicComposer))
+	if err != nil {
+		t.Fatalf("failed to read alert: %q", err)
+	}
+	if v := f.SecureManagedCluster(); v.ProjectID != "test-project" || v.Region != "us-central1" || v.Kind != "composer" || v.Name != "airflow" {
+		t.Errorf("unexpected secure managed cluster values: %+v", v)
+	}
+}
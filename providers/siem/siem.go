@@ -0,0 +1,98 @@
+package siem
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	pb "github.com/googlecloudplatform/security-response-automation/compiled/siem/protos"
+	"github.com/pkg/errors"
+)
+
+const (
+	// chronicleDetectionType is the type of Chronicle rule detections.
+	chronicleDetectionType = "RULE_DETECTION"
+	// chronicleCategoryLabel is the rule label holding the alert category.
+	chronicleCategoryLabel = "sra_category"
+)
+
+// Categories contains the supported alert categories.
+var Categories = map[string]bool{
+	"compromised_instance":   true,
+	"compromised_project":    true,
+	"data_exfiltration":      true,
+	"external_member":        true,
+	"public_bucket":          true,
+	"open_firewall":          true,
+	"key_misuse":             true,
+	"rdp_brute_force":        true,
+	"public_managed_cluster": true,
+}
+
+// FromChronicle converts a Chronicle rule detection into an alert.
+//
+// The alert category is read from the rule's "sra_category" label and the affected resource from
+// the detection fields "project_id", "zone", "instance", "bucket", "firewall_id", "member",
+// "crypto_key_version", "source_ip", "region", "composer_environment" and "dataproc_cluster". The
+// "member" and "source_ip" fields can be repeated.
+func FromChronicle(d *pb.ChronicleDetection) (*pb.Alert, error) {
+	if d.GetType() != chronicleDetectionType || len(d.GetDetection()) == 0 {
+		return nil, errors.Errorf("unsupported chronicle detection type %q", d.GetType())
+	}
+	detection := d.GetDetection()[0]
+	category := ""
+	for _, l := range detection.GetRuleLabels() {
+		if l.GetKey() == chronicleCategoryLabel {
+			category = l.GetValue()
+		}
+	}
+	if !Categories[category] {
+		return nil, errors.Errorf("rule %q has unsupported category %q", detection.GetRuleName(), category)
+	}
+	r := &pb.Alert_Resource{}
+	for _, f := range detection.GetDetectionFields() {
+		switch f.GetKey() {
+		case "project_id":
+			r.ProjectId = f.GetValue()
+		case "zone":
+			r.Zone = f.GetValue()
+		case "instance":
+			r.Instance = f.GetValue()
+		case "bucket":
+			r.Bucket = f.GetValue()
+		case "firewall_id":
+			r.FirewallId = f.GetValue()
+		case "member":
+			r.Members = append(r.Members, f.GetValue())
+		case "crypto_key_version":
+			r.CryptoKeyVersion = f.GetValue()
+		case "source_ip":
+			r.SourceIps = append(r.SourceIps, f.GetValue())
+		case "region":
+			r.Region = f.GetValue()
+		case "composer_environment":
+			r.ComposerEnvironment = f.GetValue()
+		case "dataproc_cluster":
+			r.DataprocCluster = f.GetValue()
+		}
+	}
+	return &pb.Alert{
+		SiemAlert: &pb.Alert_SIEMAlert{
+			Source:    "chronicle",
+			Id:        d.GetId(),
+			Category:  category,
+			EventTime: d.GetDetectionTime(),
+			Resource:  r,
+		},
+	}, nil
+}
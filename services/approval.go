@@ -0,0 +1,173 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"github.com/pkg/errors"
+)
+
+// ApprovalPublisher contains minimum interface required by the approval service.
+type ApprovalPublisher interface {
+	Publish(context.Context, string, *pubsub.Message) (string, error)
+}
+
+// ApprovalStore contains minimum interface required to keep approval requests and approvals.
+type ApprovalStore interface {
+	SaveApprovalRequest(context.Context, *ApprovalRequest) error
+	ApprovalRequest(context.Context, string) (*ApprovalRequest, error)
+	RecordApproval(context.Context, string, string) error
+	Approvals(context.Context, string) ([]string, error)
+}
+
+// Approval service gates automations that must be approved by a person before they act.
+//
+// Approvals are never read from the automation's message. They are recorded by Approve once the
+// approver has been authenticated and are looked up in the store, so publishing to an
+// automation's topic is not enough to approve its changes.
+type Approval struct {
+	publisher ApprovalPublisher
+	store     ApprovalStore
+	topic     string
+	approvers map[string]bool
+}
+
+// ApprovalRequest is published when an automation is waiting to be approved.
+//
+// To approve, an approver calls the Approve function with the request's ID. Once enough approvers
+// have approved, Values are published to Topic to run the automation again.
+type ApprovalRequest struct {
+	ID       string          `json:"id"`
+	Time     string          `json:"time"`
+	Action   string          `json:"action"`
+	Resource string          `json:"resource"`
+	Changes  []string        `json:"changes"`
+	Required int             `json:"required_approvals"`
+	Topic    string          `json:"topic"`
+	Values   json.RawMessage `json:"values"`
+}
+
+// NewApproval returns an approval service publishing requests to topic and keeping them in store.
+// Only approvals from the given approvers are accepted.
+func NewApproval(publisher ApprovalPublisher, store ApprovalStore, topic string, approvers []string) *Approval {
+	a := &Approval{publisher: publisher, store: store, topic: topic, approvers: make(map[string]bool)}
+	for _, approver := range approvers {
+		if approver = strings.ToLower(strings.TrimSpace(approver)); approver != "" {
+			a.approvers[approver] = true
+		}
+	}
+	return a
+}
+
+// ApprovalID returns an ID bound to the action, resource and changes of a request so an approval
+// cannot be reused to approve a different change.
+func ApprovalID(action, resource string, changes []string) string {
+	sorted := append([]string{}, changes...)
+	sort.Strings(sorted)
+	sum := sha256.Sum256([]byte(action + "\n" + resource + "\n" + strings.Join(sorted, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// Approved returns an error unless the request has been approved by at least required distinct
+// configured approvers.
+func (a *Approval) Approved(ctx context.Context, id string, required int) error {
+	if len(a.approvers) < required {
+		return fmt.Errorf("%d approvals required but only %d approvers configured", required, len(a.approvers))
+	}
+	approved, err := a.approved(ctx, id)
+	if err != nil {
+		return err
+	}
+	if approved < required {
+		return fmt.Errorf("request %q has %d of %d required approvals", id, approved, required)
+	}
+	return nil
+}
+
+// Approve records the approval of the request by an authenticated approver. Once the request has
+// been approved by enough approvers its values are published to the automation's topic and true is
+// returned.
+func (a *Approval) Approve(ctx context.Context, id, email string) (bool, error) {
+	email = strings.ToLower(email)
+	if !a.approvers[email] {
+		return false, fmt.Errorf("%q is not an approver", email)
+	}
+	req, err := a.store.ApprovalRequest(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	if req == nil {
+		return false, fmt.Errorf("no approval request %q", id)
+	}
+	if err := a.store.RecordApproval(ctx, id, email); err != nil {
+		return false, err
+	}
+	approved, err := a.approved(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	if approved < req.Required {
+		return false, nil
+	}
+	if _, err := a.publisher.Publish(ctx, req.Topic, &pubsub.Message{Data: req.Values}); err != nil {
+		return false, errors.Wrapf(err, "failed to publish approved request to %q", req.Topic)
+	}
+	return true, nil
+}
+
+// Request stores and publishes an approval request.
+func (a *Approval) Request(ctx context.Context, req *ApprovalRequest) error {
+	if a.topic == "" {
+		return errors.New("approval topic not configured")
+	}
+	if req.Time == "" {
+		req.Time = time.Now().UTC().Format(time.RFC3339)
+	}
+	if err := a.store.SaveApprovalRequest(ctx, req); err != nil {
+		return err
+	}
+	b, err := json.Marshal(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal approval request")
+	}
+	if _, err := a.publisher.Publish(ctx, a.topic, &pubsub.Message{Data: b}); err != nil {
+		return errors.Wrapf(err, "failed to publish approval request to %q", a.topic)
+	}
+	return nil
+}
+
+// approved returns the number of distinct configured approvers that approved the request.
+func (a *Approval) approved(ctx context.Context, id string) (int, error) {
+	emails, err := a.store.Approvals(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	approved := make(map[string]bool)
+	for _, email := range emails {
+		if email = strings.ToLower(email); a.approvers[email] {
+			approved[email] = true
+		}
+	}
+	return len(approved), nil
+}
@@ -0,0 +1,79 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+)
+
+func TestApprove(t *testing.T) {
+	const id = "request"
+	values := []byte(`{"Resource":"organizations/123"}`)
+	for _, tt := range []struct {
+		name             string
+		approvals        []string
+		approver         string
+		request          bool
+		expectedError    bool
+		expectedApproved bool
+	}{
+		{name: "first of two approvals", approver: "alice@foo.com", request: true},
+		{name: "second approval", approvals: []string{"carol@foo.com"}, approver: "Alice@foo.com", request: true, expectedApproved: true},
+		{name: "same approver twice", approvals: []string{"alice@foo.com"}, approver: "alice@foo.com", request: true},
+		{name: "not an approver", approvals: []string{"carol@foo.com"}, approver: "mallory@gmail.com", request: true, expectedError: true},
+		{name: "unknown request", approver: "alice@foo.com", expectedError: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			psStub := &stubs.PubSubStub{}
+			state := NewState(&stubs.StorageStub{}, "state")
+			approval := NewApproval(NewPubSub(psStub), state, "approvals", []string{"alice@foo.com", "carol@foo.com"})
+			if tt.request {
+				if err := approval.Request(ctx, &ApprovalRequest{ID: id, Required: 2, Topic: "automation", Values: values}); err != nil {
+					t.Fatalf("%s failed to request approval: %q", tt.name, err)
+				}
+			}
+			for _, email := range tt.approvals {
+				if err := state.RecordApproval(ctx, id, email); err != nil {
+					t.Fatalf("%s failed to record approval: %q", tt.name, err)
+				}
+			}
+			psStub.PublishedMessage = nil
+			approved, err := approval.Approve(ctx, id, tt.approver)
+			if (err != nil) != tt.expectedError {
+				t.Fatalf("%s failed: got error %v, want error %t", tt.name, err, tt.expectedError)
+			}
+			if approved != tt.expectedApproved {
+				t.Errorf("%s failed: approved %t want %t", tt.name, approved, tt.expectedApproved)
+			}
+			if !tt.expectedApproved {
+				if psStub.PublishedMessage != nil {
+					t.Errorf("%s failed: values published before approval", tt.name)
+				}
+				return
+			}
+			if diff := cmp.Diff(values, psStub.PublishedMessage.Data); diff != "" {
+				t.Errorf("%s failed, difference:%+v", tt.name, diff)
+			}
+			if err := approval.Approved(ctx, id, 2); err != nil {
+				t.Errorf("%s failed: %q", tt.name, err)
+			}
+		})
+	}
+}
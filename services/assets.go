@@ -0,0 +1,149 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/googlecloudplatform/security-response-automation/clients/assets"
+	"github.com/pkg/errors"
+)
+
+// AssetsClient contains the minimum interface required by the assets service.
+type AssetsClient interface {
+	SearchResources(ctx context.Context, scope, query string, assetTypes []string) ([]*assets.Asset, error)
+}
+
+// assetTypes are the Cloud Asset Inventory types of the kinds of resources that can be located or
+// listed.
+var assetTypes = map[string][]string{
+	KindInstance:       {"compute.googleapis.com/Instance"},
+	KindBucket:         {"storage.googleapis.com/Bucket"},
+	KindForwardingRule: {"compute.googleapis.com/ForwardingRule", "compute.googleapis.com/GlobalForwardingRule"},
+	KindDataset:        {"bigquery.googleapis.com/Dataset"},
+	KindSQLInstance:    {"sqladmin.googleapis.com/Instance"},
+	KindCluster:        {"container.googleapis.com/Cluster"},
+}
+
+// Assets locates the resources findings only name by display name or IP address with Cloud Asset
+// Inventory so automations can still act on them.
+type Assets struct {
+	client   AssetsClient
+	resolver *Resolver
+}
+
+// NewAssets returns an assets service.
+func NewAssets(client AssetsClient, resolver *Resolver) *Assets {
+	return &Assets{client: client, resolver: resolver}
+}
+
+// FindByName returns the instance, bucket or forwarding rule of the project with the display
+// name. It fails unless exactly one resource of the kind has that name.
+func (a *Assets) FindByName(ctx context.Context, projectID, kind, name string) (*TypedResource, error) {
+	types, ok := assetTypes[kind]
+	if !ok {
+		return nil, errors.Errorf("resources of kind %q can't be located", kind)
+	}
+	results, err := a.client.SearchResources(ctx, "projects/"+projectID, fmt.Sprintf("displayName=%q", name), types)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to search for %s %q", kind, name)
+	}
+	var found []*assets.Asset
+	for _, asset := range results {
+		if asset.DisplayName == name {
+			found = append(found, asset)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return nil, errors.Errorf("no %s named %q in project %q", kind, name, projectID)
+	case 1:
+		return a.resolver.Resolve(ctx, found[0].Name)
+	default:
+		return nil, errors.Errorf("%d resources of kind %s are named %q in project %q", len(found), kind, name, projectID)
+	}
+}
+
+// FindByIP returns the instances and forwarding rules of the project using the internal or
+// external IP address.
+func (a *Assets) FindByIP(ctx context.Context, projectID, ip string) ([]*TypedResource, error) {
+	types := append(append([]string{}, assetTypes[KindInstance]...), assetTypes[KindForwardingRule]...)
+	results, err := a.client.SearchResources(ctx, "projects/"+projectID, fmt.Sprintf("%q", ip), types)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to search for %q", ip)
+	}
+	var found []*TypedResource
+	for _, asset := range results {
+		// The search matches text, so only resources with an attribute equal to the IP are kept.
+		if !hasAttribute(asset.AdditionalAttributes, ip) {
+			continue
+		}
+		r, err := a.resolver.Resolve(ctx, asset.Name)
+		if err != nil {
+			return nil, err
+		}
+		found = append(found, r)
+	}
+	return found, nil
+}
+
+// List returns every resource of the kind within the scope, i.e. "organizations/456",
+// "folders/123" or "projects/p".
+func (a *Assets) List(ctx context.Context, scope, kind string) ([]*TypedResource, error) {
+	types, ok := assetTypes[kind]
+	if !ok {
+		return nil, errors.Errorf("resources of kind %q can't be listed", kind)
+	}
+	results, err := a.client.SearchResources(ctx, scope, "", types)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list %s resources of %q", kind, scope)
+	}
+	found := make([]*TypedResource, 0, len(results))
+	for _, asset := range results {
+		r, err := a.resolver.Resolve(ctx, asset.Name)
+		if err != nil {
+			return nil, err
+		}
+		// Bucket names don't include their project.
+		if r.ProjectID == "" && asset.Project != "" {
+			if r.ProjectID, err = a.resolver.resource.ProjectID(ctx, strings.TrimPrefix(asset.Project, "projects/")); err != nil {
+				return nil, err
+			}
+		}
+		found = append(found, r)
+	}
+	return found, nil
+}
+
+// hasAttribute returns whether one of the attributes, or an element of a list attribute, is value.
+func hasAttribute(attributes map[string]interface{}, value string) bool {
+	for _, v := range attributes {
+		switch v := v.(type) {
+		case string:
+			if v == value {
+				return true
+			}
+		case []interface{}:
+			for _, e := range v {
+				if s, ok := e.(string); ok && s == value {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,106 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googlecloudplatform/security-response-automation/clients/assets"
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+)
+
+var stubbedAssets = []*assets.Asset{
+	{
+		Name:                 "//compute.googleapis.com/projects/test-project/zones/us-central1-a/instances/web-1",
+		AssetType:            "compute.googleapis.com/Instance",
+		DisplayName:          "web-1",
+		AdditionalAttributes: map[string]interface{}{"internalIPs": []interface{}{"10.0.0.2"}, "externalIPs": []interface{}{"35.1.2.3"}},
+	},
+	{
+		Name:                 "//compute.googleapis.com/projects/test-project/zones/us-central1-b/instances/web-2",
+		AssetType:            "compute.googleapis.com/Instance",
+		DisplayName:          "web-2",
+		AdditionalAttributes: map[string]interface{}{"internalIPs": []interface{}{"10.0.0.20"}},
+	},
+	{
+		Name:                 "//compute.googleapis.com/projects/test-project/regions/us-central1/forwardingRules/lb",
+		AssetType:            "compute.googleapis.com/ForwardingRule",
+		DisplayName:          "lb",
+		AdditionalAttributes: map[string]interface{}{"IPAddress": "35.1.2.3"},
+	},
+	{
+		Name:        "//storage.googleapis.com/web-1",
+		AssetType:   "storage.googleapis.com/Bucket",
+		DisplayName: "web-1",
+	},
+}
+
+func TestFindByName(t *testing.T) {
+	a := NewAssets(&stubs.AssetsStub{StubbedAssets: stubbedAssets}, NewResolver(NewResource(&stubs.ResourceManagerStub{}, &stubs.StorageStub{})))
+	for _, tt := range []struct {
+		name     string
+		kind     string
+		resource string
+		want     *TypedResource
+		wantErr  bool
+	}{
+		{
+			name:     "instance",
+			kind:     KindInstance,
+			resource: "web-1",
+			want:     &TypedResource{Kind: KindInstance, ProjectID: "test-project", Zone: "us-central1-a", Name: "web-1"},
+		},
+		{name: "bucket", kind: KindBucket, resource: "web-1", want: &TypedResource{Kind: KindBucket, Name: "web-1"}},
+		{
+			name:     "forwarding rule",
+			kind:     KindForwardingRule,
+			resource: "lb",
+			want:     &TypedResource{Kind: KindForwardingRule, ProjectID: "test-project", Region: "us-central1", Name: "lb"},
+		},
+		{name: "not found", kind: KindInstance, resource: "web-3", wantErr: true},
+		{name: "unsupported kind", kind: KindFirewall, resource: "allow-all", wantErr: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := a.FindByName(context.Background(), "test-project", tt.kind, tt.resource)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("%s: got error %v, want error %v", tt.name, err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("%s failed, difference: %v", tt.name, diff)
+			}
+		})
+	}
+}
+
+func TestFindByIP(t *testing.T) {
+	stub := &stubs.AssetsStub{StubbedAssets: stubbedAssets}
+	a := NewAssets(stub, NewResolver(NewResource(&stubs.ResourceManagerStub{}, &stubs.StorageStub{})))
+	got, err := a.FindByIP(context.Background(), "test-project", "35.1.2.3")
+	if err != nil {
+		t.Fatalf("failed to find by ip: %q", err)
+	}
+	want := []*TypedResource{
+		{Kind: KindInstance, ProjectID: "test-project", Zone: "us-central1-a", Name: "web-1"},
+		{Kind: KindForwardingRule, ProjectID: "test-project", Region: "us-central1", Name: "lb"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("find by ip failed, difference: %v", diff)
+	}
+	if diff := cmp.Diff([]string{`"35.1.2.3"`}, stub.SavedQueries); diff != "" {
+		t.Errorf("queries differ: %v", diff)
+	}
+}
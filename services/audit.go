@@ -0,0 +1,162 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"sort"
+
+	crm "google.golang.org/api/cloudresourcemanager/v1"
+)
+
+const (
+	// AuditResultSuccess is the result recorded when an automation made its changes.
+	AuditResultSuccess = "success"
+	// AuditResultDryRun is the result recorded when an automation ran in dry run mode.
+	AuditResultDryRun = "dry_run"
+	// AuditResultAlreadyRemediated is the result recorded when an automation finds the resource no
+	// longer needs to be remediated.
+	AuditResultAlreadyRemediated = "already_remediated"
+	// AuditResultSkipped is the result recorded when an automation doesn't apply to the resource,
+	// such as a bucket that isn't a staging bucket, or its configuration turns the change off.
+	AuditResultSkipped = "skipped"
+	// AuditResultMissingPermissions is the result recorded when an automation is not run because
+	// the service account lacks permissions it needs on the resource.
+	AuditResultMissingPermissions = "missing_permissions"
+	// AuditResultFailure is the result recorded when a playbook stopped because one of its steps
+	// failed.
+	AuditResultFailure = "failure"
+	// AuditResultFrozen is the result recorded when a freeze downgraded an automation to dry run
+	// or replaced it by a ticket.
+	AuditResultFrozen = "frozen"
+	// AuditResultStale is the result recorded when a finding older than the max finding age
+	// downgraded an automation to dry run or replaced it by a ticket.
+	AuditResultStale = "stale"
+)
+
+// Outcomes of each member handled by an automation removing members.
+const (
+	MemberRemoved  = "removed"
+	MemberDryRun   = "dry_run"
+	MemberAllowed  = "allowed"
+	MemberNotFound = "not_found"
+)
+
+// AuditRecord is a structured record of the outcome of an automation.
+type AuditRecord struct {
+	Action   string `json:"action"`
+	Resource string `json:"resource"`
+	Result   string `json:"result"`
+	Message  string `json:"message,omitempty"`
+	// Members holds the outcome for each member of the finding, keyed by member.
+	Members map[string]string `json:"members,omitempty"`
+	// PolicyChanges holds the bindings changed when an automation writes an IAM policy.
+	PolicyChanges []BindingChange `json:"policy_changes,omitempty"`
+	// AccessAnalysis explains whether the members removed from a policy keep access through other
+	// bindings.
+	AccessAnalysis []AccessAnalysis `json:"access_analysis,omitempty"`
+	// MissingPermissions holds the permissions the service account lacks to run the automation.
+	MissingPermissions []string `json:"missing_permissions,omitempty"`
+	// Steps holds the outcome of each step of a playbook, in the order they're run.
+	Steps []StepOutcome `json:"steps,omitempty"`
+	// Identity is the service account the automation acted as if it impersonated one rather than
+	// the function's own.
+	Identity string `json:"identity,omitempty"`
+	// CorrelationID traces the remediation of the finding the automation was run for.
+	CorrelationID string `json:"correlation_id,omitempty"`
+}
+
+// StepOutcome is the status of an automation run as a step of a playbook.
+type StepOutcome struct {
+	Action string `json:"action"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Changes made to the bindings of an IAM policy.
+const (
+	BindingAdded   = "added"
+	BindingRemoved = "removed"
+)
+
+// BindingChange is a member added to or removed from a role of an IAM policy.
+type BindingChange struct {
+	Role   string `json:"role"`
+	Member string `json:"member"`
+	Change string `json:"change"`
+}
+
+// MemberOutcomes returns the outcome for each of the finding's members given the members that were
+// disallowed, the disallowed members still found in the policy and the members removed from it.
+func MemberOutcomes(members, disallowed, present, removed []string, dryRun bool) map[string]string {
+	disallowedSet := memberSet(disallowed)
+	presentSet := memberSet(present)
+	removedSet := memberSet(removed)
+	outcomes := make(map[string]string, len(members))
+	for _, m := range members {
+		key := NormalizeMember(m)
+		switch {
+		case !disallowedSet[key]:
+			outcomes[m] = MemberAllowed
+		case !presentSet[key]:
+			outcomes[m] = MemberNotFound
+		case dryRun:
+			outcomes[m] = MemberDryRun
+		case removedSet[key]:
+			outcomes[m] = MemberRemoved
+		default:
+			outcomes[m] = MemberNotFound
+		}
+	}
+	return outcomes
+}
+
+// bindingMembers returns a copy of the members of each role of the bindings so they can be compared
+// after the policy is modified.
+func bindingMembers(bindings []*crm.Binding) map[string][]string {
+	members := make(map[string][]string, len(bindings))
+	for _, b := range bindings {
+		members[b.Role] = append(members[b.Role], b.Members...)
+	}
+	return members
+}
+
+// policyChanges returns the members added to and removed from each role, ordered by role.
+func policyChanges(before, after map[string][]string) []BindingChange {
+	roles := []string{}
+	for role := range before {
+		roles = append(roles, role)
+	}
+	for role := range after {
+		if _, ok := before[role]; !ok {
+			roles = append(roles, role)
+		}
+	}
+	sort.Strings(roles)
+	changes := []BindingChange{}
+	for _, role := range roles {
+		was, is := memberSet(before[role]), memberSet(after[role])
+		for _, m := range before[role] {
+			if !is[NormalizeMember(m)] {
+				changes = append(changes, BindingChange{Role: role, Member: m, Change: BindingRemoved})
+			}
+		}
+		for _, m := range after[role] {
+			if !was[NormalizeMember(m)] {
+				changes = append(changes, BindingChange{Role: role, Member: m, Change: BindingAdded})
+			}
+		}
+	}
+	return changes
+}
@@ -0,0 +1,80 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMemberOutcomes(t *testing.T) {
+	members := []string{"user:a@foo.com", "user:B@gmail.com", "user:c@gmail.com", "user:d@gmail.com"}
+	disallowed := []string{"user:B@gmail.com", "user:c@gmail.com", "user:d@gmail.com"}
+	present := []string{"user:b@gmail.com", "user:c@gmail.com"}
+	for _, tt := range []struct {
+		name    string
+		removed []string
+		dryRun  bool
+		want    map[string]string
+	}{
+		{
+			name:    "removed",
+			removed: present,
+			want: map[string]string{
+				"user:a@foo.com":   MemberAllowed,
+				"user:B@gmail.com": MemberRemoved,
+				"user:c@gmail.com": MemberRemoved,
+				"user:d@gmail.com": MemberNotFound,
+			},
+		},
+		{
+			name:   "dry run",
+			dryRun: true,
+			want: map[string]string{
+				"user:a@foo.com":   MemberAllowed,
+				"user:B@gmail.com": MemberDryRun,
+				"user:c@gmail.com": MemberDryRun,
+				"user:d@gmail.com": MemberNotFound,
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MemberOutcomes(members, disallowed, present, tt.removed, tt.dryRun)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("%s failed, difference: %s", tt.name, diff)
+			}
+		})
+	}
+}
+
+func TestPolicyChanges(t *testing.T) {
+	before := map[string][]string{
+		"roles/editor": {"user:a@foo.com", "user:b@gmail.com"},
+		"roles/owner":  {"user:c@gmail.com"},
+	}
+	after := map[string][]string{
+		"roles/editor": {"user:a@foo.com"},
+		"roles/viewer": {"user:b@gmail.com"},
+	}
+	want := []BindingChange{
+		{Role: "roles/editor", Member: "user:b@gmail.com", Change: BindingRemoved},
+		{Role: "roles/owner", Member: "user:c@gmail.com", Change: BindingRemoved},
+		{Role: "roles/viewer", Member: "user:b@gmail.com", Change: BindingAdded},
+	}
+	if diff := cmp.Diff(want, policyChanges(before, after)); diff != "" {
+		t.Errorf("policyChanges failed, difference: %v", diff)
+	}
+}
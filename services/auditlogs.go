@@ -0,0 +1,168 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+	"google.golang.org/genproto/googleapis/cloud/audit"
+	iam "google.golang.org/genproto/googleapis/iam/v1"
+	iamlogging "google.golang.org/genproto/googleapis/iam/v1/logging"
+)
+
+const (
+	// maxPolicyEntries is the number of policy changes read from a project's audit logs.
+	maxPolicyEntries = 1000
+	// maxActivityEntries is the number of recent changes to a resource returned.
+	maxActivityEntries = 10
+)
+
+// AdminActivity is a change made to a resource as recorded by the Admin Activity audit logs.
+type AdminActivity struct {
+	Time string `json:"time"`
+	// Principal is the email of the account that made the change.
+	Principal string `json:"principal"`
+	// CallerIP is the IP address the change was requested from.
+	CallerIP string `json:"caller_ip,omitempty"`
+	Method   string `json:"method"`
+	Resource string `json:"resource"`
+}
+
+// AuditLogs service reads the Admin Activity audit logs of projects.
+type AuditLogs struct {
+	logs LogAdminClient
+}
+
+// NewAuditLogs returns an audit logs service.
+func NewAuditLogs(logs LogAdminClient) *AuditLogs {
+	return &AuditLogs{logs: logs}
+}
+
+// GrantsBy returns the bindings the principal added to the project's policy since the given time
+// that it did not remove again, in the order they were added. The principal is the email of the
+// account that made the changes, i.e. "jane@example.com".
+func (a *AuditLogs) GrantsBy(ctx context.Context, projectID, principal string, since time.Time) ([]BindingChange, error) {
+	filter := activityFilter(projectID, since,
+		`protoPayload.methodName="SetIamPolicy"`,
+		fmt.Sprintf("protoPayload.authenticationInfo.principalEmail=%q", principal),
+	)
+	entries, err := a.logs.ListEntries(ctx, projectID, filter, maxPolicyEntries)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list policy changes")
+	}
+	grants := []BindingChange{}
+	index := map[BindingChange]int{}
+	// Entries are returned newest first so they're replayed from the oldest.
+	for i := len(entries) - 1; i >= 0; i-- {
+		deltas, err := bindingDeltas(entries[i].Payload)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range deltas {
+			key := BindingChange{Role: d.GetRole(), Member: d.GetMember(), Change: BindingAdded}
+			switch d.GetAction() {
+			case iam.BindingDelta_ADD:
+				if _, ok := index[key]; !ok {
+					index[key] = len(grants)
+					grants = append(grants, key)
+				}
+			case iam.BindingDelta_REMOVE:
+				if j, ok := index[key]; ok {
+					grants[j].Change = ""
+					delete(index, key)
+				}
+			}
+		}
+	}
+	added := []BindingChange{}
+	for _, g := range grants {
+		if g.Change == BindingAdded {
+			added = append(added, g)
+		}
+	}
+	return added, nil
+}
+
+// RecentActivity returns the changes made to the project since the given time, newest first. If a
+// resource is given, such as the finding's "//storage.googleapis.com/bucket", only changes to
+// resources of the same name are returned.
+func (a *AuditLogs) RecentActivity(ctx context.Context, projectID, resource string, since time.Time) ([]AdminActivity, error) {
+	var conditions []string
+	if name := resourceShortName(resource); name != "" {
+		conditions = append(conditions, fmt.Sprintf("protoPayload.resourceName:%q", name))
+	}
+	entries, err := a.logs.ListEntries(ctx, projectID, activityFilter(projectID, since, conditions...), maxActivityEntries)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list admin activity")
+	}
+	activity := []AdminActivity{}
+	for _, entry := range entries {
+		log, ok := entry.Payload.(*audit.AuditLog)
+		if !ok {
+			continue
+		}
+		activity = append(activity, AdminActivity{
+			Time:      entry.Timestamp.UTC().Format(time.RFC3339),
+			Principal: log.GetAuthenticationInfo().GetPrincipalEmail(),
+			CallerIP:  log.GetRequestMetadata().GetCallerIp(),
+			Method:    log.GetMethodName(),
+			Resource:  log.GetResourceName(),
+		})
+	}
+	return activity, nil
+}
+
+// activityFilter returns the filter of the project's Admin Activity audit logs since the given time.
+func activityFilter(projectID string, since time.Time, conditions ...string) string {
+	filter := []string{
+		fmt.Sprintf("logName=%q", "projects/"+projectID+"/logs/cloudaudit.googleapis.com%2Factivity"),
+		fmt.Sprintf("timestamp>=%q", since.UTC().Format(time.RFC3339)),
+	}
+	return strings.Join(append(filter, conditions...), " AND ")
+}
+
+// resourceShortName returns the last part of a resource name, i.e. "bucket" for
+// "//storage.googleapis.com/bucket". Audit logs name resources differently than
+// Security Command Center so only the last part is matched. An empty string is returned for
+// projects, folders and organizations since all of the project's changes apply to them.
+func resourceShortName(resource string) string {
+	parts := strings.Split(strings.TrimRight(resource, "/"), "/")
+	if len(parts) < 2 {
+		return ""
+	}
+	switch parts[len(parts)-2] {
+	case "projects", "folders", "organizations":
+		return ""
+	}
+	return parts[len(parts)-1]
+}
+
+// bindingDeltas returns the binding changes recorded by an audit log entry of a policy change.
+func bindingDeltas(payload interface{}) ([]*iam.BindingDelta, error) {
+	log, ok := payload.(*audit.AuditLog)
+	var data iamlogging.AuditData
+	if !ok || log.GetServiceData() == nil || !ptypes.Is(log.GetServiceData(), &data) {
+		return nil, nil
+	}
+	if err := ptypes.UnmarshalAny(log.GetServiceData(), &data); err != nil {
+		return nil, errors.Wrap(err, "failed to read policy delta")
+	}
+	return data.GetPolicyDelta().GetBindingDeltas(), nil
+}
@@ -0,0 +1,150 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/logging"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+	"google.golang.org/genproto/googleapis/cloud/audit"
+	iam "google.golang.org/genproto/googleapis/iam/v1"
+	iamlogging "google.golang.org/genproto/googleapis/iam/v1/logging"
+)
+
+func policyChangeEntry(t *testing.T, deltas ...*iam.BindingDelta) *logging.Entry {
+	data, err := ptypes.MarshalAny(&iamlogging.AuditData{PolicyDelta: &iam.PolicyDelta{BindingDeltas: deltas}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &logging.Entry{Payload: &audit.AuditLog{MethodName: "SetIamPolicy", ServiceData: data}}
+}
+
+func TestGrantsBy(t *testing.T) {
+	add := func(role, member string) *iam.BindingDelta {
+		return &iam.BindingDelta{Action: iam.BindingDelta_ADD, Role: role, Member: member}
+	}
+	remove := func(role, member string) *iam.BindingDelta {
+		return &iam.BindingDelta{Action: iam.BindingDelta_REMOVE, Role: role, Member: member}
+	}
+	for _, tt := range []struct {
+		name string
+		// entries are newest first, as returned by the logs.
+		entries  []*logging.Entry
+		expected []BindingChange
+	}{
+		{
+			name: "grants in order",
+			entries: []*logging.Entry{
+				policyChangeEntry(t, add("roles/viewer", "user:eve@gmail.com")),
+				policyChangeEntry(t, add("roles/owner", "user:eve@gmail.com"), add("roles/editor", "serviceAccount:sa@p.iam.gserviceaccount.com")),
+			},
+			expected: []BindingChange{
+				{Role: "roles/owner", Member: "user:eve@gmail.com", Change: BindingAdded},
+				{Role: "roles/editor", Member: "serviceAccount:sa@p.iam.gserviceaccount.com", Change: BindingAdded},
+				{Role: "roles/viewer", Member: "user:eve@gmail.com", Change: BindingAdded},
+			},
+		},
+		{
+			name: "grants removed later are ignored",
+			entries: []*logging.Entry{
+				policyChangeEntry(t, remove("roles/owner", "user:eve@gmail.com")),
+				policyChangeEntry(t, add("roles/owner", "user:eve@gmail.com"), add("roles/viewer", "user:eve@gmail.com")),
+			},
+			expected: []BindingChange{
+				{Role: "roles/viewer", Member: "user:eve@gmail.com", Change: BindingAdded},
+			},
+		},
+		{
+			name: "removals of existing grants are ignored",
+			entries: []*logging.Entry{
+				policyChangeEntry(t, remove("roles/owner", "user:admin@example.com")),
+				{Payload: "not an audit log"},
+			},
+			expected: []BindingChange{},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			logStub := &stubs.LogAdminStub{StubbedEntries: tt.entries}
+			a := NewAuditLogs(logStub)
+			got, err := a.GrantsBy(context.Background(), "test-project", "mallory@example.com", time.Date(2020, 3, 2, 0, 0, 0, 0, time.UTC))
+			if err != nil {
+				t.Fatalf("%s failed: %q", tt.name, err)
+			}
+			if diff := cmp.Diff(tt.expected, got); diff != "" {
+				t.Errorf("%s failed, difference:%+v", tt.name, diff)
+			}
+			for _, want := range []string{
+				`logName="projects/test-project/logs/cloudaudit.googleapis.com%2Factivity"`,
+				`protoPayload.authenticationInfo.principalEmail="mallory@example.com"`,
+				`timestamp>="2020-03-02T00:00:00Z"`,
+			} {
+				if !strings.Contains(logStub.SavedFilter, want) {
+					t.Errorf("%s failed: filter %q does not contain %q", tt.name, logStub.SavedFilter, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRecentActivity(t *testing.T) {
+	ts := time.Date(2020, 3, 2, 10, 30, 0, 0, time.UTC)
+	entries := []*logging.Entry{
+		{Timestamp: ts, Payload: &audit.AuditLog{
+			MethodName:         "storage.setIamPermissions",
+			ResourceName:       "projects/_/buckets/public-bucket",
+			AuthenticationInfo: &audit.AuthenticationInfo{PrincipalEmail: "mallory@example.com"},
+			RequestMetadata:    &audit.RequestMetadata{CallerIp: "198.51.100.4"},
+		}},
+		{Timestamp: ts, Payload: "not an audit log"},
+	}
+	for _, tt := range []struct {
+		name      string
+		resource  string
+		condition string
+	}{
+		{name: "resource", resource: "//storage.googleapis.com/public-bucket", condition: ` AND protoPayload.resourceName:"public-bucket"`},
+		{name: "project", resource: "//cloudresourcemanager.googleapis.com/projects/000000000000"},
+		{name: "no resource"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			logStub := &stubs.LogAdminStub{StubbedEntries: entries}
+			a := NewAuditLogs(logStub)
+			got, err := a.RecentActivity(context.Background(), "test-project", tt.resource, ts.Add(-time.Hour))
+			if err != nil {
+				t.Fatalf("%s failed: %q", tt.name, err)
+			}
+			expected := []AdminActivity{{
+				Time:      "2020-03-02T10:30:00Z",
+				Principal: "mallory@example.com",
+				CallerIP:  "198.51.100.4",
+				Method:    "storage.setIamPermissions",
+				Resource:  "projects/_/buckets/public-bucket",
+			}}
+			if diff := cmp.Diff(expected, got); diff != "" {
+				t.Errorf("%s failed, difference:%+v", tt.name, diff)
+			}
+			filter := `logName="projects/test-project/logs/cloudaudit.googleapis.com%2Factivity" AND timestamp>="2020-03-02T09:30:00Z"` + tt.condition
+			if logStub.SavedFilter != filter {
+				t.Errorf("%s failed: filter got:%q want:%q", tt.name, logStub.SavedFilter, filter)
+			}
+		})
+	}
+}
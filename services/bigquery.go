@@ -16,6 +16,8 @@ package services
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"cloud.google.com/go/bigquery"
 	"github.com/pkg/errors"
@@ -25,6 +27,17 @@ import (
 type BigQueryClient interface {
 	DatasetMetadata(ctx context.Context, projectID, datasetID string) (*bigquery.DatasetMetadata, error)
 	OverwriteDatasetMetadata(ctx context.Context, projectID, datasetID string, dm bigquery.DatasetMetadataToUpdate) (*bigquery.DatasetMetadata, error)
+	TableMetadata(ctx context.Context, projectID, datasetID, tableID string) (*bigquery.TableMetadata, error)
+	UpdateTableMetadata(ctx context.Context, projectID, datasetID, tableID string, tm bigquery.TableMetadataToUpdate, etag string) (*bigquery.TableMetadata, error)
+	QueryRows(ctx context.Context, query string) ([][]bigquery.Value, error)
+}
+
+// ExportedFinding is a finding read from a Security Command Center BigQuery export.
+type ExportedFinding struct {
+	ID        string
+	EventTime time.Time
+	// Notification is the finding and its resource in the format of a Pub/Sub notification.
+	Notification []byte
 }
 
 // BigQuery service.
@@ -54,6 +67,138 @@ func (bq *BigQuery) RemoveDatasetPublicAccess(ctx context.Context, projectID, da
 	return nil
 }
 
+// DatasetIsPublic returns true if the dataset grants access to public users.
+func (bq *BigQuery) DatasetIsPublic(ctx context.Context, projectID, datasetID string) (bool, error) {
+	md, err := bq.client.DatasetMetadata(ctx, projectID, datasetID)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to get metadata for bigquery dataset %q in project %q", datasetID, projectID)
+	}
+	return len(removePublicUsers(md)) != len(md.Access), nil
+}
+
+// BroadDatasetAccess returns the entities of the dataset's access entries granting access to
+// everyone or to a whole domain, i.e. "allAuthenticatedUsers" or "domain:example.com".
+func (bq *BigQuery) BroadDatasetAccess(ctx context.Context, projectID, datasetID string) ([]string, error) {
+	md, err := bq.client.DatasetMetadata(ctx, projectID, datasetID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get metadata for bigquery dataset %q in project %q", datasetID, projectID)
+	}
+	broad := []string{}
+	for _, a := range md.Access {
+		if broadAccess(a) {
+			broad = append(broad, accessEntity(a))
+		}
+	}
+	return broad, nil
+}
+
+// RestrictDataset removes the access entries of the dataset granting access to everyone or to a
+// whole domain and sets the label in the same update.
+func (bq *BigQuery) RestrictDataset(ctx context.Context, projectID, datasetID, key, value string) error {
+	md, err := bq.client.DatasetMetadata(ctx, projectID, datasetID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get metadata for bigquery dataset %q in project %q", datasetID, projectID)
+	}
+	access := []*bigquery.AccessEntry{}
+	for _, a := range md.Access {
+		if !broadAccess(a) {
+			access = append(access, a)
+		}
+	}
+	dm := bigquery.DatasetMetadataToUpdate{Access: access}
+	dm.SetLabel(key, value)
+	if _, err := bq.client.OverwriteDatasetMetadata(ctx, projectID, datasetID, dm); err != nil {
+		return errors.Wrapf(err, "failed to restrict bigquery dataset %q in project %q", datasetID, projectID)
+	}
+	return nil
+}
+
+// DatasetKMSKey returns the Cloud KMS key new tables of the dataset are encrypted with, or an empty
+// string if they're encrypted with a Google-managed key.
+func (bq *BigQuery) DatasetKMSKey(ctx context.Context, projectID, datasetID string) (string, error) {
+	md, err := bq.client.DatasetMetadata(ctx, projectID, datasetID)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get metadata for bigquery dataset %q in project %q", datasetID, projectID)
+	}
+	if md.DefaultEncryptionConfig == nil {
+		return "", nil
+	}
+	return md.DefaultEncryptionConfig.KMSKeyName, nil
+}
+
+// SetDatasetKMSKey sets the Cloud KMS key new tables of the dataset are encrypted with. Existing
+// tables keep their key.
+func (bq *BigQuery) SetDatasetKMSKey(ctx context.Context, projectID, datasetID, keyName string) error {
+	dm := bigquery.DatasetMetadataToUpdate{DefaultEncryptionConfig: &bigquery.EncryptionConfig{KMSKeyName: keyName}}
+	if _, err := bq.client.OverwriteDatasetMetadata(ctx, projectID, datasetID, dm); err != nil {
+		return errors.Wrapf(err, "failed to set kms key of bigquery dataset %q in project %q", datasetID, projectID)
+	}
+	return nil
+}
+
+// TableKMSKey returns the Cloud KMS key the table is encrypted with, or an empty string if it's
+// encrypted with a Google-managed key.
+func (bq *BigQuery) TableKMSKey(ctx context.Context, projectID, datasetID, tableID string) (string, error) {
+	md, err := bq.client.TableMetadata(ctx, projectID, datasetID, tableID)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get metadata for bigquery table %q in dataset %q in project %q", tableID, datasetID, projectID)
+	}
+	if md.EncryptionConfig == nil {
+		return "", nil
+	}
+	return md.EncryptionConfig.KMSKeyName, nil
+}
+
+// SetTableKMSKey encrypts the table with the Cloud KMS key. BigQuery re-encrypts the table's data in
+// place.
+func (bq *BigQuery) SetTableKMSKey(ctx context.Context, projectID, datasetID, tableID, keyName string) error {
+	md, err := bq.client.TableMetadata(ctx, projectID, datasetID, tableID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get metadata for bigquery table %q in dataset %q in project %q", tableID, datasetID, projectID)
+	}
+	tm := bigquery.TableMetadataToUpdate{EncryptionConfig: &bigquery.EncryptionConfig{KMSKeyName: keyName}}
+	if _, err := bq.client.UpdateTableMetadata(ctx, projectID, datasetID, tableID, tm, md.ETag); err != nil {
+		return errors.Wrapf(err, "failed to set kms key of bigquery table %q in dataset %q in project %q", tableID, datasetID, projectID)
+	}
+	return nil
+}
+
+// ExportedFindings returns up to limit findings of the Security Command Center export table with an
+// event time at or after since, oldest first. The table is given as "project.dataset.table".
+func (bq *BigQuery) ExportedFindings(ctx context.Context, table string, since time.Time, limit int) ([]*ExportedFinding, error) {
+	query := fmt.Sprintf("SELECT finding_id, event_time, TO_JSON_STRING(STRUCT(finding, resource)) FROM `%s` "+
+		"WHERE event_time >= TIMESTAMP(%q) ORDER BY event_time, finding_id LIMIT %d", table, since.UTC().Format(time.RFC3339Nano), limit)
+	rows, err := bq.client.QueryRows(ctx, query)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to query findings exported to %q", table)
+	}
+	findings := make([]*ExportedFinding, 0, len(rows))
+	for _, row := range rows {
+		if len(row) != 3 {
+			return nil, errors.Errorf("got %d columns of finding exported to %q want 3", len(row), table)
+		}
+		id, _ := row[0].(string)
+		eventTime, _ := row[1].(time.Time)
+		notification, _ := row[2].(string)
+		if id == "" || notification == "" {
+			return nil, errors.Errorf("finding exported to %q has no id or content", table)
+		}
+		findings = append(findings, &ExportedFinding{ID: id, EventTime: eventTime, Notification: []byte(notification)})
+	}
+	return findings, nil
+}
+
+func broadAccess(a *bigquery.AccessEntry) bool {
+	return publicUsers[a.Entity] || a.EntityType == bigquery.DomainEntity
+}
+
+func accessEntity(a *bigquery.AccessEntry) string {
+	if a.EntityType == bigquery.DomainEntity {
+		return "domain:" + a.Entity
+	}
+	return a.Entity
+}
+
 func removePublicUsers(metadata *bigquery.DatasetMetadata) []*bigquery.AccessEntry {
 	newAccesses := []*bigquery.AccessEntry{}
 	for _, a := range metadata.Access {
@@ -16,7 +16,9 @@ package services
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
 
 	"cloud.google.com/go/bigquery"
 	"github.com/google/go-cmp/cmp"
@@ -84,3 +86,82 @@ func TestRemoveDatasetPublicAccess(t *testing.T) {
 		})
 	}
 }
+
+func TestRestrictDataset(t *testing.T) {
+	const (
+		projectID = "test-project"
+		datasetID = "test-dataset"
+	)
+	bqStub := &stubs.BigQueryStub{StubbedMetadata: &bigquery.DatasetMetadata{
+		Access: []*bigquery.AccessEntry{
+			{Role: bigquery.OwnerRole, EntityType: bigquery.UserEmailEntity, Entity: "owner@org.com"},
+			{Role: bigquery.ReaderRole, EntityType: bigquery.DomainEntity, Entity: "org.com"},
+			{Role: bigquery.ReaderRole, EntityType: bigquery.SpecialGroupEntity, Entity: "allAuthenticatedUsers"},
+		},
+	}}
+	ctx := context.Background()
+	bq := NewBigQuery(bqStub)
+	broad, err := bq.BroadDatasetAccess(ctx, projectID, datasetID)
+	if err != nil {
+		t.Fatalf("BroadDatasetAccess() failed: %q", err)
+	}
+	if diff := cmp.Diff([]string{"domain:org.com", "allAuthenticatedUsers"}, broad); diff != "" {
+		t.Errorf("BroadDatasetAccess() returned unexpected entities (-want +got):\n%s", diff)
+	}
+	if err := bq.RestrictDataset(ctx, projectID, datasetID, "dlp-restricted", "true"); err != nil {
+		t.Fatalf("RestrictDataset() failed: %q", err)
+	}
+	expected := []*bigquery.AccessEntry{
+		{Role: bigquery.OwnerRole, EntityType: bigquery.UserEmailEntity, Entity: "owner@org.com"},
+	}
+	if diff := cmp.Diff(expected, bqStub.SavedDatasetMetadata.Access); diff != "" {
+		t.Errorf("RestrictDataset() saved unexpected access (-want +got):\n%s", diff)
+	}
+}
+
+func TestExportedFindings(t *testing.T) {
+	since := time.Date(2020, 3, 1, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		rows     [][]bigquery.Value
+		expected []*ExportedFinding
+		wantErr  bool
+	}{
+		{
+			name: "findings",
+			rows: [][]bigquery.Value{
+				{"finding-1", since, `{"finding":{"category":"c1"}}`},
+				{"finding-2", since.Add(time.Minute), `{"finding":{"category":"c2"}}`},
+			},
+			expected: []*ExportedFinding{
+				{ID: "finding-1", EventTime: since, Notification: []byte(`{"finding":{"category":"c1"}}`)},
+				{ID: "finding-2", EventTime: since.Add(time.Minute), Notification: []byte(`{"finding":{"category":"c2"}}`)},
+			},
+		},
+		{
+			name:     "no findings",
+			rows:     [][]bigquery.Value{},
+			expected: []*ExportedFinding{},
+		},
+		{
+			name:    "missing content",
+			rows:    [][]bigquery.Value{{"finding-1", since, nil}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bqStub := &stubs.BigQueryStub{StubbedRows: tt.rows}
+			got, err := NewBigQuery(bqStub).ExportedFindings(context.Background(), "p.scc.findings", since, 10)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ExportedFindings() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.expected, got); diff != "" {
+				t.Errorf("ExportedFindings() returned unexpected findings (-want +got):\n%s", diff)
+			}
+			if q := bqStub.SavedQueries[0]; !strings.Contains(q, "`p.scc.findings`") || !strings.Contains(q, `TIMESTAMP("2020-03-01T10:00:00Z")`) {
+				t.Errorf("ExportedFindings() ran unexpected query %q", q)
+			}
+		})
+	}
+}
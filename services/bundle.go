@@ -0,0 +1,300 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// bundleArchive is the name of the archive holding an incident's bundle.
+	bundleArchive = "bundle.tar.gz"
+	// bundleSignature is the name of the object holding the signature of the archive's SHA-256 digest.
+	bundleSignature = bundleArchive + ".sig"
+	// bundleManifest is the name of the file listing the contents of the archive.
+	bundleManifest = "manifest.json"
+	// evidenceManifest is the name of the manifest written with the evidence collected for an instance.
+	evidenceManifest = "manifest.json"
+)
+
+// BundleStorageClient contains minimum interface required by the incident bundle service.
+type BundleStorageClient interface {
+	WriteObject(context.Context, string, string, []byte) error
+	ReadObject(context.Context, string, string) ([]byte, error)
+	ListObjects(context.Context, string, string) ([]string, error)
+}
+
+// SignerClient contains minimum interface required to sign digests with a Cloud KMS key version.
+type SignerClient interface {
+	AsymmetricSign(context.Context, string, []byte) ([]byte, error)
+}
+
+// IncidentBundle service exports what is known about an incident as a signed archive.
+type IncidentBundle struct {
+	history        *History
+	storage        BundleStorageClient
+	signer         SignerClient
+	bucket         string
+	keyVersion     string
+	evidenceBucket string
+}
+
+// BundleQuery selects the incident to export. At least the finding name or project is required.
+type BundleQuery struct {
+	FindingName string `json:"finding"`
+	ProjectID   string `json:"project_id"`
+	// Resource selects the audit records included, i.e. "projects/p". Defaults to the project.
+	Resource string `json:"resource"`
+}
+
+// BundleManifest describes an exported bundle and each file of its archive.
+type BundleManifest struct {
+	FindingName string `json:"finding_name,omitempty"`
+	ProjectID   string `json:"project_id,omitempty"`
+	Resource    string `json:"resource,omitempty"`
+	CreatedAt   string `json:"created_at"`
+	Bucket      string `json:"bucket"`
+	Archive     string `json:"archive"`
+	// Signature is the object holding the signature of the archive's SHA-256 digest.
+	Signature  string       `json:"signature"`
+	KeyVersion string       `json:"key_version"`
+	SHA256     string       `json:"sha256"`
+	Files      []BundleFile `json:"files"`
+	// Errors contains the parts of the incident that could not be exported.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// BundleFile is a file of the archive along with its SHA-256 digest.
+type BundleFile struct {
+	Name   string `json:"name"`
+	SHA256 string `json:"sha256"`
+	Size   int    `json:"size"`
+}
+
+// archiveFile is a file of the archive along with the value marshalled as its content.
+type archiveFile struct {
+	name    string
+	content interface{}
+}
+
+// bundleEnrichment is the context notifications were enriched with for an event.
+type bundleEnrichment struct {
+	ID       string          `json:"id"`
+	Action   string          `json:"action"`
+	Contacts []string        `json:"contacts,omitempty"`
+	Activity []AdminActivity `json:"activity,omitempty"`
+	Intel    []IPIntel       `json:"intel,omitempty"`
+}
+
+// bundleDiff is the state of a resource before and after an automation, or the bindings it changed.
+type bundleDiff struct {
+	ID            string          `json:"id,omitempty"`
+	Time          string          `json:"time,omitempty"`
+	Action        string          `json:"action"`
+	Resource      string          `json:"resource,omitempty"`
+	Before        interface{}     `json:"before,omitempty"`
+	After         interface{}     `json:"after,omitempty"`
+	PolicyChanges []BindingChange `json:"policy_changes,omitempty"`
+}
+
+// NewIncidentBundle returns an incident bundle service writing archives signed with the key version
+// to the bucket. Evidence manifests are read from the evidence bucket if one is given.
+func NewIncidentBundle(h *History, st BundleStorageClient, signer SignerClient, bucket, keyVersion, evidenceBucket string) *IncidentBundle {
+	return &IncidentBundle{history: h, storage: st, signer: signer, bucket: bucket, keyVersion: keyVersion, evidenceBucket: evidenceBucket}
+}
+
+// Export writes the incident's finding, the context its notifications were enriched with, the audit
+// records and before and after state of the automations that ran and the manifests of the evidence
+// collected for its project to a single archive in the forensics bucket. The SHA-256 digest of the
+// archive is signed with the key version and the signature is written next to it.
+//
+// Evidence manifests are exported on a best effort basis, failures are recorded in the manifest.
+func (b *IncidentBundle) Export(ctx context.Context, q *BundleQuery) (*BundleManifest, error) {
+	if q.FindingName == "" && q.ProjectID == "" {
+		return nil, errors.New("finding or project required to export a bundle")
+	}
+	now := time.Now().UTC()
+	prefix := fmt.Sprintf("%s/%d/", bundleName(q), now.Unix())
+	m := &BundleManifest{
+		FindingName: q.FindingName,
+		ProjectID:   q.ProjectID,
+		Resource:    q.Resource,
+		CreatedAt:   now.Format(time.RFC3339),
+		Bucket:      b.bucket,
+		Archive:     prefix + bundleArchive,
+		Signature:   prefix + bundleSignature,
+		KeyVersion:  b.keyVersion,
+		Files:       []BundleFile{},
+	}
+	hq := &HistoryQuery{ProjectID: q.ProjectID, FindingName: q.FindingName, Resource: q.Resource, Limit: maxHistoryLimit}
+	events, err := b.history.Actions(ctx, hq)
+	if err != nil {
+		return nil, err
+	}
+	records, err := b.history.AuditRecords(ctx, hq)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []archiveFile{
+		{"events.json", events},
+		{"audit.json", records},
+		{"enrichment.json", enrichments(events)},
+		{"diffs.json", diffs(events, records)},
+	}
+	// Events are newest first, the finding is taken from the first automation it triggered.
+	for i := len(events) - 1; i >= 0; i-- {
+		if len(events[i].Finding) > 0 {
+			files = append(files, archiveFile{"finding.json", events[i].Finding})
+			break
+		}
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, f := range files {
+		content, err := json.MarshalIndent(f.content, "", "  ")
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to marshal %q", f.name)
+		}
+		if err := addFile(tw, m, f.name, content, now); err != nil {
+			return nil, err
+		}
+	}
+	if b.evidenceBucket != "" && q.ProjectID != "" {
+		if err := b.addEvidence(ctx, tw, m, q.ProjectID, now); err != nil {
+			return nil, err
+		}
+	}
+	content, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal manifest")
+	}
+	if err := addFile(tw, nil, bundleManifest, content, now); err != nil {
+		return nil, err
+	}
+	if err := tw.Close(); err != nil {
+		return nil, errors.Wrap(err, "failed to write archive")
+	}
+	if err := gz.Close(); err != nil {
+		return nil, errors.Wrap(err, "failed to compress archive")
+	}
+
+	digest := sha256.Sum256(buf.Bytes())
+	m.SHA256 = hex.EncodeToString(digest[:])
+	sig, err := b.signer.AsymmetricSign(ctx, b.keyVersion, digest[:])
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to sign bundle with %q", b.keyVersion)
+	}
+	if err := b.storage.WriteObject(ctx, b.bucket, m.Archive, buf.Bytes()); err != nil {
+		return nil, errors.Wrapf(err, "failed to write %q", m.Archive)
+	}
+	if err := b.storage.WriteObject(ctx, b.bucket, m.Signature, sig); err != nil {
+		return nil, errors.Wrapf(err, "failed to write %q", m.Signature)
+	}
+	return m, nil
+}
+
+// addEvidence adds the manifests of the evidence collected for the project's instances to the
+// archive under "evidence/".
+func (b *IncidentBundle) addEvidence(ctx context.Context, tw *tar.Writer, m *BundleManifest, projectID string, now time.Time) error {
+	objects, err := b.storage.ListObjects(ctx, b.evidenceBucket, projectID+"/")
+	if err != nil {
+		m.Errors = append(m.Errors, fmt.Sprintf("evidence: %s", err))
+		return nil
+	}
+	for _, object := range objects {
+		if path.Base(object) != evidenceManifest {
+			continue
+		}
+		content, err := b.storage.ReadObject(ctx, b.evidenceBucket, object)
+		if err != nil {
+			m.Errors = append(m.Errors, fmt.Sprintf("evidence %q: %s", object, err))
+			continue
+		}
+		if err := addFile(tw, m, "evidence/"+object, content, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addFile writes the file to the archive and records it in the manifest if one is given.
+func addFile(tw *tar.Writer, m *BundleManifest, name string, content []byte, modTime time.Time) error {
+	h := &tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), ModTime: modTime}
+	if err := tw.WriteHeader(h); err != nil {
+		return errors.Wrapf(err, "failed to add %q to archive", name)
+	}
+	if _, err := tw.Write(content); err != nil {
+		return errors.Wrapf(err, "failed to add %q to archive", name)
+	}
+	if m != nil {
+		digest := sha256.Sum256(content)
+		m.Files = append(m.Files, BundleFile{Name: name, SHA256: hex.EncodeToString(digest[:]), Size: len(content)})
+	}
+	return nil
+}
+
+// enrichments returns the context each event's notifications were enriched with.
+func enrichments(events []*WebhookEvent) []bundleEnrichment {
+	e := []bundleEnrichment{}
+	for _, event := range events {
+		if len(event.Contacts) == 0 && len(event.Activity) == 0 && len(event.Intel) == 0 {
+			continue
+		}
+		e = append(e, bundleEnrichment{ID: event.ID, Action: event.Action, Contacts: event.Contacts, Activity: event.Activity, Intel: event.Intel})
+	}
+	return e
+}
+
+// diffs returns the before and after state of the events and the bindings the audit records changed.
+func diffs(events []*WebhookEvent, records []*AuditEntry) []bundleDiff {
+	d := []bundleDiff{}
+	for _, event := range events {
+		if event.Before == nil && event.After == nil {
+			continue
+		}
+		d = append(d, bundleDiff{ID: event.ID, Time: event.Time, Action: event.Action, Before: event.Before, After: event.After})
+	}
+	for _, r := range records {
+		if len(r.PolicyChanges) == 0 {
+			continue
+		}
+		d = append(d, bundleDiff{Time: r.Time, Action: r.Action, Resource: r.Resource, PolicyChanges: r.PolicyChanges})
+	}
+	return d
+}
+
+// bundleName returns the path bundles of the incident are written under, the ID of the finding if
+// known or the project otherwise.
+func bundleName(q *BundleQuery) string {
+	if q.FindingName != "" {
+		return "findings/" + path.Base(q.FindingName)
+	}
+	return "projects/" + strings.Trim(q.ProjectID, "/")
+}
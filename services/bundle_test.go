@@ -0,0 +1,155 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/logging"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+)
+
+func TestExportBundle(t *testing.T) {
+	const (
+		bucket     = "forensics-bucket"
+		keyVersion = "projects/p/locations/global/keyRings/r/cryptoKeys/k/cryptoKeyVersions/1"
+		finding    = "organizations/1/sources/2/findings/abc"
+	)
+	tests := []struct {
+		name          string
+		query         *BundleQuery
+		evidence      map[string][]byte
+		expectedFiles []string
+		expectedError bool
+	}{
+		{
+			name:          "export finding",
+			query:         &BundleQuery{FindingName: finding},
+			expectedFiles: []string{"audit.json", "diffs.json", "enrichment.json", "events.json", "finding.json", "manifest.json"},
+		},
+		{
+			name:  "export project with evidence",
+			query: &BundleQuery{FindingName: finding, ProjectID: "test-project"},
+			evidence: map[string][]byte{
+				"evidence-bucket/test-project/miner/1/manifest.json":      []byte(`{"instance": "miner"}`),
+				"evidence-bucket/test-project/miner/1/metadata.json":      []byte(`{}`),
+				"evidence-bucket/other-project/miner/1/manifest.json":     []byte(`{}`),
+				"evidence-bucket/test-project/miner/2/serial-console.txt": []byte("booting"),
+			},
+			expectedFiles: []string{"audit.json", "diffs.json", "enrichment.json", "events.json", "evidence/test-project/miner/1/manifest.json", "finding.json", "manifest.json"},
+		},
+		{
+			name:          "no incident",
+			query:         &BundleQuery{},
+			expectedError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			logStub := &stubs.LogAdminStub{StubbedEntries: []*logging.Entry{
+				{Payload: map[string]interface{}{
+					"id":       "event-1",
+					"action":   "remove_public_ip",
+					"finding":  map[string]string{"ProjectID": "test-project"},
+					"result":   WebhookResultSuccess,
+					"contacts": []string{"owner@example.com"},
+					"before":   map[string]string{"ip": "203.0.113.1"},
+				}},
+			}}
+			storageStub := &stubs.StorageStub{WrittenObjects: map[string][]byte{}}
+			for k, v := range tt.evidence {
+				storageStub.WrittenObjects[k] = v
+			}
+			kmsStub := &stubs.KMSStub{}
+			b := NewIncidentBundle(NewHistory(logStub, "automation-project"), storageStub, kmsStub, bucket, keyVersion, "evidence-bucket")
+			m, err := b.Export(ctx, tt.query)
+			if tt.expectedError {
+				if err == nil {
+					t.Errorf("%s failed: expected error", tt.name)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%s failed: %q", tt.name, err)
+			}
+			if !strings.HasPrefix(m.Archive, "findings/abc/") {
+				t.Errorf("%s failed: unexpected archive %q", tt.name, m.Archive)
+			}
+			archive := storageStub.WrittenObjects[bucket+"/"+m.Archive]
+			digest := sha256.Sum256(archive)
+			if diff := cmp.Diff(kmsStub.SignedDigests[keyVersion], [][]byte{digest[:]}); diff != "" {
+				t.Errorf("%s failed, difference: %+v", tt.name, diff)
+			}
+			sig := storageStub.WrittenObjects[bucket+"/"+m.Signature]
+			if !bytes.Equal(sig, append([]byte(keyVersion+":"), digest[:]...)) {
+				t.Errorf("%s failed: unexpected signature %q", tt.name, sig)
+			}
+			files, err := archiveFiles(archive)
+			if err != nil {
+				t.Fatalf("%s failed to read archive: %q", tt.name, err)
+			}
+			names := []string{}
+			for name := range files {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			if diff := cmp.Diff(tt.expectedFiles, names); diff != "" {
+				t.Errorf("%s failed, difference: %+v", tt.name, diff)
+			}
+			if len(m.Files) != len(tt.expectedFiles)-1 {
+				t.Errorf("%s failed: got:%d want:%d files in manifest", tt.name, len(m.Files), len(tt.expectedFiles)-1)
+			}
+			if !strings.Contains(string(files["enrichment.json"]), "owner@example.com") {
+				t.Errorf("%s failed: enrichment missing contacts: %s", tt.name, files["enrichment.json"])
+			}
+			if !strings.Contains(string(files["diffs.json"]), "203.0.113.1") {
+				t.Errorf("%s failed: diffs missing before state: %s", tt.name, files["diffs.json"])
+			}
+		})
+	}
+}
+
+// archiveFiles returns the content of each file of the gzipped tar archive.
+func archiveFiles(b []byte) (map[string][]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	tr := tar.NewReader(gz)
+	files := map[string][]byte{}
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			return files, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if files[h.Name], err = ioutil.ReadAll(tr); err != nil {
+			return nil, err
+		}
+	}
+}
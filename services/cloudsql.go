@@ -17,6 +17,8 @@ package services
 import (
 	"context"
 
+	"github.com/googlecloudplatform/security-response-automation/clients/operations"
+	"github.com/pkg/errors"
 	sqladmin "google.golang.org/api/sqladmin/v1beta4"
 )
 
@@ -26,9 +28,12 @@ const allIPs = "0.0.0.0/0"
 // CloudSQLClient contains minimum interface required by the Cloud SQL service.
 type CloudSQLClient interface {
 	PatchInstance(context.Context, string, string, *sqladmin.DatabaseInstance) (*sqladmin.Operation, error)
-	WaitSQL(string, *sqladmin.Operation) []error
+	WaitSQL(context.Context, string, *sqladmin.Operation) []error
+	Operation(context.Context, string, string) (*sqladmin.Operation, error)
 	InstanceDetails(context.Context, string, string) (*sqladmin.DatabaseInstance, error)
 	UpdateUser(context.Context, string, string, string, string, *sqladmin.User) (*sqladmin.Operation, error)
+	ListUsers(context.Context, string, string) (*sqladmin.UsersListResponse, error)
+	DeleteUser(context.Context, string, string, string, string) (*sqladmin.Operation, error)
 }
 
 // CloudSQL service.
@@ -43,7 +48,28 @@ func NewCloudSQL(cc CloudSQLClient) *CloudSQL {
 
 // RequireSSL modifies the configuration to require only SSL connections.
 func (s *CloudSQL) RequireSSL(ctx context.Context, projectID string, instance string) error {
-	op, err := s.client.PatchInstance(ctx, projectID, instance, &sqladmin.DatabaseInstance{
+	op, err := s.requireSSL(ctx, projectID, instance)
+	if err != nil {
+		return err
+	}
+	if err := s.wait(ctx, projectID, op); err != nil {
+		return err
+	}
+	return nil
+}
+
+// StartRequireSSL starts requiring SSL connections without waiting for the change to complete and
+// returns the name of the operation.
+func (s *CloudSQL) StartRequireSSL(ctx context.Context, projectID, instance string) (string, error) {
+	op, err := s.requireSSL(ctx, projectID, instance)
+	if err != nil {
+		return "", err
+	}
+	return op.Name, nil
+}
+
+func (s *CloudSQL) requireSSL(ctx context.Context, projectID, instance string) (*sqladmin.Operation, error) {
+	return s.client.PatchInstance(ctx, projectID, instance, &sqladmin.DatabaseInstance{
 		Name:    instance,
 		Project: projectID,
 		Settings: &sqladmin.Settings{
@@ -52,13 +78,53 @@ func (s *CloudSQL) RequireSSL(ctx context.Context, projectID string, instance st
 			},
 		},
 	})
+}
+
+// EnableBackups turns on automated backups for the instance. Binary logging, required for point in
+// time recovery of MySQL instances, is enabled as well when binaryLog is set.
+func (s *CloudSQL) EnableBackups(ctx context.Context, projectID, instance string, binaryLog bool) error {
+	op, err := s.enableBackups(ctx, projectID, instance, binaryLog)
 	if err != nil {
 		return err
 	}
-	if err := s.wait(projectID, op); err != nil {
-		return err
+	return s.wait(ctx, projectID, op)
+}
+
+// StartEnableBackups starts enabling automated backups without waiting for the change to complete
+// and returns the name of the operation.
+func (s *CloudSQL) StartEnableBackups(ctx context.Context, projectID, instance string, binaryLog bool) (string, error) {
+	op, err := s.enableBackups(ctx, projectID, instance, binaryLog)
+	if err != nil {
+		return "", err
 	}
-	return nil
+	return op.Name, nil
+}
+
+func (s *CloudSQL) enableBackups(ctx context.Context, projectID, instance string, binaryLog bool) (*sqladmin.Operation, error) {
+	return s.client.PatchInstance(ctx, projectID, instance, &sqladmin.DatabaseInstance{
+		Name:    instance,
+		Project: projectID,
+		Settings: &sqladmin.Settings{
+			BackupConfiguration: &sqladmin.BackupConfiguration{
+				Enabled:          true,
+				BinaryLogEnabled: binaryLog,
+			},
+		},
+	})
+}
+
+// OperationDone returns whether the operation has completed. An error is returned if the operation
+// completed with errors.
+func (s *CloudSQL) OperationDone(ctx context.Context, projectID, name string) (bool, error) {
+	op, err := s.client.Operation(ctx, projectID, name)
+	if err != nil {
+		return false, err
+	}
+	done, err := operations.SQLDone(op)
+	if err != nil {
+		return true, errors.Wrapf(err, "operation %q", name)
+	}
+	return done, nil
 }
 
 // UpdateUserPassword updates a user's password.
@@ -67,7 +133,7 @@ func (s *CloudSQL) UpdateUserPassword(ctx context.Context, projectID, instance,
 	if err != nil {
 		return err
 	}
-	if err := s.wait(projectID, op); err != nil {
+	if err := s.wait(ctx, projectID, op); err != nil {
 		return err
 	}
 	return nil
@@ -106,12 +172,30 @@ func (s *CloudSQL) ClosePublicAccess(ctx context.Context, projectID, instance st
 	if err != nil {
 		return err
 	}
-	if err := s.wait(projectID, op); err != nil {
+	if err := s.wait(ctx, projectID, op); err != nil {
 		return err
 	}
 	return nil
 }
 
+// ListUsers returns the users of the given instance.
+func (s *CloudSQL) ListUsers(ctx context.Context, projectID, instance string) ([]*sqladmin.User, error) {
+	resp, err := s.client.ListUsers(ctx, projectID, instance)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Items, nil
+}
+
+// DeleteUser removes the user with the given name and host from the instance.
+func (s *CloudSQL) DeleteUser(ctx context.Context, projectID, instance, host, name string) error {
+	op, err := s.client.DeleteUser(ctx, projectID, instance, host, name)
+	if err != nil {
+		return err
+	}
+	return s.wait(ctx, projectID, op)
+}
+
 // IsPublic checks if the Cloud SQL instance contains public IPs.
 func (s *CloudSQL) IsPublic(acls []*sqladmin.AclEntry) bool {
 	found := false
@@ -124,8 +208,8 @@ func (s *CloudSQL) IsPublic(acls []*sqladmin.AclEntry) bool {
 	return found
 }
 
-func (s *CloudSQL) wait(project string, op *sqladmin.Operation) error {
-	if errs := s.client.WaitSQL(project, op); len(errs) > 0 {
+func (s *CloudSQL) wait(ctx context.Context, project string, op *sqladmin.Operation) error {
+	if errs := s.client.WaitSQL(ctx, project, op); len(errs) > 0 {
 		return errs[0]
 	}
 	return nil
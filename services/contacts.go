@@ -0,0 +1,147 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// securityCategory is the Essential Contacts notification category for security incidents.
+const securityCategory = "SECURITY"
+
+// EssentialContactsClient contains minimum interface required by the essential contacts service.
+type EssentialContactsClient interface {
+	ComputeContacts(context.Context, string, []string) ([]string, error)
+}
+
+// EssentialContacts service.
+type EssentialContacts struct {
+	client EssentialContactsClient
+}
+
+// NewEssentialContacts returns an essential contacts service.
+func NewEssentialContacts(client EssentialContactsClient) *EssentialContacts {
+	return &EssentialContacts{client: client}
+}
+
+// SecurityContacts returns the email addresses of the security contacts of the project, including
+// those inherited from its folders and organization.
+func (e *EssentialContacts) SecurityContacts(ctx context.Context, projectID string) ([]string, error) {
+	contacts, err := e.client.ComputeContacts(ctx, "projects/"+projectID, []string{securityCategory})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get security contacts of project %q", projectID)
+	}
+	return uniqueMembers(contacts), nil
+}
+
+// ContactNotifier emails the security contacts of the affected project each time an automation runs.
+type ContactNotifier struct {
+	contacts *EssentialContacts
+	email    *Email
+	from     string
+	messages *Messages
+}
+
+// NewContactNotifier returns a notifier sending emails from the given address. Messages can be nil
+// to send the built-in summary.
+func NewContactNotifier(contacts *EssentialContacts, email *Email, from string, messages *Messages) *ContactNotifier {
+	return &ContactNotifier{contacts: contacts, email: email, from: from, messages: messages}
+}
+
+// Notify emails a summary of the event to the security contacts of its project. Nothing is sent
+// if the event has no project or the project has no security contacts.
+func (n *ContactNotifier) Notify(ctx context.Context, event *WebhookEvent) error {
+	if event.ProjectID == "" {
+		return nil
+	}
+	to, err := n.contacts.SecurityContacts(ctx, event.ProjectID)
+	if err != nil {
+		return err
+	}
+	if len(to) == 0 {
+		return nil
+	}
+	subject, body, err := renderText(ctx, n.messages, event)
+	if err != nil {
+		return err
+	}
+	if _, err := n.email.Send(subject, n.from, body, to); err != nil {
+		return errors.Wrapf(err, "failed to email security contacts of project %q", event.ProjectID)
+	}
+	return nil
+}
+
+// renderText returns the subject and plain text body of the event from the messages' templates,
+// or the built-in ones.
+func renderText(ctx context.Context, messages *Messages, event *WebhookEvent) (string, string, error) {
+	subject, err := messages.Render(ctx, MessageSubject, event)
+	if err != nil {
+		return "", "", err
+	}
+	if subject == "" {
+		subject = summarySubject(event)
+	}
+	body, err := messages.Render(ctx, MessageText, event)
+	if err != nil {
+		return "", "", err
+	}
+	if body == "" {
+		body = contactSummary(event)
+	}
+	return subject, body, nil
+}
+
+// summarySubject returns the subject of the email or the title of the incident for the event.
+func summarySubject(event *WebhookEvent) string {
+	return fmt.Sprintf("Security Response Automation: %s on %s (%s)", event.Action, event.ProjectID, event.Result)
+}
+
+// contactSummary returns the plain text body of the email sent for the event.
+func contactSummary(event *WebhookEvent) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Security Response Automation ran %q on project %q.\n\n", event.Action, event.ProjectID)
+	fmt.Fprintf(&b, "Result: %s\n", event.Result)
+	fmt.Fprintf(&b, "Time: %s\n", event.Time)
+	fmt.Fprintf(&b, "Event ID: %s\n", event.ID)
+	if event.CorrelationID != "" {
+		fmt.Fprintf(&b, "Correlation ID: %s\n", event.CorrelationID)
+	}
+	if event.Error != "" {
+		fmt.Fprintf(&b, "Error: %s\n", event.Error)
+	}
+	if event.Runbook != "" {
+		fmt.Fprintf(&b, "Runbook: %s\n", event.Runbook)
+	}
+	if len(event.Activity) > 0 {
+		fmt.Fprintf(&b, "\nRecent activity:\n")
+		for _, a := range event.Activity {
+			fmt.Fprintf(&b, "%s %s from %s: %s on %s\n", a.Time, a.Principal, a.CallerIP, a.Method, a.Resource)
+		}
+	}
+	if len(event.Intel) > 0 {
+		fmt.Fprintf(&b, "\nIP intelligence:\n")
+		for _, i := range event.Intel {
+			fmt.Fprintf(&b, "%s: %s %s\n", i.IP, i.Reputation, i.Country)
+		}
+	}
+	if len(event.Finding) > 0 {
+		fmt.Fprintf(&b, "\nFinding:\n%s\n", event.Finding)
+	}
+	return b.String()
+}
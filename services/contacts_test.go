@@ -0,0 +1,72 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+)
+
+func TestContactNotifierNotify(t *testing.T) {
+	tests := []struct {
+		name     string
+		contacts map[string][]string
+		event    *WebhookEvent
+		expected []string
+	}{
+		{
+			name:     "emails security contacts",
+			contacts: map[string][]string{"projects/project-id": {"security@example.com", "soc@example.com", "security@example.com"}},
+			event:    &WebhookEvent{Action: "remove_public_ip", ProjectID: "project-id", Result: WebhookResultSuccess},
+			expected: []string{"security@example.com", "soc@example.com"},
+		},
+		{
+			name:     "no security contacts",
+			contacts: map[string][]string{"projects/other-project": {"security@example.com"}},
+			event:    &WebhookEvent{Action: "remove_public_ip", ProjectID: "project-id", Result: WebhookResultSuccess},
+			expected: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			emailStub := &stubs.EmailStub{}
+			contacts := NewEssentialContacts(&stubs.EssentialContactsStub{StubbedContacts: tt.contacts})
+			n := NewContactNotifier(contacts, NewEmail(emailStub), "sra@example.com", nil)
+			if err := n.Notify(context.Background(), tt.event); err != nil {
+				t.Fatalf("%s failed: %q", tt.name, err)
+			}
+			if tt.expected == nil {
+				if len(emailStub.SentEmails) != 0 {
+					t.Errorf("%s failed: expected no email, got %d", tt.name, len(emailStub.SentEmails))
+				}
+				return
+			}
+			if len(emailStub.SentEmails) != 1 {
+				t.Fatalf("%s failed: expected one email, got %d", tt.name, len(emailStub.SentEmails))
+			}
+			sent := emailStub.SentEmails[0]
+			if diff := cmp.Diff(tt.expected, sent.To); diff != "" {
+				t.Errorf("%s failed, difference: %v", tt.name, diff)
+			}
+			if sent.From != "sra@example.com" || !strings.Contains(sent.Body, tt.event.Action) {
+				t.Errorf("%s failed: unexpected email %+v", tt.name, sent)
+			}
+		})
+	}
+}
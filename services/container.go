@@ -17,12 +17,18 @@ package services
 import (
 	"context"
 
+	"github.com/pkg/errors"
 	container "google.golang.org/api/container/v1"
 )
 
 // ContainerClient holds the minimum interface required by the Container service.
 type ContainerClient interface {
 	UpdateAddonsConfig(context.Context, string, string, string, *container.SetAddonsConfigRequest) (*container.Operation, error)
+	GetCluster(context.Context, string, string, string) (*container.Cluster, error)
+	SetNetworkPolicy(context.Context, string, string, string, *container.SetNetworkPolicyRequest) (*container.Operation, error)
+	UpdateCluster(context.Context, string, string, string, *container.UpdateClusterRequest) (*container.Operation, error)
+	StartIPRotation(context.Context, string, string, string, *container.StartIPRotationRequest) (*container.Operation, error)
+	WaitContainer(context.Context, string, string, *container.Operation) error
 }
 
 // Container Service.
@@ -35,8 +41,9 @@ func NewContainer(client ContainerClient) *Container {
 	return &Container{client: client}
 }
 
-// DisableDashboard disables the Kubernetes Dashboard for a given cluster.
-func (c *Container) DisableDashboard(ctx context.Context, projectID, zone, clusterID string) (*container.Operation, error) {
+// DisableDashboard disables the Kubernetes Dashboard for a given cluster. The location is the
+// cluster's zone, or its region for regional clusters.
+func (c *Container) DisableDashboard(ctx context.Context, projectID, location, clusterID string) (*container.Operation, error) {
 	req := &container.SetAddonsConfigRequest{
 		AddonsConfig: &container.AddonsConfig{
 			KubernetesDashboard: &container.KubernetesDashboard{
@@ -44,5 +51,102 @@ func (c *Container) DisableDashboard(ctx context.Context, projectID, zone, clust
 			},
 		},
 	}
-	return c.client.UpdateAddonsConfig(ctx, projectID, zone, clusterID, req)
+	return c.client.UpdateAddonsConfig(ctx, projectID, location, clusterID, req)
+}
+
+// DashboardDisabled returns true if the Kubernetes Dashboard is disabled for a given cluster.
+func (c *Container) DashboardDisabled(ctx context.Context, projectID, location, clusterID string) (bool, error) {
+	cluster, err := c.client.GetCluster(ctx, projectID, location, clusterID)
+	if err != nil {
+		return false, err
+	}
+	dashboard := cluster.AddonsConfig
+	if dashboard == nil || dashboard.KubernetesDashboard == nil {
+		return false, nil
+	}
+	return dashboard.KubernetesDashboard.Disabled, nil
+}
+
+// Cluster returns the given cluster in its zone or region.
+func (c *Container) Cluster(ctx context.Context, projectID, location, clusterID string) (*container.Cluster, error) {
+	return c.client.GetCluster(ctx, projectID, location, clusterID)
+}
+
+// NetworkPolicyEnabled returns true if network policy is enforced on the cluster's nodes.
+func NetworkPolicyEnabled(cluster *container.Cluster) bool {
+	return cluster.NetworkPolicy != nil && cluster.NetworkPolicy.Enabled
+}
+
+// AuthorizedNetworksEnabled returns true if access to the cluster's control plane is restricted to
+// authorized networks.
+func AuthorizedNetworksEnabled(cluster *container.Cluster) bool {
+	return cluster.MasterAuthorizedNetworksConfig != nil && cluster.MasterAuthorizedNetworksConfig.Enabled
+}
+
+// PrivateNodes returns true if the cluster's nodes only have internal IP addresses.
+func PrivateNodes(cluster *container.Cluster) bool {
+	return cluster.PrivateClusterConfig != nil && cluster.PrivateClusterConfig.EnablePrivateNodes
+}
+
+// EnableNetworkPolicy enforces network policy with Calico on the nodes of a given cluster. The
+// network policy addon is enabled on the control plane first if it isn't already. A cluster can't
+// be modified while it's reconfiguring itself so each operation is waited on before the next is
+// started. Enforcing network policy recreates the cluster's node pools.
+func (c *Container) EnableNetworkPolicy(ctx context.Context, projectID, location string, cluster *container.Cluster) error {
+	if cluster.AddonsConfig == nil || cluster.AddonsConfig.NetworkPolicyConfig == nil || cluster.AddonsConfig.NetworkPolicyConfig.Disabled {
+		op, err := c.client.UpdateAddonsConfig(ctx, projectID, location, cluster.Name, &container.SetAddonsConfigRequest{
+			AddonsConfig: &container.AddonsConfig{
+				NetworkPolicyConfig: &container.NetworkPolicyConfig{Disabled: false, ForceSendFields: []string{"Disabled"}},
+			},
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to enable network policy addon")
+		}
+		if err := c.client.WaitContainer(ctx, projectID, location, op); err != nil {
+			return errors.Wrap(err, "failed waiting for network policy addon")
+		}
+	}
+	op, err := c.client.SetNetworkPolicy(ctx, projectID, location, cluster.Name, &container.SetNetworkPolicyRequest{
+		NetworkPolicy: &container.NetworkPolicy{Enabled: true, Provider: "CALICO"},
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to enable network policy")
+	}
+	if err := c.client.WaitContainer(ctx, projectID, location, op); err != nil {
+		return errors.Wrap(err, "failed waiting for network policy")
+	}
+	return nil
+}
+
+// RestrictControlPlane enables master authorized networks on a given cluster, allowing access to
+// its control plane only from the networks, and waits for the update to complete.
+func (c *Container) RestrictControlPlane(ctx context.Context, projectID, location, clusterID string, networks []string) error {
+	conf := &container.MasterAuthorizedNetworksConfig{Enabled: true}
+	for _, n := range networks {
+		conf.CidrBlocks = append(conf.CidrBlocks, &container.CidrBlock{CidrBlock: n})
+	}
+	op, err := c.client.UpdateCluster(ctx, projectID, location, clusterID, &container.UpdateClusterRequest{
+		Update: &container.ClusterUpdate{DesiredMasterAuthorizedNetworksConfig: conf},
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to enable master authorized networks")
+	}
+	if err := c.client.WaitContainer(ctx, projectID, location, op); err != nil {
+		return errors.Wrap(err, "failed waiting for master authorized networks")
+	}
+	return nil
+}
+
+// RotateCredentials starts rotating the credentials of a given cluster's control plane, along with
+// its IP address, and waits for the new ones to be issued. Clients must fetch the new credentials
+// before the rotation is completed, GKE completes it on its own after 7 days.
+func (c *Container) RotateCredentials(ctx context.Context, projectID, location, clusterID string) error {
+	op, err := c.client.StartIPRotation(ctx, projectID, location, clusterID, &container.StartIPRotationRequest{RotateCredentials: true})
+	if err != nil {
+		return errors.Wrap(err, "failed to start credential rotation")
+	}
+	if err := c.client.WaitContainer(ctx, projectID, location, op); err != nil {
+		return errors.Wrap(err, "failed waiting for credential rotation")
+	}
+	return nil
 }
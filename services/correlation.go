@@ -0,0 +1,43 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type correlationKey struct{}
+
+// NewCorrelationID returns a new ID to trace the remediation of a finding with.
+func NewCorrelationID() string {
+	return uuid.New().String()
+}
+
+// WithCorrelationID returns a context carrying the correlation ID of the finding being remediated.
+// The ID is logged with every message, audit record and event of the loggers returned by For and
+// forwarded to the automations the finding triggers, so a remediation can be traced across
+// functions.
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationKey{}, id)
+}
+
+// CorrelationID returns the correlation ID carried by the context, or an empty string if it has
+// none.
+func CorrelationID(ctx context.Context) string {
+	id, _ := ctx.Value(correlationKey{}).(string)
+	return id
+}
@@ -0,0 +1,66 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+)
+
+func TestLoggerFor(t *testing.T) {
+	const correlationID = "5f0c6b9e-correlation"
+	for _, tt := range []struct {
+		name     string
+		ctx      context.Context
+		message  string
+		expected string
+	}{
+		{
+			name:     "correlated",
+			ctx:      WithCorrelationID(context.Background(), correlationID),
+			message:  "closed bucket %q",
+			expected: correlationID,
+		},
+		{
+			name:    "not correlated",
+			ctx:     context.Background(),
+			message: "closed bucket %q",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := &stubs.LoggerStub{}
+			l := NewLogger(stub).For(tt.ctx)
+			l.Audit(&AuditRecord{Action: "close_bucket"})
+			l.Event(&WebhookEvent{Action: "close_bucket"})
+			got := []string{
+				stub.AuditRecords[0].(*AuditRecord).CorrelationID,
+				stub.AuditRecords[1].(*WebhookEvent).CorrelationID,
+			}
+			if diff := cmp.Diff([]string{tt.expected, tt.expected}, got); diff != "" {
+				t.Errorf("%s correlation IDs difference:%+v", tt.name, diff)
+			}
+			want := tt.message
+			if tt.expected != "" {
+				want = "[" + tt.expected + "] " + tt.message
+			}
+			if got := l.tag(tt.message); got != want {
+				t.Errorf("%s got message %q want %q", tt.name, got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"github.com/pkg/errors"
+	pubsubpb "google.golang.org/genproto/googleapis/pubsub/v1"
+)
+
+const (
+	// DeadLetterTopic is the topic findings the router failed to route are sent to.
+	DeadLetterTopic = "threat-findings-dead-letter"
+	// DeadLetterSubscription is the subscription dead letters are replayed from.
+	DeadLetterSubscription = "threat-findings-dead-letter-replay"
+	// deadLetterError is the attribute holding why the finding failed.
+	deadLetterError = "sra-error"
+	// deadLetterTime is the attribute holding when the finding failed, in RFC 3339 format.
+	deadLetterTime = "sra-dead-letter-time"
+	// deadLetterReplays is the attribute holding how many times the finding was replayed.
+	deadLetterReplays = "sra-replays"
+)
+
+// DeadLetterClient contains minimum interface required by the dead letters service.
+type DeadLetterClient interface {
+	Pull(context.Context, string, int) ([]*pubsubpb.ReceivedMessage, error)
+	Acknowledge(context.Context, string, []string) error
+}
+
+// DeadLetters service receives the findings sent to the dead-letter topic.
+type DeadLetters struct {
+	client       DeadLetterClient
+	subscription string
+}
+
+// DeadLetter is a finding the router failed to route.
+type DeadLetter struct {
+	ID    string
+	AckID string
+	// Data is the finding.
+	Data       []byte
+	Attributes map[string]string
+	// Error is why the finding failed the last time it was routed.
+	Error string
+	// Time is when the finding failed the last time it was routed.
+	Time time.Time
+	// Replays is how many times the finding was replayed.
+	Replays int
+}
+
+// NewDeadLetters returns a dead letters service receiving from the subscription.
+func NewDeadLetters(client DeadLetterClient, subscription string) *DeadLetters {
+	return &DeadLetters{client: client, subscription: subscription}
+}
+
+// NewDeadLetterMessage returns the message sending the finding of m to the dead-letter topic. The
+// attributes of m are kept so the replays of a finding are counted across failures.
+func NewDeadLetterMessage(m *pubsub.Message, err error, now time.Time) *pubsub.Message {
+	attrs := map[string]string{}
+	for k, v := range m.Attributes {
+		attrs[k] = v
+	}
+	attrs[deadLetterError] = err.Error()
+	attrs[deadLetterTime] = now.UTC().Format(time.RFC3339)
+	return &pubsub.Message{Data: m.Data, Attributes: attrs}
+}
+
+// ReplayMessage returns the message sending the finding back to the router.
+func (d *DeadLetter) ReplayMessage() *pubsub.Message {
+	attrs := map[string]string{}
+	for k, v := range d.Attributes {
+		attrs[k] = v
+	}
+	delete(attrs, deadLetterError)
+	delete(attrs, deadLetterTime)
+	attrs[deadLetterReplays] = strconv.Itoa(d.Replays + 1)
+	return &pubsub.Message{Data: d.Data, Attributes: attrs}
+}
+
+// Receive returns up to max dead letters. Dead letters not acknowledged are delivered again once
+// the subscription's acknowledgement deadline passes.
+func (d *DeadLetters) Receive(ctx context.Context, max int) ([]*DeadLetter, error) {
+	received, err := d.client.Pull(ctx, d.subscription, max)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to pull dead letters from %q", d.subscription)
+	}
+	letters := make([]*DeadLetter, 0, len(received))
+	for _, r := range received {
+		m := r.GetMessage()
+		l := &DeadLetter{
+			ID:         m.GetMessageId(),
+			AckID:      r.GetAckId(),
+			Data:       m.GetData(),
+			Attributes: m.GetAttributes(),
+			Error:      m.GetAttributes()[deadLetterError],
+		}
+		if t, err := time.Parse(time.RFC3339, m.GetAttributes()[deadLetterTime]); err == nil {
+			l.Time = t
+		}
+		if n, err := strconv.Atoi(m.GetAttributes()[deadLetterReplays]); err == nil {
+			l.Replays = n
+		}
+		letters = append(letters, l)
+	}
+	return letters, nil
+}
+
+// Ack acknowledges the dead letters so they're not delivered again.
+func (d *DeadLetters) Ack(ctx context.Context, letters []*DeadLetter) error {
+	if len(letters) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(letters))
+	for _, l := range letters {
+		ids = append(ids, l.AckID)
+	}
+	if err := d.client.Acknowledge(ctx, d.subscription, ids); err != nil {
+		return errors.Wrapf(err, "failed to acknowledge %d dead letters", len(ids))
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+	pubsubpb "google.golang.org/genproto/googleapis/pubsub/v1"
+)
+
+func TestDeadLetters(t *testing.T) {
+	ctx := context.Background()
+	failed := time.Date(2020, 3, 1, 10, 0, 0, 0, time.UTC)
+	for _, tt := range []struct {
+		name       string
+		attributes map[string]string
+		replays    int
+		replayed   map[string]string
+	}{
+		{
+			name:     "first failure",
+			replayed: map[string]string{"sra-replays": "1"},
+		},
+		{
+			name:       "failed after replays",
+			attributes: map[string]string{"sra-replays": "2", "source": "siem"},
+			replays:    2,
+			replayed:   map[string]string{"sra-replays": "3", "source": "siem"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewDeadLetterMessage(&pubsub.Message{Data: []byte("finding"), Attributes: tt.attributes}, errors.New("permission denied"), failed)
+			subStub := &stubs.SubscriberStub{Messages: []*pubsubpb.ReceivedMessage{
+				{AckId: "ack-1", Message: &pubsubpb.PubsubMessage{MessageId: "1", Data: m.Data, Attributes: m.Attributes}},
+			}}
+			dl := NewDeadLetters(subStub, DeadLetterSubscription)
+			letters, err := dl.Receive(ctx, 10)
+			if err != nil {
+				t.Fatalf("%s failed to receive: %q", tt.name, err)
+			}
+			if len(letters) != 1 {
+				t.Fatalf("%s got %d dead letters want 1", tt.name, len(letters))
+			}
+			l := letters[0]
+			if l.Error != "permission denied" || !l.Time.Equal(failed) || l.Replays != tt.replays {
+				t.Errorf("%s got dead letter %+v", tt.name, l)
+			}
+			r := l.ReplayMessage()
+			if diff := cmp.Diff(tt.replayed, r.Attributes); diff != "" {
+				t.Errorf("%s replay attributes difference:%+v", tt.name, diff)
+			}
+			if err := dl.Ack(ctx, letters); err != nil {
+				t.Fatalf("%s failed to ack: %q", tt.name, err)
+			}
+			if diff := cmp.Diff([]string{"ack-1"}, subStub.Acked); diff != "" {
+				t.Errorf("%s acked difference:%+v", tt.name, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"time"
+)
+
+// DeadlineMargin is how long before the function's timeout its context is cancelled. The margin
+// leaves time to record the automation's progress and outcome before the runtime stops it.
+var DeadlineMargin = 5 * time.Second
+
+// WithDeadline returns a copy of ctx that is cancelled DeadlineMargin before timeout elapses, or
+// earlier if ctx has an earlier deadline. In-flight API calls made with the returned context are
+// cancelled once it's done. A timeout of zero only adds cancellation.
+func WithDeadline(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	d := timeout - DeadlineMargin
+	if d <= 0 {
+		d = timeout / 2
+	}
+	return context.WithTimeout(ctx, d)
+}
+
+// Detach returns a context carrying the values of ctx that isn't cancelled along with it. The
+// context expires after DeadlineMargin. It's used to record progress after ctx has run out.
+func Detach(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(detached{parent: ctx}, DeadlineMargin)
+}
+
+// detached is a context that keeps the values of its parent but not its deadline or cancellation.
+type detached struct {
+	parent context.Context
+}
+
+func (detached) Deadline() (time.Time, bool)         { return time.Time{}, false }
+func (detached) Done() <-chan struct{}               { return nil }
+func (detached) Err() error                          { return nil }
+func (d detached) Value(key interface{}) interface{} { return d.parent.Value(key) }
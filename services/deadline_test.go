@@ -0,0 +1,60 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestWithDeadline(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		timeout     time.Duration
+		hasDeadline bool
+		max         time.Duration
+	}{
+		{name: "no timeout", timeout: 0, hasDeadline: false},
+		{name: "margin removed", timeout: time.Minute, hasDeadline: true, max: time.Minute - DeadlineMargin},
+		{name: "short timeout halved", timeout: 2 * time.Second, hasDeadline: true, max: time.Second},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := WithDeadline(context.Background(), tt.timeout)
+			defer cancel()
+			deadline, ok := ctx.Deadline()
+			if ok != tt.hasDeadline {
+				t.Fatalf("got deadline %t want %t", ok, tt.hasDeadline)
+			}
+			if ok && time.Until(deadline) > tt.max {
+				t.Errorf("deadline in %s, want at most %s", time.Until(deadline), tt.max)
+			}
+		})
+	}
+}
+
+func TestDetach(t *testing.T) {
+	type key struct{}
+	parent, cancel := context.WithCancel(context.WithValue(context.Background(), key{}, "value"))
+	cancel()
+	ctx, cancel := Detach(parent)
+	defer cancel()
+	if err := ctx.Err(); err != nil {
+		t.Errorf("detached context is done: %q", err)
+	}
+	if got := ctx.Value(key{}); got != "value" {
+		t.Errorf("got value %v want %q", got, "value")
+	}
+}
@@ -36,6 +36,7 @@ var (
 // EmailClient is the interface used for sending emails.
 type EmailClient interface {
 	Send(subject, from, body string, to []string) (*rest.Response, error)
+	SendHTML(subject, from, body string, to []string) (*rest.Response, error)
 }
 
 // EmailResponse contains the response from sending an email.
@@ -59,6 +60,11 @@ func (m *Email) Send(subject, from, body string, to []string) (*rest.Response, e
 	return m.service.Send(subject, from, body, to)
 }
 
+// SendHTML will send an email with an HTML body.
+func (m *Email) SendHTML(subject, from, body string, to []string) (*rest.Response, error) {
+	return m.service.SendHTML(subject, from, body, to)
+}
+
 // RenderTemplate parses the content based on template.
 func (m *Email) RenderTemplate(templateName string, templateContent interface{}) (string, error) {
 	fileName := filepath.Join(templatesPath, templateName)
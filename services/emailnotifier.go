@@ -0,0 +1,132 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"bytes"
+	"context"
+	"html/template"
+
+	"github.com/pkg/errors"
+)
+
+// summaryTemplate is the HTML body of the email sent after an automation runs.
+var summaryTemplate = template.Must(template.New("summary").Parse(`<html>
+<body>
+<h2>Security Response Automation: {{.Action}}</h2>
+<table>
+<tr><td><b>Project</b></td><td>{{.ProjectID}}</td></tr>
+<tr><td><b>Result</b></td><td>{{.Result}}</td></tr>
+{{- if .Severity}}
+<tr><td><b>Severity</b></td><td>{{.Severity}}</td></tr>
+{{- end}}
+<tr><td><b>Time</b></td><td>{{.Time}}</td></tr>
+<tr><td><b>Event ID</b></td><td>{{.ID}}</td></tr>
+{{- if .CorrelationID}}
+<tr><td><b>Correlation ID</b></td><td>{{.CorrelationID}}</td></tr>
+{{- end}}
+{{- if .Error}}
+<tr><td><b>Error</b></td><td>{{.Error}}</td></tr>
+{{- end}}
+{{- if .Runbook}}
+<tr><td><b>Runbook</b></td><td><a href="{{.Runbook}}">{{.Runbook}}</a></td></tr>
+{{- end}}
+</table>
+{{- if .Activity}}
+<h3>Recent activity</h3>
+<table>
+<tr><th>Time</th><th>Principal</th><th>Caller IP</th><th>Method</th><th>Resource</th></tr>
+{{- range .Activity}}
+<tr><td>{{.Time}}</td><td>{{.Principal}}</td><td>{{.CallerIP}}</td><td>{{.Method}}</td><td>{{.Resource}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if .Intel}}
+<h3>IP intelligence</h3>
+<table>
+<tr><th>IP</th><th>Reputation</th><th>Country</th></tr>
+{{- range .Intel}}
+<tr><td>{{.IP}}</td><td>{{.Reputation}}</td><td>{{.Country}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if .Finding}}
+<h3>Finding</h3>
+<pre>{{printf "%s" .Finding}}</pre>
+{{- end}}
+</body>
+</html>
+`))
+
+// EmailNotifier emails an HTML summary of the outcome of an automation.
+type EmailNotifier struct {
+	email    *Email
+	from     string
+	messages *Messages
+}
+
+// NewEmailNotifier returns a notifier sending emails from the given address. Messages can be nil
+// to send the built-in summary.
+func NewEmailNotifier(email *Email, from string, messages *Messages) *EmailNotifier {
+	return &EmailNotifier{email: email, from: from, messages: messages}
+}
+
+// Notify emails the summary of the event to the recipients.
+func (n *EmailNotifier) Notify(ctx context.Context, event *WebhookEvent, to []string) error {
+	body, err := n.messages.Render(ctx, MessageEmail, event)
+	if err != nil {
+		return err
+	}
+	if body == "" {
+		if body, err = renderSummary(event); err != nil {
+			return err
+		}
+	}
+	subject, err := n.messages.Render(ctx, MessageSubject, event)
+	if err != nil {
+		return err
+	}
+	if subject == "" {
+		subject = summarySubject(event)
+	}
+	if _, err := n.email.SendHTML(subject, n.from, body, to); err != nil {
+		return errors.Wrapf(err, "failed to email summary of %q", event.ID)
+	}
+	return nil
+}
+
+// Recipients returns a notifier emailing the summary of each event to the given recipients.
+func (n *EmailNotifier) Recipients(to []string) Notifier {
+	return &emailRecipients{notifier: n, to: to}
+}
+
+// emailRecipients emails the summary of each event to a fixed list of recipients.
+type emailRecipients struct {
+	notifier *EmailNotifier
+	to       []string
+}
+
+func (r *emailRecipients) Notify(ctx context.Context, event *WebhookEvent) error {
+	return r.notifier.Notify(ctx, event, r.to)
+}
+
+// renderSummary returns the HTML summary of the event.
+func renderSummary(event *WebhookEvent) (string, error) {
+	var b bytes.Buffer
+	if err := summaryTemplate.Execute(&b, event); err != nil {
+		return "", errors.Wrap(errParseTemplate, err.Error())
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,113 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+)
+
+func TestEmailNotifierNotify(t *testing.T) {
+	tests := []struct {
+		name     string
+		event    *WebhookEvent
+		contains []string
+		excludes []string
+	}{
+		{
+			name: "success",
+			event: &WebhookEvent{
+				ID:        "event-id",
+				Action:    "close_bucket",
+				ProjectID: "project-id",
+				Severity:  "HIGH",
+				Result:    WebhookResultSuccess,
+				Finding:   json.RawMessage(`{"bucket_name":"<public>"}`),
+			},
+			contains: []string{"close_bucket", "project-id", "HIGH", "event-id", "&lt;public&gt;"},
+			excludes: []string{"Error"},
+		},
+		{
+			name: "failure",
+			event: &WebhookEvent{
+				ID:        "event-id",
+				Action:    "close_bucket",
+				ProjectID: "project-id",
+				Result:    WebhookResultFailure,
+				Error:     "permission denied",
+			},
+			contains: []string{"permission denied", WebhookResultFailure},
+			excludes: []string{"Severity", "Finding", "Recent activity"},
+		},
+		{
+			name: "recent activity",
+			event: &WebhookEvent{
+				ID:        "event-id",
+				Action:    "close_bucket",
+				ProjectID: "project-id",
+				Result:    WebhookResultSuccess,
+				Activity: []AdminActivity{
+					{Time: "2020-03-02T10:30:00Z", Principal: "mallory@example.com", CallerIP: "198.51.100.4", Method: "storage.setIamPermissions", Resource: "projects/_/buckets/public"},
+				},
+			},
+			contains: []string{"Recent activity", "mallory@example.com", "198.51.100.4", "storage.setIamPermissions"},
+		},
+		{
+			name: "ip intelligence",
+			event: &WebhookEvent{
+				Action:    "remove_public_ip",
+				ProjectID: "project-id",
+				Result:    WebhookResultSuccess,
+				Intel:     []IPIntel{{IP: "198.51.100.7", Reputation: ReputationMalicious, Country: "RU"}},
+			},
+			contains: []string{"IP intelligence", "198.51.100.7", "malicious", "RU"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			emailStub := &stubs.EmailStub{}
+			n := NewEmailNotifier(NewEmail(emailStub), "sra@example.com", nil)
+			to := []string{"soc@example.com"}
+			if err := n.Notify(context.Background(), tt.event, to); err != nil {
+				t.Fatalf("%s failed: %q", tt.name, err)
+			}
+			if len(emailStub.SentEmails) != 1 {
+				t.Fatalf("%s failed: expected one email, got %d", tt.name, len(emailStub.SentEmails))
+			}
+			sent := emailStub.SentEmails[0]
+			if diff := cmp.Diff(to, sent.To); diff != "" {
+				t.Errorf("%s failed, difference: %v", tt.name, diff)
+			}
+			if !sent.HTML {
+				t.Errorf("%s failed: expected an HTML email", tt.name)
+			}
+			for _, s := range tt.contains {
+				if !strings.Contains(sent.Body, s) {
+					t.Errorf("%s failed: body does not contain %q", tt.name, s)
+				}
+			}
+			for _, s := range tt.excludes {
+				if strings.Contains(sent.Body, s) {
+					t.Errorf("%s failed: body contains %q", tt.name, s)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"cloud.google.com/go/functions/metadata"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorKind classifies errors so cloud functions can decide whether retrying could succeed.
+type ErrorKind string
+
+// Kinds of errors.
+const (
+	// KindUnknown is the kind of errors that aren't classified. They are retried.
+	KindUnknown ErrorKind = ""
+	// KindNotFound is the kind of errors caused by a resource that doesn't exist.
+	KindNotFound ErrorKind = "not_found"
+	// KindPermissionDenied is the kind of errors caused by missing permissions.
+	KindPermissionDenied ErrorKind = "permission_denied"
+	// KindConflict is the kind of errors caused by a concurrent change, such as a policy written
+	// with a stale etag. They are retried.
+	KindConflict ErrorKind = "conflict"
+	// KindInvalidFinding is the kind of errors caused by a finding that can't be parsed or lacks
+	// the values an automation needs.
+	KindInvalidFinding ErrorKind = "invalid_finding"
+	// KindExempted is the kind of errors caused by a resource being excluded from an automation.
+	KindExempted ErrorKind = "exempted"
+	// KindDeadlineExceeded is the kind of errors caused by the function running out of time before
+	// a call completed. They are retried.
+	KindDeadlineExceeded ErrorKind = "deadline_exceeded"
+)
+
+// Error is an error of a known kind.
+type Error struct {
+	Kind ErrorKind
+	Err  error
+}
+
+// Error returns the message of the wrapped error.
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+// NotFound returns err classified as a resource that doesn't exist.
+func NotFound(err error) error { return &Error{Kind: KindNotFound, Err: err} }
+
+// PermissionDenied returns err classified as missing permissions.
+func PermissionDenied(err error) error { return &Error{Kind: KindPermissionDenied, Err: err} }
+
+// Conflict returns err classified as a concurrent change.
+func Conflict(err error) error { return &Error{Kind: KindConflict, Err: err} }
+
+// InvalidFinding returns err classified as a finding that can't be acted on.
+func InvalidFinding(err error) error { return &Error{Kind: KindInvalidFinding, Err: err} }
+
+// Exempted returns err classified as a resource excluded from an automation.
+func Exempted(err error) error { return &Error{Kind: KindExempted, Err: err} }
+
+// Kind returns the kind of err. Errors wrapped with github.com/pkg/errors are unwrapped and errors
+// returned by Google APIs over REST or gRPC are classified by their status.
+func Kind(err error) ErrorKind {
+	for err != nil {
+		switch e := err.(type) {
+		case *Error:
+			return e.Kind
+		case *googleapi.Error:
+			return httpKind(e.Code)
+		case *url.Error:
+			err = e.Err
+			continue
+		}
+		if err == context.DeadlineExceeded || err == context.Canceled {
+			return KindDeadlineExceeded
+		}
+		if s, ok := status.FromError(err); ok && s.Code() != codes.Unknown {
+			return grpcKind(s.Code())
+		}
+		c, ok := err.(interface{ Cause() error })
+		if !ok {
+			break
+		}
+		err = c.Cause()
+	}
+	return KindUnknown
+}
+
+// IsNotFound returns whether err is caused by a resource that doesn't exist.
+func IsNotFound(err error) bool { return Kind(err) == KindNotFound }
+
+// IsPermissionDenied returns whether err is caused by missing permissions.
+func IsPermissionDenied(err error) bool { return Kind(err) == KindPermissionDenied }
+
+// IsConflict returns whether err is caused by a concurrent change.
+func IsConflict(err error) bool { return Kind(err) == KindConflict }
+
+// IsInvalidFinding returns whether err is caused by a finding that can't be acted on.
+func IsInvalidFinding(err error) bool { return Kind(err) == KindInvalidFinding }
+
+// IsExempted returns whether err is caused by a resource excluded from an automation.
+func IsExempted(err error) bool { return Kind(err) == KindExempted }
+
+// IsDeadlineExceeded returns whether err is caused by the function running out of time.
+func IsDeadlineExceeded(err error) bool { return Kind(err) == KindDeadlineExceeded }
+
+// Permanent returns whether err would happen again if the automation were retried. The Pub/Sub
+// message of a permanent failure should be acknowledged rather than redelivered.
+func Permanent(err error) bool {
+	switch Kind(err) {
+	case KindNotFound, KindPermissionDenied, KindInvalidFinding, KindExempted:
+		return true
+	default:
+		return false
+	}
+}
+
+// RetryWindow is how long after its event was published a failed automation is retried. Pub/Sub
+// triggered functions are redelivered for up to 7 days, so failures are final past the window.
+var RetryWindow = time.Hour
+
+// FinalAttempt returns whether a failure of the event being handled won't be retried, because it
+// was published more than RetryWindow ago. Events without metadata, such as those run outside of a
+// trigger, aren't redelivered and are always final.
+func FinalAttempt(ctx context.Context) bool {
+	m, err := metadata.FromContext(ctx)
+	if err != nil || m.Timestamp.IsZero() {
+		return true
+	}
+	return time.Since(m.Timestamp) > RetryWindow
+}
+
+func httpKind(code int) ErrorKind {
+	switch code {
+	case http.StatusNotFound, http.StatusGone:
+		return KindNotFound
+	case http.StatusForbidden, http.StatusUnauthorized:
+		return KindPermissionDenied
+	case http.StatusConflict, http.StatusPreconditionFailed:
+		return KindConflict
+	default:
+		return KindUnknown
+	}
+}
+
+func grpcKind(code codes.Code) ErrorKind {
+	switch code {
+	case codes.NotFound:
+		return KindNotFound
+	case codes.PermissionDenied, codes.Unauthenticated:
+		return KindPermissionDenied
+	case codes.AlreadyExists, codes.Aborted:
+		return KindConflict
+	case codes.DeadlineExceeded, codes.Canceled:
+		return KindDeadlineExceeded
+	default:
+		return KindUnknown
+	}
+}
@@ -0,0 +1,90 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/functions/metadata"
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+	"github.com/pkg/errors"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestErrorKind(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		err       error
+		kind      ErrorKind
+		permanent bool
+	}{
+		{name: "nil", err: nil, kind: KindUnknown},
+		{name: "unclassified", err: fmt.Errorf("failed"), kind: KindUnknown},
+		{name: "typed", err: InvalidFinding(fmt.Errorf("bad finding")), kind: KindInvalidFinding, permanent: true},
+		{name: "wrapped typed", err: errors.Wrap(Exempted(fmt.Errorf("excluded")), "failed to publish"), kind: KindExempted, permanent: true},
+		{name: "rest not found", err: errors.Wrap(stubs.ErrNonexistentVM, "failed to get instance"), kind: KindNotFound, permanent: true},
+		{name: "rest forbidden", err: &googleapi.Error{Code: http.StatusForbidden}, kind: KindPermissionDenied, permanent: true},
+		{name: "rest conflict", err: &googleapi.Error{Code: http.StatusPreconditionFailed}, kind: KindConflict},
+		{name: "rest unavailable", err: &googleapi.Error{Code: http.StatusServiceUnavailable}, kind: KindUnknown},
+		{name: "grpc not found", err: stubs.ErrEntityNonExistent, kind: KindNotFound, permanent: true},
+		{name: "grpc aborted", err: errors.Wrap(status.Error(codes.Aborted, "concurrent change"), "failed"), kind: KindConflict},
+		{name: "deadline", err: errors.Wrap(context.DeadlineExceeded, "failed to get policy"), kind: KindDeadlineExceeded},
+		{name: "http deadline", err: &url.Error{Op: "Get", URL: "https://example.com", Err: context.DeadlineExceeded}, kind: KindDeadlineExceeded},
+		{name: "grpc deadline", err: status.Error(codes.DeadlineExceeded, "context deadline exceeded"), kind: KindDeadlineExceeded},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Kind(tt.err); got != tt.kind {
+				t.Errorf("got kind %q want %q", got, tt.kind)
+			}
+			if got := Permanent(tt.err); got != tt.permanent {
+				t.Errorf("got permanent %t want %t", got, tt.permanent)
+			}
+		})
+	}
+}
+
+func TestServiceErrorsKeepKind(t *testing.T) {
+	computeStub := &stubs.ComputeStub{}
+	computeStub.Inject("GetInstance", stubs.Result{Err: stubs.ErrNonexistentVM})
+	_, err := NewHost(computeStub).HasExternalIP(context.Background(), "project-id", "zone", "nonexistent")
+	if !IsNotFound(err) || !Permanent(err) {
+		t.Errorf("got kind %q for %q want not found", Kind(err), err)
+	}
+}
+
+func TestFinalAttempt(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		ctx   context.Context
+		final bool
+	}{
+		{name: "no metadata", ctx: context.Background(), final: true},
+		{name: "within window", ctx: metadata.NewContext(context.Background(), &metadata.Metadata{Timestamp: time.Now().Add(-time.Minute)})},
+		{name: "past window", ctx: metadata.NewContext(context.Background(), &metadata.Metadata{Timestamp: time.Now().Add(-RetryWindow - time.Minute)}), final: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FinalAttempt(tt.ctx); got != tt.final {
+				t.Errorf("got final %t want %t", got, tt.final)
+			}
+		})
+	}
+}
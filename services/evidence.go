@@ -0,0 +1,189 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/logging"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+)
+
+const (
+	// evidenceSnapshotPrefix is the prefix used for snapshots taken as evidence.
+	evidenceSnapshotPrefix = "evidence-"
+	// flowLogsWindow is how far back VPC flow logs are collected.
+	flowLogsWindow = time.Hour
+	// maxFlowLogEntries is the maximum number of VPC flow log entries collected.
+	maxFlowLogEntries = 1000
+	// flowLogsFilter selects VPC flow logs sent or received by an instance.
+	flowLogsFilter = `logName="projects/%s/logs/compute.googleapis.com%%2Fvpc_flows" AND (jsonPayload.src_instance.vm_name="%s" OR jsonPayload.dest_instance.vm_name="%s") AND timestamp>="%s"`
+)
+
+// EvidenceStorageClient contains minimum interface required by the evidence service to store evidence.
+type EvidenceStorageClient interface {
+	WriteObject(context.Context, string, string, []byte) error
+}
+
+// LogAdminClient contains minimum interface required to read log entries.
+type LogAdminClient interface {
+	ListEntries(context.Context, string, string, int) ([]*logging.Entry, error)
+}
+
+// Evidence service.
+type Evidence struct {
+	host    *Host
+	compute ComputeClient
+	storage EvidenceStorageClient
+	logs    LogAdminClient
+}
+
+// EvidenceManifest describes the evidence collected for an instance.
+type EvidenceManifest struct {
+	ProjectID   string   `json:"project_id"`
+	Zone        string   `json:"zone"`
+	Instance    string   `json:"instance"`
+	Bucket      string   `json:"bucket"`
+	CollectedAt string   `json:"collected_at"`
+	Objects     []string `json:"objects"`
+	Snapshots   []string `json:"snapshots"`
+	// Errors contains evidence that could not be collected.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// NewEvidence returns an evidence service.
+func NewEvidence(cs ComputeClient, st EvidenceStorageClient, la LogAdminClient) *Evidence {
+	return &Evidence{host: NewHost(cs), compute: cs, storage: st, logs: la}
+}
+
+// CollectInstance gathers instance metadata, serial console output, recent VPC flow logs and disk
+// snapshots for the given instance. Everything but the disk snapshots is written to the bucket
+// along with a manifest describing what was collected.
+//
+// Serial console output and flow logs are collected on a best effort basis, failures are recorded
+// in the manifest. Failing to save the metadata, snapshots or manifest returns an error so callers
+// can avoid running destructive remediations without evidence.
+func (e *Evidence) CollectInstance(ctx context.Context, projectID, zone, instance, bucket string) (*EvidenceManifest, error) {
+	now := time.Now().UTC()
+	m := &EvidenceManifest{
+		ProjectID:   projectID,
+		Zone:        zone,
+		Instance:    instance,
+		Bucket:      bucket,
+		CollectedAt: now.Format(time.RFC3339),
+		Objects:     []string{},
+		Snapshots:   []string{},
+	}
+	prefix := fmt.Sprintf("%s/%s/%d/", projectID, instance, now.Unix())
+
+	i, err := e.compute.GetInstance(ctx, projectID, zone, instance)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get instance %q", instance)
+	}
+	b, err := json.MarshalIndent(i, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal instance")
+	}
+	if err := e.write(ctx, m, prefix+"metadata.json", b); err != nil {
+		return nil, err
+	}
+
+	if out, err := e.compute.GetSerialPortOutput(ctx, projectID, zone, instance); err != nil {
+		m.Errors = append(m.Errors, fmt.Sprintf("serial console: %s", err))
+	} else if err := e.write(ctx, m, prefix+"serial-console.txt", []byte(out.Contents)); err != nil {
+		return nil, err
+	}
+
+	if b, err := e.flowLogs(ctx, projectID, instance, now.Add(-flowLogsWindow)); err != nil {
+		m.Errors = append(m.Errors, fmt.Sprintf("flow logs: %s", err))
+	} else if err := e.write(ctx, m, prefix+"flow-logs.json", b); err != nil {
+		return nil, err
+	}
+
+	disks, err := e.host.ListInstanceDisks(ctx, projectID, zone, instance)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list disks for %q", instance)
+	}
+	for _, disk := range disks {
+		name := evidenceSnapshotName(disk.Name, now)
+		if err := e.host.CreateDiskSnapshot(ctx, projectID, zone, disk.Name, name); err != nil {
+			return nil, errors.Wrapf(err, "failed to snapshot disk %q", disk.Name)
+		}
+		m.Snapshots = append(m.Snapshots, name)
+	}
+
+	b, err = json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal manifest")
+	}
+	if err := e.storage.WriteObject(ctx, bucket, prefix+"manifest.json", b); err != nil {
+		return nil, errors.Wrap(err, "failed to write manifest")
+	}
+	return m, nil
+}
+
+// write stores the object in the evidence bucket and records it in the manifest.
+func (e *Evidence) write(ctx context.Context, m *EvidenceManifest, object string, content []byte) error {
+	if err := e.storage.WriteObject(ctx, m.Bucket, object, content); err != nil {
+		return errors.Wrapf(err, "failed to write %q", object)
+	}
+	m.Objects = append(m.Objects, object)
+	return nil
+}
+
+// flowLogs returns the VPC flow logs of the instance since the given time as a JSON array.
+func (e *Evidence) flowLogs(ctx context.Context, projectID, instance string, since time.Time) ([]byte, error) {
+	filter := fmt.Sprintf(flowLogsFilter, projectID, instance, instance, since.Format(time.RFC3339))
+	entries, err := e.logs.ListEntries(ctx, projectID, filter, maxFlowLogEntries)
+	if err != nil {
+		return nil, err
+	}
+	payloads := []json.RawMessage{}
+	for _, entry := range entries {
+		p, err := entryPayload(entry)
+		if err != nil {
+			return nil, err
+		}
+		payloads = append(payloads, p)
+	}
+	return json.MarshalIndent(payloads, "", "  ")
+}
+
+// entryPayload returns the JSON representation of a log entry's payload.
+func entryPayload(entry *logging.Entry) (json.RawMessage, error) {
+	if m, ok := entry.Payload.(proto.Message); ok {
+		s, err := (&jsonpb.Marshaler{}).MarshalToString(m)
+		if err != nil {
+			return nil, err
+		}
+		return json.RawMessage(s), nil
+	}
+	return json.Marshal(entry.Payload)
+}
+
+// evidenceSnapshotName returns a snapshot name within the 63 character limit.
+func evidenceSnapshotName(disk string, t time.Time) string {
+	name := fmt.Sprintf("%s%d-%s", evidenceSnapshotPrefix, t.Unix(), disk)
+	if len(name) > 63 {
+		name = name[:63]
+	}
+	return strings.TrimRight(name, "-")
+}
@@ -0,0 +1,117 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/logging"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+	"google.golang.org/api/compute/v1"
+)
+
+func TestCollectInstance(t *testing.T) {
+	const (
+		projectID = "test-project"
+		zone      = "us-central1-a"
+		instance  = "compromised-instance"
+		bucket    = "forensics-bucket"
+	)
+	disks := &compute.DiskList{Items: []*compute.Disk{
+		{Name: "boot-disk", Users: []string{"projects/test-project/zones/us-central1-a/instances/compromised-instance"}},
+		{Name: "other-disk", Users: []string{"projects/test-project/zones/us-central1-a/instances/other-instance"}},
+	}}
+	tests := []struct {
+		name              string
+		serialPortOutput  *compute.SerialPortOutput
+		instance          *compute.Instance
+		expectedObjects   []string
+		expectedSnapshots []string
+		expectedErrors    int
+		expectedError     bool
+	}{
+		{
+			name:              "collect all evidence",
+			serialPortOutput:  &compute.SerialPortOutput{Contents: "booting"},
+			instance:          &compute.Instance{Name: instance},
+			expectedObjects:   []string{"flow-logs.json", "manifest.json", "metadata.json", "serial-console.txt"},
+			expectedSnapshots: []string{"boot-disk"},
+		},
+		{
+			name:              "serial console not available",
+			instance:          &compute.Instance{Name: instance},
+			expectedObjects:   []string{"flow-logs.json", "manifest.json", "metadata.json"},
+			expectedSnapshots: []string{"boot-disk"},
+			expectedErrors:    1,
+		},
+		{
+			name:          "instance not found",
+			expectedError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			computeStub := &stubs.ComputeStub{
+				SavedCreateSnapshots:    make(map[string]compute.Snapshot),
+				StubbedInstance:         tt.instance,
+				StubbedListDisks:        disks,
+				StubbedSerialPortOutput: tt.serialPortOutput,
+				GetInstanceShouldFail:   tt.instance == nil,
+			}
+			storageStub := &stubs.StorageStub{}
+			logStub := &stubs.LogAdminStub{StubbedEntries: []*logging.Entry{{Payload: map[string]string{"src_ip": "10.0.0.2"}}}}
+			e := NewEvidence(computeStub, storageStub, logStub)
+			m, err := e.CollectInstance(ctx, projectID, zone, instance, bucket)
+			if tt.expectedError {
+				if err == nil {
+					t.Errorf("%s failed: expected error", tt.name)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%s failed: %q", tt.name, err)
+			}
+			objects := []string{}
+			for k := range storageStub.WrittenObjects {
+				if !strings.HasPrefix(k, bucket+"/"+projectID+"/"+instance+"/") {
+					t.Errorf("%s failed: unexpected object %q", tt.name, k)
+				}
+				objects = append(objects, k[strings.LastIndex(k, "/")+1:])
+			}
+			sort.Strings(objects)
+			if diff := cmp.Diff(tt.expectedObjects, objects); diff != "" {
+				t.Errorf("%s failed, difference: %+v", tt.name, diff)
+			}
+			snapshots := []string{}
+			for k := range computeStub.SavedCreateSnapshots {
+				snapshots = append(snapshots, k)
+			}
+			if diff := cmp.Diff(tt.expectedSnapshots, snapshots); diff != "" {
+				t.Errorf("%s failed, difference: %+v", tt.name, diff)
+			}
+			if len(m.Errors) != tt.expectedErrors {
+				t.Errorf("%s failed: got:%d want:%d errors", tt.name, len(m.Errors), tt.expectedErrors)
+			}
+			if !strings.Contains(logStub.SavedFilter, `jsonPayload.src_instance.vm_name="compromised-instance"`) {
+				t.Errorf("%s failed: unexpected filter %q", tt.name, logStub.SavedFilter)
+			}
+		})
+	}
+}
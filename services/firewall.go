@@ -21,11 +21,14 @@ import (
 
 	"github.com/pkg/errors"
 	compute "google.golang.org/api/compute/v1"
-	"google.golang.org/api/googleapi"
 )
 
-// sshBlockName is the firewall rule name created when blocking SSH.
-const sshBlockName = "automatic-ssh-block"
+const (
+	// SSHBlockName is the firewall rule name created when blocking SSH.
+	SSHBlockName = "automatic-ssh-block"
+	// RDPBlockName is the firewall rule name created when blocking RDP.
+	RDPBlockName = "automatic-rdp-block"
+)
 
 // FirewallClient holds the minimum interface required by the firewall service.
 type FirewallClient interface {
@@ -33,7 +36,7 @@ type FirewallClient interface {
 	PatchFirewallRule(context.Context, string, string, *compute.Firewall) (*compute.Operation, error)
 	FirewallRule(context.Context, string, string) (*compute.Firewall, error)
 	DeleteFirewallRule(context.Context, string, string) (*compute.Operation, error)
-	WaitGlobal(string, *compute.Operation) []error
+	WaitGlobal(context.Context, string, *compute.Operation) []error
 }
 
 // Firewall service.
@@ -48,25 +51,36 @@ func NewFirewall(client FirewallClient) *Firewall {
 
 // BlockSSH will add a firewall rule that blocks SSH for the given project.
 func (f *Firewall) BlockSSH(ctx context.Context, projectID string, sourceRanges []string) error {
-	log.Printf("will attempt to block ssh for %q in %q", sourceRanges, projectID)
-	fw, err := f.FirewallRule(ctx, projectID, sshBlockName)
+	return f.block(ctx, projectID, SSHBlockName, "SSH", "22", sourceRanges)
+}
+
+// BlockRDP will add a firewall rule that blocks RDP for the given project.
+func (f *Firewall) BlockRDP(ctx context.Context, projectID string, sourceRanges []string) error {
+	return f.block(ctx, projectID, RDPBlockName, "RDP", "3389", sourceRanges)
+}
+
+// block adds the source ranges to the named firewall rule denying the TCP port, creating the rule
+// if it doesn't exist yet.
+func (f *Firewall) block(ctx context.Context, projectID, name, protocol, port string, sourceRanges []string) error {
+	log.Printf("will attempt to block %s for %q in %q", protocol, sourceRanges, projectID)
+	fw, err := f.FirewallRule(ctx, projectID, name)
 	if err != nil {
-		switch err.(*googleapi.Error).Code {
-		case 404:
-			log.Println("adding a new firewall rule to block ssh")
-			return f.addFirewallRule(ctx, projectID, &compute.Firewall{
+		switch {
+		case IsNotFound(err):
+			log.Printf("adding a new firewall rule to block %s", protocol)
+			return f.AddFirewallRule(ctx, projectID, &compute.Firewall{
 				Denied: []*compute.FirewallDenied{
 					{
 						IPProtocol: "tcp",
-						Ports:      []string{"22"},
+						Ports:      []string{port},
 					},
 				},
-				Description:  "Block SSH TCP port 22 by Security Response Automation",
-				Name:         sshBlockName,
+				Description:  fmt.Sprintf("Block %s TCP port %s by Security Response Automation", protocol, port),
+				Name:         name,
 				SourceRanges: sourceRanges,
 			})
 		default:
-			return errors.Wrapf(err, "failed getting firewall rule: %q", sshBlockName)
+			return errors.Wrapf(err, "failed getting firewall rule: %q", name)
 		}
 	}
 
@@ -81,13 +95,48 @@ func (f *Firewall) BlockSSH(ctx context.Context, projectID string, sourceRanges
 	return nil
 }
 
-// addFirewallRule will add a firewall rule.
-func (f *Firewall) addFirewallRule(ctx context.Context, projectID string, fw *compute.Firewall) error {
+// Unblock removes the source ranges from the named firewall rule created by BlockSSH or BlockRDP,
+// deleting the rule once it no longer blocks any range. A range blocked again since is removed
+// as well.
+func (f *Firewall) Unblock(ctx context.Context, projectID, name string, sourceRanges []string) error {
+	fw, err := f.FirewallRule(ctx, projectID, name)
+	if IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed getting firewall rule: %q", name)
+	}
+	unblocked := make(map[string]bool)
+	for _, r := range sourceRanges {
+		unblocked[r] = true
+	}
+	remaining := []string{}
+	for _, r := range fw.SourceRanges {
+		if !unblocked[r] {
+			remaining = append(remaining, r)
+		}
+	}
+	ruleID := fmt.Sprintf("%d", fw.Id)
+	if len(remaining) > 0 {
+		return f.UpdateFirewallRuleSourceRange(ctx, projectID, ruleID, fw.Name, remaining)
+	}
+	op, err := f.DeleteFirewallRule(ctx, projectID, ruleID)
+	if err != nil {
+		return err
+	}
+	if errs := f.WaitGlobal(ctx, projectID, op); len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+// AddFirewallRule will add a firewall rule and wait for it to be created.
+func (f *Firewall) AddFirewallRule(ctx context.Context, projectID string, fw *compute.Firewall) error {
 	op, err := f.client.InsertFirewallRule(ctx, projectID, fw)
 	if err != nil {
 		return err
 	}
-	if errs := f.WaitGlobal(projectID, op); len(errs) > 0 {
+	if errs := f.WaitGlobal(ctx, projectID, op); len(errs) > 0 {
 		return errs[0]
 	}
 	return nil
@@ -95,7 +144,8 @@ func (f *Firewall) addFirewallRule(ctx context.Context, projectID string, fw *co
 
 // EnableFirewallRule sets the firewall rule to enabled.
 func (f *Firewall) EnableFirewallRule(ctx context.Context, projectID string, ruleID string, name string) (*compute.Operation, error) {
-	return f.client.PatchFirewallRule(ctx, projectID, ruleID, &compute.Firewall{Name: name, Disabled: false})
+	// Disabled must be sent explicitly since false is otherwise omitted from the patch.
+	return f.client.PatchFirewallRule(ctx, projectID, ruleID, &compute.Firewall{Name: name, Disabled: false, ForceSendFields: []string{"Disabled"}})
 }
 
 // DisableFirewallRule sets the firewall rule to disabled.
@@ -109,7 +159,7 @@ func (f *Firewall) UpdateFirewallRuleSourceRange(ctx context.Context, projectID
 	if err != nil {
 		return err
 	}
-	if errs := f.WaitGlobal(projectID, op); len(errs) > 0 {
+	if errs := f.WaitGlobal(ctx, projectID, op); len(errs) > 0 {
 		return errs[0]
 	}
 	return nil
@@ -126,6 +176,6 @@ func (f *Firewall) FirewallRule(ctx context.Context, projectID string, ruleID st
 }
 
 // WaitGlobal will wait for the global operation to complete.
-func (f *Firewall) WaitGlobal(project string, op *compute.Operation) []error {
-	return f.client.WaitGlobal(project, op)
+func (f *Firewall) WaitGlobal(ctx context.Context, project string, op *compute.Operation) []error {
+	return f.client.WaitGlobal(ctx, project, op)
 }
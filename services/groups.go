@@ -0,0 +1,90 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	admin "google.golang.org/api/admin/directory/v1"
+)
+
+// GroupsClient contains minimum interface required by the groups service.
+type GroupsClient interface {
+	GroupMembers(context.Context, string) ([]*admin.Member, error)
+	RemoveGroupMember(context.Context, string, string) error
+}
+
+// Groups service manages the members of Google Groups.
+type Groups struct {
+	client GroupsClient
+}
+
+// NewGroups returns a groups service.
+func NewGroups(client GroupsClient) *Groups {
+	return &Groups{client: client}
+}
+
+// ExternalMembers returns the email addresses of the group's direct members outside of the allowed
+// domains, other than the allowed members. Members of any type are returned, including nested groups
+// of other domains. Members without an email, such as the whole customer, are never returned.
+func (g *Groups) ExternalMembers(ctx context.Context, group string, allowDomains, allowMembers []string) ([]string, error) {
+	if len(allowDomains) == 0 {
+		return nil, errors.New("must provide at least one domain to allow")
+	}
+	members, err := g.client.GroupMembers(ctx, group)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get members of group %q", group)
+	}
+	domains := make(map[string]bool, len(allowDomains))
+	for _, d := range allowDomains {
+		domains[strings.ToLower(d)] = true
+	}
+	exempt := make(map[string]bool, len(allowMembers))
+	for _, m := range allowMembers {
+		exempt[strings.ToLower(memberEmail(m))] = true
+	}
+	external := []string{}
+	for _, m := range members {
+		email := strings.ToLower(m.Email)
+		i := strings.LastIndex(email, "@")
+		if i < 0 || domains[email[i+1:]] || exempt[email] {
+			continue
+		}
+		external = append(external, m.Email)
+	}
+	return external, nil
+}
+
+// RemoveMembers removes the members from the group, returning those removed before any failure.
+func (g *Groups) RemoveMembers(ctx context.Context, group string, members []string) ([]string, error) {
+	removed := []string{}
+	for _, m := range members {
+		if err := g.client.RemoveGroupMember(ctx, group, m); err != nil {
+			return removed, errors.Wrapf(err, "failed to remove %q from group %q", m, group)
+		}
+		removed = append(removed, m)
+	}
+	return removed, nil
+}
+
+// memberEmail returns the email of an IAM member such as "user:tom@gmail.com".
+func memberEmail(member string) string {
+	if i := strings.Index(member, ":"); i >= 0 {
+		return member[i+1:]
+	}
+	return member
+}
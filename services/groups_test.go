@@ -0,0 +1,69 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+	admin "google.golang.org/api/admin/directory/v1"
+)
+
+func TestExternalMembers(t *testing.T) {
+	groupsStub := &stubs.GroupsStub{StubbedMembers: map[string][]*admin.Member{
+		"eng@example.com": {
+			{Email: "alice@example.com", Type: "USER"},
+			{Email: "Eve@Gmail.com", Type: "USER"},
+			{Email: "contractor@partner.com", Type: "USER"},
+			{Email: "outsiders@other.com", Type: "GROUP"},
+			{Type: "CUSTOMER"},
+		},
+	}}
+	g := NewGroups(groupsStub)
+	ctx := context.Background()
+	for _, tt := range []struct {
+		name         string
+		allowDomains []string
+		allowMembers []string
+		expected     []string
+	}{
+		{
+			name:         "external members",
+			allowDomains: []string{"example.com"},
+			expected:     []string{"Eve@Gmail.com", "contractor@partner.com", "outsiders@other.com"},
+		},
+		{
+			name:         "allowed members",
+			allowDomains: []string{"example.com", "partner.com"},
+			allowMembers: []string{"user:eve@gmail.com"},
+			expected:     []string{"outsiders@other.com"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := g.ExternalMembers(ctx, "eng@example.com", tt.allowDomains, tt.allowMembers)
+			if err != nil {
+				t.Fatalf("%s failed: %q", tt.name, err)
+			}
+			if diff := cmp.Diff(tt.expected, got); diff != "" {
+				t.Errorf("%s failed, difference: %v", tt.name, diff)
+			}
+		})
+	}
+	if _, err := g.ExternalMembers(ctx, "eng@example.com", nil, nil); err == nil {
+		t.Errorf("expected an error without allowed domains")
+	}
+}
@@ -0,0 +1,49 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+)
+
+func TestWithHealth(t *testing.T) {
+	NewLogger(&stubs.LoggerStub{}).Event(NewWebhookEvent("close_bucket", "test-project", nil, false, nil))
+	h := WithHealth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	for _, tt := range []struct {
+		path       string
+		wantStatus int
+		wantBody   string
+	}{
+		{path: "/healthz", wantStatus: http.StatusOK, wantBody: "ok"},
+		{path: "/metrics", wantStatus: http.StatusOK, wantBody: `sra_remediations_total{action="close_bucket",result="success"}`},
+		{path: "/v1/actions", wantStatus: http.StatusTeapot},
+	} {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+		if w.Code != tt.wantStatus {
+			t.Errorf("%s: got status %d, want %d", tt.path, w.Code, tt.wantStatus)
+		}
+		if !strings.Contains(w.Body.String(), tt.wantBody) {
+			t.Errorf("%s: body %q does not contain %q", tt.path, w.Body.String(), tt.wantBody)
+		}
+	}
+}
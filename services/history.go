@@ -0,0 +1,163 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/logging"
+	"github.com/pkg/errors"
+)
+
+const (
+	// historyLog is the log automations write their events and audit records to.
+	historyLog = "security-response-automation"
+	// defaultHistoryLimit is the number of records returned if no limit is given.
+	defaultHistoryLimit = 100
+	// maxHistoryLimit is the largest number of records returned by a single query.
+	maxHistoryLimit = 1000
+)
+
+// History service reads the events and audit records automations logged.
+type History struct {
+	logs      LogAdminClient
+	projectID string
+}
+
+// HistoryQuery filters the records returned. Empty values are ignored.
+type HistoryQuery struct {
+	ProjectID   string
+	FindingName string
+	// Resource only applies to policy changes, i.e. "projects/p".
+	Resource string
+	Start    time.Time
+	End      time.Time
+	Limit    int
+}
+
+// AuditEntry is an audit record along with the time it was logged.
+type AuditEntry struct {
+	Time string `json:"time"`
+	*AuditRecord
+}
+
+// NewHistory returns a history service reading the logs of the project automations run in.
+func NewHistory(logs LogAdminClient, projectID string) *History {
+	return &History{logs: logs, projectID: projectID}
+}
+
+// Actions returns the events of the automations that ran, newest first.
+func (h *History) Actions(ctx context.Context, q *HistoryQuery) ([]*WebhookEvent, error) {
+	filter := h.filter(q, "jsonPayload.id:*")
+	if q.ProjectID != "" {
+		filter = append(filter, "jsonPayload.project_id="+strconv.Quote(q.ProjectID))
+	}
+	if q.FindingName != "" {
+		filter = append(filter, "jsonPayload.finding_name="+strconv.Quote(q.FindingName))
+	}
+	entries, err := h.logs.ListEntries(ctx, h.projectID, strings.Join(filter, " AND "), historyLimit(q.Limit))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list events")
+	}
+	events := make([]*WebhookEvent, 0, len(entries))
+	for _, entry := range entries {
+		var e WebhookEvent
+		if err := decodeEntry(entry, &e); err != nil {
+			return nil, err
+		}
+		events = append(events, &e)
+	}
+	return events, nil
+}
+
+// PolicyChanges returns the audit records of automations that changed an IAM policy, newest first.
+func (h *History) PolicyChanges(ctx context.Context, q *HistoryQuery) ([]*AuditEntry, error) {
+	return h.auditRecords(ctx, q, "jsonPayload.policy_changes:*")
+}
+
+// AuditRecords returns the audit records of automations, newest first.
+func (h *History) AuditRecords(ctx context.Context, q *HistoryQuery) ([]*AuditEntry, error) {
+	return h.auditRecords(ctx, q, "jsonPayload.resource:*")
+}
+
+// auditRecords returns the audit records of the given kind, filtered by resource or by the
+// project if no resource is given.
+func (h *History) auditRecords(ctx context.Context, q *HistoryQuery, kind string) ([]*AuditEntry, error) {
+	filter := h.filter(q, kind)
+	resource := q.Resource
+	if resource == "" && q.ProjectID != "" {
+		resource = "projects/" + q.ProjectID
+	}
+	if resource != "" {
+		filter = append(filter, "jsonPayload.resource="+strconv.Quote(resource))
+	}
+	entries, err := h.logs.ListEntries(ctx, h.projectID, strings.Join(filter, " AND "), historyLimit(q.Limit))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list audit records")
+	}
+	records := make([]*AuditEntry, 0, len(entries))
+	for _, entry := range entries {
+		r := &AuditEntry{Time: entry.Timestamp.UTC().Format(time.RFC3339), AuditRecord: &AuditRecord{}}
+		if err := decodeEntry(entry, r.AuditRecord); err != nil {
+			return nil, err
+		}
+		records = append(records, r)
+	}
+	return records, nil
+}
+
+// filter returns the conditions shared by all queries of the history log.
+func (h *History) filter(q *HistoryQuery, kind string) []string {
+	filter := []string{
+		fmt.Sprintf("logName=%q", "projects/"+h.projectID+"/logs/"+historyLog),
+		kind,
+	}
+	if !q.Start.IsZero() {
+		filter = append(filter, fmt.Sprintf("timestamp>=%q", q.Start.UTC().Format(time.RFC3339)))
+	}
+	if !q.End.IsZero() {
+		filter = append(filter, fmt.Sprintf("timestamp<%q", q.End.UTC().Format(time.RFC3339)))
+	}
+	return filter
+}
+
+// historyLimit returns the limit bounded to the maximum, or the default if none is given.
+func historyLimit(limit int) int {
+	switch {
+	case limit <= 0:
+		return defaultHistoryLimit
+	case limit > maxHistoryLimit:
+		return maxHistoryLimit
+	default:
+		return limit
+	}
+}
+
+// decodeEntry unmarshals the JSON payload of the log entry into v.
+func decodeEntry(entry *logging.Entry, v interface{}) error {
+	b, err := entryPayload(entry)
+	if err != nil {
+		return errors.Wrap(err, "failed to read log entry")
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return errors.Wrap(err, "failed to decode log entry")
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/logging"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+)
+
+func TestHistoryActions(t *testing.T) {
+	start := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	logStub := &stubs.LogAdminStub{StubbedEntries: []*logging.Entry{
+		{Payload: map[string]string{"id": "event-1", "action": "close_bucket", "project_id": "test-project", "result": WebhookResultSuccess}},
+	}}
+	h := NewHistory(logStub, "automation-project")
+	events, err := h.Actions(context.Background(), &HistoryQuery{ProjectID: "test-project", FindingName: "organizations/1/sources/2/findings/3", Start: start})
+	if err != nil {
+		t.Fatalf("failed to list actions: %q", err)
+	}
+	want := []*WebhookEvent{{ID: "event-1", Action: "close_bucket", ProjectID: "test-project", Result: WebhookResultSuccess}}
+	if diff := cmp.Diff(want, events); diff != "" {
+		t.Errorf("actions failed, difference: %v", diff)
+	}
+	filter := `logName="projects/automation-project/logs/security-response-automation" AND jsonPayload.id:* AND ` +
+		`timestamp>="2020-01-02T03:04:05Z" AND jsonPayload.project_id="test-project" AND ` +
+		`jsonPayload.finding_name="organizations/1/sources/2/findings/3"`
+	if diff := cmp.Diff(filter, logStub.SavedFilter); diff != "" {
+		t.Errorf("actions filter failed, difference: %v", diff)
+	}
+}
+
+func TestHistoryPolicyChanges(t *testing.T) {
+	ts := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	logStub := &stubs.LogAdminStub{StubbedEntries: []*logging.Entry{
+		{
+			Timestamp: ts,
+			Payload: &AuditRecord{
+				Action:        "iam_revoke",
+				Resource:      "projects/test-project",
+				Result:        AuditResultSuccess,
+				PolicyChanges: []BindingChange{{Role: "roles/editor", Member: "user:a@gmail.com", Change: BindingRemoved}},
+			},
+		},
+	}}
+	h := NewHistory(logStub, "automation-project")
+	records, err := h.PolicyChanges(context.Background(), &HistoryQuery{ProjectID: "test-project"})
+	if err != nil {
+		t.Fatalf("failed to list policy changes: %q", err)
+	}
+	want := []*AuditEntry{{
+		Time: "2020-01-02T03:04:05Z",
+		AuditRecord: &AuditRecord{
+			Action:        "iam_revoke",
+			Resource:      "projects/test-project",
+			Result:        AuditResultSuccess,
+			PolicyChanges: []BindingChange{{Role: "roles/editor", Member: "user:a@gmail.com", Change: BindingRemoved}},
+		},
+	}}
+	if diff := cmp.Diff(want, records); diff != "" {
+		t.Errorf("policy changes failed, difference: %v", diff)
+	}
+	filter := `logName="projects/automation-project/logs/security-response-automation" AND jsonPayload.policy_changes:* AND ` +
+		`jsonPayload.resource="projects/test-project"`
+	if diff := cmp.Diff(filter, logStub.SavedFilter); diff != "" {
+		t.Errorf("policy changes filter failed, difference: %v", diff)
+	}
+}
+
+func TestHistoryAuditRecords(t *testing.T) {
+	logStub := &stubs.LogAdminStub{}
+	h := NewHistory(logStub, "automation-project")
+	if _, err := h.AuditRecords(context.Background(), &HistoryQuery{Resource: "instances/miner"}); err != nil {
+		t.Fatalf("failed to list audit records: %q", err)
+	}
+	filter := `logName="projects/automation-project/logs/security-response-automation" AND jsonPayload.resource:* AND ` +
+		`jsonPayload.resource="instances/miner"`
+	if diff := cmp.Diff(filter, logStub.SavedFilter); diff != "" {
+		t.Errorf("audit records filter failed, difference: %v", diff)
+	}
+}
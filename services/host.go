@@ -22,6 +22,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/googlecloudplatform/security-response-automation/clients/operations"
 	"github.com/pkg/errors"
 	compute "google.golang.org/api/compute/v1"
 )
@@ -31,16 +32,24 @@ type ComputeClient interface {
 	DiskInsert(context.Context, string, string, *compute.Disk) (*compute.Operation, error)
 	CreateSnapshot(context.Context, string, string, string, *compute.Snapshot) (*compute.Operation, error)
 	DeleteAccessConfig(ctx context.Context, project, zone, instance, accessConfig, networkInterface string) (*compute.Operation, error)
+	AddAccessConfig(ctx context.Context, project, zone, instance, networkInterface string, accessConfig *compute.AccessConfig) (*compute.Operation, error)
 	DeleteDiskSnapshot(context.Context, string, string) (*compute.Operation, error)
 	DeleteInstance(context.Context, string, string, string) (*compute.Operation, error)
 	GetInstance(ctx context.Context, project, zone, instance string) (*compute.Instance, error)
+	InsertInstance(ctx context.Context, project, zone string, instance *compute.Instance) (*compute.Operation, error)
+	ListInstancesInAllZones(ctx context.Context, project, filter string) ([]*compute.Instance, error)
+	GetSerialPortOutput(ctx context.Context, project, zone, instance string) (*compute.SerialPortOutput, error)
 	ListDisks(context.Context, string, string) (*compute.DiskList, error)
 	ListProjectSnapshots(context.Context, string) (*compute.SnapshotList, error)
 	SetLabels(context.Context, string, string, *compute.GlobalSetLabelsRequest) (*compute.Operation, error)
+	SetMetadata(ctx context.Context, project, zone, instance string, metadata *compute.Metadata) (*compute.Operation, error)
+	SetCanIPForward(ctx context.Context, project, zone, instance string, canIPForward bool) (*compute.Operation, error)
+	UpdateShieldedInstanceConfig(ctx context.Context, project, zone, instance string, config *compute.ShieldedInstanceConfig) (*compute.Operation, error)
 	StartInstance(context.Context, string, string, string) (*compute.Operation, error)
 	StopInstance(context.Context, string, string, string) (*compute.Operation, error)
-	WaitGlobal(string, *compute.Operation) []error
-	WaitZone(string, string, *compute.Operation) []error
+	WaitGlobal(context.Context, string, *compute.Operation) []error
+	WaitZone(context.Context, string, string, *compute.Operation) []error
+	ZoneOperation(ctx context.Context, project, zone, name string) (*compute.Operation, error)
 }
 
 // Host service.
@@ -59,7 +68,7 @@ func (h *Host) DeleteDiskSnapshot(ctx context.Context, projectID, snapshot strin
 	if err != nil {
 		return nil
 	}
-	if errs := h.WaitGlobal(projectID, op); len(errs) > 0 {
+	if errs := h.WaitGlobal(ctx, projectID, op); len(errs) > 0 {
 		return errors.Wrap(errs[0], "failed waiting")
 	}
 	return nil
@@ -69,7 +78,7 @@ func (h *Host) DeleteDiskSnapshot(ctx context.Context, projectID, snapshot strin
 func (h *Host) RemoveExternalIPs(ctx context.Context, project, zone, instance string) error {
 	i, err := h.client.GetInstance(ctx, project, zone, instance)
 	if err != nil {
-		return fmt.Errorf("failed to get instance: %q", err)
+		return errors.Wrap(err, "failed to get instance")
 	}
 
 	for _, ni := range i.NetworkInterfaces {
@@ -80,9 +89,9 @@ func (h *Host) RemoveExternalIPs(ctx context.Context, project, zone, instance st
 
 			op, err := h.client.DeleteAccessConfig(ctx, project, zone, instance, ac.Name, ni.Name)
 			if err != nil {
-				return fmt.Errorf("failed to remove external ip: %q", err)
+				return errors.Wrap(err, "failed to remove external ip")
 			}
-			if errs := h.WaitZone(project, zone, op); len(errs) > 0 {
+			if errs := h.WaitZone(ctx, project, zone, op); len(errs) > 0 {
 				return fmt.Errorf("failed to waiting instance. Errors[0]: %s", errs[0])
 			}
 		}
@@ -91,6 +100,240 @@ func (h *Host) RemoveExternalIPs(ctx context.Context, project, zone, instance st
 	return nil
 }
 
+// ExternalAccessConfig is an external IP address of an instance's network interface.
+type ExternalAccessConfig struct {
+	NetworkInterface string `json:"network_interface"`
+	Name             string `json:"name"`
+	NatIP            string `json:"nat_ip"`
+	NetworkTier      string `json:"network_tier"`
+}
+
+// ExternalAccessConfigs returns the external IP addresses of every network interface of the instance.
+func (h *Host) ExternalAccessConfigs(ctx context.Context, project, zone, instance string) ([]ExternalAccessConfig, error) {
+	i, err := h.client.GetInstance(ctx, project, zone, instance)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get instance")
+	}
+	configs := []ExternalAccessConfig{}
+	for _, ni := range i.NetworkInterfaces {
+		for _, ac := range ni.AccessConfigs {
+			if ac.Type != "ONE_TO_ONE_NAT" {
+				continue
+			}
+			configs = append(configs, ExternalAccessConfig{NetworkInterface: ni.Name, Name: ac.Name, NatIP: ac.NatIP, NetworkTier: ac.NetworkTier})
+		}
+	}
+	return configs, nil
+}
+
+// RestoreExternalIPs adds the access configs back to the instance. The original address is
+// requested first and, since ephemeral addresses are released when removed, a new ephemeral
+// address is used if it's no longer available.
+func (h *Host) RestoreExternalIPs(ctx context.Context, project, zone, instance string, configs []ExternalAccessConfig) error {
+	for _, c := range configs {
+		ac := &compute.AccessConfig{Name: c.Name, Type: "ONE_TO_ONE_NAT", NatIP: c.NatIP, NetworkTier: c.NetworkTier}
+		op, err := h.client.AddAccessConfig(ctx, project, zone, instance, c.NetworkInterface, ac)
+		if err != nil && c.NatIP != "" {
+			log.Printf("failed to restore address %q to instance %q, using an ephemeral address: %q", c.NatIP, instance, err)
+			ac.NatIP = ""
+			op, err = h.client.AddAccessConfig(ctx, project, zone, instance, c.NetworkInterface, ac)
+		}
+		if err != nil {
+			return errors.Wrap(err, "failed to restore external ip")
+		}
+		if errs := h.WaitZone(ctx, project, zone, op); len(errs) > 0 {
+			return fmt.Errorf("failed to waiting instance. Errors[0]: %s", errs[0])
+		}
+	}
+	return nil
+}
+
+// Instance returns the instance.
+func (h *Host) Instance(ctx context.Context, project, zone, instance string) (*compute.Instance, error) {
+	i, err := h.client.GetInstance(ctx, project, zone, instance)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get instance %q", instance)
+	}
+	return i, nil
+}
+
+// HasExternalIP returns true if any network interface of the instance has an external IP address.
+func (h *Host) HasExternalIP(ctx context.Context, project, zone, instance string) (bool, error) {
+	i, err := h.client.GetInstance(ctx, project, zone, instance)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get instance")
+	}
+	for _, ni := range i.NetworkInterfaces {
+		for _, ac := range ni.AccessConfigs {
+			if ac.Type == "ONE_TO_ONE_NAT" {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+const (
+	// serialPortKey is the metadata key enabling interactive access to an instance's serial ports.
+	serialPortKey = "serial-port-enable"
+	// legacyEndpointsKey is the metadata key disabling the v0.1 and v1beta1 metadata server endpoints.
+	legacyEndpointsKey = "disable-legacy-endpoints"
+)
+
+// SerialPortDisabled returns true if the instance's metadata disables interactive serial port
+// access. Instance metadata overrides project metadata so this holds whatever the project sets.
+func (h *Host) SerialPortDisabled(ctx context.Context, project, zone, instance string) (bool, error) {
+	enabled, set, err := h.metadataBool(ctx, project, zone, instance, serialPortKey)
+	return set && !enabled, err
+}
+
+// DisableSerialPort disables interactive serial port access in the instance's metadata, keeping
+// its other metadata items.
+func (h *Host) DisableSerialPort(ctx context.Context, project, zone, instance string) error {
+	return h.setMetadataItem(ctx, project, zone, instance, serialPortKey, "false")
+}
+
+// LegacyMetadataDisabled returns true if the instance's metadata disables the legacy metadata
+// server endpoints, which don't require the Metadata-Flavor header and so are reachable by SSRF.
+func (h *Host) LegacyMetadataDisabled(ctx context.Context, project, zone, instance string) (bool, error) {
+	disabled, _, err := h.metadataBool(ctx, project, zone, instance, legacyEndpointsKey)
+	return disabled, err
+}
+
+// DisableLegacyMetadata disables the legacy metadata server endpoints in the instance's metadata,
+// keeping its other metadata items.
+func (h *Host) DisableLegacyMetadata(ctx context.Context, project, zone, instance string) error {
+	return h.setMetadataItem(ctx, project, zone, instance, legacyEndpointsKey, "true")
+}
+
+// metadataBool returns the boolean value of the instance's metadata item, set is false if the item
+// is missing or isn't a boolean.
+func (h *Host) metadataBool(ctx context.Context, project, zone, instance, key string) (value, set bool, err error) {
+	i, err := h.client.GetInstance(ctx, project, zone, instance)
+	if err != nil {
+		return false, false, errors.Wrap(err, "failed to get instance")
+	}
+	if i.Metadata == nil {
+		return false, false, nil
+	}
+	for _, item := range i.Metadata.Items {
+		if item.Key == key && item.Value != nil {
+			v, err := strconv.ParseBool(*item.Value)
+			return v, err == nil, nil
+		}
+	}
+	return false, false, nil
+}
+
+// setMetadataItem sets the item in the instance's metadata, keeping its other items.
+func (h *Host) setMetadataItem(ctx context.Context, project, zone, instance, key, value string) error {
+	i, err := h.client.GetInstance(ctx, project, zone, instance)
+	if err != nil {
+		return errors.Wrap(err, "failed to get instance")
+	}
+	md := i.Metadata
+	if md == nil {
+		md = &compute.Metadata{}
+	}
+	items := []*compute.MetadataItems{{Key: key, Value: &value}}
+	for _, item := range md.Items {
+		if item.Key != key {
+			items = append(items, item)
+		}
+	}
+	op, err := h.client.SetMetadata(ctx, project, zone, instance, &compute.Metadata{Fingerprint: md.Fingerprint, Items: items})
+	if err != nil {
+		return errors.Wrap(err, "failed to set metadata")
+	}
+	if errs := h.WaitZone(ctx, project, zone, op); len(errs) > 0 {
+		return fmt.Errorf("failed to waiting instance. Errors[0]: %s", errs[0])
+	}
+	return nil
+}
+
+// ShieldedVM describes the Shielded VM state of an instance.
+type ShieldedVM struct {
+	// Enabled is true if secure boot, vTPM and integrity monitoring are all enabled.
+	Enabled bool
+	// Supported is false if the boot disk's image doesn't support UEFI, which Shielded VM requires.
+	Supported bool
+	// Running is true if the instance must be stopped before its options are changed.
+	Running bool
+}
+
+// ShieldedVMState returns the Shielded VM state of the instance.
+func (h *Host) ShieldedVMState(ctx context.Context, project, zone, instance string) (*ShieldedVM, error) {
+	i, err := h.client.GetInstance(ctx, project, zone, instance)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get instance")
+	}
+	c := i.ShieldedInstanceConfig
+	state := &ShieldedVM{
+		Enabled: c != nil && c.EnableSecureBoot && c.EnableVtpm && c.EnableIntegrityMonitoring,
+		Running: i.Status != "TERMINATED",
+	}
+	for _, d := range i.Disks {
+		if !d.Boot {
+			continue
+		}
+		for _, f := range d.GuestOsFeatures {
+			if f.Type == "UEFI_COMPATIBLE" {
+				state.Supported = true
+			}
+		}
+	}
+	return state, nil
+}
+
+// EnableShieldedVM enables secure boot, vTPM and integrity monitoring on the instance. The options
+// can only be changed while the instance is stopped so a running instance is stopped and started
+// again.
+func (h *Host) EnableShieldedVM(ctx context.Context, project, zone, instance string) error {
+	state, err := h.ShieldedVMState(ctx, project, zone, instance)
+	if err != nil {
+		return err
+	}
+	if state.Running {
+		if err := h.StopInstance(ctx, project, zone, instance); err != nil {
+			return err
+		}
+	}
+	config := &compute.ShieldedInstanceConfig{EnableSecureBoot: true, EnableVtpm: true, EnableIntegrityMonitoring: true}
+	op, err := h.client.UpdateShieldedInstanceConfig(ctx, project, zone, instance, config)
+	if err != nil {
+		return errors.Wrap(err, "failed to update shielded instance config")
+	}
+	if errs := h.WaitZone(ctx, project, zone, op); len(errs) > 0 {
+		return fmt.Errorf("failed to waiting instance. Errors[0]: %s", errs[0])
+	}
+	if state.Running {
+		return h.StartInstance(ctx, project, zone, instance)
+	}
+	return nil
+}
+
+// IPForwardingEnabled returns true if the instance can forward packets for other addresses.
+func (h *Host) IPForwardingEnabled(ctx context.Context, project, zone, instance string) (bool, error) {
+	i, err := h.client.GetInstance(ctx, project, zone, instance)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get instance")
+	}
+	return i.CanIpForward, nil
+}
+
+// DisableIPForwarding stops the instance from forwarding packets for other addresses. A running
+// instance is restarted for the change to apply.
+func (h *Host) DisableIPForwarding(ctx context.Context, project, zone, instance string) error {
+	op, err := h.client.SetCanIPForward(ctx, project, zone, instance, false)
+	if err != nil {
+		return errors.Wrap(err, "failed to disable ip forwarding")
+	}
+	if errs := h.WaitZone(ctx, project, zone, op); len(errs) > 0 {
+		return fmt.Errorf("failed to waiting instance. Errors[0]: %s", errs[0])
+	}
+	return nil
+}
+
 // DiskSnapshot gets a snapshot by name associated with a given disk.
 func (h *Host) DiskSnapshot(ctx context.Context, snapshotName, projectID string, disk *compute.Disk) (*compute.Snapshot, error) {
 	snapshots, err := h.ListProjectSnapshots(ctx, projectID)
@@ -113,14 +356,47 @@ func (h *Host) CreateDiskSnapshot(ctx context.Context, projectID, zone, disk, na
 		CreationTimestamp: time.Now().Format(time.RFC3339),
 	})
 	if err != nil {
-		return fmt.Errorf("failed to create snapshot: %q", err)
+		return errors.Wrap(err, "failed to create snapshot")
 	}
-	if errs := h.WaitZone(projectID, zone, op); len(errs) > 0 {
+	if errs := h.WaitZone(ctx, projectID, zone, op); len(errs) > 0 {
 		return errors.Wrap(errs[0], "failed waiting: first error")
 	}
 	return nil
 }
 
+// StartDiskSnapshot starts creating a labeled snapshot without waiting for it to complete. The
+// operation is returned as "zones/<zone>/operations/<name>" to check with ZoneOperationDone.
+func (h *Host) StartDiskSnapshot(ctx context.Context, projectID, zone, disk, name string, labels map[string]string) (string, error) {
+	op, err := h.client.CreateSnapshot(ctx, projectID, zone, disk, &compute.Snapshot{
+		Description:       "Snapshot of " + disk,
+		Name:              name,
+		CreationTimestamp: time.Now().Format(time.RFC3339),
+		Labels:            labels,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create snapshot")
+	}
+	return fmt.Sprintf("zones/%s/operations/%s", zone, op.Name), nil
+}
+
+// ZoneOperationDone returns whether the zonal operation, "zones/<zone>/operations/<name>", has
+// completed. An error is returned if the operation completed with errors.
+func (h *Host) ZoneOperationDone(ctx context.Context, projectID, operation string) (bool, error) {
+	parts := strings.Split(operation, "/")
+	if len(parts) != 4 || parts[0] != "zones" || parts[2] != "operations" {
+		return false, errors.Errorf("malformed zonal operation %q", operation)
+	}
+	op, err := h.client.ZoneOperation(ctx, projectID, parts[1], parts[3])
+	if err != nil {
+		return false, err
+	}
+	done, err := operations.ComputeDone(op)
+	if err != nil {
+		return true, errors.Wrapf(err, "operation %q", operation)
+	}
+	return done, nil
+}
+
 // CopyDiskSnapshot creates a disk from a snapshot and moves it to another project.
 func (h *Host) CopyDiskSnapshot(ctx context.Context, srcProjectID, dstProjectID, zone, name string) error {
 	op, err := h.client.DiskInsert(ctx, dstProjectID, zone, &compute.Disk{
@@ -128,9 +404,9 @@ func (h *Host) CopyDiskSnapshot(ctx context.Context, srcProjectID, dstProjectID,
 		SourceSnapshot: fmt.Sprintf("projects/%s/global/snapshots/%s", srcProjectID, name),
 	})
 	if err != nil {
-		return fmt.Errorf("failed to copy snapshot: %q", err)
+		return errors.Wrap(err, "failed to copy snapshot")
 	}
-	if errs := h.WaitZone(dstProjectID, zone, op); len(errs) > 0 {
+	if errs := h.WaitZone(ctx, dstProjectID, zone, op); len(errs) > 0 {
 		return errors.Wrap(errs[0], "failed waiting: first error")
 	}
 	return nil
@@ -145,7 +421,7 @@ func (h *Host) ListProjectSnapshots(ctx context.Context, projectID string) (*com
 func (h *Host) ListInstanceDisks(ctx context.Context, projectID, zone, instance string) ([]*compute.Disk, error) {
 	ds, err := h.client.ListDisks(ctx, projectID, zone)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list disks: %q", err)
+		return nil, errors.Wrap(err, "failed to list disks")
 	}
 	dl := []*compute.Disk{}
 	for _, d := range ds.Items {
@@ -173,20 +449,56 @@ func (h *Host) SetSnapshotLabels(ctx context.Context, projectID, snapshotName st
 	if err != nil {
 		return errors.Wrapf(err, "failed setting labels for %s %s", projectID, id)
 	}
-	if errs := h.WaitGlobal(projectID, op); len(errs) > 0 {
+	if errs := h.WaitGlobal(ctx, projectID, op); len(errs) > 0 {
 		return errors.Wrapf(errs[0], "failed waiting for setting labels on %s", projectID)
 	}
 	return nil
 }
 
+// CreateAnalysisInstance creates an instance without external network access and attaches a read-only
+// disk created from each of the given snapshots. Snapshots are referenced by their resource path
+// such as "projects/project-id/global/snapshots/snapshot-name".
+func (h *Host) CreateAnalysisInstance(ctx context.Context, projectID, zone, name, machineType, network, image string, snapshots []string) (*compute.Instance, error) {
+	disks := []*compute.AttachedDisk{
+		{
+			Boot:             true,
+			AutoDelete:       true,
+			InitializeParams: &compute.AttachedDiskInitializeParams{SourceImage: image},
+		},
+	}
+	for _, s := range snapshots {
+		disks = append(disks, &compute.AttachedDisk{
+			AutoDelete:       true,
+			Mode:             "READ_ONLY",
+			InitializeParams: &compute.AttachedDiskInitializeParams{SourceSnapshot: s},
+		})
+	}
+	op, err := h.client.InsertInstance(ctx, projectID, zone, &compute.Instance{
+		Name:        name,
+		Description: "Forensic analysis instance created by Security Response Automation",
+		MachineType: fmt.Sprintf("zones/%s/machineTypes/%s", zone, machineType),
+		Disks:       disks,
+		// No access configs are set so the instance does not get an external IP.
+		NetworkInterfaces: []*compute.NetworkInterface{{Network: network}},
+		Labels:            map[string]string{"info": "created-by-security-response-automation"},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create instance")
+	}
+	if errs := h.WaitZone(ctx, projectID, zone, op); len(errs) > 0 {
+		return nil, errors.Wrap(errs[0], "failed waiting: first error")
+	}
+	return h.client.GetInstance(ctx, projectID, zone, name)
+}
+
 // WaitZone will wait for the zonal operation to complete.
-func (h *Host) WaitZone(project, zone string, op *compute.Operation) []error {
-	return h.client.WaitZone(project, zone, op)
+func (h *Host) WaitZone(ctx context.Context, project, zone string, op *compute.Operation) []error {
+	return h.client.WaitZone(ctx, project, zone, op)
 }
 
 // WaitGlobal will wait for the global operation to complete.
-func (h *Host) WaitGlobal(project string, op *compute.Operation) []error {
-	return h.client.WaitGlobal(project, op)
+func (h *Host) WaitGlobal(ctx context.Context, project string, op *compute.Operation) []error {
+	return h.client.WaitGlobal(ctx, project, op)
 }
 
 // diskBelongsToInstance returns if the disk is attributed to the given instance.
@@ -199,13 +511,28 @@ func (h *Host) diskBelongsToInstance(disks *compute.Disk, instance string) bool
 	return false
 }
 
+// RunningInstances returns the running instances of the project in every zone.
+func (h *Host) RunningInstances(ctx context.Context, project string) ([]*compute.Instance, error) {
+	instances, err := h.client.ListInstancesInAllZones(ctx, project, `status = "RUNNING"`)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list instances of project %q", project)
+	}
+	running := []*compute.Instance{}
+	for _, i := range instances {
+		if i.Status == "RUNNING" {
+			running = append(running, i)
+		}
+	}
+	return running, nil
+}
+
 // StopInstance stops the provided instance.
 func (h *Host) StopInstance(ctx context.Context, projectID, zone, instance string) error {
 	op, err := h.client.StopInstance(ctx, projectID, zone, instance)
 	if err != nil {
-		return fmt.Errorf("failed to stop instance: %q", err)
+		return errors.Wrap(err, "failed to stop instance")
 	}
-	if errs := h.WaitZone(projectID, zone, op); len(errs) > 0 {
+	if errs := h.WaitZone(ctx, projectID, zone, op); len(errs) > 0 {
 		return fmt.Errorf("failed to waiting instance. Errors[0]: %s", errs[0])
 	}
 	return nil
@@ -215,9 +542,9 @@ func (h *Host) StopInstance(ctx context.Context, projectID, zone, instance strin
 func (h *Host) StartInstance(ctx context.Context, projectID, zone, instance string) error {
 	op, err := h.client.StartInstance(ctx, projectID, zone, instance)
 	if err != nil {
-		return fmt.Errorf("failed to start instance: %q", err)
+		return errors.Wrap(err, "failed to start instance")
 	}
-	if errs := h.WaitZone(projectID, zone, op); len(errs) > 0 {
+	if errs := h.WaitZone(ctx, projectID, zone, op); len(errs) > 0 {
 		return fmt.Errorf("failed to waiting instance. Errors[0]: %s", errs[0])
 	}
 	return nil
@@ -0,0 +1,63 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	oauth2 "google.golang.org/api/oauth2/v2"
+)
+
+// IdentityClient contains minimum interface required by the identity service.
+type IdentityClient interface {
+	TokenInfo(context.Context, string) (*oauth2.Tokeninfo, error)
+}
+
+// Identity service authenticates the callers of HTTP functions by their Google-signed ID token.
+type Identity struct {
+	client   IdentityClient
+	audience string
+}
+
+// NewIdentity returns an identity service accepting ID tokens issued for audience.
+func NewIdentity(client IdentityClient, audience string) *Identity {
+	return &Identity{client: client, audience: audience}
+}
+
+// Caller returns the verified email of the caller from the bearer ID token in the Authorization
+// header. Tokens issued for another audience or without a verified email are rejected.
+func (i *Identity) Caller(ctx context.Context, authorization string) (string, error) {
+	if i.audience == "" {
+		return "", errors.New("audience not configured")
+	}
+	token := strings.TrimPrefix(authorization, "Bearer ")
+	if token == "" || token == authorization {
+		return "", errors.New("missing bearer token")
+	}
+	info, err := i.client.TokenInfo(ctx, token)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to verify token")
+	}
+	if info.Audience != i.audience {
+		return "", fmt.Errorf("token issued for %q", info.Audience)
+	}
+	if info.Email == "" || !info.VerifiedEmail {
+		return "", errors.New("token has no verified email")
+	}
+	return strings.ToLower(info.Email), nil
+}
@@ -0,0 +1,55 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"testing"
+
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+	oauth2 "google.golang.org/api/oauth2/v2"
+)
+
+func TestCaller(t *testing.T) {
+	const audience = "https://us-central1-automation.cloudfunctions.net/Approve"
+	stub := &stubs.TokenInfoStub{StubbedTokens: map[string]*oauth2.Tokeninfo{
+		"alice":      {Audience: audience, Email: "Alice@foo.com", VerifiedEmail: true},
+		"unverified": {Audience: audience, Email: "alice@foo.com"},
+		"other":      {Audience: "https://example.com", Email: "alice@foo.com", VerifiedEmail: true},
+	}}
+	for _, tt := range []struct {
+		name          string
+		authorization string
+		expected      string
+		expectedError bool
+	}{
+		{name: "verified", authorization: "Bearer alice", expected: "alice@foo.com"},
+		{name: "no bearer token", authorization: "alice", expectedError: true},
+		{name: "missing", authorization: "", expectedError: true},
+		{name: "invalid token", authorization: "Bearer forged", expectedError: true},
+		{name: "unverified email", authorization: "Bearer unverified", expectedError: true},
+		{name: "other audience", authorization: "Bearer other", expectedError: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewIdentity(stub, audience).Caller(context.Background(), tt.authorization)
+			if (err != nil) != tt.expectedError {
+				t.Fatalf("%s failed: got error %v, want error %t", tt.name, err, tt.expectedError)
+			}
+			if got != tt.expected {
+				t.Errorf("%s failed: got %q want %q", tt.name, got, tt.expected)
+			}
+		})
+	}
+}
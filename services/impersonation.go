@@ -0,0 +1,68 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+
+	"github.com/googlecloudplatform/security-response-automation/clients"
+	"github.com/pkg/errors"
+)
+
+// ImpersonationScope is a service account automations act as in the projects of a scope.
+type ImpersonationScope struct {
+	// Scope is an ancestry pattern, as used in the targets of automations, such as
+	// "organizations/456/folders/123/*".
+	Scope string `json:"scope"`
+	// ServiceAccount is the email of the service account to act as.
+	ServiceAccount string `json:"service_account"`
+}
+
+// Impersonation service selects the service account automations act as in each project.
+type Impersonation struct {
+	resource *Resource
+	scopes   []ImpersonationScope
+}
+
+// NewImpersonation returns an impersonation service. The scopes are matched in order so nested
+// folders should be listed before their parents.
+func NewImpersonation(resource *Resource, scopes []ImpersonationScope) *Impersonation {
+	return &Impersonation{resource: resource, scopes: scopes}
+}
+
+// ServiceAccount returns the service account to act as in the project, or an empty string if the
+// project is in no scope and the function's own is used.
+func (i *Impersonation) ServiceAccount(ctx context.Context, projectID string) (string, error) {
+	for _, s := range i.scopes {
+		ok, err := i.resource.CheckMatches(ctx, projectID, []string{s.Scope}, nil)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to match project %q to %q", projectID, s.Scope)
+		}
+		if ok {
+			return s.ServiceAccount, nil
+		}
+	}
+	return "", nil
+}
+
+// Scope returns a context the clients act in the project with, as the service account of its
+// scope if it has one.
+func (i *Impersonation) Scope(ctx context.Context, projectID string) (context.Context, error) {
+	email, err := i.ServiceAccount(ctx, projectID)
+	if err != nil || email == "" {
+		return ctx, err
+	}
+	return clients.WithServiceAccount(ctx, email), nil
+}
@@ -0,0 +1,62 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"testing"
+
+	"github.com/googlecloudplatform/security-response-automation/clients"
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+)
+
+func TestImpersonationScope(t *testing.T) {
+	scopes := []ImpersonationScope{
+		{Scope: "organizations/456/folders/123/*", ServiceAccount: "sra@folder-123.iam.gserviceaccount.com"},
+		{Scope: "organizations/456/*", ServiceAccount: "sra@org-456.iam.gserviceaccount.com"},
+	}
+	for _, tt := range []struct {
+		name      string
+		ancestors []string
+		expected  string
+	}{
+		{
+			name:      "folder scope",
+			ancestors: []string{"project/p", "folder/123", "organization/456"},
+			expected:  "sra@folder-123.iam.gserviceaccount.com",
+		},
+		{
+			name:      "organization scope",
+			ancestors: []string{"project/p", "folder/789", "organization/456"},
+			expected:  "sra@org-456.iam.gserviceaccount.com",
+		},
+		{
+			name:      "no scope",
+			ancestors: []string{"project/p", "organization/999"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			crmStub := &stubs.ResourceManagerStub{GetAncestryResponse: CreateAncestors(tt.ancestors)}
+			i := NewImpersonation(NewResource(crmStub, &stubs.StorageStub{}), scopes)
+			ctx, err := i.Scope(context.Background(), "p")
+			if err != nil {
+				t.Fatalf("%s failed: %q", tt.name, err)
+			}
+			if got := clients.ServiceAccount(ctx); got != tt.expected {
+				t.Errorf("%s acts as %q want %q", tt.name, got, tt.expected)
+			}
+		})
+	}
+}
@@ -2,28 +2,95 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"os"
 
 	"github.com/googlecloudplatform/security-response-automation/clients"
+	"github.com/pkg/errors"
 )
 
 const (
 	authFile = "credentials/auth.json"
+	// webhookFile optionally holds the URL and secret of the outbound webhook.
+	webhookFile = "credentials/webhook.json"
+	// stateFile optionally holds the bucket the state store writes to.
+	stateFile = "credentials/state.json"
+	// emailFile optionally holds the SendGrid API key or SMTP server and the sender used to send emails.
+	emailFile = "credentials/email.json"
+	// rateLimitFile optionally holds the requests per second allowed to each API, such as compute.
+	rateLimitFile = "credentials/rate-limits.json"
+	// intelFile optionally holds the API key of the threat intelligence provider IPs are looked up with.
+	intelFile = "credentials/intel.json"
+	// groupsFile optionally holds the G Suite administrator the service account acts as to manage groups.
+	groupsFile = "credentials/groups.json"
+	// bundleFile optionally holds the forensics bucket incident bundles are exported to, the key version
+	// signing them and the bucket evidence is collected to.
+	bundleFile = "credentials/bundle.json"
+	// notifiersFile optionally holds the notification channels, such as Slack or PagerDuty, and the
+	// severities and categories of the events each is sent.
+	notifiersFile = "credentials/notifiers.json"
+	// messagesFile optionally holds the bucket the templates of notification messages are read
+	// from and the runbook of each finding category.
+	messagesFile = "credentials/messages.json"
+	// impersonationFile optionally holds the service account automations act as in the projects of
+	// each scope, such as a folder.
+	impersonationFile = "credentials/impersonation.json"
 )
 
 // Global holds all initialized services.
 type Global struct {
 	Logger                *Logger
 	Resource              *Resource
+	Resolver              *Resolver
+	Tags                  *Tags
+	Assets                *Assets
 	Host                  *Host
 	Firewall              *Firewall
+	LoadBalancer          *LoadBalancer
+	KMS                   *KMS
 	Container             *Container
 	CloudSQL              *CloudSQL
 	SecurityCommandCenter *CommandCenter
+	Evidence              *Evidence
+	AuditLogs             *AuditLogs
+	ServiceAccounts       *ServiceAccounts
+	Troubleshooter        *PolicyTroubleshooter
+	Insights              *Insights
+	ManagedClusters       *ManagedClusters
+	// State is nil if no state bucket is configured.
+	State *State
+	// Notifier fans out the outcome of each automation to the webhook, the security contacts of the
+	// project and the configured channels, it's nil if there are none.
+	Notifier *NotifierMux
+	// EmailNotifier is nil if no email sender is configured.
+	EmailNotifier *EmailNotifier
+	// Intel is nil if no threat intelligence provider is configured.
+	Intel *Intel
+	// Groups is nil if no G Suite administrator is configured.
+	Groups *Groups
+	// Impersonation is nil if no service accounts to impersonate are configured.
+	Impersonation *Impersonation
 }
 
 // New returns an initialized Global struct.
 func New(ctx context.Context) (*Global, error) {
+	if err := initRateLimits(); err != nil {
+		return nil, err
+	}
+	// Services deployed on Cloud Run serve the metrics of the requests made by their clients.
+	if os.Getenv("REQUEST_METRICS") == "true" {
+		clients.EnableRequestMetrics()
+	}
+	if err := initTracing(ctx); err != nil {
+		return nil, err
+	}
+	scopes, err := initImpersonation()
+	if err != nil {
+		return nil, err
+	}
+
 	host, err := initHost(ctx)
 	if err != nil {
 		return nil, err
@@ -39,11 +106,37 @@ func New(ctx context.Context) (*Global, error) {
 		return nil, err
 	}
 
+	var imp *Impersonation
+	if len(scopes) > 0 {
+		imp = NewImpersonation(res, scopes)
+	}
+
 	fw, err := initFirewall(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	resolver := NewResolver(res)
+	tags, err := initTags(ctx, res)
+	if err != nil {
+		return nil, err
+	}
+
+	assets, err := initAssets(ctx, resolver)
+	if err != nil {
+		return nil, err
+	}
+
+	lb, err := initLoadBalancer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	kms, err := initKMS(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	cont, err := initContainer(ctx)
 	if err != nil {
 		return nil, err
@@ -59,14 +152,110 @@ func New(ctx context.Context) (*Global, error) {
 		return nil, err
 	}
 
+	ev, err := initEvidence(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	al, err := initAuditLogs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sa, err := initServiceAccounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pt, err := initPolicyTroubleshooter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ins, err := initInsights(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	mc, err := initManagedClusters(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	wh, err := initWebhook()
+	if err != nil {
+		return nil, err
+	}
+
+	st, err := initState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	email, from, err := initEmail()
+	if err != nil {
+		return nil, err
+	}
+
+	intel, err := initIntel()
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := initGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	messages, runbooks, err := initMessages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	mux := NewNotifierMux(runbooks)
+	if wh != nil {
+		mux.Add("webhook", wh, NotifierFilter{})
+	}
+	var en *EmailNotifier
+	if email != nil {
+		ec, err := clients.NewEssentialContacts(ctx, authFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to initialize essential contacts client")
+		}
+		mux.Add("security_contacts", NewContactNotifier(NewEssentialContacts(ec), email, from, messages), NotifierFilter{})
+		en = NewEmailNotifier(email, from, messages)
+	}
+	if err := initNotifiers(mux, en, messages); err != nil {
+		return nil, err
+	}
+	if mux.Len() == 0 {
+		mux = nil
+	}
+
 	return &Global{
 		Host:                  host,
 		Logger:                log,
 		Resource:              res,
+		Resolver:              resolver,
+		Tags:                  tags,
+		Assets:                assets,
 		Firewall:              fw,
+		LoadBalancer:          lb,
+		KMS:                   kms,
 		Container:             cont,
 		CloudSQL:              sql,
 		SecurityCommandCenter: scc,
+		Evidence:              ev,
+		AuditLogs:             al,
+		ServiceAccounts:       sa,
+		Troubleshooter:        pt,
+		Insights:              ins,
+		ManagedClusters:       mc,
+		State:                 st,
+		Notifier:              mux,
+		EmailNotifier:         en,
+		Intel:                 intel,
+		Groups:                groups,
+		Impersonation:         imp,
 	}, nil
 }
 
@@ -80,7 +269,7 @@ func InitPagerDuty(apiKey string) *PagerDuty {
 func InitBigQuery(ctx context.Context, projectID string) (*BigQuery, error) {
 	bq, err := clients.NewBigQuery(ctx, authFile, projectID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to initialize bigquery client: %q", err)
+		return nil, errors.Wrap(err, "failed to initialize bigquery client")
 	}
 	return NewBigQuery(bq), nil
 }
@@ -89,15 +278,92 @@ func InitBigQuery(ctx context.Context, projectID string) (*BigQuery, error) {
 func InitPubSub(ctx context.Context, projectID string) (*PubSub, error) {
 	pubsub, err := clients.NewPubSub(ctx, authFile, projectID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to initialize pubsub client: %q", err)
+		return nil, errors.Wrap(err, "failed to initialize pubsub client")
 	}
 	return NewPubSub(pubsub), nil
 }
 
+// InitDeadLetters creates and initializes a new instance of DeadLetters receiving from the
+// dead-letter subscription of the project.
+func InitDeadLetters(ctx context.Context, projectID string) (*DeadLetters, error) {
+	sub, err := clients.NewSubscriber(ctx, authFile, projectID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to initialize subscriber client")
+	}
+	return NewDeadLetters(sub, DeadLetterSubscription), nil
+}
+
+// InitHistory creates and initializes a new instance of History reading the logs of the given project.
+func InitHistory(ctx context.Context, projectID string) (*History, error) {
+	la, err := clients.NewLogAdmin(ctx, authFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to initialize logadmin client")
+	}
+	return NewHistory(la, projectID), nil
+}
+
+// InitIncidentBundle creates and initializes a new instance of IncidentBundle reading the history
+// from the logs of the given project, or returns nil if no forensics bucket is configured.
+func InitIncidentBundle(ctx context.Context, projectID string) (*IncidentBundle, error) {
+	b, err := ioutil.ReadFile(bundleFile)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read bundle config")
+	}
+	var conf struct {
+		Bucket         string `json:"bucket"`
+		KeyVersion     string `json:"key_version"`
+		EvidenceBucket string `json:"evidence_bucket"`
+	}
+	if err := json.Unmarshal(b, &conf); err != nil {
+		return nil, errors.Wrap(err, "failed to parse bundle config")
+	}
+	if conf.Bucket == "" {
+		return nil, nil
+	}
+	if conf.KeyVersion == "" {
+		return nil, fmt.Errorf("bundle config requires a key version to sign bundles with")
+	}
+	h, err := InitHistory(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	stg, err := clients.NewStorage(ctx, authFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to initialize storage client")
+	}
+	k, err := clients.NewKMS(ctx, authFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to initialize kms client")
+	}
+	return NewIncidentBundle(h, stg, k, conf.Bucket, conf.KeyVersion, conf.EvidenceBucket), nil
+}
+
+// InitSecretManager creates and initializes a new instance of SecretManager.
+func InitSecretManager(ctx context.Context) (*SecretManager, error) {
+	sm, err := clients.NewSecretManager(ctx, authFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to initialize secret manager client")
+	}
+	return NewSecretManager(sm), nil
+}
+
+// InitIdentity creates and initializes a new instance of Identity accepting ID tokens issued for
+// audience.
+func InitIdentity(ctx context.Context, audience string) (*Identity, error) {
+	ti, err := clients.NewTokenInfo(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to initialize tokeninfo client")
+	}
+	return NewIdentity(ti, audience), nil
+}
+
 func initHost(ctx context.Context) (*Host, error) {
 	cs, err := clients.NewCompute(ctx, authFile)
 	if err != nil {
-		return nil, fmt.Errorf("failed to initialize compute client: %q", err)
+		return nil, errors.Wrap(err, "failed to initialize compute client")
 	}
 	return NewHost(cs), nil
 }
@@ -105,7 +371,7 @@ func initHost(ctx context.Context) (*Host, error) {
 func initLog(ctx context.Context) (*Logger, error) {
 	logClient, err := clients.NewLogger(ctx, authFile)
 	if err != nil {
-		return nil, fmt.Errorf("failed to initialize logger client: %q", err)
+		return nil, errors.Wrap(err, "failed to initialize logger client")
 	}
 	return NewLogger(logClient), nil
 }
@@ -113,27 +379,51 @@ func initLog(ctx context.Context) (*Logger, error) {
 func initResource(ctx context.Context) (*Resource, error) {
 	crm, err := clients.NewCloudResourceManager(ctx, authFile)
 	if err != nil {
-		return nil, fmt.Errorf("failed to initialize cloud resource manager client: %q", err)
+		return nil, errors.Wrap(err, "failed to initialize cloud resource manager client")
 	}
 	stg, err := clients.NewStorage(ctx, authFile)
 	if err != nil {
-		return nil, fmt.Errorf("failed to initialize storage client: %q", err)
+		return nil, errors.Wrap(err, "failed to initialize storage client")
 	}
 	return NewResource(crm, stg), nil
 }
 
+func initTags(ctx context.Context, res *Resource) (*Tags, error) {
+	t, err := clients.NewTags(ctx, authFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to initialize tags client")
+	}
+	return NewTags(t, res), nil
+}
+
 func initFirewall(ctx context.Context) (*Firewall, error) {
 	cs, err := clients.NewCompute(ctx, authFile)
 	if err != nil {
-		return nil, fmt.Errorf("failed to initialize compute client: %q", err)
+		return nil, errors.Wrap(err, "failed to initialize compute client")
 	}
 	return NewFirewall(cs), nil
 }
 
+func initLoadBalancer(ctx context.Context) (*LoadBalancer, error) {
+	cs, err := clients.NewCompute(ctx, authFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to initialize compute client")
+	}
+	return NewLoadBalancer(cs), nil
+}
+
+func initKMS(ctx context.Context) (*KMS, error) {
+	k, err := clients.NewKMS(ctx, authFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to initialize kms client")
+	}
+	return NewKMS(k), nil
+}
+
 func initContainer(ctx context.Context) (*Container, error) {
 	cc, err := clients.NewContainer(ctx, authFile)
 	if err != nil {
-		return nil, fmt.Errorf("Failed to initialize container client: %q", err)
+		return nil, errors.Wrap(err, "Failed to initialize container client")
 	}
 	return NewContainer(cc), nil
 }
@@ -141,7 +431,7 @@ func initContainer(ctx context.Context) (*Container, error) {
 func initCloudSQL(ctx context.Context) (*CloudSQL, error) {
 	cs, err := clients.NewCloudSQL(ctx, authFile)
 	if err != nil {
-		return nil, fmt.Errorf("failed to initialize sql client: %q", err)
+		return nil, errors.Wrap(err, "failed to initialize sql client")
 	}
 	return NewCloudSQL(cs), nil
 }
@@ -149,7 +439,345 @@ func initCloudSQL(ctx context.Context) (*CloudSQL, error) {
 func initSecurityCommandCenter(ctx context.Context) (*CommandCenter, error) {
 	scc, err := clients.NewSecurityCommandCenter(ctx, authFile)
 	if err != nil {
-		return nil, fmt.Errorf("failed to initialize scc client: %q", err)
+		return nil, errors.Wrap(err, "failed to initialize scc client")
 	}
 	return NewCommandCenter(scc), nil
 }
+
+func initEvidence(ctx context.Context) (*Evidence, error) {
+	cs, err := clients.NewCompute(ctx, authFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to initialize compute client")
+	}
+	stg, err := clients.NewStorage(ctx, authFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to initialize storage client")
+	}
+	la, err := clients.NewLogAdmin(ctx, authFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to initialize logadmin client")
+	}
+	return NewEvidence(cs, stg, la), nil
+}
+
+func initAuditLogs(ctx context.Context) (*AuditLogs, error) {
+	la, err := clients.NewLogAdmin(ctx, authFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to initialize logadmin client")
+	}
+	return NewAuditLogs(la), nil
+}
+
+func initServiceAccounts(ctx context.Context) (*ServiceAccounts, error) {
+	sa, err := clients.NewServiceAccounts(ctx, authFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to initialize service accounts client")
+	}
+	return NewServiceAccounts(sa), nil
+}
+
+func initPolicyTroubleshooter(ctx context.Context) (*PolicyTroubleshooter, error) {
+	pt, err := clients.NewPolicyTroubleshooter(ctx, authFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to initialize policy troubleshooter client")
+	}
+	return NewPolicyTroubleshooter(pt), nil
+}
+
+func initManagedClusters(ctx context.Context) (*ManagedClusters, error) {
+	c, err := clients.NewComposer(ctx, authFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to initialize composer client")
+	}
+	d, err := clients.NewDataproc(ctx, authFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to initialize dataproc client")
+	}
+	return NewManagedClusters(c, d), nil
+}
+
+func initInsights(ctx context.Context) (*Insights, error) {
+	r, err := clients.NewRecommender(ctx, authFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to initialize recommender client")
+	}
+	return NewInsights(r), nil
+}
+
+func initWebhook() (*Webhook, error) {
+	b, err := ioutil.ReadFile(webhookFile)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read webhook config")
+	}
+	var conf struct {
+		URL    string `json:"url"`
+		Secret string `json:"secret"`
+	}
+	if err := json.Unmarshal(b, &conf); err != nil {
+		return nil, errors.Wrap(err, "failed to parse webhook config")
+	}
+	if conf.URL == "" {
+		return nil, nil
+	}
+	return NewWebhook(clients.NewWebhook(), conf.URL, conf.Secret), nil
+}
+
+// initNotifiers adds the configured notification channels to the multiplexer. Email channels
+// require an email sender.
+func initNotifiers(mux *NotifierMux, email *EmailNotifier, messages *Messages) error {
+	b, err := ioutil.ReadFile(notifiersFile)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to read notifiers config")
+	}
+	var conf struct {
+		Channels []struct {
+			Name      string   `json:"name"`
+			Type      string   `json:"type"`
+			URL       string   `json:"url"`
+			Secret    string   `json:"secret"`
+			APIKey    string   `json:"api_key"`
+			From      string   `json:"from"`
+			ServiceID string   `json:"service_id"`
+			To        []string `json:"to"`
+			NotifierFilter
+		} `json:"channels"`
+	}
+	if err := json.Unmarshal(b, &conf); err != nil {
+		return errors.Wrap(err, "failed to parse notifiers config")
+	}
+	for i, c := range conf.Channels {
+		name := c.Name
+		if name == "" {
+			name = fmt.Sprintf("%s-%d", c.Type, i)
+		}
+		var n Notifier
+		switch c.Type {
+		case "slack":
+			n = NewSlackNotifier(clients.NewWebhook(), c.URL, messages)
+		case "webhook":
+			n = NewWebhook(clients.NewWebhook(), c.URL, c.Secret)
+		case "pagerduty":
+			n = NewPagerDutyNotifier(InitPagerDuty(c.APIKey), c.From, c.ServiceID, messages)
+		case "email":
+			if email == nil {
+				return fmt.Errorf("notifier %q requires an email sender", name)
+			}
+			n = email.Recipients(c.To)
+		default:
+			return fmt.Errorf("notifier %q has unknown type %q", name, c.Type)
+		}
+		mux.Add(name, n, c.NotifierFilter)
+	}
+	return nil
+}
+
+// initMessages returns the templates of the notification messages, nil if the built-in messages
+// are sent, and the runbook of each finding category.
+func initMessages(ctx context.Context) (*Messages, map[string]string, error) {
+	b, err := ioutil.ReadFile(messagesFile)
+	if os.IsNotExist(err) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to read messages config")
+	}
+	var conf struct {
+		Bucket   string            `json:"bucket"`
+		Prefix   string            `json:"prefix"`
+		Runbooks map[string]string `json:"runbooks"`
+	}
+	if err := json.Unmarshal(b, &conf); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to parse messages config")
+	}
+	if conf.Bucket == "" {
+		return nil, conf.Runbooks, nil
+	}
+	stg, err := clients.NewStorage(ctx, authFile)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to initialize storage client")
+	}
+	return NewMessages(stg, conf.Bucket, conf.Prefix), conf.Runbooks, nil
+}
+
+func initIntel() (*Intel, error) {
+	b, err := ioutil.ReadFile(intelFile)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read intel config")
+	}
+	var conf struct {
+		VirusTotalAPIKey string `json:"virustotal_api_key"`
+	}
+	if err := json.Unmarshal(b, &conf); err != nil {
+		return nil, errors.Wrap(err, "failed to parse intel config")
+	}
+	if conf.VirusTotalAPIKey == "" {
+		return nil, nil
+	}
+	return NewIntel(clients.NewVirusTotalIPs(conf.VirusTotalAPIKey)), nil
+}
+
+func initGroups(ctx context.Context) (*Groups, error) {
+	b, err := ioutil.ReadFile(groupsFile)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read groups config")
+	}
+	var conf struct {
+		AdminEmail string `json:"admin_email"`
+	}
+	if err := json.Unmarshal(b, &conf); err != nil {
+		return nil, errors.Wrap(err, "failed to parse groups config")
+	}
+	if conf.AdminEmail == "" {
+		return nil, nil
+	}
+	g, err := clients.NewGroups(ctx, authFile, conf.AdminEmail)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to initialize groups client")
+	}
+	return NewGroups(g), nil
+}
+
+func initAssets(ctx context.Context, resolver *Resolver) (*Assets, error) {
+	a, err := clients.NewAssets(ctx, authFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to initialize cloud asset client")
+	}
+	return NewAssets(a, resolver), nil
+}
+
+// initRateLimits limits the requests the clients created afterwards make to each configured API.
+// The limits apply per function instance and are shared by all clients of the same API.
+func initRateLimits() error {
+	b, err := ioutil.ReadFile(rateLimitFile)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to read rate limit config")
+	}
+	var conf map[string]float64
+	if err := json.Unmarshal(b, &conf); err != nil {
+		return errors.Wrap(err, "failed to parse rate limit config")
+	}
+	for api, qps := range conf {
+		if err := clients.SetRateLimit(api, qps); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// initTracing exports the spans of the automations and of the requests made by the clients
+// created afterwards to Cloud Trace if the TRACING environment variable is "true".
+func initTracing(ctx context.Context) error {
+	if os.Getenv("TRACING") != "true" {
+		return nil
+	}
+	exporter, err := clients.NewCloudTrace(ctx, authFile)
+	if err != nil {
+		return err
+	}
+	clients.EnableTracing(exporter)
+	return nil
+}
+
+// initImpersonation returns the configured impersonation scopes and lets the clients created
+// afterwards act as their service accounts.
+func initImpersonation() ([]ImpersonationScope, error) {
+	b, err := ioutil.ReadFile(impersonationFile)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read impersonation config")
+	}
+	var conf struct {
+		Scopes []ImpersonationScope `json:"scopes"`
+	}
+	if err := json.Unmarshal(b, &conf); err != nil {
+		return nil, errors.Wrap(err, "failed to parse impersonation config")
+	}
+	for _, s := range conf.Scopes {
+		if s.Scope == "" || s.ServiceAccount == "" {
+			return nil, fmt.Errorf("impersonation scope %+v needs a scope and a service account", s)
+		}
+	}
+	if len(conf.Scopes) > 0 {
+		clients.EnableImpersonation()
+	}
+	return conf.Scopes, nil
+}
+
+func initState(ctx context.Context) (*State, error) {
+	b, err := ioutil.ReadFile(stateFile)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read state config")
+	}
+	var conf struct {
+		Bucket string `json:"bucket"`
+	}
+	if err := json.Unmarshal(b, &conf); err != nil {
+		return nil, errors.Wrap(err, "failed to parse state config")
+	}
+	if conf.Bucket == "" {
+		return nil, nil
+	}
+	stg, err := clients.NewStorage(ctx, authFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to initialize storage client")
+	}
+	return NewState(stg, conf.Bucket), nil
+}
+
+// initEmail returns the email service and sender address, or a nil service if no email sender
+// is configured. SendGrid is used if an API key is given, otherwise the SMTP server.
+func initEmail() (*Email, string, error) {
+	b, err := ioutil.ReadFile(emailFile)
+	if os.IsNotExist(err) {
+		return nil, "", nil
+	}
+	if err != nil {
+		return nil, "", errors.Wrap(err, "failed to read email config")
+	}
+	var conf struct {
+		APIKey string `json:"api_key"`
+		From   string `json:"from"`
+		SMTP   struct {
+			Host     string `json:"host"`
+			Port     int    `json:"port"`
+			Username string `json:"username"`
+			Password string `json:"password"`
+		} `json:"smtp"`
+	}
+	if err := json.Unmarshal(b, &conf); err != nil {
+		return nil, "", errors.Wrap(err, "failed to parse email config")
+	}
+	switch {
+	case conf.From == "":
+		return nil, "", nil
+	case conf.APIKey != "":
+		return NewEmail(clients.NewSendGridClient(conf.APIKey)), conf.From, nil
+	case conf.SMTP.Host != "":
+		if conf.SMTP.Port == 0 {
+			conf.SMTP.Port = 587
+		}
+		return NewEmail(clients.NewSMTP(conf.SMTP.Host, conf.SMTP.Port, conf.SMTP.Username, conf.SMTP.Password)), conf.From, nil
+	default:
+		return nil, "", nil
+	}
+}
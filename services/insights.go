@@ -0,0 +1,78 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/googlecloudplatform/security-response-automation/clients/insights"
+	"github.com/pkg/errors"
+)
+
+// firewallInsightType is the Recommender insight type of Firewall Insights.
+const firewallInsightType = "google.compute.firewall.Insight"
+
+// RecommenderClient contains minimum interface required by the insights service.
+type RecommenderClient interface {
+	ListInsights(context.Context, string, string) ([]*insights.Insight, error)
+	MarkInsightAccepted(context.Context, string, string) error
+}
+
+// Insights service reads and resolves Recommender insights.
+type Insights struct {
+	client RecommenderClient
+}
+
+// NewInsights returns an insights service.
+func NewInsights(client RecommenderClient) *Insights {
+	return &Insights{client: client}
+}
+
+// FirewallInsights returns the active Firewall Insights of the given subtypes, i.e.
+// "SHADOWED_RULE", about the project's firewall rule given its name.
+func (i *Insights) FirewallInsights(ctx context.Context, projectID, rule string, subtypes []string) ([]*insights.Insight, error) {
+	parent := fmt.Sprintf("projects/%s/locations/global/insightTypes/%s", projectID, firewallInsightType)
+	all, err := i.client.ListInsights(ctx, parent, `stateInfo.state="ACTIVE"`)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list firewall insights of %q", projectID)
+	}
+	target := fmt.Sprintf("//compute.googleapis.com/projects/%s/global/firewalls/%s", projectID, rule)
+	wanted := make(map[string]bool, len(subtypes))
+	for _, s := range subtypes {
+		wanted[s] = true
+	}
+	found := []*insights.Insight{}
+	for _, in := range all {
+		if !wanted[in.InsightSubtype] {
+			continue
+		}
+		for _, r := range in.TargetResources {
+			if r == target {
+				found = append(found, in)
+				break
+			}
+		}
+	}
+	return found, nil
+}
+
+// Accept marks the insight as acted on so it's no longer reported as active.
+func (i *Insights) Accept(ctx context.Context, in *insights.Insight) error {
+	if err := i.client.MarkInsightAccepted(ctx, in.Name, in.Etag); err != nil {
+		return errors.Wrapf(err, "failed to mark insight %q accepted", in.Name)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Reputations of an IP address, from worst to best.
+const (
+	ReputationMalicious  = "malicious"
+	ReputationSuspicious = "suspicious"
+	ReputationHarmless   = "harmless"
+	ReputationUnknown    = "unknown"
+)
+
+// reputationRank orders reputations so the worst of several IPs can be found.
+var reputationRank = map[string]int{
+	ReputationMalicious:  3,
+	ReputationSuspicious: 2,
+	ReputationHarmless:   1,
+	ReputationUnknown:    0,
+}
+
+// IPIntelClient contains minimum interface required by the intel service. Any threat intelligence
+// or GeoIP provider returning a reputation and country for an IP address can be used.
+type IPIntelClient interface {
+	LookupIP(context.Context, string) (string, string, error)
+}
+
+// IPIntel is the reputation and country of an IP address seen in a finding.
+type IPIntel struct {
+	IP         string `json:"ip"`
+	Reputation string `json:"reputation"`
+	// Country is the ISO 3166 code of the country the IP is located in if known, i.e. "US".
+	Country string `json:"country,omitempty"`
+}
+
+// Intel service looks up IP addresses with a threat intelligence provider. Results are cached for
+// the lifetime of the function instance.
+type Intel struct {
+	client IPIntelClient
+	mu     sync.Mutex
+	cache  map[string]IPIntel
+}
+
+// NewIntel returns an intel service.
+func NewIntel(client IPIntelClient) *Intel {
+	return &Intel{client: client, cache: make(map[string]IPIntel)}
+}
+
+// Lookup returns the reputation and country of each IP address, in the order given.
+func (i *Intel) Lookup(ctx context.Context, ips []string) ([]IPIntel, error) {
+	intel := make([]IPIntel, 0, len(ips))
+	for _, ip := range ips {
+		r, err := i.lookup(ctx, ip)
+		if err != nil {
+			return nil, err
+		}
+		intel = append(intel, r)
+	}
+	return intel, nil
+}
+
+func (i *Intel) lookup(ctx context.Context, ip string) (IPIntel, error) {
+	i.mu.Lock()
+	r, ok := i.cache[ip]
+	i.mu.Unlock()
+	if ok {
+		return r, nil
+	}
+	reputation, country, err := i.client.LookupIP(ctx, ip)
+	if err != nil {
+		return IPIntel{}, errors.Wrapf(err, "failed to look up %q", ip)
+	}
+	if _, ok := reputationRank[reputation]; !ok {
+		reputation = ReputationUnknown
+	}
+	r = IPIntel{IP: ip, Reputation: reputation, Country: country}
+	i.mu.Lock()
+	i.cache[ip] = r
+	i.mu.Unlock()
+	return r, nil
+}
+
+// WorstReputation returns the worst reputation of the IP addresses, or unknown if there are none.
+func WorstReputation(intel []IPIntel) string {
+	worst := ReputationUnknown
+	for _, r := range intel {
+		if reputationRank[r.Reputation] > reputationRank[worst] {
+			worst = r.Reputation
+		}
+	}
+	return worst
+}
@@ -0,0 +1,62 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+)
+
+func TestIntelLookup(t *testing.T) {
+	ctx := context.Background()
+	client := &stubs.IPIntelStub{StubbedIPs: map[string]stubs.StubbedIP{
+		"198.51.100.7": {Reputation: "malicious", Country: "RU"},
+		"203.0.113.9":  {Reputation: "harmless", Country: "US"},
+		"192.0.2.1":    {Reputation: "bogus"},
+	}}
+	i := NewIntel(client)
+	ips := []string{"203.0.113.9", "198.51.100.7", "192.0.2.1", "192.0.2.2"}
+	expected := []IPIntel{
+		{IP: "203.0.113.9", Reputation: ReputationHarmless, Country: "US"},
+		{IP: "198.51.100.7", Reputation: ReputationMalicious, Country: "RU"},
+		{IP: "192.0.2.1", Reputation: ReputationUnknown},
+		{IP: "192.0.2.2", Reputation: ReputationUnknown},
+	}
+	intel, err := i.Lookup(ctx, ips)
+	if err != nil {
+		t.Fatalf("Lookup() failed: %q", err)
+	}
+	if diff := cmp.Diff(expected, intel); diff != "" {
+		t.Errorf("Lookup() returned unexpected intel (-want +got):\n%s", diff)
+	}
+	if got := WorstReputation(intel); got != ReputationMalicious {
+		t.Errorf("WorstReputation() = %q, want %q", got, ReputationMalicious)
+	}
+	if _, err := i.Lookup(ctx, ips); err != nil {
+		t.Fatalf("Lookup() failed: %q", err)
+	}
+	if len(client.LookedUp) != len(ips) {
+		t.Errorf("looked up %d IPs, want %d cached after the first lookup", len(client.LookedUp), len(ips))
+	}
+}
+
+func TestWorstReputationEmpty(t *testing.T) {
+	if got := WorstReputation(nil); got != ReputationUnknown {
+		t.Errorf("WorstReputation(nil) = %q, want %q", got, ReputationUnknown)
+	}
+}
@@ -0,0 +1,119 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	kms "google.golang.org/api/cloudkms/v1"
+)
+
+const (
+	// keyVersionEnabled is the state of a key version that can be used.
+	keyVersionEnabled = "ENABLED"
+	// keyVersionDisabled is the state of a key version that can't be used until it's enabled again.
+	keyVersionDisabled = "DISABLED"
+	// BreakGlassRole is the only role left on a restricted key, held by the break-glass group.
+	BreakGlassRole = "roles/cloudkms.admin"
+)
+
+// KMSClient contains minimum interface required by the KMS service.
+type KMSClient interface {
+	GetCryptoKeyVersion(context.Context, string) (*kms.CryptoKeyVersion, error)
+	UpdateCryptoKeyVersionState(context.Context, string, string) (*kms.CryptoKeyVersion, error)
+	GetCryptoKeyPolicy(context.Context, string) (*kms.Policy, error)
+	SetCryptoKeyPolicy(context.Context, string, *kms.Policy) (*kms.Policy, error)
+}
+
+// KMS service manages Cloud KMS keys.
+type KMS struct {
+	client KMSClient
+}
+
+// NewKMS returns a KMS service.
+func NewKMS(client KMSClient) *KMS {
+	return &KMS{client: client}
+}
+
+// CryptoKey returns the resource name of the key the key version belongs to.
+func CryptoKey(version string) string {
+	if i := strings.Index(version, "/cryptoKeyVersions/"); i >= 0 {
+		return version[:i]
+	}
+	return version
+}
+
+// KeyVersionEnabled returns whether the key version can be used to encrypt and decrypt.
+func (k *KMS) KeyVersionEnabled(ctx context.Context, version string) (bool, error) {
+	v, err := k.client.GetCryptoKeyVersion(ctx, version)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to get key version %q", version)
+	}
+	return v.State == keyVersionEnabled, nil
+}
+
+// DisableKeyVersion disables the key version. Data encrypted with it can't be decrypted until the
+// version is enabled again, the key material is kept.
+func (k *KMS) DisableKeyVersion(ctx context.Context, version string) error {
+	if _, err := k.client.UpdateCryptoKeyVersionState(ctx, version, keyVersionDisabled); err != nil {
+		return errors.Wrapf(err, "failed to disable key version %q", version)
+	}
+	return nil
+}
+
+// KeyPolicyChanges returns the bindings restricting the key's IAM policy to the break-glass member
+// would change, none if the policy is already restricted.
+func (k *KMS) KeyPolicyChanges(ctx context.Context, key, breakGlass string) ([]BindingChange, error) {
+	p, err := k.client.GetCryptoKeyPolicy(ctx, key)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get policy of key %q", key)
+	}
+	return policyChanges(kmsBindingMembers(p.Bindings), kmsBindingMembers(restrictedBindings(breakGlass))), nil
+}
+
+// RestrictKeyPolicy replaces the key's IAM policy with a single binding granting the break-glass
+// member BreakGlassRole. Every other member loses access to the key. The changed bindings are
+// returned, none if the policy was already restricted.
+func (k *KMS) RestrictKeyPolicy(ctx context.Context, key, breakGlass string) ([]BindingChange, error) {
+	p, err := k.client.GetCryptoKeyPolicy(ctx, key)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get policy of key %q", key)
+	}
+	before := kmsBindingMembers(p.Bindings)
+	p.Bindings = restrictedBindings(breakGlass)
+	changes := policyChanges(before, kmsBindingMembers(p.Bindings))
+	if len(changes) == 0 {
+		return changes, nil
+	}
+	if _, err := k.client.SetCryptoKeyPolicy(ctx, key, p); err != nil {
+		return nil, errors.Wrapf(err, "failed to set policy of key %q", key)
+	}
+	return changes, nil
+}
+
+func restrictedBindings(breakGlass string) []*kms.Binding {
+	return []*kms.Binding{{Role: BreakGlassRole, Members: []string{breakGlass}}}
+}
+
+// kmsBindingMembers returns a copy of the members of each role of the key policy's bindings.
+func kmsBindingMembers(bindings []*kms.Binding) map[string][]string {
+	members := make(map[string][]string, len(bindings))
+	for _, b := range bindings {
+		members[b.Role] = append(members[b.Role], b.Members...)
+	}
+	return members
+}
@@ -0,0 +1,104 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+	kms "google.golang.org/api/cloudkms/v1"
+)
+
+const (
+	testKey        = "projects/p/locations/us/keyRings/r/cryptoKeys/k"
+	testBreakGlass = "group:break-glass@example.com"
+)
+
+func TestCryptoKey(t *testing.T) {
+	if got := CryptoKey(testKey + "/cryptoKeyVersions/3"); got != testKey {
+		t.Errorf("CryptoKey() = %q, want %q", got, testKey)
+	}
+}
+
+func TestRestrictKeyPolicy(t *testing.T) {
+	ctx := context.Background()
+	for _, tt := range []struct {
+		name     string
+		policy   *kms.Policy
+		expected []BindingChange
+		saved    bool
+	}{
+		{
+			name: "restrict",
+			policy: &kms.Policy{Etag: "abc", Bindings: []*kms.Binding{
+				{Role: "roles/cloudkms.cryptoKeyDecrypter", Members: []string{"serviceAccount:app@p.iam.gserviceaccount.com"}},
+				{Role: BreakGlassRole, Members: []string{"user:admin@example.com", testBreakGlass}},
+			}},
+			expected: []BindingChange{
+				{Role: BreakGlassRole, Member: "user:admin@example.com", Change: BindingRemoved},
+				{Role: "roles/cloudkms.cryptoKeyDecrypter", Member: "serviceAccount:app@p.iam.gserviceaccount.com", Change: BindingRemoved},
+			},
+			saved: true,
+		},
+		{
+			name:     "grant break-glass group",
+			policy:   &kms.Policy{},
+			expected: []BindingChange{{Role: BreakGlassRole, Member: testBreakGlass, Change: BindingAdded}},
+			saved:    true,
+		},
+		{
+			name: "already restricted",
+			policy: &kms.Policy{Bindings: []*kms.Binding{
+				{Role: BreakGlassRole, Members: []string{testBreakGlass}},
+			}},
+			expected: []BindingChange{},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			kmsStub := &stubs.KMSStub{StubbedPolicies: map[string]*kms.Policy{testKey: tt.policy}}
+			k := NewKMS(kmsStub)
+			planned, err := k.KeyPolicyChanges(ctx, testKey, testBreakGlass)
+			if err != nil {
+				t.Fatalf("%s failed: %q", tt.name, err)
+			}
+			if diff := cmp.Diff(tt.expected, planned); diff != "" {
+				t.Errorf("%s failed, planned difference: %v", tt.name, diff)
+			}
+			got, err := k.RestrictKeyPolicy(ctx, testKey, testBreakGlass)
+			if err != nil {
+				t.Fatalf("%s failed: %q", tt.name, err)
+			}
+			if diff := cmp.Diff(tt.expected, got); diff != "" {
+				t.Errorf("%s failed, difference: %v", tt.name, diff)
+			}
+			saved, ok := kmsStub.SavedPolicies[testKey]
+			if ok != tt.saved {
+				t.Fatalf("%s failed, policy saved %t want %t", tt.name, ok, tt.saved)
+			}
+			if !ok {
+				return
+			}
+			if saved.Etag != tt.policy.Etag {
+				t.Errorf("%s failed, etag %q want %q", tt.name, saved.Etag, tt.policy.Etag)
+			}
+			want := []*kms.Binding{{Role: BreakGlassRole, Members: []string{testBreakGlass}}}
+			if diff := cmp.Diff(want, saved.Bindings); diff != "" {
+				t.Errorf("%s failed, saved bindings difference: %v", tt.name, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,438 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+	compute "google.golang.org/api/compute/v1"
+)
+
+// externalScheme is the load balancing scheme of internet facing load balancers.
+const externalScheme = "EXTERNAL"
+
+// Collections of the target proxies fronting external load balancers.
+const (
+	TargetHTTPProxies  = "targetHttpProxies"
+	TargetHTTPSProxies = "targetHttpsProxies"
+	TargetSSLProxies   = "targetSslProxies"
+)
+
+// computeLink is the prefix of the self links of compute resources.
+const computeLink = "https://www.googleapis.com/compute/v1"
+
+// LoadBalancerClient holds the minimum interface required by the load balancer service.
+type LoadBalancerClient interface {
+	ListInstanceGroups(context.Context, string, string) (*compute.InstanceGroupList, error)
+	ListInstanceGroupInstances(context.Context, string, string, string) (*compute.InstanceGroupsListInstances, error)
+	ListBackendServices(context.Context, string) (*compute.BackendServiceList, error)
+	ListURLMaps(context.Context, string) (*compute.UrlMapList, error)
+	UpdateURLMap(context.Context, string, string, *compute.UrlMap) (*compute.Operation, error)
+	ListTargetHTTPProxies(context.Context, string) (*compute.TargetHttpProxyList, error)
+	ListTargetHTTPSProxies(context.Context, string) (*compute.TargetHttpsProxyList, error)
+	ListGlobalForwardingRules(context.Context, string) (*compute.ForwardingRuleList, error)
+	DeleteGlobalForwardingRule(context.Context, string, string) (*compute.Operation, error)
+	GetURLMap(context.Context, string, string) (*compute.UrlMap, error)
+	InsertURLMap(context.Context, string, *compute.UrlMap) (*compute.Operation, error)
+	GetTargetHTTPProxy(context.Context, string, string) (*compute.TargetHttpProxy, error)
+	SetTargetHTTPProxyURLMap(context.Context, string, string, string) (*compute.Operation, error)
+	GetTargetHTTPSProxy(context.Context, string, string) (*compute.TargetHttpsProxy, error)
+	SetTargetHTTPSProxySSLPolicy(context.Context, string, string, string) (*compute.Operation, error)
+	GetTargetSSLProxy(context.Context, string, string) (*compute.TargetSslProxy, error)
+	SetTargetSSLProxySSLPolicy(context.Context, string, string, string) (*compute.Operation, error)
+	GetSSLPolicy(context.Context, string, string) (*compute.SslPolicy, error)
+	InsertSSLPolicy(context.Context, string, *compute.SslPolicy) (*compute.Operation, error)
+	WaitGlobal(context.Context, string, *compute.Operation) []error
+}
+
+// LoadBalancer service.
+type LoadBalancer struct {
+	client LoadBalancerClient
+}
+
+// NewLoadBalancer returns a load balancer service.
+func NewLoadBalancer(client LoadBalancerClient) *LoadBalancer {
+	return &LoadBalancer{client: client}
+}
+
+// Exposure describes the external HTTP(S) load balancers routing traffic to an instance.
+type Exposure struct {
+	// BackendServices holds the self links of the backend services containing the instance.
+	BackendServices []string
+	// URLMaps holds the names of the URL maps routing to those backend services.
+	URLMaps []string
+	// ForwardingRules holds the names of the external forwarding rules serving those URL maps.
+	ForwardingRules []string
+}
+
+// InstanceExposure returns the external HTTP(S) load balancers that route traffic to the instance
+// through the zonal instance groups it belongs to.
+func (l *LoadBalancer) InstanceExposure(ctx context.Context, projectID, zone, instance string) (*Exposure, error) {
+	groups, err := l.instanceGroups(ctx, projectID, zone, instance)
+	if err != nil {
+		return nil, err
+	}
+	exposure := &Exposure{}
+	if len(groups) == 0 {
+		return exposure, nil
+	}
+	bs, err := l.client.ListBackendServices(ctx, projectID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list backend services")
+	}
+	backends := make(map[string]bool)
+	for _, s := range bs.Items {
+		for _, b := range s.Backends {
+			if groups[b.Group] {
+				backends[s.SelfLink] = true
+				exposure.BackendServices = append(exposure.BackendServices, s.SelfLink)
+				break
+			}
+		}
+	}
+	if len(backends) == 0 {
+		return exposure, nil
+	}
+	maps, err := l.client.ListURLMaps(ctx, projectID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list url maps")
+	}
+	urlMaps := make(map[string]bool)
+	for _, m := range maps.Items {
+		if urlMapRoutesTo(m, backends) {
+			urlMaps[m.SelfLink] = true
+			exposure.URLMaps = append(exposure.URLMaps, m.Name)
+		}
+	}
+	proxies, err := l.proxies(ctx, projectID, urlMaps)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := l.client.ListGlobalForwardingRules(ctx, projectID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list forwarding rules")
+	}
+	for _, r := range rules.Items {
+		if r.LoadBalancingScheme == externalScheme && proxies[r.Target] {
+			exposure.ForwardingRules = append(exposure.ForwardingRules, r.Name)
+		}
+	}
+	return exposure, nil
+}
+
+// DeleteForwardingRules deletes the global forwarding rules.
+func (l *LoadBalancer) DeleteForwardingRules(ctx context.Context, projectID string, rules []string) error {
+	for _, r := range rules {
+		op, err := l.client.DeleteGlobalForwardingRule(ctx, projectID, r)
+		if err != nil {
+			return errors.Wrapf(err, "failed to delete forwarding rule %q", r)
+		}
+		if errs := l.client.WaitGlobal(ctx, projectID, op); len(errs) > 0 {
+			return errs[0]
+		}
+	}
+	return nil
+}
+
+// DetachBackendServices routes the URL maps' traffic for the backend services to the quarantine
+// backend service instead.
+func (l *LoadBalancer) DetachBackendServices(ctx context.Context, projectID string, urlMaps, backendServices []string, quarantine string) error {
+	bs, err := l.client.ListBackendServices(ctx, projectID)
+	if err != nil {
+		return errors.Wrap(err, "failed to list backend services")
+	}
+	quarantineLink := ""
+	for _, s := range bs.Items {
+		if s.Name == quarantine {
+			quarantineLink = s.SelfLink
+		}
+	}
+	if quarantineLink == "" {
+		return fmt.Errorf("quarantine backend service %q not found", quarantine)
+	}
+	maps, err := l.client.ListURLMaps(ctx, projectID)
+	if err != nil {
+		return errors.Wrap(err, "failed to list url maps")
+	}
+	detach := make(map[string]bool, len(backendServices))
+	for _, s := range backendServices {
+		detach[s] = true
+	}
+	names := make(map[string]bool, len(urlMaps))
+	for _, m := range urlMaps {
+		names[m] = true
+	}
+	for _, m := range maps.Items {
+		if !names[m.Name] {
+			continue
+		}
+		replaceService(m, detach, quarantineLink)
+		op, err := l.client.UpdateURLMap(ctx, projectID, m.Name, m)
+		if err != nil {
+			return errors.Wrapf(err, "failed to update url map %q", m.Name)
+		}
+		if errs := l.client.WaitGlobal(ctx, projectID, op); len(errs) > 0 {
+			return errs[0]
+		}
+	}
+	return nil
+}
+
+// ModernSSLPolicy returns true if the SSL policy, given by name or self link, requires TLS 1.2 or
+// later and doesn't use the COMPATIBLE profile which allows weak ciphers.
+func (l *LoadBalancer) ModernSSLPolicy(ctx context.Context, projectID, policy string) (bool, error) {
+	p, err := l.client.GetSSLPolicy(ctx, projectID, path.Base(policy))
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to get ssl policy %q", policy)
+	}
+	return modernSSLPolicy(p), nil
+}
+
+// EnsureSSLPolicy returns the self link of the SSL policy, creating it with the MODERN profile
+// requiring TLS 1.2 if it doesn't exist. An existing policy allowing weaker TLS is an error rather
+// than being changed since other load balancers may depend on it.
+func (l *LoadBalancer) EnsureSSLPolicy(ctx context.Context, projectID, name string) (string, error) {
+	p, err := l.client.GetSSLPolicy(ctx, projectID, name)
+	if err == nil {
+		if !modernSSLPolicy(p) {
+			return "", fmt.Errorf("ssl policy %q allows TLS older than 1.2 or weak ciphers", name)
+		}
+		return globalLink(projectID, "sslPolicies", name), nil
+	}
+	if !IsNotFound(err) {
+		return "", errors.Wrapf(err, "failed to get ssl policy %q", name)
+	}
+	op, err := l.client.InsertSSLPolicy(ctx, projectID, &compute.SslPolicy{
+		Name:          name,
+		Description:   "Requires TLS 1.2 or later.",
+		Profile:       "MODERN",
+		MinTlsVersion: "TLS_1_2",
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to create ssl policy %q", name)
+	}
+	if errs := l.client.WaitGlobal(ctx, projectID, op); len(errs) > 0 {
+		return "", errs[0]
+	}
+	return globalLink(projectID, "sslPolicies", name), nil
+}
+
+// ProxySSLPolicy returns the self link of the SSL policy attached to the HTTPS or SSL proxy, or an
+// empty string if the proxy uses the default policy.
+func (l *LoadBalancer) ProxySSLPolicy(ctx context.Context, projectID, proxyType, proxy string) (string, error) {
+	switch proxyType {
+	case TargetHTTPSProxies:
+		p, err := l.client.GetTargetHTTPSProxy(ctx, projectID, proxy)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to get target https proxy %q", proxy)
+		}
+		return p.SslPolicy, nil
+	case TargetSSLProxies:
+		p, err := l.client.GetTargetSSLProxy(ctx, projectID, proxy)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to get target ssl proxy %q", proxy)
+		}
+		return p.SslPolicy, nil
+	default:
+		return "", fmt.Errorf("unsupported proxy type %q", proxyType)
+	}
+}
+
+// SetProxySSLPolicy attaches the SSL policy to the HTTPS or SSL proxy.
+func (l *LoadBalancer) SetProxySSLPolicy(ctx context.Context, projectID, proxyType, proxy, policy string) error {
+	var op *compute.Operation
+	var err error
+	switch proxyType {
+	case TargetHTTPSProxies:
+		op, err = l.client.SetTargetHTTPSProxySSLPolicy(ctx, projectID, proxy, policy)
+	case TargetSSLProxies:
+		op, err = l.client.SetTargetSSLProxySSLPolicy(ctx, projectID, proxy, policy)
+	default:
+		return fmt.Errorf("unsupported proxy type %q", proxyType)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to set ssl policy of %q", proxy)
+	}
+	if errs := l.client.WaitGlobal(ctx, projectID, op); len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+// RedirectsToHTTPS returns true if the URL map of the HTTP proxy redirects all requests to HTTPS.
+func (l *LoadBalancer) RedirectsToHTTPS(ctx context.Context, projectID, proxy string) (bool, error) {
+	p, err := l.client.GetTargetHTTPProxy(ctx, projectID, proxy)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to get target http proxy %q", proxy)
+	}
+	m, err := l.client.GetURLMap(ctx, projectID, path.Base(p.UrlMap))
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to get url map of %q", proxy)
+	}
+	return redirectsToHTTPS(m), nil
+}
+
+// RedirectToHTTPS points the HTTP proxy at a URL map redirecting all requests to HTTPS, creating
+// the URL map if needed. The name of the URL map the proxy used before is returned.
+func (l *LoadBalancer) RedirectToHTTPS(ctx context.Context, projectID, proxy string) (string, error) {
+	p, err := l.client.GetTargetHTTPProxy(ctx, projectID, proxy)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get target http proxy %q", proxy)
+	}
+	name := redirectURLMapName(proxy)
+	m, err := l.client.GetURLMap(ctx, projectID, name)
+	switch {
+	case err == nil:
+		if !redirectsToHTTPS(m) {
+			return "", fmt.Errorf("url map %q exists and does not redirect to https", name)
+		}
+	case IsNotFound(err):
+		op, err := l.client.InsertURLMap(ctx, projectID, &compute.UrlMap{
+			Name:        name,
+			Description: fmt.Sprintf("Redirects the requests of %s to HTTPS.", proxy),
+			DefaultUrlRedirect: &compute.HttpRedirectAction{
+				HttpsRedirect:        true,
+				RedirectResponseCode: "MOVED_PERMANENTLY_DEFAULT",
+			},
+		})
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to create url map %q", name)
+		}
+		if errs := l.client.WaitGlobal(ctx, projectID, op); len(errs) > 0 {
+			return "", errs[0]
+		}
+	default:
+		return "", errors.Wrapf(err, "failed to get url map %q", name)
+	}
+	op, err := l.client.SetTargetHTTPProxyURLMap(ctx, projectID, proxy, globalLink(projectID, "urlMaps", name))
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to set url map of %q", proxy)
+	}
+	if errs := l.client.WaitGlobal(ctx, projectID, op); len(errs) > 0 {
+		return "", errs[0]
+	}
+	return path.Base(p.UrlMap), nil
+}
+
+func modernSSLPolicy(p *compute.SslPolicy) bool {
+	return (p.MinTlsVersion == "TLS_1_2" || p.MinTlsVersion == "TLS_1_3") && p.Profile != "COMPATIBLE"
+}
+
+func redirectsToHTTPS(m *compute.UrlMap) bool {
+	return m.DefaultUrlRedirect != nil && m.DefaultUrlRedirect.HttpsRedirect && len(m.HostRules) == 0
+}
+
+// redirectURLMapName returns the name of the URL map redirecting the proxy's requests to HTTPS,
+// keeping within the 63 character limit of resource names.
+func redirectURLMapName(proxy string) string {
+	const suffix = "-https-redirect"
+	if len(proxy) > 63-len(suffix) {
+		proxy = strings.TrimRight(proxy[:63-len(suffix)], "-")
+	}
+	return proxy + suffix
+}
+
+// globalLink returns the self link of the global compute resource.
+func globalLink(projectID, collection, name string) string {
+	return fmt.Sprintf("%s/projects/%s/global/%s/%s", computeLink, projectID, collection, name)
+}
+
+// instanceGroups returns the self links of the zonal instance groups containing the instance.
+func (l *LoadBalancer) instanceGroups(ctx context.Context, projectID, zone, instance string) (map[string]bool, error) {
+	igs, err := l.client.ListInstanceGroups(ctx, projectID, zone)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list instance groups")
+	}
+	suffix := fmt.Sprintf("/zones/%s/instances/%s", zone, instance)
+	groups := make(map[string]bool)
+	for _, g := range igs.Items {
+		instances, err := l.client.ListInstanceGroupInstances(ctx, projectID, zone, g.Name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list instances of group %q", g.Name)
+		}
+		for _, i := range instances.Items {
+			if strings.HasSuffix(i.Instance, suffix) {
+				groups[g.SelfLink] = true
+			}
+		}
+	}
+	return groups, nil
+}
+
+// proxies returns the self links of the target HTTP(S) proxies using the URL maps.
+func (l *LoadBalancer) proxies(ctx context.Context, projectID string, urlMaps map[string]bool) (map[string]bool, error) {
+	proxies := make(map[string]bool)
+	if len(urlMaps) == 0 {
+		return proxies, nil
+	}
+	http, err := l.client.ListTargetHTTPProxies(ctx, projectID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list target http proxies")
+	}
+	for _, p := range http.Items {
+		if urlMaps[p.UrlMap] {
+			proxies[p.SelfLink] = true
+		}
+	}
+	https, err := l.client.ListTargetHTTPSProxies(ctx, projectID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list target https proxies")
+	}
+	for _, p := range https.Items {
+		if urlMaps[p.UrlMap] {
+			proxies[p.SelfLink] = true
+		}
+	}
+	return proxies, nil
+}
+
+// urlMapRoutesTo returns true if any rule of the URL map routes to one of the services.
+func urlMapRoutesTo(m *compute.UrlMap, services map[string]bool) bool {
+	if services[m.DefaultService] {
+		return true
+	}
+	for _, pm := range m.PathMatchers {
+		if services[pm.DefaultService] {
+			return true
+		}
+		for _, r := range pm.PathRules {
+			if services[r.Service] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// replaceService points every rule of the URL map routing to one of the services to replacement.
+func replaceService(m *compute.UrlMap, services map[string]bool, replacement string) {
+	if services[m.DefaultService] {
+		m.DefaultService = replacement
+	}
+	for _, pm := range m.PathMatchers {
+		if services[pm.DefaultService] {
+			pm.DefaultService = replacement
+		}
+		for _, r := range pm.PathRules {
+			if services[r.Service] {
+				r.Service = replacement
+			}
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// zoneName matches zone names, i.e. "us-central1-a", as opposed to regions such as "us-central1".
+var zoneName = regexp.MustCompile(`^[a-z]+-[a-z]+[0-9]+-[a-z]$`)
+
+// IsZone returns whether the location is a zone rather than a region.
+func IsZone(location string) bool {
+	return zoneName.MatchString(location)
+}
+
+// RegionOfZone returns the region of the zone, i.e. "us-central1" for "us-central1-a". Regions are
+// returned unchanged.
+func RegionOfZone(location string) string {
+	if !IsZone(location) {
+		return location
+	}
+	return location[:strings.LastIndex(location, "-")]
+}
+
+// LocationOf returns the last path segment of a location URL such as an instance's zone,
+// "https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-a", or the location itself.
+func LocationOf(url string) string {
+	return url[strings.LastIndex(url, "/")+1:]
+}
+
+// InstanceZone returns the zone of the instance. If zone is empty, as when a finding omits it, the
+// instance is looked up in every zone of the project.
+func (h *Host) InstanceZone(ctx context.Context, project, zone, instance string) (string, error) {
+	if zone != "" {
+		return zone, nil
+	}
+	instances, err := h.client.ListInstancesInAllZones(ctx, project, fmt.Sprintf("name = %q", instance))
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to find the zone of instance %q", instance)
+	}
+	var zones []string
+	for _, i := range instances {
+		if i.Name == instance {
+			zones = append(zones, LocationOf(i.Zone))
+		}
+	}
+	switch len(zones) {
+	case 0:
+		return "", errors.Errorf("instance %q not found in any zone of project %q", instance, project)
+	case 1:
+		return zones[0], nil
+	default:
+		return "", errors.Errorf("instance %q is in several zones of project %q: %s", instance, project, strings.Join(zones, ", "))
+	}
+}
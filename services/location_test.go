@@ -0,0 +1,78 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"testing"
+
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+	compute "google.golang.org/api/compute/v1"
+)
+
+func TestRegionOfZone(t *testing.T) {
+	for _, tt := range []struct {
+		location string
+		zone     bool
+		region   string
+	}{
+		{location: "us-central1-a", zone: true, region: "us-central1"},
+		{location: "northamerica-northeast1-c", zone: true, region: "northamerica-northeast1"},
+		{location: "europe-west1", zone: false, region: "europe-west1"},
+		{location: "global", zone: false, region: "global"},
+	} {
+		if z := IsZone(tt.location); z != tt.zone {
+			t.Errorf("IsZone(%q) = %v, expected %v", tt.location, z, tt.zone)
+		}
+		if r := RegionOfZone(tt.location); r != tt.region {
+			t.Errorf("RegionOfZone(%q) = %q, expected %q", tt.location, r, tt.region)
+		}
+	}
+}
+
+func TestInstanceZone(t *testing.T) {
+	const zoneURL = "https://www.googleapis.com/compute/v1/projects/p/zones/"
+	for _, tt := range []struct {
+		name      string
+		zone      string
+		instances []*compute.Instance
+		expected  string
+		wantErr   bool
+	}{
+		{name: "zone given", zone: "us-east1-b", expected: "us-east1-b"},
+		{
+			name:      "found in all zones",
+			instances: []*compute.Instance{{Name: "other", Zone: zoneURL + "us-east1-b"}, {Name: "i", Zone: zoneURL + "asia-east1-a"}},
+			expected:  "asia-east1-a",
+		},
+		{name: "not found", instances: []*compute.Instance{{Name: "other", Zone: zoneURL + "us-east1-b"}}, wantErr: true},
+		{
+			name:      "ambiguous",
+			instances: []*compute.Instance{{Name: "i", Zone: zoneURL + "us-east1-b"}, {Name: "i", Zone: zoneURL + "asia-east1-a"}},
+			wantErr:   true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHost(&stubs.ComputeStub{StubbedInstancesInAllZones: tt.instances})
+			zone, err := h.InstanceZone(context.Background(), "p", tt.zone, "i")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("%s: got error %v, want error %v", tt.name, err, tt.wantErr)
+			}
+			if zone != tt.expected {
+				t.Errorf("%s: got zone %q, expected %q", tt.name, zone, tt.expected)
+			}
+		})
+	}
+}
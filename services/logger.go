@@ -14,18 +14,34 @@ package services
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/googlecloudplatform/security-response-automation/clients"
+)
+
+// RemediationsMetric counts the outcomes of the automations by action and result.
+const RemediationsMetric = "sra_remediations_total"
+
+var remediationLabels = []string{"action", "result"}
+
 // LoggerClient contains minimum interface required by the logger service.
 type LoggerClient interface {
 	Info(message string, a ...interface{})
 	Warning(message string, a ...interface{})
 	Error(message string, a ...interface{})
 	Debug(message string, a ...interface{})
+	Audit(payload interface{})
 	Close()
 }
 
 // Logger client.
 type Logger struct {
 	client LoggerClient
+	// correlationID tags what's logged by the loggers returned by For.
+	correlationID string
 }
 
 // NewLogger initializes and returns a Logger struct.
@@ -33,24 +49,83 @@ func NewLogger(l LoggerClient) *Logger {
 	return &Logger{client: l}
 }
 
+// For returns a logger prefixing its messages with the correlation ID of ctx and recording it in
+// its audit records and events. It returns l if ctx has no correlation ID.
+func (l *Logger) For(ctx context.Context) *Logger {
+	id := CorrelationID(ctx)
+	if l == nil || id == "" {
+		return l
+	}
+	return &Logger{client: l.client, correlationID: id}
+}
+
 // Info sends a message to the logger using info as the severity.
 func (l *Logger) Info(message string, a ...interface{}) {
-	l.client.Info(message, a...)
+	l.client.Info(l.tag(message), a...)
 }
 
 // Warning sends a message to the logger using warning as the severity.
 func (l *Logger) Warning(message string, a ...interface{}) {
-	l.client.Warning(message, a...)
+	l.client.Warning(l.tag(message), a...)
 }
 
 // Error sends a message to the logger using error as the severity.
 func (l *Logger) Error(message string, a ...interface{}) {
-	l.client.Error(message, a...)
+	l.client.Error(l.tag(message), a...)
 }
 
 // Debug sends a message to the logger using debug as the severity.
 func (l *Logger) Debug(message string, a ...interface{}) {
-	l.client.Debug(message, a...)
+	l.client.Debug(l.tag(message), a...)
+}
+
+// Audit sends a structured audit record to the logger.
+func (l *Logger) Audit(record *AuditRecord) {
+	if record.CorrelationID == "" {
+		record.CorrelationID = l.correlationID
+	}
+	l.client.Audit(record)
+}
+
+// Event records the outcome of an automation so its history can be queried and counts it in the
+// remediation metrics.
+func (l *Logger) Event(event *WebhookEvent) {
+	clients.DefaultMetrics.Inc(RemediationsMetric, "Outcomes of the automations by action and result.", remediationLabels, event.Action, event.Result)
+	if event.CorrelationID == "" {
+		event.CorrelationID = l.correlationID
+	}
+	l.client.Audit(event)
+}
+
+// tag prefixes the message format with the correlation ID, if any.
+func (l *Logger) tag(message string) string {
+	if l.correlationID == "" {
+		return message
+	}
+	return "[" + strings.Replace(l.correlationID, "%", "%%", -1) + "] " + message
+}
+
+// AlreadyRemediated records that an automation re-read the current state of a resource and found
+// there was nothing left to remediate.
+func (l *Logger) AlreadyRemediated(action, resource, message string, a ...interface{}) {
+	l.Audit(&AuditRecord{
+		Action:   action,
+		Resource: resource,
+		Result:   AuditResultAlreadyRemediated,
+		Message:  fmt.Sprintf(message, a...),
+	})
+}
+
+// MissingPermissions records that an automation was not run on a resource because the service
+// account lacks the given permissions.
+func (l *Logger) MissingPermissions(action, resource string, missing []string) {
+	l.Audit(&AuditRecord{
+		Action:             action,
+		Resource:           resource,
+		Result:             AuditResultMissingPermissions,
+		Message:            fmt.Sprintf("service account lacks %d permissions needed by %q", len(missing), action),
+		MissingPermissions: missing,
+	})
 }
 
 // Close buffer and send messages to stackdriver.
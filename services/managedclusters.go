@@ -0,0 +1,100 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+	composer "google.golang.org/api/composer/v1"
+	dataproc "google.golang.org/api/dataproc/v1"
+)
+
+// ComposerClient contains minimum interface required by the managed clusters service.
+type ComposerClient interface {
+	GetEnvironment(context.Context, string, string, string) (*composer.Environment, error)
+}
+
+// DataprocClient contains minimum interface required by the managed clusters service.
+type DataprocClient interface {
+	GetCluster(context.Context, string, string, string) (*dataproc.Cluster, error)
+}
+
+// ManagedClusters service looks up the resources Cloud Composer environments and Dataproc clusters
+// run on.
+type ManagedClusters struct {
+	composer ComposerClient
+	dataproc DataprocClient
+}
+
+// NewManagedClusters returns a managed clusters service.
+func NewManagedClusters(composer ComposerClient, dataproc DataprocClient) *ManagedClusters {
+	return &ManagedClusters{composer: composer, dataproc: dataproc}
+}
+
+// DataprocNodes are the instances of a Dataproc cluster.
+type DataprocNodes struct {
+	Zone      string
+	Instances []string
+	// ServiceAccount is the email of the service account the instances run as, empty if they use
+	// the project's default compute service account.
+	ServiceAccount string
+}
+
+// ComposerCluster returns the location and ID of the GKE cluster a Cloud Composer environment runs
+// on.
+func (m *ManagedClusters) ComposerCluster(ctx context.Context, projectID, location, environment string) (string, string, error) {
+	env, err := m.composer.GetEnvironment(ctx, projectID, location, environment)
+	if err != nil {
+		return "", "", errors.Wrapf(err, "failed to get composer environment %q", environment)
+	}
+	if env.Config == nil || env.Config.GkeCluster == "" {
+		return "", "", fmt.Errorf("composer environment %q has no gke cluster", environment)
+	}
+	// The cluster is named "projects/p/zones/z/clusters/c" or "projects/p/locations/l/clusters/c".
+	parts := strings.Split(env.Config.GkeCluster, "/")
+	if len(parts) != 6 || parts[4] != "clusters" {
+		return "", "", fmt.Errorf("composer environment %q has unknown gke cluster %q", environment, env.Config.GkeCluster)
+	}
+	return parts[3], parts[5], nil
+}
+
+// DataprocNodes returns the instances of a Dataproc cluster's master, worker and secondary worker
+// groups.
+func (m *ManagedClusters) DataprocNodes(ctx context.Context, projectID, region, cluster string) (*DataprocNodes, error) {
+	c, err := m.dataproc.GetCluster(ctx, projectID, region, cluster)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get dataproc cluster %q", cluster)
+	}
+	nodes := &DataprocNodes{Instances: []string{}}
+	if c.Config == nil {
+		return nodes, nil
+	}
+	if gce := c.Config.GceClusterConfig; gce != nil {
+		if gce.ZoneUri != "" {
+			nodes.Zone = path.Base(gce.ZoneUri)
+		}
+		nodes.ServiceAccount = gce.ServiceAccount
+	}
+	for _, g := range []*dataproc.InstanceGroupConfig{c.Config.MasterConfig, c.Config.WorkerConfig, c.Config.SecondaryWorkerConfig} {
+		if g != nil {
+			nodes.Instances = append(nodes.Instances, g.InstanceNames...)
+		}
+	}
+	return nodes, nil
+}
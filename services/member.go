@@ -0,0 +1,84 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"regexp"
+	"strings"
+)
+
+// deletedPrefix is prepended by IAM to members whose account was deleted, i.e.
+// "deleted:user:tom@gmail.com?uid=123456789".
+const deletedPrefix = "deleted:"
+
+// NormalizeMember returns the member in the form used to compare members of IAM policies. Whitespace
+// is trimmed, the member is lower cased and the prefix and unique ID IAM adds to deleted members are
+// removed so "deleted:user:Tom@gmail.com?uid=123" matches "user:tom@gmail.com".
+func NormalizeMember(member string) string {
+	m := strings.ToLower(strings.TrimSpace(member))
+	if strings.HasPrefix(m, deletedPrefix) {
+		m = strings.TrimPrefix(m, deletedPrefix)
+		if i := strings.Index(m, "?uid="); i >= 0 {
+			m = m[:i]
+		}
+	}
+	return m
+}
+
+// DomainMatcher returns the expression matching normalized members from any of the domains, i.e.
+// "user:tom@example.com" for "example.com". Domains are matched literally and in full.
+func DomainMatcher(domains []string) *regexp.Regexp {
+	quoted := make([]string, len(domains))
+	for i, d := range domains {
+		quoted[i] = regexp.QuoteMeta(strings.ToLower(d))
+	}
+	return regexp.MustCompile("^.+@(?:" + strings.Join(quoted, "|") + ")$")
+}
+
+// memberSet returns a set of the normalized members so large member lists can be matched
+// against every binding of a policy without comparing each pair.
+func memberSet(members []string) map[string]bool {
+	set := make(map[string]bool, len(members))
+	for _, m := range members {
+		set[NormalizeMember(m)] = true
+	}
+	return set
+}
+
+// ExemptMembers returns members without those found in exempt. Members are compared after being normalized.
+func ExemptMembers(members, exempt []string) []string {
+	set := memberSet(exempt)
+	kept := []string{}
+	for _, m := range members {
+		if !set[NormalizeMember(m)] {
+			kept = append(kept, m)
+		}
+	}
+	return kept
+}
+
+// uniqueMembers returns members without duplicates, keeping their order.
+func uniqueMembers(members []string) []string {
+	seen := make(map[string]bool)
+	unique := []string{}
+	for _, m := range members {
+		if seen[m] {
+			continue
+		}
+		seen[m] = true
+		unique = append(unique, m)
+	}
+	return unique
+}
@@ -0,0 +1,94 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+	crm "google.golang.org/api/cloudresourcemanager/v1"
+)
+
+func TestNormalizeMember(t *testing.T) {
+	for _, tt := range []struct {
+		name, member, want string
+	}{
+		{name: "unchanged", member: "user:tom@gmail.com", want: "user:tom@gmail.com"},
+		{name: "case", member: "user:Tom@GMail.com", want: "user:tom@gmail.com"},
+		{name: "whitespace", member: " user:tom@gmail.com\n", want: "user:tom@gmail.com"},
+		{name: "deleted", member: "deleted:user:Tom@gmail.com?uid=123456789", want: "user:tom@gmail.com"},
+		{name: "deleted without uid", member: "deleted:serviceAccount:sa@p.iam.gserviceaccount.com", want: "serviceaccount:sa@p.iam.gserviceaccount.com"},
+		{name: "public", member: "allUsers", want: "allusers"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeMember(tt.member); got != tt.want {
+				t.Errorf("NormalizeMember(%q) = %q, want %q", tt.member, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDomainMatcher(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		domains []string
+		member  string
+		want    bool
+	}{
+		{name: "domain", domains: []string{"test.com"}, member: "user:tom@test.com", want: true},
+		{name: "case", domains: []string{"Test.com"}, member: "user:tom@test.com", want: true},
+		{name: "subdomain", domains: []string{"test.com"}, member: "user:tom@evil.test.com", want: false},
+		{name: "dot is literal", domains: []string{"test.com"}, member: "user:tom@testxcom", want: false},
+		{name: "metacharacters are literal", domains: []string{"t+.com"}, member: "user:tom@ttt.com", want: false},
+		{name: "middle domain anchored", domains: []string{"a.com", "b.com", "c.com"}, member: "user:x@evil.b.com.attacker", want: false},
+		{name: "middle domain", domains: []string{"a.com", "b.com", "c.com"}, member: "user:x@b.com", want: true},
+		{name: "no domains", member: "user:tom@test.com", want: false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DomainMatcher(tt.domains).MatchString(tt.member); got != tt.want {
+				t.Errorf("DomainMatcher(%q) matched %q: %t, want %t", tt.domains, tt.member, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRemoveDeletedMembers(t *testing.T) {
+	crmStub := &stubs.ResourceManagerStub{
+		GetPolicyResponse: &crm.Policy{Bindings: []*crm.Binding{
+			{Role: "roles/editor", Members: []string{"user:test@foo.com", "deleted:user:tom@gmail.com?uid=123"}},
+			{Role: "roles/viewer", Members: []string{" USER:tom@gmail.com"}},
+		}},
+	}
+	r := NewResource(crmStub, &stubs.StorageStub{})
+	present, err := r.PresentMembers(context.Background(), "projects/test-project", []string{"user:tom@gmail.com"})
+	if err != nil {
+		t.Fatalf("failed to get present members: %q", err)
+	}
+	if diff := cmp.Diff([]string{"deleted:user:tom@gmail.com?uid=123", " USER:tom@gmail.com"}, present); diff != "" {
+		t.Errorf("unexpected present members: %s", diff)
+	}
+	if _, err := r.RemoveUsersProject(context.Background(), "test-project", []string{"user:tom@gmail.com"}); err != nil {
+		t.Fatalf("failed to remove users: %q", err)
+	}
+	want := []*crm.Binding{
+		{Role: "roles/editor", Members: []string{"user:test@foo.com"}},
+		{Role: "roles/viewer", Members: []string{}},
+	}
+	if diff := cmp.Diff(want, crmStub.SavedSetPolicy.Bindings); diff != "" {
+		t.Errorf("unexpected bindings: %s", diff)
+	}
+}
@@ -0,0 +1,148 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"bytes"
+	"context"
+	htmltemplate "html/template"
+	"io"
+	"path"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/pkg/errors"
+)
+
+// Messages sent by the notifiers that can be replaced by a template.
+const (
+	// MessageSubject is the subject of emails and the title of PagerDuty incidents.
+	MessageSubject = "subject"
+	// MessageText is the plain text body of emails to security contacts and of PagerDuty incidents.
+	MessageText = "text"
+	// MessageSlack is the message posted to Slack in its mrkdwn format.
+	MessageSlack = "slack"
+	// MessageEmail is the HTML body of emails to the recipients of email channels.
+	MessageEmail = "email"
+)
+
+// templateExtension is the extension of the template objects.
+const templateExtension = ".tmpl"
+
+// templateFuncs are the functions available to templates besides the built-in ones.
+var templateFuncs = map[string]interface{}{
+	"join":  strings.Join,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+}
+
+// MessagesClient contains minimum interface required by the messages service.
+type MessagesClient interface {
+	ListObjects(context.Context, string, string) ([]string, error)
+	ReadObject(context.Context, string, string) ([]byte, error)
+}
+
+// executor is a parsed text or HTML template.
+type executor interface {
+	Execute(io.Writer, interface{}) error
+}
+
+// Messages service renders the messages of the notifiers from Go templates stored in a bucket, so
+// their wording can be customized or translated without changing the code.
+//
+// Templates are named after the message, i.e. "slack.tmpl" under the prefix, and can be
+// overridden for the findings of a category with "<category>/slack.tmpl". They are executed with
+// the WebhookEvent. The email template is an html/template, the others text/templates. Messages
+// without a template are the built-in ones.
+type Messages struct {
+	client MessagesClient
+	bucket string
+	prefix string
+
+	mu        sync.Mutex
+	templates map[string]executor
+}
+
+// NewMessages returns a messages service reading the templates under the prefix of the bucket.
+func NewMessages(client MessagesClient, bucket, prefix string) *Messages {
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	return &Messages{client: client, bucket: bucket, prefix: prefix}
+}
+
+// Render returns the message for the event from its template, preferring the one of the event's
+// category. It returns an empty string if the message has no template or m is nil, so the
+// built-in message is sent.
+func (m *Messages) Render(ctx context.Context, name string, event *WebhookEvent) (string, error) {
+	if m == nil {
+		return "", nil
+	}
+	templates, err := m.load(ctx)
+	if err != nil {
+		return "", err
+	}
+	t, ok := templates[path.Join(event.Category, name)]
+	if !ok {
+		if t, ok = templates[name]; !ok {
+			return "", nil
+		}
+	}
+	var b bytes.Buffer
+	if err := t.Execute(&b, event); err != nil {
+		return "", errors.Wrapf(err, "failed to render %q message of %q", name, event.ID)
+	}
+	return b.String(), nil
+}
+
+// load reads and parses the templates of the bucket the first time it's called.
+func (m *Messages) load(ctx context.Context) (map[string]executor, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.templates != nil {
+		return m.templates, nil
+	}
+	objects, err := m.client.ListObjects(ctx, m.bucket, m.prefix)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list message templates in %q", m.bucket)
+	}
+	templates := map[string]executor{}
+	for _, object := range objects {
+		if !strings.HasSuffix(object, templateExtension) {
+			continue
+		}
+		b, err := m.client.ReadObject(ctx, m.bucket, object)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read message template %q", object)
+		}
+		key := strings.TrimSuffix(strings.TrimPrefix(object, m.prefix), templateExtension)
+		t, err := parseMessageTemplate(key, string(b))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse message template %q", object)
+		}
+		templates[key] = t
+	}
+	m.templates = templates
+	return templates, nil
+}
+
+// parseMessageTemplate parses the template of the message named by key, i.e. "bad_ip/email".
+func parseMessageTemplate(key, text string) (executor, error) {
+	if path.Base(key) == MessageEmail {
+		return htmltemplate.New(key).Funcs(templateFuncs).Parse(text)
+	}
+	return template.New(key).Funcs(templateFuncs).Parse(text)
+}
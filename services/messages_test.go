@@ -0,0 +1,90 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"testing"
+
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+)
+
+func TestMessagesRender(t *testing.T) {
+	storageStub := &stubs.StorageStub{WrittenObjects: map[string][]byte{
+		"templates/templates/slack.tmpl":        []byte(`{{.Action}} sur {{.ProjectID}} : {{.Result}}`),
+		"templates/templates/bad_ip/slack.tmpl": []byte(`IP malveillante, {{.Action}} sur {{.ProjectID}}{{if .Runbook}} ({{.Runbook}}){{end}}`),
+		"templates/templates/email.tmpl":        []byte(`<p>{{.Error}}</p>`),
+		"templates/templates/README.md":         []byte(`{{ not a template`),
+		"templates/other/text.tmpl":             []byte(`outside the prefix`),
+	}}
+	m := NewMessages(storageStub, "templates", "templates")
+	for _, tt := range []struct {
+		name     string
+		message  string
+		event    *WebhookEvent
+		expected string
+	}{
+		{
+			name:     "default template",
+			message:  MessageSlack,
+			event:    &WebhookEvent{Action: "close_bucket", ProjectID: "test-project", Result: WebhookResultSuccess, Category: "public_bucket_acl"},
+			expected: "close_bucket sur test-project : success",
+		},
+		{
+			name:     "category template",
+			message:  MessageSlack,
+			event:    &WebhookEvent{Action: "remove_public_ip", ProjectID: "test-project", Category: "bad_ip", Runbook: "https://wiki.example.com/bad-ip"},
+			expected: "IP malveillante, remove_public_ip sur test-project (https://wiki.example.com/bad-ip)",
+		},
+		{
+			name:     "html escaped",
+			message:  MessageEmail,
+			event:    &WebhookEvent{Error: "<denied>"},
+			expected: "<p>&lt;denied&gt;</p>",
+		},
+		{
+			name:    "no template",
+			message: MessageText,
+			event:   &WebhookEvent{Action: "close_bucket"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := m.Render(context.Background(), tt.message, tt.event)
+			if err != nil {
+				t.Fatalf("%s failed: %q", tt.name, err)
+			}
+			if got != tt.expected {
+				t.Errorf("%s got %q want %q", tt.name, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSlackNotifierTemplate(t *testing.T) {
+	storageStub := &stubs.StorageStub{WrittenObjects: map[string][]byte{
+		"templates/slack.tmpl": []byte(`{{upper .Action}}: {{.Runbook}}`),
+	}}
+	stub := &stubs.WebhookStub{}
+	mux := NewNotifierMux(map[string]string{"bad_ip": "https://wiki.example.com/bad-ip"})
+	mux.Add("slack", NewSlackNotifier(stub, "https://hooks.slack.com/services/T/B/X", NewMessages(storageStub, "templates", "")), NotifierFilter{})
+	event := &WebhookEvent{ID: "event-1", Action: "remove_public_ip", Category: "bad_ip"}
+	if err := mux.Notify(context.Background(), event); err != nil {
+		t.Fatalf("failed to notify: %q", err)
+	}
+	want := `{"text":"REMOVE_PUBLIC_IP: https://wiki.example.com/bad-ip"}`
+	if string(stub.SavedBody) != want {
+		t.Errorf("unexpected body: got %s, want %s", stub.SavedBody, want)
+	}
+}
@@ -0,0 +1,95 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// Changes made to a notification config or topic.
+const (
+	NotificationCreated   = "created"
+	NotificationUpdated   = "updated"
+	NotificationUnchanged = "unchanged"
+)
+
+// notificationDescription describes the notification configs managed by SRA.
+const notificationDescription = "Findings routed to Security Response Automation."
+
+// NotificationConfigClient contains minimum interface required by the notifications service.
+type NotificationConfigClient interface {
+	GetNotificationConfig(context.Context, string) (string, string, error)
+	CreateNotificationConfig(context.Context, string, string, string, string, string) error
+	UpdateNotificationConfig(context.Context, string, string, string, string) error
+}
+
+// TopicClient contains minimum interface required to manage the topics of notifications.
+type TopicClient interface {
+	TopicExists(context.Context, string) (bool, error)
+	CreateTopic(context.Context, string) error
+}
+
+// Notifications service.
+type Notifications struct {
+	configs NotificationConfigClient
+	topics  TopicClient
+}
+
+// NewNotifications returns a notifications service.
+func NewNotifications(configs NotificationConfigClient, topics TopicClient) *Notifications {
+	return &Notifications{configs: configs, topics: topics}
+}
+
+// EnsureTopic creates the topic unless it exists.
+func (n *Notifications) EnsureTopic(ctx context.Context, topicID string) (string, error) {
+	ok, err := n.topics.TopicExists(ctx, topicID)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to check if topic %q exists", topicID)
+	}
+	if ok {
+		return NotificationUnchanged, nil
+	}
+	if err := n.topics.CreateTopic(ctx, topicID); err != nil {
+		return "", errors.Wrapf(err, "failed to create topic %q", topicID)
+	}
+	return NotificationCreated, nil
+}
+
+// EnsureNotificationConfig creates the organization's notification config publishing findings
+// matching the filter to the topic of the project, or updates it if its topic or filter differ.
+func (n *Notifications) EnsureNotificationConfig(ctx context.Context, orgID, configID, projectID, topicID, filter string) (string, error) {
+	parent := "organizations/" + orgID
+	name := fmt.Sprintf("%s/notificationConfigs/%s", parent, configID)
+	topic := fmt.Sprintf("projects/%s/topics/%s", projectID, topicID)
+	t, f, err := n.configs.GetNotificationConfig(ctx, name)
+	switch {
+	case IsNotFound(err):
+		if err := n.configs.CreateNotificationConfig(ctx, parent, configID, notificationDescription, topic, filter); err != nil {
+			return "", errors.Wrapf(err, "failed to create notification config %q", name)
+		}
+		return NotificationCreated, nil
+	case err != nil:
+		return "", errors.Wrapf(err, "failed to get notification config %q", name)
+	case t == topic && f == filter:
+		return NotificationUnchanged, nil
+	}
+	if err := n.configs.UpdateNotificationConfig(ctx, name, notificationDescription, topic, filter); err != nil {
+		return "", errors.Wrapf(err, "failed to update notification config %q", name)
+	}
+	return NotificationUpdated, nil
+}
@@ -0,0 +1,93 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+)
+
+func TestEnsureNotificationConfig(t *testing.T) {
+	const name = "organizations/123/notificationConfigs/sra-sha"
+	const topic = "projects/automation/topics/threat-findings"
+	tests := []struct {
+		name     string
+		existing map[string]*stubs.StubbedNotificationConfig
+		filter   string
+		expected string
+	}{
+		{
+			name:     "creates missing config",
+			filter:   `state = "ACTIVE"`,
+			expected: NotificationCreated,
+		},
+		{
+			name:     "updates changed filter",
+			existing: map[string]*stubs.StubbedNotificationConfig{name: {Topic: topic, Filter: `state = "ACTIVE"`}},
+			filter:   `state = "ACTIVE" AND category = "OPEN_FIREWALL"`,
+			expected: NotificationUpdated,
+		},
+		{
+			name:     "leaves matching config",
+			existing: map[string]*stubs.StubbedNotificationConfig{name: {Description: "custom", Topic: topic, Filter: `state = "ACTIVE"`}},
+			filter:   `state = "ACTIVE"`,
+			expected: NotificationUnchanged,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configs := &stubs.NotificationConfigsStub{Configs: tt.existing}
+			n := NewNotifications(configs, &stubs.PubSubStub{})
+			got, err := n.EnsureNotificationConfig(context.Background(), "123", "sra-sha", "automation", "threat-findings", tt.filter)
+			if err != nil {
+				t.Fatalf("%s failed: %q", tt.name, err)
+			}
+			if got != tt.expected {
+				t.Errorf("%s failed: got %q, expected %q", tt.name, got, tt.expected)
+			}
+			c := configs.Configs[name]
+			if diff := cmp.Diff([]string{topic, tt.filter}, []string{c.Topic, c.Filter}); diff != "" {
+				t.Errorf("%s failed, difference: %v", tt.name, diff)
+			}
+		})
+	}
+}
+
+func TestEnsureTopic(t *testing.T) {
+	ps := &stubs.PubSubStub{ExistingTopics: map[string]bool{"threat-findings": true}}
+	n := NewNotifications(&stubs.NotificationConfigsStub{}, ps)
+	for _, tt := range []struct {
+		topic    string
+		expected string
+	}{
+		{topic: "threat-findings", expected: NotificationUnchanged},
+		{topic: "scc-findings", expected: NotificationCreated},
+		{topic: "scc-findings", expected: NotificationUnchanged},
+	} {
+		got, err := n.EnsureTopic(context.Background(), tt.topic)
+		if err != nil {
+			t.Fatalf("failed to ensure topic %q: %q", tt.topic, err)
+		}
+		if got != tt.expected {
+			t.Errorf("topic %q: got %q, expected %q", tt.topic, got, tt.expected)
+		}
+	}
+	if diff := cmp.Diff([]string{"scc-findings"}, ps.CreatedTopics); diff != "" {
+		t.Errorf("created topics difference: %v", diff)
+	}
+}
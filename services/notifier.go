@@ -0,0 +1,104 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Notifier sends the outcome of an automation to a notification channel.
+type Notifier interface {
+	Notify(context.Context, *WebhookEvent) error
+}
+
+// NotifierFilter selects the events sent to a channel. Empty lists match every event.
+type NotifierFilter struct {
+	// Severities of the findings sent, i.e. "HIGH". Events without a severity never match.
+	Severities []string `json:"severities"`
+	// Categories are the rules the findings were routed by, i.e. "bad_ip". Events without a
+	// category never match.
+	Categories []string `json:"categories"`
+}
+
+// Matches returns whether the event should be sent to the channel.
+func (f NotifierFilter) Matches(event *WebhookEvent) bool {
+	return matchesAny(f.Severities, event.Severity) && matchesAny(f.Categories, event.Category)
+}
+
+func matchesAny(values []string, value string) bool {
+	if len(values) == 0 {
+		return true
+	}
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// notifierChannel is a notifier along with the events it is sent.
+type notifierChannel struct {
+	name     string
+	notifier Notifier
+	filter   NotifierFilter
+}
+
+// NotifierMux fans out events to each channel whose filter matches.
+type NotifierMux struct {
+	channels []notifierChannel
+	runbooks map[string]string
+}
+
+// NewNotifierMux returns a multiplexer without channels. Events are sent with the link to the
+// runbook of their category, as keyed in runbooks, i.e. "bad_ip".
+func NewNotifierMux(runbooks map[string]string) *NotifierMux {
+	return &NotifierMux{runbooks: runbooks}
+}
+
+// Add sends the events matching the filter to the notifier. The name identifies the channel in errors.
+func (m *NotifierMux) Add(name string, n Notifier, f NotifierFilter) {
+	m.channels = append(m.channels, notifierChannel{name: name, notifier: n, filter: f})
+}
+
+// Len returns the number of channels.
+func (m *NotifierMux) Len() int {
+	return len(m.channels)
+}
+
+// Notify sends the event to each channel whose filter matches. A failing channel does not stop the
+// event being sent to the others, the channels that failed are returned in a single error.
+func (m *NotifierMux) Notify(ctx context.Context, event *WebhookEvent) error {
+	if event.Runbook == "" {
+		event.Runbook = m.runbooks[event.Category]
+	}
+	var failed []string
+	for _, c := range m.channels {
+		if !c.filter.Matches(event) {
+			continue
+		}
+		if err := c.notifier.Notify(ctx, event); err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %s", c.name, err))
+		}
+	}
+	if len(failed) > 0 {
+		return errors.Errorf("failed to notify %d channels: %s", len(failed), strings.Join(failed, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+)
+
+func TestNotifierMux(t *testing.T) {
+	tests := []struct {
+		name          string
+		event         *WebhookEvent
+		filter        NotifierFilter
+		expectedSent  bool
+		channelErr    error
+		expectedError bool
+	}{
+		{
+			name:         "no filter",
+			event:        &WebhookEvent{ID: "event-1", Action: "close_bucket"},
+			expectedSent: true,
+		},
+		{
+			name:         "matching severity and category",
+			event:        &WebhookEvent{ID: "event-1", Severity: "HIGH", Category: "bad_ip"},
+			filter:       NotifierFilter{Severities: []string{"high", "critical"}, Categories: []string{"bad_ip"}},
+			expectedSent: true,
+		},
+		{
+			name:   "other severity",
+			event:  &WebhookEvent{ID: "event-1", Severity: "LOW", Category: "bad_ip"},
+			filter: NotifierFilter{Severities: []string{"HIGH"}},
+		},
+		{
+			name:   "no category",
+			event:  &WebhookEvent{ID: "event-1", Severity: "HIGH"},
+			filter: NotifierFilter{Categories: []string{"bad_ip"}},
+		},
+		{
+			name:          "failing channel",
+			event:         &WebhookEvent{ID: "event-1"},
+			expectedSent:  true,
+			channelErr:    errors.New("unavailable"),
+			expectedError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			failing := &stubs.WebhookStub{StubbedErr: tt.channelErr}
+			filtered := &stubs.WebhookStub{}
+			mux := NewNotifierMux(nil)
+			mux.Add("first", NewSlackNotifier(failing, "https://hooks.slack.com/first", nil), NotifierFilter{})
+			mux.Add("second", NewSlackNotifier(filtered, "https://hooks.slack.com/second", nil), tt.filter)
+			err := mux.Notify(context.Background(), tt.event)
+			if (err != nil) != tt.expectedError {
+				t.Errorf("%s failed: got error %v, want error %t", tt.name, err, tt.expectedError)
+			}
+			if err != nil && !strings.Contains(err.Error(), "first") {
+				t.Errorf("%s failed: error %q does not name the failing channel", tt.name, err)
+			}
+			if sent := filtered.SavedURL != ""; sent != tt.expectedSent {
+				t.Errorf("%s failed: got sent %t, want %t", tt.name, sent, tt.expectedSent)
+			}
+		})
+	}
+}
+
+func TestSlackNotifierNotify(t *testing.T) {
+	stub := &stubs.WebhookStub{}
+	n := NewSlackNotifier(stub, "https://hooks.slack.com/services/T/B/X", nil)
+	event := &WebhookEvent{ID: "event-1", Action: "close_bucket", ProjectID: "test-project", Result: WebhookResultSuccess, Severity: "HIGH"}
+	if err := n.Notify(context.Background(), event); err != nil {
+		t.Fatalf("failed to notify: %q", err)
+	}
+	want := `{"text":"*Security Response Automation: close_bucket* on ` + "`test-project`" + `: success\nSeverity: HIGH\nEvent ID: event-1"}`
+	if string(stub.SavedBody) != want {
+		t.Errorf("unexpected body: got %s, want %s", stub.SavedBody, want)
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/PagerDuty/go-pagerduty"
+	"github.com/pkg/errors"
 )
 
 // PagerDuty service.
@@ -42,3 +43,29 @@ func (p *PagerDuty) CreateIncident(ctx context.Context, from, serviceID, title,
 	}
 	return nil
 }
+
+// PagerDutyNotifier opens a PagerDuty incident on a service each time an automation runs.
+type PagerDutyNotifier struct {
+	pagerDuty *PagerDuty
+	from      string
+	serviceID string
+	messages  *Messages
+}
+
+// NewPagerDutyNotifier returns a notifier opening incidents on the service as the given user.
+// Messages can be nil to open incidents with the built-in title and description.
+func NewPagerDutyNotifier(p *PagerDuty, from, serviceID string, messages *Messages) *PagerDutyNotifier {
+	return &PagerDutyNotifier{pagerDuty: p, from: from, serviceID: serviceID, messages: messages}
+}
+
+// Notify opens an incident describing the event.
+func (n *PagerDutyNotifier) Notify(ctx context.Context, event *WebhookEvent) error {
+	title, body, err := renderText(ctx, n.messages, event)
+	if err != nil {
+		return err
+	}
+	if err := n.pagerDuty.CreateIncident(ctx, n.from, n.serviceID, title, body); err != nil {
+		return errors.Wrapf(err, "failed to open incident for %q", event.ID)
+	}
+	return nil
+}
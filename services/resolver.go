@@ -0,0 +1,170 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Kinds of resources a finding may reference.
+const (
+	KindOrganization = "organization"
+	KindFolder       = "folder"
+	KindProject      = "project"
+	KindInstance     = "instance"
+	KindFirewall     = "firewall"
+	KindBucket       = "bucket"
+	KindDataset      = "dataset"
+	KindCluster      = "cluster"
+	KindSQLInstance  = "sql_instance"
+	// KindForwardingRule is a regional or global forwarding rule of a load balancer.
+	KindForwardingRule = "forwarding_rule"
+)
+
+var (
+	// projectNumber matches project numbers, as opposed to project IDs which must start with a letter.
+	projectNumber = regexp.MustCompile(`^[0-9]+$`)
+	// numberedProject matches ancestry patterns naming a project by its number.
+	numberedProject = regexp.MustCompile(`projects/[0-9]+(\s|$)`)
+)
+
+// TypedResource is a resource referenced by a finding.
+type TypedResource struct {
+	Kind string
+	// ProjectID is the ID of the project containing the resource, or of the project itself.
+	ProjectID string
+	// Zone is set for zonal resources such as instances and clusters.
+	Zone string
+	// Region is set for regional resources such as forwarding rules.
+	Region string
+	// Name is the name or ID of the resource within its project, i.e. the instance name. For
+	// organizations and folders it's their numerical ID.
+	Name string
+}
+
+// Resolver normalizes the resource names used by findings into typed resources.
+type Resolver struct {
+	resource *Resource
+}
+
+// NewResolver returns a resolver service.
+func NewResolver(r *Resource) *Resolver {
+	return &Resolver{resource: r}
+}
+
+// Resolve parses the resource name into a typed resource. Full resource names
+// ("//compute.googleapis.com/projects/p/zones/z/instances/i"), self-links
+// ("https://www.googleapis.com/compute/v1/projects/p/...") and relative names ("projects/123") are
+// accepted. Project numbers are translated to project IDs using Cloud Resource Manager.
+func (r *Resolver) Resolve(ctx context.Context, name string) (*TypedResource, error) {
+	service, path := splitResourceName(name)
+	if service == "storage" {
+		bucket := strings.Split(path, "/")[0]
+		if bucket == "" {
+			return nil, errors.Errorf("no bucket in resource name %q", name)
+		}
+		return &TypedResource{Kind: KindBucket, Name: bucket}, nil
+	}
+	if projectNumber.MatchString(path) {
+		path = "projects/" + path
+	}
+	parts := []string{}
+	for _, p := range strings.Split(path, "/") {
+		// Global resources have no location, i.e. "projects/p/global/firewalls/f".
+		if p != "global" {
+			parts = append(parts, p)
+		}
+	}
+	segments := map[string]string{}
+	last := ""
+	for i := 0; i+1 < len(parts); i += 2 {
+		segments[parts[i]] = parts[i+1]
+		last = parts[i]
+	}
+	res := &TypedResource{Zone: segments["zones"], Region: segments["regions"]}
+	switch {
+	case last == "organizations":
+		return &TypedResource{Kind: KindOrganization, Name: segments[last]}, nil
+	case last == "folders":
+		return &TypedResource{Kind: KindFolder, Name: segments[last]}, nil
+	case last == "projects":
+		res.Kind = KindProject
+	case last == "instances" && (service == "cloudsql" || service == "sqladmin"):
+		res.Kind = KindSQLInstance
+	case last == "instances":
+		res.Kind = KindInstance
+	case last == "firewalls":
+		res.Kind = KindFirewall
+	case last == "forwardingRules":
+		res.Kind = KindForwardingRule
+	case last == "datasets":
+		res.Kind = KindDataset
+	case last == "clusters":
+		res.Kind = KindCluster
+		if res.Zone == "" {
+			res.Zone = segments["locations"]
+		}
+	default:
+		return nil, errors.Errorf("unsupported resource name %q", name)
+	}
+	if last != "projects" {
+		res.Name = segments[last]
+	}
+	if segments["projects"] == "" {
+		return nil, errors.Errorf("no project in resource name %q", name)
+	}
+	projectID, err := r.resource.ProjectID(ctx, segments["projects"])
+	if err != nil {
+		return nil, err
+	}
+	res.ProjectID = projectID
+	return res, nil
+}
+
+// splitResourceName returns the short service name, i.e. "compute", and the relative path of
+// the resource name. Relative names have no service.
+func splitResourceName(name string) (string, string) {
+	switch {
+	case strings.HasPrefix(name, "//"):
+		s := strings.SplitN(strings.TrimPrefix(name, "//"), "/", 2)
+		if len(s) < 2 {
+			return serviceName(s[0]), ""
+		}
+		return serviceName(s[0]), s[1]
+	case strings.HasPrefix(name, "https://"):
+		// Self-links include the API in the path: "https://www.googleapis.com/compute/v1/projects/p".
+		s := strings.SplitN(strings.TrimPrefix(name, "https://"), "/", 4)
+		if len(s) < 4 {
+			return "", ""
+		}
+		if s[0] != "www.googleapis.com" {
+			return serviceName(s[0]), s[3]
+		}
+		if s[1] == "storage" {
+			return s[1], strings.TrimPrefix(s[3], "b/")
+		}
+		return s[1], s[3]
+	default:
+		return "", name
+	}
+}
+
+func serviceName(host string) string {
+	return strings.TrimSuffix(host, ".googleapis.com")
+}
@@ -0,0 +1,118 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+	crm "google.golang.org/api/cloudresourcemanager/v1"
+)
+
+func TestResolve(t *testing.T) {
+	crmStub := &stubs.ResourceManagerStub{
+		GetProjectResponse: map[string]*crm.Project{"459837319394": {ProjectId: "onboarding-project", ProjectNumber: 459837319394}},
+	}
+	r := NewResolver(NewResource(crmStub, &stubs.StorageStub{}))
+	for _, tt := range []struct {
+		name     string
+		resource string
+		want     *TypedResource
+		wantErr  bool
+	}{
+		{
+			name:     "project number",
+			resource: "//cloudresourcemanager.googleapis.com/projects/459837319394",
+			want:     &TypedResource{Kind: KindProject, ProjectID: "onboarding-project"},
+		},
+		{
+			name:     "bare project number",
+			resource: "459837319394",
+			want:     &TypedResource{Kind: KindProject, ProjectID: "onboarding-project"},
+		},
+		{
+			name:     "organization",
+			resource: "//cloudresourcemanager.googleapis.com/organizations/456",
+			want:     &TypedResource{Kind: KindOrganization, Name: "456"},
+		},
+		{
+			name:     "instance",
+			resource: "//compute.googleapis.com/projects/459837319394/zones/us-central1-a/instances/instance-1",
+			want:     &TypedResource{Kind: KindInstance, ProjectID: "onboarding-project", Zone: "us-central1-a", Name: "instance-1"},
+		},
+		{
+			name:     "regional forwarding rule",
+			resource: "//compute.googleapis.com/projects/test-project/regions/us-central1/forwardingRules/web",
+			want:     &TypedResource{Kind: KindForwardingRule, ProjectID: "test-project", Region: "us-central1", Name: "web"},
+		},
+		{
+			name:     "instance self-link",
+			resource: "https://www.googleapis.com/compute/v1/projects/test-project/zones/us-central1-a/instances/instance-1",
+			want:     &TypedResource{Kind: KindInstance, ProjectID: "test-project", Zone: "us-central1-a", Name: "instance-1"},
+		},
+		{
+			name:     "firewall",
+			resource: "//compute.googleapis.com/projects/onboarding-project/global/firewalls/6190685430815455733",
+			want:     &TypedResource{Kind: KindFirewall, ProjectID: "onboarding-project", Name: "6190685430815455733"},
+		},
+		{
+			name:     "bucket",
+			resource: "//storage.googleapis.com/this-is-public-on-purpose",
+			want:     &TypedResource{Kind: KindBucket, Name: "this-is-public-on-purpose"},
+		},
+		{
+			name:     "bucket self-link",
+			resource: "https://www.googleapis.com/storage/v1/b/this-is-public-on-purpose",
+			want:     &TypedResource{Kind: KindBucket, Name: "this-is-public-on-purpose"},
+		},
+		{
+			name:     "sql instance",
+			resource: "//cloudsql.googleapis.com/projects/sha-resources-20191002/instances/public-sql-instance",
+			want:     &TypedResource{Kind: KindSQLInstance, ProjectID: "sha-resources-20191002", Name: "public-sql-instance"},
+		},
+		{
+			name:     "dataset",
+			resource: "//bigquery.googleapis.com/projects/test-project/datasets/public_dataset123",
+			want:     &TypedResource{Kind: KindDataset, ProjectID: "test-project", Name: "public_dataset123"},
+		},
+		{
+			name:     "cluster",
+			resource: "//container.googleapis.com/projects/test-project/zones/us-central1-a/clusters/cluster-1",
+			want:     &TypedResource{Kind: KindCluster, ProjectID: "test-project", Zone: "us-central1-a", Name: "cluster-1"},
+		},
+		{
+			name:     "unknown project number",
+			resource: "//cloudresourcemanager.googleapis.com/projects/1234",
+			wantErr:  true,
+		},
+		{
+			name:     "unsupported resource",
+			resource: "//pubsub.googleapis.com/projects/test-project/topics/topic-1",
+			wantErr:  true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.Resolve(context.Background(), tt.resource)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("%s failed: got err %v, want err %t", tt.name, err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("%s failed, difference: %v", tt.name, diff)
+			}
+		})
+	}
+}
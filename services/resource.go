@@ -19,86 +19,573 @@ import (
 	"fmt"
 	"log"
 	"regexp"
+	"strconv"
 	"strings"
+	"sync"
 
 	"cloud.google.com/go/iam"
+	"cloud.google.com/go/storage"
 	"github.com/pkg/errors"
 	crm "google.golang.org/api/cloudresourcemanager/v1"
+	crmv2 "google.golang.org/api/cloudresourcemanager/v2"
 )
 
 type crmClient interface {
 	GetAncestry(context.Context, string) (*crm.GetAncestryResponse, error)
+	GetProject(context.Context, string) (*crm.Project, error)
 	SetPolicyProject(context.Context, string, *crm.Policy) (*crm.Policy, error)
 	GetPolicyProject(context.Context, string) (*crm.Policy, error)
 	GetPolicyOrganization(context.Context, string) (*crm.Policy, error)
 	SetPolicyOrganization(context.Context, string, *crm.Policy) (*crm.Policy, error)
 	GetOrganization(context.Context, string) (*crm.Organization, error)
 	SetPolicyProjectWithMask(context.Context, string, *crm.Policy, ...string) (*crm.Policy, error)
+	GetPolicyFolder(context.Context, string) (*crmv2.Policy, error)
+	SetPolicyFolder(context.Context, string, *crmv2.Policy) (*crmv2.Policy, error)
+	GetFolder(context.Context, string) (*crmv2.Folder, error)
+	TestPermissionsProject(context.Context, string, []string) ([]string, error)
 }
 
 type storageClient interface {
 	SetBucketPolicy(context.Context, string, *iam.Policy) error
 	BucketPolicy(context.Context, string) (*iam.Policy, error)
 	EnableBucketOnlyPolicy(context.Context, string) error
+	EnableBucketLogging(context.Context, string, string, string) error
+	EnableBucketVersioning(context.Context, string) error
+	BucketAttrs(context.Context, string) (*storage.BucketAttrs, error)
+	ListObjects(context.Context, string, string) ([]string, error)
+	ListObjectsUpTo(context.Context, string, string, int) ([]string, bool, error)
+	ObjectACL(context.Context, string, string) ([]storage.ACLRule, error)
+	DeleteObjectACL(context.Context, string, string, storage.ACLEntity) error
+	SetBucketLabel(context.Context, string, string, string) error
+	SetBucketDefaultKMSKey(context.Context, string, string) error
 }
 
 // Resource service.
 type Resource struct {
 	crm     crmClient
 	storage storageClient
+	mu      sync.Mutex
+	// projectIDs and projectNumbers cache the projects looked up, keyed by number and ID.
+	projectIDs     map[string]string
+	projectNumbers map[string]string
 }
 
 // NewResource returns a new resource service.
 func NewResource(crm crmClient, s storageClient) *Resource {
 	return &Resource{
-		crm:     crm,
-		storage: s,
+		crm:            crm,
+		storage:        s,
+		projectIDs:     make(map[string]string),
+		projectNumbers: make(map[string]string),
 	}
 }
 
-// ProjectOnlyKeepUsersFromDomains removes users from the policy if they do not match the domain. (Non-users are not affected.)
-func (r *Resource) ProjectOnlyKeepUsersFromDomains(ctx context.Context, projectID string, allowDomains []string) ([]string, error) {
+// ProjectID returns the ID of the project given either its ID or number.
+func (r *Resource) ProjectID(ctx context.Context, project string) (string, error) {
+	if !projectNumber.MatchString(project) {
+		return project, nil
+	}
+	r.mu.Lock()
+	id, ok := r.projectIDs[project]
+	r.mu.Unlock()
+	if ok {
+		return id, nil
+	}
+	id, _, err := r.lookupProject(ctx, project)
+	return id, err
+}
+
+// ProjectNumber returns the number of the project given either its ID or number.
+func (r *Resource) ProjectNumber(ctx context.Context, project string) (string, error) {
+	if project == "" || projectNumber.MatchString(project) {
+		return project, nil
+	}
+	r.mu.Lock()
+	number, ok := r.projectNumbers[project]
+	r.mu.Unlock()
+	if ok {
+		return number, nil
+	}
+	_, number, err := r.lookupProject(ctx, project)
+	return number, err
+}
+
+// lookupProject returns the ID and number of the project, caching both.
+func (r *Resource) lookupProject(ctx context.Context, project string) (string, string, error) {
+	p, err := r.crm.GetProject(ctx, project)
+	if err != nil {
+		return "", "", errors.Wrapf(err, "failed to get project %q", project)
+	}
+	number := strconv.FormatInt(p.ProjectNumber, 10)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.projectIDs[number] = p.ProjectId
+	r.projectNumbers[p.ProjectId] = number
+	return p.ProjectId, number, nil
+}
+
+// ProjectOnlyKeepUsersFromDomains removes users from the policy if they do not match the domain
+// or the allowed members. (Non-users are not affected.) The policy is not written if there are no
+// users to remove. The removed users and the changes made to the policy are returned.
+func (r *Resource) ProjectOnlyKeepUsersFromDomains(ctx context.Context, projectID string, allowDomains, allowMembers []string) ([]string, []BindingChange, error) {
 	existingPolicy, err := r.crm.GetPolicyProject(ctx, projectID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get project policy: %q", err)
+		return nil, nil, errors.Wrap(err, "failed to get project policy")
 	}
-	removed, policy, err := r.keepUsersFromPolicy(existingPolicy, allowDomains)
+	before := bindingMembers(existingPolicy.Bindings)
+	removed, policy, err := r.keepUsersFromPolicy(existingPolicy, allowDomains, allowMembers)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	if len(removed) == 0 {
+		return removed, nil, nil
 	}
 	if _, err := r.crm.SetPolicyProject(ctx, projectID, policy); err != nil {
-		return nil, fmt.Errorf("failed to set project policy: %q", err)
+		return nil, nil, errors.Wrap(err, "failed to set project policy")
 	}
-	return removed, nil
+	return removed, policyChanges(before, bindingMembers(policy.Bindings)), nil
 }
 
-// OrganizationOnlyKeepUsersFromDomains removes all users from an organization except where the user matches allowed domains.
-func (r *Resource) OrganizationOnlyKeepUsersFromDomains(ctx context.Context, orgID string, allowDomains []string) ([]string, error) {
+// OrganizationOnlyKeepUsersFromDomains removes all users from an organization except where the user matches allowed domains
+// or is one of the allowed members.
+func (r *Resource) OrganizationOnlyKeepUsersFromDomains(ctx context.Context, orgID string, allowDomains, allowMembers []string) ([]string, error) {
 	existingPolicy, err := r.crm.GetPolicyOrganization(ctx, orgID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get project policy: %q", err)
+		return nil, errors.Wrap(err, "failed to get project policy")
 	}
-	removed, policy, err := r.keepUsersFromPolicy(existingPolicy, allowDomains)
+	removed, policy, err := r.keepUsersFromPolicy(existingPolicy, allowDomains, allowMembers)
 	if err != nil {
 		return nil, err
 	}
 	if _, err := r.crm.SetPolicyOrganization(ctx, orgID, policy); err != nil {
-		return nil, fmt.Errorf("failed to set project policy: %q", err)
+		return nil, errors.Wrap(err, "failed to set project policy")
 	}
 	return removed, nil
 }
 
-// RemoveUsersProject removes a slice of users from a project.
-func (r *Resource) RemoveUsersProject(ctx context.Context, projectID string, remove []string) error {
+// RemoveUsersProject removes a slice of users from a project and returns the changes made to the policy.
+func (r *Resource) RemoveUsersProject(ctx context.Context, projectID string, remove []string) ([]BindingChange, error) {
 	existingPolicy, err := r.crm.GetPolicyProject(ctx, projectID)
 	if err != nil {
-		return fmt.Errorf("failed to get project policy: %q", err)
+		return nil, errors.Wrap(err, "failed to get project policy")
 	}
+	before := bindingMembers(existingPolicy.Bindings)
 	policy := r.removeUsersFromPolicy(existingPolicy, remove)
 	if _, err := r.crm.SetPolicyProject(ctx, projectID, policy); err != nil {
-		return fmt.Errorf("failed to set project policy: %q", err)
+		return nil, errors.Wrap(err, "failed to set project policy")
 	}
-	return nil
+	return policyChanges(before, bindingMembers(policy.Bindings)), nil
+}
+
+// RemoveBindingsProject removes members from the roles of a project's policy they were bound to by
+// the given bindings and returns the changes made. The policy is not written if none of the
+// bindings are in it.
+func (r *Resource) RemoveBindingsProject(ctx context.Context, projectID string, bindings []BindingChange) ([]BindingChange, error) {
+	existingPolicy, err := r.crm.GetPolicyProject(ctx, projectID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get project policy")
+	}
+	before := bindingMembers(existingPolicy.Bindings)
+	remove := map[string]map[string]bool{}
+	for _, b := range bindings {
+		if remove[b.Role] == nil {
+			remove[b.Role] = map[string]bool{}
+		}
+		remove[b.Role][NormalizeMember(b.Member)] = true
+	}
+	for _, b := range existingPolicy.Bindings {
+		members := []string{}
+		for _, member := range b.Members {
+			if !remove[b.Role][NormalizeMember(member)] {
+				members = append(members, member)
+			}
+		}
+		b.Members = members
+	}
+	changes := policyChanges(before, bindingMembers(existingPolicy.Bindings))
+	if len(changes) == 0 {
+		return changes, nil
+	}
+	if _, err := r.crm.SetPolicyProject(ctx, projectID, existingPolicy); err != nil {
+		return nil, errors.Wrap(err, "failed to set project policy")
+	}
+	return changes, nil
+}
+
+// RemoveUsersOrganization removes a slice of users from an organization and returns the users
+// that were found in the policy. The policy is not written if none of the users were found.
+func (r *Resource) RemoveUsersOrganization(ctx context.Context, orgName string, remove []string) ([]string, error) {
+	existingPolicy, err := r.crm.GetPolicyOrganization(ctx, orgName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get organization policy")
+	}
+	found := []string{}
+	for _, b := range existingPolicy.Bindings {
+		found = append(found, matchingMembers(b.Members, remove)...)
+	}
+	if len(found) == 0 {
+		return found, nil
+	}
+	policy := r.removeUsersFromPolicy(existingPolicy, remove)
+	if _, err := r.crm.SetPolicyOrganization(ctx, orgName, policy); err != nil {
+		return nil, errors.Wrap(err, "failed to set organization policy")
+	}
+	return uniqueMembers(found), nil
+}
+
+// RemoveUsersFolder removes a slice of users from a folder and returns the users that were
+// found in the policy. The policy is not written if none of the users were found.
+func (r *Resource) RemoveUsersFolder(ctx context.Context, folderName string, remove []string) ([]string, error) {
+	policy, err := r.crm.GetPolicyFolder(ctx, folderName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get folder policy")
+	}
+	found := []string{}
+	for _, b := range policy.Bindings {
+		matched := matchingMembers(b.Members, remove)
+		if len(matched) == 0 {
+			continue
+		}
+		found = append(found, matched...)
+		set := memberSet(matched)
+		members := []string{}
+		for _, member := range b.Members {
+			if !set[NormalizeMember(member)] {
+				members = append(members, member)
+			}
+		}
+		b.Members = members
+	}
+	if len(found) == 0 {
+		return found, nil
+	}
+	if _, err := r.crm.SetPolicyFolder(ctx, folderName, policy); err != nil {
+		return nil, errors.Wrap(err, "failed to set folder policy")
+	}
+	return uniqueMembers(found), nil
+}
+
+// RemoveMemberBindings removes the member from every role of the IAM policy of a project, folder or
+// organization resource, i.e. "projects/p", "folders/123" or "organizations/456", and returns the
+// bindings removed. The policy is not written if the member has no roles on the resource.
+func (r *Resource) RemoveMemberBindings(ctx context.Context, resource, member string) ([]BindingChange, error) {
+	removed := []BindingChange{}
+	switch {
+	case strings.HasPrefix(resource, "projects/"):
+		projectID := strings.TrimPrefix(resource, "projects/")
+		p, err := r.crm.GetPolicyProject(ctx, projectID)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get project policy")
+		}
+		for _, b := range p.Bindings {
+			var changes []BindingChange
+			b.Members, changes = withoutMember(b.Role, b.Members, member)
+			removed = append(removed, changes...)
+		}
+		if len(removed) == 0 {
+			return removed, nil
+		}
+		if _, err := r.crm.SetPolicyProject(ctx, projectID, p); err != nil {
+			return nil, errors.Wrap(err, "failed to set project policy")
+		}
+	case strings.HasPrefix(resource, "organizations/"):
+		p, err := r.crm.GetPolicyOrganization(ctx, resource)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get organization policy")
+		}
+		for _, b := range p.Bindings {
+			var changes []BindingChange
+			b.Members, changes = withoutMember(b.Role, b.Members, member)
+			removed = append(removed, changes...)
+		}
+		if len(removed) == 0 {
+			return removed, nil
+		}
+		if _, err := r.crm.SetPolicyOrganization(ctx, resource, p); err != nil {
+			return nil, errors.Wrap(err, "failed to set organization policy")
+		}
+	case strings.HasPrefix(resource, "folders/"):
+		p, err := r.crm.GetPolicyFolder(ctx, resource)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get folder policy")
+		}
+		for _, b := range p.Bindings {
+			var changes []BindingChange
+			b.Members, changes = withoutMember(b.Role, b.Members, member)
+			removed = append(removed, changes...)
+		}
+		if len(removed) == 0 {
+			return removed, nil
+		}
+		if _, err := r.crm.SetPolicyFolder(ctx, resource, p); err != nil {
+			return nil, errors.Wrap(err, "failed to set folder policy")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported resource %q", resource)
+	}
+	return removed, nil
+}
+
+// MemberBindings returns the bindings of the member, of any type, on the IAM policy of a project,
+// folder or organization resource, i.e. the bindings RemoveMemberBindings would remove.
+func (r *Resource) MemberBindings(ctx context.Context, resource, member string) ([]BindingChange, error) {
+	found := []BindingChange{}
+	switch {
+	case strings.HasPrefix(resource, "projects/"):
+		p, err := r.crm.GetPolicyProject(ctx, strings.TrimPrefix(resource, "projects/"))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get project policy")
+		}
+		for _, b := range p.Bindings {
+			_, changes := withoutMember(b.Role, b.Members, member)
+			found = append(found, changes...)
+		}
+	case strings.HasPrefix(resource, "organizations/"):
+		p, err := r.crm.GetPolicyOrganization(ctx, resource)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get organization policy")
+		}
+		for _, b := range p.Bindings {
+			_, changes := withoutMember(b.Role, b.Members, member)
+			found = append(found, changes...)
+		}
+	case strings.HasPrefix(resource, "folders/"):
+		p, err := r.crm.GetPolicyFolder(ctx, resource)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get folder policy")
+		}
+		for _, b := range p.Bindings {
+			_, changes := withoutMember(b.Role, b.Members, member)
+			found = append(found, changes...)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported resource %q", resource)
+	}
+	return found, nil
+}
+
+// AddBindings adds the members of bindings back to their roles on the IAM policy of a project,
+// folder or organization resource and returns the bindings added. Bindings already in the policy
+// are skipped and the policy is not written if all of them are.
+func (r *Resource) AddBindings(ctx context.Context, resource string, bindings []BindingChange) ([]BindingChange, error) {
+	switch {
+	case strings.HasPrefix(resource, "projects/"):
+		projectID := strings.TrimPrefix(resource, "projects/")
+		p, err := r.crm.GetPolicyProject(ctx, projectID)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get project policy")
+		}
+		added := addBindings(bindingMembers(p.Bindings), bindings)
+		if len(added) == 0 {
+			return added, nil
+		}
+		for _, b := range added {
+			p.Bindings = addMember(p.Bindings, b.Role, b.Member)
+		}
+		if _, err := r.crm.SetPolicyProject(ctx, projectID, p); err != nil {
+			return nil, errors.Wrap(err, "failed to set project policy")
+		}
+		return added, nil
+	case strings.HasPrefix(resource, "organizations/"):
+		p, err := r.crm.GetPolicyOrganization(ctx, resource)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get organization policy")
+		}
+		added := addBindings(bindingMembers(p.Bindings), bindings)
+		if len(added) == 0 {
+			return added, nil
+		}
+		for _, b := range added {
+			p.Bindings = addMember(p.Bindings, b.Role, b.Member)
+		}
+		if _, err := r.crm.SetPolicyOrganization(ctx, resource, p); err != nil {
+			return nil, errors.Wrap(err, "failed to set organization policy")
+		}
+		return added, nil
+	case strings.HasPrefix(resource, "folders/"):
+		p, err := r.crm.GetPolicyFolder(ctx, resource)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get folder policy")
+		}
+		existing := map[string][]string{}
+		for _, b := range p.Bindings {
+			existing[b.Role] = append(existing[b.Role], b.Members...)
+		}
+		added := addBindings(existing, bindings)
+		if len(added) == 0 {
+			return added, nil
+		}
+		for _, b := range added {
+			p.Bindings = addFolderMember(p.Bindings, b.Role, b.Member)
+		}
+		if _, err := r.crm.SetPolicyFolder(ctx, resource, p); err != nil {
+			return nil, errors.Wrap(err, "failed to set folder policy")
+		}
+		return added, nil
+	default:
+		return nil, fmt.Errorf("unsupported resource %q", resource)
+	}
+}
+
+// addBindings returns the bindings whose member isn't bound to the role in existing, which maps
+// roles to their members.
+func addBindings(existing map[string][]string, bindings []BindingChange) []BindingChange {
+	added := []BindingChange{}
+	for _, b := range bindings {
+		if memberSet(existing[b.Role])[NormalizeMember(b.Member)] {
+			continue
+		}
+		existing[b.Role] = append(existing[b.Role], b.Member)
+		added = append(added, BindingChange{Role: b.Role, Member: b.Member, Change: BindingAdded})
+	}
+	return added
+}
+
+// addMember adds the member to the role's binding, adding the binding if the role has none.
+func addMember(bindings []*crm.Binding, role, member string) []*crm.Binding {
+	for _, b := range bindings {
+		if b.Role == role && b.Condition == nil {
+			b.Members = append(b.Members, member)
+			return bindings
+		}
+	}
+	return append(bindings, &crm.Binding{Role: role, Members: []string{member}})
+}
+
+// addFolderMember adds the member to the role's binding of a folder policy.
+func addFolderMember(bindings []*crmv2.Binding, role, member string) []*crmv2.Binding {
+	for _, b := range bindings {
+		if b.Role == role && b.Condition == nil {
+			b.Members = append(b.Members, member)
+			return bindings
+		}
+	}
+	return append(bindings, &crmv2.Binding{Role: role, Members: []string{member}})
+}
+
+// withoutMember returns the members of a role's binding without member along with the bindings
+// removed. The member is recorded as found in the policy so it can be added back unchanged.
+func withoutMember(role string, members []string, member string) ([]string, []BindingChange) {
+	kept := []string{}
+	removed := []BindingChange{}
+	for _, m := range members {
+		if NormalizeMember(m) == NormalizeMember(member) {
+			removed = append(removed, BindingChange{Role: role, Member: m, Change: BindingRemoved})
+			continue
+		}
+		kept = append(kept, m)
+	}
+	return kept, removed
+}
+
+// PresentMembers returns the users of members that are still found in the IAM policy of the given
+// project, folder or organization resource, i.e. "projects/p", "folders/123" or "organizations/456".
+func (r *Resource) PresentMembers(ctx context.Context, resource string, members []string) ([]string, error) {
+	var policyMembers []string
+	switch {
+	case strings.HasPrefix(resource, "projects/"):
+		p, err := r.crm.GetPolicyProject(ctx, strings.TrimPrefix(resource, "projects/"))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get project policy")
+		}
+		for _, b := range p.Bindings {
+			policyMembers = append(policyMembers, b.Members...)
+		}
+	case strings.HasPrefix(resource, "organizations/"):
+		p, err := r.crm.GetPolicyOrganization(ctx, resource)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get organization policy")
+		}
+		for _, b := range p.Bindings {
+			policyMembers = append(policyMembers, b.Members...)
+		}
+	case strings.HasPrefix(resource, "folders/"):
+		p, err := r.crm.GetPolicyFolder(ctx, resource)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get folder policy")
+		}
+		for _, b := range p.Bindings {
+			policyMembers = append(policyMembers, b.Members...)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported resource %q", resource)
+	}
+	return uniqueMembers(matchingMembers(policyMembers, members)), nil
+}
+
+// ProjectOwners returns the email addresses of the users granted the owner role on the project.
+func (r *Resource) ProjectOwners(ctx context.Context, projectID string) ([]string, error) {
+	return r.projectMembers(ctx, projectID, []string{"roles/owner"}, []string{"user:"})
+}
+
+// ProjectContacts returns the email addresses of the users and groups granted the owner or editor
+// role on the project. These are the people responsible for the project and should be notified when
+// an automation changes it.
+func (r *Resource) ProjectContacts(ctx context.Context, projectID string) ([]string, error) {
+	return r.projectMembers(ctx, projectID, []string{"roles/owner", "roles/editor"}, []string{"user:", "group:"})
+}
+
+// ProjectGroups returns the email addresses of the groups granted any role on the project.
+func (r *Resource) ProjectGroups(ctx context.Context, projectID string) ([]string, error) {
+	p, err := r.crm.GetPolicyProject(ctx, projectID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get project policy")
+	}
+	groups := []string{}
+	for _, b := range p.Bindings {
+		for _, m := range b.Members {
+			if strings.HasPrefix(m, "group:") {
+				groups = append(groups, strings.TrimPrefix(m, "group:"))
+			}
+		}
+	}
+	return uniqueMembers(groups), nil
+}
+
+// ProjectBindings returns the changes removing each member of each role of the project's policy,
+// ordered by role.
+func (r *Resource) ProjectBindings(ctx context.Context, projectID string) ([]BindingChange, error) {
+	p, err := r.crm.GetPolicyProject(ctx, projectID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get project policy")
+	}
+	return policyChanges(bindingMembers(p.Bindings), nil), nil
+}
+
+// projectMembers returns the email addresses of the members of the given roles on the project
+// whose member type is one of the given prefixes.
+func (r *Resource) projectMembers(ctx context.Context, projectID string, roles, prefixes []string) ([]string, error) {
+	p, err := r.crm.GetPolicyProject(ctx, projectID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get project policy")
+	}
+	roleSet := memberSet(roles)
+	emails := []string{}
+	for _, b := range p.Bindings {
+		if !roleSet[strings.ToLower(b.Role)] {
+			continue
+		}
+		for _, m := range b.Members {
+			for _, prefix := range prefixes {
+				if strings.HasPrefix(m, prefix) {
+					emails = append(emails, strings.TrimPrefix(m, prefix))
+				}
+			}
+		}
+	}
+	return uniqueMembers(emails), nil
+}
+
+// matchingMembers returns the user members that are within the remove list.
+func matchingMembers(members, remove []string) []string {
+	set := memberSet(remove)
+	matched := []string{}
+	for _, member := range members {
+		if key := NormalizeMember(member); strings.HasPrefix(key, "user:") && set[key] {
+			matched = append(matched, member)
+		}
+	}
+	return matched
 }
 
 // RemoveMembersFromBucket removes members from the bucket.
@@ -109,18 +596,16 @@ func (r *Resource) RemoveMembersFromBucket(ctx context.Context, bucketName strin
 	}
 	// Save what we need to remove in a map so we don't mutate a slice while we iterate over it.
 	toRemove := make(map[iam.RoleName]map[string]bool)
-
+	set := memberSet(members)
 	for _, role := range p.Roles() {
 		for _, policyMember := range p.Members(role) {
-			for _, m := range members {
-				if policyMember != m {
-					continue
-				}
-				if toRemove[role] == nil {
-					toRemove[role] = make(map[string]bool)
-				}
-				toRemove[role][m] = true
+			if !set[NormalizeMember(policyMember)] {
+				continue
 			}
+			if toRemove[role] == nil {
+				toRemove[role] = make(map[string]bool)
+			}
+			toRemove[role][policyMember] = true
 		}
 	}
 
@@ -132,30 +617,149 @@ func (r *Resource) RemoveMembersFromBucket(ctx context.Context, bucketName strin
 	return r.storage.SetBucketPolicy(ctx, bucketName, p)
 }
 
+// PresentBucketMembers returns the members that are still found in the bucket's policy.
+func (r *Resource) PresentBucketMembers(ctx context.Context, bucketName string, members []string) ([]string, error) {
+	p, err := r.storage.BucketPolicy(ctx, bucketName)
+	if err != nil {
+		return nil, err
+	}
+	policyMembers := []string{}
+	for _, role := range p.Roles() {
+		policyMembers = append(policyMembers, p.Members(role)...)
+	}
+	set := memberSet(policyMembers)
+	present := []string{}
+	for _, m := range members {
+		if set[NormalizeMember(m)] {
+			present = append(present, m)
+		}
+	}
+	return present, nil
+}
+
+// BroadBucketMembers returns the members of the bucket's policy granting access to everyone or to a
+// whole domain: allUsers, allAuthenticatedUsers and domain members.
+func (r *Resource) BroadBucketMembers(ctx context.Context, bucketName string) ([]string, error) {
+	p, err := r.storage.BucketPolicy(ctx, bucketName)
+	if err != nil {
+		return nil, err
+	}
+	broad := []string{}
+	for _, role := range p.Roles() {
+		for _, m := range p.Members(role) {
+			if m == "allUsers" || m == "allAuthenticatedUsers" || strings.HasPrefix(m, "domain:") {
+				broad = append(broad, m)
+			}
+		}
+	}
+	return uniqueMembers(broad), nil
+}
+
+// LabelBucket sets the label of the bucket, keeping its other labels.
+func (r *Resource) LabelBucket(ctx context.Context, bucketName, key, value string) error {
+	return r.storage.SetBucketLabel(ctx, bucketName, key, value)
+}
+
+// BucketDefaultKMSKey returns the Cloud KMS key new objects of the bucket are encrypted with, or an
+// empty string if they're encrypted with a Google-managed key.
+func (r *Resource) BucketDefaultKMSKey(ctx context.Context, bucketName string) (string, error) {
+	attrs, err := r.storage.BucketAttrs(ctx, bucketName)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get attributes of bucket %q", bucketName)
+	}
+	if attrs.Encryption == nil {
+		return "", nil
+	}
+	return attrs.Encryption.DefaultKMSKeyName, nil
+}
+
+// SetBucketDefaultKMSKey sets the Cloud KMS key new objects of the bucket are encrypted with.
+// Existing objects keep the key they were written with.
+func (r *Resource) SetBucketDefaultKMSKey(ctx context.Context, bucketName, keyName string) error {
+	return r.storage.SetBucketDefaultKMSKey(ctx, bucketName, keyName)
+}
+
+// PublicObjects returns the names of the bucket's objects whose access control lists grant access
+// to all users or all authenticated users. Objects of buckets with bucket policy only enabled are
+// never public through their ACLs so they aren't listed. Only the ACLs of the first max objects are
+// read, truncated is true if the bucket holds more.
+func (r *Resource) PublicObjects(ctx context.Context, bucketName string, max int) (public []string, truncated bool, err error) {
+	attrs, err := r.storage.BucketAttrs(ctx, bucketName)
+	if err != nil {
+		return nil, false, errors.Wrapf(err, "failed to get attributes of bucket %q", bucketName)
+	}
+	if attrs.BucketPolicyOnly.Enabled {
+		return []string{}, false, nil
+	}
+	objects, truncated, err := r.storage.ListObjectsUpTo(ctx, bucketName, "", max)
+	if err != nil {
+		return nil, false, errors.Wrapf(err, "failed to list objects of bucket %q", bucketName)
+	}
+	public = []string{}
+	for _, o := range objects {
+		acl, err := r.storage.ObjectACL(ctx, bucketName, o)
+		if err != nil {
+			return nil, false, errors.Wrapf(err, "failed to get acl of object %q", o)
+		}
+		for _, rule := range acl {
+			if rule.Entity == storage.AllUsers || rule.Entity == storage.AllAuthenticatedUsers {
+				public = append(public, o)
+				break
+			}
+		}
+	}
+	return public, truncated, nil
+}
+
+// RemovePublicObjectACLs removes all users and all authenticated users from the access control
+// lists of the bucket's objects.
+func (r *Resource) RemovePublicObjectACLs(ctx context.Context, bucketName string, objects []string) error {
+	for _, o := range objects {
+		acl, err := r.storage.ObjectACL(ctx, bucketName, o)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get acl of object %q", o)
+		}
+		for _, rule := range acl {
+			if rule.Entity != storage.AllUsers && rule.Entity != storage.AllAuthenticatedUsers {
+				continue
+			}
+			if err := r.storage.DeleteObjectACL(ctx, bucketName, o, rule.Entity); err != nil {
+				return errors.Wrapf(err, "failed to remove %q from acl of object %q", rule.Entity, o)
+			}
+		}
+	}
+	return nil
+}
+
+// BucketAttrs returns the attributes of the given bucket.
+func (r *Resource) BucketAttrs(ctx context.Context, bucketName string) (*storage.BucketAttrs, error) {
+	return r.storage.BucketAttrs(ctx, bucketName)
+}
+
+// auditLogTypes are the log types required to be enabled on all services.
+var auditLogTypes = []string{"ADMIN_READ", "DATA_READ", "DATA_WRITE"}
+
 // EnableAuditLogs enable audit logs to all services and LogTypes.
+//
+// Existing log configs for the required log types are kept as they are so any exempted
+// members previously configured are not removed.
 func (r *Resource) EnableAuditLogs(ctx context.Context, projectID string) (*crm.Policy, error) {
 	res, err := r.crm.GetPolicyProject(ctx, projectID)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get project policy")
 	}
-	isDefault := false
-	enableAll := &crm.AuditConfig{
-		AuditLogConfigs: []*crm.AuditLogConfig{
-			{LogType: "ADMIN_READ"},
-			{LogType: "DATA_READ"},
-			{LogType: "DATA_WRITE"},
-		},
-		Service: "allServices",
-	}
+	var allServices *crm.AuditConfig
 	for _, conf := range res.AuditConfigs {
 		if conf.Service == "allServices" {
-			conf.AuditLogConfigs = enableAll.AuditLogConfigs
-			isDefault = true
+			allServices = conf
+			break
 		}
 	}
-	if !isDefault {
-		res.AuditConfigs = append(res.AuditConfigs, enableAll)
+	if allServices == nil {
+		allServices = &crm.AuditConfig{Service: "allServices"}
+		res.AuditConfigs = append(res.AuditConfigs, allServices)
 	}
+	allServices.AuditLogConfigs = mergeAuditLogConfigs(allServices.AuditLogConfigs)
 
 	result, err := r.crm.SetPolicyProjectWithMask(ctx, projectID, res, "auditConfigs")
 	if err != nil {
@@ -164,24 +768,69 @@ func (r *Resource) EnableAuditLogs(ctx context.Context, projectID string) (*crm.
 	return result, nil
 }
 
-// keepUsersFromPolicy keeps users if they match the given domain.
-func (r *Resource) keepUsersFromPolicy(policy *crm.Policy, allowedDomains []string) ([]string, *crm.Policy, error) {
+// AuditLogsEnabled returns true if all required audit log types are enabled for all services.
+func (r *Resource) AuditLogsEnabled(ctx context.Context, projectID string) (bool, error) {
+	res, err := r.crm.GetPolicyProject(ctx, projectID)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get project policy")
+	}
+	enabled := make(map[string]bool)
+	for _, conf := range res.AuditConfigs {
+		if conf.Service != "allServices" {
+			continue
+		}
+		for _, c := range conf.AuditLogConfigs {
+			enabled[c.LogType] = true
+		}
+	}
+	for _, logType := range auditLogTypes {
+		if !enabled[logType] {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// mergeAuditLogConfigs returns the required log configs, reusing existing configs where present.
+func mergeAuditLogConfigs(existing []*crm.AuditLogConfig) []*crm.AuditLogConfig {
+	byType := make(map[string]*crm.AuditLogConfig)
+	for _, c := range existing {
+		byType[c.LogType] = c
+	}
+	merged := []*crm.AuditLogConfig{}
+	for _, logType := range auditLogTypes {
+		if c, ok := byType[logType]; ok {
+			merged = append(merged, c)
+			delete(byType, logType)
+			continue
+		}
+		merged = append(merged, &crm.AuditLogConfig{LogType: logType})
+	}
+	// Keep any other log types that may have been configured.
+	for _, c := range existing {
+		if _, ok := byType[c.LogType]; ok {
+			merged = append(merged, c)
+		}
+	}
+	return merged
+}
+
+// keepUsersFromPolicy keeps users if they match the given domain or are one of the allowed members.
+func (r *Resource) keepUsersFromPolicy(policy *crm.Policy, allowedDomains, allowedMembers []string) ([]string, *crm.Policy, error) {
 	// Throw an error if no allowed domains are passed. Otherwise all users would be removed.
 	if len(allowedDomains) == 0 {
 		return nil, nil, errors.New("must provide at least one domain to allow")
 	}
-	allowed := strings.Replace(strings.Join(allowedDomains, "|"), ".", `\.`, -1)
-	allowedRegExp, err := regexp.Compile("^.+@(?:" + allowed + ")$")
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to compile regex: %q", err)
-	}
+	allowedRegExp := DomainMatcher(allowedDomains)
+	exempt := memberSet(allowedMembers)
 	removed := []string{}
 	for _, b := range policy.Bindings {
 		members := []string{}
 		for _, member := range b.Members {
-			isUser := strings.HasPrefix(member, "user:")
-			found := false
-			if allowedRegExp.MatchString(member) {
+			key := NormalizeMember(member)
+			isUser := strings.HasPrefix(key, "user:")
+			found := exempt[key]
+			if allowedRegExp.MatchString(key) {
 				found = true
 			}
 			if !isUser || found {
@@ -197,19 +846,16 @@ func (r *Resource) keepUsersFromPolicy(policy *crm.Policy, allowedDomains []stri
 	return removed, policy, nil
 }
 
-// removeUsersFromPolicy removes a slice of users from a policy
+// removeUsersFromPolicy removes a slice of users from a policy.
+// All users are removed from every binding so the policy only needs to be written once.
 func (r *Resource) removeUsersFromPolicy(policy *crm.Policy, users []string) *crm.Policy {
+	set := memberSet(users)
 	for _, b := range policy.Bindings {
 		members := []string{}
 		for _, member := range b.Members {
-			isUser := strings.HasPrefix(member, "user:")
-			found := false
-			for _, user := range users {
-				if strings.EqualFold(user, member) {
-					found = true
-					break
-				}
-			}
+			key := NormalizeMember(member)
+			isUser := strings.HasPrefix(key, "user:")
+			found := set[key]
 			if !isUser || !found {
 				members = append(members, member)
 				continue
@@ -235,6 +881,16 @@ func (r *Resource) EnableBucketOnlyPolicy(ctx context.Context, bucketName string
 	return r.storage.EnableBucketOnlyPolicy(ctx, bucketName)
 }
 
+// EnableBucketLogging enables access logging for the given bucket.
+func (r *Resource) EnableBucketLogging(ctx context.Context, bucketName, logBucket, logObjectPrefix string) error {
+	return r.storage.EnableBucketLogging(ctx, bucketName, logBucket, logObjectPrefix)
+}
+
+// EnableBucketVersioning enables object versioning for the given bucket.
+func (r *Resource) EnableBucketVersioning(ctx context.Context, bucketName string) error {
+	return r.storage.EnableBucketVersioning(ctx, bucketName)
+}
+
 func (r *Resource) getProjectAncestryPath(ctx context.Context, projectID string) (string, error) {
 	resp, err := r.crm.GetAncestry(ctx, projectID)
 	if err != nil {
@@ -247,9 +903,114 @@ func (r *Resource) getProjectAncestryPath(ctx context.Context, projectID string)
 	return strings.Join(s, "/"), nil
 }
 
+// Ancestors returns the folders and organization the project is nested in, closest first, i.e.
+// "folders/789", "folders/123" and "organizations/456".
+func (r *Resource) Ancestors(ctx context.Context, projectID string) ([]string, error) {
+	resp, err := r.crm.GetAncestry(ctx, projectID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get ancestry of %q", projectID)
+	}
+	ancestors := []string{}
+	for _, a := range resp.Ancestor {
+		if a.ResourceId.Type == "project" {
+			continue
+		}
+		ancestors = append(ancestors, a.ResourceId.Type+"s/"+a.ResourceId.Id)
+	}
+	return ancestors, nil
+}
+
+// WithinFolders checks if the project is nested, at any depth, in one of the folders and in none
+// of the excluded ones, i.e. everything under the production folder except the sandbox folder
+// nested in it. Folders are given as "folders/123" or by ID.
+func (r *Resource) WithinFolders(ctx context.Context, projectID string, folders, excluded []string) (bool, error) {
+	ancestors, err := r.Ancestors(ctx, projectID)
+	if err != nil {
+		return false, err
+	}
+	nested := make(map[string]bool, len(ancestors))
+	for _, a := range ancestors {
+		nested[a] = true
+	}
+	for _, f := range excluded {
+		if nested[folderName(f)] {
+			return false, nil
+		}
+	}
+	for _, f := range folders {
+		if nested[folderName(f)] {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// folderName returns the resource name of the folder given by name or ID.
+func folderName(folder string) string {
+	if strings.HasPrefix(folder, "folders/") {
+		return folder
+	}
+	return "folders/" + folder
+}
+
+// AncestryPath returns the ancestry path of a project, folder or organization resource, i.e.
+// "organizations/456/folders/123/projects/p" for "projects/p".
+func (r *Resource) AncestryPath(ctx context.Context, resource string) (string, error) {
+	path, err := r.getResourceAncestryPath(ctx, resource)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get ancestry path of %q", resource)
+	}
+	return path, nil
+}
+
+// ProjectLabels returns the labels of the project.
+func (r *Resource) ProjectLabels(ctx context.Context, projectID string) (map[string]string, error) {
+	p, err := r.crm.GetProject(ctx, projectID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get project %q", projectID)
+	}
+	return p.Labels, nil
+}
+
+// getResourceAncestryPath returns the ancestry path of a project, folder or organization resource
+// such as "projects/p", "folders/123" or "organizations/456".
+func (r *Resource) getResourceAncestryPath(ctx context.Context, resource string) (string, error) {
+	switch {
+	case strings.HasPrefix(resource, "projects/"):
+		return r.getProjectAncestryPath(ctx, strings.TrimPrefix(resource, "projects/"))
+	case strings.HasPrefix(resource, "organizations/"):
+		return resource, nil
+	case strings.HasPrefix(resource, "folders/"):
+		path := []string{resource}
+		parent := resource
+		// Folders can be nested up to 10 levels deep below the organization.
+		for i := 0; i < 10 && strings.HasPrefix(parent, "folders/"); i++ {
+			f, err := r.crm.GetFolder(ctx, parent)
+			if err != nil {
+				return "", err
+			}
+			parent = f.Parent
+			path = append([]string{parent}, path...)
+		}
+		if !strings.HasPrefix(parent, "organizations/") {
+			return "", fmt.Errorf("failed to find organization of %q", resource)
+		}
+		return strings.Join(path, "/"), nil
+	default:
+		return "", fmt.Errorf("unsupported resource %q", resource)
+	}
+}
+
+// ancestryMatches returns whether the ancestry path matches one of the patterns. Patterns starting
+// with the organization match from the root of the path while those starting with a folder, i.e.
+// "folders/123/*", match the folder at any depth.
 func (r *Resource) ancestryMatches(patterns []string, ancestorPath string) (bool, error) {
 	for _, pattern := range patterns {
-		match, err := regexp.MatchString("^"+strings.Replace(pattern, "*", ".*", -1), ancestorPath)
+		expr := "^" + strings.Replace(pattern, "*", ".*", -1)
+		if strings.HasPrefix(pattern, "folders/") {
+			expr = "(^|/)" + strings.Replace(pattern, "*", ".*", -1) + "(/|$)"
+		}
+		match, err := regexp.MatchString(expr, ancestorPath)
 		if err != nil {
 			return false, errors.Wrapf(err, "failed to parse: %s", pattern)
 		}
@@ -263,12 +1024,93 @@ func (r *Resource) ancestryMatches(patterns []string, ancestorPath string) (bool
 	return false, nil
 }
 
+// Visible returns an error if the project, folder or organization resource, i.e. "projects/p",
+// "folders/123" or "organizations/456", does not exist or cannot be read.
+func (r *Resource) Visible(ctx context.Context, resource string) error {
+	var err error
+	switch {
+	case strings.HasPrefix(resource, "projects/"):
+		_, err = r.crm.GetAncestry(ctx, strings.TrimPrefix(resource, "projects/"))
+	case strings.HasPrefix(resource, "folders/"):
+		_, err = r.crm.GetFolder(ctx, resource)
+	case strings.HasPrefix(resource, "organizations/"):
+		_, err = r.crm.GetOrganization(ctx, resource)
+	default:
+		return fmt.Errorf("unsupported resource %q", resource)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to read %q", resource)
+	}
+	return nil
+}
+
+// MissingPermissions returns the permissions the service account lacks on the project, in the order
+// they're given.
+func (r *Resource) MissingPermissions(ctx context.Context, projectID string, permissions []string) ([]string, error) {
+	if len(permissions) == 0 {
+		return nil, nil
+	}
+	granted, err := r.crm.TestPermissionsProject(ctx, projectID, permissions)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to test permissions on %q", projectID)
+	}
+	has := make(map[string]bool, len(granted))
+	for _, p := range granted {
+		has[p] = true
+	}
+	missing := []string{}
+	for _, p := range permissions {
+		if !has[p] {
+			missing = append(missing, p)
+		}
+	}
+	return missing, nil
+}
+
 // CheckMatches checks if a project is included in the target and not included in ignore.
 func (r *Resource) CheckMatches(ctx context.Context, projectID string, target, ignore []string) (bool, error) {
 	ancestorPath, err := r.getProjectAncestryPath(ctx, projectID)
 	if err != nil {
 		return false, errors.Wrap(err, "failed to get project ancestry path")
 	}
+	paths := []string{ancestorPath}
+	// Patterns may name projects by number while the ancestry path uses the project ID.
+	if numberedProject.MatchString(strings.Join(append(target, ignore...), " ")) {
+		number, err := r.ProjectNumber(ctx, projectID)
+		if err != nil {
+			return false, err
+		}
+		i := strings.LastIndex(ancestorPath, "projects/")
+		paths = append(paths, ancestorPath[:i]+"projects/"+number)
+	}
+	for _, path := range paths {
+		matchesIgnore, err := r.ancestryMatches(ignore, path)
+		if err != nil {
+			return false, errors.Wrap(err, "failed to process ignore list")
+		}
+		if matchesIgnore {
+			return false, nil
+		}
+	}
+	for _, path := range paths {
+		matchesTarget, err := r.ancestryMatches(target, path)
+		if err != nil {
+			return false, errors.Wrap(err, "failed to process target list")
+		}
+		if matchesTarget {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// CheckResourceMatches checks if a project, folder or organization resource is included in the
+// target and not included in ignore.
+func (r *Resource) CheckResourceMatches(ctx context.Context, resource string, target, ignore []string) (bool, error) {
+	ancestorPath, err := r.getResourceAncestryPath(ctx, resource)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get resource ancestry path")
+	}
 	matchesIgnore, err := r.ancestryMatches(ignore, ancestorPath)
 	if err != nil {
 		return false, errors.Wrap(err, "failed to process ignore list")
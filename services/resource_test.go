@@ -22,6 +22,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
 	crm "google.golang.org/api/cloudresourcemanager/v1"
+	crmv2 "google.golang.org/api/cloudresourcemanager/v2"
 )
 
 // TestRemoveUsersProject tests the removal of members from a policy.
@@ -59,7 +60,7 @@ func TestRemoveUsersProject(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			crmStub.GetPolicyResponse = &crm.Policy{Bindings: tt.input}
-			if err := r.RemoveUsersProject(ctx, tt.name, tt.removeMembers); err != nil {
+			if _, err := r.RemoveUsersProject(ctx, tt.name, tt.removeMembers); err != nil {
 				t.Errorf("%v failed, err: %+v", tt.name, err)
 			}
 			if diff := cmp.Diff(crmStub.SavedSetPolicy.Bindings, tt.expected); diff != "" {
@@ -172,7 +173,7 @@ func TestRemoveNonOrganizationMembers(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			resource, crmStub := setupOrgTest(tt.input)
-			if _, err := resource.OrganizationOnlyKeepUsersFromDomains(ctx, orgID, tt.allowedDomains); err != nil && !tt.shouldFail {
+			if _, err := resource.OrganizationOnlyKeepUsersFromDomains(ctx, orgID, tt.allowedDomains, nil); err != nil && !tt.shouldFail {
 				t.Errorf("%v failed, err: %+v", tt.name, err)
 			}
 			if !tt.shouldFail {
@@ -225,6 +226,15 @@ func TestEnableAuditLogsOnProject(t *testing.T) {
 				{AuditLogConfigs: []*crm.AuditLogConfig{{LogType: "ADMIN_READ"}, {LogType: "DATA_READ"}, {LogType: "DATA_WRITE"}}, Service: "allServices"},
 			},
 		},
+		{
+			name: "keep exempted members",
+			existingConfig: &crm.AuditConfig{
+				AuditLogConfigs: []*crm.AuditLogConfig{{LogType: "DATA_WRITE", ExemptedMembers: []string{"user:tom@foo.com"}}}, Service: "allServices",
+			},
+			expectedConfig: []*crm.AuditConfig{
+				{AuditLogConfigs: []*crm.AuditLogConfig{{LogType: "ADMIN_READ"}, {LogType: "DATA_READ"}, {LogType: "DATA_WRITE", ExemptedMembers: []string{"user:tom@foo.com"}}}, Service: "allServices"},
+			},
+		},
 	}
 	for _, tt := range tests {
 		ctx := context.Background()
@@ -254,7 +264,9 @@ func setupResourceManager(auditConfig *crm.AuditConfig) *stubs.ResourceManagerSt
 }
 
 func TestCheckMatches(t *testing.T) {
-	crmStub := &stubs.ResourceManagerStub{}
+	crmStub := &stubs.ResourceManagerStub{
+		GetProjectResponse: map[string]*crm.Project{"test-project": {ProjectId: "test-project", ProjectNumber: 789}},
+	}
 	storageStub := &stubs.StorageStub{}
 	r := NewResource(crmStub, storageStub)
 	ctx := context.Background()
@@ -277,6 +289,8 @@ func TestCheckMatches(t *testing.T) {
 		{name: "project not in target and in ignore", mustMatch: false, target: "organizations/456/folders/123/projects/yet-other-project", ignore: "organizations/456/folders/123/projects/" + projectID},
 		{name: "org not in target and not in ignore", mustMatch: false, target: "", ignore: ""},
 		{name: "specify project in any folder", mustMatch: true, target: "organizations/456/*/projects/test-project", ignore: "organizations/456/folders/12/*"},
+		{name: "project number in target", mustMatch: true, target: "organizations/456/folders/123/projects/789", ignore: "organizations/456/folders/12/*"},
+		{name: "project number in ignore", mustMatch: false, target: "organizations/456/*", ignore: "organizations/456/*/projects/789"},
 	}
 
 	for _, tt := range tests {
@@ -297,3 +311,247 @@ func TestCheckMatches(t *testing.T) {
 	}
 
 }
+
+func TestCheckMatchesNestedFolders(t *testing.T) {
+	crmStub := &stubs.ResourceManagerStub{}
+	crmStub.GetAncestryResponse = CreateAncestors([]string{"project/test-project", "folder/789", "folder/123", "organization/456"})
+	r := NewResource(crmStub, &stubs.StorageStub{})
+	for _, tt := range []struct {
+		name      string
+		target    []string
+		ignore    []string
+		mustMatch bool
+	}{
+		{name: "parent folder at any depth", target: []string{"folders/789/*"}, mustMatch: true},
+		{name: "ancestor folder at any depth", target: []string{"folders/123/*"}, mustMatch: true},
+		{name: "nested folder excluded", target: []string{"folders/123/*"}, ignore: []string{"folders/789/*"}},
+		{name: "nested path excluded", target: []string{"folders/123/*"}, ignore: []string{"folders/123/folders/789/*"}},
+		{name: "other folder excluded", target: []string{"folders/123/*"}, ignore: []string{"folders/78/*"}, mustMatch: true},
+		{name: "folder prefix", target: []string{"folders/12/*"}},
+		{name: "rooted pattern", target: []string{"organizations/456/folders/123/folders/789/projects/test-project"}, mustMatch: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			matches, err := r.CheckMatches(context.Background(), "test-project", tt.target, tt.ignore)
+			if err != nil {
+				t.Fatalf("%s failed: %q", tt.name, err)
+			}
+			if matches != tt.mustMatch {
+				t.Errorf("%s failed: got %t want %t", tt.name, matches, tt.mustMatch)
+			}
+		})
+	}
+}
+
+func TestWithinFolders(t *testing.T) {
+	crmStub := &stubs.ResourceManagerStub{}
+	crmStub.GetAncestryResponse = CreateAncestors([]string{"project/test-project", "folder/789", "folder/123", "organization/456"})
+	r := NewResource(crmStub, &stubs.StorageStub{})
+	ancestors, err := r.Ancestors(context.Background(), "test-project")
+	if err != nil {
+		t.Fatalf("failed to get ancestors: %q", err)
+	}
+	if diff := cmp.Diff([]string{"folders/789", "folders/123", "organizations/456"}, ancestors); diff != "" {
+		t.Errorf("ancestors difference:%+v", diff)
+	}
+	for _, tt := range []struct {
+		name     string
+		folders  []string
+		excluded []string
+		expected bool
+	}{
+		{name: "parent folder", folders: []string{"folders/789"}, expected: true},
+		{name: "ancestor folder by ID", folders: []string{"123"}, expected: true},
+		{name: "nested folder excluded", folders: []string{"folders/123"}, excluded: []string{"789"}},
+		{name: "other folder excluded", folders: []string{"folders/123"}, excluded: []string{"folders/555"}, expected: true},
+		{name: "other folder", folders: []string{"folders/12"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			within, err := r.WithinFolders(context.Background(), "test-project", tt.folders, tt.excluded)
+			if err != nil {
+				t.Fatalf("%s failed: %q", tt.name, err)
+			}
+			if within != tt.expected {
+				t.Errorf("%s failed: got %t want %t", tt.name, within, tt.expected)
+			}
+		})
+	}
+}
+
+// TestProdExceptSandbox acts on everything under the prod folder except the sandbox folder nested
+// in it, with prod as folders/100 and sandbox as folders/100/folders/200.
+func TestProdExceptSandbox(t *testing.T) {
+	const prod, sandbox = "folders/100", "folders/200"
+	for _, tt := range []struct {
+		name     string
+		ancestry []string
+		expected bool
+	}{
+		{name: "prod project", ancestry: []string{"project/app", "folder/100", "organization/456"}, expected: true},
+		{name: "nested prod project", ancestry: []string{"project/app", "folder/300", "folder/100", "organization/456"}, expected: true},
+		{name: "sandbox project", ancestry: []string{"project/app", "folder/200", "folder/100", "organization/456"}},
+		{name: "nested sandbox project", ancestry: []string{"project/app", "folder/400", "folder/200", "folder/100", "organization/456"}},
+		{name: "other project", ancestry: []string{"project/app", "folder/500", "organization/456"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			crmStub := &stubs.ResourceManagerStub{GetAncestryResponse: CreateAncestors(tt.ancestry)}
+			r := NewResource(crmStub, &stubs.StorageStub{})
+			within, err := r.WithinFolders(context.Background(), "app", []string{prod}, []string{sandbox})
+			if err != nil {
+				t.Fatalf("%s failed: %q", tt.name, err)
+			}
+			if within != tt.expected {
+				t.Errorf("%s failed: within folders got %t want %t", tt.name, within, tt.expected)
+			}
+			matches, err := r.CheckMatches(context.Background(), "app", []string{prod + "/*"}, []string{sandbox + "/*"})
+			if err != nil {
+				t.Fatalf("%s failed: %q", tt.name, err)
+			}
+			if matches != tt.expected {
+				t.Errorf("%s failed: matches got %t want %t", tt.name, matches, tt.expected)
+			}
+		})
+	}
+}
+
+func TestProjectIDAndNumber(t *testing.T) {
+	crmStub := &stubs.ResourceManagerStub{
+		GetProjectResponse: map[string]*crm.Project{
+			"test-project": {ProjectId: "test-project", ProjectNumber: 789},
+			"789":          {ProjectId: "test-project", ProjectNumber: 789},
+		},
+	}
+	r := NewResource(crmStub, &stubs.StorageStub{})
+	ctx := context.Background()
+	for _, tt := range []struct {
+		name       string
+		project    string
+		wantID     string
+		wantNumber string
+	}{
+		{name: "project ID", project: "test-project", wantID: "test-project", wantNumber: "789"},
+		{name: "project number", project: "789", wantID: "test-project", wantNumber: "789"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := r.ProjectID(ctx, tt.project)
+			if err != nil {
+				t.Fatalf("%s failed: %q", tt.name, err)
+			}
+			number, err := r.ProjectNumber(ctx, tt.project)
+			if err != nil {
+				t.Fatalf("%s failed: %q", tt.name, err)
+			}
+			if id != tt.wantID || number != tt.wantNumber {
+				t.Errorf("%s failed: got %q and %q, want %q and %q", tt.name, id, number, tt.wantID, tt.wantNumber)
+			}
+		})
+	}
+	// Both directions are cached once a project has been looked up.
+	crmStub.GetProjectResponse = nil
+	if id, err := r.ProjectID(ctx, "789"); err != nil || id != "test-project" {
+		t.Errorf("cached lookup failed: got %q, err %v", id, err)
+	}
+	if number, err := r.ProjectNumber(ctx, "test-project"); err != nil || number != "789" {
+		t.Errorf("cached lookup failed: got %q, err %v", number, err)
+	}
+}
+
+func TestCheckResourceMatches(t *testing.T) {
+	crmStub := &stubs.ResourceManagerStub{
+		GetFolderResponse: map[string]*crmv2.Folder{
+			"folders/12":  {Parent: "folders/123"},
+			"folders/123": {Parent: "organizations/456"},
+		},
+	}
+	r := NewResource(crmStub, &stubs.StorageStub{})
+	ctx := context.Background()
+	tests := []struct {
+		name      string
+		resource  string
+		target    string
+		ignore    string
+		mustMatch bool
+	}{
+		{name: "org in target", resource: "organizations/456", mustMatch: true, target: "organizations/456*", ignore: "organizations/888*"},
+		{name: "org not in target", resource: "organizations/456", mustMatch: false, target: "organizations/456/folders/123*", ignore: "organizations/888*"},
+		{name: "folder in target", resource: "folders/123", mustMatch: true, target: "organizations/456/folders/123*", ignore: "organizations/888*"},
+		{name: "nested folder in target", resource: "folders/12", mustMatch: true, target: "organizations/456/folders/123/*", ignore: "organizations/888*"},
+		{name: "nested folder in ignore", resource: "folders/12", mustMatch: false, target: "organizations/456/*", ignore: "organizations/456/folders/123/folders/12"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches, err := r.CheckResourceMatches(ctx, tt.resource, []string{tt.target}, []string{tt.ignore})
+			if err != nil {
+				t.Errorf("%s failed, err: %+v", tt.name, err)
+			}
+			if matches != tt.mustMatch {
+				t.Errorf("%s failed: got match %t, want %t", tt.name, matches, tt.mustMatch)
+			}
+		})
+	}
+	if _, err := r.CheckResourceMatches(ctx, "folders/999", []string{"*"}, nil); err == nil {
+		t.Errorf("unknown folder should fail")
+	}
+}
+
+func TestRemoveUsersFolder(t *testing.T) {
+	crmStub := &stubs.ResourceManagerStub{
+		GetFolderPolicyResponse: &crmv2.Policy{Bindings: []*crmv2.Binding{
+			{Role: "roles/owner", Members: []string{"user:test@foo.com", "user:Tom@gmail.com"}},
+			{Role: "roles/viewer", Members: []string{"user:tom@gmail.com", "serviceAccount:tom@gmail.com"}},
+		}},
+	}
+	r := NewResource(crmStub, &stubs.StorageStub{})
+	removed, err := r.RemoveUsersFolder(context.Background(), "folders/123", []string{"user:tom@gmail.com", "serviceAccount:tom@gmail.com"})
+	if err != nil {
+		t.Fatalf("failed to remove users: %q", err)
+	}
+	if diff := cmp.Diff([]string{"user:Tom@gmail.com", "user:tom@gmail.com"}, removed); diff != "" {
+		t.Errorf("unexpected removed members: %s", diff)
+	}
+	want := []*crmv2.Binding{
+		{Role: "roles/owner", Members: []string{"user:test@foo.com"}},
+		{Role: "roles/viewer", Members: []string{"serviceAccount:tom@gmail.com"}},
+	}
+	if diff := cmp.Diff(want, crmStub.SavedSetFolderPolicy.Bindings); diff != "" {
+		t.Errorf("unexpected policy: %s", diff)
+	}
+}
+
+// TestProjectContacts tests the owners and editors of a project are returned.
+func TestProjectContacts(t *testing.T) {
+	crmStub := &stubs.ResourceManagerStub{}
+	r := NewResource(crmStub, &stubs.StorageStub{})
+	ctx := context.Background()
+	tests := []struct {
+		name     string
+		input    []*crm.Binding
+		expected []string
+	}{
+		{
+			name: "owners and editors",
+			input: []*crm.Binding{
+				{Role: "roles/owner", Members: []string{"user:alice@example.com", "serviceAccount:sa@example.com"}},
+				{Role: "roles/editor", Members: []string{"group:app-team@example.com", "user:alice@example.com"}},
+				{Role: "roles/viewer", Members: []string{"user:bob@example.com"}},
+			},
+			expected: []string{"alice@example.com", "app-team@example.com"},
+		},
+		{
+			name:     "no contacts",
+			input:    []*crm.Binding{{Role: "roles/viewer", Members: []string{"user:bob@example.com"}}},
+			expected: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			crmStub.GetPolicyResponse = &crm.Policy{Bindings: tt.input}
+			contacts, err := r.ProjectContacts(ctx, "project-id")
+			if err != nil {
+				t.Errorf("%v failed, err: %+v", tt.name, err)
+			}
+			if diff := cmp.Diff(contacts, tt.expected); diff != "" {
+				t.Errorf("%v failed, difference: %v", tt.name, diff)
+			}
+		})
+	}
+}
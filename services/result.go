@@ -0,0 +1,127 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Result is the structured outcome of an automation. Automations return it instead of logging and
+// auditing their outcome themselves so it is recorded, notified and marked on the finding the same
+// way for all of them.
+type Result struct {
+	Action string `json:"action"`
+	// Resources are the resources the automation changed, or would have changed in dry run mode.
+	Resources []string `json:"resources,omitempty"`
+	// Members holds the outcome for each member of the finding, keyed by member.
+	Members map[string]string `json:"members,omitempty"`
+	// PolicyChanges holds the bindings changed when the automation writes an IAM policy.
+	PolicyChanges []BindingChange `json:"policy_changes,omitempty"`
+	// AccessAnalysis explains whether the members removed from a policy keep access through other
+	// bindings, when a preflight was requested.
+	AccessAnalysis []AccessAnalysis `json:"access_analysis,omitempty"`
+	// Skipped is why the automation made no change, such as the resource already being remediated.
+	Skipped string `json:"skipped,omitempty"`
+	// NotApplicable is whether the automation was skipped because it doesn't apply to the resource
+	// rather than because the resource was already remediated.
+	NotApplicable bool `json:"not_applicable,omitempty"`
+	// Message describes the change made, it's recorded in the audit log.
+	Message string `json:"message,omitempty"`
+	DryRun  bool   `json:"dry_run,omitempty"`
+}
+
+// NewResult returns the result of an automation that has not changed anything yet.
+func NewResult(action string, dryRun bool) *Result {
+	return &Result{Action: action, DryRun: dryRun}
+}
+
+// Touch records the resources the automation changed.
+func (r *Result) Touch(resources ...string) *Result {
+	r.Resources = append(r.Resources, resources...)
+	return r
+}
+
+// Skip records why the automation made no change, the resource it found already remediated is
+// recorded as well.
+func (r *Result) Skip(resource, reason string, a ...interface{}) *Result {
+	r.Resources = append(r.Resources, resource)
+	r.Skipped = fmt.Sprintf(reason, a...)
+	return r
+}
+
+// Ignore records why the automation doesn't apply to the resource, such as a bucket that isn't a
+// staging bucket. Unlike Skip the resource is not recorded as already remediated.
+func (r *Result) Ignore(resource, reason string, a ...interface{}) *Result {
+	r.NotApplicable = true
+	return r.Skip(resource, reason, a...)
+}
+
+// Empty returns true if the result records nothing, such as a scheduled run with nothing to do.
+func (r *Result) Empty() bool {
+	return len(r.Resources) == 0 && len(r.Members) == 0 && r.Skipped == ""
+}
+
+// Status returns the audit result of the automation.
+func (r *Result) Status() string {
+	switch {
+	case r.Skipped != "" && r.NotApplicable:
+		return AuditResultSkipped
+	case r.Skipped != "":
+		return AuditResultAlreadyRemediated
+	case r.DryRun:
+		return AuditResultDryRun
+	default:
+		return AuditResultSuccess
+	}
+}
+
+// AuditRecord returns the audit record of the result. The first resource is recorded as the
+// resource, or the project if the automation touched none. The reason is recorded as the message
+// of a skipped automation.
+func (r *Result) AuditRecord(projectID string) *AuditRecord {
+	resource := "projects/" + projectID
+	if len(r.Resources) > 0 {
+		resource = r.Resources[0]
+	}
+	message := r.Message
+	if r.Skipped != "" {
+		message = r.Skipped
+	}
+	return &AuditRecord{
+		Action:         r.Action,
+		Resource:       resource,
+		Result:         r.Status(),
+		Message:        message,
+		Members:        r.Members,
+		PolicyChanges:  r.PolicyChanges,
+		AccessAnalysis: r.AccessAnalysis,
+	}
+}
+
+// String summarizes the result for logs.
+func (r *Result) String() string {
+	resources := strings.Join(r.Resources, ", ")
+	switch {
+	case r.Skipped != "":
+		return fmt.Sprintf("%s skipped: %s", r.Action, r.Skipped)
+	case len(r.Resources) == 0:
+		return fmt.Sprintf("%s made no changes", r.Action)
+	case r.DryRun:
+		return fmt.Sprintf("dry_run on, %s would have changed %s", r.Action, resources)
+	default:
+		return fmt.Sprintf("%s changed %s", r.Action, resources)
+	}
+}
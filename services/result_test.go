@@ -0,0 +1,92 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestResultAuditRecord(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		result *Result
+		want   *AuditRecord
+	}{
+		{
+			name:   "changed",
+			result: NewResult("close_bucket", false).Touch("bucket-name"),
+			want:   &AuditRecord{Action: "close_bucket", Resource: "bucket-name", Result: AuditResultSuccess},
+		},
+		{
+			name:   "dry run",
+			result: NewResult("close_bucket", true).Touch("bucket-name"),
+			want:   &AuditRecord{Action: "close_bucket", Resource: "bucket-name", Result: AuditResultDryRun},
+		},
+		{
+			name:   "already remediated",
+			result: NewResult("close_bucket", true).Skip("bucket-name", "bucket %q is not public", "bucket-name"),
+			want: &AuditRecord{
+				Action:   "close_bucket",
+				Resource: "bucket-name",
+				Result:   AuditResultAlreadyRemediated,
+				Message:  `bucket "bucket-name" is not public`,
+			},
+		},
+		{
+			name:   "not applicable",
+			result: NewResult("close_staging_bucket", false).Ignore("bucket-name", "bucket %q is not a staging bucket", "bucket-name"),
+			want: &AuditRecord{
+				Action:   "close_staging_bucket",
+				Resource: "bucket-name",
+				Result:   AuditResultSkipped,
+				Message:  `bucket "bucket-name" is not a staging bucket`,
+			},
+		},
+		{
+			name: "members",
+			result: &Result{
+				Action:        "iam_revoke",
+				Members:       map[string]string{"user:a@gmail.com": MemberRemoved},
+				PolicyChanges: []BindingChange{{Role: "roles/editor", Member: "user:a@gmail.com", Change: BindingRemoved}},
+				Message:       "removed external members",
+			},
+			want: &AuditRecord{
+				Action:        "iam_revoke",
+				Resource:      "projects/test-project",
+				Result:        AuditResultSuccess,
+				Message:       "removed external members",
+				Members:       map[string]string{"user:a@gmail.com": MemberRemoved},
+				PolicyChanges: []BindingChange{{Role: "roles/editor", Member: "user:a@gmail.com", Change: BindingRemoved}},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, tt.result.AuditRecord("test-project")); diff != "" {
+				t.Errorf("%s failed, audit record difference:%+v", tt.name, diff)
+			}
+		})
+	}
+}
+
+func TestResultEmpty(t *testing.T) {
+	if r := NewResult("restore_containment", false); !r.Empty() {
+		t.Errorf("result without resources should be empty: %+v", r)
+	}
+	if r := NewResult("restore_containment", false).Touch("firewall"); r.Empty() {
+		t.Errorf("result with resources should not be empty: %+v", r)
+	}
+}
@@ -0,0 +1,50 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// SecretManagerClient contains minimum interface required by the Secret Manager service.
+type SecretManagerClient interface {
+	CreateSecret(context.Context, string, string) error
+	AddSecretVersion(context.Context, string, string, []byte) error
+}
+
+// SecretManager service.
+type SecretManager struct {
+	client SecretManagerClient
+}
+
+// NewSecretManager returns a Secret Manager service.
+func NewSecretManager(client SecretManagerClient) *SecretManager {
+	return &SecretManager{client: client}
+}
+
+// StoreSecret saves the payload as the latest version of the secret, creating the secret if needed.
+func (s *SecretManager) StoreSecret(ctx context.Context, projectID, secretID string, payload []byte) error {
+	if err := s.client.CreateSecret(ctx, projectID, secretID); err != nil {
+		if !IsConflict(err) {
+			return errors.Wrapf(err, "failed to create secret %q", secretID)
+		}
+	}
+	if err := s.client.AddSecretVersion(ctx, projectID, secretID, payload); err != nil {
+		return errors.Wrapf(err, "failed to add version to secret %q", secretID)
+	}
+	return nil
+}
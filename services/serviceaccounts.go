@@ -0,0 +1,115 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	iam "google.golang.org/api/iam/v1"
+)
+
+// serviceAccountDomain is the domain of the emails of service accounts, user-managed and those
+// managed by Google alike.
+const serviceAccountDomain = ".gserviceaccount.com"
+
+// ServiceAccountsClient contains minimum interface required by the service accounts service.
+type ServiceAccountsClient interface {
+	GetServiceAccount(context.Context, string) (*iam.ServiceAccount, error)
+	ListServiceAccounts(context.Context, string) ([]*iam.ServiceAccount, error)
+	ListServiceAccountKeys(context.Context, string) ([]*iam.ServiceAccountKey, error)
+	DeleteServiceAccountKey(context.Context, string) error
+	DisableServiceAccount(context.Context, string) error
+	EnableServiceAccount(context.Context, string) error
+}
+
+// ServiceAccounts service manages IAM service accounts.
+type ServiceAccounts struct {
+	client ServiceAccountsClient
+}
+
+// NewServiceAccounts returns a service accounts service.
+func NewServiceAccounts(client ServiceAccountsClient) *ServiceAccounts {
+	return &ServiceAccounts{client: client}
+}
+
+// IsServiceAccount returns whether the email, or member such as "serviceAccount:sa@p.iam.gserviceaccount.com",
+// is a service account.
+func IsServiceAccount(email string) bool {
+	return strings.HasSuffix(strings.ToLower(strings.TrimPrefix(email, "serviceAccount:")), serviceAccountDomain)
+}
+
+// serviceAccountName returns the resource name of the service account given its email, the project
+// is looked up by IAM.
+func serviceAccountName(email string) string {
+	return "projects/-/serviceAccounts/" + email
+}
+
+// Disabled returns whether the service account is disabled.
+func (s *ServiceAccounts) Disabled(ctx context.Context, email string) (bool, error) {
+	a, err := s.client.GetServiceAccount(ctx, serviceAccountName(email))
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to get service account %q", email)
+	}
+	return a.Disabled, nil
+}
+
+// ProjectServiceAccounts returns the user-managed service accounts of the project.
+func (s *ServiceAccounts) ProjectServiceAccounts(ctx context.Context, projectID string) ([]*iam.ServiceAccount, error) {
+	accounts, err := s.client.ListServiceAccounts(ctx, projectID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list service accounts of project %q", projectID)
+	}
+	return accounts, nil
+}
+
+// Keys returns the resource names of the service account's user-managed keys.
+func (s *ServiceAccounts) Keys(ctx context.Context, email string) ([]string, error) {
+	keys, err := s.client.ListServiceAccountKeys(ctx, serviceAccountName(email))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list keys of service account %q", email)
+	}
+	names := make([]string, 0, len(keys))
+	for _, k := range keys {
+		names = append(names, k.Name)
+	}
+	return names, nil
+}
+
+// DeleteKey deletes a user-managed key of a service account given its resource name.
+func (s *ServiceAccounts) DeleteKey(ctx context.Context, name string) error {
+	if err := s.client.DeleteServiceAccountKey(ctx, name); err != nil {
+		return errors.Wrapf(err, "failed to delete key %q", name)
+	}
+	return nil
+}
+
+// Disable disables the service account. The account and its keys are kept but can't be used to
+// authenticate until the account is enabled.
+func (s *ServiceAccounts) Disable(ctx context.Context, email string) error {
+	if err := s.client.DisableServiceAccount(ctx, serviceAccountName(email)); err != nil {
+		return errors.Wrapf(err, "failed to disable service account %q", email)
+	}
+	return nil
+}
+
+// Enable enables a disabled service account.
+func (s *ServiceAccounts) Enable(ctx context.Context, email string) error {
+	if err := s.client.EnableServiceAccount(ctx, serviceAccountName(email)); err != nil {
+		return errors.Wrapf(err, "failed to enable service account %q", email)
+	}
+	return nil
+}
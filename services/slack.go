@@ -0,0 +1,85 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// SlackNotifier posts a summary of the outcome of an automation to a Slack incoming webhook.
+type SlackNotifier struct {
+	client   WebhookClient
+	url      string
+	messages *Messages
+}
+
+// NewSlackNotifier returns a notifier posting to the incoming webhook URL. Messages can be nil to
+// post the built-in summary.
+func NewSlackNotifier(client WebhookClient, url string, messages *Messages) *SlackNotifier {
+	return &SlackNotifier{client: client, url: url, messages: messages}
+}
+
+// Notify posts the summary of the event to the channel of the incoming webhook.
+func (n *SlackNotifier) Notify(ctx context.Context, event *WebhookEvent) error {
+	text, err := n.messages.Render(ctx, MessageSlack, event)
+	if err != nil {
+		return err
+	}
+	if text == "" {
+		text = slackSummary(event)
+	}
+	b, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal slack message")
+	}
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	if err := n.client.Post(ctx, n.url, b, header); err != nil {
+		return errors.Wrapf(err, "failed to post event %q to slack", event.ID)
+	}
+	return nil
+}
+
+// slackSummary returns the message posted for the event in Slack's mrkdwn format.
+func slackSummary(event *WebhookEvent) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*Security Response Automation: %s* on `%s`: %s", event.Action, event.ProjectID, event.Result)
+	if event.Severity != "" {
+		fmt.Fprintf(&b, "\nSeverity: %s", event.Severity)
+	}
+	if event.Category != "" {
+		fmt.Fprintf(&b, "\nCategory: %s", event.Category)
+	}
+	if event.FindingName != "" {
+		fmt.Fprintf(&b, "\nFinding: %s", event.FindingName)
+	}
+	if event.Error != "" {
+		fmt.Fprintf(&b, "\nError: %s", event.Error)
+	}
+	if event.Runbook != "" {
+		fmt.Fprintf(&b, "\nRunbook: %s", event.Runbook)
+	}
+	fmt.Fprintf(&b, "\nEvent ID: %s", event.ID)
+	if event.CorrelationID != "" {
+		fmt.Fprintf(&b, "\nCorrelation ID: %s", event.CorrelationID)
+	}
+	return b.String()
+}
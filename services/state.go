@@ -0,0 +1,663 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+const (
+	// containmentPrefix is the object prefix containment records are stored under.
+	containmentPrefix = "containment/"
+	// operationPrefix is the object prefix long running operation records are stored under.
+	operationPrefix = "operations/"
+	// progressPrefix is the object prefix progress records are stored under.
+	progressPrefix = "progress/"
+	// playbookPrefix is the object prefix playbook records are stored under.
+	playbookPrefix = "playbooks/"
+	// quarantinePrefix is the object prefix quarantined service account records are stored under.
+	quarantinePrefix = "quarantine/"
+	// lockdownPrefix is the object prefix locked down project records are stored under.
+	lockdownPrefix = "lockdown/"
+	// watermarkPrefix is the object prefix finding export watermarks are stored under.
+	watermarkPrefix = "watermarks/"
+	// approvalPrefix is the object prefix approval requests and their approvals are stored under.
+	approvalPrefix = "approvals/"
+)
+
+const (
+	// OperationCloudSQL is the service of operations started with the Cloud SQL Admin API.
+	OperationCloudSQL = "cloudsql"
+	// OperationComputeZone is the service of zonal operations started with the Compute Engine API,
+	// recorded as "zones/<zone>/operations/<name>".
+	OperationComputeZone = "compute_zone"
+)
+
+// StateClient contains minimum interface required by the state service.
+type StateClient interface {
+	WriteObject(context.Context, string, string, []byte) error
+	ReadObject(context.Context, string, string) ([]byte, error)
+	ListObjects(context.Context, string, string) ([]string, error)
+	DeleteObject(context.Context, string, string) error
+}
+
+// State service stores the actions taken by automations in a bucket.
+type State struct {
+	client StateClient
+	bucket string
+}
+
+// ContainmentRecord records a temporary containment action along with the state of the resource
+// before it was changed so the action can be reverted once it expires.
+type ContainmentRecord struct {
+	ID         string          `json:"id"`
+	Action     string          `json:"action"`
+	ProjectID  string          `json:"project_id"`
+	Resource   string          `json:"resource"`
+	CreateTime time.Time       `json:"create_time"`
+	ExpireTime time.Time       `json:"expire_time"`
+	Before     json.RawMessage `json:"before"`
+}
+
+// OperationRecord records a long running operation started by an automation so its completion can
+// be checked by a later function instead of the automation blocking until it's done.
+type OperationRecord struct {
+	ID        string    `json:"id"`
+	Action    string    `json:"action"`
+	ProjectID string    `json:"project_id"`
+	Resource  string    `json:"resource"`
+	Service   string    `json:"service"`
+	Operation string    `json:"operation"`
+	Message   string    `json:"message"`
+	StartTime time.Time `json:"start_time"`
+}
+
+// ProgressRecord records the steps an automation completed before it failed or ran out of time so
+// a retry can resume rather than repeat them.
+type ProgressRecord struct {
+	ID         string    `json:"id"`
+	Action     string    `json:"action"`
+	ProjectID  string    `json:"project_id"`
+	Resource   string    `json:"resource"`
+	Steps      []string  `json:"steps"`
+	UpdateTime time.Time `json:"update_time"`
+}
+
+// Done returns whether the step was completed.
+func (r *ProgressRecord) Done(step string) bool {
+	for _, s := range r.Steps {
+		if s == step {
+			return true
+		}
+	}
+	return false
+}
+
+// Complete marks the step as completed.
+func (r *ProgressRecord) Complete(step string) {
+	if !r.Done(step) {
+		r.Steps = append(r.Steps, step)
+	}
+}
+
+// Statuses of the steps of a playbook.
+const (
+	StepPending   = "pending"
+	StepRunning   = "running"
+	StepSucceeded = "succeeded"
+	StepFailed    = "failed"
+	StepSkipped   = "skipped"
+)
+
+// PlaybookStep is an automation run by a playbook along with the message it's sent.
+type PlaybookStep struct {
+	Action     string            `json:"action"`
+	Topic      string            `json:"topic"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+	Values     json.RawMessage   `json:"values"`
+	Status     string            `json:"status"`
+	Error      string            `json:"error,omitempty"`
+}
+
+// PlaybookRecord records the steps of a playbook run for a finding. Steps are run one at a time and
+// each step starts the next once it succeeds.
+type PlaybookRecord struct {
+	ID        string          `json:"id"`
+	Name      string          `json:"name"`
+	Resource  string          `json:"resource"`
+	Steps     []*PlaybookStep `json:"steps"`
+	StartTime time.Time       `json:"start_time"`
+}
+
+// QuarantineRecord records the bindings removed from a quarantined service account so they can be
+// restored once the account is found to be safe.
+type QuarantineRecord struct {
+	ID             string `json:"id"`
+	ProjectID      string `json:"project_id"`
+	ServiceAccount string `json:"service_account"`
+	// Bindings maps each project, folder or organization, i.e. "folders/123", to the bindings
+	// removed from its policy.
+	Bindings map[string][]BindingChange `json:"bindings"`
+	// Keys are the user-managed keys of the account, kept but unusable while it's disabled.
+	Keys []string `json:"keys"`
+	// WasDisabled is whether the account was already disabled when it was quarantined.
+	WasDisabled bool      `json:"was_disabled"`
+	CreateTime  time.Time `json:"create_time"`
+}
+
+// LockdownRecord records the state of a project before it was locked down: the bindings removed
+// from its policy, its service accounts and the instances that were running.
+type LockdownRecord struct {
+	ID        string `json:"id"`
+	ProjectID string `json:"project_id"`
+	// Bindings are the bindings removed from the project's policy.
+	Bindings []BindingChange `json:"bindings"`
+	// ServiceAccounts are the project's service accounts when it was locked down.
+	ServiceAccounts []LockedServiceAccount `json:"service_accounts"`
+	// Instances are the instances that were running, i.e. "us-central1-a/instance-1".
+	Instances  []string  `json:"instances"`
+	CreateTime time.Time `json:"create_time"`
+}
+
+// LockedServiceAccount is a service account of a locked down project.
+type LockedServiceAccount struct {
+	Email string `json:"email"`
+	// WasDisabled is whether the account was already disabled when the project was locked down.
+	WasDisabled bool `json:"was_disabled"`
+	// Keys are the user-managed keys of the account, kept but unusable while it's disabled.
+	Keys []string `json:"keys"`
+}
+
+// WatermarkRecord records how far the findings of an export table have been processed.
+type WatermarkRecord struct {
+	ID    string `json:"id"`
+	Table string `json:"table"`
+	// EventTime is the event time of the newest finding processed.
+	EventTime time.Time `json:"event_time"`
+	// Findings are the IDs of the findings processed with that event time, they're read again as
+	// findings are read from the event time onwards.
+	Findings   []string  `json:"findings"`
+	UpdateTime time.Time `json:"update_time"`
+}
+
+// Processed returns if the finding was already processed.
+func (r *WatermarkRecord) Processed(id string, eventTime time.Time) bool {
+	if eventTime.Before(r.EventTime) {
+		return true
+	}
+	if eventTime.After(r.EventTime) {
+		return false
+	}
+	for _, f := range r.Findings {
+		if f == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Advance moves the watermark to the finding once it's processed.
+func (r *WatermarkRecord) Advance(id string, eventTime time.Time) {
+	if eventTime.After(r.EventTime) {
+		r.EventTime = eventTime
+		r.Findings = []string{}
+	}
+	r.Findings = append(r.Findings, id)
+}
+
+// NewState returns a state service storing records in bucket.
+func NewState(client StateClient, bucket string) *State {
+	return &State{client: client, bucket: bucket}
+}
+
+// RecordContainment stores a containment action that expires after ttl.
+func (s *State) RecordContainment(ctx context.Context, action, projectID, resource string, ttl time.Duration, before interface{}) (*ContainmentRecord, error) {
+	b, err := json.Marshal(before)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal before state")
+	}
+	now := time.Now().UTC()
+	r := &ContainmentRecord{
+		ID:         uuid.New().String(),
+		Action:     action,
+		ProjectID:  projectID,
+		Resource:   resource,
+		CreateTime: now,
+		ExpireTime: now.Add(ttl),
+		Before:     b,
+	}
+	content, err := json.Marshal(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal containment record")
+	}
+	if err := s.client.WriteObject(ctx, s.bucket, containmentPrefix+r.ID+".json", content); err != nil {
+		return nil, errors.Wrapf(err, "failed to write containment record to %q", s.bucket)
+	}
+	return r, nil
+}
+
+// ExpiredContainments returns the containment records that expired before now.
+func (s *State) ExpiredContainments(ctx context.Context, now time.Time) ([]*ContainmentRecord, error) {
+	names, err := s.client.ListObjects(ctx, s.bucket, containmentPrefix)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list containment records in %q", s.bucket)
+	}
+	expired := []*ContainmentRecord{}
+	for _, name := range names {
+		b, err := s.client.ReadObject(ctx, s.bucket, name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read containment record %q", name)
+		}
+		var r ContainmentRecord
+		if err := json.Unmarshal(b, &r); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal containment record %q", name)
+		}
+		if r.ExpireTime.Before(now) {
+			expired = append(expired, &r)
+		}
+	}
+	return expired, nil
+}
+
+// RemoveContainment deletes a containment record once it has been reverted.
+func (s *State) RemoveContainment(ctx context.Context, r *ContainmentRecord) error {
+	if err := s.client.DeleteObject(ctx, s.bucket, containmentPrefix+r.ID+".json"); err != nil {
+		return errors.Wrapf(err, "failed to delete containment record %q", r.ID)
+	}
+	return nil
+}
+
+// RecordOperation stores a long running operation started by action on the resource. The message is
+// recorded once the operation completes. Only one operation is kept for each action and resource.
+func (s *State) RecordOperation(ctx context.Context, action, projectID, resource, service, operation, message string) (*OperationRecord, error) {
+	r := &OperationRecord{
+		ID:        operationID(action, projectID, resource),
+		Action:    action,
+		ProjectID: projectID,
+		Resource:  resource,
+		Service:   service,
+		Operation: operation,
+		Message:   message,
+		StartTime: time.Now().UTC(),
+	}
+	content, err := json.Marshal(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal operation record")
+	}
+	if err := s.client.WriteObject(ctx, s.bucket, operationPrefix+r.ID+".json", content); err != nil {
+		return nil, errors.Wrapf(err, "failed to write operation record to %q", s.bucket)
+	}
+	return r, nil
+}
+
+// PendingOperation returns the operation started by action on the resource that hasn't completed
+// yet or nil if there is none. Automations check this before starting an operation so a redelivered
+// message doesn't start the same change twice.
+func (s *State) PendingOperation(ctx context.Context, action, projectID, resource string) (*OperationRecord, error) {
+	records, err := s.operations(ctx, operationPrefix+operationID(action, projectID, resource)+".json")
+	if err != nil || len(records) == 0 {
+		return nil, err
+	}
+	return records[0], nil
+}
+
+// PendingOperations returns every recorded operation that hasn't completed yet.
+func (s *State) PendingOperations(ctx context.Context) ([]*OperationRecord, error) {
+	return s.operations(ctx, operationPrefix)
+}
+
+// RemoveOperation deletes an operation record once the operation has completed.
+func (s *State) RemoveOperation(ctx context.Context, r *OperationRecord) error {
+	if err := s.client.DeleteObject(ctx, s.bucket, operationPrefix+r.ID+".json"); err != nil {
+		return errors.Wrapf(err, "failed to delete operation record %q", r.ID)
+	}
+	return nil
+}
+
+// Progress returns the steps action completed on the resource in earlier attempts. A record without
+// steps is returned if there were none.
+func (s *State) Progress(ctx context.Context, action, projectID, resource string) (*ProgressRecord, error) {
+	r := &ProgressRecord{
+		ID:        operationID(action, projectID, resource),
+		Action:    action,
+		ProjectID: projectID,
+		Resource:  resource,
+		Steps:     []string{},
+	}
+	name := progressPrefix + r.ID + ".json"
+	names, err := s.client.ListObjects(ctx, s.bucket, name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list progress records in %q", s.bucket)
+	}
+	if len(names) == 0 {
+		return r, nil
+	}
+	b, err := s.client.ReadObject(ctx, s.bucket, name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read progress record %q", name)
+	}
+	if err := json.Unmarshal(b, r); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal progress record %q", name)
+	}
+	return r, nil
+}
+
+// SaveProgress stores the steps completed so far. Records without steps aren't stored.
+func (s *State) SaveProgress(ctx context.Context, r *ProgressRecord) error {
+	if len(r.Steps) == 0 {
+		return nil
+	}
+	r.UpdateTime = time.Now().UTC()
+	content, err := json.Marshal(r)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal progress record")
+	}
+	if err := s.client.WriteObject(ctx, s.bucket, progressPrefix+r.ID+".json", content); err != nil {
+		return errors.Wrapf(err, "failed to write progress record to %q", s.bucket)
+	}
+	return nil
+}
+
+// RemoveProgress deletes a progress record once the automation has completed.
+func (s *State) RemoveProgress(ctx context.Context, r *ProgressRecord) error {
+	if len(r.Steps) == 0 {
+		return nil
+	}
+	if err := s.client.DeleteObject(ctx, s.bucket, progressPrefix+r.ID+".json"); err != nil {
+		return errors.Wrapf(err, "failed to delete progress record %q", r.ID)
+	}
+	return nil
+}
+
+// RecordPlaybook stores a new playbook. Steps without a status are pending.
+func (s *State) RecordPlaybook(ctx context.Context, name, resource string, steps []*PlaybookStep) (*PlaybookRecord, error) {
+	for _, step := range steps {
+		if step.Status == "" {
+			step.Status = StepPending
+		}
+	}
+	r := &PlaybookRecord{
+		ID:        uuid.New().String(),
+		Name:      name,
+		Resource:  resource,
+		Steps:     steps,
+		StartTime: time.Now().UTC(),
+	}
+	if err := s.SavePlaybook(ctx, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Playbook returns the playbook with the given ID.
+func (s *State) Playbook(ctx context.Context, id string) (*PlaybookRecord, error) {
+	name := playbookPrefix + id + ".json"
+	b, err := s.client.ReadObject(ctx, s.bucket, name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read playbook record %q", name)
+	}
+	var r PlaybookRecord
+	if err := json.Unmarshal(b, &r); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal playbook record %q", name)
+	}
+	return &r, nil
+}
+
+// SavePlaybook stores the status of the steps of a playbook.
+func (s *State) SavePlaybook(ctx context.Context, r *PlaybookRecord) error {
+	content, err := json.Marshal(r)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal playbook record")
+	}
+	if err := s.client.WriteObject(ctx, s.bucket, playbookPrefix+r.ID+".json", content); err != nil {
+		return errors.Wrapf(err, "failed to write playbook record to %q", s.bucket)
+	}
+	return nil
+}
+
+// RemovePlaybook deletes a playbook record once the playbook has stopped.
+func (s *State) RemovePlaybook(ctx context.Context, r *PlaybookRecord) error {
+	if err := s.client.DeleteObject(ctx, s.bucket, playbookPrefix+r.ID+".json"); err != nil {
+		return errors.Wrapf(err, "failed to delete playbook record %q", r.ID)
+	}
+	return nil
+}
+
+func (s *State) operations(ctx context.Context, prefix string) ([]*OperationRecord, error) {
+	names, err := s.client.ListObjects(ctx, s.bucket, prefix)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list operation records in %q", s.bucket)
+	}
+	records := []*OperationRecord{}
+	for _, name := range names {
+		b, err := s.client.ReadObject(ctx, s.bucket, name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read operation record %q", name)
+		}
+		var r OperationRecord
+		if err := json.Unmarshal(b, &r); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal operation record %q", name)
+		}
+		records = append(records, &r)
+	}
+	return records, nil
+}
+
+// operationID returns the record ID of operations and progress of action on the resource.
+func operationID(action, projectID, resource string) string {
+	sha := sha256.Sum256([]byte(action + "/" + projectID + "/" + resource))
+	return hex.EncodeToString(sha[:16])
+}
+
+// Quarantine returns the record of the service account quarantined in the project. A record without
+// bindings is returned if the account isn't quarantined.
+func (s *State) Quarantine(ctx context.Context, projectID, serviceAccount string) (*QuarantineRecord, error) {
+	r := &QuarantineRecord{
+		ID:             operationID("quarantine", projectID, serviceAccount),
+		ProjectID:      projectID,
+		ServiceAccount: serviceAccount,
+		Bindings:       map[string][]BindingChange{},
+		Keys:           []string{},
+	}
+	name := quarantinePrefix + r.ID + ".json"
+	names, err := s.client.ListObjects(ctx, s.bucket, name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list quarantine records in %q", s.bucket)
+	}
+	if len(names) == 0 {
+		return r, nil
+	}
+	b, err := s.client.ReadObject(ctx, s.bucket, name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read quarantine record %q", name)
+	}
+	if err := json.Unmarshal(b, r); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal quarantine record %q", name)
+	}
+	return r, nil
+}
+
+// SaveQuarantine stores the record of a quarantined service account.
+func (s *State) SaveQuarantine(ctx context.Context, r *QuarantineRecord) error {
+	if r.CreateTime.IsZero() {
+		r.CreateTime = time.Now().UTC()
+	}
+	content, err := json.Marshal(r)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal quarantine record")
+	}
+	if err := s.client.WriteObject(ctx, s.bucket, quarantinePrefix+r.ID+".json", content); err != nil {
+		return errors.Wrapf(err, "failed to write quarantine record to %q", s.bucket)
+	}
+	return nil
+}
+
+// RemoveQuarantine deletes the record of a service account once its bindings have been restored.
+func (s *State) RemoveQuarantine(ctx context.Context, r *QuarantineRecord) error {
+	if err := s.client.DeleteObject(ctx, s.bucket, quarantinePrefix+r.ID+".json"); err != nil {
+		return errors.Wrapf(err, "failed to delete quarantine record %q", r.ID)
+	}
+	return nil
+}
+
+// Lockdown returns the record of the locked down project. A record without bindings, service
+// accounts or instances is returned if the project isn't locked down.
+func (s *State) Lockdown(ctx context.Context, projectID string) (*LockdownRecord, error) {
+	r := &LockdownRecord{
+		ID:              operationID("lockdown", projectID, projectID),
+		ProjectID:       projectID,
+		Bindings:        []BindingChange{},
+		ServiceAccounts: []LockedServiceAccount{},
+		Instances:       []string{},
+	}
+	name := lockdownPrefix + r.ID + ".json"
+	names, err := s.client.ListObjects(ctx, s.bucket, name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list lockdown records in %q", s.bucket)
+	}
+	if len(names) == 0 {
+		return r, nil
+	}
+	b, err := s.client.ReadObject(ctx, s.bucket, name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read lockdown record %q", name)
+	}
+	if err := json.Unmarshal(b, r); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal lockdown record %q", name)
+	}
+	return r, nil
+}
+
+// SaveLockdown stores the record of a locked down project.
+func (s *State) SaveLockdown(ctx context.Context, r *LockdownRecord) error {
+	if r.CreateTime.IsZero() {
+		r.CreateTime = time.Now().UTC()
+	}
+	content, err := json.Marshal(r)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal lockdown record")
+	}
+	if err := s.client.WriteObject(ctx, s.bucket, lockdownPrefix+r.ID+".json", content); err != nil {
+		return errors.Wrapf(err, "failed to write lockdown record to %q", s.bucket)
+	}
+	return nil
+}
+
+// Watermark returns how far the findings of the export table have been processed. A record without
+// an event time is returned if none have been.
+func (s *State) Watermark(ctx context.Context, table string) (*WatermarkRecord, error) {
+	r := &WatermarkRecord{
+		ID:       operationID("watermark", table, table),
+		Table:    table,
+		Findings: []string{},
+	}
+	name := watermarkPrefix + r.ID + ".json"
+	names, err := s.client.ListObjects(ctx, s.bucket, name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list watermark records in %q", s.bucket)
+	}
+	if len(names) == 0 {
+		return r, nil
+	}
+	b, err := s.client.ReadObject(ctx, s.bucket, name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read watermark record %q", name)
+	}
+	if err := json.Unmarshal(b, r); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal watermark record %q", name)
+	}
+	return r, nil
+}
+
+// SaveWatermark stores how far the findings of the export table have been processed.
+func (s *State) SaveWatermark(ctx context.Context, r *WatermarkRecord) error {
+	r.UpdateTime = time.Now().UTC()
+	content, err := json.Marshal(r)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal watermark record")
+	}
+	if err := s.client.WriteObject(ctx, s.bucket, watermarkPrefix+r.ID+".json", content); err != nil {
+		return errors.Wrapf(err, "failed to write watermark record to %q", s.bucket)
+	}
+	return nil
+}
+
+// SaveApprovalRequest stores an approval request so its values can be published once it's approved.
+func (s *State) SaveApprovalRequest(ctx context.Context, r *ApprovalRequest) error {
+	content, err := json.Marshal(r)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal approval request")
+	}
+	if err := s.client.WriteObject(ctx, s.bucket, approvalPrefix+r.ID+"/request.json", content); err != nil {
+		return errors.Wrapf(err, "failed to write approval request to %q", s.bucket)
+	}
+	return nil
+}
+
+// ApprovalRequest returns the stored approval request, nil is returned if there is none.
+func (s *State) ApprovalRequest(ctx context.Context, id string) (*ApprovalRequest, error) {
+	name := approvalPrefix + id + "/request.json"
+	names, err := s.client.ListObjects(ctx, s.bucket, name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list approval requests in %q", s.bucket)
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+	b, err := s.client.ReadObject(ctx, s.bucket, name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read approval request %q", name)
+	}
+	var r ApprovalRequest
+	if err := json.Unmarshal(b, &r); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal approval request %q", name)
+	}
+	return &r, nil
+}
+
+// RecordApproval records that the approver approved the request. Each approval is its own object so
+// concurrent approvals are never lost.
+func (s *State) RecordApproval(ctx context.Context, id, email string) error {
+	content, err := json.Marshal(map[string]string{"email": email, "time": time.Now().UTC().Format(time.RFC3339)})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal approval")
+	}
+	if err := s.client.WriteObject(ctx, s.bucket, approvalPrefix+id+"/approvers/"+email, content); err != nil {
+		return errors.Wrapf(err, "failed to write approval to %q", s.bucket)
+	}
+	return nil
+}
+
+// Approvals returns the emails of the approvers that approved the request.
+func (s *State) Approvals(ctx context.Context, id string) ([]string, error) {
+	prefix := approvalPrefix + id + "/approvers/"
+	names, err := s.client.ListObjects(ctx, s.bucket, prefix)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list approvals in %q", s.bucket)
+	}
+	emails := []string{}
+	for _, name := range names {
+		emails = append(emails, strings.TrimPrefix(name, prefix))
+	}
+	return emails, nil
+}
@@ -0,0 +1,77 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// resourceManagerPrefix is prepended to project, folder and organization names to form the full
+// resource names tags are bound to.
+const resourceManagerPrefix = "//cloudresourcemanager.googleapis.com/"
+
+// TagsClient contains minimum interface required by the tags service.
+type TagsClient interface {
+	EffectiveTags(context.Context, string) ([]string, error)
+}
+
+// Tags service scopes automations by the Resource Manager tags of resources.
+type Tags struct {
+	client   TagsClient
+	resource *Resource
+}
+
+// NewTags returns a tags service, projects are looked up with resource to find their numbers.
+func NewTags(client TagsClient, resource *Resource) *Tags {
+	return &Tags{client: client, resource: resource}
+}
+
+// EffectiveTags returns the tag values of a project, folder or organization resource, i.e.
+// "projects/p", including those inherited from its ancestors. Each value is returned both by ID and
+// namespaced name, i.e. "tagValues/123" and "456/env/prod".
+func (t *Tags) EffectiveTags(ctx context.Context, resource string) ([]string, error) {
+	name := resource
+	if strings.HasPrefix(resource, "projects/") {
+		// Tags are bound to projects by number.
+		number, err := t.resource.ProjectNumber(ctx, strings.TrimPrefix(resource, "projects/"))
+		if err != nil {
+			return nil, err
+		}
+		name = "projects/" + number
+	}
+	tags, err := t.client.EffectiveTags(ctx, resourceManagerPrefix+name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get tags of %q", resource)
+	}
+	return tags, nil
+}
+
+// TagsMatch returns whether any of the tags matches one of the patterns. Patterns are tag values
+// by ID or namespaced name where "*" matches any part of the name, i.e. "456/env/*".
+func TagsMatch(patterns, tags []string) bool {
+	for _, pattern := range patterns {
+		re := regexp.MustCompile("^" + strings.Replace(regexp.QuoteMeta(pattern), `\*`, "[^/]*", -1) + "$")
+		for _, tag := range tags {
+			if re.MatchString(tag) {
+				return true
+			}
+		}
+	}
+	return false
+}
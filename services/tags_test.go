@@ -0,0 +1,73 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+	crm "google.golang.org/api/cloudresourcemanager/v1"
+)
+
+func TestEffectiveTags(t *testing.T) {
+	crmStub := &stubs.ResourceManagerStub{
+		GetProjectResponse: map[string]*crm.Project{"p": {ProjectId: "p", ProjectNumber: 789}},
+	}
+	tagsStub := &stubs.TagsStub{StubbedTags: map[string][]string{
+		"//cloudresourcemanager.googleapis.com/projects/789": {"tagValues/1", "456/env/prod"},
+		"//cloudresourcemanager.googleapis.com/folders/123":  {"tagValues/2", "456/env/dev"},
+	}}
+	tags := NewTags(tagsStub, NewResource(crmStub, &stubs.StorageStub{}))
+	for _, tt := range []struct {
+		resource string
+		expected []string
+	}{
+		{resource: "projects/p", expected: []string{"tagValues/1", "456/env/prod"}},
+		{resource: "projects/789", expected: []string{"tagValues/1", "456/env/prod"}},
+		{resource: "folders/123", expected: []string{"tagValues/2", "456/env/dev"}},
+		{resource: "organizations/456"},
+	} {
+		got, err := tags.EffectiveTags(context.Background(), tt.resource)
+		if err != nil {
+			t.Fatalf("%q failed: %q", tt.resource, err)
+		}
+		if diff := cmp.Diff(tt.expected, got); diff != "" {
+			t.Errorf("%q failed, difference:%+v", tt.resource, diff)
+		}
+	}
+}
+
+func TestTagsMatch(t *testing.T) {
+	tags := []string{"tagValues/1", "456/env/prod"}
+	for _, tt := range []struct {
+		patterns []string
+		expected bool
+	}{
+		{patterns: []string{"tagValues/1"}, expected: true},
+		{patterns: []string{"456/env/prod"}, expected: true},
+		{patterns: []string{"456/env/*"}, expected: true},
+		{patterns: []string{"*/env/prod"}, expected: true},
+		{patterns: []string{"456/*"}, expected: false},
+		{patterns: []string{"tagValues/2", "456/env/dev"}, expected: false},
+		{patterns: []string{"456/env/pro."}, expected: false},
+		{expected: false},
+	} {
+		if got := TagsMatch(tt.patterns, tags); got != tt.expected {
+			t.Errorf("TagsMatch(%q) = %v, expected %v", tt.patterns, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// TicketPublisher contains minimum interface required by the tickets service.
+type TicketPublisher interface {
+	Publish(context.Context, string, *pubsub.Message) (string, error)
+}
+
+// Tickets service opens tickets for findings an automation can't remediate itself, such as a change
+// that requires recreating a resource. Tickets are published to a topic an issue tracker
+// integration subscribes to.
+type Tickets struct {
+	publisher TicketPublisher
+	topic     string
+}
+
+// Ticket asks the owners of a resource to make a change by hand.
+type Ticket struct {
+	ID        string `json:"id"`
+	Time      string `json:"time"`
+	Action    string `json:"action"`
+	ProjectID string `json:"project_id"`
+	Resource  string `json:"resource"`
+	Title     string `json:"title"`
+	// Description explains what must be changed and how.
+	Description string `json:"description"`
+	// CorrelationID traces the remediation of the finding the ticket was opened for.
+	CorrelationID string `json:"correlation_id,omitempty"`
+}
+
+// NewTickets returns a tickets service publishing tickets to topic.
+func NewTickets(publisher TicketPublisher, topic string) *Tickets {
+	return &Tickets{publisher: publisher, topic: topic}
+}
+
+// Open publishes the ticket, setting its ID, time and the correlation ID of ctx if they're empty.
+func (t *Tickets) Open(ctx context.Context, ticket *Ticket) error {
+	if t.topic == "" {
+		return errors.New("ticket topic not configured")
+	}
+	if ticket.ID == "" {
+		ticket.ID = uuid.New().String()
+	}
+	if ticket.Time == "" {
+		ticket.Time = time.Now().UTC().Format(time.RFC3339)
+	}
+	if ticket.CorrelationID == "" {
+		ticket.CorrelationID = CorrelationID(ctx)
+	}
+	b, err := json.Marshal(ticket)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal ticket")
+	}
+	if _, err := t.publisher.Publish(ctx, t.topic, &pubsub.Message{Data: b}); err != nil {
+		return errors.Wrapf(err, "failed to publish ticket to %q", t.topic)
+	}
+	return nil
+}
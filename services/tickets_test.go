@@ -0,0 +1,42 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+)
+
+func TestOpenTicket(t *testing.T) {
+	psStub := &stubs.PubSubStub{}
+	tickets := NewTickets(NewPubSub(psStub), "tickets")
+	ctx := WithCorrelationID(context.Background(), "5f0c6b9e-correlation")
+	if err := tickets.Open(ctx, &Ticket{Action: "enforce_cmek", ProjectID: "test-project", Resource: "disk-1", Title: "Encrypt disk-1"}); err != nil {
+		t.Fatalf("failed to open ticket: %q", err)
+	}
+	var sent Ticket
+	if err := json.Unmarshal(psStub.PublishedMessage.Data, &sent); err != nil {
+		t.Fatal(err)
+	}
+	if sent.ID == "" || sent.Time == "" || sent.Resource != "disk-1" || sent.CorrelationID != "5f0c6b9e-correlation" {
+		t.Errorf("unexpected ticket %+v", sent)
+	}
+	if err := NewTickets(NewPubSub(psStub), "").Open(context.Background(), &Ticket{}); err == nil {
+		t.Errorf("missing topic should fail")
+	}
+}
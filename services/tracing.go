@@ -0,0 +1,96 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+
+	"github.com/googlecloudplatform/security-response-automation/clients"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// CorrelationSpanAttribute is the span attribute holding the correlation ID of the remediation.
+const CorrelationSpanAttribute = "sra.correlation_id"
+
+// propagator carries the span of a remediation across functions in the attributes of the Pub/Sub
+// messages they're sent, using the W3C "traceparent" attribute.
+var propagator = propagation.TraceContext{}
+
+// StartSpan starts a span as a child of the span of ctx, if any, and returns the context carrying
+// it along with the function ending it with the error of the traced work, if any. Attributes are
+// given as key value pairs, i.e. "sra.action", "close_bucket". Spans are only recorded and exported
+// if tracing is enabled.
+func StartSpan(ctx context.Context, name string, kv ...string) (context.Context, func(error)) {
+	attrs := spanAttributes(kv)
+	if id := CorrelationID(ctx); id != "" {
+		attrs = append(attrs, attribute.String(CorrelationSpanAttribute, id))
+	}
+	ctx, span := otel.Tracer(clients.TracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+	return ctx, func(err error) {
+		recordError(span, err)
+		span.End()
+	}
+}
+
+// RecordSpan names the span of ctx after the work it traced and records its attributes, given as
+// key value pairs, and its error, if any.
+func RecordSpan(ctx context.Context, name string, err error, kv ...string) {
+	span := trace.SpanFromContext(ctx)
+	span.SetName(name)
+	span.SetAttributes(spanAttributes(kv)...)
+	recordError(span, err)
+}
+
+// InjectSpan adds the span of ctx to the attributes of a message so the function it's sent to
+// continues the trace. The attributes are returned, created if they were nil and there's a span.
+func InjectSpan(ctx context.Context, attrs map[string]string) map[string]string {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return attrs
+	}
+	if attrs == nil {
+		attrs = map[string]string{}
+	}
+	propagator.Inject(ctx, propagation.MapCarrier(attrs))
+	return attrs
+}
+
+// ExtractSpan returns a context carrying the span a message was sent from, if any, so the spans of
+// the function receiving it continue its trace.
+func ExtractSpan(ctx context.Context, attrs map[string]string) context.Context {
+	if len(attrs) == 0 {
+		return ctx
+	}
+	return propagator.Extract(ctx, propagation.MapCarrier(attrs))
+}
+
+func spanAttributes(kv []string) []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, len(kv)/2)
+	for i := 0; i+1 < len(kv); i += 2 {
+		attrs = append(attrs, attribute.String(kv[i], kv[i+1]))
+	}
+	return attrs
+}
+
+func recordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
@@ -0,0 +1,73 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestSpanContinuesAcrossMessages(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	if attrs := InjectSpan(context.Background(), nil); attrs != nil {
+		t.Errorf("attributes %+v added without a span", attrs)
+	}
+	ctx := WithCorrelationID(context.Background(), "5f0c6b9e-correlation")
+	ctx, end := StartSpan(ctx, "route", "sra.rule", "public_bucket_acl")
+	attrs := InjectSpan(ctx, map[string]string{"category": "public_bucket_acl"})
+	end(nil)
+
+	received, end := StartSpan(ExtractSpan(context.Background(), attrs), "invocation")
+	RecordSpan(received, "close_bucket", errors.New("bucket not found"), "sra.action", "close_bucket")
+	end(nil)
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(spans))
+	}
+	router, automation := spans[0], spans[1]
+	if automation.SpanContext().TraceID() != router.SpanContext().TraceID() || automation.Parent().SpanID() != router.SpanContext().SpanID() {
+		t.Errorf("automation span %+v does not continue the trace of %+v", automation.SpanContext(), router.SpanContext())
+	}
+	if automation.Name() != "close_bucket" || automation.Status().Code != codes.Error {
+		t.Errorf("unexpected automation span %q %+v", automation.Name(), automation.Status())
+	}
+	if got := spanAttribute(router, CorrelationSpanAttribute); got != "5f0c6b9e-correlation" {
+		t.Errorf("router span correlation ID %q", got)
+	}
+	if attrs["category"] != "public_bucket_acl" || attrs["traceparent"] == "" {
+		t.Errorf("unexpected attributes %+v", attrs)
+	}
+	if trace.SpanContextFromContext(ExtractSpan(context.Background(), nil)).IsValid() {
+		t.Errorf("span extracted from no attributes")
+	}
+}
+
+func spanAttribute(span sdktrace.ReadOnlySpan, key string) string {
+	for _, kv := range span.Attributes() {
+		if string(kv.Key) == key {
+			return kv.Value.AsString()
+		}
+	}
+	return ""
+}
@@ -0,0 +1,148 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	pt "google.golang.org/api/policytroubleshooter/v1beta"
+)
+
+const (
+	// probePermission is the permission a member's access to a project is troubleshot with. The
+	// membership of the member in every binding of the project's effective policy is explained
+	// whatever the permission.
+	probePermission = "resourcemanager.projects.get"
+	// Memberships of a principal in the member of a binding.
+	membershipIncluded = "MEMBERSHIP_INCLUDED"
+	membershipDenied   = "MEMBERSHIP_UNKNOWN_INFO_DENIED"
+)
+
+// PolicyTroubleshooterClient contains minimum interface required by the policy troubleshooter
+// service.
+type PolicyTroubleshooterClient interface {
+	TroubleshootIAMPolicy(context.Context, string, string, string) (*pt.GoogleCloudPolicytroubleshooterV1betaTroubleshootIamPolicyResponse, error)
+}
+
+// PolicyTroubleshooter service explains the access members have through IAM policies.
+type PolicyTroubleshooter struct {
+	client PolicyTroubleshooterClient
+}
+
+// NewPolicyTroubleshooter returns a policy troubleshooter service.
+func NewPolicyTroubleshooter(client PolicyTroubleshooterClient) *PolicyTroubleshooter {
+	return &PolicyTroubleshooter{client: client}
+}
+
+// AccessAnalysis explains whether a member keeps access to a project once its bindings are removed.
+type AccessAnalysis struct {
+	Member string `json:"member"`
+	// Retained holds the bindings the member keeps, such as through a group or on a folder.
+	Retained []RetainedBinding `json:"retained,omitempty"`
+	// Equivalent is true if a retained binding grants a removed role, so removing the member doesn't
+	// cut its access.
+	Equivalent bool `json:"equivalent,omitempty"`
+	// Error is why the member's access couldn't be analyzed.
+	Error string `json:"error,omitempty"`
+}
+
+// RetainedBinding is a binding of the effective policy of a project that includes a member.
+type RetainedBinding struct {
+	// Resource is the resource the binding is on, i.e. "folders/123".
+	Resource string `json:"resource"`
+	Role     string `json:"role"`
+	// Via is the group or domain the member is included through, empty for a direct binding.
+	Via string `json:"via,omitempty"`
+	// Unverified is true if the member's membership couldn't be read, such as of a group.
+	Unverified bool `json:"unverified,omitempty"`
+}
+
+// RetainedAccess explains, for each user and service account whose bindings are removed from the
+// project, the bindings of the project's effective policy that still include them. Other members,
+// such as groups, can't be troubleshot and are left out.
+func (p *PolicyTroubleshooter) RetainedAccess(ctx context.Context, projectID string, removed []BindingChange) []AccessAnalysis {
+	roles := map[string]map[string]bool{}
+	names := map[string]string{}
+	for _, b := range removed {
+		m := NormalizeMember(b.Member)
+		if !troubleshootable(m) {
+			continue
+		}
+		if roles[m] == nil {
+			roles[m] = map[string]bool{}
+			names[m] = b.Member
+		}
+		roles[m][b.Role] = true
+	}
+	members := make([]string, 0, len(roles))
+	for m := range roles {
+		members = append(members, m)
+	}
+	sort.Strings(members)
+	project := "projects/" + projectID
+	analyses := make([]AccessAnalysis, 0, len(members))
+	for _, m := range members {
+		a := AccessAnalysis{Member: names[m]}
+		principal := m[strings.Index(m, ":")+1:]
+		resp, err := p.client.TroubleshootIAMPolicy(ctx, principal, resourceManagerPrefix+project, probePermission)
+		if err != nil {
+			a.Error = err.Error()
+			analyses = append(analyses, a)
+			continue
+		}
+		for _, policy := range resp.ExplainedPolicies {
+			resource := strings.TrimPrefix(policy.FullResourceName, resourceManagerPrefix)
+			for _, b := range policy.BindingExplanations {
+				for member, ms := range b.Memberships {
+					member = NormalizeMember(member)
+					if ms.Membership != membershipIncluded && ms.Membership != membershipDenied {
+						continue
+					}
+					// The member's own bindings on the project are the ones being removed.
+					if resource == project && member == m && roles[m][b.Role] {
+						continue
+					}
+					r := RetainedBinding{Resource: resource, Role: b.Role, Unverified: ms.Membership == membershipDenied}
+					if member != m {
+						r.Via = member
+					}
+					a.Retained = append(a.Retained, r)
+					if roles[m][b.Role] && !r.Unverified {
+						a.Equivalent = true
+					}
+				}
+			}
+		}
+		sort.Slice(a.Retained, func(i, j int) bool {
+			x, y := a.Retained[i], a.Retained[j]
+			if x.Resource != y.Resource {
+				return x.Resource < y.Resource
+			}
+			if x.Role != y.Role {
+				return x.Role < y.Role
+			}
+			return x.Via < y.Via
+		})
+		analyses = append(analyses, a)
+	}
+	return analyses
+}
+
+// troubleshootable returns whether the access of the member can be troubleshot.
+func troubleshootable(member string) bool {
+	return strings.HasPrefix(member, "user:") || strings.HasPrefix(member, "serviceaccount:")
+}
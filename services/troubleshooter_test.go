@@ -0,0 +1,95 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+	pt "google.golang.org/api/policytroubleshooter/v1beta"
+)
+
+func TestRetainedAccess(t *testing.T) {
+	type memberships = map[string]pt.GoogleCloudPolicytroubleshooterV1betaBindingExplanationAnnotatedMembership
+	explained := &pt.GoogleCloudPolicytroubleshooterV1betaTroubleshootIamPolicyResponse{
+		ExplainedPolicies: []*pt.GoogleCloudPolicytroubleshooterV1betaExplainedPolicy{
+			{
+				FullResourceName: "//cloudresourcemanager.googleapis.com/projects/p",
+				BindingExplanations: []*pt.GoogleCloudPolicytroubleshooterV1betaBindingExplanation{
+					{Role: "roles/editor", Memberships: memberships{
+						"user:Jane@example.com":    {Membership: "MEMBERSHIP_INCLUDED"},
+						"group:admins@example.com": {Membership: "MEMBERSHIP_UNKNOWN_INFO_DENIED"},
+					}},
+					{Role: "roles/viewer", Memberships: memberships{
+						"user:jane@example.com": {Membership: "MEMBERSHIP_INCLUDED"},
+						"user:bob@example.com":  {Membership: "MEMBERSHIP_NOT_INCLUDED"},
+					}},
+				},
+			},
+			{
+				FullResourceName: "//cloudresourcemanager.googleapis.com/organizations/456",
+				BindingExplanations: []*pt.GoogleCloudPolicytroubleshooterV1betaBindingExplanation{
+					{Role: "roles/editor", Memberships: memberships{
+						"domain:example.com": {Membership: "MEMBERSHIP_INCLUDED"},
+					}},
+				},
+			},
+		},
+	}
+	for _, tt := range []struct {
+		name     string
+		removed  []BindingChange
+		err      error
+		expected []AccessAnalysis
+	}{
+		{
+			name:    "equivalent access through the organization",
+			removed: []BindingChange{{Role: "roles/editor", Member: "user:jane@example.com", Change: BindingRemoved}},
+			expected: []AccessAnalysis{{
+				Member: "user:jane@example.com",
+				Retained: []RetainedBinding{
+					{Resource: "organizations/456", Role: "roles/editor", Via: "domain:example.com"},
+					{Resource: "projects/p", Role: "roles/editor", Via: "group:admins@example.com", Unverified: true},
+					{Resource: "projects/p", Role: "roles/viewer"},
+				},
+				Equivalent: true,
+			}},
+		},
+		{
+			name:    "groups are not troubleshot",
+			removed: []BindingChange{{Role: "roles/editor", Member: "group:admins@example.com", Change: BindingRemoved}},
+		},
+		{
+			name:     "failed to troubleshoot",
+			removed:  []BindingChange{{Role: "roles/editor", Member: "user:jane@example.com", Change: BindingRemoved}},
+			err:      errors.New("permission denied"),
+			expected: []AccessAnalysis{{Member: "user:jane@example.com", Error: "permission denied"}},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := &stubs.PolicyTroubleshooterStub{Calls: stubs.Calls{Results: map[string][]stubs.Result{
+				"TroubleshootIAMPolicy": {{Response: explained, Err: tt.err}},
+			}}}
+			got := NewPolicyTroubleshooter(stub).RetainedAccess(context.Background(), "p", tt.removed)
+			if diff := cmp.Diff(tt.expected, got, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("%s failed, difference:%+v", tt.name, diff)
+			}
+		})
+	}
+}
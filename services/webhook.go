@@ -0,0 +1,151 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+const (
+	// WebhookResultSuccess is the result of an automation that completed.
+	WebhookResultSuccess = "success"
+	// WebhookResultFailure is the result of an automation that returned an error.
+	WebhookResultFailure = "failure"
+	// WebhookResultDryRun is the result of an automation that ran in dry run mode.
+	WebhookResultDryRun = "dry_run"
+
+	// webhookSignatureHeader holds the HMAC-SHA256 signature of the request.
+	webhookSignatureHeader = "X-SRA-Signature"
+	// webhookTimestampHeader holds the Unix time the request was signed at.
+	webhookTimestampHeader = "X-SRA-Timestamp"
+)
+
+// WebhookClient contains minimum interface required by the webhook service.
+type WebhookClient interface {
+	Post(context.Context, string, []byte, http.Header) error
+}
+
+// Webhook service.
+type Webhook struct {
+	client WebhookClient
+	url    string
+	secret string
+}
+
+// WebhookEvent is the normalized event sent to the webhook after an automation runs.
+type WebhookEvent struct {
+	ID        string `json:"id"`
+	Time      string `json:"time"`
+	Action    string `json:"action"`
+	ProjectID string `json:"project_id"`
+	// CorrelationID traces the remediation of the finding across the automations it triggered.
+	CorrelationID string `json:"correlation_id,omitempty"`
+	// Severity of the finding that triggered the automation if known, i.e. "HIGH".
+	Severity string `json:"severity,omitempty"`
+	// FindingName is the Security Command Center name of the finding if known.
+	FindingName string `json:"finding_name,omitempty"`
+	// Category is the rule the finding was routed by if known, i.e. "bad_ip".
+	Category string `json:"category,omitempty"`
+	// Runbook is the link to the runbook of the category if one is configured.
+	Runbook string `json:"runbook,omitempty"`
+	// Rollout is the rollout cohort of the resource if the automation is being rolled out, either
+	// "enforced" or "dry_run".
+	Rollout string `json:"rollout,omitempty"`
+	// Finding contains the values the automation was triggered with, as extracted from the finding.
+	Finding json.RawMessage `json:"finding,omitempty"`
+	Result  string          `json:"result"`
+	Error   string          `json:"error,omitempty"`
+	// Contacts are the owners and editors of the affected project so the responsible team can be paged.
+	Contacts []string `json:"contacts,omitempty"`
+	// Activity is the recent admin activity on the affected resource: who changed it and from
+	// what IP.
+	Activity []AdminActivity `json:"activity,omitempty"`
+	// Intel is the reputation and country of the public IPs seen in the finding.
+	Intel []IPIntel `json:"intel,omitempty"`
+	// Outcome is the result the automation returned, if it didn't fail.
+	Outcome *Result `json:"outcome,omitempty"`
+	// Before and After optionally contain the state of the resource before and after the automation.
+	Before interface{} `json:"before,omitempty"`
+	After  interface{} `json:"after,omitempty"`
+}
+
+// NewWebhook returns a webhook service.
+func NewWebhook(client WebhookClient, url, secret string) *Webhook {
+	return &Webhook{client: client, url: url, secret: secret}
+}
+
+// NewWebhookEvent returns an event for the outcome of an automation.
+func NewWebhookEvent(action, projectID string, finding []byte, dryRun bool, err error) *WebhookEvent {
+	e := &WebhookEvent{
+		ID:        uuid.New().String(),
+		Time:      time.Now().UTC().Format(time.RFC3339),
+		Action:    action,
+		ProjectID: projectID,
+		Finding:   json.RawMessage(finding),
+		Result:    WebhookResultSuccess,
+	}
+	switch {
+	case err != nil:
+		e.Result = WebhookResultFailure
+		e.Error = err.Error()
+	case dryRun:
+		e.Result = WebhookResultDryRun
+	}
+	return e
+}
+
+// Send posts the event to the webhook.
+//
+// The request is signed with HMAC-SHA256 using the webhook secret. The X-SRA-Signature header
+// holds "sha256=" followed by the hex encoded signature of the X-SRA-Timestamp header value,
+// a period and the request body.
+func (w *Webhook) Send(ctx context.Context, event *WebhookEvent) error {
+	b, err := json.Marshal(event)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal event")
+	}
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	header.Set(webhookTimestampHeader, ts)
+	header.Set(webhookSignatureHeader, "sha256="+signWebhook(w.secret, ts, b))
+	if err := w.client.Post(ctx, w.url, b, header); err != nil {
+		return errors.Wrapf(err, "failed to send event %q", event.ID)
+	}
+	return nil
+}
+
+// Notify sends the event to the webhook so it can be used as a notification channel.
+func (w *Webhook) Notify(ctx context.Context, event *WebhookEvent) error {
+	return w.Send(ctx, event)
+}
+
+// signWebhook returns the hex encoded HMAC-SHA256 signature of the timestamp and body.
+func signWebhook(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,72 @@
+package services
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/googlecloudplatform/security-response-automation/clients/stubs"
+)
+
+func TestWebhookSend(t *testing.T) {
+	tests := []struct {
+		name           string
+		dryRun         bool
+		err            error
+		expectedResult string
+	}{
+		{name: "success", expectedResult: WebhookResultSuccess},
+		{name: "dry run", dryRun: true, expectedResult: WebhookResultDryRun},
+		{name: "failure", err: errors.New("failed to close bucket"), expectedResult: WebhookResultFailure},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := &stubs.WebhookStub{}
+			w := NewWebhook(stub, "https://soar.example.com/hook", "secret")
+			event := NewWebhookEvent("close_bucket", "test-project", []byte(`{"BucketName":"public-bucket"}`), tt.dryRun, tt.err)
+			if err := w.Send(context.Background(), event); err != nil {
+				t.Fatalf("%s failed: %q", tt.name, err)
+			}
+			if stub.SavedURL != "https://soar.example.com/hook" {
+				t.Errorf("%s failed: got url %q", tt.name, stub.SavedURL)
+			}
+			ts := stub.SavedHeader.Get(webhookTimestampHeader)
+			if got, want := stub.SavedHeader.Get(webhookSignatureHeader), "sha256="+signWebhook("secret", ts, stub.SavedBody); got != want {
+				t.Errorf("%s failed: got signature %q want %q", tt.name, got, want)
+			}
+			var sent WebhookEvent
+			if err := json.Unmarshal(stub.SavedBody, &sent); err != nil {
+				t.Fatalf("%s failed: %q", tt.name, err)
+			}
+			if sent.Result != tt.expectedResult || sent.Action != "close_bucket" || sent.ProjectID != "test-project" {
+				t.Errorf("%s failed: unexpected event %+v", tt.name, sent)
+			}
+			if string(sent.Finding) != `{"BucketName":"public-bucket"}` {
+				t.Errorf("%s failed: got finding %s", tt.name, sent.Finding)
+			}
+		})
+	}
+}
+
+func TestSignWebhook(t *testing.T) {
+	// Generated with: printf '1583020800.{}' | openssl dgst -sha256 -hmac secret
+	const expected = "3375ff5dc1e78f19c19401f8912bf78e35ff94dca48a2f0511f0adc2b28954d3"
+	if got := signWebhook("secret", "1583020800", []byte("{}")); got != expected {
+		t.Errorf("got %q want %q", got, expected)
+	}
+}
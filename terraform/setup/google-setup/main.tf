@@ -1,6 +1,8 @@
 locals {
   // GCS bucket to store GCF code.
   bucket-name = "${var.automation-project}-cloud-functions-code"
+  // GCS bucket to store the state of automations, i.e. temporary containment actions.
+  state-bucket-name = "${var.automation-project}-sra-state"
 }
 
 // GCF
@@ -24,6 +26,13 @@ data "archive_file" "cloud_functions_zip" {
   "README.md", "CONTRIBUTING.md", "automations.md", "LICENSE", "terraform.tfstate", "terraform", "local"]
   depends_on = [
     local_file.cloudfunction-key-file,
+    local_file.webhook-config-file,
+    local_file.state-config-file,
+    local_file.email-config-file,
+    local_file.rate-limit-config-file,
+    local_file.intel-config-file,
+    local_file.groups-config-file,
+    local_file.impersonation-config-file,
     google_project_service.cloudresourcemanager_api,
     google_project_service.logging_api,
     google_project_service.pubsub_api,
@@ -48,6 +57,110 @@ resource "local_file" "cloudfunction-key-file" {
   filename = "./credentials/auth.json"
 }
 
+resource "local_file" "webhook-config-file" {
+  count    = var.webhook-url == "" ? 0 : 1
+  content  = jsonencode({ url = var.webhook-url, secret = var.webhook-secret })
+  filename = "./credentials/webhook.json"
+}
+
+resource "local_file" "email-config-file" {
+  count = var.sendgrid-api-key == "" && var.smtp-host == "" ? 0 : 1
+  content = jsonencode({
+    api_key = var.sendgrid-api-key,
+    from    = var.notification-email-from,
+    smtp = {
+      host     = var.smtp-host,
+      port     = var.smtp-port,
+      username = var.smtp-username,
+      password = var.smtp-password,
+    },
+  })
+  filename = "./credentials/email.json"
+}
+
+resource "local_file" "rate-limit-config-file" {
+  count    = length(var.rate-limits) == 0 ? 0 : 1
+  content  = jsonencode(var.rate-limits)
+  filename = "./credentials/rate-limits.json"
+}
+
+resource "local_file" "intel-config-file" {
+  count    = var.virustotal-api-key == "" ? 0 : 1
+  content  = jsonencode({ virustotal_api_key = var.virustotal-api-key })
+  filename = "./credentials/intel.json"
+}
+
+resource "local_file" "groups-config-file" {
+  count    = var.groups-admin-email == "" ? 0 : 1
+  content  = jsonencode({ admin_email = var.groups-admin-email })
+  filename = "./credentials/groups.json"
+}
+
+resource "local_file" "notifiers-config-file" {
+  count    = length(var.notification-channels) == 0 ? 0 : 1
+  content  = jsonencode({ channels = var.notification-channels })
+  filename = "./credentials/notifiers.json"
+}
+
+resource "local_file" "messages-config-file" {
+  count = var.message-templates-bucket == "" && length(var.runbooks) == 0 ? 0 : 1
+  content = jsonencode({
+    bucket   = var.message-templates-bucket,
+    prefix   = var.message-templates-prefix,
+    runbooks = var.runbooks,
+  })
+  filename = "./credentials/messages.json"
+}
+
+resource "google_storage_bucket_iam_member" "message-templates-object-viewer" {
+  count  = var.message-templates-bucket == "" ? 0 : 1
+  bucket = var.message-templates-bucket
+  role   = "roles/storage.objectViewer"
+  member = "serviceAccount:${google_service_account.automation-service-account.email}"
+}
+
+resource "local_file" "bundle-config-file" {
+  count = var.forensics-bucket == "" ? 0 : 1
+  content = jsonencode({
+    bucket          = var.forensics-bucket,
+    key_version     = var.bundle-signing-key-version,
+    evidence_bucket = var.evidence-bucket,
+  })
+  filename = "./credentials/bundle.json"
+}
+
+resource "local_file" "impersonation-config-file" {
+  count    = length(var.impersonation-scopes) == 0 ? 0 : 1
+  content  = jsonencode({ scopes = var.impersonation-scopes })
+  filename = "./credentials/impersonation.json"
+}
+
+// The automation service account generates access tokens of the service accounts it impersonates.
+resource "google_service_account_iam_member" "impersonation-token-creator" {
+  for_each           = toset([for s in var.impersonation-scopes : s.service_account])
+  service_account_id = "projects/-/serviceAccounts/${each.value}"
+  role               = "roles/iam.serviceAccountTokenCreator"
+  member             = "serviceAccount:${google_service_account.automation-service-account.email}"
+}
+
+// state store
+resource "google_storage_bucket" "state_bucket" {
+  name               = local.state-bucket-name
+  project            = var.automation-project
+  bucket_policy_only = true
+}
+
+resource "google_storage_bucket_iam_member" "state-bucket-object-admin" {
+  bucket = google_storage_bucket.state_bucket.name
+  role   = "roles/storage.objectAdmin"
+  member = "serviceAccount:${google_service_account.automation-service-account.email}"
+}
+
+resource "local_file" "state-config-file" {
+  content  = jsonencode({ bucket = local.state-bucket-name })
+  filename = "./credentials/state.json"
+}
+
 // sinks
 resource "google_logging_project_sink" "sink" {
   name                   = "sink-threat-findings"
@@ -69,10 +182,27 @@ resource "google_organization_iam_member" "scc-marks-writer" {
   member = "serviceAccount:${google_service_account.automation-service-account.email}"
 }
 
+// Required to find the security contacts of projects, including those inherited from folders.
+resource "google_organization_iam_member" "essential-contacts-viewer" {
+  role   = "roles/essentialcontacts.viewer"
+  org_id = var.organization-id
+  member = "serviceAccount:${google_service_account.automation-service-account.email}"
+}
+
 resource "google_pubsub_topic" "topic" {
   name = "threat-findings"
 }
 
+// Requests for automations that must be approved before they act.
+resource "google_pubsub_topic" "approval-requests-topic" {
+  name = "threat-findings-approval-requests"
+}
+
+// Tickets for findings that must be remediated by hand, for an issue tracker to subscribe to.
+resource "google_pubsub_topic" "tickets-topic" {
+  name = "threat-findings-tickets"
+}
+
 // CSCC notifications.
 resource "google_pubsub_topic" "cscc-notifications-topic" {
   name = "${var.cscc-notifications-topic-prefix}-topic"
@@ -90,6 +220,20 @@ resource "google_project_iam_member" "stackdriver-writer" {
   member  = "serviceAccount:${google_service_account.automation-service-account.email}"
 }
 
+// Required to read the remediation history logged by automations.
+resource "google_project_iam_member" "stackdriver-viewer" {
+  project = var.automation-project
+  role    = "roles/logging.viewer"
+  member  = "serviceAccount:${google_service_account.automation-service-account.email}"
+}
+
+// Required to export the spans of automations when tracing is enabled.
+resource "google_project_iam_member" "cloudtrace-agent" {
+  project = var.automation-project
+  role    = "roles/cloudtrace.agent"
+  member  = "serviceAccount:${google_service_account.automation-service-account.email}"
+}
+
 resource "google_project_service" "cloudresourcemanager_api" {
   project                    = var.automation-project
   service                    = "cloudresourcemanager.googleapis.com"
@@ -138,3 +282,17 @@ resource "google_project_service" "cloudbuild_api" {
   disable_dependent_services = false
   disable_on_destroy         = false
 }
+
+resource "google_project_service" "policytroubleshooter_api" {
+  project                    = var.automation-project
+  service                    = "policytroubleshooter.googleapis.com"
+  disable_dependent_services = false
+  disable_on_destroy         = false
+}
+
+resource "google_project_service" "essentialcontacts_api" {
+  project                    = var.automation-project
+  service                    = "essentialcontacts.googleapis.com"
+  disable_dependent_services = false
+  disable_on_destroy         = false
+}
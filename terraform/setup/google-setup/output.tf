@@ -29,3 +29,15 @@ output "findings-topic" {
 output "organization-id" {
   value = var.organization-id
 }
+
+output "approval-topic" {
+  value = google_pubsub_topic.approval-requests-topic.name
+}
+
+output "ticket-topic" {
+  value = google_pubsub_topic.tickets-topic.name
+}
+
+output "state-bucket" {
+  value = google_storage_bucket.state_bucket.name
+}
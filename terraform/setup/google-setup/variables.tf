@@ -24,3 +24,79 @@ variable "region" {
 variable "findings-topic" {
   type = string
 }
+
+variable "webhook-url" {
+  type = string
+}
+
+variable "webhook-secret" {
+  type = string
+}
+
+variable "sendgrid-api-key" {
+  type = string
+}
+
+variable "notification-email-from" {
+  type = string
+}
+
+variable "smtp-host" {
+  type = string
+}
+
+variable "smtp-port" {
+  type = number
+}
+
+variable "smtp-username" {
+  type = string
+}
+
+variable "smtp-password" {
+  type = string
+}
+
+variable "rate-limits" {
+  type = map(number)
+}
+
+variable "virustotal-api-key" {
+  type = string
+}
+
+variable "groups-admin-email" {
+  type = string
+}
+
+variable "forensics-bucket" {
+  type = string
+}
+
+variable "bundle-signing-key-version" {
+  type = string
+}
+
+variable "evidence-bucket" {
+  type = string
+}
+
+variable "notification-channels" {
+  type = any
+}
+
+variable "message-templates-bucket" {
+  type = string
+}
+
+variable "message-templates-prefix" {
+  type = string
+}
+
+variable "runbooks" {
+  type = map(string)
+}
+
+variable "impersonation-scopes" {
+  type = list(object({ scope = string, service_account = string }))
+}
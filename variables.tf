@@ -17,3 +17,141 @@ variable "folder-ids" {
   type        = list(string)
   description = "Folder IDs to apply automations to."
 }
+
+variable "siem-adapter-token" {
+  type        = string
+  default     = ""
+  description = "Bearer token SIEMs must send to the SIEM adapter. The adapter rejects all requests if empty."
+}
+
+variable "webhook-url" {
+  type        = string
+  default     = ""
+  description = "Optional URL where a signed event is posted after each automation runs."
+}
+
+variable "webhook-secret" {
+  type        = string
+  default     = ""
+  description = "Secret used to sign the events posted to the webhook."
+}
+
+variable "sendgrid-api-key" {
+  type        = string
+  default     = ""
+  description = "Optional SendGrid API key used to email the security contacts of a project after each automation runs."
+}
+
+variable "notification-email-from" {
+  type        = string
+  default     = ""
+  description = "Address notification emails are sent from."
+}
+
+variable "smtp-host" {
+  type        = string
+  default     = ""
+  description = "Optional SMTP server used to send notification emails when no SendGrid API key is set."
+}
+
+variable "smtp-port" {
+  type        = number
+  default     = 587
+  description = "Port of the SMTP server."
+}
+
+variable "smtp-username" {
+  type        = string
+  default     = ""
+  description = "Username to authenticate to the SMTP server with, if required."
+}
+
+variable "smtp-password" {
+  type        = string
+  default     = ""
+  description = "Password to authenticate to the SMTP server with, if required."
+}
+
+variable "approvers" {
+  type        = list(string)
+  default     = []
+  description = "Emails of the people allowed to approve automations, they approve by calling the Approve function."
+}
+
+variable "rate-limits" {
+  type        = map(number)
+  default     = {}
+  description = "Requests per second each function instance may send to an API, keyed by compute, cloudresourcemanager, sqladmin, container, storage or virustotal."
+}
+
+variable "virustotal-api-key" {
+  type        = string
+  default     = ""
+  description = "Optional VirusTotal API key used to look up the reputation and country of the IPs in findings."
+}
+
+variable "groups-admin-email" {
+  type        = string
+  default     = ""
+  description = "Optional G Suite administrator impersonated to remove external members from Google Groups."
+}
+
+variable "notification-channels" {
+  type        = any
+  default     = []
+  description = "Optional Slack, PagerDuty, webhook or email channels notified each time an automation runs, each filtered by severities and categories. See the README."
+}
+
+variable "message-templates-bucket" {
+  type        = string
+  default     = ""
+  description = "Optional bucket holding Go templates that replace the wording of notification messages, such as slack.tmpl or bad_ip/email.tmpl. See the README."
+}
+
+variable "message-templates-prefix" {
+  type        = string
+  default     = ""
+  description = "Folder of message-templates-bucket the templates are read from."
+}
+
+variable "runbooks" {
+  type        = map(string)
+  default     = {}
+  description = "Optional links to the runbook of each finding category, i.e. bad_ip, included in notifications."
+}
+
+variable "forensics-bucket" {
+  type        = string
+  default     = ""
+  description = "Optional bucket incident bundles are exported to when requested and whenever a playbook finishes."
+}
+
+variable "bundle-signing-key-version" {
+  type        = string
+  default     = ""
+  description = "Resource name of the asymmetric signing Cloud KMS key version incident bundles are signed with, required with forensics-bucket."
+}
+
+variable "evidence-bucket" {
+  type        = string
+  default     = ""
+  description = "Optional bucket evidence is collected to, the manifests of the evidence are included in incident bundles."
+}
+
+variable "impersonation-scopes" {
+  type        = list(object({ scope = string, service_account = string }))
+  default     = []
+  description = "Optional service accounts automations act as in the projects of each scope, such as organizations/456/folders/123/*. The first matching scope is used."
+}
+
+variable "findings-export-table" {
+  type        = string
+  default     = ""
+  description = "Optional BigQuery table Security Command Center findings are exported to, i.e. \"project.dataset.findings\". Findings exported since the last run are sent to the router on a schedule."
+}
+
+variable "router-max-instances" {
+  type        = number
+  default     = 0
+  description = "Maximum number of router instances, which bounds how many findings are routed at once. Unlimited if 0."
+}